@@ -1,11 +1,12 @@
 package main
 
 import (
-	"flag"
-	"log/slog"
+	"context"
+	"os"
+	"os/signal"
+	_ "time/tzdata" // time zones for server task cron schedules
 
-	"github.com/gameap/gameap/internal/application"
-	"github.com/gameap/gameap/internal/application/defaults"
+	"github.com/gameap/gameap/internal/cli"
 
 	_ "github.com/go-sql-driver/mysql"
 	_ "github.com/jackc/pgx/v5/stdlib"
@@ -13,15 +14,11 @@ import (
 )
 
 func main() {
-	envFile := flag.String("env", "", "Path to environment file")
-	legacyEnvFile := flag.String("legacy-env", defaults.LegacyEnvPath, "Path to legacy environment file")
+	// The administration commands are canceled on interrupt, the server handles the signal itself
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
 
-	flag.Parse()
+	code := cli.Run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
 
-	slog.Info("Starting ...")
-
-	application.Run(application.RunParams{
-		EnvFile:       *envFile,
-		LegacyEnvFile: *legacyEnvFile,
-	})
+	stop()
+	os.Exit(code)
 }
@@ -0,0 +1,88 @@
+package base
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+// ReadFilter reads the filter of the audit logs from the query:
+// filter[user_id], filter[action], filter[entity_type], filter[entity_id], filter[result]
+// and the period filter[from] and filter[to] in RFC 3339.
+func ReadFilter(r *http.Request) (*filters.FindAuditLog, error) {
+	queryReader := api.NewQueryReader(r)
+
+	filter := &filters.FindAuditLog{}
+
+	userIDs, err := queryReader.ReadUintList("filter[user_id]")
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to read filter[user_id] list")
+	}
+	filter.UserIDs = userIDs
+
+	actions, err := queryReader.ReadList("filter[action]")
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to read filter[action] list")
+	}
+	filter.Actions = lo.Compact(actions)
+
+	entityTypes, err := queryReader.ReadList("filter[entity_type]")
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to read filter[entity_type] list")
+	}
+	filter.EntityTypes = lo.Map(lo.Compact(entityTypes), func(entityType string, _ int) domain.EntityType {
+		return domain.EntityType(entityType)
+	})
+
+	entityIDs, err := queryReader.ReadList("filter[entity_id]")
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to read filter[entity_id] list")
+	}
+	filter.EntityIDs = lo.Compact(entityIDs)
+
+	results, err := queryReader.ReadList("filter[result]")
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to read filter[result] list")
+	}
+	for _, result := range lo.Compact(results) {
+		switch domain.AuditResult(result) {
+		case domain.AuditResultSuccess, domain.AuditResultFailure:
+			filter.Results = append(filter.Results, domain.AuditResult(result))
+		default:
+			return nil, errors.Errorf("invalid filter[result] value: %s", result)
+		}
+	}
+
+	if filter.CreatedFrom, err = readTime(queryReader, "filter[from]"); err != nil {
+		return nil, err
+	}
+
+	if filter.CreatedTo, err = readTime(queryReader, "filter[to]"); err != nil {
+		return nil, err
+	}
+
+	return filter, nil
+}
+
+func readTime(queryReader *api.QueryReader, key string) (*time.Time, error) {
+	value, err := queryReader.ReadString(key)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to read %s", key)
+	}
+
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "invalid %s value", key)
+	}
+
+	return &t, nil
+}
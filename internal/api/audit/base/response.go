@@ -0,0 +1,61 @@
+package base
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+)
+
+type AuditLogResponse struct {
+	ID         uint               `json:"id"`
+	UserID     *uint              `json:"user_id"`
+	TokenID    *uint              `json:"token_id"`
+	IP         string             `json:"ip"`
+	UserAgent  string             `json:"user_agent"`
+	Action     string             `json:"action"`
+	EntityType *domain.EntityType `json:"entity_type"`
+	EntityID   *string            `json:"entity_id"`
+	Changes    json.RawMessage    `json:"changes"`
+	Request    json.RawMessage    `json:"request"`
+	Result     domain.AuditResult `json:"result"`
+	StatusCode int                `json:"status_code"`
+	CreatedAt  *time.Time         `json:"created_at"`
+}
+
+func NewAuditLogsResponse(logs []domain.AuditLog) []AuditLogResponse {
+	response := make([]AuditLogResponse, 0, len(logs))
+
+	for i := range logs {
+		response = append(response, NewAuditLogResponse(&logs[i]))
+	}
+
+	return response
+}
+
+func NewAuditLogResponse(log *domain.AuditLog) AuditLogResponse {
+	return AuditLogResponse{
+		ID:         log.ID,
+		UserID:     log.UserID,
+		TokenID:    log.TokenID,
+		IP:         log.IP,
+		UserAgent:  log.UserAgent,
+		Action:     log.Action,
+		EntityType: log.EntityType,
+		EntityID:   log.EntityID,
+		Changes:    rawJSON(log.Changes),
+		Request:    rawJSON(log.Request),
+		Result:     log.Result,
+		StatusCode: log.StatusCode,
+		CreatedAt:  log.CreatedAt,
+	}
+}
+
+// rawJSON embeds the stored JSON document into the response, null is used for the missing or invalid ones.
+func rawJSON(s *string) json.RawMessage {
+	if s == nil || !json.Valid([]byte(*s)) {
+		return json.RawMessage("null")
+	}
+
+	return json.RawMessage(*s)
+}
@@ -0,0 +1,153 @@
+package getaudit
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	auditbase "github.com/gameap/gameap/internal/api/audit/base"
+	"github.com/gameap/gameap/internal/api/base"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+)
+
+var sortableFields = map[string]bool{
+	"id":         true,
+	"action":     true,
+	"created_at": true,
+}
+
+// Handler returns the filtered audit logs by pages.
+type Handler struct {
+	auditLogRepo repositories.AuditLogRepository
+	responder    base.Responder
+}
+
+func NewHandler(
+	auditLogRepo repositories.AuditLogRepository,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		auditLogRepo: auditLogRepo,
+		responder:    responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	filter, err := auditbase.ReadFilter(r)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "failed to read filter"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	pageNumber, pageSize, err := readPage(r)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "failed to read page"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	total, err := h.auditLogRepo.Count(ctx, filter)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to count audit logs"))
+
+		return
+	}
+
+	logs, err := h.auditLogRepo.Find(ctx, filter, buildSorting(r.URL.Query().Get("sort")), &filters.Pagination{
+		Limit:  pageSize,
+		Offset: (pageNumber - 1) * pageSize,
+	})
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to find audit logs"))
+
+		return
+	}
+
+	h.responder.Write(ctx, rw, base.NewPaginatedResponse(
+		auditbase.NewAuditLogsResponse(logs),
+		pageNumber,
+		pageSize,
+		total,
+	))
+}
+
+func readPage(r *http.Request) (int, int, error) {
+	queryReader := api.NewQueryReader(r)
+
+	pageNumber, err := readPositiveInt(queryReader, "page[number]", 1)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	pageSize, err := readPositiveInt(queryReader, "page[size]", base.DefaultPageSize)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return pageNumber, pageSize, nil
+}
+
+func readPositiveInt(queryReader *api.QueryReader, key string, defaultValue int) (int, error) {
+	value, err := queryReader.ReadString(key)
+	if err != nil {
+		return 0, errors.WithMessagef(err, "failed to read %s", key)
+	}
+
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errors.WithMessagef(err, "invalid %s value", key)
+	}
+
+	if n < 1 {
+		return 0, errors.Errorf("%s must be positive", key)
+	}
+
+	return n, nil
+}
+
+// buildSorting parses the sort parameter: sort=field or sort=-field for the descending order.
+// The newest records are returned first by default.
+func buildSorting(sort string) []filters.Sorting {
+	direction := filters.SortDirectionAsc
+
+	field, desc := strings.CutPrefix(sort, "-")
+	if desc {
+		direction = filters.SortDirectionDesc
+	}
+
+	if !sortableFields[field] {
+		return []filters.Sorting{
+			{Field: "created_at", Direction: filters.SortDirectionDesc},
+			{Field: "id", Direction: filters.SortDirectionDesc},
+		}
+	}
+
+	return []filters.Sorting{{Field: field, Direction: direction}}
+}
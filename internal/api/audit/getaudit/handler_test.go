@@ -0,0 +1,241 @@
+package getaudit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	auditbase "github.com/gameap/gameap/internal/api/audit/base"
+	"github.com/gameap/gameap/internal/api/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRepo(t *testing.T) *inmemory.AuditLogRepository {
+	t.Helper()
+
+	repo := inmemory.NewAuditLogRepository()
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	logs := []*domain.AuditLog{
+		{
+			UserID:     lo.ToPtr[uint](1),
+			Action:     "POST /api/servers",
+			EntityType: lo.ToPtr(domain.EntityTypeServer),
+			Result:     domain.AuditResultSuccess,
+			StatusCode: http.StatusCreated,
+			Request:    lo.ToPtr(`{"name":"server"}`),
+			CreatedAt:  lo.ToPtr(createdAt),
+		},
+		{
+			UserID:     lo.ToPtr[uint](2),
+			Action:     "DELETE /api/users/{id}",
+			EntityType: lo.ToPtr(domain.EntityTypeUser),
+			EntityID:   lo.ToPtr("1"),
+			Result:     domain.AuditResultFailure,
+			StatusCode: http.StatusForbidden,
+			CreatedAt:  lo.ToPtr(createdAt.Add(time.Hour)),
+		},
+		{
+			UserID:     lo.ToPtr[uint](1),
+			Action:     "PUT /api/servers/{id}",
+			EntityType: lo.ToPtr(domain.EntityTypeServer),
+			EntityID:   lo.ToPtr("5"),
+			Changes:    lo.ToPtr(`{"name":{"old":"a","new":"b"}}`),
+			Result:     domain.AuditResultSuccess,
+			StatusCode: http.StatusOK,
+			CreatedAt:  lo.ToPtr(createdAt.Add(2 * time.Hour)),
+		},
+	}
+
+	for _, log := range logs {
+		require.NoError(t, repo.Save(context.Background(), log))
+	}
+
+	return repo
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		queryParams    string
+		authenticated  bool
+		expectedStatus int
+		expectedError  string
+		checkResponse  func(*testing.T, *base.PaginatedResponse[auditbase.AuditLogResponse])
+	}{
+		{
+			name:           "all logs newest first",
+			authenticated:  true,
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, resp *base.PaginatedResponse[auditbase.AuditLogResponse]) {
+				t.Helper()
+
+				assert.Equal(t, 3, resp.Total)
+				require.Len(t, resp.Data, 3)
+				assert.Equal(t, uint(3), resp.Data[0].ID)
+				assert.Equal(t, uint(1), resp.Data[2].ID)
+				assert.JSONEq(t, `{"name":{"old":"a","new":"b"}}`, string(resp.Data[0].Changes))
+				assert.JSONEq(t, `{"name":"server"}`, string(resp.Data[2].Request))
+				assert.JSONEq(t, `null`, string(resp.Data[1].Changes))
+			},
+		},
+		{
+			name:           "filter by user and entity type",
+			queryParams:    "?filter[user_id]=1&filter[entity_type]=" + string(domain.EntityTypeServer),
+			authenticated:  true,
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, resp *base.PaginatedResponse[auditbase.AuditLogResponse]) {
+				t.Helper()
+
+				assert.Equal(t, 2, resp.Total)
+				require.Len(t, resp.Data, 2)
+				assert.Equal(t, uint(3), resp.Data[0].ID)
+				assert.Equal(t, uint(1), resp.Data[1].ID)
+			},
+		},
+		{
+			name:           "filter by result",
+			queryParams:    "?filter[result]=failure",
+			authenticated:  true,
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, resp *base.PaginatedResponse[auditbase.AuditLogResponse]) {
+				t.Helper()
+
+				require.Len(t, resp.Data, 1)
+				assert.Equal(t, uint(2), resp.Data[0].ID)
+				assert.Equal(t, domain.AuditResultFailure, resp.Data[0].Result)
+			},
+		},
+		{
+			name:           "filter by date range",
+			queryParams:    "?filter[from]=2024-05-01T10:30:00Z&filter[to]=2024-05-01T11:30:00Z",
+			authenticated:  true,
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, resp *base.PaginatedResponse[auditbase.AuditLogResponse]) {
+				t.Helper()
+
+				require.Len(t, resp.Data, 1)
+				assert.Equal(t, uint(2), resp.Data[0].ID)
+			},
+		},
+		{
+			name:           "pagination and sorting",
+			queryParams:    "?sort=id&page[number]=2&page[size]=2",
+			authenticated:  true,
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, resp *base.PaginatedResponse[auditbase.AuditLogResponse]) {
+				t.Helper()
+
+				assert.Equal(t, 3, resp.Total)
+				assert.Equal(t, 2, resp.CurrentPage)
+				assert.Equal(t, 2, resp.LastPage)
+				require.Len(t, resp.Data, 1)
+				assert.Equal(t, uint(3), resp.Data[0].ID)
+			},
+		},
+		{
+			name:           "invalid result filter",
+			queryParams:    "?filter[result]=unknown",
+			authenticated:  true,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "failed to read filter",
+		},
+		{
+			name:           "invalid date filter",
+			queryParams:    "?filter[from]=yesterday",
+			authenticated:  true,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "failed to read filter",
+		},
+		{
+			name:           "invalid page",
+			queryParams:    "?page[size]=0",
+			authenticated:  true,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "failed to read page",
+		},
+		{
+			name:           "not authenticated",
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "user not authenticated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHandler(setupRepo(t), api.NewResponder())
+
+			req := httptest.NewRequest(http.MethodGet, "/api/audit"+tt.queryParams, nil)
+			rec := httptest.NewRecorder()
+
+			if tt.authenticated {
+				ctx := auth.ContextWithSession(req.Context(), &auth.Session{
+					User: &domain.User{ID: 1, Login: "admin", Email: "admin@example.com"},
+				})
+				req = req.WithContext(ctx)
+			}
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			if tt.expectedError != "" {
+				var errResp map[string]any
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&errResp))
+				assert.Contains(t, errResp["error"].(string), tt.expectedError)
+
+				return
+			}
+
+			var response base.PaginatedResponse[auditbase.AuditLogResponse]
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+			tt.checkResponse(t, &response)
+		})
+	}
+}
+
+func TestBuildSorting(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []filters.Sorting
+	}{
+		{
+			input: "",
+			expected: []filters.Sorting{
+				{Field: "created_at", Direction: filters.SortDirectionDesc},
+				{Field: "id", Direction: filters.SortDirectionDesc},
+			},
+		},
+		{
+			input:    "action",
+			expected: []filters.Sorting{{Field: "action", Direction: filters.SortDirectionAsc}},
+		},
+		{
+			input:    "-id",
+			expected: []filters.Sorting{{Field: "id", Direction: filters.SortDirectionDesc}},
+		},
+		{
+			input: "request",
+			expected: []filters.Sorting{
+				{Field: "created_at", Direction: filters.SortDirectionDesc},
+				{Field: "id", Direction: filters.SortDirectionDesc},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, buildSorting(tt.input))
+		})
+	}
+}
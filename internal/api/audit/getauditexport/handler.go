@@ -0,0 +1,174 @@
+package getauditexport
+
+import (
+	"encoding/csv"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	auditbase "github.com/gameap/gameap/internal/api/audit/base"
+	"github.com/gameap/gameap/internal/api/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+// batchSize is the number of records read from the repository at once.
+const batchSize = 500
+
+var header = []string{
+	"id",
+	"created_at",
+	"user_id",
+	"token_id",
+	"ip",
+	"user_agent",
+	"action",
+	"entity_type",
+	"entity_id",
+	"result",
+	"status_code",
+	"changes",
+	"request",
+}
+
+// Handler exports the filtered audit logs to CSV, the newest records first.
+type Handler struct {
+	auditLogRepo repositories.AuditLogRepository
+	responder    base.Responder
+}
+
+func NewHandler(
+	auditLogRepo repositories.AuditLogRepository,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		auditLogRepo: auditLogRepo,
+		responder:    responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	filter, err := auditbase.ReadFilter(r)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "failed to read filter"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	order := []filters.Sorting{{Field: "id", Direction: filters.SortDirectionDesc}}
+
+	// The first batch is read before the headers are sent, so the errors are returned as usual
+	logs, err := h.auditLogRepo.Find(ctx, filter, order, &filters.Pagination{Limit: batchSize})
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to find audit logs"))
+
+		return
+	}
+
+	rw.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	rw.Header().Set("Content-Disposition", "attachment; filename=\"audit.csv\"")
+	rw.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(rw)
+
+	if err = writer.Write(header); err != nil {
+		slog.WarnContext(ctx, "Failed to write audit export", slog.String("error", err.Error()))
+
+		return
+	}
+
+	for len(logs) > 0 {
+		for i := range logs {
+			if err = writer.Write(record(&logs[i])); err != nil {
+				slog.WarnContext(ctx, "Failed to write audit export", slog.String("error", err.Error()))
+
+				return
+			}
+		}
+
+		if len(logs) < batchSize {
+			break
+		}
+
+		// Paging by the ID keeps the export consistent while new records are added
+		filter.BeforeID = lo.ToPtr(logs[len(logs)-1].ID)
+
+		logs, err = h.auditLogRepo.Find(ctx, filter, order, &filters.Pagination{Limit: batchSize})
+		if err != nil {
+			// Headers are already sent, the export is cut short
+			slog.ErrorContext(ctx, "Failed to find audit logs for export", slog.String("error", err.Error()))
+
+			return
+		}
+	}
+
+	writer.Flush()
+
+	if err = writer.Error(); err != nil {
+		slog.WarnContext(ctx, "Failed to write audit export", slog.String("error", err.Error()))
+	}
+}
+
+func record(log *domain.AuditLog) []string {
+	var createdAt string
+	if log.CreatedAt != nil {
+		createdAt = log.CreatedAt.UTC().Format(time.RFC3339)
+	}
+
+	return lo.Map([]string{
+		strconv.FormatUint(uint64(log.ID), 10),
+		createdAt,
+		formatID(log.UserID),
+		formatID(log.TokenID),
+		log.IP,
+		log.UserAgent,
+		log.Action,
+		string(lo.FromPtr(log.EntityType)),
+		lo.FromPtr(log.EntityID),
+		string(log.Result),
+		strconv.Itoa(log.StatusCode),
+		lo.FromPtr(log.Changes),
+		lo.FromPtr(log.Request),
+	}, func(value string, _ int) string {
+		return escapeFormula(value)
+	})
+}
+
+// escapeFormula prevents spreadsheets from evaluating the values like user agents as formulas.
+func escapeFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
+
+func formatID(id *uint) string {
+	if id == nil {
+		return ""
+	}
+
+	return strconv.FormatUint(uint64(*id), 10)
+}
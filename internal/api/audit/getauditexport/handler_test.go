@@ -0,0 +1,148 @@
+package getauditexport
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serve(t *testing.T, repo *inmemory.AuditLogRepository, query string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	handler := NewHandler(repo, api.NewResponder())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/audit/export"+query, nil)
+	req = req.WithContext(auth.ContextWithSession(req.Context(), &auth.Session{
+		User: &domain.User{ID: 1, Login: "admin", Email: "admin@example.com"},
+	}))
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	repo := inmemory.NewAuditLogRepository()
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	require.NoError(t, repo.Save(context.Background(), &domain.AuditLog{
+		UserID:     lo.ToPtr[uint](1),
+		TokenID:    lo.ToPtr[uint](7),
+		IP:         "192.0.2.1",
+		UserAgent:  "=HYPERLINK(\"http://example.com\")",
+		Action:     "PUT /api/servers/{id}",
+		EntityType: lo.ToPtr(domain.EntityTypeServer),
+		EntityID:   lo.ToPtr("5"),
+		Changes:    lo.ToPtr(`{"name":{"old":"a","new":"b"}}`),
+		Result:     domain.AuditResultSuccess,
+		StatusCode: http.StatusOK,
+		CreatedAt:  lo.ToPtr(createdAt),
+	}))
+	require.NoError(t, repo.Save(context.Background(), &domain.AuditLog{
+		Action:     "POST /api/auth/login",
+		Result:     domain.AuditResultFailure,
+		StatusCode: http.StatusUnauthorized,
+		CreatedAt:  lo.ToPtr(createdAt.Add(time.Hour)),
+	}))
+
+	rec := serve(t, repo, "")
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Header().Get("Content-Disposition"), "audit.csv")
+
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+
+	assert.Equal(t, header, records[0])
+	assert.Equal(t, []string{
+		"2", "2024-05-01T11:00:00Z", "", "", "", "", "POST /api/auth/login", "", "", "failure", "401", "", "",
+	}, records[1])
+	assert.Equal(t, []string{
+		"1",
+		"2024-05-01T10:00:00Z",
+		"1",
+		"7",
+		"192.0.2.1",
+		"'=HYPERLINK(\"http://example.com\")",
+		"PUT /api/servers/{id}",
+		string(domain.EntityTypeServer),
+		"5",
+		"success",
+		"200",
+		`{"name":{"old":"a","new":"b"}}`,
+		"",
+	}, records[2])
+}
+
+func TestHandler_ServeHTTP_Batches(t *testing.T) {
+	repo := inmemory.NewAuditLogRepository()
+
+	for range batchSize*2 + 1 {
+		require.NoError(t, repo.Save(context.Background(), &domain.AuditLog{
+			Action:     "POST /api/servers",
+			Result:     domain.AuditResultSuccess,
+			StatusCode: http.StatusCreated,
+		}))
+	}
+
+	rec := serve(t, repo, "?filter[result]=success")
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, batchSize*2+2)
+
+	// Each record is exported once, the newest first
+	assert.Equal(t, "1001", records[1][0])
+	assert.Equal(t, "1", records[len(records)-1][0])
+
+	ids := lo.Map(records[1:], func(record []string, _ int) string {
+		return record[0]
+	})
+	assert.Len(t, lo.Uniq(ids), len(ids))
+}
+
+func TestHandler_ServeHTTP_InvalidFilter(t *testing.T) {
+	rec := serve(t, inmemory.NewAuditLogRepository(), "?filter[to]=tomorrow")
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var errResp map[string]any
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&errResp))
+	assert.Contains(t, errResp["error"].(string), "failed to read filter")
+}
+
+func TestHandler_ServeHTTP_NotAuthenticated(t *testing.T) {
+	handler := NewHandler(inmemory.NewAuditLogRepository(), api.NewResponder())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/audit/export", nil)
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestEscapeFormula(t *testing.T) {
+	assert.Equal(t, "'=1+1", escapeFormula("=1+1"))
+	assert.Equal(t, "'-1", escapeFormula("-1"))
+	assert.Equal(t, "'@SUM(A1)", escapeFormula("@SUM(A1)"))
+	assert.Equal(t, "curl/8.0", escapeFormula("curl/8.0"))
+	assert.Empty(t, escapeFormula(""))
+}
@@ -0,0 +1,95 @@
+package forgotpassword
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gameap/gameap/internal/api/base"
+	"github.com/gameap/gameap/internal/services/passwordreset"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+type passwordResetter interface {
+	Forgot(ctx context.Context, clientIP, email, lang string) error
+}
+
+type languageMatcher interface {
+	HasLanguage(lang string) bool
+	MatchLanguage(acceptLanguage string) string
+}
+
+type Handler struct {
+	service        passwordResetter
+	languages      languageMatcher
+	clientIPHeader string
+	responder      base.Responder
+}
+
+func NewHandler(
+	service passwordResetter,
+	languages languageMatcher,
+	clientIPHeader string,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		service:        service,
+		languages:      languages,
+		clientIPHeader: clientIPHeader,
+		responder:      responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	input := &forgotPasswordInput{}
+
+	err := json.NewDecoder(r.Body).Decode(input)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid request body"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if err = input.Validate(); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	lang := input.Language
+	if !h.languages.HasLanguage(lang) {
+		lang = h.languages.MatchLanguage(r.Header.Get("Accept-Language"))
+	}
+
+	err = h.service.Forgot(ctx, base.ClientIP(r, h.clientIPHeader), input.Email, lang)
+	if err != nil {
+		h.writeError(ctx, rw, err)
+
+		return
+	}
+
+	// The response is the same for unknown emails
+	h.responder.Write(ctx, rw, base.Success)
+}
+
+func (h *Handler) writeError(ctx context.Context, rw http.ResponseWriter, err error) {
+	var tooMany *passwordreset.TooManyAttemptsError
+
+	switch {
+	case errors.As(err, &tooMany):
+		rw.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(tooMany.RetryAfter.Seconds()))))
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(err, http.StatusTooManyRequests))
+	case errors.Is(err, passwordreset.ErrNotConfigured):
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(err, http.StatusServiceUnavailable))
+	default:
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to request password reset"))
+	}
+}
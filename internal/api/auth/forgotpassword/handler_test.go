@@ -0,0 +1,183 @@
+package forgotpassword
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/i18n"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services/mail"
+	"github.com/gameap/gameap/internal/services/passwordreset"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeMailer struct {
+	mu   sync.Mutex
+	sent []*mail.Message
+}
+
+func (m *fakeMailer) Send(_ context.Context, msg *mail.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sent = append(m.sent, msg)
+
+	return nil
+}
+
+func (m *fakeMailer) messages() []*mail.Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.sent
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		acceptLanguage string
+		mailDisabled   bool
+		limitExhausted bool
+		wantStatus     int
+		wantError      string
+		wantSubject    string
+	}{
+		{
+			name:        "sends_link",
+			body:        `{"email": "admin@example.com"}`,
+			wantStatus:  http.StatusOK,
+			wantSubject: "Reset Password Notification",
+		},
+		{
+			name:           "language_from_accept_language",
+			body:           `{"email": "admin@example.com"}`,
+			acceptLanguage: "ru-RU,ru;q=0.9",
+			wantStatus:     http.StatusOK,
+			wantSubject:    "Сброс пароля",
+		},
+		{
+			name:           "language_from_body",
+			body:           `{"email": "admin@example.com", "language": "ru"}`,
+			acceptLanguage: "en-US",
+			wantStatus:     http.StatusOK,
+			wantSubject:    "Сброс пароля",
+		},
+		{
+			name:       "unknown_email_looks_the_same",
+			body:       `{"email": "unknown@example.com"}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing_email",
+			body:       `{}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantError:  "email is required",
+		},
+		{
+			name:       "invalid_email",
+			body:       `{"email": "Admin <admin@example.com>"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantError:  "email must be a valid email address",
+		},
+		{
+			name:       "invalid_body",
+			body:       `{"email":`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "rate_limited",
+			body:           `{"email": "admin@example.com"}`,
+			limitExhausted: true,
+			wantStatus:     http.StatusTooManyRequests,
+			wantError:      "too many password reset attempts",
+		},
+		{
+			name:         "mail_not_configured",
+			body:         `{"email": "admin@example.com"}`,
+			mailDisabled: true,
+			wantStatus:   http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			translator, err := i18n.NewTranslator()
+			require.NoError(t, err)
+
+			userRepo := inmemory.NewUserRepository()
+			require.NoError(t, userRepo.Save(context.Background(), &domain.User{
+				Login: "admin",
+				Email: "admin@example.com",
+			}))
+
+			mailer := &fakeMailer{}
+
+			var serviceMailer mail.Mailer = mailer
+			if test.mailDisabled {
+				serviceMailer = nil
+			}
+
+			limiter := cache.NewRateLimiter(cache.NewInMemory(), "password_reset", 1, time.Minute)
+			if test.limitExhausted {
+				_, _, err = limiter.Hit(context.Background(), "192.0.2.1")
+				require.NoError(t, err)
+			}
+
+			service := passwordreset.NewService(
+				userRepo,
+				inmemory.NewPasswordResetRepository(),
+				serviceMailer,
+				translator,
+				limiter,
+				passwordreset.Config{
+					URL:      "https://panel.example.com/password/reset/:token?email=:email",
+					TTL:      time.Hour,
+					Throttle: time.Minute,
+				},
+			)
+
+			handler := NewHandler(service, translator, "", api.NewResponder())
+
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/password/forgot", strings.NewReader(test.body))
+			req.RemoteAddr = "192.0.2.1:12345"
+			if test.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", test.acceptLanguage)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			service.Wait()
+
+			assert.Equal(t, test.wantStatus, rr.Code)
+
+			if test.wantError != "" {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, test.wantError, response["error"])
+			}
+
+			if test.wantStatus == http.StatusTooManyRequests {
+				assert.NotEmpty(t, rr.Header().Get("Retry-After"))
+			}
+
+			if test.wantSubject != "" {
+				messages := mailer.messages()
+				require.Len(t, messages, 1)
+				assert.Equal(t, test.wantSubject, messages[0].Subject)
+			} else {
+				assert.Empty(t, mailer.messages())
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package forgotpassword
+
+import (
+	"net/mail"
+	"strings"
+
+	"github.com/gameap/gameap/pkg/api"
+)
+
+const maxEmailLength = 255
+
+var (
+	ErrEmailRequired = api.NewValidationError("email is required")
+	ErrInvalidEmail  = api.NewValidationError("email must be a valid email address")
+)
+
+type forgotPasswordInput struct {
+	Email string `json:"email"`
+	// Language of the email, the Accept-Language header is used if it's empty.
+	Language string `json:"language"`
+}
+
+func (in *forgotPasswordInput) Validate() error {
+	in.Email = strings.TrimSpace(in.Email)
+
+	if in.Email == "" {
+		return ErrEmailRequired
+	}
+
+	if len(in.Email) > maxEmailLength {
+		return ErrInvalidEmail
+	}
+
+	address, err := mail.ParseAddress(in.Email)
+	if err != nil || address.Address != in.Email {
+		return ErrInvalidEmail
+	}
+
+	return nil
+}
@@ -1,14 +1,15 @@
 package login
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"time"
 
 	"github.com/gameap/gameap/internal/api/base"
-	"github.com/gameap/gameap/internal/domain"
-	"github.com/gameap/gameap/internal/filters"
-	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/authenticator"
+	"github.com/gameap/gameap/internal/services/ldap"
+	"github.com/gameap/gameap/internal/services/twofactor"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
 	"github.com/pkg/errors"
@@ -19,19 +20,28 @@ const (
 	RememberMeDuration   = 30 * 24 * time.Hour // 30 days
 )
 
+type twoFactorChallenger interface {
+	BeginLogin(ctx context.Context, userID uint, remember bool) (*twofactor.Challenge, error)
+}
+
 type Handler struct {
-	userRepo    repositories.UserRepository
-	responder   base.Responder
-	authService auth.Service
+	authenticator authenticator.Authenticator
+	twoFactor     twoFactorChallenger
+	responder     base.Responder
+	authService   auth.Service
 }
 
 func NewHandler(
-	authService auth.Service, userRepo repositories.UserRepository, responder base.Responder,
+	authService auth.Service,
+	authenticator authenticator.Authenticator,
+	twoFactor twoFactorChallenger,
+	responder base.Responder,
 ) *Handler {
 	return &Handler{
-		userRepo:    userRepo,
-		responder:   responder,
-		authService: authService,
+		authenticator: authenticator,
+		twoFactor:     twoFactor,
+		responder:     responder,
+		authService:   authService,
 	}
 }
 
@@ -57,44 +67,34 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Find user by email or login
-	var users []domain.User
-	var filter *filters.FindUser
-
-	if input.IsEmailLogin() {
-		filter = &filters.FindUser{Emails: []string{input.Email}}
-	} else {
-		filter = &filters.FindUser{Logins: []string{input.Login}}
-	}
-
-	users, err = h.userRepo.Find(ctx, filter, nil, &filters.Pagination{
-		Limit:  1,
-		Offset: 0,
+	user, err := h.authenticator.Authenticate(ctx, authenticator.Credentials{
+		Login:    input.Login,
+		Email:    input.Email,
+		Password: input.Password,
 	})
 	if err != nil {
-		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to find user"))
+		switch {
+		case errors.Is(err, authenticator.ErrInvalidCredentials):
+			h.responder.WriteError(ctx, rw, api.WrapHTTPError(err, http.StatusUnauthorized))
+		case errors.Is(err, authenticator.ErrUserDisabled), errors.Is(err, ldap.ErrNotLinked):
+			h.responder.WriteError(ctx, rw, api.WrapHTTPError(err, http.StatusForbidden))
+		default:
+			h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to authenticate user"))
+		}
 
 		return
 	}
 
-	if len(users) == 0 {
-		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
-			errors.New("invalid credentials"),
-			http.StatusUnauthorized,
-		))
+	// The token is issued by the second step if the user has two-factor authentication
+	challenge, err := h.twoFactor.BeginLogin(ctx, user.ID, input.RememberMe())
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to begin two-factor login"))
 
 		return
 	}
 
-	user := users[0]
-
-	// Verify password
-	err = auth.VerifyPassword(user.Password, input.Password)
-	if err != nil {
-		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
-			errors.New("invalid credentials"),
-			http.StatusUnauthorized,
-		))
+	if challenge != nil {
+		h.responder.Write(ctx, rw, newTwoFactorResponse(challenge))
 
 		return
 	}
@@ -105,13 +105,13 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	}
 
 	// Generate JWT token
-	token, err := h.authService.GenerateTokenForUser(&user, duration)
+	token, err := h.authService.GenerateTokenForUser(user, duration)
 	if err != nil {
 		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to generate token"))
 
 		return
 	}
 
-	response := newLoginResponseFromUser(&user, token, DefaultTokenDuration)
+	response := newLoginResponseFromUser(user, token, DefaultTokenDuration)
 	h.responder.Write(ctx, rw, response)
 }
@@ -12,13 +12,38 @@ import (
 
 	"github.com/gameap/gameap/internal/domain"
 	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services/authenticator"
+	"github.com/gameap/gameap/internal/services/ldap"
+	"github.com/gameap/gameap/internal/services/twofactor"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+type fakeChallenger struct {
+	challenge *twofactor.Challenge
+	userID    uint
+	remember  bool
+}
+
+func (f *fakeChallenger) BeginLogin(_ context.Context, userID uint, remember bool) (*twofactor.Challenge, error) {
+	f.userID = userID
+	f.remember = remember
+
+	return f.challenge, nil
+}
+
+type fakeAuthenticator struct {
+	err error
+}
+
+func (f *fakeAuthenticator) Authenticate(_ context.Context, _ authenticator.Credentials) (*domain.User, error) {
+	return nil, f.err
+}
+
 func TestHandler_ServeHTTP(t *testing.T) {
 	hashedPassword, _ := auth.HashPassword("password123")
 	now := time.Now()
@@ -105,6 +130,20 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			expectedStatus: http.StatusUnauthorized,
 			wantError:      "invalid credentials",
 		},
+		{
+			name: "disabled user",
+			setupRepo: func(repo *inmemory.UserRepository) {
+				disabledUser := *testUser
+				disabledUser.DisabledAt = &now
+				_ = repo.Save(context.Background(), &disabledUser)
+			},
+			requestBody: `{
+				"login": "testuser",
+				"password": "password123"
+			}`,
+			expectedStatus: http.StatusForbidden,
+			wantError:      "user is disabled",
+		},
 		{
 			name:      "missing login field",
 			setupRepo: func(_ *inmemory.UserRepository) {},
@@ -160,7 +199,7 @@ func TestHandler_ServeHTTP(t *testing.T) {
 				tt.setupRepo(repo)
 			}
 			responder := api.NewResponder()
-			handler := NewHandler(auth.NewJWTService([]byte("test-secret-key")), repo, responder)
+			handler := NewHandler(auth.NewJWTService([]byte("test-secret-key")), authenticator.NewLocal(repo), &fakeChallenger{}, responder)
 
 			body := []byte(tt.requestBody)
 
@@ -193,7 +232,7 @@ func TestHandler_MultipleUsers(t *testing.T) {
 	// ARRANGE
 	repo := inmemory.NewUserRepository()
 	responder := api.NewResponder()
-	handler := NewHandler(auth.NewJWTService([]byte("test-secret-key")), repo, responder)
+	handler := NewHandler(auth.NewJWTService([]byte("test-secret-key")), authenticator.NewLocal(repo), &fakeChallenger{}, responder)
 
 	// Create multiple users
 	hashedPassword1, _ := auth.HashPassword("pass1")
@@ -299,7 +338,7 @@ func TestHandler_SpecialCharacters(t *testing.T) {
 	// ARRANGE
 	repo := inmemory.NewUserRepository()
 	responder := api.NewResponder()
-	handler := NewHandler(auth.NewJWTService([]byte("test-secret-key")), repo, responder)
+	handler := NewHandler(auth.NewJWTService([]byte("test-secret-key")), authenticator.NewLocal(repo), &fakeChallenger{}, responder)
 
 	// Create user with special characters
 	specialPassword := "p@$$w0rd!#%&*()"
@@ -364,7 +403,7 @@ func TestHandler_TokenValidation(t *testing.T) {
 	// ARRANGE
 	repo := inmemory.NewUserRepository()
 	responder := api.NewResponder()
-	handler := NewHandler(auth.NewJWTService([]byte("test-secret-key")), repo, responder)
+	handler := NewHandler(auth.NewJWTService([]byte("test-secret-key")), authenticator.NewLocal(repo), &fakeChallenger{}, responder)
 
 	hashedPassword, _ := auth.HashPassword("testpass")
 	now := time.Now()
@@ -412,3 +451,74 @@ func TestHandler_TokenValidation(t *testing.T) {
 	require.True(t, ok)
 	assert.Equal(t, float64(86400), expiresIn)
 }
+
+func TestHandler_TwoFactorChallenge(t *testing.T) {
+	// ARRANGE
+	repo := inmemory.NewUserRepository()
+	challenger := &fakeChallenger{
+		challenge: &twofactor.Challenge{
+			Token:         "challenge-token",
+			SetupRequired: true,
+			ExpiresIn:     5 * time.Minute,
+		},
+	}
+	handler := NewHandler(auth.NewJWTService([]byte("test-secret-key")), authenticator.NewLocal(repo), challenger, api.NewResponder())
+
+	hashedPassword, _ := auth.HashPassword("testpass")
+	user := &domain.User{
+		Login:    "admin",
+		Email:    "admin@example.com",
+		Password: hashedPassword,
+	}
+	require.NoError(t, repo.Save(context.Background(), user))
+
+	// ACT
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/api/auth/login",
+		strings.NewReader(`{"login": "admin", "password": "testpass", "remember": "true"}`),
+	)
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	// ASSERT
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+	assert.NotContains(t, response, "token")
+	assert.NotContains(t, response, "user")
+	assert.Equal(t, true, response["two_factor_required"])
+	assert.Equal(t, true, response["two_factor_setup_required"])
+	assert.Equal(t, "challenge-token", response["two_factor_token"])
+	assert.Equal(t, float64(300), response["expires_in"])
+
+	assert.Equal(t, user.ID, challenger.userID)
+	assert.True(t, challenger.remember)
+}
+
+func TestHandler_DirectoryEntryNotLinked(t *testing.T) {
+	// ARRANGE
+	handler := NewHandler(
+		auth.NewJWTService([]byte("test-secret-key")),
+		&fakeAuthenticator{err: errors.Wrap(ldap.ErrNotLinked, "user with the same login exists")},
+		&fakeChallenger{},
+		api.NewResponder(),
+	)
+
+	// ACT
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/api/auth/login",
+		strings.NewReader(`{"login": "admin", "password": "testpass"}`),
+	)
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	// ASSERT
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "directory entry is not linked to a user")
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/services/twofactor"
 )
 
 type loginResponse struct {
@@ -29,3 +30,20 @@ func newLoginResponseFromUser(user *domain.User, token string, expiresIn time.Du
 		},
 	}
 }
+
+// twoFactorResponse is returned instead of the token when the login needs the second step.
+type twoFactorResponse struct {
+	TwoFactorRequired      bool   `json:"two_factor_required"`
+	TwoFactorSetupRequired bool   `json:"two_factor_setup_required"`
+	TwoFactorToken         string `json:"two_factor_token"`
+	ExpiresIn              int64  `json:"expires_in"` // Two-factor token expiration in seconds
+}
+
+func newTwoFactorResponse(challenge *twofactor.Challenge) twoFactorResponse {
+	return twoFactorResponse{
+		TwoFactorRequired:      true,
+		TwoFactorSetupRequired: challenge.SetupRequired,
+		TwoFactorToken:         challenge.Token,
+		ExpiresIn:              int64(challenge.ExpiresIn.Seconds()),
+	}
+}
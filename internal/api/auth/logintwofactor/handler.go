@@ -0,0 +1,106 @@
+package logintwofactor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/auth/login"
+	"github.com/gameap/gameap/internal/api/base"
+	twofactorbase "github.com/gameap/gameap/internal/api/twofactor/base"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/twofactor"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+)
+
+type loginCompleter interface {
+	CompleteLogin(ctx context.Context, token, code string) (*twofactor.LoginResult, error)
+}
+
+// Handler is the second login step, it issues the token after the two-factor code is verified.
+type Handler struct {
+	authService auth.Service
+	userRepo    repositories.UserRepository
+	twoFactor   loginCompleter
+	responder   base.Responder
+}
+
+func NewHandler(
+	authService auth.Service,
+	userRepo repositories.UserRepository,
+	twoFactor loginCompleter,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		authService: authService,
+		userRepo:    userRepo,
+		twoFactor:   twoFactor,
+		responder:   responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	input := &loginTwoFactorInput{}
+
+	err := json.NewDecoder(r.Body).Decode(input)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid request body"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if err = input.Validate(); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	result, err := h.twoFactor.CompleteLogin(ctx, input.Token, input.Code)
+	if err != nil {
+		twofactorbase.WriteError(ctx, rw, h.responder, err, "failed to complete two-factor login")
+
+		return
+	}
+
+	users, err := h.userRepo.Find(ctx, &filters.FindUser{IDs: []uint{result.UserID}}, nil, &filters.Pagination{
+		Limit: 1,
+	})
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to find user"))
+
+		return
+	}
+
+	if len(users) == 0 {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("invalid credentials"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	user := users[0]
+
+	duration := login.DefaultTokenDuration
+	if result.Remember {
+		duration = login.RememberMeDuration
+	}
+
+	token, err := h.authService.GenerateTokenForUser(&user, duration)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to generate token"))
+
+		return
+	}
+
+	h.responder.Write(ctx, rw, newLoginResponse(&user, token, duration, result.RecoveryCodes))
+}
@@ -0,0 +1,224 @@
+package logintwofactor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services/twofactor"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRBAC struct {
+	adminID uint
+}
+
+func (f *fakeRBAC) Can(_ context.Context, userID uint, _ []domain.AbilityName) (bool, error) {
+	return userID == f.adminID, nil
+}
+
+type testEnv struct {
+	service *twofactor.Service
+	handler *Handler
+	user    *domain.User
+}
+
+func setup(t *testing.T, enforceAdmins bool) *testEnv {
+	t.Helper()
+
+	userRepo := inmemory.NewUserRepository()
+	user := &domain.User{Login: "admin", Email: "admin@example.com"}
+	require.NoError(t, userRepo.Save(context.Background(), user))
+
+	c := cache.NewInMemory()
+	service := twofactor.NewService(
+		inmemory.NewUserTwoFactorRepository(),
+		userRepo,
+		&fakeRBAC{adminID: user.ID},
+		c,
+		cache.NewRateLimiter(c, "two_factor", 3, time.Minute),
+		twofactor.Config{Issuer: "GameAP", EnforceAdmins: enforceAdmins, ChallengeTTL: 5 * time.Minute},
+	)
+
+	return &testEnv{
+		service: service,
+		handler: NewHandler(auth.NewJWTService([]byte("test-secret-key")), userRepo, service, api.NewResponder()),
+		user:    user,
+	}
+}
+
+// enroll enables two-factor authentication and returns the secret and the recovery codes.
+func (e *testEnv) enroll(t *testing.T) (string, []string) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	enrollment, err := e.service.StartEnrollment(ctx, e.user)
+	require.NoError(t, err)
+
+	code, err := twofactor.Code(enrollment.Secret, time.Now())
+	require.NoError(t, err)
+
+	recoveryCodes, err := e.service.ConfirmEnrollment(ctx, e.user.ID, code)
+	require.NoError(t, err)
+
+	return enrollment.Secret, recoveryCodes
+}
+
+func (e *testEnv) serve(t *testing.T, body string) (*httptest.ResponseRecorder, map[string]any) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/login/two-factor", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	e.handler.ServeHTTP(w, req)
+
+	var response map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response), w.Body.String())
+
+	return w, response
+}
+
+func TestHandler_TOTPCode(t *testing.T) {
+	env := setup(t, false)
+	secret, _ := env.enroll(t)
+
+	challenge, err := env.service.BeginLogin(context.Background(), env.user.ID, true)
+	require.NoError(t, err)
+
+	// The code of the current time step was used by the enrollment
+	code, err := twofactor.Code(secret, time.Now().Add(30*time.Second))
+	require.NoError(t, err)
+
+	w, response := env.serve(t, `{"two_factor_token": "`+challenge.Token+`", "code": "`+code+`"}`)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.NotEmpty(t, response["token"])
+	assert.Equal(t, float64(30*24*60*60), response["expires_in"])
+	assert.NotContains(t, response, "recovery_codes")
+
+	user, ok := response["user"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "admin", user["login"])
+
+	// The challenge can't be used again
+	w, response = env.serve(t, `{"two_factor_token": "`+challenge.Token+`", "code": "`+code+`"}`)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, "two-factor challenge is invalid or expired", response["message"])
+}
+
+func TestHandler_RecoveryCode(t *testing.T) {
+	env := setup(t, false)
+	_, recoveryCodes := env.enroll(t)
+
+	challenge, err := env.service.BeginLogin(context.Background(), env.user.ID, false)
+	require.NoError(t, err)
+
+	w, response := env.serve(t, `{"two_factor_token": "`+challenge.Token+`", "code": "`+recoveryCodes[3]+`"}`)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.NotEmpty(t, response["token"])
+	assert.Equal(t, float64(24*60*60), response["expires_in"])
+}
+
+func TestHandler_SetupDuringLogin(t *testing.T) {
+	ctx := context.Background()
+	env := setup(t, true)
+
+	challenge, err := env.service.BeginLogin(ctx, env.user.ID, false)
+	require.NoError(t, err)
+	require.True(t, challenge.SetupRequired)
+
+	enrollment, err := env.service.StartLoginEnrollment(ctx, challenge.Token)
+	require.NoError(t, err)
+
+	code, err := twofactor.Code(enrollment.Secret, time.Now())
+	require.NoError(t, err)
+
+	w, response := env.serve(t, `{"two_factor_token": "`+challenge.Token+`", "code": "`+code+`"}`)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.NotEmpty(t, response["token"])
+
+	recoveryCodes, ok := response["recovery_codes"].([]any)
+	require.True(t, ok)
+	assert.Len(t, recoveryCodes, 8)
+}
+
+func TestHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        func(token string) string
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:        "invalid_code",
+			body:        func(token string) string { return `{"two_factor_token": "` + token + `", "code": "aaaaa-bbbbb"}` },
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantMessage: "two-factor code is invalid",
+		},
+		{
+			name:        "unknown_token",
+			body:        func(_ string) string { return `{"two_factor_token": "unknown", "code": "123456"}` },
+			wantStatus:  http.StatusUnauthorized,
+			wantMessage: "two-factor challenge is invalid or expired",
+		},
+		{
+			name:        "token_is_required",
+			body:        func(_ string) string { return `{"code": "123456"}` },
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantMessage: "two_factor_token is required",
+		},
+		{
+			name:        "code_is_required",
+			body:        func(token string) string { return `{"two_factor_token": "` + token + `"}` },
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantMessage: "code is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := setup(t, false)
+			env.enroll(t)
+
+			challenge, err := env.service.BeginLogin(context.Background(), env.user.ID, false)
+			require.NoError(t, err)
+
+			w, response := env.serve(t, tt.body(challenge.Token))
+
+			require.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+			assert.Equal(t, tt.wantMessage, response["message"])
+		})
+	}
+}
+
+func TestHandler_TooManyAttempts(t *testing.T) {
+	env := setup(t, false)
+	env.enroll(t)
+
+	challenge, err := env.service.BeginLogin(context.Background(), env.user.ID, false)
+	require.NoError(t, err)
+
+	body := `{"two_factor_token": "` + challenge.Token + `", "code": "aaaaa-bbbbb"}`
+
+	for range 3 {
+		w, _ := env.serve(t, body)
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	}
+
+	w, _ := env.serve(t, body)
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+}
@@ -0,0 +1,32 @@
+package logintwofactor
+
+import (
+	"strings"
+
+	"github.com/gameap/gameap/pkg/api"
+)
+
+var (
+	ErrTokenRequired = api.NewValidationError("two_factor_token is required")
+	ErrCodeRequired  = api.NewValidationError("code is required")
+)
+
+type loginTwoFactorInput struct {
+	Token string `json:"two_factor_token"`
+	// Code is the TOTP code or a recovery code.
+	Code string `json:"code"`
+}
+
+func (in *loginTwoFactorInput) Validate() error {
+	in.Code = strings.TrimSpace(in.Code)
+
+	if in.Token == "" {
+		return ErrTokenRequired
+	}
+
+	if in.Code == "" {
+		return ErrCodeRequired
+	}
+
+	return nil
+}
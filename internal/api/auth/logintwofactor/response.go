@@ -0,0 +1,36 @@
+package logintwofactor
+
+import (
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+)
+
+type loginResponse struct {
+	Token     string   `json:"token"`
+	ExpiresIn int64    `json:"expires_in"` // Token expiration in seconds
+	User      userInfo `json:"user"`
+	// RecoveryCodes are returned once when two-factor authentication was set up during the login.
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
+}
+
+type userInfo struct {
+	Login string  `json:"login"`
+	Email string  `json:"email"`
+	Name  *string `json:"name"`
+}
+
+func newLoginResponse(
+	user *domain.User, token string, expiresIn time.Duration, recoveryCodes []string,
+) loginResponse {
+	return loginResponse{
+		Token:     token,
+		ExpiresIn: int64(expiresIn.Seconds()),
+		User: userInfo{
+			Login: user.Login,
+			Email: user.Email,
+			Name:  user.Name,
+		},
+		RecoveryCodes: recoveryCodes,
+	}
+}
@@ -0,0 +1,62 @@
+package logintwofactorsetup
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	twofactorbase "github.com/gameap/gameap/internal/api/twofactor/base"
+	"github.com/gameap/gameap/internal/services/twofactor"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+type loginEnrollmentStarter interface {
+	StartLoginEnrollment(ctx context.Context, token string) (*twofactor.Enrollment, error)
+}
+
+// Handler starts the enrollment of the user who must set up two-factor authentication to log in.
+// The enrollment is confirmed with the first code by the second login step.
+type Handler struct {
+	twoFactor loginEnrollmentStarter
+	responder base.Responder
+}
+
+func NewHandler(twoFactor loginEnrollmentStarter, responder base.Responder) *Handler {
+	return &Handler{
+		twoFactor: twoFactor,
+		responder: responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	input := &setupInput{}
+
+	err := json.NewDecoder(r.Body).Decode(input)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid request body"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if err = input.Validate(); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	enrollment, err := h.twoFactor.StartLoginEnrollment(ctx, input.Token)
+	if err != nil {
+		twofactorbase.WriteError(ctx, rw, h.responder, err, "failed to start two-factor enrollment")
+
+		return
+	}
+
+	h.responder.Write(ctx, rw, twofactorbase.NewEnrollmentResponse(enrollment))
+}
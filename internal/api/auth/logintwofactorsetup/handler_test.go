@@ -0,0 +1,104 @@
+package logintwofactorsetup
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services/twofactor"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRBAC struct{}
+
+func (fakeRBAC) Can(_ context.Context, _ uint, _ []domain.AbilityName) (bool, error) {
+	return true, nil
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        func(token string) string
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:       "enrollment_is_started",
+			body:       func(token string) string { return `{"two_factor_token": "` + token + `"}` },
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:        "unknown_token",
+			body:        func(_ string) string { return `{"two_factor_token": "unknown"}` },
+			wantStatus:  http.StatusUnauthorized,
+			wantMessage: "two-factor challenge is invalid or expired",
+		},
+		{
+			name:        "token_is_required",
+			body:        func(_ string) string { return `{}` },
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantMessage: "two_factor_token is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			userRepo := inmemory.NewUserRepository()
+			user := &domain.User{Login: "admin", Email: "admin@example.com"}
+			require.NoError(t, userRepo.Save(ctx, user))
+
+			c := cache.NewInMemory()
+			service := twofactor.NewService(
+				inmemory.NewUserTwoFactorRepository(),
+				userRepo,
+				fakeRBAC{},
+				c,
+				cache.NewRateLimiter(c, "two_factor", 5, time.Minute),
+				twofactor.Config{Issuer: "GameAP", EnforceAdmins: true, ChallengeTTL: 5 * time.Minute},
+			)
+			handler := NewHandler(service, api.NewResponder())
+
+			challenge, err := service.BeginLogin(ctx, user.ID, false)
+			require.NoError(t, err)
+			require.True(t, challenge.SetupRequired)
+
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"/api/auth/login/two-factor/setup",
+				strings.NewReader(tt.body(challenge.Token)),
+			)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+			if tt.wantMessage != "" {
+				assert.Equal(t, tt.wantMessage, response["message"])
+
+				return
+			}
+
+			assert.NotEmpty(t, response["secret"])
+			assert.Contains(t, response["uri"], "otpauth://totp/GameAP:admin?")
+
+			status, err := service.Status(ctx, user.ID)
+			require.NoError(t, err)
+			assert.True(t, status.Pending)
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package logintwofactorsetup
+
+import "github.com/gameap/gameap/pkg/api"
+
+var ErrTokenRequired = api.NewValidationError("two_factor_token is required")
+
+type setupInput struct {
+	Token string `json:"two_factor_token"`
+}
+
+func (in *setupInput) Validate() error {
+	if in.Token == "" {
+		return ErrTokenRequired
+	}
+
+	return nil
+}
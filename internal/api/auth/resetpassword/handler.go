@@ -0,0 +1,77 @@
+package resetpassword
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gameap/gameap/internal/api/base"
+	"github.com/gameap/gameap/internal/services/passwordreset"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+type passwordResetter interface {
+	Reset(ctx context.Context, clientIP, email, token, password string) error
+}
+
+type Handler struct {
+	service        passwordResetter
+	clientIPHeader string
+	responder      base.Responder
+}
+
+func NewHandler(service passwordResetter, clientIPHeader string, responder base.Responder) *Handler {
+	return &Handler{
+		service:        service,
+		clientIPHeader: clientIPHeader,
+		responder:      responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	input := &resetPasswordInput{}
+
+	err := json.NewDecoder(r.Body).Decode(input)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid request body"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if err = input.Validate(); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	err = h.service.Reset(ctx, base.ClientIP(r, h.clientIPHeader), input.Email, input.Token, input.Password)
+	if err != nil {
+		h.writeError(ctx, rw, err)
+
+		return
+	}
+
+	h.responder.Write(ctx, rw, base.Success)
+}
+
+func (h *Handler) writeError(ctx context.Context, rw http.ResponseWriter, err error) {
+	var tooMany *passwordreset.TooManyAttemptsError
+
+	switch {
+	case errors.As(err, &tooMany):
+		rw.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(tooMany.RetryAfter.Seconds()))))
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(err, http.StatusTooManyRequests))
+	case errors.Is(err, passwordreset.ErrInvalidToken):
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(err, http.StatusUnprocessableEntity))
+	default:
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to reset password"))
+	}
+}
@@ -0,0 +1,177 @@
+package resetpassword
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/i18n"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services/passwordreset"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testToken = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	hashedToken, err := auth.HashPassword(testToken)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		body           string
+		resetCreatedAt time.Time
+		limitExhausted bool
+		wantStatus     int
+		wantError      string
+		wantChanged    bool
+	}{
+		{
+			name:           "password_is_reset",
+			body:           `{"email": "admin@example.com", "token": "` + testToken + `", "password": "new-password"}`,
+			resetCreatedAt: time.Now(),
+			wantStatus:     http.StatusOK,
+			wantChanged:    true,
+		},
+		{
+			name: "password_confirmation_matches",
+			body: `{"email": "admin@example.com", "token": "` + testToken + `", ` +
+				`"password": "new-password", "password_confirmation": "new-password"}`,
+			resetCreatedAt: time.Now(),
+			wantStatus:     http.StatusOK,
+			wantChanged:    true,
+		},
+		{
+			name: "password_confirmation_mismatch",
+			body: `{"email": "admin@example.com", "token": "` + testToken + `", ` +
+				`"password": "new-password", "password_confirmation": "other-password"}`,
+			resetCreatedAt: time.Now(),
+			wantStatus:     http.StatusUnprocessableEntity,
+			wantError:      "password confirmation does not match",
+		},
+		{
+			name:           "invalid_token",
+			body:           `{"email": "admin@example.com", "token": "invalid", "password": "new-password"}`,
+			resetCreatedAt: time.Now(),
+			wantStatus:     http.StatusUnprocessableEntity,
+			wantError:      "password reset token is invalid",
+		},
+		{
+			name:           "expired_token",
+			body:           `{"email": "admin@example.com", "token": "` + testToken + `", "password": "new-password"}`,
+			resetCreatedAt: time.Now().Add(-2 * time.Hour),
+			wantStatus:     http.StatusUnprocessableEntity,
+			wantError:      "password reset token is invalid",
+		},
+		{
+			name:           "short_password",
+			body:           `{"email": "admin@example.com", "token": "` + testToken + `", "password": "short"}`,
+			resetCreatedAt: time.Now(),
+			wantStatus:     http.StatusUnprocessableEntity,
+			wantError:      "password must be at least 8 characters long",
+		},
+		{
+			name:           "missing_token",
+			body:           `{"email": "admin@example.com", "password": "new-password"}`,
+			resetCreatedAt: time.Now(),
+			wantStatus:     http.StatusUnprocessableEntity,
+			wantError:      "token is required",
+		},
+		{
+			name:           "invalid_body",
+			body:           `{"email":`,
+			resetCreatedAt: time.Now(),
+			wantStatus:     http.StatusBadRequest,
+		},
+		{
+			name:           "rate_limited",
+			body:           `{"email": "admin@example.com", "token": "` + testToken + `", "password": "new-password"}`,
+			resetCreatedAt: time.Now(),
+			limitExhausted: true,
+			wantStatus:     http.StatusTooManyRequests,
+			wantError:      "too many password reset attempts",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			translator, err := i18n.NewTranslator()
+			require.NoError(t, err)
+
+			oldPassword, err := auth.HashPassword("old-password")
+			require.NoError(t, err)
+
+			userRepo := inmemory.NewUserRepository()
+			require.NoError(t, userRepo.Save(ctx, &domain.User{
+				Login:    "admin",
+				Email:    "admin@example.com",
+				Password: oldPassword,
+			}))
+
+			resetRepo := inmemory.NewPasswordResetRepository()
+			require.NoError(t, resetRepo.Save(ctx, &domain.PasswordReset{
+				Email:     "admin@example.com",
+				Token:     hashedToken,
+				CreatedAt: lo.ToPtr(test.resetCreatedAt),
+			}))
+
+			limiter := cache.NewRateLimiter(cache.NewInMemory(), "password_reset", 1, time.Minute)
+			if test.limitExhausted {
+				_, _, err = limiter.Hit(ctx, "192.0.2.1")
+				require.NoError(t, err)
+			}
+
+			service := passwordreset.NewService(
+				userRepo,
+				resetRepo,
+				nil,
+				translator,
+				limiter,
+				passwordreset.Config{TTL: time.Hour, Throttle: time.Minute},
+			)
+
+			handler := NewHandler(service, "", api.NewResponder())
+
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/password/reset", strings.NewReader(test.body))
+			req.RemoteAddr = "192.0.2.1:12345"
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.wantStatus, rr.Code)
+
+			if test.wantError != "" {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, test.wantError, response["error"])
+			}
+
+			users, err := userRepo.Find(ctx, filters.FindUserByEmails("admin@example.com"), nil, nil)
+			require.NoError(t, err)
+			require.Len(t, users, 1)
+
+			if test.wantChanged {
+				require.NoError(t, auth.VerifyPassword(users[0].Password, "new-password"))
+
+				resets, err := resetRepo.Find(ctx, nil, nil, nil)
+				require.NoError(t, err)
+				assert.Empty(t, resets)
+			} else {
+				require.NoError(t, auth.VerifyPassword(users[0].Password, "old-password"))
+			}
+		})
+	}
+}
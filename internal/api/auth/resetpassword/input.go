@@ -0,0 +1,63 @@
+package resetpassword
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gameap/gameap/pkg/api"
+)
+
+const (
+	minPasswordLength = 8
+	maxPasswordLength = 64
+)
+
+var (
+	ErrEmailRequired    = api.NewValidationError("email is required")
+	ErrTokenRequired    = api.NewValidationError("token is required")
+	ErrPasswordRequired = api.NewValidationError("password is required")
+	ErrPasswordTooShort = api.NewValidationError(
+		fmt.Sprintf("password must be at least %d characters long", minPasswordLength),
+	)
+	ErrPasswordTooLong = api.NewValidationError(
+		fmt.Sprintf("password must not exceed %d characters", maxPasswordLength),
+	)
+	ErrPasswordConfirmation = api.NewValidationError("password confirmation does not match")
+)
+
+type resetPasswordInput struct {
+	Email                string  `json:"email"`
+	Token                string  `json:"token"`
+	Password             string  `json:"password"`
+	PasswordConfirmation *string `json:"password_confirmation"`
+}
+
+func (in *resetPasswordInput) Validate() error {
+	in.Email = strings.TrimSpace(in.Email)
+
+	if in.Email == "" {
+		return ErrEmailRequired
+	}
+
+	if in.Token == "" {
+		return ErrTokenRequired
+	}
+
+	if in.Password == "" {
+		return ErrPasswordRequired
+	}
+
+	if len(in.Password) < minPasswordLength {
+		return ErrPasswordTooShort
+	}
+
+	if len(in.Password) > maxPasswordLength {
+		return ErrPasswordTooLong
+	}
+
+	if in.PasswordConfirmation != nil && *in.PasswordConfirmation != in.Password {
+		return ErrPasswordConfirmation
+	}
+
+	return nil
+}
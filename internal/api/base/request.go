@@ -0,0 +1,26 @@
+package base
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// ClientIP returns the client IP of the request. It's read from the header if it's set,
+// the first address is used when the header contains a list like X-Forwarded-For.
+// Otherwise, the connection address is used.
+func ClientIP(r *http.Request, header string) string {
+	if header != "" {
+		value, _, _ := strings.Cut(r.Header.Get(header), ",")
+		if ip := strings.TrimSpace(value); ip != "" {
+			return ip
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
@@ -0,0 +1,69 @@
+package base
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		name       string
+		remoteAddr string
+		header     string
+		value      string
+		want       string
+	}{
+		{
+			name:       "connection_address",
+			remoteAddr: "203.0.113.5:51234",
+			want:       "203.0.113.5",
+		},
+		{
+			name:       "ipv6_connection_address",
+			remoteAddr: "[2001:db8::1]:51234",
+			want:       "2001:db8::1",
+		},
+		{
+			name:       "header_is_ignored_when_not_configured",
+			remoteAddr: "10.0.0.1:51234",
+			value:      "203.0.113.5",
+			want:       "10.0.0.1",
+		},
+		{
+			name:       "configured_header",
+			remoteAddr: "10.0.0.1:51234",
+			header:     "X-Real-IP",
+			value:      "203.0.113.5",
+			want:       "203.0.113.5",
+		},
+		{
+			name:       "first_address_of_list",
+			remoteAddr: "10.0.0.1:51234",
+			header:     "X-Forwarded-For",
+			value:      "203.0.113.5, 10.0.0.2",
+			want:       "203.0.113.5",
+		},
+		{
+			name:       "empty_header_falls_back_to_connection_address",
+			remoteAddr: "10.0.0.1:51234",
+			header:     "X-Real-IP",
+			want:       "10.0.0.1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/", nil)
+			r.RemoteAddr = test.remoteAddr
+
+			if test.value != "" {
+				r.Header.Set("X-Real-IP", test.value)
+				r.Header.Set("X-Forwarded-For", test.value)
+			}
+
+			assert.Equal(t, test.want, ClientIP(r, test.header))
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/gameap/gameap/internal/api/base"
 	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/events"
 	"github.com/gameap/gameap/internal/filters"
 	"github.com/gameap/gameap/internal/repositories"
 	"github.com/gameap/gameap/pkg/api"
@@ -14,18 +15,25 @@ import (
 	"github.com/pkg/errors"
 )
 
+type eventPublisher interface {
+	Publish(ctx context.Context, event domain.Event)
+}
+
 type Handler struct {
-	serverRepo repositories.ServerRepository
-	responder  base.Responder
+	serverRepo     repositories.ServerRepository
+	eventPublisher eventPublisher
+	responder      base.Responder
 }
 
 func NewHandler(
 	serverRepo repositories.ServerRepository,
+	eventPublisher eventPublisher,
 	responder base.Responder,
 ) *Handler {
 	return &Handler{
-		serverRepo: serverRepo,
-		responder:  responder,
+		serverRepo:     serverRepo,
+		eventPublisher: eventPublisher,
+		responder:      responder,
 	}
 }
 
@@ -68,7 +76,7 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.updateServers(servers, inputs)
+	statusEvents := h.updateServers(servers, inputs)
 
 	err = h.saveServers(ctx, servers)
 	if err != nil {
@@ -80,6 +88,10 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	for _, event := range statusEvents {
+		h.eventPublisher.Publish(ctx, event)
+	}
+
 	h.responder.Write(ctx, rw, newBulkUpdateServerResponse())
 }
 
@@ -139,16 +151,22 @@ func (h *Handler) fetchServers(
 	return serverMap, nil
 }
 
+// updateServers applies the inputs to the servers and returns the events
+// of the servers which went online or offline.
 func (h *Handler) updateServers(
 	serverMap map[uint]*domain.Server,
 	inputs []bulkUpdateServerInput,
-) {
+) []domain.Event {
+	var statusEvents []domain.Event
+
 	for _, input := range inputs {
 		server, exists := serverMap[input.ID]
 		if !exists {
 			continue
 		}
 
+		wasOnline := server.IsOnline()
+
 		if input.Installed != nil {
 			server.Installed = domain.ServerInstalledStatus(*input.Installed)
 		}
@@ -160,7 +178,13 @@ func (h *Handler) updateServers(
 		if input.LastProcessCheck != nil {
 			server.LastProcessCheck = &input.LastProcessCheck.Time
 		}
+
+		if event, changed := events.ServerStatusChanged(server, wasOnline); changed {
+			statusEvents = append(statusEvents, event)
+		}
 	}
+
+	return statusEvents
 }
 
 func (h *Handler) saveServers(
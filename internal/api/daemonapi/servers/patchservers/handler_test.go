@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/events"
 	"github.com/gameap/gameap/internal/repositories/inmemory"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
@@ -713,6 +714,7 @@ func TestHandler_ServeHTTP(t *testing.T) {
 
 			handler := NewHandler(
 				serverRepo,
+				events.NewBus(),
 				responder,
 			)
 
@@ -761,6 +763,7 @@ func TestHandler_ResponseStructure(t *testing.T) {
 
 	handler := NewHandler(
 		serverRepo,
+		events.NewBus(),
 		responder,
 	)
 
@@ -835,12 +838,65 @@ func TestHandler_ResponseStructure(t *testing.T) {
 	assert.Equal(t, "success", response.Message)
 }
 
+func TestHandler_PublishesStatusEvents(t *testing.T) {
+	serverRepo := inmemory.NewServerRepository()
+	bus := events.NewBus()
+	handler := NewHandler(serverRepo, bus, api.NewResponder())
+
+	var published []domain.Event
+	bus.Subscribe(events.HandlerFunc(func(_ context.Context, event domain.Event) error {
+		published = append(published, event)
+
+		return nil
+	}))
+
+	now := time.Now()
+
+	for _, server := range []*domain.Server{
+		{ID: 1, UUID: uuid.New(), DSID: 1},
+		{ID: 2, UUID: uuid.New(), DSID: 1, ProcessActive: true, LastProcessCheck: &now},
+		{ID: 3, UUID: uuid.New(), DSID: 1, ProcessActive: true, LastProcessCheck: &now},
+	} {
+		require.NoError(t, serverRepo.Save(context.Background(), server))
+	}
+
+	ctx := auth.ContextWithDaemonSession(context.Background(), &auth.DaemonSession{
+		Node: &domain.Node{ID: 1},
+	})
+
+	checkedAt := now.UTC().Format(time.RFC3339)
+	body := `[
+		{"id": 1, "process_active": 1, "last_process_check": "` + checkedAt + `"},
+		{"id": 2, "process_active": 0, "last_process_check": "` + checkedAt + `"},
+		{"id": 3, "process_active": 1, "last_process_check": "` + checkedAt + `"}
+	]`
+
+	req := httptest.NewRequest(http.MethodPatch, "/gdaemon_api/servers", bytes.NewReader([]byte(body)))
+	req = req.WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	types := make(map[uint]domain.EventType, len(published))
+	for _, event := range published {
+		types[*event.ServerID] = event.Type
+	}
+
+	assert.Equal(t, map[uint]domain.EventType{
+		1: domain.EventTypeServerOnline,
+		2: domain.EventTypeServerOffline,
+	}, types)
+}
+
 func TestHandler_NewHandler(t *testing.T) {
 	serverRepo := inmemory.NewServerRepository()
 	responder := api.NewResponder()
 
 	handler := NewHandler(
 		serverRepo,
+		events.NewBus(),
 		responder,
 	)
 
@@ -0,0 +1,275 @@
+package postresourceusage
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gameap/gameap/internal/api/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+type metricsRecorder interface {
+	Record(ctx context.Context, samples []*domain.Metric) error
+}
+
+// Handler stores the resource usage of the node servers reported by the daemon
+// and records it to the usage history of the servers and the node.
+// The node usage is the total usage of the reported servers.
+// Usage of servers from other nodes is ignored.
+type Handler struct {
+	serverRepo        repositories.ServerRepository
+	resourceUsageRepo repositories.ServerResourceUsageRepository
+	metricsRecorder   metricsRecorder
+	responder         base.Responder
+}
+
+func NewHandler(
+	serverRepo repositories.ServerRepository,
+	resourceUsageRepo repositories.ServerResourceUsageRepository,
+	metricsRecorder metricsRecorder,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		serverRepo:        serverRepo,
+		resourceUsageRepo: resourceUsageRepo,
+		metricsRecorder:   metricsRecorder,
+		responder:         responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	node, err := h.validateDaemonSession(ctx)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			err,
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	inputs, err := h.parseAndValidateInputs(r)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			err,
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if len(inputs) == 0 {
+		h.responder.Write(ctx, rw, newResourceUsageResponse())
+
+		return
+	}
+
+	servers, err := h.fetchServers(ctx, node, inputs)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			err,
+			http.StatusInternalServerError,
+		))
+
+		return
+	}
+
+	usages, err := h.saveUsages(ctx, servers, inputs)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			err,
+			http.StatusInternalServerError,
+		))
+
+		return
+	}
+
+	err = h.metricsRecorder.Record(ctx, newMetrics(node, usages))
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "failed to record metrics"),
+			http.StatusInternalServerError,
+		))
+
+		return
+	}
+
+	h.responder.Write(ctx, rw, newResourceUsageResponse())
+}
+
+func (h *Handler) validateDaemonSession(ctx context.Context) (*domain.Node, error) {
+	daemonSession := auth.DaemonSessionFromContext(ctx)
+	if daemonSession == nil || daemonSession.Node == nil {
+		return nil, errors.New("daemon session not found")
+	}
+
+	return daemonSession.Node, nil
+}
+
+func (h *Handler) parseAndValidateInputs(r *http.Request) ([]resourceUsageInput, error) {
+	var inputs []resourceUsageInput
+
+	err := json.NewDecoder(r.Body).Decode(&inputs)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid request")
+	}
+
+	for _, input := range inputs {
+		err = input.Validate()
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid input")
+		}
+	}
+
+	return inputs, nil
+}
+
+func (h *Handler) fetchServers(
+	ctx context.Context,
+	node *domain.Node,
+	inputs []resourceUsageInput,
+) (map[uint]*domain.Server, error) {
+	serverIDs := make([]uint, 0, len(inputs))
+	for _, input := range inputs {
+		serverIDs = append(serverIDs, input.ServerID)
+	}
+
+	servers, err := h.serverRepo.Find(ctx, &filters.FindServer{
+		IDs:   serverIDs,
+		DSIDs: []uint{node.ID},
+	}, nil, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find servers")
+	}
+
+	serverMap := make(map[uint]*domain.Server, len(servers))
+	for i := range servers {
+		serverMap[servers[i].ID] = &servers[i]
+	}
+
+	return serverMap, nil
+}
+
+// reportedUsage is the saved usage of a server together with the disk usage,
+// which is recorded to the history only.
+type reportedUsage struct {
+	domain.ServerResourceUsage
+
+	Disk *int
+}
+
+func (h *Handler) saveUsages(
+	ctx context.Context,
+	servers map[uint]*domain.Server,
+	inputs []resourceUsageInput,
+) ([]reportedUsage, error) {
+	now := time.Now()
+	usages := make([]reportedUsage, 0, len(inputs))
+
+	for _, input := range inputs {
+		server, exists := servers[input.ServerID]
+		if !exists {
+			continue
+		}
+
+		usage := &domain.ServerResourceUsage{
+			ServerID:   server.ID,
+			CPU:        input.CPU,
+			RAM:        input.RAM,
+			Net:        input.Net,
+			ReportedAt: now,
+		}
+
+		if input.ReportedAt != nil && !input.ReportedAt.IsZero() {
+			usage.ReportedAt = input.ReportedAt.Time
+		}
+
+		err := h.resourceUsageRepo.Save(ctx, usage)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to save resource usage of server %d", server.ID)
+		}
+
+		usages = append(usages, reportedUsage{ServerResourceUsage: *usage, Disk: input.Disk})
+
+		for _, warning := range usage.LimitWarnings(server) {
+			slog.WarnContext(
+				ctx,
+				"Server exceeds resource limit",
+				slog.Uint64("server_id", uint64(server.ID)),
+				slog.String("resource", string(warning.Resource)),
+				slog.Int("limit", warning.Limit),
+				slog.Float64("usage", warning.Usage),
+			)
+		}
+	}
+
+	return usages, nil
+}
+
+func newMetrics(node *domain.Node, usages []reportedUsage) []*domain.Metric {
+	if len(usages) == 0 {
+		return nil
+	}
+
+	metrics := make([]*domain.Metric, 0, len(usages)+1)
+
+	nodeMetric := &domain.Metric{
+		SubjectType: domain.MetricSubjectNode,
+		SubjectID:   node.ID,
+		RecordedAt:  usages[0].ReportedAt,
+	}
+
+	for _, usage := range usages {
+		metric := &domain.Metric{
+			SubjectType: domain.MetricSubjectServer,
+			SubjectID:   usage.ServerID,
+			RecordedAt:  usage.ReportedAt,
+			CPU:         usage.CPU,
+			RAM:         intToFloatPtr(usage.RAM),
+			Disk:        intToFloatPtr(usage.Disk),
+		}
+
+		metrics = append(metrics, metric)
+
+		nodeMetric.CPU = sumPtr(nodeMetric.CPU, metric.CPU)
+		nodeMetric.RAM = sumPtr(nodeMetric.RAM, metric.RAM)
+		nodeMetric.Disk = sumPtr(nodeMetric.Disk, metric.Disk)
+
+		if usage.ReportedAt.After(nodeMetric.RecordedAt) {
+			nodeMetric.RecordedAt = usage.ReportedAt
+		}
+	}
+
+	return append(metrics, nodeMetric)
+}
+
+func intToFloatPtr(v *int) *float64 {
+	if v == nil {
+		return nil
+	}
+
+	return lo.ToPtr(float64(*v))
+}
+
+func sumPtr(total, v *float64) *float64 {
+	if v == nil {
+		return total
+	}
+
+	if total == nil {
+		return lo.ToPtr(*v)
+	}
+
+	return lo.ToPtr(*total + *v)
+}
@@ -0,0 +1,224 @@
+package postresourceusage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services/metrics"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		withSession    bool
+		requestBody    any
+		expectedStatus int
+		wantError      string
+		validateUsages func(*testing.T, []domain.ServerResourceUsage)
+		wantMetrics    []domain.Metric
+	}{
+		{
+			name:        "store usage of node servers",
+			withSession: true,
+			requestBody: []map[string]any{
+				{"server_id": 1, "cpu": 135.5, "ram": 3000, "net": 20, "disk": 5000, "reported_at": "2025-03-01T10:00:00Z"},
+				{"server_id": 2, "ram": 512, "reported_at": "2025-03-01T09:59:50Z"},
+			},
+			expectedStatus: http.StatusOK,
+			validateUsages: func(t *testing.T, usages []domain.ServerResourceUsage) {
+				t.Helper()
+
+				require.Len(t, usages, 2)
+
+				assert.Equal(t, uint(1), usages[0].ServerID)
+				assert.Equal(t, lo.ToPtr(135.5), usages[0].CPU)
+				assert.Equal(t, lo.ToPtr(3000), usages[0].RAM)
+				assert.Equal(t, lo.ToPtr(20), usages[0].Net)
+				assert.Equal(t, time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC), usages[0].ReportedAt.UTC())
+
+				assert.Equal(t, uint(2), usages[1].ServerID)
+				assert.Nil(t, usages[1].CPU)
+				assert.Equal(t, lo.ToPtr(512), usages[1].RAM)
+			},
+			wantMetrics: []domain.Metric{
+				{
+					SubjectType: domain.MetricSubjectNode,
+					SubjectID:   1,
+					Resolution:  domain.MetricResolutionRaw,
+					RecordedAt:  time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC),
+					CPU:         lo.ToPtr(135.5),
+					RAM:         lo.ToPtr(3512.0),
+					Disk:        lo.ToPtr(5000.0),
+					Samples:     1,
+				},
+				{
+					SubjectType: domain.MetricSubjectServer,
+					SubjectID:   1,
+					Resolution:  domain.MetricResolutionRaw,
+					RecordedAt:  time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC),
+					CPU:         lo.ToPtr(135.5),
+					RAM:         lo.ToPtr(3000.0),
+					Disk:        lo.ToPtr(5000.0),
+					Samples:     1,
+				},
+				{
+					SubjectType: domain.MetricSubjectServer,
+					SubjectID:   2,
+					Resolution:  domain.MetricResolutionRaw,
+					RecordedAt:  time.Date(2025, 3, 1, 9, 59, 50, 0, time.UTC),
+					RAM:         lo.ToPtr(512.0),
+					Samples:     1,
+				},
+			},
+		},
+		{
+			name:        "reported time defaults to now",
+			withSession: true,
+			requestBody: []map[string]any{
+				{"server_id": 2, "ram": 512},
+			},
+			expectedStatus: http.StatusOK,
+			validateUsages: func(t *testing.T, usages []domain.ServerResourceUsage) {
+				t.Helper()
+
+				require.Len(t, usages, 1)
+				assert.WithinDuration(t, time.Now(), usages[0].ReportedAt, time.Minute)
+			},
+		},
+		{
+			name:        "usage of servers from other nodes is ignored",
+			withSession: true,
+			requestBody: []map[string]any{
+				{"server_id": 3, "cpu": 10},
+				{"server_id": 999, "cpu": 10},
+			},
+			expectedStatus: http.StatusOK,
+			validateUsages: func(t *testing.T, usages []domain.ServerResourceUsage) {
+				t.Helper()
+
+				assert.Empty(t, usages)
+			},
+			wantMetrics: []domain.Metric{},
+		},
+		{
+			name:           "empty request",
+			withSession:    true,
+			requestBody:    []map[string]any{},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing server id",
+			withSession:    true,
+			requestBody:    []map[string]any{{"cpu": 10}},
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "server ID is required",
+		},
+		{
+			name:           "negative usage",
+			withSession:    true,
+			requestBody:    []map[string]any{{"server_id": 1, "ram": -1}},
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "resource usage can't be negative",
+		},
+		{
+			name:           "invalid json",
+			withSession:    true,
+			requestBody:    "{invalid",
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "invalid request",
+		},
+		{
+			name:           "no daemon session",
+			requestBody:    []map[string]any{{"server_id": 1, "cpu": 10}},
+			expectedStatus: http.StatusUnauthorized,
+			wantError:      "daemon session not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverRepo := inmemory.NewServerRepository()
+			resourceUsageRepo := inmemory.NewServerResourceUsageRepository()
+
+			for _, server := range []*domain.Server{
+				{ID: 1, UUID: uuid.New(), DSID: 1, RAMLimit: lo.ToPtr(2048), ServerIP: "127.0.0.1", ServerPort: 27015},
+				{ID: 2, UUID: uuid.New(), DSID: 1, ServerIP: "127.0.0.1", ServerPort: 27016},
+				{ID: 3, UUID: uuid.New(), DSID: 2, ServerIP: "127.0.0.2", ServerPort: 27015},
+			} {
+				require.NoError(t, serverRepo.Save(context.Background(), server))
+			}
+
+			metricRepo := inmemory.NewMetricRepository()
+			metricsService := metrics.NewService(metricRepo, metrics.Retention{})
+
+			handler := NewHandler(serverRepo, resourceUsageRepo, metricsService, api.NewResponder())
+
+			ctx := context.Background()
+			if tt.withSession {
+				ctx = auth.ContextWithDaemonSession(ctx, &auth.DaemonSession{
+					Node: &domain.Node{ID: 1, Enabled: true},
+				})
+			}
+
+			var body []byte
+			var err error
+			if str, ok := tt.requestBody.(string); ok {
+				body = []byte(str)
+			} else {
+				body, err = json.Marshal(tt.requestBody)
+				require.NoError(t, err)
+			}
+
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"/gdaemon_api/servers_resource_usage",
+				bytes.NewReader(body),
+			)
+			req = req.WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.wantError != "" {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "error", response["status"])
+				errorMsg, ok := response["error"].(string)
+				require.True(t, ok)
+				assert.Contains(t, errorMsg, tt.wantError)
+			} else {
+				var response resourceUsageResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "success", response.Message)
+			}
+
+			if tt.validateUsages != nil {
+				usages, err := resourceUsageRepo.Find(context.Background(), &filters.FindServerResourceUsage{}, nil, nil)
+				require.NoError(t, err)
+				tt.validateUsages(t, usages)
+			}
+
+			if tt.wantMetrics != nil {
+				points, err := metricRepo.Find(context.Background(), nil, nil, nil)
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantMetrics, points)
+			}
+		})
+	}
+}
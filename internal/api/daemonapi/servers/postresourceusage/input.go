@@ -0,0 +1,33 @@
+package postresourceusage
+
+import (
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/flexible"
+)
+
+var (
+	ErrInvalidServerID = api.NewValidationError("server ID is required")
+	ErrNegativeUsage   = api.NewValidationError("resource usage can't be negative")
+)
+
+type resourceUsageInput struct {
+	ServerID   uint           `json:"server_id"`
+	CPU        *float64       `json:"cpu,omitempty"`
+	RAM        *int           `json:"ram,omitempty"`
+	Net        *int           `json:"net,omitempty"`
+	Disk       *int           `json:"disk,omitempty"`
+	ReportedAt *flexible.Time `json:"reported_at,omitempty"`
+}
+
+func (in *resourceUsageInput) Validate() error {
+	if in.ServerID == 0 {
+		return ErrInvalidServerID
+	}
+
+	if (in.CPU != nil && *in.CPU < 0) || (in.RAM != nil && *in.RAM < 0) ||
+		(in.Net != nil && *in.Net < 0) || (in.Disk != nil && *in.Disk < 0) {
+		return ErrNegativeUsage
+	}
+
+	return nil
+}
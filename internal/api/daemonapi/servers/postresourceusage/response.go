@@ -0,0 +1,11 @@
+package postresourceusage
+
+type resourceUsageResponse struct {
+	Message string `json:"message"`
+}
+
+func newResourceUsageResponse() *resourceUsageResponse {
+	return &resourceUsageResponse{
+		Message: "success",
+	}
+}
@@ -1,11 +1,13 @@
 package putserver
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 
 	"github.com/gameap/gameap/internal/api/base"
 	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/events"
 	"github.com/gameap/gameap/internal/filters"
 	"github.com/gameap/gameap/internal/repositories"
 	"github.com/gameap/gameap/pkg/api"
@@ -13,18 +15,25 @@ import (
 	"github.com/pkg/errors"
 )
 
+type eventPublisher interface {
+	Publish(ctx context.Context, event domain.Event)
+}
+
 type Handler struct {
-	serverRepo repositories.ServerRepository
-	responder  base.Responder
+	serverRepo     repositories.ServerRepository
+	eventPublisher eventPublisher
+	responder      base.Responder
 }
 
 func NewHandler(
 	serverRepo repositories.ServerRepository,
+	eventPublisher eventPublisher,
 	responder base.Responder,
 ) *Handler {
 	return &Handler{
-		serverRepo: serverRepo,
-		responder:  responder,
+		serverRepo:     serverRepo,
+		eventPublisher: eventPublisher,
+		responder:      responder,
 	}
 }
 
@@ -101,6 +110,7 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	}
 
 	server := &servers[0]
+	wasOnline := server.IsOnline()
 
 	if input.Installed != nil {
 		server.Installed = domain.ServerInstalledStatus(*input.Installed)
@@ -124,5 +134,9 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if event, changed := events.ServerStatusChanged(server, wasOnline); changed {
+		h.eventPublisher.Publish(ctx, event)
+	}
+
 	h.responder.Write(ctx, rw, newUpdateServerResponse())
 }
@@ -4,12 +4,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/events"
 	"github.com/gameap/gameap/internal/repositories/inmemory"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
@@ -538,6 +540,7 @@ func TestHandler_ServeHTTP(t *testing.T) {
 
 			handler := NewHandler(
 				serverRepo,
+				events.NewBus(),
 				responder,
 			)
 
@@ -590,6 +593,7 @@ func TestHandler_ResponseStructure(t *testing.T) {
 
 	handler := NewHandler(
 		serverRepo,
+		events.NewBus(),
 		responder,
 	)
 
@@ -662,12 +666,58 @@ func TestHandler_ResponseStructure(t *testing.T) {
 	assert.Equal(t, "success", response.Message)
 }
 
+func TestHandler_PublishesStatusEvents(t *testing.T) {
+	serverRepo := inmemory.NewServerRepository()
+	bus := events.NewBus()
+	handler := NewHandler(serverRepo, bus, api.NewResponder())
+
+	var published []domain.Event
+	bus.Subscribe(events.HandlerFunc(func(_ context.Context, event domain.Event) error {
+		published = append(published, event)
+
+		return nil
+	}))
+
+	require.NoError(t, serverRepo.Save(context.Background(), &domain.Server{ID: 1, UUID: uuid.New(), DSID: 1}))
+
+	ctx := auth.ContextWithDaemonSession(context.Background(), &auth.DaemonSession{
+		Node: &domain.Node{ID: 1},
+	})
+
+	update := func(processActive int) {
+		body := fmt.Sprintf(
+			`{"process_active": %d, "last_process_check": %q}`,
+			processActive,
+			time.Now().UTC().Format(time.RFC3339),
+		)
+
+		req := httptest.NewRequest(http.MethodPut, "/gdaemon_api/servers/1", bytes.NewReader([]byte(body)))
+		req = req.WithContext(ctx)
+		req = mux.SetURLVars(req, map[string]string{"server": "1"})
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+	}
+
+	update(1)
+	update(1)
+	update(0)
+
+	types := lo.Map(published, func(event domain.Event, _ int) domain.EventType {
+		return event.Type
+	})
+	assert.Equal(t, []domain.EventType{domain.EventTypeServerOnline, domain.EventTypeServerOffline}, types)
+}
+
 func TestHandler_NewHandler(t *testing.T) {
 	serverRepo := inmemory.NewServerRepository()
 	responder := api.NewResponder()
 
 	handler := NewHandler(
 		serverRepo,
+		events.NewBus(),
 		responder,
 	)
 
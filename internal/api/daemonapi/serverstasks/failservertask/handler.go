@@ -1,12 +1,14 @@
 package failservertask
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"time"
 
 	"github.com/gameap/gameap/internal/api/base"
 	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/events"
 	"github.com/gameap/gameap/internal/filters"
 	"github.com/gameap/gameap/internal/repositories"
 	"github.com/gameap/gameap/pkg/api"
@@ -14,10 +16,15 @@ import (
 	"github.com/pkg/errors"
 )
 
+type eventPublisher interface {
+	Publish(ctx context.Context, event domain.Event)
+}
+
 type Handler struct {
 	serverTaskRepo     repositories.ServerTaskRepository
 	serverTaskFailRepo repositories.ServerTaskFailRepository
 	serverRepo         repositories.ServerRepository
+	eventPublisher     eventPublisher
 	responder          base.Responder
 }
 
@@ -25,12 +32,14 @@ func NewHandler(
 	serverTaskRepo repositories.ServerTaskRepository,
 	serverTaskFailRepo repositories.ServerTaskFailRepository,
 	serverRepo repositories.ServerRepository,
+	eventPublisher eventPublisher,
 	responder base.Responder,
 ) *Handler {
 	return &Handler{
 		serverTaskRepo:     serverTaskRepo,
 		serverTaskFailRepo: serverTaskFailRepo,
 		serverRepo:         serverRepo,
+		eventPublisher:     eventPublisher,
 		responder:          responder,
 	}
 }
@@ -127,5 +136,7 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.eventPublisher.Publish(ctx, events.ServerTaskFailed(&tasks[0], input.Output))
+
 	h.responder.Write(ctx, rw, newFailServerTaskResponse())
 }
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/events"
 	"github.com/gameap/gameap/internal/repositories/inmemory"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
@@ -377,7 +378,7 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			failRepo := inmemory.NewServerTaskFailRepository()
 			responder := api.NewResponder()
 
-			handler := NewHandler(taskRepo, failRepo, serverRepo, responder)
+			handler := NewHandler(taskRepo, failRepo, serverRepo, events.NewBus(), responder)
 
 			ctx := tt.setupContext(taskRepo, serverRepo)
 
@@ -421,7 +422,15 @@ func TestHandler_ResponseStructure(t *testing.T) {
 	failRepo := inmemory.NewServerTaskFailRepository()
 	responder := api.NewResponder()
 
-	handler := NewHandler(taskRepo, failRepo, serverRepo, responder)
+	bus := events.NewBus()
+	handler := NewHandler(taskRepo, failRepo, serverRepo, bus, responder)
+
+	var published []domain.Event
+	bus.Subscribe(events.HandlerFunc(func(_ context.Context, event domain.Event) error {
+		published = append(published, event)
+
+		return nil
+	}))
 
 	now := time.Now()
 	node := &domain.Node{
@@ -502,6 +511,17 @@ func TestHandler_ResponseStructure(t *testing.T) {
 	require.Len(t, fails, 1)
 	assert.Equal(t, uint(1), fails[0].ServerTaskID)
 	assert.Equal(t, "Server failed to stop: process not responding", fails[0].Output)
+
+	require.Len(t, published, 1)
+	assert.Equal(t, domain.EventTypeServerTaskFailed, published[0].Type)
+	assert.Equal(t, events.ServerTaskFailedData{
+		Task: events.ServerTask{
+			ID:       1,
+			ServerID: 10,
+			Command:  string(domain.ServerTaskCommandStop),
+		},
+		Output: "Server failed to stop: process not responding",
+	}, published[0].Data)
 }
 
 func TestHandler_NewHandler(t *testing.T) {
@@ -510,7 +530,7 @@ func TestHandler_NewHandler(t *testing.T) {
 	failRepo := inmemory.NewServerTaskFailRepository()
 	responder := api.NewResponder()
 
-	handler := NewHandler(taskRepo, failRepo, serverRepo, responder)
+	handler := NewHandler(taskRepo, failRepo, serverRepo, events.NewBus(), responder)
 
 	require.NotNil(t, handler)
 	assert.Equal(t, taskRepo, handler.serverTaskRepo)
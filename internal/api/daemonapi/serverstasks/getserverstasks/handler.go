@@ -63,6 +63,11 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 
 	response := make([]ServerTaskResponse, 0, len(tasks))
 	for i := range tasks {
+		// Such tasks are executed by the panel scheduler, the daemon doesn't know how to run them
+		if tasks[i].Command.ExecutedByPanel() {
+			continue
+		}
+
 		response = append(response, newServerTaskResponse(&tasks[i]))
 	}
 
@@ -90,6 +90,52 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			expectTasks:    1,
 		},
+		{
+			name: "tasks executed by panel are excluded",
+			setupContext: func(taskRepo *inmemory.ServerTaskRepository, serverRepo *inmemory.ServerRepository) context.Context {
+				now := time.Now()
+				node := &domain.Node{
+					ID:       1,
+					Enabled:  true,
+					Name:     "test-node",
+					OS:       "linux",
+					WorkPath: "/srv/gameap",
+				}
+
+				server := &domain.Server{
+					ID:        10,
+					Enabled:   true,
+					Installed: domain.ServerInstalledStatusInstalled,
+					Name:      "Test Server",
+					UUID:      uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"),
+					GameID:    "rust",
+					DSID:      1,
+					Dir:       "/srv/gameap/servers/server1",
+					CreatedAt: &now,
+					UpdatedAt: &now,
+				}
+				require.NoError(t, serverRepo.Save(context.Background(), server))
+
+				for _, command := range []domain.ServerTaskCommand{
+					domain.ServerTaskCommandRestart,
+					domain.ServerTaskCommandBackup,
+				} {
+					require.NoError(t, taskRepo.Save(context.Background(), &domain.ServerTask{
+						Command:     command,
+						ServerID:    10,
+						ExecuteDate: now.Add(time.Hour),
+						CreatedAt:   &now,
+						UpdatedAt:   &now,
+					}))
+				}
+
+				return auth.ContextWithDaemonSession(context.Background(), &auth.DaemonSession{
+					Node: node,
+				})
+			},
+			expectedStatus: http.StatusOK,
+			expectTasks:    1,
+		},
 		{
 			name: "multiple tasks for same node",
 			setupContext: func(taskRepo *inmemory.ServerTaskRepository, serverRepo *inmemory.ServerRepository) context.Context {
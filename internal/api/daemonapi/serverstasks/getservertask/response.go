@@ -35,7 +35,7 @@ func newServerTaskResponse(task *domain.ServerTask) ServerTaskResponse {
 		Command:      string(task.Command),
 		ServerID:     task.ServerID,
 		Repeat:       task.Repeat,
-		RepeatPeriod: int(task.RepeatPeriod.Seconds()),
+		RepeatPeriod: int(task.ApproximateRepeatPeriod().Seconds()),
 		Counter:      task.Counter,
 		ExecuteDate:  executeDate,
 		Payload:      task.Payload,
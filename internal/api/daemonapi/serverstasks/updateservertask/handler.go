@@ -3,6 +3,7 @@ package updateservertask
 import (
 	"context"
 	"encoding/json"
+	"log/slog"
 	"math"
 	"net/http"
 	"time"
@@ -157,4 +158,18 @@ func (h *Handler) updateTask(task *domain.ServerTask, input *updateServerTaskInp
 
 	now := time.Now()
 	task.UpdatedAt = &now
+
+	// The daemon schedules cron tasks with an approximate repeat period,
+	// so the next execution date is calculated from the cron expression.
+	if task.IsCron() {
+		schedule, err := task.Schedule()
+		if err != nil {
+			slog.Warn("failed to parse server task cron expression", "task_id", task.ID, "error", err)
+
+			return
+		}
+
+		task.RepeatPeriod = 0
+		task.ExecuteDate = schedule.Next(now)
+	}
 }
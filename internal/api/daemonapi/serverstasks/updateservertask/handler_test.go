@@ -15,6 +15,7 @@ import (
 	"github.com/gameap/gameap/pkg/auth"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -193,6 +194,45 @@ func TestHandler_ServeHTTP(t *testing.T) {
 				assert.Equal(t, 6, int(tasks[0].Counter))
 			},
 		},
+		{
+			name: "cron_task_execute_date_is_calculated_from_cron_expression",
+			setupContext: func(taskRepo *inmemory.ServerTaskRepository, serverRepo *inmemory.ServerRepository) context.Context {
+				now := time.Now()
+				node := makeTestNode(now)
+				server := makeTestServer(now, 1)
+				require.NoError(t, serverRepo.Save(context.Background(), server))
+
+				task := &domain.ServerTask{
+					Command:        domain.ServerTaskCommandRestart,
+					ServerID:       10,
+					ExecuteDate:    now,
+					CronExpression: lo.ToPtr("0 5 * * *"),
+					Timezone:       lo.ToPtr("UTC"),
+					CreatedAt:      &now,
+					UpdatedAt:      &now,
+				}
+				require.NoError(t, taskRepo.Save(context.Background(), task))
+
+				return makeDaemonContext(node)
+			},
+			taskID: "1",
+			requestBody: map[string]any{
+				"execute_date":  time.Now().Add(24 * time.Hour).Format(time.RFC3339),
+				"repeat_period": 86400,
+			},
+			expectedStatus: http.StatusOK,
+			validateServerTask: func(t *testing.T, taskRepo *inmemory.ServerTaskRepository, _ uint) {
+				t.Helper()
+				tasks, err := taskRepo.Find(context.Background(), nil, nil, nil)
+				require.NoError(t, err)
+				require.Len(t, tasks, 1)
+				assert.Equal(t, uint(1), tasks[0].Counter)
+				assert.Equal(t, time.Duration(0), tasks[0].RepeatPeriod)
+				assert.Equal(t, 5, tasks[0].ExecuteDate.UTC().Hour())
+				assert.Equal(t, 0, tasks[0].ExecuteDate.Minute())
+				assert.True(t, tasks[0].ExecuteDate.After(time.Now()))
+			},
+		},
 		{
 			name: "successful server task update with only required field",
 			setupContext: func(taskRepo *inmemory.ServerTaskRepository, serverRepo *inmemory.ServerRepository) context.Context {
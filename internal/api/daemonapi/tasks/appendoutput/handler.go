@@ -1,7 +1,9 @@
 package appendoutput
 
 import (
+	"context"
 	"encoding/json"
+	"log/slog"
 	"net/http"
 
 	"github.com/gameap/gameap/internal/api/base"
@@ -12,18 +14,25 @@ import (
 	"github.com/pkg/errors"
 )
 
+type outputPublisher interface {
+	PublishOutput(ctx context.Context, taskID uint, length int, output string) error
+}
+
 type Handler struct {
-	daemonTaskRepo repositories.DaemonTaskRepository
-	responder      base.Responder
+	daemonTaskRepo  repositories.DaemonTaskRepository
+	outputPublisher outputPublisher
+	responder       base.Responder
 }
 
 func NewHandler(
 	daemonTaskRepo repositories.DaemonTaskRepository,
+	outputPublisher outputPublisher,
 	responder base.Responder,
 ) *Handler {
 	return &Handler{
-		daemonTaskRepo: daemonTaskRepo,
-		responder:      responder,
+		daemonTaskRepo:  daemonTaskRepo,
+		outputPublisher: outputPublisher,
+		responder:       responder,
 	}
 }
 
@@ -98,7 +107,7 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.daemonTaskRepo.AppendOutput(ctx, taskID, input.Output)
+	length, err := h.daemonTaskRepo.AppendOutput(ctx, taskID, input.Output)
 	if err != nil {
 		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
 			errors.WithMessage(err, "failed to append output to daemon task"),
@@ -108,5 +117,18 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if input.Output != "" {
+		// Output is already saved, streaming clients catch up from the database
+		err = h.outputPublisher.PublishOutput(ctx, taskID, length, input.Output)
+		if err != nil {
+			slog.WarnContext(
+				ctx,
+				"Failed to publish daemon task output",
+				slog.Uint64("task_id", uint64(taskID)),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
 	h.responder.Write(ctx, rw, newAppendOutputResponse())
 }
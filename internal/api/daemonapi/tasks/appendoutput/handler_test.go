@@ -10,7 +10,9 @@ import (
 	"time"
 
 	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/pubsub"
 	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services/daemontaskoutput"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
 	"github.com/gorilla/mux"
@@ -370,8 +372,11 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			taskRepo := inmemory.NewDaemonTaskRepository()
 			responder := api.NewResponder()
 
+			broadcaster := daemontaskoutput.NewBroadcaster(pubsub.NewInMemory())
+
 			handler := NewHandler(
 				taskRepo,
+				broadcaster,
 				responder,
 			)
 
@@ -422,8 +427,11 @@ func TestHandler_ResponseStructure(t *testing.T) {
 	taskRepo := inmemory.NewDaemonTaskRepository()
 	responder := api.NewResponder()
 
+	broadcaster := daemontaskoutput.NewBroadcaster(pubsub.NewInMemory())
+
 	handler := NewHandler(
 		taskRepo,
+		broadcaster,
 		responder,
 	)
 
@@ -485,17 +493,63 @@ func TestHandler_ResponseStructure(t *testing.T) {
 	assert.Equal(t, "success", response.Message)
 }
 
+func TestHandler_PublishesOutput(t *testing.T) {
+	taskRepo := inmemory.NewDaemonTaskRepository()
+	broadcaster := daemontaskoutput.NewBroadcaster(pubsub.NewInMemory())
+	handler := NewHandler(taskRepo, broadcaster, api.NewResponder())
+
+	require.NoError(t, taskRepo.Save(context.Background(), &domain.DaemonTask{
+		ID:                1,
+		DedicatedServerID: 1,
+		Task:              domain.DaemonTaskTypeServerStart,
+		Status:            domain.DaemonTaskStatusWorking,
+		Output:            lo.ToPtr("Previous output\n"),
+	}))
+
+	sub, err := broadcaster.Subscribe(context.Background(), 1)
+	require.NoError(t, err)
+	defer sub.Close()
+
+	ctx := auth.ContextWithDaemonSession(context.Background(), &auth.DaemonSession{
+		Node: &domain.Node{ID: 1},
+	})
+
+	req := httptest.NewRequest(
+		http.MethodPut,
+		"/gdaemon_api/tasks/1/output",
+		bytes.NewReader([]byte(`{"output":"New output\n"}`)),
+	)
+	req = req.WithContext(ctx)
+	req = mux.SetURLVars(req, map[string]string{"gdaemon_task": "1"})
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	select {
+	case event := <-sub.Events():
+		assert.Equal(t, daemontaskoutput.Event{Offset: 16, Output: "New output\n"}, event)
+	case <-time.After(time.Second):
+		require.Fail(t, "timeout waiting for daemon task output event")
+	}
+}
+
 func TestHandler_NewHandler(t *testing.T) {
 	taskRepo := inmemory.NewDaemonTaskRepository()
 	responder := api.NewResponder()
 
+	broadcaster := daemontaskoutput.NewBroadcaster(pubsub.NewInMemory())
+
 	handler := NewHandler(
 		taskRepo,
+		broadcaster,
 		responder,
 	)
 
 	require.NotNil(t, handler)
 	assert.Equal(t, taskRepo, handler.daemonTaskRepo)
+	assert.Equal(t, broadcaster, handler.outputPublisher)
 	assert.Equal(t, responder, handler.responder)
 }
 
@@ -1,6 +1,7 @@
 package gettask
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/gameap/gameap/internal/api/base"
@@ -10,6 +11,7 @@ import (
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
 	"github.com/pkg/errors"
+	"github.com/samber/lo"
 )
 
 type Handler struct {
@@ -55,6 +57,16 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	tasks, err = h.excludePanelTasks(ctx, tasks)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "failed to find daemon tasks"),
+			http.StatusInternalServerError,
+		))
+
+		return
+	}
+
 	response := make([]TaskResponse, 0, len(tasks))
 	for i := range tasks {
 		response = append(response, newTaskResponse(&tasks[i]))
@@ -63,6 +75,51 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	h.responder.Write(ctx, rw, response)
 }
 
+// excludePanelTasks removes tasks executed by the panel, such as server moves.
+// Tasks running after an unfinished panel task are removed too,
+// otherwise the daemon would run them without waiting for the panel task.
+func (h *Handler) excludePanelTasks(ctx context.Context, tasks []domain.DaemonTask) ([]domain.DaemonTask, error) {
+	tasks = lo.Reject(tasks, func(task domain.DaemonTask, _ int) bool {
+		return task.Task.ExecutedByPanel()
+	})
+
+	runAftIDs := lo.Uniq(lo.FilterMap(tasks, func(task domain.DaemonTask, _ int) (uint, bool) {
+		if task.RunAftID == nil || *task.RunAftID == 0 {
+			return 0, false
+		}
+
+		return *task.RunAftID, true
+	}))
+
+	if len(runAftIDs) == 0 {
+		return tasks, nil
+	}
+
+	prevTasks, err := h.daemonTaskRepo.Find(ctx, &filters.FindDaemonTask{
+		IDs: runAftIDs,
+	}, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	unfinished := make(map[uint]struct{}, len(prevTasks))
+	for _, task := range prevTasks {
+		if task.Task.ExecutedByPanel() && task.Status != domain.DaemonTaskStatusSuccess {
+			unfinished[task.ID] = struct{}{}
+		}
+	}
+
+	return lo.Reject(tasks, func(task domain.DaemonTask, _ int) bool {
+		if task.RunAftID == nil {
+			return false
+		}
+
+		_, ok := unfinished[*task.RunAftID]
+
+		return ok
+	}), nil
+}
+
 func parseFilters(r *http.Request) *filters.FindDaemonTask {
 	query := r.URL.Query()
 
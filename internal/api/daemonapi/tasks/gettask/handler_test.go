@@ -240,6 +240,54 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			expectTasks:    1,
 		},
+		{
+			name: "excludes panel tasks and tasks waiting for them",
+			setupContext: func(taskRepo *inmemory.DaemonTaskRepository) context.Context {
+				now := time.Now()
+				serverID := uint(10)
+
+				moveTask := &domain.DaemonTask{
+					DedicatedServerID: 1,
+					ServerID:          &serverID,
+					Task:              domain.DaemonTaskTypeServerMove,
+					Status:            domain.DaemonTaskStatusWaiting,
+					CreatedAt:         &now,
+					UpdatedAt:         &now,
+				}
+				require.NoError(t, taskRepo.Save(context.Background(), moveTask))
+
+				startTask := &domain.DaemonTask{
+					RunAftID:          &moveTask.ID,
+					DedicatedServerID: 1,
+					ServerID:          &serverID,
+					Task:              domain.DaemonTaskTypeServerStart,
+					Status:            domain.DaemonTaskStatusWaiting,
+					CreatedAt:         &now,
+					UpdatedAt:         &now,
+				}
+				require.NoError(t, taskRepo.Save(context.Background(), startTask))
+
+				otherServerID := uint(11)
+				stopTask := &domain.DaemonTask{
+					DedicatedServerID: 1,
+					ServerID:          &otherServerID,
+					Task:              domain.DaemonTaskTypeServerStop,
+					Status:            domain.DaemonTaskStatusWaiting,
+					CreatedAt:         &now,
+					UpdatedAt:         &now,
+				}
+				require.NoError(t, taskRepo.Save(context.Background(), stopTask))
+
+				daemonSession := &auth.DaemonSession{
+					Node: &domain.Node{ID: 1},
+				}
+
+				return auth.ContextWithDaemonSession(context.Background(), daemonSession)
+			},
+			setupQuery:     "",
+			expectedStatus: http.StatusOK,
+			expectTasks:    1,
+		},
 		{
 			name: "daemon session not found",
 			setupContext: func(_ *inmemory.DaemonTaskRepository) context.Context {
@@ -1,10 +1,14 @@
 package updatetask
 
 import (
+	"context"
 	"encoding/json"
+	"log/slog"
 	"net/http"
 
 	"github.com/gameap/gameap/internal/api/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/events"
 	"github.com/gameap/gameap/internal/filters"
 	"github.com/gameap/gameap/internal/repositories"
 	"github.com/gameap/gameap/pkg/api"
@@ -12,18 +16,32 @@ import (
 	"github.com/pkg/errors"
 )
 
+type statusPublisher interface {
+	PublishStatus(ctx context.Context, taskID uint, status domain.DaemonTaskStatus) error
+}
+
+type eventPublisher interface {
+	Publish(ctx context.Context, event domain.Event)
+}
+
 type Handler struct {
-	daemonTaskRepo repositories.DaemonTaskRepository
-	responder      base.Responder
+	daemonTaskRepo  repositories.DaemonTaskRepository
+	statusPublisher statusPublisher
+	eventPublisher  eventPublisher
+	responder       base.Responder
 }
 
 func NewHandler(
 	daemonTaskRepo repositories.DaemonTaskRepository,
+	statusPublisher statusPublisher,
+	eventPublisher eventPublisher,
 	responder base.Responder,
 ) *Handler {
 	return &Handler{
-		daemonTaskRepo: daemonTaskRepo,
-		responder:      responder,
+		daemonTaskRepo:  daemonTaskRepo,
+		statusPublisher: statusPublisher,
+		eventPublisher:  eventPublisher,
+		responder:       responder,
 	}
 }
 
@@ -99,6 +117,7 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	}
 
 	task := &tasks[0]
+	previousStatus := task.Status
 
 	task.Status = input.ToStatus()
 
@@ -112,5 +131,23 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	err = h.statusPublisher.PublishStatus(ctx, taskID, task.Status)
+	if err != nil {
+		slog.WarnContext(
+			ctx,
+			"Failed to publish daemon task status",
+			slog.Uint64("task_id", uint64(taskID)),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	if task.Status != previousStatus {
+		h.eventPublisher.Publish(ctx, events.DaemonTaskStatusChanged(task, previousStatus))
+
+		if event, ok := events.ServerUpdateFinished(task); ok {
+			h.eventPublisher.Publish(ctx, event)
+		}
+	}
+
 	h.responder.Write(ctx, rw, newUpdateTaskResponse())
 }
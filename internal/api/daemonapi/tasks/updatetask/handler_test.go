@@ -10,7 +10,10 @@ import (
 	"time"
 
 	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/events"
+	"github.com/gameap/gameap/internal/pubsub"
 	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services/daemontaskoutput"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
 	"github.com/gameap/gameap/pkg/flexible"
@@ -507,8 +510,12 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			taskRepo := inmemory.NewDaemonTaskRepository()
 			responder := api.NewResponder()
 
+			broadcaster := daemontaskoutput.NewBroadcaster(pubsub.NewInMemory())
+
 			handler := NewHandler(
 				taskRepo,
+				broadcaster,
+				events.NewBus(),
 				responder,
 			)
 
@@ -559,8 +566,12 @@ func TestHandler_ResponseStructure(t *testing.T) {
 	taskRepo := inmemory.NewDaemonTaskRepository()
 	responder := api.NewResponder()
 
+	broadcaster := daemontaskoutput.NewBroadcaster(pubsub.NewInMemory())
+
 	handler := NewHandler(
 		taskRepo,
+		broadcaster,
+		events.NewBus(),
 		responder,
 	)
 
@@ -622,17 +633,152 @@ func TestHandler_ResponseStructure(t *testing.T) {
 	assert.Equal(t, "success", response.Message)
 }
 
+func TestHandler_PublishesStatus(t *testing.T) {
+	taskRepo := inmemory.NewDaemonTaskRepository()
+	broadcaster := daemontaskoutput.NewBroadcaster(pubsub.NewInMemory())
+	handler := NewHandler(taskRepo, broadcaster, events.NewBus(), api.NewResponder())
+
+	require.NoError(t, taskRepo.Save(context.Background(), &domain.DaemonTask{
+		ID:                1,
+		DedicatedServerID: 1,
+		Task:              domain.DaemonTaskTypeServerStart,
+		Status:            domain.DaemonTaskStatusWorking,
+	}))
+
+	sub, err := broadcaster.Subscribe(context.Background(), 1)
+	require.NoError(t, err)
+	defer sub.Close()
+
+	ctx := auth.ContextWithDaemonSession(context.Background(), &auth.DaemonSession{
+		Node: &domain.Node{ID: 1},
+	})
+
+	req := httptest.NewRequest(
+		http.MethodPut,
+		"/gdaemon_api/tasks/1",
+		bytes.NewReader([]byte(`{"status":4}`)),
+	)
+	req = req.WithContext(ctx)
+	req = mux.SetURLVars(req, map[string]string{"gdaemon_task": "1"})
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	select {
+	case event := <-sub.Events():
+		assert.Equal(t, daemontaskoutput.Event{Status: domain.DaemonTaskStatusSuccess}, event)
+	case <-time.After(time.Second):
+		require.Fail(t, "timeout waiting for daemon task status event")
+	}
+}
+
+func TestHandler_PublishesStatusChangedEvent(t *testing.T) {
+	taskRepo := inmemory.NewDaemonTaskRepository()
+	bus := events.NewBus()
+	handler := NewHandler(taskRepo, daemontaskoutput.NewBroadcaster(pubsub.NewInMemory()), bus, api.NewResponder())
+
+	var published []domain.Event
+	bus.Subscribe(events.HandlerFunc(func(_ context.Context, event domain.Event) error {
+		published = append(published, event)
+
+		return nil
+	}))
+
+	serverID := uint(10)
+	require.NoError(t, taskRepo.Save(context.Background(), &domain.DaemonTask{
+		ID:                1,
+		DedicatedServerID: 1,
+		ServerID:          &serverID,
+		Task:              domain.DaemonTaskTypeServerStart,
+		Status:            domain.DaemonTaskStatusWorking,
+	}))
+
+	ctx := auth.ContextWithDaemonSession(context.Background(), &auth.DaemonSession{
+		Node: &domain.Node{ID: 1},
+	})
+
+	update := func(body string) {
+		req := httptest.NewRequest(http.MethodPut, "/gdaemon_api/tasks/1", bytes.NewReader([]byte(body)))
+		req = req.WithContext(ctx)
+		req = mux.SetURLVars(req, map[string]string{"gdaemon_task": "1"})
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+	}
+
+	update(`{"status":4}`)
+	// The same status again is not a change
+	update(`{"status":4}`)
+
+	require.Len(t, published, 1)
+	assert.Equal(t, domain.EventTypeDaemonTaskStatusChanged, published[0].Type)
+	assert.Equal(t, &serverID, published[0].ServerID)
+
+	data, ok := published[0].Data.(events.DaemonTaskStatusChangedData)
+	require.True(t, ok)
+	assert.Equal(t, string(domain.DaemonTaskStatusWorking), data.PreviousStatus)
+	assert.Equal(t, string(domain.DaemonTaskStatusSuccess), data.Task.Status)
+	assert.Equal(t, string(domain.DaemonTaskTypeServerStart), data.Task.Task)
+}
+
+func TestHandler_PublishesServerUpdateFinishedEvent(t *testing.T) {
+	taskRepo := inmemory.NewDaemonTaskRepository()
+	bus := events.NewBus()
+	handler := NewHandler(taskRepo, daemontaskoutput.NewBroadcaster(pubsub.NewInMemory()), bus, api.NewResponder())
+
+	var published []domain.EventType
+	bus.Subscribe(events.HandlerFunc(func(_ context.Context, event domain.Event) error {
+		published = append(published, event.Type)
+
+		return nil
+	}))
+
+	require.NoError(t, taskRepo.Save(context.Background(), &domain.DaemonTask{
+		ID:                1,
+		DedicatedServerID: 1,
+		ServerID:          lo.ToPtr(uint(10)),
+		Task:              domain.DaemonTaskTypeServerUpdate,
+		Status:            domain.DaemonTaskStatusWorking,
+	}))
+
+	ctx := auth.ContextWithDaemonSession(context.Background(), &auth.DaemonSession{
+		Node: &domain.Node{ID: 1},
+	})
+
+	req := httptest.NewRequest(http.MethodPut, "/gdaemon_api/tasks/1", bytes.NewReader([]byte(`{"status":3}`)))
+	req = req.WithContext(ctx)
+	req = mux.SetURLVars(req, map[string]string{"gdaemon_task": "1"})
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []domain.EventType{
+		domain.EventTypeDaemonTaskStatusChanged,
+		domain.EventTypeServerUpdateFailed,
+	}, published)
+}
+
 func TestHandler_NewHandler(t *testing.T) {
 	taskRepo := inmemory.NewDaemonTaskRepository()
 	responder := api.NewResponder()
 
+	broadcaster := daemontaskoutput.NewBroadcaster(pubsub.NewInMemory())
+
 	handler := NewHandler(
 		taskRepo,
+		broadcaster,
+		events.NewBus(),
 		responder,
 	)
 
 	require.NotNil(t, handler)
 	assert.Equal(t, taskRepo, handler.daemonTaskRepo)
+	assert.Equal(t, broadcaster, handler.statusPublisher)
 	assert.Equal(t, responder, handler.responder)
 }
 
@@ -0,0 +1,355 @@
+package getdaemontaskoutputstream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gameap/gameap/internal/api/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/daemontaskoutput"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+)
+
+const (
+	writeWait = 10 * time.Second
+
+	// defaultCheckInterval is the interval of keep-alive comments and task status checks.
+	// Status is checked in case the status event was missed.
+	defaultCheckInterval = 15 * time.Second
+)
+
+type outputSubscriber interface {
+	Subscribe(ctx context.Context, taskID uint) (*daemontaskoutput.Subscription, error)
+}
+
+// Handler streams daemon task output using Server-Sent Events.
+// Output chunks are pushed as the daemon appends them.
+// Every output event id is the output offset in bytes after the chunk,
+// so the client can resume from it with the Last-Event-ID header or the offset query parameter.
+// The stream is closed after the task is finished.
+type Handler struct {
+	daemonTasksRepo  repositories.DaemonTaskRepository
+	outputSubscriber outputSubscriber
+	responder        base.Responder
+	checkInterval    time.Duration
+}
+
+func NewHandler(
+	daemonTasksRepo repositories.DaemonTaskRepository,
+	outputSubscriber outputSubscriber,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		daemonTasksRepo:  daemonTasksRepo,
+		outputSubscriber: outputSubscriber,
+		responder:        responder,
+		checkInterval:    defaultCheckInterval,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	taskID, err := api.NewInputReader(r).ReadUint("id")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid task id"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	offset, err := readOffset(r)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(err, http.StatusBadRequest))
+
+		return
+	}
+
+	// Subscribe before reading the task output, so no chunk is lost in between
+	sub, err := h.outputSubscriber.Subscribe(ctx, taskID)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+	defer func() {
+		_ = sub.Close()
+	}()
+
+	task, err := h.findTask(ctx, taskID, true)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	s := &stream{
+		rw:     rw,
+		rc:     http.NewResponseController(rw),
+		taskID: taskID,
+		offset: offset,
+	}
+
+	rw.Header().Set("Content-Type", "text/event-stream")
+	rw.Header().Set("Cache-Control", "no-cache")
+	rw.Header().Set("Connection", "keep-alive")
+	rw.Header().Set("X-Accel-Buffering", "no")
+	rw.WriteHeader(http.StatusOK)
+
+	if err = s.flush(); err != nil {
+		return
+	}
+
+	h.run(ctx, s, sub, task)
+}
+
+func (h *Handler) run(
+	ctx context.Context,
+	s *stream,
+	sub *daemontaskoutput.Subscription,
+	task *domain.DaemonTask,
+) {
+	if err := s.writeTaskOutput(task); err != nil {
+		return
+	}
+
+	if isFinished(task.Status) {
+		_ = s.writeStatus(task.Status)
+
+		return
+	}
+
+	ticker := time.NewTicker(h.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		var err error
+
+		select {
+		case <-ctx.Done():
+			return
+
+		case event, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+
+			switch {
+			case event.Status != "":
+				if !isFinished(event.Status) {
+					continue
+				}
+
+				h.finish(ctx, s, event.Status)
+
+				return
+
+			case event.Offset > s.offset:
+				// Some chunks were missed, catch up from the database
+				err = h.catchUp(ctx, s)
+
+			default:
+				err = s.writeOutput(event)
+			}
+
+		case <-ticker.C:
+			var t *domain.DaemonTask
+
+			t, err = h.findTask(ctx, task.ID, false)
+			if err == nil && isFinished(t.Status) {
+				h.finish(ctx, s, t.Status)
+
+				return
+			}
+
+			if err == nil {
+				err = s.writeComment("ping")
+			}
+		}
+
+		if err != nil {
+			if ctx.Err() == nil {
+				slog.DebugContext(
+					ctx,
+					"Daemon task output stream closed",
+					slog.Uint64("task_id", uint64(task.ID)),
+					slog.String("error", err.Error()),
+				)
+			}
+
+			return
+		}
+	}
+}
+
+// finish sends the rest of the output and the final task status.
+func (h *Handler) finish(ctx context.Context, s *stream, status domain.DaemonTaskStatus) {
+	if err := h.catchUp(ctx, s); err != nil {
+		return
+	}
+
+	_ = s.writeStatus(status)
+}
+
+func (h *Handler) catchUp(ctx context.Context, s *stream) error {
+	task, err := h.findTask(ctx, s.taskID, true)
+	if err != nil {
+		return err
+	}
+
+	return s.writeTaskOutput(task)
+}
+
+func (h *Handler) findTask(ctx context.Context, taskID uint, withOutput bool) (*domain.DaemonTask, error) {
+	filter := &filters.FindDaemonTask{
+		IDs: []uint{taskID},
+	}
+	pagination := &filters.Pagination{
+		Limit: 1,
+	}
+
+	var tasks []domain.DaemonTask
+	var err error
+
+	if withOutput {
+		tasks, err = h.daemonTasksRepo.FindWithOutput(ctx, filter, nil, pagination)
+	} else {
+		tasks, err = h.daemonTasksRepo.Find(ctx, filter, nil, pagination)
+	}
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find daemon task")
+	}
+
+	if len(tasks) == 0 {
+		return nil, api.NewNotFoundError("daemon task not found")
+	}
+
+	return &tasks[0], nil
+}
+
+func isFinished(status domain.DaemonTaskStatus) bool {
+	switch status {
+	case domain.DaemonTaskStatusSuccess, domain.DaemonTaskStatusError, domain.DaemonTaskStatusCanceled:
+		return true
+	default:
+		return false
+	}
+}
+
+type stream struct {
+	rw http.ResponseWriter
+	rc *http.ResponseController
+
+	taskID uint
+
+	// offset is the position in the task output in bytes the client has received output up to.
+	offset int
+}
+
+// writeTaskOutput sends the task output the client has not received yet.
+func (s *stream) writeTaskOutput(task *domain.DaemonTask) error {
+	var output string
+	if task.Output != nil {
+		output = *task.Output
+	}
+
+	if s.offset > len(output) {
+		// The client is ahead of the stored output, nothing to send
+		return nil
+	}
+
+	return s.writeOutput(daemontaskoutput.Event{
+		Offset: s.offset,
+		Output: output[s.offset:],
+	})
+}
+
+// writeOutput sends the part of the output chunk the client has not received yet.
+func (s *stream) writeOutput(event daemontaskoutput.Event) error {
+	if event.End() <= s.offset {
+		return nil
+	}
+
+	output := event.Output[s.offset-event.Offset:]
+
+	err := s.writeEvent(eventOutput, strconv.Itoa(event.End()), outputResponse{
+		Offset: s.offset,
+		Output: output,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.offset = event.End()
+
+	return nil
+}
+
+func (s *stream) writeStatus(status domain.DaemonTaskStatus) error {
+	return s.writeEvent(eventStatus, "", statusResponse{
+		Status: status,
+	})
+}
+
+func (s *stream) writeEvent(name, id string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return errors.WithMessage(err, "failed to marshal event data")
+	}
+
+	_ = s.rc.SetWriteDeadline(time.Now().Add(writeWait))
+
+	if id != "" {
+		_, err = fmt.Fprintf(s.rw, "id: %s\n", id)
+		if err != nil {
+			return errors.WithMessage(err, "failed to write event")
+		}
+	}
+
+	_, err = fmt.Fprintf(s.rw, "event: %s\ndata: %s\n\n", name, payload)
+	if err != nil {
+		return errors.WithMessage(err, "failed to write event")
+	}
+
+	return s.flush()
+}
+
+func (s *stream) writeComment(comment string) error {
+	_ = s.rc.SetWriteDeadline(time.Now().Add(writeWait))
+
+	_, err := fmt.Fprintf(s.rw, ": %s\n\n", comment)
+	if err != nil {
+		return errors.WithMessage(err, "failed to write comment")
+	}
+
+	return s.flush()
+}
+
+func (s *stream) flush() error {
+	if err := s.rc.Flush(); err != nil {
+		return errors.WithMessage(err, "failed to flush response")
+	}
+
+	return nil
+}
@@ -0,0 +1,372 @@
+package getdaemontaskoutputstream
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/pubsub"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services/daemontaskoutput"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sseEvent struct {
+	ID   string
+	Name string
+	Data string
+}
+
+func parseEvents(t *testing.T, body string) []sseEvent {
+	t.Helper()
+
+	var events []sseEvent
+
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for {
+		event, ok := scanEvent(scanner)
+		if !ok {
+			break
+		}
+		events = append(events, event)
+	}
+
+	return events
+}
+
+func scanEvent(scanner *bufio.Scanner) (sseEvent, bool) {
+	var event sseEvent
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case line == "":
+			if event.Name != "" {
+				return event, true
+			}
+		case strings.HasPrefix(line, "id: "):
+			event.ID = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			event.Name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			event.Data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+
+	return event, false
+}
+
+func outputEvent(t *testing.T, id string, offset int, output string) sseEvent {
+	t.Helper()
+
+	data, err := json.Marshal(outputResponse{Offset: offset, Output: output})
+	require.NoError(t, err)
+
+	return sseEvent{ID: id, Name: eventOutput, Data: string(data)}
+}
+
+func statusEvent(status domain.DaemonTaskStatus) sseEvent {
+	return sseEvent{Name: eventStatus, Data: `{"status":"` + string(status) + `"}`}
+}
+
+func newTestRequest(ctx context.Context, taskID, query string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/api/gdaemon_tasks/"+taskID+"/output/stream"+query, nil)
+
+	return mux.SetURLVars(req.WithContext(ctx), map[string]string{"id": taskID})
+}
+
+func authenticatedContext() context.Context {
+	return auth.ContextWithSession(context.Background(), &auth.Session{
+		User: &domain.User{ID: 1},
+	})
+}
+
+func TestHandler_ServeHTTP_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		ctx            context.Context
+		taskID         string
+		query          string
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "not authenticated",
+			ctx:            context.Background(),
+			taskID:         "1",
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "user not authenticated",
+		},
+		{
+			name:           "invalid task id",
+			ctx:            authenticatedContext(),
+			taskID:         "invalid",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid task id",
+		},
+		{
+			name:           "invalid offset",
+			ctx:            authenticatedContext(),
+			taskID:         "1",
+			query:          "?offset=abc",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid offset",
+		},
+		{
+			name:           "negative offset",
+			ctx:            authenticatedContext(),
+			taskID:         "1",
+			query:          "?offset=-1",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "offset must not be negative",
+		},
+		{
+			name:           "task not found",
+			ctx:            authenticatedContext(),
+			taskID:         "999",
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "daemon task not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHandler(
+				inmemory.NewDaemonTaskRepository(),
+				daemontaskoutput.NewBroadcaster(pubsub.NewInMemory()),
+				api.NewResponder(),
+			)
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, newTestRequest(tt.ctx, tt.taskID, tt.query))
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, "error", response["status"])
+			assert.Contains(t, response["error"], tt.expectedError)
+		})
+	}
+}
+
+func TestHandler_ServeHTTP_FinishedTask(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		lastEventID    string
+		expectedEvents func(t *testing.T) []sseEvent
+	}{
+		{
+			name: "whole output",
+			expectedEvents: func(t *testing.T) []sseEvent {
+				t.Helper()
+
+				return []sseEvent{
+					outputEvent(t, "14", 0, "line 1\nline 2\n"),
+					statusEvent(domain.DaemonTaskStatusSuccess),
+				}
+			},
+		},
+		{
+			name:  "resume from offset query parameter",
+			query: "?offset=7",
+			expectedEvents: func(t *testing.T) []sseEvent {
+				t.Helper()
+
+				return []sseEvent{
+					outputEvent(t, "14", 7, "line 2\n"),
+					statusEvent(domain.DaemonTaskStatusSuccess),
+				}
+			},
+		},
+		{
+			name:        "resume from last event id",
+			lastEventID: "7",
+			expectedEvents: func(t *testing.T) []sseEvent {
+				t.Helper()
+
+				return []sseEvent{
+					outputEvent(t, "14", 7, "line 2\n"),
+					statusEvent(domain.DaemonTaskStatusSuccess),
+				}
+			},
+		},
+		{
+			name:        "everything received",
+			lastEventID: "14",
+			expectedEvents: func(_ *testing.T) []sseEvent {
+				return []sseEvent{
+					statusEvent(domain.DaemonTaskStatusSuccess),
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := inmemory.NewDaemonTaskRepository()
+			require.NoError(t, repo.Save(context.Background(), &domain.DaemonTask{
+				ID:                1,
+				DedicatedServerID: 1,
+				Task:              domain.DaemonTaskTypeServerInstall,
+				Status:            domain.DaemonTaskStatusSuccess,
+				Output:            lo.ToPtr("line 1\nline 2\n"),
+			}))
+
+			handler := NewHandler(
+				repo,
+				daemontaskoutput.NewBroadcaster(pubsub.NewInMemory()),
+				api.NewResponder(),
+			)
+
+			req := newTestRequest(authenticatedContext(), "1", tt.query)
+			if tt.lastEventID != "" {
+				req.Header.Set(lastEventIDHeader, tt.lastEventID)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+			assert.Equal(t, tt.expectedEvents(t), parseEvents(t, rec.Body.String()))
+		})
+	}
+}
+
+func TestHandler_ServeHTTP_StreamsOutput(t *testing.T) {
+	ctx := context.Background()
+	repo := inmemory.NewDaemonTaskRepository()
+	broadcaster := daemontaskoutput.NewBroadcaster(pubsub.NewInMemory())
+
+	require.NoError(t, repo.Save(ctx, &domain.DaemonTask{
+		ID:                1,
+		DedicatedServerID: 1,
+		Task:              domain.DaemonTaskTypeServerInstall,
+		Status:            domain.DaemonTaskStatusWorking,
+		Output:            lo.ToPtr("line 1\n"),
+	}))
+
+	handler := NewHandler(repo, broadcaster, api.NewResponder())
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		r = mux.SetURLVars(r.WithContext(authenticatedContext()), map[string]string{"id": "1"})
+
+		handler.ServeHTTP(rw, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	resp, err := http.Get(srv.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	events := make(chan sseEvent)
+	go func() {
+		defer close(events)
+
+		scanner := bufio.NewScanner(resp.Body)
+		for {
+			event, ok := scanEvent(scanner)
+			if !ok {
+				return
+			}
+			events <- event
+		}
+	}()
+
+	next := func() sseEvent {
+		t.Helper()
+
+		select {
+		case event, ok := <-events:
+			require.True(t, ok, "stream is closed")
+
+			return event
+		case <-time.After(time.Second):
+			require.Fail(t, "timeout waiting for stream event")
+		}
+
+		return sseEvent{}
+	}
+
+	appendOutput := func(output string, publish bool) {
+		t.Helper()
+
+		length, err := repo.AppendOutput(ctx, 1, output)
+		require.NoError(t, err)
+
+		if publish {
+			require.NoError(t, broadcaster.PublishOutput(ctx, 1, length, output))
+		}
+	}
+
+	assert.Equal(t, outputEvent(t, "7", 0, "line 1\n"), next())
+
+	appendOutput("line 2\n", true)
+	assert.Equal(t, outputEvent(t, "14", 7, "line 2\n"), next())
+
+	// Duplicated chunk is skipped
+	require.NoError(t, broadcaster.PublishOutput(ctx, 1, 14, "line 2\n"))
+
+	// Missed chunk is read from the database
+	appendOutput("line 3\n", false)
+	appendOutput("line 4\n", true)
+	assert.Equal(t, outputEvent(t, "28", 14, "line 3\nline 4\n"), next())
+
+	appendOutput("done\n", false)
+	require.NoError(t, broadcaster.PublishStatus(ctx, 1, domain.DaemonTaskStatusSuccess))
+	assert.Equal(t, outputEvent(t, "33", 28, "done\n"), next())
+	assert.Equal(t, statusEvent(domain.DaemonTaskStatusSuccess), next())
+
+	select {
+	case _, ok := <-events:
+		assert.False(t, ok, "stream must be closed after the task is finished")
+	case <-time.After(time.Second):
+		require.Fail(t, "timeout waiting for stream to be closed")
+	}
+}
+
+func TestHandler_ServeHTTP_ChecksStatus(t *testing.T) {
+	ctx := context.Background()
+	repo := inmemory.NewDaemonTaskRepository()
+
+	task := &domain.DaemonTask{
+		ID:                1,
+		DedicatedServerID: 1,
+		Task:              domain.DaemonTaskTypeServerInstall,
+		Status:            domain.DaemonTaskStatusWorking,
+	}
+	require.NoError(t, repo.Save(ctx, task))
+
+	handler := NewHandler(repo, daemontaskoutput.NewBroadcaster(pubsub.NewInMemory()), api.NewResponder())
+	handler.checkInterval = 10 * time.Millisecond
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+
+		task.Status = domain.DaemonTaskStatusError
+		_ = repo.Save(ctx, task)
+	}()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newTestRequest(authenticatedContext(), "1", ""))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), ": ping\n\n")
+	assert.Equal(t, []sseEvent{statusEvent(domain.DaemonTaskStatusError)}, parseEvents(t, rec.Body.String()))
+}
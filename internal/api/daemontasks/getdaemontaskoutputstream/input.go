@@ -0,0 +1,40 @@
+package getdaemontaskoutputstream
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+const lastEventIDHeader = "Last-Event-ID"
+
+// readOffset reads the output offset to resume streaming from.
+// The offset query parameter takes precedence over the Last-Event-ID header
+// sent by EventSource on reconnect.
+func readOffset(r *http.Request) (int, error) {
+	value, err := api.NewQueryReader(r).ReadString("offset")
+	if err != nil {
+		return 0, errors.WithMessage(err, "failed to read offset")
+	}
+
+	if value == "" {
+		value = r.Header.Get(lastEventIDHeader)
+	}
+
+	if value == "" {
+		return 0, nil
+	}
+
+	offset, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errors.WithMessage(err, "invalid offset")
+	}
+
+	if offset < 0 {
+		return 0, errors.New("offset must not be negative")
+	}
+
+	return offset, nil
+}
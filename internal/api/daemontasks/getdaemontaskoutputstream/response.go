@@ -0,0 +1,19 @@
+package getdaemontaskoutputstream
+
+import (
+	"github.com/gameap/gameap/internal/domain"
+)
+
+const (
+	eventOutput = "output"
+	eventStatus = "status"
+)
+
+type outputResponse struct {
+	Offset int    `json:"offset"`
+	Output string `json:"output"`
+}
+
+type statusResponse struct {
+	Status domain.DaemonTaskStatus `json:"status"`
+}
@@ -15,6 +15,8 @@ type gameResponse struct {
 	RemoteRepositoryWindows *string `json:"remote_repository_windows"`
 	LocalRepositoryWindows  *string `json:"local_repository_windows"`
 	Enabled                 bool    `json:"enabled"`
+	QueryPortOffset         int     `json:"query_port_offset"`
+	RconPortOffset          int     `json:"rcon_port_offset"`
 }
 
 func newGameResponseFromGame(g *domain.Game) gameResponse {
@@ -31,5 +33,7 @@ func newGameResponseFromGame(g *domain.Game) gameResponse {
 		RemoteRepositoryWindows: g.RemoteRepositoryWindows,
 		LocalRepositoryWindows:  g.LocalRepositoryWindows,
 		Enabled:                 g.Enabled == 1,
+		QueryPortOffset:         g.QueryPortOffset,
+		RconPortOffset:          g.RconPortOffset,
 	}
 }
@@ -37,6 +37,7 @@ func TestGames(t *testing.T) {
 					LocalRepositoryLinux:    lo.ToPtr("/var/repo/linux"),
 					LocalRepositoryWindows:  lo.ToPtr("C:\\repo\\windows"),
 					Enabled:                 1,
+					QueryPortOffset:         1,
 				},
 			},
 			want: `[
@@ -52,7 +53,9 @@ func TestGames(t *testing.T) {
 					"remote_repository_windows": "http://example.com/windows",
 					"local_repository_linux": "/var/repo/linux",
 					"local_repository_windows": "C:\\repo\\windows",
-					"enabled": 1
+					"enabled": 1,
+					"query_port_offset": 1,
+					"rcon_port_offset": 0
 				}
 			]`,
 		},
@@ -15,6 +15,8 @@ type gameResponse struct {
 	LocalRepositoryLinux    *string `json:"local_repository_linux"`
 	LocalRepositoryWindows  *string `json:"local_repository_windows"`
 	Enabled                 int     `json:"enabled"`
+	QueryPortOffset         int     `json:"query_port_offset"`
+	RconPortOffset          int     `json:"rcon_port_offset"`
 }
 
 func newGamesResponseFromGames(games []domain.Game) []gameResponse {
@@ -41,5 +43,7 @@ func newGameResponseFromGame(g *domain.Game) gameResponse {
 		LocalRepositoryLinux:    g.LocalRepositoryLinux,
 		LocalRepositoryWindows:  g.LocalRepositoryWindows,
 		Enabled:                 g.Enabled,
+		QueryPortOffset:         g.QueryPortOffset,
+		RconPortOffset:          g.RconPortOffset,
 	}
 }
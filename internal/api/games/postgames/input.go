@@ -19,6 +19,7 @@ const (
 	maxEngineVersionLength = 128
 	maxConfigLength        = 128
 	maxRepositoryLength    = 128
+	maxPortOffset          = 1000
 )
 
 var (
@@ -56,6 +57,9 @@ var (
 	ErrLocalRepositoryTooLong = api.NewValidationError(
 		fmt.Sprintf("local repository must not exceed %d characters", maxRepositoryLength),
 	)
+	ErrPortOffsetInvalid = api.NewValidationError(
+		fmt.Sprintf("query_port_offset and rcon_port_offset must be between -%d and %d", maxPortOffset, maxPortOffset),
+	)
 )
 
 type createGameInput struct {
@@ -72,6 +76,8 @@ type createGameInput struct {
 	LocalRepositoryLinux    *string        `json:"local_repository_linux,omitempty"`    // maxlen=128
 	LocalRepositoryWindows  *string        `json:"local_repository_windows,omitempty"`  // maxlen=128
 	Enabled                 int            `json:"enabled"`                             //
+	QueryPortOffset         *flexible.Int  `json:"query_port_offset,omitempty"`         // query port of new servers relative to game port
+	RconPortOffset          *flexible.Int  `json:"rcon_port_offset,omitempty"`          // rcon port of new servers relative to game port
 }
 
 func (g *createGameInput) Validate() error {
@@ -133,6 +139,12 @@ func (g *createGameInput) Validate() error {
 		return ErrLocalRepositoryTooLong
 	}
 
+	for _, offset := range []*flexible.Int{g.QueryPortOffset, g.RconPortOffset} {
+		if offset != nil && (offset.Int() < -maxPortOffset || offset.Int() > maxPortOffset) {
+			return ErrPortOffsetInvalid
+		}
+	}
+
 	return nil
 }
 
@@ -150,5 +162,7 @@ func (g *createGameInput) ToDomain() *domain.Game {
 		LocalRepositoryLinux:    g.LocalRepositoryLinux,
 		LocalRepositoryWindows:  g.LocalRepositoryWindows,
 		Enabled:                 g.Enabled,
+		QueryPortOffset:         g.QueryPortOffset.Int(),
+		RconPortOffset:          g.RconPortOffset.Int(),
 	}
 }
@@ -16,6 +16,7 @@ const (
 	maxEngineVersionLength = 128
 	maxConfigLength        = 128
 	maxRepositoryLength    = 128
+	maxPortOffset          = 1000
 )
 
 var (
@@ -42,6 +43,9 @@ var (
 	ErrLocalRepositoryTooLong = api.NewValidationError(
 		fmt.Sprintf("local repository must not exceed %d characters", maxRepositoryLength),
 	)
+	ErrPortOffsetInvalid = api.NewValidationError(
+		fmt.Sprintf("query_port_offset and rcon_port_offset must be between -%d and %d", maxPortOffset, maxPortOffset),
+	)
 )
 
 type updateGameInput struct {
@@ -56,6 +60,8 @@ type updateGameInput struct {
 	LocalRepositoryLinux    *string        `json:"local_repository_linux,omitempty"`    // maxlen=128
 	LocalRepositoryWindows  *string        `json:"local_repository_windows,omitempty"`  // maxlen=128
 	Enabled                 int            `json:"enabled"`                             //
+	QueryPortOffset         *flexible.Int  `json:"query_port_offset,omitempty"`         // query port of new servers relative to game port
+	RconPortOffset          *flexible.Int  `json:"rcon_port_offset,omitempty"`          // rcon port of new servers relative to game port
 }
 
 func (g *updateGameInput) Validate() error {
@@ -104,6 +110,12 @@ func (g *updateGameInput) Validate() error {
 		return ErrLocalRepositoryTooLong
 	}
 
+	for _, offset := range []*flexible.Int{g.QueryPortOffset, g.RconPortOffset} {
+		if offset != nil && (offset.Int() < -maxPortOffset || offset.Int() > maxPortOffset) {
+			return ErrPortOffsetInvalid
+		}
+	}
+
 	return nil
 }
 
@@ -119,4 +131,12 @@ func (g *updateGameInput) Apply(game *domain.Game) {
 	game.LocalRepositoryLinux = g.LocalRepositoryLinux
 	game.LocalRepositoryWindows = g.LocalRepositoryWindows
 	game.Enabled = g.Enabled
+
+	if g.QueryPortOffset != nil {
+		game.QueryPortOffset = g.QueryPortOffset.Int()
+	}
+
+	if g.RconPortOffset != nil {
+		game.RconPortOffset = g.RconPortOffset.Int()
+	}
 }
@@ -0,0 +1,50 @@
+package getprometheusmetrics
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gameap/gameap/internal/api/base"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+// Handler serves the panel metrics to Prometheus. The scraper authenticates with the dedicated token
+// in the "Authorization: Bearer <token>" header, the endpoint is disabled if the token isn't configured.
+type Handler struct {
+	token     string
+	metrics   http.Handler
+	responder base.Responder
+}
+
+func NewHandler(token string, metrics http.Handler, responder base.Responder) *Handler {
+	return &Handler{
+		token:     token,
+		metrics:   metrics,
+		responder: responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if h.token == "" {
+		h.responder.WriteError(ctx, rw, api.NewNotFoundError("metrics are disabled"))
+
+		return
+	}
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(strings.TrimSpace(token)), []byte(h.token)) != 1 {
+		rw.Header().Set("WWW-Authenticate", "Bearer")
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("invalid metrics token"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	h.metrics.ServeHTTP(rw, r)
+}
@@ -0,0 +1,82 @@
+package getprometheusmetrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/monitoring"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		authorization string
+		wantStatus    int
+		wantMetrics   bool
+	}{
+		{
+			name:          "valid_token",
+			token:         "metrics-token",
+			authorization: "Bearer metrics-token",
+			wantStatus:    http.StatusOK,
+			wantMetrics:   true,
+		},
+		{
+			name:          "invalid_token",
+			token:         "metrics-token",
+			authorization: "Bearer other-token",
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "token_without_bearer_prefix",
+			token:         "metrics-token",
+			authorization: "metrics-token",
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:       "missing_token",
+			token:      "metrics-token",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:          "disabled",
+			token:         "",
+			authorization: "Bearer ",
+			wantStatus:    http.StatusNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			metrics := monitoring.NewMetrics()
+			metrics.ObserveHTTPRequest(http.MethodGet, "/api/servers/{id}", http.StatusOK, 10*time.Millisecond)
+
+			handler := NewHandler(test.token, metrics.Handler(), api.NewResponder())
+
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			if test.authorization != "" {
+				req.Header.Set("Authorization", test.authorization)
+			}
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.wantStatus, rr.Code)
+
+			if test.wantMetrics {
+				assert.Contains(
+					t,
+					rr.Body.String(),
+					`gameap_http_requests_total{method="GET",route="/api/servers/{id}",status="200"} 1`,
+				)
+			} else {
+				assert.NotContains(t, rr.Body.String(), "gameap_http_requests_total")
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gameap/gameap/internal/api/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/services/audit"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+)
+
+// maxAuditRequestSize limits the recorded request bodies, larger bodies aren't recorded.
+const maxAuditRequestSize = 64 * 1024
+
+// auditCollections are the entity types of the "{id}" path variables by the collection before the variable,
+// e.g. "/api/users/{id}". The requests to the collections themselves, like creations, have the type only.
+var auditCollections = map[string]domain.EntityType{
+	"servers":               domain.EntityTypeServer,
+	"users":                 domain.EntityTypeUser,
+	"dedicated_servers":     domain.EntityTypeNode,
+	"nodes":                 domain.EntityTypeNode,
+	"games":                 domain.EntityTypeGame,
+	"game_mods":             domain.EntityTypeGameMod,
+	"client_certificates":   domain.EntityTypeClientCertificate,
+	"server_templates":      domain.EntityTypeServerTemplate,
+	"webhooks":              domain.EntityTypeWebhook,
+	"notification_channels": domain.EntityTypeNotificationChannel,
+	"tokens":                domain.EntityTypePersonalAccessToken,
+}
+
+// auditVariables are the entity types of the named path variables.
+var auditVariables = map[string]domain.EntityType{
+	"server":  domain.EntityTypeServer,
+	"node":    domain.EntityTypeNode,
+	"code":    domain.EntityTypeGame,
+	"webhook": domain.EntityTypeWebhook,
+	"channel": domain.EntityTypeNotificationChannel,
+}
+
+type auditService interface {
+	Snapshot(ctx context.Context, entityType domain.EntityType, id string) (audit.Snapshot, error)
+	Record(ctx context.Context, log *domain.AuditLog) error
+}
+
+// AuditMiddleware records the requests to the mutating routes with the changes of the target entity.
+type AuditMiddleware struct {
+	service        auditService
+	clientIPHeader string
+}
+
+func NewAuditMiddleware(service auditService, clientIPHeader string) *AuditMiddleware {
+	return &AuditMiddleware{
+		service:        service,
+		clientIPHeader: clientIPHeader,
+	}
+}
+
+// Middleware records the requests to the route registered with the method and the path template.
+// The requests with the safe methods aren't recorded.
+func (m *AuditMiddleware) Middleware(next http.Handler, method, path string) http.Handler {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return next
+	}
+
+	action := method + " " + path
+	target := newAuditTarget(path)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		entityID := target.entityID(mux.Vars(r))
+		before := m.snapshot(ctx, target.entityType, entityID)
+		request := m.readRequest(r)
+
+		rw := &statusResponseWriter{ResponseWriter: w}
+
+		defer func() {
+			if p := recover(); p != nil {
+				if rw.status == 0 {
+					rw.status = http.StatusInternalServerError
+				}
+
+				m.record(r, rw.status, action, target.entityType, entityID, before, request)
+
+				panic(p)
+			}
+		}()
+
+		next.ServeHTTP(rw, r)
+
+		m.record(r, rw.statusCode(), action, target.entityType, entityID, before, request)
+	})
+}
+
+func (m *AuditMiddleware) record(
+	r *http.Request,
+	status int,
+	action string,
+	entityType domain.EntityType,
+	entityID string,
+	before audit.Snapshot,
+	request *string,
+) {
+	// The record is saved even if the client has gone away
+	ctx := context.WithoutCancel(r.Context())
+
+	log := &domain.AuditLog{
+		IP:         base.ClientIP(r, m.clientIPHeader),
+		UserAgent:  lo.Substring(r.UserAgent(), 0, 512),
+		Action:     action,
+		EntityID:   lo.EmptyableToPtr(entityID),
+		Request:    request,
+		Result:     domain.AuditResultSuccess,
+		StatusCode: status,
+	}
+
+	if entityType != domain.EntityTypeEmpty {
+		log.EntityType = &entityType
+	}
+
+	if status >= http.StatusBadRequest {
+		log.Result = domain.AuditResultFailure
+	}
+
+	session := auth.SessionFromContext(ctx)
+	if session.IsAuthenticated() {
+		log.UserID = &session.User.ID
+	}
+	if session.IsTokenSession() {
+		log.TokenID = &session.Token.ID
+	}
+
+	if entityID != "" && log.Result == domain.AuditResultSuccess {
+		after := m.snapshot(ctx, entityType, entityID)
+
+		if changes := audit.Diff(before, after); len(changes) > 0 {
+			encoded, err := json.Marshal(changes)
+			if err != nil {
+				slog.WarnContext(ctx, "Failed to encode audit changes", slog.String("error", err.Error()))
+			} else {
+				log.Changes = lo.ToPtr(string(encoded))
+			}
+		}
+	}
+
+	if err := m.service.Record(ctx, log); err != nil {
+		slog.ErrorContext(
+			ctx,
+			"Failed to record audit log",
+			slog.String("action", action),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+func (m *AuditMiddleware) snapshot(ctx context.Context, entityType domain.EntityType, entityID string) audit.Snapshot {
+	if entityID == "" {
+		return nil
+	}
+
+	snapshot, err := m.service.Snapshot(ctx, entityType, entityID)
+	if err != nil {
+		slog.WarnContext(
+			ctx,
+			"Failed to snapshot audited entity",
+			slog.String("entity_type", string(entityType)),
+			slog.String("entity_id", entityID),
+			slog.String("error", err.Error()),
+		)
+
+		return nil
+	}
+
+	return snapshot
+}
+
+// readRequest returns the redacted JSON body, the body is restored for the handler.
+// Other bodies, like file uploads, aren't recorded.
+func (m *AuditMiddleware) readRequest(r *http.Request) *string {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		return nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxAuditRequestSize+1))
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), r.Body), r.Body}
+
+	if err != nil || len(body) > maxAuditRequestSize || len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	request, err := audit.RedactJSON(body)
+	if err != nil {
+		return nil
+	}
+
+	return &request
+}
+
+// auditTarget is the entity the requests to a route are made to.
+type auditTarget struct {
+	entityType domain.EntityType
+	// variable is the path variable with the ID of the entity, empty for the routes without an entity ID.
+	variable string
+}
+
+// newAuditTarget resolves the target of the path template. The "{id}" variable after a known collection
+// takes precedence, then the first known named variable is used, e.g. the server of the server tasks.
+func newAuditTarget(path string) auditTarget {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i := 1; i < len(segments); i++ {
+		if entityType, ok := auditCollections[segments[i-1]]; ok && segments[i] == "{id}" {
+			return auditTarget{entityType: entityType, variable: "id"}
+		}
+	}
+
+	for _, segment := range segments {
+		variable, ok := strings.CutPrefix(segment, "{")
+		if !ok {
+			continue
+		}
+
+		variable = strings.TrimSuffix(variable, "}")
+
+		if entityType, ok := auditVariables[variable]; ok {
+			return auditTarget{entityType: entityType, variable: variable}
+		}
+	}
+
+	if entityType, ok := auditCollections[segments[len(segments)-1]]; ok {
+		return auditTarget{entityType: entityType}
+	}
+
+	return auditTarget{}
+}
+
+func (t auditTarget) entityID(vars map[string]string) string {
+	if t.variable == "" {
+		return ""
+	}
+
+	return vars[t.variable]
+}
@@ -0,0 +1,238 @@
+package middlewares
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services/audit"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAuditTarget(t *testing.T) {
+	tests := []struct {
+		path string
+		want auditTarget
+	}{
+		{
+			path: "/api/users/{id}",
+			want: auditTarget{entityType: domain.EntityTypeUser, variable: "id"},
+		},
+		{
+			path: "/api/dedicated_servers/{id}/settings",
+			want: auditTarget{entityType: domain.EntityTypeNode, variable: "id"},
+		},
+		{
+			path: "/api/servers/{server}/tasks/{id}",
+			want: auditTarget{entityType: domain.EntityTypeServer, variable: "server"},
+		},
+		{
+			path: "/api/games/{code}",
+			want: auditTarget{entityType: domain.EntityTypeGame, variable: "code"},
+		},
+		{
+			path: "/api/servers",
+			want: auditTarget{entityType: domain.EntityTypeServer},
+		},
+		{
+			path: "/api/profile",
+			want: auditTarget{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			assert.Equal(t, test.want, newAuditTarget(test.path))
+		})
+	}
+}
+
+func setupAuditMiddleware(t *testing.T) (*AuditMiddleware, *inmemory.UserRepository, *inmemory.AuditLogRepository) {
+	t.Helper()
+
+	userRepo := inmemory.NewUserRepository()
+	auditLogRepo := inmemory.NewAuditLogRepository()
+
+	service := audit.NewService(auditLogRepo, audit.Repositories{
+		Users: userRepo,
+		RBAC:  inmemory.NewRBACRepository(),
+	})
+
+	return NewAuditMiddleware(service, ""), userRepo, auditLogRepo
+}
+
+func serveAudited(
+	m *AuditMiddleware,
+	method, path string,
+	handler http.HandlerFunc,
+	req *http.Request,
+) *httptest.ResponseRecorder {
+	router := mux.NewRouter()
+	router.Handle(path, m.Middleware(handler, method, path)).Methods(method)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func findAuditLogs(t *testing.T, repo *inmemory.AuditLogRepository) []domain.AuditLog {
+	t.Helper()
+
+	logs, err := repo.Find(context.Background(), &filters.FindAuditLog{}, nil, nil)
+	require.NoError(t, err)
+
+	return logs
+}
+
+func TestAuditMiddleware_RecordsChanges(t *testing.T) {
+	m, userRepo, auditLogRepo := setupAuditMiddleware(t)
+
+	admin := &domain.User{Login: "admin", Email: "admin@example.com", Password: "hash"}
+	require.NoError(t, userRepo.Save(context.Background(), admin))
+	user := &domain.User{Login: "john", Email: "john@example.com", Password: "old-hash"}
+	require.NoError(t, userRepo.Save(context.Background(), user))
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"email":"new@example.com","password":"new-password"}`, string(body))
+
+		user.Email = "new@example.com"
+		user.Password = "new-hash"
+		assert.NoError(t, userRepo.Save(r.Context(), user))
+
+		w.WriteHeader(http.StatusOK)
+	}
+
+	req := httptest.NewRequest(
+		http.MethodPut,
+		"/api/users/2",
+		strings.NewReader(`{"email":"new@example.com","password":"new-password"}`),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "test-agent")
+	req.RemoteAddr = "192.0.2.1:1234"
+	req = req.WithContext(auth.ContextWithSession(req.Context(), &auth.Session{User: admin}))
+
+	rr := serveAudited(m, http.MethodPut, "/api/users/{id}", handler, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	logs := findAuditLogs(t, auditLogRepo)
+	require.Len(t, logs, 1)
+
+	log := logs[0]
+	assert.Equal(t, "PUT /api/users/{id}", log.Action)
+	assert.Equal(t, domain.AuditResultSuccess, log.Result)
+	assert.Equal(t, http.StatusOK, log.StatusCode)
+	assert.Equal(t, "192.0.2.1", log.IP)
+	assert.Equal(t, "test-agent", log.UserAgent)
+	require.NotNil(t, log.UserID)
+	assert.Equal(t, admin.ID, *log.UserID)
+	assert.Nil(t, log.TokenID)
+	require.NotNil(t, log.EntityType)
+	assert.Equal(t, domain.EntityTypeUser, *log.EntityType)
+	require.NotNil(t, log.EntityID)
+	assert.Equal(t, "2", *log.EntityID)
+
+	require.NotNil(t, log.Request)
+	assert.JSONEq(t, `{"email":"new@example.com","password":"[redacted]"}`, *log.Request)
+
+	require.NotNil(t, log.Changes)
+
+	var changes map[string]audit.Change
+	require.NoError(t, json.Unmarshal([]byte(*log.Changes), &changes))
+	assert.JSONEq(t, `"john@example.com"`, string(changes["email"].Old))
+	assert.JSONEq(t, `"new@example.com"`, string(changes["email"].New))
+	assert.JSONEq(t, `"[redacted]"`, string(changes["password"].New))
+}
+
+func TestAuditMiddleware_Failure(t *testing.T) {
+	m, _, auditLogRepo := setupAuditMiddleware(t)
+
+	handler := func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/users/5", nil)
+
+	rr := serveAudited(m, http.MethodDelete, "/api/users/{id}", handler, req)
+	require.Equal(t, http.StatusForbidden, rr.Code)
+
+	logs := findAuditLogs(t, auditLogRepo)
+	require.Len(t, logs, 1)
+	assert.Equal(t, domain.AuditResultFailure, logs[0].Result)
+	assert.Equal(t, http.StatusForbidden, logs[0].StatusCode)
+	assert.Nil(t, logs[0].UserID)
+	assert.Nil(t, logs[0].Changes)
+	assert.Nil(t, logs[0].Request)
+}
+
+func TestAuditMiddleware_Panic(t *testing.T) {
+	m, _, auditLogRepo := setupAuditMiddleware(t)
+
+	handler := func(http.ResponseWriter, *http.Request) {
+		panic("unexpected")
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/servers", nil)
+
+	assert.Panics(t, func() {
+		serveAudited(m, http.MethodPost, "/api/servers", handler, req)
+	})
+
+	logs := findAuditLogs(t, auditLogRepo)
+	require.Len(t, logs, 1)
+	assert.Equal(t, domain.AuditResultFailure, logs[0].Result)
+	assert.Equal(t, http.StatusInternalServerError, logs[0].StatusCode)
+}
+
+func TestAuditMiddleware_SkipsSafeMethods(t *testing.T) {
+	m, _, auditLogRepo := setupAuditMiddleware(t)
+
+	handler := func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/users/1", nil)
+
+	rr := serveAudited(m, http.MethodGet, "/api/users/{id}", handler, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	assert.Empty(t, findAuditLogs(t, auditLogRepo))
+}
+
+func TestAuditMiddleware_SkipsNotJSONBodies(t *testing.T) {
+	m, _, auditLogRepo := setupAuditMiddleware(t)
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "file content", string(body))
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/servers", strings.NewReader("file content"))
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	rr := serveAudited(m, http.MethodPost, "/api/servers", handler, req)
+	require.Equal(t, http.StatusNoContent, rr.Code)
+
+	logs := findAuditLogs(t, auditLogRepo)
+	require.Len(t, logs, 1)
+	assert.Nil(t, logs[0].Request)
+	assert.Nil(t, logs[0].EntityID)
+	require.NotNil(t, logs[0].EntityType)
+	assert.Equal(t, domain.EntityTypeServer, *logs[0].EntityType)
+}
@@ -2,6 +2,7 @@ package middlewares
 
 import (
 	"context"
+	"log/slog"
 	"net/http"
 	"strconv"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	"github.com/gameap/gameap/internal/api/base"
 	"github.com/gameap/gameap/internal/domain"
 	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/logger"
 	"github.com/gameap/gameap/internal/repositories"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
@@ -28,6 +30,7 @@ var (
 	errInvalidOrExpiredToken    = errors.New("invalid or expired token")
 	errInvalidTokenSubject      = errors.New("invalid token subject")
 	errUserNotFound             = errors.New("user not found")
+	errUserDisabled             = errors.New("user is disabled")
 	errUserNotAuthenticated     = errors.New("user not authenticated")
 	errAdminPermissionsRequired = errors.New("admin permissions required")
 )
@@ -121,6 +124,10 @@ func (m *AuthMiddleware) middleware(next http.Handler, optional bool) http.Handl
 
 		ctx := auth.ContextWithSession(r.Context(), session)
 
+		if session.IsAuthenticated() {
+			ctx = logger.WithAttrs(ctx, slog.Uint64("user_id", uint64(session.User.ID)))
+		}
+
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
@@ -253,6 +260,13 @@ func (m *AuthMiddleware) processPersonalAccessToken(ctx context.Context, token s
 
 	user := &users[0]
 
+	if user.Disabled() {
+		return nil, api.WrapHTTPError(
+			errUserDisabled,
+			http.StatusUnauthorized,
+		)
+	}
+
 	return &auth.Session{
 		ID:    xid.New().String(),
 		Login: user.Login,
@@ -316,6 +330,13 @@ func (m *AuthMiddleware) processUserAuthToken(ctx context.Context, token string)
 
 	user := &users[0]
 
+	if user.Disabled() {
+		return nil, api.WrapHTTPError(
+			errUserDisabled,
+			http.StatusUnauthorized,
+		)
+	}
+
 	return &auth.Session{
 		ID:    xid.New().String(),
 		Login: user.Login,
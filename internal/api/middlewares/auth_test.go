@@ -35,12 +35,23 @@ func TestAuthMiddleware_Middleware(t *testing.T) {
 		UpdatedAt: &now,
 	}
 	_ = userRepo.Save(context.Background(), testUser)
+	disabledUser := &domain.User{
+		ID:         2,
+		Login:      "disableduser",
+		Email:      "disabled@example.com",
+		Password:   hashedPassword,
+		CreatedAt:  &now,
+		UpdatedAt:  &now,
+		DisabledAt: &now,
+	}
+	_ = userRepo.Save(context.Background(), disabledUser)
 
 	// Setup JWT service and generate token
 	jwtService := auth.NewJWTService([]byte(testJWTSecret))
 	validToken, _ := jwtService.GenerateTokenForUser(testUser, 24*time.Hour)
 	expiredToken, _ := jwtService.GenerateTokenForUser(testUser, -1*time.Hour)
 	invalidUserToken, _ := jwtService.GenerateTokenForUser(&domain.User{ID: 999, Login: "invalid"}, 24*time.Hour)
+	disabledUserToken, _ := jwtService.GenerateTokenForUser(disabledUser, 24*time.Hour)
 
 	tests := []struct {
 		name       string
@@ -106,6 +117,13 @@ func TestAuthMiddleware_Middleware(t *testing.T) {
 			wantUser:   false,
 			wantError:  "user not found",
 		},
+		{
+			name:       "token for disabled user",
+			authHeader: "Bearer " + disabledUserToken,
+			wantStatus: http.StatusUnauthorized,
+			wantUser:   false,
+			wantError:  "user is disabled",
+		},
 		{
 			name:       "malformed Authorization header",
 			authHeader: "InvalidScheme " + validToken,
@@ -1,10 +1,12 @@
 package middlewares
 
 import (
+	"log/slog"
 	"net/http"
 
 	"github.com/gameap/gameap/internal/api/base"
 	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/logger"
 	"github.com/gameap/gameap/internal/repositories"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
@@ -72,6 +74,7 @@ func (m *DaemonAuthMiddleware) Middleware(next http.Handler) http.Handler {
 		ctx := auth.ContextWithDaemonSession(r.Context(), &auth.DaemonSession{
 			Node: node,
 		})
+		ctx = logger.WithAttrs(ctx, slog.Uint64("node_id", uint64(node.ID)))
 
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
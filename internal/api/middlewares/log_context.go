@@ -0,0 +1,63 @@
+package middlewares
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gameap/gameap/internal/logger"
+	"github.com/gorilla/mux"
+)
+
+// LogContextMiddleware adds the IDs of the server and the node the request is made to
+// to the log records of the request.
+type LogContextMiddleware struct{}
+
+func NewLogContextMiddleware() *LogContextMiddleware {
+	return &LogContextMiddleware{}
+}
+
+// Middleware adds the IDs of the path variables of the route registered with the path template.
+func (m *LogContextMiddleware) Middleware(next http.Handler, path string) http.Handler {
+	serverVariable := pathVariable(path, "servers", "server")
+	nodeVariable := pathVariable(path, "dedicated_servers", "node")
+
+	if serverVariable == "" && nodeVariable == "" {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		var attrs []slog.Attr
+
+		if id := vars[serverVariable]; serverVariable != "" && id != "" {
+			attrs = append(attrs, slog.String("server_id", id))
+		}
+
+		if id := vars[nodeVariable]; nodeVariable != "" && id != "" {
+			attrs = append(attrs, slog.String("node_id", id))
+		}
+
+		next.ServeHTTP(w, r.WithContext(logger.WithAttrs(r.Context(), attrs...)))
+	})
+}
+
+// pathVariable returns the variable with the entity ID of the path template, either the named variable
+// or the "{id}" variable of the collection right after the API prefix, e.g. "/api/servers/{id}".
+// The "{id}" of the nested collections, like "/api/users/{id}/servers", isn't the entity one.
+func pathVariable(path, collection, name string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for _, segment := range segments {
+		if segment == "{"+name+"}" {
+			return name
+		}
+	}
+
+	if len(segments) >= 3 && segments[1] == collection && segments[2] == "{id}" {
+		return "id"
+	}
+
+	return ""
+}
@@ -0,0 +1,96 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/logger"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogContextMiddleware_Middleware(t *testing.T) {
+	tests := []struct {
+		name    string
+		route   string
+		path    string
+		wantLog string
+	}{
+		{
+			name:    "server_variable",
+			route:   "/api/servers/{server}/start",
+			path:    "/api/servers/5/start",
+			wantLog: "msg=Handled server_id=5\n",
+		},
+		{
+			name:    "server_id_variable",
+			route:   "/api/servers/{id}",
+			path:    "/api/servers/5",
+			wantLog: "msg=Handled server_id=5\n",
+		},
+		{
+			name:    "file_manager_server",
+			route:   "/api/file-manager/{server}/tree",
+			path:    "/api/file-manager/5/tree",
+			wantLog: "msg=Handled server_id=5\n",
+		},
+		{
+			name:    "node_id_variable",
+			route:   "/api/dedicated_servers/{id}",
+			path:    "/api/dedicated_servers/3",
+			wantLog: "msg=Handled node_id=3\n",
+		},
+		{
+			name:    "nested_collection_id_skipped",
+			route:   "/api/users/{id}/servers",
+			path:    "/api/users/2/servers",
+			wantLog: "msg=Handled\n",
+		},
+		{
+			name:    "user_server_permissions",
+			route:   "/api/users/{id}/servers/{server}/permissions",
+			path:    "/api/users/2/servers/5/permissions",
+			wantLog: "msg=Handled server_id=5\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+
+			handler, err := logger.NewHandler(buf, logger.FormatText, slog.LevelInfo)
+			require.NoError(t, err)
+
+			log := slog.New(removeTimeHandler{handler})
+
+			router := mux.NewRouter()
+			router.Handle(test.route, NewLogContextMiddleware().Middleware(
+				http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+					log.InfoContext(r.Context(), "Handled")
+				}),
+				test.route,
+			))
+
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, test.path, nil))
+
+			assert.Equal(t, "level=INFO "+test.wantLog, buf.String())
+		})
+	}
+}
+
+// removeTimeHandler drops the time of the records, so the output is stable.
+type removeTimeHandler struct {
+	slog.Handler
+}
+
+func (h removeTimeHandler) Handle(ctx context.Context, record slog.Record) error {
+	record.Time = time.Time{}
+
+	return h.Handler.Handle(ctx, record)
+}
@@ -0,0 +1,33 @@
+package middlewares
+
+import (
+	"net/http"
+	"time"
+)
+
+type metricsObserver interface {
+	ObserveHTTPRequest(method, route string, status int, duration time.Duration)
+}
+
+// MetricsMiddleware observes the number and the duration of the requests by the route templates,
+// so the requests to the same route with different IDs are counted together.
+type MetricsMiddleware struct {
+	metrics metricsObserver
+}
+
+func NewMetricsMiddleware(metrics metricsObserver) *MetricsMiddleware {
+	return &MetricsMiddleware{
+		metrics: metrics,
+	}
+}
+
+func (m *MetricsMiddleware) Middleware(next http.Handler, method, path string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rw := &statusResponseWriter{ResponseWriter: w}
+
+		next.ServeHTTP(rw, r)
+
+		m.metrics.ObserveHTTPRequest(method, path, rw.statusCode(), time.Since(start))
+	})
+}
@@ -0,0 +1,120 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type observedRequest struct {
+	method string
+	route  string
+	status int
+}
+
+type fakeMetricsObserver struct {
+	mu       sync.Mutex
+	requests []observedRequest
+}
+
+func (o *fakeMetricsObserver) ObserveHTTPRequest(method, route string, status int, _ time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.requests = append(o.requests, observedRequest{method: method, route: route, status: status})
+}
+
+func (o *fakeMetricsObserver) observed() []observedRequest {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return o.requests
+}
+
+func TestMetricsMiddleware_Middleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		wantStatus int
+	}{
+		{
+			name: "status_written",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "body_written_without_status",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte("ok"))
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "nothing_written",
+			handler:    func(http.ResponseWriter, *http.Request) {},
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			observer := &fakeMetricsObserver{}
+			handler := NewMetricsMiddleware(observer).Middleware(test.handler, http.MethodGet, "/api/servers/{id}")
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/servers/5", nil))
+
+			require.Len(t, observer.requests, 1)
+			assert.Equal(t, observedRequest{
+				method: http.MethodGet,
+				route:  "/api/servers/{id}",
+				status: test.wantStatus,
+			}, observer.requests[0])
+		})
+	}
+}
+
+func TestMetricsMiddleware_HijackedConnection(t *testing.T) {
+	observer := &fakeMetricsObserver{}
+	handler := NewMetricsMiddleware(observer).Middleware(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			conn, buf, err := http.NewResponseController(w).Hijack()
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+
+				return
+			}
+			defer conn.Close()
+
+			_, _ = buf.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: test\r\n\r\n")
+			_ = buf.Flush()
+		}),
+		http.MethodGet,
+		"/api/servers/{server}/console/stream",
+	)
+
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/api/servers/5/console/stream", nil)
+	require.NoError(t, err)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "test")
+
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+
+	require.Eventually(t, func() bool {
+		return len(observer.observed()) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, http.StatusSwitchingProtocols, observer.observed()[0].status)
+}
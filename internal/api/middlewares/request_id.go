@@ -0,0 +1,36 @@
+package middlewares
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/gameap/gameap/internal/logger"
+	"github.com/google/uuid"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// validRequestID matches the IDs set by the reverse proxies and the clients, other IDs are replaced,
+// so the log records can't be forged with the header.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestIDMiddleware adds the request ID to the context and to the response headers.
+// The ID of the incoming request header is kept, so the records of the proxy and the panel can be matched.
+type RequestIDMiddleware struct{}
+
+func NewRequestIDMiddleware() *RequestIDMiddleware {
+	return &RequestIDMiddleware{}
+}
+
+func (m *RequestIDMiddleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = uuid.NewString()
+		}
+
+		w.Header().Set(RequestIDHeader, id)
+
+		next.ServeHTTP(w, r.WithContext(logger.WithRequestID(r.Context(), id)))
+	})
+}
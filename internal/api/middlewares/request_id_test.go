@@ -0,0 +1,68 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gameap/gameap/internal/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestIDMiddleware_Middleware(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    string
+		wantKept  bool
+		wantValid bool
+	}{
+		{
+			name:     "incoming_id_kept",
+			header:   "a1b2c3d4-proxy.id:1",
+			wantKept: true,
+		},
+		{
+			name:   "missing_id_generated",
+			header: "",
+		},
+		{
+			name:   "invalid_characters_replaced",
+			header: "id with spaces\" injected=1",
+		},
+		{
+			name:   "too_long_replaced",
+			header: strings.Repeat("a", 129),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var contextID string
+
+			handler := NewRequestIDMiddleware().Middleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				contextID = logger.RequestIDFromContext(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/servers", nil)
+			if test.header != "" {
+				req.Header.Set(RequestIDHeader, test.header)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			responseID := rr.Header().Get(RequestIDHeader)
+			assert.Equal(t, contextID, responseID)
+
+			if test.wantKept {
+				assert.Equal(t, test.header, responseID)
+			} else {
+				_, err := uuid.Parse(responseID)
+				require.NoError(t, err)
+			}
+		})
+	}
+}
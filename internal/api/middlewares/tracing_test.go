@@ -0,0 +1,95 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gameap/gameap/internal/tracing/tracingtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracingMiddleware_Middleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		wantStatus codes.Code
+	}{
+		{
+			name:       "success",
+			status:     http.StatusOK,
+			wantStatus: codes.Unset,
+		},
+		{
+			name:       "client_error",
+			status:     http.StatusNotFound,
+			wantStatus: codes.Unset,
+		},
+		{
+			name:       "server_error",
+			status:     http.StatusInternalServerError,
+			wantStatus: codes.Error,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := tracingtest.NewRecorder(t)
+
+			var handlerSpan trace.SpanContext
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				handlerSpan = trace.SpanContextFromContext(r.Context())
+
+				w.WriteHeader(test.status)
+			})
+
+			handler := NewTracingMiddleware().Middleware(next, http.MethodGet, "/api/servers/{id}")
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/servers/5", nil))
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+
+			span := spans[0]
+			assert.Equal(t, "GET /api/servers/{id}", span.Name())
+			assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+			assert.Equal(t, test.wantStatus, span.Status().Code)
+			assert.Equal(t, span.SpanContext().SpanID(), handlerSpan.SpanID())
+			assert.Contains(t, span.Attributes(), attribute.String("http.route", "/api/servers/{id}"))
+			assert.Contains(t, span.Attributes(), attribute.Int("http.response.status_code", test.status))
+		})
+	}
+}
+
+func TestTracingMiddleware_Middleware_ContinuesCallerTrace(t *testing.T) {
+	recorder := tracingtest.NewRecorder(t)
+
+	previous := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTextMapPropagator(previous)
+	})
+
+	handler := NewTracingMiddleware().Middleware(
+		http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}),
+		http.MethodPost,
+		"/api/servers/{id}/start",
+	)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/servers/5/start", nil)
+	req.Header.Set("Traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+	assert.True(t, spans[0].Parent().IsRemote())
+}
@@ -2,7 +2,6 @@ package getbusyports
 
 import (
 	"net/http"
-	"sort"
 
 	"github.com/gameap/gameap/internal/api/base"
 	"github.com/gameap/gameap/internal/domain"
@@ -11,7 +10,6 @@ import (
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
 	"github.com/pkg/errors"
-	"github.com/samber/lo"
 )
 
 type Handler struct {
@@ -63,34 +61,7 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	busyPorts := h.collectBusyPorts(servers)
+	busyPorts := domain.ServersBusyPorts(servers)
 
 	h.responder.Write(ctx, rw, newBusyPortsResponse(busyPorts))
 }
-
-func (h *Handler) collectBusyPorts(servers []domain.Server) map[string][]int {
-	result := make(map[string][]int, len(servers))
-
-	for _, server := range servers {
-		if _, exists := result[server.ServerIP]; !exists {
-			result[server.ServerIP] = make([]int, 0, len(servers)*3)
-		}
-
-		result[server.ServerIP] = append(result[server.ServerIP], server.ServerPort)
-
-		if server.QueryPort != nil {
-			result[server.ServerIP] = append(result[server.ServerIP], *server.QueryPort)
-		}
-
-		if server.RconPort != nil {
-			result[server.ServerIP] = append(result[server.ServerIP], *server.RconPort)
-		}
-	}
-
-	for ip := range result {
-		result[ip] = lo.Uniq(result[ip])
-		sort.Ints(result[ip])
-	}
-
-	return result
-}
@@ -260,51 +260,6 @@ func TestHandler_ServeHTTP(t *testing.T) {
 	}
 }
 
-func TestHandler_CollectBusyPorts(t *testing.T) {
-	handler := &Handler{}
-
-	now := time.Now()
-	queryPort1 := 27016
-	rconPort1 := 27017
-	queryPort2 := 27019
-
-	servers := []domain.Server{
-		{
-			ID:         1,
-			UUID:       uuid.MustParse("11111111-1111-1111-1111-111111111111"),
-			DSID:       1,
-			ServerIP:   "192.168.1.1",
-			ServerPort: 27015,
-			QueryPort:  &queryPort1,
-			RconPort:   &rconPort1,
-			CreatedAt:  &now,
-		},
-		{
-			ID:         2,
-			UUID:       uuid.MustParse("22222222-2222-2222-2222-222222222222"),
-			DSID:       1,
-			ServerIP:   "192.168.1.1",
-			ServerPort: 27018,
-			QueryPort:  &queryPort2,
-			CreatedAt:  &now,
-		},
-		{
-			ID:         3,
-			UUID:       uuid.MustParse("33333333-3333-3333-3333-333333333333"),
-			DSID:       1,
-			ServerIP:   "192.168.1.2",
-			ServerPort: 27015,
-			CreatedAt:  &now,
-		},
-	}
-
-	result := handler.collectBusyPorts(servers)
-
-	assert.Equal(t, 2, len(result))
-	assert.Equal(t, []int{27015, 27016, 27017, 27018, 27019}, result["192.168.1.1"])
-	assert.Equal(t, []int{27015}, result["192.168.1.2"])
-}
-
 func TestUniqueAndSort(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -40,6 +40,8 @@ type nodeResponse struct {
 	ScriptGetConsole    *string    `json:"script_get_console"`
 	ScriptSendCommand   *string    `json:"script_send_command"`
 	ScriptDelete        *string    `json:"script_delete"`
+	PortRangeStart      *int       `json:"port_range_start"`
+	PortRangeEnd        *int       `json:"port_range_end"`
 	CreatedAt           *time.Time `json:"created_at"`
 	UpdatedAt           *time.Time `json:"updated_at"`
 	DeletedAt           *time.Time `json:"deleted_at"`
@@ -80,6 +82,8 @@ func newNodeResponseFromNode(n *domain.Node) nodeResponse {
 		ScriptGetConsole:    n.ScriptGetConsole,
 		ScriptSendCommand:   n.ScriptSendCommand,
 		ScriptDelete:        n.ScriptDelete,
+		PortRangeStart:      n.PortRangeStart,
+		PortRangeEnd:        n.PortRangeEnd,
 		CreatedAt:           n.CreatedAt,
 		UpdatedAt:           n.UpdatedAt,
 		DeletedAt:           n.DeletedAt,
@@ -8,6 +8,7 @@ import (
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/flexible"
 	"github.com/gameap/gameap/pkg/validation"
+	"github.com/samber/lo"
 )
 
 const (
@@ -56,6 +57,10 @@ var (
 	ErrGdaemonPortInvalid  = api.NewValidationError(
 		fmt.Sprintf("gdaemon_port must be between %d and %d", minPortNumber, maxPortNumber),
 	)
+	ErrPortRangeInvalid = api.NewValidationError(
+		fmt.Sprintf("port_range_start and port_range_end must be between 0 and %d", maxPortNumber),
+	)
+	ErrPortRangeStartAfterEnd  = api.NewValidationError("port_range_start must not be greater than port_range_end")
 	ErrClientCertificateIDZero = api.NewValidationError("client_certificate_id must be greater than 0")
 	ErrScriptTooLong           = api.NewValidationError("script content is too long")
 	ErrCertificateRequired     = api.NewValidationError("gdaemon_server_cert is required")
@@ -91,6 +96,9 @@ type createDedicatedServerInput struct {
 	ScriptGetConsole    *string `json:"script_get_console,omitempty"`
 	ScriptSendCommand   *string `json:"script_send_command,omitempty"`
 	ScriptDelete        *string `json:"script_delete,omitempty"`
+
+	PortRangeStart *flexible.Int `json:"port_range_start,omitempty"`
+	PortRangeEnd   *flexible.Int `json:"port_range_end,omitempty"`
 }
 
 func (in *createDedicatedServerInput) Validate() error {
@@ -168,7 +176,7 @@ func (in *createDedicatedServerInput) Validate() error {
 		return ErrCertificateRequired
 	}
 
-	return nil
+	return validatePortRange(in.PortRangeStart, in.PortRangeEnd)
 }
 
 func (in *createDedicatedServerInput) ToDomain(apiKey, certPath string) *domain.Node {
@@ -201,6 +209,8 @@ func (in *createDedicatedServerInput) ToDomain(apiKey, certPath string) *domain.
 		ScriptGetConsole:    trimStringPtr(in.ScriptGetConsole),
 		ScriptSendCommand:   trimStringPtr(in.ScriptSendCommand),
 		ScriptDelete:        trimStringPtr(in.ScriptDelete),
+		PortRangeStart:      portOrNil(in.PortRangeStart),
+		PortRangeEnd:        portOrNil(in.PortRangeEnd),
 	}
 }
 
@@ -226,3 +236,26 @@ func trimStringPtr(s *string) *string {
 
 	return &trimmed
 }
+
+func validatePortRange(start, end *flexible.Int) error {
+	for _, port := range []*flexible.Int{start, end} {
+		if port != nil && (port.Int() < 0 || port.Int() > maxPortNumber) {
+			return ErrPortRangeInvalid
+		}
+	}
+
+	if start != nil && end != nil && start.Int() > 0 && end.Int() > 0 && start.Int() > end.Int() {
+		return ErrPortRangeStartAfterEnd
+	}
+
+	return nil
+}
+
+// portOrNil returns nil for an empty or zero port.
+func portOrNil(port *flexible.Int) *int {
+	if port == nil || port.Int() == 0 {
+		return nil
+	}
+
+	return lo.ToPtr(port.Int())
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/flexible"
 	"github.com/gameap/gameap/pkg/validation"
+	"github.com/samber/lo"
 )
 
 const (
@@ -56,6 +57,10 @@ var (
 	ErrGdaemonPortInvalid  = api.NewValidationError(
 		fmt.Sprintf("gdaemon_port must be between %d and %d", minPortNumber, maxPortNumber),
 	)
+	ErrPortRangeInvalid = api.NewValidationError(
+		fmt.Sprintf("port_range_start and port_range_end must be between 0 and %d", maxPortNumber),
+	)
+	ErrPortRangeStartAfterEnd = api.NewValidationError("port_range_start must not be greater than port_range_end")
 )
 
 type updateNodeInput struct {
@@ -91,6 +96,8 @@ type updateNodeInput struct {
 	ScriptGetConsole    *string        `json:"script_get_console,omitempty"`
 	ScriptSendCommand   *string        `json:"script_send_command,omitempty"`
 	ScriptDelete        *string        `json:"script_delete,omitempty"`
+	PortRangeStart      *flexible.Int  `json:"port_range_start,omitempty"`
+	PortRangeEnd        *flexible.Int  `json:"port_range_end,omitempty"`
 }
 
 func (in *updateNodeInput) Validate() error {
@@ -105,6 +112,7 @@ func (in *updateNodeInput) Validate() error {
 		in.validateGdaemonHost,
 		in.validateGdaemonPort,
 		in.validateGdaemonServerCert,
+		in.validatePortRange,
 	}
 
 	for _, validator := range validators {
@@ -239,6 +247,12 @@ func (in *updateNodeInput) validateGdaemonServerCert() error {
 	return nil
 }
 
+// validatePortRange checks the port range fields.
+// A zero port resets the range boundary to the panel default.
+func (in *updateNodeInput) validatePortRange() error {
+	return validatePortRange(in.PortRangeStart, in.PortRangeEnd)
+}
+
 func (in *updateNodeInput) ApplyToNode(node *domain.Node) {
 	in.applyBasicFields(node)
 	in.applyGdaemonFields(node)
@@ -276,6 +290,12 @@ func (in *updateNodeInput) applyBasicFields(node *domain.Node) {
 	if in.SteamcmdPath != nil {
 		node.SteamcmdPath = in.SteamcmdPath
 	}
+	if in.PortRangeStart != nil {
+		node.PortRangeStart = portOrNil(in.PortRangeStart)
+	}
+	if in.PortRangeEnd != nil {
+		node.PortRangeEnd = portOrNil(in.PortRangeEnd)
+	}
 }
 
 func (in *updateNodeInput) applyGdaemonFields(node *domain.Node) {
@@ -346,3 +366,26 @@ func (in *updateNodeInput) applyScriptFields(node *domain.Node) {
 		node.ScriptDelete = in.ScriptDelete
 	}
 }
+
+func validatePortRange(start, end *flexible.Int) error {
+	for _, port := range []*flexible.Int{start, end} {
+		if port != nil && (port.Int() < 0 || port.Int() > maxPortNumber) {
+			return ErrPortRangeInvalid
+		}
+	}
+
+	if start != nil && end != nil && start.Int() > 0 && end.Int() > 0 && start.Int() > end.Int() {
+		return ErrPortRangeStartAfterEnd
+	}
+
+	return nil
+}
+
+// portOrNil returns nil for an empty or zero port.
+func portOrNil(port *flexible.Int) *int {
+	if port == nil || port.Int() == 0 {
+		return nil
+	}
+
+	return lo.ToPtr(port.Int())
+}
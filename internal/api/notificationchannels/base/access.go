@@ -0,0 +1,67 @@
+package base
+
+import (
+	"context"
+
+	"github.com/gameap/gameap/internal/api/base"
+	serversbase "github.com/gameap/gameap/internal/api/servers/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+// ChannelAccess is responsible for access control of notification channels.
+//
+// Users manage their own channels, which may be limited to one of their servers.
+type ChannelAccess struct {
+	channelRepo  repositories.NotificationChannelRepository
+	serverFinder *serversbase.ServerFinder
+}
+
+func NewChannelAccess(
+	channelRepo repositories.NotificationChannelRepository,
+	serverRepo repositories.ServerRepository,
+	rbac base.RBAC,
+) *ChannelAccess {
+	return &ChannelAccess{
+		channelRepo:  channelRepo,
+		serverFinder: serversbase.NewServerFinder(serverRepo, rbac),
+	}
+}
+
+// FindUserChannel returns the channel if it belongs to the user.
+func (a *ChannelAccess) FindUserChannel(
+	ctx context.Context,
+	user *domain.User,
+	channelID uint,
+) (*domain.NotificationChannel, error) {
+	channels, err := a.channelRepo.Find(ctx, &filters.FindNotificationChannel{
+		IDs:     []uint{channelID},
+		UserIDs: []uint{user.ID},
+	}, nil, &filters.Pagination{
+		Limit:  1,
+		Offset: 0,
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find notification channel")
+	}
+
+	if len(channels) == 0 {
+		return nil, api.NewNotFoundError("notification channel not found")
+	}
+
+	return &channels[0], nil
+}
+
+// CheckInput checks that the channel may be limited only to a server the user has access to.
+func (a *ChannelAccess) CheckInput(ctx context.Context, user *domain.User, input *ChannelInput) error {
+	if input.ServerID != nil {
+		if _, err := a.serverFinder.FindUserServer(ctx, user, *input.ServerID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
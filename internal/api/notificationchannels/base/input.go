@@ -0,0 +1,135 @@
+package base
+
+import (
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/pkg/api"
+)
+
+var (
+	ErrNameIsRequired    = api.NewValidationError("name is required")
+	ErrNameTooLong       = api.NewValidationError("name must not exceed 128 characters")
+	ErrInvalidType       = api.NewValidationError("type must be one of: discord, telegram")
+	ErrTargetIsRequired  = api.NewValidationError("target is required")
+	ErrTargetTooLong     = api.NewValidationError("target must not exceed 2048 characters")
+	ErrInvalidDiscordURL = api.NewValidationError("target must be a Discord webhook URL")
+	ErrInvalidTelegramID = api.NewValidationError("target must be a Telegram chat ID or a @channel username")
+	ErrInvalidLanguage   = api.NewValidationError("unsupported language")
+	ErrInvalidEvent      = api.NewValidationError("invalid event type")
+	ErrDuplicateEvents   = api.NewValidationError("duplicate event types are not allowed")
+)
+
+const (
+	maxNameLength   = 128
+	maxTargetLength = 2048
+
+	discordWebhookPathPrefix = "/api/webhooks/"
+)
+
+var (
+	discordHosts = []string{"discord.com", "discordapp.com", "ptb.discord.com", "canary.discord.com"}
+
+	// telegramChatPattern matches numeric chat IDs, negative for groups and channels, and public channel usernames.
+	telegramChatPattern = regexp.MustCompile(`^(-?[0-9]{1,20}|@[A-Za-z][A-Za-z0-9_]{4,31})$`)
+)
+
+// ChannelInput is the request body to create or update a notification channel.
+type ChannelInput struct {
+	Name string                         `json:"name"`
+	Type domain.NotificationChannelType `json:"type"`
+	// Target is the Discord webhook URL or the Telegram chat ID.
+	Target string `json:"target"`
+	// Language of the messages, the default language is used if empty.
+	Language string `json:"language"`
+	// ServerID limits the channel to the events of the server.
+	ServerID *uint `json:"server_id"`
+	// Events are the subscribed event types, all notification events are sent if empty.
+	Events  []domain.EventType `json:"events"`
+	Enabled *bool              `json:"enabled"`
+}
+
+// Validate checks the input, languages are the supported message languages.
+func (in *ChannelInput) Validate(languages []string) error {
+	if in.Name == "" {
+		return ErrNameIsRequired
+	}
+
+	if len(in.Name) > maxNameLength {
+		return ErrNameTooLong
+	}
+
+	if !in.Type.Valid() {
+		return ErrInvalidType
+	}
+
+	if in.Target == "" {
+		return ErrTargetIsRequired
+	}
+
+	if len(in.Target) > maxTargetLength {
+		return ErrTargetTooLong
+	}
+
+	switch in.Type {
+	case domain.NotificationChannelTypeDiscord:
+		if !isDiscordWebhookURL(in.Target) {
+			return ErrInvalidDiscordURL
+		}
+	case domain.NotificationChannelTypeTelegram:
+		if !telegramChatPattern.MatchString(in.Target) {
+			return ErrInvalidTelegramID
+		}
+	}
+
+	if in.Language != "" && !slices.Contains(languages, in.Language) {
+		return ErrInvalidLanguage
+	}
+
+	for i, event := range in.Events {
+		if !slices.Contains(domain.NotificationEventTypes, event) {
+			return ErrInvalidEvent
+		}
+
+		if slices.Contains(in.Events[:i], event) {
+			return ErrDuplicateEvents
+		}
+	}
+
+	return nil
+}
+
+// Apply sets the input fields to the channel. The language is kept when it isn't set in the input.
+func (in *ChannelInput) Apply(channel *domain.NotificationChannel) {
+	channel.Name = in.Name
+	channel.Type = in.Type
+	channel.Target = in.Target
+	channel.ServerID = in.ServerID
+	channel.Events = in.Events
+
+	if in.Language != "" {
+		channel.Language = in.Language
+	}
+
+	if in.Enabled != nil {
+		channel.Enabled = *in.Enabled
+	}
+}
+
+// isDiscordWebhookURL reports whether the URL is a Discord webhook URL.
+// Other hosts are refused, so channels can't be used to send requests to arbitrary addresses.
+func isDiscordWebhookURL(target string) bool {
+	u, err := url.Parse(target)
+	if err != nil || u.Scheme != "https" || u.User != nil || u.Port() != "" {
+		return false
+	}
+
+	if !slices.Contains(discordHosts, strings.ToLower(u.Hostname())) {
+		return false
+	}
+
+	return strings.HasPrefix(u.Path, discordWebhookPathPrefix) && len(u.Path) > len(discordWebhookPathPrefix)
+}
@@ -0,0 +1,136 @@
+package base
+
+import (
+	"testing"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChannelInput_Validate(t *testing.T) {
+	languages := []string{"en", "ru"}
+
+	tests := []struct {
+		name    string
+		input   ChannelInput
+		wantErr error
+	}{
+		{
+			name: "valid discord",
+			input: ChannelInput{
+				Name:     "Discord",
+				Type:     domain.NotificationChannelTypeDiscord,
+				Target:   "https://discord.com/api/webhooks/123/token",
+				Language: "ru",
+				Events:   []domain.EventType{domain.EventTypeServerCrashed, domain.EventTypeBackupFailed},
+			},
+		},
+		{
+			name: "valid telegram group",
+			input: ChannelInput{
+				Name:   "Telegram",
+				Type:   domain.NotificationChannelTypeTelegram,
+				Target: "-1001234567890",
+			},
+		},
+		{
+			name: "valid telegram channel username",
+			input: ChannelInput{
+				Name:   "Telegram",
+				Type:   domain.NotificationChannelTypeTelegram,
+				Target: "@gameap_status",
+			},
+		},
+		{
+			name:    "name is required",
+			input:   ChannelInput{Type: domain.NotificationChannelTypeTelegram, Target: "1"},
+			wantErr: ErrNameIsRequired,
+		},
+		{
+			name:    "unknown type",
+			input:   ChannelInput{Name: "Slack", Type: "slack", Target: "1"},
+			wantErr: ErrInvalidType,
+		},
+		{
+			name:    "target is required",
+			input:   ChannelInput{Name: "Telegram", Type: domain.NotificationChannelTypeTelegram},
+			wantErr: ErrTargetIsRequired,
+		},
+		{
+			name: "discord url with other host",
+			input: ChannelInput{
+				Name:   "Discord",
+				Type:   domain.NotificationChannelTypeDiscord,
+				Target: "https://example.com/api/webhooks/123/token",
+			},
+			wantErr: ErrInvalidDiscordURL,
+		},
+		{
+			name: "discord url without https",
+			input: ChannelInput{
+				Name:   "Discord",
+				Type:   domain.NotificationChannelTypeDiscord,
+				Target: "http://discord.com/api/webhooks/123/token",
+			},
+			wantErr: ErrInvalidDiscordURL,
+		},
+		{
+			name: "discord url with other path",
+			input: ChannelInput{
+				Name:   "Discord",
+				Type:   domain.NotificationChannelTypeDiscord,
+				Target: "https://discord.com/channels/123",
+			},
+			wantErr: ErrInvalidDiscordURL,
+		},
+		{
+			name:    "invalid telegram chat",
+			input:   ChannelInput{Name: "Telegram", Type: domain.NotificationChannelTypeTelegram, Target: "chat"},
+			wantErr: ErrInvalidTelegramID,
+		},
+		{
+			name: "unsupported language",
+			input: ChannelInput{
+				Name:     "Telegram",
+				Type:     domain.NotificationChannelTypeTelegram,
+				Target:   "1",
+				Language: "de",
+			},
+			wantErr: ErrInvalidLanguage,
+		},
+		{
+			name: "not notification event",
+			input: ChannelInput{
+				Name:   "Telegram",
+				Type:   domain.NotificationChannelTypeTelegram,
+				Target: "1",
+				Events: []domain.EventType{domain.EventTypeServerCreated},
+			},
+			wantErr: ErrInvalidEvent,
+		},
+		{
+			name: "duplicate events",
+			input: ChannelInput{
+				Name:   "Telegram",
+				Type:   domain.NotificationChannelTypeTelegram,
+				Target: "1",
+				Events: []domain.EventType{domain.EventTypeServerCrashed, domain.EventTypeServerCrashed},
+			},
+			wantErr: ErrDuplicateEvents,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.Validate(languages)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
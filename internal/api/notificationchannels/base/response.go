@@ -0,0 +1,38 @@
+package base
+
+import (
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+)
+
+type ChannelResponse struct {
+	ID        uint                           `json:"id"`
+	ServerID  *uint                          `json:"server_id"`
+	Name      string                         `json:"name"`
+	Type      domain.NotificationChannelType `json:"type"`
+	Target    string                         `json:"target"`
+	Language  string                         `json:"language"`
+	Events    []domain.EventType             `json:"events"`
+	Enabled   bool                           `json:"enabled"`
+	CreatedAt *time.Time                     `json:"created_at"`
+	UpdatedAt *time.Time                     `json:"updated_at"`
+}
+
+func NewChannelResponse(channel *domain.NotificationChannel) ChannelResponse {
+	events := make([]domain.EventType, 0, len(channel.Events))
+	events = append(events, channel.Events...)
+
+	return ChannelResponse{
+		ID:        channel.ID,
+		ServerID:  channel.ServerID,
+		Name:      channel.Name,
+		Type:      channel.Type,
+		Target:    channel.Target,
+		Language:  channel.Language,
+		Events:    events,
+		Enabled:   channel.Enabled,
+		CreatedAt: channel.CreatedAt,
+		UpdatedAt: channel.UpdatedAt,
+	}
+}
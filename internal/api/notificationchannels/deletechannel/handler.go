@@ -0,0 +1,70 @@
+package deletechannel
+
+import (
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	channelsbase "github.com/gameap/gameap/internal/api/notificationchannels/base"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+)
+
+type Handler struct {
+	channelRepo repositories.NotificationChannelRepository
+	access      *channelsbase.ChannelAccess
+	responder   base.Responder
+}
+
+func NewHandler(
+	channelRepo repositories.NotificationChannelRepository,
+	serverRepo repositories.ServerRepository,
+	rbac base.RBAC,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		channelRepo: channelRepo,
+		access:      channelsbase.NewChannelAccess(channelRepo, serverRepo, rbac),
+		responder:   responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	channelID, err := api.NewInputReader(r).ReadUint("channel")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid notification channel id"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	channel, err := h.access.FindUserChannel(ctx, session.User, channelID)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	if err = h.channelRepo.Delete(ctx, channel.ID); err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to delete notification channel"))
+
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,98 @@
+package deletechannel
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testUser  = domain.User{ID: 1, Login: "user", Email: "user@example.com"}
+	testOther = domain.User{ID: 2, Login: "other", Email: "other@example.com"}
+)
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name             string
+		user             *domain.User
+		channelID        string
+		expectedStatus   int
+		wantError        string
+		expectedChannels int
+	}{
+		{
+			name:             "user deletes own channel",
+			user:             &testUser,
+			channelID:        "1",
+			expectedStatus:   http.StatusNoContent,
+			expectedChannels: 0,
+		},
+		{
+			name:             "user can't delete channel of another user",
+			user:             &testOther,
+			channelID:        "1",
+			expectedStatus:   http.StatusNotFound,
+			wantError:        "notification channel not found",
+			expectedChannels: 1,
+		},
+		{
+			name:             "user not authenticated",
+			channelID:        "1",
+			expectedStatus:   http.StatusUnauthorized,
+			wantError:        "user not authenticated",
+			expectedChannels: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			channelRepo := inmemory.NewNotificationChannelRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), inmemory.NewRBACRepository(), 0)
+			handler := NewHandler(channelRepo, inmemory.NewServerRepository(), rbacService, api.NewResponder())
+
+			require.NoError(t, channelRepo.Save(ctx, &domain.NotificationChannel{
+				UserID: testUser.ID,
+				Name:   "Own",
+				Type:   domain.NotificationChannelTypeTelegram,
+				Target: "100",
+			}))
+
+			if tt.user != nil {
+				ctx = auth.ContextWithSession(ctx, &auth.Session{
+					Login: tt.user.Login,
+					Email: tt.user.Email,
+					User:  tt.user,
+				})
+			}
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/notification_channels/"+tt.channelID, nil)
+			req = req.WithContext(ctx)
+			req = mux.SetURLVars(req, map[string]string{"channel": tt.channelID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+
+			if tt.wantError != "" {
+				assert.Contains(t, w.Body.String(), tt.wantError)
+			}
+
+			channels, err := channelRepo.Find(ctx, nil, nil, nil)
+			require.NoError(t, err)
+			assert.Len(t, channels, tt.expectedChannels)
+		})
+	}
+}
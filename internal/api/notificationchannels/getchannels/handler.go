@@ -0,0 +1,61 @@
+package getchannels
+
+import (
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	channelsbase "github.com/gameap/gameap/internal/api/notificationchannels/base"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+)
+
+// Handler returns the notification channels of the user.
+type Handler struct {
+	channelRepo repositories.NotificationChannelRepository
+	responder   base.Responder
+}
+
+func NewHandler(
+	channelRepo repositories.NotificationChannelRepository,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		channelRepo: channelRepo,
+		responder:   responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	channels, err := h.channelRepo.Find(ctx, &filters.FindNotificationChannel{
+		UserIDs: []uint{session.User.ID},
+	}, []filters.Sorting{
+		{Field: "id", Direction: filters.SortDirectionAsc},
+	}, nil)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to find notification channels"))
+
+		return
+	}
+
+	response := make([]channelsbase.ChannelResponse, 0, len(channels))
+	for i := range channels {
+		response = append(response, channelsbase.NewChannelResponse(&channels[i]))
+	}
+
+	h.responder.Write(ctx, rw, response)
+}
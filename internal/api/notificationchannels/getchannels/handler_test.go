@@ -0,0 +1,83 @@
+package getchannels
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUser = domain.User{ID: 1, Login: "user", Email: "user@example.com"}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		user           *domain.User
+		expectedStatus int
+		expectedIDs    []uint
+	}{
+		{
+			name:           "user gets own channels",
+			user:           &testUser,
+			expectedStatus: http.StatusOK,
+			expectedIDs:    []uint{1, 3},
+		},
+		{
+			name:           "user not authenticated",
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			channelRepo := inmemory.NewNotificationChannelRepository()
+			handler := NewHandler(channelRepo, api.NewResponder())
+
+			for _, channel := range []*domain.NotificationChannel{
+				{UserID: testUser.ID, Name: "Own", Type: domain.NotificationChannelTypeTelegram, Target: "1"},
+				{UserID: 3, Name: "Other", Type: domain.NotificationChannelTypeTelegram, Target: "2"},
+				{UserID: testUser.ID, Name: "Own 2", Type: domain.NotificationChannelTypeTelegram, Target: "3"},
+			} {
+				require.NoError(t, channelRepo.Save(ctx, channel))
+			}
+
+			if tt.user != nil {
+				ctx = auth.ContextWithSession(ctx, &auth.Session{
+					Login: tt.user.Login,
+					Email: tt.user.Email,
+					User:  tt.user,
+				})
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/notification_channels", nil)
+			req = req.WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response []map[string]any
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+			ids := make([]uint, 0, len(response))
+			for _, channel := range response {
+				ids = append(ids, uint(channel["id"].(float64)))
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+		})
+	}
+}
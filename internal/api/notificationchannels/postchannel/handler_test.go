@@ -0,0 +1,176 @@
+package postchannel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUser = domain.User{ID: 1, Login: "user", Email: "user@example.com"}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		user           *domain.User
+		body           string
+		expectedStatus int
+		wantError      string
+		validate       func(t *testing.T, channel *domain.NotificationChannel, response map[string]any)
+	}{
+		{
+			name: "user creates discord channel",
+			user: &testUser,
+			body: `{
+				"name": "Discord",
+				"type": "discord",
+				"target": "https://discord.com/api/webhooks/123/token",
+				"events": ["server.crashed", "backup.failed"]
+			}`,
+			expectedStatus: http.StatusCreated,
+			validate: func(t *testing.T, channel *domain.NotificationChannel, response map[string]any) {
+				t.Helper()
+
+				assert.Equal(t, testUser.ID, channel.UserID)
+				assert.Nil(t, channel.ServerID)
+				assert.Equal(t, domain.NotificationChannelTypeDiscord, channel.Type)
+				assert.Equal(t, "en", channel.Language)
+				assert.True(t, channel.Enabled)
+				assert.Equal(t, domain.NotificationEvents{
+					domain.EventTypeServerCrashed,
+					domain.EventTypeBackupFailed,
+				}, channel.Events)
+
+				assert.Equal(t, "discord", response["type"])
+				assert.Equal(t, "en", response["language"])
+			},
+		},
+		{
+			name: "user creates telegram channel for own server",
+			user: &testUser,
+			body: `{
+				"name": "Telegram",
+				"type": "telegram",
+				"target": "-100500",
+				"language": "ru",
+				"server_id": 1,
+				"enabled": false
+			}`,
+			expectedStatus: http.StatusCreated,
+			validate: func(t *testing.T, channel *domain.NotificationChannel, _ map[string]any) {
+				t.Helper()
+
+				assert.Equal(t, lo.ToPtr(uint(1)), channel.ServerID)
+				assert.Equal(t, "-100500", channel.Target)
+				assert.Equal(t, "ru", channel.Language)
+				assert.False(t, channel.Enabled)
+				assert.Empty(t, channel.Events)
+			},
+		},
+		{
+			name:           "user can't create channel for server of another user",
+			user:           &testUser,
+			body:           `{"name": "Telegram", "type": "telegram", "target": "1", "server_id": 2}`,
+			expectedStatus: http.StatusNotFound,
+			wantError:      "server not found",
+		},
+		{
+			name:           "discord url of another host",
+			user:           &testUser,
+			body:           `{"name": "Discord", "type": "discord", "target": "https://example.com/api/webhooks/1/t"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "target must be a Discord webhook URL",
+		},
+		{
+			name:           "unsupported language",
+			user:           &testUser,
+			body:           `{"name": "Telegram", "type": "telegram", "target": "1", "language": "de"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "unsupported language",
+		},
+		{
+			name:           "invalid request body",
+			user:           &testUser,
+			body:           `{`,
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "invalid request body",
+		},
+		{
+			name:           "user not authenticated",
+			body:           `{"name": "Telegram", "type": "telegram", "target": "1"}`,
+			expectedStatus: http.StatusUnauthorized,
+			wantError:      "user not authenticated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			channelRepo := inmemory.NewNotificationChannelRepository()
+			serverRepo := inmemory.NewServerRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), inmemory.NewRBACRepository(), 0)
+			handler := NewHandler(
+				channelRepo,
+				serverRepo,
+				rbacService,
+				[]string{"en", "ru"},
+				"en",
+				api.NewResponder(),
+			)
+
+			require.NoError(t, serverRepo.Save(ctx, &domain.Server{ID: 1, UUID: uuid.New(), Name: "Own"}))
+			serverRepo.AddUserServer(testUser.ID, 1)
+			require.NoError(t, serverRepo.Save(ctx, &domain.Server{ID: 2, UUID: uuid.New(), Name: "Other"}))
+			serverRepo.AddUserServer(3, 2)
+
+			if tt.user != nil {
+				ctx = auth.ContextWithSession(ctx, &auth.Session{
+					Login: tt.user.Login,
+					Email: tt.user.Email,
+					User:  tt.user,
+				})
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/notification_channels", bytes.NewBufferString(tt.body))
+			req = req.WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+
+			if tt.wantError != "" {
+				assert.Contains(t, w.Body.String(), tt.wantError)
+
+				channels, err := channelRepo.Find(ctx, nil, nil, nil)
+				require.NoError(t, err)
+				assert.Empty(t, channels)
+
+				return
+			}
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+			channels, err := channelRepo.Find(ctx, nil, nil, nil)
+			require.NoError(t, err)
+			require.Len(t, channels, 1)
+			assert.InDelta(t, float64(channels[0].ID), response["id"], 0)
+
+			tt.validate(t, &channels[0], response)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package posttest
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	channelsbase "github.com/gameap/gameap/internal/api/notificationchannels/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+)
+
+type channelTester interface {
+	Test(ctx context.Context, channel *domain.NotificationChannel) error
+}
+
+// Handler sends a test message to a notification channel.
+// A failed message isn't an error of the request, the response contains the error.
+type Handler struct {
+	access    *channelsbase.ChannelAccess
+	tester    channelTester
+	responder base.Responder
+}
+
+func NewHandler(
+	channelRepo repositories.NotificationChannelRepository,
+	serverRepo repositories.ServerRepository,
+	rbac base.RBAC,
+	tester channelTester,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		access:    channelsbase.NewChannelAccess(channelRepo, serverRepo, rbac),
+		tester:    tester,
+		responder: responder,
+	}
+}
+
+type response struct {
+	Sent  bool    `json:"sent"`
+	Error *string `json:"error"`
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	channelID, err := api.NewInputReader(r).ReadUint("channel")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid notification channel id"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	channel, err := h.access.FindUserChannel(ctx, session.User, channelID)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	if err = h.tester.Test(ctx, channel); err != nil {
+		msg := err.Error()
+
+		h.responder.Write(ctx, rw, response{Sent: false, Error: &msg})
+
+		return
+	}
+
+	h.responder.Write(ctx, rw, response{Sent: true})
+}
@@ -0,0 +1,150 @@
+package posttest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/i18n"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/notifications"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testUser  = domain.User{ID: 1, Login: "user", Email: "user@example.com"}
+	testOther = domain.User{ID: 2, Login: "other", Email: "other@example.com"}
+)
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		user           *domain.User
+		channelID      string
+		responseStatus int
+		expectedStatus int
+		wantError      string
+		wantSent       bool
+	}{
+		{
+			name:           "message is sent",
+			user:           &testUser,
+			channelID:      "1",
+			responseStatus: http.StatusOK,
+			expectedStatus: http.StatusOK,
+			wantSent:       true,
+		},
+		{
+			name:           "message is rejected by telegram",
+			user:           &testUser,
+			channelID:      "1",
+			responseStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusOK,
+			wantError:      "unexpected response status 400",
+		},
+		{
+			name:           "channel of another user",
+			user:           &testOther,
+			channelID:      "1",
+			expectedStatus: http.StatusNotFound,
+			wantError:      "notification channel not found",
+		},
+		{
+			name:           "user not authenticated",
+			channelID:      "1",
+			expectedStatus: http.StatusUnauthorized,
+			wantError:      "user not authenticated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var received map[string]any
+			telegram := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/bot123:abc/sendMessage", r.URL.Path)
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+				rw.WriteHeader(tt.responseStatus)
+			}))
+			defer telegram.Close()
+
+			translator, err := i18n.NewTranslator()
+			require.NoError(t, err)
+
+			channelRepo := inmemory.NewNotificationChannelRepository()
+			serverRepo := inmemory.NewServerRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), inmemory.NewRBACRepository(), 0)
+			service := notifications.NewService(
+				channelRepo,
+				serverRepo,
+				rbacService,
+				translator,
+				map[domain.NotificationChannelType]notifications.Sender{
+					domain.NotificationChannelTypeTelegram: notifications.NewTelegramSender(
+						telegram.Client(), telegram.URL, "123:abc",
+					),
+				},
+				time.Second,
+			)
+			handler := NewHandler(channelRepo, serverRepo, rbacService, service, api.NewResponder())
+
+			require.NoError(t, channelRepo.Save(ctx, &domain.NotificationChannel{
+				UserID:   testUser.ID,
+				Name:     "Own",
+				Type:     domain.NotificationChannelTypeTelegram,
+				Target:   "100500",
+				Language: "en",
+				Enabled:  true,
+			}))
+
+			if tt.user != nil {
+				ctx = auth.ContextWithSession(ctx, &auth.Session{
+					Login: tt.user.Login,
+					Email: tt.user.Email,
+					User:  tt.user,
+				})
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/notification_channels/"+tt.channelID+"/test", nil)
+			req = req.WithContext(ctx)
+			req = mux.SetURLVars(req, map[string]string{"channel": tt.channelID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+
+			if tt.expectedStatus != http.StatusOK {
+				assert.Contains(t, w.Body.String(), tt.wantError)
+				assert.Nil(t, received)
+
+				return
+			}
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.wantSent, response["sent"])
+
+			if tt.wantError != "" {
+				assert.Contains(t, response["error"], tt.wantError)
+			} else {
+				assert.Nil(t, response["error"])
+			}
+
+			require.NotNil(t, received)
+			assert.Equal(t, "100500", received["chat_id"])
+			assert.Equal(t, "Test notification from GameAP.", received["text"])
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package putchannel
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gameap/gameap/internal/api/base"
+	channelsbase "github.com/gameap/gameap/internal/api/notificationchannels/base"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+type Handler struct {
+	channelRepo repositories.NotificationChannelRepository
+	access      *channelsbase.ChannelAccess
+	languages   []string
+	responder   base.Responder
+}
+
+func NewHandler(
+	channelRepo repositories.NotificationChannelRepository,
+	serverRepo repositories.ServerRepository,
+	rbac base.RBAC,
+	languages []string,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		channelRepo: channelRepo,
+		access:      channelsbase.NewChannelAccess(channelRepo, serverRepo, rbac),
+		languages:   languages,
+		responder:   responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	channelID, err := api.NewInputReader(r).ReadUint("channel")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid notification channel id"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	input := &channelsbase.ChannelInput{}
+	if err = json.NewDecoder(r.Body).Decode(input); err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid request body"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if err = input.Validate(h.languages); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	channel, err := h.access.FindUserChannel(ctx, session.User, channelID)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	if err = h.access.CheckInput(ctx, session.User, input); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	input.Apply(channel)
+	channel.UpdatedAt = lo.ToPtr(time.Now())
+
+	if err = h.channelRepo.Save(ctx, channel); err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to save notification channel"))
+
+		return
+	}
+
+	h.responder.Write(ctx, rw, channelsbase.NewChannelResponse(channel))
+}
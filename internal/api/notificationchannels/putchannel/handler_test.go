@@ -0,0 +1,161 @@
+package putchannel
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testUser  = domain.User{ID: 1, Login: "user", Email: "user@example.com"}
+	testOther = domain.User{ID: 2, Login: "other", Email: "other@example.com"}
+)
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		user           *domain.User
+		channelID      string
+		body           string
+		expectedStatus int
+		wantError      string
+		validate       func(t *testing.T, channel *domain.NotificationChannel)
+	}{
+		{
+			name:      "user updates own channel and keeps language",
+			user:      &testUser,
+			channelID: "1",
+			body: `{
+				"name": "Renamed",
+				"type": "discord",
+				"target": "https://discord.com/api/webhooks/1/token",
+				"events": ["server.update_finished"],
+				"enabled": false
+			}`,
+			expectedStatus: http.StatusOK,
+			validate: func(t *testing.T, channel *domain.NotificationChannel) {
+				t.Helper()
+
+				assert.Equal(t, "Renamed", channel.Name)
+				assert.Equal(t, domain.NotificationChannelTypeDiscord, channel.Type)
+				assert.Equal(t, "https://discord.com/api/webhooks/1/token", channel.Target)
+				assert.Equal(t, "ru", channel.Language)
+				assert.Equal(t, domain.NotificationEvents{domain.EventTypeServerUpdateFinished}, channel.Events)
+				assert.False(t, channel.Enabled)
+				assert.Equal(t, testUser.ID, channel.UserID)
+			},
+		},
+		{
+			name:           "user changes language",
+			user:           &testUser,
+			channelID:      "1",
+			body:           `{"name": "Own", "type": "telegram", "target": "100", "language": "en"}`,
+			expectedStatus: http.StatusOK,
+			validate: func(t *testing.T, channel *domain.NotificationChannel) {
+				t.Helper()
+
+				assert.Equal(t, "en", channel.Language)
+				assert.True(t, channel.Enabled)
+			},
+		},
+		{
+			name:           "user can't update channel of another user",
+			user:           &testOther,
+			channelID:      "1",
+			body:           `{"name": "Own", "type": "telegram", "target": "100"}`,
+			expectedStatus: http.StatusNotFound,
+			wantError:      "notification channel not found",
+		},
+		{
+			name:           "invalid target",
+			user:           &testUser,
+			channelID:      "1",
+			body:           `{"name": "Own", "type": "telegram", "target": "https://t.me/chat"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "target must be a Telegram chat ID",
+		},
+		{
+			name:           "invalid channel id",
+			user:           &testUser,
+			channelID:      "invalid",
+			body:           `{"name": "Own", "type": "telegram", "target": "100"}`,
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "invalid notification channel id",
+		},
+		{
+			name:           "user not authenticated",
+			channelID:      "1",
+			body:           `{"name": "Own", "type": "telegram", "target": "100"}`,
+			expectedStatus: http.StatusUnauthorized,
+			wantError:      "user not authenticated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			channelRepo := inmemory.NewNotificationChannelRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), inmemory.NewRBACRepository(), 0)
+			handler := NewHandler(
+				channelRepo,
+				inmemory.NewServerRepository(),
+				rbacService,
+				[]string{"en", "ru"},
+				api.NewResponder(),
+			)
+
+			require.NoError(t, channelRepo.Save(ctx, &domain.NotificationChannel{
+				UserID:   testUser.ID,
+				Name:     "Own",
+				Type:     domain.NotificationChannelTypeTelegram,
+				Target:   "100",
+				Language: "ru",
+				Enabled:  true,
+			}))
+
+			if tt.user != nil {
+				ctx = auth.ContextWithSession(ctx, &auth.Session{
+					Login: tt.user.Login,
+					Email: tt.user.Email,
+					User:  tt.user,
+				})
+			}
+
+			req := httptest.NewRequest(
+				http.MethodPut, "/api/notification_channels/"+tt.channelID, bytes.NewBufferString(tt.body),
+			)
+			req = req.WithContext(ctx)
+			req = mux.SetURLVars(req, map[string]string{"channel": tt.channelID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+
+			if tt.wantError != "" {
+				assert.Contains(t, w.Body.String(), tt.wantError)
+
+				return
+			}
+
+			channels, err := channelRepo.Find(ctx, &filters.FindNotificationChannel{IDs: []uint{1}}, nil, nil)
+			require.NoError(t, err)
+			require.Len(t, channels, 1)
+
+			tt.validate(t, &channels[0])
+		})
+	}
+}
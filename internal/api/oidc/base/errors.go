@@ -0,0 +1,37 @@
+package base
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	"github.com/gameap/gameap/internal/services/oidc"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+// WriteError writes the OpenID Connect service error with the matching status code.
+// The details of the provider errors are logged and not returned to the client.
+func WriteError(ctx context.Context, rw http.ResponseWriter, responder base.Responder, err error, message string) {
+	switch {
+	case errors.Is(err, oidc.ErrUnknownProvider):
+		responder.WriteError(ctx, rw, api.WrapHTTPError(oidc.ErrUnknownProvider, http.StatusNotFound))
+	case errors.Is(err, oidc.ErrInvalidState):
+		responder.WriteError(ctx, rw, api.WrapHTTPError(oidc.ErrInvalidState, http.StatusUnauthorized))
+	case errors.Is(err, oidc.ErrInvalidGrant):
+		slog.WarnContext(ctx, "Identity provider rejected the authorization code", slog.String("error", err.Error()))
+		responder.WriteError(ctx, rw, api.WrapHTTPError(oidc.ErrInvalidGrant, http.StatusUnauthorized))
+	case errors.Is(err, oidc.ErrInvalidIDToken):
+		slog.WarnContext(ctx, "Identity provider returned invalid id token", slog.String("error", err.Error()))
+		responder.WriteError(ctx, rw, api.WrapHTTPError(oidc.ErrInvalidIDToken, http.StatusUnauthorized))
+	case errors.Is(err, oidc.ErrNotLinked),
+		errors.Is(err, oidc.ErrEmailRequired),
+		errors.Is(err, oidc.ErrUserDisabled):
+		responder.WriteError(ctx, rw, api.WrapHTTPError(err, http.StatusForbidden))
+	case errors.Is(err, oidc.ErrProviderUnavailable):
+		responder.WriteError(ctx, rw, api.WrapHTTPError(err, http.StatusBadGateway))
+	default:
+		responder.WriteError(ctx, rw, errors.WithMessage(err, message))
+	}
+}
@@ -0,0 +1,29 @@
+package getproviders
+
+import (
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	"github.com/gameap/gameap/internal/services/oidc"
+)
+
+type providersLister interface {
+	Providers() []oidc.ProviderInfo
+}
+
+// Handler lists the identity providers for the login page.
+type Handler struct {
+	service   providersLister
+	responder base.Responder
+}
+
+func NewHandler(service providersLister, responder base.Responder) *Handler {
+	return &Handler{
+		service:   service,
+		responder: responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	h.responder.Write(r.Context(), rw, newProvidersResponse(h.service.Providers()))
+}
@@ -0,0 +1,67 @@
+package getproviders
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/oidc"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		providers []oidc.ProviderConfig
+		want      []map[string]any
+	}{
+		{
+			name: "configured_providers",
+			providers: []oidc.ProviderConfig{
+				{Name: "keycloak", DisplayName: "Company SSO"},
+				{Name: "google"},
+			},
+			want: []map[string]any{
+				{"name": "keycloak", "display_name": "Company SSO"},
+				{"name": "google", "display_name": "google"},
+			},
+		},
+		{
+			name: "no_providers",
+			want: []map[string]any{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service := oidc.NewService(
+				test.providers,
+				http.DefaultClient,
+				inmemory.NewUserIdentityRepository(),
+				inmemory.NewUserRepository(),
+				nil,
+				services.NewNilTransactionManager(),
+				cache.NewInMemory(),
+				oidc.Config{StateTTL: time.Minute},
+			)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/auth/oidc/providers", nil)
+			w := httptest.NewRecorder()
+
+			NewHandler(service, api.NewResponder()).ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+
+			var response []map[string]any
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, test.want, response)
+		})
+	}
+}
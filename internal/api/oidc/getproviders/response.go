@@ -0,0 +1,21 @@
+package getproviders
+
+import "github.com/gameap/gameap/internal/services/oidc"
+
+type providerResponse struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+}
+
+func newProvidersResponse(providers []oidc.ProviderInfo) []providerResponse {
+	response := make([]providerResponse, 0, len(providers))
+
+	for _, p := range providers {
+		response = append(response, providerResponse{
+			Name:        p.Name,
+			DisplayName: p.DisplayName,
+		})
+	}
+
+	return response
+}
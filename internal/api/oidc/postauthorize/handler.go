@@ -0,0 +1,55 @@
+package postauthorize
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	oidcbase "github.com/gameap/gameap/internal/api/oidc/base"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+type authorizer interface {
+	AuthorizationURL(ctx context.Context, providerName string) (string, error)
+}
+
+// Handler starts the login with an identity provider, the client redirects the user to the returned URL.
+type Handler struct {
+	service   authorizer
+	responder base.Responder
+}
+
+func NewHandler(service authorizer, responder base.Responder) *Handler {
+	return &Handler{
+		service:   service,
+		responder: responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	providerName, err := api.NewInputReader(r).ReadString("provider")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid provider"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	authURL, err := h.service.AuthorizationURL(ctx, providerName)
+	if err != nil {
+		oidcbase.WriteError(ctx, rw, h.responder, err, "failed to start login")
+
+		return
+	}
+
+	h.responder.Write(ctx, rw, authorizeResponse{AuthorizationURL: authURL})
+}
+
+type authorizeResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+}
@@ -0,0 +1,105 @@
+package postauthorize
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/oidc"
+	"github.com/gameap/gameap/internal/services/oidc/oidctest"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setup(t *testing.T, issuerURL string) *Handler {
+	t.Helper()
+
+	service := oidc.NewService(
+		[]oidc.ProviderConfig{{
+			Name:        "keycloak",
+			IssuerURL:   issuerURL,
+			ClientID:    "gameap",
+			RedirectURL: "https://panel.example.com/auth/oidc/keycloak/callback",
+			Scopes:      []string{"openid", "email"},
+		}},
+		http.DefaultClient,
+		inmemory.NewUserIdentityRepository(),
+		inmemory.NewUserRepository(),
+		nil,
+		services.NewNilTransactionManager(),
+		cache.NewInMemory(),
+		oidc.Config{StateTTL: time.Minute},
+	)
+
+	return NewHandler(service, api.NewResponder())
+}
+
+func serve(t *testing.T, handler *Handler, provider string) (*httptest.ResponseRecorder, map[string]any) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/oidc/"+provider+"/authorize", nil)
+	req = mux.SetURLVars(req, map[string]string{"provider": provider})
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	var response map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response), w.Body.String())
+
+	return w, response
+}
+
+func TestHandler_Success(t *testing.T) {
+	server := oidctest.NewServer(t, "gameap", "")
+	handler := setup(t, server.Issuer())
+
+	w, response := serve(t, handler, "keycloak")
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	authURL, ok := response["authorization_url"].(string)
+	require.True(t, ok)
+
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+	assert.Equal(t, server.Issuer()+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+
+	q := u.Query()
+	assert.Equal(t, "code", q.Get("response_type"))
+	assert.Equal(t, "gameap", q.Get("client_id"))
+	assert.Equal(t, "https://panel.example.com/auth/oidc/keycloak/callback", q.Get("redirect_uri"))
+	assert.Equal(t, "openid email", q.Get("scope"))
+	assert.Equal(t, "S256", q.Get("code_challenge_method"))
+	assert.NotEmpty(t, q.Get("code_challenge"))
+	assert.NotEmpty(t, q.Get("state"))
+	assert.NotEmpty(t, q.Get("nonce"))
+}
+
+func TestHandler_UnknownProvider(t *testing.T) {
+	server := oidctest.NewServer(t, "gameap", "")
+	handler := setup(t, server.Issuer())
+
+	w, response := serve(t, handler, "unknown")
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "identity provider is not configured", response["message"])
+}
+
+func TestHandler_ProviderUnavailable(t *testing.T) {
+	server := oidctest.NewServer(t, "gameap", "")
+	handler := setup(t, server.Issuer())
+	server.Close()
+
+	w, response := serve(t, handler, "keycloak")
+
+	require.Equal(t, http.StatusBadGateway, w.Code)
+	assert.Equal(t, "Bad Gateway", response["message"])
+}
@@ -0,0 +1,114 @@
+package postcallback
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/auth/login"
+	"github.com/gameap/gameap/internal/api/base"
+	oidcbase "github.com/gameap/gameap/internal/api/oidc/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/services/twofactor"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+)
+
+type authenticator interface {
+	Authenticate(ctx context.Context, providerName, state, code string) (*domain.User, error)
+}
+
+type twoFactorChallenger interface {
+	BeginLogin(ctx context.Context, userID uint, remember bool) (*twofactor.Challenge, error)
+}
+
+// Handler completes the login with an identity provider.
+// The client passes the code and the state the provider has sent to the redirect URL.
+// Users with two-factor authentication get the challenge instead of the token as with the password login.
+type Handler struct {
+	authService auth.Service
+	service     authenticator
+	twoFactor   twoFactorChallenger
+	responder   base.Responder
+}
+
+func NewHandler(
+	authService auth.Service,
+	service authenticator,
+	twoFactor twoFactorChallenger,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		authService: authService,
+		service:     service,
+		twoFactor:   twoFactor,
+		responder:   responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	providerName, err := api.NewInputReader(r).ReadString("provider")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid provider"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	input := &callbackInput{}
+
+	err = json.NewDecoder(r.Body).Decode(input)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid request body"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if err = input.Validate(); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	user, err := h.service.Authenticate(ctx, providerName, input.State, input.Code)
+	if err != nil {
+		oidcbase.WriteError(ctx, rw, h.responder, err, "failed to complete login")
+
+		return
+	}
+
+	challenge, err := h.twoFactor.BeginLogin(ctx, user.ID, input.RememberMe())
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to begin two-factor login"))
+
+		return
+	}
+
+	if challenge != nil {
+		h.responder.Write(ctx, rw, newTwoFactorResponse(challenge))
+
+		return
+	}
+
+	duration := login.DefaultTokenDuration
+	if input.RememberMe() {
+		duration = login.RememberMeDuration
+	}
+
+	token, err := h.authService.GenerateTokenForUser(user, duration)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to generate token"))
+
+		return
+	}
+
+	h.responder.Write(ctx, rw, newLoginResponse(user, token, duration))
+}
@@ -0,0 +1,242 @@
+package postcallback
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/oidc"
+	"github.com/gameap/gameap/internal/services/oidc/oidctest"
+	"github.com/gameap/gameap/internal/services/twofactor"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRBAC struct {
+	roles map[uint][]string
+}
+
+func (f *fakeRBAC) SetRolesToUser(_ context.Context, userID uint, roleNames []string) error {
+	f.roles[userID] = roleNames
+
+	return nil
+}
+
+func (f *fakeRBAC) Can(_ context.Context, _ uint, _ []domain.AbilityName) (bool, error) {
+	return false, nil
+}
+
+type testEnv struct {
+	handler   *Handler
+	service   *oidc.Service
+	twoFactor *twofactor.Service
+	server    *oidctest.Server
+	userRepo  *inmemory.UserRepository
+	rbac      *fakeRBAC
+}
+
+func setup(t *testing.T) *testEnv {
+	t.Helper()
+
+	server := oidctest.NewServer(t, "gameap", "secret")
+	userRepo := inmemory.NewUserRepository()
+	rbac := &fakeRBAC{roles: map[uint][]string{}}
+	c := cache.NewInMemory()
+
+	service := oidc.NewService(
+		[]oidc.ProviderConfig{{
+			Name:          "keycloak",
+			IssuerURL:     server.Issuer(),
+			ClientID:      "gameap",
+			ClientSecret:  "secret",
+			RedirectURL:   "https://panel.example.com/auth/oidc/keycloak/callback",
+			Scopes:        []string{"openid", "profile", "email"},
+			AutoProvision: true,
+			LinkByEmail:   true,
+			RolesClaim:    "groups",
+			RoleMapping:   map[string]string{"gameap-admins": "admin"},
+			DefaultRoles:  []string{"user"},
+		}},
+		http.DefaultClient,
+		inmemory.NewUserIdentityRepository(),
+		userRepo,
+		rbac,
+		services.NewNilTransactionManager(),
+		c,
+		oidc.Config{StateTTL: time.Minute},
+	)
+
+	twoFactor := twofactor.NewService(
+		inmemory.NewUserTwoFactorRepository(),
+		userRepo,
+		rbac,
+		c,
+		cache.NewRateLimiter(c, "two_factor", 3, time.Minute),
+		twofactor.Config{Issuer: "GameAP", ChallengeTTL: 5 * time.Minute},
+	)
+
+	return &testEnv{
+		handler:   NewHandler(auth.NewJWTService([]byte("test-secret-key")), service, twoFactor, api.NewResponder()),
+		service:   service,
+		twoFactor: twoFactor,
+		server:    server,
+		userRepo:  userRepo,
+		rbac:      rbac,
+	}
+}
+
+// authorize emulates the user signing in at the provider and returns the code and the state.
+func (e *testEnv) authorize(t *testing.T, claims jwt.MapClaims) (string, string) {
+	t.Helper()
+
+	authURL, err := e.service.AuthorizationURL(context.Background(), "keycloak")
+	require.NoError(t, err)
+
+	return e.server.Login(t, authURL, claims)
+}
+
+func (e *testEnv) serve(t *testing.T, body string) (*httptest.ResponseRecorder, map[string]any) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/oidc/keycloak/callback", strings.NewReader(body))
+	req = mux.SetURLVars(req, map[string]string{"provider": "keycloak"})
+	w := httptest.NewRecorder()
+
+	e.handler.ServeHTTP(w, req)
+
+	var response map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response), w.Body.String())
+
+	return w, response
+}
+
+func TestHandler_ProvisionsUser(t *testing.T) {
+	env := setup(t)
+
+	code, state := env.authorize(t, jwt.MapClaims{
+		"sub":                "5f1c0a3e",
+		"email":              "john@example.com",
+		"preferred_username": "john",
+		"groups":             []any{"gameap-admins"},
+	})
+
+	w, response := env.serve(t, `{"code": "`+code+`", "state": "`+state+`", "remember": "true"}`)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.NotEmpty(t, response["token"])
+	assert.Equal(t, float64(30*24*60*60), response["expires_in"])
+
+	user, ok := response["user"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "john", user["login"])
+	assert.Equal(t, "john@example.com", user["email"])
+
+	users, err := env.userRepo.FindAll(context.Background(), nil, nil)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, []string{"admin"}, env.rbac.roles[users[0].ID])
+
+	// The state can't be used again
+	w, response = env.serve(t, `{"code": "`+code+`", "state": "`+state+`"}`)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, "login state is invalid or expired", response["message"])
+}
+
+func TestHandler_TwoFactorRequired(t *testing.T) {
+	env := setup(t)
+
+	user := &domain.User{Login: "john", Email: "john@example.com"}
+	require.NoError(t, env.userRepo.Save(context.Background(), user))
+
+	enrollment, err := env.twoFactor.StartEnrollment(context.Background(), user)
+	require.NoError(t, err)
+
+	totp, err := twofactor.Code(enrollment.Secret, time.Now())
+	require.NoError(t, err)
+
+	_, err = env.twoFactor.ConfirmEnrollment(context.Background(), user.ID, totp)
+	require.NoError(t, err)
+
+	code, state := env.authorize(t, jwt.MapClaims{
+		"sub":            "5f1c0a3e",
+		"email":          "john@example.com",
+		"email_verified": true,
+	})
+
+	w, response := env.serve(t, `{"code": "`+code+`", "state": "`+state+`"}`)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, true, response["two_factor_required"])
+	assert.Equal(t, false, response["two_factor_setup_required"])
+	assert.NotEmpty(t, response["two_factor_token"])
+	assert.NotContains(t, response, "token")
+}
+
+func TestHandler_EmailNotVerified(t *testing.T) {
+	env := setup(t)
+
+	require.NoError(t, env.userRepo.Save(context.Background(), &domain.User{
+		Login: "john",
+		Email: "john@example.com",
+	}))
+
+	code, state := env.authorize(t, jwt.MapClaims{
+		"sub":   "5f1c0a3e",
+		"email": "john@example.com",
+	})
+
+	w, response := env.serve(t, `{"code": "`+code+`", "state": "`+state+`"}`)
+
+	require.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, response["message"], "account is not linked to a user")
+}
+
+func TestHandler_InvalidCode(t *testing.T) {
+	env := setup(t)
+
+	_, state := env.authorize(t, jwt.MapClaims{"sub": "5f1c0a3e"})
+
+	w, response := env.serve(t, `{"code": "invalid", "state": "`+state+`"}`)
+
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, "authorization code is invalid or expired", response["message"])
+}
+
+func TestHandler_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		code    int
+		message string
+	}{
+		{name: "invalid_json", body: `{`, code: http.StatusBadRequest},
+		{name: "missing_code", body: `{"state": "state"}`, code: http.StatusUnprocessableEntity, message: "code is required"},
+		{name: "missing_state", body: `{"code": "code"}`, code: http.StatusUnprocessableEntity, message: "state is required"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			env := setup(t)
+
+			w, response := env.serve(t, test.body)
+
+			require.Equal(t, test.code, w.Code)
+
+			if test.message != "" {
+				assert.Equal(t, test.message, response["message"])
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package postcallback
+
+import (
+	"github.com/gameap/gameap/pkg/api"
+)
+
+var (
+	ErrCodeRequired  = api.NewValidationError("code is required")
+	ErrStateRequired = api.NewValidationError("state is required")
+)
+
+// callbackInput is the query of the redirect from the identity provider, passed by the client.
+type callbackInput struct {
+	Code     string `json:"code"`
+	State    string `json:"state"`
+	Remember string `json:"remember"`
+}
+
+func (in *callbackInput) Validate() error {
+	if in.Code == "" {
+		return ErrCodeRequired
+	}
+
+	if in.State == "" {
+		return ErrStateRequired
+	}
+
+	return nil
+}
+
+func (in *callbackInput) RememberMe() bool {
+	return in.Remember == "on" || in.Remember == "true"
+}
@@ -0,0 +1,49 @@
+package postcallback
+
+import (
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/services/twofactor"
+)
+
+type loginResponse struct {
+	Token     string   `json:"token"`
+	ExpiresIn int64    `json:"expires_in"` // Token expiration in seconds
+	User      userInfo `json:"user"`
+}
+
+type userInfo struct {
+	Login string  `json:"login"`
+	Email string  `json:"email"`
+	Name  *string `json:"name"`
+}
+
+func newLoginResponse(user *domain.User, token string, expiresIn time.Duration) loginResponse {
+	return loginResponse{
+		Token:     token,
+		ExpiresIn: int64(expiresIn.Seconds()),
+		User: userInfo{
+			Login: user.Login,
+			Email: user.Email,
+			Name:  user.Name,
+		},
+	}
+}
+
+// twoFactorResponse is returned instead of the token when the login needs the second step.
+type twoFactorResponse struct {
+	TwoFactorRequired      bool   `json:"two_factor_required"`
+	TwoFactorSetupRequired bool   `json:"two_factor_setup_required"`
+	TwoFactorToken         string `json:"two_factor_token"`
+	ExpiresIn              int64  `json:"expires_in"` // Two-factor token expiration in seconds
+}
+
+func newTwoFactorResponse(challenge *twofactor.Challenge) twoFactorResponse {
+	return twoFactorResponse{
+		TwoFactorRequired:      true,
+		TwoFactorSetupRequired: challenge.SetupRequired,
+		TwoFactorToken:         challenge.Token,
+		ExpiresIn:              int64(challenge.ExpiresIn.Seconds()),
+	}
+}
@@ -7,7 +7,13 @@ import (
 	"log/slog"
 	"net/http"
 
+	"github.com/gameap/gameap/internal/api/audit/getaudit"
+	"github.com/gameap/gameap/internal/api/audit/getauditexport"
+	"github.com/gameap/gameap/internal/api/auth/forgotpassword"
 	"github.com/gameap/gameap/internal/api/auth/login"
+	"github.com/gameap/gameap/internal/api/auth/logintwofactor"
+	"github.com/gameap/gameap/internal/api/auth/logintwofactorsetup"
+	"github.com/gameap/gameap/internal/api/auth/resetpassword"
 	"github.com/gameap/gameap/internal/api/clientcertificates/deleteclientcertificates"
 	"github.com/gameap/gameap/internal/api/clientcertificates/getclientcertificates"
 	"github.com/gameap/gameap/internal/api/clientcertificates/postclientcertificates"
@@ -18,6 +24,7 @@ import (
 	daemonapigetserverid "github.com/gameap/gameap/internal/api/daemonapi/servers/getserverid"
 	daemonapigetservers "github.com/gameap/gameap/internal/api/daemonapi/servers/getservers"
 	daemonapipatchservers "github.com/gameap/gameap/internal/api/daemonapi/servers/patchservers"
+	daemonapipostresourceusage "github.com/gameap/gameap/internal/api/daemonapi/servers/postresourceusage"
 	daemonapiputserver "github.com/gameap/gameap/internal/api/daemonapi/servers/putserver"
 	daemonapifailservertask "github.com/gameap/gameap/internal/api/daemonapi/serverstasks/failservertask"
 	daemonapiserverstasks "github.com/gameap/gameap/internal/api/daemonapi/serverstasks/getserverstasks"
@@ -27,6 +34,7 @@ import (
 	daemonapitasks "github.com/gameap/gameap/internal/api/daemonapi/tasks/gettask"
 	daemonapiupdatetask "github.com/gameap/gameap/internal/api/daemonapi/tasks/updatetask"
 	"github.com/gameap/gameap/internal/api/daemontasks/getdaemontask"
+	"github.com/gameap/gameap/internal/api/daemontasks/getdaemontaskoutputstream"
 	"github.com/gameap/gameap/internal/api/daemontasks/getdaemontasks"
 	"github.com/gameap/gameap/internal/api/filemanager/content"
 	filemanagercreatedirectory "github.com/gameap/gameap/internal/api/filemanager/createdirectory"
@@ -54,6 +62,7 @@ import (
 	"github.com/gameap/gameap/internal/api/games/putgame"
 	"github.com/gameap/gameap/internal/api/games/upgradegames"
 	"github.com/gameap/gameap/internal/api/gethealth"
+	"github.com/gameap/gameap/internal/api/getprometheusmetrics"
 	"github.com/gameap/gameap/internal/api/middlewares"
 	"github.com/gameap/gameap/internal/api/nodes/deletenode"
 	"github.com/gameap/gameap/internal/api/nodes/getbusyports"
@@ -67,19 +76,39 @@ import (
 	"github.com/gameap/gameap/internal/api/nodes/nodesetup"
 	"github.com/gameap/gameap/internal/api/nodes/postnode"
 	"github.com/gameap/gameap/internal/api/nodes/putnode"
+	"github.com/gameap/gameap/internal/api/notificationchannels/deletechannel"
+	"github.com/gameap/gameap/internal/api/notificationchannels/getchannels"
+	"github.com/gameap/gameap/internal/api/notificationchannels/postchannel"
+	channelsposttest "github.com/gameap/gameap/internal/api/notificationchannels/posttest"
+	"github.com/gameap/gameap/internal/api/notificationchannels/putchannel"
+	"github.com/gameap/gameap/internal/api/oidc/getproviders"
+	"github.com/gameap/gameap/internal/api/oidc/postauthorize"
+	"github.com/gameap/gameap/internal/api/oidc/postcallback"
 	"github.com/gameap/gameap/internal/api/profile/getprofile"
 	"github.com/gameap/gameap/internal/api/profile/putprofile"
+	"github.com/gameap/gameap/internal/api/serverbackups/deleteserverbackup"
+	"github.com/gameap/gameap/internal/api/serverbackups/getserverbackups"
+	"github.com/gameap/gameap/internal/api/serverbackups/postserverbackup"
+	"github.com/gameap/gameap/internal/api/serverbackups/restoreserverbackup"
 	"github.com/gameap/gameap/internal/api/servers/deleteserver"
 	"github.com/gameap/gameap/internal/api/servers/getabilities"
+	"github.com/gameap/gameap/internal/api/servers/getautorestart"
 	"github.com/gameap/gameap/internal/api/servers/getconsole"
+	"github.com/gameap/gameap/internal/api/servers/getconsolestream"
+	"github.com/gameap/gameap/internal/api/servers/getexpiration"
+	"github.com/gameap/gameap/internal/api/servers/getmetrics"
 	"github.com/gameap/gameap/internal/api/servers/getquery"
+	"github.com/gameap/gameap/internal/api/servers/getqueryhistory"
+	"github.com/gameap/gameap/internal/api/servers/getresourceusage"
 	"github.com/gameap/gameap/internal/api/servers/getserver"
 	"github.com/gameap/gameap/internal/api/servers/getserverabilities"
 	"github.com/gameap/gameap/internal/api/servers/getservers"
 	"github.com/gameap/gameap/internal/api/servers/getstatus"
 	"github.com/gameap/gameap/internal/api/servers/getsummary"
+	"github.com/gameap/gameap/internal/api/servers/postclone"
 	"github.com/gameap/gameap/internal/api/servers/postcommand"
 	"github.com/gameap/gameap/internal/api/servers/postconsole"
+	"github.com/gameap/gameap/internal/api/servers/postmove"
 	"github.com/gameap/gameap/internal/api/servers/postserver"
 	"github.com/gameap/gameap/internal/api/servers/putserver"
 	"github.com/gameap/gameap/internal/api/servers/rcon/getfastrcon"
@@ -91,13 +120,23 @@ import (
 	"github.com/gameap/gameap/internal/api/serversettings/getserversettings"
 	"github.com/gameap/gameap/internal/api/serversettings/putserversettings"
 	"github.com/gameap/gameap/internal/api/servertasks/deleteservertask"
+	"github.com/gameap/gameap/internal/api/servertasks/getschedulepreview"
 	"github.com/gameap/gameap/internal/api/servertasks/getservertasks"
 	"github.com/gameap/gameap/internal/api/servertasks/postservertask"
 	"github.com/gameap/gameap/internal/api/servertasks/putservertask"
+	"github.com/gameap/gameap/internal/api/servertemplates/deleteservertemplate"
+	"github.com/gameap/gameap/internal/api/servertemplates/getservertemplates"
+	"github.com/gameap/gameap/internal/api/servertemplates/postservertemplate"
+	"github.com/gameap/gameap/internal/api/servertemplates/postservertemplateserver"
 	"github.com/gameap/gameap/internal/api/tokens/deletetoken"
 	tokensgetabilities "github.com/gameap/gameap/internal/api/tokens/getabilities"
 	"github.com/gameap/gameap/internal/api/tokens/gettokens"
 	"github.com/gameap/gameap/internal/api/tokens/posttoken"
+	"github.com/gameap/gameap/internal/api/twofactor/confirmenrollment"
+	"github.com/gameap/gameap/internal/api/twofactor/deletetwofactor"
+	"github.com/gameap/gameap/internal/api/twofactor/gettwofactor"
+	"github.com/gameap/gameap/internal/api/twofactor/postenrollment"
+	"github.com/gameap/gameap/internal/api/twofactor/postrecoverycodes"
 	"github.com/gameap/gameap/internal/api/user/getuser"
 	"github.com/gameap/gameap/internal/api/users/deleteuser"
 	"github.com/gameap/gameap/internal/api/users/getserverperms"
@@ -107,18 +146,40 @@ import (
 	"github.com/gameap/gameap/internal/api/users/postusers"
 	"github.com/gameap/gameap/internal/api/users/putserverperms"
 	"github.com/gameap/gameap/internal/api/users/putuser"
+	"github.com/gameap/gameap/internal/api/webhooks/deletewebhook"
+	"github.com/gameap/gameap/internal/api/webhooks/getdeliveries"
+	"github.com/gameap/gameap/internal/api/webhooks/getwebhooks"
+	"github.com/gameap/gameap/internal/api/webhooks/posttest"
+	"github.com/gameap/gameap/internal/api/webhooks/postwebhook"
+	"github.com/gameap/gameap/internal/api/webhooks/putwebhook"
 	"github.com/gameap/gameap/internal/cache"
 	"github.com/gameap/gameap/internal/certificates"
 	"github.com/gameap/gameap/internal/config"
 	"github.com/gameap/gameap/internal/daemon"
 	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/events"
 	"github.com/gameap/gameap/internal/files"
 	"github.com/gameap/gameap/internal/i18n"
+	"github.com/gameap/gameap/internal/monitoring"
 	"github.com/gameap/gameap/internal/rbac"
 	"github.com/gameap/gameap/internal/repositories"
 	"github.com/gameap/gameap/internal/repositories/base"
 	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/audit"
+	"github.com/gameap/gameap/internal/services/authenticator"
+	"github.com/gameap/gameap/internal/services/backup"
+	"github.com/gameap/gameap/internal/services/daemontaskoutput"
+	"github.com/gameap/gameap/internal/services/metrics"
+	"github.com/gameap/gameap/internal/services/notifications"
+	"github.com/gameap/gameap/internal/services/oidc"
+	"github.com/gameap/gameap/internal/services/passwordreset"
+	"github.com/gameap/gameap/internal/services/serverclone"
+	"github.com/gameap/gameap/internal/services/serverconsole"
 	"github.com/gameap/gameap/internal/services/servercontrol"
+	"github.com/gameap/gameap/internal/services/servermove"
+	"github.com/gameap/gameap/internal/services/serverports"
+	"github.com/gameap/gameap/internal/services/twofactor"
+	"github.com/gameap/gameap/internal/services/webhooks"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
 	webstatic "github.com/gameap/gameap/web/static"
@@ -138,6 +199,14 @@ type container interface {
 	AuthService() auth.Service
 	UserService() *services.UserService
 	ServerControlService() *servercontrol.Service
+	ServerConsoleHub() *serverconsole.Hub
+	DaemonTaskOutputBroadcaster() *daemontaskoutput.Broadcaster
+	BackupService() *backup.Service
+	ServerMoveService() *servermove.Service
+	ServerCloneService() *serverclone.Service
+	ServerPortsService() *serverports.Service
+	ServerExpirationPolicy() domain.ServerExpirationPolicy
+	AutoRestartPolicy() domain.AutoRestartPolicy
 	GameUpgradeService() *services.GameUpgradeService
 	RBACRepository() repositories.RBACRepository
 	PersonalAccessTokenRepository() repositories.PersonalAccessTokenRepository
@@ -147,6 +216,25 @@ type container interface {
 	ServerSettingRepository() repositories.ServerSettingRepository
 	NodeRepository() repositories.NodeRepository
 	ClientCertificateRepository() repositories.ClientCertificateRepository
+	BackupRepository() repositories.BackupRepository
+	ServerTemplateRepository() repositories.ServerTemplateRepository
+	ServerResourceUsageRepository() repositories.ServerResourceUsageRepository
+	ServerQueryRecordRepository() repositories.ServerQueryRecordRepository
+	ServerAutoRestartRepository() repositories.ServerAutoRestartRepository
+	WebhookRepository() repositories.WebhookRepository
+	WebhookDeliveryRepository() repositories.WebhookDeliveryRepository
+	NotificationChannelRepository() repositories.NotificationChannelRepository
+	AuditLogRepository() repositories.AuditLogRepository
+	AuditService() *audit.Service
+	MetricsService() *metrics.Service
+	EventBus() *events.Bus
+	WebhooksService() *webhooks.Service
+	NotificationsService() *notifications.Service
+	PasswordResetService() *passwordreset.Service
+	TwoFactorService() *twofactor.Service
+	OIDCService() *oidc.Service
+	Authenticator() authenticator.Authenticator
+	Translator() *i18n.Translator
 	RBAC() *rbac.RBAC
 	FileManager() files.FileManager
 	Cache() cache.Cache
@@ -155,6 +243,7 @@ type container interface {
 	DaemonStatus() *daemon.StatusService
 	DaemonFiles() *daemon.FileService
 	DaemonCommands() *daemon.CommandService
+	Monitoring() *monitoring.Metrics
 }
 
 func CreateRouter(c container) *http.ServeMux {
@@ -167,6 +256,11 @@ func CreateRouter(c container) *http.ServeMux {
 	)
 	serverMux.Handle("/gdaemon/", gdaemonSetupRoutes(c, router))
 	serverMux.Handle("/gdaemon_api/", gdaemonAPIRoutes(c, router))
+	serverMux.Handle("/metrics", getprometheusmetrics.NewHandler(
+		c.Config().Prometheus.Token,
+		c.Monitoring().Handler(),
+		c.Responder(),
+	))
 
 	static, err := webstatic.GetFS()
 	if err != nil {
@@ -251,7 +345,71 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 		{
 			Method:           http.MethodPost,
 			Path:             "/api/auth/login",
-			Handler:          login.NewHandler(c.AuthService(), c.UserService(), c.Responder()),
+			Handler:          login.NewHandler(c.AuthService(), c.Authenticator(), c.TwoFactorService(), c.Responder()),
+			AllowGuestAccess: true,
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/auth/login/two-factor",
+			Handler: logintwofactor.NewHandler(
+				c.AuthService(),
+				c.UserService(),
+				c.TwoFactorService(),
+				c.Responder(),
+			),
+			AllowGuestAccess: true,
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/auth/login/two-factor/setup",
+			Handler: logintwofactorsetup.NewHandler(
+				c.TwoFactorService(),
+				c.Responder(),
+			),
+			AllowGuestAccess: true,
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/auth/password/forgot",
+			Handler: forgotpassword.NewHandler(
+				c.PasswordResetService(),
+				c.Translator(),
+				c.Config().PasswordReset.ClientIPHeader,
+				c.Responder(),
+			),
+			AllowGuestAccess: true,
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/auth/password/reset",
+			Handler: resetpassword.NewHandler(
+				c.PasswordResetService(),
+				c.Config().PasswordReset.ClientIPHeader,
+				c.Responder(),
+			),
+			AllowGuestAccess: true,
+		},
+		{
+			Method:           http.MethodGet,
+			Path:             "/api/auth/oidc/providers",
+			Handler:          getproviders.NewHandler(c.OIDCService(), c.Responder()),
+			AllowGuestAccess: true,
+		},
+		{
+			Method:           http.MethodPost,
+			Path:             "/api/auth/oidc/{provider}/authorize",
+			Handler:          postauthorize.NewHandler(c.OIDCService(), c.Responder()),
+			AllowGuestAccess: true,
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/auth/oidc/{provider}/callback",
+			Handler: postcallback.NewHandler(
+				c.AuthService(),
+				c.OIDCService(),
+				c.TwoFactorService(),
+				c.Responder(),
+			),
 			AllowGuestAccess: true,
 		},
 
@@ -279,6 +437,46 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 				c.Responder(),
 			),
 		},
+		{
+			Method: http.MethodGet,
+			Path:   "/api/profile/two-factor",
+			Handler: gettwofactor.NewHandler(
+				c.TwoFactorService(),
+				c.Responder(),
+			),
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/profile/two-factor",
+			Handler: postenrollment.NewHandler(
+				c.TwoFactorService(),
+				c.Responder(),
+			),
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/profile/two-factor/confirm",
+			Handler: confirmenrollment.NewHandler(
+				c.TwoFactorService(),
+				c.Responder(),
+			),
+		},
+		{
+			Method: http.MethodDelete,
+			Path:   "/api/profile/two-factor",
+			Handler: deletetwofactor.NewHandler(
+				c.TwoFactorService(),
+				c.Responder(),
+			),
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/profile/two-factor/recovery-codes",
+			Handler: postrecoverycodes.NewHandler(
+				c.TwoFactorService(),
+				c.Responder(),
+			),
+		},
 
 		// Tokens
 		{
@@ -315,6 +513,122 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			),
 		},
 
+		// Webhooks
+		{
+			Method: http.MethodGet,
+			Path:   "/api/webhooks",
+			Handler: getwebhooks.NewHandler(
+				c.WebhookRepository(),
+				c.ServerRepository(),
+				c.RBAC(),
+				c.Responder(),
+			),
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/webhooks",
+			Handler: postwebhook.NewHandler(
+				c.WebhookRepository(),
+				c.ServerRepository(),
+				c.RBAC(),
+				c.Responder(),
+			),
+		},
+		{
+			Method: http.MethodPut,
+			Path:   "/api/webhooks/{webhook}",
+			Handler: putwebhook.NewHandler(
+				c.WebhookRepository(),
+				c.ServerRepository(),
+				c.RBAC(),
+				c.Responder(),
+			),
+		},
+		{
+			Method: http.MethodDelete,
+			Path:   "/api/webhooks/{webhook}",
+			Handler: deletewebhook.NewHandler(
+				c.WebhookRepository(),
+				c.WebhookDeliveryRepository(),
+				c.ServerRepository(),
+				c.RBAC(),
+				c.Responder(),
+			),
+		},
+		{
+			Method: http.MethodGet,
+			Path:   "/api/webhooks/{webhook}/deliveries",
+			Handler: getdeliveries.NewHandler(
+				c.WebhookRepository(),
+				c.WebhookDeliveryRepository(),
+				c.ServerRepository(),
+				c.RBAC(),
+				c.Responder(),
+			),
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/webhooks/{webhook}/test",
+			Handler: posttest.NewHandler(
+				c.WebhookRepository(),
+				c.ServerRepository(),
+				c.RBAC(),
+				c.WebhooksService(),
+				c.Responder(),
+			),
+		},
+
+		// Notification channels
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/notification_channels",
+			Handler: getchannels.NewHandler(c.NotificationChannelRepository(), c.Responder()),
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/notification_channels",
+			Handler: postchannel.NewHandler(
+				c.NotificationChannelRepository(),
+				c.ServerRepository(),
+				c.RBAC(),
+				c.Translator().Languages(),
+				c.Config().Notifications.DefaultLanguage,
+				c.Responder(),
+			),
+		},
+		{
+			Method: http.MethodPut,
+			Path:   "/api/notification_channels/{channel}",
+			Handler: putchannel.NewHandler(
+				c.NotificationChannelRepository(),
+				c.ServerRepository(),
+				c.RBAC(),
+				c.Translator().Languages(),
+				c.Responder(),
+			),
+		},
+		{
+			Method: http.MethodDelete,
+			Path:   "/api/notification_channels/{channel}",
+			Handler: deletechannel.NewHandler(
+				c.NotificationChannelRepository(),
+				c.ServerRepository(),
+				c.RBAC(),
+				c.Responder(),
+			),
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/notification_channels/{channel}/test",
+			Handler: channelsposttest.NewHandler(
+				c.NotificationChannelRepository(),
+				c.ServerRepository(),
+				c.RBAC(),
+				c.NotificationsService(),
+				c.Responder(),
+			),
+		},
+
 		// Servers
 		{
 			Method: http.MethodGet,
@@ -335,8 +649,11 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Handler: postserver.NewHandler(
 				c.ServerRepository(),
 				c.NodeRepository(),
+				c.GameRepository(),
 				c.GameModRepository(),
 				c.DaemonTaskRepository(),
+				c.ServerPortsService(),
+				c.EventBus(),
 				c.Responder(),
 			),
 			AdminOnly: true,
@@ -383,6 +700,7 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 				c.ServerRepository(),
 				c.DaemonTaskRepository(),
 				c.RBAC(),
+				c.EventBus(),
 				c.Responder(),
 			),
 			AdminOnly: true,
@@ -403,6 +721,32 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 				domain.PATAbilityServerCreate,
 			},
 		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/servers/{server}/move",
+			Handler: postmove.NewHandler(
+				c.ServerRepository(),
+				c.ServerMoveService(),
+				c.Responder(),
+			),
+			AdminOnly: true,
+			CheckPATAbilities: []domain.PATAbility{
+				domain.PATAbilityServerCreate,
+			},
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/servers/{server}/clone",
+			Handler: postclone.NewHandler(
+				c.ServerRepository(),
+				c.ServerCloneService(),
+				c.Responder(),
+			),
+			AdminOnly: true,
+			CheckPATAbilities: []domain.PATAbility{
+				domain.PATAbilityServerCreate,
+			},
+		},
 		{
 			Method: http.MethodGet,
 			Path:   "/api/servers/{server}/abilities",
@@ -417,10 +761,65 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Path:   "/api/servers/{server}/status",
 			Handler: getstatus.NewHandler(
 				c.ServerRepository(),
+				c.ServerSettingRepository(),
 				c.RBAC(),
 				c.Responder(),
 			),
 		},
+		{
+			Method: http.MethodGet,
+			Path:   "/api/servers/{server}/resource_usage",
+			Handler: getresourceusage.NewHandler(
+				c.ServerRepository(),
+				c.ServerResourceUsageRepository(),
+				c.RBAC(),
+				c.Responder(),
+			),
+		},
+		{
+			Method: http.MethodGet,
+			Path:   "/api/servers/{server}/query_history",
+			Handler: getqueryhistory.NewHandler(
+				c.ServerRepository(),
+				c.ServerQueryRecordRepository(),
+				c.RBAC(),
+				c.Responder(),
+			),
+		},
+		{
+			Method: http.MethodGet,
+			Path:   "/api/servers/{server}/metrics",
+			Handler: getmetrics.NewHandler(
+				c.ServerRepository(),
+				c.MetricsService(),
+				c.RBAC(),
+				c.Responder(),
+			),
+		},
+		{
+			Method: http.MethodGet,
+			Path:   "/api/servers/{server}/auto_restart",
+			Handler: getautorestart.NewHandler(
+				c.ServerRepository(),
+				c.ServerAutoRestartRepository(),
+				c.AutoRestartPolicy(),
+				c.RBAC(),
+				c.Responder(),
+			),
+		},
+		{
+			Method: http.MethodGet,
+			Path:   "/api/servers/{server}/expiration",
+			Handler: getexpiration.NewHandler(
+				c.ServerRepository(),
+				c.ServerExpirationPolicy(),
+				c.RBAC(),
+				c.Responder(),
+			),
+			CheckPATAbilities: []domain.PATAbility{
+				domain.PATAbilityServerList,
+			},
+		},
 		{
 			Method: http.MethodGet,
 			Path:   "/api/servers/{server}/query",
@@ -519,6 +918,24 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 				domain.PATAbilityServerConsole,
 			},
 		},
+		{
+			Method: http.MethodGet,
+			Path:   "/api/servers/{server}/console/stream",
+			Handler: getconsolestream.NewHandler(
+				c.ServerRepository(),
+				c.NodeRepository(),
+				c.RBAC(),
+				c.DaemonCommands(),
+				c.DaemonFiles(),
+				c.ServerConsoleHub(),
+				c.AuditService(),
+				c.Config().Audit.ClientIPHeader,
+				c.Responder(),
+			),
+			CheckPATAbilities: []domain.PATAbility{
+				domain.PATAbilityServerConsole,
+			},
+		},
 		{
 			Method: http.MethodPost,
 			Path:   "/api/servers/{server}/console",
@@ -686,6 +1103,19 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Path:   "/api/servers/{server}/tasks",
 			Handler: postservertask.NewHandler(
 				c.ServerTaskRepository(),
+				c.ServerRepository(),
+				c.RBAC(),
+				c.Config().ServerTaskScheduler.Enabled,
+				c.Responder(),
+			),
+			CheckPATAbilities: []domain.PATAbility{
+				domain.PATAbilityServerTasksManage,
+			},
+		},
+		{
+			Method: http.MethodGet,
+			Path:   "/api/servers/{server}/tasks/preview",
+			Handler: getschedulepreview.NewHandler(
 				c.ServerRepository(),
 				c.RBAC(),
 				c.Responder(),
@@ -701,6 +1131,7 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 				c.ServerTaskRepository(),
 				c.ServerRepository(),
 				c.RBAC(),
+				c.Config().ServerTaskScheduler.Enabled,
 				c.Responder(),
 			),
 			CheckPATAbilities: []domain.PATAbility{
@@ -721,6 +1152,62 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			},
 		},
 
+		// Server Backups
+		{
+			Method: http.MethodGet,
+			Path:   "/api/servers/{server}/backups",
+			Handler: getserverbackups.NewHandler(
+				c.BackupRepository(),
+				c.ServerRepository(),
+				c.RBAC(),
+				c.Responder(),
+			),
+			CheckPATAbilities: []domain.PATAbility{
+				domain.PATAbilityServerBackups,
+			},
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/servers/{server}/backups",
+			Handler: postserverbackup.NewHandler(
+				c.ServerRepository(),
+				c.RBAC(),
+				c.BackupService(),
+				c.Responder(),
+			),
+			CheckPATAbilities: []domain.PATAbility{
+				domain.PATAbilityServerBackups,
+			},
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/servers/{server}/backups/{backup}/restore",
+			Handler: restoreserverbackup.NewHandler(
+				c.BackupRepository(),
+				c.ServerRepository(),
+				c.RBAC(),
+				c.BackupService(),
+				c.Responder(),
+			),
+			CheckPATAbilities: []domain.PATAbility{
+				domain.PATAbilityServerBackups,
+			},
+		},
+		{
+			Method: http.MethodDelete,
+			Path:   "/api/servers/{server}/backups/{backup}",
+			Handler: deleteserverbackup.NewHandler(
+				c.BackupRepository(),
+				c.ServerRepository(),
+				c.RBAC(),
+				c.BackupService(),
+				c.Responder(),
+			),
+			CheckPATAbilities: []domain.PATAbility{
+				domain.PATAbilityServerBackups,
+			},
+		},
+
 		// Server Settings
 		{
 			Method: http.MethodGet,
@@ -789,6 +1276,32 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 				domain.PATAbilityServerRestart,
 			},
 		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/servers/{server}/pause",
+			Handler: postcommand.NewHandler(
+				c.ServerRepository(),
+				c.ServerControlService(),
+				c.RBAC(),
+				c.Responder(),
+			),
+			CheckPATAbilities: []domain.PATAbility{
+				domain.PATAbilityServerPause,
+			},
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/servers/{server}/unpause",
+			Handler: postcommand.NewHandler(
+				c.ServerRepository(),
+				c.ServerControlService(),
+				c.RBAC(),
+				c.Responder(),
+			),
+			CheckPATAbilities: []domain.PATAbility{
+				domain.PATAbilityServerPause,
+			},
+		},
 		{
 			Method: http.MethodPost,
 			Path:   "/api/servers/{server}/update",
@@ -844,6 +1357,49 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 		//
 		// Admin routes
 
+		// Server Templates
+		{
+			Method: http.MethodGet,
+			Path:   "/api/server_templates",
+			Handler: getservertemplates.NewHandler(
+				c.ServerTemplateRepository(),
+				c.Responder(),
+			),
+			AdminOnly: true,
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/server_templates",
+			Handler: postservertemplate.NewHandler(
+				c.ServerRepository(),
+				c.ServerCloneService(),
+				c.Responder(),
+			),
+			AdminOnly: true,
+		},
+		{
+			Method: http.MethodDelete,
+			Path:   "/api/server_templates/{id}",
+			Handler: deleteservertemplate.NewHandler(
+				c.ServerTemplateRepository(),
+				c.Responder(),
+			),
+			AdminOnly: true,
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/server_templates/{id}/servers",
+			Handler: postservertemplateserver.NewHandler(
+				c.ServerTemplateRepository(),
+				c.ServerCloneService(),
+				c.Responder(),
+			),
+			AdminOnly: true,
+			CheckPATAbilities: []domain.PATAbility{
+				domain.PATAbilityServerCreate,
+			},
+		},
+
 		// Users
 		{
 			Method:    http.MethodGet,
@@ -1207,6 +1763,20 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			AdminOnly: true,
 		},
 
+		// Audit
+		{
+			Method:    http.MethodGet,
+			Path:      "/api/audit",
+			Handler:   getaudit.NewHandler(c.AuditLogRepository(), c.Responder()),
+			AdminOnly: true,
+		},
+		{
+			Method:    http.MethodGet,
+			Path:      "/api/audit/export",
+			Handler:   getauditexport.NewHandler(c.AuditLogRepository(), c.Responder()),
+			AdminOnly: true,
+		},
+
 		// Daemon Tasks
 		{
 			Method:    http.MethodGet,
@@ -1229,6 +1799,16 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Handler:   getdaemontask.NewHandler(c.DaemonTaskRepository(), c.Responder(), true),
 			AdminOnly: true,
 		},
+		{
+			Method: http.MethodGet,
+			Path:   "/api/gdaemon_tasks/{id}/output/stream",
+			Handler: getdaemontaskoutputstream.NewHandler(
+				c.DaemonTaskRepository(),
+				c.DaemonTaskOutputBroadcaster(),
+				c.Responder(),
+			),
+			AdminOnly: true,
+		},
 
 		// Game Mods
 		{
@@ -1320,10 +1900,20 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 		c.Responder(),
 	)
 
+	auditMiddleware := middlewares.NewAuditMiddleware(
+		c.AuditService(),
+		c.Config().Audit.ClientIPHeader,
+	)
+
 	recoveryMiddleware := middlewares.NewRecoveryMiddleware(
 		c.Responder(),
 	)
 
+	metricsMiddleware := middlewares.NewMetricsMiddleware(c.Monitoring())
+	tracingMiddleware := middlewares.NewTracingMiddleware()
+	logContextMiddleware := middlewares.NewLogContextMiddleware()
+	requestIDMiddleware := middlewares.NewRequestIDMiddleware()
+
 	for _, r := range routes {
 		handler := r.Handler
 
@@ -1337,6 +1927,9 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			handler = isAdminMiddleware.Middleware(handler)
 		}
 
+		// Audit middleware needs the session and records the requests rejected by the checks above
+		handler = auditMiddleware.Middleware(handler, r.Method, r.Path)
+
 		if !r.AllowGuestAccess {
 			handler = authMiddleware.Middleware(handler)
 		} else {
@@ -1346,6 +1939,18 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 		// Recovery middleware wraps everything to catch panics
 		handler = recoveryMiddleware.Middleware(handler)
 
+		handler = logContextMiddleware.Middleware(handler, r.Path)
+
+		// Metrics middleware observes the responses written by the recovery middleware too
+		handler = metricsMiddleware.Middleware(handler, r.Method, r.Path)
+
+		if c.Config().Tracing.Enabled {
+			handler = tracingMiddleware.Middleware(handler, r.Method, r.Path)
+		}
+
+		// Request ID middleware is the outermost one, so all the records of the request have the ID
+		handler = requestIDMiddleware.Middleware(handler)
+
 		router.Handle(r.Path, handler).Methods(r.Method)
 	}
 
@@ -1357,6 +1962,8 @@ func gdaemonSetupRoutes(c container, router *mux.Router) *mux.Router {
 		c.Responder(),
 	)
 
+	requestIDMiddleware := middlewares.NewRequestIDMiddleware()
+
 	routes := []struct {
 		Method      string
 		Path        string
@@ -1395,6 +2002,8 @@ func gdaemonSetupRoutes(c container, router *mux.Router) *mux.Router {
 		// Recovery middleware wraps everything to catch panics
 		handler = recoveryMiddleware.Middleware(handler)
 
+		handler = requestIDMiddleware.Middleware(handler)
+
 		if handler != nil {
 			router.Handle(r.Path, handler).Methods(r.Method)
 		}
@@ -1414,6 +2023,9 @@ func gdaemonAPIRoutes(c container, router *mux.Router) *mux.Router {
 		c.Responder(),
 	)
 
+	logContextMiddleware := middlewares.NewLogContextMiddleware()
+	requestIDMiddleware := middlewares.NewRequestIDMiddleware()
+
 	routes := []struct {
 		Method      string
 		Path        string
@@ -1466,6 +2078,7 @@ func gdaemonAPIRoutes(c container, router *mux.Router) *mux.Router {
 			Path:   "/gdaemon_api/servers/{server}",
 			Handler: daemonapiputserver.NewHandler(
 				c.ServerRepository(),
+				c.EventBus(),
 				c.Responder(),
 			),
 			Middlewares: []mux.MiddlewareFunc{
@@ -1477,6 +2090,20 @@ func gdaemonAPIRoutes(c container, router *mux.Router) *mux.Router {
 			Path:   "/gdaemon_api/servers",
 			Handler: daemonapipatchservers.NewHandler(
 				c.ServerRepository(),
+				c.EventBus(),
+				c.Responder(),
+			),
+			Middlewares: []mux.MiddlewareFunc{
+				daemonAuthMiddleware.Middleware,
+			},
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/gdaemon_api/servers_resource_usage",
+			Handler: daemonapipostresourceusage.NewHandler(
+				c.ServerRepository(),
+				c.ServerResourceUsageRepository(),
+				c.MetricsService(),
 				c.Responder(),
 			),
 			Middlewares: []mux.MiddlewareFunc{
@@ -1499,6 +2126,8 @@ func gdaemonAPIRoutes(c container, router *mux.Router) *mux.Router {
 			Path:   "/gdaemon_api/tasks/{gdaemon_task}",
 			Handler: daemonapiupdatetask.NewHandler(
 				c.DaemonTaskRepository(),
+				c.DaemonTaskOutputBroadcaster(),
+				c.EventBus(),
 				c.Responder(),
 			),
 			Middlewares: []mux.MiddlewareFunc{
@@ -1510,6 +2139,7 @@ func gdaemonAPIRoutes(c container, router *mux.Router) *mux.Router {
 			Path:   "/gdaemon_api/tasks/{gdaemon_task}/output",
 			Handler: daemonapiappendoutput.NewHandler(
 				c.DaemonTaskRepository(),
+				c.DaemonTaskOutputBroadcaster(),
 				c.Responder(),
 			),
 			Middlewares: []mux.MiddlewareFunc{
@@ -1559,6 +2189,7 @@ func gdaemonAPIRoutes(c container, router *mux.Router) *mux.Router {
 				c.ServerTaskRepository(),
 				c.ServerTaskFailRepository(),
 				c.ServerRepository(),
+				c.EventBus(),
 				c.Responder(),
 			),
 			Middlewares: []mux.MiddlewareFunc{
@@ -1577,6 +2208,9 @@ func gdaemonAPIRoutes(c container, router *mux.Router) *mux.Router {
 		// Recovery middleware wraps everything to catch panics
 		handler = recoveryMiddleware.Middleware(handler)
 
+		handler = logContextMiddleware.Middleware(handler, r.Path)
+		handler = requestIDMiddleware.Middleware(handler)
+
 		if handler != nil {
 			router.Handle(r.Path, handler).Methods(r.Method)
 		}
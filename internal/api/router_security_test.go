@@ -220,6 +220,21 @@ func TestRouterSecurity_TokenAccess(t *testing.T) {
 			tokenAbilities:     []domain.PATAbility{domain.PATAbilityServerCreate, domain.PATAbilityGDaemonTaskRead},
 			expectedStatusCode: http.StatusForbidden,
 		},
+
+		// "POST /api/profile/two-factor" endpoint tests
+		{
+			// Two-factor authentication is managed only via user password authentication.
+			name:               "token_cannot_enable_two_factor",
+			request:            "POST /api/profile/two-factor",
+			tokenAbilities:     []domain.PATAbility{domain.PATAbilityServerList},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "token_cannot_disable_two_factor",
+			request:            "DELETE /api/profile/two-factor",
+			tokenAbilities:     []domain.PATAbility{domain.PATAbilityServerList},
+			expectedStatusCode: http.StatusForbidden,
+		},
 	}
 
 	for _, test := range tests {
@@ -376,6 +391,30 @@ func TestRouterSecurity_UserAccess(t *testing.T) {
 			isAdmin:            true,
 			expectedStatusCode: http.StatusOK,
 		},
+		{
+			name:               "regular_user_cannot_access_audit",
+			request:            "GET /api/audit",
+			isAdmin:            false,
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "admin_can_access_audit",
+			request:            "GET /api/audit",
+			isAdmin:            true,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "regular_user_cannot_export_audit",
+			request:            "GET /api/audit/export",
+			isAdmin:            false,
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "admin_can_export_audit",
+			request:            "GET /api/audit/export",
+			isAdmin:            true,
+			expectedStatusCode: http.StatusOK,
+		},
 		{
 			name:               "regular_user_cannot_access_client_certificates",
 			request:            "GET /api/client_certificates",
@@ -423,3 +462,67 @@ func TestRouterSecurity_UserAccess(t *testing.T) {
 		})
 	}
 }
+
+func TestRouterSecurity_MetricsAccess(t *testing.T) {
+	tests := []struct {
+		name               string
+		metricsToken       string
+		useUserToken       bool
+		authorization      string
+		expectedStatusCode int
+	}{
+		{
+			name:               "metrics_token_can_access_metrics",
+			metricsToken:       "metrics-token",
+			authorization:      "Bearer metrics-token",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "admin_user_token_cannot_access_metrics",
+			metricsToken:       "metrics-token",
+			useUserToken:       true,
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "guest_cannot_access_metrics",
+			metricsToken:       "metrics-token",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "metrics_disabled_without_token",
+			metricsToken:       "",
+			authorization:      "Bearer ",
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, err := testcontainer.LoadInmemoryContainer()
+			require.NoError(t, err)
+
+			c.Config().Prometheus.Token = test.metricsToken
+
+			fixtures, err := testcontainer.SetupFixtures(context.Background(), c)
+			require.NoError(t, err)
+
+			router := api.CreateRouter(c)
+
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+
+			if test.useUserToken {
+				token, err := c.AuthService().GenerateTokenForUser(fixtures.AdminUser, time.Hour)
+				require.NoError(t, err)
+
+				req.Header.Set("Authorization", "Bearer "+token)
+			} else if test.authorization != "" {
+				req.Header.Set("Authorization", test.authorization)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/api"
+	"github.com/gameap/gameap/pkg/testcontainer"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouter_ConsoleStreamUpgradesThroughMiddlewares(t *testing.T) {
+	c, err := testcontainer.LoadInmemoryContainer()
+	require.NoError(t, err)
+
+	// Tracing wraps the response writer too
+	c.Config().Tracing.Enabled = true
+
+	fixtures, err := testcontainer.SetupFixtures(context.Background(), c)
+	require.NoError(t, err)
+
+	token, err := c.AuthService().GenerateTokenForUser(fixtures.AdminUser, time.Hour)
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(api.CreateRouter(c))
+	defer srv.Close()
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+token)
+
+	conn, resp, err := websocket.DefaultDialer.Dial(
+		"ws"+strings.TrimPrefix(srv.URL, "http")+"/api/servers/1/console/stream",
+		header,
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+
+	require.NoError(t, conn.WriteMessage(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+	))
+}
@@ -0,0 +1,94 @@
+package getserverbackups
+
+import (
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	serversbase "github.com/gameap/gameap/internal/api/servers/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+)
+
+type Handler struct {
+	backupRepo     repositories.BackupRepository
+	serverFinder   *serversbase.ServerFinder
+	abilityChecker *serversbase.AbilityChecker
+	responder      base.Responder
+}
+
+func NewHandler(
+	backupRepo repositories.BackupRepository,
+	serversRepo repositories.ServerRepository,
+	rbac base.RBAC,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		backupRepo:     backupRepo,
+		serverFinder:   serversbase.NewServerFinder(serversRepo, rbac),
+		abilityChecker: serversbase.NewAbilityChecker(rbac),
+		responder:      responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	inputReader := api.NewInputReader(r)
+
+	serverID, err := inputReader.ReadUint("server")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid server id"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	server, err := h.serverFinder.FindUserServer(ctx, session.User, serverID)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	err = h.abilityChecker.CheckOrError(
+		ctx,
+		session.User.ID,
+		server.ID,
+		[]domain.AbilityName{domain.AbilityNameGameServerBackups},
+	)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	backups, err := h.backupRepo.Find(
+		ctx,
+		filters.FindBackupByServerIDs(server.ID),
+		[]filters.Sorting{{Field: "id", Direction: filters.SortDirectionDesc}},
+		nil,
+	)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to find backups"))
+
+		return
+	}
+
+	h.responder.Write(ctx, rw, newBackupsResponse(backups))
+}
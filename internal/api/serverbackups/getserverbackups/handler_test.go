@@ -0,0 +1,176 @@
+package getserverbackups
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupAuth(userID uint) context.Context {
+	session := &auth.Session{
+		Login: "user",
+		Email: "user@example.com",
+		User: &domain.User{
+			ID:    userID,
+			Login: "user",
+			Email: "user@example.com",
+		},
+	}
+
+	return auth.ContextWithSession(context.Background(), session)
+}
+
+func setupRepos(
+	t *testing.T,
+	backupRepo *inmemory.BackupRepository,
+	serverRepo *inmemory.ServerRepository,
+	rbacRepo *inmemory.RBACRepository,
+) {
+	t.Helper()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	require.NoError(t, serverRepo.Save(ctx, &domain.Server{
+		ID:        1,
+		UUID:      uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+		UUIDShort: "short1",
+		Name:      "Test Server",
+		Dir:       "/home/gameap/servers/test1",
+		CreatedAt: &now,
+		UpdatedAt: &now,
+	}))
+	serverRepo.AddUserServer(2, 1)
+
+	for _, b := range []domain.Backup{
+		{ServerID: 1, Status: domain.BackupStatusCompleted, Path: "backups/1.tar.gz", Size: 100, CreatedAt: &now},
+		{ServerID: 1, Status: domain.BackupStatusCreating, Path: "backups/2.tar.gz", CreatedAt: &now},
+		{ServerID: 2, Status: domain.BackupStatusCompleted, Path: "backups/3.tar.gz", CreatedAt: &now},
+	} {
+		require.NoError(t, backupRepo.Save(ctx, &b))
+	}
+
+	adminRole := &domain.Role{
+		Name:  "admin",
+		Title: lo.ToPtr("Administrator"),
+		Level: lo.ToPtr(uint(100)),
+	}
+	require.NoError(t, rbacRepo.SaveRole(ctx, adminRole))
+	require.NoError(t, rbacRepo.SaveAssignedRole(ctx, &domain.AssignedRole{
+		RoleID:     adminRole.ID,
+		EntityID:   1,
+		EntityType: domain.EntityTypeUser,
+	}))
+
+	ability := &domain.Ability{
+		Name:  domain.AbilityNameAdminRolesPermissions,
+		Title: lo.ToPtr("Admin Permissions"),
+	}
+	require.NoError(t, rbacRepo.SaveAbility(ctx, ability))
+	require.NoError(t, rbacRepo.SavePermission(ctx, &domain.Permission{
+		AbilityID:  ability.ID,
+		EntityID:   lo.ToPtr(uint(1)),
+		EntityType: lo.ToPtr(domain.EntityTypeUser),
+	}))
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		serverID   string
+		wantStatus int
+		wantError  string
+		wantIDs    []uint
+	}{
+		{
+			name:       "admin gets server backups",
+			ctx:        setupAuth(1),
+			serverID:   "1",
+			wantStatus: http.StatusOK,
+			wantIDs:    []uint{2, 1},
+		},
+		{
+			name:       "user not authenticated",
+			ctx:        context.Background(),
+			serverID:   "1",
+			wantStatus: http.StatusUnauthorized,
+			wantError:  "user not authenticated",
+		},
+		{
+			name:       "invalid server id",
+			ctx:        setupAuth(1),
+			serverID:   "invalid",
+			wantStatus: http.StatusBadRequest,
+			wantError:  "invalid server id",
+		},
+		{
+			name:       "server not found",
+			ctx:        setupAuth(1),
+			serverID:   "999",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "user without backups permission",
+			ctx:        setupAuth(2),
+			serverID:   "1",
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serversRepo := inmemory.NewServerRepository()
+			backupRepo := inmemory.NewBackupRepository()
+			rbacRepo := inmemory.NewRBACRepository()
+			setupRepos(t, backupRepo, serversRepo, rbacRepo)
+
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+			handler := NewHandler(backupRepo, serversRepo, rbacService, api.NewResponder())
+
+			req := httptest.NewRequest(http.MethodGet, "/api/servers/"+tt.serverID+"/backups", nil)
+			req = req.WithContext(tt.ctx)
+			req = mux.SetURLVars(req, map[string]string{"server": tt.serverID})
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code, rr.Body.String())
+
+			if tt.wantError != "" {
+				var errorResponse map[string]any
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorResponse))
+				errorMsg, ok := errorResponse["error"].(string)
+				if !ok {
+					errorMsg, _ = errorResponse["message"].(string)
+				}
+				assert.Contains(t, errorMsg, tt.wantError)
+			}
+
+			if tt.wantIDs != nil {
+				var response []backupResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+
+				ids := lo.Map(response, func(b backupResponse, _ int) uint {
+					return b.ID
+				})
+				assert.Equal(t, tt.wantIDs, ids)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package postserverbackup
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	serversbase "github.com/gameap/gameap/internal/api/servers/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/backup"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+)
+
+type backupCreator interface {
+	Start(ctx context.Context, server *domain.Server) (*domain.Backup, error)
+}
+
+type Handler struct {
+	serverFinder   *serversbase.ServerFinder
+	abilityChecker *serversbase.AbilityChecker
+	backupCreator  backupCreator
+	responder      base.Responder
+}
+
+func NewHandler(
+	serversRepo repositories.ServerRepository,
+	rbac base.RBAC,
+	backupCreator backupCreator,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		serverFinder:   serversbase.NewServerFinder(serversRepo, rbac),
+		abilityChecker: serversbase.NewAbilityChecker(rbac),
+		backupCreator:  backupCreator,
+		responder:      responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	inputReader := api.NewInputReader(r)
+
+	serverID, err := inputReader.ReadUint("server")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid server id"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	server, err := h.serverFinder.FindUserServer(ctx, session.User, serverID)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	err = h.abilityChecker.CheckOrError(
+		ctx,
+		session.User.ID,
+		server.ID,
+		[]domain.AbilityName{domain.AbilityNameGameServerBackups},
+	)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	if server.Blocked {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("server is blocked"),
+			http.StatusForbidden,
+		))
+
+		return
+	}
+
+	b, err := h.backupCreator.Start(ctx, server)
+	if err != nil {
+		if errors.Is(err, backup.ErrOperationInProgress) {
+			h.responder.WriteError(ctx, rw, api.WrapHTTPError(err, http.StatusConflict))
+
+			return
+		}
+
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to start backup"))
+
+		return
+	}
+
+	rw.WriteHeader(http.StatusAccepted)
+	h.responder.Write(ctx, rw, newBackupResponse(b))
+}
@@ -0,0 +1,199 @@
+package postserverbackup
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/backup"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeBackupCreator struct {
+	err     error
+	started []uint
+}
+
+func (c *fakeBackupCreator) Start(_ context.Context, server *domain.Server) (*domain.Backup, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	c.started = append(c.started, server.ID)
+
+	return &domain.Backup{
+		ID:       1,
+		ServerID: server.ID,
+		Status:   domain.BackupStatusCreating,
+	}, nil
+}
+
+func setupAuth(userID uint) context.Context {
+	session := &auth.Session{
+		Login: "user",
+		Email: "user@example.com",
+		User: &domain.User{
+			ID:    userID,
+			Login: "user",
+			Email: "user@example.com",
+		},
+	}
+
+	return auth.ContextWithSession(context.Background(), session)
+}
+
+func setupRepos(t *testing.T, serverRepo *inmemory.ServerRepository, rbacRepo *inmemory.RBACRepository) {
+	t.Helper()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	require.NoError(t, serverRepo.Save(ctx, &domain.Server{
+		ID:        1,
+		UUID:      uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+		UUIDShort: "short1",
+		Name:      "Test Server",
+		Dir:       "/home/gameap/servers/test1",
+		CreatedAt: &now,
+		UpdatedAt: &now,
+	}))
+	require.NoError(t, serverRepo.Save(ctx, &domain.Server{
+		ID:        2,
+		UUID:      uuid.MustParse("22222222-2222-2222-2222-222222222222"),
+		UUIDShort: "short2",
+		Name:      "Blocked Server",
+		Dir:       "/home/gameap/servers/test2",
+		Blocked:   true,
+		CreatedAt: &now,
+		UpdatedAt: &now,
+	}))
+	serverRepo.AddUserServer(2, 1)
+
+	adminRole := &domain.Role{
+		Name:  "admin",
+		Title: lo.ToPtr("Administrator"),
+		Level: lo.ToPtr(uint(100)),
+	}
+	require.NoError(t, rbacRepo.SaveRole(ctx, adminRole))
+	require.NoError(t, rbacRepo.SaveAssignedRole(ctx, &domain.AssignedRole{
+		RoleID:     adminRole.ID,
+		EntityID:   1,
+		EntityType: domain.EntityTypeUser,
+	}))
+
+	ability := &domain.Ability{
+		Name:  domain.AbilityNameAdminRolesPermissions,
+		Title: lo.ToPtr("Admin Permissions"),
+	}
+	require.NoError(t, rbacRepo.SaveAbility(ctx, ability))
+	require.NoError(t, rbacRepo.SavePermission(ctx, &domain.Permission{
+		AbilityID:  ability.ID,
+		EntityID:   lo.ToPtr(uint(1)),
+		EntityType: lo.ToPtr(domain.EntityTypeUser),
+	}))
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		serverID    string
+		creatorErr  error
+		wantStatus  int
+		wantError   string
+		wantStarted []uint
+	}{
+		{
+			name:        "backup started",
+			ctx:         setupAuth(1),
+			serverID:    "1",
+			wantStatus:  http.StatusAccepted,
+			wantStarted: []uint{1},
+		},
+		{
+			name:       "user not authenticated",
+			ctx:        context.Background(),
+			serverID:   "1",
+			wantStatus: http.StatusUnauthorized,
+			wantError:  "user not authenticated",
+		},
+		{
+			name:       "server not found",
+			ctx:        setupAuth(1),
+			serverID:   "999",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "user without backups permission",
+			ctx:        setupAuth(2),
+			serverID:   "1",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "server is blocked",
+			ctx:        setupAuth(1),
+			serverID:   "2",
+			wantStatus: http.StatusForbidden,
+			wantError:  "server is blocked",
+		},
+		{
+			name:       "operation in progress",
+			ctx:        setupAuth(1),
+			serverID:   "1",
+			creatorErr: backup.ErrOperationInProgress,
+			wantStatus: http.StatusConflict,
+			wantError:  backup.ErrOperationInProgress.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serversRepo := inmemory.NewServerRepository()
+			rbacRepo := inmemory.NewRBACRepository()
+			setupRepos(t, serversRepo, rbacRepo)
+
+			creator := &fakeBackupCreator{err: tt.creatorErr}
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+			handler := NewHandler(serversRepo, rbacService, creator, api.NewResponder())
+
+			req := httptest.NewRequest(http.MethodPost, "/api/servers/"+tt.serverID+"/backups", nil)
+			req = req.WithContext(tt.ctx)
+			req = mux.SetURLVars(req, map[string]string{"server": tt.serverID})
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code, rr.Body.String())
+			assert.Equal(t, tt.wantStarted, creator.started)
+
+			if tt.wantError != "" {
+				var errorResponse map[string]any
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorResponse))
+				errorMsg, ok := errorResponse["error"].(string)
+				if !ok {
+					errorMsg, _ = errorResponse["message"].(string)
+				}
+				assert.Contains(t, errorMsg, tt.wantError)
+			}
+
+			if tt.wantStatus == http.StatusAccepted {
+				var response backupResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, string(domain.BackupStatusCreating), response.Status)
+			}
+		})
+	}
+}
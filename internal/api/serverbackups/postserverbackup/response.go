@@ -0,0 +1,29 @@
+package postserverbackup
+
+import (
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+)
+
+type backupResponse struct {
+	ID        uint       `json:"id"`
+	ServerID  uint       `json:"server_id"`
+	Status    string     `json:"status"`
+	Size      int64      `json:"size"`
+	Error     *string    `json:"error"`
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+func newBackupResponse(backup *domain.Backup) backupResponse {
+	return backupResponse{
+		ID:        backup.ID,
+		ServerID:  backup.ServerID,
+		Status:    string(backup.Status),
+		Size:      backup.Size,
+		Error:     backup.Error,
+		CreatedAt: backup.CreatedAt,
+		UpdatedAt: backup.UpdatedAt,
+	}
+}
@@ -0,0 +1,144 @@
+package restoreserverbackup
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	serversbase "github.com/gameap/gameap/internal/api/servers/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/backup"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+)
+
+type backupRestorer interface {
+	Restore(ctx context.Context, server *domain.Server, backup *domain.Backup) (*domain.Backup, error)
+}
+
+type Handler struct {
+	backupRepo     repositories.BackupRepository
+	serverFinder   *serversbase.ServerFinder
+	abilityChecker *serversbase.AbilityChecker
+	backupRestorer backupRestorer
+	responder      base.Responder
+}
+
+func NewHandler(
+	backupRepo repositories.BackupRepository,
+	serversRepo repositories.ServerRepository,
+	rbac base.RBAC,
+	backupRestorer backupRestorer,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		backupRepo:     backupRepo,
+		serverFinder:   serversbase.NewServerFinder(serversRepo, rbac),
+		abilityChecker: serversbase.NewAbilityChecker(rbac),
+		backupRestorer: backupRestorer,
+		responder:      responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	inputReader := api.NewInputReader(r)
+
+	serverID, err := inputReader.ReadUint("server")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid server id"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	backupID, err := inputReader.ReadUint("backup")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid backup id"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	server, err := h.serverFinder.FindUserServer(ctx, session.User, serverID)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	err = h.abilityChecker.CheckOrError(
+		ctx,
+		session.User.ID,
+		server.ID,
+		[]domain.AbilityName{
+			domain.AbilityNameGameServerBackups,
+			domain.AbilityNameGameServerBackupsRestore,
+		},
+	)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	if server.Blocked {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("server is blocked"),
+			http.StatusForbidden,
+		))
+
+		return
+	}
+
+	backups, err := h.backupRepo.Find(ctx, &filters.FindBackup{
+		IDs:       []uint{backupID},
+		ServerIDs: []uint{server.ID},
+	}, nil, nil)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to find backup"))
+
+		return
+	}
+
+	if len(backups) == 0 {
+		h.responder.WriteError(ctx, rw, api.NewNotFoundError("backup not found"))
+
+		return
+	}
+
+	b, err := h.backupRestorer.Restore(ctx, server, &backups[0])
+	if err != nil {
+		switch {
+		case errors.Is(err, backup.ErrOperationInProgress):
+			h.responder.WriteError(ctx, rw, api.WrapHTTPError(err, http.StatusConflict))
+		case errors.Is(err, backup.ErrBackupNotCompleted):
+			h.responder.WriteError(ctx, rw, api.WrapHTTPError(err, http.StatusUnprocessableEntity))
+		default:
+			h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to restore backup"))
+		}
+
+		return
+	}
+
+	rw.WriteHeader(http.StatusAccepted)
+	h.responder.Write(ctx, rw, newBackupResponse(b))
+}
@@ -0,0 +1,226 @@
+package restoreserverbackup
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/backup"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeBackupRestorer struct {
+	err      error
+	restored []uint
+}
+
+func (r *fakeBackupRestorer) Restore(
+	_ context.Context,
+	_ *domain.Server,
+	b *domain.Backup,
+) (*domain.Backup, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	r.restored = append(r.restored, b.ID)
+
+	restoring := *b
+	restoring.Status = domain.BackupStatusRestoring
+
+	return &restoring, nil
+}
+
+func setupAuth(userID uint) context.Context {
+	session := &auth.Session{
+		Login: "user",
+		Email: "user@example.com",
+		User: &domain.User{
+			ID:    userID,
+			Login: "user",
+			Email: "user@example.com",
+		},
+	}
+
+	return auth.ContextWithSession(context.Background(), session)
+}
+
+func setupRepos(
+	t *testing.T,
+	backupRepo *inmemory.BackupRepository,
+	serverRepo *inmemory.ServerRepository,
+	rbacRepo *inmemory.RBACRepository,
+) {
+	t.Helper()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	for _, s := range []domain.Server{
+		{ID: 1, UUID: uuid.MustParse("11111111-1111-1111-1111-111111111111"), Name: "Test Server"},
+		{ID: 2, UUID: uuid.MustParse("22222222-2222-2222-2222-222222222222"), Name: "Other Server"},
+	} {
+		s.Dir = "/home/gameap/servers/" + s.UUID.String()
+		s.CreatedAt = &now
+		s.UpdatedAt = &now
+		require.NoError(t, serverRepo.Save(ctx, &s))
+	}
+	serverRepo.AddUserServer(2, 1)
+
+	// Backup 1 belongs to server 1, backup 2 belongs to server 2
+	for _, b := range []domain.Backup{
+		{ServerID: 1, Status: domain.BackupStatusCompleted, Path: "backups/1.tar.gz", CreatedAt: &now},
+		{ServerID: 2, Status: domain.BackupStatusCompleted, Path: "backups/2.tar.gz", CreatedAt: &now},
+	} {
+		require.NoError(t, backupRepo.Save(ctx, &b))
+	}
+
+	adminRole := &domain.Role{
+		Name:  "admin",
+		Title: lo.ToPtr("Administrator"),
+		Level: lo.ToPtr(uint(100)),
+	}
+	require.NoError(t, rbacRepo.SaveRole(ctx, adminRole))
+	require.NoError(t, rbacRepo.SaveAssignedRole(ctx, &domain.AssignedRole{
+		RoleID:     adminRole.ID,
+		EntityID:   1,
+		EntityType: domain.EntityTypeUser,
+	}))
+
+	ability := &domain.Ability{
+		Name:  domain.AbilityNameAdminRolesPermissions,
+		Title: lo.ToPtr("Admin Permissions"),
+	}
+	require.NoError(t, rbacRepo.SaveAbility(ctx, ability))
+	require.NoError(t, rbacRepo.SavePermission(ctx, &domain.Permission{
+		AbilityID:  ability.ID,
+		EntityID:   lo.ToPtr(uint(1)),
+		EntityType: lo.ToPtr(domain.EntityTypeUser),
+	}))
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		backupID     string
+		restorerErr  error
+		wantStatus   int
+		wantError    string
+		wantRestored []uint
+	}{
+		{
+			name:         "restore started",
+			ctx:          setupAuth(1),
+			backupID:     "1",
+			wantStatus:   http.StatusAccepted,
+			wantRestored: []uint{1},
+		},
+		{
+			name:       "user not authenticated",
+			ctx:        context.Background(),
+			backupID:   "1",
+			wantStatus: http.StatusUnauthorized,
+			wantError:  "user not authenticated",
+		},
+		{
+			name:       "invalid backup id",
+			ctx:        setupAuth(1),
+			backupID:   "invalid",
+			wantStatus: http.StatusBadRequest,
+			wantError:  "invalid backup id",
+		},
+		{
+			name:       "backup not found",
+			ctx:        setupAuth(1),
+			backupID:   "999",
+			wantStatus: http.StatusNotFound,
+			wantError:  "backup not found",
+		},
+		{
+			name:       "backup of another server",
+			ctx:        setupAuth(1),
+			backupID:   "2",
+			wantStatus: http.StatusNotFound,
+			wantError:  "backup not found",
+		},
+		{
+			name:       "user without restore permission",
+			ctx:        setupAuth(2),
+			backupID:   "1",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:        "operation in progress",
+			ctx:         setupAuth(1),
+			backupID:    "1",
+			restorerErr: backup.ErrOperationInProgress,
+			wantStatus:  http.StatusConflict,
+			wantError:   backup.ErrOperationInProgress.Error(),
+		},
+		{
+			name:        "backup not completed",
+			ctx:         setupAuth(1),
+			backupID:    "1",
+			restorerErr: backup.ErrBackupNotCompleted,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantError:   backup.ErrBackupNotCompleted.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serversRepo := inmemory.NewServerRepository()
+			backupRepo := inmemory.NewBackupRepository()
+			rbacRepo := inmemory.NewRBACRepository()
+			setupRepos(t, backupRepo, serversRepo, rbacRepo)
+
+			restorer := &fakeBackupRestorer{err: tt.restorerErr}
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+			handler := NewHandler(backupRepo, serversRepo, rbacService, restorer, api.NewResponder())
+
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"/api/servers/1/backups/"+tt.backupID+"/restore",
+				nil,
+			)
+			req = req.WithContext(tt.ctx)
+			req = mux.SetURLVars(req, map[string]string{"server": "1", "backup": tt.backupID})
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code, rr.Body.String())
+			assert.Equal(t, tt.wantRestored, restorer.restored)
+
+			if tt.wantError != "" {
+				var errorResponse map[string]any
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorResponse))
+				errorMsg, ok := errorResponse["error"].(string)
+				if !ok {
+					errorMsg, _ = errorResponse["message"].(string)
+				}
+				assert.Contains(t, errorMsg, tt.wantError)
+			}
+
+			if tt.wantStatus == http.StatusAccepted {
+				var response backupResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, string(domain.BackupStatusRestoring), response.Status)
+			}
+		})
+	}
+}
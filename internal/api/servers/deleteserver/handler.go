@@ -9,6 +9,7 @@ import (
 
 	"github.com/gameap/gameap/internal/api/base"
 	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/events"
 	"github.com/gameap/gameap/internal/filters"
 	"github.com/gameap/gameap/internal/repositories"
 	"github.com/gameap/gameap/pkg/api"
@@ -16,10 +17,15 @@ import (
 	"github.com/samber/lo"
 )
 
+type eventPublisher interface {
+	Publish(ctx context.Context, event domain.Event)
+}
+
 type Handler struct {
 	serverRepo     repositories.ServerRepository
 	daemonTaskRepo repositories.DaemonTaskRepository
 	rbac           base.RBAC
+	eventPublisher eventPublisher
 	responder      base.Responder
 }
 
@@ -27,12 +33,14 @@ func NewHandler(
 	serverRepo repositories.ServerRepository,
 	daemonTaskRepo repositories.DaemonTaskRepository,
 	rbac base.RBAC,
+	eventPublisher eventPublisher,
 	responder base.Responder,
 ) *Handler {
 	return &Handler{
 		serverRepo:     serverRepo,
 		daemonTaskRepo: daemonTaskRepo,
 		rbac:           rbac,
+		eventPublisher: eventPublisher,
 		responder:      responder,
 	}
 }
@@ -98,6 +106,8 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	h.eventPublisher.Publish(ctx, events.ServerDeleted(server))
+
 	rw.WriteHeader(http.StatusNoContent)
 }
 
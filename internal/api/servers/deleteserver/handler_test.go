@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/events"
 	"github.com/gameap/gameap/internal/filters"
 	"github.com/gameap/gameap/internal/rbac"
 	"github.com/gameap/gameap/internal/repositories/inmemory"
@@ -174,7 +175,7 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			rbacRepo := inmemory.NewRBACRepository()
 			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
 			responder := api.NewResponder()
-			handler := NewHandler(serverRepo, daemonTaskRepo, rbacService, responder)
+			handler := NewHandler(serverRepo, daemonTaskRepo, rbacService, events.NewBus(), responder)
 
 			if tt.setupRepo != nil {
 				tt.setupRepo(serverRepo, rbacRepo)
@@ -216,7 +217,16 @@ func TestHandler_ServerActuallyDeleted(t *testing.T) {
 	rbacRepo := inmemory.NewRBACRepository()
 	rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
 	responder := api.NewResponder()
-	handler := NewHandler(serverRepo, daemonTaskRepo, rbacService, responder)
+	bus := events.NewBus()
+
+	var published []domain.Event
+	bus.Subscribe(events.HandlerFunc(func(_ context.Context, event domain.Event) error {
+		published = append(published, event)
+
+		return nil
+	}))
+
+	handler := NewHandler(serverRepo, daemonTaskRepo, rbacService, bus, responder)
 
 	now := time.Now()
 	u := uuid.New()
@@ -269,6 +279,10 @@ func TestHandler_ServerActuallyDeleted(t *testing.T) {
 	})
 	require.NoError(t, err)
 	assert.Len(t, servers, 0)
+
+	require.Len(t, published, 1)
+	assert.Equal(t, domain.EventTypeServerDeleted, published[0].Type)
+	assert.Equal(t, lo.ToPtr(server.ID), published[0].ServerID)
 }
 
 func TestHandler_NewHandler(t *testing.T) {
@@ -278,7 +292,7 @@ func TestHandler_NewHandler(t *testing.T) {
 	rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
 	responder := api.NewResponder()
 
-	handler := NewHandler(serverRepo, daemonTaskRepo, rbacService, responder)
+	handler := NewHandler(serverRepo, daemonTaskRepo, rbacService, events.NewBus(), responder)
 
 	require.NotNil(t, handler)
 	assert.Equal(t, serverRepo, handler.serverRepo)
@@ -294,7 +308,7 @@ func TestHandler_DeleteFiles(t *testing.T) {
 		rbacRepo := inmemory.NewRBACRepository()
 		rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
 		responder := api.NewResponder()
-		handler := NewHandler(serverRepo, daemonTaskRepo, rbacService, responder)
+		handler := NewHandler(serverRepo, daemonTaskRepo, rbacService, events.NewBus(), responder)
 
 		now := time.Now()
 		u := uuid.New()
@@ -347,7 +361,7 @@ func TestHandler_DeleteFiles(t *testing.T) {
 		rbacRepo := inmemory.NewRBACRepository()
 		rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
 		responder := api.NewResponder()
-		handler := NewHandler(serverRepo, daemonTaskRepo, rbacService, responder)
+		handler := NewHandler(serverRepo, daemonTaskRepo, rbacService, events.NewBus(), responder)
 
 		now := time.Now()
 		u := uuid.New()
@@ -408,7 +422,7 @@ func TestHandler_DeleteFiles(t *testing.T) {
 		rbacRepo := inmemory.NewRBACRepository()
 		rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
 		responder := api.NewResponder()
-		handler := NewHandler(serverRepo, daemonTaskRepo, rbacService, responder)
+		handler := NewHandler(serverRepo, daemonTaskRepo, rbacService, events.NewBus(), responder)
 
 		now := time.Now()
 		u := uuid.New()
@@ -480,7 +494,7 @@ func TestHandler_DeleteFiles(t *testing.T) {
 		rbacRepo := inmemory.NewRBACRepository()
 		rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
 		responder := api.NewResponder()
-		handler := NewHandler(serverRepo, daemonTaskRepo, rbacService, responder)
+		handler := NewHandler(serverRepo, daemonTaskRepo, rbacService, events.NewBus(), responder)
 
 		now := time.Now()
 		u := uuid.New()
@@ -538,7 +552,7 @@ func TestHandler_DeleteFiles(t *testing.T) {
 		rbacRepo := inmemory.NewRBACRepository()
 		rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
 		responder := api.NewResponder()
-		handler := NewHandler(serverRepo, daemonTaskRepo, rbacService, responder)
+		handler := NewHandler(serverRepo, daemonTaskRepo, rbacService, events.NewBus(), responder)
 
 		now := time.Now()
 		u := uuid.New()
@@ -605,7 +619,7 @@ func TestHandler_DeleteFiles(t *testing.T) {
 		rbacRepo := inmemory.NewRBACRepository()
 		rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
 		responder := api.NewResponder()
-		handler := NewHandler(serverRepo, daemonTaskRepo, rbacService, responder)
+		handler := NewHandler(serverRepo, daemonTaskRepo, rbacService, events.NewBus(), responder)
 
 		now := time.Now()
 		u := uuid.New()
@@ -661,7 +675,7 @@ func TestHandler_DeleteFiles(t *testing.T) {
 		rbacRepo := inmemory.NewRBACRepository()
 		rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
 		responder := api.NewResponder()
-		handler := NewHandler(serverRepo, daemonTaskRepo, rbacService, responder)
+		handler := NewHandler(serverRepo, daemonTaskRepo, rbacService, events.NewBus(), responder)
 
 		now := time.Now()
 		u := uuid.New()
@@ -726,7 +740,7 @@ func TestHandler_DeleteFiles(t *testing.T) {
 		rbacRepo := inmemory.NewRBACRepository()
 		rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
 		responder := api.NewResponder()
-		handler := NewHandler(serverRepo, daemonTaskRepo, rbacService, responder)
+		handler := NewHandler(serverRepo, daemonTaskRepo, rbacService, events.NewBus(), responder)
 
 		now := time.Now()
 		u := uuid.New()
@@ -0,0 +1,84 @@
+package getautorestart
+
+import (
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	serversbase "github.com/gameap/gameap/internal/api/servers/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+)
+
+// Handler returns the state of automatic restarts of a crashed server by the panel watchdog.
+type Handler struct {
+	serverFinder *serversbase.ServerFinder
+	restartRepo  repositories.ServerAutoRestartRepository
+	policy       domain.AutoRestartPolicy
+	responder    base.Responder
+}
+
+func NewHandler(
+	serverRepo repositories.ServerRepository,
+	restartRepo repositories.ServerAutoRestartRepository,
+	policy domain.AutoRestartPolicy,
+	rbac base.RBAC,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		serverFinder: serversbase.NewServerFinder(serverRepo, rbac),
+		restartRepo:  restartRepo,
+		policy:       policy,
+		responder:    responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	serverID, err := api.NewInputReader(r).ReadUint("server")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid server id"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	server, err := h.serverFinder.FindUserServer(ctx, session.User, serverID)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	restarts, err := h.restartRepo.Find(ctx, &filters.FindServerAutoRestart{
+		ServerIDs: []uint{server.ID},
+	}, nil, nil)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to find server auto restart"))
+
+		return
+	}
+
+	restart := &domain.ServerAutoRestart{ServerID: server.ID}
+	if len(restarts) > 0 {
+		restart = &restarts[0]
+	}
+
+	h.responder.Write(ctx, rw, newAutoRestartResponse(restart, h.policy))
+}
@@ -0,0 +1,202 @@
+package getautorestart
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUser1 = domain.User{
+	ID:    1,
+	Login: "testuser",
+	Email: "test@example.com",
+}
+
+var testPolicy = domain.AutoRestartPolicy{
+	Threshold:    time.Minute,
+	BaseDelay:    30 * time.Second,
+	MaxDelay:     10 * time.Minute,
+	MaxAttempts:  5,
+	StablePeriod: 10 * time.Minute,
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	crashedAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		serverID       string
+		authenticated  bool
+		restart        *domain.ServerAutoRestart
+		expectedStatus int
+		wantError      string
+		wantBody       string
+	}{
+		{
+			name:           "crashed server waiting for restart",
+			serverID:       "1",
+			authenticated:  true,
+			expectedStatus: http.StatusOK,
+			restart: &domain.ServerAutoRestart{
+				ServerID:      1,
+				CrashedAt:     &crashedAt,
+				Attempts:      2,
+				LastAttemptAt: lo.ToPtr(crashedAt.Add(2 * time.Minute)),
+				LastTaskID:    lo.ToPtr(uint(7)),
+				CrashLoops:    1,
+			},
+			wantBody: `{
+				"crashed": true,
+				"crashed_at": "2025-03-01T10:00:00Z",
+				"attempts": 2,
+				"max_attempts": 5,
+				"last_attempt_at": "2025-03-01T10:02:00Z",
+				"last_task_id": 7,
+				"next_attempt_at": "2025-03-01T10:03:00Z",
+				"crash_loop": false,
+				"crash_loop_at": null,
+				"crash_loops": 1
+			}`,
+		},
+		{
+			name:           "server in crash loop",
+			serverID:       "1",
+			authenticated:  true,
+			expectedStatus: http.StatusOK,
+			restart: &domain.ServerAutoRestart{
+				ServerID:      1,
+				CrashedAt:     &crashedAt,
+				Attempts:      5,
+				LastAttemptAt: lo.ToPtr(crashedAt.Add(10 * time.Minute)),
+				CrashLoop:     true,
+				CrashLoopAt:   lo.ToPtr(crashedAt.Add(20 * time.Minute)),
+				CrashLoops:    1,
+			},
+			wantBody: `{
+				"crashed": true,
+				"crashed_at": "2025-03-01T10:00:00Z",
+				"attempts": 5,
+				"max_attempts": 5,
+				"last_attempt_at": "2025-03-01T10:10:00Z",
+				"last_task_id": null,
+				"next_attempt_at": null,
+				"crash_loop": true,
+				"crash_loop_at": "2025-03-01T10:20:00Z",
+				"crash_loops": 1
+			}`,
+		},
+		{
+			name:           "never restarted",
+			serverID:       "1",
+			authenticated:  true,
+			expectedStatus: http.StatusOK,
+			wantBody: `{
+				"crashed": false,
+				"crashed_at": null,
+				"attempts": 0,
+				"max_attempts": 5,
+				"last_attempt_at": null,
+				"last_task_id": null,
+				"next_attempt_at": null,
+				"crash_loop": false,
+				"crash_loop_at": null,
+				"crash_loops": 0
+			}`,
+		},
+		{
+			name:           "server of another user",
+			serverID:       "2",
+			authenticated:  true,
+			expectedStatus: http.StatusNotFound,
+			wantError:      "server not found",
+		},
+		{
+			name:           "invalid server id",
+			serverID:       "invalid",
+			authenticated:  true,
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "invalid server id",
+		},
+		{
+			name:           "user not authenticated",
+			serverID:       "1",
+			expectedStatus: http.StatusUnauthorized,
+			wantError:      "user not authenticated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			serverRepo := inmemory.NewServerRepository()
+			restartRepo := inmemory.NewServerAutoRestartRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), inmemory.NewRBACRepository(), 0)
+			handler := NewHandler(serverRepo, restartRepo, testPolicy, rbacService, api.NewResponder())
+
+			require.NoError(t, serverRepo.Save(ctx, &domain.Server{
+				ID:         1,
+				UUID:       uuid.New(),
+				Enabled:    true,
+				Name:       "Test Server 1",
+				DSID:       1,
+				ServerIP:   "127.0.0.1",
+				ServerPort: 27015,
+			}))
+			serverRepo.AddUserServer(1, 1)
+
+			require.NoError(t, serverRepo.Save(ctx, &domain.Server{
+				ID:         2,
+				UUID:       uuid.New(),
+				Enabled:    true,
+				Name:       "Test Server 2",
+				DSID:       1,
+				ServerIP:   "127.0.0.1",
+				ServerPort: 27016,
+			}))
+			serverRepo.AddUserServer(2, 2)
+
+			if tt.restart != nil {
+				require.NoError(t, restartRepo.Save(ctx, tt.restart))
+			}
+
+			if tt.authenticated {
+				ctx = auth.ContextWithSession(ctx, &auth.Session{
+					Login: testUser1.Login,
+					Email: testUser1.Email,
+					User:  &testUser1,
+				})
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/servers/"+tt.serverID+"/auto_restart", nil)
+			req = req.WithContext(ctx)
+			req = mux.SetURLVars(req, map[string]string{"server": tt.serverID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.wantError != "" {
+				assert.Contains(t, w.Body.String(), tt.wantError)
+
+				return
+			}
+
+			assert.JSONEq(t, tt.wantBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package getautorestart
+
+import (
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+)
+
+type autoRestartResponse struct {
+	Crashed       bool       `json:"crashed"`
+	CrashedAt     *time.Time `json:"crashed_at"`
+	Attempts      int        `json:"attempts"`
+	MaxAttempts   int        `json:"max_attempts"`
+	LastAttemptAt *time.Time `json:"last_attempt_at"`
+	LastTaskID    *uint      `json:"last_task_id"`
+	// NextAttemptAt is set while the crashed server is waiting for a restart.
+	NextAttemptAt *time.Time `json:"next_attempt_at"`
+	CrashLoop     bool       `json:"crash_loop"`
+	CrashLoopAt   *time.Time `json:"crash_loop_at"`
+	CrashLoops    int        `json:"crash_loops"`
+}
+
+func newAutoRestartResponse(
+	restart *domain.ServerAutoRestart,
+	policy domain.AutoRestartPolicy,
+) autoRestartResponse {
+	response := autoRestartResponse{
+		Crashed:       restart.CrashedAt != nil,
+		CrashedAt:     restart.CrashedAt,
+		Attempts:      restart.Attempts,
+		MaxAttempts:   policy.MaxAttempts,
+		LastAttemptAt: restart.LastAttemptAt,
+		LastTaskID:    restart.LastTaskID,
+		CrashLoop:     restart.CrashLoop,
+		CrashLoopAt:   restart.CrashLoopAt,
+		CrashLoops:    restart.CrashLoops,
+	}
+
+	if restart.CrashedAt != nil && !restart.CrashLoop {
+		next := restart.NextAttemptAt(policy)
+		response.NextAttemptAt = &next
+	}
+
+	return response
+}
@@ -3,23 +3,18 @@ package getconsole
 import (
 	"context"
 	"net/http"
-	"path/filepath"
-	"strings"
-	"unicode/utf8"
 
 	"github.com/gameap/gameap/internal/api/base"
 	serversbase "github.com/gameap/gameap/internal/api/servers/base"
 	"github.com/gameap/gameap/internal/daemon"
 	"github.com/gameap/gameap/internal/domain"
-	"github.com/gameap/gameap/internal/filters"
 	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/serverconsole"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
 	"github.com/pkg/errors"
 )
 
-const consoleMaxSymbols = 65536
-
 type daemonCommands interface {
 	ExecuteCommand(
 		ctx context.Context,
@@ -36,9 +31,7 @@ type fileService interface {
 type Handler struct {
 	serverFinder   *serversbase.ServerFinder
 	abilityChecker *serversbase.AbilityChecker
-	nodeRepo       repositories.NodeRepository
-	daemonCommands daemonCommands
-	fileService    fileService
+	consoleReader  *serverconsole.Reader
 	responder      base.Responder
 }
 
@@ -53,9 +46,7 @@ func NewHandler(
 	return &Handler{
 		serverFinder:   serversbase.NewServerFinder(serverRepo, rbac),
 		abilityChecker: serversbase.NewAbilityChecker(rbac),
-		nodeRepo:       nodeRepo,
-		daemonCommands: daemonCommands,
-		fileService:    fs,
+		consoleReader:  serverconsole.NewReader(nodeRepo, daemonCommands, fs),
 		responder:      responder,
 	}
 }
@@ -103,7 +94,7 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	consoleOutput, err := h.getConsoleLog(ctx, server)
+	consoleOutput, err := h.consoleReader.Read(ctx, server)
 	if err != nil {
 		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to get console log"))
 
@@ -112,70 +103,3 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 
 	h.responder.Write(ctx, rw, newConsoleResponse(consoleOutput))
 }
-
-func (h *Handler) getConsoleLog(ctx context.Context, server *domain.Server) (string, error) {
-	nodes, err := h.nodeRepo.Find(ctx, &filters.FindNode{
-		IDs: []uint{server.DSID},
-	}, nil, &filters.Pagination{
-		Limit: 1,
-	})
-	if err != nil {
-		return "", errors.WithMessage(err, "failed to find node")
-	}
-
-	if len(nodes) == 0 {
-		return "", api.NewNotFoundError("node not found")
-	}
-
-	node := &nodes[0]
-
-	if node.ScriptGetConsole != nil && *node.ScriptGetConsole != "" {
-		cmd := server.ReplaceServerShortcodes(node, *node.ScriptGetConsole, nil)
-
-		result, err := h.daemonCommands.ExecuteCommand(ctx, node, cmd)
-		if err != nil {
-			return "", errors.WithMessage(err, "failed to execute get console script")
-		}
-
-		return result.Output, nil
-	}
-
-	return h.downloadOutputFile(ctx, node, server.Dir)
-}
-
-func (h *Handler) downloadOutputFile(ctx context.Context, node *domain.Node, filePath string) (string, error) {
-	outputPath := filepath.Join(filePath, "output.txt")
-
-	content, err := h.fileService.Download(ctx, node, outputPath)
-	if err != nil {
-		return "", errors.WithMessage(err, "failed to download console log")
-	}
-
-	result := string(content)
-
-	if len(result) > consoleMaxSymbols {
-		result = result[len(result)-consoleMaxSymbols:]
-	}
-
-	result = sanitizeUTF8(result)
-
-	return result, nil
-}
-
-func sanitizeUTF8(s string) string {
-	if utf8.ValidString(s) {
-		return s
-	}
-
-	var b strings.Builder
-	b.Grow(len(s))
-
-	for _, r := range s {
-		if r == utf8.RuneError {
-			continue
-		}
-		b.WriteRune(r)
-	}
-
-	return b.String()
-}
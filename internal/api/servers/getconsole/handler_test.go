@@ -14,6 +14,7 @@ import (
 	"github.com/gameap/gameap/internal/rbac"
 	"github.com/gameap/gameap/internal/repositories/inmemory"
 	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/serverconsole"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
 	"github.com/google/uuid"
@@ -589,7 +590,7 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			setupMockFS: func() *mockFileService {
 				return &mockFileService{
 					downloadFunc: func(_ context.Context, _ *domain.Node, _ string) ([]byte, error) {
-						longOutput := strings.Repeat("a", consoleMaxSymbols+1000)
+						longOutput := strings.Repeat("a", serverconsole.MaxSymbols+1000)
 
 						return []byte(longOutput), nil
 					},
@@ -603,8 +604,8 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			validateConsoleValue: func(t *testing.T, console string) {
 				t.Helper()
 
-				assert.LessOrEqual(t, len(console), consoleMaxSymbols)
-				assert.Equal(t, consoleMaxSymbols, len(console))
+				assert.LessOrEqual(t, len(console), serverconsole.MaxSymbols)
+				assert.Equal(t, serverconsole.MaxSymbols, len(console))
 			},
 		},
 		{
@@ -805,48 +806,10 @@ func TestHandler_NewHandler(t *testing.T) {
 	require.NotNil(t, handler)
 	assert.NotNil(t, handler.serverFinder)
 	assert.NotNil(t, handler.abilityChecker)
-	assert.Equal(t, nodeRepo, handler.nodeRepo)
-	assert.Equal(t, mockDaemon, handler.daemonCommands)
-	assert.Equal(t, mockFS, handler.fileService)
+	assert.NotNil(t, handler.consoleReader)
 	assert.Equal(t, responder, handler.responder)
 }
 
-func TestHandler_SanitizeUTF8(t *testing.T) {
-	tests := []struct {
-		name     string
-		input    string
-		expected string
-	}{
-		{
-			name:     "valid utf8",
-			input:    "Hello World!",
-			expected: "Hello World!",
-		},
-		{
-			name:     "valid utf8 with unicode",
-			input:    "Hello 世界!",
-			expected: "Hello 世界!",
-		},
-		{
-			name:     "empty string",
-			input:    "",
-			expected: "",
-		},
-		{
-			name:     "string with emojis",
-			input:    "Server starting 🚀",
-			expected: "Server starting 🚀",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := sanitizeUTF8(tt.input)
-			assert.Equal(t, tt.expected, result)
-		})
-	}
-}
-
 func TestNewConsoleResponse(t *testing.T) {
 	consoleOutput := "Server starting...\nServer online\n"
 	response := newConsoleResponse(consoleOutput)
@@ -0,0 +1,338 @@
+package getconsolestream
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gameap/gameap/internal/api/base"
+	serversbase "github.com/gameap/gameap/internal/api/servers/base"
+	"github.com/gameap/gameap/internal/daemon"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/audit"
+	"github.com/gameap/gameap/internal/services/serverconsole"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+const (
+	writeWait      = 10 * time.Second
+	pongWait       = 60 * time.Second
+	pingPeriod     = pongWait * 9 / 10
+	maxMessageSize = 4096
+
+	// auditAction is the action of the audit logs of the commands sent over the stream.
+	auditAction = "GET /api/servers/{server}/console/stream"
+)
+
+type consoleHub interface {
+	Subscribe(server *domain.Server) *serverconsole.Subscription
+}
+
+type daemonCommands interface {
+	ExecuteCommand(
+		ctx context.Context,
+		node *domain.Node,
+		command string,
+		opts ...daemon.CommandServiceOption,
+	) (*daemon.CommandResult, error)
+}
+
+type fileService interface {
+	Upload(ctx context.Context, node *domain.Node, filePath string, content []byte, perms os.FileMode) error
+}
+
+type auditService interface {
+	Record(ctx context.Context, log *domain.AuditLog) error
+}
+
+// Handler streams game server console over WebSocket.
+// New console output is pushed to the client as it appears,
+// the client can send console commands over the same connection.
+// The audit middleware skips GET routes, so the handler records the sent commands itself.
+type Handler struct {
+	serverFinder   *serversbase.ServerFinder
+	abilityChecker *serversbase.AbilityChecker
+	hub            consoleHub
+	consoleSender  *serverconsole.Sender
+	auditService   auditService
+	clientIPHeader string
+	responder      base.Responder
+	upgrader       websocket.Upgrader
+}
+
+func NewHandler(
+	serverRepo repositories.ServerRepository,
+	nodeRepo repositories.NodeRepository,
+	rbac base.RBAC,
+	daemonCommands daemonCommands,
+	fs fileService,
+	hub consoleHub,
+	auditService auditService,
+	clientIPHeader string,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		serverFinder:   serversbase.NewServerFinder(serverRepo, rbac),
+		abilityChecker: serversbase.NewAbilityChecker(rbac),
+		hub:            hub,
+		consoleSender:  serverconsole.NewSender(nodeRepo, daemonCommands, fs),
+		auditService:   auditService,
+		clientIPHeader: clientIPHeader,
+		responder:      responder,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 4096,
+		},
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	input := api.NewInputReader(r)
+
+	serverID, err := input.ReadUint("server")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid server id"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	server, err := h.serverFinder.FindUserServer(ctx, session.User, serverID)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	if err = h.abilityChecker.CheckOrError(
+		ctx,
+		session.User.ID,
+		server.ID,
+		[]domain.AbilityName{domain.AbilityNameGameServerConsoleView},
+	); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	canSend, err := h.abilityChecker.Check(
+		ctx,
+		session.User.ID,
+		server.ID,
+		[]domain.AbilityName{domain.AbilityNameGameServerConsoleSend},
+	)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	// Upgrade writes the error response itself
+	conn, err := h.upgrader.Upgrade(rw, r, nil)
+	if err != nil {
+		return
+	}
+
+	s := &stream{
+		conn:         conn,
+		server:       server,
+		sender:       h.consoleSender,
+		canSend:      canSend,
+		auditService: h.auditService,
+		auditLog:     h.newAuditLog(r, session, server),
+	}
+	s.run(ctx, h.hub.Subscribe(server))
+}
+
+// newAuditLog returns the audit log template of the commands sent over the stream of the request.
+func (h *Handler) newAuditLog(r *http.Request, session *auth.Session, server *domain.Server) domain.AuditLog {
+	log := domain.AuditLog{
+		UserID:     &session.User.ID,
+		IP:         base.ClientIP(r, h.clientIPHeader),
+		UserAgent:  lo.Substring(r.UserAgent(), 0, 512),
+		Action:     auditAction,
+		EntityType: lo.ToPtr(domain.EntityTypeServer),
+		EntityID:   lo.ToPtr(strconv.FormatUint(uint64(server.ID), 10)),
+	}
+
+	if session.IsTokenSession() {
+		log.TokenID = &session.Token.ID
+	}
+
+	return log
+}
+
+type stream struct {
+	conn    *websocket.Conn
+	server  *domain.Server
+	sender  *serverconsole.Sender
+	canSend bool
+
+	auditService auditService
+	auditLog     domain.AuditLog
+
+	writeMu sync.Mutex
+}
+
+func (s *stream) run(ctx context.Context, sub *serverconsole.Subscription) {
+	defer func() {
+		sub.Close()
+		_ = s.conn.Close()
+	}()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go s.readLoop(ctx, cancel)
+
+	ticker := time.NewTicker(pingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+
+			if err := s.write(newEventMessage(event)); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := s.writeControl(websocket.PingMessage); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (s *stream) readLoop(ctx context.Context, cancel context.CancelFunc) {
+	defer cancel()
+
+	s.conn.SetReadLimit(maxMessageSize)
+	_ = s.conn.SetReadDeadline(time.Now().Add(pongWait))
+	s.conn.SetPongHandler(func(string) error {
+		return s.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		_, data, err := s.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				slog.DebugContext(ctx, "Console stream closed", slog.String("error", err.Error()))
+			}
+
+			return
+		}
+
+		if err = s.write(s.handleMessage(ctx, data)); err != nil {
+			return
+		}
+	}
+}
+
+func (s *stream) handleMessage(ctx context.Context, data []byte) message {
+	var in commandInput
+	if err := json.Unmarshal(data, &in); err != nil {
+		return newErrorMessage(errors.WithMessage(err, "failed to parse message"))
+	}
+
+	if err := in.validate(); err != nil {
+		return newErrorMessage(err)
+	}
+
+	if !s.canSend {
+		s.record(ctx, data, http.StatusForbidden)
+
+		return newErrorMessage(errors.New("user does not have required permissions"))
+	}
+
+	if err := s.sender.Send(ctx, s.server, in.Command); err != nil {
+		slog.ErrorContext(
+			ctx,
+			"Failed to send console command",
+			slog.Uint64("server_id", uint64(s.server.ID)),
+			slog.String("error", err.Error()),
+		)
+
+		s.record(ctx, data, http.StatusInternalServerError)
+
+		return newErrorMessage(errors.New("failed to send console command"))
+	}
+
+	s.record(ctx, data, http.StatusOK)
+
+	return newCommandMessage()
+}
+
+// record saves the audit log of the command message with the HTTP status matching the result.
+func (s *stream) record(ctx context.Context, data []byte, status int) {
+	// The record is saved even if the client has gone away
+	ctx = context.WithoutCancel(ctx)
+
+	log := s.auditLog
+	log.StatusCode = status
+	log.Result = domain.AuditResultSuccess
+
+	if status >= http.StatusBadRequest {
+		log.Result = domain.AuditResultFailure
+	}
+
+	request, err := audit.RedactJSON(data)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to redact console command", slog.String("error", err.Error()))
+	} else {
+		log.Request = &request
+	}
+
+	if err = s.auditService.Record(ctx, &log); err != nil {
+		slog.ErrorContext(
+			ctx,
+			"Failed to record audit log",
+			slog.String("action", log.Action),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+func (s *stream) write(msg message) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	_ = s.conn.SetWriteDeadline(time.Now().Add(writeWait))
+
+	return s.conn.WriteJSON(msg)
+}
+
+func (s *stream) writeControl(messageType int) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	return s.conn.WriteControl(messageType, nil, time.Now().Add(writeWait))
+}
@@ -0,0 +1,344 @@
+package getconsolestream
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/daemon"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/audit"
+	"github.com/gameap/gameap/internal/services/serverconsole"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUser1 = domain.User{
+	ID:    1,
+	Login: "testuser",
+	Email: "test@example.com",
+}
+
+type mockDaemonCommands struct{}
+
+func (m *mockDaemonCommands) ExecuteCommand(
+	_ context.Context,
+	_ *domain.Node,
+	_ string,
+	_ ...daemon.CommandServiceOption,
+) (*daemon.CommandResult, error) {
+	return &daemon.CommandResult{}, nil
+}
+
+type mockFileService struct {
+	mu       sync.Mutex
+	uploaded map[string]string
+}
+
+func (m *mockFileService) Upload(
+	_ context.Context,
+	_ *domain.Node,
+	filePath string,
+	content []byte,
+	_ os.FileMode,
+) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.uploaded == nil {
+		m.uploaded = make(map[string]string)
+	}
+	m.uploaded[filePath] = string(content)
+
+	return nil
+}
+
+func (m *mockFileService) get(filePath string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.uploaded[filePath]
+}
+
+type staticConsoleReader struct {
+	output string
+}
+
+func (r *staticConsoleReader) Read(_ context.Context, _ *domain.Server) (string, error) {
+	return r.output, nil
+}
+
+type testEnv struct {
+	serverRepo   *inmemory.ServerRepository
+	rbacRepo     *inmemory.RBACRepository
+	auditLogRepo *inmemory.AuditLogRepository
+	fs           *mockFileService
+	handler      *Handler
+}
+
+func setup(t *testing.T) *testEnv {
+	t.Helper()
+
+	now := time.Now()
+
+	env := &testEnv{
+		serverRepo:   inmemory.NewServerRepository(),
+		rbacRepo:     inmemory.NewRBACRepository(),
+		auditLogRepo: inmemory.NewAuditLogRepository(),
+		fs:           &mockFileService{},
+	}
+
+	nodeRepo := inmemory.NewNodeRepository()
+	require.NoError(t, nodeRepo.Save(context.Background(), &domain.Node{
+		ID:        1,
+		Enabled:   true,
+		Name:      "test-node",
+		OS:        "linux",
+		WorkPath:  "/srv/gameap",
+		CreatedAt: &now,
+		UpdatedAt: &now,
+	}))
+
+	require.NoError(t, env.serverRepo.Save(context.Background(), &domain.Server{
+		ID:        1,
+		UUID:      uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+		UUIDShort: "short1",
+		Enabled:   true,
+		Installed: 1,
+		Name:      "Test Server 1",
+		GameID:    "cs",
+		DSID:      1,
+		ServerIP:  "127.0.0.1",
+		Dir:       "/home/gameap/servers/test1",
+		CreatedAt: &now,
+		UpdatedAt: &now,
+	}))
+	env.serverRepo.AddUserServer(testUser1.ID, 1)
+
+	env.handler = NewHandler(
+		env.serverRepo,
+		nodeRepo,
+		rbac.NewRBAC(services.NewNilTransactionManager(), env.rbacRepo, 0),
+		&mockDaemonCommands{},
+		env.fs,
+		serverconsole.NewHub(&staticConsoleReader{output: "Server started\n"}, time.Hour),
+		audit.NewService(env.auditLogRepo, audit.Repositories{}),
+		"",
+		api.NewResponder(),
+	)
+
+	return env
+}
+
+func (env *testEnv) auditLogs(t *testing.T) []domain.AuditLog {
+	t.Helper()
+
+	logs, err := env.auditLogRepo.Find(context.Background(), nil, nil, nil)
+	require.NoError(t, err)
+
+	return logs
+}
+
+func (env *testEnv) allow(t *testing.T, abilities ...domain.AbilityName) {
+	t.Helper()
+
+	for _, ability := range abilities {
+		require.NoError(t, env.rbacRepo.Allow(
+			context.Background(),
+			testUser1.ID,
+			domain.EntityTypeUser,
+			[]domain.Ability{
+				{
+					Name:       ability,
+					EntityID:   lo.ToPtr(uint(1)),
+					EntityType: lo.ToPtr(domain.EntityTypeServer),
+				},
+			},
+		))
+	}
+}
+
+func (env *testEnv) startServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		ctx := auth.ContextWithSession(r.Context(), &auth.Session{
+			Login: testUser1.Login,
+			Email: testUser1.Email,
+			User:  &testUser1,
+		})
+
+		r = mux.SetURLVars(r.WithContext(ctx), map[string]string{"server": "1"})
+
+		env.handler.ServeHTTP(rw, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func dial(t *testing.T, srv *httptest.Server) *websocket.Conn {
+	t.Helper()
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/api/servers/1/console/stream"
+
+	conn, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+	return conn
+}
+
+func TestHandler_StreamsConsole(t *testing.T) {
+	env := setup(t)
+	env.allow(t, domain.AbilityNameGameServerConsoleView)
+	srv := env.startServer(t)
+
+	conn := dial(t, srv)
+
+	var msg message
+	require.NoError(t, conn.ReadJSON(&msg))
+	assert.Equal(t, message{Type: "reset", Data: "Server started\n"}, msg)
+}
+
+func TestHandler_SendsCommand(t *testing.T) {
+	env := setup(t)
+	env.allow(t, domain.AbilityNameGameServerConsoleView, domain.AbilityNameGameServerConsoleSend)
+	srv := env.startServer(t)
+
+	conn := dial(t, srv)
+
+	var msg message
+	require.NoError(t, conn.ReadJSON(&msg))
+	require.Equal(t, "reset", msg.Type)
+
+	require.NoError(t, conn.WriteJSON(map[string]string{"command": "status"}))
+
+	msg = message{}
+	require.NoError(t, conn.ReadJSON(&msg))
+
+	assert.Equal(t, message{Type: "command", Status: "success"}, msg)
+	assert.Equal(t, "status", env.fs.get("/home/gameap/servers/test1/input.txt"))
+
+	logs := env.auditLogs(t)
+	require.Len(t, logs, 1)
+	assert.Equal(t, "GET /api/servers/{server}/console/stream", logs[0].Action)
+	assert.Equal(t, lo.ToPtr(testUser1.ID), logs[0].UserID)
+	assert.Equal(t, lo.ToPtr(domain.EntityTypeServer), logs[0].EntityType)
+	assert.Equal(t, lo.ToPtr("1"), logs[0].EntityID)
+	assert.Equal(t, "127.0.0.1", logs[0].IP)
+	assert.Equal(t, domain.AuditResultSuccess, logs[0].Result)
+	assert.Equal(t, http.StatusOK, logs[0].StatusCode)
+	require.NotNil(t, logs[0].Request)
+	assert.JSONEq(t, `{"command":"status"}`, *logs[0].Request)
+}
+
+func TestHandler_SendCommandWithoutAbility(t *testing.T) {
+	env := setup(t)
+	env.allow(t, domain.AbilityNameGameServerConsoleView)
+	srv := env.startServer(t)
+
+	conn := dial(t, srv)
+
+	var msg message
+	require.NoError(t, conn.ReadJSON(&msg))
+
+	require.NoError(t, conn.WriteJSON(map[string]string{"command": "status"}))
+
+	msg = message{}
+	require.NoError(t, conn.ReadJSON(&msg))
+
+	assert.Equal(t, "error", msg.Type)
+	assert.Contains(t, msg.Error, "permissions")
+	assert.Empty(t, env.fs.get("/home/gameap/servers/test1/input.txt"))
+
+	logs := env.auditLogs(t)
+	require.Len(t, logs, 1)
+	assert.Equal(t, domain.AuditResultFailure, logs[0].Result)
+	assert.Equal(t, http.StatusForbidden, logs[0].StatusCode)
+}
+
+func TestHandler_EmptyCommand(t *testing.T) {
+	env := setup(t)
+	env.allow(t, domain.AbilityNameGameServerConsoleView, domain.AbilityNameGameServerConsoleSend)
+	srv := env.startServer(t)
+
+	conn := dial(t, srv)
+
+	var msg message
+	require.NoError(t, conn.ReadJSON(&msg))
+
+	require.NoError(t, conn.WriteJSON(map[string]string{"command": ""}))
+
+	msg = message{}
+	require.NoError(t, conn.ReadJSON(&msg))
+
+	assert.Equal(t, message{Type: "error", Error: "command is required"}, msg)
+}
+
+func TestHandler_Forbidden(t *testing.T) {
+	env := setup(t)
+
+	ctx := auth.ContextWithSession(context.Background(), &auth.Session{
+		Login: testUser1.Login,
+		Email: testUser1.Email,
+		User:  &testUser1,
+	})
+	req := httptest.NewRequest(http.MethodGet, "/api/servers/1/console/stream", nil)
+	req = mux.SetURLVars(req.WithContext(ctx), map[string]string{"server": "1"})
+	w := httptest.NewRecorder()
+
+	env.handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestHandler_Unauthenticated(t *testing.T) {
+	env := setup(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/servers/1/console/stream", nil)
+	req = mux.SetURLVars(req, map[string]string{"server": "1"})
+	w := httptest.NewRecorder()
+
+	env.handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestHandler_NotWebSocketRequest(t *testing.T) {
+	env := setup(t)
+	env.allow(t, domain.AbilityNameGameServerConsoleView)
+
+	ctx := auth.ContextWithSession(context.Background(), &auth.Session{
+		Login: testUser1.Login,
+		Email: testUser1.Email,
+		User:  &testUser1,
+	})
+	req := httptest.NewRequest(http.MethodGet, "/api/servers/1/console/stream", nil)
+	req = mux.SetURLVars(req.WithContext(ctx), map[string]string{"server": "1"})
+	w := httptest.NewRecorder()
+
+	env.handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
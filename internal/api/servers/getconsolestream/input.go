@@ -0,0 +1,17 @@
+package getconsolestream
+
+import (
+	"github.com/pkg/errors"
+)
+
+type commandInput struct {
+	Command string `json:"command"`
+}
+
+func (in *commandInput) validate() error {
+	if in.Command == "" {
+		return errors.New("command is required")
+	}
+
+	return nil
+}
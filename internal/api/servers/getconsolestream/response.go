@@ -0,0 +1,45 @@
+package getconsolestream
+
+import (
+	"github.com/gameap/gameap/internal/services/serverconsole"
+)
+
+const (
+	messageTypeCommand = "command"
+	messageTypeError   = "error"
+)
+
+type message struct {
+	Type   string `json:"type"`
+	Data   string `json:"data,omitempty"`
+	Status string `json:"status,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+func newEventMessage(event serverconsole.Event) message {
+	if event.Type == serverconsole.EventTypeError {
+		return message{
+			Type:  messageTypeError,
+			Error: event.Data,
+		}
+	}
+
+	return message{
+		Type: string(event.Type),
+		Data: event.Data,
+	}
+}
+
+func newCommandMessage() message {
+	return message{
+		Type:   messageTypeCommand,
+		Status: "success",
+	}
+}
+
+func newErrorMessage(err error) message {
+	return message{
+		Type:  messageTypeError,
+		Error: err.Error(),
+	}
+}
@@ -0,0 +1,68 @@
+package getexpiration
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gameap/gameap/internal/api/base"
+	serversbase "github.com/gameap/gameap/internal/api/servers/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+)
+
+type Handler struct {
+	serverFinder *serversbase.ServerFinder
+	policy       domain.ServerExpirationPolicy
+	responder    base.Responder
+}
+
+func NewHandler(
+	serverRepo repositories.ServerRepository,
+	policy domain.ServerExpirationPolicy,
+	rbac base.RBAC,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		serverFinder: serversbase.NewServerFinder(serverRepo, rbac),
+		policy:       policy,
+		responder:    responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	input := api.NewInputReader(r)
+
+	serverID, err := input.ReadUint("server")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid server id"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	server, err := h.serverFinder.FindUserServer(ctx, session.User, serverID)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	h.responder.Write(ctx, rw, newExpirationResponse(server, h.policy, time.Now()))
+}
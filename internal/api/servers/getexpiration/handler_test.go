@@ -0,0 +1,230 @@
+package getexpiration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUser1 = domain.User{
+	ID:    1,
+	Login: "testuser",
+	Email: "test@example.com",
+}
+
+func createServer(t *testing.T, repo *inmemory.ServerRepository, id uint, expires *time.Time, blocked bool) {
+	t.Helper()
+
+	now := time.Now()
+
+	server := &domain.Server{
+		ID:         id,
+		UUID:       uuid.New(),
+		UUIDShort:  "short",
+		Enabled:    true,
+		Installed:  1,
+		Blocked:    blocked,
+		Name:       "Test Server",
+		GameID:     "cs",
+		DSID:       1,
+		GameModID:  1,
+		Expires:    expires,
+		ServerIP:   "127.0.0.1",
+		ServerPort: 27015,
+		Dir:        "/home/gameap/servers/test",
+		CreatedAt:  &now,
+		UpdatedAt:  &now,
+	}
+
+	require.NoError(t, repo.Save(context.Background(), server))
+	repo.AddUserServer(testUser1.ID, id)
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	policy := domain.ServerExpirationPolicy{
+		Enabled:     true,
+		GracePeriod: 24 * time.Hour,
+		DeleteAfter: 7 * 24 * time.Hour,
+	}
+
+	tests := []struct {
+		name           string
+		serverID       string
+		setupAuth      func() context.Context
+		setupRepo      func(*inmemory.ServerRepository)
+		expectedStatus int
+		wantError      string
+		wantState      domain.ServerExpirationState
+		wantDeadlines  bool
+	}{
+		{
+			name:     "server_without_expiration",
+			serverID: "1",
+			setupAuth: func() context.Context {
+				return auth.ContextWithSession(context.Background(), &auth.Session{User: &testUser1})
+			},
+			setupRepo: func(repo *inmemory.ServerRepository) {
+				createServer(t, repo, 1, nil, false)
+			},
+			expectedStatus: http.StatusOK,
+			wantState:      domain.ServerExpirationStateNone,
+		},
+		{
+			name:     "active_server",
+			serverID: "1",
+			setupAuth: func() context.Context {
+				return auth.ContextWithSession(context.Background(), &auth.Session{User: &testUser1})
+			},
+			setupRepo: func(repo *inmemory.ServerRepository) {
+				createServer(t, repo, 1, lo.ToPtr(time.Now().Add(time.Hour)), false)
+			},
+			expectedStatus: http.StatusOK,
+			wantState:      domain.ServerExpirationStateActive,
+			wantDeadlines:  true,
+		},
+		{
+			name:     "server_in_grace_period",
+			serverID: "1",
+			setupAuth: func() context.Context {
+				return auth.ContextWithSession(context.Background(), &auth.Session{User: &testUser1})
+			},
+			setupRepo: func(repo *inmemory.ServerRepository) {
+				createServer(t, repo, 1, lo.ToPtr(time.Now().Add(-time.Hour)), false)
+			},
+			expectedStatus: http.StatusOK,
+			wantState:      domain.ServerExpirationStateGracePeriod,
+			wantDeadlines:  true,
+		},
+		{
+			name:     "blocked_server",
+			serverID: "1",
+			setupAuth: func() context.Context {
+				return auth.ContextWithSession(context.Background(), &auth.Session{User: &testUser1})
+			},
+			setupRepo: func(repo *inmemory.ServerRepository) {
+				createServer(t, repo, 1, lo.ToPtr(time.Now().Add(-48*time.Hour)), true)
+			},
+			expectedStatus: http.StatusOK,
+			wantState:      domain.ServerExpirationStateBlocked,
+			wantDeadlines:  true,
+		},
+		{
+			name:     "user_not_authenticated",
+			serverID: "1",
+			setupAuth: func() context.Context {
+				return context.Background()
+			},
+			setupRepo:      func(_ *inmemory.ServerRepository) {},
+			expectedStatus: http.StatusUnauthorized,
+			wantError:      "user not authenticated",
+		},
+		{
+			name:     "invalid_server_id",
+			serverID: "invalid",
+			setupAuth: func() context.Context {
+				return auth.ContextWithSession(context.Background(), &auth.Session{User: &testUser1})
+			},
+			setupRepo:      func(_ *inmemory.ServerRepository) {},
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "invalid server id",
+		},
+		{
+			name:     "server_not_found",
+			serverID: "999",
+			setupAuth: func() context.Context {
+				return auth.ContextWithSession(context.Background(), &auth.Session{User: &testUser1})
+			},
+			setupRepo:      func(_ *inmemory.ServerRepository) {},
+			expectedStatus: http.StatusNotFound,
+			wantError:      "server not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverRepo := inmemory.NewServerRepository()
+			rbacRepo := inmemory.NewRBACRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+			handler := NewHandler(serverRepo, policy, rbacService, api.NewResponder())
+
+			tt.setupRepo(serverRepo)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/servers/"+tt.serverID+"/expiration", nil)
+			req = req.WithContext(tt.setupAuth())
+			req = mux.SetURLVars(req, map[string]string{"server": tt.serverID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.wantError != "" {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "error", response["status"])
+				assert.Contains(t, response["error"], tt.wantError)
+
+				return
+			}
+
+			var response expirationResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.True(t, response.Enabled)
+			require.NotNil(t, response.State)
+			assert.Equal(t, string(tt.wantState), *response.State)
+
+			if tt.wantDeadlines {
+				assert.NotNil(t, response.Expires)
+				assert.NotNil(t, response.BlockAt)
+				assert.NotNil(t, response.DeleteAt)
+			} else {
+				assert.Nil(t, response.BlockAt)
+				assert.Nil(t, response.DeleteAt)
+			}
+		})
+	}
+}
+
+func TestHandler_ServeHTTP_ExpirationDisabled(t *testing.T) {
+	serverRepo := inmemory.NewServerRepository()
+	rbacRepo := inmemory.NewRBACRepository()
+	rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+	handler := NewHandler(serverRepo, domain.ServerExpirationPolicy{
+		GracePeriod: 24 * time.Hour,
+		DeleteAfter: 7 * 24 * time.Hour,
+	}, rbacService, api.NewResponder())
+
+	createServer(t, serverRepo, 1, lo.ToPtr(time.Now().Add(-48*time.Hour)), false)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/servers/1/expiration", nil)
+	req = req.WithContext(auth.ContextWithSession(context.Background(), &auth.Session{User: &testUser1}))
+	req = mux.SetURLVars(req, map[string]string{"server": "1"})
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, false, response["enabled"])
+	assert.NotNil(t, response["expires"])
+	assert.Nil(t, response["state"])
+	assert.Nil(t, response["block_at"])
+	assert.Nil(t, response["delete_at"])
+}
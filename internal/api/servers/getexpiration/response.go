@@ -0,0 +1,39 @@
+package getexpiration
+
+import (
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+)
+
+type expirationResponse struct {
+	Enabled bool       `json:"enabled"`
+	Expires *time.Time `json:"expires"`
+	// State, BlockAt and DeleteAt are null when the expiration worker is disabled.
+	State    *string    `json:"state"`
+	Blocked  bool       `json:"blocked"`
+	BlockAt  *time.Time `json:"block_at"`
+	DeleteAt *time.Time `json:"delete_at"`
+}
+
+func newExpirationResponse(
+	s *domain.Server,
+	policy domain.ServerExpirationPolicy,
+	now time.Time,
+) expirationResponse {
+	response := expirationResponse{
+		Enabled: policy.Enabled,
+		Expires: s.Expires,
+		Blocked: s.Blocked,
+	}
+
+	if policy.Enabled {
+		state := string(policy.State(s, now))
+
+		response.State = &state
+		response.BlockAt = policy.BlockAt(s)
+		response.DeleteAt = policy.DeleteAt(s)
+	}
+
+	return response
+}
@@ -0,0 +1,101 @@
+package getmetrics
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gameap/gameap/internal/api/base"
+	serversbase "github.com/gameap/gameap/internal/api/servers/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/metrics"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+)
+
+type metricsQuerier interface {
+	Query(ctx context.Context, q metrics.Query, now time.Time) (*metrics.Series, error)
+}
+
+// Handler returns the resource usage history of a server for charting.
+type Handler struct {
+	serverFinder   *serversbase.ServerFinder
+	metricsQuerier metricsQuerier
+	responder      base.Responder
+}
+
+func NewHandler(
+	serverRepo repositories.ServerRepository,
+	metricsQuerier metricsQuerier,
+	rbac base.RBAC,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		serverFinder:   serversbase.NewServerFinder(serverRepo, rbac),
+		metricsQuerier: metricsQuerier,
+		responder:      responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	serverID, err := api.NewInputReader(r).ReadUint("server")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid server id"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	now := time.Now()
+
+	in, err := readInput(r, now)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(err, http.StatusBadRequest))
+
+		return
+	}
+
+	if err = in.Validate(); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	server, err := h.serverFinder.FindUserServer(ctx, session.User, serverID)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	series, err := h.metricsQuerier.Query(ctx, metrics.Query{
+		SubjectType: domain.MetricSubjectServer,
+		SubjectID:   server.ID,
+		From:        in.From,
+		To:          in.To,
+		Step:        in.Step,
+	}, now)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to query server metrics"))
+
+		return
+	}
+
+	h.responder.Write(ctx, rw, newMetricsResponse(in, series))
+}
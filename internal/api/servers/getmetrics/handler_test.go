@@ -0,0 +1,235 @@
+package getmetrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/metrics"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUser1 = domain.User{
+	ID:    1,
+	Login: "testuser",
+	Email: "test@example.com",
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		serverID       string
+		query          string
+		authenticated  bool
+		expectedStatus int
+		wantError      string
+		wantBody       string
+	}{
+		{
+			name:           "history averaged into steps",
+			serverID:       "1",
+			query:          "from=2025-03-01T10:00:00Z&to=2025-03-01T10:03:00Z&step=1m",
+			authenticated:  true,
+			expectedStatus: http.StatusOK,
+			wantBody: `{
+				"from": "2025-03-01T10:00:00Z",
+				"to": "2025-03-01T10:03:00Z",
+				"step": 60,
+				"resolution": "1m",
+				"points": [
+					{"time": "2025-03-01T10:00:00Z", "cpu": 15, "ram": 1024, "disk": null, "players": null},
+					{"time": "2025-03-01T10:01:00Z", "cpu": 50, "ram": 2048, "disk": null, "players": null}
+				]
+			}`,
+		},
+		{
+			name:           "unix timestamps and step in seconds",
+			serverID:       "1",
+			query:          "from=1740823200&to=1740823380&step=180",
+			authenticated:  true,
+			expectedStatus: http.StatusOK,
+			wantBody: `{
+				"from": "2025-03-01T10:00:00Z",
+				"to": "2025-03-01T10:03:00Z",
+				"step": 180,
+				"resolution": "1m",
+				"points": [
+					{"time": "2025-03-01T10:00:00Z", "cpu": 26.666666666666668, "ram": 1365.3333333333333, "disk": null, "players": null}
+				]
+			}`,
+		},
+		{
+			name:           "empty history",
+			serverID:       "1",
+			query:          "from=2025-02-01T10:00:00Z&to=2025-02-01T11:00:00Z&step=1h",
+			authenticated:  true,
+			expectedStatus: http.StatusOK,
+			wantBody: `{
+				"from": "2025-02-01T10:00:00Z",
+				"to": "2025-02-01T11:00:00Z",
+				"step": 3600,
+				"resolution": "1h",
+				"points": []
+			}`,
+		},
+		{
+			name:           "from after to",
+			serverID:       "1",
+			query:          "from=2025-03-01T11:00:00Z&to=2025-03-01T10:00:00Z",
+			authenticated:  true,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "from must be before to",
+		},
+		{
+			name:           "too many points",
+			serverID:       "1",
+			query:          "from=2025-03-01T00:00:00Z&to=2025-03-02T00:00:00Z&step=10s",
+			authenticated:  true,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "too many points requested",
+		},
+		{
+			name:           "invalid step",
+			serverID:       "1",
+			query:          "step=often",
+			authenticated:  true,
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "invalid step",
+		},
+		{
+			name:           "server of another user",
+			serverID:       "2",
+			authenticated:  true,
+			expectedStatus: http.StatusNotFound,
+			wantError:      "server not found",
+		},
+		{
+			name:           "user not authenticated",
+			serverID:       "1",
+			expectedStatus: http.StatusUnauthorized,
+			wantError:      "user not authenticated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			serverRepo := inmemory.NewServerRepository()
+			metricRepo := inmemory.NewMetricRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), inmemory.NewRBACRepository(), 0)
+			handler := NewHandler(
+				serverRepo,
+				metrics.NewService(metricRepo, metrics.Retention{}),
+				rbacService,
+				api.NewResponder(),
+			)
+
+			for _, server := range []*domain.Server{
+				{ID: 1, UUID: uuid.New(), Enabled: true, DSID: 1, ServerIP: "127.0.0.1", ServerPort: 27015},
+				{ID: 2, UUID: uuid.New(), Enabled: true, DSID: 1, ServerIP: "127.0.0.1", ServerPort: 27016},
+			} {
+				require.NoError(t, serverRepo.Save(ctx, server))
+				serverRepo.AddUserServer(server.ID, server.ID)
+			}
+
+			base := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+			require.NoError(t, metricRepo.SaveBulk(ctx, []*domain.Metric{
+				{
+					SubjectType: domain.MetricSubjectServer, SubjectID: 1,
+					Resolution: domain.MetricResolutionMinute, RecordedAt: base,
+					CPU: lo.ToPtr(15.0), RAM: lo.ToPtr(1024.0), Samples: 2,
+				},
+				{
+					SubjectType: domain.MetricSubjectServer, SubjectID: 1,
+					Resolution: domain.MetricResolutionRaw, RecordedAt: base.Add(70 * time.Second),
+					CPU: lo.ToPtr(50.0), RAM: lo.ToPtr(2048.0), Samples: 1,
+				},
+				{
+					SubjectType: domain.MetricSubjectServer, SubjectID: 2,
+					Resolution: domain.MetricResolutionMinute, RecordedAt: base,
+					CPU: lo.ToPtr(99.0), Samples: 1,
+				},
+			}))
+
+			if tt.authenticated {
+				ctx = auth.ContextWithSession(ctx, &auth.Session{
+					Login: testUser1.Login,
+					Email: testUser1.Email,
+					User:  &testUser1,
+				})
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/servers/"+tt.serverID+"/metrics?"+tt.query, nil)
+			req = req.WithContext(ctx)
+			req = mux.SetURLVars(req, map[string]string{"server": tt.serverID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.wantError != "" {
+				assert.Contains(t, w.Body.String(), tt.wantError)
+
+				return
+			}
+
+			assert.JSONEq(t, tt.wantBody, w.Body.String())
+		})
+	}
+}
+
+func TestReadInput_Defaults(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		query    string
+		wantFrom time.Time
+		wantStep time.Duration
+	}{
+		{
+			name:     "last hour",
+			query:    "",
+			wantFrom: now.Add(-time.Hour),
+			wantStep: time.Minute,
+		},
+		{
+			name:     "last day",
+			query:    "from=2025-02-28T12:00:00Z",
+			wantFrom: now.Add(-24 * time.Hour),
+			wantStep: 5 * time.Minute,
+		},
+		{
+			name:     "last month",
+			query:    "from=2025-02-01T12:00:00Z",
+			wantFrom: time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC),
+			wantStep: 6 * time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/servers/1/metrics?"+tt.query, nil)
+
+			in, err := readInput(req, now)
+			require.NoError(t, err)
+			assert.Equal(t, now, in.To)
+			assert.Equal(t, tt.wantFrom, in.From)
+			assert.Equal(t, tt.wantStep, in.Step)
+			require.NoError(t, in.Validate())
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package getmetrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultRange = time.Hour
+	minStep      = time.Second
+	maxPoints    = 1000
+)
+
+var (
+	ErrInvalidRange  = api.NewValidationError("from must be before to")
+	ErrStepTooSmall  = api.NewValidationError("step must be at least 1s")
+	ErrTooManyPoints = api.NewValidationError(
+		"too many points requested, increase the step or shorten the range",
+	)
+)
+
+// autoSteps are the steps chosen when the step isn't set, the first one giving
+// no more than autoStepPoints points is used.
+var autoSteps = []time.Duration{
+	time.Minute,
+	5 * time.Minute,
+	15 * time.Minute,
+	time.Hour,
+	6 * time.Hour,
+	24 * time.Hour,
+}
+
+const autoStepPoints = 300
+
+type input struct {
+	From time.Time
+	To   time.Time
+	Step time.Duration
+}
+
+// readInput reads the range and the step of the requested history.
+// from and to are RFC 3339 times or unix timestamps, to defaults to now and from to an hour before to.
+// step is a duration like 5m or a number of seconds.
+func readInput(r *http.Request, now time.Time) (*input, error) {
+	reader := api.NewQueryReader(r)
+
+	result := &input{To: now}
+
+	to, err := reader.ReadTime("to")
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid to")
+	}
+
+	if !to.IsZero() {
+		result.To = to
+	}
+
+	result.From = result.To.Add(-defaultRange)
+
+	from, err := reader.ReadTime("from")
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid from")
+	}
+
+	if !from.IsZero() {
+		result.From = from
+	}
+
+	step, err := reader.ReadString("step")
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to read step")
+	}
+
+	if step != "" {
+		result.Step, err = parseStep(step)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid step")
+		}
+	} else {
+		result.Step = autoStep(result.To.Sub(result.From))
+	}
+
+	return result, nil
+}
+
+func (in *input) Validate() error {
+	if !in.From.Before(in.To) {
+		return ErrInvalidRange
+	}
+
+	if in.Step < minStep {
+		return ErrStepTooSmall
+	}
+
+	if in.To.Sub(in.From)/in.Step > maxPoints {
+		return ErrTooManyPoints
+	}
+
+	return nil
+}
+
+func parseStep(value string) (time.Duration, error) {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+
+	return time.ParseDuration(value)
+}
+
+func autoStep(period time.Duration) time.Duration {
+	for _, step := range autoSteps {
+		if period/step <= autoStepPoints {
+			return step
+		}
+	}
+
+	return autoSteps[len(autoSteps)-1]
+}
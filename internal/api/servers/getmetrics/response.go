@@ -0,0 +1,45 @@
+package getmetrics
+
+import (
+	"time"
+
+	"github.com/gameap/gameap/internal/services/metrics"
+)
+
+type metricsResponse struct {
+	From       time.Time       `json:"from"`
+	To         time.Time       `json:"to"`
+	Step       int             `json:"step"`
+	Resolution string          `json:"resolution"`
+	Points     []pointResponse `json:"points"`
+}
+
+type pointResponse struct {
+	Time    time.Time `json:"time"`
+	CPU     *float64  `json:"cpu"`
+	RAM     *float64  `json:"ram"`
+	Disk    *float64  `json:"disk"`
+	Players *float64  `json:"players"`
+}
+
+func newMetricsResponse(in *input, series *metrics.Series) metricsResponse {
+	response := metricsResponse{
+		From:       in.From,
+		To:         in.To,
+		Step:       int(in.Step.Seconds()),
+		Resolution: string(series.Resolution),
+		Points:     make([]pointResponse, 0, len(series.Points)),
+	}
+
+	for _, point := range series.Points {
+		response.Points = append(response.Points, pointResponse{
+			Time:    point.RecordedAt,
+			CPU:     point.CPU,
+			RAM:     point.RAM,
+			Disk:    point.Disk,
+			Players: point.Players,
+		})
+	}
+
+	return response
+}
@@ -2,7 +2,6 @@ package getquery
 
 import (
 	"net/http"
-	"strings"
 
 	"github.com/gameap/gameap/internal/api/base"
 	serversbase "github.com/gameap/gameap/internal/api/servers/base"
@@ -90,7 +89,7 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 
 	game := games[0]
 
-	queryProtocol, ok := getQueryProtocolByEngine(strings.ToLower(game.Engine))
+	queryProtocol, ok := query.ProtocolByEngine(game.Engine)
 	if !ok {
 		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
 			errors.New("unsupported game engine for query"),
@@ -0,0 +1,92 @@
+package getqueryhistory
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gameap/gameap/internal/api/base"
+	serversbase "github.com/gameap/gameap/internal/api/servers/base"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+)
+
+// Handler returns the players history of a server collected by the query poller,
+// the daily peaks of players and the time since which the server is empty.
+type Handler struct {
+	serverFinder *serversbase.ServerFinder
+	recordRepo   repositories.ServerQueryRecordRepository
+	responder    base.Responder
+}
+
+func NewHandler(
+	serverRepo repositories.ServerRepository,
+	recordRepo repositories.ServerQueryRecordRepository,
+	rbac base.RBAC,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		serverFinder: serversbase.NewServerFinder(serverRepo, rbac),
+		recordRepo:   recordRepo,
+		responder:    responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	serverID, err := api.NewInputReader(r).ReadUint("server")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid server id"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	in, err := readInput(r, time.Now())
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(err, http.StatusBadRequest))
+
+		return
+	}
+
+	if err = in.Validate(); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	server, err := h.serverFinder.FindUserServer(ctx, session.User, serverID)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	records, err := h.recordRepo.Find(ctx, &filters.FindServerQueryRecord{
+		ServerIDs:     []uint{server.ID},
+		QueriedFrom:   &in.From,
+		QueriedBefore: &in.To,
+	}, nil, nil)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to find server query records"))
+
+		return
+	}
+
+	h.responder.Write(ctx, rw, newHistoryResponse(in, records))
+}
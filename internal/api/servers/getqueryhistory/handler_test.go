@@ -0,0 +1,186 @@
+package getqueryhistory
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUser1 = domain.User{
+	ID:    1,
+	Login: "testuser",
+	Email: "test@example.com",
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		serverID       string
+		query          string
+		authenticated  bool
+		expectedStatus int
+		wantError      string
+		wantBody       string
+	}{
+		{
+			name:           "history with daily peaks",
+			serverID:       "1",
+			query:          "from=2025-03-01T00:00:00Z&to=2025-03-03T00:00:00Z",
+			authenticated:  true,
+			expectedStatus: http.StatusOK,
+			wantBody: `{
+				"from": "2025-03-01T00:00:00Z",
+				"to": "2025-03-03T00:00:00Z",
+				"records": [
+					{"time": "2025-03-01T20:00:00Z", "online": true, "players_num": 12, "max_players_num": 32, "map": "de_dust2"},
+					{"time": "2025-03-01T22:00:00Z", "online": false, "players_num": 0, "max_players_num": 0, "map": ""},
+					{"time": "2025-03-02T10:00:00Z", "online": true, "players_num": 3, "max_players_num": 32, "map": "de_nuke"},
+					{"time": "2025-03-02T11:00:00Z", "online": true, "players_num": 0, "max_players_num": 32, "map": "de_nuke"}
+				],
+				"daily_peaks": [
+					{"day": "2025-03-01", "players": 12, "max_players": 32, "map": "de_dust2", "peak_at": "2025-03-01T20:00:00Z"},
+					{"day": "2025-03-02", "players": 3, "max_players": 32, "map": "de_nuke", "peak_at": "2025-03-02T10:00:00Z"}
+				],
+				"empty_since": "2025-03-02T11:00:00Z"
+			}`,
+		},
+		{
+			name:           "daily peaks in time zone",
+			serverID:       "1",
+			query:          "from=1740787200&to=1740960000&tz=Asia/Tokyo",
+			authenticated:  true,
+			expectedStatus: http.StatusOK,
+			wantBody: `{
+				"from": "2025-03-01T00:00:00Z",
+				"to": "2025-03-03T00:00:00Z",
+				"records": [
+					{"time": "2025-03-01T20:00:00Z", "online": true, "players_num": 12, "max_players_num": 32, "map": "de_dust2"},
+					{"time": "2025-03-01T22:00:00Z", "online": false, "players_num": 0, "max_players_num": 0, "map": ""},
+					{"time": "2025-03-02T10:00:00Z", "online": true, "players_num": 3, "max_players_num": 32, "map": "de_nuke"},
+					{"time": "2025-03-02T11:00:00Z", "online": true, "players_num": 0, "max_players_num": 32, "map": "de_nuke"}
+				],
+				"daily_peaks": [
+					{"day": "2025-03-02", "players": 12, "max_players": 32, "map": "de_dust2", "peak_at": "2025-03-02T05:00:00+09:00"}
+				],
+				"empty_since": "2025-03-02T11:00:00Z"
+			}`,
+		},
+		{
+			name:           "empty history",
+			serverID:       "1",
+			query:          "from=2025-02-01T00:00:00Z&to=2025-02-02T00:00:00Z",
+			authenticated:  true,
+			expectedStatus: http.StatusOK,
+			wantBody: `{
+				"from": "2025-02-01T00:00:00Z",
+				"to": "2025-02-02T00:00:00Z",
+				"records": [],
+				"daily_peaks": [],
+				"empty_since": null
+			}`,
+		},
+		{
+			name:           "from after to",
+			serverID:       "1",
+			query:          "from=2025-03-02T00:00:00Z&to=2025-03-01T00:00:00Z",
+			authenticated:  true,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "from must be before to",
+		},
+		{
+			name:           "range too long",
+			serverID:       "1",
+			query:          "from=2025-01-01T00:00:00Z&to=2025-03-01T00:00:00Z",
+			authenticated:  true,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "range must not be longer than 31 days",
+		},
+		{
+			name:           "invalid time zone",
+			serverID:       "1",
+			query:          "tz=Mars/Olympus",
+			authenticated:  true,
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "invalid tz",
+		},
+		{
+			name:           "server of another user",
+			serverID:       "2",
+			authenticated:  true,
+			expectedStatus: http.StatusNotFound,
+			wantError:      "server not found",
+		},
+		{
+			name:           "user not authenticated",
+			serverID:       "1",
+			expectedStatus: http.StatusUnauthorized,
+			wantError:      "user not authenticated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			serverRepo := inmemory.NewServerRepository()
+			recordRepo := inmemory.NewServerQueryRecordRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), inmemory.NewRBACRepository(), 0)
+			handler := NewHandler(serverRepo, recordRepo, rbacService, api.NewResponder())
+
+			server := &domain.Server{ID: 1, UUID: uuid.New(), Enabled: true, DSID: 1, ServerIP: "127.0.0.1", ServerPort: 27015}
+			require.NoError(t, serverRepo.Save(ctx, server))
+			serverRepo.AddUserServer(testUser1.ID, server.ID)
+
+			require.NoError(t, serverRepo.Save(ctx, &domain.Server{
+				ID: 2, UUID: uuid.New(), Enabled: true, DSID: 1, ServerIP: "127.0.0.1", ServerPort: 27016,
+			}))
+
+			base := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+			require.NoError(t, recordRepo.SaveBulk(ctx, []*domain.ServerQueryRecord{
+				{ServerID: 1, QueriedAt: base.Add(20 * time.Hour), Online: true, PlayersNum: 12, MaxPlayersNum: 32, Map: "de_dust2"},
+				{ServerID: 1, QueriedAt: base.Add(22 * time.Hour)},
+				{ServerID: 1, QueriedAt: base.Add(34 * time.Hour), Online: true, PlayersNum: 3, MaxPlayersNum: 32, Map: "de_nuke"},
+				{ServerID: 1, QueriedAt: base.Add(35 * time.Hour), Online: true, PlayersNum: 0, MaxPlayersNum: 32, Map: "de_nuke"},
+				{ServerID: 1, QueriedAt: base.Add(72 * time.Hour), Online: true, PlayersNum: 5, MaxPlayersNum: 32, Map: "de_nuke"},
+				{ServerID: 2, QueriedAt: base.Add(20 * time.Hour), Online: true, PlayersNum: 30, MaxPlayersNum: 32},
+			}))
+
+			if tt.authenticated {
+				ctx = auth.ContextWithSession(ctx, &auth.Session{
+					Login: testUser1.Login,
+					Email: testUser1.Email,
+					User:  &testUser1,
+				})
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/servers/"+tt.serverID+"/query_history?"+tt.query, nil)
+			req = req.WithContext(ctx)
+			req = mux.SetURLVars(req, map[string]string{"server": tt.serverID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.wantError != "" {
+				assert.Contains(t, w.Body.String(), tt.wantError)
+
+				return
+			}
+
+			assert.JSONEq(t, tt.wantBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package getqueryhistory
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultRange = 24 * time.Hour
+	maxRange     = 31 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidRange = api.NewValidationError("from must be before to")
+	ErrRangeTooLong = api.NewValidationError("range must not be longer than 31 days")
+)
+
+type input struct {
+	From     time.Time
+	To       time.Time
+	Location *time.Location
+}
+
+// readInput reads the range of the requested history and the time zone of the daily peaks.
+// from and to are RFC 3339 times or unix timestamps, to defaults to now and from to a day before to.
+// tz is an IANA time zone name, UTC by default.
+func readInput(r *http.Request, now time.Time) (*input, error) {
+	reader := api.NewQueryReader(r)
+
+	result := &input{To: now, Location: time.UTC}
+
+	to, err := reader.ReadTime("to")
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid to")
+	}
+
+	if !to.IsZero() {
+		result.To = to
+	}
+
+	result.From = result.To.Add(-defaultRange)
+
+	from, err := reader.ReadTime("from")
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid from")
+	}
+
+	if !from.IsZero() {
+		result.From = from
+	}
+
+	tz, err := reader.ReadString("tz")
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to read tz")
+	}
+
+	if tz != "" {
+		result.Location, err = time.LoadLocation(tz)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid tz")
+		}
+	}
+
+	return result, nil
+}
+
+func (in *input) Validate() error {
+	if !in.From.Before(in.To) {
+		return ErrInvalidRange
+	}
+
+	if in.To.Sub(in.From) > maxRange {
+		return ErrRangeTooLong
+	}
+
+	return nil
+}
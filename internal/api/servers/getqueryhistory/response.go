@@ -0,0 +1,65 @@
+package getqueryhistory
+
+import (
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+)
+
+type historyResponse struct {
+	From       time.Time           `json:"from"`
+	To         time.Time           `json:"to"`
+	Records    []recordResponse    `json:"records"`
+	DailyPeaks []dailyPeakResponse `json:"daily_peaks"`
+	EmptySince *time.Time          `json:"empty_since"`
+}
+
+type recordResponse struct {
+	Time          time.Time `json:"time"`
+	Online        bool      `json:"online"`
+	PlayersNum    int       `json:"players_num"`
+	MaxPlayersNum int       `json:"max_players_num"`
+	Map           string    `json:"map"`
+}
+
+type dailyPeakResponse struct {
+	Day        string    `json:"day"`
+	Players    int       `json:"players"`
+	MaxPlayers int       `json:"max_players"`
+	Map        string    `json:"map"`
+	PeakAt     time.Time `json:"peak_at"`
+}
+
+func newHistoryResponse(in *input, records []domain.ServerQueryRecord) historyResponse {
+	response := historyResponse{
+		From:       in.From,
+		To:         in.To,
+		Records:    make([]recordResponse, 0, len(records)),
+		EmptySince: domain.EmptySince(records),
+	}
+
+	for _, record := range records {
+		response.Records = append(response.Records, recordResponse{
+			Time:          record.QueriedAt,
+			Online:        record.Online,
+			PlayersNum:    record.PlayersNum,
+			MaxPlayersNum: record.MaxPlayersNum,
+			Map:           record.Map,
+		})
+	}
+
+	peaks := domain.DailyPlayersPeaks(records, in.Location)
+
+	response.DailyPeaks = make([]dailyPeakResponse, 0, len(peaks))
+	for _, peak := range peaks {
+		response.DailyPeaks = append(response.DailyPeaks, dailyPeakResponse{
+			Day:        peak.Day.Format(time.DateOnly),
+			Players:    peak.Players,
+			MaxPlayers: peak.MaxPlayers,
+			Map:        peak.Map,
+			PeakAt:     peak.PeakAt,
+		})
+	}
+
+	return response
+}
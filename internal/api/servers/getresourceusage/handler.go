@@ -0,0 +1,82 @@
+package getresourceusage
+
+import (
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	serversbase "github.com/gameap/gameap/internal/api/servers/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+)
+
+type Handler struct {
+	serverFinder      *serversbase.ServerFinder
+	resourceUsageRepo repositories.ServerResourceUsageRepository
+	responder         base.Responder
+}
+
+func NewHandler(
+	serverRepo repositories.ServerRepository,
+	resourceUsageRepo repositories.ServerResourceUsageRepository,
+	rbac base.RBAC,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		serverFinder:      serversbase.NewServerFinder(serverRepo, rbac),
+		resourceUsageRepo: resourceUsageRepo,
+		responder:         responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	input := api.NewInputReader(r)
+
+	serverID, err := input.ReadUint("server")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid server id"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	server, err := h.serverFinder.FindUserServer(ctx, session.User, serverID)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	usages, err := h.resourceUsageRepo.Find(ctx, &filters.FindServerResourceUsage{
+		ServerIDs: []uint{server.ID},
+	}, nil, nil)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to find server resource usage"))
+
+		return
+	}
+
+	var usage *domain.ServerResourceUsage
+	if len(usages) > 0 {
+		usage = &usages[0]
+	}
+
+	h.responder.Write(ctx, rw, newResourceUsageResponse(server, usage))
+}
@@ -0,0 +1,152 @@
+package getresourceusage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUser1 = domain.User{
+	ID:    1,
+	Login: "testuser",
+	Email: "test@example.com",
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		serverID       string
+		authenticated  bool
+		withUsage      bool
+		expectedStatus int
+		wantError      string
+		wantBody       string
+	}{
+		{
+			name:           "usage with warnings",
+			serverID:       "1",
+			authenticated:  true,
+			withUsage:      true,
+			expectedStatus: http.StatusOK,
+			wantBody: `{
+				"limits": {"cpu": 100, "ram": 2048, "net": null},
+				"usage": {"cpu": 150.5, "ram": 1024, "net": 40, "reported_at": "2025-03-01T10:00:00Z"},
+				"warnings": [{"resource": "cpu", "limit": 100, "usage": 150.5}]
+			}`,
+		},
+		{
+			name:           "usage not reported",
+			serverID:       "1",
+			authenticated:  true,
+			expectedStatus: http.StatusOK,
+			wantBody: `{
+				"limits": {"cpu": 100, "ram": 2048, "net": null},
+				"usage": null,
+				"warnings": []
+			}`,
+		},
+		{
+			name:           "server of another user",
+			serverID:       "2",
+			authenticated:  true,
+			expectedStatus: http.StatusNotFound,
+			wantError:      "server not found",
+		},
+		{
+			name:           "invalid server id",
+			serverID:       "invalid",
+			authenticated:  true,
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "invalid server id",
+		},
+		{
+			name:           "user not authenticated",
+			serverID:       "1",
+			expectedStatus: http.StatusUnauthorized,
+			wantError:      "user not authenticated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			serverRepo := inmemory.NewServerRepository()
+			resourceUsageRepo := inmemory.NewServerResourceUsageRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), inmemory.NewRBACRepository(), 0)
+			handler := NewHandler(serverRepo, resourceUsageRepo, rbacService, api.NewResponder())
+
+			require.NoError(t, serverRepo.Save(ctx, &domain.Server{
+				ID:         1,
+				UUID:       uuid.New(),
+				Enabled:    true,
+				Name:       "Test Server 1",
+				DSID:       1,
+				ServerIP:   "127.0.0.1",
+				ServerPort: 27015,
+				CPULimit:   lo.ToPtr(100),
+				RAMLimit:   lo.ToPtr(2048),
+			}))
+			serverRepo.AddUserServer(1, 1)
+
+			require.NoError(t, serverRepo.Save(ctx, &domain.Server{
+				ID:         2,
+				UUID:       uuid.New(),
+				Enabled:    true,
+				Name:       "Test Server 2",
+				DSID:       1,
+				ServerIP:   "127.0.0.1",
+				ServerPort: 27016,
+			}))
+			serverRepo.AddUserServer(2, 2)
+
+			if tt.withUsage {
+				require.NoError(t, resourceUsageRepo.Save(ctx, &domain.ServerResourceUsage{
+					ServerID:   1,
+					CPU:        lo.ToPtr(150.5),
+					RAM:        lo.ToPtr(1024),
+					Net:        lo.ToPtr(40),
+					ReportedAt: time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC),
+				}))
+			}
+
+			if tt.authenticated {
+				ctx = auth.ContextWithSession(ctx, &auth.Session{
+					Login: testUser1.Login,
+					Email: testUser1.Email,
+					User:  &testUser1,
+				})
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/servers/"+tt.serverID+"/resource_usage", nil)
+			req = req.WithContext(ctx)
+			req = mux.SetURLVars(req, map[string]string{"server": tt.serverID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.wantError != "" {
+				assert.Contains(t, w.Body.String(), tt.wantError)
+
+				return
+			}
+
+			assert.JSONEq(t, tt.wantBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package getresourceusage
+
+import (
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+)
+
+type resourceUsageResponse struct {
+	Limits   limitsResponse    `json:"limits"`
+	Usage    *usageResponse    `json:"usage"`
+	Warnings []warningResponse `json:"warnings"`
+}
+
+type limitsResponse struct {
+	CPU *int `json:"cpu"`
+	RAM *int `json:"ram"`
+	Net *int `json:"net"`
+}
+
+type usageResponse struct {
+	CPU        *float64  `json:"cpu"`
+	RAM        *int      `json:"ram"`
+	Net        *int      `json:"net"`
+	ReportedAt time.Time `json:"reported_at"`
+}
+
+type warningResponse struct {
+	Resource string  `json:"resource"`
+	Limit    int     `json:"limit"`
+	Usage    float64 `json:"usage"`
+}
+
+func newResourceUsageResponse(server *domain.Server, usage *domain.ServerResourceUsage) resourceUsageResponse {
+	response := resourceUsageResponse{
+		Limits: limitsResponse{
+			CPU: server.CPULimit,
+			RAM: server.RAMLimit,
+			Net: server.NetLimit,
+		},
+		Warnings: []warningResponse{},
+	}
+
+	if usage == nil {
+		return response
+	}
+
+	response.Usage = &usageResponse{
+		CPU:        usage.CPU,
+		RAM:        usage.RAM,
+		Net:        usage.Net,
+		ReportedAt: usage.ReportedAt,
+	}
+
+	for _, warning := range usage.LimitWarnings(server) {
+		response.Warnings = append(response.Warnings, warningResponse{
+			Resource: string(warning.Resource),
+			Limit:    warning.Limit,
+			Usage:    warning.Usage,
+		})
+	}
+
+	return response
+}
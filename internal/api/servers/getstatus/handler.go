@@ -5,25 +5,30 @@ import (
 
 	"github.com/gameap/gameap/internal/api/base"
 	serversbase "github.com/gameap/gameap/internal/api/servers/base"
+	"github.com/gameap/gameap/internal/filters"
 	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/servercontrol"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
 	"github.com/pkg/errors"
 )
 
 type Handler struct {
-	serverFinder *serversbase.ServerFinder
-	responder    base.Responder
+	serverFinder      *serversbase.ServerFinder
+	serverSettingRepo repositories.ServerSettingRepository
+	responder         base.Responder
 }
 
 func NewHandler(
 	serverRepo repositories.ServerRepository,
+	serverSettingRepo repositories.ServerSettingRepository,
 	rbac base.RBAC,
 	responder base.Responder,
 ) *Handler {
 	return &Handler{
-		serverFinder: serversbase.NewServerFinder(serverRepo, rbac),
-		responder:    responder,
+		serverFinder:      serversbase.NewServerFinder(serverRepo, rbac),
+		serverSettingRepo: serverSettingRepo,
+		responder:         responder,
 	}
 }
 
@@ -59,5 +64,17 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.responder.Write(ctx, rw, newStatusResponse(server))
+	settings, err := h.serverSettingRepo.Find(ctx, &filters.FindServerSetting{
+		ServerIDs: []uint{server.ID},
+		Names:     []string{servercontrol.PausedSettingKey},
+	}, nil, nil)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to find server settings"))
+
+		return
+	}
+
+	paused := len(settings) > 0 && servercontrol.IsPaused(settings[0])
+
+	h.responder.Write(ctx, rw, newStatusResponse(server, paused))
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/gameap/gameap/internal/rbac"
 	"github.com/gameap/gameap/internal/repositories/inmemory"
 	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/servercontrol"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
 	"github.com/google/uuid"
@@ -315,7 +316,7 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			rbacRepo := inmemory.NewRBACRepository()
 			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
 			responder := api.NewResponder()
-			handler := NewHandler(serverRepo, rbacService, responder)
+			handler := NewHandler(serverRepo, inmemory.NewServerSettingRepository(), rbacService, responder)
 
 			if tt.setupRepo != nil {
 				tt.setupRepo(serverRepo, rbacRepo)
@@ -355,13 +356,68 @@ func TestHandler_NewHandler(t *testing.T) {
 	rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
 	responder := api.NewResponder()
 
-	handler := NewHandler(serverRepo, rbacService, responder)
+	handler := NewHandler(serverRepo, inmemory.NewServerSettingRepository(), rbacService, responder)
 
 	require.NotNil(t, handler)
 	assert.NotNil(t, handler.serverFinder)
+	assert.NotNil(t, handler.serverSettingRepo)
 	assert.Equal(t, responder, handler.responder)
 }
 
+func TestHandler_ServeHTTP_PausedServer(t *testing.T) {
+	serverRepo := inmemory.NewServerRepository()
+	serverSettingRepo := inmemory.NewServerSettingRepository()
+	rbacRepo := inmemory.NewRBACRepository()
+	rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+	handler := NewHandler(serverRepo, serverSettingRepo, rbacService, api.NewResponder())
+
+	now := time.Now()
+	lastCheck := now.Add(-30 * time.Second)
+
+	server := &domain.Server{
+		ID:               1,
+		UUID:             uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+		Enabled:          true,
+		Installed:        1,
+		Name:             "Test Server 1",
+		GameID:           "cs",
+		DSID:             1,
+		ServerIP:         "127.0.0.1",
+		ServerPort:       27015,
+		ProcessActive:    true,
+		LastProcessCheck: &lastCheck,
+		CreatedAt:        &now,
+		UpdatedAt:        &now,
+	}
+	require.NoError(t, serverRepo.Save(context.Background(), server))
+	serverRepo.AddUserServer(testUser1.ID, server.ID)
+
+	require.NoError(t, serverSettingRepo.Save(context.Background(), &domain.ServerSetting{
+		Name:     servercontrol.PausedSettingKey,
+		ServerID: server.ID,
+		Value:    domain.NewServerSettingValue(true),
+	}))
+
+	ctx := auth.ContextWithSession(context.Background(), &auth.Session{
+		Login: testUser1.Login,
+		Email: testUser1.Email,
+		User:  &testUser1,
+	})
+	req := httptest.NewRequest(http.MethodGet, "/api/servers/1/status", nil)
+	req = req.WithContext(ctx)
+	req = mux.SetURLVars(req, map[string]string{"server": "1"})
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var status statusResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+	assert.True(t, status.ProcessActive)
+	assert.True(t, status.Paused)
+}
+
 func TestNewStatusResponse(t *testing.T) {
 	tests := []struct {
 		name                  string
@@ -420,8 +476,19 @@ func TestNewStatusResponse(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			response := newStatusResponse(tt.server)
+			response := newStatusResponse(tt.server, false)
 			assert.Equal(t, tt.expectedProcessActive, response.ProcessActive)
+			assert.False(t, response.Paused)
 		})
 	}
 }
+
+func TestNewStatusResponse_Paused(t *testing.T) {
+	lastCheck := time.Now().Add(-30 * time.Second)
+
+	online := &domain.Server{ID: 1, ProcessActive: true, LastProcessCheck: &lastCheck}
+	offline := &domain.Server{ID: 2, ProcessActive: false, LastProcessCheck: &lastCheck}
+
+	assert.True(t, newStatusResponse(online, true).Paused)
+	assert.False(t, newStatusResponse(offline, true).Paused, "stopped server is not reported as paused")
+}
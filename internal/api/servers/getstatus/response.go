@@ -6,10 +6,14 @@ import (
 
 type statusResponse struct {
 	ProcessActive bool `json:"processActive"`
+	Paused        bool `json:"paused"`
 }
 
-func newStatusResponse(s *domain.Server) statusResponse {
+func newStatusResponse(s *domain.Server, paused bool) statusResponse {
+	online := s.IsOnline()
+
 	return statusResponse{
-		ProcessActive: s.IsOnline(),
+		ProcessActive: online,
+		Paused:        online && paused,
 	}
 }
@@ -0,0 +1,91 @@
+package postclone
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/serverclone"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+type serverCloner interface {
+	Clone(ctx context.Context, source *domain.Server, opts serverclone.Options) (*serverclone.Result, error)
+}
+
+type Handler struct {
+	serverRepo   repositories.ServerRepository
+	serverCloner serverCloner
+	responder    base.Responder
+}
+
+func NewHandler(
+	serverRepo repositories.ServerRepository,
+	serverCloner serverCloner,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		serverRepo:   serverRepo,
+		serverCloner: serverCloner,
+		responder:    responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	serverID, err := api.NewInputReader(r).ReadUint("server")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid server id"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	servers, err := h.serverRepo.Find(ctx, filters.FindServerByIDs(serverID), nil, nil)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to find server"))
+
+		return
+	}
+
+	if len(servers) == 0 {
+		h.responder.WriteError(ctx, rw, api.NewNotFoundError("server not found"))
+
+		return
+	}
+
+	input := &cloneServerInput{}
+	err = json.NewDecoder(r.Body).Decode(input)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid request body"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if err = input.Validate(); err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "validation failed"))
+
+		return
+	}
+
+	result, err := h.serverCloner.Clone(ctx, &servers[0], input.ToOptions())
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to clone server"))
+
+		return
+	}
+
+	rw.WriteHeader(http.StatusCreated)
+	h.responder.Write(ctx, rw, newCloneServerResponse(result.Server.ID, result.TaskID))
+}
@@ -0,0 +1,198 @@
+package postclone
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/events"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/serverclone"
+	"github.com/gameap/gameap/internal/services/servermove"
+	"github.com/gameap/gameap/internal/services/serverports"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name         string
+		serverID     string
+		requestBody  string
+		wantStatus   int
+		wantError    string
+		wantTaskType domain.DaemonTaskType
+	}{
+		{
+			name:        "server cloned without files",
+			serverID:    "1",
+			requestBody: `{"name": "Clone"}`,
+			wantStatus:  http.StatusCreated,
+		},
+		{
+			name:         "server cloned with files",
+			serverID:     "1",
+			requestBody:  `{"name": "Clone", "ds_id": "2", "copy_files": true}`,
+			wantStatus:   http.StatusCreated,
+			wantTaskType: domain.DaemonTaskTypeServerCopy,
+		},
+		{
+			name:         "server cloned and installed",
+			serverID:     "1",
+			requestBody:  `{"name": "Clone", "install": 1}`,
+			wantStatus:   http.StatusCreated,
+			wantTaskType: domain.DaemonTaskTypeServerInstall,
+		},
+		{
+			name:        "invalid server id",
+			serverID:    "invalid",
+			requestBody: `{}`,
+			wantStatus:  http.StatusBadRequest,
+			wantError:   "invalid server id",
+		},
+		{
+			name:        "server not found",
+			serverID:    "999",
+			requestBody: `{"name": "Clone"}`,
+			wantStatus:  http.StatusNotFound,
+			wantError:   "server not found",
+		},
+		{
+			name:        "invalid request body",
+			serverID:    "1",
+			requestBody: `{invalid`,
+			wantStatus:  http.StatusBadRequest,
+			wantError:   "invalid request body",
+		},
+		{
+			name:        "name is required",
+			serverID:    "1",
+			requestBody: `{"ds_id": 2}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantError:   "name is required",
+		},
+		{
+			name:        "dir outside of work path",
+			serverID:    "1",
+			requestBody: `{"name": "Clone", "dir": "../../etc"}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantError:   ErrInvalidDir.Error(),
+		},
+		{
+			name:        "dir with shell metacharacters",
+			serverID:    "1",
+			requestBody: `{"name": "Clone", "dir": "servers/$(reboot)"}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantError:   ErrInvalidDir.Error(),
+		},
+		{
+			name:        "node not found",
+			serverID:    "1",
+			requestBody: `{"name": "Clone", "ds_id": 999}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantError:   "node not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			serverRepo := inmemory.NewServerRepository()
+			nodeRepo := inmemory.NewNodeRepository()
+			daemonTaskRepo := inmemory.NewDaemonTaskRepository()
+			tm := services.NewNilTransactionManager()
+
+			require.NoError(t, nodeRepo.Save(ctx, &domain.Node{ID: 1, Enabled: true, IPs: domain.IPList{"10.0.0.1"}}))
+			require.NoError(t, nodeRepo.Save(ctx, &domain.Node{ID: 2, Enabled: true, IPs: domain.IPList{"10.0.0.2"}}))
+			require.NoError(t, serverRepo.Save(ctx, &domain.Server{
+				ID:         1,
+				UUID:       uuid.New(),
+				Name:       "Public CS",
+				GameID:     "cstrike",
+				DSID:       1,
+				ServerIP:   "10.0.0.1",
+				ServerPort: 27015,
+				QueryPort:  lo.ToPtr(27016),
+				Dir:        "servers/cs",
+			}))
+
+			cloner := serverclone.NewService(
+				serverRepo,
+				inmemory.NewServerSettingRepository(),
+				inmemory.NewServerTemplateRepository(),
+				nodeRepo,
+				daemonTaskRepo,
+				servermove.NewService(daemonTaskRepo, serverRepo, nodeRepo, tm),
+				serverports.NewService(
+					serverRepo,
+					cache.NewInMemory(),
+					tm,
+					domain.PortRange{Start: 27015, End: 27999},
+					time.Second,
+				),
+				events.NewBus(),
+			)
+			handler := NewHandler(serverRepo, cloner, api.NewResponder())
+
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"/api/servers/"+tt.serverID+"/clone",
+				strings.NewReader(tt.requestBody),
+			)
+			req = mux.SetURLVars(req, map[string]string{"server": tt.serverID})
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code, rr.Body.String())
+
+			if tt.wantError != "" {
+				var errorResponse map[string]any
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorResponse))
+				errorMsg, ok := errorResponse["error"].(string)
+				if !ok {
+					errorMsg, _ = errorResponse["message"].(string)
+				}
+				assert.Contains(t, errorMsg, tt.wantError)
+
+				return
+			}
+
+			var response cloneServerResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			assert.Equal(t, "success", response.Message)
+
+			servers, err := serverRepo.Find(ctx, filters.FindServerByIDs(response.Result.ServerID), nil, nil)
+			require.NoError(t, err)
+			require.Len(t, servers, 1)
+			assert.Equal(t, "Clone", servers[0].Name)
+			assert.Equal(t, "cstrike", servers[0].GameID)
+
+			if tt.wantTaskType == "" {
+				assert.Zero(t, response.Result.TaskID)
+
+				return
+			}
+
+			tasks, err := daemonTaskRepo.Find(ctx, &filters.FindDaemonTask{
+				IDs: []uint{response.Result.TaskID},
+			}, nil, nil)
+			require.NoError(t, err)
+			require.Len(t, tasks, 1)
+			assert.Equal(t, tt.wantTaskType, tasks[0].Task)
+		})
+	}
+}
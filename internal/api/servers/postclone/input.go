@@ -0,0 +1,71 @@
+package postclone
+
+import (
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/services/serverclone"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/flexible"
+	"github.com/gameap/gameap/pkg/validation"
+)
+
+const maxNameLength = 128
+
+var (
+	ErrNameIsRequired  = api.NewValidationError("name is required")
+	ErrNameTooLong     = api.NewValidationError("name must not exceed 128 characters")
+	ErrInvalidDSID     = api.NewValidationError("ds_id must be positive")
+	ErrInvalidServerIP = api.NewValidationError("server_ip is not a valid IP address or hostname")
+	ErrInvalidDir      = api.NewValidationError("dir must be a relative path without \"..\" segments and special characters")
+)
+
+type cloneServerInput struct {
+	Name      string         `json:"name"`
+	DSID      *flexible.Int  `json:"ds_id,omitempty"`
+	ServerIP  string         `json:"server_ip,omitempty"`
+	Dir       *string        `json:"dir,omitempty"`
+	CopyFiles *flexible.Bool `json:"copy_files,omitempty"`
+	Install   *flexible.Bool `json:"install,omitempty"`
+}
+
+func (in *cloneServerInput) Validate() error {
+	if in.Name == "" {
+		return ErrNameIsRequired
+	}
+
+	if len(in.Name) > maxNameLength {
+		return ErrNameTooLong
+	}
+
+	if in.DSID != nil && in.DSID.Int() <= 0 {
+		return ErrInvalidDSID
+	}
+
+	if in.ServerIP != "" && !validation.IsValidIPOrHostname(in.ServerIP) {
+		return ErrInvalidServerIP
+	}
+
+	if in.Dir != nil && *in.Dir != "" && !domain.IsValidServerDir(*in.Dir) {
+		return ErrInvalidDir
+	}
+
+	return nil
+}
+
+func (in *cloneServerInput) ToOptions() serverclone.Options {
+	opts := serverclone.Options{
+		Name:      in.Name,
+		ServerIP:  in.ServerIP,
+		CopyFiles: in.CopyFiles != nil && in.CopyFiles.Bool(),
+		Install:   in.Install != nil && in.Install.Bool(),
+	}
+
+	if in.DSID != nil {
+		opts.NodeID = uint(in.DSID.Int()) //nolint:gosec // We check it in Validate
+	}
+
+	if in.Dir != nil {
+		opts.Dir = *in.Dir
+	}
+
+	return opts
+}
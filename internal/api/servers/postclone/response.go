@@ -0,0 +1,21 @@
+package postclone
+
+type cloneServerResult struct {
+	TaskID   uint `json:"taskId"`
+	ServerID uint `json:"serverId"`
+}
+
+type cloneServerResponse struct {
+	Message string            `json:"message"`
+	Result  cloneServerResult `json:"result"`
+}
+
+func newCloneServerResponse(serverID, taskID uint) *cloneServerResponse {
+	return &cloneServerResponse{
+		Message: "success",
+		Result: cloneServerResult{
+			TaskID:   taskID,
+			ServerID: serverID,
+		},
+	}
+}
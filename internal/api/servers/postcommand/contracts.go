@@ -10,6 +10,8 @@ type serverManager interface {
 	Start(ctx context.Context, server *domain.Server) (taskID uint, err error)
 	Stop(ctx context.Context, server *domain.Server) (taskID uint, err error)
 	Restart(ctx context.Context, server *domain.Server) (taskID uint, err error)
+	Pause(ctx context.Context, server *domain.Server) (taskID uint, err error)
+	Unpause(ctx context.Context, server *domain.Server) (taskID uint, err error)
 	Update(ctx context.Context, server *domain.Server) (taskID uint, err error)
 	Install(ctx context.Context, server *domain.Server) (taskID uint, err error)
 	Reinstall(ctx context.Context, server *domain.Server) (taskID uint, err error)
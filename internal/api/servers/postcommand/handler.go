@@ -10,6 +10,7 @@ import (
 	serversbase "github.com/gameap/gameap/internal/api/servers/base"
 	"github.com/gameap/gameap/internal/domain"
 	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/servercontrol"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
 	"github.com/pkg/errors"
@@ -39,6 +40,8 @@ func NewHandler(
 			"start":     serverManager.Start,
 			"stop":      serverManager.Stop,
 			"restart":   serverManager.Restart,
+			"pause":     serverManager.Pause,
+			"unpause":   serverManager.Unpause,
 			"update":    serverManager.Update,
 			"install":   serverManager.Install,
 			"reinstall": serverManager.Reinstall,
@@ -56,6 +59,14 @@ func NewHandler(
 				domain.AbilityNameGameServerCommon,
 				domain.AbilityNameGameServerRestart,
 			},
+			"pause": {
+				domain.AbilityNameGameServerCommon,
+				domain.AbilityNameGameServerPause,
+			},
+			"unpause": {
+				domain.AbilityNameGameServerCommon,
+				domain.AbilityNameGameServerPause,
+			},
 			"update": {
 				domain.AbilityNameGameServerCommon,
 				domain.AbilityNameGameServerUpdate,
@@ -134,7 +145,21 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if server.Blocked && command != "stop" {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("server is blocked"),
+			http.StatusForbidden,
+		))
+
+		return
+	}
+
 	daemonTaskID, err := fn(ctx, server)
+	if errors.Is(err, servercontrol.ErrEmptyNodePauseScript) || errors.Is(err, servercontrol.ErrEmptyNodeUnpauseScript) {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(err, http.StatusUnprocessableEntity))
+
+		return
+	}
 	if err != nil {
 		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to execute command"))
 
@@ -18,6 +18,7 @@ import (
 	"github.com/gameap/gameap/pkg/auth"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -703,6 +704,90 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			wantStatus: http.StatusInternalServerError,
 			wantError:  "Internal Server Error",
 		},
+		{
+			name:     "blocked_server_cannot_be_started",
+			serverID: "1",
+			command:  "start",
+			setupAuth: func() context.Context {
+				session := &auth.Session{
+					Login: "testuser",
+					Email: "test@example.com",
+					User:  &testUser1,
+				}
+
+				return auth.ContextWithSession(context.Background(), session)
+			},
+			setupRepo: func(serverRepo *inmemory.ServerRepository, rbacRepo *inmemory.RBACRepository) {
+				now := time.Now()
+				startCmd := testStartCommand
+				server := &domain.Server{
+					ID:           1,
+					UUID:         uuid.New(),
+					UUIDShort:    "short1",
+					Enabled:      true,
+					Installed:    1,
+					Blocked:      true,
+					Name:         "Test Server",
+					GameID:       "cstrike",
+					DSID:         1,
+					GameModID:    1,
+					ServerIP:     "192.168.1.1",
+					ServerPort:   27015,
+					StartCommand: &startCmd,
+					CreatedAt:    &now,
+					UpdatedAt:    &now,
+				}
+				require.NoError(t, serverRepo.Save(context.Background(), server))
+				serverRepo.AddUserServer(testUser1.ID, server.ID)
+
+				allowUserAbilityForServer(t, rbacRepo, testUser1.ID, server.ID, domain.AbilityNameGameServerCommon)
+				allowUserAbilityForServer(t, rbacRepo, testUser1.ID, server.ID, domain.AbilityNameGameServerStart)
+			},
+			wantStatus: http.StatusForbidden,
+			wantError:  "server is blocked",
+		},
+		{
+			name:     "blocked_server_can_be_stopped",
+			serverID: "1",
+			command:  "stop",
+			setupAuth: func() context.Context {
+				session := &auth.Session{
+					Login: "testuser",
+					Email: "test@example.com",
+					User:  &testUser1,
+				}
+
+				return auth.ContextWithSession(context.Background(), session)
+			},
+			setupRepo: func(serverRepo *inmemory.ServerRepository, rbacRepo *inmemory.RBACRepository) {
+				now := time.Now()
+				startCmd := testStartCommand
+				server := &domain.Server{
+					ID:           1,
+					UUID:         uuid.New(),
+					UUIDShort:    "short1",
+					Enabled:      true,
+					Installed:    1,
+					Blocked:      true,
+					Name:         "Test Server",
+					GameID:       "cstrike",
+					DSID:         1,
+					GameModID:    1,
+					ServerIP:     "192.168.1.1",
+					ServerPort:   27015,
+					StartCommand: &startCmd,
+					CreatedAt:    &now,
+					UpdatedAt:    &now,
+				}
+				require.NoError(t, serverRepo.Save(context.Background(), server))
+				serverRepo.AddUserServer(testUser1.ID, server.ID)
+
+				allowUserAbilityForServer(t, rbacRepo, testUser1.ID, server.ID, domain.AbilityNameGameServerCommon)
+				allowUserAbilityForServer(t, rbacRepo, testUser1.ID, server.ID, domain.AbilityNameGameServerStop)
+			},
+			wantStatus: http.StatusOK,
+			wantTaskID: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -716,6 +801,7 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			serverControlService := servercontrol.NewService(
 				daemonTaskRepo,
 				serverSettingRepo,
+				inmemory.NewNodeRepository(),
 				services.NewNilTransactionManager(),
 			)
 			responder := api.NewResponder()
@@ -771,6 +857,7 @@ func TestHandler_NewHandler(t *testing.T) {
 	serverControlService := servercontrol.NewService(
 		daemonTaskRepo,
 		serverSettingRepo,
+		inmemory.NewNodeRepository(),
 		services.NewNilTransactionManager(),
 	)
 	responder := api.NewResponder()
@@ -782,8 +869,8 @@ func TestHandler_NewHandler(t *testing.T) {
 	assert.Equal(t, responder, handler.responder)
 	assert.NotNil(t, handler.commandMap)
 	assert.NotNil(t, handler.abilitiesMap)
-	assert.Len(t, handler.commandMap, 6)
-	assert.Len(t, handler.abilitiesMap, 6)
+	assert.Len(t, handler.commandMap, 8)
+	assert.Len(t, handler.abilitiesMap, 8)
 }
 
 func TestHandler_CommandMapContainsAllCommands(t *testing.T) {
@@ -796,13 +883,14 @@ func TestHandler_CommandMapContainsAllCommands(t *testing.T) {
 	serverControlService := servercontrol.NewService(
 		daemonTaskRepo,
 		serverSettingRepo,
+		inmemory.NewNodeRepository(),
 		services.NewNilTransactionManager(),
 	)
 	responder := api.NewResponder()
 
 	handler := NewHandler(serverRepo, serverControlService, rbacService, responder)
 
-	expectedCommands := []string{"start", "stop", "restart", "update", "install", "reinstall"}
+	expectedCommands := []string{"start", "stop", "restart", "pause", "unpause", "update", "install", "reinstall"}
 
 	for _, cmd := range expectedCommands {
 		assert.Contains(t, handler.commandMap, cmd, "commandMap should contain "+cmd)
@@ -820,6 +908,7 @@ func TestHandler_AbilitiesMapContainsCorrectAbilities(t *testing.T) {
 	serverControlService := servercontrol.NewService(
 		daemonTaskRepo,
 		serverSettingRepo,
+		inmemory.NewNodeRepository(),
 		services.NewNilTransactionManager(),
 	)
 	responder := api.NewResponder()
@@ -851,6 +940,20 @@ func TestHandler_AbilitiesMapContainsCorrectAbilities(t *testing.T) {
 				domain.AbilityNameGameServerRestart,
 			},
 		},
+		{
+			command: "pause",
+			expectedAbilities: []domain.AbilityName{
+				domain.AbilityNameGameServerCommon,
+				domain.AbilityNameGameServerPause,
+			},
+		},
+		{
+			command: "unpause",
+			expectedAbilities: []domain.AbilityName{
+				domain.AbilityNameGameServerCommon,
+				domain.AbilityNameGameServerPause,
+			},
+		},
 		{
 			command: "update",
 			expectedAbilities: []domain.AbilityName{
@@ -999,6 +1102,7 @@ func TestHandler_FindUserServer(t *testing.T) {
 			serverControlService := servercontrol.NewService(
 				daemonTaskRepo,
 				serverSettingRepo,
+				inmemory.NewNodeRepository(),
 				services.NewNilTransactionManager(),
 			)
 			responder := api.NewResponder()
@@ -1052,6 +1156,18 @@ func TestHandler_DaemonTaskCreation(t *testing.T) {
 			expectedTaskType: domain.DaemonTaskTypeServerRestart,
 			abilities:        []domain.AbilityName{domain.AbilityNameGameServerCommon, domain.AbilityNameGameServerRestart},
 		},
+		{
+			name:             "pause_command_creates_pause_task",
+			command:          "pause",
+			expectedTaskType: domain.DaemonTaskTypeServerPause,
+			abilities:        []domain.AbilityName{domain.AbilityNameGameServerCommon, domain.AbilityNameGameServerPause},
+		},
+		{
+			name:             "unpause_command_creates_unpause_task",
+			command:          "unpause",
+			expectedTaskType: domain.DaemonTaskTypeServerUnpause,
+			abilities:        []domain.AbilityName{domain.AbilityNameGameServerCommon, domain.AbilityNameGameServerPause},
+		},
 		{
 			name:             "update_command_creates_update_task",
 			command:          "update",
@@ -1074,9 +1190,16 @@ func TestHandler_DaemonTaskCreation(t *testing.T) {
 			serverSettingRepo := inmemory.NewServerSettingRepository()
 
 			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+			nodeRepo := inmemory.NewNodeRepository()
+			require.NoError(t, nodeRepo.Save(context.Background(), &domain.Node{
+				ID:            1,
+				ScriptPause:   lo.ToPtr("kill -STOP {pid}"),
+				ScriptUnpause: lo.ToPtr("kill -CONT {pid}"),
+			}))
 			serverControlService := servercontrol.NewService(
 				daemonTaskRepo,
 				serverSettingRepo,
+				nodeRepo,
 				services.NewNilTransactionManager(),
 			)
 			responder := api.NewResponder()
@@ -1143,3 +1266,61 @@ func TestHandler_DaemonTaskCreation(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_PauseWithoutNodeScript(t *testing.T) {
+	for _, command := range []string{"pause", "unpause"} {
+		t.Run(command, func(t *testing.T) {
+			serverRepo := inmemory.NewServerRepository()
+			rbacRepo := inmemory.NewRBACRepository()
+			daemonTaskRepo := inmemory.NewDaemonTaskRepository()
+			nodeRepo := inmemory.NewNodeRepository()
+			require.NoError(t, nodeRepo.Save(context.Background(), &domain.Node{ID: 1}))
+
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+			serverControlService := servercontrol.NewService(
+				daemonTaskRepo,
+				inmemory.NewServerSettingRepository(),
+				nodeRepo,
+				services.NewNilTransactionManager(),
+			)
+
+			now := time.Now()
+			server := &domain.Server{
+				ID:         1,
+				UUID:       uuid.New(),
+				UUIDShort:  "short1",
+				Enabled:    true,
+				Installed:  1,
+				Name:       "Test Server",
+				GameID:     "cstrike",
+				DSID:       1,
+				GameModID:  1,
+				ServerIP:   "192.168.1.1",
+				ServerPort: 27015,
+				CreatedAt:  &now,
+				UpdatedAt:  &now,
+			}
+			require.NoError(t, serverRepo.Save(context.Background(), server))
+			serverRepo.AddUserServer(testUser1.ID, server.ID)
+			allowUserAbilityForServer(t, rbacRepo, testUser1.ID, server.ID, domain.AbilityNameGameServerCommon)
+			allowUserAbilityForServer(t, rbacRepo, testUser1.ID, server.ID, domain.AbilityNameGameServerPause)
+
+			handler := NewHandler(serverRepo, serverControlService, rbacService, api.NewResponder())
+
+			ctx := auth.ContextWithSession(context.Background(), &auth.Session{User: &testUser1})
+			req := httptest.NewRequest(http.MethodPost, "/api/servers/1/command/"+command, nil)
+			req = req.WithContext(ctx)
+			req = mux.SetURLVars(req, map[string]string{"server": "1"})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+			assert.Contains(t, w.Body.String(), "script is not set for the node")
+
+			tasks, err := daemonTaskRepo.FindAll(ctx, nil, nil)
+			require.NoError(t, err)
+			assert.Empty(t, tasks)
+		})
+	}
+}
@@ -5,14 +5,13 @@ import (
 	"encoding/json"
 	"net/http"
 	"os"
-	"path/filepath"
 
 	"github.com/gameap/gameap/internal/api/base"
 	serversbase "github.com/gameap/gameap/internal/api/servers/base"
 	"github.com/gameap/gameap/internal/daemon"
 	"github.com/gameap/gameap/internal/domain"
-	"github.com/gameap/gameap/internal/filters"
 	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/serverconsole"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
 	"github.com/pkg/errors"
@@ -34,9 +33,7 @@ type fileService interface {
 type Handler struct {
 	serverFinder   *serversbase.ServerFinder
 	abilityChecker *serversbase.AbilityChecker
-	nodeRepo       repositories.NodeRepository
-	daemonCommands daemonCommands
-	fileService    fileService
+	consoleSender  *serverconsole.Sender
 	responder      base.Responder
 }
 
@@ -51,9 +48,7 @@ func NewHandler(
 	return &Handler{
 		serverFinder:   serversbase.NewServerFinder(serverRepo, rbac),
 		abilityChecker: serversbase.NewAbilityChecker(rbac),
-		nodeRepo:       nodeRepo,
-		daemonCommands: daemonCommands,
-		fileService:    fs,
+		consoleSender:  serverconsole.NewSender(nodeRepo, daemonCommands, fs),
 		responder:      responder,
 	}
 }
@@ -120,7 +115,7 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.sendConsoleCommand(ctx, server, in.Command); err != nil {
+	if err := h.consoleSender.Send(ctx, server, in.Command); err != nil {
 		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to send console command"))
 
 		return
@@ -128,46 +123,3 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 
 	h.responder.Write(ctx, rw, newConsoleResponse())
 }
-
-func (h *Handler) sendConsoleCommand(ctx context.Context, server *domain.Server, command string) error {
-	nodes, err := h.nodeRepo.Find(ctx, &filters.FindNode{
-		IDs: []uint{server.DSID},
-	}, nil, &filters.Pagination{
-		Limit: 1,
-	})
-	if err != nil {
-		return errors.WithMessage(err, "failed to find node")
-	}
-
-	if len(nodes) == 0 {
-		return api.NewNotFoundError("node not found")
-	}
-
-	node := &nodes[0]
-
-	if node.ScriptSendCommand != nil && *node.ScriptSendCommand != "" {
-		cmd := server.ReplaceServerShortcodes(node, *node.ScriptSendCommand, map[string]string{
-			"command": command,
-		})
-
-		_, err := h.daemonCommands.ExecuteCommand(ctx, node, cmd)
-		if err != nil {
-			return errors.WithMessage(err, "failed to execute send command script")
-		}
-
-		return nil
-	}
-
-	return h.uploadInputFile(ctx, node, server.Dir, command)
-}
-
-func (h *Handler) uploadInputFile(ctx context.Context, node *domain.Node, serverDir string, command string) error {
-	inputPath := filepath.Join(serverDir, "input.txt")
-
-	err := h.fileService.Upload(ctx, node, inputPath, []byte(command), 0644)
-	if err != nil {
-		return errors.WithMessage(err, "failed to upload console command")
-	}
-
-	return nil
-}
@@ -819,9 +819,7 @@ func TestHandler_NewHandler(t *testing.T) {
 	require.NotNil(t, handler)
 	assert.NotNil(t, handler.serverFinder)
 	assert.NotNil(t, handler.abilityChecker)
-	assert.Equal(t, nodeRepo, handler.nodeRepo)
-	assert.Equal(t, mockDaemon, handler.daemonCommands)
-	assert.Equal(t, mockFS, handler.fileService)
+	assert.NotNil(t, handler.consoleSender)
 	assert.Equal(t, responder, handler.responder)
 }
 
@@ -0,0 +1,97 @@
+package postmove
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/servermove"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+type serverMover interface {
+	Move(ctx context.Context, server *domain.Server, target servermove.Target) (uint, error)
+}
+
+type Handler struct {
+	serverRepo  repositories.ServerRepository
+	serverMover serverMover
+	responder   base.Responder
+}
+
+func NewHandler(
+	serverRepo repositories.ServerRepository,
+	serverMover serverMover,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		serverRepo:  serverRepo,
+		serverMover: serverMover,
+		responder:   responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	serverID, err := api.NewInputReader(r).ReadUint("server")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid server id"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	servers, err := h.serverRepo.Find(ctx, filters.FindServerByIDs(serverID), nil, nil)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to find server"))
+
+		return
+	}
+
+	if len(servers) == 0 {
+		h.responder.WriteError(ctx, rw, api.NewNotFoundError("server not found"))
+
+		return
+	}
+
+	input := &moveServerInput{}
+	err = json.NewDecoder(r.Body).Decode(input)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid request body"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if err = input.Validate(); err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "validation failed"))
+
+		return
+	}
+
+	daemonTaskID, err := h.serverMover.Move(ctx, &servers[0], input.ToTarget())
+	if err != nil {
+		if errors.Is(err, servermove.ErrUnfinishedTasksExist) {
+			h.responder.WriteError(ctx, rw, api.WrapHTTPError(err, http.StatusConflict))
+
+			return
+		}
+
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to move server"))
+
+		return
+	}
+
+	rw.WriteHeader(http.StatusAccepted)
+	h.responder.Write(ctx, rw, newMoveResponse(daemonTaskID))
+}
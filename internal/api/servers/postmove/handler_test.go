@@ -0,0 +1,176 @@
+package postmove
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/servermove"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name        string
+		serverID    string
+		requestBody string
+		setupRepos  func(t *testing.T, daemonTaskRepo *inmemory.DaemonTaskRepository)
+		wantStatus  int
+		wantError   string
+	}{
+		{
+			name:        "move scheduled",
+			serverID:    "1",
+			requestBody: `{"ds_id": 2, "server_ip": "10.0.0.2", "server_port": 27025, "query_port": "27026"}`,
+			wantStatus:  http.StatusAccepted,
+		},
+		{
+			name:        "invalid server id",
+			serverID:    "invalid",
+			requestBody: `{}`,
+			wantStatus:  http.StatusBadRequest,
+			wantError:   "invalid server id",
+		},
+		{
+			name:        "server not found",
+			serverID:    "999",
+			requestBody: `{"ds_id": 2, "server_ip": "10.0.0.2", "server_port": 27025}`,
+			wantStatus:  http.StatusNotFound,
+			wantError:   "server not found",
+		},
+		{
+			name:        "invalid request body",
+			serverID:    "1",
+			requestBody: `{invalid`,
+			wantStatus:  http.StatusBadRequest,
+			wantError:   "invalid request body",
+		},
+		{
+			name:        "ds_id is required",
+			serverID:    "1",
+			requestBody: `{"server_ip": "10.0.0.2", "server_port": 27025}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantError:   "ds_id is required",
+		},
+		{
+			name:        "invalid server port",
+			serverID:    "1",
+			requestBody: `{"ds_id": 2, "server_ip": "10.0.0.2", "server_port": 70000}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantError:   "server_port must be between 1 and 65535",
+		},
+		{
+			name:        "absolute dir",
+			serverID:    "1",
+			requestBody: `{"ds_id": 2, "server_ip": "10.0.0.2", "server_port": 27025, "dir": "/etc"}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantError:   ErrInvalidDir.Error(),
+		},
+		{
+			name:        "dir with shell metacharacters",
+			serverID:    "1",
+			requestBody: `{"ds_id": 2, "server_ip": "10.0.0.2", "server_port": 27025, "dir": "servers/cs\"; reboot; \""}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantError:   ErrInvalidDir.Error(),
+		},
+		{
+			name:        "port is busy on target node",
+			serverID:    "1",
+			requestBody: `{"ds_id": 2, "server_ip": "10.0.0.2", "server_port": 27015}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantError:   "port 27015 is already in use on 10.0.0.2",
+		},
+		{
+			name:        "unfinished tasks exist",
+			serverID:    "1",
+			requestBody: `{"ds_id": 2, "server_ip": "10.0.0.2", "server_port": 27025}`,
+			setupRepos: func(t *testing.T, daemonTaskRepo *inmemory.DaemonTaskRepository) {
+				t.Helper()
+
+				require.NoError(t, daemonTaskRepo.Save(context.Background(), &domain.DaemonTask{
+					DedicatedServerID: 1,
+					ServerID:          lo.ToPtr(uint(1)),
+					Task:              domain.DaemonTaskTypeServerInstall,
+					Status:            domain.DaemonTaskStatusWaiting,
+				}))
+			},
+			wantStatus: http.StatusConflict,
+			wantError:  servermove.ErrUnfinishedTasksExist.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			serverRepo := inmemory.NewServerRepository()
+			nodeRepo := inmemory.NewNodeRepository()
+			daemonTaskRepo := inmemory.NewDaemonTaskRepository()
+
+			require.NoError(t, nodeRepo.Save(ctx, &domain.Node{ID: 1, Enabled: true, IPs: domain.IPList{"10.0.0.1"}}))
+			require.NoError(t, nodeRepo.Save(ctx, &domain.Node{ID: 2, Enabled: true, IPs: domain.IPList{"10.0.0.2"}}))
+			require.NoError(t, serverRepo.Save(ctx, &domain.Server{
+				ID:         1,
+				UUID:       uuid.New(),
+				DSID:       1,
+				ServerIP:   "10.0.0.1",
+				ServerPort: 27015,
+				Dir:        "servers/cs",
+			}))
+			require.NoError(t, serverRepo.Save(ctx, &domain.Server{
+				ID:         2,
+				UUID:       uuid.New(),
+				DSID:       2,
+				ServerIP:   "10.0.0.2",
+				ServerPort: 27015,
+				Dir:        "servers/other",
+			}))
+
+			if tt.setupRepos != nil {
+				tt.setupRepos(t, daemonTaskRepo)
+			}
+
+			mover := servermove.NewService(daemonTaskRepo, serverRepo, nodeRepo, services.NewNilTransactionManager())
+			handler := NewHandler(serverRepo, mover, api.NewResponder())
+
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"/api/servers/"+tt.serverID+"/move",
+				strings.NewReader(tt.requestBody),
+			)
+			req = mux.SetURLVars(req, map[string]string{"server": tt.serverID})
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code, rr.Body.String())
+
+			if tt.wantError != "" {
+				var errorResponse map[string]any
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorResponse))
+				errorMsg, ok := errorResponse["error"].(string)
+				if !ok {
+					errorMsg, _ = errorResponse["message"].(string)
+				}
+				assert.Contains(t, errorMsg, tt.wantError)
+			}
+
+			if tt.wantStatus == http.StatusAccepted {
+				var response moveResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.NotZero(t, response.DaemonTaskID)
+			}
+		})
+	}
+}
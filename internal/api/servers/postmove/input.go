@@ -0,0 +1,96 @@
+package postmove
+
+import (
+	"fmt"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/services/servermove"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/flexible"
+	"github.com/gameap/gameap/pkg/validation"
+	"github.com/samber/lo"
+)
+
+const (
+	minPort = 1
+	maxPort = 65535
+)
+
+var (
+	ErrDSIDIsRequired    = api.NewValidationError("ds_id is required")
+	ErrServerIPRequired  = api.NewValidationError("server_ip is required")
+	ErrInvalidServerIP   = api.NewValidationError("server_ip is not a valid IP address or hostname")
+	ErrInvalidServerPort = api.NewValidationError(
+		fmt.Sprintf("server_port must be between %d and %d", minPort, maxPort),
+	)
+	ErrInvalidQueryPort = api.NewValidationError(
+		fmt.Sprintf("query_port must be between %d and %d", minPort, maxPort),
+	)
+	ErrInvalidRconPort = api.NewValidationError(
+		fmt.Sprintf("rcon_port must be between %d and %d", minPort, maxPort),
+	)
+	ErrInvalidDir = api.NewValidationError("dir must be a relative path without \"..\" segments and special characters")
+)
+
+type moveServerInput struct {
+	DSID       flexible.Int  `json:"ds_id"`
+	ServerIP   string        `json:"server_ip"`
+	ServerPort flexible.Int  `json:"server_port"`
+	QueryPort  *flexible.Int `json:"query_port,omitempty"`
+	RconPort   *flexible.Int `json:"rcon_port,omitempty"`
+	Dir        *string       `json:"dir,omitempty"`
+}
+
+func (in *moveServerInput) Validate() error {
+	if in.DSID.Int() <= 0 {
+		return ErrDSIDIsRequired
+	}
+
+	if in.ServerIP == "" {
+		return ErrServerIPRequired
+	}
+
+	if !validation.IsValidIPOrHostname(in.ServerIP) {
+		return ErrInvalidServerIP
+	}
+
+	if in.ServerPort.Int() < minPort || in.ServerPort.Int() > maxPort {
+		return ErrInvalidServerPort
+	}
+
+	if in.QueryPort != nil && (in.QueryPort.Int() < minPort || in.QueryPort.Int() > maxPort) {
+		return ErrInvalidQueryPort
+	}
+
+	if in.RconPort != nil && (in.RconPort.Int() < minPort || in.RconPort.Int() > maxPort) {
+		return ErrInvalidRconPort
+	}
+
+	if in.Dir != nil && *in.Dir != "" && !domain.IsValidServerDir(*in.Dir) {
+		return ErrInvalidDir
+	}
+
+	return nil
+}
+
+func (in *moveServerInput) ToTarget() servermove.Target {
+	target := servermove.Target{
+		NodeID:     uint(in.DSID.Int()), //nolint:gosec // We check it in Validate
+		ServerIP:   in.ServerIP,
+		ServerPort: in.ServerPort.Int(),
+	}
+
+	if in.QueryPort != nil {
+		target.QueryPort = lo.ToPtr(in.QueryPort.Int())
+	}
+
+	if in.RconPort != nil {
+		target.RconPort = lo.ToPtr(in.RconPort.Int())
+	}
+
+	if in.Dir != nil {
+		target.Dir = *in.Dir
+	}
+
+	return target
+}
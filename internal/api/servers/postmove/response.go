@@ -0,0 +1,11 @@
+package postmove
+
+type moveResponse struct {
+	DaemonTaskID uint `json:"gdaemonTaskId"`
+}
+
+func newMoveResponse(daemonTaskID uint) *moveResponse {
+	return &moveResponse{
+		DaemonTaskID: daemonTaskID,
+	}
+}
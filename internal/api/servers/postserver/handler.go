@@ -7,32 +7,57 @@ import (
 
 	"github.com/gameap/gameap/internal/api/base"
 	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/events"
 	"github.com/gameap/gameap/internal/filters"
 	"github.com/gameap/gameap/internal/repositories"
 	pkgstrings "github.com/gameap/gameap/pkg/strings"
 	"github.com/pkg/errors"
 )
 
+type portAllocator interface {
+	Do(ctx context.Context, nodeID uint, fn func(ctx context.Context) error) error
+	Allocate(
+		ctx context.Context,
+		node *domain.Node,
+		serverIP string,
+		layout domain.PortLayout,
+		request domain.ServerPortsRequest,
+	) (domain.ServerPorts, error)
+}
+
+type eventPublisher interface {
+	Publish(ctx context.Context, event domain.Event)
+}
+
 type Handler struct {
 	serverRepo     repositories.ServerRepository
 	nodeRepo       repositories.NodeRepository
+	gameRepo       repositories.GameRepository
 	gameModRepo    repositories.GameModRepository
 	daemonTaskRepo repositories.DaemonTaskRepository
+	portAllocator  portAllocator
+	eventPublisher eventPublisher
 	responder      base.Responder
 }
 
 func NewHandler(
 	serverRepo repositories.ServerRepository,
 	nodeRepo repositories.NodeRepository,
+	gameRepo repositories.GameRepository,
 	gameModRepo repositories.GameModRepository,
 	daemonTaskRepo repositories.DaemonTaskRepository,
+	portAllocator portAllocator,
+	eventPublisher eventPublisher,
 	responder base.Responder,
 ) *Handler {
 	return &Handler{
 		serverRepo:     serverRepo,
 		nodeRepo:       nodeRepo,
+		gameRepo:       gameRepo,
 		gameModRepo:    gameModRepo,
 		daemonTaskRepo: daemonTaskRepo,
+		portAllocator:  portAllocator,
+		eventPublisher: eventPublisher,
 		responder:      responder,
 	}
 }
@@ -60,30 +85,21 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 
 	server := input.ToDomain()
 
-	err = h.prepareServer(ctx, server, input)
+	node, err := h.prepareServer(ctx, server, input)
 	if err != nil {
 		h.responder.WriteError(ctx, rw, err)
 
 		return
 	}
 
-	err = h.serverRepo.Save(ctx, server)
+	taskID, err := h.createServer(ctx, node, server, input)
 	if err != nil {
-		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to save server"))
+		h.responder.WriteError(ctx, rw, err)
 
 		return
 	}
 
-	taskID := uint(0)
-
-	if input.Install != nil && *input.Install {
-		taskID, err = h.createInstallTask(ctx, server)
-		if err != nil {
-			h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to create install task"))
-
-			return
-		}
-	}
+	h.eventPublisher.Publish(ctx, events.ServerCreated(server))
 
 	response := createServerResponse{
 		Message: "success",
@@ -96,26 +112,92 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	h.responder.Write(ctx, rw, response)
 }
 
+// createServer saves the server and creates the install task if it is requested.
+// Automatic ports are allocated in the same transaction while no other server is created on the node.
+func (h *Handler) createServer(
+	ctx context.Context,
+	node *domain.Node,
+	server *domain.Server,
+	input *serverInput,
+) (uint, error) {
+	request := input.PortsRequest()
+
+	var layout domain.PortLayout
+	if request.HasAuto() {
+		var err error
+
+		layout, err = h.gamePortLayout(ctx, server.GameID)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	taskID := uint(0)
+
+	err := h.portAllocator.Do(ctx, node.ID, func(ctx context.Context) error {
+		if request.HasAuto() {
+			ports, err := h.portAllocator.Allocate(ctx, node, server.ServerIP, layout, request)
+			if err != nil {
+				return err
+			}
+
+			ports.ApplyTo(server)
+		}
+
+		err := h.serverRepo.Save(ctx, server)
+		if err != nil {
+			return errors.WithMessage(err, "failed to save server")
+		}
+
+		if input.Install != nil && *input.Install {
+			taskID, err = h.createInstallTask(ctx, server)
+			if err != nil {
+				return errors.WithMessage(err, "failed to create install task")
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return taskID, nil
+}
+
+func (h *Handler) gamePortLayout(ctx context.Context, gameID string) (domain.PortLayout, error) {
+	games, err := h.gameRepo.Find(ctx, &filters.FindGame{Codes: []string{gameID}}, nil, nil)
+	if err != nil {
+		return domain.PortLayout{}, errors.WithMessage(err, "failed to find game")
+	}
+
+	if len(games) == 0 {
+		return domain.PortLayout{}, nil
+	}
+
+	return games[0].PortLayout(), nil
+}
+
 func (h *Handler) prepareServer(
 	ctx context.Context,
 	server *domain.Server,
 	input *serverInput,
-) error {
+) (*domain.Node, error) {
 	if server.Rcon == nil || *server.Rcon == "" {
 		rconPassword, err := pkgstrings.CryptoRandomString(defaultRconPasswordLength)
 		if err != nil {
-			return errors.WithMessage(err, "failed to generate rcon password")
+			return nil, errors.WithMessage(err, "failed to generate rcon password")
 		}
 		server.Rcon = &rconPassword
 	}
 
 	nodes, err := h.nodeRepo.Find(ctx, &filters.FindNode{IDs: []uint{server.DSID}}, nil, nil)
 	if err != nil {
-		return errors.WithMessage(err, "failed to find node")
+		return nil, errors.WithMessage(err, "failed to find node")
 	}
 
 	if len(nodes) == 0 {
-		return errors.New("node not found")
+		return nil, errors.New("node not found")
 	}
 
 	node := &nodes[0]
@@ -123,11 +205,11 @@ func (h *Handler) prepareServer(
 	if server.StartCommand == nil || *server.StartCommand == "" {
 		gameMods, err := h.gameModRepo.Find(ctx, &filters.FindGameMod{IDs: []uint{server.GameModID}}, nil, nil)
 		if err != nil {
-			return errors.WithMessage(err, "failed to find game mod")
+			return nil, errors.WithMessage(err, "failed to find game mod")
 		}
 
 		if len(gameMods) == 0 {
-			return errors.New("game mod not found")
+			return nil, errors.New("game mod not found")
 		}
 
 		gameMod := &gameMods[0]
@@ -148,7 +230,7 @@ func (h *Handler) prepareServer(
 		server.Installed = domain.ServerInstalledStatusNotInstalled
 	}
 
-	return nil
+	return node, nil
 }
 
 func (h *Handler) createInstallTask(ctx context.Context, server *domain.Server) (uint, error) {
@@ -8,14 +8,32 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/gameap/gameap/internal/cache"
 	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/events"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
 	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/serverports"
 	"github.com/gameap/gameap/pkg/api"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+func newPortAllocator(serverRepo repositories.ServerRepository) *serverports.Service {
+	return serverports.NewService(
+		serverRepo,
+		cache.NewInMemory(),
+		services.NewNilTransactionManager(),
+		domain.PortRange{Start: 27015, End: 27999},
+		time.Second,
+	)
+}
+
 func TestHandler_ServeHTTP(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -454,7 +472,16 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			_ = nodeRepo.Save(context.Background(), &domain.Node{ID: 1, OS: "linux"})
 			_ = gameModRepo.Save(context.Background(), &domain.GameMod{ID: 1, GameCode: "cstrike"})
 
-			handler := NewHandler(serverRepo, nodeRepo, gameModRepo, daemonTaskRepo, responder)
+			handler := NewHandler(
+				serverRepo,
+				nodeRepo,
+				inmemory.NewGameRepository(),
+				gameModRepo,
+				daemonTaskRepo,
+				newPortAllocator(serverRepo),
+				events.NewBus(),
+				responder,
+			)
 
 			body := []byte(tt.requestBody)
 			req := httptest.NewRequest(http.MethodPost, "/api/servers", bytes.NewBuffer(body))
@@ -504,7 +531,25 @@ func TestHandler_ServerPersistence(t *testing.T) {
 	_ = nodeRepo.Save(context.Background(), &domain.Node{ID: 1, OS: "linux"})
 	_ = gameModRepo.Save(context.Background(), &domain.GameMod{ID: 1, GameCode: "cstrike"})
 
-	handler := NewHandler(serverRepo, nodeRepo, gameModRepo, daemonTaskRepo, responder)
+	bus := events.NewBus()
+
+	var published []domain.Event
+	bus.Subscribe(events.HandlerFunc(func(_ context.Context, event domain.Event) error {
+		published = append(published, event)
+
+		return nil
+	}))
+
+	handler := NewHandler(
+		serverRepo,
+		nodeRepo,
+		inmemory.NewGameRepository(),
+		gameModRepo,
+		daemonTaskRepo,
+		newPortAllocator(serverRepo),
+		bus,
+		responder,
+	)
 
 	serverData := map[string]any{
 		"install":     true,
@@ -560,6 +605,10 @@ func TestHandler_ServerPersistence(t *testing.T) {
 	assert.Equal(t, "success", response.Message)
 	assert.Equal(t, server.ID, response.Result.ServerID)
 	assert.NotEmpty(t, response.Result.TaskID)
+
+	require.Len(t, published, 1)
+	assert.Equal(t, domain.EventTypeServerCreated, published[0].Type)
+	assert.Equal(t, &server.ID, published[0].ServerID)
 }
 
 func TestHandler_MultipleServers(t *testing.T) {
@@ -575,7 +624,16 @@ func TestHandler_MultipleServers(t *testing.T) {
 	_ = gameModRepo.Save(context.Background(), &domain.GameMod{ID: 1, GameCode: "cstrike"})
 	_ = gameModRepo.Save(context.Background(), &domain.GameMod{ID: 2, GameCode: "valve"})
 
-	handler := NewHandler(serverRepo, nodeRepo, gameModRepo, daemonTaskRepo, responder)
+	handler := NewHandler(
+		serverRepo,
+		nodeRepo,
+		inmemory.NewGameRepository(),
+		gameModRepo,
+		daemonTaskRepo,
+		newPortAllocator(serverRepo),
+		events.NewBus(),
+		responder,
+	)
 
 	servers := []map[string]any{
 		{
@@ -621,3 +679,185 @@ func TestHandler_MultipleServers(t *testing.T) {
 	assert.Equal(t, "Server 1", allServers[0].Name)
 	assert.Equal(t, "Server 2", allServers[1].Name)
 }
+
+func TestHandler_AutoPorts(t *testing.T) {
+	tests := []struct {
+		name          string
+		requestBody   string
+		wantServer    int
+		wantQueryPort *int
+		wantRconPort  *int
+	}{
+		{
+			name: "all ports placed by game layout",
+			requestBody: `{
+				"name": "Auto",
+				"game_id": "cstrike",
+				"ds_id": 1,
+				"game_mod_id": 1,
+				"server_ip": "192.168.1.100",
+				"server_port": "auto",
+				"query_port": "auto",
+				"rcon_port": "auto"
+			}`,
+			wantServer:    30002,
+			wantQueryPort: lo.ToPtr(30003),
+			wantRconPort:  lo.ToPtr(30004),
+		},
+		{
+			name: "game port only",
+			requestBody: `{
+				"name": "Auto",
+				"game_id": "cstrike",
+				"ds_id": 1,
+				"game_mod_id": 1,
+				"server_ip": "192.168.1.100",
+				"server_port": "auto"
+			}`,
+			wantServer: 30002,
+		},
+		{
+			name: "query port relative to fixed game port",
+			requestBody: `{
+				"name": "Auto",
+				"game_id": "cstrike",
+				"ds_id": 1,
+				"game_mod_id": 1,
+				"server_ip": "192.168.1.100",
+				"server_port": 31000,
+				"query_port": "AUTO",
+				"rcon_port": 31005
+			}`,
+			wantServer:    31000,
+			wantQueryPort: lo.ToPtr(31001),
+			wantRconPort:  lo.ToPtr(31005),
+		},
+		{
+			name: "other IP of the node",
+			requestBody: `{
+				"name": "Auto",
+				"game_id": "cstrike",
+				"ds_id": 1,
+				"game_mod_id": 1,
+				"server_ip": "192.168.1.101",
+				"server_port": "auto",
+				"query_port": "auto"
+			}`,
+			wantServer:    30000,
+			wantQueryPort: lo.ToPtr(30001),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// ARRANGE
+			ctx := context.Background()
+			serverRepo := inmemory.NewServerRepository()
+			nodeRepo := inmemory.NewNodeRepository()
+			gameRepo := inmemory.NewGameRepository()
+			gameModRepo := inmemory.NewGameModRepository()
+
+			require.NoError(t, nodeRepo.Save(ctx, &domain.Node{
+				ID:             1,
+				OS:             "linux",
+				PortRangeStart: lo.ToPtr(30000),
+				PortRangeEnd:   lo.ToPtr(30100),
+			}))
+			require.NoError(t, gameRepo.Save(ctx, &domain.Game{
+				Code:            "cstrike",
+				QueryPortOffset: 1,
+				RconPortOffset:  2,
+			}))
+			require.NoError(t, gameModRepo.Save(ctx, &domain.GameMod{ID: 1, GameCode: "cstrike"}))
+			require.NoError(t, serverRepo.Save(ctx, &domain.Server{
+				DSID:       1,
+				ServerIP:   "192.168.1.100",
+				ServerPort: 30000,
+				QueryPort:  lo.ToPtr(30001),
+			}))
+
+			handler := NewHandler(
+				serverRepo,
+				nodeRepo,
+				gameRepo,
+				gameModRepo,
+				inmemory.NewDaemonTaskRepository(),
+				newPortAllocator(serverRepo),
+				events.NewBus(),
+				api.NewResponder(),
+			)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/servers", strings.NewReader(tt.requestBody))
+			w := httptest.NewRecorder()
+
+			// ACT
+			handler.ServeHTTP(w, req)
+
+			// ASSERT
+			require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+			var response createServerResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+			servers, err := serverRepo.Find(ctx, filters.FindServerByIDs(response.Result.ServerID), nil, nil)
+			require.NoError(t, err)
+			require.Len(t, servers, 1)
+			assert.Equal(t, tt.wantServer, servers[0].ServerPort)
+			assert.Equal(t, tt.wantQueryPort, servers[0].QueryPort)
+			assert.Equal(t, tt.wantRconPort, servers[0].RconPort)
+		})
+	}
+}
+
+func TestHandler_AutoPorts_NoFreePorts(t *testing.T) {
+	// ARRANGE
+	ctx := context.Background()
+	serverRepo := inmemory.NewServerRepository()
+	nodeRepo := inmemory.NewNodeRepository()
+	gameModRepo := inmemory.NewGameModRepository()
+
+	require.NoError(t, nodeRepo.Save(ctx, &domain.Node{
+		ID:             1,
+		OS:             "linux",
+		PortRangeStart: lo.ToPtr(30000),
+		PortRangeEnd:   lo.ToPtr(30000),
+	}))
+	require.NoError(t, gameModRepo.Save(ctx, &domain.GameMod{ID: 1, GameCode: "cstrike"}))
+	require.NoError(t, serverRepo.Save(ctx, &domain.Server{
+		DSID:       1,
+		ServerIP:   "192.168.1.100",
+		ServerPort: 30000,
+	}))
+
+	handler := NewHandler(
+		serverRepo,
+		nodeRepo,
+		inmemory.NewGameRepository(),
+		gameModRepo,
+		inmemory.NewDaemonTaskRepository(),
+		newPortAllocator(serverRepo),
+		events.NewBus(),
+		api.NewResponder(),
+	)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/servers", strings.NewReader(`{
+		"name": "Auto",
+		"game_id": "cstrike",
+		"ds_id": 1,
+		"game_mod_id": 1,
+		"server_ip": "192.168.1.100",
+		"server_port": "auto"
+	}`))
+	w := httptest.NewRecorder()
+
+	// ACT
+	handler.ServeHTTP(w, req)
+
+	// ASSERT
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), "no free ports")
+
+	servers, err := serverRepo.FindAll(ctx, nil, nil)
+	require.NoError(t, err)
+	assert.Len(t, servers, 1)
+}
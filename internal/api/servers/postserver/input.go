@@ -1,19 +1,24 @@
 package postserver
 
 import (
+	"encoding/json"
 	"log/slog"
+	"strings"
 
 	"github.com/gameap/gameap/internal/domain"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/flexible"
 	"github.com/gameap/gameap/pkg/validation"
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 )
 
 const (
 	maxNameLength = 128
 	minPort       = 1
 	maxPort       = 65535
+
+	autoPort = "auto"
 )
 
 var (
@@ -36,9 +41,9 @@ type serverInput struct {
 	GameID       string         `json:"game_id"`
 	GameModID    flexible.Int   `json:"game_mod_id"`
 	ServerIP     string         `json:"server_ip"`
-	ServerPort   flexible.Int   `json:"server_port"`
-	QueryPort    *flexible.Int  `json:"query_port,omitempty"`
-	RconPort     *flexible.Int  `json:"rcon_port,omitempty"`
+	ServerPort   portInput      `json:"server_port"`
+	QueryPort    *portInput     `json:"query_port,omitempty"`
+	RconPort     *portInput     `json:"rcon_port,omitempty"`
 	Rcon         *string        `json:"rcon,omitempty"`
 	Dir          *string        `json:"dir,omitempty"`
 	StartCommand *string        `json:"start_command,omitempty"`
@@ -74,15 +79,15 @@ func (s *serverInput) Validate() error {
 		return ErrInvalidServerIP
 	}
 
-	if s.ServerPort.Int() < minPort || s.ServerPort.Int() > maxPort {
+	if !s.ServerPort.Valid() {
 		return ErrInvalidServerPort
 	}
 
-	if s.QueryPort != nil && (s.QueryPort.Int() < minPort || s.QueryPort.Int() > maxPort) {
+	if s.QueryPort != nil && !s.QueryPort.Valid() {
 		return ErrInvalidQueryPort
 	}
 
-	if s.RconPort != nil && (s.RconPort.Int() < minPort || s.RconPort.Int() > maxPort) {
+	if s.RconPort != nil && !s.RconPort.Valid() {
 		return ErrInvalidRconPort
 	}
 
@@ -100,18 +105,6 @@ func (s *serverInput) ToDomain() *domain.Server {
 		u = uuid.New()
 	}
 
-	var queryPort *int
-	if s.QueryPort != nil {
-		qp := s.QueryPort.Int()
-		queryPort = &qp
-	}
-
-	var rconPort *int
-	if s.RconPort != nil {
-		rp := s.RconPort.Int()
-		rconPort = &rp
-	}
-
 	server := &domain.Server{
 		UUID:         u,
 		UUIDShort:    u.String()[0:8],
@@ -123,9 +116,9 @@ func (s *serverInput) ToDomain() *domain.Server {
 		DSID:         uint(s.DSID.Int()),      //nolint:gosec // We check it in Validate
 		GameModID:    uint(s.GameModID.Int()), //nolint:gosec // We check it in Validate
 		ServerIP:     s.ServerIP,
-		ServerPort:   s.ServerPort.Int(),
-		QueryPort:    queryPort,
-		RconPort:     rconPort,
+		ServerPort:   s.ServerPort.Port.Int(),
+		QueryPort:    s.QueryPort.port(),
+		RconPort:     s.RconPort.port(),
 		Rcon:         s.Rcon,
 		Dir:          getDir(s.Dir),
 		StartCommand: s.StartCommand,
@@ -135,6 +128,61 @@ func (s *serverInput) ToDomain() *domain.Server {
 	return server
 }
 
+// PortsRequest returns the requested ports, some of them may be allocated automatically.
+func (s *serverInput) PortsRequest() domain.ServerPortsRequest {
+	request := domain.ServerPortsRequest{
+		ServerPort: s.ServerPort.Request(),
+	}
+
+	if s.QueryPort != nil {
+		request.QueryPort = lo.ToPtr(s.QueryPort.Request())
+	}
+
+	if s.RconPort != nil {
+		request.RconPort = lo.ToPtr(s.RconPort.Request())
+	}
+
+	return request
+}
+
+// portInput is a port number or "auto" to allocate the port automatically.
+type portInput struct {
+	Port flexible.Int
+	Auto bool
+}
+
+func (p *portInput) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil && strings.EqualFold(str, autoPort) {
+		p.Auto = true
+
+		return nil
+	}
+
+	return p.Port.UnmarshalJSON(data)
+}
+
+func (p *portInput) Valid() bool {
+	return p.Auto || (p.Port.Int() >= minPort && p.Port.Int() <= maxPort)
+}
+
+// port returns the requested port, automatic ports are allocated later.
+func (p *portInput) port() *int {
+	if p == nil || p.Auto {
+		return nil
+	}
+
+	return lo.ToPtr(p.Port.Int())
+}
+
+func (p *portInput) Request() domain.PortRequest {
+	if p.Auto {
+		return domain.AutoPort()
+	}
+
+	return domain.FixedPort(p.Port.Int())
+}
+
 func getDir(dir *string) string {
 	if dir == nil || *dir == "" {
 		return ""
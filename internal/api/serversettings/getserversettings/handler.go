@@ -17,6 +17,8 @@ const (
 	autostartSettingKey         = "autostart"
 	autostartCurrentSettingKey  = "autostart_current"
 	updateBeforeStartSettingKey = "update_before_start"
+	backupKeepLastSettingKey    = "backup_keep_last"
+	backupMaxAgeDaysSettingKey  = "backup_max_age_days"
 )
 
 type Handler struct {
@@ -169,6 +171,26 @@ func (h *Handler) buildSettingsResponse(
 	}
 	order = append(order, updateBeforeStartSettingKey)
 
+	if isAdmin {
+		settingsMap[backupKeepLastSettingKey] = SettingResponse{
+			Name:     backupKeepLastSettingKey,
+			Value:    "",
+			Type:     "string",
+			Label:    "Number of backups to keep",
+			AdminVar: true,
+		}
+		order = append(order, backupKeepLastSettingKey)
+
+		settingsMap[backupMaxAgeDaysSettingKey] = SettingResponse{
+			Name:     backupMaxAgeDaysSettingKey,
+			Value:    "",
+			Type:     "string",
+			Label:    "Maximum backup age in days",
+			AdminVar: true,
+		}
+		order = append(order, backupMaxAgeDaysSettingKey)
+	}
+
 	if gameMod != nil {
 		for _, gmVar := range gameMod.Vars {
 			if gmVar.AdminVar && !isAdmin {
@@ -19,6 +19,8 @@ const (
 	autostartSettingKey         = "autostart"
 	autostartCurrentSettingKey  = "autostart_current"
 	updateBeforeStartSettingKey = "update_before_start"
+	backupKeepLastSettingKey    = "backup_keep_last"
+	backupMaxAgeDaysSettingKey  = "backup_max_age_days"
 )
 
 type Handler struct {
@@ -162,6 +164,18 @@ func (h *Handler) buildAllowedSettings(gameMod *domain.GameMod, isAdmin bool) ma
 		adminVar: false,
 	}
 
+	if isAdmin {
+		allowedSettings[backupKeepLastSettingKey] = settingMetadata{
+			name:     backupKeepLastSettingKey,
+			adminVar: true,
+		}
+
+		allowedSettings[backupMaxAgeDaysSettingKey] = settingMetadata{
+			name:     backupMaxAgeDaysSettingKey,
+			adminVar: true,
+		}
+	}
+
 	if gameMod != nil {
 		for _, gmVar := range gameMod.Vars {
 			if gmVar.AdminVar && !isAdmin {
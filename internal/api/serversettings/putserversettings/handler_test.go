@@ -157,6 +157,10 @@ func TestPutServerSettings(t *testing.T) {
 					"name":  "maxplayers",
 					"value": "16",
 				},
+				{
+					"name":  "backup_keep_last",
+					"value": "3",
+				},
 			},
 			abilities: []domain.Ability{
 				{
@@ -203,8 +207,9 @@ func TestPutServerSettings(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			verifySettings: true,
 			wantFinalVals: map[string]string{
-				"rcon_password": "secret123",
-				"maxplayers":    "16",
+				"rcon_password":    "secret123",
+				"maxplayers":       "16",
+				"backup_keep_last": "3",
 			},
 		},
 		{
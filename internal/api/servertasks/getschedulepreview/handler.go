@@ -0,0 +1,114 @@
+package getschedulepreview
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gameap/gameap/internal/api/base"
+	serversbase "github.com/gameap/gameap/internal/api/servers/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+// Handler returns the next runs of a cron schedule before a server task is saved.
+type Handler struct {
+	serverFinder   *serversbase.ServerFinder
+	abilityChecker *serversbase.AbilityChecker
+	responder      base.Responder
+}
+
+func NewHandler(
+	serversRepo repositories.ServerRepository,
+	rbac base.RBAC,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		serverFinder:   serversbase.NewServerFinder(serversRepo, rbac),
+		abilityChecker: serversbase.NewAbilityChecker(rbac),
+		responder:      responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	inputReader := api.NewInputReader(r)
+
+	serverID, err := inputReader.ReadUint("server")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid server id"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	server, err := h.serverFinder.FindUserServer(ctx, session.User, serverID)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	err = h.abilityChecker.CheckOrError(
+		ctx,
+		session.User.ID,
+		server.ID,
+		[]domain.AbilityName{domain.AbilityNameGameServerTasks},
+	)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	input, err := readInput(r)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	err = input.Validate()
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "validation failed"))
+
+		return
+	}
+
+	task := &domain.ServerTask{
+		CronExpression: &input.CronExpression,
+		Timezone:       lo.EmptyableToPtr(input.Timezone),
+	}
+
+	// Schedule is validated here, so that the error is reported as a validation error.
+	if _, err = task.Schedule(); err != nil {
+		h.responder.WriteError(ctx, rw, api.NewValidationError(err.Error()))
+
+		return
+	}
+
+	runs, err := task.NextRuns(time.Now(), input.Count)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to calculate next runs"))
+
+		return
+	}
+
+	h.responder.Write(ctx, rw, newPreviewResponse(input.Timezone, runs))
+}
@@ -0,0 +1,188 @@
+package getschedulepreview
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUser1 = domain.User{
+	ID:    1,
+	Login: "testuser",
+	Email: "test@example.com",
+}
+
+func setupAdmin(t *testing.T, rbacRepo *inmemory.RBACRepository) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	adminRole := &domain.Role{
+		Name:  "admin",
+		Title: lo.ToPtr("Administrator"),
+		Level: lo.ToPtr(uint(100)),
+	}
+	require.NoError(t, rbacRepo.SaveRole(ctx, adminRole))
+	require.NoError(t, rbacRepo.SaveAssignedRole(ctx, &domain.AssignedRole{
+		RoleID:     adminRole.ID,
+		EntityID:   testUser1.ID,
+		EntityType: domain.EntityTypeUser,
+	}))
+
+	ability := &domain.Ability{
+		Name:  domain.AbilityNameAdminRolesPermissions,
+		Title: lo.ToPtr("Admin Permissions"),
+	}
+	require.NoError(t, rbacRepo.SaveAbility(ctx, ability))
+	require.NoError(t, rbacRepo.SavePermission(ctx, &domain.Permission{
+		AbilityID:  ability.ID,
+		EntityID:   lo.ToPtr(testUser1.ID),
+		EntityType: lo.ToPtr(domain.EntityTypeUser),
+	}))
+}
+
+func setupServer(t *testing.T, repo *inmemory.ServerRepository) {
+	t.Helper()
+
+	now := time.Now()
+
+	require.NoError(t, repo.Save(context.Background(), &domain.Server{
+		ID:         1,
+		UUID:       uuid.New(),
+		UUIDShort:  "short",
+		Enabled:    true,
+		Name:       "Test Server",
+		GameID:     "cs",
+		ServerIP:   "127.0.0.1",
+		ServerPort: 27015,
+		Dir:        "/home/gameap/servers/test",
+		CreatedAt:  &now,
+		UpdatedAt:  &now,
+	}))
+	repo.AddUserServer(testUser1.ID, 1)
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		authenticated  bool
+		query          url.Values
+		expectedStatus int
+		wantError      string
+		wantRuns       int
+	}{
+		{
+			name:          "default_count",
+			authenticated: true,
+			query: url.Values{
+				"cron_expression": {"0 5 * * 1-6"},
+				"timezone":        {"Europe/Berlin"},
+			},
+			expectedStatus: http.StatusOK,
+			wantRuns:       defaultCount,
+		},
+		{
+			name:          "custom_count",
+			authenticated: true,
+			query: url.Values{
+				"cron_expression": {"@hourly"},
+				"count":           {"10"},
+			},
+			expectedStatus: http.StatusOK,
+			wantRuns:       10,
+		},
+		{
+			name:           "missing_cron_expression",
+			authenticated:  true,
+			query:          url.Values{},
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "cron_expression is required",
+		},
+		{
+			name:          "invalid_cron_expression",
+			authenticated: true,
+			query: url.Values{
+				"cron_expression": {"0 5 *"},
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "invalid cron expression",
+		},
+		{
+			name:          "count_is_too_big",
+			authenticated: true,
+			query: url.Values{
+				"cron_expression": {"@daily"},
+				"count":           {"1000"},
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "count must be between 1 and 50",
+		},
+		{
+			name: "user_not_authenticated",
+			query: url.Values{
+				"cron_expression": {"@daily"},
+			},
+			expectedStatus: http.StatusUnauthorized,
+			wantError:      "user not authenticated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverRepo := inmemory.NewServerRepository()
+			rbacRepo := inmemory.NewRBACRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+			handler := NewHandler(serverRepo, rbacService, api.NewResponder())
+
+			setupServer(t, serverRepo)
+			setupAdmin(t, rbacRepo)
+
+			ctx := context.Background()
+			if tt.authenticated {
+				ctx = auth.ContextWithSession(ctx, &auth.Session{User: &testUser1})
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/servers/1/tasks/preview?"+tt.query.Encode(), nil)
+			req = req.WithContext(ctx)
+			req = mux.SetURLVars(req, map[string]string{"server": "1"})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.wantError != "" {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "error", response["status"])
+				assert.Contains(t, response["error"], tt.wantError)
+
+				return
+			}
+
+			var response previewResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			require.Len(t, response.Runs, tt.wantRuns)
+
+			for i := 1; i < len(response.Runs); i++ {
+				assert.True(t, response.Runs[i].After(response.Runs[i-1]))
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package getschedulepreview
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gameap/gameap/pkg/api"
+)
+
+const (
+	defaultCount = 5
+	maxCount     = 50
+)
+
+var (
+	ErrCronExpressionIsRequired = api.NewValidationError("cron_expression is required")
+	ErrInvalidCount             = api.NewValidationError("count must be between 1 and 50")
+)
+
+type previewInput struct {
+	CronExpression string
+	Timezone       string
+	Count          int
+}
+
+func readInput(r *http.Request) (*previewInput, error) {
+	queryReader := api.NewQueryReader(r)
+
+	cronExpression, _ := queryReader.ReadString("cron_expression")
+	timezone, _ := queryReader.ReadString("timezone")
+	countStr, _ := queryReader.ReadString("count")
+
+	input := &previewInput{
+		CronExpression: cronExpression,
+		Timezone:       timezone,
+		Count:          defaultCount,
+	}
+
+	if countStr != "" {
+		count, err := strconv.Atoi(countStr)
+		if err != nil {
+			return nil, ErrInvalidCount
+		}
+
+		input.Count = count
+	}
+
+	return input, nil
+}
+
+func (in *previewInput) Validate() error {
+	if in.CronExpression == "" {
+		return ErrCronExpressionIsRequired
+	}
+
+	if in.Count < 1 || in.Count > maxCount {
+		return ErrInvalidCount
+	}
+
+	return nil
+}
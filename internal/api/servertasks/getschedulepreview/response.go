@@ -0,0 +1,19 @@
+package getschedulepreview
+
+import "time"
+
+type previewResponse struct {
+	Timezone string      `json:"timezone"`
+	Runs     []time.Time `json:"runs"`
+}
+
+func newPreviewResponse(timezone string, runs []time.Time) previewResponse {
+	if timezone == "" {
+		timezone = time.UTC.String()
+	}
+
+	return previewResponse{
+		Timezone: timezone,
+		Runs:     runs,
+	}
+}
@@ -8,16 +8,18 @@ import (
 )
 
 type serverTaskResponse struct {
-	ID           uint       `json:"id"`
-	Command      string     `json:"command"`
-	ServerID     uint       `json:"server_id"`
-	Repeat       uint8      `json:"repeat"`
-	RepeatPeriod string     `json:"repeat_period"`
-	Counter      uint       `json:"counter"`
-	ExecuteDate  time.Time  `json:"execute_date"`
-	Payload      *string    `json:"payload"`
-	CreatedAt    *time.Time `json:"created_at"`
-	UpdatedAt    *time.Time `json:"updated_at"`
+	ID             uint       `json:"id"`
+	Command        string     `json:"command"`
+	ServerID       uint       `json:"server_id"`
+	Repeat         uint8      `json:"repeat"`
+	RepeatPeriod   string     `json:"repeat_period"`
+	Counter        uint       `json:"counter"`
+	ExecuteDate    time.Time  `json:"execute_date"`
+	Payload        *string    `json:"payload"`
+	CronExpression *string    `json:"cron_expression"`
+	Timezone       *string    `json:"timezone"`
+	CreatedAt      *time.Time `json:"created_at"`
+	UpdatedAt      *time.Time `json:"updated_at"`
 }
 
 func newServerTasksResponseFromServerTasks(tasks []domain.ServerTask) []serverTaskResponse {
@@ -32,15 +34,17 @@ func newServerTasksResponseFromServerTasks(tasks []domain.ServerTask) []serverTa
 
 func newServerTaskResponseFromServerTask(task *domain.ServerTask) serverTaskResponse {
 	return serverTaskResponse{
-		ID:           task.ID,
-		Command:      string(task.Command),
-		ServerID:     task.ServerID,
-		Repeat:       task.Repeat,
-		RepeatPeriod: carbon.Humanize(task.RepeatPeriod),
-		Counter:      task.Counter,
-		ExecuteDate:  task.ExecuteDate,
-		Payload:      task.Payload,
-		CreatedAt:    task.CreatedAt,
-		UpdatedAt:    task.UpdatedAt,
+		ID:             task.ID,
+		Command:        string(task.Command),
+		ServerID:       task.ServerID,
+		Repeat:         task.Repeat,
+		RepeatPeriod:   carbon.Humanize(task.RepeatPeriod),
+		Counter:        task.Counter,
+		ExecuteDate:    task.ExecuteDate,
+		Payload:        task.Payload,
+		CronExpression: task.CronExpression,
+		Timezone:       task.Timezone,
+		CreatedAt:      task.CreatedAt,
+		UpdatedAt:      task.UpdatedAt,
 	}
 }
@@ -112,6 +112,8 @@ func TestHandler_ServeHTTP(t *testing.T) {
 		wantStatus       int
 		wantError        string
 		validateResponse func(t *testing.T, r serverTaskResponse)
+		// schedulerDisabled disables the panel task scheduler
+		schedulerDisabled bool
 	}{
 		{
 			name:       "successful task creation with admin user",
@@ -154,6 +156,120 @@ func TestHandler_ServeHTTP(t *testing.T) {
 				assert.Equal(t, "1 hour", r.RepeatPeriod)
 			},
 		},
+		{
+			name:       "successful task creation with cron expression",
+			setupAuth:  defaultSetupAuth,
+			setupRepos: defaultSetupRepos,
+			requestBody: map[string]any{
+				"command":         "restart",
+				"cron_expression": "0 5 * * 1-6",
+				"timezone":        "Europe/Berlin",
+			},
+			wantStatus: http.StatusCreated,
+			validateResponse: func(t *testing.T, r serverTaskResponse) {
+				t.Helper()
+
+				assert.Equal(t, "restart", r.Command)
+				assert.Equal(t, uint8(0), r.Repeat)
+				require.NotNil(t, r.CronExpression)
+				assert.Equal(t, "0 5 * * 1-6", *r.CronExpression)
+				require.NotNil(t, r.Timezone)
+				assert.Equal(t, "Europe/Berlin", *r.Timezone)
+				assert.True(t, r.ExecuteDate.After(time.Now()))
+
+				berlin, err := time.LoadLocation("Europe/Berlin")
+				require.NoError(t, err)
+				executeDate := r.ExecuteDate.In(berlin)
+				assert.Equal(t, 5, executeDate.Hour())
+				assert.NotEqual(t, time.Sunday, executeDate.Weekday())
+			},
+		},
+		{
+			name:       "successful backup task creation",
+			setupAuth:  defaultSetupAuth,
+			setupRepos: defaultSetupRepos,
+			requestBody: map[string]any{
+				"command":         "backup",
+				"cron_expression": "0 4 * * *",
+			},
+			wantStatus: http.StatusCreated,
+			validateResponse: func(t *testing.T, r serverTaskResponse) {
+				t.Helper()
+
+				assert.Equal(t, "backup", r.Command)
+				assert.Equal(t, uint(1), r.ServerID)
+			},
+		},
+		{
+			name:       "backup task with disabled scheduler",
+			setupAuth:  defaultSetupAuth,
+			setupRepos: defaultSetupRepos,
+			requestBody: map[string]any{
+				"command":         "backup",
+				"cron_expression": "0 4 * * *",
+			},
+			schedulerDisabled: true,
+			wantStatus:        http.StatusUnprocessableEntity,
+			wantError:         ErrSchedulerDisabled.Error(),
+		},
+		{
+			name:       "restart task with disabled scheduler",
+			setupAuth:  defaultSetupAuth,
+			setupRepos: defaultSetupRepos,
+			requestBody: map[string]any{
+				"command":         "restart",
+				"cron_expression": "0 4 * * *",
+			},
+			schedulerDisabled: true,
+			wantStatus:        http.StatusCreated,
+		},
+		{
+			name:       "invalid cron expression",
+			setupAuth:  defaultSetupAuth,
+			setupRepos: defaultSetupRepos,
+			requestBody: map[string]any{
+				"command":         "restart",
+				"cron_expression": "0 5 * *",
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantError:  "invalid cron expression",
+		},
+		{
+			name:       "invalid cron timezone",
+			setupAuth:  defaultSetupAuth,
+			setupRepos: defaultSetupRepos,
+			requestBody: map[string]any{
+				"command":         "restart",
+				"cron_expression": "0 5 * * *",
+				"timezone":        "Mars/Olympus",
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantError:  "invalid time zone",
+		},
+		{
+			name:       "cron runs too often",
+			setupAuth:  defaultSetupAuth,
+			setupRepos: defaultSetupRepos,
+			requestBody: map[string]any{
+				"command":         "restart",
+				"cron_expression": "*/5 * * * *",
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantError:  "validation failed: 10 minutes is minimum interval between cron runs",
+		},
+		{
+			name:       "timezone without cron expression",
+			setupAuth:  defaultSetupAuth,
+			setupRepos: defaultSetupRepos,
+			requestBody: map[string]any{
+				"command":      "restart",
+				"repeat":       1,
+				"execute_date": time.Now().Add(time.Hour).Format(time.RFC3339),
+				"timezone":     "Europe/Berlin",
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantError:  "validation failed: timezone can be set only with cron_expression",
+		},
 		{
 			name: "unauthenticated request",
 			setupRepos: func(
@@ -194,7 +310,7 @@ func TestHandler_ServeHTTP(t *testing.T) {
 				"execute_date": time.Now().Add(time.Hour).Format(time.RFC3339),
 			},
 			wantStatus: http.StatusUnprocessableEntity,
-			wantError:  "validation failed: invalid command, must be one of: start, stop, restart, update, reinstall",
+			wantError:  "validation failed: invalid command, must be one of: start, stop, restart, update, reinstall, backup",
 		},
 		{
 			name:       "invalid repeat value",
@@ -703,7 +819,7 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			// Create handler
 			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
 			responder := api.NewResponder()
-			handler := NewHandler(serverTasksRepo, serversRepo, rbacService, responder)
+			handler := NewHandler(serverTasksRepo, serversRepo, rbacService, !tt.schedulerDisabled, responder)
 
 			// Setup auth context
 			ctx := context.Background()
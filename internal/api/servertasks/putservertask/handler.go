@@ -18,20 +18,24 @@ type Handler struct {
 	serverTasksRepo repositories.ServerTaskRepository
 	serverFinder    *serversbase.ServerFinder
 	abilityChecker  *serversbase.AbilityChecker
-	responder       base.Responder
+	// schedulerEnabled is whether the panel executes the tasks, the backup tasks are executed only by the panel.
+	schedulerEnabled bool
+	responder        base.Responder
 }
 
 func NewHandler(
 	serverTasksRepo repositories.ServerTaskRepository,
 	serversRepo repositories.ServerRepository,
 	rbac base.RBAC,
+	schedulerEnabled bool,
 	responder base.Responder,
 ) *Handler {
 	return &Handler{
-		serverTasksRepo: serverTasksRepo,
-		serverFinder:    serversbase.NewServerFinder(serversRepo, rbac),
-		abilityChecker:  serversbase.NewAbilityChecker(rbac),
-		responder:       responder,
+		serverTasksRepo:  serverTasksRepo,
+		serverFinder:     serversbase.NewServerFinder(serversRepo, rbac),
+		abilityChecker:   serversbase.NewAbilityChecker(rbac),
+		schedulerEnabled: schedulerEnabled,
+		responder:        responder,
 	}
 }
 
@@ -144,6 +148,27 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Scheduled backups are created on behalf of the user
+	if updatedTask.Command == domain.ServerTaskCommandBackup {
+		if !h.schedulerEnabled {
+			h.responder.WriteError(ctx, rw, errors.WithMessage(ErrSchedulerDisabled, "validation failed"))
+
+			return
+		}
+
+		err = h.abilityChecker.CheckOrError(
+			ctx,
+			session.User.ID,
+			server.ID,
+			[]domain.AbilityName{domain.AbilityNameGameServerBackups},
+		)
+		if err != nil {
+			h.responder.WriteError(ctx, rw, err)
+
+			return
+		}
+	}
+
 	updatedTask.ID = taskID
 
 	err = h.serverTasksRepo.Save(ctx, updatedTask)
@@ -151,6 +151,8 @@ func TestHandler_ServeHTTP(t *testing.T) {
 		wantStatus       int
 		wantError        string
 		validateResponse func(t *testing.T, r serverTaskResponse)
+		// schedulerDisabled disables the panel task scheduler
+		schedulerDisabled bool
 	}{
 		{
 			name:       "successful task update with admin user",
@@ -200,6 +202,42 @@ func TestHandler_ServeHTTP(t *testing.T) {
 				assert.Equal(t, uint8(1), r.Repeat)
 			},
 		},
+		{
+			name:       "successful task update - switch to cron expression",
+			setupAuth:  defaultSetupAuth,
+			setupRepos: defaultSetupRepos,
+			taskID:     "1",
+			serverID:   "1",
+			requestBody: map[string]any{
+				"command":         "restart",
+				"cron_expression": "@daily",
+				"timezone":        "UTC",
+			},
+			wantStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, r serverTaskResponse) {
+				t.Helper()
+
+				assert.Equal(t, uint(1), r.ID)
+				require.NotNil(t, r.CronExpression)
+				assert.Equal(t, "@daily", *r.CronExpression)
+				assert.Equal(t, "0 seconds", r.RepeatPeriod)
+				assert.Equal(t, 0, r.ExecuteDate.UTC().Hour())
+				assert.True(t, r.ExecuteDate.After(time.Now()))
+			},
+		},
+		{
+			name:       "invalid cron expression",
+			setupAuth:  defaultSetupAuth,
+			setupRepos: defaultSetupRepos,
+			taskID:     "1",
+			serverID:   "1",
+			requestBody: map[string]any{
+				"command":         "restart",
+				"cron_expression": "every day",
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantError:  "invalid cron expression",
+		},
 		{
 			name: "unauthenticated request",
 			setupRepos: func(
@@ -273,7 +311,21 @@ func TestHandler_ServeHTTP(t *testing.T) {
 				"execute_date": time.Now().Add(time.Hour).Format(time.RFC3339),
 			},
 			wantStatus: http.StatusUnprocessableEntity,
-			wantError:  "validation failed: invalid command, must be one of: start, stop, restart, update, reinstall",
+			wantError:  "validation failed: invalid command, must be one of: start, stop, restart, update, reinstall, backup",
+		},
+		{
+			name:       "backup task with disabled scheduler",
+			setupAuth:  defaultSetupAuth,
+			setupRepos: defaultSetupRepos,
+			taskID:     "1",
+			serverID:   "1",
+			requestBody: map[string]any{
+				"command":         "backup",
+				"cron_expression": "0 4 * * *",
+			},
+			schedulerDisabled: true,
+			wantStatus:        http.StatusUnprocessableEntity,
+			wantError:         ErrSchedulerDisabled.Error(),
 		},
 		{
 			name:       "invalid repeat value",
@@ -907,7 +959,7 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
 			responder := api.NewResponder()
 
-			handler := NewHandler(taskRepo, serverRepo, rbacService, responder)
+			handler := NewHandler(taskRepo, serverRepo, rbacService, !tt.schedulerDisabled, responder)
 
 			body, err := json.Marshal(tt.requestBody)
 			require.NoError(t, err)
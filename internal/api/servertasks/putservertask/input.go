@@ -11,12 +11,13 @@ import (
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/carbon"
 	"github.com/gameap/gameap/pkg/flexible"
+	"github.com/samber/lo"
 )
 
 var (
 	ErrCommandIsRequired = api.NewValidationError("command is required")
 	ErrInvalidCommand    = api.NewValidationError(
-		"invalid command, must be one of: start, stop, restart, update, reinstall",
+		"invalid command, must be one of: start, stop, restart, update, reinstall, backup",
 	)
 	ErrExecuteDateIsRequired = api.NewValidationError("execute_date is required")
 	ErrInvalidRepeat         = api.NewValidationError("repeat must be between 0 and 255")
@@ -26,9 +27,17 @@ var (
 	)
 	ErrRepeatPeriodIsTooShort = api.NewValidationError("10 minutes is minimum repeat period")
 	ErrRepeatPeriodIsTooLong  = api.NewValidationError("repeat period is too long")
+	ErrCronIntervalIsTooShort = api.NewValidationError("10 minutes is minimum interval between cron runs")
+	ErrTimezoneWithoutCron    = api.NewValidationError("timezone can be set only with cron_expression")
+	ErrSchedulerDisabled      = api.NewValidationError(
+		"backup tasks are executed by the panel task scheduler, which is disabled",
+	)
 )
 
-var validCommands = []string{"start", "stop", "restart", "update", "reinstall"}
+// cronIntervalCheckRuns is the number of upcoming cron runs checked for the minimum interval.
+const cronIntervalCheckRuns = 10
+
+var validCommands = []string{"start", "stop", "restart", "update", "reinstall", "backup"}
 var repeatPeriodRegex = regexp.MustCompile(`^\d+\s\w+$`)
 
 type serverTaskInput struct {
@@ -37,6 +46,9 @@ type serverTaskInput struct {
 	RepeatPeriod *string        `json:"repeat_period,omitempty"`
 	ExecuteDate  *flexible.Time `json:"execute_date"`
 	Payload      *string        `json:"payload,omitempty"`
+
+	CronExpression *string `json:"cron_expression,omitempty"`
+	Timezone       *string `json:"timezone,omitempty"`
 }
 
 func (s *serverTaskInput) Validate() error {
@@ -48,6 +60,14 @@ func (s *serverTaskInput) Validate() error {
 		return ErrInvalidCommand
 	}
 
+	if s.isCron() {
+		return s.validateCron()
+	}
+
+	if s.Timezone != nil && *s.Timezone != "" {
+		return ErrTimezoneWithoutCron
+	}
+
 	if s.ExecuteDate == nil {
 		return ErrExecuteDateIsRequired
 	}
@@ -71,20 +91,81 @@ func (s *serverTaskInput) Validate() error {
 	return nil
 }
 
+func (s *serverTaskInput) isCron() bool {
+	return s.CronExpression != nil && *s.CronExpression != ""
+}
+
+func (s *serverTaskInput) validateCron() error {
+	if s.Repeat != nil && (*s.Repeat > 255 || *s.Repeat < 0) {
+		return ErrInvalidRepeat
+	}
+
+	schedule, err := domain.ParseServerTaskSchedule(*s.CronExpression, lo.FromPtr(s.Timezone))
+	if err != nil {
+		return api.NewValidationError(err.Error())
+	}
+
+	prev := schedule.Next(time.Now())
+	for range cronIntervalCheckRuns {
+		next := schedule.Next(prev)
+		if next.IsZero() {
+			break
+		}
+
+		if next.Sub(prev) < 10*time.Minute {
+			return ErrCronIntervalIsTooShort
+		}
+
+		prev = next
+	}
+
+	return nil
+}
+
+func (s *serverTaskInput) cronToDomain(task *domain.ServerTask) (*domain.ServerTask, error) {
+	task.CronExpression = s.CronExpression
+	task.Timezone = lo.EmptyableToPtr(lo.FromPtr(s.Timezone))
+
+	// Cron tasks are repeated endlessly unless the number of runs is limited explicitly.
+	task.Repeat = 0
+	if s.Repeat != nil {
+		task.Repeat = uint8(*s.Repeat) //nolint:gosec // overflow validation above
+	}
+
+	schedule, err := task.Schedule()
+	if err != nil {
+		return nil, api.NewValidationError(err.Error())
+	}
+
+	after := time.Now()
+	if s.ExecuteDate != nil && s.ExecuteDate.After(after) {
+		after = s.ExecuteDate.Time
+	}
+
+	task.ExecuteDate = schedule.Next(after)
+
+	return task, nil
+}
+
 func (s *serverTaskInput) ToDomain(serverID uint, existingTask *domain.ServerTask) (*domain.ServerTask, error) {
 	var err error
 
 	task := &domain.ServerTask{
-		ID:          existingTask.ID,
-		Command:     domain.NewServerTaskCommandFromString(s.Command),
-		ServerID:    serverID,
-		ExecuteDate: s.ExecuteDate.Time,
-		Payload:     s.Payload,
-		Counter:     existingTask.Counter,
-		CreatedAt:   existingTask.CreatedAt,
-		UpdatedAt:   existingTask.UpdatedAt,
+		ID:        existingTask.ID,
+		Command:   domain.NewServerTaskCommandFromString(s.Command),
+		ServerID:  serverID,
+		Payload:   s.Payload,
+		Counter:   existingTask.Counter,
+		CreatedAt: existingTask.CreatedAt,
+		UpdatedAt: existingTask.UpdatedAt,
+	}
+
+	if s.isCron() {
+		return s.cronToDomain(task)
 	}
 
+	task.ExecuteDate = s.ExecuteDate.Time
+
 	if s.Repeat != nil && *s.Repeat < math.MaxUint8 {
 		task.Repeat = uint8(*s.Repeat) //nolint:gosec // overflow validation above
 	}
@@ -0,0 +1,63 @@
+package deleteservertemplate
+
+import (
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+type Handler struct {
+	templateRepo repositories.ServerTemplateRepository
+	responder    base.Responder
+}
+
+func NewHandler(
+	templateRepo repositories.ServerTemplateRepository,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		templateRepo: templateRepo,
+		responder:    responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := api.NewInputReader(r).ReadUint("id")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid server template id"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	templates, err := h.templateRepo.Find(ctx, &filters.FindServerTemplate{
+		IDs: []uint{id},
+	}, nil, nil)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to find server template"))
+
+		return
+	}
+
+	if len(templates) == 0 {
+		h.responder.WriteError(ctx, rw, api.NewNotFoundError("server template not found"))
+
+		return
+	}
+
+	if err = h.templateRepo.Delete(ctx, id); err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to delete server template"))
+
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,70 @@
+package deleteservertemplate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name          string
+		templateID    string
+		wantStatus    int
+		wantTemplates int
+	}{
+		{
+			name:          "template deleted",
+			templateID:    "1",
+			wantStatus:    http.StatusNoContent,
+			wantTemplates: 0,
+		},
+		{
+			name:          "template not found",
+			templateID:    "999",
+			wantStatus:    http.StatusNotFound,
+			wantTemplates: 1,
+		},
+		{
+			name:          "invalid template id",
+			templateID:    "invalid",
+			wantStatus:    http.StatusBadRequest,
+			wantTemplates: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			templateRepo := inmemory.NewServerTemplateRepository()
+			require.NoError(t, templateRepo.Save(ctx, &domain.ServerTemplate{
+				Name:       "CS public",
+				GameID:     "cstrike",
+				ServerPort: 27015,
+			}))
+
+			handler := NewHandler(templateRepo, api.NewResponder())
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/server_templates/"+tt.templateID, nil)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.templateID})
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code, rr.Body.String())
+
+			templates, err := templateRepo.Find(ctx, nil, nil, nil)
+			require.NoError(t, err)
+			assert.Len(t, templates, tt.wantTemplates)
+		})
+	}
+}
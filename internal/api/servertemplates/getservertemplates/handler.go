@@ -0,0 +1,48 @@
+package getservertemplates
+
+import (
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/pkg/errors"
+)
+
+type Handler struct {
+	templateRepo repositories.ServerTemplateRepository
+	responder    base.Responder
+}
+
+func NewHandler(
+	templateRepo repositories.ServerTemplateRepository,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		templateRepo: templateRepo,
+		responder:    responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter := &filters.FindServerTemplate{}
+	if gameID := r.URL.Query().Get("game_id"); gameID != "" {
+		filter.GameIDs = []string{gameID}
+	}
+
+	templates, err := h.templateRepo.Find(ctx, filter, []filters.Sorting{
+		{
+			Field:     "name",
+			Direction: filters.SortDirectionAsc,
+		},
+	}, nil)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to find server templates"))
+
+		return
+	}
+
+	h.responder.Write(ctx, rw, newServerTemplatesResponse(templates))
+}
@@ -0,0 +1,77 @@
+package getservertemplates
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		wantNames []string
+	}{
+		{
+			name:      "all templates ordered by name",
+			wantNames: []string{"CS public", "CS war", "Rust"},
+		},
+		{
+			name:      "templates of game",
+			query:     "?game_id=rust",
+			wantNames: []string{"Rust"},
+		},
+		{
+			name:      "no templates of game",
+			query:     "?game_id=minecraft",
+			wantNames: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			templateRepo := inmemory.NewServerTemplateRepository()
+			require.NoError(t, templateRepo.Save(ctx, &domain.ServerTemplate{
+				Name:       "Rust",
+				GameID:     "rust",
+				ServerPort: 28015,
+			}))
+			require.NoError(t, templateRepo.Save(ctx, &domain.ServerTemplate{
+				Name:       "CS war",
+				GameID:     "cstrike",
+				ServerPort: 27025,
+			}))
+			require.NoError(t, templateRepo.Save(ctx, &domain.ServerTemplate{
+				Name:       "CS public",
+				GameID:     "cstrike",
+				ServerPort: 27015,
+				Settings:   domain.ServerTemplateSettings{"autostart": true},
+			}))
+
+			handler := NewHandler(templateRepo, api.NewResponder())
+
+			req := httptest.NewRequest(http.MethodGet, "/api/server_templates"+tt.query, nil)
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+			var response []serverTemplateResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			assert.Equal(t, tt.wantNames, lo.Map(response, func(tpl serverTemplateResponse, _ int) string {
+				return tpl.Name
+			}))
+		})
+	}
+}
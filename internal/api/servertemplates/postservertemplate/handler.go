@@ -0,0 +1,85 @@
+package postservertemplate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+type templateCreator interface {
+	CreateTemplate(
+		ctx context.Context,
+		server *domain.Server,
+		name string,
+		description *string,
+	) (*domain.ServerTemplate, error)
+}
+
+type Handler struct {
+	serverRepo      repositories.ServerRepository
+	templateCreator templateCreator
+	responder       base.Responder
+}
+
+func NewHandler(
+	serverRepo repositories.ServerRepository,
+	templateCreator templateCreator,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		serverRepo:      serverRepo,
+		templateCreator: templateCreator,
+		responder:       responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	input := &serverTemplateInput{}
+	err := json.NewDecoder(r.Body).Decode(input)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid request body"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if err = input.Validate(); err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "validation failed"))
+
+		return
+	}
+
+	servers, err := h.serverRepo.Find(ctx, filters.FindServerByIDs(input.ServerID.Uint()), nil, nil)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to find server"))
+
+		return
+	}
+
+	if len(servers) == 0 {
+		h.responder.WriteError(ctx, rw, api.NewNotFoundError("server not found"))
+
+		return
+	}
+
+	template, err := h.templateCreator.CreateTemplate(ctx, &servers[0], input.Name, input.Description)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to create server template"))
+
+		return
+	}
+
+	rw.WriteHeader(http.StatusCreated)
+	h.responder.Write(ctx, rw, newServerTemplateResponse(template))
+}
@@ -0,0 +1,142 @@
+package postservertemplate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/events"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/serverclone"
+	"github.com/gameap/gameap/internal/services/serverports"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name        string
+		requestBody string
+		wantStatus  int
+		wantError   string
+	}{
+		{
+			name:        "template created",
+			requestBody: `{"server_id": 1, "name": "CS template", "description": "Public server"}`,
+			wantStatus:  http.StatusCreated,
+		},
+		{
+			name:        "invalid request body",
+			requestBody: `{invalid`,
+			wantStatus:  http.StatusBadRequest,
+			wantError:   "invalid request body",
+		},
+		{
+			name:        "server_id is required",
+			requestBody: `{"name": "CS template"}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantError:   "server_id is required",
+		},
+		{
+			name:        "name is required",
+			requestBody: `{"server_id": 1}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantError:   "name is required",
+		},
+		{
+			name:        "server not found",
+			requestBody: `{"server_id": 999, "name": "CS template"}`,
+			wantStatus:  http.StatusNotFound,
+			wantError:   "server not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			serverRepo := inmemory.NewServerRepository()
+			serverSettingRepo := inmemory.NewServerSettingRepository()
+			templateRepo := inmemory.NewServerTemplateRepository()
+
+			require.NoError(t, serverRepo.Save(ctx, &domain.Server{
+				ID:           1,
+				UUID:         uuid.New(),
+				GameID:       "cstrike",
+				GameModID:    3,
+				DSID:         1,
+				ServerIP:     "10.0.0.1",
+				ServerPort:   27015,
+				StartCommand: lo.ToPtr("./hlds_run"),
+				Dir:          "servers/cs",
+			}))
+			require.NoError(t, serverSettingRepo.Save(ctx, &domain.ServerSetting{
+				Name:     "autostart",
+				ServerID: 1,
+				Value:    domain.NewServerSettingValue(true),
+			}))
+
+			creator := serverclone.NewService(
+				serverRepo,
+				serverSettingRepo,
+				templateRepo,
+				inmemory.NewNodeRepository(),
+				inmemory.NewDaemonTaskRepository(),
+				nil,
+				serverports.NewService(
+					serverRepo,
+					cache.NewInMemory(),
+					services.NewNilTransactionManager(),
+					domain.PortRange{Start: 27015, End: 27999},
+					time.Second,
+				),
+				events.NewBus(),
+			)
+			handler := NewHandler(serverRepo, creator, api.NewResponder())
+
+			req := httptest.NewRequest(http.MethodPost, "/api/server_templates", strings.NewReader(tt.requestBody))
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code, rr.Body.String())
+
+			if tt.wantError != "" {
+				var errorResponse map[string]any
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorResponse))
+				errorMsg, ok := errorResponse["error"].(string)
+				if !ok {
+					errorMsg, _ = errorResponse["message"].(string)
+				}
+				assert.Contains(t, errorMsg, tt.wantError)
+
+				return
+			}
+
+			var response serverTemplateResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			assert.NotZero(t, response.ID)
+			assert.Equal(t, "CS template", response.Name)
+			assert.Equal(t, lo.ToPtr("Public server"), response.Description)
+			assert.Equal(t, "cstrike", response.GameID)
+			assert.Equal(t, lo.ToPtr("./hlds_run"), response.StartCommand)
+			assert.Equal(t, domain.ServerTemplateSettings{"autostart": true}, response.Settings)
+
+			templates, err := templateRepo.Find(ctx, &filters.FindServerTemplate{
+				IDs: []uint{response.ID},
+			}, nil, nil)
+			require.NoError(t, err)
+			assert.Len(t, templates, 1)
+		})
+	}
+}
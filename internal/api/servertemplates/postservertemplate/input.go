@@ -0,0 +1,36 @@
+package postservertemplate
+
+import (
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/flexible"
+)
+
+const maxNameLength = 128
+
+var (
+	ErrServerIDIsRequired = api.NewValidationError("server_id is required")
+	ErrNameIsRequired     = api.NewValidationError("name is required")
+	ErrNameTooLong        = api.NewValidationError("name must not exceed 128 characters")
+)
+
+type serverTemplateInput struct {
+	ServerID    flexible.Uint `json:"server_id"`
+	Name        string        `json:"name"`
+	Description *string       `json:"description,omitempty"`
+}
+
+func (in *serverTemplateInput) Validate() error {
+	if in.ServerID.Uint() == 0 {
+		return ErrServerIDIsRequired
+	}
+
+	if in.Name == "" {
+		return ErrNameIsRequired
+	}
+
+	if len(in.Name) > maxNameLength {
+		return ErrNameTooLong
+	}
+
+	return nil
+}
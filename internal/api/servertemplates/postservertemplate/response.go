@@ -0,0 +1,55 @@
+package postservertemplate
+
+import (
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+)
+
+type serverTemplateResponse struct {
+	ID               uint                          `json:"id"`
+	Name             string                        `json:"name"`
+	Description      *string                       `json:"description"`
+	GameID           string                        `json:"game_id"`
+	GameModID        uint                          `json:"game_mod_id"`
+	ServerPort       int                           `json:"server_port"`
+	QueryPort        *int                          `json:"query_port"`
+	RconPort         *int                          `json:"rcon_port"`
+	SuUser           *string                       `json:"su_user"`
+	CPULimit         *int                          `json:"cpu_limit"`
+	RAMLimit         *int                          `json:"ram_limit"`
+	NetLimit         *int                          `json:"net_limit"`
+	StartCommand     *string                       `json:"start_command"`
+	StopCommand      *string                       `json:"stop_command"`
+	ForceStopCommand *string                       `json:"force_stop_command"`
+	RestartCommand   *string                       `json:"restart_command"`
+	Vars             *string                       `json:"vars"`
+	Settings         domain.ServerTemplateSettings `json:"settings"`
+	CreatedAt        *time.Time                    `json:"created_at"`
+	UpdatedAt        *time.Time                    `json:"updated_at"`
+}
+
+func newServerTemplateResponse(template *domain.ServerTemplate) serverTemplateResponse {
+	return serverTemplateResponse{
+		ID:               template.ID,
+		Name:             template.Name,
+		Description:      template.Description,
+		GameID:           template.GameID,
+		GameModID:        template.GameModID,
+		ServerPort:       template.ServerPort,
+		QueryPort:        template.QueryPort,
+		RconPort:         template.RconPort,
+		SuUser:           template.SuUser,
+		CPULimit:         template.CPULimit,
+		RAMLimit:         template.RAMLimit,
+		NetLimit:         template.NetLimit,
+		StartCommand:     template.StartCommand,
+		StopCommand:      template.StopCommand,
+		ForceStopCommand: template.ForceStopCommand,
+		RestartCommand:   template.RestartCommand,
+		Vars:             template.Vars,
+		Settings:         template.Settings,
+		CreatedAt:        template.CreatedAt,
+		UpdatedAt:        template.UpdatedAt,
+	}
+}
@@ -0,0 +1,97 @@
+package postservertemplateserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/serverclone"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+type serverCreator interface {
+	CreateFromTemplate(
+		ctx context.Context,
+		template *domain.ServerTemplate,
+		opts serverclone.Options,
+	) (*serverclone.Result, error)
+}
+
+type Handler struct {
+	templateRepo  repositories.ServerTemplateRepository
+	serverCreator serverCreator
+	responder     base.Responder
+}
+
+func NewHandler(
+	templateRepo repositories.ServerTemplateRepository,
+	serverCreator serverCreator,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		templateRepo:  templateRepo,
+		serverCreator: serverCreator,
+		responder:     responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := api.NewInputReader(r).ReadUint("id")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid server template id"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	templates, err := h.templateRepo.Find(ctx, &filters.FindServerTemplate{
+		IDs: []uint{id},
+	}, nil, nil)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to find server template"))
+
+		return
+	}
+
+	if len(templates) == 0 {
+		h.responder.WriteError(ctx, rw, api.NewNotFoundError("server template not found"))
+
+		return
+	}
+
+	input := &templateServerInput{}
+	err = json.NewDecoder(r.Body).Decode(input)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid request body"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if err = input.Validate(); err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "validation failed"))
+
+		return
+	}
+
+	result, err := h.serverCreator.CreateFromTemplate(ctx, &templates[0], input.ToOptions())
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to create server from template"))
+
+		return
+	}
+
+	rw.WriteHeader(http.StatusCreated)
+	h.responder.Write(ctx, rw, newCreateServerResponse(result.Server.ID, result.TaskID))
+}
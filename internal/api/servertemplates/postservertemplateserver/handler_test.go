@@ -0,0 +1,173 @@
+package postservertemplateserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/events"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/serverclone"
+	"github.com/gameap/gameap/internal/services/serverports"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name        string
+		templateID  string
+		requestBody string
+		wantStatus  int
+		wantError   string
+		wantPort    int
+		wantInstall bool
+	}{
+		{
+			name:        "server created with shifted ports",
+			templateID:  "1",
+			requestBody: `{"name": "CS #2", "ds_id": 1}`,
+			wantStatus:  http.StatusCreated,
+			wantPort:    27016,
+		},
+		{
+			name:        "server created and installed",
+			templateID:  "1",
+			requestBody: `{"name": "CS #2", "ds_id": 1, "server_ip": "10.0.0.2", "install": true}`,
+			wantStatus:  http.StatusCreated,
+			wantPort:    27015,
+			wantInstall: true,
+		},
+		{
+			name:        "invalid template id",
+			templateID:  "invalid",
+			requestBody: `{}`,
+			wantStatus:  http.StatusBadRequest,
+			wantError:   "invalid server template id",
+		},
+		{
+			name:        "template not found",
+			templateID:  "999",
+			requestBody: `{"name": "CS #2", "ds_id": 1}`,
+			wantStatus:  http.StatusNotFound,
+			wantError:   "server template not found",
+		},
+		{
+			name:        "ds_id is required",
+			templateID:  "1",
+			requestBody: `{"name": "CS #2"}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantError:   "ds_id is required",
+		},
+		{
+			name:        "ip is not assigned to node",
+			templateID:  "1",
+			requestBody: `{"name": "CS #2", "ds_id": 1, "server_ip": "10.0.0.9"}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantError:   "server_ip is not assigned to the node",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			serverRepo := inmemory.NewServerRepository()
+			nodeRepo := inmemory.NewNodeRepository()
+			templateRepo := inmemory.NewServerTemplateRepository()
+			daemonTaskRepo := inmemory.NewDaemonTaskRepository()
+
+			require.NoError(t, nodeRepo.Save(ctx, &domain.Node{
+				ID:      1,
+				Enabled: true,
+				IPs:     domain.IPList{"10.0.0.1", "10.0.0.2"},
+			}))
+			require.NoError(t, serverRepo.Save(ctx, &domain.Server{
+				ID:         1,
+				UUID:       uuid.New(),
+				DSID:       1,
+				ServerIP:   "10.0.0.1",
+				ServerPort: 27015,
+				Dir:        "servers/cs",
+			}))
+			require.NoError(t, templateRepo.Save(ctx, &domain.ServerTemplate{
+				Name:         "CS public",
+				GameID:       "cstrike",
+				GameModID:    3,
+				ServerPort:   27015,
+				StartCommand: lo.ToPtr("./hlds_run"),
+			}))
+
+			creator := serverclone.NewService(
+				serverRepo,
+				inmemory.NewServerSettingRepository(),
+				templateRepo,
+				nodeRepo,
+				daemonTaskRepo,
+				nil,
+				serverports.NewService(
+					serverRepo,
+					cache.NewInMemory(),
+					services.NewNilTransactionManager(),
+					domain.PortRange{Start: 27015, End: 27999},
+					time.Second,
+				),
+				events.NewBus(),
+			)
+			handler := NewHandler(templateRepo, creator, api.NewResponder())
+
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"/api/server_templates/"+tt.templateID+"/servers",
+				strings.NewReader(tt.requestBody),
+			)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.templateID})
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code, rr.Body.String())
+
+			if tt.wantError != "" {
+				var errorResponse map[string]any
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorResponse))
+				errorMsg, ok := errorResponse["error"].(string)
+				if !ok {
+					errorMsg, _ = errorResponse["message"].(string)
+				}
+				assert.Contains(t, errorMsg, tt.wantError)
+
+				return
+			}
+
+			var response createServerResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+
+			servers, err := serverRepo.Find(ctx, filters.FindServerByIDs(response.Result.ServerID), nil, nil)
+			require.NoError(t, err)
+			require.Len(t, servers, 1)
+			assert.Equal(t, "CS #2", servers[0].Name)
+			assert.Equal(t, "cstrike", servers[0].GameID)
+			assert.Equal(t, lo.ToPtr("./hlds_run"), servers[0].StartCommand)
+			assert.Equal(t, tt.wantPort, servers[0].ServerPort)
+
+			if tt.wantInstall {
+				assert.NotZero(t, response.Result.TaskID)
+			} else {
+				assert.Zero(t, response.Result.TaskID)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package postservertemplateserver
+
+import (
+	"github.com/gameap/gameap/internal/services/serverclone"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/flexible"
+	"github.com/gameap/gameap/pkg/validation"
+)
+
+const maxNameLength = 128
+
+var (
+	ErrNameIsRequired  = api.NewValidationError("name is required")
+	ErrNameTooLong     = api.NewValidationError("name must not exceed 128 characters")
+	ErrDSIDIsRequired  = api.NewValidationError("ds_id is required")
+	ErrInvalidServerIP = api.NewValidationError("server_ip is not a valid IP address or hostname")
+)
+
+type templateServerInput struct {
+	Name     string         `json:"name"`
+	DSID     flexible.Int   `json:"ds_id"`
+	ServerIP string         `json:"server_ip,omitempty"`
+	Dir      *string        `json:"dir,omitempty"`
+	Install  *flexible.Bool `json:"install,omitempty"`
+}
+
+func (in *templateServerInput) Validate() error {
+	if in.Name == "" {
+		return ErrNameIsRequired
+	}
+
+	if len(in.Name) > maxNameLength {
+		return ErrNameTooLong
+	}
+
+	if in.DSID.Int() <= 0 {
+		return ErrDSIDIsRequired
+	}
+
+	if in.ServerIP != "" && !validation.IsValidIPOrHostname(in.ServerIP) {
+		return ErrInvalidServerIP
+	}
+
+	return nil
+}
+
+func (in *templateServerInput) ToOptions() serverclone.Options {
+	opts := serverclone.Options{
+		Name:     in.Name,
+		NodeID:   uint(in.DSID.Int()), //nolint:gosec // We check it in Validate
+		ServerIP: in.ServerIP,
+		Install:  in.Install != nil && in.Install.Bool(),
+	}
+
+	if in.Dir != nil {
+		opts.Dir = *in.Dir
+	}
+
+	return opts
+}
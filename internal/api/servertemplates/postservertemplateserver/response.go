@@ -0,0 +1,21 @@
+package postservertemplateserver
+
+type createServerResult struct {
+	TaskID   uint `json:"taskId"`
+	ServerID uint `json:"serverId"`
+}
+
+type createServerResponse struct {
+	Message string             `json:"message"`
+	Result  createServerResult `json:"result"`
+}
+
+func newCreateServerResponse(serverID, taskID uint) *createServerResponse {
+	return &createServerResponse{
+		Message: "success",
+		Result: createServerResult{
+			TaskID:   taskID,
+			ServerID: serverID,
+		},
+	}
+}
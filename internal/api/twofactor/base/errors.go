@@ -0,0 +1,36 @@
+package base
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gameap/gameap/internal/api/base"
+	"github.com/gameap/gameap/internal/services/twofactor"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+// WriteError writes the two-factor service error with the matching status code.
+func WriteError(ctx context.Context, rw http.ResponseWriter, responder base.Responder, err error, message string) {
+	var tooMany *twofactor.TooManyAttemptsError
+
+	switch {
+	case errors.As(err, &tooMany):
+		rw.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(tooMany.RetryAfter.Seconds()))))
+		responder.WriteError(ctx, rw, api.WrapHTTPError(err, http.StatusTooManyRequests))
+	case errors.Is(err, twofactor.ErrInvalidCode),
+		errors.Is(err, twofactor.ErrNotEnrolling),
+		errors.Is(err, twofactor.ErrNotEnabled):
+		responder.WriteError(ctx, rw, api.WrapHTTPError(err, http.StatusUnprocessableEntity))
+	case errors.Is(err, twofactor.ErrInvalidChallenge):
+		responder.WriteError(ctx, rw, api.WrapHTTPError(err, http.StatusUnauthorized))
+	case errors.Is(err, twofactor.ErrAlreadyEnabled):
+		responder.WriteError(ctx, rw, api.WrapHTTPError(err, http.StatusConflict))
+	case errors.Is(err, twofactor.ErrRequired):
+		responder.WriteError(ctx, rw, api.WrapHTTPError(err, http.StatusForbidden))
+	default:
+		responder.WriteError(ctx, rw, errors.WithMessage(err, message))
+	}
+}
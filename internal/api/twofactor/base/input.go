@@ -0,0 +1,24 @@
+package base
+
+import (
+	"strings"
+
+	"github.com/gameap/gameap/pkg/api"
+)
+
+var ErrCodeRequired = api.NewValidationError("code is required")
+
+// CodeInput is the TOTP code or a recovery code confirming a two-factor action.
+type CodeInput struct {
+	Code string `json:"code"`
+}
+
+func (in *CodeInput) Validate() error {
+	in.Code = strings.TrimSpace(in.Code)
+
+	if in.Code == "" {
+		return ErrCodeRequired
+	}
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package base
+
+import "github.com/gameap/gameap/internal/services/twofactor"
+
+type EnrollmentResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+func NewEnrollmentResponse(enrollment *twofactor.Enrollment) EnrollmentResponse {
+	return EnrollmentResponse{
+		Secret: enrollment.Secret,
+		URI:    enrollment.URI,
+	}
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func NewRecoveryCodesResponse(codes []string) RecoveryCodesResponse {
+	return RecoveryCodesResponse{
+		RecoveryCodes: codes,
+	}
+}
@@ -0,0 +1,79 @@
+package confirmenrollment
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	twofactorbase "github.com/gameap/gameap/internal/api/twofactor/base"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+)
+
+type enrollmentConfirmer interface {
+	ConfirmEnrollment(ctx context.Context, userID uint, code string) ([]string, error)
+}
+
+type Handler struct {
+	service   enrollmentConfirmer
+	responder base.Responder
+}
+
+func NewHandler(service enrollmentConfirmer, responder base.Responder) *Handler {
+	return &Handler{
+		service:   service,
+		responder: responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	if session.IsTokenSession() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("token sessions cannot manage two-factor authentication"),
+			http.StatusForbidden,
+		))
+
+		return
+	}
+
+	input := &twofactorbase.CodeInput{}
+
+	err := json.NewDecoder(r.Body).Decode(input)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid request body"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if err = input.Validate(); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	codes, err := h.service.ConfirmEnrollment(ctx, session.User.ID, input.Code)
+	if err != nil {
+		twofactorbase.WriteError(ctx, rw, h.responder, err, "failed to confirm two-factor enrollment")
+
+		return
+	}
+
+	h.responder.Write(ctx, rw, twofactorbase.NewRecoveryCodesResponse(codes))
+}
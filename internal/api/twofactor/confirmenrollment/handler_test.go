@@ -0,0 +1,142 @@
+package confirmenrollment
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/twofactor"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUser = domain.User{ID: 1, Login: "user", Email: "user@example.com"}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name            string
+		user            *domain.User
+		startEnrollment bool
+		code            func(secret string) string
+		wantStatus      int
+		wantError       string
+	}{
+		{
+			name:            "enrollment_is_confirmed",
+			user:            &testUser,
+			startEnrollment: true,
+			code: func(secret string) string {
+				code, _ := twofactor.Code(secret, time.Now())
+
+				return code
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:            "invalid_code",
+			user:            &testUser,
+			startEnrollment: true,
+			code: func(secret string) string {
+				code, _ := twofactor.Code(secret, time.Now().Add(-time.Hour))
+
+				return code
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantError:  "two-factor code is invalid",
+		},
+		{
+			name:       "enrollment_not_started",
+			user:       &testUser,
+			code:       func(_ string) string { return "123456" },
+			wantStatus: http.StatusUnprocessableEntity,
+			wantError:  "two-factor enrollment is not started",
+		},
+		{
+			name:            "code_is_required",
+			user:            &testUser,
+			startEnrollment: true,
+			code:            func(_ string) string { return " " },
+			wantStatus:      http.StatusUnprocessableEntity,
+			wantError:       "code is required",
+		},
+		{
+			name:       "user_not_authenticated",
+			code:       func(_ string) string { return "123456" },
+			wantStatus: http.StatusUnauthorized,
+			wantError:  "user not authenticated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			service := newService()
+			handler := NewHandler(service, api.NewResponder())
+
+			var secret string
+			if tt.startEnrollment {
+				enrollment, err := service.StartEnrollment(ctx, &testUser)
+				require.NoError(t, err)
+
+				secret = enrollment.Secret
+			}
+
+			if tt.user != nil {
+				ctx = auth.ContextWithSession(ctx, &auth.Session{
+					Login: tt.user.Login,
+					Email: tt.user.Email,
+					User:  tt.user,
+				})
+			}
+
+			body := `{"code": "` + tt.code(secret) + `"}`
+			req := httptest.NewRequest(http.MethodPost, "/api/profile/two-factor/confirm", strings.NewReader(body))
+			req = req.WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+
+			if tt.wantError != "" {
+				assert.Contains(t, w.Body.String(), tt.wantError)
+
+				return
+			}
+
+			var response struct {
+				RecoveryCodes []string `json:"recovery_codes"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Len(t, response.RecoveryCodes, 8)
+
+			status, err := service.Status(ctx, testUser.ID)
+			require.NoError(t, err)
+			assert.True(t, status.Enabled)
+		})
+	}
+}
+
+func newService() *twofactor.Service {
+	c := cache.NewInMemory()
+
+	return twofactor.NewService(
+		inmemory.NewUserTwoFactorRepository(),
+		inmemory.NewUserRepository(),
+		rbac.NewRBAC(services.NewNilTransactionManager(), inmemory.NewRBACRepository(), 0),
+		c,
+		cache.NewRateLimiter(c, "two_factor", 5, time.Minute),
+		twofactor.Config{Issuer: "GameAP", ChallengeTTL: 5 * time.Minute},
+	)
+}
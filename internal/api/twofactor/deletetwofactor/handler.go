@@ -0,0 +1,75 @@
+package deletetwofactor
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	twofactorbase "github.com/gameap/gameap/internal/api/twofactor/base"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+)
+
+type twoFactorDisabler interface {
+	Disable(ctx context.Context, userID uint, code string) error
+}
+
+type Handler struct {
+	service   twoFactorDisabler
+	responder base.Responder
+}
+
+func NewHandler(service twoFactorDisabler, responder base.Responder) *Handler {
+	return &Handler{
+		service:   service,
+		responder: responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	if session.IsTokenSession() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("token sessions cannot manage two-factor authentication"),
+			http.StatusForbidden,
+		))
+
+		return
+	}
+
+	input := &twofactorbase.CodeInput{}
+
+	// The body is optional, an unconfirmed enrollment is cancelled without the code
+	err := json.NewDecoder(r.Body).Decode(input)
+	if err != nil && !errors.Is(err, io.EOF) {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid request body"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	err = h.service.Disable(ctx, session.User.ID, input.Code)
+	if err != nil {
+		twofactorbase.WriteError(ctx, rw, h.responder, err, "failed to disable two-factor authentication")
+
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,157 @@
+package deletetwofactor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services/twofactor"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testUser  = domain.User{ID: 1, Login: "user", Email: "user@example.com"}
+	testAdmin = domain.User{ID: 2, Login: "admin", Email: "admin@example.com"}
+)
+
+type fakeRBAC struct{}
+
+func (fakeRBAC) Can(_ context.Context, userID uint, _ []domain.AbilityName) (bool, error) {
+	return userID == testAdmin.ID, nil
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name          string
+		user          *domain.User
+		enrollment    string // "", "pending" or "enabled"
+		body          func(recoveryCodes []string) string
+		wantStatus    int
+		wantError     string
+		wantTwoFactor bool
+	}{
+		{
+			name:       "disabled_with_recovery_code",
+			user:       &testUser,
+			enrollment: "enabled",
+			body: func(recoveryCodes []string) string {
+				return `{"code": "` + recoveryCodes[0] + `"}`
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:          "invalid_code",
+			user:          &testUser,
+			enrollment:    "enabled",
+			body:          func(_ []string) string { return `{"code": "aaaaa-bbbbb"}` },
+			wantStatus:    http.StatusUnprocessableEntity,
+			wantError:     "two-factor code is invalid",
+			wantTwoFactor: true,
+		},
+		{
+			name:          "code_is_required_when_enabled",
+			user:          &testUser,
+			enrollment:    "enabled",
+			body:          func(_ []string) string { return "" },
+			wantStatus:    http.StatusUnprocessableEntity,
+			wantError:     "two-factor code is invalid",
+			wantTwoFactor: true,
+		},
+		{
+			name:       "pending_enrollment_is_cancelled",
+			user:       &testUser,
+			enrollment: "pending",
+			body:       func(_ []string) string { return "" },
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "not_enabled",
+			user:       &testUser,
+			body:       func(_ []string) string { return `{"code": "123456"}` },
+			wantStatus: http.StatusUnprocessableEntity,
+			wantError:  "two-factor authentication is not enabled",
+		},
+		{
+			name:       "required_for_admin",
+			user:       &testAdmin,
+			enrollment: "enabled",
+			body: func(recoveryCodes []string) string {
+				return `{"code": "` + recoveryCodes[0] + `"}`
+			},
+			wantStatus:    http.StatusForbidden,
+			wantError:     "two-factor authentication is required for administrators",
+			wantTwoFactor: true,
+		},
+		{
+			name:       "user_not_authenticated",
+			body:       func(_ []string) string { return `{"code": "123456"}` },
+			wantStatus: http.StatusUnauthorized,
+			wantError:  "user not authenticated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := inmemory.NewUserTwoFactorRepository()
+			c := cache.NewInMemory()
+			service := twofactor.NewService(
+				repo,
+				inmemory.NewUserRepository(),
+				fakeRBAC{},
+				c,
+				cache.NewRateLimiter(c, "two_factor", 5, time.Minute),
+				twofactor.Config{Issuer: "GameAP", EnforceAdmins: true, ChallengeTTL: 5 * time.Minute},
+			)
+			handler := NewHandler(service, api.NewResponder())
+
+			var recoveryCodes []string
+
+			if tt.enrollment != "" && tt.user != nil {
+				enrollment, err := service.StartEnrollment(ctx, tt.user)
+				require.NoError(t, err)
+
+				if tt.enrollment == "enabled" {
+					code, err := twofactor.Code(enrollment.Secret, time.Now())
+					require.NoError(t, err)
+
+					recoveryCodes, err = service.ConfirmEnrollment(ctx, tt.user.ID, code)
+					require.NoError(t, err)
+				}
+			}
+
+			if tt.user != nil {
+				ctx = auth.ContextWithSession(ctx, &auth.Session{
+					Login: tt.user.Login,
+					Email: tt.user.Email,
+					User:  tt.user,
+				})
+			}
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/profile/two-factor", strings.NewReader(tt.body(recoveryCodes)))
+			req = req.WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+
+			if tt.wantError != "" {
+				assert.Contains(t, w.Body.String(), tt.wantError)
+			}
+
+			twoFactors, err := repo.Find(ctx, nil, nil, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTwoFactor, len(twoFactors) > 0)
+		})
+	}
+}
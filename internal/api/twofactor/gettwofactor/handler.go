@@ -0,0 +1,51 @@
+package gettwofactor
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	"github.com/gameap/gameap/internal/services/twofactor"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+)
+
+type statusGetter interface {
+	Status(ctx context.Context, userID uint) (*twofactor.Status, error)
+}
+
+type Handler struct {
+	service   statusGetter
+	responder base.Responder
+}
+
+func NewHandler(service statusGetter, responder base.Responder) *Handler {
+	return &Handler{
+		service:   service,
+		responder: responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	status, err := h.service.Status(ctx, session.User.ID)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to get two-factor status"))
+
+		return
+	}
+
+	h.responder.Write(ctx, rw, newStatusResponse(status))
+}
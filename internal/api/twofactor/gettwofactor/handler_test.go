@@ -0,0 +1,128 @@
+package gettwofactor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services/twofactor"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testUser  = domain.User{ID: 1, Login: "user", Email: "user@example.com"}
+	testAdmin = domain.User{ID: 2, Login: "admin", Email: "admin@example.com"}
+)
+
+type fakeRBAC struct{}
+
+func (fakeRBAC) Can(_ context.Context, userID uint, _ []domain.AbilityName) (bool, error) {
+	return userID == testAdmin.ID, nil
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name       string
+		user       *domain.User
+		enrollment string // "", "pending" or "enabled"
+		wantStatus int
+		wantError  string
+		want       statusResponse
+	}{
+		{
+			name:       "not_enrolled",
+			user:       &testUser,
+			wantStatus: http.StatusOK,
+			want:       statusResponse{},
+		},
+		{
+			name:       "pending",
+			user:       &testUser,
+			enrollment: "pending",
+			wantStatus: http.StatusOK,
+			want:       statusResponse{Pending: true},
+		},
+		{
+			name:       "enabled",
+			user:       &testUser,
+			enrollment: "enabled",
+			wantStatus: http.StatusOK,
+			want:       statusResponse{Enabled: true, RecoveryCodesLeft: 8},
+		},
+		{
+			name:       "required_for_admin",
+			user:       &testAdmin,
+			wantStatus: http.StatusOK,
+			want:       statusResponse{Required: true},
+		},
+		{
+			name:       "user_not_authenticated",
+			wantStatus: http.StatusUnauthorized,
+			wantError:  "user not authenticated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			c := cache.NewInMemory()
+			service := twofactor.NewService(
+				inmemory.NewUserTwoFactorRepository(),
+				inmemory.NewUserRepository(),
+				fakeRBAC{},
+				c,
+				cache.NewRateLimiter(c, "two_factor", 5, time.Minute),
+				twofactor.Config{Issuer: "GameAP", EnforceAdmins: true, ChallengeTTL: 5 * time.Minute},
+			)
+			handler := NewHandler(service, api.NewResponder())
+
+			if tt.enrollment != "" {
+				enrollment, err := service.StartEnrollment(ctx, tt.user)
+				require.NoError(t, err)
+
+				if tt.enrollment == "enabled" {
+					code, err := twofactor.Code(enrollment.Secret, time.Now())
+					require.NoError(t, err)
+
+					_, err = service.ConfirmEnrollment(ctx, tt.user.ID, code)
+					require.NoError(t, err)
+				}
+			}
+
+			if tt.user != nil {
+				ctx = auth.ContextWithSession(ctx, &auth.Session{
+					Login: tt.user.Login,
+					Email: tt.user.Email,
+					User:  tt.user,
+				})
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/profile/two-factor", nil)
+			req = req.WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+
+			if tt.wantError != "" {
+				assert.Contains(t, w.Body.String(), tt.wantError)
+
+				return
+			}
+
+			var response statusResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.want, response)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package gettwofactor
+
+import "github.com/gameap/gameap/internal/services/twofactor"
+
+type statusResponse struct {
+	Enabled           bool `json:"enabled"`
+	Pending           bool `json:"pending"`
+	Required          bool `json:"required"`
+	RecoveryCodesLeft int  `json:"recovery_codes_left"`
+}
+
+func newStatusResponse(status *twofactor.Status) statusResponse {
+	return statusResponse{
+		Enabled:           status.Enabled,
+		Pending:           status.Pending,
+		Required:          status.Required,
+		RecoveryCodesLeft: status.RecoveryCodesLeft,
+	}
+}
@@ -0,0 +1,62 @@
+package postenrollment
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	twofactorbase "github.com/gameap/gameap/internal/api/twofactor/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/services/twofactor"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+)
+
+type enrollmentStarter interface {
+	StartEnrollment(ctx context.Context, user *domain.User) (*twofactor.Enrollment, error)
+}
+
+type Handler struct {
+	service   enrollmentStarter
+	responder base.Responder
+}
+
+func NewHandler(service enrollmentStarter, responder base.Responder) *Handler {
+	return &Handler{
+		service:   service,
+		responder: responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	if session.IsTokenSession() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("token sessions cannot manage two-factor authentication"),
+			http.StatusForbidden,
+		))
+
+		return
+	}
+
+	enrollment, err := h.service.StartEnrollment(ctx, session.User)
+	if err != nil {
+		twofactorbase.WriteError(ctx, rw, h.responder, err, "failed to start two-factor enrollment")
+
+		return
+	}
+
+	h.responder.Write(ctx, rw, twofactorbase.NewEnrollmentResponse(enrollment))
+}
@@ -0,0 +1,125 @@
+package postenrollment
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/twofactor"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUser = domain.User{ID: 1, Login: "user", Email: "user@example.com"}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name       string
+		user       *domain.User
+		enabled    bool
+		token      bool
+		wantStatus int
+		wantError  string
+	}{
+		{
+			name:       "enrollment_is_started",
+			user:       &testUser,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "already_enabled",
+			user:       &testUser,
+			enabled:    true,
+			wantStatus: http.StatusConflict,
+			wantError:  "two-factor authentication is already enabled",
+		},
+		{
+			name:       "token_session",
+			user:       &testUser,
+			token:      true,
+			wantStatus: http.StatusForbidden,
+			wantError:  "token sessions cannot manage two-factor authentication",
+		},
+		{
+			name:       "user_not_authenticated",
+			wantStatus: http.StatusUnauthorized,
+			wantError:  "user not authenticated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			c := cache.NewInMemory()
+			service := twofactor.NewService(
+				inmemory.NewUserTwoFactorRepository(),
+				inmemory.NewUserRepository(),
+				rbac.NewRBAC(services.NewNilTransactionManager(), inmemory.NewRBACRepository(), 0),
+				c,
+				cache.NewRateLimiter(c, "two_factor", 5, time.Minute),
+				twofactor.Config{Issuer: "GameAP", ChallengeTTL: 5 * time.Minute},
+			)
+			handler := NewHandler(service, api.NewResponder())
+
+			if tt.enabled {
+				enrollment, err := service.StartEnrollment(ctx, &testUser)
+				require.NoError(t, err)
+
+				code, err := twofactor.Code(enrollment.Secret, time.Now())
+				require.NoError(t, err)
+
+				_, err = service.ConfirmEnrollment(ctx, testUser.ID, code)
+				require.NoError(t, err)
+			}
+
+			if tt.user != nil {
+				ctx = auth.ContextWithSession(ctx, &auth.Session{
+					Login: tt.user.Login,
+					Email: tt.user.Email,
+					User:  tt.user,
+				})
+			}
+
+			if tt.token {
+				session := auth.SessionFromContext(ctx)
+				session.Token = &domain.PersonalAccessToken{ID: 1, TokenableID: tt.user.ID}
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/profile/two-factor", nil)
+			req = req.WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+
+			if tt.wantError != "" {
+				assert.Contains(t, w.Body.String(), tt.wantError)
+
+				return
+			}
+
+			var response struct {
+				Secret string `json:"secret"`
+				URI    string `json:"uri"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.NotEmpty(t, response.Secret)
+			assert.Contains(t, response.URI, "otpauth://totp/GameAP:user?")
+
+			status, err := service.Status(ctx, testUser.ID)
+			require.NoError(t, err)
+			assert.True(t, status.Pending)
+		})
+	}
+}
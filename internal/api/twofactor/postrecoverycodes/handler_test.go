@@ -0,0 +1,139 @@
+package postrecoverycodes
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/twofactor"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUser = domain.User{ID: 1, Login: "user", Email: "user@example.com"}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name       string
+		user       *domain.User
+		enabled    bool
+		body       func(recoveryCodes []string) string
+		wantStatus int
+		wantError  string
+	}{
+		{
+			name:    "codes_are_regenerated",
+			user:    &testUser,
+			enabled: true,
+			body: func(recoveryCodes []string) string {
+				return `{"code": "` + recoveryCodes[0] + `"}`
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid_code",
+			user:       &testUser,
+			enabled:    true,
+			body:       func(_ []string) string { return `{"code": "aaaaa-bbbbb"}` },
+			wantStatus: http.StatusUnprocessableEntity,
+			wantError:  "two-factor code is invalid",
+		},
+		{
+			name:       "not_enabled",
+			user:       &testUser,
+			body:       func(_ []string) string { return `{"code": "123456"}` },
+			wantStatus: http.StatusUnprocessableEntity,
+			wantError:  "two-factor authentication is not enabled",
+		},
+		{
+			name:       "code_is_required",
+			user:       &testUser,
+			enabled:    true,
+			body:       func(_ []string) string { return `{}` },
+			wantStatus: http.StatusUnprocessableEntity,
+			wantError:  "code is required",
+		},
+		{
+			name:       "user_not_authenticated",
+			body:       func(_ []string) string { return `{"code": "123456"}` },
+			wantStatus: http.StatusUnauthorized,
+			wantError:  "user not authenticated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			c := cache.NewInMemory()
+			service := twofactor.NewService(
+				inmemory.NewUserTwoFactorRepository(),
+				inmemory.NewUserRepository(),
+				rbac.NewRBAC(services.NewNilTransactionManager(), inmemory.NewRBACRepository(), 0),
+				c,
+				cache.NewRateLimiter(c, "two_factor", 5, time.Minute),
+				twofactor.Config{Issuer: "GameAP", ChallengeTTL: 5 * time.Minute},
+			)
+			handler := NewHandler(service, api.NewResponder())
+
+			var recoveryCodes []string
+
+			if tt.enabled {
+				enrollment, err := service.StartEnrollment(ctx, &testUser)
+				require.NoError(t, err)
+
+				code, err := twofactor.Code(enrollment.Secret, time.Now())
+				require.NoError(t, err)
+
+				recoveryCodes, err = service.ConfirmEnrollment(ctx, testUser.ID, code)
+				require.NoError(t, err)
+			}
+
+			if tt.user != nil {
+				ctx = auth.ContextWithSession(ctx, &auth.Session{
+					Login: tt.user.Login,
+					Email: tt.user.Email,
+					User:  tt.user,
+				})
+			}
+
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"/api/profile/two-factor/recovery-codes",
+				strings.NewReader(tt.body(recoveryCodes)),
+			)
+			req = req.WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+
+			if tt.wantError != "" {
+				assert.Contains(t, w.Body.String(), tt.wantError)
+
+				return
+			}
+
+			var response struct {
+				RecoveryCodes []string `json:"recovery_codes"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			require.Len(t, response.RecoveryCodes, 8)
+
+			for _, code := range recoveryCodes {
+				assert.NotContains(t, response.RecoveryCodes, code)
+			}
+		})
+	}
+}
@@ -9,19 +9,21 @@ type PermissionResponse struct {
 }
 
 var abilityNameToDisplayName = map[domain.AbilityName]string{
-	domain.AbilityNameGameServerCommon:      "Common Game Server Ability",
-	domain.AbilityNameGameServerStart:       "Start Game Server",
-	domain.AbilityNameGameServerStop:        "Stop Game Server",
-	domain.AbilityNameGameServerRestart:     "Restart Game Server",
-	domain.AbilityNameGameServerPause:       "Pause Game Server",
-	domain.AbilityNameGameServerUpdate:      "Update Game Server",
-	domain.AbilityNameGameServerFiles:       "Access to filemanager",
-	domain.AbilityNameGameServerTasks:       "Access to task scheduler",
-	domain.AbilityNameGameServerSettings:    "Access to settings",
-	domain.AbilityNameGameServerConsoleView: "Access to read server console",
-	domain.AbilityNameGameServerConsoleSend: "Access to send console commands",
-	domain.AbilityNameGameServerRconConsole: "RCON console",
-	domain.AbilityNameGameServerRconPlayers: "RCON players manage",
+	domain.AbilityNameGameServerCommon:         "Common Game Server Ability",
+	domain.AbilityNameGameServerStart:          "Start Game Server",
+	domain.AbilityNameGameServerStop:           "Stop Game Server",
+	domain.AbilityNameGameServerRestart:        "Restart Game Server",
+	domain.AbilityNameGameServerPause:          "Pause Game Server",
+	domain.AbilityNameGameServerUpdate:         "Update Game Server",
+	domain.AbilityNameGameServerFiles:          "Access to filemanager",
+	domain.AbilityNameGameServerTasks:          "Access to task scheduler",
+	domain.AbilityNameGameServerSettings:       "Access to settings",
+	domain.AbilityNameGameServerConsoleView:    "Access to read server console",
+	domain.AbilityNameGameServerConsoleSend:    "Access to send console commands",
+	domain.AbilityNameGameServerRconConsole:    "RCON console",
+	domain.AbilityNameGameServerRconPlayers:    "RCON players manage",
+	domain.AbilityNameGameServerBackups:        "Create and delete backups",
+	domain.AbilityNameGameServerBackupsRestore: "Restore backups",
 }
 
 func NewPermissionResponse(abilityName domain.AbilityName, value bool) PermissionResponse {
@@ -0,0 +1,105 @@
+package base
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	serversbase "github.com/gameap/gameap/internal/api/servers/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+// WebhookAccess is responsible for access control of webhooks.
+//
+// Users manage their own webhooks, which may be limited to one of their servers.
+// Admins manage the webhooks of all users and the global webhooks.
+type WebhookAccess struct {
+	webhookRepo  repositories.WebhookRepository
+	serverFinder *serversbase.ServerFinder
+	rbac         base.RBAC
+}
+
+func NewWebhookAccess(
+	webhookRepo repositories.WebhookRepository,
+	serverRepo repositories.ServerRepository,
+	rbac base.RBAC,
+) *WebhookAccess {
+	return &WebhookAccess{
+		webhookRepo:  webhookRepo,
+		serverFinder: serversbase.NewServerFinder(serverRepo, rbac),
+		rbac:         rbac,
+	}
+}
+
+func (a *WebhookAccess) IsAdmin(ctx context.Context, user *domain.User) (bool, error) {
+	isAdmin, err := a.rbac.Can(ctx, user.ID, []domain.AbilityName{domain.AbilityNameAdminRolesPermissions})
+	if err != nil {
+		return false, errors.WithMessage(err, "failed to check admin permissions")
+	}
+
+	return isAdmin, nil
+}
+
+// FindUserWebhook returns the webhook if the user manages it.
+func (a *WebhookAccess) FindUserWebhook(
+	ctx context.Context,
+	user *domain.User,
+	webhookID uint,
+) (*domain.Webhook, error) {
+	isAdmin, err := a.IsAdmin(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &filters.FindWebhook{
+		IDs: []uint{webhookID},
+	}
+
+	if !isAdmin {
+		filter.UserIDs = []uint{user.ID}
+	}
+
+	webhooks, err := a.webhookRepo.Find(ctx, filter, nil, &filters.Pagination{
+		Limit:  1,
+		Offset: 0,
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find webhook")
+	}
+
+	if len(webhooks) == 0 {
+		return nil, api.NewNotFoundError("webhook not found")
+	}
+
+	return &webhooks[0], nil
+}
+
+// CheckInput checks that the user may save a webhook with the input:
+// only admins create global webhooks, and a webhook may be limited only to a server the user has access to.
+func (a *WebhookAccess) CheckInput(ctx context.Context, user *domain.User, input *WebhookInput) error {
+	if input.Global {
+		isAdmin, err := a.IsAdmin(ctx, user)
+		if err != nil {
+			return err
+		}
+
+		if !isAdmin {
+			return api.WrapHTTPError(
+				errors.New("only admins can manage global webhooks"),
+				http.StatusForbidden,
+			)
+		}
+	}
+
+	if input.ServerID != nil {
+		if _, err := a.serverFinder.FindUserServer(ctx, user, *input.ServerID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
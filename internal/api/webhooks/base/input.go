@@ -0,0 +1,107 @@
+package base
+
+import (
+	"net/url"
+	"slices"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/pkg/api"
+)
+
+var (
+	ErrNameIsRequired   = api.NewValidationError("name is required")
+	ErrNameTooLong      = api.NewValidationError("name must not exceed 255 characters")
+	ErrURLIsRequired    = api.NewValidationError("url is required")
+	ErrURLTooLong       = api.NewValidationError("url must not exceed 2048 characters")
+	ErrInvalidURL       = api.NewValidationError("url must be an absolute http or https URL")
+	ErrSecretTooLong    = api.NewValidationError("secret must not exceed 255 characters")
+	ErrInvalidEvent     = api.NewValidationError("invalid event type")
+	ErrDuplicateEvents  = api.NewValidationError("duplicate event types are not allowed")
+	ErrGlobalWithServer = api.NewValidationError("global webhooks can't be limited to a server")
+)
+
+const (
+	maxNameLength   = 255
+	maxURLLength    = 2048
+	maxSecretLength = 255
+)
+
+// WebhookInput is the request body to create or update a webhook.
+type WebhookInput struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	// Secret is generated when a webhook is created without it, and kept when a webhook is updated without it.
+	Secret string `json:"secret"`
+	// ServerID limits the webhook to the events of the server.
+	ServerID *uint `json:"server_id"`
+	// Global webhooks receive the events of all servers, only admins manage them.
+	Global bool `json:"global"`
+	// Events are the subscribed event types, all events are delivered if empty.
+	Events  []domain.EventType `json:"events"`
+	Enabled *bool              `json:"enabled"`
+}
+
+func (in *WebhookInput) Validate() error {
+	if in.Name == "" {
+		return ErrNameIsRequired
+	}
+
+	if len(in.Name) > maxNameLength {
+		return ErrNameTooLong
+	}
+
+	if in.URL == "" {
+		return ErrURLIsRequired
+	}
+
+	if len(in.URL) > maxURLLength {
+		return ErrURLTooLong
+	}
+
+	u, err := url.Parse(in.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidURL
+	}
+
+	if len(in.Secret) > maxSecretLength {
+		return ErrSecretTooLong
+	}
+
+	if in.Global && in.ServerID != nil {
+		return ErrGlobalWithServer
+	}
+
+	for i, event := range in.Events {
+		if !event.Valid() {
+			return ErrInvalidEvent
+		}
+
+		if slices.Contains(in.Events[:i], event) {
+			return ErrDuplicateEvents
+		}
+	}
+
+	return nil
+}
+
+// Apply sets the input fields to the webhook. The owner of a global webhook is cleared.
+func (in *WebhookInput) Apply(webhook *domain.Webhook, user *domain.User) {
+	webhook.Name = in.Name
+	webhook.URL = in.URL
+	webhook.ServerID = in.ServerID
+	webhook.Events = in.Events
+
+	if in.Secret != "" {
+		webhook.Secret = in.Secret
+	}
+
+	if in.Enabled != nil {
+		webhook.Enabled = *in.Enabled
+	}
+
+	if in.Global {
+		webhook.UserID = nil
+	} else if webhook.UserID == nil {
+		webhook.UserID = &user.ID
+	}
+}
@@ -0,0 +1,97 @@
+package base
+
+import (
+	"testing"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookInput_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   WebhookInput
+		wantErr error
+	}{
+		{
+			name: "valid",
+			input: WebhookInput{
+				Name:   "Discord bridge",
+				URL:    "https://example.com/hook",
+				Events: []domain.EventType{domain.EventTypeServerOnline, domain.EventTypeServerOffline},
+			},
+		},
+		{
+			name:    "name is required",
+			input:   WebhookInput{URL: "https://example.com/hook"},
+			wantErr: ErrNameIsRequired,
+		},
+		{
+			name:    "url is required",
+			input:   WebhookInput{Name: "hook"},
+			wantErr: ErrURLIsRequired,
+		},
+		{
+			name:    "url without scheme",
+			input:   WebhookInput{Name: "hook", URL: "example.com/hook"},
+			wantErr: ErrInvalidURL,
+		},
+		{
+			name:    "url with unsupported scheme",
+			input:   WebhookInput{Name: "hook", URL: "ftp://example.com/hook"},
+			wantErr: ErrInvalidURL,
+		},
+		{
+			name: "unknown event",
+			input: WebhookInput{
+				Name:   "hook",
+				URL:    "https://example.com/hook",
+				Events: []domain.EventType{"server.unknown"},
+			},
+			wantErr: ErrInvalidEvent,
+		},
+		{
+			name: "ping can't be subscribed",
+			input: WebhookInput{
+				Name:   "hook",
+				URL:    "https://example.com/hook",
+				Events: []domain.EventType{domain.EventTypePing},
+			},
+			wantErr: ErrInvalidEvent,
+		},
+		{
+			name: "duplicate events",
+			input: WebhookInput{
+				Name:   "hook",
+				URL:    "https://example.com/hook",
+				Events: []domain.EventType{domain.EventTypeServerOnline, domain.EventTypeServerOnline},
+			},
+			wantErr: ErrDuplicateEvents,
+		},
+		{
+			name: "global webhook limited to a server",
+			input: WebhookInput{
+				Name:     "hook",
+				URL:      "https://example.com/hook",
+				Global:   true,
+				ServerID: lo.ToPtr(uint(1)),
+			},
+			wantErr: ErrGlobalWithServer,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.Validate()
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package base
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+)
+
+// WebhookResponse is a webhook without its secret.
+type WebhookResponse struct {
+	ID        uint               `json:"id"`
+	UserID    *uint              `json:"user_id"`
+	ServerID  *uint              `json:"server_id"`
+	Name      string             `json:"name"`
+	URL       string             `json:"url"`
+	Global    bool               `json:"global"`
+	Events    []domain.EventType `json:"events"`
+	Enabled   bool               `json:"enabled"`
+	CreatedAt *time.Time         `json:"created_at"`
+	UpdatedAt *time.Time         `json:"updated_at"`
+}
+
+func NewWebhookResponse(webhook *domain.Webhook) WebhookResponse {
+	events := make([]domain.EventType, 0, len(webhook.Events))
+	events = append(events, webhook.Events...)
+
+	return WebhookResponse{
+		ID:        webhook.ID,
+		UserID:    webhook.UserID,
+		ServerID:  webhook.ServerID,
+		Name:      webhook.Name,
+		URL:       webhook.URL,
+		Global:    webhook.IsGlobal(),
+		Events:    events,
+		Enabled:   webhook.Enabled,
+		CreatedAt: webhook.CreatedAt,
+		UpdatedAt: webhook.UpdatedAt,
+	}
+}
+
+type DeliveryResponse struct {
+	ID             uint                         `json:"id"`
+	WebhookID      uint                         `json:"webhook_id"`
+	EventID        string                       `json:"event_id"`
+	EventType      domain.EventType             `json:"event_type"`
+	Payload        json.RawMessage              `json:"payload"`
+	Status         domain.WebhookDeliveryStatus `json:"status"`
+	Attempts       int                          `json:"attempts"`
+	NextAttemptAt  *time.Time                   `json:"next_attempt_at"`
+	ResponseStatus *int                         `json:"response_status"`
+	Error          *string                      `json:"error"`
+	CreatedAt      *time.Time                   `json:"created_at"`
+	UpdatedAt      *time.Time                   `json:"updated_at"`
+}
+
+func NewDeliveryResponse(delivery *domain.WebhookDelivery) DeliveryResponse {
+	return DeliveryResponse{
+		ID:             delivery.ID,
+		WebhookID:      delivery.WebhookID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Payload:        json.RawMessage(delivery.Payload),
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		ResponseStatus: delivery.ResponseStatus,
+		Error:          delivery.Error,
+		CreatedAt:      delivery.CreatedAt,
+		UpdatedAt:      delivery.UpdatedAt,
+	}
+}
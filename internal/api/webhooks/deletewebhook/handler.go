@@ -0,0 +1,80 @@
+package deletewebhook
+
+import (
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	webhooksbase "github.com/gameap/gameap/internal/api/webhooks/base"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+)
+
+// Handler deletes a webhook with its delivery log.
+type Handler struct {
+	webhookRepo  repositories.WebhookRepository
+	deliveryRepo repositories.WebhookDeliveryRepository
+	access       *webhooksbase.WebhookAccess
+	responder    base.Responder
+}
+
+func NewHandler(
+	webhookRepo repositories.WebhookRepository,
+	deliveryRepo repositories.WebhookDeliveryRepository,
+	serverRepo repositories.ServerRepository,
+	rbac base.RBAC,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		access:       webhooksbase.NewWebhookAccess(webhookRepo, serverRepo, rbac),
+		responder:    responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	webhookID, err := api.NewInputReader(r).ReadUint("webhook")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid webhook id"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	webhook, err := h.access.FindUserWebhook(ctx, session.User, webhookID)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	if err = h.deliveryRepo.DeleteByWebhookID(ctx, webhook.ID); err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to delete webhook deliveries"))
+
+		return
+	}
+
+	if err = h.webhookRepo.Delete(ctx, webhook.ID); err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to delete webhook"))
+
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,138 @@
+package deletewebhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testUser  = domain.User{ID: 1, Login: "user", Email: "user@example.com"}
+	testAdmin = domain.User{ID: 2, Login: "admin", Email: "admin@example.com"}
+)
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name               string
+		user               *domain.User
+		webhookID          string
+		expectedStatus     int
+		wantError          string
+		expectedWebhooks   int
+		expectedDeliveries int
+	}{
+		{
+			name:               "user deletes own webhook",
+			user:               &testUser,
+			webhookID:          "1",
+			expectedStatus:     http.StatusNoContent,
+			expectedWebhooks:   1,
+			expectedDeliveries: 1,
+		},
+		{
+			name:               "admin deletes global webhook",
+			user:               &testAdmin,
+			webhookID:          "2",
+			expectedStatus:     http.StatusNoContent,
+			expectedWebhooks:   1,
+			expectedDeliveries: 2,
+		},
+		{
+			name:               "user can't delete global webhook",
+			user:               &testUser,
+			webhookID:          "2",
+			expectedStatus:     http.StatusNotFound,
+			wantError:          "webhook not found",
+			expectedWebhooks:   2,
+			expectedDeliveries: 3,
+		},
+		{
+			name:               "user not authenticated",
+			webhookID:          "1",
+			expectedStatus:     http.StatusUnauthorized,
+			wantError:          "user not authenticated",
+			expectedWebhooks:   2,
+			expectedDeliveries: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			webhookRepo := inmemory.NewWebhookRepository()
+			deliveryRepo := inmemory.NewWebhookDeliveryRepository()
+			rbacRepo := inmemory.NewRBACRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+			handler := NewHandler(
+				webhookRepo,
+				deliveryRepo,
+				inmemory.NewServerRepository(),
+				rbacService,
+				api.NewResponder(),
+			)
+
+			adminAbility := &domain.Ability{ID: 1, Name: domain.AbilityNameAdminRolesPermissions}
+			require.NoError(t, rbacRepo.SaveAbility(ctx, adminAbility))
+			require.NoError(t, rbacRepo.AssignAbilityToUser(ctx, testAdmin.ID, adminAbility.ID))
+
+			require.NoError(t, webhookRepo.Save(ctx, &domain.Webhook{
+				UserID: lo.ToPtr(testUser.ID),
+				Name:   "Own",
+				URL:    "https://example.com/1",
+			}))
+			require.NoError(t, webhookRepo.Save(ctx, &domain.Webhook{
+				Name: "Global",
+				URL:  "https://example.com/2",
+			}))
+
+			for _, webhookID := range []uint{1, 1, 2} {
+				require.NoError(t, deliveryRepo.Save(ctx, &domain.WebhookDelivery{
+					WebhookID: webhookID,
+					EventType: domain.EventTypeServerOnline,
+					Status:    domain.WebhookDeliveryStatusSucceeded,
+				}))
+			}
+
+			if tt.user != nil {
+				ctx = auth.ContextWithSession(ctx, &auth.Session{
+					Login: tt.user.Login,
+					Email: tt.user.Email,
+					User:  tt.user,
+				})
+			}
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/webhooks/"+tt.webhookID, nil)
+			req = req.WithContext(ctx)
+			req = mux.SetURLVars(req, map[string]string{"webhook": tt.webhookID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+
+			if tt.wantError != "" {
+				assert.Contains(t, w.Body.String(), tt.wantError)
+			}
+
+			webhooks, err := webhookRepo.Find(ctx, nil, nil, nil)
+			require.NoError(t, err)
+			assert.Len(t, webhooks, tt.expectedWebhooks)
+
+			deliveries, err := deliveryRepo.Find(ctx, nil, nil, nil)
+			require.NoError(t, err)
+			assert.Len(t, deliveries, tt.expectedDeliveries)
+		})
+	}
+}
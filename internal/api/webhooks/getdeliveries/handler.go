@@ -0,0 +1,88 @@
+package getdeliveries
+
+import (
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	webhooksbase "github.com/gameap/gameap/internal/api/webhooks/base"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+)
+
+const deliveriesLimit = 100
+
+// Handler returns the latest deliveries of a webhook.
+type Handler struct {
+	deliveryRepo repositories.WebhookDeliveryRepository
+	access       *webhooksbase.WebhookAccess
+	responder    base.Responder
+}
+
+func NewHandler(
+	webhookRepo repositories.WebhookRepository,
+	deliveryRepo repositories.WebhookDeliveryRepository,
+	serverRepo repositories.ServerRepository,
+	rbac base.RBAC,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		deliveryRepo: deliveryRepo,
+		access:       webhooksbase.NewWebhookAccess(webhookRepo, serverRepo, rbac),
+		responder:    responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	webhookID, err := api.NewInputReader(r).ReadUint("webhook")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid webhook id"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	webhook, err := h.access.FindUserWebhook(ctx, session.User, webhookID)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	deliveries, err := h.deliveryRepo.Find(ctx, &filters.FindWebhookDelivery{
+		WebhookIDs: []uint{webhook.ID},
+	}, []filters.Sorting{
+		{Field: "id", Direction: filters.SortDirectionDesc},
+	}, &filters.Pagination{
+		Limit:  deliveriesLimit,
+		Offset: 0,
+	})
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to find webhook deliveries"))
+
+		return
+	}
+
+	response := make([]webhooksbase.DeliveryResponse, 0, len(deliveries))
+	for i := range deliveries {
+		response = append(response, webhooksbase.NewDeliveryResponse(&deliveries[i]))
+	}
+
+	h.responder.Write(ctx, rw, response)
+}
@@ -0,0 +1,183 @@
+package getdeliveries
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testUser  = domain.User{ID: 1, Login: "user", Email: "user@example.com"}
+	testOther = domain.User{ID: 2, Login: "other", Email: "other@example.com"}
+)
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	createdAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	nextAttemptAt := createdAt.Add(time.Minute)
+
+	tests := []struct {
+		name           string
+		user           *domain.User
+		webhookID      string
+		expectedStatus int
+		wantError      string
+		wantBody       string
+	}{
+		{
+			name:           "latest deliveries first",
+			user:           &testUser,
+			webhookID:      "1",
+			expectedStatus: http.StatusOK,
+			wantBody: `[
+				{
+					"id": 2,
+					"webhook_id": 1,
+					"event_id": "event-2",
+					"event_type": "server.offline",
+					"payload": {"event": "server.offline"},
+					"status": "pending",
+					"attempts": 1,
+					"next_attempt_at": "2025-03-01T10:01:00Z",
+					"response_status": 500,
+					"error": "unexpected response status 500",
+					"created_at": "2025-03-01T10:00:00Z"
+				},
+				{
+					"id": 1,
+					"webhook_id": 1,
+					"event_id": "event-1",
+					"event_type": "server.online",
+					"payload": {"event": "server.online"},
+					"status": "succeeded",
+					"attempts": 1,
+					"next_attempt_at": null,
+					"response_status": 200,
+					"error": null,
+					"created_at": "2025-03-01T10:00:00Z"
+				}
+			]`,
+		},
+		{
+			name:           "webhook of another user",
+			user:           &testOther,
+			webhookID:      "1",
+			expectedStatus: http.StatusNotFound,
+			wantError:      "webhook not found",
+		},
+		{
+			name:           "invalid webhook id",
+			user:           &testUser,
+			webhookID:      "invalid",
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "invalid webhook id",
+		},
+		{
+			name:           "user not authenticated",
+			webhookID:      "1",
+			expectedStatus: http.StatusUnauthorized,
+			wantError:      "user not authenticated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			webhookRepo := inmemory.NewWebhookRepository()
+			deliveryRepo := inmemory.NewWebhookDeliveryRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), inmemory.NewRBACRepository(), 0)
+			handler := NewHandler(
+				webhookRepo,
+				deliveryRepo,
+				inmemory.NewServerRepository(),
+				rbacService,
+				api.NewResponder(),
+			)
+
+			require.NoError(t, webhookRepo.Save(ctx, &domain.Webhook{
+				UserID: lo.ToPtr(testUser.ID),
+				Name:   "Own",
+				URL:    "https://example.com/1",
+			}))
+
+			require.NoError(t, deliveryRepo.Save(ctx, &domain.WebhookDelivery{
+				WebhookID:      1,
+				EventID:        "event-1",
+				EventType:      domain.EventTypeServerOnline,
+				Payload:        `{"event": "server.online"}`,
+				Status:         domain.WebhookDeliveryStatusSucceeded,
+				Attempts:       1,
+				ResponseStatus: lo.ToPtr(http.StatusOK),
+				CreatedAt:      &createdAt,
+			}))
+			require.NoError(t, deliveryRepo.Save(ctx, &domain.WebhookDelivery{
+				WebhookID:      1,
+				EventID:        "event-2",
+				EventType:      domain.EventTypeServerOffline,
+				Payload:        `{"event": "server.offline"}`,
+				Status:         domain.WebhookDeliveryStatusPending,
+				Attempts:       1,
+				NextAttemptAt:  &nextAttemptAt,
+				ResponseStatus: lo.ToPtr(http.StatusInternalServerError),
+				Error:          lo.ToPtr("unexpected response status 500"),
+				CreatedAt:      &createdAt,
+			}))
+			require.NoError(t, deliveryRepo.Save(ctx, &domain.WebhookDelivery{
+				WebhookID: 2,
+				EventID:   "event-3",
+				EventType: domain.EventTypeServerOnline,
+				Payload:   `{}`,
+				Status:    domain.WebhookDeliveryStatusSucceeded,
+				CreatedAt: &createdAt,
+			}))
+
+			if tt.user != nil {
+				ctx = auth.ContextWithSession(ctx, &auth.Session{
+					Login: tt.user.Login,
+					Email: tt.user.Email,
+					User:  tt.user,
+				})
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/webhooks/"+tt.webhookID+"/deliveries", nil)
+			req = req.WithContext(ctx)
+			req = mux.SetURLVars(req, map[string]string{"webhook": tt.webhookID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+
+			if tt.wantError != "" {
+				assert.Contains(t, w.Body.String(), tt.wantError)
+
+				return
+			}
+
+			// updated_at is set by the repository when the delivery is saved
+			var response []map[string]any
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			for _, delivery := range response {
+				assert.NotNil(t, delivery["updated_at"])
+				delete(delivery, "updated_at")
+			}
+
+			body, err := json.Marshal(response)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.wantBody, string(body))
+		})
+	}
+}
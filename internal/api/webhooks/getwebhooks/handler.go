@@ -0,0 +1,75 @@
+package getwebhooks
+
+import (
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	webhooksbase "github.com/gameap/gameap/internal/api/webhooks/base"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+)
+
+// Handler returns the webhooks of the user. Admins get the webhooks of all users and the global webhooks.
+type Handler struct {
+	webhookRepo repositories.WebhookRepository
+	access      *webhooksbase.WebhookAccess
+	responder   base.Responder
+}
+
+func NewHandler(
+	webhookRepo repositories.WebhookRepository,
+	serverRepo repositories.ServerRepository,
+	rbac base.RBAC,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		webhookRepo: webhookRepo,
+		access:      webhooksbase.NewWebhookAccess(webhookRepo, serverRepo, rbac),
+		responder:   responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	isAdmin, err := h.access.IsAdmin(ctx, session.User)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	filter := &filters.FindWebhook{}
+	if !isAdmin {
+		filter.UserIDs = []uint{session.User.ID}
+	}
+
+	webhooks, err := h.webhookRepo.Find(ctx, filter, []filters.Sorting{
+		{Field: "id", Direction: filters.SortDirectionAsc},
+	}, nil)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to find webhooks"))
+
+		return
+	}
+
+	response := make([]webhooksbase.WebhookResponse, 0, len(webhooks))
+	for i := range webhooks {
+		response = append(response, webhooksbase.NewWebhookResponse(&webhooks[i]))
+	}
+
+	h.responder.Write(ctx, rw, response)
+}
@@ -0,0 +1,103 @@
+package getwebhooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testUser  = domain.User{ID: 1, Login: "user", Email: "user@example.com"}
+	testAdmin = domain.User{ID: 2, Login: "admin", Email: "admin@example.com"}
+)
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		user           *domain.User
+		expectedStatus int
+		expectedIDs    []uint
+	}{
+		{
+			name:           "user gets own webhooks",
+			user:           &testUser,
+			expectedStatus: http.StatusOK,
+			expectedIDs:    []uint{1},
+		},
+		{
+			name:           "admin gets all webhooks",
+			user:           &testAdmin,
+			expectedStatus: http.StatusOK,
+			expectedIDs:    []uint{1, 2, 3},
+		},
+		{
+			name:           "user not authenticated",
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			webhookRepo := inmemory.NewWebhookRepository()
+			rbacRepo := inmemory.NewRBACRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+			handler := NewHandler(webhookRepo, inmemory.NewServerRepository(), rbacService, api.NewResponder())
+
+			adminAbility := &domain.Ability{ID: 1, Name: domain.AbilityNameAdminRolesPermissions}
+			require.NoError(t, rbacRepo.SaveAbility(ctx, adminAbility))
+			require.NoError(t, rbacRepo.AssignAbilityToUser(ctx, testAdmin.ID, adminAbility.ID))
+
+			for _, webhook := range []*domain.Webhook{
+				{UserID: lo.ToPtr(testUser.ID), Name: "Own", URL: "https://example.com/1", Secret: "secret"},
+				{UserID: lo.ToPtr(uint(3)), Name: "Other", URL: "https://example.com/2", Secret: "secret"},
+				{Name: "Global", URL: "https://example.com/3", Secret: "secret"},
+			} {
+				require.NoError(t, webhookRepo.Save(ctx, webhook))
+			}
+
+			if tt.user != nil {
+				ctx = auth.ContextWithSession(ctx, &auth.Session{
+					Login: tt.user.Login,
+					Email: tt.user.Email,
+					User:  tt.user,
+				})
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/webhooks", nil)
+			req = req.WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			assert.NotContains(t, w.Body.String(), "secret")
+
+			var response []map[string]any
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+			ids := make([]uint, 0, len(response))
+			for _, webhook := range response {
+				ids = append(ids, uint(webhook["id"].(float64)))
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package posttest
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	webhooksbase "github.com/gameap/gameap/internal/api/webhooks/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+)
+
+type webhookTester interface {
+	Test(ctx context.Context, webhook *domain.Webhook) (*domain.WebhookDelivery, error)
+}
+
+// Handler sends a ping event to a webhook and returns the delivery.
+// A failed delivery isn't an error of the request, the delivery contains the response status and the error.
+type Handler struct {
+	access    *webhooksbase.WebhookAccess
+	tester    webhookTester
+	responder base.Responder
+}
+
+func NewHandler(
+	webhookRepo repositories.WebhookRepository,
+	serverRepo repositories.ServerRepository,
+	rbac base.RBAC,
+	tester webhookTester,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		access:    webhooksbase.NewWebhookAccess(webhookRepo, serverRepo, rbac),
+		tester:    tester,
+		responder: responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	webhookID, err := api.NewInputReader(r).ReadUint("webhook")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid webhook id"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	webhook, err := h.access.FindUserWebhook(ctx, session.User, webhookID)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	delivery, err := h.tester.Test(ctx, webhook)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to test webhook"))
+
+		return
+	}
+
+	h.responder.Write(ctx, rw, webhooksbase.NewDeliveryResponse(delivery))
+}
@@ -0,0 +1,145 @@
+package posttest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/webhooks"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testUser  = domain.User{ID: 1, Login: "user", Email: "user@example.com"}
+	testOther = domain.User{ID: 2, Login: "other", Email: "other@example.com"}
+)
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		user           *domain.User
+		webhookID      string
+		responseStatus int
+		expectedStatus int
+		wantError      string
+		wantDelivery   domain.WebhookDeliveryStatus
+	}{
+		{
+			name:           "successful ping",
+			user:           &testUser,
+			webhookID:      "1",
+			responseStatus: http.StatusNoContent,
+			expectedStatus: http.StatusOK,
+			wantDelivery:   domain.WebhookDeliveryStatusSucceeded,
+		},
+		{
+			name:           "failed ping isn't retried",
+			user:           &testUser,
+			webhookID:      "1",
+			responseStatus: http.StatusInternalServerError,
+			expectedStatus: http.StatusOK,
+			wantDelivery:   domain.WebhookDeliveryStatusFailed,
+		},
+		{
+			name:           "webhook of another user",
+			user:           &testOther,
+			webhookID:      "1",
+			expectedStatus: http.StatusNotFound,
+			wantError:      "webhook not found",
+		},
+		{
+			name:           "user not authenticated",
+			webhookID:      "1",
+			expectedStatus: http.StatusUnauthorized,
+			wantError:      "user not authenticated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var received http.Header
+			target := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				received = r.Header.Clone()
+				_, _ = io.Copy(io.Discard, r.Body)
+				rw.WriteHeader(tt.responseStatus)
+			}))
+			defer target.Close()
+
+			webhookRepo := inmemory.NewWebhookRepository()
+			deliveryRepo := inmemory.NewWebhookDeliveryRepository()
+			serverRepo := inmemory.NewServerRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), inmemory.NewRBACRepository(), 0)
+			service := webhooks.NewService(
+				webhookRepo,
+				deliveryRepo,
+				serverRepo,
+				rbacService,
+				webhooks.NewSender(webhooks.NewHTTPClient(time.Second, true)),
+			)
+			handler := NewHandler(webhookRepo, serverRepo, rbacService, service, api.NewResponder())
+
+			require.NoError(t, webhookRepo.Save(ctx, &domain.Webhook{
+				UserID:  lo.ToPtr(testUser.ID),
+				Name:    "Own",
+				URL:     target.URL,
+				Secret:  "secret",
+				Enabled: true,
+			}))
+
+			if tt.user != nil {
+				ctx = auth.ContextWithSession(ctx, &auth.Session{
+					Login: tt.user.Login,
+					Email: tt.user.Email,
+					User:  tt.user,
+				})
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/webhooks/"+tt.webhookID+"/test", nil)
+			req = req.WithContext(ctx)
+			req = mux.SetURLVars(req, map[string]string{"webhook": tt.webhookID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+
+			if tt.wantError != "" {
+				assert.Contains(t, w.Body.String(), tt.wantError)
+				assert.Nil(t, received)
+
+				return
+			}
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, string(tt.wantDelivery), response["status"])
+			assert.Equal(t, string(domain.EventTypePing), response["event_type"])
+			assert.InDelta(t, float64(tt.responseStatus), response["response_status"], 0)
+			assert.Nil(t, response["next_attempt_at"])
+
+			require.NotNil(t, received)
+			assert.Equal(t, string(domain.EventTypePing), received.Get(webhooks.HeaderEvent))
+			assert.NotEmpty(t, received.Get(webhooks.HeaderSignature))
+
+			deliveries, err := deliveryRepo.Find(ctx, nil, nil, nil)
+			require.NoError(t, err)
+			require.Len(t, deliveries, 1)
+			assert.Equal(t, tt.wantDelivery, deliveries[0].Status)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package postwebhook
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gameap/gameap/internal/api/base"
+	webhooksbase "github.com/gameap/gameap/internal/api/webhooks/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+const secretLength = 32
+
+type Handler struct {
+	webhookRepo repositories.WebhookRepository
+	access      *webhooksbase.WebhookAccess
+	responder   base.Responder
+}
+
+func NewHandler(
+	webhookRepo repositories.WebhookRepository,
+	serverRepo repositories.ServerRepository,
+	rbac base.RBAC,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		webhookRepo: webhookRepo,
+		access:      webhooksbase.NewWebhookAccess(webhookRepo, serverRepo, rbac),
+		responder:   responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	input := &webhooksbase.WebhookInput{}
+	if err := json.NewDecoder(r.Body).Decode(input); err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid request body"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	if err := h.access.CheckInput(ctx, session.User, input); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	if input.Secret == "" {
+		secret, err := generateSecret()
+		if err != nil {
+			h.responder.WriteError(ctx, rw, err)
+
+			return
+		}
+
+		input.Secret = secret
+	}
+
+	if input.Enabled == nil {
+		input.Enabled = lo.ToPtr(true)
+	}
+
+	now := time.Now()
+	webhook := &domain.Webhook{
+		CreatedAt: &now,
+		UpdatedAt: &now,
+	}
+	input.Apply(webhook, session.User)
+
+	if err := h.webhookRepo.Save(ctx, webhook); err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to save webhook"))
+
+		return
+	}
+
+	rw.WriteHeader(http.StatusCreated)
+	h.responder.Write(ctx, rw, newWebhookResponse(webhook))
+}
+
+func generateSecret() (string, error) {
+	b := make([]byte, secretLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "failed to generate webhook secret")
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,192 @@
+package postwebhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testUser  = domain.User{ID: 1, Login: "user", Email: "user@example.com"}
+	testAdmin = domain.User{ID: 2, Login: "admin", Email: "admin@example.com"}
+)
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		user           *domain.User
+		body           string
+		expectedStatus int
+		wantError      string
+		validate       func(t *testing.T, webhook *domain.Webhook, response map[string]any)
+	}{
+		{
+			name: "user creates webhook with generated secret",
+			user: &testUser,
+			body: `{
+				"name": "Discord bridge",
+				"url": "https://example.com/hook",
+				"events": ["server.online", "server.offline"]
+			}`,
+			expectedStatus: http.StatusCreated,
+			validate: func(t *testing.T, webhook *domain.Webhook, response map[string]any) {
+				t.Helper()
+
+				assert.Equal(t, lo.ToPtr(testUser.ID), webhook.UserID)
+				assert.Nil(t, webhook.ServerID)
+				assert.True(t, webhook.Enabled)
+				assert.Len(t, webhook.Secret, 2*secretLength)
+				assert.Equal(t, domain.WebhookEvents{
+					domain.EventTypeServerOnline,
+					domain.EventTypeServerOffline,
+				}, webhook.Events)
+
+				assert.Equal(t, webhook.Secret, response["secret"])
+				assert.Equal(t, false, response["global"])
+			},
+		},
+		{
+			name: "user creates server webhook with own secret",
+			user: &testUser,
+			body: `{
+				"name": "Server hook",
+				"url": "http://example.com/hook",
+				"secret": "my-secret",
+				"server_id": 1,
+				"enabled": false
+			}`,
+			expectedStatus: http.StatusCreated,
+			validate: func(t *testing.T, webhook *domain.Webhook, _ map[string]any) {
+				t.Helper()
+
+				assert.Equal(t, lo.ToPtr(uint(1)), webhook.ServerID)
+				assert.Equal(t, "my-secret", webhook.Secret)
+				assert.False(t, webhook.Enabled)
+				assert.Empty(t, webhook.Events)
+			},
+		},
+		{
+			name:           "admin creates global webhook",
+			user:           &testAdmin,
+			body:           `{"name": "Global", "url": "https://example.com/hook", "global": true}`,
+			expectedStatus: http.StatusCreated,
+			validate: func(t *testing.T, webhook *domain.Webhook, response map[string]any) {
+				t.Helper()
+
+				assert.Nil(t, webhook.UserID)
+				assert.Equal(t, true, response["global"])
+			},
+		},
+		{
+			name:           "user can't create global webhook",
+			user:           &testUser,
+			body:           `{"name": "Global", "url": "https://example.com/hook", "global": true}`,
+			expectedStatus: http.StatusForbidden,
+			wantError:      "only admins can manage global webhooks",
+		},
+		{
+			name:           "user can't create webhook for server of another user",
+			user:           &testUser,
+			body:           `{"name": "hook", "url": "https://example.com/hook", "server_id": 2}`,
+			expectedStatus: http.StatusNotFound,
+			wantError:      "server not found",
+		},
+		{
+			name:           "invalid url",
+			user:           &testUser,
+			body:           `{"name": "hook", "url": "file:///etc/passwd"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "url must be an absolute http or https URL",
+		},
+		{
+			name:           "invalid event",
+			user:           &testUser,
+			body:           `{"name": "hook", "url": "https://example.com/hook", "events": ["ping"]}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "invalid event type",
+		},
+		{
+			name:           "invalid request body",
+			user:           &testUser,
+			body:           `{`,
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "invalid request body",
+		},
+		{
+			name:           "user not authenticated",
+			body:           `{"name": "hook", "url": "https://example.com/hook"}`,
+			expectedStatus: http.StatusUnauthorized,
+			wantError:      "user not authenticated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			webhookRepo := inmemory.NewWebhookRepository()
+			serverRepo := inmemory.NewServerRepository()
+			rbacRepo := inmemory.NewRBACRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+			handler := NewHandler(webhookRepo, serverRepo, rbacService, api.NewResponder())
+
+			adminAbility := &domain.Ability{ID: 1, Name: domain.AbilityNameAdminRolesPermissions}
+			require.NoError(t, rbacRepo.SaveAbility(ctx, adminAbility))
+			require.NoError(t, rbacRepo.AssignAbilityToUser(ctx, testAdmin.ID, adminAbility.ID))
+
+			require.NoError(t, serverRepo.Save(ctx, &domain.Server{ID: 1, UUID: uuid.New(), Name: "Own"}))
+			serverRepo.AddUserServer(testUser.ID, 1)
+			require.NoError(t, serverRepo.Save(ctx, &domain.Server{ID: 2, UUID: uuid.New(), Name: "Other"}))
+			serverRepo.AddUserServer(3, 2)
+
+			if tt.user != nil {
+				ctx = auth.ContextWithSession(ctx, &auth.Session{
+					Login: tt.user.Login,
+					Email: tt.user.Email,
+					User:  tt.user,
+				})
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/webhooks", bytes.NewBufferString(tt.body))
+			req = req.WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+
+			if tt.wantError != "" {
+				assert.Contains(t, w.Body.String(), tt.wantError)
+
+				webhooks, err := webhookRepo.Find(ctx, nil, nil, nil)
+				require.NoError(t, err)
+				assert.Empty(t, webhooks)
+
+				return
+			}
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+			webhooks, err := webhookRepo.Find(ctx, nil, nil, nil)
+			require.NoError(t, err)
+			require.Len(t, webhooks, 1)
+			assert.InDelta(t, float64(webhooks[0].ID), response["id"], 0)
+
+			tt.validate(t, &webhooks[0], response)
+		})
+	}
+}
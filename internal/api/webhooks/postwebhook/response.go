@@ -0,0 +1,20 @@
+package postwebhook
+
+import (
+	webhooksbase "github.com/gameap/gameap/internal/api/webhooks/base"
+	"github.com/gameap/gameap/internal/domain"
+)
+
+// webhookResponse includes the secret, it is returned only when the webhook is created.
+type webhookResponse struct {
+	webhooksbase.WebhookResponse
+
+	Secret string `json:"secret"`
+}
+
+func newWebhookResponse(webhook *domain.Webhook) webhookResponse {
+	return webhookResponse{
+		WebhookResponse: webhooksbase.NewWebhookResponse(webhook),
+		Secret:          webhook.Secret,
+	}
+}
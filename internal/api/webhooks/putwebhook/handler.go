@@ -0,0 +1,98 @@
+package putwebhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gameap/gameap/internal/api/base"
+	webhooksbase "github.com/gameap/gameap/internal/api/webhooks/base"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+type Handler struct {
+	webhookRepo repositories.WebhookRepository
+	access      *webhooksbase.WebhookAccess
+	responder   base.Responder
+}
+
+func NewHandler(
+	webhookRepo repositories.WebhookRepository,
+	serverRepo repositories.ServerRepository,
+	rbac base.RBAC,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		webhookRepo: webhookRepo,
+		access:      webhooksbase.NewWebhookAccess(webhookRepo, serverRepo, rbac),
+		responder:   responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	webhookID, err := api.NewInputReader(r).ReadUint("webhook")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid webhook id"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	input := &webhooksbase.WebhookInput{}
+	if err = json.NewDecoder(r.Body).Decode(input); err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid request body"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if err = input.Validate(); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	webhook, err := h.access.FindUserWebhook(ctx, session.User, webhookID)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	if err = h.access.CheckInput(ctx, session.User, input); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	input.Apply(webhook, session.User)
+	webhook.UpdatedAt = lo.ToPtr(time.Now())
+
+	if err = h.webhookRepo.Save(ctx, webhook); err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to save webhook"))
+
+		return
+	}
+
+	h.responder.Write(ctx, rw, webhooksbase.NewWebhookResponse(webhook))
+}
@@ -0,0 +1,179 @@
+package putwebhook
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testUser  = domain.User{ID: 1, Login: "user", Email: "user@example.com"}
+	testAdmin = domain.User{ID: 2, Login: "admin", Email: "admin@example.com"}
+)
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		user           *domain.User
+		webhookID      string
+		body           string
+		expectedStatus int
+		wantError      string
+		validate       func(t *testing.T, webhook *domain.Webhook)
+	}{
+		{
+			name:      "user updates own webhook and keeps secret",
+			user:      &testUser,
+			webhookID: "1",
+			body: `{
+				"name": "Renamed",
+				"url": "https://example.com/new",
+				"events": ["server.crash_loop"],
+				"enabled": false
+			}`,
+			expectedStatus: http.StatusOK,
+			validate: func(t *testing.T, webhook *domain.Webhook) {
+				t.Helper()
+
+				assert.Equal(t, "Renamed", webhook.Name)
+				assert.Equal(t, "https://example.com/new", webhook.URL)
+				assert.Equal(t, "secret-1", webhook.Secret)
+				assert.Equal(t, domain.WebhookEvents{domain.EventTypeServerCrashLoop}, webhook.Events)
+				assert.False(t, webhook.Enabled)
+				assert.Equal(t, lo.ToPtr(testUser.ID), webhook.UserID)
+			},
+		},
+		{
+			name:           "user rotates secret",
+			user:           &testUser,
+			webhookID:      "1",
+			body:           `{"name": "Own", "url": "https://example.com/1", "secret": "rotated"}`,
+			expectedStatus: http.StatusOK,
+			validate: func(t *testing.T, webhook *domain.Webhook) {
+				t.Helper()
+
+				assert.Equal(t, "rotated", webhook.Secret)
+				assert.True(t, webhook.Enabled)
+			},
+		},
+		{
+			name:           "admin updates webhook of another user",
+			user:           &testAdmin,
+			webhookID:      "1",
+			body:           `{"name": "Checked", "url": "https://example.com/1"}`,
+			expectedStatus: http.StatusOK,
+			validate: func(t *testing.T, webhook *domain.Webhook) {
+				t.Helper()
+
+				assert.Equal(t, "Checked", webhook.Name)
+				assert.Equal(t, lo.ToPtr(testUser.ID), webhook.UserID)
+			},
+		},
+		{
+			name:           "user can't update global webhook",
+			user:           &testUser,
+			webhookID:      "2",
+			body:           `{"name": "Global", "url": "https://example.com/2"}`,
+			expectedStatus: http.StatusNotFound,
+			wantError:      "webhook not found",
+		},
+		{
+			name:           "user can't make webhook global",
+			user:           &testUser,
+			webhookID:      "1",
+			body:           `{"name": "Own", "url": "https://example.com/1", "global": true}`,
+			expectedStatus: http.StatusForbidden,
+			wantError:      "only admins can manage global webhooks",
+		},
+		{
+			name:           "invalid webhook id",
+			user:           &testUser,
+			webhookID:      "invalid",
+			body:           `{"name": "Own", "url": "https://example.com/1"}`,
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "invalid webhook id",
+		},
+		{
+			name:           "user not authenticated",
+			webhookID:      "1",
+			body:           `{"name": "Own", "url": "https://example.com/1"}`,
+			expectedStatus: http.StatusUnauthorized,
+			wantError:      "user not authenticated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			webhookRepo := inmemory.NewWebhookRepository()
+			rbacRepo := inmemory.NewRBACRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+			handler := NewHandler(webhookRepo, inmemory.NewServerRepository(), rbacService, api.NewResponder())
+
+			adminAbility := &domain.Ability{ID: 1, Name: domain.AbilityNameAdminRolesPermissions}
+			require.NoError(t, rbacRepo.SaveAbility(ctx, adminAbility))
+			require.NoError(t, rbacRepo.AssignAbilityToUser(ctx, testAdmin.ID, adminAbility.ID))
+
+			require.NoError(t, webhookRepo.Save(ctx, &domain.Webhook{
+				UserID:  lo.ToPtr(testUser.ID),
+				Name:    "Own",
+				URL:     "https://example.com/1",
+				Secret:  "secret-1",
+				Enabled: true,
+			}))
+			require.NoError(t, webhookRepo.Save(ctx, &domain.Webhook{
+				Name:    "Global",
+				URL:     "https://example.com/2",
+				Secret:  "secret-2",
+				Enabled: true,
+			}))
+
+			if tt.user != nil {
+				ctx = auth.ContextWithSession(ctx, &auth.Session{
+					Login: tt.user.Login,
+					Email: tt.user.Email,
+					User:  tt.user,
+				})
+			}
+
+			req := httptest.NewRequest(
+				http.MethodPut, "/api/webhooks/"+tt.webhookID, bytes.NewBufferString(tt.body),
+			)
+			req = req.WithContext(ctx)
+			req = mux.SetURLVars(req, map[string]string{"webhook": tt.webhookID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+
+			if tt.wantError != "" {
+				assert.Contains(t, w.Body.String(), tt.wantError)
+
+				return
+			}
+
+			assert.NotContains(t, w.Body.String(), "secret")
+
+			webhooks, err := webhookRepo.Find(ctx, &filters.FindWebhook{IDs: []uint{1}}, nil, nil)
+			require.NoError(t, err)
+			require.Len(t, webhooks, 1)
+
+			tt.validate(t, &webhooks[0])
+		})
+	}
+}
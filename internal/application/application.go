@@ -11,6 +11,7 @@ import (
 
 	"github.com/gameap/gameap/internal/application/defaults"
 	"github.com/gameap/gameap/internal/config"
+	"github.com/gameap/gameap/internal/logger"
 	"github.com/gameap/gameap/migrations"
 	"github.com/pkg/errors"
 )
@@ -20,52 +21,84 @@ type RunParams struct {
 	LegacyEnvFile string
 }
 
-//nolint:funlen
-func Run(runParams RunParams) {
-	if err := loadEnvFile(runParams.EnvFile); err != nil {
-		slog.Error("Failed to load env file", slog.String("error", err.Error()))
-
-		os.Exit(1)
-
-		return
+// Bootstrap loads the env files and the configuration and sets up the default logger.
+// The container is shared by the server and the administration commands, the caller shuts it down.
+func Bootstrap(ctx context.Context, params RunParams) (*Container, error) {
+	if err := loadEnvFile(params.EnvFile); err != nil {
+		return nil, errors.WithMessage(err, "failed to load env file")
 	}
 
-	if err := loadLegacyEnv(runParams.LegacyEnvFile); err != nil {
+	if err := loadLegacyEnv(params.LegacyEnvFile); err != nil {
 		// Log the error but continue execution
 		slog.Error("Failed to load legacy env file", slog.String("error", err.Error()))
 	}
 
 	cfg, err := config.LoadConfig()
 	if err != nil {
-		slog.Error("Failed to load config", slog.String("error", err.Error()))
+		return nil, errors.WithMessage(err, "failed to load config")
+	}
 
-		os.Exit(1)
+	log, logCloser, err := logger.New(logger.Config{
+		Level:      cfg.Logger.Level,
+		Format:     cfg.Logger.Format,
+		File:       cfg.Logger.File,
+		MaxSize:    cfg.Logger.FileMaxSize,
+		MaxBackups: cfg.Logger.FileMaxBackups,
+		MaxAge:     cfg.Logger.FileMaxAge,
+		Compress:   cfg.Logger.FileCompress,
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create logger")
+	}
 
-		return
+	slog.SetDefault(log)
+
+	container := NewContainer(cfg)
+	container.SetContext(ctx)
+	container.appendShutdownFunc(logCloser.Close)
+
+	return container, nil
+}
+
+// Migrate applies the pending migrations and seeds the initial data, like the roles and the admin user.
+func Migrate(ctx context.Context, c *Container) error {
+	if err := migrations.Run(ctx, c); err != nil {
+		return errors.WithMessage(err, "failed to run migrations")
+	}
+
+	if err := seed(ctx, c); err != nil {
+		return errors.WithMessage(err, "failed to seed database")
 	}
 
-	logLevel := slog.LevelInfo
+	return nil
+}
 
-	switch cfg.Logger.Level {
-	case "debug":
-		logLevel = slog.LevelDebug
-	case "info":
-		logLevel = slog.LevelInfo
-	case "error":
-		logLevel = slog.LevelError
+//nolint:funlen
+func Run(runParams RunParams) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	container, err := Bootstrap(ctx, runParams)
+	if err != nil {
+		slog.Error("Failed to bootstrap application", slog.String("error", err.Error()))
+
+		cancel()
+		os.Exit(1)
+
+		return
 	}
 
-	slog.SetLogLoggerLevel(logLevel)
+	cfg := container.Config()
 
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt)
 
-	ctx, cancel := context.WithCancel(context.Background())
-
-	container := NewContainer(cfg)
-	container.SetContext(ctx)
+	// The server stops accepting connections at the beginning of the shutdown,
+	// the process exits only after the background operations are finished
+	shutdownDone := make(chan struct{})
 
 	go func() {
+		defer close(shutdownDone)
+
 		oscall := <-c
 
 		slog.Info("Got signal: " + oscall.String())
@@ -82,24 +115,26 @@ func Run(runParams RunParams) {
 		}
 	}()
 
-	err = migrations.Run(ctx, container)
-	if err != nil {
-		slog.ErrorContext(
-			ctx,
-			"Failed to run migrations",
-			slog.String("error", err.Error()),
-		)
+	if cfg.Tracing.Enabled {
+		err = container.SetupTracing(ctx)
+		if err != nil {
+			slog.ErrorContext(
+				ctx,
+				"Failed to setup tracing",
+				slog.String("error", err.Error()),
+			)
 
-		os.Exit(1)
+			os.Exit(1)
 
-		return
+			return
+		}
 	}
 
-	err = seed(ctx, container)
+	err = Migrate(ctx, container)
 	if err != nil {
 		slog.ErrorContext(
 			ctx,
-			"Failed to seed database",
+			"Failed to migrate database",
 			slog.String("error", err.Error()),
 		)
 
@@ -108,6 +143,30 @@ func Run(runParams RunParams) {
 		return
 	}
 
+	if cfg.ServerExpiration.Enabled {
+		go container.ServerExpirationWorker().Run(ctx)
+	}
+
+	if cfg.ServerTaskScheduler.Enabled {
+		go container.ServerTaskSchedulerWorker().Run(ctx)
+	}
+
+	if cfg.ServerQueryPoller.Enabled {
+		go container.ServerQueryPoller().Run(ctx)
+	}
+
+	if cfg.ServerWatchdog.Enabled {
+		go container.ServerWatchdogWorker().Run(ctx)
+	}
+
+	go container.ServerMoveWorker().Run(ctx)
+	go container.MetricsWorker().Run(ctx)
+	go container.WebhooksWorker().Run(ctx)
+
+	if cfg.LDAP.URL != "" {
+		go container.LDAPWorker().Run(ctx)
+	}
+
 	slog.InfoContext(
 		ctx,
 		"GameAP started",
@@ -131,6 +190,8 @@ func Run(runParams RunParams) {
 
 		return
 	}
+
+	<-shutdownDone
 }
 
 func startHTTPSServer(ctx context.Context, cfg *config.Config, container *Container) {
@@ -5,7 +5,9 @@ import (
 	"database/sql"
 	"log/slog"
 	"net/http"
+	netmail "net/mail"
 	"path"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -19,7 +21,12 @@ import (
 	"github.com/gameap/gameap/internal/certificates"
 	"github.com/gameap/gameap/internal/config"
 	"github.com/gameap/gameap/internal/daemon"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/events"
 	"github.com/gameap/gameap/internal/files"
+	"github.com/gameap/gameap/internal/i18n"
+	"github.com/gameap/gameap/internal/monitoring"
+	"github.com/gameap/gameap/internal/pubsub"
 	"github.com/gameap/gameap/internal/rbac"
 	"github.com/gameap/gameap/internal/repositories"
 	"github.com/gameap/gameap/internal/repositories/base"
@@ -29,9 +36,31 @@ import (
 	"github.com/gameap/gameap/internal/repositories/postgres"
 	"github.com/gameap/gameap/internal/repositories/sqlite"
 	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/audit"
+	"github.com/gameap/gameap/internal/services/authenticator"
+	"github.com/gameap/gameap/internal/services/backup"
+	"github.com/gameap/gameap/internal/services/daemontaskoutput"
+	"github.com/gameap/gameap/internal/services/ldap"
+	"github.com/gameap/gameap/internal/services/mail"
+	"github.com/gameap/gameap/internal/services/metrics"
+	"github.com/gameap/gameap/internal/services/notifications"
+	"github.com/gameap/gameap/internal/services/oidc"
+	"github.com/gameap/gameap/internal/services/passwordreset"
+	"github.com/gameap/gameap/internal/services/serverclone"
+	"github.com/gameap/gameap/internal/services/serverconsole"
 	"github.com/gameap/gameap/internal/services/servercontrol"
+	"github.com/gameap/gameap/internal/services/serverexpiration"
+	"github.com/gameap/gameap/internal/services/servermove"
+	"github.com/gameap/gameap/internal/services/serverports"
+	"github.com/gameap/gameap/internal/services/serverquery"
+	"github.com/gameap/gameap/internal/services/servertaskscheduler"
+	"github.com/gameap/gameap/internal/services/serverwatchdog"
+	"github.com/gameap/gameap/internal/services/twofactor"
+	"github.com/gameap/gameap/internal/services/webhooks"
+	"github.com/gameap/gameap/internal/tracing"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
+	"github.com/gameap/gameap/pkg/quercon/query"
 	"github.com/pkg/errors"
 )
 
@@ -53,6 +82,8 @@ const (
 	httpServerWriteTimeout = 15 * time.Second
 	httpServerReadTimeout  = 15 * time.Second
 	httpServerIdleTimeout  = 60 * time.Second
+
+	tracingShutdownTimeout = 10 * time.Second
 )
 
 type Container struct {
@@ -77,6 +108,19 @@ type Container struct {
 	serverSettingRepository       repositories.ServerSettingRepository
 	nodeRepository                repositories.NodeRepository
 	clientCertificateRepository   repositories.ClientCertificateRepository
+	backupRepository              repositories.BackupRepository
+	serverTemplateRepository      repositories.ServerTemplateRepository
+	serverResourceUsageRepository repositories.ServerResourceUsageRepository
+	metricRepository              repositories.MetricRepository
+	serverQueryRecordRepository   repositories.ServerQueryRecordRepository
+	serverAutoRestartRepository   repositories.ServerAutoRestartRepository
+	webhookRepository             repositories.WebhookRepository
+	webhookDeliveryRepository     repositories.WebhookDeliveryRepository
+	notificationChannelRepository repositories.NotificationChannelRepository
+	passwordResetRepository       repositories.PasswordResetRepository
+	userTwoFactorRepository       repositories.UserTwoFactorRepository
+	userIdentityRepository        repositories.UserIdentityRepository
+	auditLogRepository            repositories.AuditLogRepository
 
 	// Services
 	authService          auth.Service
@@ -86,8 +130,38 @@ type Container struct {
 	gameUpgrader         *services.GameUpgradeService
 	rbac                 *rbac.RBAC
 	cache                cache.Cache
+	pubSub               pubsub.PubSub
 	fileManager          files.FileManager
 	certificatesService  *certificates.Service
+	serverConsoleHub     *serverconsole.Hub
+	daemonTaskOutput     *daemontaskoutput.Broadcaster
+	backupService        *backup.Service
+	serverMoveService    *servermove.Service
+	serverCloneService   *serverclone.Service
+	serverPortsService   *serverports.Service
+	metricsService       *metrics.Service
+	eventBus             *events.Bus
+	webhooksService      *webhooks.Service
+	translator           *i18n.Translator
+	notificationsService *notifications.Service
+	mailer               mail.Mailer
+	passwordResetService *passwordreset.Service
+	twoFactorService     *twofactor.Service
+	oidcService          *oidc.Service
+	ldapClient           *ldap.Client
+	authenticator        authenticator.Authenticator
+	auditService         *audit.Service
+	monitoring           *monitoring.Metrics
+
+	// Workers
+	serverExpirationWorker    *serverexpiration.Worker
+	serverTaskSchedulerWorker *servertaskscheduler.Worker
+	serverMoveWorker          *servermove.Worker
+	metricsWorker             *metrics.Worker
+	serverQueryPoller         *serverquery.Poller
+	serverWatchdogWorker      *serverwatchdog.Worker
+	webhooksWorker            *webhooks.Worker
+	ldapWorker                *ldap.Worker
 
 	// Daemon Services
 	daemonStatus   *daemon.StatusService
@@ -114,8 +188,11 @@ func (c *Container) SetContext(ctx context.Context) {
 	c.context = ctx
 }
 
+// Shutdown runs the shutdown functions in the reverse order, like deferred calls.
+// The services are created after their dependencies, so, for example, the background operations
+// are finished before the database is closed.
 func (c *Container) Shutdown() error {
-	for _, fn := range c.shotdownFuncs {
+	for _, fn := range slices.Backward(c.shotdownFuncs) {
 		if err := fn(); err != nil {
 			slog.Error(
 				"failed to execute shutdown function",
@@ -131,6 +208,30 @@ func (c *Container) appendShutdownFunc(fn func() error) {
 	c.shotdownFuncs = append(c.shotdownFuncs, fn)
 }
 
+// SetupTracing installs the tracer provider exporting the spans to the configured collector.
+// The pending spans are flushed on shutdown.
+func (c *Container) SetupTracing(ctx context.Context) error {
+	shutdown, err := tracing.Setup(ctx, tracing.Config{
+		Endpoint:    c.config.Tracing.Endpoint,
+		Insecure:    c.config.Tracing.Insecure,
+		Headers:     c.config.Tracing.Headers,
+		ServiceName: c.config.Tracing.ServiceName,
+		SampleRatio: c.config.Tracing.SampleRatio,
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed to setup tracing")
+	}
+
+	c.appendShutdownFunc(func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+		defer cancel()
+
+		return shutdown(ctx)
+	})
+
+	return nil
+}
+
 func (c *Container) Config() *config.Config {
 	return c.config
 }
@@ -173,6 +274,10 @@ func (c *Container) TransactionalDB() base.DB {
 		if c.config.Logger.LogDBQueries {
 			c.transactionalDB = base.NewDBLogWrapper(c.transactionalDB)
 		}
+
+		if c.config.Tracing.Enabled {
+			c.transactionalDB = base.NewDBTraceWrapper(c.transactionalDB, c.config.DatabaseDriver)
+		}
 	}
 
 	return c.transactionalDB
@@ -426,10 +531,610 @@ func (c *Container) createServerControlService() *servercontrol.Service {
 	return servercontrol.NewService(
 		c.DaemonTaskRepository(),
 		c.ServerSettingRepository(),
+		c.NodeRepository(),
+		c.TransactionManager(),
+	)
+}
+
+func (c *Container) ServerConsoleHub() *serverconsole.Hub {
+	if c.serverConsoleHub == nil {
+		c.serverConsoleHub = c.createServerConsoleHub()
+	}
+
+	return c.serverConsoleHub
+}
+
+func (c *Container) createServerConsoleHub() *serverconsole.Hub {
+	pollInterval, err := time.ParseDuration(c.config.ConsoleStream.PollInterval)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid console stream poll interval"))
+	}
+
+	return serverconsole.NewHub(
+		serverconsole.NewReader(c.NodeRepository(), c.DaemonCommands(), c.DaemonFiles()),
+		pollInterval,
+	)
+}
+
+func (c *Container) BackupService() *backup.Service {
+	if c.backupService == nil {
+		c.backupService = c.createBackupService()
+	}
+
+	return c.backupService
+}
+
+func (c *Container) createBackupService() *backup.Service {
+	var maxAge time.Duration
+	if c.config.Backups.MaxAge != "" {
+		var err error
+
+		maxAge, err = time.ParseDuration(c.config.Backups.MaxAge)
+		if err != nil {
+			panic(errors.WithMessage(err, "invalid backups max age"))
+		}
+	}
+
+	timeout, err := time.ParseDuration(c.config.Backups.Timeout)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid backups timeout"))
+	}
+
+	service := backup.NewService(
+		c.BackupRepository(),
+		c.ServerSettingRepository(),
+		c.NodeRepository(),
+		c.DaemonCommands(),
+		c.DaemonFiles(),
+		c.FileManager(),
+		domain.BackupRetentionPolicy{
+			KeepLast: c.config.Backups.KeepLast,
+			MaxAge:   maxAge,
+		},
+		timeout,
+		c.EventBus(),
+	)
+
+	c.appendShutdownFunc(func() error {
+		service.Stop()
+
+		return nil
+	})
+
+	return service
+}
+
+func (c *Container) ServerMoveService() *servermove.Service {
+	if c.serverMoveService == nil {
+		c.serverMoveService = c.createServerMoveService()
+	}
+
+	return c.serverMoveService
+}
+
+func (c *Container) createServerMoveService() *servermove.Service {
+	return servermove.NewService(
+		c.DaemonTaskRepository(),
+		c.ServerRepository(),
+		c.NodeRepository(),
+		c.TransactionManager(),
+	)
+}
+
+func (c *Container) ServerCloneService() *serverclone.Service {
+	if c.serverCloneService == nil {
+		c.serverCloneService = c.createServerCloneService()
+	}
+
+	return c.serverCloneService
+}
+
+func (c *Container) createServerCloneService() *serverclone.Service {
+	return serverclone.NewService(
+		c.ServerRepository(),
+		c.ServerSettingRepository(),
+		c.ServerTemplateRepository(),
+		c.NodeRepository(),
+		c.DaemonTaskRepository(),
+		c.ServerMoveService(),
+		c.ServerPortsService(),
+		c.EventBus(),
+	)
+}
+
+func (c *Container) ServerPortsService() *serverports.Service {
+	if c.serverPortsService == nil {
+		c.serverPortsService = c.createServerPortsService()
+	}
+
+	return c.serverPortsService
+}
+
+func (c *Container) createServerPortsService() *serverports.Service {
+	lockTimeout, err := time.ParseDuration(c.config.ServerPorts.LockTimeout)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid server ports lock timeout"))
+	}
+
+	return serverports.NewService(
+		c.ServerRepository(),
+		c.Cache(),
+		c.TransactionManager(),
+		domain.PortRange{
+			Start: c.config.ServerPorts.RangeStart,
+			End:   c.config.ServerPorts.RangeEnd,
+		},
+		lockTimeout,
+	)
+}
+
+func (c *Container) MetricsService() *metrics.Service {
+	if c.metricsService == nil {
+		c.metricsService = metrics.NewService(c.MetricRepository(), c.MetricsRetention())
+	}
+
+	return c.metricsService
+}
+
+func (c *Container) MetricsRetention() metrics.Retention {
+	raw, err := time.ParseDuration(c.config.Metrics.RawRetention)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid raw metrics retention"))
+	}
+
+	minute, err := time.ParseDuration(c.config.Metrics.MinuteRetention)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid minute metrics retention"))
+	}
+
+	hour, err := time.ParseDuration(c.config.Metrics.HourRetention)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid hour metrics retention"))
+	}
+
+	return metrics.Retention{
+		Raw:    raw,
+		Minute: minute,
+		Hour:   hour,
+	}
+}
+
+func (c *Container) AutoRestartPolicy() domain.AutoRestartPolicy {
+	threshold, err := time.ParseDuration(c.config.ServerWatchdog.Threshold)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid server watchdog threshold"))
+	}
+
+	baseDelay, err := time.ParseDuration(c.config.ServerWatchdog.BaseDelay)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid server watchdog base delay"))
+	}
+
+	maxDelay, err := time.ParseDuration(c.config.ServerWatchdog.MaxDelay)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid server watchdog max delay"))
+	}
+
+	stablePeriod, err := time.ParseDuration(c.config.ServerWatchdog.StablePeriod)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid server watchdog stable period"))
+	}
+
+	return domain.AutoRestartPolicy{
+		Threshold:    threshold,
+		BaseDelay:    baseDelay,
+		MaxDelay:     maxDelay,
+		MaxAttempts:  c.config.ServerWatchdog.MaxAttempts,
+		StablePeriod: stablePeriod,
+	}
+}
+
+// EventBus publishes the panel events. The webhooks and notifications services are subscribed to it.
+func (c *Container) EventBus() *events.Bus {
+	if c.eventBus == nil {
+		c.eventBus = events.NewBus()
+		c.eventBus.Subscribe(c.WebhooksService())
+		c.eventBus.Subscribe(c.NotificationsService())
+		c.eventBus.Subscribe(c.ServerControlService())
+	}
+
+	return c.eventBus
+}
+
+func (c *Container) WebhooksService() *webhooks.Service {
+	if c.webhooksService == nil {
+		c.webhooksService = c.createWebhooksService()
+	}
+
+	return c.webhooksService
+}
+
+func (c *Container) createWebhooksService() *webhooks.Service {
+	return webhooks.NewService(
+		c.WebhookRepository(),
+		c.WebhookDeliveryRepository(),
+		c.ServerRepository(),
+		c.RBAC(),
+		c.WebhookSender(),
+	)
+}
+
+func (c *Container) AuditService() *audit.Service {
+	if c.auditService == nil {
+		c.auditService = audit.NewService(c.AuditLogRepository(), audit.Repositories{
+			Servers:              c.ServerRepository(),
+			Nodes:                c.NodeRepository(),
+			Users:                c.UserRepository(),
+			RBAC:                 c.RBACRepository(),
+			Games:                c.GameRepository(),
+			GameMods:             c.GameModRepository(),
+			ClientCertificates:   c.ClientCertificateRepository(),
+			ServerTemplates:      c.ServerTemplateRepository(),
+			Webhooks:             c.WebhookRepository(),
+			NotificationChannels: c.NotificationChannelRepository(),
+			PersonalAccessTokens: c.PersonalAccessTokenRepository(),
+		})
+	}
+
+	return c.auditService
+}
+
+func (c *Container) Monitoring() *monitoring.Metrics {
+	if c.monitoring == nil {
+		// The metrics are set before the collectors are created,
+		// so the dependencies of the collectors like the cache can observe them
+		c.monitoring = monitoring.NewMetrics()
+		c.registerMonitoringCollectors()
+	}
+
+	return c.monitoring
+}
+
+func (c *Container) registerMonitoringCollectors() {
+	timeout, err := time.ParseDuration(c.config.Prometheus.CollectTimeout)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid prometheus collect timeout"))
+	}
+
+	err = c.monitoring.Register(
+		monitoring.NewDaemonPoolCollector(map[string]monitoring.PoolStatsProvider{
+			"status":   c.DaemonStatus(),
+			"files":    c.DaemonFiles(),
+			"commands": c.DaemonCommands(),
+		}),
+		monitoring.NewDaemonTaskCollector(c.DaemonTaskRepository(), timeout),
+		monitoring.NewServerCollector(c.ServerRepository(), timeout),
+	)
+	if err != nil {
+		panic(errors.WithMessage(err, "failed to register monitoring collectors"))
+	}
+
+	if c.config.DatabaseDriver == databaseDriverInMemory {
+		return
+	}
+
+	err = c.monitoring.Register(monitoring.NewDBStatsCollector(c.DB(), c.config.DatabaseDriver))
+	if err != nil {
+		panic(errors.WithMessage(err, "failed to register database collector"))
+	}
+}
+
+func (c *Container) Translator() *i18n.Translator {
+	if c.translator == nil {
+		translator, err := i18n.NewTranslator()
+		if err != nil {
+			panic(errors.WithMessage(err, "failed to create translator"))
+		}
+
+		c.translator = translator
+	}
+
+	return c.translator
+}
+
+func (c *Container) NotificationsService() *notifications.Service {
+	if c.notificationsService == nil {
+		c.notificationsService = c.createNotificationsService()
+	}
+
+	return c.notificationsService
+}
+
+func (c *Container) createNotificationsService() *notifications.Service {
+	timeout, err := time.ParseDuration(c.config.Notifications.Timeout)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid notifications timeout"))
+	}
+
+	client := &http.Client{
+		Timeout: timeout,
+	}
+
+	return notifications.NewService(
+		c.NotificationChannelRepository(),
+		c.ServerRepository(),
+		c.RBAC(),
+		c.Translator(),
+		map[domain.NotificationChannelType]notifications.Sender{
+			domain.NotificationChannelTypeDiscord: notifications.NewDiscordSender(client),
+			domain.NotificationChannelTypeTelegram: notifications.NewTelegramSender(
+				client,
+				c.config.Notifications.TelegramAPIURL,
+				c.config.Notifications.TelegramBotToken,
+			),
+		},
+		timeout,
+	)
+}
+
+// Mailer returns nil when the SMTP host or the from address isn't configured.
+func (c *Container) Mailer() mail.Mailer {
+	if c.mailer == nil && c.config.Mail.Host != "" {
+		c.mailer = c.createMailer()
+	}
+
+	return c.mailer
+}
+
+func (c *Container) createMailer() mail.Mailer {
+	timeout, err := time.ParseDuration(c.config.Mail.Timeout)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid mail timeout"))
+	}
+
+	encryption := mail.Encryption(strings.ToLower(c.config.Mail.Encryption))
+	if !encryption.Valid() {
+		panic(errors.Errorf("invalid mail encryption %q", c.config.Mail.Encryption))
+	}
+
+	if c.config.Mail.FromAddress == "" {
+		slog.Warn("Mail from address is not configured, emails are disabled")
+
+		return nil
+	}
+
+	from, err := netmail.ParseAddress(c.config.Mail.FromAddress)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid mail from address"))
+	}
+
+	from.Name = c.config.Mail.FromName
+
+	return mail.NewSMTPMailer(mail.SMTPConfig{
+		Host:       c.config.Mail.Host,
+		Port:       c.config.Mail.Port,
+		Username:   c.config.Mail.Username,
+		Password:   c.config.Mail.Password,
+		Encryption: encryption,
+		From:       *from,
+		Timeout:    timeout,
+	})
+}
+
+func (c *Container) PasswordResetService() *passwordreset.Service {
+	if c.passwordResetService == nil {
+		c.passwordResetService = c.createPasswordResetService()
+	}
+
+	return c.passwordResetService
+}
+
+func (c *Container) createPasswordResetService() *passwordreset.Service {
+	ttl, err := time.ParseDuration(c.config.PasswordReset.TTL)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid password reset ttl"))
+	}
+
+	throttle, err := time.ParseDuration(c.config.PasswordReset.Throttle)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid password reset throttle"))
+	}
+
+	window, err := time.ParseDuration(c.config.PasswordReset.RateLimitWindow)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid password reset rate limit window"))
+	}
+
+	service := passwordreset.NewService(
+		c.UserRepository(),
+		c.PasswordResetRepository(),
+		c.Mailer(),
+		c.Translator(),
+		cache.NewRateLimiter(c.Cache(), "password_reset", c.config.PasswordReset.MaxAttempts, window),
+		passwordreset.Config{
+			URL:      c.config.PasswordReset.URL,
+			TTL:      ttl,
+			Throttle: throttle,
+		},
+	)
+
+	c.appendShutdownFunc(func() error {
+		service.Wait()
+
+		return nil
+	})
+
+	return service
+}
+
+func (c *Container) TwoFactorService() *twofactor.Service {
+	if c.twoFactorService == nil {
+		c.twoFactorService = c.createTwoFactorService()
+	}
+
+	return c.twoFactorService
+}
+
+func (c *Container) createTwoFactorService() *twofactor.Service {
+	challengeTTL, err := time.ParseDuration(c.config.TwoFactor.ChallengeTTL)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid two-factor challenge ttl"))
+	}
+
+	window, err := time.ParseDuration(c.config.TwoFactor.RateLimitWindow)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid two-factor rate limit window"))
+	}
+
+	return twofactor.NewService(
+		c.UserTwoFactorRepository(),
+		c.UserRepository(),
+		c.RBAC(),
+		c.Cache(),
+		cache.NewRateLimiter(c.Cache(), "two_factor", c.config.TwoFactor.MaxAttempts, window),
+		twofactor.Config{
+			Issuer:        c.config.TwoFactor.Issuer,
+			EnforceAdmins: c.config.TwoFactor.EnforceAdmins,
+			ChallengeTTL:  challengeTTL,
+		},
+	)
+}
+
+func (c *Container) OIDCService() *oidc.Service {
+	if c.oidcService == nil {
+		c.oidcService = c.createOIDCService()
+	}
+
+	return c.oidcService
+}
+
+func (c *Container) createOIDCService() *oidc.Service {
+	stateTTL, err := time.ParseDuration(c.config.OIDC.StateTTL)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid oidc state ttl"))
+	}
+
+	timeout, err := time.ParseDuration(c.config.OIDC.Timeout)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid oidc timeout"))
+	}
+
+	providers := make([]oidc.ProviderConfig, 0, len(c.config.OIDC.Providers))
+	for _, p := range c.config.OIDC.Providers {
+		providers = append(providers, oidc.ProviderConfig{
+			Name:          p.Name,
+			DisplayName:   p.DisplayName,
+			IssuerURL:     p.IssuerURL,
+			ClientID:      p.ClientID,
+			ClientSecret:  p.ClientSecret,
+			RedirectURL:   p.RedirectURL,
+			Scopes:        p.Scopes,
+			AutoProvision: p.AutoProvision,
+			LinkByEmail:   p.LinkByEmail,
+			RolesClaim:    p.RolesClaim,
+			RoleMapping:   p.RoleMapping,
+			DefaultRoles:  p.DefaultRoles,
+		})
+	}
+
+	return oidc.NewService(
+		providers,
+		&http.Client{Timeout: timeout},
+		c.UserIdentityRepository(),
+		c.UserRepository(),
+		c.RBAC(),
 		c.TransactionManager(),
+		c.Cache(),
+		oidc.Config{StateTTL: stateTTL},
 	)
 }
 
+// Authenticator checks the login passwords, the local passwords are checked first and then the LDAP directory.
+func (c *Container) Authenticator() authenticator.Authenticator {
+	if c.authenticator == nil {
+		c.authenticator = c.createAuthenticator()
+	}
+
+	return c.authenticator
+}
+
+func (c *Container) createAuthenticator() authenticator.Authenticator {
+	chain := authenticator.NewChain(authenticator.NewLocal(c.UserService()))
+
+	if c.config.LDAP.URL != "" {
+		chain = append(chain, ldap.NewService(
+			c.LDAPClient(),
+			c.UserIdentityRepository(),
+			c.UserService(),
+			c.RBAC(),
+			c.TransactionManager(),
+			ldap.Config{
+				RoleMapping:       c.config.LDAP.RoleMapping,
+				DefaultRoles:      c.config.LDAP.DefaultRoles,
+				LinkExistingUsers: c.config.LDAP.LinkExistingUsers,
+				LinkByEmail:       c.config.LDAP.LinkByEmail,
+			},
+		))
+	}
+
+	return chain
+}
+
+func (c *Container) LDAPClient() *ldap.Client {
+	if c.ldapClient == nil {
+		c.ldapClient = c.createLDAPClient()
+	}
+
+	return c.ldapClient
+}
+
+func (c *Container) createLDAPClient() *ldap.Client {
+	timeout, err := time.ParseDuration(c.config.LDAP.Timeout)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid ldap timeout"))
+	}
+
+	return ldap.NewClient(ldap.ClientConfig{
+		URL:                c.config.LDAP.URL,
+		StartTLS:           c.config.LDAP.StartTLS,
+		InsecureSkipVerify: c.config.LDAP.InsecureSkipVerify,
+		BindDN:             c.config.LDAP.BindDN,
+		BindPassword:       c.config.LDAP.BindPassword,
+		BaseDN:             c.config.LDAP.BaseDN,
+		UserFilter:         c.config.LDAP.UserFilter,
+		LoginAttribute:     c.config.LDAP.LoginAttribute,
+		EmailAttribute:     c.config.LDAP.EmailAttribute,
+		NameAttribute:      c.config.LDAP.NameAttribute,
+		GroupAttribute:     c.config.LDAP.GroupAttribute,
+		Timeout:            timeout,
+	})
+}
+
+func (c *Container) WebhookSender() *webhooks.Sender {
+	timeout, err := time.ParseDuration(c.config.Webhooks.Timeout)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid webhooks timeout"))
+	}
+
+	return webhooks.NewSender(webhooks.NewHTTPClient(timeout, c.config.Webhooks.AllowPrivateNetworks))
+}
+
+func (c *Container) WebhookRetryPolicy() domain.WebhookRetryPolicy {
+	baseDelay, err := time.ParseDuration(c.config.Webhooks.BaseDelay)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid webhooks base delay"))
+	}
+
+	maxDelay, err := time.ParseDuration(c.config.Webhooks.MaxDelay)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid webhooks max delay"))
+	}
+
+	return domain.WebhookRetryPolicy{
+		BaseDelay:   baseDelay,
+		MaxDelay:    maxDelay,
+		MaxAttempts: c.config.Webhooks.MaxAttempts,
+	}
+}
+
+func (c *Container) DaemonTaskOutputBroadcaster() *daemontaskoutput.Broadcaster {
+	if c.daemonTaskOutput == nil {
+		c.daemonTaskOutput = daemontaskoutput.NewBroadcaster(c.PubSub())
+	}
+
+	return c.daemonTaskOutput
+}
+
 func (c *Container) AuthService() auth.Service {
 	if c.authService == nil {
 		c.authService = c.createAuthService()
@@ -620,7 +1325,319 @@ func (c *Container) createServerTaskFailRepository() repositories.ServerTaskFail
 	}
 }
 
-func (c *Container) ServerSettingRepository() repositories.ServerSettingRepository {
+func (c *Container) BackupRepository() repositories.BackupRepository {
+	if c.backupRepository == nil {
+		c.backupRepository = c.createBackupRepository()
+	}
+
+	return c.backupRepository
+}
+
+func (c *Container) createBackupRepository() repositories.BackupRepository {
+	switch c.config.DatabaseDriver {
+	case databaseDriverMySQL:
+		return mysql.NewBackupRepository(c.TransactionalDB())
+	case databaseDriverPostgres, databaseDriverPGX:
+		return postgres.NewBackupRepository(c.TransactionalDB())
+	case databaseDriverSQLite:
+		return sqlite.NewBackupRepository(c.TransactionalDB())
+	case databaseDriverInMemory:
+		return inmemory.NewBackupRepository()
+	default:
+		// Use in-memory repository as fallback
+		return inmemory.NewBackupRepository()
+	}
+}
+
+func (c *Container) ServerTemplateRepository() repositories.ServerTemplateRepository {
+	if c.serverTemplateRepository == nil {
+		c.serverTemplateRepository = c.createServerTemplateRepository()
+	}
+
+	return c.serverTemplateRepository
+}
+
+func (c *Container) createServerTemplateRepository() repositories.ServerTemplateRepository {
+	switch c.config.DatabaseDriver {
+	case databaseDriverMySQL:
+		return mysql.NewServerTemplateRepository(c.TransactionalDB())
+	case databaseDriverPostgres, databaseDriverPGX:
+		return postgres.NewServerTemplateRepository(c.TransactionalDB())
+	case databaseDriverSQLite:
+		return sqlite.NewServerTemplateRepository(c.TransactionalDB())
+	case databaseDriverInMemory:
+		return inmemory.NewServerTemplateRepository()
+	default:
+		// Use in-memory repository as fallback
+		return inmemory.NewServerTemplateRepository()
+	}
+}
+
+func (c *Container) ServerResourceUsageRepository() repositories.ServerResourceUsageRepository {
+	if c.serverResourceUsageRepository == nil {
+		c.serverResourceUsageRepository = c.createServerResourceUsageRepository()
+	}
+
+	return c.serverResourceUsageRepository
+}
+
+func (c *Container) createServerResourceUsageRepository() repositories.ServerResourceUsageRepository {
+	switch c.config.DatabaseDriver {
+	case databaseDriverMySQL:
+		return mysql.NewServerResourceUsageRepository(c.TransactionalDB())
+	case databaseDriverPostgres, databaseDriverPGX:
+		return postgres.NewServerResourceUsageRepository(c.TransactionalDB())
+	case databaseDriverSQLite:
+		return sqlite.NewServerResourceUsageRepository(c.TransactionalDB())
+	case databaseDriverInMemory:
+		return inmemory.NewServerResourceUsageRepository()
+	default:
+		// Use in-memory repository as fallback
+		return inmemory.NewServerResourceUsageRepository()
+	}
+}
+
+func (c *Container) MetricRepository() repositories.MetricRepository {
+	if c.metricRepository == nil {
+		c.metricRepository = c.createMetricRepository()
+	}
+
+	return c.metricRepository
+}
+
+func (c *Container) createMetricRepository() repositories.MetricRepository {
+	switch c.config.DatabaseDriver {
+	case databaseDriverMySQL:
+		return mysql.NewMetricRepository(c.TransactionalDB())
+	case databaseDriverPostgres, databaseDriverPGX:
+		return postgres.NewMetricRepository(c.TransactionalDB())
+	case databaseDriverSQLite:
+		return sqlite.NewMetricRepository(c.TransactionalDB())
+	case databaseDriverInMemory:
+		return inmemory.NewMetricRepository()
+	default:
+		// Use in-memory repository as fallback
+		return inmemory.NewMetricRepository()
+	}
+}
+
+func (c *Container) ServerQueryRecordRepository() repositories.ServerQueryRecordRepository {
+	if c.serverQueryRecordRepository == nil {
+		c.serverQueryRecordRepository = c.createServerQueryRecordRepository()
+	}
+
+	return c.serverQueryRecordRepository
+}
+
+func (c *Container) createServerQueryRecordRepository() repositories.ServerQueryRecordRepository {
+	switch c.config.DatabaseDriver {
+	case databaseDriverMySQL:
+		return mysql.NewServerQueryRecordRepository(c.TransactionalDB())
+	case databaseDriverPostgres, databaseDriverPGX:
+		return postgres.NewServerQueryRecordRepository(c.TransactionalDB())
+	case databaseDriverSQLite:
+		return sqlite.NewServerQueryRecordRepository(c.TransactionalDB())
+	case databaseDriverInMemory:
+		return inmemory.NewServerQueryRecordRepository()
+	default:
+		// Use in-memory repository as fallback
+		return inmemory.NewServerQueryRecordRepository()
+	}
+}
+
+func (c *Container) ServerAutoRestartRepository() repositories.ServerAutoRestartRepository {
+	if c.serverAutoRestartRepository == nil {
+		c.serverAutoRestartRepository = c.createServerAutoRestartRepository()
+	}
+
+	return c.serverAutoRestartRepository
+}
+
+func (c *Container) createServerAutoRestartRepository() repositories.ServerAutoRestartRepository {
+	switch c.config.DatabaseDriver {
+	case databaseDriverMySQL:
+		return mysql.NewServerAutoRestartRepository(c.TransactionalDB())
+	case databaseDriverPostgres, databaseDriverPGX:
+		return postgres.NewServerAutoRestartRepository(c.TransactionalDB())
+	case databaseDriverSQLite:
+		return sqlite.NewServerAutoRestartRepository(c.TransactionalDB())
+	case databaseDriverInMemory:
+		return inmemory.NewServerAutoRestartRepository()
+	default:
+		// Use in-memory repository as fallback
+		return inmemory.NewServerAutoRestartRepository()
+	}
+}
+
+func (c *Container) WebhookRepository() repositories.WebhookRepository {
+	if c.webhookRepository == nil {
+		c.webhookRepository = c.createWebhookRepository()
+	}
+
+	return c.webhookRepository
+}
+
+func (c *Container) createWebhookRepository() repositories.WebhookRepository {
+	switch c.config.DatabaseDriver {
+	case databaseDriverMySQL:
+		return mysql.NewWebhookRepository(c.TransactionalDB())
+	case databaseDriverPostgres, databaseDriverPGX:
+		return postgres.NewWebhookRepository(c.TransactionalDB())
+	case databaseDriverSQLite:
+		return sqlite.NewWebhookRepository(c.TransactionalDB())
+	case databaseDriverInMemory:
+		return inmemory.NewWebhookRepository()
+	default:
+		// Use in-memory repository as fallback
+		return inmemory.NewWebhookRepository()
+	}
+}
+
+func (c *Container) WebhookDeliveryRepository() repositories.WebhookDeliveryRepository {
+	if c.webhookDeliveryRepository == nil {
+		c.webhookDeliveryRepository = c.createWebhookDeliveryRepository()
+	}
+
+	return c.webhookDeliveryRepository
+}
+
+func (c *Container) createWebhookDeliveryRepository() repositories.WebhookDeliveryRepository {
+	switch c.config.DatabaseDriver {
+	case databaseDriverMySQL:
+		return mysql.NewWebhookDeliveryRepository(c.TransactionalDB())
+	case databaseDriverPostgres, databaseDriverPGX:
+		return postgres.NewWebhookDeliveryRepository(c.TransactionalDB())
+	case databaseDriverSQLite:
+		return sqlite.NewWebhookDeliveryRepository(c.TransactionalDB())
+	case databaseDriverInMemory:
+		return inmemory.NewWebhookDeliveryRepository()
+	default:
+		// Use in-memory repository as fallback
+		return inmemory.NewWebhookDeliveryRepository()
+	}
+}
+
+func (c *Container) NotificationChannelRepository() repositories.NotificationChannelRepository {
+	if c.notificationChannelRepository == nil {
+		c.notificationChannelRepository = c.createNotificationChannelRepository()
+	}
+
+	return c.notificationChannelRepository
+}
+
+func (c *Container) createNotificationChannelRepository() repositories.NotificationChannelRepository {
+	switch c.config.DatabaseDriver {
+	case databaseDriverMySQL:
+		return mysql.NewNotificationChannelRepository(c.TransactionalDB())
+	case databaseDriverPostgres, databaseDriverPGX:
+		return postgres.NewNotificationChannelRepository(c.TransactionalDB())
+	case databaseDriverSQLite:
+		return sqlite.NewNotificationChannelRepository(c.TransactionalDB())
+	case databaseDriverInMemory:
+		return inmemory.NewNotificationChannelRepository()
+	default:
+		// Use in-memory repository as fallback
+		return inmemory.NewNotificationChannelRepository()
+	}
+}
+
+func (c *Container) PasswordResetRepository() repositories.PasswordResetRepository {
+	if c.passwordResetRepository == nil {
+		c.passwordResetRepository = c.createPasswordResetRepository()
+	}
+
+	return c.passwordResetRepository
+}
+
+func (c *Container) createPasswordResetRepository() repositories.PasswordResetRepository {
+	switch c.config.DatabaseDriver {
+	case databaseDriverMySQL:
+		return mysql.NewPasswordResetRepository(c.TransactionalDB())
+	case databaseDriverPostgres, databaseDriverPGX:
+		return postgres.NewPasswordResetRepository(c.TransactionalDB())
+	case databaseDriverSQLite:
+		return sqlite.NewPasswordResetRepository(c.TransactionalDB())
+	case databaseDriverInMemory:
+		return inmemory.NewPasswordResetRepository()
+	default:
+		// Use in-memory repository as fallback
+		return inmemory.NewPasswordResetRepository()
+	}
+}
+
+func (c *Container) UserTwoFactorRepository() repositories.UserTwoFactorRepository {
+	if c.userTwoFactorRepository == nil {
+		c.userTwoFactorRepository = c.createUserTwoFactorRepository()
+	}
+
+	return c.userTwoFactorRepository
+}
+
+func (c *Container) createUserTwoFactorRepository() repositories.UserTwoFactorRepository {
+	switch c.config.DatabaseDriver {
+	case databaseDriverMySQL:
+		return mysql.NewUserTwoFactorRepository(c.TransactionalDB())
+	case databaseDriverPostgres, databaseDriverPGX:
+		return postgres.NewUserTwoFactorRepository(c.TransactionalDB())
+	case databaseDriverSQLite:
+		return sqlite.NewUserTwoFactorRepository(c.TransactionalDB())
+	case databaseDriverInMemory:
+		return inmemory.NewUserTwoFactorRepository()
+	default:
+		// Use in-memory repository as fallback
+		return inmemory.NewUserTwoFactorRepository()
+	}
+}
+
+func (c *Container) UserIdentityRepository() repositories.UserIdentityRepository {
+	if c.userIdentityRepository == nil {
+		c.userIdentityRepository = c.createUserIdentityRepository()
+	}
+
+	return c.userIdentityRepository
+}
+
+func (c *Container) createUserIdentityRepository() repositories.UserIdentityRepository {
+	switch c.config.DatabaseDriver {
+	case databaseDriverMySQL:
+		return mysql.NewUserIdentityRepository(c.TransactionalDB())
+	case databaseDriverPostgres, databaseDriverPGX:
+		return postgres.NewUserIdentityRepository(c.TransactionalDB())
+	case databaseDriverSQLite:
+		return sqlite.NewUserIdentityRepository(c.TransactionalDB())
+	case databaseDriverInMemory:
+		return inmemory.NewUserIdentityRepository()
+	default:
+		// Use in-memory repository as fallback
+		return inmemory.NewUserIdentityRepository()
+	}
+}
+
+func (c *Container) AuditLogRepository() repositories.AuditLogRepository {
+	if c.auditLogRepository == nil {
+		c.auditLogRepository = c.createAuditLogRepository()
+	}
+
+	return c.auditLogRepository
+}
+
+func (c *Container) createAuditLogRepository() repositories.AuditLogRepository {
+	switch c.config.DatabaseDriver {
+	case databaseDriverMySQL:
+		return mysql.NewAuditLogRepository(c.TransactionalDB())
+	case databaseDriverPostgres, databaseDriverPGX:
+		return postgres.NewAuditLogRepository(c.TransactionalDB())
+	case databaseDriverSQLite:
+		return sqlite.NewAuditLogRepository(c.TransactionalDB())
+	case databaseDriverInMemory:
+		return inmemory.NewAuditLogRepository()
+	default:
+		// Use in-memory repository as fallback
+		return inmemory.NewAuditLogRepository()
+	}
+}
+
+func (c *Container) ServerSettingRepository() repositories.ServerSettingRepository {
 	if c.serverSettingRepository == nil {
 		c.serverSettingRepository = c.createServerSettingRepository()
 	}
@@ -730,7 +1747,16 @@ func (c *Container) createClientCertificateRepository() repositories.ClientCerti
 
 func (c *Container) Cache() cache.Cache {
 	if c.cache == nil {
-		c.cache = c.createCache()
+		metrics := c.Monitoring()
+		driver := c.config.Cache.Driver
+
+		c.cache = cache.NewInstrumented(c.createCache(), func(hit bool) {
+			metrics.ObserveCacheRead(driver, hit)
+		})
+
+		if c.config.Tracing.Enabled {
+			c.cache = cache.NewTraced(c.cache, driver)
+		}
 	}
 
 	return c.cache
@@ -758,7 +1784,7 @@ func (c *Container) createCache() cache.Cache {
 		}
 
 		c.appendShutdownFunc(func() error {
-			if rc, ok := c.cache.(*cache.Redis); ok {
+			if rc, ok := cache.Unwrap(c.cache).(*cache.Redis); ok {
 				return rc.Close()
 			}
 
@@ -772,6 +1798,24 @@ func (c *Container) createCache() cache.Cache {
 	}
 }
 
+func (c *Container) PubSub() pubsub.PubSub {
+	if c.pubSub == nil {
+		c.pubSub = c.createPubSub()
+	}
+
+	return c.pubSub
+}
+
+// createPubSub uses Redis when it is the cache driver, so events are delivered across panel replicas.
+// Other cache drivers are not shared message brokers, events are delivered within the process only.
+func (c *Container) createPubSub() pubsub.PubSub {
+	if redisCache, ok := cache.Unwrap(c.Cache()).(*cache.Redis); ok {
+		return pubsub.NewRedis(redisCache.Client())
+	}
+
+	return pubsub.NewInMemory()
+}
+
 func (c *Container) FileManager() files.FileManager {
 	if c.fileManager == nil {
 		c.fileManager = c.createFileManager()
@@ -896,3 +1940,279 @@ func (c *Container) DaemonCommands() *daemon.CommandService {
 
 	return c.daemonCommands
 }
+
+func (c *Container) ServerExpirationPolicy() domain.ServerExpirationPolicy {
+	gracePeriod, err := time.ParseDuration(c.config.ServerExpiration.GracePeriod)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid server expiration grace period"))
+	}
+
+	var deleteAfter time.Duration
+
+	if c.config.ServerExpiration.DeleteAfter != "" {
+		deleteAfter, err = time.ParseDuration(c.config.ServerExpiration.DeleteAfter)
+		if err != nil {
+			panic(errors.WithMessage(err, "invalid server expiration delete after"))
+		}
+	}
+
+	return domain.ServerExpirationPolicy{
+		Enabled:     c.config.ServerExpiration.Enabled,
+		GracePeriod: gracePeriod,
+		DeleteAfter: deleteAfter,
+	}
+}
+
+func (c *Container) ServerExpirationWorker() *serverexpiration.Worker {
+	if c.serverExpirationWorker == nil {
+		c.serverExpirationWorker = c.createServerExpirationWorker()
+	}
+
+	return c.serverExpirationWorker
+}
+
+func (c *Container) createServerExpirationWorker() *serverexpiration.Worker {
+	interval, err := time.ParseDuration(c.config.ServerExpiration.CheckInterval)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid server expiration check interval"))
+	}
+
+	return serverexpiration.NewWorker(
+		c.ServerRepository(),
+		c.ServerControlService(),
+		c.ServerExpirationPolicy(),
+		interval,
+	)
+}
+
+func (c *Container) ServerTaskSchedulerWorker() *servertaskscheduler.Worker {
+	if c.serverTaskSchedulerWorker == nil {
+		c.serverTaskSchedulerWorker = c.createServerTaskSchedulerWorker()
+	}
+
+	return c.serverTaskSchedulerWorker
+}
+
+func (c *Container) createServerTaskSchedulerWorker() *servertaskscheduler.Worker {
+	interval, err := time.ParseDuration(c.config.ServerTaskScheduler.Interval)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid server task scheduler interval"))
+	}
+
+	takeoverDelay, err := time.ParseDuration(c.config.ServerTaskScheduler.TakeoverDelay)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid server task scheduler takeover delay"))
+	}
+
+	lockTTL, err := time.ParseDuration(c.config.ServerTaskScheduler.LockTTL)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid server task scheduler lock ttl"))
+	}
+
+	return servertaskscheduler.NewWorker(
+		c.ServerTaskRepository(),
+		c.ServerTaskFailRepository(),
+		c.ServerRepository(),
+		c.ServerControlService(),
+		c.BackupService(),
+		c.Cache(),
+		lockTTL,
+		interval,
+		takeoverDelay,
+	)
+}
+
+func (c *Container) ServerMoveWorker() *servermove.Worker {
+	if c.serverMoveWorker == nil {
+		c.serverMoveWorker = c.createServerMoveWorker()
+	}
+
+	return c.serverMoveWorker
+}
+
+func (c *Container) createServerMoveWorker() *servermove.Worker {
+	interval, err := time.ParseDuration(c.config.ServerMove.CheckInterval)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid server move check interval"))
+	}
+
+	timeout, err := time.ParseDuration(c.config.ServerMove.Timeout)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid server move timeout"))
+	}
+
+	return servermove.NewWorker(
+		c.DaemonTaskRepository(),
+		c.ServerRepository(),
+		c.NodeRepository(),
+		c.DaemonCommands(),
+		c.DaemonFiles(),
+		c.Cache(),
+		interval,
+		timeout,
+	)
+}
+
+func (c *Container) MetricsWorker() *metrics.Worker {
+	if c.metricsWorker == nil {
+		c.metricsWorker = c.createMetricsWorker()
+	}
+
+	return c.metricsWorker
+}
+
+func (c *Container) createMetricsWorker() *metrics.Worker {
+	interval, err := time.ParseDuration(c.config.Metrics.DownsampleInterval)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid metrics downsample interval"))
+	}
+
+	return metrics.NewWorker(
+		c.MetricRepository(),
+		c.MetricsRetention(),
+		interval,
+	)
+}
+
+func (c *Container) ServerQueryPoller() *serverquery.Poller {
+	if c.serverQueryPoller == nil {
+		c.serverQueryPoller = c.createServerQueryPoller()
+	}
+
+	return c.serverQueryPoller
+}
+
+func (c *Container) createServerQueryPoller() *serverquery.Poller {
+	interval, err := time.ParseDuration(c.config.ServerQueryPoller.Interval)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid server query poller interval"))
+	}
+
+	lockTTL, err := time.ParseDuration(c.config.ServerQueryPoller.LockTTL)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid server query poller lock ttl"))
+	}
+
+	var retention time.Duration
+	if c.config.ServerQueryPoller.Retention != "" {
+		retention, err = time.ParseDuration(c.config.ServerQueryPoller.Retention)
+		if err != nil {
+			panic(errors.WithMessage(err, "invalid server query poller retention"))
+		}
+	}
+
+	return serverquery.NewPoller(
+		c.ServerRepository(),
+		c.GameRepository(),
+		c.ServerQueryRecordRepository(),
+		serverquery.QuerierFunc(query.Query),
+		c.MetricsService(),
+		c.Cache(),
+		lockTTL,
+		interval,
+		c.config.ServerQueryPoller.Concurrency,
+		c.config.ServerQueryPoller.NodeRate,
+		retention,
+	)
+}
+
+func (c *Container) ServerWatchdogWorker() *serverwatchdog.Worker {
+	if c.serverWatchdogWorker == nil {
+		c.serverWatchdogWorker = c.createServerWatchdogWorker()
+	}
+
+	return c.serverWatchdogWorker
+}
+
+func (c *Container) createServerWatchdogWorker() *serverwatchdog.Worker {
+	interval, err := time.ParseDuration(c.config.ServerWatchdog.Interval)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid server watchdog interval"))
+	}
+
+	lockTTL, err := time.ParseDuration(c.config.ServerWatchdog.LockTTL)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid server watchdog lock ttl"))
+	}
+
+	return serverwatchdog.NewWorker(
+		c.ServerRepository(),
+		c.ServerSettingRepository(),
+		c.DaemonTaskRepository(),
+		c.ServerAutoRestartRepository(),
+		c.ServerControlService(),
+		serverwatchdog.NewEventNotifier(c.EventBus()),
+		c.Cache(),
+		lockTTL,
+		interval,
+		c.AutoRestartPolicy(),
+	)
+}
+
+func (c *Container) WebhooksWorker() *webhooks.Worker {
+	if c.webhooksWorker == nil {
+		c.webhooksWorker = c.createWebhooksWorker()
+	}
+
+	return c.webhooksWorker
+}
+
+func (c *Container) LDAPWorker() *ldap.Worker {
+	if c.ldapWorker == nil {
+		c.ldapWorker = c.createLDAPWorker()
+	}
+
+	return c.ldapWorker
+}
+
+func (c *Container) createLDAPWorker() *ldap.Worker {
+	interval, err := time.ParseDuration(c.config.LDAP.SyncInterval)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid ldap sync interval"))
+	}
+
+	lockTTL, err := time.ParseDuration(c.config.LDAP.LockTTL)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid ldap sync lock ttl"))
+	}
+
+	return ldap.NewWorker(
+		c.LDAPClient(),
+		c.UserIdentityRepository(),
+		c.UserRepository(),
+		c.Cache(),
+		lockTTL,
+		interval,
+	)
+}
+
+func (c *Container) createWebhooksWorker() *webhooks.Worker {
+	interval, err := time.ParseDuration(c.config.Webhooks.Interval)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid webhooks interval"))
+	}
+
+	lockTTL, err := time.ParseDuration(c.config.Webhooks.LockTTL)
+	if err != nil {
+		panic(errors.WithMessage(err, "invalid webhooks lock ttl"))
+	}
+
+	var retention time.Duration
+	if c.config.Webhooks.Retention != "" {
+		retention, err = time.ParseDuration(c.config.Webhooks.Retention)
+		if err != nil {
+			panic(errors.WithMessage(err, "invalid webhooks retention"))
+		}
+	}
+
+	return webhooks.NewWorker(
+		c.WebhookRepository(),
+		c.WebhookDeliveryRepository(),
+		c.WebhookSender(),
+		c.Cache(),
+		lockTTL,
+		interval,
+		c.WebhookRetryPolicy(),
+		retention,
+	)
+}
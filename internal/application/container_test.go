@@ -0,0 +1,29 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/gameap/gameap/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainer_Shutdown_RunsFunctionsInReverseOrder(t *testing.T) {
+	c := NewContainer(&config.Config{})
+
+	var calls []string
+
+	c.appendShutdownFunc(func() error {
+		calls = append(calls, "database")
+
+		return nil
+	})
+	c.appendShutdownFunc(func() error {
+		calls = append(calls, "backups")
+
+		return nil
+	})
+
+	require.NoError(t, c.Shutdown())
+	assert.Equal(t, []string{"backups", "database"}, calls)
+}
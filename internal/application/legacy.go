@@ -107,6 +107,57 @@ func convertLegacyEnvToCurrentEnv(legacyVars map[string]string) {
 	if redisPassword, ok := legacyVars["REDIS_PASSWORD"]; ok && redisPassword != "" && redisPassword != "null" {
 		setEnvIfNotExists("CACHE_REDIS_PASSWORD", redisPassword)
 	}
+
+	convertLegacyMail(legacyVars)
+}
+
+// convertLegacyMail converts the SMTP settings of the legacy panel.
+// Legacy "tls" encryption is STARTTLS and "ssl" is implicit TLS.
+func convertLegacyMail(legacyVars map[string]string) {
+	driver := legacyValue(legacyVars, "MAIL_MAILER")
+	if driver == "" {
+		driver = legacyValue(legacyVars, "MAIL_DRIVER")
+	}
+
+	if driver != "" && driver != "smtp" {
+		return
+	}
+
+	host := legacyValue(legacyVars, "MAIL_HOST")
+	if host == "" {
+		return
+	}
+
+	setEnvIfNotExists("MAIL_HOST", host)
+	setEnvIfNotExists("MAIL_PORT", legacyValue(legacyVars, "MAIL_PORT"))
+	setEnvIfNotExists("MAIL_USERNAME", legacyValue(legacyVars, "MAIL_USERNAME"))
+	setEnvIfNotExists("MAIL_PASSWORD", legacyValue(legacyVars, "MAIL_PASSWORD"))
+	setEnvIfNotExists("MAIL_FROM_ADDRESS", legacyValue(legacyVars, "MAIL_FROM_ADDRESS"))
+	setEnvIfNotExists("MAIL_FROM_NAME", legacyValue(legacyVars, "MAIL_FROM_NAME"))
+
+	switch strings.ToLower(legacyValue(legacyVars, "MAIL_ENCRYPTION")) {
+	case "tls":
+		setEnvIfNotExists("MAIL_ENCRYPTION", "starttls")
+	case "ssl":
+		setEnvIfNotExists("MAIL_ENCRYPTION", "tls")
+	case "":
+		setEnvIfNotExists("MAIL_ENCRYPTION", "none")
+	}
+}
+
+// legacyValue returns the unquoted value of the legacy variable, "null" is an empty value.
+func legacyValue(legacyVars map[string]string, key string) string {
+	value := legacyVars[key]
+
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		value = value[1 : len(value)-1]
+	}
+
+	if value == "null" {
+		return ""
+	}
+
+	return value
 }
 
 func buildDatabaseURL(legacyVars map[string]string) {
@@ -132,6 +132,51 @@ func TestConvertLegacyEnvToCurrentEnv(t *testing.T) {
 			},
 			expected: map[string]string{},
 		},
+		{
+			name: "mail_conversion",
+			legacyVars: map[string]string{
+				"MAIL_DRIVER":       "smtp",
+				"MAIL_HOST":         "smtp.example.com",
+				"MAIL_PORT":         "465",
+				"MAIL_USERNAME":     "gameap",
+				"MAIL_PASSWORD":     "secret",
+				"MAIL_ENCRYPTION":   "ssl",
+				"MAIL_FROM_ADDRESS": "noreply@example.com",
+				"MAIL_FROM_NAME":    `"GameAP Panel"`,
+			},
+			expected: map[string]string{
+				"MAIL_HOST":         "smtp.example.com",
+				"MAIL_PORT":         "465",
+				"MAIL_USERNAME":     "gameap",
+				"MAIL_PASSWORD":     "secret",
+				"MAIL_ENCRYPTION":   "tls",
+				"MAIL_FROM_ADDRESS": "noreply@example.com",
+				"MAIL_FROM_NAME":    "GameAP Panel",
+			},
+		},
+		{
+			name: "mail_starttls_and_null_values",
+			legacyVars: map[string]string{
+				"MAIL_HOST":       "smtp.example.com",
+				"MAIL_USERNAME":   "null",
+				"MAIL_ENCRYPTION": "tls",
+			},
+			expected: map[string]string{
+				"MAIL_HOST":       "smtp.example.com",
+				"MAIL_USERNAME":   "",
+				"MAIL_ENCRYPTION": "starttls",
+			},
+		},
+		{
+			name: "mail_of_other_driver_ignored",
+			legacyVars: map[string]string{
+				"MAIL_DRIVER": "log",
+				"MAIL_HOST":   "smtp.example.com",
+			},
+			expected: map[string]string{
+				"MAIL_HOST": "",
+			},
+		},
 		{
 			name: "complete_legacy_config",
 			legacyVars: map[string]string{
@@ -1,10 +1,26 @@
 package cache
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type Cache interface {
 	Get(ctx context.Context, key string) (any, error)
 	Set(ctx context.Context, key string, value any, options ...Option) error
 	Delete(ctx context.Context, key string) error
 	Clear(ctx context.Context) error
+
+	// SetIfAbsent atomically stores the value if the key doesn't exist or has expired.
+	// It reports whether the value is stored.
+	SetIfAbsent(ctx context.Context, key string, value any, options ...Option) (bool, error)
+
+	// ExpireIfEqual atomically sets the time to live of the key if it holds the value.
+	// Zero ttl removes the expiration. It reports whether the key holds the value.
+	// The values are compared by their JSON encoding.
+	ExpireIfEqual(ctx context.Context, key string, value any, ttl time.Duration) (bool, error)
+
+	// DeleteIfEqual atomically removes the key if it holds the value.
+	// It reports whether the key is removed. The values are compared by their JSON encoding.
+	DeleteIfEqual(ctx context.Context, key string, value any) (bool, error)
 }
@@ -364,3 +364,83 @@ func (s *Suite) TestNilValue() {
 	require.NoError(s.T(), err)
 	assert.Nil(s.T(), value)
 }
+
+func (s *Suite) TestSetIfAbsent() {
+	ctx := context.Background()
+
+	stored, err := s.cacheInstance.SetIfAbsent(ctx, "absent_key", "first", WithExpiration(time.Minute))
+	require.NoError(s.T(), err)
+	assert.True(s.T(), stored)
+
+	stored, err = s.cacheInstance.SetIfAbsent(ctx, "absent_key", "second", WithExpiration(time.Minute))
+	require.NoError(s.T(), err)
+	assert.False(s.T(), stored)
+
+	value, err := s.cacheInstance.Get(ctx, "absent_key")
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "first", value)
+}
+
+func (s *Suite) TestSetIfAbsentReplacesExpired() {
+	ctx := context.Background()
+
+	err := s.cacheInstance.Set(ctx, "expired_absent_key", "first", WithExpiration(time.Second))
+	require.NoError(s.T(), err)
+
+	time.Sleep(2 * time.Second)
+
+	stored, err := s.cacheInstance.SetIfAbsent(ctx, "expired_absent_key", "second", WithExpiration(time.Minute))
+	require.NoError(s.T(), err)
+	assert.True(s.T(), stored)
+
+	value, err := s.cacheInstance.Get(ctx, "expired_absent_key")
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "second", value)
+}
+
+func (s *Suite) TestExpireIfEqual() {
+	ctx := context.Background()
+
+	err := s.cacheInstance.Set(ctx, "expire_key", "owner", WithExpiration(time.Second))
+	require.NoError(s.T(), err)
+
+	expired, err := s.cacheInstance.ExpireIfEqual(ctx, "expire_key", "other", time.Minute)
+	require.NoError(s.T(), err)
+	assert.False(s.T(), expired)
+
+	expired, err = s.cacheInstance.ExpireIfEqual(ctx, "expire_key", "owner", time.Minute)
+	require.NoError(s.T(), err)
+	assert.True(s.T(), expired)
+
+	// The lease is extended, so the value outlives the original expiration
+	time.Sleep(2 * time.Second)
+
+	value, err := s.cacheInstance.Get(ctx, "expire_key")
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "owner", value)
+
+	expired, err = s.cacheInstance.ExpireIfEqual(ctx, "missing_expire_key", "owner", time.Minute)
+	require.NoError(s.T(), err)
+	assert.False(s.T(), expired)
+}
+
+func (s *Suite) TestDeleteIfEqual() {
+	ctx := context.Background()
+
+	err := s.cacheInstance.Set(ctx, "delete_key", "owner", WithExpiration(time.Minute))
+	require.NoError(s.T(), err)
+
+	deleted, err := s.cacheInstance.DeleteIfEqual(ctx, "delete_key", "other")
+	require.NoError(s.T(), err)
+	assert.False(s.T(), deleted)
+
+	_, err = s.cacheInstance.Get(ctx, "delete_key")
+	require.NoError(s.T(), err)
+
+	deleted, err = s.cacheInstance.DeleteIfEqual(ctx, "delete_key", "owner")
+	require.NoError(s.T(), err)
+	assert.True(s.T(), deleted)
+
+	_, err = s.cacheInstance.Get(ctx, "delete_key")
+	assert.ErrorIs(s.T(), err, ErrNotFound)
+}
@@ -1,7 +1,9 @@
 package cache
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"sync"
 	"time"
 )
@@ -74,6 +76,70 @@ func (c *InMemory) Delete(_ context.Context, key string) error {
 	return nil
 }
 
+func (c *InMemory) SetIfAbsent(_ context.Context, key string, value any, options ...Option) (bool, error) {
+	opts := ApplyOptions(options...)
+
+	var expiration time.Time
+	if opts.Expiration > 0 {
+		expiration = time.Now().Add(opts.Expiration)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if itm, exists := c.items[key]; exists && !itm.isExpired() {
+		return false, nil
+	}
+
+	c.items[key] = &item{
+		value:      value,
+		expiration: expiration,
+	}
+
+	return true, nil
+}
+
+func (c *InMemory) ExpireIfEqual(_ context.Context, key string, value any, ttl time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	itm, exists := c.items[key]
+	if !exists || itm.isExpired() {
+		return false, nil
+	}
+
+	equal, err := equalValues(itm.value, value)
+	if err != nil || !equal {
+		return false, err
+	}
+
+	itm.expiration = time.Time{}
+	if ttl > 0 {
+		itm.expiration = time.Now().Add(ttl)
+	}
+
+	return true, nil
+}
+
+func (c *InMemory) DeleteIfEqual(_ context.Context, key string, value any) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	itm, exists := c.items[key]
+	if !exists || itm.isExpired() {
+		return false, nil
+	}
+
+	equal, err := equalValues(itm.value, value)
+	if err != nil || !equal {
+		return false, err
+	}
+
+	delete(c.items, key)
+
+	return true, nil
+}
+
 func (c *InMemory) Clear(_ context.Context) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -102,3 +168,18 @@ func (c *InMemory) cleanup() {
 		}
 	}
 }
+
+// equalValues compares the values by their JSON encoding, like the other drivers store them.
+func equalValues(a, b any) (bool, error) {
+	encodedA, err := json.Marshal(a)
+	if err != nil {
+		return false, err
+	}
+
+	encodedB, err := json.Marshal(b)
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(encodedA, encodedB), nil
+}
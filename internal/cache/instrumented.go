@@ -0,0 +1,51 @@
+package cache
+
+import (
+	"context"
+	"errors"
+)
+
+// Instrumented reports the hits and the misses of the cache reads.
+type Instrumented struct {
+	Cache
+
+	observe func(hit bool)
+}
+
+func NewInstrumented(c Cache, observe func(hit bool)) *Instrumented {
+	return &Instrumented{
+		Cache:   c,
+		observe: observe,
+	}
+}
+
+func (c *Instrumented) Get(ctx context.Context, key string) (any, error) {
+	value, err := c.Cache.Get(ctx, key)
+
+	switch {
+	case err == nil:
+		c.observe(true)
+	case errors.Is(err, ErrNotFound):
+		c.observe(false)
+	}
+
+	return value, err
+}
+
+// Unwrap returns the underlying cache.
+func (c *Instrumented) Unwrap() Cache {
+	return c.Cache
+}
+
+// Unwrap returns the underlying cache of the wrappers like Instrumented,
+// so the driver specific features can be used.
+func Unwrap(c Cache) Cache {
+	for {
+		wrapper, ok := c.(interface{ Unwrap() Cache })
+		if !ok {
+			return c
+		}
+
+		c = wrapper.Unwrap()
+	}
+}
@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstrumented_Get(t *testing.T) {
+	ctx := context.Background()
+
+	var hits, misses int
+
+	c := NewInstrumented(NewInMemory(), func(hit bool) {
+		if hit {
+			hits++
+		} else {
+			misses++
+		}
+	})
+
+	_, err := c.Get(ctx, "key")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, c.Set(ctx, "key", "value"))
+
+	value, err := c.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, "value", value)
+
+	assert.Equal(t, 1, hits)
+	assert.Equal(t, 1, misses)
+}
+
+func TestUnwrap(t *testing.T) {
+	inMemory := NewInMemory()
+
+	wrapped := NewInstrumented(NewInstrumented(inMemory, func(bool) {}), func(bool) {})
+
+	assert.Same(t, inMemory, Unwrap(wrapped))
+	assert.Same(t, inMemory, Unwrap(inMemory))
+}
@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const lockKeyPrefix = "lock:"
+
+// Lock is a lease based lock stored in a cache.
+// It is used to elect a single leader among several panel replicas sharing the same cache.
+// The lease is taken, extended and released with the atomic operations of the cache,
+// so only one owner holds the lock until the lease expires.
+type Lock struct {
+	cache Cache
+	name  string
+	key   string
+	owner string
+	ttl   time.Duration
+}
+
+// NewLock creates a lock with a random owner identifier.
+func NewLock(c Cache, name string, ttl time.Duration) *Lock {
+	return &Lock{
+		cache: c,
+		name:  name,
+		key:   lockKeyPrefix + name,
+		owner: uuid.NewString(),
+		ttl:   ttl,
+	}
+}
+
+// Owner returns the identifier of the lock owner.
+func (l *Lock) Owner() string {
+	return l.owner
+}
+
+// Acquire takes the lock or extends the lease if the lock is already held by this owner.
+// It returns false when the lock is held by another owner.
+func (l *Lock) Acquire(ctx context.Context) (bool, error) {
+	acquired, err := l.cache.SetIfAbsent(ctx, l.key, l.owner, WithExpiration(l.ttl))
+	if err != nil {
+		return false, fmt.Errorf("failed to set lock: %w", err)
+	}
+
+	if acquired {
+		return true, nil
+	}
+
+	extended, err := l.cache.ExpireIfEqual(ctx, l.key, l.owner, l.ttl)
+	if err != nil {
+		return false, fmt.Errorf("failed to extend lock: %w", err)
+	}
+
+	return extended, nil
+}
+
+// Release frees the lock if it is held by this owner.
+func (l *Lock) Release(ctx context.Context) error {
+	if _, err := l.cache.DeleteIfEqual(ctx, l.key, l.owner); err != nil {
+		return fmt.Errorf("failed to delete lock: %w", err)
+	}
+
+	return nil
+}
+
+// Do calls fn if the lock is acquired or its lease is extended, otherwise fn isn't called.
+func (l *Lock) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	acquired, err := l.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire leader lock: %w", err)
+	}
+
+	if !acquired {
+		return nil
+	}
+
+	return fn(ctx)
+}
+
+// RunAsLeader calls fn right away and then with the interval until the context is canceled.
+// fn is called only while the lock is held, so only one panel replica runs it at a time.
+// The errors are logged, the lock is released when the context is canceled.
+func RunAsLeader(ctx context.Context, lock *Lock, interval time.Duration, fn func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	defer func() {
+		if err := lock.Release(context.WithoutCancel(ctx)); err != nil {
+			slog.ErrorContext(
+				ctx,
+				"Failed to release leader lock",
+				slog.String("lock", lock.name),
+				slog.String("error", err.Error()),
+			)
+		}
+	}()
+
+	for {
+		if err := lock.Do(ctx, fn); err != nil {
+			slog.ErrorContext(
+				ctx,
+				"Failed to run leader task",
+				slog.String("lock", lock.name),
+				slog.String("error", err.Error()),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
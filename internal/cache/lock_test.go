@@ -0,0 +1,161 @@
+package cache_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLock(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("only_one_owner_acquires_lock", func(t *testing.T) {
+		c := cache.NewInMemory()
+		first := cache.NewLock(c, "test", time.Minute)
+		second := cache.NewLock(c, "test", time.Minute)
+
+		acquired, err := first.Acquire(ctx)
+		require.NoError(t, err)
+		assert.True(t, acquired)
+
+		acquired, err = second.Acquire(ctx)
+		require.NoError(t, err)
+		assert.False(t, acquired)
+	})
+
+	t.Run("owner_extends_lease", func(t *testing.T) {
+		c := cache.NewInMemory()
+		lock := cache.NewLock(c, "test", time.Minute)
+
+		acquired, err := lock.Acquire(ctx)
+		require.NoError(t, err)
+		assert.True(t, acquired)
+
+		acquired, err = lock.Acquire(ctx)
+		require.NoError(t, err)
+		assert.True(t, acquired)
+	})
+
+	t.Run("released_lock_can_be_acquired", func(t *testing.T) {
+		c := cache.NewInMemory()
+		first := cache.NewLock(c, "test", time.Minute)
+		second := cache.NewLock(c, "test", time.Minute)
+
+		_, err := first.Acquire(ctx)
+		require.NoError(t, err)
+
+		require.NoError(t, second.Release(ctx))
+
+		acquired, err := second.Acquire(ctx)
+		require.NoError(t, err)
+		assert.False(t, acquired, "release by another owner must not free the lock")
+
+		require.NoError(t, first.Release(ctx))
+
+		acquired, err = second.Acquire(ctx)
+		require.NoError(t, err)
+		assert.True(t, acquired)
+	})
+
+	t.Run("expired_lock_can_be_acquired", func(t *testing.T) {
+		c := cache.NewInMemory()
+		first := cache.NewLock(c, "test", time.Millisecond)
+		second := cache.NewLock(c, "test", time.Minute)
+
+		_, err := first.Acquire(ctx)
+		require.NoError(t, err)
+
+		time.Sleep(5 * time.Millisecond)
+
+		acquired, err := second.Acquire(ctx)
+		require.NoError(t, err)
+		assert.True(t, acquired)
+	})
+	t.Run("concurrent_owners_acquire_lock_once", func(t *testing.T) {
+		c := cache.NewInMemory()
+
+		var (
+			wg       sync.WaitGroup
+			acquired atomic.Int32
+		)
+
+		for range 50 {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				ok, err := cache.NewLock(c, "test", time.Minute).Acquire(ctx)
+				assert.NoError(t, err)
+
+				if ok {
+					acquired.Add(1)
+				}
+			}()
+		}
+
+		wg.Wait()
+
+		assert.Equal(t, int32(1), acquired.Load())
+	})
+}
+
+func TestLock_Do(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewInMemory()
+	leader := cache.NewLock(c, "test", time.Minute)
+	follower := cache.NewLock(c, "test", time.Minute)
+
+	var calls atomic.Int32
+	fn := func(context.Context) error {
+		calls.Add(1)
+
+		return nil
+	}
+
+	require.NoError(t, leader.Do(ctx, fn))
+	require.NoError(t, follower.Do(ctx, fn))
+	require.NoError(t, leader.Do(ctx, fn))
+
+	assert.Equal(t, int32(2), calls.Load(), "only the leader must run the function")
+}
+
+func TestRunAsLeader(t *testing.T) {
+	c := cache.NewInMemory()
+	lock := cache.NewLock(c, "test", time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	calls := make(chan struct{}, 10)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		cache.RunAsLeader(ctx, lock, time.Millisecond, func(context.Context) error {
+			select {
+			case calls <- struct{}{}:
+			default:
+			}
+
+			return assert.AnError
+		})
+	}()
+
+	// The function is called again after a failure
+	<-calls
+	<-calls
+
+	cancel()
+	<-done
+
+	acquired, err := cache.NewLock(c, "test", time.Minute).Acquire(context.Background())
+	require.NoError(t, err)
+	assert.True(t, acquired, "the lock must be released when the context is canceled")
+}
@@ -176,6 +176,147 @@ func (c *MySQL) Delete(ctx context.Context, key string) error {
 	return nil
 }
 
+// SetIfAbsent removes the expired value of the key and inserts the value if there is no other one.
+// A concurrent insert of the same key is ignored by the unique key, so only one caller stores the value.
+func (c *MySQL) SetIfAbsent(ctx context.Context, key string, value any, options ...Option) (bool, error) {
+	opts := ApplyOptions(options...)
+	fullKey := c.buildKey(key)
+
+	valueJSON, err := json.Marshal(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal value: %w", err)
+	}
+
+	now := time.Now()
+
+	var expiresAt sql.NullTime
+	if opts.Expiration > 0 {
+		expiresAt = sql.NullTime{
+			Time:  now.Add(opts.Expiration),
+			Valid: true,
+		}
+	}
+
+	query, args, err := sq.Delete(kvStoreTable).
+		Where(sq.Eq{"`key`": fullKey}).
+		Where(sq.NotEq{"expires_at": nil}).
+		Where(sq.Lt{"expires_at": now}).
+		PlaceholderFormat(sq.Question).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	if _, err = c.db.ExecContext(ctx, query, args...); err != nil {
+		return false, fmt.Errorf("failed to delete expired cache value: %w", err)
+	}
+
+	query, args, err = sq.Insert(kvStoreTable).
+		Options("IGNORE").
+		Columns("`key`", "`value`", "`expires_at`").
+		Values(fullKey, string(valueJSON), expiresAt).
+		PlaceholderFormat(sq.Question).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	result, err := c.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to set cache value: %w", err)
+	}
+
+	return rowsAffected(result)
+}
+
+func (c *MySQL) ExpireIfEqual(ctx context.Context, key string, value any, ttl time.Duration) (bool, error) {
+	valueJSON, err := json.Marshal(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal value: %w", err)
+	}
+
+	now := time.Now()
+	condition := c.holdsCondition(key, valueJSON, now)
+
+	var expiresAt sql.NullTime
+	if ttl > 0 {
+		expiresAt = sql.NullTime{
+			Time:  now.Add(ttl),
+			Valid: true,
+		}
+	}
+
+	query, args, err := sq.Update(kvStoreTable).
+		Set("expires_at", expiresAt).
+		Where(condition).
+		PlaceholderFormat(sq.Question).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	result, err := c.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to expire cache value: %w", err)
+	}
+
+	updated, err := rowsAffected(result)
+	if err != nil || updated {
+		return updated, err
+	}
+
+	// MySQL reports only the changed rows, the expiration may be the same within a second
+	query, args, err = sq.Select("COUNT(*)").
+		From(kvStoreTable).
+		Where(condition).
+		PlaceholderFormat(sq.Question).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	var count int
+	if err = c.db.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return false, fmt.Errorf("failed to query row: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+func (c *MySQL) DeleteIfEqual(ctx context.Context, key string, value any) (bool, error) {
+	valueJSON, err := json.Marshal(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal value: %w", err)
+	}
+
+	query, args, err := sq.Delete(kvStoreTable).
+		Where(c.holdsCondition(key, valueJSON, time.Now())).
+		PlaceholderFormat(sq.Question).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	result, err := c.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete cache value: %w", err)
+	}
+
+	return rowsAffected(result)
+}
+
+// holdsCondition matches the not expired key holding the value.
+func (c *MySQL) holdsCondition(key string, valueJSON []byte, now time.Time) sq.And {
+	return sq.And{
+		sq.Eq{"`key`": c.buildKey(key)},
+		sq.Eq{"`value`": string(valueJSON)},
+		sq.Or{
+			sq.Eq{"expires_at": nil},
+			sq.GtOrEq{"expires_at": now},
+		},
+	}
+}
+
 func (c *MySQL) Clear(ctx context.Context) error {
 	builder := sq.Delete(kvStoreTable)
 
@@ -219,3 +360,12 @@ func (c *MySQL) CleanupExpired(ctx context.Context) error {
 
 	return nil
 }
+
+func rowsAffected(result sql.Result) (bool, error) {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return affected > 0, nil
+}
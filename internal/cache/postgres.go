@@ -182,6 +182,111 @@ func (c *PostgreSQL) Delete(ctx context.Context, key string) error {
 	return nil
 }
 
+// SetIfAbsent inserts the value or replaces the expired one in a single statement.
+func (c *PostgreSQL) SetIfAbsent(ctx context.Context, key string, value any, options ...Option) (bool, error) {
+	opts := ApplyOptions(options...)
+	fullKey := c.buildKey(key)
+
+	valueJSON, err := json.Marshal(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal value: %w", err)
+	}
+
+	now := time.Now()
+
+	var expiresAt sql.NullTime
+	if opts.Expiration > 0 {
+		expiresAt = sql.NullTime{
+			Time:  now.Add(opts.Expiration),
+			Valid: true,
+		}
+	}
+
+	query := `
+		INSERT INTO ` + postgresKVStoreTable + ` (key, value, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (key) DO UPDATE
+		SET value = EXCLUDED.value,
+		    expires_at = EXCLUDED.expires_at
+		WHERE ` + postgresKVStoreTable + `.expires_at IS NOT NULL
+		  AND ` + postgresKVStoreTable + `.expires_at < $4
+	`
+
+	result, err := c.db.ExecContext(ctx, query, fullKey, string(valueJSON), expiresAt, now)
+	if err != nil {
+		return false, fmt.Errorf("failed to set cache value: %w", err)
+	}
+
+	return rowsAffected(result)
+}
+
+func (c *PostgreSQL) ExpireIfEqual(ctx context.Context, key string, value any, ttl time.Duration) (bool, error) {
+	valueJSON, err := json.Marshal(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal value: %w", err)
+	}
+
+	now := time.Now()
+
+	var expiresAt sql.NullTime
+	if ttl > 0 {
+		expiresAt = sql.NullTime{
+			Time:  now.Add(ttl),
+			Valid: true,
+		}
+	}
+
+	query, args, err := sq.Update(postgresKVStoreTable).
+		Set("expires_at", expiresAt).
+		Where(c.holdsCondition(key, valueJSON, now)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	result, err := c.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to expire cache value: %w", err)
+	}
+
+	return rowsAffected(result)
+}
+
+func (c *PostgreSQL) DeleteIfEqual(ctx context.Context, key string, value any) (bool, error) {
+	valueJSON, err := json.Marshal(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal value: %w", err)
+	}
+
+	query, args, err := sq.Delete(postgresKVStoreTable).
+		Where(c.holdsCondition(key, valueJSON, time.Now())).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	result, err := c.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete cache value: %w", err)
+	}
+
+	return rowsAffected(result)
+}
+
+// holdsCondition matches the not expired key holding the value.
+func (c *PostgreSQL) holdsCondition(key string, valueJSON []byte, now time.Time) sq.And {
+	return sq.And{
+		sq.Eq{"key": c.buildKey(key)},
+		sq.Eq{"value": string(valueJSON)},
+		sq.Or{
+			sq.Eq{"expires_at": nil},
+			sq.GtOrEq{"expires_at": now},
+		},
+	}
+}
+
 func (c *PostgreSQL) Clear(ctx context.Context) error {
 	builder := sq.Delete(postgresKVStoreTable)
 
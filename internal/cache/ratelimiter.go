@@ -0,0 +1,106 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const rateLimitKeyPrefix = "ratelimit:"
+
+// RateLimiter limits the number of attempts of a key in a fixed time window.
+//
+// The counter is stored as a string, so it survives the JSON round trip of the Redis and database caches.
+// Like Lock, it isn't strictly atomic, a few concurrent attempts may be counted once.
+type RateLimiter struct {
+	cache  Cache
+	prefix string
+	limit  int
+	window time.Duration
+}
+
+// NewRateLimiter creates a limiter allowing limit attempts of a key per window.
+func NewRateLimiter(c Cache, name string, limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{
+		cache:  c,
+		prefix: rateLimitKeyPrefix + name + ":",
+		limit:  limit,
+		window: window,
+	}
+}
+
+// Hit counts an attempt of the key.
+// It returns false and the time left until the window is reset when the limit is exceeded.
+func (l *RateLimiter) Hit(ctx context.Context, key string) (bool, time.Duration, error) {
+	now := time.Now()
+
+	count, resetAt, err := l.current(ctx, key)
+	if err != nil {
+		return false, 0, err
+	}
+
+	if count == 0 || !now.Before(resetAt) {
+		count = 0
+		resetAt = now.Add(l.window)
+	}
+
+	if count >= l.limit {
+		return false, resetAt.Sub(now), nil
+	}
+
+	count++
+
+	value := strconv.Itoa(count) + ":" + strconv.FormatInt(resetAt.UnixNano(), 10)
+
+	err = l.cache.Set(ctx, l.prefix+key, value, WithExpiration(resetAt.Sub(now)))
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to set rate limit counter: %w", err)
+	}
+
+	return true, 0, nil
+}
+
+// Reset clears the attempts of the key.
+func (l *RateLimiter) Reset(ctx context.Context, key string) error {
+	if err := l.cache.Delete(ctx, l.prefix+key); err != nil {
+		return fmt.Errorf("failed to delete rate limit counter: %w", err)
+	}
+
+	return nil
+}
+
+func (l *RateLimiter) current(ctx context.Context, key string) (int, time.Time, error) {
+	value, err := l.cache.Get(ctx, l.prefix+key)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return 0, time.Time{}, nil
+		}
+
+		return 0, time.Time{}, fmt.Errorf("failed to get rate limit counter: %w", err)
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return 0, time.Time{}, nil
+	}
+
+	countStr, resetAtStr, ok := strings.Cut(str, ":")
+	if !ok {
+		return 0, time.Time{}, nil
+	}
+
+	count, err := strconv.Atoi(countStr)
+	if err != nil {
+		return 0, time.Time{}, nil //nolint:nilerr // a broken counter starts a new window
+	}
+
+	resetAt, err := strconv.ParseInt(resetAtStr, 10, 64)
+	if err != nil {
+		return 0, time.Time{}, nil //nolint:nilerr // a broken counter starts a new window
+	}
+
+	return count, time.Unix(0, resetAt), nil
+}
@@ -0,0 +1,84 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("limit_exceeded", func(t *testing.T) {
+		limiter := cache.NewRateLimiter(cache.NewInMemory(), "test", 2, time.Minute)
+
+		for range 2 {
+			allowed, _, err := limiter.Hit(ctx, "key")
+			require.NoError(t, err)
+			assert.True(t, allowed)
+		}
+
+		allowed, retryAfter, err := limiter.Hit(ctx, "key")
+		require.NoError(t, err)
+		assert.False(t, allowed)
+		assert.Greater(t, retryAfter, time.Duration(0))
+		assert.LessOrEqual(t, retryAfter, time.Minute)
+	})
+
+	t.Run("keys_are_counted_separately", func(t *testing.T) {
+		limiter := cache.NewRateLimiter(cache.NewInMemory(), "test", 1, time.Minute)
+
+		allowed, _, err := limiter.Hit(ctx, "first")
+		require.NoError(t, err)
+		assert.True(t, allowed)
+
+		allowed, _, err = limiter.Hit(ctx, "second")
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	})
+
+	t.Run("limiters_are_counted_separately", func(t *testing.T) {
+		c := cache.NewInMemory()
+		first := cache.NewRateLimiter(c, "first", 1, time.Minute)
+		second := cache.NewRateLimiter(c, "second", 1, time.Minute)
+
+		allowed, _, err := first.Hit(ctx, "key")
+		require.NoError(t, err)
+		assert.True(t, allowed)
+
+		allowed, _, err = second.Hit(ctx, "key")
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	})
+
+	t.Run("window_is_reset", func(t *testing.T) {
+		limiter := cache.NewRateLimiter(cache.NewInMemory(), "test", 1, 5*time.Millisecond)
+
+		allowed, _, err := limiter.Hit(ctx, "key")
+		require.NoError(t, err)
+		assert.True(t, allowed)
+
+		time.Sleep(10 * time.Millisecond)
+
+		allowed, _, err = limiter.Hit(ctx, "key")
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	})
+
+	t.Run("reset_clears_attempts", func(t *testing.T) {
+		limiter := cache.NewRateLimiter(cache.NewInMemory(), "test", 1, time.Minute)
+
+		_, _, err := limiter.Hit(ctx, "key")
+		require.NoError(t, err)
+
+		require.NoError(t, limiter.Reset(ctx, "key"))
+
+		allowed, _, err := limiter.Hit(ctx, "key")
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	})
+}
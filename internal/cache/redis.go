@@ -13,6 +13,30 @@ import (
 
 const redisKeyPrefix = "gameap:"
 
+// redisExpireIfEqualScript sets the time to live in milliseconds of the key holding the value.
+var redisExpireIfEqualScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+
+if tonumber(ARGV[2]) > 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+else
+	redis.call("PERSIST", KEYS[1])
+end
+
+return 1
+`)
+
+// redisDeleteIfEqualScript removes the key holding the value.
+var redisDeleteIfEqualScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+
+return redis.call("DEL", KEYS[1])
+`)
+
 type Redis struct {
 	client *redis.Client
 }
@@ -96,6 +120,64 @@ func (r *Redis) Delete(ctx context.Context, key string) error {
 	return nil
 }
 
+// SetIfAbsent stores a value with SET NX if the key doesn't exist.
+func (r *Redis) SetIfAbsent(ctx context.Context, key string, value any, options ...Option) (bool, error) {
+	opts := ApplyOptions(options...)
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return false, fmt.Errorf("marshal error: %w", err)
+	}
+
+	expiration := time.Duration(0)
+	if opts.Expiration > 0 {
+		expiration = opts.Expiration
+	}
+
+	stored, err := r.client.SetNX(ctx, redisKeyPrefix+key, data, expiration).Result()
+	if err != nil {
+		return false, fmt.Errorf("redis set nx error: %w", err)
+	}
+
+	return stored, nil
+}
+
+// ExpireIfEqual sets the time to live of the key holding the value.
+func (r *Redis) ExpireIfEqual(ctx context.Context, key string, value any, ttl time.Duration) (bool, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return false, fmt.Errorf("marshal error: %w", err)
+	}
+
+	// The sub-millisecond ttl is rounded up, zero disables the expiration
+	milliseconds := int64(0)
+	if ttl > 0 {
+		milliseconds = max(ttl.Milliseconds(), 1)
+	}
+
+	result, err := redisExpireIfEqualScript.Run(ctx, r.client, []string{redisKeyPrefix + key}, data, milliseconds).Int()
+	if err != nil {
+		return false, fmt.Errorf("redis expire error: %w", err)
+	}
+
+	return result == 1, nil
+}
+
+// DeleteIfEqual removes the key holding the value.
+func (r *Redis) DeleteIfEqual(ctx context.Context, key string, value any) (bool, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return false, fmt.Errorf("marshal error: %w", err)
+	}
+
+	result, err := redisDeleteIfEqualScript.Run(ctx, r.client, []string{redisKeyPrefix + key}, data).Int()
+	if err != nil {
+		return false, fmt.Errorf("redis delete error: %w", err)
+	}
+
+	return result == 1, nil
+}
+
 // Clear removes all keys from the current database.
 func (r *Redis) Clear(ctx context.Context) error {
 	if err := r.client.FlushDB(ctx).Err(); err != nil {
@@ -127,6 +209,11 @@ func (r *Redis) DeletePattern(ctx context.Context, pattern string) error {
 	return nil
 }
 
+// Client returns the underlying Redis client.
+func (r *Redis) Client() *redis.Client {
+	return r.client
+}
+
 // Close closes the Redis connection.
 func (r *Redis) Close() error {
 	return r.client.Close()
@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gameap/gameap/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Traced records the cache operations as tracing spans.
+type Traced struct {
+	Cache
+
+	driver attribute.KeyValue
+}
+
+func NewTraced(c Cache, driver string) *Traced {
+	return &Traced{
+		Cache:  c,
+		driver: attribute.String("cache.driver", driver),
+	}
+}
+
+func (c *Traced) Get(ctx context.Context, key string) (any, error) {
+	ctx, span := c.start(ctx, "cache.get", key)
+
+	value, err := c.Cache.Get(ctx, key)
+
+	span.SetAttributes(attribute.Bool("cache.hit", err == nil))
+
+	// A missing key is a regular result, not an error of the operation
+	if errors.Is(err, ErrNotFound) {
+		tracing.End(span, nil)
+	} else {
+		tracing.End(span, err)
+	}
+
+	return value, err
+}
+
+func (c *Traced) Set(ctx context.Context, key string, value any, options ...Option) error {
+	ctx, span := c.start(ctx, "cache.set", key)
+
+	err := c.Cache.Set(ctx, key, value, options...)
+	tracing.End(span, err)
+
+	return err
+}
+
+func (c *Traced) Delete(ctx context.Context, key string) error {
+	ctx, span := c.start(ctx, "cache.delete", key)
+
+	err := c.Cache.Delete(ctx, key)
+	tracing.End(span, err)
+
+	return err
+}
+
+func (c *Traced) SetIfAbsent(ctx context.Context, key string, value any, options ...Option) (bool, error) {
+	ctx, span := c.start(ctx, "cache.set_if_absent", key)
+
+	stored, err := c.Cache.SetIfAbsent(ctx, key, value, options...)
+	tracing.End(span, err)
+
+	return stored, err
+}
+
+func (c *Traced) ExpireIfEqual(ctx context.Context, key string, value any, ttl time.Duration) (bool, error) {
+	ctx, span := c.start(ctx, "cache.expire_if_equal", key)
+
+	expired, err := c.Cache.ExpireIfEqual(ctx, key, value, ttl)
+	tracing.End(span, err)
+
+	return expired, err
+}
+
+func (c *Traced) DeleteIfEqual(ctx context.Context, key string, value any) (bool, error) {
+	ctx, span := c.start(ctx, "cache.delete_if_equal", key)
+
+	deleted, err := c.Cache.DeleteIfEqual(ctx, key, value)
+	tracing.End(span, err)
+
+	return deleted, err
+}
+
+func (c *Traced) Clear(ctx context.Context) error {
+	ctx, span := tracing.Start(ctx, "cache.clear", c.driver)
+
+	err := c.Cache.Clear(ctx)
+	tracing.End(span, err)
+
+	return err
+}
+
+// Unwrap returns the underlying cache.
+func (c *Traced) Unwrap() Cache {
+	return c.Cache
+}
+
+func (c *Traced) start(ctx context.Context, name, key string) (context.Context, trace.Span) {
+	return tracing.Start(ctx, name, c.driver, attribute.String("cache.key", key))
+}
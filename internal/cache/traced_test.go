@@ -0,0 +1,46 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gameap/gameap/internal/tracing/tracingtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func TestTraced(t *testing.T) {
+	recorder := tracingtest.NewRecorder(t)
+	ctx := context.Background()
+
+	c := NewTraced(NewInMemory(), "memory")
+
+	_, err := c.Get(ctx, "key")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, c.Set(ctx, "key", "value"))
+
+	_, err = c.Get(ctx, "key")
+	require.NoError(t, err)
+
+	require.NoError(t, c.Delete(ctx, "key"))
+	require.NoError(t, c.Clear(ctx))
+
+	assert.Equal(
+		t,
+		[]string{"cache.get", "cache.set", "cache.get", "cache.delete", "cache.clear"},
+		tracingtest.SpanNames(recorder),
+	)
+
+	spans := recorder.Ended()
+
+	assert.Contains(t, spans[0].Attributes(), attribute.Bool("cache.hit", false))
+	assert.Contains(t, spans[0].Attributes(), attribute.String("cache.driver", "memory"))
+	assert.Equal(t, codes.Unset, spans[0].Status().Code, "missing key isn't an error")
+	assert.Contains(t, spans[2].Attributes(), attribute.Bool("cache.hit", true))
+	assert.Contains(t, spans[2].Attributes(), attribute.String("cache.key", "key"))
+
+	assert.Same(t, Unwrap(c), c.Cache)
+}
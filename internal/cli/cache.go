@@ -0,0 +1,22 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/gameap/gameap/internal/application"
+	"github.com/pkg/errors"
+)
+
+func cacheClear(_ *flag.FlagSet) action {
+	return func(ctx context.Context, c *application.Container, con *console) error {
+		if err := c.Cache().Clear(ctx); err != nil {
+			return errors.WithMessage(err, "failed to clear cache")
+		}
+
+		_, _ = fmt.Fprintln(con.stdout, "Cache cleared")
+
+		return nil
+	}
+}
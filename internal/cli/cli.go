@@ -0,0 +1,239 @@
+// Package cli implements the commands of the gameap binary: the server and the administration commands
+// for scripting deployments. The commands use the same container as the server, so they are configured
+// with the same environment variables and env files.
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gameap/gameap/internal/application"
+	"github.com/gameap/gameap/internal/application/defaults"
+	"github.com/pkg/errors"
+)
+
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+// console is the input and the output of the commands, the logs are written to stderr by the logger.
+type console struct {
+	stdin  io.Reader
+	stdout io.Writer
+}
+
+// action runs the command with the parsed flags.
+type action func(ctx context.Context, c *application.Container, con *console) error
+
+type command struct {
+	// name is the command with the subcommand, for example "user create".
+	name        string
+	description string
+	// setup defines the flags of the command and returns the action reading them.
+	setup func(fs *flag.FlagSet) action
+}
+
+func commands() []command {
+	return []command{
+		{name: "migrate up", description: "Apply the pending migrations and seed the initial data", setup: migrateUp},
+		{name: "migrate down", description: "Roll back the last migration or the migrations after a version", setup: migrateDown},
+		{name: "migrate status", description: "Show the state of the migrations", setup: migrateStatus},
+		{name: "user create", description: "Create a user", setup: userCreate},
+		{name: "user passwd", description: "Change the password of a user", setup: userPasswd},
+		{name: "user set-role", description: "Replace the roles of a user", setup: userSetRole},
+		{name: "node list", description: "List the nodes", setup: nodeList},
+		{name: "node check", description: "Check the connection to the daemons of the nodes", setup: nodeCheck},
+		{name: "token create", description: "Create a personal access token of a user", setup: tokenCreate},
+		{name: "games upgrade", description: "Upgrade the games and the mods from the GameAP API", setup: gamesUpgrade},
+		{name: "cache clear", description: "Remove all the cached values", setup: cacheClear},
+	}
+}
+
+// usageError is an invalid invocation of a command, the usage of the command is printed with it.
+type usageError struct {
+	message string
+}
+
+func newUsageError(format string, args ...any) error {
+	return usageError{message: fmt.Sprintf(format, args...)}
+}
+
+func (e usageError) Error() string {
+	return e.message
+}
+
+// Run runs the command of the arguments and returns the exit code. The server is started
+// if the arguments have no command, so the binary runs the same way as before the commands were added.
+func Run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	params := application.RunParams{
+		LegacyEnvFile: defaults.LegacyEnvPath,
+	}
+
+	global := flag.NewFlagSet("gameap", flag.ContinueOnError)
+	global.SetOutput(stderr)
+	global.Usage = func() {
+		printUsage(stderr, global)
+	}
+	bindRunParams(global, &params)
+
+	if err := global.Parse(args); err != nil {
+		return parseErrorCode(err)
+	}
+
+	args = global.Args()
+
+	if len(args) == 0 || args[0] == "serve" {
+		return serve(args, params, stderr)
+	}
+
+	cmd, rest := findCommand(args)
+	if cmd == nil {
+		_, _ = fmt.Fprintf(stderr, "Unknown command %q\n\n", strings.Join(args, " "))
+		printUsage(stderr, global)
+
+		return exitUsage
+	}
+
+	fs := flag.NewFlagSet("gameap "+cmd.name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	bindRunParams(fs, &params)
+
+	run := cmd.setup(fs)
+
+	if err := fs.Parse(rest); err != nil {
+		return parseErrorCode(err)
+	}
+
+	if fs.NArg() > 0 {
+		_, _ = fmt.Fprintf(stderr, "Unexpected arguments: %s\n", strings.Join(fs.Args(), " "))
+		fs.Usage()
+
+		return exitUsage
+	}
+
+	container, err := application.Bootstrap(ctx, params)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "Error: %s\n", err)
+
+		return exitError
+	}
+
+	defer func() {
+		_ = container.Shutdown()
+	}()
+
+	err = runRecovered(ctx, run, container, &console{stdin: stdin, stdout: stdout})
+
+	var usageErr usageError
+
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.As(err, &usageErr):
+		_, _ = fmt.Fprintf(stderr, "%s\n", usageErr.message)
+		fs.Usage()
+
+		return exitUsage
+	default:
+		_, _ = fmt.Fprintf(stderr, "Error: %s\n", err)
+
+		return exitError
+	}
+}
+
+// runRecovered runs the action returning the panics as errors, the container panics
+// when a service can't be created, for example when the database is unavailable.
+func runRecovered(ctx context.Context, run action, c *application.Container, con *console) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("%v", r)
+		}
+	}()
+
+	return run(ctx, c, con)
+}
+
+func serve(args []string, params application.RunParams, stderr io.Writer) int {
+	fs := flag.NewFlagSet("gameap serve", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	bindRunParams(fs, &params)
+
+	if len(args) > 0 {
+		if err := fs.Parse(args[1:]); err != nil {
+			return parseErrorCode(err)
+		}
+	}
+
+	slog.Info("Starting ...")
+
+	application.Run(params)
+
+	return exitOK
+}
+
+// bindRunParams binds the flags of the env files. The flags are accepted before and after the command,
+// the values parsed before the command are the defaults of the command flags.
+func bindRunParams(fs *flag.FlagSet, params *application.RunParams) {
+	fs.StringVar(&params.EnvFile, "env", params.EnvFile, "Path to environment file")
+	fs.StringVar(&params.LegacyEnvFile, "legacy-env", params.LegacyEnvFile, "Path to legacy environment file")
+}
+
+// findCommand returns the command with the longest name matching the first arguments and the rest arguments.
+func findCommand(args []string) (*command, []string) {
+	var found *command
+	var rest []string
+
+	for _, cmd := range commands() {
+		words := strings.Fields(cmd.name)
+
+		if len(args) < len(words) || strings.Join(args[:len(words)], " ") != cmd.name {
+			continue
+		}
+
+		if found == nil || len(words) > len(strings.Fields(found.name)) {
+			found = &cmd
+			rest = args[len(words):]
+		}
+	}
+
+	return found, rest
+}
+
+func parseErrorCode(err error) int {
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+
+	return exitUsage
+}
+
+func printUsage(w io.Writer, global *flag.FlagSet) {
+	_, _ = fmt.Fprintf(w, "Usage: gameap [flags] [command] [command flags]\n\n")
+	_, _ = fmt.Fprintf(w, "Commands:\n")
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintf(tw, "  serve\tStart the panel, the default command\n")
+
+	for _, cmd := range commands() {
+		_, _ = fmt.Fprintf(tw, "  %s\t%s\n", cmd.name, cmd.description)
+	}
+
+	_ = tw.Flush()
+
+	_, _ = fmt.Fprintf(w, "\nFlags:\n")
+	global.PrintDefaults()
+	_, _ = fmt.Fprintf(w, "\nRun \"gameap <command> -h\" for the flags of a command.\n")
+}
+
+// newTable returns the writer aligning the tab separated columns, it must be flushed.
+func newTable(w io.Writer) *tabwriter.Writer {
+	return tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+}
@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gameap/gameap/internal/application"
+	"github.com/gameap/gameap/internal/config"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/migrations"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	_ "modernc.org/sqlite"
+)
+
+// setupContainer returns the container with a migrated SQLite database and the seeded roles.
+func setupContainer(t *testing.T) *application.Container {
+	t.Helper()
+
+	ctx := context.Background()
+
+	setEnv(t)
+	t.Setenv("DATABASE_DRIVER", "sqlite")
+	t.Setenv("DATABASE_URL", "file:"+filepath.Join(t.TempDir(), "gameap.db")+"?_busy_timeout=5000")
+
+	cfg, err := config.LoadConfig()
+	require.NoError(t, err)
+
+	c := application.NewContainer(cfg)
+	c.SetContext(ctx)
+
+	t.Cleanup(func() {
+		_ = c.Shutdown()
+	})
+
+	require.NoError(t, migrations.Run(ctx, c))
+
+	adminRole := domain.Role{Name: "admin", Title: lo.ToPtr("Administrator")}
+	require.NoError(t, c.RBACRepository().SaveRole(ctx, &adminRole))
+	require.NoError(t, c.RBACRepository().SaveRole(ctx, &domain.Role{Name: "user", Title: lo.ToPtr("User")}))
+	require.NoError(t, c.RBACRepository().Allow(ctx, adminRole.ID, domain.EntityTypeRole, []domain.Ability{
+		{Name: domain.AbilityNameAdminRolesPermissions},
+	}))
+
+	return c
+}
+
+// setEnv sets the required configuration of the in-memory database.
+func setEnv(t *testing.T) {
+	t.Helper()
+
+	t.Setenv("DATABASE_DRIVER", "inmemory")
+	t.Setenv("DATABASE_URL", "inmemory")
+	t.Setenv("AUTH_SECRET", "test-secret")
+	t.Setenv("FILES_LOCAL_BASE_PATH", t.TempDir())
+}
+
+// runAction parses the arguments with the flags of the command and runs it.
+func runAction(
+	t *testing.T,
+	c *application.Container,
+	setup func(fs *flag.FlagSet) action,
+	stdin string,
+	args ...string,
+) (string, error) {
+	t.Helper()
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	run := setup(fs)
+	require.NoError(t, fs.Parse(args))
+
+	stdout := &bytes.Buffer{}
+
+	err := run(context.Background(), c, &console{stdin: strings.NewReader(stdin), stdout: stdout})
+
+	return stdout.String(), err
+}
+
+func TestFindCommand(t *testing.T) {
+	cmd, rest := findCommand([]string{"user", "create", "-login", "admin"})
+	require.NotNil(t, cmd)
+	assert.Equal(t, "user create", cmd.name)
+	assert.Equal(t, []string{"-login", "admin"}, rest)
+
+	cmd, _ = findCommand([]string{"user"})
+	assert.Nil(t, cmd)
+
+	cmd, _ = findCommand([]string{"user", "delete"})
+	assert.Nil(t, cmd)
+}
+
+func TestRun_Usage(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantStderr string
+	}{
+		{
+			name:       "help",
+			args:       []string{"-h"},
+			wantCode:   exitOK,
+			wantStderr: "user create",
+		},
+		{
+			name:       "unknown_command",
+			args:       []string{"user", "delete"},
+			wantCode:   exitUsage,
+			wantStderr: `Unknown command "user delete"`,
+		},
+		{
+			name:       "unknown_flag",
+			args:       []string{"cache", "clear", "-force"},
+			wantCode:   exitUsage,
+			wantStderr: "flag provided but not defined: -force",
+		},
+		{
+			name:       "unexpected_arguments",
+			args:       []string{"cache", "clear", "now"},
+			wantCode:   exitUsage,
+			wantStderr: "Unexpected arguments: now",
+		},
+		{
+			name:       "missing_required_flags",
+			args:       []string{"user", "set-role", "-login", "admin"},
+			wantCode:   exitUsage,
+			wantStderr: "-login and -roles are required",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			setEnv(t)
+
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+
+			code := Run(context.Background(), append([]string{"-legacy-env", ""}, test.args...), nil, stdout, stderr)
+
+			assert.Equal(t, test.wantCode, code)
+			assert.Contains(t, stderr.String(), test.wantStderr)
+			assert.Empty(t, stdout.String())
+		})
+	}
+}
+
+func TestRun_CacheClear(t *testing.T) {
+	setEnv(t)
+
+	stdout := &bytes.Buffer{}
+
+	code := Run(context.Background(), []string{"cache", "clear", "-legacy-env", ""}, nil, stdout, &bytes.Buffer{})
+
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "Cache cleared\n", stdout.String())
+}
@@ -0,0 +1,22 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/gameap/gameap/internal/application"
+	"github.com/pkg/errors"
+)
+
+func gamesUpgrade(_ *flag.FlagSet) action {
+	return func(ctx context.Context, c *application.Container, con *console) error {
+		if err := c.GameUpgradeService().UpgradeGames(ctx); err != nil {
+			return errors.WithMessage(err, "failed to upgrade games")
+		}
+
+		_, _ = fmt.Fprintln(con.stdout, "Games upgraded")
+
+		return nil
+	}
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/gameap/gameap/internal/application"
+	"github.com/gameap/gameap/migrations"
+	"github.com/pressly/goose/v3"
+)
+
+func migrateUp(_ *flag.FlagSet) action {
+	return func(ctx context.Context, c *application.Container, con *console) error {
+		if err := application.Migrate(ctx, c); err != nil {
+			return err
+		}
+
+		_, _ = fmt.Fprintln(con.stdout, "Migrations applied")
+
+		return nil
+	}
+}
+
+func migrateDown(fs *flag.FlagSet) action {
+	to := fs.Int64("to", -1, "Roll back the migrations applied after the version, only the last one if it's negative")
+
+	return func(ctx context.Context, c *application.Container, con *console) error {
+		var results []*goose.MigrationResult
+
+		if *to < 0 {
+			result, err := migrations.Down(ctx, c)
+			if err != nil {
+				return err
+			}
+
+			results = append(results, result)
+		} else {
+			var err error
+
+			results, err = migrations.DownTo(ctx, c, *to)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(results) == 0 {
+			_, _ = fmt.Fprintln(con.stdout, "No migrations to roll back")
+
+			return nil
+		}
+
+		for _, result := range results {
+			_, _ = fmt.Fprintf(con.stdout, "Rolled back %s\n", result)
+		}
+
+		return nil
+	}
+}
+
+func migrateStatus(_ *flag.FlagSet) action {
+	return func(ctx context.Context, c *application.Container, con *console) error {
+		statuses, err := migrations.Status(ctx, c)
+		if err != nil {
+			return err
+		}
+
+		table := newTable(con.stdout)
+
+		_, _ = fmt.Fprintln(table, "VERSION\tSTATE\tAPPLIED AT\tSOURCE")
+
+		for _, status := range statuses {
+			appliedAt := "-"
+			if !status.AppliedAt.IsZero() {
+				appliedAt = status.AppliedAt.Format(time.DateTime)
+			}
+
+			source := string(status.Source.Type)
+			if status.Source.Path != "" {
+				source = status.Source.Path
+			}
+
+			_, _ = fmt.Fprintf(table, "%d\t%s\t%s\t%s\n", status.Source.Version, status.State, appliedAt, source)
+		}
+
+		return table.Flush()
+	}
+}
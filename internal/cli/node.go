@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"cmp"
+	"context"
+	"flag"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gameap/gameap/internal/application"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/pkg/errors"
+)
+
+var errNodesUnavailable = errors.New("some nodes are unavailable")
+
+func nodeList(_ *flag.FlagSet) action {
+	return func(ctx context.Context, c *application.Container, con *console) error {
+		nodes, err := findNodes(ctx, c, nil)
+		if err != nil {
+			return err
+		}
+
+		table := newTable(con.stdout)
+
+		_, _ = fmt.Fprintln(table, "ID\tNAME\tLOCATION\tDAEMON\tENABLED")
+
+		for _, node := range nodes {
+			_, _ = fmt.Fprintf(
+				table,
+				"%d\t%s\t%s\t%s:%d\t%t\n",
+				node.ID, node.Name, node.Location, node.GdaemonHost, node.GdaemonPort, node.Enabled,
+			)
+		}
+
+		return table.Flush()
+	}
+}
+
+func nodeCheck(fs *flag.FlagSet) action {
+	ids := fs.String("id", "", "Comma separated IDs of the checked nodes, all the nodes are checked if it's empty")
+	timeout := fs.Duration("timeout", 10*time.Second, "Timeout of the check of a node")
+
+	return func(ctx context.Context, c *application.Container, con *console) error {
+		nodeIDs, err := parseIDs(*ids)
+		if err != nil {
+			return newUsageError("invalid -id: %s", err)
+		}
+
+		nodes, err := findNodes(ctx, c, nodeIDs)
+		if err != nil {
+			return err
+		}
+
+		if len(nodes) == 0 {
+			return errors.New("no nodes found")
+		}
+
+		table := newTable(con.stdout)
+
+		_, _ = fmt.Fprintln(table, "ID\tNAME\tSTATUS\tVERSION\tUPTIME\tDETAILS")
+
+		failed := false
+
+		for _, node := range nodes {
+			checkCtx, cancel := context.WithTimeout(ctx, *timeout)
+			status, err := c.DaemonStatus().Status(checkCtx, &node)
+			cancel()
+
+			if err != nil {
+				failed = true
+
+				_, _ = fmt.Fprintf(table, "%d\t%s\tFAIL\t-\t-\t%s\n", node.ID, node.Name, err)
+
+				continue
+			}
+
+			_, _ = fmt.Fprintf(
+				table,
+				"%d\t%s\tOK\t%s\t%s\tonline servers: %d, working tasks: %d, waiting tasks: %d\n",
+				node.ID,
+				node.Name,
+				status.Version,
+				status.Uptime.Truncate(time.Second),
+				status.OnlineServers,
+				status.WorkingTasks,
+				status.WaitingTasks,
+			)
+		}
+
+		if err := table.Flush(); err != nil {
+			return err
+		}
+
+		if failed {
+			return errNodesUnavailable
+		}
+
+		return nil
+	}
+}
+
+func findNodes(ctx context.Context, c *application.Container, ids []uint) ([]domain.Node, error) {
+	var nodes []domain.Node
+	var err error
+
+	if len(ids) > 0 {
+		nodes, err = c.NodeRepository().Find(ctx, filters.FindNodeByIDs(ids...), nil, nil)
+	} else {
+		nodes, err = c.NodeRepository().FindAll(ctx, nil, nil)
+	}
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find nodes")
+	}
+
+	slices.SortFunc(nodes, func(a, b domain.Node) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return nodes, nil
+}
+
+func parseIDs(s string) ([]uint, error) {
+	items := splitList(s)
+	ids := make([]uint, 0, len(items))
+
+	for _, item := range items {
+		id, err := strconv.ParseUint(item, 10, 0)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid id %q", item)
+		}
+
+		ids = append(ids, uint(id))
+	}
+
+	return ids, nil
+}
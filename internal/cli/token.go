@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/gameap/gameap/internal/application"
+	"github.com/gameap/gameap/internal/domain"
+	pkgstrings "github.com/gameap/gameap/pkg/strings"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+const tokenLength = 48
+
+func tokenCreate(fs *flag.FlagSet) action {
+	login := fs.String("login", "", "Login of the token owner")
+	name := fs.String("name", "", "Name of the token")
+	abilities := fs.String("abilities", "", "Comma separated abilities of the token, for example \"server:start,server:stop\"")
+
+	return func(ctx context.Context, c *application.Container, con *console) error {
+		tokenAbilities := splitList(*abilities)
+
+		if *login == "" || *name == "" || len(tokenAbilities) == 0 {
+			return newUsageError("-login, -name and -abilities are required")
+		}
+
+		for _, ability := range tokenAbilities {
+			if !domain.ValidateAbility(ability) {
+				return errors.Errorf("invalid ability %q", ability)
+			}
+		}
+
+		user, err := findUser(ctx, c, *login)
+		if err != nil {
+			return err
+		}
+
+		isAdmin, err := c.RBAC().Can(ctx, user.ID, []domain.AbilityName{domain.AbilityNameAdminRolesPermissions})
+		if err != nil {
+			return errors.WithMessage(err, "failed to check user permissions")
+		}
+
+		if !isAdmin {
+			adminAbilities := lo.Keyify(domain.GetAdminAbilities())
+
+			for _, ability := range tokenAbilities {
+				if _, ok := adminAbilities[domain.PATAbility(ability)]; ok {
+					return errors.Errorf("ability %q requires admin role", ability)
+				}
+			}
+		}
+
+		plainToken, err := pkgstrings.CryptoRandomString(tokenLength)
+		if err != nil {
+			return errors.WithMessage(err, "failed to generate token")
+		}
+
+		parsedAbilities := domain.ParseAbilities(lo.Uniq(tokenAbilities))
+		now := time.Now()
+
+		token := &domain.PersonalAccessToken{
+			TokenableType: domain.EntityTypeUser,
+			TokenableID:   user.ID,
+			Name:          *name,
+			Token:         pkgstrings.SHA256(plainToken),
+			Abilities:     &parsedAbilities,
+			CreatedAt:     &now,
+			UpdatedAt:     &now,
+		}
+
+		if err := c.PersonalAccessTokenRepository().Save(ctx, token); err != nil {
+			return errors.WithMessage(err, "failed to save token")
+		}
+
+		// Only the token is written to stdout, so it can be captured by the scripts
+		_, _ = fmt.Fprintf(con.stdout, "%d|%s\n", token.ID, plainToken)
+
+		return nil
+	}
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	pkgstrings "github.com/gameap/gameap/pkg/strings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenCreate(t *testing.T) {
+	c := setupContainer(t)
+	ctx := context.Background()
+
+	_, err := runAction(t, c, userCreate, "", "-login", "john", "-email", "john@example.com", "-password", "password123")
+	require.NoError(t, err)
+
+	out, err := runAction(t, c, tokenCreate, "", "-login", "john", "-name", "deploy", "-abilities", "server:start,server:stop")
+	require.NoError(t, err)
+
+	id, plainToken, ok := strings.Cut(strings.TrimSpace(out), "|")
+	require.True(t, ok)
+	assert.Len(t, plainToken, tokenLength)
+
+	tokenID, err := strconv.ParseUint(id, 10, 64)
+	require.NoError(t, err)
+
+	tokens, err := c.PersonalAccessTokenRepository().Find(ctx, &filters.FindPersonalAccessToken{
+		Tokens: []string{pkgstrings.SHA256(plainToken)},
+	}, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	assert.Equal(t, uint(tokenID), tokens[0].ID)
+	assert.Equal(t, "deploy", tokens[0].Name)
+	require.NotNil(t, tokens[0].Abilities)
+	assert.ElementsMatch(t, []domain.PATAbility{
+		domain.PATAbilityServerStart,
+		domain.PATAbilityServerStop,
+	}, *tokens[0].Abilities)
+}
+
+func TestTokenCreate_Errors(t *testing.T) {
+	c := setupContainer(t)
+
+	_, err := runAction(t, c, userCreate, "", "-login", "john", "-email", "john@example.com", "-password", "password123")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "missing_abilities",
+			args:    []string{"-login", "john", "-name", "deploy"},
+			wantErr: "-login, -name and -abilities are required",
+		},
+		{
+			name:    "invalid_ability",
+			args:    []string{"-login", "john", "-name", "deploy", "-abilities", "server:fly"},
+			wantErr: `invalid ability "server:fly"`,
+		},
+		{
+			name:    "admin_ability_for_user",
+			args:    []string{"-login", "john", "-name", "deploy", "-abilities", "admin:server:create"},
+			wantErr: `ability "admin:server:create" requires admin role`,
+		},
+		{
+			name:    "unknown_user",
+			args:    []string{"-login", "jane", "-name", "deploy", "-abilities", "server:start"},
+			wantErr: `user "jane" not found`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := runAction(t, c, tokenCreate, "", test.args...)
+
+			require.EqualError(t, err, test.wantErr)
+		})
+	}
+}
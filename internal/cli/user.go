@@ -0,0 +1,198 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gameap/gameap/internal/application"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/gameap/gameap/pkg/validation"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+const (
+	minPasswordLength = 8
+	maxPasswordLength = 64
+)
+
+var (
+	errUserExists       = errors.New("user with this login or email already exists")
+	errInvalidEmail     = errors.New("email is not valid")
+	errPasswordTooShort = errors.Errorf("password must be at least %d characters long", minPasswordLength)
+	errPasswordTooLong  = errors.Errorf("password must not exceed %d characters", maxPasswordLength)
+)
+
+func userCreate(fs *flag.FlagSet) action {
+	login := fs.String("login", "", "Login of the user")
+	email := fs.String("email", "", "Email of the user")
+	name := fs.String("name", "", "Name of the user")
+	password := fs.String("password", "", "Password of the user, read from the first line of stdin if it's empty")
+	roles := fs.String("roles", "user", "Comma separated roles of the user")
+
+	return func(ctx context.Context, c *application.Container, con *console) error {
+		if *login == "" || *email == "" {
+			return newUsageError("-login and -email are required")
+		}
+
+		if !validation.IsEmail(*email) {
+			return errInvalidEmail
+		}
+
+		pw, err := readPassword(*password, con.stdin)
+		if err != nil {
+			return err
+		}
+
+		hash, err := auth.HashPassword(pw)
+		if err != nil {
+			return errors.WithMessage(err, "failed to hash password")
+		}
+
+		existing, err := c.UserService().Find(ctx, &filters.FindUser{Logins: []string{*login}}, nil, nil)
+		if err != nil {
+			return errors.WithMessage(err, "failed to find users")
+		}
+
+		if len(existing) == 0 {
+			existing, err = c.UserService().Find(ctx, &filters.FindUser{Emails: []string{*email}}, nil, nil)
+			if err != nil {
+				return errors.WithMessage(err, "failed to find users")
+			}
+		}
+
+		if len(existing) > 0 {
+			return errUserExists
+		}
+
+		user := &domain.User{
+			Login:    *login,
+			Email:    *email,
+			Name:     lo.EmptyableToPtr(*name),
+			Password: hash,
+		}
+
+		err = c.TransactionManager().Do(ctx, func(ctx context.Context) error {
+			if err := c.UserService().Save(ctx, user); err != nil {
+				return errors.WithMessage(err, "failed to save user")
+			}
+
+			return c.RBAC().SetRolesToUser(ctx, user.ID, splitList(*roles))
+		})
+		if err != nil {
+			return err
+		}
+
+		_, _ = fmt.Fprintf(con.stdout, "User %s created with ID %d\n", user.Login, user.ID)
+
+		return nil
+	}
+}
+
+func userPasswd(fs *flag.FlagSet) action {
+	login := fs.String("login", "", "Login of the user")
+	password := fs.String("password", "", "New password of the user, read from the first line of stdin if it's empty")
+
+	return func(ctx context.Context, c *application.Container, con *console) error {
+		if *login == "" {
+			return newUsageError("-login is required")
+		}
+
+		user, err := findUser(ctx, c, *login)
+		if err != nil {
+			return err
+		}
+
+		pw, err := readPassword(*password, con.stdin)
+		if err != nil {
+			return err
+		}
+
+		user.Password, err = auth.HashPassword(pw)
+		if err != nil {
+			return errors.WithMessage(err, "failed to hash password")
+		}
+
+		if err := c.UserService().Save(ctx, user); err != nil {
+			return errors.WithMessage(err, "failed to save user")
+		}
+
+		_, _ = fmt.Fprintf(con.stdout, "Password of user %s changed\n", user.Login)
+
+		return nil
+	}
+}
+
+func userSetRole(fs *flag.FlagSet) action {
+	login := fs.String("login", "", "Login of the user")
+	roles := fs.String("roles", "", "Comma separated roles replacing the current ones, for example \"admin\"")
+
+	return func(ctx context.Context, c *application.Container, con *console) error {
+		if *login == "" || *roles == "" {
+			return newUsageError("-login and -roles are required")
+		}
+
+		user, err := findUser(ctx, c, *login)
+		if err != nil {
+			return err
+		}
+
+		if err := c.RBAC().SetRolesToUser(ctx, user.ID, splitList(*roles)); err != nil {
+			return err
+		}
+
+		_, _ = fmt.Fprintf(con.stdout, "Roles of user %s set to %s\n", user.Login, strings.Join(splitList(*roles), ", "))
+
+		return nil
+	}
+}
+
+func findUser(ctx context.Context, c *application.Container, login string) (*domain.User, error) {
+	users, err := c.UserService().Find(ctx, &filters.FindUser{Logins: []string{login}}, nil, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find user")
+	}
+
+	if len(users) == 0 {
+		return nil, errors.Errorf("user %q not found", login)
+	}
+
+	return &users[0], nil
+}
+
+// readPassword returns the password of the flag or the first line of the input,
+// so the password isn't visible in the process list when it's piped.
+func readPassword(password string, stdin io.Reader) (string, error) {
+	if password == "" {
+		line, err := bufio.NewReader(stdin).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", errors.Wrap(err, "failed to read password")
+		}
+
+		password = strings.TrimRight(line, "\r\n")
+	}
+
+	switch {
+	case password == "":
+		return "", newUsageError("-password is required or must be passed to stdin")
+	case len(password) < minPasswordLength:
+		return "", errPasswordTooShort
+	case len(password) > maxPasswordLength:
+		return "", errPasswordTooLong
+	}
+
+	return password, nil
+}
+
+// splitList splits the comma separated values, the empty values are skipped.
+func splitList(s string) []string {
+	return lo.Compact(lo.Map(strings.Split(s, ","), func(item string, _ int) string {
+		return strings.TrimSpace(item)
+	}))
+}
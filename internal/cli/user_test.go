@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserCreate(t *testing.T) {
+	c := setupContainer(t)
+	ctx := context.Background()
+
+	out, err := runAction(t, c, userCreate, "",
+		"-login", "Admin", "-email", "admin@example.com", "-name", "Admin", "-password", "password123", "-roles", "admin",
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "User admin created with ID 1\n", out)
+
+	user, err := findUser(ctx, c, "admin")
+	require.NoError(t, err)
+	assert.Equal(t, "admin@example.com", user.Email)
+	require.NotNil(t, user.Name)
+	assert.Equal(t, "Admin", *user.Name)
+	assert.NoError(t, auth.VerifyPassword(user.Password, "password123"))
+
+	roles, err := c.RBAC().GetRoles(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admin"}, roles)
+}
+
+func TestUserCreate_PasswordFromStdin(t *testing.T) {
+	c := setupContainer(t)
+
+	_, err := runAction(t, c, userCreate, "secret-password\n", "-login", "john", "-email", "john@example.com")
+	require.NoError(t, err)
+
+	user, err := findUser(context.Background(), c, "john")
+	require.NoError(t, err)
+	assert.NoError(t, auth.VerifyPassword(user.Password, "secret-password"))
+}
+
+func TestUserCreate_Errors(t *testing.T) {
+	c := setupContainer(t)
+
+	_, err := runAction(t, c, userCreate, "", "-login", "john", "-email", "john@example.com", "-password", "password123")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		stdin   string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "missing_login",
+			args:    []string{"-email", "jane@example.com", "-password", "password123"},
+			wantErr: "-login and -email are required",
+		},
+		{
+			name:    "invalid_email",
+			args:    []string{"-login", "jane", "-email", "jane", "-password", "password123"},
+			wantErr: errInvalidEmail.Error(),
+		},
+		{
+			name:    "missing_password",
+			args:    []string{"-login", "jane", "-email", "jane@example.com"},
+			wantErr: "-password is required or must be passed to stdin",
+		},
+		{
+			name:    "short_password",
+			stdin:   "short\n",
+			args:    []string{"-login", "jane", "-email", "jane@example.com"},
+			wantErr: errPasswordTooShort.Error(),
+		},
+		{
+			name:    "existing_login",
+			args:    []string{"-login", "JOHN", "-email", "jane@example.com", "-password", "password123"},
+			wantErr: errUserExists.Error(),
+		},
+		{
+			name:    "existing_email",
+			args:    []string{"-login", "jane", "-email", "john@example.com", "-password", "password123"},
+			wantErr: errUserExists.Error(),
+		},
+		{
+			name:    "unknown_role",
+			args:    []string{"-login", "jane", "-email", "jane@example.com", "-password", "password123", "-roles", "owner"},
+			wantErr: "owner",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := runAction(t, c, userCreate, test.stdin, test.args...)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.wantErr)
+		})
+	}
+
+	// The user with the unknown role isn't created
+	_, err = findUser(context.Background(), c, "jane")
+	require.Error(t, err)
+}
+
+func TestUserPasswd(t *testing.T) {
+	c := setupContainer(t)
+
+	_, err := runAction(t, c, userCreate, "", "-login", "john", "-email", "john@example.com", "-password", "password123")
+	require.NoError(t, err)
+
+	out, err := runAction(t, c, userPasswd, "new-password\n", "-login", "john")
+	require.NoError(t, err)
+	assert.Equal(t, "Password of user john changed\n", out)
+
+	user, err := findUser(context.Background(), c, "john")
+	require.NoError(t, err)
+	assert.NoError(t, auth.VerifyPassword(user.Password, "new-password"))
+
+	_, err = runAction(t, c, userPasswd, "new-password\n", "-login", "jane")
+	require.EqualError(t, err, `user "jane" not found`)
+}
+
+func TestUserSetRole(t *testing.T) {
+	c := setupContainer(t)
+	ctx := context.Background()
+
+	_, err := runAction(t, c, userCreate, "", "-login", "john", "-email", "john@example.com", "-password", "password123")
+	require.NoError(t, err)
+
+	out, err := runAction(t, c, userSetRole, "", "-login", "john", "-roles", "admin, user")
+	require.NoError(t, err)
+	assert.Equal(t, "Roles of user john set to admin, user\n", out)
+
+	user, err := findUser(ctx, c, "john")
+	require.NoError(t, err)
+
+	roles, err := c.RBAC().GetRoles(ctx, user.ID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"admin", "user"}, roles)
+}
@@ -73,6 +73,236 @@ type Config struct {
 	Logger struct {
 		Level        string `env:"LOGGER_LEVEL" envDefault:"info"`
 		LogDBQueries bool   `env:"LOGGER_LOG_DB_QUERIES" envDefault:"false"`
+		// Format is "text" or "json".
+		Format string `env:"LOGGER_FORMAT" envDefault:"text"`
+		// File is the path of the log file written in addition to stderr, disabled if it's empty.
+		File string `env:"LOGGER_FILE" envDefault:""`
+		// FileMaxSize is the size in megabytes after which the log file is rotated.
+		FileMaxSize    int  `env:"LOGGER_FILE_MAX_SIZE" envDefault:"100"`
+		FileMaxBackups int  `env:"LOGGER_FILE_MAX_BACKUPS" envDefault:"7"`
+		FileMaxAge     int  `env:"LOGGER_FILE_MAX_AGE" envDefault:"30"`
+		FileCompress   bool `env:"LOGGER_FILE_COMPRESS" envDefault:"false"`
+	}
+
+	ServerExpiration struct {
+		Enabled       bool   `env:"SERVER_EXPIRATION_ENABLED" envDefault:"false"`
+		CheckInterval string `env:"SERVER_EXPIRATION_CHECK_INTERVAL" envDefault:"1m"`
+		GracePeriod   string `env:"SERVER_EXPIRATION_GRACE_PERIOD" envDefault:"0s"`
+		// DeleteAfter is counted from the expiration date. Empty or zero value disables deletion.
+		DeleteAfter string `env:"SERVER_EXPIRATION_DELETE_AFTER" envDefault:""`
+	}
+
+	ServerTaskScheduler struct {
+		Enabled  bool   `env:"SERVER_TASK_SCHEDULER_ENABLED" envDefault:"false"`
+		Interval string `env:"SERVER_TASK_SCHEDULER_INTERVAL" envDefault:"30s"`
+		// TakeoverDelay is how long a task may stay overdue before the panel executes it instead of the daemon.
+		TakeoverDelay string `env:"SERVER_TASK_SCHEDULER_TAKEOVER_DELAY" envDefault:"2m"`
+		LockTTL       string `env:"SERVER_TASK_SCHEDULER_LOCK_TTL" envDefault:"1m"`
+	}
+
+	ConsoleStream struct {
+		// PollInterval is how often a streamed server console is read from the node.
+		PollInterval string `env:"CONSOLE_STREAM_POLL_INTERVAL" envDefault:"1s"`
+	}
+
+	Backups struct {
+		// KeepLast and MaxAge are the default retention policy, a server may override them in its settings.
+		// Zero values disable the corresponding rule.
+		KeepLast int    `env:"BACKUPS_KEEP_LAST" envDefault:"0"`
+		MaxAge   string `env:"BACKUPS_MAX_AGE" envDefault:""`
+		// Timeout limits a single backup or restore operation.
+		Timeout string `env:"BACKUPS_TIMEOUT" envDefault:"1h"`
+	}
+
+	ServerMove struct {
+		CheckInterval string `env:"SERVER_MOVE_CHECK_INTERVAL" envDefault:"10s"`
+		// Timeout limits transferring the server files between nodes.
+		Timeout string `env:"SERVER_MOVE_TIMEOUT" envDefault:"2h"`
+	}
+
+	ServerPorts struct {
+		// RangeStart and RangeEnd are the default range of automatically allocated ports,
+		// a node may override them.
+		RangeStart int `env:"SERVER_PORTS_RANGE_START" envDefault:"27015"`
+		RangeEnd   int `env:"SERVER_PORTS_RANGE_END" envDefault:"27999"`
+		// LockTimeout limits waiting for another server creation on the same node.
+		LockTimeout string `env:"SERVER_PORTS_LOCK_TIMEOUT" envDefault:"10s"`
+	}
+
+	Metrics struct {
+		// DownsampleInterval is how often raw points are averaged into minute and hour points.
+		DownsampleInterval string `env:"METRICS_DOWNSAMPLE_INTERVAL" envDefault:"1m"`
+		RawRetention       string `env:"METRICS_RAW_RETENTION" envDefault:"24h"`
+		MinuteRetention    string `env:"METRICS_MINUTE_RETENTION" envDefault:"168h"`
+		HourRetention      string `env:"METRICS_HOUR_RETENTION" envDefault:"2160h"`
+	}
+
+	ServerQueryPoller struct {
+		Enabled  bool   `env:"SERVER_QUERY_POLLER_ENABLED" envDefault:"false"`
+		Interval string `env:"SERVER_QUERY_POLLER_INTERVAL" envDefault:"1m"`
+		// Concurrency is the maximum number of servers queried at the same time.
+		Concurrency int `env:"SERVER_QUERY_POLLER_CONCURRENCY" envDefault:"10"`
+		// NodeRate is the maximum number of queries per second sent to the servers of one node.
+		// Zero value disables the limit.
+		NodeRate int `env:"SERVER_QUERY_POLLER_NODE_RATE" envDefault:"5"`
+		// Retention is how long the query history is kept. Empty or zero value keeps it forever.
+		Retention string `env:"SERVER_QUERY_POLLER_RETENTION" envDefault:"720h"`
+		LockTTL   string `env:"SERVER_QUERY_POLLER_LOCK_TTL" envDefault:"2m"`
+	}
+
+	ServerWatchdog struct {
+		Enabled  bool   `env:"SERVER_WATCHDOG_ENABLED" envDefault:"false"`
+		Interval string `env:"SERVER_WATCHDOG_INTERVAL" envDefault:"30s"`
+		// Threshold is how long a server must stay offline before it is restarted.
+		Threshold string `env:"SERVER_WATCHDOG_THRESHOLD" envDefault:"1m"`
+		// BaseDelay is the delay between the first and the second restart, each next delay is doubled up to MaxDelay.
+		BaseDelay string `env:"SERVER_WATCHDOG_BASE_DELAY" envDefault:"30s"`
+		MaxDelay  string `env:"SERVER_WATCHDOG_MAX_DELAY" envDefault:"10m"`
+		// MaxAttempts is the number of restarts after which the server is considered to be in a crash loop.
+		MaxAttempts int `env:"SERVER_WATCHDOG_MAX_ATTEMPTS" envDefault:"5"`
+		// StablePeriod is how long a server must run after the last restart to reset the attempts.
+		StablePeriod string `env:"SERVER_WATCHDOG_STABLE_PERIOD" envDefault:"10m"`
+		LockTTL      string `env:"SERVER_WATCHDOG_LOCK_TTL" envDefault:"1m"`
+	}
+
+	Webhooks struct {
+		// Interval is how often pending deliveries are sent.
+		Interval string `env:"WEBHOOKS_INTERVAL" envDefault:"5s"`
+		// Timeout limits a single delivery request.
+		Timeout string `env:"WEBHOOKS_TIMEOUT" envDefault:"10s"`
+		// BaseDelay is the delay before the first retry of a failed delivery, each next delay is doubled up to MaxDelay.
+		BaseDelay string `env:"WEBHOOKS_BASE_DELAY" envDefault:"30s"`
+		MaxDelay  string `env:"WEBHOOKS_MAX_DELAY" envDefault:"1h"`
+		// MaxAttempts is the number of attempts after which a delivery is marked as failed.
+		MaxAttempts int `env:"WEBHOOKS_MAX_ATTEMPTS" envDefault:"6"`
+		// Retention is how long the delivery history is kept. Empty or zero value keeps it forever.
+		Retention string `env:"WEBHOOKS_RETENTION" envDefault:"168h"`
+		// AllowPrivateNetworks allows webhook URLs resolving to loopback, private and link-local addresses.
+		AllowPrivateNetworks bool   `env:"WEBHOOKS_ALLOW_PRIVATE_NETWORKS" envDefault:"false"`
+		LockTTL              string `env:"WEBHOOKS_LOCK_TTL" envDefault:"1m"`
+	}
+
+	Notifications struct {
+		// Timeout limits a single message request to Discord or Telegram.
+		Timeout string `env:"NOTIFICATIONS_TIMEOUT" envDefault:"10s"`
+		// DefaultLanguage is the language of the messages of channels created without a language.
+		DefaultLanguage string `env:"NOTIFICATIONS_DEFAULT_LANGUAGE" envDefault:"en"`
+		// TelegramBotToken is the token of the bot sending the messages to Telegram chats.
+		TelegramBotToken string `env:"NOTIFICATIONS_TELEGRAM_BOT_TOKEN" envDefault:""`
+		TelegramAPIURL   string `env:"NOTIFICATIONS_TELEGRAM_API_URL" envDefault:"https://api.telegram.org"`
+	}
+
+	Mail struct {
+		// Host of the SMTP server, emails aren't sent if it's empty.
+		Host     string `env:"MAIL_HOST" envDefault:""`
+		Port     uint16 `env:"MAIL_PORT" envDefault:"587"`
+		Username string `env:"MAIL_USERNAME" envDefault:""`
+		Password string `env:"MAIL_PASSWORD" envDefault:""`
+		// Encryption is "starttls", "tls" for implicit TLS, or "none".
+		Encryption  string `env:"MAIL_ENCRYPTION" envDefault:"starttls"`
+		FromAddress string `env:"MAIL_FROM_ADDRESS" envDefault:""`
+		FromName    string `env:"MAIL_FROM_NAME" envDefault:"GameAP"`
+		// Timeout limits sending a single email.
+		Timeout string `env:"MAIL_TIMEOUT" envDefault:"30s"`
+	}
+
+	PasswordReset struct {
+		// URL is the link to the reset page sent by email, ":token" and ":email" are replaced.
+		// Password reset is disabled if it's empty.
+		URL string `env:"PASSWORD_RESET_URL" envDefault:""`
+		// TTL is how long a reset token is valid.
+		TTL string `env:"PASSWORD_RESET_TTL" envDefault:"1h"`
+		// Throttle is the minimal interval between the emails sent to the same address.
+		Throttle string `env:"PASSWORD_RESET_THROTTLE" envDefault:"1m"`
+		// MaxAttempts is the number of requests allowed from a client IP per RateLimitWindow.
+		MaxAttempts     int    `env:"PASSWORD_RESET_MAX_ATTEMPTS" envDefault:"5"`
+		RateLimitWindow string `env:"PASSWORD_RESET_RATE_LIMIT_WINDOW" envDefault:"15m"`
+		// ClientIPHeader is the header with the client IP set by a reverse proxy, for example "X-Real-IP".
+		// The connection address is used if it's empty. The proxy must overwrite the header sent by clients.
+		ClientIPHeader string `env:"PASSWORD_RESET_CLIENT_IP_HEADER" envDefault:""`
+	}
+
+	TwoFactor struct {
+		// EnforceAdmins requires administrators to use two-factor authentication.
+		// Administrators without it have to set it up during the next login.
+		EnforceAdmins bool `env:"TWO_FACTOR_ENFORCE_ADMINS" envDefault:"false"`
+		// Issuer is the account issuer shown in authenticator apps.
+		Issuer string `env:"TWO_FACTOR_ISSUER" envDefault:"GameAP"`
+		// ChallengeTTL is how long the second login step waits for the code.
+		ChallengeTTL string `env:"TWO_FACTOR_CHALLENGE_TTL" envDefault:"5m"`
+		// MaxAttempts is the number of invalid codes allowed for a user per RateLimitWindow.
+		MaxAttempts     int    `env:"TWO_FACTOR_MAX_ATTEMPTS" envDefault:"5"`
+		RateLimitWindow string `env:"TWO_FACTOR_RATE_LIMIT_WINDOW" envDefault:"15m"`
+	}
+
+	OIDC struct {
+		// StateTTL is how long the login at the identity provider may take.
+		StateTTL string `env:"OIDC_STATE_TTL" envDefault:"10m"`
+		// Timeout limits a single request to an identity provider.
+		Timeout string `env:"OIDC_TIMEOUT" envDefault:"10s"`
+		// Providers are configured with indexed variables, for example OIDC_PROVIDERS_0_NAME.
+		Providers []OIDCProvider `envPrefix:"OIDC_PROVIDERS"`
+	}
+
+	LDAP struct {
+		// URL of the directory, for example "ldaps://ldap.example.com:636". LDAP login is disabled if it's empty.
+		URL      string `env:"LDAP_URL" envDefault:""`
+		StartTLS bool   `env:"LDAP_START_TLS" envDefault:"false"`
+		// InsecureSkipVerify disables the verification of the directory certificate.
+		InsecureSkipVerify bool `env:"LDAP_INSECURE_SKIP_VERIFY" envDefault:"false"`
+		// BindDN and BindPassword are the service account searching the users. Anonymous search is used if BindDN is empty.
+		BindDN       string `env:"LDAP_BIND_DN" envDefault:""`
+		BindPassword string `env:"LDAP_BIND_PASSWORD" envDefault:""`
+		BaseDN       string `env:"LDAP_BASE_DN" envDefault:""`
+		// UserFilter finds the user entry, "{login}" is replaced with the escaped login or email.
+		UserFilter     string `env:"LDAP_USER_FILTER" envDefault:"(&(objectClass=person)(uid={login}))"`
+		LoginAttribute string `env:"LDAP_LOGIN_ATTRIBUTE" envDefault:"uid"`
+		EmailAttribute string `env:"LDAP_EMAIL_ATTRIBUTE" envDefault:"mail"`
+		NameAttribute  string `env:"LDAP_NAME_ATTRIBUTE" envDefault:"cn"`
+		// GroupAttribute is the attribute of the user entry with the group DNs.
+		GroupAttribute string `env:"LDAP_GROUP_ATTRIBUTE" envDefault:"memberOf"`
+		// RoleMapping maps the group DNs to the panel roles, for example
+		// "cn=gameap-admins,ou=groups,dc=example,dc=com:admin;cn=players,ou=groups,dc=example,dc=com:user".
+		// The roles of the user are synced on each login if it isn't empty.
+		RoleMapping map[string]string `env:"LDAP_ROLE_MAPPING" envSeparator:";" envKeyValSeparator:":"`
+		// DefaultRoles are assigned to provisioned users and to users without mapped groups.
+		DefaultRoles []string `env:"LDAP_DEFAULT_ROLES" envSeparator:"," envDefault:"user"`
+		// LinkExistingUsers links an entry on the first login to the existing user with the same login.
+		LinkExistingUsers bool `env:"LDAP_LINK_EXISTING_USERS" envDefault:"false"`
+		// LinkByEmail links an entry on the first login to the existing user with the same login and email.
+		LinkByEmail bool `env:"LDAP_LINK_BY_EMAIL" envDefault:"false"`
+		// Timeout limits a single directory operation.
+		Timeout string `env:"LDAP_TIMEOUT" envDefault:"10s"`
+		// SyncInterval is how often the users are checked in the directory, users missing there are disabled.
+		SyncInterval string `env:"LDAP_SYNC_INTERVAL" envDefault:"1h"`
+		LockTTL      string `env:"LDAP_SYNC_LOCK_TTL" envDefault:"10m"`
+	}
+
+	Audit struct {
+		// ClientIPHeader is the header with the client IP set by a reverse proxy, for example "X-Real-IP".
+		// The connection address is recorded if it's empty.
+		ClientIPHeader string `env:"AUDIT_CLIENT_IP_HEADER" envDefault:""`
+	}
+
+	Prometheus struct {
+		// Token protects the /metrics endpoint, the endpoint is disabled if it's empty.
+		Token string `env:"PROMETHEUS_TOKEN" envDefault:""`
+		// CollectTimeout limits the database queries of a scrape.
+		CollectTimeout string `env:"PROMETHEUS_COLLECT_TIMEOUT" envDefault:"5s"`
+	}
+
+	Tracing struct {
+		Enabled bool `env:"TRACING_ENABLED" envDefault:"false"`
+		// Endpoint is the host and port of the OTLP/HTTP collector.
+		Endpoint string `env:"TRACING_OTLP_ENDPOINT" envDefault:"localhost:4318"`
+		// Insecure sends the spans over plain HTTP.
+		Insecure bool `env:"TRACING_OTLP_INSECURE" envDefault:"false"`
+		// Headers are sent with the exported spans, for example "Authorization:Bearer token;X-Tenant:gameap".
+		Headers     map[string]string `env:"TRACING_OTLP_HEADERS" envSeparator:";" envKeyValSeparator:":"`
+		ServiceName string            `env:"TRACING_SERVICE_NAME" envDefault:"gameap"`
+		// SampleRatio is the part of the traces started by the panel which are recorded, from 0 to 1.
+		// The sampling decision of the incoming traces is respected.
+		SampleRatio float64 `env:"TRACING_SAMPLE_RATIO" envDefault:"1"`
 	}
 
 	Legacy struct {
@@ -85,6 +315,29 @@ type Config struct {
 	}
 }
 
+type OIDCProvider struct {
+	// Name identifies the provider in the API paths, for example "keycloak".
+	Name        string `env:"NAME"`
+	DisplayName string `env:"DISPLAY_NAME"`
+	// IssuerURL is the issuer, the discovery document is loaded from its /.well-known/openid-configuration.
+	IssuerURL    string   `env:"ISSUER_URL"`
+	ClientID     string   `env:"CLIENT_ID"`
+	ClientSecret string   `env:"CLIENT_SECRET"`
+	RedirectURL  string   `env:"REDIRECT_URL"`
+	Scopes       []string `env:"SCOPES" envSeparator:"," envDefault:"openid,profile,email"`
+	// AutoProvision creates a user on the first login with an unknown account.
+	AutoProvision bool `env:"AUTO_PROVISION" envDefault:"false"`
+	// LinkByEmail links an unknown account to the user with the same email, the email must be verified.
+	LinkByEmail bool `env:"LINK_BY_EMAIL" envDefault:"true"`
+	// RolesClaim is the claim with the groups or roles of the account, dots separate nested claims.
+	RolesClaim string `env:"ROLES_CLAIM" envDefault:"groups"`
+	// RoleMapping maps the values of RolesClaim to the panel roles, for example "gameap-admins=admin".
+	// The roles of the user are synced on each login if it isn't empty.
+	RoleMapping map[string]string `env:"ROLE_MAPPING" envSeparator:"," envKeyValSeparator:"="`
+	// DefaultRoles are assigned to provisioned users and to users without mapped roles.
+	DefaultRoles []string `env:"DEFAULT_ROLES" envSeparator:"," envDefault:"user"`
+}
+
 func LoadConfig() (*Config, error) {
 	var cfg Config
 	var err error
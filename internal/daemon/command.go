@@ -10,6 +10,8 @@ import (
 	"github.com/gameap/gameap/internal/domain"
 	"github.com/gameap/gameap/internal/files"
 	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/tracing"
+	"github.com/jackc/puddle/v2"
 	"github.com/pkg/errors"
 )
 
@@ -45,7 +47,12 @@ func (s *CommandService) ExecuteCommand(
 	node *domain.Node,
 	command string,
 	opts ...CommandServiceOption,
-) (*CommandResult, error) {
+) (_ *CommandResult, err error) {
+	ctx, span := startNodeSpan(ctx, "daemon.command", node)
+	defer func() { tracing.End(span, err) }()
+
+	ctx = withNodeLogAttrs(ctx, node)
+
 	cfg, err := s.configMaker.MakeWithMode(ctx, node, binnapi.ModeCMD)
 	if err != nil {
 		return nil, errors.WithMessage(err, "failed to make config")
@@ -77,7 +84,7 @@ func (s *CommandService) ExecuteCommand(
 		defer func() {
 			err = conn.Close()
 			if err != nil {
-				slog.Warn("failed to close connection", "error", err)
+				slog.WarnContext(ctx, "failed to close connection", "error", err)
 			}
 		}()
 
@@ -119,6 +126,14 @@ func (s *CommandService) ExecuteCommand(
 	}, nil
 }
 
+// PoolStats returns the statistics of the connection pools by the node IDs.
+func (s *CommandService) PoolStats() map[uint]*puddle.Stat {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return poolStats(s.pools)
+}
+
 func (s *CommandService) getPool(nodeID uint, cfg config) (*Pool, error) {
 	s.mu.RLock()
 	pool, exists := s.pools[nodeID]
@@ -10,7 +10,10 @@ import (
 	"time"
 
 	"github.com/gameap/gameap/internal/daemon/binnapi"
+	"github.com/gameap/gameap/internal/tracing"
 	"github.com/pkg/errors"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -65,7 +68,15 @@ func Connect(ctx context.Context, cfg config) (*Connection, error) {
 	return c, nil
 }
 
-func (c *Connection) connect(ctx context.Context) error {
+func (c *Connection) connect(ctx context.Context) (err error) {
+	ctx, span := tracing.Tracer().Start(
+		ctx,
+		"daemon.connect",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.ServerAddress(c.cfg.Host), semconv.ServerPort(c.cfg.Port)),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	// Load server CA certificate
 	serverCertPool := x509.NewCertPool()
 	if len(c.cfg.ServerCertificate) > 0 {
@@ -121,7 +132,7 @@ func (c *Connection) connect(ctx context.Context) error {
 	if err := conn.SetDeadline(time.Now().Add(c.cfg.Timeout)); err != nil {
 		closeErr := conn.Close()
 		if closeErr != nil {
-			slog.Warn("could not set connection deadline", "error", closeErr)
+			slog.WarnContext(ctx, "could not set connection deadline", "error", closeErr)
 		}
 
 		return errors.WithMessage(err, "could not set connection deadline")
@@ -129,10 +140,10 @@ func (c *Connection) connect(ctx context.Context) error {
 
 	c.conn = conn
 
-	if err := binnapi.Login(ctx, conn, c.cfg.Mode, c.cfg.Username, c.cfg.Password); err != nil {
+	if err := c.login(ctx, conn); err != nil {
 		closeErr := conn.Close()
 		if closeErr != nil {
-			slog.Warn("could not close connection", "error", closeErr)
+			slog.WarnContext(ctx, "could not close connection", "error", closeErr)
 		}
 
 		return err
@@ -141,6 +152,13 @@ func (c *Connection) connect(ctx context.Context) error {
 	return nil
 }
 
+func (c *Connection) login(ctx context.Context, conn net.Conn) (err error) {
+	ctx, span := tracing.Start(ctx, "daemon.login")
+	defer func() { tracing.End(span, err) }()
+
+	return binnapi.Login(ctx, conn, c.cfg.Mode, c.cfg.Username, c.cfg.Password)
+}
+
 func (c *Connection) Write(buffer []byte) (int, error) {
 	if c.conn == nil {
 		return 0, ErrConnectionNotEstablished
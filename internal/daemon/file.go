@@ -15,6 +15,7 @@ import (
 	"github.com/gameap/gameap/internal/domain"
 	"github.com/gameap/gameap/internal/files"
 	"github.com/gameap/gameap/internal/repositories"
+	"github.com/jackc/puddle/v2"
 	"github.com/pkg/errors"
 )
 
@@ -80,6 +81,8 @@ func (s *FileService) ReadDir(
 	node *domain.Node,
 	directory string,
 ) ([]*FileInfo, error) {
+	ctx = withNodeLogAttrs(ctx, node)
+
 	cfg, err := s.configMaker.MakeWithMode(ctx, node, binnapi.ModeFiles)
 	if err != nil {
 		return nil, errors.WithMessage(err, "failed to make config")
@@ -100,7 +103,7 @@ func (s *FileService) ReadDir(
 		defer func() {
 			err = conn.Close()
 			if err != nil {
-				slog.Warn("failed to close connection", "error", err)
+				slog.WarnContext(ctx, "failed to close connection", "error", err)
 			}
 		}()
 
@@ -162,6 +165,8 @@ func (s *FileService) ReadDir(
 
 // MkDir creates a directory.
 func (s *FileService) MkDir(ctx context.Context, node *domain.Node, directory string) error {
+	ctx = withNodeLogAttrs(ctx, node)
+
 	cfg, err := s.configMaker.MakeWithMode(ctx, node, binnapi.ModeFiles)
 	if err != nil {
 		return errors.WithMessage(err, "failed to make config")
@@ -182,7 +187,7 @@ func (s *FileService) MkDir(ctx context.Context, node *domain.Node, directory st
 		defer func() {
 			err = conn.Close()
 			if err != nil {
-				slog.Warn("failed to close connection", "error", err)
+				slog.WarnContext(ctx, "failed to close connection", "error", err)
 			}
 		}()
 
@@ -225,6 +230,8 @@ func (s *FileService) Move(ctx context.Context, node *domain.Node, source, desti
 
 // Move moves or copies a file.
 func (s *FileService) move(ctx context.Context, node *domain.Node, source, destination string, cp bool) error {
+	ctx = withNodeLogAttrs(ctx, node)
+
 	cfg, err := s.configMaker.MakeWithMode(ctx, node, binnapi.ModeFiles)
 	if err != nil {
 		return errors.WithMessage(err, "failed to make config")
@@ -245,7 +252,7 @@ func (s *FileService) move(ctx context.Context, node *domain.Node, source, desti
 		defer func() {
 			err = conn.Close()
 			if err != nil {
-				slog.Warn("failed to close connection", "error", err)
+				slog.WarnContext(ctx, "failed to close connection", "error", err)
 			}
 		}()
 
@@ -282,6 +289,8 @@ func (s *FileService) move(ctx context.Context, node *domain.Node, source, desti
 
 // Download downloads a file from the daemon.
 func (s *FileService) Download(ctx context.Context, node *domain.Node, filePath string) ([]byte, error) {
+	ctx = withNodeLogAttrs(ctx, node)
+
 	cfg, err := s.configMaker.MakeWithMode(ctx, node, binnapi.ModeFiles)
 	if err != nil {
 		return nil, errors.WithMessage(err, "failed to make config")
@@ -303,7 +312,7 @@ func (s *FileService) Download(ctx context.Context, node *domain.Node, filePath
 		defer func() {
 			err = conn.Close()
 			if err != nil {
-				slog.Warn("failed to close connection", "error", err)
+				slog.WarnContext(ctx, "failed to close connection", "error", err)
 			}
 		}()
 
@@ -360,6 +369,8 @@ func (s *FileService) DownloadStream(
 	node *domain.Node,
 	filePath string,
 ) (io.ReadCloser, error) {
+	ctx = withNodeLogAttrs(ctx, node)
+
 	cfg, err := s.configMaker.MakeWithMode(ctx, node, binnapi.ModeFiles)
 	if err != nil {
 		return nil, errors.WithMessage(err, "failed to make config")
@@ -387,7 +398,7 @@ func (s *FileService) DownloadStream(
 		if err != nil {
 			connCloseErr := conn.Close()
 			if connCloseErr != nil {
-				slog.Warn("failed to close connection", "error", connCloseErr)
+				slog.WarnContext(ctx, "failed to close connection", "error", connCloseErr)
 			}
 
 			return errors.WithMessage(err, "failed to write download request")
@@ -397,7 +408,7 @@ func (s *FileService) DownloadStream(
 		if err != nil {
 			connCloseErr := conn.Close()
 			if connCloseErr != nil {
-				slog.Warn("failed to close connection", "error", connCloseErr)
+				slog.WarnContext(ctx, "failed to close connection", "error", connCloseErr)
 			}
 
 			return errors.WithMessage(err, "failed to read download response")
@@ -406,7 +417,7 @@ func (s *FileService) DownloadStream(
 		if resp.Code != binnapi.StatusCodeReadyToTransfer {
 			connCloseErr := conn.Close()
 			if connCloseErr != nil {
-				slog.Warn("failed to close connection", "error", connCloseErr)
+				slog.WarnContext(ctx, "failed to close connection", "error", connCloseErr)
 			}
 
 			return errors.Errorf("download failed with status code %d: %s", resp.Code, resp.Info)
@@ -416,7 +427,7 @@ func (s *FileService) DownloadStream(
 		if err != nil {
 			connCloseErr := conn.Close()
 			if connCloseErr != nil {
-				slog.Warn("failed to close connection", "error", connCloseErr)
+				slog.WarnContext(ctx, "failed to close connection", "error", connCloseErr)
 			}
 
 			return errors.WithMessage(err, "failed to get file size")
@@ -436,7 +447,7 @@ func (s *FileService) DownloadStream(
 	if fileSize > uint64(math.MaxInt64) {
 		connCloseErr := conn.Close()
 		if connCloseErr != nil {
-			slog.Warn("failed to close connection", "error", connCloseErr)
+			slog.WarnContext(ctx, "failed to close connection", "error", connCloseErr)
 		}
 
 		return nil, errors.New("file size exceeds maximum supported size")
@@ -488,6 +499,8 @@ func (s *FileService) UploadStream(
 	size uint64,
 	perms os.FileMode,
 ) error {
+	ctx = withNodeLogAttrs(ctx, node)
+
 	cfg, err := s.configMaker.MakeWithMode(ctx, node, binnapi.ModeFiles)
 	if err != nil {
 		return errors.WithMessage(err, "failed to make config")
@@ -508,7 +521,7 @@ func (s *FileService) UploadStream(
 		defer func() {
 			err = conn.Close()
 			if err != nil {
-				slog.Warn("failed to close connection", "error", err)
+				slog.WarnContext(ctx, "failed to close connection", "error", err)
 			}
 		}()
 
@@ -560,6 +573,8 @@ func (s *FileService) UploadStream(
 
 // Remove removes a file or directory.
 func (s *FileService) Remove(ctx context.Context, node *domain.Node, path string, recursive bool) error {
+	ctx = withNodeLogAttrs(ctx, node)
+
 	cfg, err := s.configMaker.MakeWithMode(ctx, node, binnapi.ModeFiles)
 	if err != nil {
 		return errors.WithMessage(err, "failed to make config")
@@ -580,7 +595,7 @@ func (s *FileService) Remove(ctx context.Context, node *domain.Node, path string
 		defer func() {
 			err = conn.Close()
 			if err != nil {
-				slog.Warn("failed to close connection", "error", err)
+				slog.WarnContext(ctx, "failed to close connection", "error", err)
 			}
 		}()
 
@@ -616,6 +631,8 @@ func (s *FileService) Remove(ctx context.Context, node *domain.Node, path string
 
 // GetFileInfo gets detailed information about a file.
 func (s *FileService) GetFileInfo(ctx context.Context, node *domain.Node, path string) (*FileDetails, error) {
+	ctx = withNodeLogAttrs(ctx, node)
+
 	cfg, err := s.configMaker.MakeWithMode(ctx, node, binnapi.ModeFiles)
 	if err != nil {
 		return nil, errors.WithMessage(err, "failed to make config")
@@ -636,7 +653,7 @@ func (s *FileService) GetFileInfo(ctx context.Context, node *domain.Node, path s
 		defer func() {
 			err = conn.Close()
 			if err != nil {
-				slog.Warn("failed to close connection", "error", err)
+				slog.WarnContext(ctx, "failed to close connection", "error", err)
 			}
 		}()
 
@@ -685,6 +702,8 @@ func (s *FileService) GetFileInfo(ctx context.Context, node *domain.Node, path s
 
 // Chmod changes file permissions.
 func (s *FileService) Chmod(ctx context.Context, node *domain.Node, path string, perm uint32) error {
+	ctx = withNodeLogAttrs(ctx, node)
+
 	cfg, err := s.configMaker.MakeWithMode(ctx, node, binnapi.ModeFiles)
 	if err != nil {
 		return errors.WithMessage(err, "failed to make config")
@@ -705,7 +724,7 @@ func (s *FileService) Chmod(ctx context.Context, node *domain.Node, path string,
 		defer func() {
 			err = conn.Close()
 			if err != nil {
-				slog.Warn("failed to close connection", "error", err)
+				slog.WarnContext(ctx, "failed to close connection", "error", err)
 			}
 		}()
 
@@ -739,6 +758,14 @@ func (s *FileService) Chmod(ctx context.Context, node *domain.Node, path string,
 	return nil
 }
 
+// PoolStats returns the statistics of the connection pools by the node IDs.
+func (s *FileService) PoolStats() map[uint]*puddle.Stat {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return poolStats(s.pools)
+}
+
 func (s *FileService) getPool(nodeID uint, cfg config) (*Pool, error) {
 	s.mu.RLock()
 	pool, exists := s.pools[nodeID]
@@ -0,0 +1,18 @@
+package daemon
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/logger"
+)
+
+// withNodeLogAttrs adds the node to the log records of a call to the daemon of the node.
+func withNodeLogAttrs(ctx context.Context, node *domain.Node) context.Context {
+	if node == nil {
+		return ctx
+	}
+
+	return logger.WithAttrs(ctx, slog.Uint64("node_id", uint64(node.ID)))
+}
@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/gameap/gameap/internal/tracing"
 	"github.com/jackc/puddle/v2"
 	"github.com/pkg/errors"
 	"go.uber.org/multierr"
@@ -68,9 +69,11 @@ func (p *Pool) Close() error {
 	return nil
 }
 
-func (p *Pool) Acquire(ctx context.Context) (net.Conn, error) {
+func (p *Pool) Acquire(ctx context.Context) (_ net.Conn, err error) {
+	ctx, span := tracing.Start(ctx, "daemon.acquire")
+	defer func() { tracing.End(span, err) }()
+
 	var res *puddle.Resource[net.Conn]
-	var err error
 
 	for {
 		select {
@@ -103,7 +106,10 @@ func (p *Pool) Acquire(ctx context.Context) (net.Conn, error) {
 	}, nil
 }
 
-func (p *Pool) TryAcquire(ctx context.Context) (net.Conn, error) {
+func (p *Pool) TryAcquire(ctx context.Context) (_ net.Conn, err error) {
+	ctx, span := tracing.Start(ctx, "daemon.acquire")
+	defer func() { tracing.End(span, err) }()
+
 	res, err := p.p.TryAcquire(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not acquire connection from pool")
@@ -120,6 +126,16 @@ func (p *Pool) Stat() *puddle.Stat {
 	return p.p.Stat()
 }
 
+func poolStats(pools map[uint]*Pool) map[uint]*puddle.Stat {
+	stats := make(map[uint]*puddle.Stat, len(pools))
+
+	for nodeID, pool := range pools {
+		stats[nodeID] = pool.Stat()
+	}
+
+	return stats
+}
+
 func (p *Pool) WriteContext(ctx context.Context, buffer []byte) (n int, err error) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -11,6 +11,8 @@ import (
 	"github.com/gameap/gameap/internal/domain"
 	"github.com/gameap/gameap/internal/files"
 	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/tracing"
+	"github.com/jackc/puddle/v2"
 	"github.com/pkg/errors"
 )
 
@@ -50,7 +52,12 @@ type NodeVersion struct {
 	BuildDate string
 }
 
-func (s *StatusService) Version(ctx context.Context, node *domain.Node) (*NodeVersion, error) {
+func (s *StatusService) Version(ctx context.Context, node *domain.Node) (_ *NodeVersion, err error) {
+	ctx, span := startNodeSpan(ctx, "daemon.version", node)
+	defer func() { tracing.End(span, err) }()
+
+	ctx = withNodeLogAttrs(ctx, node)
+
 	cfg, err := s.configMaker.Make(ctx, node)
 	if err != nil {
 		return nil, errors.WithMessage(err, "failed to make config")
@@ -71,7 +78,7 @@ func (s *StatusService) Version(ctx context.Context, node *domain.Node) (*NodeVe
 		defer func() {
 			err = conn.Close()
 			if err != nil {
-				slog.Warn("failed to close connection", "error", err)
+				slog.WarnContext(ctx, "failed to close connection", "error", err)
 			}
 		}()
 
@@ -101,7 +108,12 @@ func (s *StatusService) Version(ctx context.Context, node *domain.Node) (*NodeVe
 	}, nil
 }
 
-func (s *StatusService) Status(ctx context.Context, node *domain.Node) (*NodeStatus, error) {
+func (s *StatusService) Status(ctx context.Context, node *domain.Node) (_ *NodeStatus, err error) {
+	ctx, span := startNodeSpan(ctx, "daemon.status", node)
+	defer func() { tracing.End(span, err) }()
+
+	ctx = withNodeLogAttrs(ctx, node)
+
 	cfg, err := s.configMaker.Make(ctx, node)
 	if err != nil {
 		return nil, errors.WithMessage(err, "failed to make config")
@@ -123,7 +135,7 @@ func (s *StatusService) Status(ctx context.Context, node *domain.Node) (*NodeSta
 		defer func() {
 			err = conn.Close()
 			if err != nil {
-				slog.Warn("failed to close connection", "error", err)
+				slog.WarnContext(ctx, "failed to close connection", "error", err)
 			}
 		}()
 
@@ -197,6 +209,14 @@ func (s *StatusService) Status(ctx context.Context, node *domain.Node) (*NodeSta
 	}, nil
 }
 
+// PoolStats returns the statistics of the connection pools by the node IDs.
+func (s *StatusService) PoolStats() map[uint]*puddle.Stat {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return poolStats(s.pools)
+}
+
 func (s *StatusService) getPool(nodeID uint, cfg config) (*Pool, error) {
 	s.mu.RLock()
 	pool, exists := s.pools[nodeID]
@@ -0,0 +1,27 @@
+package daemon
+
+import (
+	"context"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startNodeSpan starts a span of a call to the daemon of the node.
+func startNodeSpan(ctx context.Context, name string, node *domain.Node) (context.Context, trace.Span) {
+	options := []trace.SpanStartOption{trace.WithSpanKind(trace.SpanKindClient)}
+
+	// The calls with a missing node fail before connecting, the span records the error only
+	if node != nil {
+		options = append(options, trace.WithAttributes(
+			attribute.Int64("gameap.node.id", int64(node.ID)),
+			semconv.ServerAddress(node.GdaemonHost),
+			semconv.ServerPort(node.GdaemonPort),
+		))
+	}
+
+	return tracing.Tracer().Start(ctx, name, options...)
+}
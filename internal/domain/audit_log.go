@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// Types of the audited entities which aren't covered by the RBAC.
+const (
+	EntityTypeServerTemplate      EntityType = "server_templates"
+	EntityTypeWebhook             EntityType = "webhooks"
+	EntityTypeNotificationChannel EntityType = "notification_channels"
+	EntityTypePersonalAccessToken EntityType = "personal_access_tokens"
+)
+
+type AuditResult string
+
+const (
+	AuditResultSuccess AuditResult = "success"
+	AuditResultFailure AuditResult = "failure"
+)
+
+// AuditLog is a record of an action changing the panel or the game servers.
+type AuditLog struct {
+	ID uint `db:"id"`
+	// UserID is the user who made the request, nil for guest requests like logins.
+	UserID *uint `db:"user_id"`
+	// TokenID is the personal access token the request was authenticated with.
+	TokenID   *uint  `db:"token_id"`
+	IP        string `db:"ip"`
+	UserAgent string `db:"user_agent"`
+	// Action is the method and the route of the request, e.g. "POST /api/servers/{server}/start".
+	Action     string      `db:"action"`
+	EntityType *EntityType `db:"entity_type"`
+	// EntityID is the ID of the target entity, it's a string as games are identified by codes.
+	EntityID *string `db:"entity_id"`
+	// Changes is the JSON object with the changed fields of the entity as {"field": {"old": ..., "new": ...}}.
+	Changes *string `db:"changes"`
+	// Request is the JSON request body with the sensitive fields redacted.
+	Request    *string     `db:"request"`
+	Result     AuditResult `db:"result"`
+	StatusCode int         `db:"status_code"`
+	CreatedAt  *time.Time  `db:"created_at"`
+}
@@ -18,12 +18,14 @@ const (
 	PATAbilityServerStart          PATAbility = "server:start"
 	PATAbilityServerStop           PATAbility = "server:stop"
 	PATAbilityServerRestart        PATAbility = "server:restart"
+	PATAbilityServerPause          PATAbility = "server:pause"
 	PATAbilityServerUpdate         PATAbility = "server:update"
 	PATAbilityServerConsole        PATAbility = "server:console"
 	PATAbilityServerRconConsole    PATAbility = "server:rcon-console"
 	PATAbilityServerRconPlayers    PATAbility = "server:rcon-players"
 	PATAbilityServerTasksManage    PATAbility = "server:tasks-manage"
 	PATAbilityServerSettingsManage PATAbility = "server:settings-manage"
+	PATAbilityServerBackups        PATAbility = "server:backups"
 )
 
 type PATAbilityGroup string
@@ -46,11 +48,17 @@ type PersonalAccessToken struct {
 }
 
 type PasswordReset struct {
-	Email     string     `db:"email"`
+	Email string `db:"email"`
+	// Token is the bcrypt hash of the token sent to the user.
 	Token     string     `db:"token"`
 	CreatedAt *time.Time `db:"created_at"`
 }
 
+// Expired reports whether the reset is older than the ttl. A reset without the creation time is expired.
+func (r *PasswordReset) Expired(now time.Time, ttl time.Duration) bool {
+	return r.CreatedAt == nil || !now.Before(r.CreatedAt.Add(ttl))
+}
+
 func (token *PersonalAccessToken) HasAbility(ability PATAbility) bool {
 	return slices.Contains(*token.Abilities, ability)
 }
@@ -82,12 +90,14 @@ func GetUserAbilities() []PATAbility {
 		PATAbilityServerStart,
 		PATAbilityServerStop,
 		PATAbilityServerRestart,
+		PATAbilityServerPause,
 		PATAbilityServerUpdate,
 		PATAbilityServerConsole,
 		PATAbilityServerRconConsole,
 		PATAbilityServerRconPlayers,
 		PATAbilityServerTasksManage,
 		PATAbilityServerSettingsManage,
+		PATAbilityServerBackups,
 	}
 }
 
@@ -113,12 +123,14 @@ func GetAbilityDescriptions() map[PATAbility]string {
 		PATAbilityServerStart:          "Start game server",
 		PATAbilityServerStop:           "Stop game server",
 		PATAbilityServerRestart:        "Restart game server",
+		PATAbilityServerPause:          "Pause and unpause game server",
 		PATAbilityServerUpdate:         "Update game server",
 		PATAbilityServerConsole:        "Access to read and write into game server console",
 		PATAbilityServerRconConsole:    "Access to game server RCON console",
 		PATAbilityServerRconPlayers:    "Access to players management on game server",
 		PATAbilityServerTasksManage:    "Manage game server tasks",
 		PATAbilityServerSettingsManage: "Manage game server settings",
+		PATAbilityServerBackups:        "Manage game server backups",
 	}
 }
 
@@ -131,12 +143,14 @@ func GetGroupedAbilities(includeAdmin bool) GroupedAbilities {
 		{PATAbilityServerStart, descriptions[PATAbilityServerStart]},
 		{PATAbilityServerStop, descriptions[PATAbilityServerStop]},
 		{PATAbilityServerRestart, descriptions[PATAbilityServerRestart]},
+		{PATAbilityServerPause, descriptions[PATAbilityServerPause]},
 		{PATAbilityServerUpdate, descriptions[PATAbilityServerUpdate]},
 		{PATAbilityServerConsole, descriptions[PATAbilityServerConsole]},
 		{PATAbilityServerRconConsole, descriptions[PATAbilityServerRconConsole]},
 		{PATAbilityServerRconPlayers, descriptions[PATAbilityServerRconPlayers]},
 		{PATAbilityServerTasksManage, descriptions[PATAbilityServerTasksManage]},
 		{PATAbilityServerSettingsManage, descriptions[PATAbilityServerSettingsManage]},
+		{PATAbilityServerBackups, descriptions[PATAbilityServerBackups]},
 	}
 
 	if includeAdmin {
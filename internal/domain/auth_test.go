@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -216,16 +217,18 @@ func TestPersonalAccessToken_HasAllAbilities(t *testing.T) {
 func TestGetUserAbilities(t *testing.T) {
 	abilities := GetUserAbilities()
 
-	assert.Len(t, abilities, 10, "should return 10 user abilities")
+	assert.Len(t, abilities, 12, "should return 12 user abilities")
 	assert.Contains(t, abilities, PATAbilityServerStart)
 	assert.Contains(t, abilities, PATAbilityServerStop)
 	assert.Contains(t, abilities, PATAbilityServerRestart)
+	assert.Contains(t, abilities, PATAbilityServerPause)
 	assert.Contains(t, abilities, PATAbilityServerUpdate)
 	assert.Contains(t, abilities, PATAbilityServerConsole)
 	assert.Contains(t, abilities, PATAbilityServerRconConsole)
 	assert.Contains(t, abilities, PATAbilityServerRconPlayers)
 	assert.Contains(t, abilities, PATAbilityServerTasksManage)
 	assert.Contains(t, abilities, PATAbilityServerSettingsManage)
+	assert.Contains(t, abilities, PATAbilityServerBackups)
 
 	assert.NotContains(t, abilities, PATAbilityServerCreate)
 	assert.NotContains(t, abilities, PATAbilityGDaemonTaskRead)
@@ -285,7 +288,7 @@ func TestGetGroupedAbilities(t *testing.T) {
 		assert.NotContains(t, grouped, PATAbilityGroupGDaemonTask)
 
 		serverAbilities := grouped[PATAbilityGroupServer]
-		assert.Len(t, serverAbilities, 10, "should have 10 server abilities without admin")
+		assert.Len(t, serverAbilities, 12, "should have 12 server abilities without admin")
 
 		var hasServerCreate bool
 		for _, ab := range serverAbilities {
@@ -305,7 +308,7 @@ func TestGetGroupedAbilities(t *testing.T) {
 		require.Contains(t, grouped, PATAbilityGroupGDaemonTask)
 
 		serverAbilities := grouped[PATAbilityGroupServer]
-		assert.Len(t, serverAbilities, 11, "should have 11 server abilities with admin")
+		assert.Len(t, serverAbilities, 13, "should have 13 server abilities with admin")
 
 		var hasServerCreate bool
 		for _, ab := range serverAbilities {
@@ -528,12 +531,52 @@ func TestPasswordReset_Fields(t *testing.T) {
 	assert.Equal(t, &now, reset.CreatedAt)
 }
 
+func TestPasswordReset_Expired(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		createdAt *time.Time
+		want      bool
+	}{
+		{
+			name:      "fresh",
+			createdAt: lo.ToPtr(now.Add(-30 * time.Minute)),
+			want:      false,
+		},
+		{
+			name:      "older_than_ttl",
+			createdAt: lo.ToPtr(now.Add(-2 * time.Hour)),
+			want:      true,
+		},
+		{
+			name:      "exactly_ttl",
+			createdAt: lo.ToPtr(now.Add(-time.Hour)),
+			want:      true,
+		},
+		{
+			name:      "without_created_at",
+			createdAt: nil,
+			want:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reset := PasswordReset{CreatedAt: test.createdAt}
+
+			assert.Equal(t, test.want, reset.Expired(now, time.Hour))
+		})
+	}
+}
+
 func TestPATAbilityConstants(t *testing.T) {
 	assert.Equal(t, PATAbility("admin:server:create"), PATAbilityServerCreate)
 	assert.Equal(t, PATAbility("admin:gdaemon-task:read"), PATAbilityGDaemonTaskRead)
 	assert.Equal(t, PATAbility("server:start"), PATAbilityServerStart)
 	assert.Equal(t, PATAbility("server:stop"), PATAbilityServerStop)
 	assert.Equal(t, PATAbility("server:restart"), PATAbilityServerRestart)
+	assert.Equal(t, PATAbility("server:pause"), PATAbilityServerPause)
 	assert.Equal(t, PATAbility("server:update"), PATAbilityServerUpdate)
 	assert.Equal(t, PATAbility("server:console"), PATAbilityServerConsole)
 	assert.Equal(t, PATAbility("server:rcon-console"), PATAbilityServerRconConsole)
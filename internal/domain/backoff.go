@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// exponentialBackoff returns the delay after the given number of attempts.
+// The first delay is base, each next delay is doubled and limited by maxDelay if it is set.
+func exponentialBackoff(base, maxDelay time.Duration, attempts int) time.Duration {
+	if attempts <= 0 {
+		return 0
+	}
+
+	delay := base
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+
+		if maxDelay > 0 && delay >= maxDelay {
+			return maxDelay
+		}
+	}
+
+	if maxDelay > 0 {
+		return min(delay, maxDelay)
+	}
+
+	return delay
+}
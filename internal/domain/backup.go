@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+type BackupStatus string
+
+const (
+	// BackupStatusCreating means the server files are being archived and transferred to the storage.
+	BackupStatusCreating BackupStatus = "creating"
+	// BackupStatusCompleted means the backup archive is stored and can be restored.
+	BackupStatusCompleted BackupStatus = "completed"
+	// BackupStatusFailed means the backup could not be created, Error holds the reason.
+	BackupStatusFailed BackupStatus = "failed"
+	// BackupStatusRestoring means the backup archive is being restored into the server directory.
+	BackupStatusRestoring BackupStatus = "restoring"
+)
+
+// Backup is an archive of the game server directory kept in the panel file storage.
+type Backup struct {
+	ID       uint         `db:"id"`
+	ServerID uint         `db:"server_id"`
+	Status   BackupStatus `db:"status"`
+	// Path is the archive path in the panel file storage.
+	Path string `db:"path"`
+	// Size is the archive size in bytes.
+	Size int64 `db:"size"`
+	// Error is the reason of the last failed backup or restore.
+	Error     *string    `db:"error"`
+	CreatedAt *time.Time `db:"created_at"`
+	UpdatedAt *time.Time `db:"updated_at"`
+}
+
+// InProgress reports whether the backup is being created or restored.
+func (b *Backup) InProgress() bool {
+	return b.Status == BackupStatusCreating || b.Status == BackupStatusRestoring
+}
+
+// BackupRetentionPolicy describes which backups of a server are kept.
+type BackupRetentionPolicy struct {
+	// KeepLast is the number of the latest completed backups to keep. Zero value means unlimited.
+	KeepLast int
+
+	// MaxAge is the maximum age of a completed backup. Zero value means unlimited.
+	MaxAge time.Duration
+}
+
+// Expired returns completed backups which should be deleted at the given time.
+// The latest completed backup is always kept, so a server never loses its last backup because of age.
+func (p BackupRetentionPolicy) Expired(backups []Backup, now time.Time) []Backup {
+	completed := make([]Backup, 0, len(backups))
+	for _, b := range backups {
+		if b.Status == BackupStatusCompleted {
+			completed = append(completed, b)
+		}
+	}
+
+	// Latest first
+	slices.SortFunc(completed, func(a, b Backup) int {
+		if c := backupCreatedAt(&b).Compare(backupCreatedAt(&a)); c != 0 {
+			return c
+		}
+
+		return int(b.ID) - int(a.ID)
+	})
+
+	var expired []Backup
+
+	for i := 1; i < len(completed); i++ {
+		b := completed[i]
+
+		tooMany := p.KeepLast > 0 && i >= p.KeepLast
+		tooOld := p.MaxAge > 0 && backupCreatedAt(&b).Before(now.Add(-p.MaxAge))
+
+		if tooMany || tooOld {
+			expired = append(expired, b)
+		}
+	}
+
+	return expired
+}
+
+func backupCreatedAt(b *Backup) time.Time {
+	if b.CreatedAt == nil {
+		return time.Time{}
+	}
+
+	return *b.CreatedAt
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackup_InProgress(t *testing.T) {
+	assert.True(t, (&Backup{Status: BackupStatusCreating}).InProgress())
+	assert.True(t, (&Backup{Status: BackupStatusRestoring}).InProgress())
+	assert.False(t, (&Backup{Status: BackupStatusCompleted}).InProgress())
+	assert.False(t, (&Backup{Status: BackupStatusFailed}).InProgress())
+}
+
+func TestBackupRetentionPolicy_Expired(t *testing.T) {
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) *time.Time {
+		return lo.ToPtr(now.AddDate(0, 0, -days))
+	}
+
+	backups := []Backup{
+		{ID: 1, Status: BackupStatusCompleted, CreatedAt: daysAgo(30)},
+		{ID: 2, Status: BackupStatusCompleted, CreatedAt: daysAgo(10)},
+		{ID: 3, Status: BackupStatusFailed, CreatedAt: daysAgo(5)},
+		{ID: 4, Status: BackupStatusCompleted, CreatedAt: daysAgo(3)},
+		{ID: 5, Status: BackupStatusCompleted, CreatedAt: daysAgo(1)},
+		{ID: 6, Status: BackupStatusCreating, CreatedAt: daysAgo(0)},
+	}
+
+	tests := []struct {
+		name    string
+		policy  BackupRetentionPolicy
+		backups []Backup
+		want    []uint
+	}{
+		{
+			name:    "unlimited",
+			policy:  BackupRetentionPolicy{},
+			backups: backups,
+			want:    nil,
+		},
+		{
+			name:    "keep last",
+			policy:  BackupRetentionPolicy{KeepLast: 2},
+			backups: backups,
+			want:    []uint{2, 1},
+		},
+		{
+			name:    "max age",
+			policy:  BackupRetentionPolicy{MaxAge: 7 * 24 * time.Hour},
+			backups: backups,
+			want:    []uint{2, 1},
+		},
+		{
+			name:    "keep last and max age",
+			policy:  BackupRetentionPolicy{KeepLast: 1, MaxAge: 20 * 24 * time.Hour},
+			backups: backups,
+			want:    []uint{4, 2, 1},
+		},
+		{
+			name:   "latest backup is kept regardless of age",
+			policy: BackupRetentionPolicy{MaxAge: 24 * time.Hour},
+			backups: []Backup{
+				{ID: 1, Status: BackupStatusCompleted, CreatedAt: daysAgo(30)},
+				{ID: 2, Status: BackupStatusCompleted, CreatedAt: daysAgo(10)},
+			},
+			want: []uint{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expired := tt.policy.Expired(tt.backups, now)
+
+			ids := lo.Map(expired, func(b Backup, _ int) uint {
+				return b.ID
+			})
+			if len(ids) == 0 {
+				ids = nil
+			}
+
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType is the type of the panel events delivered to webhooks.
+type EventType string
+
+const (
+	EventTypeDaemonTaskStatusChanged EventType = "daemon_task.status_changed"
+	EventTypeServerCreated           EventType = "server.created"
+	EventTypeServerDeleted           EventType = "server.deleted"
+	EventTypeServerOnline            EventType = "server.online"
+	EventTypeServerOffline           EventType = "server.offline"
+	EventTypeServerTaskFailed        EventType = "server_task.failed"
+	EventTypeServerCrashLoop         EventType = "server.crash_loop"
+	EventTypeServerCrashed           EventType = "server.crashed"
+	EventTypeServerUpdateFinished    EventType = "server.update_finished"
+	EventTypeServerUpdateFailed      EventType = "server.update_failed"
+	EventTypeBackupFailed            EventType = "backup.failed"
+
+	// EventTypePing is only sent to test a webhook, it can't be subscribed to.
+	EventTypePing EventType = "ping"
+)
+
+// EventTypes are the event types webhooks can subscribe to.
+var EventTypes = []EventType{
+	EventTypeDaemonTaskStatusChanged,
+	EventTypeServerCreated,
+	EventTypeServerDeleted,
+	EventTypeServerOnline,
+	EventTypeServerOffline,
+	EventTypeServerTaskFailed,
+	EventTypeServerCrashLoop,
+	EventTypeServerCrashed,
+	EventTypeServerUpdateFinished,
+	EventTypeServerUpdateFailed,
+	EventTypeBackupFailed,
+}
+
+func (t EventType) Valid() bool {
+	return slices.Contains(EventTypes, t)
+}
+
+// Event is something that happened in the panel.
+type Event struct {
+	ID         uuid.UUID
+	Type       EventType
+	OccurredAt time.Time
+	// ServerID is the server the event is related to, nil for events not related to a server.
+	ServerID *uint
+	// Data is the event payload, it is serialized to JSON.
+	Data any
+}
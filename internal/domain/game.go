@@ -13,4 +13,16 @@ type Game struct {
 	LocalRepositoryLinux    *string `db:"local_repository_linux"`    // maxlen=128
 	LocalRepositoryWindows  *string `db:"local_repository_windows"`  // maxlen=128
 	Enabled                 int     `db:"enabled"`                   //
+	// QueryPortOffset and RconPortOffset are the distances from the game port
+	// to the query and rcon ports of new servers.
+	QueryPortOffset int `db:"query_port_offset"`
+	RconPortOffset  int `db:"rcon_port_offset"`
+}
+
+// PortLayout returns the layout of the ports of new servers of the game.
+func (g *Game) PortLayout() PortLayout {
+	return PortLayout{
+		QueryOffset: g.QueryPortOffset,
+		RconOffset:  g.RconPortOffset,
+	}
 }
@@ -26,10 +26,13 @@ const (
 	DaemonTaskTypeServerStart   DaemonTaskType = "gsstart"
 	DaemonTaskTypeServerStop    DaemonTaskType = "gsstop"
 	DaemonTaskTypeServerRestart DaemonTaskType = "gsrest"
+	DaemonTaskTypeServerPause   DaemonTaskType = "gspause"
+	DaemonTaskTypeServerUnpause DaemonTaskType = "gsunpause"
 	DaemonTaskTypeServerUpdate  DaemonTaskType = "gsupd"
 	DaemonTaskTypeServerInstall DaemonTaskType = "gsinst"
 	DaemonTaskTypeServerDelete  DaemonTaskType = "gsdel"
 	DaemonTaskTypeServerMove    DaemonTaskType = "gsmove"
+	DaemonTaskTypeServerCopy    DaemonTaskType = "gscopy"
 	DaemonTaskTypeCmdExec       DaemonTaskType = "cmdexec"
 )
 
@@ -46,3 +49,8 @@ type DaemonTask struct {
 	Output            *string          `db:"output"`
 	Status            DaemonTaskStatus `db:"status"`
 }
+
+// ExecutedByPanel reports whether the task is executed by the panel instead of the daemon.
+func (t DaemonTaskType) ExecutedByPanel() bool {
+	return t == DaemonTaskTypeServerMove || t == DaemonTaskTypeServerCopy
+}
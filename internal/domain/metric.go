@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// MetricSubject is the kind of object the metric belongs to.
+type MetricSubject string
+
+const (
+	MetricSubjectServer MetricSubject = "server"
+	MetricSubjectNode   MetricSubject = "node"
+)
+
+// MetricResolution is the interval a metric point covers.
+// Raw points are reported samples, other points are averages of the points of the finer resolution.
+type MetricResolution string
+
+const (
+	MetricResolutionRaw    MetricResolution = "raw"
+	MetricResolutionMinute MetricResolution = "1m"
+	MetricResolutionHour   MetricResolution = "1h"
+)
+
+// MetricResolutions are ordered from the finest to the coarsest.
+var MetricResolutions = []MetricResolution{
+	MetricResolutionRaw,
+	MetricResolutionMinute,
+	MetricResolutionHour,
+}
+
+// Step returns the interval covered by a point. It is zero for raw points.
+func (r MetricResolution) Step() time.Duration {
+	switch r {
+	case MetricResolutionMinute:
+		return time.Minute
+	case MetricResolutionHour:
+		return time.Hour
+	case MetricResolutionRaw:
+		return 0
+	default:
+		return 0
+	}
+}
+
+// Metric is a point of the resource usage history of a server or a node.
+// CPU is in percent of a single core, RAM and disk in megabytes.
+// Nil values are not reported. Samples is the number of raw points the point is averaged from.
+type Metric struct {
+	SubjectType MetricSubject    `db:"subject_type"`
+	SubjectID   uint             `db:"subject_id"`
+	Resolution  MetricResolution `db:"resolution"`
+	RecordedAt  time.Time        `db:"recorded_at"`
+	CPU         *float64         `db:"cpu"`
+	RAM         *float64         `db:"ram"`
+	Disk        *float64         `db:"disk"`
+	Players     *float64         `db:"players"`
+	Samples     int              `db:"samples"`
+}
+
+// AggregateMetrics averages the points of each subject into buckets of the step.
+// A bucket starts at the point time truncated to the step. Every value is averaged over
+// the points reporting it, weighted by their samples. The result is sorted by subject and time.
+func AggregateMetrics(points []Metric, resolution MetricResolution, step time.Duration) []Metric {
+	type bucketKey struct {
+		subjectType MetricSubject
+		subjectID   uint
+		start       time.Time
+	}
+
+	type bucket struct {
+		metric Metric
+		sums   [4]metricSum
+	}
+
+	buckets := make(map[bucketKey]*bucket)
+
+	for _, point := range points {
+		start := point.RecordedAt.UTC()
+		if step > 0 {
+			start = start.Truncate(step)
+		}
+
+		key := bucketKey{subjectType: point.SubjectType, subjectID: point.SubjectID, start: start}
+
+		b, ok := buckets[key]
+		if !ok {
+			b = &bucket{metric: Metric{
+				SubjectType: point.SubjectType,
+				SubjectID:   point.SubjectID,
+				Resolution:  resolution,
+				RecordedAt:  start,
+			}}
+			buckets[key] = b
+		}
+
+		samples := max(point.Samples, 1)
+		b.metric.Samples += samples
+
+		for i, value := range point.values() {
+			b.sums[i].add(value, samples)
+		}
+	}
+
+	result := make([]Metric, 0, len(buckets))
+	for _, b := range buckets {
+		b.metric.CPU = b.sums[0].avg()
+		b.metric.RAM = b.sums[1].avg()
+		b.metric.Disk = b.sums[2].avg()
+		b.metric.Players = b.sums[3].avg()
+
+		result = append(result, b.metric)
+	}
+
+	slices.SortFunc(result, func(a, b Metric) int {
+		return cmp.Or(
+			cmp.Compare(a.SubjectType, b.SubjectType),
+			cmp.Compare(a.SubjectID, b.SubjectID),
+			a.RecordedAt.Compare(b.RecordedAt),
+		)
+	})
+
+	return result
+}
+
+func (m *Metric) values() [4]*float64 {
+	return [4]*float64{m.CPU, m.RAM, m.Disk, m.Players}
+}
+
+type metricSum struct {
+	sum     float64
+	samples int
+}
+
+func (s *metricSum) add(value *float64, samples int) {
+	if value == nil {
+		return
+	}
+
+	s.sum += *value * float64(samples)
+	s.samples += samples
+}
+
+func (s *metricSum) avg() *float64 {
+	if s.samples == 0 {
+		return nil
+	}
+
+	avg := s.sum / float64(s.samples)
+
+	return &avg
+}
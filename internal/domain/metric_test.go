@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregateMetrics(t *testing.T) {
+	base := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	points := []Metric{
+		{
+			SubjectType: MetricSubjectServer, SubjectID: 1, RecordedAt: base.Add(10 * time.Second),
+			CPU: lo.ToPtr(10.0), RAM: lo.ToPtr(100.0), Samples: 1,
+		},
+		{
+			SubjectType: MetricSubjectServer, SubjectID: 1, RecordedAt: base.Add(40 * time.Second),
+			CPU: lo.ToPtr(30.0), Players: lo.ToPtr(4.0), Samples: 1,
+		},
+		{
+			SubjectType: MetricSubjectServer, SubjectID: 1, RecordedAt: base.Add(70 * time.Second),
+			CPU: lo.ToPtr(50.0), Samples: 3,
+		},
+		{
+			SubjectType: MetricSubjectServer, SubjectID: 1, RecordedAt: base.Add(100 * time.Second),
+			CPU: lo.ToPtr(10.0), Samples: 1,
+		},
+		{
+			SubjectType: MetricSubjectNode, SubjectID: 1, RecordedAt: base.Add(20 * time.Second),
+			Disk: lo.ToPtr(2048.0),
+		},
+	}
+
+	got := AggregateMetrics(points, MetricResolutionMinute, time.Minute)
+
+	assert.Equal(t, []Metric{
+		{
+			SubjectType: MetricSubjectNode,
+			SubjectID:   1,
+			Resolution:  MetricResolutionMinute,
+			RecordedAt:  base,
+			Disk:        lo.ToPtr(2048.0),
+			Samples:     1,
+		},
+		{
+			SubjectType: MetricSubjectServer,
+			SubjectID:   1,
+			Resolution:  MetricResolutionMinute,
+			RecordedAt:  base,
+			CPU:         lo.ToPtr(20.0),
+			RAM:         lo.ToPtr(100.0),
+			Players:     lo.ToPtr(4.0),
+			Samples:     2,
+		},
+		{
+			SubjectType: MetricSubjectServer,
+			SubjectID:   1,
+			Resolution:  MetricResolutionMinute,
+			RecordedAt:  base.Add(time.Minute),
+			CPU:         lo.ToPtr(40.0),
+			Samples:     4,
+		},
+	}, got)
+}
+
+func TestAggregateMetrics_Empty(t *testing.T) {
+	assert.Empty(t, AggregateMetrics(nil, MetricResolutionHour, time.Hour))
+}
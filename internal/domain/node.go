@@ -42,9 +42,28 @@ type Node struct {
 	ScriptGetConsole    *string                 `db:"script_get_console"`
 	ScriptSendCommand   *string                 `db:"script_send_command"`
 	ScriptDelete        *string                 `db:"script_delete"`
-	CreatedAt           *time.Time              `db:"created_at"`
-	UpdatedAt           *time.Time              `db:"updated_at"`
-	DeletedAt           *time.Time              `db:"deleted_at"`
+	// PortRangeStart and PortRangeEnd limit automatically allocated server ports.
+	// If they are not set, the default range from the panel configuration is used.
+	PortRangeStart *int       `db:"port_range_start"`
+	PortRangeEnd   *int       `db:"port_range_end"`
+	CreatedAt      *time.Time `db:"created_at"`
+	UpdatedAt      *time.Time `db:"updated_at"`
+	DeletedAt      *time.Time `db:"deleted_at"`
+}
+
+// PortRange returns the range of automatically allocated server ports of the node.
+func (n *Node) PortRange(defaultRange PortRange) PortRange {
+	r := defaultRange
+
+	if n.PortRangeStart != nil {
+		r.Start = *n.PortRangeStart
+	}
+
+	if n.PortRangeEnd != nil {
+		r.End = *n.PortRangeEnd
+	}
+
+	return r
 }
 
 type NodeOS string
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// serverDirPattern matches relative paths of safe names separated by slashes.
+var serverDirPattern = regexp.MustCompile(`^[A-Za-z0-9._-]+(/[A-Za-z0-9._-]+)*$`)
+
+// IsValidServerDir reports whether the directory can be used as a server directory relative to the node work path.
+// Absolute paths, "." and ".." segments and characters with a special meaning for the node shell are rejected,
+// so the directory stays inside the work path.
+func IsValidServerDir(dir string) bool {
+	if !serverDirPattern.MatchString(dir) {
+		return false
+	}
+
+	for segment := range strings.SplitSeq(dir, "/") {
+		if segment == "." || segment == ".." {
+			return false
+		}
+	}
+
+	return true
+}
+
+// TarCommand builds tar command line working with the archive in the directory.
+// tar is shipped with Linux distributions and Windows 10 and newer.
+// The paths are quoted for the shell of the node, so they are passed to tar as is.
+func (os NodeOS) TarCommand(flags, archive, dir string, members ...string) (string, error) {
+	b := strings.Builder{}
+	b.WriteString("tar ")
+	b.WriteString(flags)
+
+	for i, arg := range append([]string{archive, dir}, members...) {
+		quoted, err := os.QuoteArg(arg)
+		if err != nil {
+			return "", err
+		}
+
+		if i == 1 {
+			b.WriteString(" -C")
+		}
+
+		b.WriteByte(' ')
+		b.WriteString(quoted)
+	}
+
+	return b.String(), nil
+}
+
+// CopyDirCommand builds command line copying the directory with its contents to the target directory,
+// which must not exist. Windows nodes copy with xcopy, the others with cp.
+func (os NodeOS) CopyDirCommand(source, target string) (string, error) {
+	if os == NodeOSWindows {
+		// xcopy treats slashes as the beginning of the options
+		source = strings.ReplaceAll(source, "/", `\`)
+		target = strings.ReplaceAll(target, "/", `\`)
+	}
+
+	quotedSource, err := os.QuoteArg(source)
+	if err != nil {
+		return "", err
+	}
+
+	quotedTarget, err := os.QuoteArg(target)
+	if err != nil {
+		return "", err
+	}
+
+	if os == NodeOSWindows {
+		return "xcopy " + quotedSource + " " + quotedTarget + " /E /I /H /K /Y /Q", nil
+	}
+
+	return "cp -a " + quotedSource + " " + quotedTarget, nil
+}
+
+// QuoteArg quotes the command argument. On Windows the argument is put into double quotes,
+// the double quotes and the variable expansion can't be escaped there, so such arguments are rejected.
+// Elsewhere the argument is put into single quotes, which keep all the characters, and the single quotes
+// are closed, escaped and opened again.
+func (os NodeOS) QuoteArg(arg string) (string, error) {
+	if os == NodeOSWindows {
+		if strings.ContainsAny(arg, "\"%") {
+			return "", errors.Errorf("unsupported characters in path %q", arg)
+		}
+
+		return "\"" + arg + "\"", nil
+	}
+
+	return "'" + strings.ReplaceAll(arg, "'", "'\\''") + "'", nil
+}
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidServerDir(t *testing.T) {
+	tests := []struct {
+		dir  string
+		want bool
+	}{
+		{dir: "servers/cs", want: true},
+		{dir: "servers/cs-1.6_v2", want: true},
+		{dir: "cs", want: true},
+		{dir: "", want: false},
+		{dir: "/srv/servers/cs", want: false},
+		{dir: `C:\servers\cs`, want: false},
+		{dir: "servers/../../etc", want: false},
+		{dir: "..", want: false},
+		{dir: ".", want: false},
+		{dir: "servers/./cs", want: false},
+		{dir: "servers//cs", want: false},
+		{dir: "servers/cs/", want: false},
+		{dir: `servers/"cs"`, want: false},
+		{dir: "servers/$(touch pwned)", want: false},
+		{dir: "servers/`touch pwned`", want: false},
+		{dir: "servers/cs; rm -rf ~", want: false},
+		{dir: "servers/it's", want: false},
+		{dir: "servers/with space", want: false},
+		{dir: "servers/new\nline", want: false},
+		{dir: "servers/%PATH%", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dir, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsValidServerDir(tt.dir))
+		})
+	}
+}
+
+func TestNodeOS_TarCommand(t *testing.T) {
+	t.Run("linux", func(t *testing.T) {
+		command, err := NodeOSLinux.TarCommand("-czf", "/srv/a b/x.tar.gz", "/srv/it's", ".")
+		require.NoError(t, err)
+		assert.Equal(t, `tar -czf '/srv/a b/x.tar.gz' -C '/srv/it'\''s' '.'`, command)
+	})
+
+	t.Run("windows", func(t *testing.T) {
+		command, err := NodeOSWindows.TarCommand("-xzf", `C:\gameap\x.tar.gz`, `C:\gameap\servers\cs`)
+		require.NoError(t, err)
+		assert.Equal(t, `tar -xzf "C:\gameap\x.tar.gz" -C "C:\gameap\servers\cs"`, command)
+	})
+
+	t.Run("windows_rejects_unescapable_paths", func(t *testing.T) {
+		for _, dir := range []string{`C:\"servers`, `C:\%PATH%`} {
+			_, err := NodeOSWindows.TarCommand("-xzf", `C:\gameap\x.tar.gz`, dir)
+			require.Error(t, err, dir)
+		}
+	})
+}
+
+func TestNodeOS_CopyDirCommand(t *testing.T) {
+	t.Run("linux", func(t *testing.T) {
+		command, err := NodeOSLinux.CopyDirCommand("/srv/servers/cs", "/srv/servers/it's")
+		require.NoError(t, err)
+		assert.Equal(t, `cp -a '/srv/servers/cs' '/srv/servers/it'\''s'`, command)
+	})
+
+	t.Run("windows", func(t *testing.T) {
+		command, err := NodeOSWindows.CopyDirCommand(`C:\gameap/servers/cs`, `C:\gameap/servers/cs2`)
+		require.NoError(t, err)
+		assert.Equal(t, `xcopy "C:\gameap\servers\cs" "C:\gameap\servers\cs2" /E /I /H /K /Y /Q`, command)
+	})
+
+	t.Run("windows_rejects_unescapable_paths", func(t *testing.T) {
+		_, err := NodeOSWindows.CopyDirCommand(`C:\gameap\servers\cs`, `C:\gameap\%PATH%`)
+		require.Error(t, err)
+	})
+}
+
+func TestNodeOS_QuoteArg_HostilePaths(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sh is not available")
+	}
+
+	paths := []string{
+		`/srv/servers/cs`,
+		`/srv/servers/with space`,
+		`/srv/"quoted"`,
+		`/srv/$(touch pwned)`,
+		"/srv/`touch pwned`",
+		`/srv/$HOME`,
+		`/srv/it's`,
+		`/srv/'; touch pwned; '`,
+		`/srv/back\slash`,
+		"/srv/new\nline",
+		`-rf`,
+	}
+
+	dir := t.TempDir()
+
+	for _, path := range paths {
+		quoted, err := NodeOSLinux.QuoteArg(path)
+		require.NoError(t, err)
+
+		cmd := exec.Command("sh", "-c", "printf '%s' "+quoted)
+		cmd.Dir = dir
+
+		output, err := cmd.Output()
+		require.NoError(t, err, path)
+		assert.Equal(t, path, string(output))
+	}
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "the quoted paths must not run commands")
+}
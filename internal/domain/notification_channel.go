@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"slices"
+	"time"
+)
+
+type NotificationChannelType string
+
+const (
+	// NotificationChannelTypeDiscord posts messages to a Discord webhook, the target is the webhook URL.
+	NotificationChannelTypeDiscord NotificationChannelType = "discord"
+	// NotificationChannelTypeTelegram sends messages with the panel Telegram bot, the target is the chat ID.
+	NotificationChannelTypeTelegram NotificationChannelType = "telegram"
+)
+
+func (t NotificationChannelType) Valid() bool {
+	return t == NotificationChannelTypeDiscord || t == NotificationChannelTypeTelegram
+}
+
+// NotificationEventTypes are the event types notification channels can subscribe to.
+var NotificationEventTypes = []EventType{
+	EventTypeServerCrashed,
+	EventTypeServerCrashLoop,
+	EventTypeServerUpdateFinished,
+	EventTypeServerUpdateFailed,
+	EventTypeBackupFailed,
+}
+
+// NotificationChannel is a chat the events of the servers of a user are sent to as text messages.
+//
+// A channel receives the events of all servers of the user, or of one server if ServerID is set.
+type NotificationChannel struct {
+	ID     uint `db:"id"`
+	UserID uint `db:"user_id"`
+	// ServerID limits the channel to the events of one server.
+	ServerID *uint                   `db:"server_id"`
+	Name     string                  `db:"name"`
+	Type     NotificationChannelType `db:"type"`
+	// Target is the Discord webhook URL or the Telegram chat ID.
+	Target string `db:"target"`
+	// Language is the language of the messages.
+	Language string `db:"language"`
+	// Events are the subscribed event types, all notification events are sent if empty.
+	Events    NotificationEvents `db:"events"`
+	Enabled   bool               `db:"enabled"`
+	CreatedAt *time.Time         `db:"created_at"`
+	UpdatedAt *time.Time         `db:"updated_at"`
+}
+
+// Subscribed reports whether the channel receives the events of the type.
+func (c *NotificationChannel) Subscribed(eventType EventType) bool {
+	if !slices.Contains(NotificationEventTypes, eventType) {
+		return false
+	}
+
+	return len(c.Events) == 0 || slices.Contains(c.Events, eventType)
+}
+
+// NotificationEvents are the event types of a notification channel. It is stored as a JSON array.
+type NotificationEvents []EventType
+
+func (e *NotificationEvents) Scan(value any) error {
+	return (*WebhookEvents)(e).Scan(value)
+}
+
+func (e NotificationEvents) Value() (driver.Value, error) {
+	return WebhookEvents(e).Value()
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationChannel_Subscribed(t *testing.T) {
+	all := NotificationChannel{}
+	assert.True(t, all.Subscribed(EventTypeServerCrashed))
+	assert.True(t, all.Subscribed(EventTypeBackupFailed))
+	assert.False(t, all.Subscribed(EventTypeServerCreated))
+
+	some := NotificationChannel{Events: NotificationEvents{EventTypeServerUpdateFinished}}
+	assert.True(t, some.Subscribed(EventTypeServerUpdateFinished))
+	assert.False(t, some.Subscribed(EventTypeServerCrashed))
+}
+
+func TestNotificationEvents_ScanValue(t *testing.T) {
+	events := NotificationEvents{EventTypeServerCrashed, EventTypeBackupFailed}
+
+	value, err := events.Value()
+	require.NoError(t, err)
+	assert.Equal(t, `["server.crashed","backup.failed"]`, value)
+
+	var scanned NotificationEvents
+	require.NoError(t, scanned.Scan(value))
+	assert.Equal(t, events, scanned)
+
+	require.NoError(t, scanned.Scan(nil))
+	assert.Nil(t, scanned)
+}
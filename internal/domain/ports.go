@@ -0,0 +1,179 @@
+package domain
+
+import "slices"
+
+const MaxPort = 65535
+
+// PortRange is an inclusive range of ports.
+type PortRange struct {
+	Start int
+	End   int
+}
+
+func (r PortRange) Valid() bool {
+	return r.Start >= 1 && r.End <= MaxPort && r.Start <= r.End
+}
+
+func (r PortRange) Contains(port int) bool {
+	return port >= r.Start && port <= r.End
+}
+
+// PortLayout describes the query and rcon ports of a server relative to its game port.
+type PortLayout struct {
+	QueryOffset int
+	RconOffset  int
+}
+
+// PortRequest is a requested port of a new server.
+// An automatic port is allocated by the panel, otherwise Port is used as is.
+type PortRequest struct {
+	Port int
+	Auto bool
+}
+
+func FixedPort(port int) PortRequest {
+	return PortRequest{Port: port}
+}
+
+func AutoPort() PortRequest {
+	return PortRequest{Auto: true}
+}
+
+// ServerPortsRequest holds the requested ports of a new server.
+// QueryPort and RconPort are nil if the server has no such ports.
+type ServerPortsRequest struct {
+	ServerPort PortRequest
+	QueryPort  *PortRequest
+	RconPort   *PortRequest
+}
+
+// HasAuto reports whether any of the ports is allocated automatically.
+func (r ServerPortsRequest) HasAuto() bool {
+	return r.ServerPort.Auto ||
+		(r.QueryPort != nil && r.QueryPort.Auto) ||
+		(r.RconPort != nil && r.RconPort.Auto)
+}
+
+// ServerPorts are the game, query and rcon ports of a server.
+type ServerPorts struct {
+	ServerPort int
+	QueryPort  *int
+	RconPort   *int
+}
+
+func (p ServerPorts) ApplyTo(server *Server) {
+	server.ServerPort = p.ServerPort
+	server.QueryPort = p.QueryPort
+	server.RconPort = p.RconPort
+}
+
+// AllocateServerPorts resolves automatic ports of the request.
+//
+// If the game port is automatic, the first port from the range is taken, for which
+// all automatic ports placed by the layout are inside the range and not busy.
+// Otherwise, automatic query and rcon ports are placed by the layout relative to the requested game port.
+// Fixed ports are kept as is and can't be taken by automatic ones. The game port and the automatic ports
+// must be different, so a layout placing them on the same port is rejected.
+// It returns false if there are no free ports.
+func AllocateServerPorts(
+	request ServerPortsRequest,
+	layout PortLayout,
+	portRange PortRange,
+	busy []int,
+) (ServerPorts, bool) {
+	busy = append(slices.Clip(busy), request.fixedPorts()...)
+
+	if !request.ServerPort.Auto {
+		ports, automatic := request.place(request.ServerPort.Port, layout)
+
+		if request.collide(ports.ServerPort, automatic) {
+			return ServerPorts{}, false
+		}
+
+		return ports, !slices.ContainsFunc(automatic, func(port int) bool {
+			return port < 1 || port > MaxPort || slices.Contains(busy, port)
+		})
+	}
+
+	if !portRange.Valid() {
+		return ServerPorts{}, false
+	}
+
+	for port := portRange.Start; port <= portRange.End; port++ {
+		ports, automatic := request.place(port, layout)
+
+		if request.collide(ports.ServerPort, automatic) {
+			return ServerPorts{}, false
+		}
+
+		if !slices.ContainsFunc(automatic, func(port int) bool {
+			return !portRange.Contains(port) || slices.Contains(busy, port)
+		}) {
+			return ports, true
+		}
+	}
+
+	return ServerPorts{}, false
+}
+
+// fixedPorts returns the fixed query and rcon ports of the request.
+func (r ServerPortsRequest) fixedPorts() []int {
+	ports := make([]int, 0, 2)
+
+	for _, request := range []*PortRequest{r.QueryPort, r.RconPort} {
+		if request != nil && !request.Auto {
+			ports = append(ports, request.Port)
+		}
+	}
+
+	return ports
+}
+
+// collide reports whether the game port and the automatic ports placed by the layout aren't all different.
+func (r ServerPortsRequest) collide(serverPort int, automatic []int) bool {
+	placed := automatic
+	if !r.ServerPort.Auto {
+		placed = append([]int{serverPort}, automatic...)
+	}
+
+	for i, port := range placed {
+		if slices.Contains(placed[i+1:], port) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// place places the ports by the layout relative to the game port.
+// It returns the ports and the list of automatically allocated ones.
+func (r ServerPortsRequest) place(serverPort int, layout PortLayout) (ServerPorts, []int) {
+	ports := ServerPorts{ServerPort: serverPort}
+	automatic := make([]int, 0, 3)
+
+	if r.ServerPort.Auto {
+		automatic = append(automatic, serverPort)
+	}
+
+	resolve := func(request *PortRequest, offset int) *int {
+		if request == nil {
+			return nil
+		}
+
+		if !request.Auto {
+			port := request.Port
+
+			return &port
+		}
+
+		port := serverPort + offset
+		automatic = append(automatic, port)
+
+		return &port
+	}
+
+	ports.QueryPort = resolve(r.QueryPort, layout.QueryOffset)
+	ports.RconPort = resolve(r.RconPort, layout.RconOffset)
+
+	return ports, automatic
+}
@@ -0,0 +1,168 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllocateServerPorts(t *testing.T) {
+	portRange := PortRange{Start: 27015, End: 27030}
+
+	auto := lo.ToPtr(AutoPort())
+	allAuto := ServerPortsRequest{ServerPort: AutoPort(), QueryPort: auto, RconPort: auto}
+
+	tests := []struct {
+		name    string
+		request ServerPortsRequest
+		layout  PortLayout
+		busy    []int
+		want    ServerPorts
+		wantOK  bool
+	}{
+		{
+			name:    "offset 0 places query and rcon ports on game port",
+			request: allAuto,
+			busy:    []int{27015},
+			wantOK:  false,
+		},
+		{
+			name:    "offset 0 places query port on fixed game port",
+			request: ServerPortsRequest{ServerPort: FixedPort(30000), QueryPort: auto},
+			wantOK:  false,
+		},
+		{
+			name:    "same query and rcon offsets",
+			request: allAuto,
+			layout:  PortLayout{QueryOffset: 1, RconOffset: 1},
+			wantOK:  false,
+		},
+		{
+			name:    "offset 0 for port that isn't requested",
+			request: ServerPortsRequest{ServerPort: AutoPort(), QueryPort: auto},
+			layout:  PortLayout{QueryOffset: 1},
+			busy:    []int{27015},
+			want:    ServerPorts{ServerPort: 27016, QueryPort: lo.ToPtr(27017)},
+			wantOK:  true,
+		},
+		{
+			name:    "layout offsets",
+			request: allAuto,
+			layout:  PortLayout{QueryOffset: 1, RconOffset: 2},
+			busy:    []int{27017},
+			want:    ServerPorts{ServerPort: 27018, QueryPort: lo.ToPtr(27019), RconPort: lo.ToPtr(27020)},
+			wantOK:  true,
+		},
+		{
+			name:    "server without query and rcon ports",
+			request: ServerPortsRequest{ServerPort: AutoPort()},
+			layout:  PortLayout{QueryOffset: 1},
+			busy:    []int{27015, 27017},
+			want:    ServerPorts{ServerPort: 27016},
+			wantOK:  true,
+		},
+		{
+			name: "fixed rcon port is kept",
+			request: ServerPortsRequest{
+				ServerPort: AutoPort(),
+				QueryPort:  auto,
+				RconPort:   lo.ToPtr(FixedPort(28000)),
+			},
+			layout: PortLayout{QueryOffset: 1},
+			want:   ServerPorts{ServerPort: 27015, QueryPort: lo.ToPtr(27016), RconPort: lo.ToPtr(28000)},
+			wantOK: true,
+		},
+		{
+			name: "query port placed relative to fixed game port",
+			request: ServerPortsRequest{
+				ServerPort: FixedPort(30000),
+				QueryPort:  auto,
+				RconPort:   lo.ToPtr(FixedPort(30005)),
+			},
+			layout: PortLayout{QueryOffset: 1},
+			want:   ServerPorts{ServerPort: 30000, QueryPort: lo.ToPtr(30001), RconPort: lo.ToPtr(30005)},
+			wantOK: true,
+		},
+		{
+			name:    "query port placed relative to fixed game port is busy",
+			request: ServerPortsRequest{ServerPort: FixedPort(30000), QueryPort: auto},
+			layout:  PortLayout{QueryOffset: 1},
+			busy:    []int{30001},
+			wantOK:  false,
+		},
+		{
+			name:    "ports placed by layout must be inside range",
+			request: allAuto,
+			layout:  PortLayout{QueryOffset: 1, RconOffset: 10},
+			busy:    []int{27015, 27016, 27017, 27018, 27019},
+			want:    ServerPorts{ServerPort: 27020, QueryPort: lo.ToPtr(27021), RconPort: lo.ToPtr(27030)},
+			wantOK:  true,
+		},
+		{
+			name: "fixed rcon port inside range isn't taken by game port",
+			request: ServerPortsRequest{
+				ServerPort: AutoPort(),
+				QueryPort:  auto,
+				RconPort:   lo.ToPtr(FixedPort(27015)),
+			},
+			layout: PortLayout{QueryOffset: 1},
+			want:   ServerPorts{ServerPort: 27016, QueryPort: lo.ToPtr(27017), RconPort: lo.ToPtr(27015)},
+			wantOK: true,
+		},
+		{
+			name: "fixed rcon port inside range isn't taken by query port",
+			request: ServerPortsRequest{
+				ServerPort: AutoPort(),
+				QueryPort:  auto,
+				RconPort:   lo.ToPtr(FixedPort(27016)),
+			},
+			layout: PortLayout{QueryOffset: 1},
+			want:   ServerPorts{ServerPort: 27017, QueryPort: lo.ToPtr(27018), RconPort: lo.ToPtr(27016)},
+			wantOK: true,
+		},
+		{
+			name: "fixed rcon port isn't taken by query port placed relative to fixed game port",
+			request: ServerPortsRequest{
+				ServerPort: FixedPort(30000),
+				QueryPort:  auto,
+				RconPort:   lo.ToPtr(FixedPort(30001)),
+			},
+			layout: PortLayout{QueryOffset: 1},
+			wantOK: false,
+		},
+		{
+			name:    "no free ports in range",
+			request: allAuto,
+			layout:  PortLayout{QueryOffset: 1, RconOffset: 16},
+			wantOK:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := AllocateServerPorts(tt.request, tt.layout, portRange, tt.busy)
+			assert.Equal(t, tt.wantOK, ok)
+
+			if tt.wantOK {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNode_PortRange(t *testing.T) {
+	defaultRange := PortRange{Start: 27015, End: 27999}
+
+	assert.Equal(t, defaultRange, (&Node{}).PortRange(defaultRange))
+	assert.Equal(
+		t,
+		PortRange{Start: 30000, End: 27999},
+		(&Node{PortRangeStart: lo.ToPtr(30000)}).PortRange(defaultRange),
+	)
+	assert.Equal(
+		t,
+		PortRange{Start: 30000, End: 31000},
+		(&Node{PortRangeStart: lo.ToPtr(30000), PortRangeEnd: lo.ToPtr(31000)}).PortRange(defaultRange),
+	)
+}
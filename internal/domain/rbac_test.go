@@ -36,6 +36,8 @@ func TestAbilityNameConstants_GameServer(t *testing.T) {
 	assert.Equal(t, AbilityName("game-server-console-send"), AbilityNameGameServerConsoleSend)
 	assert.Equal(t, AbilityName("game-server-rcon-console"), AbilityNameGameServerRconConsole)
 	assert.Equal(t, AbilityName("game-server-rcon-players"), AbilityNameGameServerRconPlayers)
+	assert.Equal(t, AbilityName("game-server-backups"), AbilityNameGameServerBackups)
+	assert.Equal(t, AbilityName("game-server-backups-restore"), AbilityNameGameServerBackupsRestore)
 }
 
 func TestAbilityNameConstants_General(t *testing.T) {
@@ -64,9 +66,11 @@ func TestServersAbilities(t *testing.T) {
 		AbilityNameGameServerConsoleSend,
 		AbilityNameGameServerRconConsole,
 		AbilityNameGameServerRconPlayers,
+		AbilityNameGameServerBackups,
+		AbilityNameGameServerBackupsRestore,
 	}
 
-	assert.Equal(t, len(expectedAbilities), len(ServersAbilities), "should have 13 server abilities")
+	assert.Equal(t, len(expectedAbilities), len(ServersAbilities), "should have 15 server abilities")
 	assert.Equal(t, expectedAbilities, ServersAbilities)
 
 	for _, ability := range expectedAbilities {
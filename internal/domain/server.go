@@ -1,11 +1,13 @@
 package domain
 
 import (
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 )
 
 type ServerInstalledStatus int
@@ -71,6 +73,39 @@ func (s *Server) IsOnline() bool {
 	return s.ProcessActive && s.LastProcessCheck.UTC().After(time.Now().UTC().Add(-timeExpireProcessCheck))
 }
 
+// Ports returns the game, query and rcon ports of the server.
+func (s *Server) Ports() []int {
+	ports := make([]int, 0, 3)
+	ports = append(ports, s.ServerPort)
+
+	if s.QueryPort != nil {
+		ports = append(ports, *s.QueryPort)
+	}
+
+	if s.RconPort != nil {
+		ports = append(ports, *s.RconPort)
+	}
+
+	return ports
+}
+
+// ServersBusyPorts returns sorted unique ports used by the servers grouped by the server IP.
+func ServersBusyPorts(servers []Server) map[string][]int {
+	result := make(map[string][]int, len(servers))
+
+	for i := range servers {
+		ip := servers[i].ServerIP
+		result[ip] = append(result[ip], servers[i].Ports()...)
+	}
+
+	for ip := range result {
+		result[ip] = lo.Uniq(result[ip])
+		slices.Sort(result[ip])
+	}
+
+	return result
+}
+
 // ReplaceServerShortcodes replaces shortcode placeholders in a command string with server-specific values.
 // It first replaces any extra data provided, then replaces standard server shortcodes.
 // Shortcodes are replaced in the format {key} with their corresponding values.
@@ -89,6 +124,9 @@ func (s *Server) ReplaceServerShortcodes(node *Node, command string, extra map[s
 		"query_port":      "", // default empty, may be set below
 		"rcon_port":       "", // default empty, may be set below
 		"user":            "", // default empty, may be set below
+		"cpu_limit":       "", // default empty, may be set below
+		"ram_limit":       "", // default empty, may be set below
+		"net_limit":       "", // default empty, may be set below
 		"id":              strconv.FormatUint(uint64(s.ID), 10),
 		"uuid":            s.UUID.String(),
 		"uuid_short":      s.UUIDShort,
@@ -109,6 +147,18 @@ func (s *Server) ReplaceServerShortcodes(node *Node, command string, extra map[s
 		replaceMap["user"] = *s.SuUser
 	}
 
+	if s.CPULimit != nil {
+		replaceMap["cpu_limit"] = strconv.Itoa(*s.CPULimit)
+	}
+
+	if s.RAMLimit != nil {
+		replaceMap["ram_limit"] = strconv.Itoa(*s.RAMLimit)
+	}
+
+	if s.NetLimit != nil {
+		replaceMap["net_limit"] = strconv.Itoa(*s.NetLimit)
+	}
+
 	// Replace all server shortcodes
 	for key, value := range replaceMap {
 		command = strings.ReplaceAll(command, "{"+key+"}", value)
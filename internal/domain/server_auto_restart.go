@@ -0,0 +1,64 @@
+package domain
+
+import "time"
+
+// AutoRestartPolicy configures restarts of crashed servers by the panel watchdog.
+type AutoRestartPolicy struct {
+	// Threshold is how long a server must stay crashed before it is restarted.
+	Threshold time.Duration
+	// BaseDelay is the delay between the first and the second restart, each next delay is doubled.
+	BaseDelay time.Duration
+	// MaxDelay limits the delay between restarts.
+	MaxDelay time.Duration
+	// MaxAttempts is the number of restarts after which the server is considered to be in a crash loop.
+	MaxAttempts int
+	// StablePeriod is how long a server must run after the last restart to reset the attempts.
+	StablePeriod time.Duration
+}
+
+// Backoff returns the delay before the next restart after the given number of attempts.
+func (p AutoRestartPolicy) Backoff(attempts int) time.Duration {
+	return exponentialBackoff(p.BaseDelay, p.MaxDelay, attempts)
+}
+
+// ServerAutoRestart is the state of automatic restarts of a crashed server by the panel watchdog.
+type ServerAutoRestart struct {
+	ServerID uint `db:"server_id"`
+	// CrashedAt is when the watchdog noticed that the server crashed, nil while the server is running.
+	CrashedAt *time.Time `db:"crashed_at"`
+	// Attempts is the number of restarts since the server was last running stable.
+	Attempts      int        `db:"attempts"`
+	LastAttemptAt *time.Time `db:"last_attempt_at"`
+	LastTaskID    *uint      `db:"last_task_id"`
+	// CrashLoop is set when the server keeps crashing after the maximum number of restarts.
+	// The watchdog doesn't restart the server until it runs stable again.
+	CrashLoop   bool       `db:"crash_loop"`
+	CrashLoopAt *time.Time `db:"crash_loop_at"`
+	// CrashLoops is the total number of crash loops of the server.
+	CrashLoops int       `db:"crash_loops"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+// NextAttemptAt returns the earliest time of the next restart.
+func (r *ServerAutoRestart) NextAttemptAt(policy AutoRestartPolicy) time.Time {
+	var next time.Time
+
+	if r.CrashedAt != nil {
+		next = r.CrashedAt.Add(policy.Threshold)
+	}
+
+	if r.LastAttemptAt != nil {
+		if backoff := r.LastAttemptAt.Add(policy.Backoff(r.Attempts)); backoff.After(next) {
+			next = backoff
+		}
+	}
+
+	return next
+}
+
+// IsStable reports whether the running server has been running long enough after the last restart
+// to reset the attempts.
+func (r *ServerAutoRestart) IsStable(policy AutoRestartPolicy, now time.Time) bool {
+	return r.CrashedAt == nil &&
+		(r.LastAttemptAt == nil || !now.Before(r.LastAttemptAt.Add(policy.StablePeriod)))
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+var testAutoRestartPolicy = AutoRestartPolicy{
+	Threshold:    time.Minute,
+	BaseDelay:    30 * time.Second,
+	MaxDelay:     5 * time.Minute,
+	MaxAttempts:  5,
+	StablePeriod: 10 * time.Minute,
+}
+
+func TestAutoRestartPolicy_Backoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 0, want: 0},
+		{attempts: 1, want: 30 * time.Second},
+		{attempts: 2, want: time.Minute},
+		{attempts: 3, want: 2 * time.Minute},
+		{attempts: 4, want: 4 * time.Minute},
+		{attempts: 5, want: 5 * time.Minute},
+		{attempts: 100, want: 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, testAutoRestartPolicy.Backoff(tt.attempts), "attempts %d", tt.attempts)
+	}
+}
+
+func TestServerAutoRestart_NextAttemptAt(t *testing.T) {
+	crashedAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		restart ServerAutoRestart
+		want    time.Time
+	}{
+		{
+			name:    "first restart after threshold",
+			restart: ServerAutoRestart{CrashedAt: &crashedAt},
+			want:    crashedAt.Add(time.Minute),
+		},
+		{
+			name: "backoff after previous attempts",
+			restart: ServerAutoRestart{
+				CrashedAt:     &crashedAt,
+				Attempts:      3,
+				LastAttemptAt: lo.ToPtr(crashedAt.Add(2 * time.Minute)),
+			},
+			want: crashedAt.Add(4 * time.Minute),
+		},
+		{
+			name: "threshold after crash following a restart",
+			restart: ServerAutoRestart{
+				CrashedAt:     lo.ToPtr(crashedAt.Add(5 * time.Minute)),
+				Attempts:      1,
+				LastAttemptAt: &crashedAt,
+			},
+			want: crashedAt.Add(6 * time.Minute),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.restart.NextAttemptAt(testAutoRestartPolicy))
+		})
+	}
+}
+
+func TestServerAutoRestart_IsStable(t *testing.T) {
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	assert.True(t, (&ServerAutoRestart{}).IsStable(testAutoRestartPolicy, now))
+	assert.True(t, (&ServerAutoRestart{
+		LastAttemptAt: lo.ToPtr(now.Add(-10 * time.Minute)),
+	}).IsStable(testAutoRestartPolicy, now))
+	assert.False(t, (&ServerAutoRestart{
+		LastAttemptAt: lo.ToPtr(now.Add(-9 * time.Minute)),
+	}).IsStable(testAutoRestartPolicy, now))
+	assert.False(t, (&ServerAutoRestart{
+		CrashedAt: lo.ToPtr(now.Add(-time.Hour)),
+	}).IsStable(testAutoRestartPolicy, now))
+}
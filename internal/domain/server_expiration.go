@@ -0,0 +1,77 @@
+package domain
+
+import "time"
+
+type ServerExpirationState string
+
+const (
+	// ServerExpirationStateNone means the server has no expiration date.
+	ServerExpirationStateNone ServerExpirationState = "none"
+	// ServerExpirationStateActive means the expiration date is in the future.
+	ServerExpirationStateActive ServerExpirationState = "active"
+	// ServerExpirationStateGracePeriod means the server has expired, but it is not blocked yet.
+	ServerExpirationStateGracePeriod ServerExpirationState = "grace_period"
+	// ServerExpirationStateExpired means the grace period is over and the server is waiting to be blocked.
+	ServerExpirationStateExpired ServerExpirationState = "expired"
+	// ServerExpirationStateBlocked means the server has been stopped and blocked.
+	ServerExpirationStateBlocked ServerExpirationState = "blocked"
+)
+
+// ServerExpirationPolicy describes what happens with a server after its expiration date.
+type ServerExpirationPolicy struct {
+	// Enabled means the expiration worker is running and applies the policy.
+	// Nothing happens with the expired servers otherwise.
+	Enabled bool
+
+	// GracePeriod is a time after the expiration date when the server is still allowed to work.
+	// The server is stopped and blocked when the grace period is over.
+	GracePeriod time.Duration
+
+	// DeleteAfter is a time after the expiration date when the server is soft deleted.
+	// Zero value disables deletion.
+	DeleteAfter time.Duration
+}
+
+// BlockAt returns the time when the server should be stopped and blocked.
+func (p ServerExpirationPolicy) BlockAt(s *Server) *time.Time {
+	if s.Expires == nil {
+		return nil
+	}
+
+	t := s.Expires.Add(p.GracePeriod)
+
+	return &t
+}
+
+// DeleteAt returns the time when the server should be soft deleted.
+// The server is never deleted before it is blocked.
+func (p ServerExpirationPolicy) DeleteAt(s *Server) *time.Time {
+	if s.Expires == nil || p.DeleteAfter <= 0 {
+		return nil
+	}
+
+	t := s.Expires.Add(max(p.DeleteAfter, p.GracePeriod))
+
+	return &t
+}
+
+// State returns the expiration state of the server at the given time.
+func (p ServerExpirationPolicy) State(s *Server, now time.Time) ServerExpirationState {
+	if s.Expires == nil {
+		return ServerExpirationStateNone
+	}
+
+	if now.Before(*s.Expires) {
+		return ServerExpirationStateActive
+	}
+
+	if now.Before(*p.BlockAt(s)) {
+		return ServerExpirationStateGracePeriod
+	}
+
+	if s.Blocked {
+		return ServerExpirationStateBlocked
+	}
+
+	return ServerExpirationStateExpired
+}
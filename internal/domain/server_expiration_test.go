@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerExpirationPolicy_State(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	policy := ServerExpirationPolicy{
+		GracePeriod: 24 * time.Hour,
+		DeleteAfter: 7 * 24 * time.Hour,
+	}
+
+	tests := []struct {
+		name    string
+		expires *time.Time
+		blocked bool
+		want    ServerExpirationState
+	}{
+		{
+			name: "no_expiration_date",
+			want: ServerExpirationStateNone,
+		},
+		{
+			name:    "expires_in_future",
+			expires: lo.ToPtr(now.Add(time.Hour)),
+			want:    ServerExpirationStateActive,
+		},
+		{
+			name:    "expired_within_grace_period",
+			expires: lo.ToPtr(now.Add(-time.Hour)),
+			want:    ServerExpirationStateGracePeriod,
+		},
+		{
+			name:    "grace_period_is_over_not_blocked",
+			expires: lo.ToPtr(now.Add(-25 * time.Hour)),
+			want:    ServerExpirationStateExpired,
+		},
+		{
+			name:    "grace_period_is_over_blocked",
+			expires: lo.ToPtr(now.Add(-25 * time.Hour)),
+			blocked: true,
+			want:    ServerExpirationStateBlocked,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := &Server{
+				Expires: test.expires,
+				Blocked: test.blocked,
+			}
+
+			assert.Equal(t, test.want, policy.State(server, now))
+		})
+	}
+}
+
+func TestServerExpirationPolicy_BlockAt(t *testing.T) {
+	expires := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	t.Run("no_expiration_date", func(t *testing.T) {
+		policy := ServerExpirationPolicy{GracePeriod: time.Hour}
+
+		assert.Nil(t, policy.BlockAt(&Server{}))
+	})
+
+	t.Run("with_grace_period", func(t *testing.T) {
+		policy := ServerExpirationPolicy{GracePeriod: time.Hour}
+
+		blockAt := policy.BlockAt(&Server{Expires: &expires})
+		require.NotNil(t, blockAt)
+		assert.Equal(t, expires.Add(time.Hour), *blockAt)
+	})
+}
+
+func TestServerExpirationPolicy_DeleteAt(t *testing.T) {
+	expires := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	t.Run("deletion_disabled", func(t *testing.T) {
+		policy := ServerExpirationPolicy{GracePeriod: time.Hour}
+
+		assert.Nil(t, policy.DeleteAt(&Server{Expires: &expires}))
+	})
+
+	t.Run("delete_after", func(t *testing.T) {
+		policy := ServerExpirationPolicy{GracePeriod: time.Hour, DeleteAfter: 48 * time.Hour}
+
+		deleteAt := policy.DeleteAt(&Server{Expires: &expires})
+		require.NotNil(t, deleteAt)
+		assert.Equal(t, expires.Add(48*time.Hour), *deleteAt)
+	})
+
+	t.Run("delete_after_is_less_than_grace_period", func(t *testing.T) {
+		policy := ServerExpirationPolicy{GracePeriod: 72 * time.Hour, DeleteAfter: 48 * time.Hour}
+
+		deleteAt := policy.DeleteAt(&Server{Expires: &expires})
+		require.NotNil(t, deleteAt)
+		assert.Equal(t, expires.Add(72*time.Hour), *deleteAt)
+	})
+}
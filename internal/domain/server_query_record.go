@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// ServerQueryRecord is the result of a periodic query of a server by the panel.
+// An offline record means the server didn't respond to the query.
+type ServerQueryRecord struct {
+	ServerID      uint      `db:"server_id"`
+	QueriedAt     time.Time `db:"queried_at"`
+	Online        bool      `db:"online"`
+	PlayersNum    int       `db:"players_num"`
+	MaxPlayersNum int       `db:"max_players_num"`
+	Map           string    `db:"map"`
+}
+
+// DailyPlayersPeak is the highest number of players on a server during a day.
+type DailyPlayersPeak struct {
+	// Day is the start of the day.
+	Day        time.Time
+	Players    int
+	MaxPlayers int
+	Map        string
+	PeakAt     time.Time
+}
+
+// DailyPlayersPeaks returns the peaks of players per day in the location, sorted by day.
+// Days without online records are skipped. The earliest record with the highest number
+// of players is the peak of the day.
+func DailyPlayersPeaks(records []ServerQueryRecord, loc *time.Location) []DailyPlayersPeak {
+	peaks := make(map[time.Time]*DailyPlayersPeak)
+
+	for _, record := range records {
+		if !record.Online {
+			continue
+		}
+
+		queriedAt := record.QueriedAt.In(loc)
+		day := time.Date(queriedAt.Year(), queriedAt.Month(), queriedAt.Day(), 0, 0, 0, 0, loc)
+
+		peak, ok := peaks[day]
+		if ok && (record.PlayersNum < peak.Players ||
+			(record.PlayersNum == peak.Players && !queriedAt.Before(peak.PeakAt))) {
+			continue
+		}
+
+		peaks[day] = &DailyPlayersPeak{
+			Day:        day,
+			Players:    record.PlayersNum,
+			MaxPlayers: record.MaxPlayersNum,
+			Map:        record.Map,
+			PeakAt:     queriedAt,
+		}
+	}
+
+	result := make([]DailyPlayersPeak, 0, len(peaks))
+	for _, peak := range peaks {
+		result = append(result, *peak)
+	}
+
+	slices.SortFunc(result, func(a, b DailyPlayersPeak) int {
+		return a.Day.Compare(b.Day)
+	})
+
+	return result
+}
+
+// EmptySince returns the time since which the server has no players.
+// It returns nil if the server has players or is offline by the latest record.
+// Offline records don't interrupt the empty period, so an empty server stays empty after a restart.
+// The records must be sorted by time, the time of the first record is returned if all of them are empty.
+func EmptySince(records []ServerQueryRecord) *time.Time {
+	if len(records) == 0 || !records[len(records)-1].Online {
+		return nil
+	}
+
+	var since *time.Time
+
+	for i := len(records) - 1; i >= 0; i-- {
+		if records[i].PlayersNum > 0 {
+			break
+		}
+
+		since = &records[i].QueriedAt
+	}
+
+	return since
+}
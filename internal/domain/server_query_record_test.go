@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDailyPlayersPeaks(t *testing.T) {
+	day := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	records := []ServerQueryRecord{
+		{QueriedAt: day.Add(10 * time.Hour), Online: true, PlayersNum: 5, MaxPlayersNum: 32, Map: "de_dust2"},
+		{QueriedAt: day.Add(20 * time.Hour), Online: true, PlayersNum: 12, MaxPlayersNum: 32, Map: "de_inferno"},
+		{QueriedAt: day.Add(21 * time.Hour), Online: true, PlayersNum: 12, MaxPlayersNum: 32, Map: "de_nuke"},
+		{QueriedAt: day.Add(23 * time.Hour), Online: false},
+		{QueriedAt: day.Add(26 * time.Hour), Online: false},
+		{QueriedAt: day.Add(50 * time.Hour), Online: true, PlayersNum: 0, MaxPlayersNum: 16, Map: "de_aztec"},
+	}
+
+	t.Run("utc", func(t *testing.T) {
+		assert.Equal(t, []DailyPlayersPeak{
+			{Day: day, Players: 12, MaxPlayers: 32, Map: "de_inferno", PeakAt: day.Add(20 * time.Hour)},
+			{
+				Day:        day.Add(48 * time.Hour),
+				Players:    0,
+				MaxPlayers: 16,
+				Map:        "de_aztec",
+				PeakAt:     day.Add(50 * time.Hour),
+			},
+		}, DailyPlayersPeaks(records, time.UTC))
+	})
+
+	t.Run("location", func(t *testing.T) {
+		loc := time.FixedZone("UTC+5", 5*60*60)
+
+		peaks := DailyPlayersPeaks(records, loc)
+
+		assert.Len(t, peaks, 3)
+		assert.Equal(t, time.Date(2025, 3, 1, 0, 0, 0, 0, loc), peaks[0].Day)
+		assert.Equal(t, 5, peaks[0].Players)
+		assert.Equal(t, time.Date(2025, 3, 2, 0, 0, 0, 0, loc), peaks[1].Day)
+		assert.Equal(t, 12, peaks[1].Players)
+		assert.True(t, day.Add(20*time.Hour).Equal(peaks[1].PeakAt))
+	})
+
+	t.Run("no records", func(t *testing.T) {
+		assert.Empty(t, DailyPlayersPeaks(nil, time.UTC))
+	})
+}
+
+func TestEmptySince(t *testing.T) {
+	base := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		records []ServerQueryRecord
+		want    *time.Time
+	}{
+		{
+			name:    "no records",
+			records: nil,
+			want:    nil,
+		},
+		{
+			name: "has players",
+			records: []ServerQueryRecord{
+				{QueriedAt: base, Online: true, PlayersNum: 0},
+				{QueriedAt: base.Add(time.Minute), Online: true, PlayersNum: 3},
+			},
+			want: nil,
+		},
+		{
+			name: "offline",
+			records: []ServerQueryRecord{
+				{QueriedAt: base, Online: true, PlayersNum: 0},
+				{QueriedAt: base.Add(time.Minute), Online: false},
+			},
+			want: nil,
+		},
+		{
+			name: "empty after players left",
+			records: []ServerQueryRecord{
+				{QueriedAt: base, Online: true, PlayersNum: 4},
+				{QueriedAt: base.Add(time.Minute), Online: true, PlayersNum: 0},
+				{QueriedAt: base.Add(2 * time.Minute), Online: false},
+				{QueriedAt: base.Add(3 * time.Minute), Online: true, PlayersNum: 0},
+			},
+			want: &[]time.Time{base.Add(time.Minute)}[0],
+		},
+		{
+			name: "empty during whole history",
+			records: []ServerQueryRecord{
+				{QueriedAt: base, Online: true, PlayersNum: 0},
+				{QueriedAt: base.Add(time.Minute), Online: true, PlayersNum: 0},
+			},
+			want: &base,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, EmptySince(tt.records))
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package domain
+
+import "time"
+
+// ServerResource is a resource limited by the server limits.
+type ServerResource string
+
+const (
+	ServerResourceCPU ServerResource = "cpu"
+	ServerResourceRAM ServerResource = "ram"
+	ServerResourceNet ServerResource = "net"
+)
+
+// ServerResourceUsage is the latest resource usage of a server reported by the daemon.
+// The units are the same as of the server limits: CPU in percent of a single core,
+// RAM in megabytes and network in megabits per second. Nil usage is not reported.
+type ServerResourceUsage struct {
+	ServerID   uint      `db:"server_id"`
+	CPU        *float64  `db:"cpu"`
+	RAM        *int      `db:"ram"`
+	Net        *int      `db:"net"`
+	ReportedAt time.Time `db:"reported_at"`
+}
+
+// ResourceLimitWarning reports that a server uses more of a resource than its limit allows.
+type ResourceLimitWarning struct {
+	Resource ServerResource
+	Limit    int
+	Usage    float64
+}
+
+// LimitWarnings returns the resources used over the limits of the server.
+// Resources without a limit or without reported usage are skipped.
+func (u *ServerResourceUsage) LimitWarnings(server *Server) []ResourceLimitWarning {
+	var warnings []ResourceLimitWarning
+
+	check := func(resource ServerResource, limit *int, usage *float64) {
+		if limit == nil || *limit <= 0 || usage == nil {
+			return
+		}
+
+		if *usage > float64(*limit) {
+			warnings = append(warnings, ResourceLimitWarning{
+				Resource: resource,
+				Limit:    *limit,
+				Usage:    *usage,
+			})
+		}
+	}
+
+	check(ServerResourceCPU, server.CPULimit, u.CPU)
+	check(ServerResourceRAM, server.RAMLimit, intToFloatPtr(u.RAM))
+	check(ServerResourceNet, server.NetLimit, intToFloatPtr(u.Net))
+
+	return warnings
+}
+
+func intToFloatPtr(v *int) *float64 {
+	if v == nil {
+		return nil
+	}
+
+	f := float64(*v)
+
+	return &f
+}
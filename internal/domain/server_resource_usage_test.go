@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerResourceUsage_LimitWarnings(t *testing.T) {
+	tests := []struct {
+		name   string
+		server *Server
+		usage  *ServerResourceUsage
+		want   []ResourceLimitWarning
+	}{
+		{
+			name:   "no limits",
+			server: &Server{},
+			usage:  &ServerResourceUsage{CPU: lo.ToPtr(350.0), RAM: lo.ToPtr(4096), Net: lo.ToPtr(100)},
+			want:   nil,
+		},
+		{
+			name:   "within limits",
+			server: &Server{CPULimit: lo.ToPtr(100), RAMLimit: lo.ToPtr(1024), NetLimit: lo.ToPtr(10)},
+			usage:  &ServerResourceUsage{CPU: lo.ToPtr(100.0), RAM: lo.ToPtr(512), Net: lo.ToPtr(10)},
+			want:   nil,
+		},
+		{
+			name:   "over limits",
+			server: &Server{CPULimit: lo.ToPtr(100), RAMLimit: lo.ToPtr(1024), NetLimit: lo.ToPtr(10)},
+			usage:  &ServerResourceUsage{CPU: lo.ToPtr(120.5), RAM: lo.ToPtr(1500), Net: lo.ToPtr(5)},
+			want: []ResourceLimitWarning{
+				{Resource: ServerResourceCPU, Limit: 100, Usage: 120.5},
+				{Resource: ServerResourceRAM, Limit: 1024, Usage: 1500},
+			},
+		},
+		{
+			name:   "usage not reported",
+			server: &Server{CPULimit: lo.ToPtr(100), RAMLimit: lo.ToPtr(1024)},
+			usage:  &ServerResourceUsage{RAM: lo.ToPtr(2048)},
+			want: []ResourceLimitWarning{
+				{Resource: ServerResourceRAM, Limit: 1024, Usage: 2048},
+			},
+		},
+		{
+			name:   "zero limit means unlimited",
+			server: &Server{RAMLimit: lo.ToPtr(0)},
+			usage:  &ServerResourceUsage{RAM: lo.ToPtr(2048)},
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.usage.LimitWarnings(tt.server))
+		})
+	}
+}
@@ -1,6 +1,12 @@
 package domain
 
-import "time"
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/robfig/cron/v3"
+)
 
 type ServerTaskCommand string
 
@@ -10,6 +16,7 @@ const (
 	ServerTaskCommandRestart   ServerTaskCommand = "restart"
 	ServerTaskCommandUpdate    ServerTaskCommand = "update"
 	ServerTaskCommandReinstall ServerTaskCommand = "reinstall"
+	ServerTaskCommandBackup    ServerTaskCommand = "backup"
 )
 
 func NewServerTaskCommandFromString(s string) ServerTaskCommand {
@@ -24,11 +31,18 @@ func NewServerTaskCommandFromString(s string) ServerTaskCommand {
 		return ServerTaskCommandUpdate
 	case "reinstall":
 		return ServerTaskCommandReinstall
+	case "backup":
+		return ServerTaskCommandBackup
 	default:
 		return ""
 	}
 }
 
+// ExecutedByPanel reports whether the command is executed by the panel instead of the daemon.
+func (c ServerTaskCommand) ExecutedByPanel() bool {
+	return c == ServerTaskCommandBackup
+}
+
 type ServerTask struct {
 	ID           uint              `db:"id"`
 	Command      ServerTaskCommand `db:"command"`
@@ -38,8 +52,15 @@ type ServerTask struct {
 	Counter      uint              `db:"counter"`
 	ExecuteDate  time.Time         `db:"execute_date"`
 	Payload      *string           `db:"payload"`
-	CreatedAt    *time.Time        `db:"created_at"`
-	UpdatedAt    *time.Time        `db:"updated_at"`
+
+	// CronExpression is a standard five-field cron expression or a descriptor like @daily.
+	// When it is set, RepeatPeriod is ignored and ExecuteDate holds the next scheduled run.
+	CronExpression *string `db:"cron_expression"`
+	// Timezone is an IANA time zone name the cron expression is evaluated in. UTC is used when empty.
+	Timezone *string `db:"timezone"`
+
+	CreatedAt *time.Time `db:"created_at"`
+	UpdatedAt *time.Time `db:"updated_at"`
 }
 
 type ServerTaskFail struct {
@@ -49,3 +70,199 @@ type ServerTaskFail struct {
 	CreatedAt    *time.Time `db:"created_at"`
 	UpdatedAt    *time.Time `db:"updated_at"`
 }
+
+// maxMissedRuns limits the number of cron runs counted as missed.
+const maxMissedRuns = 1000
+
+var cronParser = cron.NewParser(
+	cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor,
+)
+
+// ParseServerTaskSchedule parses a cron expression evaluated in the given time zone.
+// Empty time zone means UTC.
+func ParseServerTaskSchedule(expression, timezone string) (cron.Schedule, error) {
+	if strings.Contains(expression, "TZ=") {
+		return nil, errors.New("time zone must be set separately from the cron expression")
+	}
+
+	location := time.UTC
+
+	if timezone != "" {
+		var err error
+
+		location, err = time.LoadLocation(timezone)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid time zone")
+		}
+	}
+
+	schedule, err := cronParser.Parse(expression)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid cron expression")
+	}
+
+	if spec, ok := schedule.(*cron.SpecSchedule); ok {
+		spec.Location = location
+	}
+
+	return schedule, nil
+}
+
+// IsCron reports whether the task is scheduled by a cron expression.
+func (t *ServerTask) IsCron() bool {
+	return t.CronExpression != nil && *t.CronExpression != ""
+}
+
+// Schedule returns the cron schedule of the task.
+func (t *ServerTask) Schedule() (cron.Schedule, error) {
+	if !t.IsCron() {
+		return nil, errors.New("server task has no cron expression")
+	}
+
+	var timezone string
+	if t.Timezone != nil {
+		timezone = *t.Timezone
+	}
+
+	return ParseServerTaskSchedule(*t.CronExpression, timezone)
+}
+
+// NextRuns returns up to n execution dates after the given time.
+func (t *ServerTask) NextRuns(after time.Time, n int) ([]time.Time, error) {
+	runs := make([]time.Time, 0, n)
+
+	if !t.IsCron() {
+		next := t.ExecuteDate
+		for len(runs) < n {
+			if t.Repeat != 0 && uint(len(runs))+t.Counter >= uint(t.Repeat) {
+				break
+			}
+
+			if next.After(after) {
+				runs = append(runs, next)
+			}
+
+			if t.RepeatPeriod <= 0 {
+				break
+			}
+
+			next = next.Add(t.RepeatPeriod)
+		}
+
+		return runs, nil
+	}
+
+	schedule, err := t.Schedule()
+	if err != nil {
+		return nil, err
+	}
+
+	next := after
+	for len(runs) < n {
+		if t.Repeat != 0 && uint(len(runs))+t.Counter >= uint(t.Repeat) {
+			break
+		}
+
+		next = schedule.Next(next)
+		if next.IsZero() {
+			break
+		}
+
+		runs = append(runs, next)
+	}
+
+	return runs, nil
+}
+
+// ApproximateRepeatPeriod returns the repeat period of the task.
+// For cron tasks it is the interval between the next run and the run after it.
+// It is used by clients that support fixed repeat periods only, such as the daemon.
+func (t *ServerTask) ApproximateRepeatPeriod() time.Duration {
+	if !t.IsCron() {
+		return t.RepeatPeriod
+	}
+
+	schedule, err := t.Schedule()
+	if err != nil {
+		return 0
+	}
+
+	next := schedule.Next(t.ExecuteDate)
+	if next.IsZero() {
+		return 0
+	}
+
+	return next.Sub(t.ExecuteDate)
+}
+
+// IsFinished reports whether the task has no more executions left.
+// A task without a repeat period or a cron expression is executed once.
+// Zero Repeat means the task is repeated endlessly.
+func (t *ServerTask) IsFinished() bool {
+	if t.Counter == 0 {
+		return false
+	}
+
+	if !t.IsCron() && t.RepeatPeriod <= 0 {
+		return true
+	}
+
+	return t.Repeat != 0 && t.Counter >= uint(t.Repeat)
+}
+
+// MissedRuns returns the number of repetitions which were scheduled after
+// the current execution date and are already in the past at the given time.
+func (t *ServerTask) MissedRuns(now time.Time) uint {
+	if !now.After(t.ExecuteDate) {
+		return 0
+	}
+
+	if t.IsCron() {
+		schedule, err := t.Schedule()
+		if err != nil {
+			return 0
+		}
+
+		var missed uint
+		for next := schedule.Next(t.ExecuteDate); !next.IsZero() && !next.After(now); next = schedule.Next(next) {
+			missed++
+
+			if missed >= maxMissedRuns {
+				break
+			}
+		}
+
+		return missed
+	}
+
+	if t.RepeatPeriod <= 0 {
+		return 0
+	}
+
+	return uint(now.Sub(t.ExecuteDate) / t.RepeatPeriod)
+}
+
+// Advance marks the task as executed at the given time.
+// Counter is incremented and the execution date is moved to the first repetition after the given time.
+func (t *ServerTask) Advance(now time.Time) error {
+	t.Counter++
+
+	if t.IsCron() {
+		schedule, err := t.Schedule()
+		if err != nil {
+			return err
+		}
+
+		t.ExecuteDate = schedule.Next(now)
+
+		return nil
+	}
+
+	if t.RepeatPeriod <= 0 {
+		return nil
+	}
+
+	t.ExecuteDate = t.ExecuteDate.Add(time.Duration(t.MissedRuns(now)+1) * t.RepeatPeriod)
+
+	return nil
+}
@@ -4,7 +4,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestServerTaskCommandConstants(t *testing.T) {
@@ -13,6 +15,7 @@ func TestServerTaskCommandConstants(t *testing.T) {
 	assert.Equal(t, ServerTaskCommand("restart"), ServerTaskCommandRestart)
 	assert.Equal(t, ServerTaskCommand("update"), ServerTaskCommandUpdate)
 	assert.Equal(t, ServerTaskCommand("reinstall"), ServerTaskCommandReinstall)
+	assert.Equal(t, ServerTaskCommand("backup"), ServerTaskCommandBackup)
 }
 
 func TestNewServerTaskCommandFromString(t *testing.T) {
@@ -46,6 +49,11 @@ func TestNewServerTaskCommandFromString(t *testing.T) {
 			input:    "reinstall",
 			expected: ServerTaskCommandReinstall,
 		},
+		{
+			name:     "backup_command",
+			input:    "backup",
+			expected: ServerTaskCommandBackup,
+		},
 		{
 			name:     "unknown_command_returns_empty",
 			input:    "unknown",
@@ -76,8 +84,14 @@ func TestNewServerTaskCommandFromString(t *testing.T) {
 	}
 }
 
+func TestServerTaskCommand_ExecutedByPanel(t *testing.T) {
+	assert.True(t, ServerTaskCommandBackup.ExecutedByPanel())
+	assert.False(t, ServerTaskCommandStart.ExecutedByPanel())
+	assert.False(t, ServerTaskCommandRestart.ExecutedByPanel())
+}
+
 func TestNewServerTaskCommandFromString_AllValidCommands(t *testing.T) {
-	validCommands := []string{"start", "stop", "restart", "update", "reinstall"}
+	validCommands := []string{"start", "stop", "restart", "update", "reinstall", "backup"}
 
 	for _, cmd := range validCommands {
 		t.Run(cmd, func(t *testing.T) {
@@ -267,3 +281,173 @@ func TestServerTaskFail_LongOutput(t *testing.T) {
 	assert.Equal(t, longOutput, taskFail.Output)
 	assert.Len(t, taskFail.Output, len(longOutput))
 }
+
+func TestServerTask_IsFinished(t *testing.T) {
+	tests := []struct {
+		name     string
+		task     ServerTask
+		expected bool
+	}{
+		{
+			name:     "not_executed_yet",
+			task:     ServerTask{Repeat: 1},
+			expected: false,
+		},
+		{
+			name:     "executed_once_without_period",
+			task:     ServerTask{Repeat: 0, Counter: 1},
+			expected: true,
+		},
+		{
+			name:     "endless_repetition",
+			task:     ServerTask{Repeat: 0, RepeatPeriod: time.Hour, Counter: 100},
+			expected: false,
+		},
+		{
+			name:     "repetitions_left",
+			task:     ServerTask{Repeat: 3, RepeatPeriod: time.Hour, Counter: 2},
+			expected: false,
+		},
+		{
+			name:     "all_repetitions_executed",
+			task:     ServerTask{Repeat: 3, RepeatPeriod: time.Hour, Counter: 3},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.task.IsFinished())
+		})
+	}
+}
+
+func TestServerTask_Advance(t *testing.T) {
+	executeDate := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	t.Run("without_period", func(t *testing.T) {
+		task := ServerTask{ExecuteDate: executeDate}
+
+		require.NoError(t, task.Advance(executeDate.Add(time.Minute)))
+
+		assert.Equal(t, uint(1), task.Counter)
+		assert.Equal(t, executeDate, task.ExecuteDate)
+	})
+
+	t.Run("on_time", func(t *testing.T) {
+		task := ServerTask{ExecuteDate: executeDate, RepeatPeriod: time.Hour}
+
+		assert.Equal(t, uint(0), task.MissedRuns(executeDate.Add(time.Minute)))
+
+		require.NoError(t, task.Advance(executeDate.Add(time.Minute)))
+
+		assert.Equal(t, uint(1), task.Counter)
+		assert.Equal(t, executeDate.Add(time.Hour), task.ExecuteDate)
+	})
+
+	t.Run("with_missed_runs", func(t *testing.T) {
+		task := ServerTask{ExecuteDate: executeDate, RepeatPeriod: time.Hour}
+		now := executeDate.Add(150 * time.Minute)
+
+		assert.Equal(t, uint(2), task.MissedRuns(now))
+
+		require.NoError(t, task.Advance(now))
+
+		assert.Equal(t, uint(1), task.Counter)
+		assert.Equal(t, executeDate.Add(3*time.Hour), task.ExecuteDate)
+	})
+}
+
+func TestParseServerTaskSchedule(t *testing.T) {
+	t.Run("valid_expression_with_timezone", func(t *testing.T) {
+		schedule, err := ParseServerTaskSchedule("0 5 * * 1-6", "Europe/Berlin")
+		require.NoError(t, err)
+
+		// Saturday, 2025-06-14 10:00 UTC
+		next := schedule.Next(time.Date(2025, 6, 14, 10, 0, 0, 0, time.UTC))
+
+		berlin, err := time.LoadLocation("Europe/Berlin")
+		require.NoError(t, err)
+
+		// Sunday is skipped
+		assert.True(t, time.Date(2025, 6, 16, 5, 0, 0, 0, berlin).Equal(next))
+	})
+
+	t.Run("descriptor", func(t *testing.T) {
+		_, err := ParseServerTaskSchedule("@daily", "")
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid_expression", func(t *testing.T) {
+		_, err := ParseServerTaskSchedule("0 5 * *", "")
+		require.Error(t, err)
+	})
+
+	t.Run("invalid_timezone", func(t *testing.T) {
+		_, err := ParseServerTaskSchedule("0 5 * * *", "Mars/Olympus")
+		require.Error(t, err)
+	})
+
+	t.Run("timezone_in_expression", func(t *testing.T) {
+		_, err := ParseServerTaskSchedule("CRON_TZ=UTC 0 5 * * *", "")
+		require.Error(t, err)
+	})
+}
+
+func TestServerTask_Cron(t *testing.T) {
+	executeDate := time.Date(2025, 6, 15, 5, 0, 0, 0, time.UTC)
+
+	newTask := func() ServerTask {
+		return ServerTask{
+			ExecuteDate:    executeDate,
+			CronExpression: lo.ToPtr("0 5 * * *"),
+			Timezone:       lo.ToPtr("UTC"),
+		}
+	}
+
+	t.Run("next_runs", func(t *testing.T) {
+		task := newTask()
+
+		runs, err := task.NextRuns(executeDate, 3)
+		require.NoError(t, err)
+		assert.Equal(t, []time.Time{
+			executeDate.Add(24 * time.Hour),
+			executeDate.Add(48 * time.Hour),
+			executeDate.Add(72 * time.Hour),
+		}, runs)
+	})
+
+	t.Run("next_runs_limited_by_repeat", func(t *testing.T) {
+		task := newTask()
+		task.Repeat = 3
+		task.Counter = 2
+
+		runs, err := task.NextRuns(executeDate, 3)
+		require.NoError(t, err)
+		assert.Len(t, runs, 1)
+	})
+
+	t.Run("is_not_finished_without_repeat_limit", func(t *testing.T) {
+		task := newTask()
+		task.Counter = 10
+
+		assert.False(t, task.IsFinished())
+	})
+
+	t.Run("advance_with_missed_runs", func(t *testing.T) {
+		task := newTask()
+		now := executeDate.Add(50 * time.Hour)
+
+		assert.Equal(t, uint(2), task.MissedRuns(now))
+
+		require.NoError(t, task.Advance(now))
+		assert.Equal(t, uint(1), task.Counter)
+		assert.Equal(t, executeDate.Add(72*time.Hour), task.ExecuteDate)
+	})
+
+	t.Run("approximate_repeat_period", func(t *testing.T) {
+		task := newTask()
+
+		assert.Equal(t, 24*time.Hour, task.ApproximateRepeatPeriod())
+	})
+}
@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ServerTemplate is a reusable server configuration. New servers can be created from a template,
+// the template is usually captured from an existing server.
+type ServerTemplate struct {
+	ID          uint    `db:"id"`
+	Name        string  `db:"name"`
+	Description *string `db:"description"`
+	GameID      string  `db:"game_id"`
+	GameModID   uint    `db:"game_mod_id"`
+	// ServerPort, QueryPort and RconPort are the preferred ports of new servers.
+	// If they are busy, the ports are shifted keeping the same distance between them.
+	ServerPort       int                    `db:"server_port"`
+	QueryPort        *int                   `db:"query_port"`
+	RconPort         *int                   `db:"rcon_port"`
+	SuUser           *string                `db:"su_user"`
+	CPULimit         *int                   `db:"cpu_limit"`
+	RAMLimit         *int                   `db:"ram_limit"`
+	NetLimit         *int                   `db:"net_limit"`
+	StartCommand     *string                `db:"start_command"`
+	StopCommand      *string                `db:"stop_command"`
+	ForceStopCommand *string                `db:"force_stop_command"`
+	RestartCommand   *string                `db:"restart_command"`
+	Vars             *string                `db:"vars"`
+	Settings         ServerTemplateSettings `db:"settings"`
+	CreatedAt        *time.Time             `db:"created_at"`
+	UpdatedAt        *time.Time             `db:"updated_at"`
+}
+
+// NewServerTemplateFromServer captures the configuration and the settings of the server.
+// Server specific data like the node, IP, directory and rcon password are not included.
+func NewServerTemplateFromServer(name string, server *Server, settings []ServerSetting) *ServerTemplate {
+	return &ServerTemplate{
+		Name:             name,
+		GameID:           server.GameID,
+		GameModID:        server.GameModID,
+		ServerPort:       server.ServerPort,
+		QueryPort:        clonePtr(server.QueryPort),
+		RconPort:         clonePtr(server.RconPort),
+		SuUser:           clonePtr(server.SuUser),
+		CPULimit:         clonePtr(server.CPULimit),
+		RAMLimit:         clonePtr(server.RAMLimit),
+		NetLimit:         clonePtr(server.NetLimit),
+		StartCommand:     clonePtr(server.StartCommand),
+		StopCommand:      clonePtr(server.StopCommand),
+		ForceStopCommand: clonePtr(server.ForceStopCommand),
+		RestartCommand:   clonePtr(server.RestartCommand),
+		Vars:             clonePtr(server.Vars),
+		Settings:         NewServerTemplateSettings(settings),
+	}
+}
+
+// ApplyTo copies the template configuration to the server. Ports are not copied,
+// they are allocated for each new server separately.
+func (t *ServerTemplate) ApplyTo(server *Server) {
+	server.GameID = t.GameID
+	server.GameModID = t.GameModID
+	server.SuUser = clonePtr(t.SuUser)
+	server.CPULimit = clonePtr(t.CPULimit)
+	server.RAMLimit = clonePtr(t.RAMLimit)
+	server.NetLimit = clonePtr(t.NetLimit)
+	server.StartCommand = clonePtr(t.StartCommand)
+	server.StopCommand = clonePtr(t.StopCommand)
+	server.ForceStopCommand = clonePtr(t.ForceStopCommand)
+	server.RestartCommand = clonePtr(t.RestartCommand)
+	server.Vars = clonePtr(t.Vars)
+}
+
+// Ports returns the preferred game, query and rcon ports of new servers.
+func (t *ServerTemplate) Ports() []int {
+	s := Server{ServerPort: t.ServerPort, QueryPort: t.QueryPort, RconPort: t.RconPort}
+
+	return s.Ports()
+}
+
+// ServerTemplateSettings holds server settings values by the setting name.
+// It is stored as a JSON object.
+type ServerTemplateSettings map[string]any
+
+func NewServerTemplateSettings(settings []ServerSetting) ServerTemplateSettings {
+	result := make(ServerTemplateSettings, len(settings))
+
+	for _, setting := range settings {
+		result[setting.Name] = setting.Value.Any()
+	}
+
+	return result
+}
+
+// ServerSettings returns the settings for the server, ordered by name.
+func (s ServerTemplateSettings) ServerSettings(serverID uint) []ServerSetting {
+	names := slices.Sorted(maps.Keys(s))
+
+	result := make([]ServerSetting, 0, len(names))
+	for _, name := range names {
+		result = append(result, ServerSetting{
+			Name:     name,
+			ServerID: serverID,
+			Value:    NewServerSettingValue(s[name]),
+		})
+	}
+
+	return result
+}
+
+func (s *ServerTemplateSettings) Scan(value any) error {
+	var b []byte
+
+	switch v := value.(type) {
+	case nil:
+		*s = nil
+
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return errors.Errorf("unsupported server template settings type %T", value)
+	}
+
+	if len(b) == 0 {
+		*s = nil
+
+		return nil
+	}
+
+	if err := json.Unmarshal(b, s); err != nil {
+		return errors.WithMessage(err, "failed to unmarshal server template settings")
+	}
+
+	return nil
+}
+
+func (s ServerTemplateSettings) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(b), nil
+}
+
+func clonePtr[T any](v *T) *T {
+	if v == nil {
+		return nil
+	}
+
+	c := *v
+
+	return &c
+}
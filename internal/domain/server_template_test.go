@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewServerTemplateFromServer(t *testing.T) {
+	server := &Server{
+		ID:           1,
+		DSID:         2,
+		Name:         "Public CS",
+		GameID:       "cstrike",
+		GameModID:    3,
+		ServerIP:     "10.0.0.1",
+		ServerPort:   27015,
+		QueryPort:    lo.ToPtr(27016),
+		Rcon:         lo.ToPtr("secret"),
+		Dir:          "servers/cs",
+		StartCommand: lo.ToPtr("./hlds_run -game cstrike"),
+		Vars:         lo.ToPtr(`{"maxplayers":"32"}`),
+	}
+	settings := []ServerSetting{
+		{Name: "autostart", ServerID: 1, Value: NewServerSettingValue(true)},
+		{Name: "backup_keep_last", ServerID: 1, Value: NewServerSettingValue(5)},
+	}
+
+	tpl := NewServerTemplateFromServer("CS template", server, settings)
+
+	assert.Equal(t, "CS template", tpl.Name)
+	assert.Equal(t, "cstrike", tpl.GameID)
+	assert.Equal(t, uint(3), tpl.GameModID)
+	assert.Equal(t, []int{27015, 27016}, tpl.Ports())
+	assert.Equal(t, server.StartCommand, tpl.StartCommand)
+	assert.NotSame(t, server.StartCommand, tpl.StartCommand)
+	assert.Equal(t, ServerTemplateSettings{"autostart": true, "backup_keep_last": 5}, tpl.Settings)
+}
+
+func TestServerTemplate_ApplyTo(t *testing.T) {
+	tpl := &ServerTemplate{
+		GameID:       "cstrike",
+		GameModID:    3,
+		ServerPort:   27015,
+		StartCommand: lo.ToPtr("./hlds_run"),
+		RAMLimit:     lo.ToPtr(1024),
+	}
+	server := &Server{ServerIP: "10.0.0.1", ServerPort: 27020}
+
+	tpl.ApplyTo(server)
+
+	assert.Equal(t, "cstrike", server.GameID)
+	assert.Equal(t, uint(3), server.GameModID)
+	assert.Equal(t, lo.ToPtr("./hlds_run"), server.StartCommand)
+	assert.Equal(t, lo.ToPtr(1024), server.RAMLimit)
+	assert.Equal(t, 27020, server.ServerPort)
+}
+
+func TestServerTemplateSettings_ServerSettings(t *testing.T) {
+	settings := ServerTemplateSettings{"update_before_start": false, "autostart": true, "backup_keep_last": float64(5)}
+
+	result := settings.ServerSettings(7)
+
+	require.Len(t, result, 3)
+	assert.Equal(t, "autostart", result[0].Name)
+	assert.Equal(t, uint(7), result[0].ServerID)
+	b, ok := result[0].Value.Bool()
+	assert.True(t, ok)
+	assert.True(t, b)
+	assert.Equal(t, "backup_keep_last", result[1].Name)
+	i, ok := result[1].Value.Int()
+	assert.True(t, ok)
+	assert.Equal(t, 5, i)
+	assert.Equal(t, "update_before_start", result[2].Name)
+}
+
+func TestServerTemplateSettings_ScanValue(t *testing.T) {
+	settings := ServerTemplateSettings{"autostart": true, "name": "value"}
+
+	value, err := settings.Value()
+	require.NoError(t, err)
+
+	var fromString ServerTemplateSettings
+	require.NoError(t, fromString.Scan(value))
+	assert.Equal(t, settings, fromString)
+
+	var fromBytes ServerTemplateSettings
+	require.NoError(t, fromBytes.Scan([]byte(value.(string))))
+	assert.Equal(t, settings, fromBytes)
+
+	var fromNil ServerTemplateSettings
+	require.NoError(t, fromNil.Scan(nil))
+	assert.Nil(t, fromNil)
+
+	assert.Error(t, fromNil.Scan([]byte("invalid")))
+	assert.Error(t, fromNil.Scan(42))
+
+	nilValue, err := ServerTemplateSettings(nil).Value()
+	require.NoError(t, err)
+	assert.Nil(t, nilValue)
+}
@@ -24,6 +24,9 @@ func TestServer_ReplaceServerShortcodes(t *testing.T) {
 		QueryPort:  &queryPort,
 		RconPort:   &rconPort,
 		SuUser:     &suUser,
+		CPULimit:   lo.ToPtr(150),
+		RAMLimit:   lo.ToPtr(2048),
+		NetLimit:   lo.ToPtr(100),
 		GameID:     "cs2",
 		Dir:        "/var/games/server1",
 	}
@@ -44,6 +47,12 @@ func TestServer_ReplaceServerShortcodes(t *testing.T) {
 			extra:   nil,
 			want:    "connect 192.168.1.100:27015",
 		},
+		{
+			name:    "replace_resource_limits",
+			command: "systemd-run --scope -p CPUQuota={cpu_limit}% -p MemoryMax={ram_limit}M ./run --net {net_limit}",
+			extra:   nil,
+			want:    "systemd-run --scope -p CPUQuota=150% -p MemoryMax=2048M ./run --net 100",
+		},
 		{
 			name:    "replace_node_paths",
 			command: "{node_work_path}/scripts/start.sh",
@@ -202,6 +211,11 @@ func TestServer_ReplaceServerShortcodes_WithNilOptionalFields(t *testing.T) {
 			command: "User: {user}",
 			want:    "User: ",
 		},
+		{
+			name:    "resource_limits_empty_when_nil",
+			command: "cpu={cpu_limit} ram={ram_limit} net={net_limit}",
+			want:    "cpu= ram= net=",
+		},
 		{
 			name:    "multiple_nil_fields",
 			command: "su {user} query={query_port} rcon={rcon_port}",
@@ -313,6 +327,94 @@ func TestServer_IsOnline(t *testing.T) {
 	}
 }
 
+func TestServersBusyPorts(t *testing.T) {
+	now := time.Now()
+	queryPort1 := 27016
+	rconPort1 := 27017
+	queryPort2 := 27019
+	sharedPort := 27015
+
+	tests := []struct {
+		name     string
+		servers  []Server
+		expected map[string][]int
+	}{
+		{
+			name: "servers grouped by ip",
+			servers: []Server{
+				{
+					ID:         1,
+					UUID:       uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+					DSID:       1,
+					ServerIP:   "192.168.1.1",
+					ServerPort: 27015,
+					QueryPort:  &queryPort1,
+					RconPort:   &rconPort1,
+					CreatedAt:  &now,
+				},
+				{
+					ID:         2,
+					UUID:       uuid.MustParse("22222222-2222-2222-2222-222222222222"),
+					DSID:       1,
+					ServerIP:   "192.168.1.1",
+					ServerPort: 27018,
+					QueryPort:  &queryPort2,
+					CreatedAt:  &now,
+				},
+				{
+					ID:         3,
+					UUID:       uuid.MustParse("33333333-3333-3333-3333-333333333333"),
+					DSID:       1,
+					ServerIP:   "192.168.1.2",
+					ServerPort: 27015,
+					CreatedAt:  &now,
+				},
+			},
+			expected: map[string][]int{
+				"192.168.1.1": {27015, 27016, 27017, 27018, 27019},
+				"192.168.1.2": {27015},
+			},
+		},
+		{
+			name: "duplicate ports are reported once",
+			servers: []Server{
+				{ID: 1, ServerIP: "192.168.1.1", ServerPort: 27015, QueryPort: &sharedPort, RconPort: &sharedPort},
+				{ID: 2, ServerIP: "192.168.1.1", ServerPort: 27015},
+			},
+			expected: map[string][]int{
+				"192.168.1.1": {27015},
+			},
+		},
+		{
+			name: "ports are sorted",
+			servers: []Server{
+				{ID: 1, ServerIP: "192.168.1.1", ServerPort: 27030},
+				{ID: 2, ServerIP: "192.168.1.1", ServerPort: 27018, QueryPort: &queryPort2},
+				{ID: 3, ServerIP: "192.168.1.1", ServerPort: 27020, RconPort: &rconPort1},
+			},
+			expected: map[string][]int{
+				"192.168.1.1": {27017, 27018, 27019, 27020, 27030},
+			},
+		},
+		{
+			name:     "no servers",
+			servers:  []Server{},
+			expected: map[string][]int{},
+		},
+		{
+			name:     "nil servers",
+			servers:  nil,
+			expected: map[string][]int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ServersBusyPorts(tt.servers))
+		})
+	}
+}
+
 func TestServerInstalledStatusConstants(t *testing.T) {
 	assert.Equal(t, ServerInstalledStatus(0), ServerInstalledStatusNotInstalled)
 	assert.Equal(t, ServerInstalledStatus(1), ServerInstalledStatusInstalled)
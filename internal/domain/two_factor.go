@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// UserTwoFactor is the TOTP two-factor authentication of a user.
+//
+// The record is created when the user starts the enrollment,
+// the two-factor authentication is enabled after the first code is confirmed.
+type UserTwoFactor struct {
+	UserID uint `db:"user_id"`
+	// Secret is the base32 encoded TOTP secret.
+	Secret string `db:"secret"`
+	// ConfirmedAt is when the enrollment was confirmed, nil while the enrollment is pending.
+	ConfirmedAt *time.Time `db:"confirmed_at"`
+	// RecoveryCodes are the SHA-256 hashes of the unused recovery codes.
+	RecoveryCodes RecoveryCodes `db:"recovery_codes"`
+	// LastUsedStep is the TOTP time step of the last accepted code, codes can't be used twice.
+	LastUsedStep int64      `db:"last_used_step"`
+	CreatedAt    *time.Time `db:"created_at"`
+	UpdatedAt    *time.Time `db:"updated_at"`
+}
+
+// Enabled reports whether the enrollment is confirmed.
+func (t *UserTwoFactor) Enabled() bool {
+	return t.ConfirmedAt != nil
+}
+
+// UseRecoveryCode removes the recovery code hash and reports whether it was found.
+func (t *UserTwoFactor) UseRecoveryCode(hash string) bool {
+	i := slices.Index(t.RecoveryCodes, hash)
+	if i < 0 {
+		return false
+	}
+
+	t.RecoveryCodes = slices.Delete(slices.Clone(t.RecoveryCodes), i, i+1)
+
+	return true
+}
+
+// RecoveryCodes are the hashes of the recovery codes. It is stored as a JSON array.
+type RecoveryCodes []string
+
+func (c *RecoveryCodes) Scan(value any) error {
+	var b []byte
+
+	switch v := value.(type) {
+	case nil:
+		*c = nil
+
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return errors.Errorf("unsupported recovery codes type %T", value)
+	}
+
+	if len(b) == 0 {
+		*c = nil
+
+		return nil
+	}
+
+	if err := json.Unmarshal(b, c); err != nil {
+		return errors.WithMessage(err, "failed to unmarshal recovery codes")
+	}
+
+	return nil
+}
+
+func (c RecoveryCodes) Value() (driver.Value, error) {
+	if len(c) == 0 {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(b), nil
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserTwoFactor_Enabled(t *testing.T) {
+	pending := UserTwoFactor{}
+	assert.False(t, pending.Enabled())
+
+	now := time.Now()
+	confirmed := UserTwoFactor{ConfirmedAt: &now}
+	assert.True(t, confirmed.Enabled())
+}
+
+func TestUserTwoFactor_UseRecoveryCode(t *testing.T) {
+	codes := RecoveryCodes{"first", "second", "third"}
+	tf := UserTwoFactor{RecoveryCodes: codes}
+
+	assert.True(t, tf.UseRecoveryCode("second"))
+	assert.Equal(t, RecoveryCodes{"first", "third"}, tf.RecoveryCodes)
+	assert.Equal(t, RecoveryCodes{"first", "second", "third"}, codes, "original slice must not be changed")
+
+	assert.False(t, tf.UseRecoveryCode("second"))
+	assert.False(t, tf.UseRecoveryCode("unknown"))
+	assert.Len(t, tf.RecoveryCodes, 2)
+}
+
+func TestRecoveryCodes_ScanValue(t *testing.T) {
+	codes := RecoveryCodes{"aa", "bb"}
+
+	value, err := codes.Value()
+	require.NoError(t, err)
+	assert.Equal(t, `["aa","bb"]`, value)
+
+	var scanned RecoveryCodes
+	require.NoError(t, scanned.Scan([]byte(`["aa","bb"]`)))
+	assert.Equal(t, codes, scanned)
+
+	require.NoError(t, scanned.Scan(nil))
+	assert.Nil(t, scanned)
+
+	value, err = RecoveryCodes(nil).Value()
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
@@ -11,4 +11,11 @@ type User struct {
 	Name          *string    `db:"name"`           // maxlen=255
 	CreatedAt     *time.Time `db:"created_at"`     //
 	UpdatedAt     *time.Time `db:"updated_at"`     //
+	// DisabledAt is set when the user can't log in, for example after the user is removed from the LDAP directory.
+	DisabledAt *time.Time `db:"disabled_at"` //
+}
+
+// Disabled reports whether the user can't log in.
+func (u *User) Disabled() bool {
+	return u.DisabledAt != nil
 }
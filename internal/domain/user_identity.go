@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// UserIdentity links a user to an account of an external identity provider.
+type UserIdentity struct {
+	// Provider is the name of the configured identity provider.
+	Provider string `db:"provider"`
+	// Subject is the unique and never reassigned identifier of the account at the provider.
+	Subject   string     `db:"subject"`
+	UserID    uint       `db:"user_id"`
+	Email     string     `db:"email"`
+	CreatedAt *time.Time `db:"created_at"`
+	UpdatedAt *time.Time `db:"updated_at"`
+}
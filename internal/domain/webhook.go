@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Webhook is an HTTP endpoint the panel events are delivered to.
+//
+// Global webhooks have no user and receive the events of all servers. Webhooks of a user
+// receive the events of the servers of the user, or of one server if ServerID is set.
+type Webhook struct {
+	ID uint `db:"id"`
+	// UserID is the owner of the webhook, nil for global webhooks.
+	UserID *uint `db:"user_id"`
+	// ServerID limits the webhook to the events of one server.
+	ServerID *uint  `db:"server_id"`
+	Name     string `db:"name"`
+	URL      string `db:"url"`
+	// Secret is the key of the HMAC-SHA256 signature of the payloads.
+	Secret string `db:"secret"`
+	// Events are the subscribed event types, all events are delivered if empty.
+	Events    WebhookEvents `db:"events"`
+	Enabled   bool          `db:"enabled"`
+	CreatedAt *time.Time    `db:"created_at"`
+	UpdatedAt *time.Time    `db:"updated_at"`
+}
+
+func (w *Webhook) IsGlobal() bool {
+	return w.UserID == nil
+}
+
+// Subscribed reports whether the webhook receives the events of the type.
+func (w *Webhook) Subscribed(eventType EventType) bool {
+	return len(w.Events) == 0 || slices.Contains(w.Events, eventType)
+}
+
+// WebhookEvents are the event types of a webhook. It is stored as a JSON array.
+type WebhookEvents []EventType
+
+func (e *WebhookEvents) Scan(value any) error {
+	var b []byte
+
+	switch v := value.(type) {
+	case nil:
+		*e = nil
+
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return errors.Errorf("unsupported webhook events type %T", value)
+	}
+
+	if len(b) == 0 {
+		*e = nil
+
+		return nil
+	}
+
+	if err := json.Unmarshal(b, e); err != nil {
+		return errors.WithMessage(err, "failed to unmarshal webhook events")
+	}
+
+	return nil
+}
+
+func (e WebhookEvents) Value() (driver.Value, error) {
+	if len(e) == 0 {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(b), nil
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookRetryPolicy configures retries of failed webhook deliveries.
+type WebhookRetryPolicy struct {
+	// BaseDelay is the delay after the first failed attempt, each next delay is doubled.
+	BaseDelay time.Duration
+	// MaxDelay limits the delay between attempts.
+	MaxDelay time.Duration
+	// MaxAttempts is the number of attempts after which the delivery is failed.
+	MaxAttempts int
+}
+
+// Backoff returns the delay before the next attempt after the given number of failed attempts.
+func (p WebhookRetryPolicy) Backoff(attempts int) time.Duration {
+	return exponentialBackoff(p.BaseDelay, p.MaxDelay, attempts)
+}
+
+// WebhookDelivery is a delivery of an event to a webhook.
+type WebhookDelivery struct {
+	ID        uint      `db:"id"`
+	WebhookID uint      `db:"webhook_id"`
+	EventID   string    `db:"event_id"`
+	EventType EventType `db:"event_type"`
+	// Payload is the JSON request body, it is signed as is.
+	Payload  string                `db:"payload"`
+	Status   WebhookDeliveryStatus `db:"status"`
+	Attempts int                   `db:"attempts"`
+	// NextAttemptAt is the time of the next attempt of a pending delivery.
+	NextAttemptAt *time.Time `db:"next_attempt_at"`
+	// ResponseStatus is the HTTP status of the last attempt, nil if no response was received.
+	ResponseStatus *int       `db:"response_status"`
+	Error          *string    `db:"error"`
+	CreatedAt      *time.Time `db:"created_at"`
+	UpdatedAt      *time.Time `db:"updated_at"`
+}
+
+// Succeed records a successful attempt.
+func (d *WebhookDelivery) Succeed(now time.Time, responseStatus int) {
+	d.Attempts++
+	d.Status = WebhookDeliveryStatusSucceeded
+	d.NextAttemptAt = nil
+	d.ResponseStatus = &responseStatus
+	d.Error = nil
+	d.UpdatedAt = &now
+}
+
+// Fail records a failed attempt. The next attempt is scheduled with the backoff of the policy,
+// the delivery is failed when the maximum number of attempts is reached.
+func (d *WebhookDelivery) Fail(policy WebhookRetryPolicy, now time.Time, responseStatus *int, errMsg string) {
+	d.Attempts++
+	d.ResponseStatus = responseStatus
+	d.Error = &errMsg
+	d.UpdatedAt = &now
+
+	if d.Attempts >= policy.MaxAttempts {
+		d.Status = WebhookDeliveryStatusFailed
+		d.NextAttemptAt = nil
+
+		return
+	}
+
+	next := now.Add(policy.Backoff(d.Attempts))
+
+	d.Status = WebhookDeliveryStatusPending
+	d.NextAttemptAt = &next
+}
+
+// Cancel fails the pending delivery which can't be sent anymore, without counting an attempt.
+func (d *WebhookDelivery) Cancel(now time.Time, reason string) {
+	d.Status = WebhookDeliveryStatusFailed
+	d.NextAttemptAt = nil
+	d.Error = &reason
+	d.UpdatedAt = &now
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook_Subscribed(t *testing.T) {
+	all := Webhook{}
+	assert.True(t, all.Subscribed(EventTypeServerCreated))
+	assert.True(t, all.Subscribed(EventTypeServerCrashLoop))
+
+	some := Webhook{Events: WebhookEvents{EventTypeServerOnline, EventTypeServerOffline}}
+	assert.True(t, some.Subscribed(EventTypeServerOffline))
+	assert.False(t, some.Subscribed(EventTypeServerCreated))
+}
+
+func TestWebhookEvents_ScanValue(t *testing.T) {
+	events := WebhookEvents{EventTypeServerCreated, EventTypeServerDeleted}
+
+	value, err := events.Value()
+	require.NoError(t, err)
+	assert.Equal(t, `["server.created","server.deleted"]`, value)
+
+	var scanned WebhookEvents
+	require.NoError(t, scanned.Scan([]byte(value.(string))))
+	assert.Equal(t, events, scanned)
+
+	value, err = WebhookEvents(nil).Value()
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	require.NoError(t, scanned.Scan(nil))
+	assert.Nil(t, scanned)
+
+	assert.Error(t, scanned.Scan(1))
+}
+
+func TestWebhookDelivery_Fail(t *testing.T) {
+	policy := WebhookRetryPolicy{BaseDelay: 30 * time.Second, MaxDelay: time.Minute, MaxAttempts: 3}
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	delivery := WebhookDelivery{Status: WebhookDeliveryStatusPending}
+
+	delivery.Fail(policy, now, lo.ToPtr(500), "unexpected response status 500")
+	assert.Equal(t, WebhookDeliveryStatusPending, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, lo.ToPtr(now.Add(30*time.Second)), delivery.NextAttemptAt)
+	assert.Equal(t, lo.ToPtr(500), delivery.ResponseStatus)
+
+	delivery.Fail(policy, now, nil, "connection refused")
+	assert.Equal(t, WebhookDeliveryStatusPending, delivery.Status)
+	assert.Equal(t, lo.ToPtr(now.Add(time.Minute)), delivery.NextAttemptAt)
+	assert.Nil(t, delivery.ResponseStatus)
+	assert.Equal(t, lo.ToPtr("connection refused"), delivery.Error)
+
+	delivery.Fail(policy, now, nil, "connection refused")
+	assert.Equal(t, WebhookDeliveryStatusFailed, delivery.Status)
+	assert.Equal(t, 3, delivery.Attempts)
+	assert.Nil(t, delivery.NextAttemptAt)
+}
+
+func TestWebhookDelivery_Succeed(t *testing.T) {
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	delivery := WebhookDelivery{
+		Status:        WebhookDeliveryStatusPending,
+		Attempts:      1,
+		NextAttemptAt: &now,
+		Error:         lo.ToPtr("timeout"),
+	}
+
+	delivery.Succeed(now, 204)
+
+	assert.Equal(t, WebhookDeliveryStatusSucceeded, delivery.Status)
+	assert.Equal(t, 2, delivery.Attempts)
+	assert.Nil(t, delivery.NextAttemptAt)
+	assert.Nil(t, delivery.Error)
+	assert.Equal(t, lo.ToPtr(204), delivery.ResponseStatus)
+}
@@ -0,0 +1,70 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/google/uuid"
+)
+
+// Handler handles the events published on the bus.
+type Handler interface {
+	HandleEvent(ctx context.Context, event domain.Event) error
+}
+
+// HandlerFunc adapts a function to the Handler interface.
+type HandlerFunc func(ctx context.Context, event domain.Event) error
+
+func (f HandlerFunc) HandleEvent(ctx context.Context, event domain.Event) error {
+	return f(ctx, event)
+}
+
+// Bus delivers the events to the subscribed handlers synchronously within the panel process.
+//
+// Handlers are called by the publisher, so they must not do slow work like HTTP requests.
+// Handler errors are logged and don't affect the publisher.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers []Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+func (b *Bus) Subscribe(handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers = append(b.handlers, handler)
+}
+
+// Publish delivers the event to all handlers. The event ID and time are set if they are empty.
+func (b *Bus) Publish(ctx context.Context, event domain.Event) {
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	b.mu.RLock()
+	handlers := b.handlers
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		if err := handler.HandleEvent(ctx, event); err != nil {
+			slog.ErrorContext(
+				ctx,
+				"Failed to handle event",
+				slog.String("event_id", event.ID.String()),
+				slog.String("event_type", string(event.Type)),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBus_Publish(t *testing.T) {
+	bus := NewBus()
+
+	var first, second []domain.Event
+
+	bus.Subscribe(HandlerFunc(func(_ context.Context, event domain.Event) error {
+		first = append(first, event)
+
+		return errors.New("handler failed")
+	}))
+	bus.Subscribe(HandlerFunc(func(_ context.Context, event domain.Event) error {
+		second = append(second, event)
+
+		return nil
+	}))
+
+	bus.Publish(context.Background(), ServerCreated(&domain.Server{ID: 7, UUID: uuid.New(), Name: "Test"}))
+
+	require.Len(t, first, 1)
+	require.Len(t, second, 1, "an error of a handler must not stop the delivery to other handlers")
+
+	event := second[0]
+	assert.Equal(t, first[0], event)
+	assert.Equal(t, domain.EventTypeServerCreated, event.Type)
+	assert.NotEqual(t, uuid.Nil, event.ID)
+	assert.WithinDuration(t, time.Now(), event.OccurredAt, time.Minute)
+	assert.Equal(t, lo.ToPtr(uint(7)), event.ServerID)
+}
+
+func TestServerStatusChanged(t *testing.T) {
+	online := &domain.Server{ID: 1, ProcessActive: true, LastProcessCheck: lo.ToPtr(time.Now())}
+	offline := &domain.Server{ID: 1}
+
+	event, ok := ServerStatusChanged(online, false)
+	require.True(t, ok)
+	assert.Equal(t, domain.EventTypeServerOnline, event.Type)
+
+	event, ok = ServerStatusChanged(offline, true)
+	require.True(t, ok)
+	assert.Equal(t, domain.EventTypeServerOffline, event.Type)
+
+	_, ok = ServerStatusChanged(online, true)
+	assert.False(t, ok)
+
+	_, ok = ServerStatusChanged(offline, false)
+	assert.False(t, ok)
+}
+
+func TestServerUpdateFinished(t *testing.T) {
+	task := &domain.DaemonTask{
+		ID:                3,
+		DedicatedServerID: 1,
+		ServerID:          lo.ToPtr(uint(5)),
+		Task:              domain.DaemonTaskTypeServerUpdate,
+		Status:            domain.DaemonTaskStatusSuccess,
+	}
+
+	event, ok := ServerUpdateFinished(task)
+	require.True(t, ok)
+	assert.Equal(t, domain.EventTypeServerUpdateFinished, event.Type)
+	assert.Equal(t, lo.ToPtr(uint(5)), event.ServerID)
+
+	task.Status = domain.DaemonTaskStatusError
+	event, ok = ServerUpdateFinished(task)
+	require.True(t, ok)
+	assert.Equal(t, domain.EventTypeServerUpdateFailed, event.Type)
+
+	task.Status = domain.DaemonTaskStatusWorking
+	_, ok = ServerUpdateFinished(task)
+	assert.False(t, ok)
+
+	task.Status = domain.DaemonTaskStatusSuccess
+	task.Task = domain.DaemonTaskTypeServerStart
+	_, ok = ServerUpdateFinished(task)
+	assert.False(t, ok)
+}
@@ -0,0 +1,199 @@
+package events
+
+import (
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/samber/lo"
+)
+
+// Server is the server data included in the event payloads.
+type Server struct {
+	ID     uint   `json:"id"`
+	UUID   string `json:"uuid"`
+	Name   string `json:"name"`
+	GameID string `json:"game_id"`
+	NodeID uint   `json:"node_id"`
+}
+
+func newServer(server *domain.Server) Server {
+	return Server{
+		ID:     server.ID,
+		UUID:   server.UUID.String(),
+		Name:   server.Name,
+		GameID: server.GameID,
+		NodeID: server.DSID,
+	}
+}
+
+// DaemonTask is the daemon task data included in the event payloads.
+type DaemonTask struct {
+	ID       uint   `json:"id"`
+	NodeID   uint   `json:"node_id"`
+	ServerID *uint  `json:"server_id"`
+	Task     string `json:"task"`
+	Status   string `json:"status"`
+}
+
+func newDaemonTask(task *domain.DaemonTask) DaemonTask {
+	return DaemonTask{
+		ID:       task.ID,
+		NodeID:   task.DedicatedServerID,
+		ServerID: task.ServerID,
+		Task:     string(task.Task),
+		Status:   string(task.Status),
+	}
+}
+
+// ServerTask is the scheduled server task data included in the event payloads.
+type ServerTask struct {
+	ID       uint   `json:"id"`
+	ServerID uint   `json:"server_id"`
+	Command  string `json:"command"`
+}
+
+type ServerData struct {
+	Server Server `json:"server"`
+}
+
+type DaemonTaskStatusChangedData struct {
+	Task           DaemonTask `json:"task"`
+	PreviousStatus string     `json:"previous_status"`
+}
+
+type ServerTaskFailedData struct {
+	Task   ServerTask `json:"task"`
+	Output string     `json:"output"`
+}
+
+type DaemonTaskData struct {
+	Task DaemonTask `json:"task"`
+}
+
+type BackupFailedData struct {
+	Server   Server `json:"server"`
+	BackupID uint   `json:"backup_id"`
+	Error    string `json:"error"`
+}
+
+type ServerCrashLoopData struct {
+	Server     Server `json:"server"`
+	Attempts   int    `json:"attempts"`
+	CrashLoops int    `json:"crash_loops"`
+}
+
+func ServerCreated(server *domain.Server) domain.Event {
+	return serverEvent(domain.EventTypeServerCreated, server)
+}
+
+func ServerDeleted(server *domain.Server) domain.Event {
+	return serverEvent(domain.EventTypeServerDeleted, server)
+}
+
+func ServerOnline(server *domain.Server) domain.Event {
+	return serverEvent(domain.EventTypeServerOnline, server)
+}
+
+func ServerOffline(server *domain.Server) domain.Event {
+	return serverEvent(domain.EventTypeServerOffline, server)
+}
+
+// ServerStatusChanged returns the online or offline event if the server status changed.
+func ServerStatusChanged(server *domain.Server, wasOnline bool) (domain.Event, bool) {
+	online := server.IsOnline()
+
+	switch {
+	case online && !wasOnline:
+		return ServerOnline(server), true
+	case !online && wasOnline:
+		return ServerOffline(server), true
+	default:
+		return domain.Event{}, false
+	}
+}
+
+func DaemonTaskStatusChanged(task *domain.DaemonTask, previous domain.DaemonTaskStatus) domain.Event {
+	return domain.Event{
+		Type:     domain.EventTypeDaemonTaskStatusChanged,
+		ServerID: task.ServerID,
+		Data: DaemonTaskStatusChangedData{
+			Task:           newDaemonTask(task),
+			PreviousStatus: string(previous),
+		},
+	}
+}
+
+// ServerUpdateFinished returns the update finished or failed event if the task is a finished server update.
+func ServerUpdateFinished(task *domain.DaemonTask) (domain.Event, bool) {
+	if task.Task != domain.DaemonTaskTypeServerUpdate || task.ServerID == nil {
+		return domain.Event{}, false
+	}
+
+	var eventType domain.EventType
+
+	switch task.Status {
+	case domain.DaemonTaskStatusSuccess:
+		eventType = domain.EventTypeServerUpdateFinished
+	case domain.DaemonTaskStatusError:
+		eventType = domain.EventTypeServerUpdateFailed
+	default:
+		return domain.Event{}, false
+	}
+
+	return domain.Event{
+		Type:     eventType,
+		ServerID: task.ServerID,
+		Data: DaemonTaskData{
+			Task: newDaemonTask(task),
+		},
+	}, true
+}
+
+func ServerTaskFailed(task *domain.ServerTask, output string) domain.Event {
+	return domain.Event{
+		Type:     domain.EventTypeServerTaskFailed,
+		ServerID: lo.ToPtr(task.ServerID),
+		Data: ServerTaskFailedData{
+			Task: ServerTask{
+				ID:       task.ID,
+				ServerID: task.ServerID,
+				Command:  string(task.Command),
+			},
+			Output: output,
+		},
+	}
+}
+
+func ServerCrashed(server *domain.Server) domain.Event {
+	return serverEvent(domain.EventTypeServerCrashed, server)
+}
+
+func BackupFailed(server *domain.Server, backup *domain.Backup, err error) domain.Event {
+	return domain.Event{
+		Type:     domain.EventTypeBackupFailed,
+		ServerID: lo.ToPtr(server.ID),
+		Data: BackupFailedData{
+			Server:   newServer(server),
+			BackupID: backup.ID,
+			Error:    err.Error(),
+		},
+	}
+}
+
+func ServerCrashLoop(server *domain.Server, restart *domain.ServerAutoRestart) domain.Event {
+	return domain.Event{
+		Type:     domain.EventTypeServerCrashLoop,
+		ServerID: lo.ToPtr(server.ID),
+		Data: ServerCrashLoopData{
+			Server:     newServer(server),
+			Attempts:   restart.Attempts,
+			CrashLoops: restart.CrashLoops,
+		},
+	}
+}
+
+func serverEvent(eventType domain.EventType, server *domain.Server) domain.Event {
+	return domain.Event{
+		Type:     eventType,
+		ServerID: lo.ToPtr(server.ID),
+		Data:     ServerData{Server: newServer(server)},
+	}
+}
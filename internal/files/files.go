@@ -1,10 +1,17 @@
 package files
 
-import "context"
+import (
+	"context"
+	"io"
+)
 
 type FileManager interface {
 	Read(ctx context.Context, path string) ([]byte, error)
 	Write(ctx context.Context, path string, data []byte) error
+	// ReadStream opens the file for reading. The caller must close the returned reader.
+	ReadStream(ctx context.Context, path string) (io.ReadCloser, error)
+	// WriteStream writes the file from r without buffering the whole content in memory.
+	WriteStream(ctx context.Context, path string, r io.Reader) error
 	Delete(ctx context.Context, path string) error
 	Exists(ctx context.Context, path string) bool
 	List(ctx context.Context, dir string) ([]string, error)
@@ -1,8 +1,10 @@
 package files
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"strings"
 	"sync"
 )
@@ -41,6 +43,24 @@ func (fm *InMemoryFileManager) Write(_ context.Context, path string, data []byte
 	return nil
 }
 
+func (fm *InMemoryFileManager) ReadStream(ctx context.Context, path string) (io.ReadCloser, error) {
+	data, err := fm.Read(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (fm *InMemoryFileManager) WriteStream(ctx context.Context, path string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("failed to read data: %w", err)
+	}
+
+	return fm.Write(ctx, path, data)
+}
+
 func (fm *InMemoryFileManager) Delete(_ context.Context, path string) error {
 	fm.mu.Lock()
 	defer fm.mu.Unlock()
@@ -2,6 +2,8 @@ package files
 
 import (
 	"context"
+	"io"
+	"strings"
 	"sync"
 	"testing"
 
@@ -104,6 +106,26 @@ func TestInMemoryFileManager_Write(t *testing.T) {
 	}
 }
 
+func TestInMemoryFileManager_Streams(t *testing.T) {
+	ctx := context.Background()
+	fm := NewInMemoryFileManager()
+
+	err := fm.WriteStream(ctx, "archive.tar.gz", strings.NewReader("archive content"))
+	require.NoError(t, err)
+
+	r, err := fm.ReadStream(ctx, "archive.tar.gz")
+	require.NoError(t, err)
+
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, "archive content", string(data))
+
+	_, err = fm.ReadStream(ctx, "nonexistent.txt")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "file not found")
+}
+
 func TestInMemoryFileManager_Delete(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -3,6 +3,7 @@ package files
 import (
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
@@ -55,6 +56,43 @@ func (fm *LocalFileManager) Write(ctx context.Context, path string, data []byte)
 	return nil
 }
 
+func (fm *LocalFileManager) ReadStream(_ context.Context, path string) (io.ReadCloser, error) {
+	f, err := fm.root.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open file")
+	}
+
+	return f, nil
+}
+
+func (fm *LocalFileManager) WriteStream(ctx context.Context, path string, r io.Reader) error {
+	if !fm.Exists(ctx, path) {
+		err := fm.mkdirAll(filepath.Dir(path))
+		if err != nil {
+			return errors.Wrapf(err, "failed to create directories: %s", filepath.Dir(path))
+		}
+	}
+
+	f, err := fm.root.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, defaultLocalFilePerm)
+	if err != nil {
+		return errors.Wrap(err, "failed to create file")
+	}
+
+	_, err = io.Copy(f, r)
+	if err != nil {
+		_ = f.Close()
+
+		return errors.Wrap(err, "failed to write file")
+	}
+
+	err = f.Close()
+	if err != nil {
+		return errors.Wrap(err, "failed to close file")
+	}
+
+	return nil
+}
+
 func (fm *LocalFileManager) mkdirAll(path string) error {
 	if path == "" || path == "." {
 		return nil
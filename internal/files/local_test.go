@@ -2,8 +2,10 @@ package files
 
 import (
 	"context"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -136,6 +138,54 @@ func TestLocalFileManager_Write(t *testing.T) {
 	}
 }
 
+func TestLocalFileManager_Streams(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("write_and_read_stream", func(t *testing.T) {
+		tempDir := t.TempDir()
+		fm := NewLocalFileManager(tempDir)
+
+		err := fm.WriteStream(ctx, "nested/dir/archive.tar.gz", strings.NewReader("archive content"))
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(filepath.Join(tempDir, "nested", "dir", "archive.tar.gz"))
+		require.NoError(t, err)
+		assert.Equal(t, "archive content", string(data))
+
+		r, err := fm.ReadStream(ctx, "nested/dir/archive.tar.gz")
+		require.NoError(t, err)
+		defer func() {
+			_ = r.Close()
+		}()
+
+		data, err = io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, "archive content", string(data))
+	})
+
+	t.Run("write_stream_truncates_existing_file", func(t *testing.T) {
+		tempDir := t.TempDir()
+		fm := NewLocalFileManager(tempDir)
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, "file.txt"), []byte("long old content"), 0644))
+
+		err := fm.WriteStream(ctx, "file.txt", strings.NewReader("new"))
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(filepath.Join(tempDir, "file.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "new", string(data))
+	})
+
+	t.Run("read_stream_non_existent_file", func(t *testing.T) {
+		fm := NewLocalFileManager(t.TempDir())
+
+		_, err := fm.ReadStream(ctx, "nonexistent.txt")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to open file")
+	})
+}
+
 func TestLocalFileManager_Delete(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -1,13 +1,18 @@
 package files
 
-import "context"
+import (
+	"context"
+	"io"
+)
 
 type MockFileManager struct {
-	ReadFunc   func(ctx context.Context, path string) ([]byte, error)
-	WriteFunc  func(ctx context.Context, path string, data []byte) error
-	DeleteFunc func(ctx context.Context, path string) error
-	ExistsFunc func(ctx context.Context, path string) bool
-	ListFunc   func(ctx context.Context, dir string) ([]string, error)
+	ReadFunc        func(ctx context.Context, path string) ([]byte, error)
+	WriteFunc       func(ctx context.Context, path string, data []byte) error
+	ReadStreamFunc  func(ctx context.Context, path string) (io.ReadCloser, error)
+	WriteStreamFunc func(ctx context.Context, path string, r io.Reader) error
+	DeleteFunc      func(ctx context.Context, path string) error
+	ExistsFunc      func(ctx context.Context, path string) bool
+	ListFunc        func(ctx context.Context, dir string) ([]string, error)
 }
 
 func (m *MockFileManager) Read(ctx context.Context, path string) ([]byte, error) {
@@ -26,6 +31,22 @@ func (m *MockFileManager) Write(ctx context.Context, path string, data []byte) e
 	return nil
 }
 
+func (m *MockFileManager) ReadStream(ctx context.Context, path string) (io.ReadCloser, error) {
+	if m.ReadStreamFunc != nil {
+		return m.ReadStreamFunc(ctx, path)
+	}
+
+	return nil, nil
+}
+
+func (m *MockFileManager) WriteStream(ctx context.Context, path string, r io.Reader) error {
+	if m.WriteStreamFunc != nil {
+		return m.WriteStreamFunc(ctx, path, r)
+	}
+
+	return nil
+}
+
 func (m *MockFileManager) Delete(ctx context.Context, path string) error {
 	if m.DeleteFunc != nil {
 		return m.DeleteFunc(ctx, path)
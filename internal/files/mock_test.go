@@ -3,6 +3,8 @@ package files
 import (
 	"context"
 	"errors"
+	"io"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -76,6 +78,48 @@ func TestMockFileManager_Write(t *testing.T) {
 	})
 }
 
+func TestMockFileManager_Streams(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("calls_custom_funcs_when_set", func(t *testing.T) {
+		var written string
+		mock := &MockFileManager{
+			ReadStreamFunc: func(_ context.Context, path string) (io.ReadCloser, error) {
+				assert.Equal(t, "read.txt", path)
+
+				return io.NopCloser(strings.NewReader("mock data")), nil
+			},
+			WriteStreamFunc: func(_ context.Context, path string, r io.Reader) error {
+				assert.Equal(t, "write.txt", path)
+
+				data, err := io.ReadAll(r)
+				written = string(data)
+
+				return err
+			},
+		}
+
+		r, err := mock.ReadStream(ctx, "read.txt")
+		require.NoError(t, err)
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, "mock data", string(data))
+
+		require.NoError(t, mock.WriteStream(ctx, "write.txt", strings.NewReader("stream data")))
+		assert.Equal(t, "stream data", written)
+	})
+
+	t.Run("returns_nil_when_funcs_not_set", func(t *testing.T) {
+		mock := &MockFileManager{}
+
+		r, err := mock.ReadStream(ctx, "test.txt")
+		assert.Nil(t, r)
+		require.NoError(t, err)
+
+		assert.NoError(t, mock.WriteStream(ctx, "test.txt", strings.NewReader("data")))
+	})
+}
+
 func TestMockFileManager_Delete(t *testing.T) {
 	ctx := context.Background()
 
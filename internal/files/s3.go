@@ -66,6 +66,35 @@ func (fm *S3FileManager) Write(ctx context.Context, path string, data []byte) er
 	return nil
 }
 
+func (fm *S3FileManager) ReadStream(ctx context.Context, path string) (io.ReadCloser, error) {
+	object, err := fm.client.GetObject(ctx, fm.bucket, path, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get object")
+	}
+
+	// GetObject is lazy, the request is sent on the first read
+	_, err = object.Stat()
+	if err != nil {
+		_ = object.Close()
+
+		return nil, errors.Wrap(err, "failed to stat object")
+	}
+
+	return object, nil
+}
+
+func (fm *S3FileManager) WriteStream(ctx context.Context, path string, r io.Reader) error {
+	// Unknown size, the object is uploaded in parts
+	_, err := fm.client.PutObject(ctx, fm.bucket, path, r, -1, minio.PutObjectOptions{
+		ContentType: "application/octet-stream",
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to put object")
+	}
+
+	return nil
+}
+
 func (fm *S3FileManager) Delete(ctx context.Context, path string) error {
 	err := fm.client.RemoveObject(ctx, fm.bucket, path, minio.RemoveObjectOptions{})
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/url"
 	"os"
 	"strings"
@@ -157,6 +158,36 @@ func TestS3FileManager_Write(t *testing.T) {
 	})
 }
 
+func TestS3FileManager_Streams(t *testing.T) {
+	fm, prefix, cleanup := setupS3Test(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	t.Run("write_and_read_stream", func(t *testing.T) {
+		path := prefix + "stream.tar.gz"
+
+		err := fm.WriteStream(ctx, path, strings.NewReader("stream content"))
+		require.NoError(t, err)
+
+		r, err := fm.ReadStream(ctx, path)
+		require.NoError(t, err)
+		defer func() {
+			_ = r.Close()
+		}()
+
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, "stream content", string(data))
+	})
+
+	t.Run("read_stream_non_existent_file", func(t *testing.T) {
+		_, err := fm.ReadStream(ctx, prefix+"nonexistent.txt")
+
+		require.Error(t, err)
+	})
+}
+
 func TestS3FileManager_Delete(t *testing.T) {
 	fm, prefix, cleanup := setupS3Test(t)
 	defer cleanup()
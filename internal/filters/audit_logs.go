@@ -0,0 +1,22 @@
+package filters
+
+import (
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+)
+
+type FindAuditLog struct {
+	IDs         []uint
+	UserIDs     []uint
+	Actions     []string
+	EntityTypes []domain.EntityType
+	EntityIDs   []string
+	Results     []domain.AuditResult
+	// CreatedFrom matches the records created at or after the time.
+	CreatedFrom *time.Time
+	// CreatedTo matches the records created before the time.
+	CreatedTo *time.Time
+	// BeforeID matches the records with lower IDs, it's used to page through the records being added.
+	BeforeID *uint
+}
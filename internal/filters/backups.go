@@ -0,0 +1,15 @@
+package filters
+
+import "github.com/gameap/gameap/internal/domain"
+
+type FindBackup struct {
+	IDs       []uint
+	ServerIDs []uint
+	Statuses  []domain.BackupStatus
+}
+
+func FindBackupByServerIDs(serverIDs ...uint) *FindBackup {
+	return &FindBackup{
+		ServerIDs: serverIDs,
+	}
+}
@@ -0,0 +1,16 @@
+package filters
+
+import (
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+)
+
+type FindMetric struct {
+	SubjectType domain.MetricSubject
+	SubjectIDs  []uint
+	Resolutions []domain.MetricResolution
+	// RecordedFrom is inclusive, RecordedBefore is exclusive.
+	RecordedFrom   *time.Time
+	RecordedBefore *time.Time
+}
@@ -0,0 +1,7 @@
+package filters
+
+type FindNotificationChannel struct {
+	IDs     []uint
+	UserIDs []uint
+	Enabled *bool
+}
@@ -0,0 +1,5 @@
+package filters
+
+type FindPasswordReset struct {
+	Emails []string
+}
@@ -0,0 +1,6 @@
+package filters
+
+type FindServerAutoRestart struct {
+	ServerIDs []uint
+	CrashLoop *bool
+}
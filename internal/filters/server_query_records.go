@@ -0,0 +1,10 @@
+package filters
+
+import "time"
+
+type FindServerQueryRecord struct {
+	ServerIDs []uint
+	// QueriedFrom is inclusive, QueriedBefore is exclusive.
+	QueriedFrom   *time.Time
+	QueriedBefore *time.Time
+}
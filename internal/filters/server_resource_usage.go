@@ -0,0 +1,5 @@
+package filters
+
+type FindServerResourceUsage struct {
+	ServerIDs []uint
+}
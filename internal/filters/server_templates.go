@@ -0,0 +1,6 @@
+package filters
+
+type FindServerTemplate struct {
+	IDs     []uint
+	GameIDs []string
+}
@@ -1,6 +1,10 @@
 package filters
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type FindServer struct {
 	IDs        []uint
@@ -13,6 +17,9 @@ type FindServer struct {
 	GameModIDs []uint
 	Names      []string
 
+	// ExpiresBefore matches servers with a non-empty expiration date earlier than the given time.
+	ExpiresBefore *time.Time
+
 	WithDeleted bool
 }
 
@@ -0,0 +1,7 @@
+package filters
+
+type FindUserIdentity struct {
+	UserIDs   []uint
+	Providers []string
+	Subjects  []string
+}
@@ -0,0 +1,5 @@
+package filters
+
+type FindUserTwoFactor struct {
+	UserIDs []uint
+}
@@ -0,0 +1,21 @@
+package filters
+
+import (
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+)
+
+type FindWebhook struct {
+	IDs     []uint
+	UserIDs []uint
+	Enabled *bool
+}
+
+type FindWebhookDelivery struct {
+	IDs        []uint
+	WebhookIDs []uint
+	Statuses   []domain.WebhookDeliveryStatus
+	// NextAttemptBefore matches deliveries with the next attempt at or before the time.
+	NextAttemptBefore *time.Time
+}
@@ -0,0 +1,136 @@
+package i18n
+
+import (
+	"encoding/json"
+	"io/fs"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultLanguage is used when a message is missing in the requested language.
+const DefaultLanguage = "en"
+
+// Translator translates messages from the embedded language bundles.
+// Message keys are dot separated paths in the bundles, for example "notifications.server_crashed".
+// Placeholders in messages are prefixed with a colon, for example ":server".
+type Translator struct {
+	messages map[string]map[string]string
+}
+
+func NewTranslator() (*Translator, error) {
+	entries, err := fs.ReadDir(embedFS, ".")
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to read language bundles")
+	}
+
+	t := &Translator{
+		messages: make(map[string]map[string]string, len(entries)),
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		content, err := fs.ReadFile(embedFS, entry.Name())
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to read language bundle %s", entry.Name())
+		}
+
+		var bundle map[string]any
+		if err = json.Unmarshal(content, &bundle); err != nil {
+			return nil, errors.WithMessagef(err, "failed to parse language bundle %s", entry.Name())
+		}
+
+		messages := make(map[string]string)
+		flatten(messages, "", bundle)
+
+		t.messages[strings.TrimSuffix(entry.Name(), ".json")] = messages
+	}
+
+	return t, nil
+}
+
+// Languages returns the sorted list of available languages.
+func (t *Translator) Languages() []string {
+	languages := make([]string, 0, len(t.messages))
+	for lang := range t.messages {
+		languages = append(languages, lang)
+	}
+
+	slices.Sort(languages)
+
+	return languages
+}
+
+// HasLanguage reports whether the language bundle exists.
+func (t *Translator) HasLanguage(lang string) bool {
+	_, ok := t.messages[lang]
+
+	return ok
+}
+
+// MatchLanguage returns the first available language of the Accept-Language header value,
+// or the default language when none of them is available.
+// The languages are expected to be sorted by preference, quality values are ignored.
+func (t *Translator) MatchLanguage(acceptLanguage string) string {
+	for tag := range strings.SplitSeq(acceptLanguage, ",") {
+		tag, _, _ = strings.Cut(tag, ";")
+		tag, _, _ = strings.Cut(strings.TrimSpace(tag), "-")
+		tag = strings.ToLower(tag)
+
+		if t.HasLanguage(tag) {
+			return tag
+		}
+	}
+
+	return DefaultLanguage
+}
+
+// Translate returns the message in the given language with the placeholders replaced by params.
+// It falls back to the default language, and to the key itself when the message doesn't exist.
+func (t *Translator) Translate(lang, key string, params map[string]string) string {
+	message, ok := t.messages[lang][key]
+	if !ok {
+		message, ok = t.messages[DefaultLanguage][key]
+	}
+	if !ok {
+		return key
+	}
+
+	if len(params) == 0 {
+		return message
+	}
+
+	// Longer names are replaced first, so ":server_name" isn't broken by ":server"
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		return len(names[i]) > len(names[j])
+	})
+
+	pairs := make([]string, 0, len(names)*2)
+	for _, name := range names {
+		pairs = append(pairs, ":"+name, params[name])
+	}
+
+	return strings.NewReplacer(pairs...).Replace(message)
+}
+
+func flatten(messages map[string]string, prefix string, values map[string]any) {
+	for key, value := range values {
+		switch v := value.(type) {
+		case string:
+			messages[prefix+key] = v
+		case map[string]any:
+			flatten(messages, prefix+key+".", v)
+		}
+	}
+}
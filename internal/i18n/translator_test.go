@@ -0,0 +1,113 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslator_Translate(t *testing.T) {
+	translator, err := NewTranslator()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		lang   string
+		key    string
+		params map[string]string
+		want   string
+	}{
+		{
+			name:   "english_with_params",
+			lang:   "en",
+			key:    "notifications.server_crashed",
+			params: map[string]string{"server": "Public CS"},
+			want:   `Server "Public CS" has crashed.`,
+		},
+		{
+			name:   "russian_with_params",
+			lang:   "ru",
+			key:    "notifications.server_crashed",
+			params: map[string]string{"server": "Public CS"},
+			want:   `Сервер "Public CS" упал.`,
+		},
+		{
+			name:   "longer_param_is_replaced_first",
+			lang:   "en",
+			key:    "auth.throttle",
+			params: map[string]string{"second": "x", "seconds": "30"},
+			want:   "Too many login attempts. Please try again in 30 seconds.",
+		},
+		{
+			name: "unknown_language_falls_back_to_default",
+			lang: "de",
+			key:  "notifications.test",
+			want: "Test notification from GameAP.",
+		},
+		{
+			name: "unknown_key",
+			lang: "en",
+			key:  "notifications.unknown",
+			want: "notifications.unknown",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, translator.Translate(test.lang, test.key, test.params))
+		})
+	}
+}
+
+func TestTranslator_Languages(t *testing.T) {
+	translator, err := NewTranslator()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"en", "ru"}, translator.Languages())
+	assert.True(t, translator.HasLanguage("ru"))
+	assert.False(t, translator.HasLanguage("de"))
+}
+
+func TestTranslator_MatchLanguage(t *testing.T) {
+	translator, err := NewTranslator()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		want           string
+	}{
+		{
+			name:           "exact",
+			acceptLanguage: "ru",
+			want:           "ru",
+		},
+		{
+			name:           "region_and_quality",
+			acceptLanguage: "ru-RU,ru;q=0.9,en-US;q=0.8",
+			want:           "ru",
+		},
+		{
+			name:           "first_available",
+			acceptLanguage: "de-DE, en;q=0.5",
+			want:           "en",
+		},
+		{
+			name:           "unavailable",
+			acceptLanguage: "de, fr",
+			want:           DefaultLanguage,
+		},
+		{
+			name:           "empty",
+			acceptLanguage: "",
+			want:           DefaultLanguage,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, translator.MatchLanguage(test.acceptLanguage))
+		})
+	}
+}
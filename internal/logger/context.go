@@ -0,0 +1,81 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+)
+
+type contextKey int
+
+const (
+	attrsContextKey contextKey = iota
+	requestIDContextKey
+)
+
+// WithAttrs returns the context with the attributes added to the log records made with it.
+// The attributes replace the ones of the parent context with the same keys.
+func WithAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	if len(attrs) == 0 {
+		return ctx
+	}
+
+	// The attributes of the parent context are copied, so the sibling contexts don't share them
+	parent := slices.DeleteFunc(slices.Clone(attrsFromContext(ctx)), func(parentAttr slog.Attr) bool {
+		return slices.ContainsFunc(attrs, func(attr slog.Attr) bool {
+			return attr.Key == parentAttr.Key
+		})
+	})
+
+	return context.WithValue(ctx, attrsContextKey, slices.Concat(parent, attrs))
+}
+
+// WithRequestID returns the context of the request with the ID, the ID is added to the log records too.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	ctx = context.WithValue(ctx, requestIDContextKey, id)
+
+	return WithAttrs(ctx, slog.String("request_id", id))
+}
+
+// RequestIDFromContext returns the ID of the request, empty if the context isn't a request one.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey).(string)
+
+	return id
+}
+
+func attrsFromContext(ctx context.Context) []slog.Attr {
+	attrs, _ := ctx.Value(attrsContextKey).([]slog.Attr)
+
+	return attrs
+}
+
+// ContextHandler adds the attributes of the context to the records.
+type ContextHandler struct {
+	slog.Handler
+}
+
+func NewContextHandler(handler slog.Handler) *ContextHandler {
+	return &ContextHandler{
+		Handler: handler,
+	}
+}
+
+func (h *ContextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if ctx != nil {
+		if attrs := attrsFromContext(ctx); len(attrs) > 0 {
+			record = record.Clone()
+			record.AddAttrs(attrs...)
+		}
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return NewContextHandler(h.Handler.WithAttrs(attrs))
+}
+
+func (h *ContextHandler) WithGroup(name string) slog.Handler {
+	return NewContextHandler(h.Handler.WithGroup(name))
+}
@@ -0,0 +1,35 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithAttrs(t *testing.T) {
+	parent := WithAttrs(context.Background(), slog.String("request_id", "req-1"), slog.String("node_id", "1"))
+
+	child := WithAttrs(parent, slog.Uint64("node_id", 2))
+	sibling := WithAttrs(parent, slog.Uint64("user_id", 3))
+
+	assert.Equal(t, []slog.Attr{
+		slog.String("request_id", "req-1"),
+		slog.String("node_id", "1"),
+	}, attrsFromContext(parent))
+	assert.Equal(t, []slog.Attr{
+		slog.String("request_id", "req-1"),
+		slog.Uint64("node_id", 2),
+	}, attrsFromContext(child))
+	assert.Equal(t, []slog.Attr{
+		slog.String("request_id", "req-1"),
+		slog.String("node_id", "1"),
+		slog.Uint64("user_id", 3),
+	}, attrsFromContext(sibling))
+}
+
+func TestRequestIDFromContext(t *testing.T) {
+	assert.Empty(t, RequestIDFromContext(context.Background()))
+	assert.Equal(t, "req-1", RequestIDFromContext(WithRequestID(context.Background(), "req-1")))
+}
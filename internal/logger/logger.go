@@ -0,0 +1,101 @@
+// Package logger configures the slog logger of the panel.
+//
+// The records are enriched with the attributes of the context, like the request ID and the user ID,
+// so the handlers and the services log them without passing them around.
+package logger
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+var ErrUnknownFormat = errors.New("unknown log format")
+
+type Config struct {
+	Level  string
+	Format string
+
+	// File is the path of the log file, the records are written to stderr only if it's empty.
+	File string
+	// MaxSize is the size of the log file in megabytes after which it's rotated.
+	MaxSize int
+	// MaxBackups is the number of the rotated files kept, zero keeps all of them.
+	MaxBackups int
+	// MaxAge is the number of days the rotated files are kept, zero keeps them regardless of the age.
+	MaxAge int
+	// Compress compresses the rotated files with gzip.
+	Compress bool
+}
+
+// New creates the logger writing to stderr and to the log file if it's configured.
+// The returned closer closes the log file.
+func New(cfg Config) (*slog.Logger, io.Closer, error) {
+	var w io.Writer = os.Stderr
+	var closer io.Closer = nopCloser{}
+
+	if cfg.File != "" {
+		file := &lumberjack.Logger{
+			Filename:   cfg.File,
+			MaxSize:    cfg.MaxSize,
+			MaxBackups: cfg.MaxBackups,
+			MaxAge:     cfg.MaxAge,
+			Compress:   cfg.Compress,
+		}
+
+		w = io.MultiWriter(os.Stderr, file)
+		closer = file
+	}
+
+	handler, err := NewHandler(w, cfg.Format, ParseLevel(cfg.Level))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return slog.New(handler), closer, nil
+}
+
+// NewHandler creates the handler of the format adding the attributes of the context to the records.
+func NewHandler(w io.Writer, format string, level slog.Level) (slog.Handler, error) {
+	options := &slog.HandlerOptions{
+		Level: level,
+	}
+
+	switch strings.ToLower(format) {
+	case FormatText, "":
+		return NewContextHandler(slog.NewTextHandler(w, options)), nil
+	case FormatJSON:
+		return NewContextHandler(slog.NewJSONHandler(w, options)), nil
+	default:
+		return nil, errors.WithMessagef(ErrUnknownFormat, "format %q", format)
+	}
+}
+
+// ParseLevel returns the level by the name, the unknown levels are the info one.
+func ParseLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error {
+	return nil
+}
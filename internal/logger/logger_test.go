@@ -0,0 +1,92 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHandler_JSON(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	handler, err := NewHandler(buf, FormatJSON, slog.LevelInfo)
+	require.NoError(t, err)
+
+	ctx := WithRequestID(context.Background(), "req-1")
+	ctx = WithAttrs(ctx, slog.Uint64("user_id", 5))
+
+	log := slog.New(handler)
+	log.InfoContext(ctx, "Server started", slog.Int("server_id", 7))
+	log.DebugContext(ctx, "Hidden by the level")
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+
+	assert.Equal(t, "Server started", record["msg"])
+	assert.Equal(t, "INFO", record["level"])
+	assert.Equal(t, "req-1", record["request_id"])
+	assert.InDelta(t, 5, record["user_id"], 0)
+	assert.InDelta(t, 7, record["server_id"], 0)
+}
+
+func TestNewHandler_Text(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	handler, err := NewHandler(buf, FormatText, slog.LevelDebug)
+	require.NoError(t, err)
+
+	slog.New(handler).DebugContext(WithRequestID(context.Background(), "req-1"), "Debug message")
+
+	assert.Contains(t, buf.String(), `msg="Debug message" request_id=req-1`)
+}
+
+func TestNewHandler_UnknownFormat(t *testing.T) {
+	_, err := NewHandler(&bytes.Buffer{}, "xml", slog.LevelInfo)
+
+	require.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestNew_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "gameap.log")
+
+	log, closer, err := New(Config{Level: "warn", Format: FormatJSON, File: path, MaxSize: 1})
+	require.NoError(t, err)
+
+	log.Info("Skipped message")
+	log.Warn("Written message")
+
+	require.NoError(t, closer.Close())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "Skipped message")
+	assert.Contains(t, string(content), `"msg":"Written message"`)
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		level string
+		want  slog.Level
+	}{
+		{level: "debug", want: slog.LevelDebug},
+		{level: "info", want: slog.LevelInfo},
+		{level: "WARN", want: slog.LevelWarn},
+		{level: "warning", want: slog.LevelWarn},
+		{level: "error", want: slog.LevelError},
+		{level: "", want: slog.LevelInfo},
+		{level: "unknown", want: slog.LevelInfo},
+	}
+
+	for _, test := range tests {
+		t.Run(test.level, func(t *testing.T) {
+			assert.Equal(t, test.want, ParseLevel(test.level))
+		})
+	}
+}
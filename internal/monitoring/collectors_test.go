@@ -0,0 +1,115 @@
+package monitoring
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/jackc/puddle/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDaemonTaskCollector(t *testing.T) {
+	repo := inmemory.NewDaemonTaskRepository()
+
+	for _, status := range []domain.DaemonTaskStatus{
+		domain.DaemonTaskStatusWaiting,
+		domain.DaemonTaskStatusWaiting,
+		domain.DaemonTaskStatusSuccess,
+	} {
+		require.NoError(t, repo.Save(context.Background(), &domain.DaemonTask{
+			DedicatedServerID: 1,
+			Task:              domain.DaemonTaskTypeServerStart,
+			Status:            status,
+		}))
+	}
+
+	err := testutil.CollectAndCompare(NewDaemonTaskCollector(repo, time.Second), strings.NewReader(`
+# HELP gameap_daemon_tasks Number of the daemon tasks by the status.
+# TYPE gameap_daemon_tasks gauge
+gameap_daemon_tasks{status="canceled"} 0
+gameap_daemon_tasks{status="error"} 0
+gameap_daemon_tasks{status="success"} 1
+gameap_daemon_tasks{status="waiting"} 2
+gameap_daemon_tasks{status="working"} 0
+`))
+	require.NoError(t, err)
+}
+
+func TestServerCollector(t *testing.T) {
+	repo := inmemory.NewServerRepository()
+	now := time.Now()
+
+	servers := []*domain.Server{
+		{DSID: 1, ProcessActive: true, LastProcessCheck: lo.ToPtr(now)},
+		{DSID: 1, ProcessActive: true, LastProcessCheck: lo.ToPtr(now)},
+		// The last check has expired
+		{DSID: 1, ProcessActive: true, LastProcessCheck: lo.ToPtr(now.Add(-time.Hour))},
+		{DSID: 2, ProcessActive: false, LastProcessCheck: lo.ToPtr(now)},
+	}
+
+	for _, server := range servers {
+		require.NoError(t, repo.Save(context.Background(), server))
+	}
+
+	err := testutil.CollectAndCompare(NewServerCollector(repo, time.Second), strings.NewReader(`
+# HELP gameap_servers_online Number of the online game servers by the node.
+# TYPE gameap_servers_online gauge
+gameap_servers_online{node_id="1"} 2
+gameap_servers_online{node_id="2"} 0
+`))
+	require.NoError(t, err)
+}
+
+type fakePoolStatsProvider struct {
+	stats map[uint]*puddle.Stat
+}
+
+func (p *fakePoolStatsProvider) PoolStats() map[uint]*puddle.Stat {
+	return p.stats
+}
+
+func TestDaemonPoolCollector(t *testing.T) {
+	pool, err := puddle.NewPool(&puddle.Config[int]{
+		Constructor: func(context.Context) (int, error) { return 1, nil },
+		Destructor:  func(int) {},
+		MaxSize:     3,
+	})
+	require.NoError(t, err)
+	defer pool.Close()
+
+	res, err := pool.Acquire(context.Background())
+	require.NoError(t, err)
+	res.Release()
+
+	collector := NewDaemonPoolCollector(map[string]PoolStatsProvider{
+		"commands": &fakePoolStatsProvider{stats: map[uint]*puddle.Stat{5: pool.Stat()}},
+		"files":    &fakePoolStatsProvider{},
+	})
+
+	err = testutil.CollectAndCompare(collector, strings.NewReader(`
+# HELP gameap_daemon_pool_acquires_total Number of the successful connection acquires from the pool.
+# TYPE gameap_daemon_pool_acquires_total counter
+gameap_daemon_pool_acquires_total{node_id="5",service="commands"} 1
+# HELP gameap_daemon_pool_idle_connections Number of the idle connections.
+# TYPE gameap_daemon_pool_idle_connections gauge
+gameap_daemon_pool_idle_connections{node_id="5",service="commands"} 1
+# HELP gameap_daemon_pool_max_connections Maximum size of the pool.
+# TYPE gameap_daemon_pool_max_connections gauge
+gameap_daemon_pool_max_connections{node_id="5",service="commands"} 3
+`,
+	),
+		"gameap_daemon_pool_acquires_total",
+		"gameap_daemon_pool_idle_connections",
+		"gameap_daemon_pool_max_connections",
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, 8, testutil.CollectAndCount(collector))
+}
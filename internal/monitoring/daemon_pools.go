@@ -0,0 +1,114 @@
+package monitoring
+
+import (
+	"strconv"
+
+	"github.com/jackc/puddle/v2"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PoolStatsProvider is a daemon service with the connection pools by the node IDs.
+type PoolStatsProvider interface {
+	PoolStats() map[uint]*puddle.Stat
+}
+
+var (
+	poolLabels = []string{"service", "node_id"}
+
+	poolAcquiresDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "daemon_pool", "acquires_total"),
+		"Number of the successful connection acquires from the pool.",
+		poolLabels, nil,
+	)
+	poolEmptyAcquiresDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "daemon_pool", "empty_acquires_total"),
+		"Number of the acquires which waited for a connection because the pool was empty.",
+		poolLabels, nil,
+	)
+	poolCanceledAcquiresDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "daemon_pool", "canceled_acquires_total"),
+		"Number of the acquires canceled by the context.",
+		poolLabels, nil,
+	)
+	poolAcquireDurationDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "daemon_pool", "acquire_duration_seconds_total"),
+		"Total duration of the successful connection acquires.",
+		poolLabels, nil,
+	)
+	poolAcquiredDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "daemon_pool", "acquired_connections"),
+		"Number of the connections in use.",
+		poolLabels, nil,
+	)
+	poolIdleDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "daemon_pool", "idle_connections"),
+		"Number of the idle connections.",
+		poolLabels, nil,
+	)
+	poolTotalDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "daemon_pool", "connections"),
+		"Number of the connections including the ones being opened.",
+		poolLabels, nil,
+	)
+	poolMaxDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "daemon_pool", "max_connections"),
+		"Maximum size of the pool.",
+		poolLabels, nil,
+	)
+)
+
+// DaemonPoolCollector collects the statistics of the daemon connection pools.
+type DaemonPoolCollector struct {
+	// providers are the daemon services by the names used as the service label.
+	providers map[string]PoolStatsProvider
+}
+
+func NewDaemonPoolCollector(providers map[string]PoolStatsProvider) *DaemonPoolCollector {
+	return &DaemonPoolCollector{
+		providers: providers,
+	}
+}
+
+func (c *DaemonPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- poolAcquiresDesc
+	ch <- poolEmptyAcquiresDesc
+	ch <- poolCanceledAcquiresDesc
+	ch <- poolAcquireDurationDesc
+	ch <- poolAcquiredDesc
+	ch <- poolIdleDesc
+	ch <- poolTotalDesc
+	ch <- poolMaxDesc
+}
+
+func (c *DaemonPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	for service, provider := range c.providers {
+		for nodeID, stat := range provider.PoolStats() {
+			labels := []string{service, strconv.FormatUint(uint64(nodeID), 10)}
+
+			ch <- prometheus.MustNewConstMetric(
+				poolAcquiresDesc, prometheus.CounterValue, float64(stat.AcquireCount()), labels...,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				poolEmptyAcquiresDesc, prometheus.CounterValue, float64(stat.EmptyAcquireCount()), labels...,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				poolCanceledAcquiresDesc, prometheus.CounterValue, float64(stat.CanceledAcquireCount()), labels...,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				poolAcquireDurationDesc, prometheus.CounterValue, stat.AcquireDuration().Seconds(), labels...,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				poolAcquiredDesc, prometheus.GaugeValue, float64(stat.AcquiredResources()), labels...,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				poolIdleDesc, prometheus.GaugeValue, float64(stat.IdleResources()), labels...,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				poolTotalDesc, prometheus.GaugeValue, float64(stat.TotalResources()), labels...,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				poolMaxDesc, prometheus.GaugeValue, float64(stat.MaxResources()), labels...,
+			)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package monitoring
+
+import (
+	"context"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var daemonTaskStatuses = []domain.DaemonTaskStatus{
+	domain.DaemonTaskStatusWaiting,
+	domain.DaemonTaskStatusWorking,
+	domain.DaemonTaskStatusError,
+	domain.DaemonTaskStatusSuccess,
+	domain.DaemonTaskStatusCanceled,
+}
+
+var daemonTasksDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "daemon_tasks"),
+	"Number of the daemon tasks by the status.",
+	[]string{"status"}, nil,
+)
+
+// DaemonTaskCollector counts the daemon tasks in the repository.
+type DaemonTaskCollector struct {
+	repo    repositories.DaemonTaskRepository
+	timeout time.Duration
+}
+
+func NewDaemonTaskCollector(repo repositories.DaemonTaskRepository, timeout time.Duration) *DaemonTaskCollector {
+	return &DaemonTaskCollector{
+		repo:    repo,
+		timeout: timeout,
+	}
+}
+
+func (c *DaemonTaskCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- daemonTasksDesc
+}
+
+func (c *DaemonTaskCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	for _, status := range daemonTaskStatuses {
+		count, err := c.repo.Count(ctx, &filters.FindDaemonTask{
+			Statuses: []domain.DaemonTaskStatus{status},
+		})
+		if err != nil {
+			ch <- prometheus.NewInvalidMetric(daemonTasksDesc, err)
+
+			return
+		}
+
+		ch <- prometheus.MustNewConstMetric(daemonTasksDesc, prometheus.GaugeValue, float64(count), string(status))
+	}
+}
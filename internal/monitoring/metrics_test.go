@@ -0,0 +1,59 @@
+package monitoring
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics_ObserveHTTPRequest(t *testing.T) {
+	m := NewMetrics()
+
+	m.ObserveHTTPRequest(http.MethodGet, "/api/servers/{id}", http.StatusOK, 20*time.Millisecond)
+	m.ObserveHTTPRequest(http.MethodGet, "/api/servers/{id}", http.StatusOK, 30*time.Millisecond)
+	m.ObserveHTTPRequest(http.MethodGet, "/api/servers/{id}", http.StatusNotFound, time.Millisecond)
+
+	err := testutil.CollectAndCompare(m.httpRequests, strings.NewReader(`
+# HELP gameap_http_requests_total Number of the HTTP requests by the route template and the status code.
+# TYPE gameap_http_requests_total counter
+gameap_http_requests_total{method="GET",route="/api/servers/{id}",status="200"} 2
+gameap_http_requests_total{method="GET",route="/api/servers/{id}",status="404"} 1
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, testutil.CollectAndCount(m.httpDuration))
+}
+
+func TestMetrics_ObserveCacheRead(t *testing.T) {
+	m := NewMetrics()
+
+	m.ObserveCacheRead("redis", true)
+	m.ObserveCacheRead("redis", true)
+	m.ObserveCacheRead("redis", false)
+
+	err := testutil.CollectAndCompare(m.cacheReads, strings.NewReader(`
+# HELP gameap_cache_reads_total Number of the cache reads by the cache driver and the result, hit or miss.
+# TYPE gameap_cache_reads_total counter
+gameap_cache_reads_total{driver="redis",result="hit"} 2
+gameap_cache_reads_total{driver="redis",result="miss"} 1
+`))
+	require.NoError(t, err)
+}
+
+func TestMetrics_Handler(t *testing.T) {
+	m := NewMetrics()
+	m.ObserveCacheRead("memory", false)
+
+	rr := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `gameap_cache_reads_total{driver="memory",result="miss"} 1`)
+	assert.Contains(t, rr.Body.String(), "go_goroutines")
+}
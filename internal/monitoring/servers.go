@@ -0,0 +1,68 @@
+package monitoring
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var serversOnlineDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "servers_online"),
+	"Number of the online game servers by the node.",
+	[]string{"node_id"}, nil,
+)
+
+// ServerCollector counts the online game servers of the nodes.
+// The nodes without servers aren't reported.
+type ServerCollector struct {
+	repo    repositories.ServerRepository
+	timeout time.Duration
+}
+
+func NewServerCollector(repo repositories.ServerRepository, timeout time.Duration) *ServerCollector {
+	return &ServerCollector{
+		repo:    repo,
+		timeout: timeout,
+	}
+}
+
+func (c *ServerCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- serversOnlineDesc
+}
+
+func (c *ServerCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	servers, err := c.repo.Find(ctx, &filters.FindServer{}, nil, nil)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(serversOnlineDesc, err)
+
+		return
+	}
+
+	online := make(map[uint]int)
+
+	for i := range servers {
+		count := online[servers[i].DSID]
+
+		if servers[i].IsOnline() {
+			count++
+		}
+
+		online[servers[i].DSID] = count
+	}
+
+	for nodeID, count := range online {
+		ch <- prometheus.MustNewConstMetric(
+			serversOnlineDesc,
+			prometheus.GaugeValue,
+			float64(count),
+			strconv.FormatUint(uint64(nodeID), 10),
+		)
+	}
+}
@@ -0,0 +1,90 @@
+package pubsub
+
+import (
+	"context"
+	"sync"
+)
+
+const inMemoryBufferSize = 64
+
+// InMemory delivers messages within a single panel process.
+type InMemory struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[*inMemorySubscription]struct{}
+}
+
+func NewInMemory() *InMemory {
+	return &InMemory{
+		subscribers: make(map[string]map[*inMemorySubscription]struct{}),
+	}
+}
+
+func (ps *InMemory) Publish(_ context.Context, topic string, payload []byte) error {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+
+	for sub := range ps.subscribers[topic] {
+		select {
+		case sub.messages <- payload:
+		default:
+			// The subscriber is too slow, the message is dropped
+		}
+	}
+
+	return nil
+}
+
+func (ps *InMemory) Subscribe(_ context.Context, topic string) (Subscription, error) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	sub := &inMemorySubscription{
+		ps:       ps,
+		topic:    topic,
+		messages: make(chan []byte, inMemoryBufferSize),
+	}
+
+	if _, ok := ps.subscribers[topic]; !ok {
+		ps.subscribers[topic] = make(map[*inMemorySubscription]struct{})
+	}
+	ps.subscribers[topic][sub] = struct{}{}
+
+	return sub, nil
+}
+
+func (ps *InMemory) unsubscribe(sub *inMemorySubscription) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	subs, ok := ps.subscribers[sub.topic]
+	if !ok {
+		return
+	}
+
+	if _, ok = subs[sub]; !ok {
+		return
+	}
+
+	delete(subs, sub)
+	close(sub.messages)
+
+	if len(subs) == 0 {
+		delete(ps.subscribers, sub.topic)
+	}
+}
+
+type inMemorySubscription struct {
+	ps       *InMemory
+	topic    string
+	messages chan []byte
+}
+
+func (s *inMemorySubscription) Messages() <-chan []byte {
+	return s.messages
+}
+
+func (s *inMemorySubscription) Close() error {
+	s.ps.unsubscribe(s)
+
+	return nil
+}
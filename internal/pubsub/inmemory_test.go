@@ -0,0 +1,16 @@
+package pubsub_test
+
+import (
+	"testing"
+
+	"github.com/gameap/gameap/internal/pubsub"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestInMemoryPubSub(t *testing.T) {
+	suite.Run(t, pubsub.NewPubSubSuite(
+		func(_ *testing.T) pubsub.PubSub {
+			return pubsub.NewInMemory()
+		},
+	))
+}
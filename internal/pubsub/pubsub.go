@@ -0,0 +1,18 @@
+package pubsub
+
+import (
+	"context"
+)
+
+// PubSub delivers messages published to a topic to all its current subscribers.
+// Delivery is best effort: messages are not persisted and may be dropped for slow subscribers.
+type PubSub interface {
+	Publish(ctx context.Context, topic string, payload []byte) error
+	Subscribe(ctx context.Context, topic string) (Subscription, error)
+}
+
+type Subscription interface {
+	// Messages returns the channel of received messages. The channel is closed by Close.
+	Messages() <-chan []byte
+	Close() error
+}
@@ -0,0 +1,112 @@
+package pubsub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type Suite struct {
+	suite.Suite
+
+	ps PubSub
+	fn func(t *testing.T) PubSub
+}
+
+func NewPubSubSuite(fn func(t *testing.T) PubSub) *Suite {
+	return &Suite{
+		fn: fn,
+	}
+}
+
+func (s *Suite) SetupTest() {
+	s.ps = s.fn(s.T())
+}
+
+func (s *Suite) TestPublishWithoutSubscribers() {
+	err := s.ps.Publish(context.Background(), "suite:no_subscribers", []byte("message"))
+
+	require.NoError(s.T(), err)
+}
+
+func (s *Suite) TestSubscribe_ReceivesPublishedMessages() {
+	ctx := context.Background()
+
+	sub, err := s.ps.Subscribe(ctx, "suite:receive")
+	require.NoError(s.T(), err)
+	defer sub.Close()
+
+	require.NoError(s.T(), s.ps.Publish(ctx, "suite:receive", []byte("first")))
+	require.NoError(s.T(), s.ps.Publish(ctx, "suite:receive", []byte("second")))
+
+	assert.Equal(s.T(), []byte("first"), s.nextMessage(sub))
+	assert.Equal(s.T(), []byte("second"), s.nextMessage(sub))
+}
+
+func (s *Suite) TestSubscribe_MultipleSubscribers() {
+	ctx := context.Background()
+
+	first, err := s.ps.Subscribe(ctx, "suite:multiple")
+	require.NoError(s.T(), err)
+	defer first.Close()
+
+	second, err := s.ps.Subscribe(ctx, "suite:multiple")
+	require.NoError(s.T(), err)
+	defer second.Close()
+
+	require.NoError(s.T(), s.ps.Publish(ctx, "suite:multiple", []byte("message")))
+
+	assert.Equal(s.T(), []byte("message"), s.nextMessage(first))
+	assert.Equal(s.T(), []byte("message"), s.nextMessage(second))
+}
+
+func (s *Suite) TestSubscribe_OtherTopic() {
+	ctx := context.Background()
+
+	sub, err := s.ps.Subscribe(ctx, "suite:topic_1")
+	require.NoError(s.T(), err)
+	defer sub.Close()
+
+	require.NoError(s.T(), s.ps.Publish(ctx, "suite:topic_2", []byte("other")))
+	require.NoError(s.T(), s.ps.Publish(ctx, "suite:topic_1", []byte("message")))
+
+	assert.Equal(s.T(), []byte("message"), s.nextMessage(sub))
+}
+
+func (s *Suite) TestClose_ClosesMessages() {
+	ctx := context.Background()
+
+	sub, err := s.ps.Subscribe(ctx, "suite:close")
+	require.NoError(s.T(), err)
+
+	require.NoError(s.T(), sub.Close())
+	require.NoError(s.T(), sub.Close())
+
+	select {
+	case _, ok := <-sub.Messages():
+		assert.False(s.T(), ok)
+	case <-time.After(time.Second):
+		s.T().Fatal("timeout waiting for messages channel to be closed")
+	}
+
+	require.NoError(s.T(), s.ps.Publish(ctx, "suite:close", []byte("message")))
+}
+
+func (s *Suite) nextMessage(sub Subscription) []byte {
+	s.T().Helper()
+
+	select {
+	case msg, ok := <-sub.Messages():
+		require.True(s.T(), ok, "messages channel is closed")
+
+		return msg
+	case <-time.After(time.Second):
+		s.T().Fatal("timeout waiting for message")
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package pubsub
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	redisChannelPrefix = "gameap:pubsub:"
+	redisBufferSize    = 64
+)
+
+// Redis delivers messages across panel replicas using Redis Pub/Sub.
+type Redis struct {
+	client *redis.Client
+}
+
+func NewRedis(client *redis.Client) *Redis {
+	return &Redis{
+		client: client,
+	}
+}
+
+func (ps *Redis) Publish(ctx context.Context, topic string, payload []byte) error {
+	if err := ps.client.Publish(ctx, redisChannelPrefix+topic, payload).Err(); err != nil {
+		return errors.WithMessage(err, "failed to publish message")
+	}
+
+	return nil
+}
+
+func (ps *Redis) Subscribe(ctx context.Context, topic string) (Subscription, error) {
+	redisPubSub := ps.client.Subscribe(ctx, redisChannelPrefix+topic)
+
+	// Wait for the subscription confirmation, so no message published after Subscribe returns is lost
+	if _, err := redisPubSub.Receive(ctx); err != nil {
+		_ = redisPubSub.Close()
+
+		return nil, errors.WithMessage(err, "failed to subscribe")
+	}
+
+	sub := &redisSubscription{
+		pubSub:   redisPubSub,
+		messages: make(chan []byte, redisBufferSize),
+		done:     make(chan struct{}),
+	}
+
+	go sub.run()
+
+	return sub, nil
+}
+
+type redisSubscription struct {
+	pubSub   *redis.PubSub
+	messages chan []byte
+	done     chan struct{}
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+func (s *redisSubscription) run() {
+	defer close(s.messages)
+
+	ch := s.pubSub.Channel()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+
+			select {
+			case s.messages <- []byte(msg.Payload):
+			default:
+				// The subscriber is too slow, the message is dropped
+			}
+		}
+	}
+}
+
+func (s *redisSubscription) Messages() <-chan []byte {
+	return s.messages
+}
+
+func (s *redisSubscription) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.done)
+		s.closeErr = s.pubSub.Close()
+	})
+
+	return s.closeErr
+}
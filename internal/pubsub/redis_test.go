@@ -0,0 +1,39 @@
+package pubsub_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/pubsub"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestRedisPubSub(t *testing.T) {
+	testRedisAddr := os.Getenv("TEST_REDIS_ADDR")
+	if testRedisAddr == "" {
+		t.Skip("Skipping Redis pubsub tests because TEST_REDIS_ADDR is not set")
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     testRedisAddr,
+		Password: os.Getenv("TEST_REDIS_PASSWORD"),
+		DB:       0,
+	})
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skipf("Skipping Redis pubsub tests because Redis is not available: %v", err)
+	}
+
+	suite.Run(t, pubsub.NewPubSubSuite(
+		func(_ *testing.T) pubsub.PubSub {
+			return pubsub.NewRedis(client)
+		},
+	))
+}
@@ -19,6 +19,19 @@ const ServerTaskFailsTable = "servers_tasks_fails"
 const ServerSettingsTable = "servers_settings"
 const NodesTable = "dedicated_servers"
 const ClientCertificatesTable = "client_certificates"
+const BackupsTable = "servers_backups"
+const ServerTemplatesTable = "server_templates"
+const ServerResourceUsageTable = "servers_resource_usage"
+const MetricsTable = "metrics"
+const ServerQueryRecordsTable = "servers_query_records"
+const ServerAutoRestartsTable = "servers_auto_restarts"
+const WebhooksTable = "webhooks"
+const WebhookDeliveriesTable = "webhook_deliveries"
+const NotificationChannelsTable = "notification_channels"
+const PasswordResetsTable = "password_resets"
+const UserTwoFactorsTable = "user_two_factors"
+const UserIdentitiesTable = "user_identities"
+const AuditLogsTable = "audit_logs"
 
 var (
 	GameFields                = allFields(domain.Game{})
@@ -36,4 +49,17 @@ var (
 	ServerSettingFields       = allFields(domain.ServerSetting{})
 	NodeFields                = allFields(domain.Node{})
 	ClientCertificateFields   = allFields(domain.ClientCertificate{})
+	BackupFields              = allFields(domain.Backup{})
+	ServerTemplateFields      = allFields(domain.ServerTemplate{})
+	ServerResourceUsageFields = allFields(domain.ServerResourceUsage{})
+	MetricFields              = allFields(domain.Metric{})
+	ServerQueryRecordFields   = allFields(domain.ServerQueryRecord{})
+	ServerAutoRestartFields   = allFields(domain.ServerAutoRestart{})
+	WebhookFields             = allFields(domain.Webhook{})
+	WebhookDeliveryFields     = allFields(domain.WebhookDelivery{})
+	NotificationChannelFields = allFields(domain.NotificationChannel{})
+	PasswordResetFields       = allFields(domain.PasswordReset{})
+	UserTwoFactorFields       = allFields(domain.UserTwoFactor{})
+	UserIdentityFields        = allFields(domain.UserIdentity{})
+	AuditLogFields            = allFields(domain.AuditLog{})
 )
@@ -8,6 +8,10 @@ import (
 
 	trsql "github.com/avito-tech/go-transaction-manager/drivers/sql/v2"
 	"github.com/avito-tech/go-transaction-manager/trm/v2"
+	"github.com/gameap/gameap/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // TransactionGetter provides methods to retrieve either an active transaction from context
@@ -213,3 +217,84 @@ func (w *DBLogWrapper) QueryRow(query string, args ...any) *sql.Row {
 
 	return row
 }
+
+// DBTraceWrapper wraps a DB interface and records the database operations as tracing spans.
+// Only the operations with a context are recorded, the others have no parent span to belong to.
+type DBTraceWrapper struct {
+	db     DB
+	system attribute.KeyValue
+}
+
+// NewDBTraceWrapper creates a new DBTraceWrapper instance, the driver is recorded as the database system.
+func NewDBTraceWrapper(db DB, driver string) *DBTraceWrapper {
+	return &DBTraceWrapper{
+		db:     db,
+		system: semconv.DBSystemNameKey.String(driver),
+	}
+}
+
+// PrepareContext records a span and delegates to the wrapped DB.
+func (w *DBTraceWrapper) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	ctx, span := w.start(ctx, "db.prepare", query)
+	stmt, err := w.db.PrepareContext(ctx, query)
+	tracing.End(span, err)
+
+	return stmt, err
+}
+
+// Prepare delegates to the wrapped DB.
+func (w *DBTraceWrapper) Prepare(query string) (*sql.Stmt, error) {
+	return w.db.Prepare(query)
+}
+
+// ExecContext records a span and delegates to the wrapped DB.
+func (w *DBTraceWrapper) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ctx, span := w.start(ctx, "db.exec", query)
+	result, err := w.db.ExecContext(ctx, query, args...)
+	tracing.End(span, err)
+
+	return result, err
+}
+
+// Exec delegates to the wrapped DB.
+func (w *DBTraceWrapper) Exec(query string, args ...any) (sql.Result, error) {
+	return w.db.Exec(query, args...)
+}
+
+// QueryContext records a span and delegates to the wrapped DB.
+// The span ends when the query returns, reading the rows isn't included.
+func (w *DBTraceWrapper) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	ctx, span := w.start(ctx, "db.query", query)
+	rows, err := w.db.QueryContext(ctx, query, args...)
+	tracing.End(span, err)
+
+	return rows, err
+}
+
+// Query delegates to the wrapped DB.
+func (w *DBTraceWrapper) Query(query string, args ...any) (*sql.Rows, error) {
+	return w.db.Query(query, args...)
+}
+
+// QueryRowContext records a span and delegates to the wrapped DB.
+func (w *DBTraceWrapper) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	ctx, span := w.start(ctx, "db.query_row", query)
+	row := w.db.QueryRowContext(ctx, query, args...)
+	tracing.End(span, row.Err())
+
+	return row
+}
+
+// QueryRow delegates to the wrapped DB.
+func (w *DBTraceWrapper) QueryRow(query string, args ...any) *sql.Row {
+	return w.db.QueryRow(query, args...)
+}
+
+func (w *DBTraceWrapper) start(ctx context.Context, name, query string) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(
+		ctx,
+		name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(w.system, semconv.DBQueryText(query)),
+	)
+}
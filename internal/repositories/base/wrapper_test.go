@@ -0,0 +1,70 @@
+package base
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/gameap/gameap/internal/tracing/tracingtest"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+type fakeDB struct {
+	err error
+}
+
+func (db *fakeDB) PrepareContext(context.Context, string) (*sql.Stmt, error) { return nil, db.err }
+func (db *fakeDB) Prepare(string) (*sql.Stmt, error)                         { return nil, db.err }
+func (db *fakeDB) ExecContext(context.Context, string, ...any) (sql.Result, error) {
+	return nil, db.err
+}
+func (db *fakeDB) Exec(string, ...any) (sql.Result, error) { return nil, db.err }
+func (db *fakeDB) QueryContext(context.Context, string, ...any) (*sql.Rows, error) {
+	return nil, db.err
+}
+func (db *fakeDB) Query(string, ...any) (*sql.Rows, error)                  { return nil, db.err }
+func (db *fakeDB) QueryRowContext(context.Context, string, ...any) *sql.Row { return &sql.Row{} }
+func (db *fakeDB) QueryRow(string, ...any) *sql.Row                         { return &sql.Row{} }
+
+func TestDBTraceWrapper(t *testing.T) {
+	recorder := tracingtest.NewRecorder(t)
+	ctx := context.Background()
+
+	db := NewDBTraceWrapper(&fakeDB{}, "mysql")
+
+	_, err := db.ExecContext(ctx, "UPDATE servers SET name = ?", "name")
+	require.NoError(t, err)
+	_, err = db.QueryContext(ctx, "SELECT id FROM servers")
+	require.NoError(t, err)
+	_ = db.QueryRowContext(ctx, "SELECT COUNT(*) FROM servers")
+	_, err = db.PrepareContext(ctx, "SELECT 1")
+	require.NoError(t, err)
+
+	// Operations without a context aren't recorded
+	_, err = db.Exec("DELETE FROM servers")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"db.exec", "db.query", "db.query_row", "db.prepare"}, tracingtest.SpanNames(recorder))
+
+	span := recorder.Ended()[0]
+	assert.Contains(t, span.Attributes(), attribute.String("db.system.name", "mysql"))
+	assert.Contains(t, span.Attributes(), attribute.String("db.query.text", "UPDATE servers SET name = ?"))
+}
+
+func TestDBTraceWrapper_Error(t *testing.T) {
+	recorder := tracingtest.NewRecorder(t)
+
+	db := NewDBTraceWrapper(&fakeDB{err: errors.New("connection refused")}, "postgres")
+
+	_, err := db.QueryContext(context.Background(), "SELECT id FROM servers")
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "connection refused", spans[0].Status().Description)
+}
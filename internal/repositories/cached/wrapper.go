@@ -143,7 +143,7 @@ func (w *Wrapper) GetOrSet(
 
 // InvalidatePattern removes all cache entries matching a pattern.
 func (w *Wrapper) InvalidatePattern(ctx context.Context, pattern string) error {
-	if redisCache, ok := w.cache.(*cache.Redis); ok {
+	if redisCache, ok := cache.Unwrap(w.cache).(*cache.Redis); ok {
 		return redisCache.DeletePattern(ctx, pattern)
 	}
 	// For non-Redis caches, we might not support pattern deletion
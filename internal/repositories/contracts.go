@@ -154,7 +154,8 @@ type DaemonTaskRepository interface {
 
 	Exists(ctx context.Context, filter *filters.FindDaemonTask) (bool, error)
 
-	AppendOutput(ctx context.Context, id uint, output string) error
+	// AppendOutput appends output to the task and returns the output length in bytes after appending.
+	AppendOutput(ctx context.Context, id uint, output string) (int, error)
 }
 
 type ServerTaskRepository interface {
@@ -206,6 +207,175 @@ type ServerSettingRepository interface {
 	Delete(ctx context.Context, id uint) error
 }
 
+type BackupRepository interface {
+	Find(
+		ctx context.Context,
+		filter *filters.FindBackup,
+		order []filters.Sorting,
+		pagination *filters.Pagination,
+	) ([]domain.Backup, error)
+
+	Save(ctx context.Context, backup *domain.Backup) error
+
+	Delete(ctx context.Context, id uint) error
+}
+
+type ServerTemplateRepository interface {
+	Find(
+		ctx context.Context,
+		filter *filters.FindServerTemplate,
+		order []filters.Sorting,
+		pagination *filters.Pagination,
+	) ([]domain.ServerTemplate, error)
+
+	Save(ctx context.Context, template *domain.ServerTemplate) error
+
+	Delete(ctx context.Context, id uint) error
+}
+
+type ServerResourceUsageRepository interface {
+	Find(
+		ctx context.Context,
+		filter *filters.FindServerResourceUsage,
+		order []filters.Sorting,
+		pagination *filters.Pagination,
+	) ([]domain.ServerResourceUsage, error)
+
+	// Save inserts or replaces the usage of the server.
+	Save(ctx context.Context, usage *domain.ServerResourceUsage) error
+}
+
+type MetricRepository interface {
+	Find(
+		ctx context.Context,
+		filter *filters.FindMetric,
+		order []filters.Sorting,
+		pagination *filters.Pagination,
+	) ([]domain.Metric, error)
+
+	// SaveBulk inserts or updates the points of the subjects with the same resolution and time.
+	// Values missing in a saved point keep the stored ones, so samples of different sources
+	// recorded at the same time are merged into one point.
+	SaveBulk(ctx context.Context, metrics []*domain.Metric) error
+
+	// DeleteBefore deletes the points of the resolution recorded before the time.
+	DeleteBefore(ctx context.Context, resolution domain.MetricResolution, before time.Time) error
+}
+
+type ServerQueryRecordRepository interface {
+	Find(
+		ctx context.Context,
+		filter *filters.FindServerQueryRecord,
+		order []filters.Sorting,
+		pagination *filters.Pagination,
+	) ([]domain.ServerQueryRecord, error)
+
+	// SaveBulk inserts the records, a record of the server with the same time is replaced.
+	SaveBulk(ctx context.Context, records []*domain.ServerQueryRecord) error
+
+	// DeleteBefore deletes the records queried before the time.
+	DeleteBefore(ctx context.Context, before time.Time) error
+}
+
+type ServerAutoRestartRepository interface {
+	Find(
+		ctx context.Context,
+		filter *filters.FindServerAutoRestart,
+		order []filters.Sorting,
+		pagination *filters.Pagination,
+	) ([]domain.ServerAutoRestart, error)
+
+	// Save inserts or replaces the auto restart state of the server.
+	Save(ctx context.Context, restart *domain.ServerAutoRestart) error
+}
+
+type WebhookRepository interface {
+	Find(
+		ctx context.Context,
+		filter *filters.FindWebhook,
+		order []filters.Sorting,
+		pagination *filters.Pagination,
+	) ([]domain.Webhook, error)
+
+	Save(ctx context.Context, webhook *domain.Webhook) error
+
+	Delete(ctx context.Context, id uint) error
+}
+
+type WebhookDeliveryRepository interface {
+	Find(
+		ctx context.Context,
+		filter *filters.FindWebhookDelivery,
+		order []filters.Sorting,
+		pagination *filters.Pagination,
+	) ([]domain.WebhookDelivery, error)
+
+	Save(ctx context.Context, delivery *domain.WebhookDelivery) error
+
+	// DeleteBefore deletes the deliveries created before the time.
+	DeleteBefore(ctx context.Context, before time.Time) error
+
+	DeleteByWebhookID(ctx context.Context, webhookID uint) error
+}
+
+type NotificationChannelRepository interface {
+	Find(
+		ctx context.Context,
+		filter *filters.FindNotificationChannel,
+		order []filters.Sorting,
+		pagination *filters.Pagination,
+	) ([]domain.NotificationChannel, error)
+
+	Save(ctx context.Context, channel *domain.NotificationChannel) error
+
+	Delete(ctx context.Context, id uint) error
+}
+
+type PasswordResetRepository interface {
+	Find(
+		ctx context.Context,
+		filter *filters.FindPasswordReset,
+		order []filters.Sorting,
+		pagination *filters.Pagination,
+	) ([]domain.PasswordReset, error)
+
+	// Save replaces the password reset of the email.
+	Save(ctx context.Context, reset *domain.PasswordReset) error
+
+	// Delete deletes the password resets of the email.
+	Delete(ctx context.Context, email string) error
+}
+
+type UserTwoFactorRepository interface {
+	Find(
+		ctx context.Context,
+		filter *filters.FindUserTwoFactor,
+		order []filters.Sorting,
+		pagination *filters.Pagination,
+	) ([]domain.UserTwoFactor, error)
+
+	// Save inserts or replaces the two-factor authentication of the user.
+	Save(ctx context.Context, twoFactor *domain.UserTwoFactor) error
+
+	// Delete deletes the two-factor authentication of the user.
+	Delete(ctx context.Context, userID uint) error
+}
+
+type UserIdentityRepository interface {
+	Find(
+		ctx context.Context,
+		filter *filters.FindUserIdentity,
+		order []filters.Sorting,
+		pagination *filters.Pagination,
+	) ([]domain.UserIdentity, error)
+
+	// Save inserts or replaces the identity by its provider and subject.
+	Save(ctx context.Context, identity *domain.UserIdentity) error
+
+	// Delete deletes all identities of the user.
+	Delete(ctx context.Context, userID uint) error
+}
+
 type NodeRepository interface {
 	FindAll(
 		ctx context.Context,
@@ -243,3 +413,17 @@ type ClientCertificateRepository interface {
 
 	Delete(ctx context.Context, id uint) error
 }
+
+type AuditLogRepository interface {
+	Find(
+		ctx context.Context,
+		filter *filters.FindAuditLog,
+		order []filters.Sorting,
+		pagination *filters.Pagination,
+	) ([]domain.AuditLog, error)
+
+	Count(ctx context.Context, filter *filters.FindAuditLog) (int, error)
+
+	// Save inserts the record, the records are never updated.
+	Save(ctx context.Context, log *domain.AuditLog) error
+}
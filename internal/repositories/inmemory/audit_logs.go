@@ -0,0 +1,186 @@
+package inmemory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/samber/lo"
+)
+
+type AuditLogRepository struct {
+	mu     sync.RWMutex
+	logs   map[uint]*domain.AuditLog
+	nextID uint32
+}
+
+func NewAuditLogRepository() *AuditLogRepository {
+	return &AuditLogRepository{
+		logs: make(map[uint]*domain.AuditLog),
+	}
+}
+
+func (r *AuditLogRepository) Find(
+	_ context.Context,
+	filter *filters.FindAuditLog,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.AuditLog, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	logs := r.filter(filter)
+
+	r.sortLogs(logs, order)
+
+	return r.applyPagination(logs, pagination), nil
+}
+
+func (r *AuditLogRepository) Count(_ context.Context, filter *filters.FindAuditLog) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.filter(filter)), nil
+}
+
+func (r *AuditLogRepository) Save(_ context.Context, log *domain.AuditLog) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if log.CreatedAt == nil || log.CreatedAt.IsZero() {
+		log.CreatedAt = lo.ToPtr(time.Now())
+	}
+
+	if log.ID == 0 {
+		log.ID = uint(atomic.AddUint32(&r.nextID, 1))
+	}
+
+	stored := *log
+	r.logs[log.ID] = &stored
+
+	return nil
+}
+
+func (r *AuditLogRepository) filter(filter *filters.FindAuditLog) []domain.AuditLog {
+	if filter == nil {
+		filter = &filters.FindAuditLog{}
+	}
+
+	logs := make([]domain.AuditLog, 0, len(r.logs))
+	for _, log := range r.logs {
+		if r.matchesFilter(log, filter) {
+			logs = append(logs, *log)
+		}
+	}
+
+	return logs
+}
+
+func (r *AuditLogRepository) matchesFilter(log *domain.AuditLog, filter *filters.FindAuditLog) bool {
+	if len(filter.IDs) > 0 && !slices.Contains(filter.IDs, log.ID) {
+		return false
+	}
+
+	if len(filter.UserIDs) > 0 && (log.UserID == nil || !slices.Contains(filter.UserIDs, *log.UserID)) {
+		return false
+	}
+
+	if len(filter.Actions) > 0 && !slices.Contains(filter.Actions, log.Action) {
+		return false
+	}
+
+	if len(filter.EntityTypes) > 0 && (log.EntityType == nil || !slices.Contains(filter.EntityTypes, *log.EntityType)) {
+		return false
+	}
+
+	if len(filter.EntityIDs) > 0 && (log.EntityID == nil || !slices.Contains(filter.EntityIDs, *log.EntityID)) {
+		return false
+	}
+
+	if len(filter.Results) > 0 && !slices.Contains(filter.Results, log.Result) {
+		return false
+	}
+
+	if filter.CreatedFrom != nil && (log.CreatedAt == nil || log.CreatedAt.Before(*filter.CreatedFrom)) {
+		return false
+	}
+
+	if filter.CreatedTo != nil && (log.CreatedAt == nil || !log.CreatedAt.Before(*filter.CreatedTo)) {
+		return false
+	}
+
+	if filter.BeforeID != nil && log.ID >= *filter.BeforeID {
+		return false
+	}
+
+	return true
+}
+
+func (r *AuditLogRepository) sortLogs(logs []domain.AuditLog, order []filters.Sorting) {
+	if len(order) == 0 {
+		sort.Slice(logs, func(i, j int) bool {
+			return logs[i].ID < logs[j].ID
+		})
+
+		return
+	}
+
+	sort.Slice(logs, func(i, j int) bool {
+		for _, o := range order {
+			cmpRes := r.compareLogs(&logs[i], &logs[j], o.Field)
+			if cmpRes != 0 {
+				if o.Direction == filters.SortDirectionDesc {
+					return cmpRes > 0
+				}
+
+				return cmpRes < 0
+			}
+		}
+
+		return false
+	})
+}
+
+func (r *AuditLogRepository) compareLogs(a, b *domain.AuditLog, field string) int {
+	switch field {
+	case "id":
+		return cmp.Compare(a.ID, b.ID)
+	case "action":
+		return strings.Compare(a.Action, b.Action)
+	case "created_at":
+		return lo.FromPtr(a.CreatedAt).Compare(lo.FromPtr(b.CreatedAt))
+	default:
+		return 0
+	}
+}
+
+func (r *AuditLogRepository) applyPagination(
+	logs []domain.AuditLog,
+	pagination *filters.Pagination,
+) []domain.AuditLog {
+	if pagination == nil {
+		return logs
+	}
+
+	limit := pagination.Limit
+	if limit <= 0 {
+		limit = filters.DefaultLimit
+	}
+
+	offset := max(pagination.Offset, 0)
+
+	if offset >= len(logs) {
+		return []domain.AuditLog{}
+	}
+
+	end := min(offset+limit, len(logs))
+
+	return logs[offset:end]
+}
@@ -0,0 +1,228 @@
+package inmemory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/samber/lo"
+)
+
+type BackupRepository struct {
+	mu      sync.RWMutex
+	backups map[uint]*domain.Backup
+	nextID  uint32
+
+	// Hash indexes for efficient filtering
+	serverIDIndex map[uint]map[uint]struct{} // serverID -> backupIDs
+}
+
+func NewBackupRepository() *BackupRepository {
+	return &BackupRepository{
+		backups:       make(map[uint]*domain.Backup),
+		serverIDIndex: make(map[uint]map[uint]struct{}),
+	}
+}
+
+func (r *BackupRepository) Find(
+	_ context.Context,
+	filter *filters.FindBackup,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.Backup, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if filter == nil {
+		filter = &filters.FindBackup{}
+	}
+
+	backups := make([]domain.Backup, 0, len(r.backups))
+	for _, backup := range r.candidates(filter) {
+		if r.matchesFilter(backup, filter) {
+			backups = append(backups, *backup)
+		}
+	}
+
+	r.sortBackups(backups, order)
+
+	return r.applyPagination(backups, pagination), nil
+}
+
+func (r *BackupRepository) Save(_ context.Context, backup *domain.Backup) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	backup.UpdatedAt = lo.ToPtr(time.Now())
+
+	if backup.ID == 0 && (backup.CreatedAt == nil || backup.CreatedAt.IsZero()) {
+		backup.CreatedAt = lo.ToPtr(time.Now())
+	}
+
+	if backup.ID != 0 {
+		if old, exists := r.backups[backup.ID]; exists {
+			r.removeFromIndexes(old)
+		}
+	} else {
+		backup.ID = uint(atomic.AddUint32(&r.nextID, 1))
+	}
+
+	stored := *backup
+	r.backups[backup.ID] = &stored
+	r.addToIndexes(&stored)
+
+	return nil
+}
+
+func (r *BackupRepository) Delete(_ context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if backup, exists := r.backups[id]; exists {
+		r.removeFromIndexes(backup)
+	}
+
+	delete(r.backups, id)
+
+	return nil
+}
+
+func (r *BackupRepository) addToIndexes(backup *domain.Backup) {
+	if r.serverIDIndex[backup.ServerID] == nil {
+		r.serverIDIndex[backup.ServerID] = make(map[uint]struct{})
+	}
+	r.serverIDIndex[backup.ServerID][backup.ID] = struct{}{}
+}
+
+func (r *BackupRepository) removeFromIndexes(backup *domain.Backup) {
+	if backupSet, exists := r.serverIDIndex[backup.ServerID]; exists {
+		delete(backupSet, backup.ID)
+		if len(backupSet) == 0 {
+			delete(r.serverIDIndex, backup.ServerID)
+		}
+	}
+}
+
+func (r *BackupRepository) candidates(filter *filters.FindBackup) []*domain.Backup {
+	var result []*domain.Backup
+
+	switch {
+	case len(filter.IDs) > 0:
+		for _, id := range filter.IDs {
+			if backup, exists := r.backups[id]; exists {
+				result = append(result, backup)
+			}
+		}
+	case len(filter.ServerIDs) > 0:
+		for _, serverID := range filter.ServerIDs {
+			for backupID := range r.serverIDIndex[serverID] {
+				result = append(result, r.backups[backupID])
+			}
+		}
+	default:
+		for _, backup := range r.backups {
+			result = append(result, backup)
+		}
+	}
+
+	return result
+}
+
+func (r *BackupRepository) matchesFilter(backup *domain.Backup, filter *filters.FindBackup) bool {
+	if len(filter.IDs) > 0 && !slices.Contains(filter.IDs, backup.ID) {
+		return false
+	}
+
+	if len(filter.ServerIDs) > 0 && !slices.Contains(filter.ServerIDs, backup.ServerID) {
+		return false
+	}
+
+	if len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, backup.Status) {
+		return false
+	}
+
+	return true
+}
+
+func (r *BackupRepository) sortBackups(backups []domain.Backup, order []filters.Sorting) {
+	if len(order) == 0 {
+		sort.Slice(backups, func(i, j int) bool {
+			return backups[i].ID < backups[j].ID
+		})
+
+		return
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		for _, o := range order {
+			cmpRes := r.compareBackups(&backups[i], &backups[j], o.Field)
+			if cmpRes != 0 {
+				if o.Direction == filters.SortDirectionDesc {
+					return cmpRes > 0
+				}
+
+				return cmpRes < 0
+			}
+		}
+
+		return false
+	})
+}
+
+func (r *BackupRepository) compareBackups(a, b *domain.Backup, field string) int {
+	switch field {
+	case "id":
+		return cmp.Compare(a.ID, b.ID)
+	case "server_id":
+		return cmp.Compare(a.ServerID, b.ServerID)
+	case "status":
+		return strings.Compare(string(a.Status), string(b.Status))
+	case "size":
+		return cmp.Compare(a.Size, b.Size)
+	case "created_at":
+		if a.CreatedAt == nil && b.CreatedAt == nil {
+			return 0
+		}
+		if a.CreatedAt == nil {
+			return -1
+		}
+		if b.CreatedAt == nil {
+			return 1
+		}
+
+		return a.CreatedAt.Compare(*b.CreatedAt)
+	default:
+		return 0
+	}
+}
+
+func (r *BackupRepository) applyPagination(
+	backups []domain.Backup,
+	pagination *filters.Pagination,
+) []domain.Backup {
+	if pagination == nil {
+		return backups
+	}
+
+	limit := pagination.Limit
+	if limit <= 0 {
+		limit = filters.DefaultLimit
+	}
+
+	offset := max(pagination.Offset, 0)
+
+	if offset >= len(backups) {
+		return []domain.Backup{}
+	}
+
+	end := min(offset+limit, len(backups))
+
+	return backups[offset:end]
+}
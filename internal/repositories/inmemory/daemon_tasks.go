@@ -144,13 +144,13 @@ func (r *DaemonTaskRepository) Delete(_ context.Context, id uint) error {
 	return nil
 }
 
-func (r *DaemonTaskRepository) AppendOutput(_ context.Context, id uint, output string) error {
+func (r *DaemonTaskRepository) AppendOutput(_ context.Context, id uint, output string) (int, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	task, exists := r.tasks[id]
 	if !exists {
-		return nil
+		return 0, nil
 	}
 
 	if task.Output == nil {
@@ -160,7 +160,7 @@ func (r *DaemonTaskRepository) AppendOutput(_ context.Context, id uint, output s
 		task.Output = &newOutput
 	}
 
-	return nil
+	return len(*task.Output), nil
 }
 
 func (r *DaemonTaskRepository) Count(_ context.Context, filter *filters.FindDaemonTask) (int, error) {
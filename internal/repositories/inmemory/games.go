@@ -89,6 +89,8 @@ func (r *GameRepository) Save(_ context.Context, game *domain.Game) error {
 		LocalRepositoryLinux:    game.LocalRepositoryLinux,
 		LocalRepositoryWindows:  game.LocalRepositoryWindows,
 		Enabled:                 game.Enabled,
+		QueryPortOffset:         game.QueryPortOffset,
+		RconPortOffset:          game.RconPortOffset,
 	}
 
 	return nil
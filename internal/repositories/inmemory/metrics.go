@@ -0,0 +1,212 @@
+package inmemory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/samber/lo"
+)
+
+type metricKey struct {
+	subjectType domain.MetricSubject
+	subjectID   uint
+	resolution  domain.MetricResolution
+	recordedAt  time.Time
+}
+
+type MetricRepository struct {
+	mu      sync.RWMutex
+	metrics map[metricKey]*domain.Metric
+}
+
+func NewMetricRepository() *MetricRepository {
+	return &MetricRepository{
+		metrics: make(map[metricKey]*domain.Metric),
+	}
+}
+
+func (r *MetricRepository) Find(
+	_ context.Context,
+	filter *filters.FindMetric,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.Metric, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if filter == nil {
+		filter = &filters.FindMetric{}
+	}
+
+	metrics := make([]domain.Metric, 0, len(r.metrics))
+	for _, metric := range r.metrics {
+		if r.matchesFilter(metric, filter) {
+			metrics = append(metrics, r.copyMetric(metric))
+		}
+	}
+
+	r.sortMetrics(metrics, order)
+
+	return r.applyPagination(metrics, pagination), nil
+}
+
+func (r *MetricRepository) SaveBulk(_ context.Context, metrics []*domain.Metric) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, metric := range metrics {
+		stored := r.copyMetric(metric)
+		stored.RecordedAt = stored.RecordedAt.UTC()
+
+		key := metricKey{
+			subjectType: stored.SubjectType,
+			subjectID:   stored.SubjectID,
+			resolution:  stored.Resolution,
+			recordedAt:  stored.RecordedAt,
+		}
+
+		if existing, ok := r.metrics[key]; ok {
+			stored.CPU = lo.CoalesceOrEmpty(stored.CPU, existing.CPU)
+			stored.RAM = lo.CoalesceOrEmpty(stored.RAM, existing.RAM)
+			stored.Disk = lo.CoalesceOrEmpty(stored.Disk, existing.Disk)
+			stored.Players = lo.CoalesceOrEmpty(stored.Players, existing.Players)
+		}
+
+		r.metrics[key] = &stored
+	}
+
+	return nil
+}
+
+func (r *MetricRepository) DeleteBefore(
+	_ context.Context,
+	resolution domain.MetricResolution,
+	before time.Time,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, metric := range r.metrics {
+		if metric.Resolution == resolution && metric.RecordedAt.Before(before) {
+			delete(r.metrics, key)
+		}
+	}
+
+	return nil
+}
+
+// copyMetric copies the metric together with its values,
+// so stored metrics can't be changed by callers.
+func (r *MetricRepository) copyMetric(metric *domain.Metric) domain.Metric {
+	c := *metric
+
+	copyValue := func(v *float64) *float64 {
+		if v == nil {
+			return nil
+		}
+
+		return lo.ToPtr(*v)
+	}
+
+	c.CPU = copyValue(metric.CPU)
+	c.RAM = copyValue(metric.RAM)
+	c.Disk = copyValue(metric.Disk)
+	c.Players = copyValue(metric.Players)
+
+	return c
+}
+
+func (r *MetricRepository) matchesFilter(metric *domain.Metric, filter *filters.FindMetric) bool {
+	if filter.SubjectType != "" && metric.SubjectType != filter.SubjectType {
+		return false
+	}
+
+	if len(filter.SubjectIDs) > 0 && !slices.Contains(filter.SubjectIDs, metric.SubjectID) {
+		return false
+	}
+
+	if len(filter.Resolutions) > 0 && !slices.Contains(filter.Resolutions, metric.Resolution) {
+		return false
+	}
+
+	if filter.RecordedFrom != nil && metric.RecordedAt.Before(*filter.RecordedFrom) {
+		return false
+	}
+
+	if filter.RecordedBefore != nil && !metric.RecordedAt.Before(*filter.RecordedBefore) {
+		return false
+	}
+
+	return true
+}
+
+func (r *MetricRepository) sortMetrics(metrics []domain.Metric, order []filters.Sorting) {
+	if len(order) == 0 {
+		order = []filters.Sorting{
+			{Field: "subject_type", Direction: filters.SortDirectionAsc},
+			{Field: "subject_id", Direction: filters.SortDirectionAsc},
+			{Field: "recorded_at", Direction: filters.SortDirectionAsc},
+		}
+	}
+
+	sort.Slice(metrics, func(i, j int) bool {
+		for _, o := range order {
+			cmpRes := r.compareMetrics(&metrics[i], &metrics[j], o.Field)
+			if cmpRes != 0 {
+				if o.Direction == filters.SortDirectionDesc {
+					return cmpRes > 0
+				}
+
+				return cmpRes < 0
+			}
+		}
+
+		return false
+	})
+}
+
+func (r *MetricRepository) compareMetrics(a, b *domain.Metric, field string) int {
+	switch field {
+	case "subject_type":
+		return strings.Compare(string(a.SubjectType), string(b.SubjectType))
+	case "subject_id":
+		return cmp.Compare(a.SubjectID, b.SubjectID)
+	case "resolution":
+		return strings.Compare(string(a.Resolution), string(b.Resolution))
+	case "recorded_at":
+		return a.RecordedAt.Compare(b.RecordedAt)
+	default:
+		return 0
+	}
+}
+
+func (r *MetricRepository) applyPagination(
+	metrics []domain.Metric,
+	pagination *filters.Pagination,
+) []domain.Metric {
+	if pagination == nil {
+		return metrics
+	}
+
+	limit := pagination.Limit
+	if limit <= 0 {
+		limit = filters.DefaultLimit
+	}
+
+	offset := max(pagination.Offset, 0)
+
+	if offset >= len(metrics) {
+		return []domain.Metric{}
+	}
+
+	end := min(offset+limit, len(metrics))
+
+	return metrics[offset:end]
+}
@@ -133,6 +133,8 @@ func (r *NodeRepository) Save(_ context.Context, node *domain.Node) error {
 		ScriptGetConsole:    node.ScriptGetConsole,
 		ScriptSendCommand:   node.ScriptSendCommand,
 		ScriptDelete:        node.ScriptDelete,
+		PortRangeStart:      node.PortRangeStart,
+		PortRangeEnd:        node.PortRangeEnd,
 		CreatedAt:           node.CreatedAt,
 		UpdatedAt:           node.UpdatedAt,
 		DeletedAt:           node.DeletedAt,
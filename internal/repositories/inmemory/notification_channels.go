@@ -0,0 +1,170 @@
+package inmemory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/samber/lo"
+)
+
+type NotificationChannelRepository struct {
+	mu       sync.RWMutex
+	channels map[uint]*domain.NotificationChannel
+	nextID   uint32
+}
+
+func NewNotificationChannelRepository() *NotificationChannelRepository {
+	return &NotificationChannelRepository{
+		channels: make(map[uint]*domain.NotificationChannel),
+	}
+}
+
+func (r *NotificationChannelRepository) Find(
+	_ context.Context,
+	filter *filters.FindNotificationChannel,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.NotificationChannel, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if filter == nil {
+		filter = &filters.FindNotificationChannel{}
+	}
+
+	channels := make([]domain.NotificationChannel, 0, len(r.channels))
+	for _, channel := range r.channels {
+		if r.matchesFilter(channel, filter) {
+			channels = append(channels, r.copyChannel(channel))
+		}
+	}
+
+	r.sortChannels(channels, order)
+
+	return r.applyPagination(channels, pagination), nil
+}
+
+func (r *NotificationChannelRepository) Save(_ context.Context, channel *domain.NotificationChannel) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	channel.UpdatedAt = lo.ToPtr(time.Now())
+
+	if channel.ID == 0 && (channel.CreatedAt == nil || channel.CreatedAt.IsZero()) {
+		channel.CreatedAt = lo.ToPtr(time.Now())
+	}
+
+	if channel.ID == 0 {
+		channel.ID = uint(atomic.AddUint32(&r.nextID, 1))
+	}
+
+	stored := r.copyChannel(channel)
+	r.channels[channel.ID] = &stored
+
+	return nil
+}
+
+func (r *NotificationChannelRepository) Delete(_ context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.channels, id)
+
+	return nil
+}
+
+// copyChannel copies the channel together with its events,
+// so stored channels can't be changed by callers.
+func (r *NotificationChannelRepository) copyChannel(channel *domain.NotificationChannel) domain.NotificationChannel {
+	c := *channel
+	c.Events = slices.Clone(channel.Events)
+
+	return c
+}
+
+func (r *NotificationChannelRepository) matchesFilter(
+	channel *domain.NotificationChannel,
+	filter *filters.FindNotificationChannel,
+) bool {
+	if len(filter.IDs) > 0 && !slices.Contains(filter.IDs, channel.ID) {
+		return false
+	}
+
+	if len(filter.UserIDs) > 0 && !slices.Contains(filter.UserIDs, channel.UserID) {
+		return false
+	}
+
+	if filter.Enabled != nil && channel.Enabled != *filter.Enabled {
+		return false
+	}
+
+	return true
+}
+
+func (r *NotificationChannelRepository) sortChannels(channels []domain.NotificationChannel, order []filters.Sorting) {
+	if len(order) == 0 {
+		sort.Slice(channels, func(i, j int) bool {
+			return channels[i].ID < channels[j].ID
+		})
+
+		return
+	}
+
+	sort.Slice(channels, func(i, j int) bool {
+		for _, o := range order {
+			cmpRes := r.compareChannels(&channels[i], &channels[j], o.Field)
+			if cmpRes != 0 {
+				if o.Direction == filters.SortDirectionDesc {
+					return cmpRes > 0
+				}
+
+				return cmpRes < 0
+			}
+		}
+
+		return false
+	})
+}
+
+func (r *NotificationChannelRepository) compareChannels(a, b *domain.NotificationChannel, field string) int {
+	switch field {
+	case "id":
+		return cmp.Compare(a.ID, b.ID)
+	case "name":
+		return strings.Compare(a.Name, b.Name)
+	default:
+		return 0
+	}
+}
+
+func (r *NotificationChannelRepository) applyPagination(
+	channels []domain.NotificationChannel,
+	pagination *filters.Pagination,
+) []domain.NotificationChannel {
+	if pagination == nil {
+		return channels
+	}
+
+	limit := pagination.Limit
+	if limit <= 0 {
+		limit = filters.DefaultLimit
+	}
+
+	offset := max(pagination.Offset, 0)
+
+	if offset >= len(channels) {
+		return []domain.NotificationChannel{}
+	}
+
+	end := min(offset+limit, len(channels))
+
+	return channels[offset:end]
+}
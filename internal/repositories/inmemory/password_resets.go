@@ -0,0 +1,149 @@
+package inmemory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/samber/lo"
+)
+
+type PasswordResetRepository struct {
+	mu     sync.RWMutex
+	resets map[string]*domain.PasswordReset // email -> reset
+}
+
+func NewPasswordResetRepository() *PasswordResetRepository {
+	return &PasswordResetRepository{
+		resets: make(map[string]*domain.PasswordReset),
+	}
+}
+
+func (r *PasswordResetRepository) Find(
+	_ context.Context,
+	filter *filters.FindPasswordReset,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.PasswordReset, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if filter == nil {
+		filter = &filters.FindPasswordReset{}
+	}
+
+	resets := make([]domain.PasswordReset, 0, len(r.resets))
+	for _, reset := range r.resets {
+		if len(filter.Emails) > 0 && !slices.Contains(filter.Emails, reset.Email) {
+			continue
+		}
+
+		resets = append(resets, r.copyReset(reset))
+	}
+
+	r.sortResets(resets, order)
+
+	return r.applyPagination(resets, pagination), nil
+}
+
+func (r *PasswordResetRepository) Save(_ context.Context, reset *domain.PasswordReset) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := r.copyReset(reset)
+	r.resets[reset.Email] = &stored
+
+	return nil
+}
+
+func (r *PasswordResetRepository) Delete(_ context.Context, email string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.resets, email)
+
+	return nil
+}
+
+func (r *PasswordResetRepository) copyReset(reset *domain.PasswordReset) domain.PasswordReset {
+	c := *reset
+
+	if reset.CreatedAt != nil {
+		c.CreatedAt = lo.ToPtr(*reset.CreatedAt)
+	}
+
+	return c
+}
+
+func (r *PasswordResetRepository) sortResets(resets []domain.PasswordReset, order []filters.Sorting) {
+	if len(order) == 0 {
+		sort.Slice(resets, func(i, j int) bool {
+			return r.compareResets(&resets[i], &resets[j], "created_at") > 0
+		})
+
+		return
+	}
+
+	sort.Slice(resets, func(i, j int) bool {
+		for _, o := range order {
+			cmpRes := r.compareResets(&resets[i], &resets[j], o.Field)
+			if cmpRes != 0 {
+				if o.Direction == filters.SortDirectionDesc {
+					return cmpRes > 0
+				}
+
+				return cmpRes < 0
+			}
+		}
+
+		return false
+	})
+}
+
+func (r *PasswordResetRepository) compareResets(a, b *domain.PasswordReset, field string) int {
+	switch field {
+	case "email":
+		return cmp.Compare(a.Email, b.Email)
+	case "created_at":
+		switch {
+		case a.CreatedAt == nil && b.CreatedAt == nil:
+			return 0
+		case a.CreatedAt == nil:
+			return -1
+		case b.CreatedAt == nil:
+			return 1
+		default:
+			return a.CreatedAt.Compare(*b.CreatedAt)
+		}
+	default:
+		return 0
+	}
+}
+
+func (r *PasswordResetRepository) applyPagination(
+	resets []domain.PasswordReset,
+	pagination *filters.Pagination,
+) []domain.PasswordReset {
+	if pagination == nil {
+		return resets
+	}
+
+	limit := pagination.Limit
+	if limit <= 0 {
+		limit = filters.DefaultLimit
+	}
+
+	offset := max(pagination.Offset, 0)
+
+	if offset >= len(resets) {
+		return []domain.PasswordReset{}
+	}
+
+	end := min(offset+limit, len(resets))
+
+	return resets[offset:end]
+}
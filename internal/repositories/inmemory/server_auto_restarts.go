@@ -0,0 +1,153 @@
+package inmemory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/samber/lo"
+)
+
+type ServerAutoRestartRepository struct {
+	mu       sync.RWMutex
+	restarts map[uint]*domain.ServerAutoRestart // serverID -> restart
+}
+
+func NewServerAutoRestartRepository() *ServerAutoRestartRepository {
+	return &ServerAutoRestartRepository{
+		restarts: make(map[uint]*domain.ServerAutoRestart),
+	}
+}
+
+func (r *ServerAutoRestartRepository) Find(
+	_ context.Context,
+	filter *filters.FindServerAutoRestart,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.ServerAutoRestart, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if filter == nil {
+		filter = &filters.FindServerAutoRestart{}
+	}
+
+	restarts := make([]domain.ServerAutoRestart, 0, len(r.restarts))
+	for _, restart := range r.restarts {
+		if len(filter.ServerIDs) > 0 && !slices.Contains(filter.ServerIDs, restart.ServerID) {
+			continue
+		}
+
+		if filter.CrashLoop != nil && restart.CrashLoop != *filter.CrashLoop {
+			continue
+		}
+
+		restarts = append(restarts, r.copyRestart(restart))
+	}
+
+	r.sortRestarts(restarts, order)
+
+	return r.applyPagination(restarts, pagination), nil
+}
+
+func (r *ServerAutoRestartRepository) Save(_ context.Context, restart *domain.ServerAutoRestart) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := r.copyRestart(restart)
+	r.restarts[restart.ServerID] = &stored
+
+	return nil
+}
+
+// copyRestart copies the restart together with its pointer values,
+// so stored restarts can't be changed by callers.
+func (r *ServerAutoRestartRepository) copyRestart(restart *domain.ServerAutoRestart) domain.ServerAutoRestart {
+	c := *restart
+
+	if restart.CrashedAt != nil {
+		c.CrashedAt = lo.ToPtr(*restart.CrashedAt)
+	}
+
+	if restart.LastAttemptAt != nil {
+		c.LastAttemptAt = lo.ToPtr(*restart.LastAttemptAt)
+	}
+
+	if restart.LastTaskID != nil {
+		c.LastTaskID = lo.ToPtr(*restart.LastTaskID)
+	}
+
+	if restart.CrashLoopAt != nil {
+		c.CrashLoopAt = lo.ToPtr(*restart.CrashLoopAt)
+	}
+
+	return c
+}
+
+func (r *ServerAutoRestartRepository) sortRestarts(restarts []domain.ServerAutoRestart, order []filters.Sorting) {
+	if len(order) == 0 {
+		sort.Slice(restarts, func(i, j int) bool {
+			return restarts[i].ServerID < restarts[j].ServerID
+		})
+
+		return
+	}
+
+	sort.Slice(restarts, func(i, j int) bool {
+		for _, o := range order {
+			cmpRes := r.compareRestarts(&restarts[i], &restarts[j], o.Field)
+			if cmpRes != 0 {
+				if o.Direction == filters.SortDirectionDesc {
+					return cmpRes > 0
+				}
+
+				return cmpRes < 0
+			}
+		}
+
+		return false
+	})
+}
+
+func (r *ServerAutoRestartRepository) compareRestarts(a, b *domain.ServerAutoRestart, field string) int {
+	switch field {
+	case "server_id":
+		return cmp.Compare(a.ServerID, b.ServerID)
+	case "attempts":
+		return cmp.Compare(a.Attempts, b.Attempts)
+	case "crash_loops":
+		return cmp.Compare(a.CrashLoops, b.CrashLoops)
+	case "updated_at":
+		return a.UpdatedAt.Compare(b.UpdatedAt)
+	default:
+		return 0
+	}
+}
+
+func (r *ServerAutoRestartRepository) applyPagination(
+	restarts []domain.ServerAutoRestart,
+	pagination *filters.Pagination,
+) []domain.ServerAutoRestart {
+	if pagination == nil {
+		return restarts
+	}
+
+	limit := pagination.Limit
+	if limit <= 0 {
+		limit = filters.DefaultLimit
+	}
+
+	offset := max(pagination.Offset, 0)
+
+	if offset >= len(restarts) {
+		return []domain.ServerAutoRestart{}
+	}
+
+	end := min(offset+limit, len(restarts))
+
+	return restarts[offset:end]
+}
@@ -0,0 +1,164 @@
+package inmemory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+)
+
+type serverQueryRecordKey struct {
+	serverID  uint
+	queriedAt time.Time
+}
+
+type ServerQueryRecordRepository struct {
+	mu      sync.RWMutex
+	records map[serverQueryRecordKey]*domain.ServerQueryRecord
+}
+
+func NewServerQueryRecordRepository() *ServerQueryRecordRepository {
+	return &ServerQueryRecordRepository{
+		records: make(map[serverQueryRecordKey]*domain.ServerQueryRecord),
+	}
+}
+
+func (r *ServerQueryRecordRepository) Find(
+	_ context.Context,
+	filter *filters.FindServerQueryRecord,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.ServerQueryRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if filter == nil {
+		filter = &filters.FindServerQueryRecord{}
+	}
+
+	records := make([]domain.ServerQueryRecord, 0, len(r.records))
+	for _, record := range r.records {
+		if r.matchesFilter(record, filter) {
+			records = append(records, *record)
+		}
+	}
+
+	r.sortRecords(records, order)
+
+	return r.applyPagination(records, pagination), nil
+}
+
+func (r *ServerQueryRecordRepository) SaveBulk(_ context.Context, records []*domain.ServerQueryRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, record := range records {
+		stored := *record
+		stored.QueriedAt = stored.QueriedAt.UTC()
+
+		r.records[serverQueryRecordKey{
+			serverID:  stored.ServerID,
+			queriedAt: stored.QueriedAt,
+		}] = &stored
+	}
+
+	return nil
+}
+
+func (r *ServerQueryRecordRepository) DeleteBefore(_ context.Context, before time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, record := range r.records {
+		if record.QueriedAt.Before(before) {
+			delete(r.records, key)
+		}
+	}
+
+	return nil
+}
+
+func (r *ServerQueryRecordRepository) matchesFilter(
+	record *domain.ServerQueryRecord,
+	filter *filters.FindServerQueryRecord,
+) bool {
+	if len(filter.ServerIDs) > 0 && !slices.Contains(filter.ServerIDs, record.ServerID) {
+		return false
+	}
+
+	if filter.QueriedFrom != nil && record.QueriedAt.Before(*filter.QueriedFrom) {
+		return false
+	}
+
+	if filter.QueriedBefore != nil && !record.QueriedAt.Before(*filter.QueriedBefore) {
+		return false
+	}
+
+	return true
+}
+
+func (r *ServerQueryRecordRepository) sortRecords(records []domain.ServerQueryRecord, order []filters.Sorting) {
+	if len(order) == 0 {
+		order = []filters.Sorting{
+			{Field: "server_id", Direction: filters.SortDirectionAsc},
+			{Field: "queried_at", Direction: filters.SortDirectionAsc},
+		}
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		for _, o := range order {
+			cmpRes := r.compareRecords(&records[i], &records[j], o.Field)
+			if cmpRes != 0 {
+				if o.Direction == filters.SortDirectionDesc {
+					return cmpRes > 0
+				}
+
+				return cmpRes < 0
+			}
+		}
+
+		return false
+	})
+}
+
+func (r *ServerQueryRecordRepository) compareRecords(a, b *domain.ServerQueryRecord, field string) int {
+	switch field {
+	case "server_id":
+		return cmp.Compare(a.ServerID, b.ServerID)
+	case "queried_at":
+		return a.QueriedAt.Compare(b.QueriedAt)
+	case "players_num":
+		return cmp.Compare(a.PlayersNum, b.PlayersNum)
+	default:
+		return 0
+	}
+}
+
+func (r *ServerQueryRecordRepository) applyPagination(
+	records []domain.ServerQueryRecord,
+	pagination *filters.Pagination,
+) []domain.ServerQueryRecord {
+	if pagination == nil {
+		return records
+	}
+
+	limit := pagination.Limit
+	if limit <= 0 {
+		limit = filters.DefaultLimit
+	}
+
+	offset := max(pagination.Offset, 0)
+
+	if offset >= len(records) {
+		return []domain.ServerQueryRecord{}
+	}
+
+	end := min(offset+limit, len(records))
+
+	return records[offset:end]
+}
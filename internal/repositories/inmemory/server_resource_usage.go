@@ -0,0 +1,141 @@
+package inmemory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/samber/lo"
+)
+
+type ServerResourceUsageRepository struct {
+	mu     sync.RWMutex
+	usages map[uint]*domain.ServerResourceUsage // serverID -> usage
+}
+
+func NewServerResourceUsageRepository() *ServerResourceUsageRepository {
+	return &ServerResourceUsageRepository{
+		usages: make(map[uint]*domain.ServerResourceUsage),
+	}
+}
+
+func (r *ServerResourceUsageRepository) Find(
+	_ context.Context,
+	filter *filters.FindServerResourceUsage,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.ServerResourceUsage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if filter == nil {
+		filter = &filters.FindServerResourceUsage{}
+	}
+
+	usages := make([]domain.ServerResourceUsage, 0, len(r.usages))
+	for _, usage := range r.usages {
+		if len(filter.ServerIDs) > 0 && !slices.Contains(filter.ServerIDs, usage.ServerID) {
+			continue
+		}
+
+		usages = append(usages, r.copyUsage(usage))
+	}
+
+	r.sortUsages(usages, order)
+
+	return r.applyPagination(usages, pagination), nil
+}
+
+func (r *ServerResourceUsageRepository) Save(_ context.Context, usage *domain.ServerResourceUsage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := r.copyUsage(usage)
+	r.usages[usage.ServerID] = &stored
+
+	return nil
+}
+
+// copyUsage copies the usage together with its values,
+// so stored usages can't be changed by callers.
+func (r *ServerResourceUsageRepository) copyUsage(usage *domain.ServerResourceUsage) domain.ServerResourceUsage {
+	c := *usage
+
+	if usage.CPU != nil {
+		c.CPU = lo.ToPtr(*usage.CPU)
+	}
+
+	if usage.RAM != nil {
+		c.RAM = lo.ToPtr(*usage.RAM)
+	}
+
+	if usage.Net != nil {
+		c.Net = lo.ToPtr(*usage.Net)
+	}
+
+	return c
+}
+
+func (r *ServerResourceUsageRepository) sortUsages(usages []domain.ServerResourceUsage, order []filters.Sorting) {
+	if len(order) == 0 {
+		sort.Slice(usages, func(i, j int) bool {
+			return usages[i].ServerID < usages[j].ServerID
+		})
+
+		return
+	}
+
+	sort.Slice(usages, func(i, j int) bool {
+		for _, o := range order {
+			cmpRes := r.compareUsages(&usages[i], &usages[j], o.Field)
+			if cmpRes != 0 {
+				if o.Direction == filters.SortDirectionDesc {
+					return cmpRes > 0
+				}
+
+				return cmpRes < 0
+			}
+		}
+
+		return false
+	})
+}
+
+func (r *ServerResourceUsageRepository) compareUsages(a, b *domain.ServerResourceUsage, field string) int {
+	switch field {
+	case "server_id":
+		return cmp.Compare(a.ServerID, b.ServerID)
+	case "reported_at":
+		return a.ReportedAt.Compare(b.ReportedAt)
+	default:
+		return 0
+	}
+}
+
+func (r *ServerResourceUsageRepository) applyPagination(
+	usages []domain.ServerResourceUsage,
+	pagination *filters.Pagination,
+) []domain.ServerResourceUsage {
+	if pagination == nil {
+		return usages
+	}
+
+	limit := pagination.Limit
+	if limit <= 0 {
+		limit = filters.DefaultLimit
+	}
+
+	offset := max(pagination.Offset, 0)
+
+	if offset >= len(usages) {
+		return []domain.ServerResourceUsage{}
+	}
+
+	end := min(offset+limit, len(usages))
+
+	return usages[offset:end]
+}
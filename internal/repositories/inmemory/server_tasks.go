@@ -100,16 +100,18 @@ func (r *ServerTaskRepository) Save(_ context.Context, task *domain.ServerTask)
 	}
 
 	r.tasks[task.ID] = &domain.ServerTask{
-		ID:           task.ID,
-		Command:      task.Command,
-		ServerID:     task.ServerID,
-		Repeat:       task.Repeat,
-		RepeatPeriod: task.RepeatPeriod,
-		Counter:      task.Counter,
-		ExecuteDate:  task.ExecuteDate,
-		Payload:      task.Payload,
-		CreatedAt:    task.CreatedAt,
-		UpdatedAt:    task.UpdatedAt,
+		ID:             task.ID,
+		Command:        task.Command,
+		ServerID:       task.ServerID,
+		Repeat:         task.Repeat,
+		RepeatPeriod:   task.RepeatPeriod,
+		Counter:        task.Counter,
+		ExecuteDate:    task.ExecuteDate,
+		Payload:        task.Payload,
+		CronExpression: task.CronExpression,
+		Timezone:       task.Timezone,
+		CreatedAt:      task.CreatedAt,
+		UpdatedAt:      task.UpdatedAt,
 	}
 
 	r.addToIndexes(r.tasks[task.ID])
@@ -0,0 +1,169 @@
+package inmemory
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/samber/lo"
+)
+
+type ServerTemplateRepository struct {
+	mu        sync.RWMutex
+	templates map[uint]*domain.ServerTemplate
+	nextID    uint32
+}
+
+func NewServerTemplateRepository() *ServerTemplateRepository {
+	return &ServerTemplateRepository{
+		templates: make(map[uint]*domain.ServerTemplate),
+	}
+}
+
+func (r *ServerTemplateRepository) Find(
+	_ context.Context,
+	filter *filters.FindServerTemplate,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.ServerTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if filter == nil {
+		filter = &filters.FindServerTemplate{}
+	}
+
+	templates := make([]domain.ServerTemplate, 0, len(r.templates))
+	for _, template := range r.templates {
+		if r.matchesFilter(template, filter) {
+			templates = append(templates, r.copyTemplate(template))
+		}
+	}
+
+	r.sortTemplates(templates, order)
+
+	return r.applyPagination(templates, pagination), nil
+}
+
+func (r *ServerTemplateRepository) Save(_ context.Context, template *domain.ServerTemplate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	template.UpdatedAt = lo.ToPtr(time.Now())
+
+	if template.ID == 0 && (template.CreatedAt == nil || template.CreatedAt.IsZero()) {
+		template.CreatedAt = lo.ToPtr(time.Now())
+	}
+
+	if template.ID == 0 {
+		template.ID = uint(atomic.AddUint32(&r.nextID, 1))
+	}
+
+	stored := r.copyTemplate(template)
+	r.templates[template.ID] = &stored
+
+	return nil
+}
+
+func (r *ServerTemplateRepository) Delete(_ context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.templates, id)
+
+	return nil
+}
+
+// copyTemplate copies the template together with its settings map,
+// so stored templates can't be changed by callers.
+func (r *ServerTemplateRepository) copyTemplate(template *domain.ServerTemplate) domain.ServerTemplate {
+	c := *template
+	c.Settings = maps.Clone(template.Settings)
+
+	return c
+}
+
+func (r *ServerTemplateRepository) matchesFilter(
+	template *domain.ServerTemplate,
+	filter *filters.FindServerTemplate,
+) bool {
+	if len(filter.IDs) > 0 && !slices.Contains(filter.IDs, template.ID) {
+		return false
+	}
+
+	if len(filter.GameIDs) > 0 && !slices.Contains(filter.GameIDs, template.GameID) {
+		return false
+	}
+
+	return true
+}
+
+func (r *ServerTemplateRepository) sortTemplates(templates []domain.ServerTemplate, order []filters.Sorting) {
+	if len(order) == 0 {
+		sort.Slice(templates, func(i, j int) bool {
+			return templates[i].ID < templates[j].ID
+		})
+
+		return
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		for _, o := range order {
+			cmpRes := r.compareTemplates(&templates[i], &templates[j], o.Field)
+			if cmpRes != 0 {
+				if o.Direction == filters.SortDirectionDesc {
+					return cmpRes > 0
+				}
+
+				return cmpRes < 0
+			}
+		}
+
+		return false
+	})
+}
+
+func (r *ServerTemplateRepository) compareTemplates(a, b *domain.ServerTemplate, field string) int {
+	switch field {
+	case "id":
+		return cmp.Compare(a.ID, b.ID)
+	case "name":
+		return strings.Compare(a.Name, b.Name)
+	case "game_id":
+		return strings.Compare(a.GameID, b.GameID)
+	default:
+		return 0
+	}
+}
+
+func (r *ServerTemplateRepository) applyPagination(
+	templates []domain.ServerTemplate,
+	pagination *filters.Pagination,
+) []domain.ServerTemplate {
+	if pagination == nil {
+		return templates
+	}
+
+	limit := pagination.Limit
+	if limit <= 0 {
+		limit = filters.DefaultLimit
+	}
+
+	offset := max(pagination.Offset, 0)
+
+	if offset >= len(templates) {
+		return []domain.ServerTemplate{}
+	}
+
+	end := min(offset+limit, len(templates))
+
+	return templates[offset:end]
+}
@@ -0,0 +1,18 @@
+package inmemory_test
+
+import (
+	"testing"
+
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	repotesting "github.com/gameap/gameap/internal/repositories/testing"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestServerTemplateRepository(t *testing.T) {
+	suite.Run(t, repotesting.NewServerTemplateRepositorySuite(
+		func(_ *testing.T) repositories.ServerTemplateRepository {
+			return inmemory.NewServerTemplateRepository()
+		},
+	))
+}
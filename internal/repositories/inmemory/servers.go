@@ -685,10 +685,22 @@ func (r *ServerRepository) getFilteredServerIDs(filter *filters.FindServer) map[
 		len(filter.DSIDs) > 0) {
 		r.intersectWithNames(resultIDs, filter.Names)
 	}
+	if filter.ExpiresBefore != nil {
+		r.filterExpiresBefore(resultIDs, *filter.ExpiresBefore)
+	}
 
 	return resultIDs
 }
 
+func (r *ServerRepository) filterExpiresBefore(resultIDs map[uint]struct{}, before time.Time) {
+	for id := range resultIDs {
+		server, exists := r.servers[id]
+		if !exists || server.Expires == nil || !server.Expires.Before(before) {
+			delete(resultIDs, id)
+		}
+	}
+}
+
 func (r *ServerRepository) intersectWithUUIDs(resultIDs map[uint]struct{}, uuids []uuid.UUID) {
 	validIDs := make(map[uint]struct{})
 	for _, u := range uuids {
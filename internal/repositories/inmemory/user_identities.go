@@ -0,0 +1,168 @@
+package inmemory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/samber/lo"
+)
+
+type userIdentityKey struct {
+	provider string
+	subject  string
+}
+
+type UserIdentityRepository struct {
+	mu         sync.RWMutex
+	identities map[userIdentityKey]*domain.UserIdentity
+}
+
+func NewUserIdentityRepository() *UserIdentityRepository {
+	return &UserIdentityRepository{
+		identities: make(map[userIdentityKey]*domain.UserIdentity),
+	}
+}
+
+func (r *UserIdentityRepository) Find(
+	_ context.Context,
+	filter *filters.FindUserIdentity,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.UserIdentity, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if filter == nil {
+		filter = &filters.FindUserIdentity{}
+	}
+
+	identities := make([]domain.UserIdentity, 0, len(r.identities))
+	for _, identity := range r.identities {
+		if len(filter.UserIDs) > 0 && !slices.Contains(filter.UserIDs, identity.UserID) {
+			continue
+		}
+
+		if len(filter.Providers) > 0 && !slices.Contains(filter.Providers, identity.Provider) {
+			continue
+		}
+
+		if len(filter.Subjects) > 0 && !slices.Contains(filter.Subjects, identity.Subject) {
+			continue
+		}
+
+		identities = append(identities, r.copyIdentity(identity))
+	}
+
+	r.sortIdentities(identities, order)
+
+	return r.applyPagination(identities, pagination), nil
+}
+
+func (r *UserIdentityRepository) Save(_ context.Context, identity *domain.UserIdentity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := userIdentityKey{provider: identity.Provider, subject: identity.Subject}
+
+	stored := r.copyIdentity(identity)
+	if existing, ok := r.identities[key]; ok {
+		stored.CreatedAt = existing.CreatedAt
+	}
+
+	r.identities[key] = &stored
+
+	return nil
+}
+
+func (r *UserIdentityRepository) Delete(_ context.Context, userID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, identity := range r.identities {
+		if identity.UserID == userID {
+			delete(r.identities, key)
+		}
+	}
+
+	return nil
+}
+
+func (r *UserIdentityRepository) copyIdentity(identity *domain.UserIdentity) domain.UserIdentity {
+	c := *identity
+
+	if identity.CreatedAt != nil {
+		c.CreatedAt = lo.ToPtr(*identity.CreatedAt)
+	}
+
+	if identity.UpdatedAt != nil {
+		c.UpdatedAt = lo.ToPtr(*identity.UpdatedAt)
+	}
+
+	return c
+}
+
+func (r *UserIdentityRepository) sortIdentities(identities []domain.UserIdentity, order []filters.Sorting) {
+	if len(order) == 0 {
+		order = []filters.Sorting{
+			{Field: "provider", Direction: filters.SortDirectionAsc},
+			{Field: "subject", Direction: filters.SortDirectionAsc},
+		}
+	}
+
+	sort.Slice(identities, func(i, j int) bool {
+		for _, o := range order {
+			cmpRes := r.compareIdentities(&identities[i], &identities[j], o.Field)
+			if cmpRes != 0 {
+				if o.Direction == filters.SortDirectionDesc {
+					return cmpRes > 0
+				}
+
+				return cmpRes < 0
+			}
+		}
+
+		return false
+	})
+}
+
+func (r *UserIdentityRepository) compareIdentities(a, b *domain.UserIdentity, field string) int {
+	switch field {
+	case "provider":
+		return cmp.Compare(a.Provider, b.Provider)
+	case "subject":
+		return cmp.Compare(a.Subject, b.Subject)
+	case "user_id":
+		return cmp.Compare(a.UserID, b.UserID)
+	default:
+		return 0
+	}
+}
+
+func (r *UserIdentityRepository) applyPagination(
+	identities []domain.UserIdentity,
+	pagination *filters.Pagination,
+) []domain.UserIdentity {
+	if pagination == nil {
+		return identities
+	}
+
+	limit := pagination.Limit
+	if limit <= 0 {
+		limit = filters.DefaultLimit
+	}
+
+	offset := max(pagination.Offset, 0)
+
+	if offset >= len(identities) {
+		return []domain.UserIdentity{}
+	}
+
+	end := min(offset+limit, len(identities))
+
+	return identities[offset:end]
+}
@@ -0,0 +1,152 @@
+package inmemory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/samber/lo"
+)
+
+type UserTwoFactorRepository struct {
+	mu         sync.RWMutex
+	twoFactors map[uint]*domain.UserTwoFactor // userID -> two-factor
+}
+
+func NewUserTwoFactorRepository() *UserTwoFactorRepository {
+	return &UserTwoFactorRepository{
+		twoFactors: make(map[uint]*domain.UserTwoFactor),
+	}
+}
+
+func (r *UserTwoFactorRepository) Find(
+	_ context.Context,
+	filter *filters.FindUserTwoFactor,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.UserTwoFactor, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if filter == nil {
+		filter = &filters.FindUserTwoFactor{}
+	}
+
+	twoFactors := make([]domain.UserTwoFactor, 0, len(r.twoFactors))
+	for _, twoFactor := range r.twoFactors {
+		if len(filter.UserIDs) > 0 && !slices.Contains(filter.UserIDs, twoFactor.UserID) {
+			continue
+		}
+
+		twoFactors = append(twoFactors, r.copyTwoFactor(twoFactor))
+	}
+
+	r.sortTwoFactors(twoFactors, order)
+
+	return r.applyPagination(twoFactors, pagination), nil
+}
+
+func (r *UserTwoFactorRepository) Save(_ context.Context, twoFactor *domain.UserTwoFactor) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := r.copyTwoFactor(twoFactor)
+	r.twoFactors[twoFactor.UserID] = &stored
+
+	return nil
+}
+
+func (r *UserTwoFactorRepository) Delete(_ context.Context, userID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.twoFactors, userID)
+
+	return nil
+}
+
+func (r *UserTwoFactorRepository) copyTwoFactor(twoFactor *domain.UserTwoFactor) domain.UserTwoFactor {
+	c := *twoFactor
+
+	if twoFactor.ConfirmedAt != nil {
+		c.ConfirmedAt = lo.ToPtr(*twoFactor.ConfirmedAt)
+	}
+
+	if twoFactor.RecoveryCodes != nil {
+		c.RecoveryCodes = slices.Clone(twoFactor.RecoveryCodes)
+	}
+
+	if twoFactor.CreatedAt != nil {
+		c.CreatedAt = lo.ToPtr(*twoFactor.CreatedAt)
+	}
+
+	if twoFactor.UpdatedAt != nil {
+		c.UpdatedAt = lo.ToPtr(*twoFactor.UpdatedAt)
+	}
+
+	return c
+}
+
+func (r *UserTwoFactorRepository) sortTwoFactors(twoFactors []domain.UserTwoFactor, order []filters.Sorting) {
+	if len(order) == 0 {
+		sort.Slice(twoFactors, func(i, j int) bool {
+			return twoFactors[i].UserID < twoFactors[j].UserID
+		})
+
+		return
+	}
+
+	sort.Slice(twoFactors, func(i, j int) bool {
+		for _, o := range order {
+			cmpRes := r.compareTwoFactors(&twoFactors[i], &twoFactors[j], o.Field)
+			if cmpRes != 0 {
+				if o.Direction == filters.SortDirectionDesc {
+					return cmpRes > 0
+				}
+
+				return cmpRes < 0
+			}
+		}
+
+		return false
+	})
+}
+
+func (r *UserTwoFactorRepository) compareTwoFactors(a, b *domain.UserTwoFactor, field string) int {
+	switch field {
+	case "user_id":
+		return cmp.Compare(a.UserID, b.UserID)
+	case "last_used_step":
+		return cmp.Compare(a.LastUsedStep, b.LastUsedStep)
+	default:
+		return 0
+	}
+}
+
+func (r *UserTwoFactorRepository) applyPagination(
+	twoFactors []domain.UserTwoFactor,
+	pagination *filters.Pagination,
+) []domain.UserTwoFactor {
+	if pagination == nil {
+		return twoFactors
+	}
+
+	limit := pagination.Limit
+	if limit <= 0 {
+		limit = filters.DefaultLimit
+	}
+
+	offset := max(pagination.Offset, 0)
+
+	if offset >= len(twoFactors) {
+		return []domain.UserTwoFactor{}
+	}
+
+	end := min(offset+limit, len(twoFactors))
+
+	return twoFactors[offset:end]
+}
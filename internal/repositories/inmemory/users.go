@@ -115,6 +115,7 @@ func (r *UserRepository) Save(_ context.Context, user *domain.User) error {
 		Name:          user.Name,
 		CreatedAt:     user.CreatedAt,
 		UpdatedAt:     user.UpdatedAt,
+		DisabledAt:    user.DisabledAt,
 	}
 
 	return nil
@@ -0,0 +1,185 @@
+package inmemory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/samber/lo"
+)
+
+type WebhookDeliveryRepository struct {
+	mu         sync.RWMutex
+	deliveries map[uint]*domain.WebhookDelivery
+	nextID     uint32
+}
+
+func NewWebhookDeliveryRepository() *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{
+		deliveries: make(map[uint]*domain.WebhookDelivery),
+	}
+}
+
+func (r *WebhookDeliveryRepository) Find(
+	_ context.Context,
+	filter *filters.FindWebhookDelivery,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if filter == nil {
+		filter = &filters.FindWebhookDelivery{}
+	}
+
+	deliveries := make([]domain.WebhookDelivery, 0, len(r.deliveries))
+	for _, delivery := range r.deliveries {
+		if r.matchesFilter(delivery, filter) {
+			deliveries = append(deliveries, *delivery)
+		}
+	}
+
+	r.sortDeliveries(deliveries, order)
+
+	return r.applyPagination(deliveries, pagination), nil
+}
+
+func (r *WebhookDeliveryRepository) Save(_ context.Context, delivery *domain.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delivery.UpdatedAt = lo.ToPtr(time.Now())
+
+	if delivery.ID == 0 && (delivery.CreatedAt == nil || delivery.CreatedAt.IsZero()) {
+		delivery.CreatedAt = lo.ToPtr(time.Now())
+	}
+
+	if delivery.ID == 0 {
+		delivery.ID = uint(atomic.AddUint32(&r.nextID, 1))
+	}
+
+	stored := *delivery
+	r.deliveries[delivery.ID] = &stored
+
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) DeleteBefore(_ context.Context, before time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, delivery := range r.deliveries {
+		if delivery.CreatedAt != nil && delivery.CreatedAt.Before(before) {
+			delete(r.deliveries, id)
+		}
+	}
+
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) DeleteByWebhookID(_ context.Context, webhookID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, delivery := range r.deliveries {
+		if delivery.WebhookID == webhookID {
+			delete(r.deliveries, id)
+		}
+	}
+
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) matchesFilter(
+	delivery *domain.WebhookDelivery,
+	filter *filters.FindWebhookDelivery,
+) bool {
+	if len(filter.IDs) > 0 && !slices.Contains(filter.IDs, delivery.ID) {
+		return false
+	}
+
+	if len(filter.WebhookIDs) > 0 && !slices.Contains(filter.WebhookIDs, delivery.WebhookID) {
+		return false
+	}
+
+	if len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, delivery.Status) {
+		return false
+	}
+
+	if filter.NextAttemptBefore != nil &&
+		(delivery.NextAttemptAt == nil || delivery.NextAttemptAt.After(*filter.NextAttemptBefore)) {
+		return false
+	}
+
+	return true
+}
+
+func (r *WebhookDeliveryRepository) sortDeliveries(deliveries []domain.WebhookDelivery, order []filters.Sorting) {
+	if len(order) == 0 {
+		sort.Slice(deliveries, func(i, j int) bool {
+			return deliveries[i].ID < deliveries[j].ID
+		})
+
+		return
+	}
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		for _, o := range order {
+			cmpRes := r.compareDeliveries(&deliveries[i], &deliveries[j], o.Field)
+			if cmpRes != 0 {
+				if o.Direction == filters.SortDirectionDesc {
+					return cmpRes > 0
+				}
+
+				return cmpRes < 0
+			}
+		}
+
+		return false
+	})
+}
+
+func (r *WebhookDeliveryRepository) compareDeliveries(a, b *domain.WebhookDelivery, field string) int {
+	switch field {
+	case "id":
+		return cmp.Compare(a.ID, b.ID)
+	case "status":
+		return strings.Compare(string(a.Status), string(b.Status))
+	case "created_at":
+		return lo.FromPtr(a.CreatedAt).Compare(lo.FromPtr(b.CreatedAt))
+	default:
+		return 0
+	}
+}
+
+func (r *WebhookDeliveryRepository) applyPagination(
+	deliveries []domain.WebhookDelivery,
+	pagination *filters.Pagination,
+) []domain.WebhookDelivery {
+	if pagination == nil {
+		return deliveries
+	}
+
+	limit := pagination.Limit
+	if limit <= 0 {
+		limit = filters.DefaultLimit
+	}
+
+	offset := max(pagination.Offset, 0)
+
+	if offset >= len(deliveries) {
+		return []domain.WebhookDelivery{}
+	}
+
+	end := min(offset+limit, len(deliveries))
+
+	return deliveries[offset:end]
+}
@@ -0,0 +1,167 @@
+package inmemory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/samber/lo"
+)
+
+type WebhookRepository struct {
+	mu       sync.RWMutex
+	webhooks map[uint]*domain.Webhook
+	nextID   uint32
+}
+
+func NewWebhookRepository() *WebhookRepository {
+	return &WebhookRepository{
+		webhooks: make(map[uint]*domain.Webhook),
+	}
+}
+
+func (r *WebhookRepository) Find(
+	_ context.Context,
+	filter *filters.FindWebhook,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if filter == nil {
+		filter = &filters.FindWebhook{}
+	}
+
+	webhooks := make([]domain.Webhook, 0, len(r.webhooks))
+	for _, webhook := range r.webhooks {
+		if r.matchesFilter(webhook, filter) {
+			webhooks = append(webhooks, r.copyWebhook(webhook))
+		}
+	}
+
+	r.sortWebhooks(webhooks, order)
+
+	return r.applyPagination(webhooks, pagination), nil
+}
+
+func (r *WebhookRepository) Save(_ context.Context, webhook *domain.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	webhook.UpdatedAt = lo.ToPtr(time.Now())
+
+	if webhook.ID == 0 && (webhook.CreatedAt == nil || webhook.CreatedAt.IsZero()) {
+		webhook.CreatedAt = lo.ToPtr(time.Now())
+	}
+
+	if webhook.ID == 0 {
+		webhook.ID = uint(atomic.AddUint32(&r.nextID, 1))
+	}
+
+	stored := r.copyWebhook(webhook)
+	r.webhooks[webhook.ID] = &stored
+
+	return nil
+}
+
+func (r *WebhookRepository) Delete(_ context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.webhooks, id)
+
+	return nil
+}
+
+// copyWebhook copies the webhook together with its events,
+// so stored webhooks can't be changed by callers.
+func (r *WebhookRepository) copyWebhook(webhook *domain.Webhook) domain.Webhook {
+	c := *webhook
+	c.Events = slices.Clone(webhook.Events)
+
+	return c
+}
+
+func (r *WebhookRepository) matchesFilter(webhook *domain.Webhook, filter *filters.FindWebhook) bool {
+	if len(filter.IDs) > 0 && !slices.Contains(filter.IDs, webhook.ID) {
+		return false
+	}
+
+	if len(filter.UserIDs) > 0 && (webhook.UserID == nil || !slices.Contains(filter.UserIDs, *webhook.UserID)) {
+		return false
+	}
+
+	if filter.Enabled != nil && webhook.Enabled != *filter.Enabled {
+		return false
+	}
+
+	return true
+}
+
+func (r *WebhookRepository) sortWebhooks(webhooks []domain.Webhook, order []filters.Sorting) {
+	if len(order) == 0 {
+		sort.Slice(webhooks, func(i, j int) bool {
+			return webhooks[i].ID < webhooks[j].ID
+		})
+
+		return
+	}
+
+	sort.Slice(webhooks, func(i, j int) bool {
+		for _, o := range order {
+			cmpRes := r.compareWebhooks(&webhooks[i], &webhooks[j], o.Field)
+			if cmpRes != 0 {
+				if o.Direction == filters.SortDirectionDesc {
+					return cmpRes > 0
+				}
+
+				return cmpRes < 0
+			}
+		}
+
+		return false
+	})
+}
+
+func (r *WebhookRepository) compareWebhooks(a, b *domain.Webhook, field string) int {
+	switch field {
+	case "id":
+		return cmp.Compare(a.ID, b.ID)
+	case "name":
+		return strings.Compare(a.Name, b.Name)
+	default:
+		return 0
+	}
+}
+
+func (r *WebhookRepository) applyPagination(
+	webhooks []domain.Webhook,
+	pagination *filters.Pagination,
+) []domain.Webhook {
+	if pagination == nil {
+		return webhooks
+	}
+
+	limit := pagination.Limit
+	if limit <= 0 {
+		limit = filters.DefaultLimit
+	}
+
+	offset := max(pagination.Offset, 0)
+
+	if offset >= len(webhooks) {
+		return []domain.Webhook{}
+	}
+
+	end := min(offset+limit, len(webhooks))
+
+	return webhooks[offset:end]
+}
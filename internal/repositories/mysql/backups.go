@@ -0,0 +1,201 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/base"
+	"github.com/samber/lo"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+type BackupRepository struct {
+	db base.DB
+}
+
+func NewBackupRepository(db base.DB) *BackupRepository {
+	return &BackupRepository{
+		db: db,
+	}
+}
+
+func (r *BackupRepository) Find(
+	ctx context.Context,
+	filter *filters.FindBackup,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.Backup, error) {
+	builder := sq.Select(base.BackupFields...).
+		From(base.BackupsTable).
+		Where(r.filterToSq(filter))
+
+	if len(order) > 0 {
+		for _, o := range order {
+			builder = builder.OrderBy(o.String())
+		}
+	} else {
+		builder = builder.OrderBy("id ASC")
+	}
+
+	if pagination != nil {
+		if pagination.Limit <= 0 {
+			pagination.Limit = filters.DefaultLimit
+		}
+
+		if pagination.Offset < 0 {
+			pagination.Offset = 0
+		}
+
+		builder = builder.Limit(uint64(pagination.Limit)).Offset(uint64(pagination.Offset))
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to build query")
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...) //nolint:sqlclosecheck // closed in defer
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to execute query")
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to close rows stream", "query", query, "err", err)
+		}
+	}(rows)
+
+	var backups []domain.Backup
+
+	for rows.Next() {
+		var backup *domain.Backup
+		backup, err = r.scan(rows)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to scan row")
+		}
+
+		backups = append(backups, *backup)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.WithMessage(err, "rows iteration error")
+	}
+
+	return backups, nil
+}
+
+func (r *BackupRepository) Save(ctx context.Context, backup *domain.Backup) error {
+	backup.UpdatedAt = lo.ToPtr(time.Now())
+
+	if backup.ID == 0 && (backup.CreatedAt == nil || backup.CreatedAt.IsZero()) {
+		backup.CreatedAt = lo.ToPtr(time.Now())
+	}
+
+	query, args, err := sq.Insert(base.BackupsTable).
+		Columns(base.BackupFields...).
+		Values(
+			backup.ID,
+			backup.ServerID,
+			backup.Status,
+			backup.Path,
+			backup.Size,
+			backup.Error,
+			backup.CreatedAt,
+			backup.UpdatedAt,
+		).
+		Suffix("ON DUPLICATE KEY UPDATE " +
+			"server_id=VALUES(server_id)," +
+			"status=VALUES(status)," +
+			"path=VALUES(path)," +
+			"size=VALUES(size)," +
+			"error=VALUES(error)," +
+			"updated_at=VALUES(updated_at)").
+		PlaceholderFormat(sq.Question).
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	if backup.ID == 0 {
+		lastID, err := result.LastInsertId()
+		if err != nil {
+			return errors.WithMessage(err, "failed to get last insert ID")
+		}
+		if lastID < 0 {
+			return errors.New("invalid last insert ID")
+		}
+		backup.ID = uint(lastID)
+	}
+
+	return nil
+}
+
+func (r *BackupRepository) Delete(ctx context.Context, id uint) error {
+	query, args, err := sq.Delete(base.BackupsTable).
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Question).
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	return nil
+}
+
+func (r *BackupRepository) scan(row base.Scanner) (*domain.Backup, error) {
+	var backup domain.Backup
+
+	err := row.Scan(
+		&backup.ID,
+		&backup.ServerID,
+		&backup.Status,
+		&backup.Path,
+		&backup.Size,
+		&backup.Error,
+		&backup.CreatedAt,
+		&backup.UpdatedAt,
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to scan row")
+	}
+
+	return &backup, nil
+}
+
+func (r *BackupRepository) filterToSq(filter *filters.FindBackup) sq.Sqlizer {
+	if filter == nil {
+		return nil
+	}
+
+	and := make(sq.And, 0, 3)
+
+	if len(filter.IDs) > 0 {
+		and = append(and, sq.Eq{"id": filter.IDs})
+	}
+
+	if len(filter.ServerIDs) > 0 {
+		and = append(and, sq.Eq{"server_id": filter.ServerIDs})
+	}
+
+	if len(filter.Statuses) > 0 {
+		and = append(and, sq.Eq{"status": filter.Statuses})
+	}
+
+	return and
+}
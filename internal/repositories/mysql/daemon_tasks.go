@@ -215,22 +215,43 @@ func (r *DaemonTaskRepository) Delete(ctx context.Context, id uint) error {
 	return nil
 }
 
-func (r *DaemonTaskRepository) AppendOutput(ctx context.Context, id uint, output string) error {
+func (r *DaemonTaskRepository) AppendOutput(ctx context.Context, id uint, output string) (int, error) {
 	query, args, err := sq.Update(base.DaemonTasksTable).
 		Set("output", sq.Expr("CONCAT(IFNULL(output,''), ?)", output)).
 		Where(sq.Eq{"id": id}).
 		PlaceholderFormat(sq.Question).
 		ToSql()
 	if err != nil {
-		return errors.WithMessage(err, "failed to build query")
+		return 0, errors.WithMessage(err, "failed to build query")
 	}
 
 	_, err = r.db.ExecContext(ctx, query, args...)
 	if err != nil {
-		return errors.WithMessage(err, "failed to execute query")
+		return 0, errors.WithMessage(err, "failed to execute query")
 	}
 
-	return nil
+	// MySQL doesn't support RETURNING, the length is read by a separate query
+	query, args, err = sq.Select("LENGTH(output)").
+		From(base.DaemonTasksTable).
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Question).
+		ToSql()
+	if err != nil {
+		return 0, errors.WithMessage(err, "failed to build query")
+	}
+
+	var length int
+
+	err = r.db.QueryRowContext(ctx, query, args...).Scan(&length)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+
+		return 0, errors.WithMessage(err, "failed to execute query")
+	}
+
+	return length, nil
 }
 
 func (r *DaemonTaskRepository) Count(ctx context.Context, filter *filters.FindDaemonTask) (int, error) {
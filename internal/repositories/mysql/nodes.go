@@ -156,6 +156,8 @@ func (r *NodeRepository) Save(ctx context.Context, node *domain.Node) error {
 			node.ScriptGetConsole,
 			node.ScriptSendCommand,
 			node.ScriptDelete,
+			node.PortRangeStart,
+			node.PortRangeEnd,
 			node.CreatedAt,
 			node.UpdatedAt,
 			node.DeletedAt,
@@ -194,6 +196,8 @@ func (r *NodeRepository) Save(ctx context.Context, node *domain.Node) error {
 			"script_get_console=VALUES(script_get_console)," +
 			"script_send_command=VALUES(script_send_command)," +
 			"script_delete=VALUES(script_delete)," +
+			"port_range_start=VALUES(port_range_start)," +
+			"port_range_end=VALUES(port_range_end)," +
 			"updated_at=VALUES(updated_at)," +
 			"deleted_at=VALUES(deleted_at)").
 		PlaceholderFormat(sq.Question).
@@ -277,6 +281,8 @@ func (r *NodeRepository) scan(row base.Scanner) (*domain.Node, error) {
 		&node.ScriptGetConsole,
 		&node.ScriptSendCommand,
 		&node.ScriptDelete,
+		&node.PortRangeStart,
+		&node.PortRangeEnd,
 		&node.CreatedAt,
 		&node.UpdatedAt,
 		&node.DeletedAt,
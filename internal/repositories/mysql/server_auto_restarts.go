@@ -0,0 +1,165 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/base"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+type ServerAutoRestartRepository struct {
+	db base.DB
+}
+
+func NewServerAutoRestartRepository(db base.DB) *ServerAutoRestartRepository {
+	return &ServerAutoRestartRepository{
+		db: db,
+	}
+}
+
+func (r *ServerAutoRestartRepository) Find(
+	ctx context.Context,
+	filter *filters.FindServerAutoRestart,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.ServerAutoRestart, error) {
+	builder := sq.Select(base.ServerAutoRestartFields...).
+		From(base.ServerAutoRestartsTable).
+		Where(r.filterToSq(filter))
+
+	if len(order) > 0 {
+		for _, o := range order {
+			builder = builder.OrderBy(o.String())
+		}
+	} else {
+		builder = builder.OrderBy("server_id ASC")
+	}
+
+	if pagination != nil {
+		if pagination.Limit <= 0 {
+			pagination.Limit = filters.DefaultLimit
+		}
+
+		if pagination.Offset < 0 {
+			pagination.Offset = 0
+		}
+
+		builder = builder.Limit(uint64(pagination.Limit)).Offset(uint64(pagination.Offset))
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to build query")
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...) //nolint:sqlclosecheck // closed in defer
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to execute query")
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to close rows stream", "query", query, "err", err)
+		}
+	}(rows)
+
+	var restarts []domain.ServerAutoRestart
+
+	for rows.Next() {
+		var restart *domain.ServerAutoRestart
+		restart, err = r.scan(rows)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to scan row")
+		}
+
+		restarts = append(restarts, *restart)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.WithMessage(err, "rows iteration error")
+	}
+
+	return restarts, nil
+}
+
+func (r *ServerAutoRestartRepository) Save(ctx context.Context, restart *domain.ServerAutoRestart) error {
+	query, args, err := sq.Insert(base.ServerAutoRestartsTable).
+		Columns(base.ServerAutoRestartFields...).
+		Values(
+			restart.ServerID,
+			restart.CrashedAt,
+			restart.Attempts,
+			restart.LastAttemptAt,
+			restart.LastTaskID,
+			restart.CrashLoop,
+			restart.CrashLoopAt,
+			restart.CrashLoops,
+			restart.UpdatedAt,
+		).
+		Suffix("ON DUPLICATE KEY UPDATE " +
+			"crashed_at=VALUES(crashed_at)," +
+			"attempts=VALUES(attempts)," +
+			"last_attempt_at=VALUES(last_attempt_at)," +
+			"last_task_id=VALUES(last_task_id)," +
+			"crash_loop=VALUES(crash_loop)," +
+			"crash_loop_at=VALUES(crash_loop_at)," +
+			"crash_loops=VALUES(crash_loops)," +
+			"updated_at=VALUES(updated_at)").
+		PlaceholderFormat(sq.Question).
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	return nil
+}
+
+func (r *ServerAutoRestartRepository) scan(row base.Scanner) (*domain.ServerAutoRestart, error) {
+	var restart domain.ServerAutoRestart
+
+	err := row.Scan(
+		&restart.ServerID,
+		&restart.CrashedAt,
+		&restart.Attempts,
+		&restart.LastAttemptAt,
+		&restart.LastTaskID,
+		&restart.CrashLoop,
+		&restart.CrashLoopAt,
+		&restart.CrashLoops,
+		&restart.UpdatedAt,
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to scan row")
+	}
+
+	return &restart, nil
+}
+
+func (r *ServerAutoRestartRepository) filterToSq(filter *filters.FindServerAutoRestart) sq.Sqlizer {
+	if filter == nil {
+		return nil
+	}
+
+	and := make(sq.And, 0, 2)
+
+	if len(filter.ServerIDs) > 0 {
+		and = append(and, sq.Eq{"server_id": filter.ServerIDs})
+	}
+
+	if filter.CrashLoop != nil {
+		and = append(and, sq.Eq{"crash_loop": *filter.CrashLoop})
+	}
+
+	return and
+}
@@ -0,0 +1,186 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/base"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+type ServerQueryRecordRepository struct {
+	db base.DB
+}
+
+func NewServerQueryRecordRepository(db base.DB) *ServerQueryRecordRepository {
+	return &ServerQueryRecordRepository{
+		db: db,
+	}
+}
+
+func (r *ServerQueryRecordRepository) Find(
+	ctx context.Context,
+	filter *filters.FindServerQueryRecord,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.ServerQueryRecord, error) {
+	builder := sq.Select(base.ServerQueryRecordFields...).
+		From(base.ServerQueryRecordsTable).
+		Where(r.filterToSq(filter))
+
+	if len(order) > 0 {
+		for _, o := range order {
+			builder = builder.OrderBy(o.String())
+		}
+	} else {
+		builder = builder.OrderBy("server_id ASC", "queried_at ASC")
+	}
+
+	if pagination != nil {
+		if pagination.Limit <= 0 {
+			pagination.Limit = filters.DefaultLimit
+		}
+
+		if pagination.Offset < 0 {
+			pagination.Offset = 0
+		}
+
+		builder = builder.Limit(uint64(pagination.Limit)).Offset(uint64(pagination.Offset))
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to build query")
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...) //nolint:sqlclosecheck // closed in defer
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to execute query")
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to close rows stream", "query", query, "err", err)
+		}
+	}(rows)
+
+	var records []domain.ServerQueryRecord
+
+	for rows.Next() {
+		var record *domain.ServerQueryRecord
+		record, err = r.scan(rows)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to scan row")
+		}
+
+		records = append(records, *record)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.WithMessage(err, "rows iteration error")
+	}
+
+	return records, nil
+}
+
+func (r *ServerQueryRecordRepository) SaveBulk(ctx context.Context, records []*domain.ServerQueryRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	builder := sq.Insert(base.ServerQueryRecordsTable).
+		Columns(base.ServerQueryRecordFields...)
+
+	for _, record := range records {
+		builder = builder.Values(
+			record.ServerID,
+			record.QueriedAt.UTC(),
+			record.Online,
+			record.PlayersNum,
+			record.MaxPlayersNum,
+			record.Map,
+		)
+	}
+
+	query, args, err := builder.
+		Suffix("ON DUPLICATE KEY UPDATE " +
+			"online=VALUES(online)," +
+			"players_num=VALUES(players_num)," +
+			"max_players_num=VALUES(max_players_num)," +
+			"map=VALUES(map)").
+		PlaceholderFormat(sq.Question).
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	return nil
+}
+
+func (r *ServerQueryRecordRepository) DeleteBefore(ctx context.Context, before time.Time) error {
+	query, args, err := sq.Delete(base.ServerQueryRecordsTable).
+		Where(sq.Lt{"queried_at": before.UTC()}).
+		PlaceholderFormat(sq.Question).
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	return nil
+}
+
+func (r *ServerQueryRecordRepository) scan(row base.Scanner) (*domain.ServerQueryRecord, error) {
+	var record domain.ServerQueryRecord
+
+	err := row.Scan(
+		&record.ServerID,
+		&record.QueriedAt,
+		&record.Online,
+		&record.PlayersNum,
+		&record.MaxPlayersNum,
+		&record.Map,
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to scan row")
+	}
+
+	return &record, nil
+}
+
+func (r *ServerQueryRecordRepository) filterToSq(filter *filters.FindServerQueryRecord) sq.Sqlizer {
+	if filter == nil {
+		return nil
+	}
+
+	and := make(sq.And, 0, 3)
+
+	if len(filter.ServerIDs) > 0 {
+		and = append(and, sq.Eq{"server_id": filter.ServerIDs})
+	}
+
+	if filter.QueriedFrom != nil {
+		and = append(and, sq.GtOrEq{"queried_at": filter.QueriedFrom.UTC()})
+	}
+
+	if filter.QueriedBefore != nil {
+		and = append(and, sq.Lt{"queried_at": filter.QueriedBefore.UTC()})
+	}
+
+	return and
+}
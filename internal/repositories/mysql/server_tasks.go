@@ -173,6 +173,8 @@ func (r *ServerTaskRepository) Save(ctx context.Context, task *domain.ServerTask
 			task.Counter,
 			task.ExecuteDate,
 			task.Payload,
+			task.CronExpression,
+			task.Timezone,
 			task.CreatedAt,
 			task.UpdatedAt,
 		).
@@ -184,6 +186,8 @@ func (r *ServerTaskRepository) Save(ctx context.Context, task *domain.ServerTask
 			"`counter`=VALUES(`counter`)," +
 			"`execute_date`=VALUES(`execute_date`)," +
 			"`payload`=VALUES(`payload`)," +
+			"`cron_expression`=VALUES(`cron_expression`)," +
+			"`timezone`=VALUES(`timezone`)," +
 			"`updated_at`=VALUES(`updated_at`)").
 		PlaceholderFormat(sq.Question).
 		ToSql()
@@ -241,6 +245,8 @@ func (r *ServerTaskRepository) scan(row base.Scanner) (*domain.ServerTask, error
 		&task.Counter,
 		&task.ExecuteDate,
 		&task.Payload,
+		&task.CronExpression,
+		&task.Timezone,
 		&task.CreatedAt,
 		&task.UpdatedAt,
 	)
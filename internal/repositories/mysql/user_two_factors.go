@@ -0,0 +1,172 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/base"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+type UserTwoFactorRepository struct {
+	db base.DB
+}
+
+func NewUserTwoFactorRepository(db base.DB) *UserTwoFactorRepository {
+	return &UserTwoFactorRepository{
+		db: db,
+	}
+}
+
+func (r *UserTwoFactorRepository) Find(
+	ctx context.Context,
+	filter *filters.FindUserTwoFactor,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.UserTwoFactor, error) {
+	builder := sq.Select(base.UserTwoFactorFields...).
+		From(base.UserTwoFactorsTable).
+		Where(r.filterToSq(filter))
+
+	if len(order) > 0 {
+		for _, o := range order {
+			builder = builder.OrderBy(o.String())
+		}
+	} else {
+		builder = builder.OrderBy("user_id ASC")
+	}
+
+	if pagination != nil {
+		if pagination.Limit <= 0 {
+			pagination.Limit = filters.DefaultLimit
+		}
+
+		if pagination.Offset < 0 {
+			pagination.Offset = 0
+		}
+
+		builder = builder.Limit(uint64(pagination.Limit)).Offset(uint64(pagination.Offset))
+	}
+
+	query, args, err := builder.PlaceholderFormat(sq.Question).ToSql()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to build query")
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...) //nolint:sqlclosecheck // closed in defer
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to execute query")
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to close rows stream", "query", query, "err", err)
+		}
+	}(rows)
+
+	var twoFactors []domain.UserTwoFactor
+
+	for rows.Next() {
+		var twoFactor *domain.UserTwoFactor
+		twoFactor, err = r.scan(rows)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to scan row")
+		}
+
+		twoFactors = append(twoFactors, *twoFactor)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.WithMessage(err, "rows iteration error")
+	}
+
+	return twoFactors, nil
+}
+
+func (r *UserTwoFactorRepository) Save(ctx context.Context, twoFactor *domain.UserTwoFactor) error {
+	query, args, err := sq.Insert(base.UserTwoFactorsTable).
+		Columns(base.UserTwoFactorFields...).
+		Values(
+			twoFactor.UserID,
+			twoFactor.Secret,
+			twoFactor.ConfirmedAt,
+			twoFactor.RecoveryCodes,
+			twoFactor.LastUsedStep,
+			twoFactor.CreatedAt,
+			twoFactor.UpdatedAt,
+		).
+		Suffix("ON DUPLICATE KEY UPDATE " +
+			"secret=VALUES(secret)," +
+			"confirmed_at=VALUES(confirmed_at)," +
+			"recovery_codes=VALUES(recovery_codes)," +
+			"last_used_step=VALUES(last_used_step)," +
+			"created_at=VALUES(created_at)," +
+			"updated_at=VALUES(updated_at)").
+		PlaceholderFormat(sq.Question).
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	return nil
+}
+
+func (r *UserTwoFactorRepository) Delete(ctx context.Context, userID uint) error {
+	query, args, err := sq.Delete(base.UserTwoFactorsTable).
+		Where(sq.Eq{"user_id": userID}).
+		PlaceholderFormat(sq.Question).
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	return nil
+}
+
+func (r *UserTwoFactorRepository) scan(row base.Scanner) (*domain.UserTwoFactor, error) {
+	var twoFactor domain.UserTwoFactor
+
+	err := row.Scan(
+		&twoFactor.UserID,
+		&twoFactor.Secret,
+		&twoFactor.ConfirmedAt,
+		&twoFactor.RecoveryCodes,
+		&twoFactor.LastUsedStep,
+		&twoFactor.CreatedAt,
+		&twoFactor.UpdatedAt,
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to scan row")
+	}
+
+	return &twoFactor, nil
+}
+
+func (r *UserTwoFactorRepository) filterToSq(filter *filters.FindUserTwoFactor) sq.Sqlizer {
+	if filter == nil {
+		return nil
+	}
+
+	and := make(sq.And, 0, 1)
+
+	if len(filter.UserIDs) > 0 {
+		and = append(and, sq.Eq{"user_id": filter.UserIDs})
+	}
+
+	return and
+}
@@ -128,6 +128,7 @@ func (r *UserRepository) Save(ctx context.Context, user *domain.User) error {
 			user.Name,
 			user.CreatedAt,
 			user.UpdatedAt,
+			user.DisabledAt,
 		).
 		Suffix("ON DUPLICATE KEY UPDATE " +
 			"login=VALUES(login)," +
@@ -135,7 +136,8 @@ func (r *UserRepository) Save(ctx context.Context, user *domain.User) error {
 			"password=VALUES(password)," +
 			"remember_token=VALUES(remember_token)," +
 			"name=VALUES(name)," +
-			"updated_at=VALUES(updated_at)").
+			"updated_at=VALUES(updated_at)," +
+			"disabled_at=VALUES(disabled_at)").
 		PlaceholderFormat(sq.Question).
 		ToSql()
 	if err != nil {
@@ -191,6 +193,7 @@ func (r *UserRepository) scan(row base.Scanner) (*domain.User, error) {
 		&user.Name,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.DisabledAt,
 	)
 	if err != nil {
 		return nil, errors.WithMessage(err, "failed to scan row")
@@ -0,0 +1,222 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/base"
+	"github.com/samber/lo"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+type WebhookDeliveryRepository struct {
+	db base.DB
+}
+
+func NewWebhookDeliveryRepository(db base.DB) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{
+		db: db,
+	}
+}
+
+func (r *WebhookDeliveryRepository) Find(
+	ctx context.Context,
+	filter *filters.FindWebhookDelivery,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.WebhookDelivery, error) {
+	builder := sq.Select(base.WebhookDeliveryFields...).
+		From(base.WebhookDeliveriesTable).
+		Where(r.filterToSq(filter))
+
+	if len(order) > 0 {
+		for _, o := range order {
+			builder = builder.OrderBy(o.String())
+		}
+	} else {
+		builder = builder.OrderBy("id ASC")
+	}
+
+	if pagination != nil {
+		if pagination.Limit <= 0 {
+			pagination.Limit = filters.DefaultLimit
+		}
+
+		if pagination.Offset < 0 {
+			pagination.Offset = 0
+		}
+
+		builder = builder.Limit(uint64(pagination.Limit)).Offset(uint64(pagination.Offset))
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to build query")
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...) //nolint:sqlclosecheck // closed in defer
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to execute query")
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to close rows stream", "query", query, "err", err)
+		}
+	}(rows)
+
+	var deliveries []domain.WebhookDelivery
+
+	for rows.Next() {
+		var delivery *domain.WebhookDelivery
+		delivery, err = r.scan(rows)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to scan row")
+		}
+
+		deliveries = append(deliveries, *delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.WithMessage(err, "rows iteration error")
+	}
+
+	return deliveries, nil
+}
+
+func (r *WebhookDeliveryRepository) Save(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	delivery.UpdatedAt = lo.ToPtr(time.Now())
+
+	if delivery.ID == 0 && (delivery.CreatedAt == nil || delivery.CreatedAt.IsZero()) {
+		delivery.CreatedAt = lo.ToPtr(time.Now())
+	}
+
+	query, args, err := sq.Insert(base.WebhookDeliveriesTable).
+		Columns(base.WebhookDeliveryFields...).
+		Values(
+			delivery.ID,
+			delivery.WebhookID,
+			delivery.EventID,
+			delivery.EventType,
+			delivery.Payload,
+			delivery.Status,
+			delivery.Attempts,
+			delivery.NextAttemptAt,
+			delivery.ResponseStatus,
+			delivery.Error,
+			delivery.CreatedAt,
+			delivery.UpdatedAt,
+		).
+		Suffix("ON DUPLICATE KEY UPDATE " +
+			"status=VALUES(status)," +
+			"attempts=VALUES(attempts)," +
+			"next_attempt_at=VALUES(next_attempt_at)," +
+			"response_status=VALUES(response_status)," +
+			"error=VALUES(error)," +
+			"created_at=VALUES(created_at)," +
+			"updated_at=VALUES(updated_at)").
+		PlaceholderFormat(sq.Question).
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	if delivery.ID == 0 {
+		lastID, err := result.LastInsertId()
+		if err != nil {
+			return errors.WithMessage(err, "failed to get last insert ID")
+		}
+		if lastID < 0 {
+			return errors.New("invalid last insert ID")
+		}
+		delivery.ID = uint(lastID)
+	}
+
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) DeleteBefore(ctx context.Context, before time.Time) error {
+	return r.delete(ctx, sq.Lt{"created_at": before})
+}
+
+func (r *WebhookDeliveryRepository) DeleteByWebhookID(ctx context.Context, webhookID uint) error {
+	return r.delete(ctx, sq.Eq{"webhook_id": webhookID})
+}
+
+func (r *WebhookDeliveryRepository) delete(ctx context.Context, where sq.Sqlizer) error {
+	query, args, err := sq.Delete(base.WebhookDeliveriesTable).
+		Where(where).
+		PlaceholderFormat(sq.Question).
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) scan(row base.Scanner) (*domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.WebhookID,
+		&delivery.EventID,
+		&delivery.EventType,
+		&delivery.Payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.NextAttemptAt,
+		&delivery.ResponseStatus,
+		&delivery.Error,
+		&delivery.CreatedAt,
+		&delivery.UpdatedAt,
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to scan row")
+	}
+
+	return &delivery, nil
+}
+
+func (r *WebhookDeliveryRepository) filterToSq(filter *filters.FindWebhookDelivery) sq.Sqlizer {
+	if filter == nil {
+		return nil
+	}
+
+	and := make(sq.And, 0, 4)
+
+	if len(filter.IDs) > 0 {
+		and = append(and, sq.Eq{"id": filter.IDs})
+	}
+
+	if len(filter.WebhookIDs) > 0 {
+		and = append(and, sq.Eq{"webhook_id": filter.WebhookIDs})
+	}
+
+	if len(filter.Statuses) > 0 {
+		and = append(and, sq.Eq{"status": filter.Statuses})
+	}
+
+	if filter.NextAttemptBefore != nil {
+		and = append(and, sq.LtOrEq{"next_attempt_at": *filter.NextAttemptBefore})
+	}
+
+	return and
+}
@@ -0,0 +1,241 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/base"
+	"github.com/samber/lo"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+var wrappedAuditLogFields = lo.Map(base.AuditLogFields, func(s string, _ int) string {
+	b := strings.Builder{}
+	b.Grow(len(s) + 2)
+	b.WriteByte('"')
+	b.WriteString(s)
+	b.WriteByte('"')
+
+	return b.String()
+})
+
+type AuditLogRepository struct {
+	db base.DB
+}
+
+func NewAuditLogRepository(db base.DB) *AuditLogRepository {
+	return &AuditLogRepository{
+		db: db,
+	}
+}
+
+func (r *AuditLogRepository) Find(
+	ctx context.Context,
+	filter *filters.FindAuditLog,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.AuditLog, error) {
+	builder := sq.Select(wrappedAuditLogFields...).
+		From(base.AuditLogsTable).
+		Where(r.filterToSq(filter))
+
+	if len(order) > 0 {
+		for _, o := range order {
+			builder = builder.OrderBy(o.String())
+		}
+	} else {
+		builder = builder.OrderBy("id ASC")
+	}
+
+	if pagination != nil {
+		if pagination.Limit <= 0 {
+			pagination.Limit = filters.DefaultLimit
+		}
+
+		if pagination.Offset < 0 {
+			pagination.Offset = 0
+		}
+
+		builder = builder.Limit(uint64(pagination.Limit)).Offset(uint64(pagination.Offset))
+	}
+
+	query, args, err := builder.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to build query")
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...) //nolint:sqlclosecheck // closed in defer
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to execute query")
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to close rows stream", "query", query, "err", err)
+		}
+	}(rows)
+
+	var logs []domain.AuditLog
+
+	for rows.Next() {
+		var log *domain.AuditLog
+		log, err = r.scan(rows)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to scan row")
+		}
+
+		logs = append(logs, *log)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.WithMessage(err, "rows iteration error")
+	}
+
+	return logs, nil
+}
+
+func (r *AuditLogRepository) Count(ctx context.Context, filter *filters.FindAuditLog) (int, error) {
+	query, args, err := sq.Select("COUNT(*)").
+		From(base.AuditLogsTable).
+		Where(r.filterToSq(filter)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, errors.WithMessage(err, "failed to build query")
+	}
+
+	var count int
+	err = r.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	if err != nil {
+		return 0, errors.WithMessage(err, "failed to execute query")
+	}
+
+	return count, nil
+}
+
+func (r *AuditLogRepository) Save(ctx context.Context, log *domain.AuditLog) error {
+	if log.CreatedAt == nil || log.CreatedAt.IsZero() {
+		log.CreatedAt = lo.ToPtr(time.Now())
+	}
+
+	query, args, err := sq.Insert(base.AuditLogsTable).
+		Columns(
+			"\"user_id\"",
+			"\"token_id\"",
+			"\"ip\"",
+			"\"user_agent\"",
+			"\"action\"",
+			"\"entity_type\"",
+			"\"entity_id\"",
+			"\"changes\"",
+			"\"request\"",
+			"\"result\"",
+			"\"status_code\"",
+			"\"created_at\"",
+		).
+		Values(
+			log.UserID,
+			log.TokenID,
+			log.IP,
+			log.UserAgent,
+			log.Action,
+			log.EntityType,
+			log.EntityID,
+			log.Changes,
+			log.Request,
+			log.Result,
+			log.StatusCode,
+			log.CreatedAt,
+		).
+		Suffix("RETURNING id").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	err = r.db.QueryRowContext(ctx, query, args...).Scan(&log.ID)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	return nil
+}
+
+func (r *AuditLogRepository) scan(row base.Scanner) (*domain.AuditLog, error) {
+	var log domain.AuditLog
+
+	err := row.Scan(
+		&log.ID,
+		&log.UserID,
+		&log.TokenID,
+		&log.IP,
+		&log.UserAgent,
+		&log.Action,
+		&log.EntityType,
+		&log.EntityID,
+		&log.Changes,
+		&log.Request,
+		&log.Result,
+		&log.StatusCode,
+		&log.CreatedAt,
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to scan row")
+	}
+
+	return &log, nil
+}
+
+func (r *AuditLogRepository) filterToSq(filter *filters.FindAuditLog) sq.Sqlizer {
+	if filter == nil {
+		return nil
+	}
+
+	and := make(sq.And, 0, 9)
+
+	if len(filter.IDs) > 0 {
+		and = append(and, sq.Eq{"id": filter.IDs})
+	}
+
+	if len(filter.UserIDs) > 0 {
+		and = append(and, sq.Eq{"user_id": filter.UserIDs})
+	}
+
+	if len(filter.Actions) > 0 {
+		and = append(and, sq.Eq{"action": filter.Actions})
+	}
+
+	if len(filter.EntityTypes) > 0 {
+		and = append(and, sq.Eq{"entity_type": filter.EntityTypes})
+	}
+
+	if len(filter.EntityIDs) > 0 {
+		and = append(and, sq.Eq{"entity_id": filter.EntityIDs})
+	}
+
+	if len(filter.Results) > 0 {
+		and = append(and, sq.Eq{"result": filter.Results})
+	}
+
+	if filter.CreatedFrom != nil {
+		and = append(and, sq.GtOrEq{"created_at": *filter.CreatedFrom})
+	}
+
+	if filter.CreatedTo != nil {
+		and = append(and, sq.Lt{"created_at": *filter.CreatedTo})
+	}
+
+	if filter.BeforeID != nil {
+		and = append(and, sq.Lt{"id": *filter.BeforeID})
+	}
+
+	return and
+}
@@ -0,0 +1,232 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/base"
+	"github.com/samber/lo"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+var wrappedBackupFields = lo.Map(base.BackupFields, func(s string, _ int) string {
+	b := strings.Builder{}
+	b.Grow(len(s) + 2)
+	b.WriteByte('"')
+	b.WriteString(s)
+	b.WriteByte('"')
+
+	return b.String()
+})
+
+type BackupRepository struct {
+	db base.DB
+}
+
+func NewBackupRepository(db base.DB) *BackupRepository {
+	return &BackupRepository{
+		db: db,
+	}
+}
+
+func (r *BackupRepository) Find(
+	ctx context.Context,
+	filter *filters.FindBackup,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.Backup, error) {
+	builder := sq.Select(wrappedBackupFields...).
+		From(base.BackupsTable).
+		Where(r.filterToSq(filter))
+
+	if len(order) > 0 {
+		for _, o := range order {
+			builder = builder.OrderBy(o.String())
+		}
+	} else {
+		builder = builder.OrderBy("id ASC")
+	}
+
+	if pagination != nil {
+		if pagination.Limit <= 0 {
+			pagination.Limit = filters.DefaultLimit
+		}
+
+		if pagination.Offset < 0 {
+			pagination.Offset = 0
+		}
+
+		builder = builder.Limit(uint64(pagination.Limit)).Offset(uint64(pagination.Offset))
+	}
+
+	query, args, err := builder.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to build query")
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...) //nolint:sqlclosecheck // closed in defer
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to execute query")
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to close rows stream", "query", query, "err", err)
+		}
+	}(rows)
+
+	var backups []domain.Backup
+
+	for rows.Next() {
+		var backup *domain.Backup
+		backup, err = r.scan(rows)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to scan row")
+		}
+
+		backups = append(backups, *backup)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.WithMessage(err, "rows iteration error")
+	}
+
+	return backups, nil
+}
+
+func (r *BackupRepository) Save(ctx context.Context, backup *domain.Backup) error {
+	backup.UpdatedAt = lo.ToPtr(time.Now())
+
+	if backup.ID == 0 && (backup.CreatedAt == nil || backup.CreatedAt.IsZero()) {
+		backup.CreatedAt = lo.ToPtr(time.Now())
+	}
+
+	builder := sq.Insert(base.BackupsTable)
+
+	if backup.ID == 0 {
+		builder = builder.
+			Columns(
+				"\"server_id\"",
+				"\"status\"",
+				"\"path\"",
+				"\"size\"",
+				"\"error\"",
+				"\"created_at\"",
+				"\"updated_at\"",
+			).
+			Values(
+				backup.ServerID,
+				backup.Status,
+				backup.Path,
+				backup.Size,
+				backup.Error,
+				backup.CreatedAt,
+				backup.UpdatedAt,
+			).
+			Suffix("RETURNING id")
+	} else {
+		builder = builder.
+			Columns(wrappedBackupFields...).
+			Values(
+				backup.ID,
+				backup.ServerID,
+				backup.Status,
+				backup.Path,
+				backup.Size,
+				backup.Error,
+				backup.CreatedAt,
+				backup.UpdatedAt,
+			).
+			Suffix("ON CONFLICT(id) DO UPDATE SET " +
+				"\"server_id\"=excluded.\"server_id\"," +
+				"\"status\"=excluded.\"status\"," +
+				"\"path\"=excluded.\"path\"," +
+				"\"size\"=excluded.\"size\"," +
+				"\"error\"=excluded.\"error\"," +
+				"\"updated_at\"=excluded.\"updated_at\" " +
+				"RETURNING id")
+	}
+
+	query, args, err := builder.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	var returnedID uint
+	err = r.db.QueryRowContext(ctx, query, args...).Scan(&returnedID)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	if backup.ID == 0 {
+		backup.ID = returnedID
+	}
+
+	return nil
+}
+
+func (r *BackupRepository) Delete(ctx context.Context, id uint) error {
+	query, args, err := sq.Delete(base.BackupsTable).
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	return nil
+}
+
+func (r *BackupRepository) scan(row base.Scanner) (*domain.Backup, error) {
+	var backup domain.Backup
+
+	err := row.Scan(
+		&backup.ID,
+		&backup.ServerID,
+		&backup.Status,
+		&backup.Path,
+		&backup.Size,
+		&backup.Error,
+		&backup.CreatedAt,
+		&backup.UpdatedAt,
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to scan row")
+	}
+
+	return &backup, nil
+}
+
+func (r *BackupRepository) filterToSq(filter *filters.FindBackup) sq.Sqlizer {
+	if filter == nil {
+		return nil
+	}
+
+	and := make(sq.And, 0, 3)
+
+	if len(filter.IDs) > 0 {
+		and = append(and, sq.Eq{"id": filter.IDs})
+	}
+
+	if len(filter.ServerIDs) > 0 {
+		and = append(and, sq.Eq{"server_id": filter.ServerIDs})
+	}
+
+	if len(filter.Statuses) > 0 {
+		and = append(and, sq.Eq{"status": filter.Statuses})
+	}
+
+	return and
+}
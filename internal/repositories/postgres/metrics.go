@@ -0,0 +1,218 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/base"
+	"github.com/samber/lo"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+var wrappedMetricFields = lo.Map(base.MetricFields, func(s string, _ int) string {
+	b := strings.Builder{}
+	b.Grow(len(s) + 2)
+	b.WriteByte('"')
+	b.WriteString(s)
+	b.WriteByte('"')
+
+	return b.String()
+})
+
+type MetricRepository struct {
+	db base.DB
+}
+
+func NewMetricRepository(db base.DB) *MetricRepository {
+	return &MetricRepository{
+		db: db,
+	}
+}
+
+func (r *MetricRepository) Find(
+	ctx context.Context,
+	filter *filters.FindMetric,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.Metric, error) {
+	builder := sq.Select(wrappedMetricFields...).
+		From(base.MetricsTable).
+		Where(r.filterToSq(filter))
+
+	if len(order) > 0 {
+		for _, o := range order {
+			builder = builder.OrderBy(o.String())
+		}
+	} else {
+		builder = builder.OrderBy("subject_type ASC", "subject_id ASC", "recorded_at ASC")
+	}
+
+	if pagination != nil {
+		if pagination.Limit <= 0 {
+			pagination.Limit = filters.DefaultLimit
+		}
+
+		if pagination.Offset < 0 {
+			pagination.Offset = 0
+		}
+
+		builder = builder.Limit(uint64(pagination.Limit)).Offset(uint64(pagination.Offset))
+	}
+
+	query, args, err := builder.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to build query")
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...) //nolint:sqlclosecheck // closed in defer
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to execute query")
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to close rows stream", "query", query, "err", err)
+		}
+	}(rows)
+
+	var metrics []domain.Metric
+
+	for rows.Next() {
+		var metric *domain.Metric
+		metric, err = r.scan(rows)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to scan row")
+		}
+
+		metrics = append(metrics, *metric)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.WithMessage(err, "rows iteration error")
+	}
+
+	return metrics, nil
+}
+
+func (r *MetricRepository) SaveBulk(ctx context.Context, metrics []*domain.Metric) error {
+	if len(metrics) == 0 {
+		return nil
+	}
+
+	builder := sq.Insert(base.MetricsTable).
+		Columns(wrappedMetricFields...)
+
+	for _, metric := range metrics {
+		builder = builder.Values(
+			metric.SubjectType,
+			metric.SubjectID,
+			metric.Resolution,
+			metric.RecordedAt.UTC(),
+			metric.CPU,
+			metric.RAM,
+			metric.Disk,
+			metric.Players,
+			metric.Samples,
+		)
+	}
+
+	query, args, err := builder.
+		Suffix("ON CONFLICT(subject_type, subject_id, resolution, recorded_at) DO UPDATE SET " +
+			"\"cpu\"=COALESCE(excluded.\"cpu\", metrics.\"cpu\")," +
+			"\"ram\"=COALESCE(excluded.\"ram\", metrics.\"ram\")," +
+			"\"disk\"=COALESCE(excluded.\"disk\", metrics.\"disk\")," +
+			"\"players\"=COALESCE(excluded.\"players\", metrics.\"players\")," +
+			"\"samples\"=excluded.\"samples\"").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	return nil
+}
+
+func (r *MetricRepository) DeleteBefore(
+	ctx context.Context,
+	resolution domain.MetricResolution,
+	before time.Time,
+) error {
+	query, args, err := sq.Delete(base.MetricsTable).
+		Where(sq.Eq{"resolution": resolution}).
+		Where(sq.Lt{"recorded_at": before.UTC()}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	return nil
+}
+
+func (r *MetricRepository) scan(row base.Scanner) (*domain.Metric, error) {
+	var metric domain.Metric
+
+	err := row.Scan(
+		&metric.SubjectType,
+		&metric.SubjectID,
+		&metric.Resolution,
+		&metric.RecordedAt,
+		&metric.CPU,
+		&metric.RAM,
+		&metric.Disk,
+		&metric.Players,
+		&metric.Samples,
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to scan row")
+	}
+
+	return &metric, nil
+}
+
+func (r *MetricRepository) filterToSq(filter *filters.FindMetric) sq.Sqlizer {
+	if filter == nil {
+		return nil
+	}
+
+	and := make(sq.And, 0, 5)
+
+	if filter.SubjectType != "" {
+		and = append(and, sq.Eq{"subject_type": filter.SubjectType})
+	}
+
+	if len(filter.SubjectIDs) > 0 {
+		and = append(and, sq.Eq{"subject_id": filter.SubjectIDs})
+	}
+
+	if len(filter.Resolutions) > 0 {
+		and = append(and, sq.Eq{"resolution": filter.Resolutions})
+	}
+
+	if filter.RecordedFrom != nil {
+		and = append(and, sq.GtOrEq{"recorded_at": filter.RecordedFrom.UTC()})
+	}
+
+	if filter.RecordedBefore != nil {
+		and = append(and, sq.Lt{"recorded_at": filter.RecordedBefore.UTC()})
+	}
+
+	return and
+}
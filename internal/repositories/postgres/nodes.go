@@ -157,6 +157,8 @@ func (r *NodeRepository) Save(ctx context.Context, node *domain.Node) error {
 				"script_get_console",
 				"script_send_command",
 				"script_delete",
+				"port_range_start",
+				"port_range_end",
 				"created_at",
 				"updated_at",
 				"deleted_at",
@@ -195,6 +197,8 @@ func (r *NodeRepository) Save(ctx context.Context, node *domain.Node) error {
 				node.ScriptGetConsole,
 				node.ScriptSendCommand,
 				node.ScriptDelete,
+				node.PortRangeStart,
+				node.PortRangeEnd,
 				node.CreatedAt,
 				node.UpdatedAt,
 				node.DeletedAt,
@@ -238,6 +242,8 @@ func (r *NodeRepository) Save(ctx context.Context, node *domain.Node) error {
 				node.ScriptGetConsole,
 				node.ScriptSendCommand,
 				node.ScriptDelete,
+				node.PortRangeStart,
+				node.PortRangeEnd,
 				node.CreatedAt,
 				node.UpdatedAt,
 				node.DeletedAt,
@@ -276,6 +282,8 @@ func (r *NodeRepository) Save(ctx context.Context, node *domain.Node) error {
 				"script_get_console=excluded.script_get_console," +
 				"script_send_command=excluded.script_send_command," +
 				"script_delete=excluded.script_delete," +
+				"port_range_start=excluded.port_range_start," +
+				"port_range_end=excluded.port_range_end," +
 				"updated_at=excluded.updated_at," +
 				"deleted_at=excluded.deleted_at " +
 				"RETURNING id")
@@ -354,6 +362,8 @@ func (r *NodeRepository) scan(row base.Scanner) (*domain.Node, error) {
 		&node.ScriptGetConsole,
 		&node.ScriptSendCommand,
 		&node.ScriptDelete,
+		&node.PortRangeStart,
+		&node.PortRangeEnd,
 		&node.CreatedAt,
 		&node.UpdatedAt,
 		&node.DeletedAt,
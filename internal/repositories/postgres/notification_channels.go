@@ -0,0 +1,247 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/base"
+	"github.com/samber/lo"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+var wrappedNotificationChannelFields = lo.Map(base.NotificationChannelFields, func(s string, _ int) string {
+	b := strings.Builder{}
+	b.Grow(len(s) + 2)
+	b.WriteByte('"')
+	b.WriteString(s)
+	b.WriteByte('"')
+
+	return b.String()
+})
+
+type NotificationChannelRepository struct {
+	db base.DB
+}
+
+func NewNotificationChannelRepository(db base.DB) *NotificationChannelRepository {
+	return &NotificationChannelRepository{
+		db: db,
+	}
+}
+
+func (r *NotificationChannelRepository) Find(
+	ctx context.Context,
+	filter *filters.FindNotificationChannel,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.NotificationChannel, error) {
+	builder := sq.Select(wrappedNotificationChannelFields...).
+		From(base.NotificationChannelsTable).
+		Where(r.filterToSq(filter))
+
+	if len(order) > 0 {
+		for _, o := range order {
+			builder = builder.OrderBy(o.String())
+		}
+	} else {
+		builder = builder.OrderBy("id ASC")
+	}
+
+	if pagination != nil {
+		if pagination.Limit <= 0 {
+			pagination.Limit = filters.DefaultLimit
+		}
+
+		if pagination.Offset < 0 {
+			pagination.Offset = 0
+		}
+
+		builder = builder.Limit(uint64(pagination.Limit)).Offset(uint64(pagination.Offset))
+	}
+
+	query, args, err := builder.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to build query")
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...) //nolint:sqlclosecheck // closed in defer
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to execute query")
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to close rows stream", "query", query, "err", err)
+		}
+	}(rows)
+
+	var channels []domain.NotificationChannel
+
+	for rows.Next() {
+		var channel *domain.NotificationChannel
+		channel, err = r.scan(rows)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to scan row")
+		}
+
+		channels = append(channels, *channel)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.WithMessage(err, "rows iteration error")
+	}
+
+	return channels, nil
+}
+
+func (r *NotificationChannelRepository) Save(ctx context.Context, channel *domain.NotificationChannel) error {
+	channel.UpdatedAt = lo.ToPtr(time.Now())
+
+	if channel.ID == 0 && (channel.CreatedAt == nil || channel.CreatedAt.IsZero()) {
+		channel.CreatedAt = lo.ToPtr(time.Now())
+	}
+
+	builder := sq.Insert(base.NotificationChannelsTable)
+
+	if channel.ID == 0 {
+		builder = builder.
+			Columns(
+				"\"user_id\"",
+				"\"server_id\"",
+				"\"name\"",
+				"\"type\"",
+				"\"target\"",
+				"\"language\"",
+				"\"events\"",
+				"\"enabled\"",
+				"\"created_at\"",
+				"\"updated_at\"",
+			).
+			Values(
+				channel.UserID,
+				channel.ServerID,
+				channel.Name,
+				channel.Type,
+				channel.Target,
+				channel.Language,
+				channel.Events,
+				channel.Enabled,
+				channel.CreatedAt,
+				channel.UpdatedAt,
+			).
+			Suffix("RETURNING id")
+	} else {
+		builder = builder.
+			Columns(wrappedNotificationChannelFields...).
+			Values(
+				channel.ID,
+				channel.UserID,
+				channel.ServerID,
+				channel.Name,
+				channel.Type,
+				channel.Target,
+				channel.Language,
+				channel.Events,
+				channel.Enabled,
+				channel.CreatedAt,
+				channel.UpdatedAt,
+			).
+			Suffix("ON CONFLICT(id) DO UPDATE SET " +
+				"\"user_id\"=excluded.\"user_id\"," +
+				"\"server_id\"=excluded.\"server_id\"," +
+				"\"name\"=excluded.\"name\"," +
+				"\"type\"=excluded.\"type\"," +
+				"\"target\"=excluded.\"target\"," +
+				"\"language\"=excluded.\"language\"," +
+				"\"events\"=excluded.\"events\"," +
+				"\"enabled\"=excluded.\"enabled\"," +
+				"\"updated_at\"=excluded.\"updated_at\" " +
+				"RETURNING id")
+	}
+
+	query, args, err := builder.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	var returnedID uint
+	err = r.db.QueryRowContext(ctx, query, args...).Scan(&returnedID)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	if channel.ID == 0 {
+		channel.ID = returnedID
+	}
+
+	return nil
+}
+
+func (r *NotificationChannelRepository) Delete(ctx context.Context, id uint) error {
+	query, args, err := sq.Delete(base.NotificationChannelsTable).
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	return nil
+}
+
+func (r *NotificationChannelRepository) scan(row base.Scanner) (*domain.NotificationChannel, error) {
+	var channel domain.NotificationChannel
+
+	err := row.Scan(
+		&channel.ID,
+		&channel.UserID,
+		&channel.ServerID,
+		&channel.Name,
+		&channel.Type,
+		&channel.Target,
+		&channel.Language,
+		&channel.Events,
+		&channel.Enabled,
+		&channel.CreatedAt,
+		&channel.UpdatedAt,
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to scan row")
+	}
+
+	return &channel, nil
+}
+
+func (r *NotificationChannelRepository) filterToSq(filter *filters.FindNotificationChannel) sq.Sqlizer {
+	if filter == nil {
+		return nil
+	}
+
+	and := make(sq.And, 0, 3)
+
+	if len(filter.IDs) > 0 {
+		and = append(and, sq.Eq{"id": filter.IDs})
+	}
+
+	if len(filter.UserIDs) > 0 {
+		and = append(and, sq.Eq{"user_id": filter.UserIDs})
+	}
+
+	if filter.Enabled != nil {
+		and = append(and, sq.Eq{"enabled": *filter.Enabled})
+	}
+
+	return and
+}
@@ -0,0 +1,27 @@
+package postgres_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/repositories/postgres"
+	repotesting "github.com/gameap/gameap/internal/repositories/testing"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestNotificationChannelRepository(t *testing.T) {
+	testPostgresDSN := os.Getenv("TEST_POSTGRES_DSN")
+
+	if testPostgresDSN == "" {
+		t.Skip("Skipping PostgreSQL tests because TEST_POSTGRES_DSN is not set")
+	}
+
+	suite.Run(t, repotesting.NewNotificationChannelRepositorySuite(
+		func(t *testing.T) repositories.NotificationChannelRepository {
+			t.Helper()
+
+			return postgres.NewNotificationChannelRepository(SetupTestDB(t, testPostgresDSN))
+		},
+	))
+}
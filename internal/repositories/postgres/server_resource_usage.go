@@ -0,0 +1,161 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/base"
+	"github.com/samber/lo"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+var wrappedServerResourceUsageFields = lo.Map(base.ServerResourceUsageFields, func(s string, _ int) string {
+	b := strings.Builder{}
+	b.Grow(len(s) + 2)
+	b.WriteByte('"')
+	b.WriteString(s)
+	b.WriteByte('"')
+
+	return b.String()
+})
+
+type ServerResourceUsageRepository struct {
+	db base.DB
+}
+
+func NewServerResourceUsageRepository(db base.DB) *ServerResourceUsageRepository {
+	return &ServerResourceUsageRepository{
+		db: db,
+	}
+}
+
+func (r *ServerResourceUsageRepository) Find(
+	ctx context.Context,
+	filter *filters.FindServerResourceUsage,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.ServerResourceUsage, error) {
+	builder := sq.Select(wrappedServerResourceUsageFields...).
+		From(base.ServerResourceUsageTable).
+		Where(r.filterToSq(filter))
+
+	if len(order) > 0 {
+		for _, o := range order {
+			builder = builder.OrderBy(o.String())
+		}
+	} else {
+		builder = builder.OrderBy("server_id ASC")
+	}
+
+	if pagination != nil {
+		if pagination.Limit <= 0 {
+			pagination.Limit = filters.DefaultLimit
+		}
+
+		if pagination.Offset < 0 {
+			pagination.Offset = 0
+		}
+
+		builder = builder.Limit(uint64(pagination.Limit)).Offset(uint64(pagination.Offset))
+	}
+
+	query, args, err := builder.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to build query")
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...) //nolint:sqlclosecheck // closed in defer
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to execute query")
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to close rows stream", "query", query, "err", err)
+		}
+	}(rows)
+
+	var usages []domain.ServerResourceUsage
+
+	for rows.Next() {
+		var usage *domain.ServerResourceUsage
+		usage, err = r.scan(rows)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to scan row")
+		}
+
+		usages = append(usages, *usage)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.WithMessage(err, "rows iteration error")
+	}
+
+	return usages, nil
+}
+
+func (r *ServerResourceUsageRepository) Save(ctx context.Context, usage *domain.ServerResourceUsage) error {
+	query, args, err := sq.Insert(base.ServerResourceUsageTable).
+		Columns(wrappedServerResourceUsageFields...).
+		Values(
+			usage.ServerID,
+			usage.CPU,
+			usage.RAM,
+			usage.Net,
+			usage.ReportedAt,
+		).
+		Suffix("ON CONFLICT(server_id) DO UPDATE SET " +
+			"\"cpu\"=excluded.\"cpu\"," +
+			"\"ram\"=excluded.\"ram\"," +
+			"\"net\"=excluded.\"net\"," +
+			"\"reported_at\"=excluded.\"reported_at\"").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	return nil
+}
+
+func (r *ServerResourceUsageRepository) scan(row base.Scanner) (*domain.ServerResourceUsage, error) {
+	var usage domain.ServerResourceUsage
+
+	err := row.Scan(
+		&usage.ServerID,
+		&usage.CPU,
+		&usage.RAM,
+		&usage.Net,
+		&usage.ReportedAt,
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to scan row")
+	}
+
+	return &usage, nil
+}
+
+func (r *ServerResourceUsageRepository) filterToSq(filter *filters.FindServerResourceUsage) sq.Sqlizer {
+	if filter == nil {
+		return nil
+	}
+
+	and := make(sq.And, 0, 1)
+
+	if len(filter.ServerIDs) > 0 {
+		and = append(and, sq.Eq{"server_id": filter.ServerIDs})
+	}
+
+	return and
+}
@@ -173,6 +173,8 @@ func (r *ServerTaskRepository) Save(ctx context.Context, task *domain.ServerTask
 				"\"counter\"",
 				"\"execute_date\"",
 				"\"payload\"",
+				"\"cron_expression\"",
+				"\"timezone\"",
 				"\"created_at\"",
 				"\"updated_at\"",
 			).
@@ -184,6 +186,8 @@ func (r *ServerTaskRepository) Save(ctx context.Context, task *domain.ServerTask
 				task.Counter,
 				task.ExecuteDate,
 				task.Payload,
+				task.CronExpression,
+				task.Timezone,
 				task.CreatedAt,
 				task.UpdatedAt,
 			).
@@ -200,6 +204,8 @@ func (r *ServerTaskRepository) Save(ctx context.Context, task *domain.ServerTask
 				task.Counter,
 				task.ExecuteDate,
 				task.Payload,
+				task.CronExpression,
+				task.Timezone,
 				task.CreatedAt,
 				task.UpdatedAt,
 			).
@@ -211,6 +217,8 @@ func (r *ServerTaskRepository) Save(ctx context.Context, task *domain.ServerTask
 				"\"counter\"=excluded.\"counter\"," +
 				"\"execute_date\"=excluded.\"execute_date\"," +
 				"\"payload\"=excluded.\"payload\"," +
+				"\"cron_expression\"=excluded.\"cron_expression\"," +
+				"\"timezone\"=excluded.\"timezone\"," +
 				"\"updated_at\"=excluded.\"updated_at\" " +
 				"RETURNING id")
 	}
@@ -264,6 +272,8 @@ func (r *ServerTaskRepository) scan(row base.Scanner) (*domain.ServerTask, error
 		&task.Counter,
 		&task.ExecuteDate,
 		&task.Payload,
+		&task.CronExpression,
+		&task.Timezone,
 		&task.CreatedAt,
 		&task.UpdatedAt,
 	)
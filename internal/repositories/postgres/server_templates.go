@@ -0,0 +1,288 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/base"
+	"github.com/samber/lo"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+var wrappedServerTemplateFields = lo.Map(base.ServerTemplateFields, func(s string, _ int) string {
+	b := strings.Builder{}
+	b.Grow(len(s) + 2)
+	b.WriteByte('"')
+	b.WriteString(s)
+	b.WriteByte('"')
+
+	return b.String()
+})
+
+type ServerTemplateRepository struct {
+	db base.DB
+}
+
+func NewServerTemplateRepository(db base.DB) *ServerTemplateRepository {
+	return &ServerTemplateRepository{
+		db: db,
+	}
+}
+
+func (r *ServerTemplateRepository) Find(
+	ctx context.Context,
+	filter *filters.FindServerTemplate,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.ServerTemplate, error) {
+	builder := sq.Select(wrappedServerTemplateFields...).
+		From(base.ServerTemplatesTable).
+		Where(r.filterToSq(filter))
+
+	if len(order) > 0 {
+		for _, o := range order {
+			builder = builder.OrderBy(o.String())
+		}
+	} else {
+		builder = builder.OrderBy("id ASC")
+	}
+
+	if pagination != nil {
+		if pagination.Limit <= 0 {
+			pagination.Limit = filters.DefaultLimit
+		}
+
+		if pagination.Offset < 0 {
+			pagination.Offset = 0
+		}
+
+		builder = builder.Limit(uint64(pagination.Limit)).Offset(uint64(pagination.Offset))
+	}
+
+	query, args, err := builder.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to build query")
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...) //nolint:sqlclosecheck // closed in defer
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to execute query")
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to close rows stream", "query", query, "err", err)
+		}
+	}(rows)
+
+	var templates []domain.ServerTemplate
+
+	for rows.Next() {
+		var template *domain.ServerTemplate
+		template, err = r.scan(rows)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to scan row")
+		}
+
+		templates = append(templates, *template)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.WithMessage(err, "rows iteration error")
+	}
+
+	return templates, nil
+}
+
+func (r *ServerTemplateRepository) Save(ctx context.Context, template *domain.ServerTemplate) error {
+	template.UpdatedAt = lo.ToPtr(time.Now())
+
+	if template.ID == 0 && (template.CreatedAt == nil || template.CreatedAt.IsZero()) {
+		template.CreatedAt = lo.ToPtr(time.Now())
+	}
+
+	builder := sq.Insert(base.ServerTemplatesTable)
+
+	if template.ID == 0 {
+		builder = builder.
+			Columns(
+				"\"name\"",
+				"\"description\"",
+				"\"game_id\"",
+				"\"game_mod_id\"",
+				"\"server_port\"",
+				"\"query_port\"",
+				"\"rcon_port\"",
+				"\"su_user\"",
+				"\"cpu_limit\"",
+				"\"ram_limit\"",
+				"\"net_limit\"",
+				"\"start_command\"",
+				"\"stop_command\"",
+				"\"force_stop_command\"",
+				"\"restart_command\"",
+				"\"vars\"",
+				"\"settings\"",
+				"\"created_at\"",
+				"\"updated_at\"",
+			).
+			Values(
+				template.Name,
+				template.Description,
+				template.GameID,
+				template.GameModID,
+				template.ServerPort,
+				template.QueryPort,
+				template.RconPort,
+				template.SuUser,
+				template.CPULimit,
+				template.RAMLimit,
+				template.NetLimit,
+				template.StartCommand,
+				template.StopCommand,
+				template.ForceStopCommand,
+				template.RestartCommand,
+				template.Vars,
+				template.Settings,
+				template.CreatedAt,
+				template.UpdatedAt,
+			).
+			Suffix("RETURNING id")
+	} else {
+		builder = builder.
+			Columns(wrappedServerTemplateFields...).
+			Values(
+				template.ID,
+				template.Name,
+				template.Description,
+				template.GameID,
+				template.GameModID,
+				template.ServerPort,
+				template.QueryPort,
+				template.RconPort,
+				template.SuUser,
+				template.CPULimit,
+				template.RAMLimit,
+				template.NetLimit,
+				template.StartCommand,
+				template.StopCommand,
+				template.ForceStopCommand,
+				template.RestartCommand,
+				template.Vars,
+				template.Settings,
+				template.CreatedAt,
+				template.UpdatedAt,
+			).
+			Suffix("ON CONFLICT(id) DO UPDATE SET " +
+				"\"name\"=excluded.\"name\"," +
+				"\"description\"=excluded.\"description\"," +
+				"\"game_id\"=excluded.\"game_id\"," +
+				"\"game_mod_id\"=excluded.\"game_mod_id\"," +
+				"\"server_port\"=excluded.\"server_port\"," +
+				"\"query_port\"=excluded.\"query_port\"," +
+				"\"rcon_port\"=excluded.\"rcon_port\"," +
+				"\"su_user\"=excluded.\"su_user\"," +
+				"\"cpu_limit\"=excluded.\"cpu_limit\"," +
+				"\"ram_limit\"=excluded.\"ram_limit\"," +
+				"\"net_limit\"=excluded.\"net_limit\"," +
+				"\"start_command\"=excluded.\"start_command\"," +
+				"\"stop_command\"=excluded.\"stop_command\"," +
+				"\"force_stop_command\"=excluded.\"force_stop_command\"," +
+				"\"restart_command\"=excluded.\"restart_command\"," +
+				"\"vars\"=excluded.\"vars\"," +
+				"\"settings\"=excluded.\"settings\"," +
+				"\"updated_at\"=excluded.\"updated_at\" " +
+				"RETURNING id")
+	}
+
+	query, args, err := builder.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	var returnedID uint
+	err = r.db.QueryRowContext(ctx, query, args...).Scan(&returnedID)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	if template.ID == 0 {
+		template.ID = returnedID
+	}
+
+	return nil
+}
+
+func (r *ServerTemplateRepository) Delete(ctx context.Context, id uint) error {
+	query, args, err := sq.Delete(base.ServerTemplatesTable).
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	return nil
+}
+
+func (r *ServerTemplateRepository) scan(row base.Scanner) (*domain.ServerTemplate, error) {
+	var template domain.ServerTemplate
+
+	err := row.Scan(
+		&template.ID,
+		&template.Name,
+		&template.Description,
+		&template.GameID,
+		&template.GameModID,
+		&template.ServerPort,
+		&template.QueryPort,
+		&template.RconPort,
+		&template.SuUser,
+		&template.CPULimit,
+		&template.RAMLimit,
+		&template.NetLimit,
+		&template.StartCommand,
+		&template.StopCommand,
+		&template.ForceStopCommand,
+		&template.RestartCommand,
+		&template.Vars,
+		&template.Settings,
+		&template.CreatedAt,
+		&template.UpdatedAt,
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to scan row")
+	}
+
+	return &template, nil
+}
+
+func (r *ServerTemplateRepository) filterToSq(filter *filters.FindServerTemplate) sq.Sqlizer {
+	if filter == nil {
+		return nil
+	}
+
+	and := make(sq.And, 0, 2)
+
+	if len(filter.IDs) > 0 {
+		and = append(and, sq.Eq{"id": filter.IDs})
+	}
+
+	if len(filter.GameIDs) > 0 {
+		and = append(and, sq.Eq{"game_id": filter.GameIDs})
+	}
+
+	return and
+}
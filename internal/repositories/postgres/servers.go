@@ -711,7 +711,7 @@ func (r *ServerRepository) filterToSq(filter *filters.FindServer) sq.Sqlizer {
 		return nil
 	}
 
-	and := make(sq.And, 0, 9)
+	and := make(sq.And, 0, 10)
 
 	if len(filter.IDs) > 0 {
 		and = append(and, sq.Eq{"id": filter.IDs})
@@ -752,6 +752,10 @@ func (r *ServerRepository) filterToSq(filter *filters.FindServer) sq.Sqlizer {
 		and = append(and, sq.Eq{"game_mod_id": filter.GameModIDs})
 	}
 
+	if filter.ExpiresBefore != nil {
+		and = append(and, sq.Expr("expires IS NOT NULL AND expires < ?", *filter.ExpiresBefore))
+	}
+
 	if !filter.WithDeleted {
 		and = append(and, sq.Expr("deleted_at IS NULL"))
 	}
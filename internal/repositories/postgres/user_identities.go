@@ -0,0 +1,187 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/base"
+	"github.com/samber/lo"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+var wrappedUserIdentityFields = lo.Map(base.UserIdentityFields, func(s string, _ int) string {
+	b := strings.Builder{}
+	b.Grow(len(s) + 2)
+	b.WriteByte('"')
+	b.WriteString(s)
+	b.WriteByte('"')
+
+	return b.String()
+})
+
+type UserIdentityRepository struct {
+	db base.DB
+}
+
+func NewUserIdentityRepository(db base.DB) *UserIdentityRepository {
+	return &UserIdentityRepository{
+		db: db,
+	}
+}
+
+func (r *UserIdentityRepository) Find(
+	ctx context.Context,
+	filter *filters.FindUserIdentity,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.UserIdentity, error) {
+	builder := sq.Select(wrappedUserIdentityFields...).
+		From(base.UserIdentitiesTable).
+		Where(r.filterToSq(filter))
+
+	if len(order) > 0 {
+		for _, o := range order {
+			builder = builder.OrderBy(o.String())
+		}
+	} else {
+		builder = builder.OrderBy("provider ASC", "subject ASC")
+	}
+
+	if pagination != nil {
+		if pagination.Limit <= 0 {
+			pagination.Limit = filters.DefaultLimit
+		}
+
+		if pagination.Offset < 0 {
+			pagination.Offset = 0
+		}
+
+		builder = builder.Limit(uint64(pagination.Limit)).Offset(uint64(pagination.Offset))
+	}
+
+	query, args, err := builder.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to build query")
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...) //nolint:sqlclosecheck // closed in defer
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to execute query")
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to close rows stream", "query", query, "err", err)
+		}
+	}(rows)
+
+	var identities []domain.UserIdentity
+
+	for rows.Next() {
+		var identity *domain.UserIdentity
+		identity, err = r.scan(rows)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to scan row")
+		}
+
+		identities = append(identities, *identity)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.WithMessage(err, "rows iteration error")
+	}
+
+	return identities, nil
+}
+
+func (r *UserIdentityRepository) Save(ctx context.Context, identity *domain.UserIdentity) error {
+	query, args, err := sq.Insert(base.UserIdentitiesTable).
+		Columns(wrappedUserIdentityFields...).
+		Values(
+			identity.Provider,
+			identity.Subject,
+			identity.UserID,
+			identity.Email,
+			identity.CreatedAt,
+			identity.UpdatedAt,
+		).
+		Suffix("ON CONFLICT(provider, subject) DO UPDATE SET " +
+			"\"user_id\"=excluded.\"user_id\"," +
+			"\"email\"=excluded.\"email\"," +
+			"\"updated_at\"=excluded.\"updated_at\"").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	return nil
+}
+
+func (r *UserIdentityRepository) Delete(ctx context.Context, userID uint) error {
+	query, args, err := sq.Delete(base.UserIdentitiesTable).
+		Where(sq.Eq{"user_id": userID}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	return nil
+}
+
+func (r *UserIdentityRepository) scan(row base.Scanner) (*domain.UserIdentity, error) {
+	var identity domain.UserIdentity
+
+	err := row.Scan(
+		&identity.Provider,
+		&identity.Subject,
+		&identity.UserID,
+		&identity.Email,
+		&identity.CreatedAt,
+		&identity.UpdatedAt,
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to scan row")
+	}
+
+	return &identity, nil
+}
+
+func (r *UserIdentityRepository) filterToSq(filter *filters.FindUserIdentity) sq.Sqlizer {
+	if filter == nil {
+		return nil
+	}
+
+	and := make(sq.And, 0, 3)
+
+	if len(filter.UserIDs) > 0 {
+		and = append(and, sq.Eq{"user_id": filter.UserIDs})
+	}
+
+	if len(filter.Providers) > 0 {
+		and = append(and, sq.Eq{"provider": filter.Providers})
+	}
+
+	if len(filter.Subjects) > 0 {
+		and = append(and, sq.Eq{"subject": filter.Subjects})
+	}
+
+	return and
+}
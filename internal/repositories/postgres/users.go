@@ -129,6 +129,7 @@ func (r *UserRepository) Save(ctx context.Context, user *domain.User) error {
 				"name",
 				"created_at",
 				"updated_at",
+				"disabled_at",
 			).
 			Values(
 				user.Login,
@@ -138,6 +139,7 @@ func (r *UserRepository) Save(ctx context.Context, user *domain.User) error {
 				user.Name,
 				user.CreatedAt,
 				user.UpdatedAt,
+				user.DisabledAt,
 			).
 			Suffix("RETURNING id")
 	} else {
@@ -152,6 +154,7 @@ func (r *UserRepository) Save(ctx context.Context, user *domain.User) error {
 				user.Name,
 				user.CreatedAt,
 				user.UpdatedAt,
+				user.DisabledAt,
 			).
 			Suffix("ON CONFLICT(id) DO UPDATE SET " +
 				"login=excluded.login," +
@@ -159,7 +162,8 @@ func (r *UserRepository) Save(ctx context.Context, user *domain.User) error {
 				"password=excluded.password," +
 				"remember_token=excluded.remember_token," +
 				"name=excluded.name," +
-				"updated_at=excluded.updated_at " +
+				"updated_at=excluded.updated_at," +
+				"disabled_at=excluded.disabled_at " +
 				"RETURNING id")
 	}
 
@@ -210,6 +214,7 @@ func (r *UserRepository) scan(row base.Scanner) (*domain.User, error) {
 		&user.Name,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.DisabledAt,
 	)
 	if err != nil {
 		return nil, errors.WithMessage(err, "failed to scan row")
@@ -0,0 +1,261 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/base"
+	"github.com/samber/lo"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+var wrappedWebhookDeliveryFields = lo.Map(base.WebhookDeliveryFields, func(s string, _ int) string {
+	b := strings.Builder{}
+	b.Grow(len(s) + 2)
+	b.WriteByte('"')
+	b.WriteString(s)
+	b.WriteByte('"')
+
+	return b.String()
+})
+
+type WebhookDeliveryRepository struct {
+	db base.DB
+}
+
+func NewWebhookDeliveryRepository(db base.DB) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{
+		db: db,
+	}
+}
+
+func (r *WebhookDeliveryRepository) Find(
+	ctx context.Context,
+	filter *filters.FindWebhookDelivery,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.WebhookDelivery, error) {
+	builder := sq.Select(wrappedWebhookDeliveryFields...).
+		From(base.WebhookDeliveriesTable).
+		Where(r.filterToSq(filter))
+
+	if len(order) > 0 {
+		for _, o := range order {
+			builder = builder.OrderBy(o.String())
+		}
+	} else {
+		builder = builder.OrderBy("id ASC")
+	}
+
+	if pagination != nil {
+		if pagination.Limit <= 0 {
+			pagination.Limit = filters.DefaultLimit
+		}
+
+		if pagination.Offset < 0 {
+			pagination.Offset = 0
+		}
+
+		builder = builder.Limit(uint64(pagination.Limit)).Offset(uint64(pagination.Offset))
+	}
+
+	query, args, err := builder.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to build query")
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...) //nolint:sqlclosecheck // closed in defer
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to execute query")
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to close rows stream", "query", query, "err", err)
+		}
+	}(rows)
+
+	var deliveries []domain.WebhookDelivery
+
+	for rows.Next() {
+		var delivery *domain.WebhookDelivery
+		delivery, err = r.scan(rows)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to scan row")
+		}
+
+		deliveries = append(deliveries, *delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.WithMessage(err, "rows iteration error")
+	}
+
+	return deliveries, nil
+}
+
+func (r *WebhookDeliveryRepository) Save(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	delivery.UpdatedAt = lo.ToPtr(time.Now())
+
+	if delivery.ID == 0 && (delivery.CreatedAt == nil || delivery.CreatedAt.IsZero()) {
+		delivery.CreatedAt = lo.ToPtr(time.Now())
+	}
+
+	builder := sq.Insert(base.WebhookDeliveriesTable)
+
+	if delivery.ID == 0 {
+		builder = builder.
+			Columns(
+				"\"webhook_id\"",
+				"\"event_id\"",
+				"\"event_type\"",
+				"\"payload\"",
+				"\"status\"",
+				"\"attempts\"",
+				"\"next_attempt_at\"",
+				"\"response_status\"",
+				"\"error\"",
+				"\"created_at\"",
+				"\"updated_at\"",
+			).
+			Values(
+				delivery.WebhookID,
+				delivery.EventID,
+				delivery.EventType,
+				delivery.Payload,
+				delivery.Status,
+				delivery.Attempts,
+				delivery.NextAttemptAt,
+				delivery.ResponseStatus,
+				delivery.Error,
+				delivery.CreatedAt,
+				delivery.UpdatedAt,
+			).
+			Suffix("RETURNING id")
+	} else {
+		builder = builder.
+			Columns(wrappedWebhookDeliveryFields...).
+			Values(
+				delivery.ID,
+				delivery.WebhookID,
+				delivery.EventID,
+				delivery.EventType,
+				delivery.Payload,
+				delivery.Status,
+				delivery.Attempts,
+				delivery.NextAttemptAt,
+				delivery.ResponseStatus,
+				delivery.Error,
+				delivery.CreatedAt,
+				delivery.UpdatedAt,
+			).
+			Suffix("ON CONFLICT(id) DO UPDATE SET " +
+				"\"status\"=excluded.\"status\"," +
+				"\"attempts\"=excluded.\"attempts\"," +
+				"\"next_attempt_at\"=excluded.\"next_attempt_at\"," +
+				"\"response_status\"=excluded.\"response_status\"," +
+				"\"error\"=excluded.\"error\"," +
+				"\"created_at\"=excluded.\"created_at\"," +
+				"\"updated_at\"=excluded.\"updated_at\" " +
+				"RETURNING id")
+	}
+
+	query, args, err := builder.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	var returnedID uint
+	err = r.db.QueryRowContext(ctx, query, args...).Scan(&returnedID)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	if delivery.ID == 0 {
+		delivery.ID = returnedID
+	}
+
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) DeleteBefore(ctx context.Context, before time.Time) error {
+	return r.delete(ctx, sq.Lt{"created_at": before})
+}
+
+func (r *WebhookDeliveryRepository) DeleteByWebhookID(ctx context.Context, webhookID uint) error {
+	return r.delete(ctx, sq.Eq{"webhook_id": webhookID})
+}
+
+func (r *WebhookDeliveryRepository) delete(ctx context.Context, where sq.Sqlizer) error {
+	query, args, err := sq.Delete(base.WebhookDeliveriesTable).
+		Where(where).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) scan(row base.Scanner) (*domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.WebhookID,
+		&delivery.EventID,
+		&delivery.EventType,
+		&delivery.Payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.NextAttemptAt,
+		&delivery.ResponseStatus,
+		&delivery.Error,
+		&delivery.CreatedAt,
+		&delivery.UpdatedAt,
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to scan row")
+	}
+
+	return &delivery, nil
+}
+
+func (r *WebhookDeliveryRepository) filterToSq(filter *filters.FindWebhookDelivery) sq.Sqlizer {
+	if filter == nil {
+		return nil
+	}
+
+	and := make(sq.And, 0, 4)
+
+	if len(filter.IDs) > 0 {
+		and = append(and, sq.Eq{"id": filter.IDs})
+	}
+
+	if len(filter.WebhookIDs) > 0 {
+		and = append(and, sq.Eq{"webhook_id": filter.WebhookIDs})
+	}
+
+	if len(filter.Statuses) > 0 {
+		and = append(and, sq.Eq{"status": filter.Statuses})
+	}
+
+	if filter.NextAttemptBefore != nil {
+		and = append(and, sq.LtOrEq{"next_attempt_at": *filter.NextAttemptBefore})
+	}
+
+	return and
+}
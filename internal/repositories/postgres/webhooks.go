@@ -0,0 +1,242 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/base"
+	"github.com/samber/lo"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+var wrappedWebhookFields = lo.Map(base.WebhookFields, func(s string, _ int) string {
+	b := strings.Builder{}
+	b.Grow(len(s) + 2)
+	b.WriteByte('"')
+	b.WriteString(s)
+	b.WriteByte('"')
+
+	return b.String()
+})
+
+type WebhookRepository struct {
+	db base.DB
+}
+
+func NewWebhookRepository(db base.DB) *WebhookRepository {
+	return &WebhookRepository{
+		db: db,
+	}
+}
+
+func (r *WebhookRepository) Find(
+	ctx context.Context,
+	filter *filters.FindWebhook,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.Webhook, error) {
+	builder := sq.Select(wrappedWebhookFields...).
+		From(base.WebhooksTable).
+		Where(r.filterToSq(filter))
+
+	if len(order) > 0 {
+		for _, o := range order {
+			builder = builder.OrderBy(o.String())
+		}
+	} else {
+		builder = builder.OrderBy("id ASC")
+	}
+
+	if pagination != nil {
+		if pagination.Limit <= 0 {
+			pagination.Limit = filters.DefaultLimit
+		}
+
+		if pagination.Offset < 0 {
+			pagination.Offset = 0
+		}
+
+		builder = builder.Limit(uint64(pagination.Limit)).Offset(uint64(pagination.Offset))
+	}
+
+	query, args, err := builder.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to build query")
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...) //nolint:sqlclosecheck // closed in defer
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to execute query")
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to close rows stream", "query", query, "err", err)
+		}
+	}(rows)
+
+	var webhooks []domain.Webhook
+
+	for rows.Next() {
+		var webhook *domain.Webhook
+		webhook, err = r.scan(rows)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to scan row")
+		}
+
+		webhooks = append(webhooks, *webhook)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.WithMessage(err, "rows iteration error")
+	}
+
+	return webhooks, nil
+}
+
+func (r *WebhookRepository) Save(ctx context.Context, webhook *domain.Webhook) error {
+	webhook.UpdatedAt = lo.ToPtr(time.Now())
+
+	if webhook.ID == 0 && (webhook.CreatedAt == nil || webhook.CreatedAt.IsZero()) {
+		webhook.CreatedAt = lo.ToPtr(time.Now())
+	}
+
+	builder := sq.Insert(base.WebhooksTable)
+
+	if webhook.ID == 0 {
+		builder = builder.
+			Columns(
+				"\"user_id\"",
+				"\"server_id\"",
+				"\"name\"",
+				"\"url\"",
+				"\"secret\"",
+				"\"events\"",
+				"\"enabled\"",
+				"\"created_at\"",
+				"\"updated_at\"",
+			).
+			Values(
+				webhook.UserID,
+				webhook.ServerID,
+				webhook.Name,
+				webhook.URL,
+				webhook.Secret,
+				webhook.Events,
+				webhook.Enabled,
+				webhook.CreatedAt,
+				webhook.UpdatedAt,
+			).
+			Suffix("RETURNING id")
+	} else {
+		builder = builder.
+			Columns(wrappedWebhookFields...).
+			Values(
+				webhook.ID,
+				webhook.UserID,
+				webhook.ServerID,
+				webhook.Name,
+				webhook.URL,
+				webhook.Secret,
+				webhook.Events,
+				webhook.Enabled,
+				webhook.CreatedAt,
+				webhook.UpdatedAt,
+			).
+			Suffix("ON CONFLICT(id) DO UPDATE SET " +
+				"\"user_id\"=excluded.\"user_id\"," +
+				"\"server_id\"=excluded.\"server_id\"," +
+				"\"name\"=excluded.\"name\"," +
+				"\"url\"=excluded.\"url\"," +
+				"\"secret\"=excluded.\"secret\"," +
+				"\"events\"=excluded.\"events\"," +
+				"\"enabled\"=excluded.\"enabled\"," +
+				"\"updated_at\"=excluded.\"updated_at\" " +
+				"RETURNING id")
+	}
+
+	query, args, err := builder.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	var returnedID uint
+	err = r.db.QueryRowContext(ctx, query, args...).Scan(&returnedID)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	if webhook.ID == 0 {
+		webhook.ID = returnedID
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) Delete(ctx context.Context, id uint) error {
+	query, args, err := sq.Delete(base.WebhooksTable).
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) scan(row base.Scanner) (*domain.Webhook, error) {
+	var webhook domain.Webhook
+
+	err := row.Scan(
+		&webhook.ID,
+		&webhook.UserID,
+		&webhook.ServerID,
+		&webhook.Name,
+		&webhook.URL,
+		&webhook.Secret,
+		&webhook.Events,
+		&webhook.Enabled,
+		&webhook.CreatedAt,
+		&webhook.UpdatedAt,
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to scan row")
+	}
+
+	return &webhook, nil
+}
+
+func (r *WebhookRepository) filterToSq(filter *filters.FindWebhook) sq.Sqlizer {
+	if filter == nil {
+		return nil
+	}
+
+	and := make(sq.And, 0, 3)
+
+	if len(filter.IDs) > 0 {
+		and = append(and, sq.Eq{"id": filter.IDs})
+	}
+
+	if len(filter.UserIDs) > 0 {
+		and = append(and, sq.Eq{"user_id": filter.UserIDs})
+	}
+
+	if filter.Enabled != nil {
+		and = append(and, sq.Eq{"enabled": *filter.Enabled})
+	}
+
+	return and
+}
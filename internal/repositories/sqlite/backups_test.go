@@ -0,0 +1,20 @@
+package sqlite_test
+
+import (
+	"testing"
+
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/repositories/sqlite"
+	repotesting "github.com/gameap/gameap/internal/repositories/testing"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestBackupRepository(t *testing.T) {
+	suite.Run(t, repotesting.NewBackupRepositorySuite(
+		func(t *testing.T) repositories.BackupRepository {
+			t.Helper()
+
+			return sqlite.NewBackupRepository(SetupTestDB(t))
+		},
+	))
+}
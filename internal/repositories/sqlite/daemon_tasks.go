@@ -210,21 +210,28 @@ func (r *DaemonTaskRepository) Delete(ctx context.Context, id uint) error {
 	return nil
 }
 
-func (r *DaemonTaskRepository) AppendOutput(ctx context.Context, id uint, output string) error {
+func (r *DaemonTaskRepository) AppendOutput(ctx context.Context, id uint, output string) (int, error) {
 	query, args, err := sq.Update(base.DaemonTasksTable).
 		Set("output", sq.Expr("COALESCE(output,'') || ?", output)).
 		Where(sq.Eq{"id": id}).
+		Suffix("RETURNING length(CAST(output AS BLOB))").
 		ToSql()
 	if err != nil {
-		return errors.WithMessage(err, "failed to build query")
+		return 0, errors.WithMessage(err, "failed to build query")
 	}
 
-	_, err = r.db.ExecContext(ctx, query, args...)
+	var length int
+
+	err = r.db.QueryRowContext(ctx, query, args...).Scan(&length)
 	if err != nil {
-		return errors.WithMessage(err, "failed to execute query")
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+
+		return 0, errors.WithMessage(err, "failed to execute query")
 	}
 
-	return nil
+	return length, nil
 }
 
 func (r *DaemonTaskRepository) Count(ctx context.Context, filter *filters.FindDaemonTask) (int, error) {
@@ -0,0 +1,20 @@
+package sqlite_test
+
+import (
+	"testing"
+
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/repositories/sqlite"
+	repotesting "github.com/gameap/gameap/internal/repositories/testing"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestMetricRepository(t *testing.T) {
+	suite.Run(t, repotesting.NewMetricRepositorySuite(
+		func(t *testing.T) repositories.MetricRepository {
+			t.Helper()
+
+			return sqlite.NewMetricRepository(SetupTestDB(t))
+		},
+	))
+}
@@ -167,6 +167,8 @@ func (r *NodeRepository) Save(ctx context.Context, node *domain.Node) error {
 			node.ScriptGetConsole,
 			node.ScriptSendCommand,
 			node.ScriptDelete,
+			node.PortRangeStart,
+			node.PortRangeEnd,
 			createdAtStr,
 			updatedAtStr,
 			deletedAtStr,
@@ -205,6 +207,8 @@ func (r *NodeRepository) Save(ctx context.Context, node *domain.Node) error {
 			"script_get_console=excluded.script_get_console," +
 			"script_send_command=excluded.script_send_command," +
 			"script_delete=excluded.script_delete," +
+			"port_range_start=excluded.port_range_start," +
+			"port_range_end=excluded.port_range_end," +
 			"updated_at=excluded.updated_at," +
 			"deleted_at=excluded.deleted_at " +
 			"RETURNING id").
@@ -281,6 +285,8 @@ func (r *NodeRepository) scan(row base.Scanner) (*domain.Node, error) {
 		&node.ScriptGetConsole,
 		&node.ScriptSendCommand,
 		&node.ScriptDelete,
+		&node.PortRangeStart,
+		&node.PortRangeEnd,
 		&createdAtStr,
 		&updatedAtStr,
 		&deletedAtStr,
@@ -0,0 +1,20 @@
+package sqlite_test
+
+import (
+	"testing"
+
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/repositories/sqlite"
+	repotesting "github.com/gameap/gameap/internal/repositories/testing"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestNotificationChannelRepository(t *testing.T) {
+	suite.Run(t, repotesting.NewNotificationChannelRepositorySuite(
+		func(t *testing.T) repositories.NotificationChannelRepository {
+			t.Helper()
+
+			return sqlite.NewNotificationChannelRepository(SetupTestDB(t))
+		},
+	))
+}
@@ -0,0 +1,191 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/base"
+	"github.com/samber/lo"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+var wrappedPasswordResetFields = lo.Map(base.PasswordResetFields, func(s string, _ int) string {
+	b := strings.Builder{}
+	b.Grow(len(s) + 2)
+	b.WriteByte('`')
+	b.WriteString(s)
+	b.WriteByte('`')
+
+	return b.String()
+})
+
+type PasswordResetRepository struct {
+	db base.DB
+}
+
+func NewPasswordResetRepository(db base.DB) *PasswordResetRepository {
+	return &PasswordResetRepository{
+		db: db,
+	}
+}
+
+func (r *PasswordResetRepository) Find(
+	ctx context.Context,
+	filter *filters.FindPasswordReset,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.PasswordReset, error) {
+	builder := sq.Select(wrappedPasswordResetFields...).
+		From(base.PasswordResetsTable).
+		Where(r.filterToSq(filter))
+
+	if len(order) > 0 {
+		for _, o := range order {
+			builder = builder.OrderBy(o.String())
+		}
+	} else {
+		builder = builder.OrderBy("created_at DESC")
+	}
+
+	if pagination != nil {
+		if pagination.Limit <= 0 {
+			pagination.Limit = filters.DefaultLimit
+		}
+
+		if pagination.Offset < 0 {
+			pagination.Offset = 0
+		}
+
+		builder = builder.Limit(uint64(pagination.Limit)).Offset(uint64(pagination.Offset))
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to build query")
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...) //nolint:sqlclosecheck // closed in defer
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to execute query")
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to close rows stream", "query", query, "err", err)
+		}
+	}(rows)
+
+	var resets []domain.PasswordReset
+
+	for rows.Next() {
+		var reset *domain.PasswordReset
+		reset, err = r.scan(rows)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to scan row")
+		}
+
+		resets = append(resets, *reset)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.WithMessage(err, "rows iteration error")
+	}
+
+	return resets, nil
+}
+
+// Save replaces the password reset of the email.
+// The legacy table has no unique key on email, so the previous resets are deleted before the insert.
+func (r *PasswordResetRepository) Save(ctx context.Context, reset *domain.PasswordReset) error {
+	err := r.Delete(ctx, reset.Email)
+	if err != nil {
+		return err
+	}
+
+	var createdAtStr *string
+	if reset.CreatedAt != nil {
+		createdAtStr = lo.ToPtr(reset.CreatedAt.Format(time.RFC3339))
+	}
+
+	query, args, err := sq.Insert(base.PasswordResetsTable).
+		Columns(wrappedPasswordResetFields...).
+		Values(
+			reset.Email,
+			reset.Token,
+			createdAtStr,
+		).
+		PlaceholderFormat(sq.Question).
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	return nil
+}
+
+func (r *PasswordResetRepository) Delete(ctx context.Context, email string) error {
+	query, args, err := sq.Delete(base.PasswordResetsTable).
+		Where(sq.Eq{"email": email}).
+		PlaceholderFormat(sq.Question).
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	return nil
+}
+
+func (r *PasswordResetRepository) scan(row base.Scanner) (*domain.PasswordReset, error) {
+	var reset domain.PasswordReset
+	var createdAtStr *string
+
+	err := row.Scan(
+		&reset.Email,
+		&reset.Token,
+		&createdAtStr,
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to scan row")
+	}
+
+	if createdAtStr != nil && *createdAtStr != "" {
+		createdAt, err := base.ParseTime(*createdAtStr)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to parse created_at time")
+		}
+		reset.CreatedAt = &createdAt
+	}
+
+	return &reset, nil
+}
+
+func (r *PasswordResetRepository) filterToSq(filter *filters.FindPasswordReset) sq.Sqlizer {
+	if filter == nil {
+		return nil
+	}
+
+	and := make(sq.And, 0, 1)
+
+	if len(filter.Emails) > 0 {
+		and = append(and, sq.Eq{"email": filter.Emails})
+	}
+
+	return and
+}
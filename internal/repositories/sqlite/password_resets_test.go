@@ -0,0 +1,20 @@
+package sqlite_test
+
+import (
+	"testing"
+
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/repositories/sqlite"
+	repotesting "github.com/gameap/gameap/internal/repositories/testing"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestPasswordResetRepository(t *testing.T) {
+	suite.Run(t, repotesting.NewPasswordResetRepositorySuite(
+		func(t *testing.T) repositories.PasswordResetRepository {
+			t.Helper()
+
+			return sqlite.NewPasswordResetRepository(SetupTestDB(t))
+		},
+	))
+}
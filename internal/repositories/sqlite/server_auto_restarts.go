@@ -0,0 +1,217 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/base"
+	"github.com/samber/lo"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+var wrappedServerAutoRestartFields = lo.Map(base.ServerAutoRestartFields, func(s string, _ int) string {
+	b := strings.Builder{}
+	b.Grow(len(s) + 2)
+	b.WriteByte('`')
+	b.WriteString(s)
+	b.WriteByte('`')
+
+	return b.String()
+})
+
+type ServerAutoRestartRepository struct {
+	db base.DB
+}
+
+func NewServerAutoRestartRepository(db base.DB) *ServerAutoRestartRepository {
+	return &ServerAutoRestartRepository{
+		db: db,
+	}
+}
+
+func (r *ServerAutoRestartRepository) Find(
+	ctx context.Context,
+	filter *filters.FindServerAutoRestart,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.ServerAutoRestart, error) {
+	builder := sq.Select(wrappedServerAutoRestartFields...).
+		From(base.ServerAutoRestartsTable).
+		Where(r.filterToSq(filter))
+
+	if len(order) > 0 {
+		for _, o := range order {
+			builder = builder.OrderBy(o.String())
+		}
+	} else {
+		builder = builder.OrderBy("server_id ASC")
+	}
+
+	if pagination != nil {
+		if pagination.Limit <= 0 {
+			pagination.Limit = filters.DefaultLimit
+		}
+
+		if pagination.Offset < 0 {
+			pagination.Offset = 0
+		}
+
+		builder = builder.Limit(uint64(pagination.Limit)).Offset(uint64(pagination.Offset))
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to build query")
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...) //nolint:sqlclosecheck // closed in defer
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to execute query")
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to close rows stream", "query", query, "err", err)
+		}
+	}(rows)
+
+	var restarts []domain.ServerAutoRestart
+
+	for rows.Next() {
+		var restart *domain.ServerAutoRestart
+		restart, err = r.scan(rows)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to scan row")
+		}
+
+		restarts = append(restarts, *restart)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.WithMessage(err, "rows iteration error")
+	}
+
+	return restarts, nil
+}
+
+func (r *ServerAutoRestartRepository) Save(ctx context.Context, restart *domain.ServerAutoRestart) error {
+	formatTime := func(t *time.Time) *string {
+		if t != nil {
+			return lo.ToPtr(t.Format(time.RFC3339))
+		}
+
+		return nil
+	}
+
+	query, args, err := sq.Insert(base.ServerAutoRestartsTable).
+		Columns(wrappedServerAutoRestartFields...).
+		Values(
+			restart.ServerID,
+			formatTime(restart.CrashedAt),
+			restart.Attempts,
+			formatTime(restart.LastAttemptAt),
+			restart.LastTaskID,
+			restart.CrashLoop,
+			formatTime(restart.CrashLoopAt),
+			restart.CrashLoops,
+			restart.UpdatedAt.Format(time.RFC3339),
+		).
+		Suffix("ON CONFLICT(server_id) DO UPDATE SET " +
+			"crashed_at=excluded.crashed_at," +
+			"attempts=excluded.attempts," +
+			"last_attempt_at=excluded.last_attempt_at," +
+			"last_task_id=excluded.last_task_id," +
+			"crash_loop=excluded.crash_loop," +
+			"crash_loop_at=excluded.crash_loop_at," +
+			"crash_loops=excluded.crash_loops," +
+			"updated_at=excluded.updated_at").
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	return nil
+}
+
+func (r *ServerAutoRestartRepository) scan(row base.Scanner) (*domain.ServerAutoRestart, error) {
+	var restart domain.ServerAutoRestart
+	var crashedAtStr, lastAttemptAtStr, crashLoopAtStr *string
+	var updatedAtStr string
+
+	err := row.Scan(
+		&restart.ServerID,
+		&crashedAtStr,
+		&restart.Attempts,
+		&lastAttemptAtStr,
+		&restart.LastTaskID,
+		&restart.CrashLoop,
+		&crashLoopAtStr,
+		&restart.CrashLoops,
+		&updatedAtStr,
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to scan row")
+	}
+
+	parseTime := func(s *string, field string) (*time.Time, error) {
+		if s == nil || *s == "" {
+			return nil, nil
+		}
+
+		t, err := base.ParseTime(*s)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to parse %s time", field)
+		}
+
+		return &t, nil
+	}
+
+	if restart.CrashedAt, err = parseTime(crashedAtStr, "crashed_at"); err != nil {
+		return nil, err
+	}
+
+	if restart.LastAttemptAt, err = parseTime(lastAttemptAtStr, "last_attempt_at"); err != nil {
+		return nil, err
+	}
+
+	if restart.CrashLoopAt, err = parseTime(crashLoopAtStr, "crash_loop_at"); err != nil {
+		return nil, err
+	}
+
+	restart.UpdatedAt, err = base.ParseTime(updatedAtStr)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to parse updated_at time")
+	}
+
+	return &restart, nil
+}
+
+func (r *ServerAutoRestartRepository) filterToSq(filter *filters.FindServerAutoRestart) sq.Sqlizer {
+	if filter == nil {
+		return nil
+	}
+
+	and := make(sq.And, 0, 2)
+
+	if len(filter.ServerIDs) > 0 {
+		and = append(and, sq.Eq{"server_id": filter.ServerIDs})
+	}
+
+	if filter.CrashLoop != nil {
+		and = append(and, sq.Eq{"crash_loop": *filter.CrashLoop})
+	}
+
+	return and
+}
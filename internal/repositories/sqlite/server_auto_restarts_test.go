@@ -0,0 +1,20 @@
+package sqlite_test
+
+import (
+	"testing"
+
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/repositories/sqlite"
+	repotesting "github.com/gameap/gameap/internal/repositories/testing"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestServerAutoRestartRepository(t *testing.T) {
+	suite.Run(t, repotesting.NewServerAutoRestartRepositorySuite(
+		func(t *testing.T) repositories.ServerAutoRestartRepository {
+			t.Helper()
+
+			return sqlite.NewServerAutoRestartRepository(SetupTestDB(t))
+		},
+	))
+}
@@ -0,0 +1,20 @@
+package sqlite_test
+
+import (
+	"testing"
+
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/repositories/sqlite"
+	repotesting "github.com/gameap/gameap/internal/repositories/testing"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestServerQueryRecordRepository(t *testing.T) {
+	suite.Run(t, repotesting.NewServerQueryRecordRepositorySuite(
+		func(t *testing.T) repositories.ServerQueryRecordRepository {
+			t.Helper()
+
+			return sqlite.NewServerQueryRecordRepository(SetupTestDB(t))
+		},
+	))
+}
@@ -182,6 +182,8 @@ func (r *ServerTaskRepository) Save(ctx context.Context, task *domain.ServerTask
 			task.Counter,
 			executeDateStr,
 			task.Payload,
+			task.CronExpression,
+			task.Timezone,
 			createdAtStr,
 			updatedAtStr,
 		).
@@ -193,6 +195,8 @@ func (r *ServerTaskRepository) Save(ctx context.Context, task *domain.ServerTask
 			"`counter`=excluded.`counter`," +
 			"`execute_date`=excluded.`execute_date`," +
 			"`payload`=excluded.`payload`," +
+			"`cron_expression`=excluded.`cron_expression`," +
+			"`timezone`=excluded.`timezone`," +
 			"`updated_at`=excluded.`updated_at` " +
 			"RETURNING id").
 		ToSql()
@@ -244,6 +248,8 @@ func (r *ServerTaskRepository) scan(row base.Scanner) (*domain.ServerTask, error
 		&task.Counter,
 		&executeDateStr,
 		&task.Payload,
+		&task.CronExpression,
+		&task.Timezone,
 		&createdAtStr,
 		&updatedAtStr,
 	)
@@ -0,0 +1,262 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/base"
+	"github.com/samber/lo"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+var wrappedServerTemplateFields = lo.Map(base.ServerTemplateFields, func(s string, _ int) string {
+	b := strings.Builder{}
+	b.Grow(len(s) + 2)
+	b.WriteByte('`')
+	b.WriteString(s)
+	b.WriteByte('`')
+
+	return b.String()
+})
+
+type ServerTemplateRepository struct {
+	db base.DB
+}
+
+func NewServerTemplateRepository(db base.DB) *ServerTemplateRepository {
+	return &ServerTemplateRepository{
+		db: db,
+	}
+}
+
+func (r *ServerTemplateRepository) Find(
+	ctx context.Context,
+	filter *filters.FindServerTemplate,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.ServerTemplate, error) {
+	builder := sq.Select(wrappedServerTemplateFields...).
+		From(base.ServerTemplatesTable).
+		Where(r.filterToSq(filter))
+
+	if len(order) > 0 {
+		for _, o := range order {
+			builder = builder.OrderBy(o.String())
+		}
+	} else {
+		builder = builder.OrderBy("id ASC")
+	}
+
+	if pagination != nil {
+		if pagination.Limit <= 0 {
+			pagination.Limit = filters.DefaultLimit
+		}
+
+		if pagination.Offset < 0 {
+			pagination.Offset = 0
+		}
+
+		builder = builder.Limit(uint64(pagination.Limit)).Offset(uint64(pagination.Offset))
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to build query")
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...) //nolint:sqlclosecheck // closed in defer
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to execute query")
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to close rows stream", "query", query, "err", err)
+		}
+	}(rows)
+
+	var templates []domain.ServerTemplate
+
+	for rows.Next() {
+		var template *domain.ServerTemplate
+		template, err = r.scan(rows)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to scan row")
+		}
+
+		templates = append(templates, *template)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.WithMessage(err, "rows iteration error")
+	}
+
+	return templates, nil
+}
+
+func (r *ServerTemplateRepository) Save(ctx context.Context, template *domain.ServerTemplate) error {
+	template.UpdatedAt = lo.ToPtr(time.Now())
+
+	if template.ID == 0 && (template.CreatedAt == nil || template.CreatedAt.IsZero()) {
+		template.CreatedAt = lo.ToPtr(time.Now())
+	}
+
+	var createdAtStr, updatedAtStr *string
+	if template.CreatedAt != nil {
+		createdAtStr = lo.ToPtr(template.CreatedAt.Format(time.RFC3339))
+	}
+	if template.UpdatedAt != nil {
+		updatedAtStr = lo.ToPtr(template.UpdatedAt.Format(time.RFC3339))
+	}
+
+	query, args, err := sq.Insert(base.ServerTemplatesTable).
+		Columns(wrappedServerTemplateFields...).
+		Values(
+			lo.EmptyableToPtr(template.ID),
+			template.Name,
+			template.Description,
+			template.GameID,
+			template.GameModID,
+			template.ServerPort,
+			template.QueryPort,
+			template.RconPort,
+			template.SuUser,
+			template.CPULimit,
+			template.RAMLimit,
+			template.NetLimit,
+			template.StartCommand,
+			template.StopCommand,
+			template.ForceStopCommand,
+			template.RestartCommand,
+			template.Vars,
+			template.Settings,
+			createdAtStr,
+			updatedAtStr,
+		).
+		Suffix("ON CONFLICT(id) DO UPDATE SET " +
+			"name=excluded.name," +
+			"description=excluded.description," +
+			"game_id=excluded.game_id," +
+			"game_mod_id=excluded.game_mod_id," +
+			"server_port=excluded.server_port," +
+			"query_port=excluded.query_port," +
+			"rcon_port=excluded.rcon_port," +
+			"su_user=excluded.su_user," +
+			"cpu_limit=excluded.cpu_limit," +
+			"ram_limit=excluded.ram_limit," +
+			"net_limit=excluded.net_limit," +
+			"start_command=excluded.start_command," +
+			"stop_command=excluded.stop_command," +
+			"force_stop_command=excluded.force_stop_command," +
+			"restart_command=excluded.restart_command," +
+			"vars=excluded.vars," +
+			"settings=excluded.settings," +
+			"updated_at=excluded.updated_at " +
+			"RETURNING id").
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	var returnedID uint
+	err = r.db.QueryRowContext(ctx, query, args...).Scan(&returnedID)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	if template.ID == 0 {
+		template.ID = returnedID
+	}
+
+	return nil
+}
+
+func (r *ServerTemplateRepository) Delete(ctx context.Context, id uint) error {
+	query, args, err := sq.Delete(base.ServerTemplatesTable).
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	return nil
+}
+
+func (r *ServerTemplateRepository) scan(row base.Scanner) (*domain.ServerTemplate, error) {
+	var template domain.ServerTemplate
+	var createdAtStr, updatedAtStr *string
+
+	err := row.Scan(
+		&template.ID,
+		&template.Name,
+		&template.Description,
+		&template.GameID,
+		&template.GameModID,
+		&template.ServerPort,
+		&template.QueryPort,
+		&template.RconPort,
+		&template.SuUser,
+		&template.CPULimit,
+		&template.RAMLimit,
+		&template.NetLimit,
+		&template.StartCommand,
+		&template.StopCommand,
+		&template.ForceStopCommand,
+		&template.RestartCommand,
+		&template.Vars,
+		&template.Settings,
+		&createdAtStr,
+		&updatedAtStr,
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to scan row")
+	}
+
+	if createdAtStr != nil && *createdAtStr != "" {
+		createdAt, err := base.ParseTime(*createdAtStr)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to parse created_at time")
+		}
+		template.CreatedAt = &createdAt
+	}
+
+	if updatedAtStr != nil && *updatedAtStr != "" {
+		updatedAt, err := base.ParseTime(*updatedAtStr)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to parse updated_at time")
+		}
+		template.UpdatedAt = &updatedAt
+	}
+
+	return &template, nil
+}
+
+func (r *ServerTemplateRepository) filterToSq(filter *filters.FindServerTemplate) sq.Sqlizer {
+	if filter == nil {
+		return nil
+	}
+
+	and := make(sq.And, 0, 2)
+
+	if len(filter.IDs) > 0 {
+		and = append(and, sq.Eq{"id": filter.IDs})
+	}
+
+	if len(filter.GameIDs) > 0 {
+		and = append(and, sq.Eq{"game_id": filter.GameIDs})
+	}
+
+	return and
+}
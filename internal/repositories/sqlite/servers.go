@@ -579,7 +579,7 @@ func (r *ServerRepository) filterToSq(filter *filters.FindServer) sq.Sqlizer {
 		return nil
 	}
 
-	and := make(sq.And, 0, 9)
+	and := make(sq.And, 0, 10)
 
 	if len(filter.IDs) > 0 {
 		and = append(and, sq.Eq{"id": filter.IDs})
@@ -620,6 +620,13 @@ func (r *ServerRepository) filterToSq(filter *filters.FindServer) sq.Sqlizer {
 		and = append(and, sq.Eq{"game_mod_id": filter.GameModIDs})
 	}
 
+	if filter.ExpiresBefore != nil {
+		and = append(and, sq.Expr(
+			"expires IS NOT NULL AND datetime(expires) < datetime(?)",
+			filter.ExpiresBefore.UTC().Format(time.RFC3339),
+		))
+	}
+
 	if !filter.WithDeleted {
 		and = append(and, sq.Expr("deleted_at IS NULL"))
 	}
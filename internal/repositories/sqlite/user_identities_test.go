@@ -0,0 +1,20 @@
+package sqlite_test
+
+import (
+	"testing"
+
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/repositories/sqlite"
+	repotesting "github.com/gameap/gameap/internal/repositories/testing"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestUserIdentityRepository(t *testing.T) {
+	suite.Run(t, repotesting.NewUserIdentityRepositorySuite(
+		func(t *testing.T) repositories.UserIdentityRepository {
+			t.Helper()
+
+			return sqlite.NewUserIdentityRepository(SetupTestDB(t))
+		},
+	))
+}
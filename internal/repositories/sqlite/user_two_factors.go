@@ -0,0 +1,219 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/base"
+	"github.com/samber/lo"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+var wrappedUserTwoFactorFields = lo.Map(base.UserTwoFactorFields, func(s string, _ int) string {
+	b := strings.Builder{}
+	b.Grow(len(s) + 2)
+	b.WriteByte('`')
+	b.WriteString(s)
+	b.WriteByte('`')
+
+	return b.String()
+})
+
+type UserTwoFactorRepository struct {
+	db base.DB
+}
+
+func NewUserTwoFactorRepository(db base.DB) *UserTwoFactorRepository {
+	return &UserTwoFactorRepository{
+		db: db,
+	}
+}
+
+func (r *UserTwoFactorRepository) Find(
+	ctx context.Context,
+	filter *filters.FindUserTwoFactor,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.UserTwoFactor, error) {
+	builder := sq.Select(wrappedUserTwoFactorFields...).
+		From(base.UserTwoFactorsTable).
+		Where(r.filterToSq(filter))
+
+	if len(order) > 0 {
+		for _, o := range order {
+			builder = builder.OrderBy(o.String())
+		}
+	} else {
+		builder = builder.OrderBy("user_id ASC")
+	}
+
+	if pagination != nil {
+		if pagination.Limit <= 0 {
+			pagination.Limit = filters.DefaultLimit
+		}
+
+		if pagination.Offset < 0 {
+			pagination.Offset = 0
+		}
+
+		builder = builder.Limit(uint64(pagination.Limit)).Offset(uint64(pagination.Offset))
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to build query")
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...) //nolint:sqlclosecheck // closed in defer
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to execute query")
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to close rows stream", "query", query, "err", err)
+		}
+	}(rows)
+
+	var twoFactors []domain.UserTwoFactor
+
+	for rows.Next() {
+		var twoFactor *domain.UserTwoFactor
+		twoFactor, err = r.scan(rows)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to scan row")
+		}
+
+		twoFactors = append(twoFactors, *twoFactor)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.WithMessage(err, "rows iteration error")
+	}
+
+	return twoFactors, nil
+}
+
+func (r *UserTwoFactorRepository) Save(ctx context.Context, twoFactor *domain.UserTwoFactor) error {
+	formatTime := func(t *time.Time) *string {
+		if t != nil {
+			return lo.ToPtr(t.Format(time.RFC3339))
+		}
+
+		return nil
+	}
+
+	query, args, err := sq.Insert(base.UserTwoFactorsTable).
+		Columns(wrappedUserTwoFactorFields...).
+		Values(
+			twoFactor.UserID,
+			twoFactor.Secret,
+			formatTime(twoFactor.ConfirmedAt),
+			twoFactor.RecoveryCodes,
+			twoFactor.LastUsedStep,
+			formatTime(twoFactor.CreatedAt),
+			formatTime(twoFactor.UpdatedAt),
+		).
+		Suffix("ON CONFLICT(user_id) DO UPDATE SET " +
+			"secret=excluded.secret," +
+			"confirmed_at=excluded.confirmed_at," +
+			"recovery_codes=excluded.recovery_codes," +
+			"last_used_step=excluded.last_used_step," +
+			"created_at=excluded.created_at," +
+			"updated_at=excluded.updated_at").
+		PlaceholderFormat(sq.Question).
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	return nil
+}
+
+func (r *UserTwoFactorRepository) Delete(ctx context.Context, userID uint) error {
+	query, args, err := sq.Delete(base.UserTwoFactorsTable).
+		Where(sq.Eq{"user_id": userID}).
+		PlaceholderFormat(sq.Question).
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	return nil
+}
+
+func (r *UserTwoFactorRepository) scan(row base.Scanner) (*domain.UserTwoFactor, error) {
+	var twoFactor domain.UserTwoFactor
+	var confirmedAtStr, createdAtStr, updatedAtStr *string
+
+	err := row.Scan(
+		&twoFactor.UserID,
+		&twoFactor.Secret,
+		&confirmedAtStr,
+		&twoFactor.RecoveryCodes,
+		&twoFactor.LastUsedStep,
+		&createdAtStr,
+		&updatedAtStr,
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to scan row")
+	}
+
+	parseTime := func(s *string, field string) (*time.Time, error) {
+		if s == nil || *s == "" {
+			return nil, nil
+		}
+
+		t, err := base.ParseTime(*s)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to parse %s time", field)
+		}
+
+		return &t, nil
+	}
+
+	if twoFactor.ConfirmedAt, err = parseTime(confirmedAtStr, "confirmed_at"); err != nil {
+		return nil, err
+	}
+
+	if twoFactor.CreatedAt, err = parseTime(createdAtStr, "created_at"); err != nil {
+		return nil, err
+	}
+
+	if twoFactor.UpdatedAt, err = parseTime(updatedAtStr, "updated_at"); err != nil {
+		return nil, err
+	}
+
+	return &twoFactor, nil
+}
+
+func (r *UserTwoFactorRepository) filterToSq(filter *filters.FindUserTwoFactor) sq.Sqlizer {
+	if filter == nil {
+		return nil
+	}
+
+	and := make(sq.And, 0, 1)
+
+	if len(filter.UserIDs) > 0 {
+		and = append(and, sq.Eq{"user_id": filter.UserIDs})
+	}
+
+	return and
+}
@@ -117,13 +117,16 @@ func (r *UserRepository) Save(ctx context.Context, user *domain.User) error {
 		user.CreatedAt = lo.ToPtr(time.Now())
 	}
 
-	var createdAtStr, updatedAtStr *string
+	var createdAtStr, updatedAtStr, disabledAtStr *string
 	if user.CreatedAt != nil {
 		createdAtStr = lo.ToPtr(user.CreatedAt.Format(time.RFC3339))
 	}
 	if user.UpdatedAt != nil {
 		updatedAtStr = lo.ToPtr(user.UpdatedAt.Format(time.RFC3339))
 	}
+	if user.DisabledAt != nil {
+		disabledAtStr = lo.ToPtr(user.DisabledAt.Format(time.RFC3339))
+	}
 
 	query, args, err := sq.Insert(base.UsersTable).
 		Columns(base.UserFields...).
@@ -136,6 +139,7 @@ func (r *UserRepository) Save(ctx context.Context, user *domain.User) error {
 			user.Name,
 			createdAtStr,
 			updatedAtStr,
+			disabledAtStr,
 		).
 		Suffix("ON CONFLICT(id) DO UPDATE SET " +
 			"login=excluded.login," +
@@ -143,7 +147,8 @@ func (r *UserRepository) Save(ctx context.Context, user *domain.User) error {
 			"password=excluded.password," +
 			"remember_token=excluded.remember_token," +
 			"name=excluded.name," +
-			"updated_at=excluded.updated_at " +
+			"updated_at=excluded.updated_at," +
+			"disabled_at=excluded.disabled_at " +
 			"RETURNING id").
 		ToSql()
 	if err != nil {
@@ -181,7 +186,7 @@ func (r *UserRepository) Delete(ctx context.Context, id uint) error {
 
 func (r *UserRepository) scan(row base.Scanner) (*domain.User, error) {
 	var user domain.User
-	var createdAtStr, updatedAtStr *string
+	var createdAtStr, updatedAtStr, disabledAtStr *string
 
 	err := row.Scan(
 		&user.ID,
@@ -192,6 +197,7 @@ func (r *UserRepository) scan(row base.Scanner) (*domain.User, error) {
 		&user.Name,
 		&createdAtStr,
 		&updatedAtStr,
+		&disabledAtStr,
 	)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to scan row")
@@ -213,6 +219,14 @@ func (r *UserRepository) scan(row base.Scanner) (*domain.User, error) {
 		user.UpdatedAt = &updatedAt
 	}
 
+	if disabledAtStr != nil && *disabledAtStr != "" {
+		disabledAt, err := base.ParseTime(*disabledAtStr)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to parse disabled_at time")
+		}
+		user.DisabledAt = &disabledAt
+	}
+
 	return &user, nil
 }
 
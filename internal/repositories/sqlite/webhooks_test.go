@@ -0,0 +1,20 @@
+package sqlite_test
+
+import (
+	"testing"
+
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/repositories/sqlite"
+	repotesting "github.com/gameap/gameap/internal/repositories/testing"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestWebhookRepository(t *testing.T) {
+	suite.Run(t, repotesting.NewWebhookRepositorySuite(
+		func(t *testing.T) repositories.WebhookRepository {
+			t.Helper()
+
+			return sqlite.NewWebhookRepository(SetupTestDB(t))
+		},
+	))
+}
@@ -0,0 +1,205 @@
+package testing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuditLogRepositorySuite struct {
+	suite.Suite
+
+	repo repositories.AuditLogRepository
+
+	fn func(t *testing.T) repositories.AuditLogRepository
+}
+
+func NewAuditLogRepositorySuite(fn func(t *testing.T) repositories.AuditLogRepository) *AuditLogRepositorySuite {
+	return &AuditLogRepositorySuite{
+		fn: fn,
+	}
+}
+
+func (s *AuditLogRepositorySuite) SetupTest() {
+	s.repo = s.fn(s.T())
+}
+
+func (s *AuditLogRepositorySuite) TestAuditLogRepositorySave() {
+	ctx := context.Background()
+	createdAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	log := &domain.AuditLog{
+		UserID:     lo.ToPtr(uint(1)),
+		TokenID:    lo.ToPtr(uint(2)),
+		IP:         "192.0.2.10",
+		UserAgent:  "curl/8.5.0",
+		Action:     "PUT /api/servers/{id}",
+		EntityType: lo.ToPtr(domain.EntityTypeServer),
+		EntityID:   lo.ToPtr("5"),
+		Changes:    lo.ToPtr(`{"name":{"old":"Old","new":"New"}}`),
+		Request:    lo.ToPtr(`{"name":"New"}`),
+		Result:     domain.AuditResultSuccess,
+		StatusCode: 200,
+		CreatedAt:  &createdAt,
+	}
+
+	require.NoError(s.T(), s.repo.Save(ctx, log))
+	assert.NotZero(s.T(), log.ID)
+
+	results, err := s.repo.Find(ctx, &filters.FindAuditLog{IDs: []uint{log.ID}}, nil, nil)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), results, 1)
+	assert.Equal(s.T(), log.UserID, results[0].UserID)
+	assert.Equal(s.T(), log.TokenID, results[0].TokenID)
+	assert.Equal(s.T(), "192.0.2.10", results[0].IP)
+	assert.Equal(s.T(), "curl/8.5.0", results[0].UserAgent)
+	assert.Equal(s.T(), "PUT /api/servers/{id}", results[0].Action)
+	assert.Equal(s.T(), log.EntityType, results[0].EntityType)
+	assert.Equal(s.T(), log.EntityID, results[0].EntityID)
+	assert.Equal(s.T(), log.Changes, results[0].Changes)
+	assert.Equal(s.T(), log.Request, results[0].Request)
+	assert.Equal(s.T(), domain.AuditResultSuccess, results[0].Result)
+	assert.Equal(s.T(), 200, results[0].StatusCode)
+	require.NotNil(s.T(), results[0].CreatedAt)
+	assert.True(s.T(), createdAt.Equal(*results[0].CreatedAt))
+
+	s.T().Run("guest_without_entity", func(t *testing.T) {
+		guest := &domain.AuditLog{
+			Action:     "POST /api/auth/login",
+			Result:     domain.AuditResultFailure,
+			StatusCode: 401,
+		}
+
+		require.NoError(t, s.repo.Save(ctx, guest))
+		assert.NotNil(t, guest.CreatedAt)
+
+		results, err := s.repo.Find(ctx, &filters.FindAuditLog{IDs: []uint{guest.ID}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Nil(t, results[0].UserID)
+		assert.Nil(t, results[0].TokenID)
+		assert.Nil(t, results[0].EntityType)
+		assert.Nil(t, results[0].EntityID)
+		assert.Nil(t, results[0].Changes)
+		assert.Nil(t, results[0].Request)
+		assert.Equal(t, domain.AuditResultFailure, results[0].Result)
+	})
+}
+
+func (s *AuditLogRepositorySuite) TestAuditLogRepositoryFind() {
+	ctx := context.Background()
+	base := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	start := s.save(1, "POST /api/servers/{server}/start", domain.EntityTypeServer, "5", domain.AuditResultSuccess, base)
+	stop := s.save(1, "POST /api/servers/{server}/stop", domain.EntityTypeServer, "5", domain.AuditResultFailure,
+		base.Add(time.Hour))
+	node := s.save(2, "PUT /api/dedicated_servers/{id}", domain.EntityTypeNode, "5", domain.AuditResultSuccess,
+		base.Add(2*time.Hour))
+
+	tests := []struct {
+		name   string
+		filter *filters.FindAuditLog
+		want   []uint
+	}{
+		{
+			name: "all",
+			want: []uint{start.ID, stop.ID, node.ID},
+		},
+		{
+			name:   "by_user",
+			filter: &filters.FindAuditLog{UserIDs: []uint{1}},
+			want:   []uint{start.ID, stop.ID},
+		},
+		{
+			name:   "by_action",
+			filter: &filters.FindAuditLog{Actions: []string{"POST /api/servers/{server}/stop"}},
+			want:   []uint{stop.ID},
+		},
+		{
+			name: "by_entity",
+			filter: &filters.FindAuditLog{
+				EntityTypes: []domain.EntityType{domain.EntityTypeNode},
+				EntityIDs:   []string{"5"},
+			},
+			want: []uint{node.ID},
+		},
+		{
+			name:   "by_result",
+			filter: &filters.FindAuditLog{Results: []domain.AuditResult{domain.AuditResultFailure}},
+			want:   []uint{stop.ID},
+		},
+		{
+			name: "by_period",
+			filter: &filters.FindAuditLog{
+				CreatedFrom: lo.ToPtr(base.Add(time.Hour)),
+				CreatedTo:   lo.ToPtr(base.Add(2 * time.Hour)),
+			},
+			want: []uint{stop.ID},
+		},
+		{
+			name:   "before_id",
+			filter: &filters.FindAuditLog{BeforeID: &node.ID},
+			want:   []uint{start.ID, stop.ID},
+		},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			results, err := s.repo.Find(ctx, tt.filter, nil, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, s.ids(results))
+
+			count, err := s.repo.Count(ctx, tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, len(tt.want), count)
+		})
+	}
+
+	s.T().Run("with_order_and_pagination", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, nil, []filters.Sorting{
+			{Field: "created_at", Direction: filters.SortDirectionDesc},
+		}, &filters.Pagination{
+			Limit:  2,
+			Offset: 1,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{stop.ID, start.ID}, s.ids(results))
+	})
+}
+
+func (s *AuditLogRepositorySuite) save(
+	userID uint,
+	action string,
+	entityType domain.EntityType,
+	entityID string,
+	result domain.AuditResult,
+	createdAt time.Time,
+) *domain.AuditLog {
+	log := &domain.AuditLog{
+		UserID:     &userID,
+		Action:     action,
+		EntityType: &entityType,
+		EntityID:   &entityID,
+		Result:     result,
+		StatusCode: 200,
+		CreatedAt:  &createdAt,
+	}
+
+	require.NoError(s.T(), s.repo.Save(context.Background(), log))
+
+	return log
+}
+
+func (s *AuditLogRepositorySuite) ids(logs []domain.AuditLog) []uint {
+	return lo.Map(logs, func(log domain.AuditLog, _ int) uint {
+		return log.ID
+	})
+}
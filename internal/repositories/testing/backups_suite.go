@@ -0,0 +1,172 @@
+package testing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type BackupRepositorySuite struct {
+	suite.Suite
+
+	repo repositories.BackupRepository
+
+	fn func(t *testing.T) repositories.BackupRepository
+}
+
+func NewBackupRepositorySuite(fn func(t *testing.T) repositories.BackupRepository) *BackupRepositorySuite {
+	return &BackupRepositorySuite{
+		fn: fn,
+	}
+}
+
+func (s *BackupRepositorySuite) SetupTest() {
+	s.repo = s.fn(s.T())
+}
+
+func (s *BackupRepositorySuite) TestBackupRepositorySave() {
+	ctx := context.Background()
+
+	s.T().Run("insert_new_backup", func(t *testing.T) {
+		backup := &domain.Backup{
+			ServerID: 1,
+			Status:   domain.BackupStatusCreating,
+			Path:     "backups/server-1/1.tar.gz",
+		}
+
+		err := s.repo.Save(ctx, backup)
+		require.NoError(t, err)
+		assert.NotZero(t, backup.ID)
+		assert.NotNil(t, backup.CreatedAt)
+		assert.NotNil(t, backup.UpdatedAt)
+	})
+
+	s.T().Run("update_existing_backup", func(t *testing.T) {
+		backup := &domain.Backup{
+			ServerID: 2,
+			Status:   domain.BackupStatusCreating,
+			Path:     "backups/server-2/1.tar.gz",
+		}
+
+		require.NoError(t, s.repo.Save(ctx, backup))
+		originalID := backup.ID
+		originalUpdatedAt := *backup.UpdatedAt
+
+		time.Sleep(10 * time.Millisecond)
+
+		backup.Status = domain.BackupStatusFailed
+		backup.Size = 1024
+		backup.Error = lo.ToPtr("archive command failed")
+
+		require.NoError(t, s.repo.Save(ctx, backup))
+		assert.Equal(t, originalID, backup.ID)
+		assert.True(t, backup.UpdatedAt.After(originalUpdatedAt))
+
+		results, err := s.repo.Find(ctx, &filters.FindBackup{IDs: []uint{backup.ID}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, domain.BackupStatusFailed, results[0].Status)
+		assert.Equal(t, int64(1024), results[0].Size)
+		require.NotNil(t, results[0].Error)
+		assert.Equal(t, "archive command failed", *results[0].Error)
+	})
+}
+
+func (s *BackupRepositorySuite) TestBackupRepositoryFind() {
+	ctx := context.Background()
+
+	backup1 := &domain.Backup{
+		ServerID: 10,
+		Status:   domain.BackupStatusCompleted,
+		Path:     "backups/server-10/1.tar.gz",
+		Size:     100,
+	}
+	backup2 := &domain.Backup{
+		ServerID: 10,
+		Status:   domain.BackupStatusFailed,
+		Path:     "backups/server-10/2.tar.gz",
+	}
+	backup3 := &domain.Backup{
+		ServerID: 11,
+		Status:   domain.BackupStatusCompleted,
+		Path:     "backups/server-11/3.tar.gz",
+		Size:     300,
+	}
+
+	require.NoError(s.T(), s.repo.Save(ctx, backup1))
+	require.NoError(s.T(), s.repo.Save(ctx, backup2))
+	require.NoError(s.T(), s.repo.Save(ctx, backup3))
+
+	s.T().Run("find_by_server_ids", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, filters.FindBackupByServerIDs(10), nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, backup1.ID, results[0].ID)
+		assert.Equal(t, backup2.ID, results[1].ID)
+	})
+
+	s.T().Run("find_by_statuses", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindBackup{
+			ServerIDs: []uint{10, 11},
+			Statuses:  []domain.BackupStatus{domain.BackupStatusCompleted},
+		}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, backup1.ID, results[0].ID)
+		assert.Equal(t, backup3.ID, results[1].ID)
+	})
+
+	s.T().Run("find_with_order", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindBackup{
+			ServerIDs: []uint{10, 11},
+		}, []filters.Sorting{{Field: "id", Direction: filters.SortDirectionDesc}}, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		assert.Equal(t, backup3.ID, results[0].ID)
+		assert.Equal(t, backup1.ID, results[2].ID)
+	})
+
+	s.T().Run("find_with_pagination", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindBackup{
+			ServerIDs: []uint{10, 11},
+		}, nil, &filters.Pagination{Limit: 1, Offset: 1})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, backup2.ID, results[0].ID)
+	})
+
+	s.T().Run("find_not_existing", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindBackup{IDs: []uint{99999}}, nil, nil)
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
+}
+
+func (s *BackupRepositorySuite) TestBackupRepositoryDelete() {
+	ctx := context.Background()
+
+	backup := &domain.Backup{
+		ServerID: 20,
+		Status:   domain.BackupStatusCompleted,
+		Path:     "backups/server-20/1.tar.gz",
+	}
+	require.NoError(s.T(), s.repo.Save(ctx, backup))
+
+	require.NoError(s.T(), s.repo.Delete(ctx, backup.ID))
+
+	results, err := s.repo.Find(ctx, &filters.FindBackup{IDs: []uint{backup.ID}}, nil, nil)
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), results)
+
+	s.Run("delete_not_existing", func() {
+		require.NoError(s.T(), s.repo.Delete(ctx, 99999))
+	})
+}
@@ -681,11 +681,13 @@ func (s *DaemonTaskRepositorySuite) TestDaemonTaskRepositoryAppendOutput() {
 
 		require.NoError(t, s.repo.Save(ctx, task))
 
-		err := s.repo.AppendOutput(ctx, task.ID, "Additional line 1\n")
+		length, err := s.repo.AppendOutput(ctx, task.ID, "Additional line 1\n")
 		require.NoError(t, err)
+		assert.Equal(t, len("Initial output\nAdditional line 1\n"), length)
 
-		err = s.repo.AppendOutput(ctx, task.ID, "Additional line 2\n")
+		length, err = s.repo.AppendOutput(ctx, task.ID, "Additional line 2\n")
 		require.NoError(t, err)
+		assert.Equal(t, len("Initial output\nAdditional line 1\nAdditional line 2\n"), length)
 
 		filter := &filters.FindDaemonTask{
 			IDs: []uint{task.ID},
@@ -706,8 +708,9 @@ func (s *DaemonTaskRepositorySuite) TestDaemonTaskRepositoryAppendOutput() {
 
 		require.NoError(t, s.repo.Save(ctx, task))
 
-		err := s.repo.AppendOutput(ctx, task.ID, "First line\n")
+		length, err := s.repo.AppendOutput(ctx, task.ID, "First line\n")
 		require.NoError(t, err)
+		assert.Equal(t, len("First line\n"), length)
 
 		filter := &filters.FindDaemonTask{
 			IDs: []uint{task.ID},
@@ -717,6 +720,20 @@ func (s *DaemonTaskRepositorySuite) TestDaemonTaskRepositoryAppendOutput() {
 		require.Len(t, results, 1)
 		assert.Equal(t, "First line\n", *results[0].Output)
 	})
+
+	s.T().Run("length_in_bytes", func(t *testing.T) {
+		task := &domain.DaemonTask{
+			DedicatedServerID: 9,
+			Task:              domain.DaemonTaskTypeCmdExec,
+			Status:            domain.DaemonTaskStatusWorking,
+		}
+
+		require.NoError(t, s.repo.Save(ctx, task))
+
+		length, err := s.repo.AppendOutput(ctx, task.ID, "Загрузка\n")
+		require.NoError(t, err)
+		assert.Equal(t, len("Загрузка\n"), length)
+	})
 }
 
 func (s *DaemonTaskRepositorySuite) TestDaemonTaskRepositoryCount() {
@@ -857,10 +874,10 @@ func (s *DaemonTaskRepositorySuite) TestDaemonTaskRepositoryIntegration() {
 		err = s.repo.Save(ctx, task)
 		require.NoError(t, err)
 
-		err = s.repo.AppendOutput(ctx, task.ID, "Starting server...\n")
+		_, err = s.repo.AppendOutput(ctx, task.ID, "Starting server...\n")
 		require.NoError(t, err)
 
-		err = s.repo.AppendOutput(ctx, task.ID, "Server started successfully\n")
+		_, err = s.repo.AppendOutput(ctx, task.ID, "Server started successfully\n")
 		require.NoError(t, err)
 
 		resultsAfterAppend, err := s.repo.FindWithOutput(ctx, filter, nil, nil)
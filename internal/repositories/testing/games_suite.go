@@ -92,6 +92,27 @@ func (s *GameRepositorySuite) TestGameRepositorySave() {
 		assert.Equal(t, lo.ToPtr(uint(440)), games[0].SteamAppIDWindows)
 		assert.Equal(t, lo.ToPtr("90 mod tf"), games[0].SteamAppSetConfig)
 	})
+
+	s.T().Run("save_with_port_offsets", func(t *testing.T) {
+		game := &domain.Game{
+			Code:            "rust",
+			Name:            "Rust",
+			Engine:          "unity",
+			EngineVersion:   "1",
+			Enabled:         1,
+			QueryPortOffset: 1,
+			RconPortOffset:  10,
+		}
+
+		err := s.repo.Save(ctx, game)
+		require.NoError(t, err)
+
+		games, err := s.repo.Find(ctx, &filters.FindGame{Codes: []string{"rust"}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, games, 1)
+		assert.Equal(t, 1, games[0].QueryPortOffset)
+		assert.Equal(t, 10, games[0].RconPortOffset)
+	})
 }
 
 func (s *GameRepositorySuite) TestGameRepositoryFindAll() {
@@ -0,0 +1,221 @@
+package testing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type MetricRepositorySuite struct {
+	suite.Suite
+
+	repo repositories.MetricRepository
+
+	fn func(t *testing.T) repositories.MetricRepository
+}
+
+func NewMetricRepositorySuite(
+	fn func(t *testing.T) repositories.MetricRepository,
+) *MetricRepositorySuite {
+	return &MetricRepositorySuite{
+		fn: fn,
+	}
+}
+
+func (s *MetricRepositorySuite) SetupTest() {
+	s.repo = s.fn(s.T())
+}
+
+func (s *MetricRepositorySuite) TestMetricRepositorySaveBulk() {
+	ctx := context.Background()
+	recordedAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	s.T().Run("insert_new_metrics", func(t *testing.T) {
+		require.NoError(t, s.repo.SaveBulk(ctx, []*domain.Metric{
+			{
+				SubjectType: domain.MetricSubjectServer,
+				SubjectID:   1,
+				Resolution:  domain.MetricResolutionRaw,
+				RecordedAt:  recordedAt,
+				CPU:         lo.ToPtr(12.5),
+				RAM:         lo.ToPtr(1024.0),
+				Disk:        lo.ToPtr(5000.0),
+				Players:     lo.ToPtr(7.0),
+				Samples:     1,
+			},
+			{
+				SubjectType: domain.MetricSubjectNode,
+				SubjectID:   1,
+				Resolution:  domain.MetricResolutionRaw,
+				RecordedAt:  recordedAt,
+				RAM:         lo.ToPtr(4096.0),
+				Samples:     1,
+			},
+		}))
+
+		results, err := s.repo.Find(ctx, &filters.FindMetric{
+			SubjectType: domain.MetricSubjectServer,
+			SubjectIDs:  []uint{1},
+		}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, domain.MetricResolutionRaw, results[0].Resolution)
+		assert.True(t, recordedAt.Equal(results[0].RecordedAt))
+		assert.Equal(t, lo.ToPtr(12.5), results[0].CPU)
+		assert.Equal(t, lo.ToPtr(1024.0), results[0].RAM)
+		assert.Equal(t, lo.ToPtr(5000.0), results[0].Disk)
+		assert.Equal(t, lo.ToPtr(7.0), results[0].Players)
+		assert.Equal(t, 1, results[0].Samples)
+
+		results, err = s.repo.Find(ctx, &filters.FindMetric{
+			SubjectType: domain.MetricSubjectNode,
+		}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Nil(t, results[0].CPU)
+		assert.Equal(t, lo.ToPtr(4096.0), results[0].RAM)
+	})
+
+	s.T().Run("update_existing_point", func(t *testing.T) {
+		require.NoError(t, s.repo.SaveBulk(ctx, []*domain.Metric{
+			{
+				SubjectType: domain.MetricSubjectServer,
+				SubjectID:   1,
+				Resolution:  domain.MetricResolutionRaw,
+				RecordedAt:  recordedAt,
+				CPU:         lo.ToPtr(30.0),
+				Samples:     2,
+			},
+		}))
+
+		results, err := s.repo.Find(ctx, &filters.FindMetric{
+			SubjectType: domain.MetricSubjectServer,
+			SubjectIDs:  []uint{1},
+		}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, lo.ToPtr(30.0), results[0].CPU)
+		assert.Equal(t, lo.ToPtr(1024.0), results[0].RAM, "missing values keep the stored ones")
+		assert.Equal(t, lo.ToPtr(7.0), results[0].Players)
+		assert.Equal(t, 2, results[0].Samples)
+	})
+
+	s.T().Run("save_empty", func(t *testing.T) {
+		require.NoError(t, s.repo.SaveBulk(ctx, nil))
+	})
+}
+
+func (s *MetricRepositorySuite) TestMetricRepositoryFind() {
+	ctx := context.Background()
+	base := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	require.NoError(s.T(), s.repo.SaveBulk(ctx, []*domain.Metric{
+		s.point(domain.MetricSubjectServer, 1, domain.MetricResolutionRaw, base.Add(2*time.Minute)),
+		s.point(domain.MetricSubjectServer, 1, domain.MetricResolutionRaw, base),
+		s.point(domain.MetricSubjectServer, 1, domain.MetricResolutionRaw, base.Add(time.Minute)),
+		s.point(domain.MetricSubjectServer, 1, domain.MetricResolutionMinute, base),
+		s.point(domain.MetricSubjectServer, 2, domain.MetricResolutionRaw, base),
+		s.point(domain.MetricSubjectNode, 1, domain.MetricResolutionRaw, base),
+	}))
+
+	s.T().Run("find_all", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, nil, nil, nil)
+		require.NoError(t, err)
+		assert.Len(t, results, 6)
+	})
+
+	s.T().Run("find_by_subject_and_resolution_sorted_by_time", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindMetric{
+			SubjectType: domain.MetricSubjectServer,
+			SubjectIDs:  []uint{1},
+			Resolutions: []domain.MetricResolution{domain.MetricResolutionRaw},
+		}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		assert.True(t, base.Equal(results[0].RecordedAt))
+		assert.True(t, base.Add(time.Minute).Equal(results[1].RecordedAt))
+		assert.True(t, base.Add(2*time.Minute).Equal(results[2].RecordedAt))
+	})
+
+	s.T().Run("find_by_time_range", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindMetric{
+			SubjectType:    domain.MetricSubjectServer,
+			SubjectIDs:     []uint{1},
+			Resolutions:    []domain.MetricResolution{domain.MetricResolutionRaw},
+			RecordedFrom:   lo.ToPtr(base.Add(time.Minute)),
+			RecordedBefore: lo.ToPtr(base.Add(2 * time.Minute)),
+		}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.True(t, base.Add(time.Minute).Equal(results[0].RecordedAt))
+	})
+
+	s.T().Run("find_with_pagination", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindMetric{
+			SubjectType: domain.MetricSubjectServer,
+			Resolutions: []domain.MetricResolution{domain.MetricResolutionRaw},
+		}, nil, &filters.Pagination{Limit: 2, Offset: 2})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, uint(1), results[0].SubjectID)
+		assert.Equal(t, uint(2), results[1].SubjectID)
+	})
+
+	s.T().Run("find_not_existing", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindMetric{
+			SubjectType: domain.MetricSubjectServer,
+			SubjectIDs:  []uint{99999},
+		}, nil, nil)
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
+}
+
+func (s *MetricRepositorySuite) TestMetricRepositoryDeleteBefore() {
+	ctx := context.Background()
+	base := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	require.NoError(s.T(), s.repo.SaveBulk(ctx, []*domain.Metric{
+		s.point(domain.MetricSubjectServer, 1, domain.MetricResolutionRaw, base),
+		s.point(domain.MetricSubjectServer, 1, domain.MetricResolutionRaw, base.Add(time.Minute)),
+		s.point(domain.MetricSubjectServer, 1, domain.MetricResolutionMinute, base),
+	}))
+
+	require.NoError(s.T(), s.repo.DeleteBefore(ctx, domain.MetricResolutionRaw, base.Add(time.Minute)))
+
+	results, err := s.repo.Find(ctx, nil, nil, nil)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), results, 2)
+
+	remaining := lo.Map(results, func(m domain.Metric, _ int) string {
+		return string(m.Resolution) + "@" + m.RecordedAt.UTC().Format(time.RFC3339)
+	})
+	assert.ElementsMatch(s.T(), []string{
+		"raw@" + base.Add(time.Minute).Format(time.RFC3339),
+		"1m@" + base.Format(time.RFC3339),
+	}, remaining)
+}
+
+func (s *MetricRepositorySuite) point(
+	subjectType domain.MetricSubject,
+	subjectID uint,
+	resolution domain.MetricResolution,
+	recordedAt time.Time,
+) *domain.Metric {
+	return &domain.Metric{
+		SubjectType: subjectType,
+		SubjectID:   subjectID,
+		Resolution:  resolution,
+		RecordedAt:  recordedAt,
+		CPU:         lo.ToPtr(10.0),
+		Samples:     1,
+	}
+}
@@ -67,6 +67,8 @@ func (s *NodeRepositorySuite) TestNodeRepositorySave() {
 			ScriptGetConsole:    lo.ToPtr("script-get-console"),
 			ScriptSendCommand:   lo.ToPtr("script-send-command"),
 			ScriptDelete:        lo.ToPtr("script-delete"),
+			PortRangeStart:      lo.ToPtr(27015),
+			PortRangeEnd:        lo.ToPtr(27100),
 		}
 
 		// ACT
@@ -125,6 +127,8 @@ func (s *NodeRepositorySuite) TestNodeRepositorySave() {
 		assert.Equal(t, "script-send-command", *nodes[0].ScriptSendCommand)
 		require.NotNil(t, nodes[0].ScriptDelete)
 		assert.Equal(t, "script-delete", *nodes[0].ScriptDelete)
+		assert.Equal(t, lo.ToPtr(27015), nodes[0].PortRangeStart)
+		assert.Equal(t, lo.ToPtr(27100), nodes[0].PortRangeEnd)
 	})
 
 	s.T().Run("update_existing_node", func(t *testing.T) {
@@ -0,0 +1,163 @@
+package testing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type NotificationChannelRepositorySuite struct {
+	suite.Suite
+
+	repo repositories.NotificationChannelRepository
+
+	fn func(t *testing.T) repositories.NotificationChannelRepository
+}
+
+func NewNotificationChannelRepositorySuite(
+	fn func(t *testing.T) repositories.NotificationChannelRepository,
+) *NotificationChannelRepositorySuite {
+	return &NotificationChannelRepositorySuite{
+		fn: fn,
+	}
+}
+
+func (s *NotificationChannelRepositorySuite) SetupTest() {
+	s.repo = s.fn(s.T())
+}
+
+func (s *NotificationChannelRepositorySuite) TestNotificationChannelRepositorySave() {
+	ctx := context.Background()
+
+	s.T().Run("insert_new_channel", func(t *testing.T) {
+		channel := &domain.NotificationChannel{
+			UserID:   1,
+			ServerID: lo.ToPtr(uint(5)),
+			Name:     "Discord",
+			Type:     domain.NotificationChannelTypeDiscord,
+			Target:   "https://discord.com/api/webhooks/1/token",
+			Language: "ru",
+			Events:   domain.NotificationEvents{domain.EventTypeServerCrashed, domain.EventTypeBackupFailed},
+			Enabled:  true,
+		}
+
+		require.NoError(t, s.repo.Save(ctx, channel))
+		assert.NotZero(t, channel.ID)
+		assert.NotNil(t, channel.CreatedAt)
+		assert.NotNil(t, channel.UpdatedAt)
+
+		results, err := s.repo.Find(ctx, &filters.FindNotificationChannel{IDs: []uint{channel.ID}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, uint(1), results[0].UserID)
+		assert.Equal(t, lo.ToPtr(uint(5)), results[0].ServerID)
+		assert.Equal(t, "Discord", results[0].Name)
+		assert.Equal(t, domain.NotificationChannelTypeDiscord, results[0].Type)
+		assert.Equal(t, "https://discord.com/api/webhooks/1/token", results[0].Target)
+		assert.Equal(t, "ru", results[0].Language)
+		assert.Equal(t, channel.Events, results[0].Events)
+		assert.True(t, results[0].Enabled)
+	})
+
+	s.T().Run("update_existing_channel", func(t *testing.T) {
+		channel := &domain.NotificationChannel{
+			UserID:   1,
+			Name:     "Telegram",
+			Type:     domain.NotificationChannelTypeTelegram,
+			Target:   "100500",
+			Language: "en",
+			Enabled:  true,
+		}
+		require.NoError(t, s.repo.Save(ctx, channel))
+
+		channel.Target = "-100200300"
+		channel.Events = domain.NotificationEvents{domain.EventTypeServerUpdateFinished}
+		channel.Enabled = false
+		require.NoError(t, s.repo.Save(ctx, channel))
+
+		results, err := s.repo.Find(ctx, &filters.FindNotificationChannel{IDs: []uint{channel.ID}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Nil(t, results[0].ServerID)
+		assert.Equal(t, "-100200300", results[0].Target)
+		assert.Equal(t, domain.NotificationEvents{domain.EventTypeServerUpdateFinished}, results[0].Events)
+		assert.False(t, results[0].Enabled)
+	})
+}
+
+func (s *NotificationChannelRepositorySuite) TestNotificationChannelRepositoryFind() {
+	ctx := context.Background()
+
+	first := s.save(1, "first", true)
+	second := s.save(2, "second", false)
+	third := s.save(1, "third", true)
+
+	s.T().Run("all", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, nil, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []uint{first.ID, second.ID, third.ID}, s.ids(results))
+	})
+
+	s.T().Run("by_user", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindNotificationChannel{UserIDs: []uint{2}}, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []uint{second.ID}, s.ids(results))
+	})
+
+	s.T().Run("enabled", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindNotificationChannel{Enabled: lo.ToPtr(true)}, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []uint{first.ID, third.ID}, s.ids(results))
+	})
+
+	s.T().Run("with_order_and_pagination", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, nil, []filters.Sorting{
+			{Field: "name", Direction: filters.SortDirectionDesc},
+		}, &filters.Pagination{
+			Limit: 2,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{third.ID, second.ID}, s.ids(results))
+	})
+}
+
+func (s *NotificationChannelRepositorySuite) TestNotificationChannelRepositoryDelete() {
+	ctx := context.Background()
+
+	channel := s.save(1, "first", true)
+	other := s.save(1, "other", true)
+
+	require.NoError(s.T(), s.repo.Delete(ctx, channel.ID))
+
+	results, err := s.repo.Find(ctx, nil, nil, nil)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []uint{other.ID}, s.ids(results))
+}
+
+func (s *NotificationChannelRepositorySuite) save(userID uint, name string, enabled bool) *domain.NotificationChannel {
+	channel := &domain.NotificationChannel{
+		UserID:   userID,
+		Name:     name,
+		Type:     domain.NotificationChannelTypeTelegram,
+		Target:   "100500",
+		Language: "en",
+		Enabled:  enabled,
+	}
+
+	require.NoError(s.T(), s.repo.Save(context.Background(), channel))
+
+	return channel
+}
+
+func (s *NotificationChannelRepositorySuite) ids(channels []domain.NotificationChannel) []uint {
+	return lo.Map(channels, func(channel domain.NotificationChannel, _ int) uint {
+		return channel.ID
+	})
+}
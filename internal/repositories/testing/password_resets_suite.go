@@ -0,0 +1,131 @@
+package testing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type PasswordResetRepositorySuite struct {
+	suite.Suite
+
+	repo repositories.PasswordResetRepository
+
+	fn func(t *testing.T) repositories.PasswordResetRepository
+}
+
+func NewPasswordResetRepositorySuite(
+	fn func(t *testing.T) repositories.PasswordResetRepository,
+) *PasswordResetRepositorySuite {
+	return &PasswordResetRepositorySuite{
+		fn: fn,
+	}
+}
+
+func (s *PasswordResetRepositorySuite) SetupTest() {
+	s.repo = s.fn(s.T())
+}
+
+func (s *PasswordResetRepositorySuite) TestPasswordResetRepositorySave() {
+	ctx := context.Background()
+
+	s.T().Run("insert_new_reset", func(t *testing.T) {
+		createdAt := time.Now().Truncate(time.Second)
+
+		require.NoError(t, s.repo.Save(ctx, &domain.PasswordReset{
+			Email:     "new@example.com",
+			Token:     "hashed-token",
+			CreatedAt: &createdAt,
+		}))
+
+		results, err := s.repo.Find(ctx, &filters.FindPasswordReset{Emails: []string{"new@example.com"}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "new@example.com", results[0].Email)
+		assert.Equal(t, "hashed-token", results[0].Token)
+		require.NotNil(t, results[0].CreatedAt)
+		assert.True(t, createdAt.Equal(*results[0].CreatedAt))
+	})
+
+	s.T().Run("replace_reset_of_email", func(t *testing.T) {
+		require.NoError(t, s.repo.Save(ctx, &domain.PasswordReset{
+			Email:     "replace@example.com",
+			Token:     "first-token",
+			CreatedAt: lo.ToPtr(time.Now().Add(-time.Hour)),
+		}))
+		require.NoError(t, s.repo.Save(ctx, &domain.PasswordReset{
+			Email:     "replace@example.com",
+			Token:     "second-token",
+			CreatedAt: lo.ToPtr(time.Now()),
+		}))
+
+		results, err := s.repo.Find(ctx, &filters.FindPasswordReset{Emails: []string{"replace@example.com"}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "second-token", results[0].Token)
+	})
+}
+
+func (s *PasswordResetRepositorySuite) TestPasswordResetRepositoryFind() {
+	ctx := context.Background()
+
+	for _, email := range []string{"first@example.com", "second@example.com"} {
+		require.NoError(s.T(), s.repo.Save(ctx, &domain.PasswordReset{
+			Email:     email,
+			Token:     "token",
+			CreatedAt: lo.ToPtr(time.Now()),
+		}))
+	}
+
+	s.T().Run("by_email", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindPasswordReset{Emails: []string{"second@example.com"}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "second@example.com", results[0].Email)
+	})
+
+	s.T().Run("unknown_email", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindPasswordReset{Emails: []string{"unknown@example.com"}}, nil, nil)
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
+
+	s.T().Run("all", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, nil, nil, nil)
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+	})
+}
+
+func (s *PasswordResetRepositorySuite) TestPasswordResetRepositoryDelete() {
+	ctx := context.Background()
+
+	require.NoError(s.T(), s.repo.Save(ctx, &domain.PasswordReset{
+		Email:     "delete@example.com",
+		Token:     "token",
+		CreatedAt: lo.ToPtr(time.Now()),
+	}))
+	require.NoError(s.T(), s.repo.Save(ctx, &domain.PasswordReset{
+		Email:     "keep@example.com",
+		Token:     "token",
+		CreatedAt: lo.ToPtr(time.Now()),
+	}))
+
+	require.NoError(s.T(), s.repo.Delete(ctx, "delete@example.com"))
+
+	results, err := s.repo.Find(ctx, &filters.FindPasswordReset{Emails: []string{"delete@example.com"}}, nil, nil)
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), results)
+
+	results, err = s.repo.Find(ctx, &filters.FindPasswordReset{Emails: []string{"keep@example.com"}}, nil, nil)
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), results, 1)
+}
@@ -0,0 +1,163 @@
+package testing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ServerAutoRestartRepositorySuite struct {
+	suite.Suite
+
+	repo repositories.ServerAutoRestartRepository
+
+	fn func(t *testing.T) repositories.ServerAutoRestartRepository
+}
+
+func NewServerAutoRestartRepositorySuite(
+	fn func(t *testing.T) repositories.ServerAutoRestartRepository,
+) *ServerAutoRestartRepositorySuite {
+	return &ServerAutoRestartRepositorySuite{
+		fn: fn,
+	}
+}
+
+func (s *ServerAutoRestartRepositorySuite) SetupTest() {
+	s.repo = s.fn(s.T())
+}
+
+func (s *ServerAutoRestartRepositorySuite) TestServerAutoRestartRepositorySave() {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	s.T().Run("insert_new_restart", func(t *testing.T) {
+		restart := &domain.ServerAutoRestart{
+			ServerID:      1,
+			CrashedAt:     lo.ToPtr(now.Add(-time.Minute)),
+			Attempts:      2,
+			LastAttemptAt: lo.ToPtr(now),
+			LastTaskID:    lo.ToPtr(uint(15)),
+			UpdatedAt:     now,
+		}
+
+		require.NoError(t, s.repo.Save(ctx, restart))
+
+		results, err := s.repo.Find(ctx, &filters.FindServerAutoRestart{ServerIDs: []uint{1}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, uint(1), results[0].ServerID)
+		require.NotNil(t, results[0].CrashedAt)
+		assert.True(t, restart.CrashedAt.Equal(*results[0].CrashedAt))
+		assert.Equal(t, 2, results[0].Attempts)
+		require.NotNil(t, results[0].LastAttemptAt)
+		assert.True(t, now.Equal(*results[0].LastAttemptAt))
+		assert.Equal(t, lo.ToPtr(uint(15)), results[0].LastTaskID)
+		assert.False(t, results[0].CrashLoop)
+		assert.Nil(t, results[0].CrashLoopAt)
+		assert.Zero(t, results[0].CrashLoops)
+		assert.True(t, now.Equal(results[0].UpdatedAt))
+	})
+
+	s.T().Run("replace_existing_restart", func(t *testing.T) {
+		require.NoError(t, s.repo.Save(ctx, &domain.ServerAutoRestart{
+			ServerID:      2,
+			CrashedAt:     lo.ToPtr(now),
+			Attempts:      5,
+			LastAttemptAt: lo.ToPtr(now),
+			UpdatedAt:     now,
+		}))
+
+		restart := &domain.ServerAutoRestart{
+			ServerID:    2,
+			Attempts:    5,
+			CrashLoop:   true,
+			CrashLoopAt: lo.ToPtr(now.Add(time.Minute)),
+			CrashLoops:  1,
+			UpdatedAt:   now.Add(time.Minute),
+		}
+		require.NoError(t, s.repo.Save(ctx, restart))
+
+		results, err := s.repo.Find(ctx, &filters.FindServerAutoRestart{ServerIDs: []uint{2}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Nil(t, results[0].CrashedAt)
+		assert.Nil(t, results[0].LastAttemptAt)
+		assert.True(t, results[0].CrashLoop)
+		require.NotNil(t, results[0].CrashLoopAt)
+		assert.True(t, restart.CrashLoopAt.Equal(*results[0].CrashLoopAt))
+		assert.Equal(t, 1, results[0].CrashLoops)
+		assert.True(t, restart.UpdatedAt.Equal(results[0].UpdatedAt))
+	})
+}
+
+func (s *ServerAutoRestartRepositorySuite) TestServerAutoRestartRepositoryFind() {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	for _, serverID := range []uint{3, 1, 2} {
+		require.NoError(s.T(), s.repo.Save(ctx, &domain.ServerAutoRestart{
+			ServerID:  serverID,
+			Attempts:  int(serverID),
+			CrashLoop: serverID == 2,
+			UpdatedAt: now,
+		}))
+	}
+
+	s.T().Run("find_all", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, nil, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		assert.Equal(t, uint(1), results[0].ServerID)
+		assert.Equal(t, uint(3), results[2].ServerID)
+	})
+
+	s.T().Run("find_by_server_ids", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindServerAutoRestart{ServerIDs: []uint{2, 3}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, uint(2), results[0].ServerID)
+		assert.Equal(t, 2, results[0].Attempts)
+		assert.Equal(t, uint(3), results[1].ServerID)
+	})
+
+	s.T().Run("find_in_crash_loop", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindServerAutoRestart{CrashLoop: lo.ToPtr(true)}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, uint(2), results[0].ServerID)
+
+		results, err = s.repo.Find(ctx, &filters.FindServerAutoRestart{CrashLoop: lo.ToPtr(false)}, nil, nil)
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+	})
+
+	s.T().Run("find_with_order", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, nil, []filters.Sorting{
+			{Field: "attempts", Direction: filters.SortDirectionDesc},
+		}, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		assert.Equal(t, uint(3), results[0].ServerID)
+	})
+
+	s.T().Run("find_with_pagination", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, nil, nil, &filters.Pagination{Limit: 1, Offset: 1})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, uint(2), results[0].ServerID)
+	})
+
+	s.T().Run("find_not_existing", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindServerAutoRestart{ServerIDs: []uint{99999}}, nil, nil)
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
+}
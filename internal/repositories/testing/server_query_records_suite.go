@@ -0,0 +1,177 @@
+package testing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ServerQueryRecordRepositorySuite struct {
+	suite.Suite
+
+	repo repositories.ServerQueryRecordRepository
+
+	fn func(t *testing.T) repositories.ServerQueryRecordRepository
+}
+
+func NewServerQueryRecordRepositorySuite(
+	fn func(t *testing.T) repositories.ServerQueryRecordRepository,
+) *ServerQueryRecordRepositorySuite {
+	return &ServerQueryRecordRepositorySuite{
+		fn: fn,
+	}
+}
+
+func (s *ServerQueryRecordRepositorySuite) SetupTest() {
+	s.repo = s.fn(s.T())
+}
+
+func (s *ServerQueryRecordRepositorySuite) TestServerQueryRecordRepositorySaveBulk() {
+	ctx := context.Background()
+	queriedAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	s.T().Run("insert_new_records", func(t *testing.T) {
+		require.NoError(t, s.repo.SaveBulk(ctx, []*domain.ServerQueryRecord{
+			{
+				ServerID:      1,
+				QueriedAt:     queriedAt,
+				Online:        true,
+				PlayersNum:    7,
+				MaxPlayersNum: 32,
+				Map:           "de_dust2",
+			},
+			{
+				ServerID:  2,
+				QueriedAt: queriedAt,
+				Online:    false,
+			},
+		}))
+
+		results, err := s.repo.Find(ctx, nil, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+
+		assert.Equal(t, uint(1), results[0].ServerID)
+		assert.True(t, queriedAt.Equal(results[0].QueriedAt))
+		assert.True(t, results[0].Online)
+		assert.Equal(t, 7, results[0].PlayersNum)
+		assert.Equal(t, 32, results[0].MaxPlayersNum)
+		assert.Equal(t, "de_dust2", results[0].Map)
+
+		assert.Equal(t, uint(2), results[1].ServerID)
+		assert.False(t, results[1].Online)
+		assert.Zero(t, results[1].PlayersNum)
+		assert.Empty(t, results[1].Map)
+	})
+
+	s.T().Run("replace_existing_record", func(t *testing.T) {
+		require.NoError(t, s.repo.SaveBulk(ctx, []*domain.ServerQueryRecord{
+			{
+				ServerID:      1,
+				QueriedAt:     queriedAt,
+				Online:        true,
+				PlayersNum:    9,
+				MaxPlayersNum: 32,
+				Map:           "de_inferno",
+			},
+		}))
+
+		results, err := s.repo.Find(ctx, &filters.FindServerQueryRecord{
+			ServerIDs: []uint{1},
+		}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, 9, results[0].PlayersNum)
+		assert.Equal(t, "de_inferno", results[0].Map)
+	})
+
+	s.T().Run("save_empty", func(t *testing.T) {
+		require.NoError(t, s.repo.SaveBulk(ctx, nil))
+	})
+}
+
+func (s *ServerQueryRecordRepositorySuite) TestServerQueryRecordRepositoryFind() {
+	ctx := context.Background()
+	base := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	require.NoError(s.T(), s.repo.SaveBulk(ctx, []*domain.ServerQueryRecord{
+		s.record(1, base.Add(2*time.Minute), 3),
+		s.record(1, base, 1),
+		s.record(1, base.Add(time.Minute), 2),
+		s.record(2, base, 5),
+	}))
+
+	s.T().Run("by_server_sorted_by_time", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindServerQueryRecord{
+			ServerIDs: []uint{1},
+		}, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3}, s.players(results))
+	})
+
+	s.T().Run("by_time_range", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindServerQueryRecord{
+			ServerIDs:     []uint{1},
+			QueriedFrom:   lo.ToPtr(base.Add(time.Minute)),
+			QueriedBefore: lo.ToPtr(base.Add(2 * time.Minute)),
+		}, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []int{2}, s.players(results))
+	})
+
+	s.T().Run("with_order_and_pagination", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, nil, []filters.Sorting{
+			{Field: "players_num", Direction: filters.SortDirectionDesc},
+		}, &filters.Pagination{
+			Limit: 2,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []int{5, 3}, s.players(results))
+	})
+}
+
+func (s *ServerQueryRecordRepositorySuite) TestServerQueryRecordRepositoryDeleteBefore() {
+	ctx := context.Background()
+	base := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	require.NoError(s.T(), s.repo.SaveBulk(ctx, []*domain.ServerQueryRecord{
+		s.record(1, base, 1),
+		s.record(1, base.Add(time.Minute), 2),
+		s.record(2, base.Add(-time.Hour), 3),
+	}))
+
+	require.NoError(s.T(), s.repo.DeleteBefore(ctx, base.Add(time.Minute)))
+
+	results, err := s.repo.Find(ctx, nil, nil, nil)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []int{2}, s.players(results))
+}
+
+func (s *ServerQueryRecordRepositorySuite) record(
+	serverID uint,
+	queriedAt time.Time,
+	players int,
+) *domain.ServerQueryRecord {
+	return &domain.ServerQueryRecord{
+		ServerID:      serverID,
+		QueriedAt:     queriedAt,
+		Online:        true,
+		PlayersNum:    players,
+		MaxPlayersNum: 32,
+		Map:           "de_dust2",
+	}
+}
+
+func (s *ServerQueryRecordRepositorySuite) players(records []domain.ServerQueryRecord) []int {
+	return lo.Map(records, func(record domain.ServerQueryRecord, _ int) int {
+		return record.PlayersNum
+	})
+}
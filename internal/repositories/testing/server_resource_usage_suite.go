@@ -0,0 +1,136 @@
+package testing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ServerResourceUsageRepositorySuite struct {
+	suite.Suite
+
+	repo repositories.ServerResourceUsageRepository
+
+	fn func(t *testing.T) repositories.ServerResourceUsageRepository
+}
+
+func NewServerResourceUsageRepositorySuite(
+	fn func(t *testing.T) repositories.ServerResourceUsageRepository,
+) *ServerResourceUsageRepositorySuite {
+	return &ServerResourceUsageRepositorySuite{
+		fn: fn,
+	}
+}
+
+func (s *ServerResourceUsageRepositorySuite) SetupTest() {
+	s.repo = s.fn(s.T())
+}
+
+func (s *ServerResourceUsageRepositorySuite) TestServerResourceUsageRepositorySave() {
+	ctx := context.Background()
+
+	s.T().Run("insert_new_usage", func(t *testing.T) {
+		usage := &domain.ServerResourceUsage{
+			ServerID:   1,
+			CPU:        lo.ToPtr(87.5),
+			RAM:        lo.ToPtr(1536),
+			Net:        lo.ToPtr(12),
+			ReportedAt: time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC),
+		}
+
+		require.NoError(t, s.repo.Save(ctx, usage))
+
+		results, err := s.repo.Find(ctx, &filters.FindServerResourceUsage{ServerIDs: []uint{1}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, uint(1), results[0].ServerID)
+		assert.Equal(t, lo.ToPtr(87.5), results[0].CPU)
+		assert.Equal(t, lo.ToPtr(1536), results[0].RAM)
+		assert.Equal(t, lo.ToPtr(12), results[0].Net)
+		assert.True(t, usage.ReportedAt.Equal(results[0].ReportedAt))
+	})
+
+	s.T().Run("replace_existing_usage", func(t *testing.T) {
+		require.NoError(t, s.repo.Save(ctx, &domain.ServerResourceUsage{
+			ServerID:   2,
+			CPU:        lo.ToPtr(10.0),
+			RAM:        lo.ToPtr(512),
+			ReportedAt: time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC),
+		}))
+
+		usage := &domain.ServerResourceUsage{
+			ServerID:   2,
+			CPU:        lo.ToPtr(20.25),
+			ReportedAt: time.Date(2025, 3, 1, 10, 1, 0, 0, time.UTC),
+		}
+		require.NoError(t, s.repo.Save(ctx, usage))
+
+		results, err := s.repo.Find(ctx, &filters.FindServerResourceUsage{ServerIDs: []uint{2}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, lo.ToPtr(20.25), results[0].CPU)
+		assert.Nil(t, results[0].RAM)
+		assert.Nil(t, results[0].Net)
+		assert.True(t, usage.ReportedAt.Equal(results[0].ReportedAt))
+	})
+}
+
+func (s *ServerResourceUsageRepositorySuite) TestServerResourceUsageRepositoryFind() {
+	ctx := context.Background()
+	reportedAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	for _, serverID := range []uint{3, 1, 2} {
+		require.NoError(s.T(), s.repo.Save(ctx, &domain.ServerResourceUsage{
+			ServerID:   serverID,
+			RAM:        lo.ToPtr(int(serverID) * 100),
+			ReportedAt: reportedAt,
+		}))
+	}
+
+	s.T().Run("find_all", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, nil, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		assert.Equal(t, uint(1), results[0].ServerID)
+		assert.Equal(t, uint(3), results[2].ServerID)
+	})
+
+	s.T().Run("find_by_server_ids", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindServerResourceUsage{ServerIDs: []uint{2, 3}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, uint(2), results[0].ServerID)
+		assert.Equal(t, lo.ToPtr(200), results[0].RAM)
+		assert.Equal(t, uint(3), results[1].ServerID)
+	})
+
+	s.T().Run("find_with_order", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, nil, []filters.Sorting{
+			{Field: "server_id", Direction: filters.SortDirectionDesc},
+		}, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		assert.Equal(t, uint(3), results[0].ServerID)
+	})
+
+	s.T().Run("find_with_pagination", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, nil, nil, &filters.Pagination{Limit: 1, Offset: 1})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, uint(2), results[0].ServerID)
+	})
+
+	s.T().Run("find_not_existing", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindServerResourceUsage{ServerIDs: []uint{99999}}, nil, nil)
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
+}
@@ -134,6 +134,40 @@ func (s *ServerTaskRepositorySuite) TestServerTaskRepositorySave() {
 		assert.Equal(t, uint(10), results[0].Counter)
 	})
 
+	s.T().Run("insert_and_update_cron_task", func(t *testing.T) {
+		task := &domain.ServerTask{
+			Command:        domain.ServerTaskCommandRestart,
+			ServerID:       1,
+			ExecuteDate:    time.Now().Add(1 * time.Hour),
+			CronExpression: lo.ToPtr("0 5 * * 1-6"),
+			Timezone:       lo.ToPtr("Europe/Berlin"),
+		}
+
+		err := s.repo.Save(ctx, task)
+		require.NoError(t, err)
+
+		filter := &filters.FindServerTask{IDs: []uint{task.ID}}
+		results, err := s.repo.Find(ctx, filter, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.NotNil(t, results[0].CronExpression)
+		require.NotNil(t, results[0].Timezone)
+		assert.Equal(t, "0 5 * * 1-6", *results[0].CronExpression)
+		assert.Equal(t, "Europe/Berlin", *results[0].Timezone)
+
+		task.CronExpression = nil
+		task.Timezone = nil
+
+		err = s.repo.Save(ctx, task)
+		require.NoError(t, err)
+
+		results, err = s.repo.Find(ctx, filter, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Nil(t, results[0].CronExpression)
+		assert.Nil(t, results[0].Timezone)
+	})
+
 	s.T().Run("auto_set_timestamps", func(t *testing.T) {
 		executeDate := time.Now().Add(3 * time.Hour)
 		task := &domain.ServerTask{
@@ -0,0 +1,174 @@
+package testing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ServerTemplateRepositorySuite struct {
+	suite.Suite
+
+	repo repositories.ServerTemplateRepository
+
+	fn func(t *testing.T) repositories.ServerTemplateRepository
+}
+
+func NewServerTemplateRepositorySuite(
+	fn func(t *testing.T) repositories.ServerTemplateRepository,
+) *ServerTemplateRepositorySuite {
+	return &ServerTemplateRepositorySuite{
+		fn: fn,
+	}
+}
+
+func (s *ServerTemplateRepositorySuite) SetupTest() {
+	s.repo = s.fn(s.T())
+}
+
+func (s *ServerTemplateRepositorySuite) TestServerTemplateRepositorySave() {
+	ctx := context.Background()
+
+	s.T().Run("insert_new_template", func(t *testing.T) {
+		template := &domain.ServerTemplate{
+			Name:         "CS 1.6 public",
+			Description:  lo.ToPtr("Public server with default maps"),
+			GameID:       "cstrike",
+			GameModID:    1,
+			ServerPort:   27015,
+			QueryPort:    lo.ToPtr(27016),
+			StartCommand: lo.ToPtr("./hlds_run -game cstrike +ip {host} +port {port}"),
+			Vars:         lo.ToPtr(`{"maxplayers":"32"}`),
+			Settings: domain.ServerTemplateSettings{
+				"autostart":        true,
+				"backup_keep_last": float64(5),
+			},
+		}
+
+		err := s.repo.Save(ctx, template)
+		require.NoError(t, err)
+		assert.NotZero(t, template.ID)
+		assert.NotNil(t, template.CreatedAt)
+		assert.NotNil(t, template.UpdatedAt)
+
+		results, err := s.repo.Find(ctx, &filters.FindServerTemplate{IDs: []uint{template.ID}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "CS 1.6 public", results[0].Name)
+		assert.Equal(t, template.Description, results[0].Description)
+		assert.Equal(t, "cstrike", results[0].GameID)
+		assert.Equal(t, uint(1), results[0].GameModID)
+		assert.Equal(t, 27015, results[0].ServerPort)
+		assert.Equal(t, lo.ToPtr(27016), results[0].QueryPort)
+		assert.Nil(t, results[0].RconPort)
+		assert.Equal(t, template.StartCommand, results[0].StartCommand)
+		assert.Equal(t, template.Vars, results[0].Vars)
+		assert.Equal(t, template.Settings, results[0].Settings)
+	})
+
+	s.T().Run("update_existing_template", func(t *testing.T) {
+		template := &domain.ServerTemplate{
+			Name:       "Minecraft",
+			GameID:     "minecraft",
+			GameModID:  2,
+			ServerPort: 25565,
+		}
+
+		require.NoError(t, s.repo.Save(ctx, template))
+		originalID := template.ID
+		originalUpdatedAt := *template.UpdatedAt
+
+		time.Sleep(10 * time.Millisecond)
+
+		template.Name = "Minecraft survival"
+		template.RAMLimit = lo.ToPtr(4096)
+		template.Settings = domain.ServerTemplateSettings{"autostart": false}
+
+		require.NoError(t, s.repo.Save(ctx, template))
+		assert.Equal(t, originalID, template.ID)
+		assert.True(t, template.UpdatedAt.After(originalUpdatedAt))
+
+		results, err := s.repo.Find(ctx, &filters.FindServerTemplate{IDs: []uint{template.ID}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "Minecraft survival", results[0].Name)
+		assert.Equal(t, lo.ToPtr(4096), results[0].RAMLimit)
+		assert.Equal(t, domain.ServerTemplateSettings{"autostart": false}, results[0].Settings)
+	})
+}
+
+func (s *ServerTemplateRepositorySuite) TestServerTemplateRepositoryFind() {
+	ctx := context.Background()
+
+	template1 := &domain.ServerTemplate{Name: "CS public", GameID: "cstrike", GameModID: 1, ServerPort: 27015}
+	template2 := &domain.ServerTemplate{Name: "CS war", GameID: "cstrike", GameModID: 1, ServerPort: 27025}
+	template3 := &domain.ServerTemplate{Name: "Rust", GameID: "rust", GameModID: 3, ServerPort: 28015}
+
+	require.NoError(s.T(), s.repo.Save(ctx, template1))
+	require.NoError(s.T(), s.repo.Save(ctx, template2))
+	require.NoError(s.T(), s.repo.Save(ctx, template3))
+
+	s.T().Run("find_all", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, nil, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		assert.Equal(t, template1.ID, results[0].ID)
+		assert.Equal(t, template3.ID, results[2].ID)
+	})
+
+	s.T().Run("find_by_game_ids", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindServerTemplate{GameIDs: []string{"cstrike"}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, template1.ID, results[0].ID)
+		assert.Equal(t, template2.ID, results[1].ID)
+	})
+
+	s.T().Run("find_with_order", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, nil, []filters.Sorting{
+			{Field: "name", Direction: filters.SortDirectionDesc},
+		}, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		assert.Equal(t, template3.ID, results[0].ID)
+		assert.Equal(t, template1.ID, results[2].ID)
+	})
+
+	s.T().Run("find_with_pagination", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, nil, nil, &filters.Pagination{Limit: 1, Offset: 1})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, template2.ID, results[0].ID)
+	})
+
+	s.T().Run("find_not_existing", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindServerTemplate{IDs: []uint{99999}}, nil, nil)
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
+}
+
+func (s *ServerTemplateRepositorySuite) TestServerTemplateRepositoryDelete() {
+	ctx := context.Background()
+
+	template := &domain.ServerTemplate{Name: "To delete", GameID: "cstrike", GameModID: 1, ServerPort: 27015}
+	require.NoError(s.T(), s.repo.Save(ctx, template))
+
+	require.NoError(s.T(), s.repo.Delete(ctx, template.ID))
+
+	results, err := s.repo.Find(ctx, &filters.FindServerTemplate{IDs: []uint{template.ID}}, nil, nil)
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), results)
+
+	s.Run("delete_not_existing", func() {
+		require.NoError(s.T(), s.repo.Delete(ctx, 99999))
+	})
+}
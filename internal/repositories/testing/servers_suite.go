@@ -355,6 +355,49 @@ func (s *ServerRepositorySuite) TestServerRepositoryFind() {
 		assert.Equal(t, uint(100), results[0].DSID)
 	})
 
+	s.T().Run("find_by_expires_before", func(t *testing.T) {
+		expiredAt := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+		expiresAt := time.Now().Add(48 * time.Hour).Truncate(time.Second)
+
+		expired := &domain.Server{
+			UUID:       uuid.New(),
+			UUIDShort:  "find003",
+			Enabled:    true,
+			Installed:  domain.ServerInstalledStatusInstalled,
+			Name:       "Find Server Expired",
+			GameID:     "csgo",
+			DSID:       300,
+			GameModID:  1,
+			Expires:    &expiredAt,
+			ServerIP:   "172.16.0.3",
+			ServerPort: 27016,
+			Dir:        "/servers/find3",
+		}
+		active := &domain.Server{
+			UUID:       uuid.New(),
+			UUIDShort:  "find004",
+			Enabled:    true,
+			Installed:  domain.ServerInstalledStatusInstalled,
+			Name:       "Find Server Active",
+			GameID:     "csgo",
+			DSID:       300,
+			GameModID:  1,
+			Expires:    &expiresAt,
+			ServerIP:   "172.16.0.3",
+			ServerPort: 27017,
+			Dir:        "/servers/find4",
+		}
+
+		require.NoError(t, s.repo.Save(ctx, expired))
+		require.NoError(t, s.repo.Save(ctx, active))
+
+		filter := &filters.FindServer{ExpiresBefore: lo.ToPtr(time.Now())}
+		results, err := s.repo.Find(ctx, filter, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, expired.ID, results[0].ID)
+	})
+
 	s.T().Run("find_with_pagination", func(t *testing.T) {
 		pagination := &filters.Pagination{Limit: 1, Offset: 0}
 		results, err := s.repo.Find(ctx, nil, nil, pagination)
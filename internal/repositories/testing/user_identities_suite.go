@@ -0,0 +1,178 @@
+package testing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type UserIdentityRepositorySuite struct {
+	suite.Suite
+
+	repo repositories.UserIdentityRepository
+
+	fn func(t *testing.T) repositories.UserIdentityRepository
+}
+
+func NewUserIdentityRepositorySuite(
+	fn func(t *testing.T) repositories.UserIdentityRepository,
+) *UserIdentityRepositorySuite {
+	return &UserIdentityRepositorySuite{
+		fn: fn,
+	}
+}
+
+func (s *UserIdentityRepositorySuite) SetupTest() {
+	s.repo = s.fn(s.T())
+}
+
+func (s *UserIdentityRepositorySuite) TestUserIdentityRepositorySave() {
+	ctx := context.Background()
+
+	s.T().Run("insert", func(t *testing.T) {
+		now := time.Now().Truncate(time.Second)
+
+		require.NoError(t, s.repo.Save(ctx, &domain.UserIdentity{
+			Provider:  "keycloak",
+			Subject:   "5f1c0a3e-0001",
+			UserID:    1,
+			Email:     "user@example.com",
+			CreatedAt: &now,
+			UpdatedAt: &now,
+		}))
+
+		results, err := s.repo.Find(ctx, &filters.FindUserIdentity{
+			Providers: []string{"keycloak"},
+			Subjects:  []string{"5f1c0a3e-0001"},
+		}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "keycloak", results[0].Provider)
+		assert.Equal(t, "5f1c0a3e-0001", results[0].Subject)
+		assert.Equal(t, uint(1), results[0].UserID)
+		assert.Equal(t, "user@example.com", results[0].Email)
+		require.NotNil(t, results[0].CreatedAt)
+		assert.True(t, now.Equal(*results[0].CreatedAt))
+	})
+
+	s.T().Run("update_existing", func(t *testing.T) {
+		createdAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+		updatedAt := time.Now().Truncate(time.Second)
+
+		require.NoError(t, s.repo.Save(ctx, &domain.UserIdentity{
+			Provider:  "google",
+			Subject:   "1000001",
+			UserID:    2,
+			Email:     "old@example.com",
+			CreatedAt: &createdAt,
+			UpdatedAt: &createdAt,
+		}))
+		require.NoError(t, s.repo.Save(ctx, &domain.UserIdentity{
+			Provider:  "google",
+			Subject:   "1000001",
+			UserID:    3,
+			Email:     "new@example.com",
+			CreatedAt: &updatedAt,
+			UpdatedAt: &updatedAt,
+		}))
+
+		results, err := s.repo.Find(ctx, &filters.FindUserIdentity{
+			Providers: []string{"google"},
+			Subjects:  []string{"1000001"},
+		}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, uint(3), results[0].UserID)
+		assert.Equal(t, "new@example.com", results[0].Email)
+		require.NotNil(t, results[0].CreatedAt)
+		assert.True(t, createdAt.Equal(*results[0].CreatedAt))
+		require.NotNil(t, results[0].UpdatedAt)
+		assert.True(t, updatedAt.Equal(*results[0].UpdatedAt))
+	})
+}
+
+func (s *UserIdentityRepositorySuite) TestUserIdentityRepositoryFind() {
+	ctx := context.Background()
+	now := time.Now()
+
+	identities := []domain.UserIdentity{
+		{Provider: "keycloak", Subject: "b", UserID: 1},
+		{Provider: "google", Subject: "a", UserID: 1},
+		{Provider: "keycloak", Subject: "a", UserID: 2},
+	}
+
+	for i := range identities {
+		identities[i].CreatedAt = &now
+		identities[i].UpdatedAt = &now
+		require.NoError(s.T(), s.repo.Save(ctx, &identities[i]))
+	}
+
+	s.T().Run("by_user_ids", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindUserIdentity{UserIDs: []uint{1}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, "google", results[0].Provider)
+		assert.Equal(t, "keycloak", results[1].Provider)
+	})
+
+	s.T().Run("by_provider_and_subject", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindUserIdentity{
+			Providers: []string{"keycloak"},
+			Subjects:  []string{"a"},
+		}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, uint(2), results[0].UserID)
+	})
+
+	s.T().Run("unknown_subject", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindUserIdentity{
+			Providers: []string{"google"},
+			Subjects:  []string{"b"},
+		}, nil, nil)
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
+
+	s.T().Run("all_with_pagination", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, nil, nil, &filters.Pagination{Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, "google", results[0].Provider)
+		assert.Equal(t, "keycloak", results[1].Provider)
+		assert.Equal(t, "a", results[1].Subject)
+	})
+}
+
+func (s *UserIdentityRepositorySuite) TestUserIdentityRepositoryDelete() {
+	ctx := context.Background()
+	now := time.Now()
+
+	identities := []domain.UserIdentity{
+		{Provider: "keycloak", Subject: "a", UserID: 1},
+		{Provider: "google", Subject: "a", UserID: 1},
+		{Provider: "keycloak", Subject: "b", UserID: 2},
+	}
+
+	for i := range identities {
+		identities[i].CreatedAt = &now
+		identities[i].UpdatedAt = &now
+		require.NoError(s.T(), s.repo.Save(ctx, &identities[i]))
+	}
+
+	require.NoError(s.T(), s.repo.Delete(ctx, 1))
+
+	results, err := s.repo.Find(ctx, nil, nil, nil)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), results, 1)
+	assert.Equal(s.T(), uint(2), results[0].UserID)
+
+	require.NoError(s.T(), s.repo.Delete(ctx, 100))
+}
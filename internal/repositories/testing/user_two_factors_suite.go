@@ -0,0 +1,151 @@
+package testing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type UserTwoFactorRepositorySuite struct {
+	suite.Suite
+
+	repo repositories.UserTwoFactorRepository
+
+	fn func(t *testing.T) repositories.UserTwoFactorRepository
+}
+
+func NewUserTwoFactorRepositorySuite(
+	fn func(t *testing.T) repositories.UserTwoFactorRepository,
+) *UserTwoFactorRepositorySuite {
+	return &UserTwoFactorRepositorySuite{
+		fn: fn,
+	}
+}
+
+func (s *UserTwoFactorRepositorySuite) SetupTest() {
+	s.repo = s.fn(s.T())
+}
+
+func (s *UserTwoFactorRepositorySuite) TestUserTwoFactorRepositorySave() {
+	ctx := context.Background()
+
+	s.T().Run("insert_pending_enrollment", func(t *testing.T) {
+		now := time.Now().Truncate(time.Second)
+
+		require.NoError(t, s.repo.Save(ctx, &domain.UserTwoFactor{
+			UserID:    1,
+			Secret:    "JBSWY3DPEHPK3PXP",
+			CreatedAt: &now,
+			UpdatedAt: &now,
+		}))
+
+		results, err := s.repo.Find(ctx, &filters.FindUserTwoFactor{UserIDs: []uint{1}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, uint(1), results[0].UserID)
+		assert.Equal(t, "JBSWY3DPEHPK3PXP", results[0].Secret)
+		assert.Nil(t, results[0].ConfirmedAt)
+		assert.Empty(t, results[0].RecoveryCodes)
+		assert.Equal(t, int64(0), results[0].LastUsedStep)
+		require.NotNil(t, results[0].CreatedAt)
+		assert.True(t, now.Equal(*results[0].CreatedAt))
+		assert.False(t, results[0].Enabled())
+	})
+
+	s.T().Run("update_existing", func(t *testing.T) {
+		createdAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+		confirmedAt := time.Now().Truncate(time.Second)
+
+		require.NoError(t, s.repo.Save(ctx, &domain.UserTwoFactor{
+			UserID:    2,
+			Secret:    "FIRSTSECRET",
+			CreatedAt: &createdAt,
+			UpdatedAt: &createdAt,
+		}))
+		require.NoError(t, s.repo.Save(ctx, &domain.UserTwoFactor{
+			UserID:        2,
+			Secret:        "SECONDSECRET",
+			ConfirmedAt:   &confirmedAt,
+			RecoveryCodes: domain.RecoveryCodes{"hash1", "hash2"},
+			LastUsedStep:  58000000,
+			CreatedAt:     &createdAt,
+			UpdatedAt:     &confirmedAt,
+		}))
+
+		results, err := s.repo.Find(ctx, &filters.FindUserTwoFactor{UserIDs: []uint{2}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "SECONDSECRET", results[0].Secret)
+		require.NotNil(t, results[0].ConfirmedAt)
+		assert.True(t, confirmedAt.Equal(*results[0].ConfirmedAt))
+		assert.Equal(t, domain.RecoveryCodes{"hash1", "hash2"}, results[0].RecoveryCodes)
+		assert.Equal(t, int64(58000000), results[0].LastUsedStep)
+		assert.True(t, results[0].Enabled())
+	})
+}
+
+func (s *UserTwoFactorRepositorySuite) TestUserTwoFactorRepositoryFind() {
+	ctx := context.Background()
+	now := time.Now()
+
+	for _, userID := range []uint{3, 1, 2} {
+		require.NoError(s.T(), s.repo.Save(ctx, &domain.UserTwoFactor{
+			UserID:    userID,
+			Secret:    "SECRET",
+			CreatedAt: &now,
+			UpdatedAt: &now,
+		}))
+	}
+
+	s.T().Run("by_user_ids", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindUserTwoFactor{UserIDs: []uint{1, 3}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, uint(1), results[0].UserID)
+		assert.Equal(t, uint(3), results[1].UserID)
+	})
+
+	s.T().Run("unknown_user", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindUserTwoFactor{UserIDs: []uint{100}}, nil, nil)
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
+
+	s.T().Run("all_with_pagination", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, nil, nil, &filters.Pagination{Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, uint(1), results[0].UserID)
+		assert.Equal(t, uint(2), results[1].UserID)
+	})
+}
+
+func (s *UserTwoFactorRepositorySuite) TestUserTwoFactorRepositoryDelete() {
+	ctx := context.Background()
+	now := time.Now()
+
+	for _, userID := range []uint{1, 2} {
+		require.NoError(s.T(), s.repo.Save(ctx, &domain.UserTwoFactor{
+			UserID:    userID,
+			Secret:    "SECRET",
+			CreatedAt: &now,
+			UpdatedAt: &now,
+		}))
+	}
+
+	require.NoError(s.T(), s.repo.Delete(ctx, 1))
+
+	results, err := s.repo.Find(ctx, nil, nil, nil)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), results, 1)
+	assert.Equal(s.T(), uint(2), results[0].UserID)
+
+	require.NoError(s.T(), s.repo.Delete(ctx, 100))
+}
@@ -187,6 +187,37 @@ func (s *UserRepositorySuite) TestUserRepositorySaveWithTimestamps() {
 	})
 }
 
+func (s *UserRepositorySuite) TestUserRepositorySaveDisabled() {
+	ctx := context.Background()
+
+	user := &domain.User{
+		Login:    "disableduser",
+		Email:    "disabled@example.com",
+		Password: "hashedpassword",
+	}
+	require.NoError(s.T(), s.repo.Save(ctx, user))
+
+	disabledAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	user.DisabledAt = lo.ToPtr(disabledAt)
+	require.NoError(s.T(), s.repo.Save(ctx, user))
+
+	results, err := s.repo.Find(ctx, &filters.FindUser{IDs: []uint{user.ID}}, nil, nil)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), results, 1)
+	require.NotNil(s.T(), results[0].DisabledAt)
+	assert.True(s.T(), disabledAt.Equal(*results[0].DisabledAt))
+	assert.True(s.T(), results[0].Disabled())
+
+	user.DisabledAt = nil
+	require.NoError(s.T(), s.repo.Save(ctx, user))
+
+	results, err = s.repo.Find(ctx, &filters.FindUser{IDs: []uint{user.ID}}, nil, nil)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), results, 1)
+	assert.Nil(s.T(), results[0].DisabledAt)
+	assert.False(s.T(), results[0].Disabled())
+}
+
 func (s *UserRepositorySuite) TestUserRepositoryFindAll() {
 	ctx := context.Background()
 
@@ -0,0 +1,170 @@
+package testing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type WebhookDeliveryRepositorySuite struct {
+	suite.Suite
+
+	repo repositories.WebhookDeliveryRepository
+
+	fn func(t *testing.T) repositories.WebhookDeliveryRepository
+}
+
+func NewWebhookDeliveryRepositorySuite(
+	fn func(t *testing.T) repositories.WebhookDeliveryRepository,
+) *WebhookDeliveryRepositorySuite {
+	return &WebhookDeliveryRepositorySuite{
+		fn: fn,
+	}
+}
+
+func (s *WebhookDeliveryRepositorySuite) SetupTest() {
+	s.repo = s.fn(s.T())
+}
+
+func (s *WebhookDeliveryRepositorySuite) TestWebhookDeliveryRepositorySave() {
+	ctx := context.Background()
+	nextAttemptAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	delivery := &domain.WebhookDelivery{
+		WebhookID:     1,
+		EventID:       "0195c1b4-4a5e-7a6f-8d2a-2f6c1c9e0b11",
+		EventType:     domain.EventTypeServerCreated,
+		Payload:       `{"event":"server.created"}`,
+		Status:        domain.WebhookDeliveryStatusPending,
+		NextAttemptAt: &nextAttemptAt,
+	}
+
+	s.T().Run("insert_new_delivery", func(t *testing.T) {
+		require.NoError(t, s.repo.Save(ctx, delivery))
+		assert.NotZero(t, delivery.ID)
+		assert.NotNil(t, delivery.CreatedAt)
+
+		results, err := s.repo.Find(ctx, &filters.FindWebhookDelivery{IDs: []uint{delivery.ID}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, uint(1), results[0].WebhookID)
+		assert.Equal(t, delivery.EventID, results[0].EventID)
+		assert.Equal(t, domain.EventTypeServerCreated, results[0].EventType)
+		assert.Equal(t, delivery.Payload, results[0].Payload)
+		assert.Equal(t, domain.WebhookDeliveryStatusPending, results[0].Status)
+		assert.Zero(t, results[0].Attempts)
+		require.NotNil(t, results[0].NextAttemptAt)
+		assert.True(t, nextAttemptAt.Equal(*results[0].NextAttemptAt))
+		assert.Nil(t, results[0].ResponseStatus)
+		assert.Nil(t, results[0].Error)
+	})
+
+	s.T().Run("update_attempt", func(t *testing.T) {
+		delivery.Fail(domain.WebhookRetryPolicy{MaxAttempts: 1}, nextAttemptAt, lo.ToPtr(502), "bad gateway")
+		require.NoError(t, s.repo.Save(ctx, delivery))
+
+		results, err := s.repo.Find(ctx, &filters.FindWebhookDelivery{IDs: []uint{delivery.ID}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, domain.WebhookDeliveryStatusFailed, results[0].Status)
+		assert.Equal(t, 1, results[0].Attempts)
+		assert.Nil(t, results[0].NextAttemptAt)
+		assert.Equal(t, lo.ToPtr(502), results[0].ResponseStatus)
+		assert.Equal(t, lo.ToPtr("bad gateway"), results[0].Error)
+	})
+}
+
+func (s *WebhookDeliveryRepositorySuite) TestWebhookDeliveryRepositoryFind() {
+	ctx := context.Background()
+	base := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	due := s.save(1, domain.WebhookDeliveryStatusPending, lo.ToPtr(base.Add(-time.Minute)))
+	now := s.save(1, domain.WebhookDeliveryStatusPending, &base)
+	later := s.save(2, domain.WebhookDeliveryStatusPending, lo.ToPtr(base.Add(time.Minute)))
+	succeeded := s.save(2, domain.WebhookDeliveryStatusSucceeded, nil)
+
+	s.T().Run("by_webhook", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindWebhookDelivery{WebhookIDs: []uint{2}}, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []uint{later.ID, succeeded.ID}, s.ids(results))
+	})
+
+	s.T().Run("due_pending", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindWebhookDelivery{
+			Statuses:          []domain.WebhookDeliveryStatus{domain.WebhookDeliveryStatusPending},
+			NextAttemptBefore: &base,
+		}, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []uint{due.ID, now.ID}, s.ids(results))
+	})
+
+	s.T().Run("with_order_and_pagination", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, nil, []filters.Sorting{
+			{Field: "id", Direction: filters.SortDirectionDesc},
+		}, &filters.Pagination{
+			Limit: 2,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{succeeded.ID, later.ID}, s.ids(results))
+	})
+}
+
+func (s *WebhookDeliveryRepositorySuite) TestWebhookDeliveryRepositoryDelete() {
+	ctx := context.Background()
+
+	old := s.save(1, domain.WebhookDeliveryStatusSucceeded, nil)
+	old.CreatedAt = lo.ToPtr(time.Now().Add(-48 * time.Hour))
+	require.NoError(s.T(), s.repo.Save(ctx, old))
+
+	recent := s.save(1, domain.WebhookDeliveryStatusSucceeded, nil)
+	other := s.save(2, domain.WebhookDeliveryStatusSucceeded, nil)
+
+	s.T().Run("before", func(t *testing.T) {
+		require.NoError(t, s.repo.DeleteBefore(ctx, time.Now().Add(-24*time.Hour)))
+
+		results, err := s.repo.Find(ctx, nil, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []uint{recent.ID, other.ID}, s.ids(results))
+	})
+
+	s.T().Run("by_webhook", func(t *testing.T) {
+		require.NoError(t, s.repo.DeleteByWebhookID(ctx, 1))
+
+		results, err := s.repo.Find(ctx, nil, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []uint{other.ID}, s.ids(results))
+	})
+}
+
+func (s *WebhookDeliveryRepositorySuite) save(
+	webhookID uint,
+	status domain.WebhookDeliveryStatus,
+	nextAttemptAt *time.Time,
+) *domain.WebhookDelivery {
+	delivery := &domain.WebhookDelivery{
+		WebhookID:     webhookID,
+		EventID:       "0195c1b4-4a5e-7a6f-8d2a-2f6c1c9e0b11",
+		EventType:     domain.EventTypeServerCreated,
+		Payload:       "{}",
+		Status:        status,
+		NextAttemptAt: nextAttemptAt,
+	}
+
+	require.NoError(s.T(), s.repo.Save(context.Background(), delivery))
+
+	return delivery
+}
+
+func (s *WebhookDeliveryRepositorySuite) ids(deliveries []domain.WebhookDelivery) []uint {
+	return lo.Map(deliveries, func(delivery domain.WebhookDelivery, _ int) uint {
+		return delivery.ID
+	})
+}
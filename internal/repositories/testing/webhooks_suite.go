@@ -0,0 +1,159 @@
+package testing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type WebhookRepositorySuite struct {
+	suite.Suite
+
+	repo repositories.WebhookRepository
+
+	fn func(t *testing.T) repositories.WebhookRepository
+}
+
+func NewWebhookRepositorySuite(
+	fn func(t *testing.T) repositories.WebhookRepository,
+) *WebhookRepositorySuite {
+	return &WebhookRepositorySuite{
+		fn: fn,
+	}
+}
+
+func (s *WebhookRepositorySuite) SetupTest() {
+	s.repo = s.fn(s.T())
+}
+
+func (s *WebhookRepositorySuite) TestWebhookRepositorySave() {
+	ctx := context.Background()
+
+	s.T().Run("insert_new_webhook", func(t *testing.T) {
+		webhook := &domain.Webhook{
+			UserID:   lo.ToPtr(uint(1)),
+			ServerID: lo.ToPtr(uint(5)),
+			Name:     "Status bot",
+			URL:      "https://example.com/hooks/gameap",
+			Secret:   "secret",
+			Events:   domain.WebhookEvents{domain.EventTypeServerOnline, domain.EventTypeServerOffline},
+			Enabled:  true,
+		}
+
+		require.NoError(t, s.repo.Save(ctx, webhook))
+		assert.NotZero(t, webhook.ID)
+		assert.NotNil(t, webhook.CreatedAt)
+		assert.NotNil(t, webhook.UpdatedAt)
+
+		results, err := s.repo.Find(ctx, &filters.FindWebhook{IDs: []uint{webhook.ID}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, lo.ToPtr(uint(1)), results[0].UserID)
+		assert.Equal(t, lo.ToPtr(uint(5)), results[0].ServerID)
+		assert.Equal(t, "Status bot", results[0].Name)
+		assert.Equal(t, "https://example.com/hooks/gameap", results[0].URL)
+		assert.Equal(t, "secret", results[0].Secret)
+		assert.Equal(t, webhook.Events, results[0].Events)
+		assert.True(t, results[0].Enabled)
+	})
+
+	s.T().Run("update_existing_webhook", func(t *testing.T) {
+		webhook := &domain.Webhook{
+			Name:    "Global",
+			URL:     "https://example.com/global",
+			Secret:  "secret",
+			Enabled: true,
+		}
+		require.NoError(t, s.repo.Save(ctx, webhook))
+
+		webhook.URL = "https://example.com/global/v2"
+		webhook.Events = domain.WebhookEvents{domain.EventTypeServerCreated}
+		webhook.Enabled = false
+		require.NoError(t, s.repo.Save(ctx, webhook))
+
+		results, err := s.repo.Find(ctx, &filters.FindWebhook{IDs: []uint{webhook.ID}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Nil(t, results[0].UserID)
+		assert.Nil(t, results[0].ServerID)
+		assert.Equal(t, "https://example.com/global/v2", results[0].URL)
+		assert.Equal(t, domain.WebhookEvents{domain.EventTypeServerCreated}, results[0].Events)
+		assert.False(t, results[0].Enabled)
+	})
+}
+
+func (s *WebhookRepositorySuite) TestWebhookRepositoryFind() {
+	ctx := context.Background()
+
+	first := s.save(lo.ToPtr(uint(1)), "first", true)
+	second := s.save(lo.ToPtr(uint(2)), "second", false)
+	global := s.save(nil, "global", true)
+
+	s.T().Run("all", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, nil, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []uint{first.ID, second.ID, global.ID}, s.ids(results))
+	})
+
+	s.T().Run("by_user", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindWebhook{UserIDs: []uint{2}}, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []uint{second.ID}, s.ids(results))
+	})
+
+	s.T().Run("enabled", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindWebhook{Enabled: lo.ToPtr(true)}, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []uint{first.ID, global.ID}, s.ids(results))
+	})
+
+	s.T().Run("with_order_and_pagination", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, nil, []filters.Sorting{
+			{Field: "name", Direction: filters.SortDirectionDesc},
+		}, &filters.Pagination{
+			Limit: 2,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{second.ID, global.ID}, s.ids(results))
+	})
+}
+
+func (s *WebhookRepositorySuite) TestWebhookRepositoryDelete() {
+	ctx := context.Background()
+
+	webhook := s.save(nil, "global", true)
+	other := s.save(nil, "other", true)
+
+	require.NoError(s.T(), s.repo.Delete(ctx, webhook.ID))
+
+	results, err := s.repo.Find(ctx, nil, nil, nil)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []uint{other.ID}, s.ids(results))
+}
+
+func (s *WebhookRepositorySuite) save(userID *uint, name string, enabled bool) *domain.Webhook {
+	webhook := &domain.Webhook{
+		UserID:  userID,
+		Name:    name,
+		URL:     "https://example.com/" + name,
+		Secret:  "secret",
+		Enabled: enabled,
+	}
+
+	require.NoError(s.T(), s.repo.Save(context.Background(), webhook))
+
+	return webhook
+}
+
+func (s *WebhookRepositorySuite) ids(webhooks []domain.Webhook) []uint {
+	return lo.Map(webhooks, func(webhook domain.Webhook, _ int) uint {
+		return webhook.ID
+	})
+}
@@ -0,0 +1,164 @@
+// Package audit records the actions changing the panel and the game servers.
+package audit
+
+import (
+	"context"
+	"slices"
+	"strconv"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+// Repositories are the repositories the audited entities are loaded from to record their changes.
+type Repositories struct {
+	Servers              repositories.ServerRepository
+	Nodes                repositories.NodeRepository
+	Users                repositories.UserRepository
+	RBAC                 repositories.RBACRepository
+	Games                repositories.GameRepository
+	GameMods             repositories.GameModRepository
+	ClientCertificates   repositories.ClientCertificateRepository
+	ServerTemplates      repositories.ServerTemplateRepository
+	Webhooks             repositories.WebhookRepository
+	NotificationChannels repositories.NotificationChannelRepository
+	PersonalAccessTokens repositories.PersonalAccessTokenRepository
+}
+
+type Service struct {
+	repo  repositories.AuditLogRepository
+	repos Repositories
+}
+
+func NewService(repo repositories.AuditLogRepository, repos Repositories) *Service {
+	return &Service{
+		repo:  repo,
+		repos: repos,
+	}
+}
+
+func (s *Service) Record(ctx context.Context, log *domain.AuditLog) error {
+	if err := s.repo.Save(ctx, log); err != nil {
+		return errors.WithMessage(err, "failed to save audit log")
+	}
+
+	return nil
+}
+
+// Snapshot returns the fields of the entity, nil if the entity isn't found or its type isn't audited.
+func (s *Service) Snapshot(ctx context.Context, entityType domain.EntityType, id string) (Snapshot, error) {
+	entity, err := s.load(ctx, entityType, id)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to load entity")
+	}
+
+	if entity == nil {
+		return nil, nil
+	}
+
+	return NewSnapshot(entity)
+}
+
+//nolint:gocyclo
+func (s *Service) load(ctx context.Context, entityType domain.EntityType, id string) (any, error) {
+	if entityType == domain.EntityTypeGame {
+		return first(s.repos.Games.Find(ctx, &filters.FindGame{Codes: []string{id}}, nil, nil))
+	}
+
+	parsed, err := strconv.ParseUint(id, 10, 0)
+	if err != nil {
+		return nil, nil //nolint:nilerr // entities of the other types have numeric IDs, nothing can be found
+	}
+
+	ids := []uint{uint(parsed)}
+
+	switch entityType {
+	case domain.EntityTypeServer:
+		return first(s.repos.Servers.Find(ctx, &filters.FindServer{IDs: ids, WithDeleted: true}, nil, nil))
+	case domain.EntityTypeNode:
+		return first(s.repos.Nodes.Find(ctx, &filters.FindNode{IDs: ids, WithDeleted: true}, nil, nil))
+	case domain.EntityTypeUser:
+		return s.loadUser(ctx, ids[0])
+	case domain.EntityTypeGameMod:
+		return first(s.repos.GameMods.Find(ctx, &filters.FindGameMod{IDs: ids}, nil, nil))
+	case domain.EntityTypeClientCertificate:
+		return first(s.repos.ClientCertificates.Find(ctx, &filters.FindClientCertificate{IDs: ids}, nil, nil))
+	case domain.EntityTypeServerTemplate:
+		return first(s.repos.ServerTemplates.Find(ctx, &filters.FindServerTemplate{IDs: ids}, nil, nil))
+	case domain.EntityTypeWebhook:
+		return first(s.repos.Webhooks.Find(ctx, &filters.FindWebhook{IDs: ids}, nil, nil))
+	case domain.EntityTypeNotificationChannel:
+		return first(s.repos.NotificationChannels.Find(ctx, &filters.FindNotificationChannel{IDs: ids}, nil, nil))
+	case domain.EntityTypePersonalAccessToken:
+		return first(s.repos.PersonalAccessTokens.Find(ctx, &filters.FindPersonalAccessToken{IDs: ids}, nil, nil))
+	default:
+		return nil, nil
+	}
+}
+
+// userSnapshot is the user with the roles and the abilities, so the RBAC changes are recorded.
+type userSnapshot struct {
+	domain.User
+
+	Roles []string `db:"roles"`
+	// Abilities are the permissions of the user as "name" or "name#entity_id",
+	// the forbidden ones are prefixed with "!".
+	Abilities []string `db:"abilities"`
+}
+
+func (s *Service) loadUser(ctx context.Context, id uint) (any, error) {
+	users, err := s.repos.Users.Find(ctx, &filters.FindUser{IDs: []uint{id}}, nil, nil)
+	if err != nil || len(users) == 0 {
+		return nil, err
+	}
+
+	roles, err := s.repos.RBAC.GetRolesForEntity(ctx, id, domain.EntityTypeUser)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get user roles")
+	}
+
+	permissions, err := s.repos.RBAC.GetPermissions(ctx, id, domain.EntityTypeUser)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get user permissions")
+	}
+
+	snapshot := &userSnapshot{
+		User: users[0],
+		Roles: lo.Map(roles, func(role domain.RestrictedRole, _ int) string {
+			return role.Name
+		}),
+		Abilities: lo.FilterMap(permissions, func(permission domain.Permission, _ int) (string, bool) {
+			if permission.Ability == nil {
+				return "", false
+			}
+
+			ability := string(permission.Ability.Name)
+
+			if permission.Ability.EntityID != nil {
+				ability += "#" + strconv.FormatUint(uint64(*permission.Ability.EntityID), 10)
+			}
+
+			if permission.Forbidden {
+				ability = "!" + ability
+			}
+
+			return ability, true
+		}),
+	}
+
+	slices.Sort(snapshot.Roles)
+	slices.Sort(snapshot.Abilities)
+
+	return snapshot, nil
+}
+
+func first[T any](items []T, err error) (any, error) {
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+
+	return &items[0], nil
+}
@@ -0,0 +1,128 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupService(t *testing.T) (*Service, Repositories, *inmemory.AuditLogRepository) {
+	t.Helper()
+
+	repos := Repositories{
+		Servers:              inmemory.NewServerRepository(),
+		Nodes:                inmemory.NewNodeRepository(),
+		Users:                inmemory.NewUserRepository(),
+		RBAC:                 inmemory.NewRBACRepository(),
+		Games:                inmemory.NewGameRepository(),
+		GameMods:             inmemory.NewGameModRepository(),
+		ClientCertificates:   inmemory.NewClientCertificateRepository(),
+		ServerTemplates:      inmemory.NewServerTemplateRepository(),
+		Webhooks:             inmemory.NewWebhookRepository(),
+		NotificationChannels: inmemory.NewNotificationChannelRepository(),
+		PersonalAccessTokens: inmemory.NewPersonalAccessTokenRepository(),
+	}
+	auditLogRepo := inmemory.NewAuditLogRepository()
+
+	return NewService(auditLogRepo, repos), repos, auditLogRepo
+}
+
+func TestService_Snapshot_User(t *testing.T) {
+	ctx := context.Background()
+	service, repos, _ := setupService(t)
+
+	user := &domain.User{Login: "john", Email: "john@example.com", Password: "hash"}
+	require.NoError(t, repos.Users.Save(ctx, user))
+
+	adminRole := &domain.Role{Name: "admin"}
+	require.NoError(t, repos.RBAC.SaveRole(ctx, adminRole))
+	require.NoError(t, repos.RBAC.AssignRolesForEntity(ctx, user.ID, domain.EntityTypeUser, []domain.RestrictedRole{
+		domain.NewRestrictedRoleFromRole(*adminRole),
+	}))
+	require.NoError(t, repos.RBAC.Allow(ctx, user.ID, domain.EntityTypeUser, []domain.Ability{
+		{Name: domain.AbilityNameGameServerStart, EntityID: lo.ToPtr[uint](5)},
+	}))
+	require.NoError(t, repos.RBAC.Forbid(ctx, user.ID, domain.EntityTypeUser, []domain.Ability{
+		{Name: domain.AbilityNameGameServerStop, EntityID: lo.ToPtr[uint](5)},
+	}))
+
+	snapshot, err := service.Snapshot(ctx, domain.EntityTypeUser, "1")
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `"john"`, string(snapshot["login"]))
+	assert.JSONEq(t, `["admin"]`, string(snapshot["roles"]))
+	assert.JSONEq(t, `["!game-server-stop#5","game-server-start#5"]`, string(snapshot["abilities"]))
+}
+
+func TestService_Snapshot_Game(t *testing.T) {
+	ctx := context.Background()
+	service, repos, _ := setupService(t)
+
+	require.NoError(t, repos.Games.Save(ctx, &domain.Game{Code: "cstrike", Name: "Counter-Strike"}))
+
+	snapshot, err := service.Snapshot(ctx, domain.EntityTypeGame, "cstrike")
+	require.NoError(t, err)
+
+	assert.Equal(t, json.RawMessage(`"Counter-Strike"`), snapshot["name"])
+}
+
+func TestService_Snapshot_NotFound(t *testing.T) {
+	service, _, _ := setupService(t)
+
+	tests := []struct {
+		name       string
+		entityType domain.EntityType
+		id         string
+	}{
+		{
+			name:       "missing_entity",
+			entityType: domain.EntityTypeServer,
+			id:         "1",
+		},
+		{
+			name:       "not_numeric_id",
+			entityType: domain.EntityTypeNode,
+			id:         "abc",
+		},
+		{
+			name:       "not_audited_type",
+			entityType: domain.EntityTypeRole,
+			id:         "1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			snapshot, err := service.Snapshot(context.Background(), test.entityType, test.id)
+
+			require.NoError(t, err)
+			assert.Nil(t, snapshot)
+		})
+	}
+}
+
+func TestService_Record(t *testing.T) {
+	ctx := context.Background()
+	service, _, auditLogRepo := setupService(t)
+
+	log := &domain.AuditLog{
+		Action:     "POST /api/servers",
+		Result:     domain.AuditResultSuccess,
+		StatusCode: 201,
+	}
+
+	require.NoError(t, service.Record(ctx, log))
+
+	logs, err := auditLogRepo.Find(ctx, &filters.FindAuditLog{}, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	assert.Equal(t, "POST /api/servers", logs[0].Action)
+	assert.NotZero(t, logs[0].ID)
+}
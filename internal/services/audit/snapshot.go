@@ -0,0 +1,222 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+const (
+	// maxStringLength limits the recorded strings, e.g. the content of edited files.
+	maxStringLength = 512
+
+	redacted = "[redacted]"
+)
+
+var (
+	jsonNull     = json.RawMessage("null")
+	jsonRedacted = json.RawMessage(`"` + redacted + `"`)
+
+	// sensitiveKeyParts are the parts of the field names with secrets, the values are never recorded.
+	// The fields ending with "token" are sensitive too.
+	sensitiveKeyParts = []string{"password", "secret", "api_key", "private_key"}
+	// sensitiveKeys are the field names with secrets which can't be matched by the parts,
+	// like the RCON password of the servers, the Discord webhook URL of the notification channels
+	// or the TOTP and recovery codes of the two-factor authentication.
+	sensitiveKeys = []string{"rcon", "target", "code", "recovery_code"}
+
+	// ignoredKeys are the fields changed on each update, they are excluded from the changes.
+	ignoredKeys = []string{"updated_at"}
+)
+
+// Snapshot is the JSON encoded fields of an entity keyed by the column names.
+type Snapshot map[string]json.RawMessage
+
+// NewSnapshot encodes the fields of the struct with the db tags, the fields of the embedded structs are included.
+func NewSnapshot(entity any) (Snapshot, error) {
+	v := reflect.Indirect(reflect.ValueOf(entity))
+	if v.Kind() != reflect.Struct {
+		return nil, errors.Errorf("unsupported entity type %T", entity)
+	}
+
+	snapshot := make(Snapshot)
+
+	if err := snapshot.add(v); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+func (s Snapshot) add(v reflect.Value) error {
+	t := v.Type()
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if err := s.add(v.Field(i)); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		name := field.Tag.Get("db")
+		if name == "" || name == "-" || !field.IsExported() {
+			continue
+		}
+
+		value, err := json.Marshal(v.Field(i).Interface())
+		if err != nil {
+			return errors.WithMessagef(err, "failed to encode field %s", name)
+		}
+
+		s[name] = value
+	}
+
+	return nil
+}
+
+// Change is the old and the new value of a field.
+type Change struct {
+	Old json.RawMessage `json:"old"`
+	New json.RawMessage `json:"new"`
+}
+
+// Diff returns the changed fields. A nil snapshot is a missing entity, e.g. a deleted one,
+// its fields are null. The values of the sensitive fields are redacted.
+func Diff(before, after Snapshot) map[string]Change {
+	if before == nil && after == nil {
+		return nil
+	}
+
+	keys := lo.Union(lo.Keys(before), lo.Keys(after))
+	changes := make(map[string]Change, len(keys))
+
+	for _, key := range keys {
+		if slices.Contains(ignoredKeys, key) {
+			continue
+		}
+
+		oldValue, ok := before[key]
+		if !ok {
+			oldValue = jsonNull
+		}
+
+		newValue, ok := after[key]
+		if !ok {
+			newValue = jsonNull
+		}
+
+		if bytes.Equal(oldValue, newValue) {
+			continue
+		}
+
+		changes[key] = Change{
+			Old: redactValue(key, oldValue),
+			New: redactValue(key, newValue),
+		}
+	}
+
+	return changes
+}
+
+// RedactJSON returns the JSON document with the values of the sensitive fields replaced
+// and the long strings truncated.
+func RedactJSON(data []byte) (string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return "", errors.WithMessage(err, "failed to decode json")
+	}
+
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(redactDocument(document)); err != nil {
+		return "", errors.WithMessage(err, "failed to encode json")
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+func redactDocument(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if isSensitive(key) && item != nil {
+				v[key] = redacted
+			} else {
+				v[key] = redactDocument(item)
+			}
+		}
+
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = redactDocument(item)
+		}
+
+		return v
+	case string:
+		return truncate(v)
+	default:
+		return v
+	}
+}
+
+func redactValue(key string, value json.RawMessage) json.RawMessage {
+	if bytes.Equal(value, jsonNull) {
+		return value
+	}
+
+	if isSensitive(key) {
+		return jsonRedacted
+	}
+
+	if len(value) <= maxStringLength || value[0] != '"' {
+		return value
+	}
+
+	var s string
+	if err := json.Unmarshal(value, &s); err != nil {
+		return value
+	}
+
+	truncated, err := json.Marshal(truncate(s))
+	if err != nil {
+		return value
+	}
+
+	return truncated
+}
+
+func isSensitive(key string) bool {
+	key = strings.ToLower(key)
+
+	if slices.Contains(sensitiveKeys, key) || strings.HasSuffix(key, "token") {
+		return true
+	}
+
+	return lo.SomeBy(sensitiveKeyParts, func(part string) bool {
+		return strings.Contains(key, part)
+	})
+}
+
+func truncate(s string) string {
+	if utf8.RuneCountInString(s) <= maxStringLength {
+		return s
+	}
+
+	return string([]rune(s)[:maxStringLength]) + "…"
+}
@@ -0,0 +1,192 @@
+package audit
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testEmbedded struct {
+	ID uint `db:"id"`
+}
+
+type testEntity struct {
+	testEmbedded
+
+	Name     string  `db:"name"`
+	Password string  `db:"password"`
+	Comment  *string `db:"comment"`
+	Internal string
+	skipped  string `db:"skipped"` //nolint:unused
+}
+
+func TestNewSnapshot(t *testing.T) {
+	snapshot, err := NewSnapshot(&testEntity{
+		testEmbedded: testEmbedded{ID: 3},
+		Name:         "Server",
+		Password:     "secret",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, Snapshot{
+		"id":       json.RawMessage(`3`),
+		"name":     json.RawMessage(`"Server"`),
+		"password": json.RawMessage(`"secret"`),
+		"comment":  json.RawMessage(`null`),
+	}, snapshot)
+}
+
+func TestNewSnapshot_NotStruct(t *testing.T) {
+	_, err := NewSnapshot("value")
+
+	require.Error(t, err)
+}
+
+func TestDiff(t *testing.T) {
+	longValue := strings.Repeat("a", maxStringLength+10)
+
+	tests := []struct {
+		name   string
+		before Snapshot
+		after  Snapshot
+		want   map[string]Change
+	}{
+		{
+			name: "both_missing",
+			want: nil,
+		},
+		{
+			name:   "changed_fields",
+			before: Snapshot{"name": json.RawMessage(`"old"`), "port": json.RawMessage(`27015`)},
+			after:  Snapshot{"name": json.RawMessage(`"new"`), "port": json.RawMessage(`27015`)},
+			want: map[string]Change{
+				"name": {Old: json.RawMessage(`"old"`), New: json.RawMessage(`"new"`)},
+			},
+		},
+		{
+			name:   "created",
+			before: nil,
+			after:  Snapshot{"name": json.RawMessage(`"new"`)},
+			want: map[string]Change{
+				"name": {Old: json.RawMessage(`null`), New: json.RawMessage(`"new"`)},
+			},
+		},
+		{
+			name:   "deleted",
+			before: Snapshot{"name": json.RawMessage(`"old"`)},
+			after:  nil,
+			want: map[string]Change{
+				"name": {Old: json.RawMessage(`"old"`), New: json.RawMessage(`null`)},
+			},
+		},
+		{
+			name:   "ignored_fields",
+			before: Snapshot{"updated_at": json.RawMessage(`"2024-01-01T00:00:00Z"`)},
+			after:  Snapshot{"updated_at": json.RawMessage(`"2024-01-02T00:00:00Z"`)},
+			want:   map[string]Change{},
+		},
+		{
+			name: "sensitive_fields",
+			before: Snapshot{
+				"password":     json.RawMessage(`"old"`),
+				"rcon":         json.RawMessage(`null`),
+				"daemon_token": json.RawMessage(`"old"`),
+			},
+			after: Snapshot{
+				"password":     json.RawMessage(`"new"`),
+				"rcon":         json.RawMessage(`"new"`),
+				"daemon_token": json.RawMessage(`"new"`),
+			},
+			want: map[string]Change{
+				"password":     {Old: jsonRedacted, New: jsonRedacted},
+				"rcon":         {Old: jsonNull, New: jsonRedacted},
+				"daemon_token": {Old: jsonRedacted, New: jsonRedacted},
+			},
+		},
+		{
+			name:   "long_strings",
+			before: Snapshot{"content": json.RawMessage(`""`)},
+			after:  Snapshot{"content": json.RawMessage(`"` + longValue + `"`)},
+			want: map[string]Change{
+				"content": {
+					Old: json.RawMessage(`""`),
+					New: json.RawMessage(`"` + longValue[:maxStringLength] + `…"`),
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, Diff(test.before, test.after))
+		})
+	}
+}
+
+func TestRedactJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "sensitive_keys",
+			data: `{"login":"admin","password":"secret","nested":{"api_key":"key","items":[{"token":"t"}]}}`,
+			want: `{"login":"admin","nested":{"api_key":"[redacted]","items":[{"token":"[redacted]"}]},"password":"[redacted]"}`,
+		},
+		{
+			name: "two_factor_codes",
+			data: `{"code":"123456","recovery_code":"abcd-efgh","remember":true}`,
+			want: `{"code":"[redacted]","recovery_code":"[redacted]","remember":true}`,
+		},
+		{
+			name: "null_sensitive_values",
+			data: `{"password":null}`,
+			want: `{"password":null}`,
+		},
+		{
+			name: "not_sensitive_token_fields",
+			data: `{"tokenable_type":"users"}`,
+			want: `{"tokenable_type":"users"}`,
+		},
+		{
+			name: "numbers",
+			data: `{"port":27015,"ratio":0.10000000000000001}`,
+			want: `{"port":27015,"ratio":0.10000000000000001}`,
+		},
+		{
+			name: "html",
+			data: `{"name":"<b>server</b>"}`,
+			want: `{"name":"<b>server</b>"}`,
+		},
+		{
+			name: "long_strings",
+			data: `["` + strings.Repeat("б", maxStringLength+1) + `"]`,
+			want: `["` + strings.Repeat("б", maxStringLength) + `…"]`,
+		},
+		{
+			name:    "invalid",
+			data:    `{"name":`,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := RedactJSON([]byte(test.data))
+
+			if test.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
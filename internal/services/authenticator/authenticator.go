@@ -0,0 +1,52 @@
+// Package authenticator checks the login credentials against the user sources,
+// such as the local passwords and the LDAP directory.
+package authenticator
+
+import (
+	"context"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrUserDisabled       = errors.New("user is disabled")
+)
+
+// Credentials are the login credentials, either Login or Email is set.
+type Credentials struct {
+	Login    string
+	Email    string
+	Password string
+}
+
+type Authenticator interface {
+	// Authenticate returns the user with the credentials.
+	// It returns ErrInvalidCredentials if the source doesn't know the user or the password doesn't match.
+	Authenticate(ctx context.Context, credentials Credentials) (*domain.User, error)
+}
+
+// Chain tries the authenticators in order until one of them accepts the credentials.
+// Errors other than ErrInvalidCredentials stop the chain.
+type Chain []Authenticator
+
+func NewChain(authenticators ...Authenticator) Chain {
+	return authenticators
+}
+
+func (c Chain) Authenticate(ctx context.Context, credentials Credentials) (*domain.User, error) {
+	for _, a := range c {
+		user, err := a.Authenticate(ctx, credentials)
+		if errors.Is(err, ErrInvalidCredentials) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return user, nil
+	}
+
+	return nil, ErrInvalidCredentials
+}
@@ -0,0 +1,173 @@
+package authenticator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAuthenticator struct {
+	user  *domain.User
+	err   error
+	calls int
+}
+
+func (f *fakeAuthenticator) Authenticate(_ context.Context, _ Credentials) (*domain.User, error) {
+	f.calls++
+
+	return f.user, f.err
+}
+
+func TestChain_Authenticate(t *testing.T) {
+	errDirectory := errors.New("directory is unavailable")
+
+	tests := []struct {
+		name      string
+		first     *fakeAuthenticator
+		second    *fakeAuthenticator
+		wantUser  uint
+		wantErr   error
+		wantCalls int
+	}{
+		{
+			name:      "first_accepts",
+			first:     &fakeAuthenticator{user: &domain.User{ID: 1}},
+			second:    &fakeAuthenticator{user: &domain.User{ID: 2}},
+			wantUser:  1,
+			wantCalls: 0,
+		},
+		{
+			name:      "falls_through_invalid_credentials",
+			first:     &fakeAuthenticator{err: ErrInvalidCredentials},
+			second:    &fakeAuthenticator{user: &domain.User{ID: 2}},
+			wantUser:  2,
+			wantCalls: 1,
+		},
+		{
+			name:      "all_reject",
+			first:     &fakeAuthenticator{err: ErrInvalidCredentials},
+			second:    &fakeAuthenticator{err: ErrInvalidCredentials},
+			wantErr:   ErrInvalidCredentials,
+			wantCalls: 1,
+		},
+		{
+			name:      "disabled_user_stops_chain",
+			first:     &fakeAuthenticator{err: ErrUserDisabled},
+			second:    &fakeAuthenticator{user: &domain.User{ID: 2}},
+			wantErr:   ErrUserDisabled,
+			wantCalls: 0,
+		},
+		{
+			name:      "error_stops_chain",
+			first:     &fakeAuthenticator{err: errDirectory},
+			second:    &fakeAuthenticator{user: &domain.User{ID: 2}},
+			wantErr:   errDirectory,
+			wantCalls: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain := NewChain(tt.first, tt.second)
+
+			user, err := chain.Authenticate(context.Background(), Credentials{Login: "john", Password: "secret"})
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, user)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantUser, user.ID)
+			}
+			assert.Equal(t, tt.wantCalls, tt.second.calls)
+		})
+	}
+}
+
+func TestChain_Empty(t *testing.T) {
+	_, err := NewChain().Authenticate(context.Background(), Credentials{Login: "john", Password: "secret"})
+
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestLocal_Authenticate(t *testing.T) {
+	hashedPassword, err := auth.HashPassword("password123")
+	require.NoError(t, err)
+
+	now := time.Now()
+
+	repo := inmemory.NewUserRepository()
+	require.NoError(t, repo.Save(context.Background(), &domain.User{
+		Login:    "john",
+		Email:    "john@example.com",
+		Password: hashedPassword,
+	}))
+	require.NoError(t, repo.Save(context.Background(), &domain.User{
+		Login:      "jane",
+		Email:      "jane@example.com",
+		Password:   hashedPassword,
+		DisabledAt: &now,
+	}))
+
+	local := NewLocal(repo)
+
+	tests := []struct {
+		name        string
+		credentials Credentials
+		wantLogin   string
+		wantErr     error
+	}{
+		{
+			name:        "login",
+			credentials: Credentials{Login: "john", Password: "password123"},
+			wantLogin:   "john",
+		},
+		{
+			name:        "email",
+			credentials: Credentials{Email: "john@example.com", Password: "password123"},
+			wantLogin:   "john",
+		},
+		{
+			name:        "wrong_password",
+			credentials: Credentials{Login: "john", Password: "wrong"},
+			wantErr:     ErrInvalidCredentials,
+		},
+		{
+			name:        "unknown_user",
+			credentials: Credentials{Login: "unknown", Password: "password123"},
+			wantErr:     ErrInvalidCredentials,
+		},
+		{
+			name:        "disabled_user",
+			credentials: Credentials{Login: "jane", Password: "password123"},
+			wantErr:     ErrUserDisabled,
+		},
+		{
+			name:        "disabled_user_wrong_password",
+			credentials: Credentials{Login: "jane", Password: "wrong"},
+			wantErr:     ErrInvalidCredentials,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := local.Authenticate(context.Background(), tt.credentials)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLogin, user.Login)
+		})
+	}
+}
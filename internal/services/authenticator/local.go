@@ -0,0 +1,54 @@
+package authenticator
+
+import (
+	"context"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+)
+
+// Local checks the password stored in the users repository.
+type Local struct {
+	userRepo repositories.UserRepository
+}
+
+func NewLocal(userRepo repositories.UserRepository) *Local {
+	return &Local{
+		userRepo: userRepo,
+	}
+}
+
+func (a *Local) Authenticate(ctx context.Context, credentials Credentials) (*domain.User, error) {
+	filter := &filters.FindUser{Logins: []string{credentials.Login}}
+	if credentials.Email != "" {
+		filter = &filters.FindUser{Emails: []string{credentials.Email}}
+	}
+
+	users, err := a.userRepo.Find(ctx, filter, nil, &filters.Pagination{
+		Limit:  1,
+		Offset: 0,
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find user")
+	}
+
+	if len(users) == 0 {
+		return nil, ErrInvalidCredentials
+	}
+
+	user := users[0]
+
+	if err = auth.VerifyPassword(user.Password, credentials.Password); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	// The password is checked first to not reveal disabled users
+	if user.Disabled() {
+		return nil, ErrUserDisabled
+	}
+
+	return &user, nil
+}
@@ -0,0 +1,601 @@
+package backup
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gameap/gameap/internal/daemon"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/events"
+	"github.com/gameap/gameap/internal/files"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+const (
+	// KeepLastSettingKey is the server setting overriding the default number of kept backups.
+	KeepLastSettingKey = "backup_keep_last"
+	// MaxAgeDaysSettingKey is the server setting overriding the default maximum backup age in days.
+	MaxAgeDaysSettingKey = "backup_max_age_days"
+
+	storageDir       = "backups"
+	archiveExtension = ".tar.gz"
+	archivePerms     = 0644
+	maxErrorLength   = 1024
+)
+
+var (
+	ErrOperationInProgress = errors.New("another backup operation is in progress for this server")
+	ErrBackupNotCompleted  = errors.New("backup is not completed")
+	ErrInterrupted         = errors.New("backup operation was interrupted by the panel shutdown")
+)
+
+type daemonCommands interface {
+	ExecuteCommand(
+		ctx context.Context,
+		node *domain.Node,
+		command string,
+		opts ...daemon.CommandServiceOption,
+	) (*daemon.CommandResult, error)
+}
+
+type daemonFiles interface {
+	DownloadStream(ctx context.Context, node *domain.Node, filePath string) (io.ReadCloser, error)
+	UploadStream(
+		ctx context.Context,
+		node *domain.Node,
+		filePath string,
+		r io.Reader,
+		size uint64,
+		perms os.FileMode,
+	) error
+	Remove(ctx context.Context, node *domain.Node, path string, recursive bool) error
+}
+
+type eventPublisher interface {
+	Publish(ctx context.Context, event domain.Event)
+}
+
+// Service creates and restores game server backups.
+//
+// A backup is created by archiving the server directory on the node with tar,
+// the archive is then downloaded from the node into the panel file storage.
+// Restoring uploads the archive back to the node and extracts it over the server directory,
+// files which are absent in the archive are kept.
+//
+// Backup and restore run in background, only one operation per server is allowed at a time.
+// The operations are canceled on Stop, the interrupted backups are marked as failed.
+type Service struct {
+	backupRepo        repositories.BackupRepository
+	serverSettingRepo repositories.ServerSettingRepository
+	nodeRepo          repositories.NodeRepository
+	daemonCommands    daemonCommands
+	daemonFiles       daemonFiles
+	fileManager       files.FileManager
+	defaultPolicy     domain.BackupRetentionPolicy
+	timeout           time.Duration
+	eventPublisher    eventPublisher
+
+	mu      sync.Mutex
+	running map[uint]struct{}
+	wg      sync.WaitGroup
+
+	// stopCtx is canceled on Stop, the background operations are canceled with it
+	stopCtx context.Context
+	stop    context.CancelFunc
+}
+
+func NewService(
+	backupRepo repositories.BackupRepository,
+	serverSettingRepo repositories.ServerSettingRepository,
+	nodeRepo repositories.NodeRepository,
+	daemonCommands daemonCommands,
+	daemonFiles daemonFiles,
+	fileManager files.FileManager,
+	defaultPolicy domain.BackupRetentionPolicy,
+	timeout time.Duration,
+	eventPublisher eventPublisher,
+) *Service {
+	stopCtx, stop := context.WithCancel(context.Background())
+
+	return &Service{
+		backupRepo:        backupRepo,
+		serverSettingRepo: serverSettingRepo,
+		nodeRepo:          nodeRepo,
+		daemonCommands:    daemonCommands,
+		daemonFiles:       daemonFiles,
+		fileManager:       fileManager,
+		defaultPolicy:     defaultPolicy,
+		timeout:           timeout,
+		eventPublisher:    eventPublisher,
+		running:           make(map[uint]struct{}),
+		stopCtx:           stopCtx,
+		stop:              stop,
+	}
+}
+
+// Start creates a backup record and starts archiving the server directory in background.
+// The returned backup is in the creating status.
+func (s *Service) Start(ctx context.Context, server *domain.Server) (*domain.Backup, error) {
+	if !s.acquire(server.ID) {
+		return nil, ErrOperationInProgress
+	}
+
+	now := time.Now()
+
+	backup := &domain.Backup{
+		ServerID:  server.ID,
+		Status:    domain.BackupStatusCreating,
+		Path:      storagePath(server, now),
+		CreatedAt: &now,
+	}
+
+	if err := s.backupRepo.Save(ctx, backup); err != nil {
+		s.release(server.ID)
+
+		return nil, errors.WithMessage(err, "failed to save backup")
+	}
+
+	result := *backup
+
+	s.runInBackground(ctx, server.ID, func(ctx context.Context) {
+		s.create(ctx, server, backup)
+	})
+
+	return &result, nil
+}
+
+// Restore starts restoring the backup into the server directory in background.
+// The returned backup is in the restoring status.
+func (s *Service) Restore(ctx context.Context, server *domain.Server, backup *domain.Backup) (*domain.Backup, error) {
+	if backup.ServerID != server.ID {
+		return nil, api.NewNotFoundError("backup not found")
+	}
+
+	if backup.Status != domain.BackupStatusCompleted {
+		return nil, ErrBackupNotCompleted
+	}
+
+	if !s.acquire(server.ID) {
+		return nil, ErrOperationInProgress
+	}
+
+	restoring := *backup
+	restoring.Status = domain.BackupStatusRestoring
+	restoring.Error = nil
+
+	if err := s.backupRepo.Save(ctx, &restoring); err != nil {
+		s.release(server.ID)
+
+		return nil, errors.WithMessage(err, "failed to save backup")
+	}
+
+	result := restoring
+
+	s.runInBackground(ctx, server.ID, func(ctx context.Context) {
+		s.restore(ctx, server, &restoring)
+	})
+
+	return &result, nil
+}
+
+// Delete removes the backup archive from the storage and deletes the backup record.
+// A backup left in progress by an interrupted operation, for example, after the panel restart, can be deleted.
+func (s *Service) Delete(ctx context.Context, backup *domain.Backup) error {
+	if backup.InProgress() && s.isRunning(backup.ServerID) {
+		return ErrOperationInProgress
+	}
+
+	return s.delete(ctx, backup)
+}
+
+// Wait blocks until all background backup operations are finished.
+func (s *Service) Wait() {
+	s.wg.Wait()
+}
+
+// Stop cancels the background backup operations and waits until they are finished.
+// The operations started after Stop are canceled right away.
+func (s *Service) Stop() {
+	s.stop()
+	s.wg.Wait()
+}
+
+func (s *Service) acquire(serverID uint) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.running[serverID]; ok {
+		return false
+	}
+
+	s.running[serverID] = struct{}{}
+
+	return true
+}
+
+func (s *Service) isRunning(serverID uint) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.running[serverID]
+
+	return ok
+}
+
+func (s *Service) release(serverID uint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.running, serverID)
+}
+
+func (s *Service) runInBackground(ctx context.Context, serverID uint, fn func(ctx context.Context)) {
+	// The operation outlives the request, keep only the context values
+	ctx, cancelTimeout := context.WithTimeout(context.WithoutCancel(ctx), s.timeout)
+	ctx, cancel := context.WithCancelCause(ctx)
+	stopAfter := context.AfterFunc(s.stopCtx, func() {
+		cancel(ErrInterrupted)
+	})
+
+	s.wg.Add(1)
+
+	go func() {
+		defer s.wg.Done()
+		defer s.release(serverID)
+		defer cancelTimeout()
+		defer cancel(nil)
+		defer stopAfter()
+
+		fn(ctx)
+	}()
+}
+
+func (s *Service) create(ctx context.Context, server *domain.Server, backup *domain.Backup) {
+	size, err := s.archive(ctx, server, backup)
+	if err != nil {
+		if errors.Is(context.Cause(ctx), ErrInterrupted) {
+			err = errors.WithMessage(ErrInterrupted, err.Error())
+		}
+
+		// The failure is saved even if the operation has been canceled
+		ctx = context.WithoutCancel(ctx)
+
+		slog.ErrorContext(
+			ctx,
+			"Failed to create server backup",
+			slog.Uint64("server_id", uint64(server.ID)),
+			slog.Uint64("backup_id", uint64(backup.ID)),
+			slog.String("error", err.Error()),
+		)
+
+		backup.Status = domain.BackupStatusFailed
+		backup.Error = lo.ToPtr(truncateError(err))
+		s.save(ctx, backup)
+
+		s.eventPublisher.Publish(ctx, events.BackupFailed(server, backup, err))
+
+		return
+	}
+
+	backup.Status = domain.BackupStatusCompleted
+	backup.Size = size
+	backup.Error = nil
+	s.save(ctx, backup)
+
+	slog.InfoContext(
+		ctx,
+		"Server backup has been created",
+		slog.Uint64("server_id", uint64(server.ID)),
+		slog.Uint64("backup_id", uint64(backup.ID)),
+		slog.Int64("size", size),
+	)
+
+	if err = s.applyRetention(ctx, server); err != nil {
+		slog.ErrorContext(
+			ctx,
+			"Failed to apply backup retention policy",
+			slog.Uint64("server_id", uint64(server.ID)),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+func (s *Service) archive(ctx context.Context, server *domain.Server, backup *domain.Backup) (int64, error) {
+	node, err := s.findNode(ctx, server.DSID)
+	if err != nil {
+		return 0, err
+	}
+
+	nodeArchivePath := nodeArchivePath(node, server, backup)
+
+	command, err := node.OS.TarCommand("-czf", nodeArchivePath, filepath.Join(node.WorkPath, server.Dir), ".")
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := s.daemonCommands.ExecuteCommand(ctx, node, command)
+	if err != nil {
+		return 0, errors.WithMessage(err, "failed to execute archive command")
+	}
+
+	defer s.removeNodeArchive(ctx, node, nodeArchivePath)
+
+	if result.ExitCode != 0 {
+		return 0, errors.Errorf("archive command exited with code %d: %s", result.ExitCode, result.Output)
+	}
+
+	stream, err := s.daemonFiles.DownloadStream(ctx, node, nodeArchivePath)
+	if err != nil {
+		return 0, errors.WithMessage(err, "failed to download archive from node")
+	}
+	defer func() {
+		if err := stream.Close(); err != nil {
+			slog.WarnContext(ctx, "Failed to close archive stream", slog.String("error", err.Error()))
+		}
+	}()
+
+	counter := &countingReader{r: stream}
+
+	if err = s.fileManager.WriteStream(ctx, backup.Path, counter); err != nil {
+		return 0, errors.WithMessage(err, "failed to write archive to storage")
+	}
+
+	return counter.n, nil
+}
+
+func (s *Service) restore(ctx context.Context, server *domain.Server, backup *domain.Backup) {
+	err := s.extract(ctx, server, backup)
+	if err != nil {
+		if errors.Is(context.Cause(ctx), ErrInterrupted) {
+			err = errors.WithMessage(ErrInterrupted, err.Error())
+		}
+
+		// The result is saved even if the operation has been canceled
+		ctx = context.WithoutCancel(ctx)
+
+		slog.ErrorContext(
+			ctx,
+			"Failed to restore server backup",
+			slog.Uint64("server_id", uint64(server.ID)),
+			slog.Uint64("backup_id", uint64(backup.ID)),
+			slog.String("error", err.Error()),
+		)
+
+		backup.Error = lo.ToPtr(truncateError(err))
+	} else {
+		slog.InfoContext(
+			ctx,
+			"Server backup has been restored",
+			slog.Uint64("server_id", uint64(server.ID)),
+			slog.Uint64("backup_id", uint64(backup.ID)),
+		)
+	}
+
+	// The archive is still valid after a failed restore, so the backup stays completed
+	backup.Status = domain.BackupStatusCompleted
+	s.save(ctx, backup)
+}
+
+func (s *Service) extract(ctx context.Context, server *domain.Server, backup *domain.Backup) error {
+	node, err := s.findNode(ctx, server.DSID)
+	if err != nil {
+		return err
+	}
+
+	if backup.Size < 0 {
+		return errors.New("invalid backup size")
+	}
+
+	stream, err := s.fileManager.ReadStream(ctx, backup.Path)
+	if err != nil {
+		return errors.WithMessage(err, "failed to read archive from storage")
+	}
+	defer func() {
+		if err := stream.Close(); err != nil {
+			slog.WarnContext(ctx, "Failed to close archive stream", slog.String("error", err.Error()))
+		}
+	}()
+
+	nodeArchivePath := nodeArchivePath(node, server, backup)
+
+	command, err := node.OS.TarCommand("-xzf", nodeArchivePath, filepath.Join(node.WorkPath, server.Dir))
+	if err != nil {
+		return err
+	}
+
+	err = s.daemonFiles.UploadStream(ctx, node, nodeArchivePath, stream, uint64(backup.Size), archivePerms)
+	if err != nil {
+		return errors.WithMessage(err, "failed to upload archive to node")
+	}
+
+	defer s.removeNodeArchive(ctx, node, nodeArchivePath)
+
+	result, err := s.daemonCommands.ExecuteCommand(ctx, node, command)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute extract command")
+	}
+
+	if result.ExitCode != 0 {
+		return errors.Errorf("extract command exited with code %d: %s", result.ExitCode, result.Output)
+	}
+
+	return nil
+}
+
+func (s *Service) applyRetention(ctx context.Context, server *domain.Server) error {
+	policy, err := s.retentionPolicy(ctx, server.ID)
+	if err != nil {
+		return err
+	}
+
+	if policy.KeepLast <= 0 && policy.MaxAge <= 0 {
+		return nil
+	}
+
+	backups, err := s.backupRepo.Find(ctx, &filters.FindBackup{
+		ServerIDs: []uint{server.ID},
+		Statuses:  []domain.BackupStatus{domain.BackupStatusCompleted},
+	}, nil, nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed to find backups")
+	}
+
+	for _, b := range policy.Expired(backups, time.Now()) {
+		if err = s.delete(ctx, &b); err != nil {
+			return errors.WithMessagef(err, "failed to delete expired backup %d", b.ID)
+		}
+
+		slog.InfoContext(
+			ctx,
+			"Expired server backup has been deleted",
+			slog.Uint64("server_id", uint64(server.ID)),
+			slog.Uint64("backup_id", uint64(b.ID)),
+		)
+	}
+
+	return nil
+}
+
+// retentionPolicy returns the default retention policy overridden by the server settings.
+func (s *Service) retentionPolicy(ctx context.Context, serverID uint) (domain.BackupRetentionPolicy, error) {
+	policy := s.defaultPolicy
+
+	settings, err := s.serverSettingRepo.Find(ctx, &filters.FindServerSetting{
+		ServerIDs: []uint{serverID},
+		Names:     []string{KeepLastSettingKey, MaxAgeDaysSettingKey},
+	}, nil, nil)
+	if err != nil {
+		return policy, errors.WithMessage(err, "failed to find server settings")
+	}
+
+	for _, setting := range settings {
+		value, ok := settingInt(setting.Value)
+		if !ok || value < 0 {
+			continue
+		}
+
+		switch setting.Name {
+		case KeepLastSettingKey:
+			policy.KeepLast = value
+		case MaxAgeDaysSettingKey:
+			policy.MaxAge = time.Duration(value) * 24 * time.Hour
+		}
+	}
+
+	return policy, nil
+}
+
+func (s *Service) delete(ctx context.Context, backup *domain.Backup) error {
+	if s.fileManager.Exists(ctx, backup.Path) {
+		if err := s.fileManager.Delete(ctx, backup.Path); err != nil {
+			return errors.WithMessage(err, "failed to delete archive from storage")
+		}
+	}
+
+	if err := s.backupRepo.Delete(ctx, backup.ID); err != nil {
+		return errors.WithMessage(err, "failed to delete backup")
+	}
+
+	return nil
+}
+
+func (s *Service) save(ctx context.Context, backup *domain.Backup) {
+	if err := s.backupRepo.Save(ctx, backup); err != nil {
+		slog.ErrorContext(
+			ctx,
+			"Failed to save backup",
+			slog.Uint64("backup_id", uint64(backup.ID)),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+func (s *Service) removeNodeArchive(ctx context.Context, node *domain.Node, archivePath string) {
+	if err := s.daemonFiles.Remove(ctx, node, archivePath, false); err != nil {
+		slog.WarnContext(
+			ctx,
+			"Failed to remove temporary backup archive from node",
+			slog.Uint64("node_id", uint64(node.ID)),
+			slog.String("path", archivePath),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+func (s *Service) findNode(ctx context.Context, nodeID uint) (*domain.Node, error) {
+	nodes, err := s.nodeRepo.Find(ctx, &filters.FindNode{
+		IDs: []uint{nodeID},
+	}, nil, &filters.Pagination{
+		Limit: 1,
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find node")
+	}
+
+	if len(nodes) == 0 {
+		return nil, errors.New("node not found")
+	}
+
+	return &nodes[0], nil
+}
+
+// storagePath returns the archive path in the panel file storage.
+func storagePath(server *domain.Server, now time.Time) string {
+	return path.Join(storageDir, server.UUID.String(), now.UTC().Format("20060102-150405")+archiveExtension)
+}
+
+// nodeArchivePath returns the temporary archive path on the node.
+// The archive is placed next to the server directories, so it is not included into itself.
+func nodeArchivePath(node *domain.Node, server *domain.Server, backup *domain.Backup) string {
+	name := ".backup-" + strconv.FormatUint(uint64(server.ID), 10) +
+		"-" + strconv.FormatUint(uint64(backup.ID), 10) + archiveExtension
+
+	return filepath.Join(node.WorkPath, name)
+}
+
+func settingInt(value domain.ServerSettingValue) (int, bool) {
+	str, ok := value.String()
+	if !ok {
+		return 0, false
+	}
+
+	i, err := strconv.Atoi(strings.TrimSpace(str))
+	if err != nil {
+		return 0, false
+	}
+
+	return i, true
+}
+
+func truncateError(err error) string {
+	msg := err.Error()
+	if len(msg) > maxErrorLength {
+		msg = msg[:maxErrorLength]
+	}
+
+	return msg
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+
+	return n, err
+}
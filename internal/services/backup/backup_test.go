@@ -0,0 +1,442 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/daemon"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/events"
+	"github.com/gameap/gameap/internal/files"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNode emulates tar and the daemon file API: "tar -czf" stores the archive content,
+// "tar -xzf" records the extracted archive.
+// If hang is set, the commands are reported to it and block until the context is canceled.
+type fakeNode struct {
+	mu        sync.Mutex
+	files     map[string][]byte
+	commands  []string
+	exitCode  int
+	extracted []byte
+	removed   []string
+	hang      chan string
+}
+
+func newFakeNode() *fakeNode {
+	return &fakeNode{files: make(map[string][]byte)}
+}
+
+func (n *fakeNode) ExecuteCommand(
+	ctx context.Context,
+	_ *domain.Node,
+	command string,
+	_ ...daemon.CommandServiceOption,
+) (*daemon.CommandResult, error) {
+	if n.hang != nil {
+		n.hang <- command
+		<-ctx.Done()
+
+		return nil, ctx.Err()
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.commands = append(n.commands, command)
+
+	if n.exitCode != 0 {
+		return &daemon.CommandResult{Output: "tar: error", ExitCode: n.exitCode}, nil
+	}
+
+	archive := strings.Trim(strings.Fields(command)[2], `'`)
+
+	switch {
+	case strings.HasPrefix(command, "tar -czf"):
+		n.files[archive] = []byte("archive content")
+	case strings.HasPrefix(command, "tar -xzf"):
+		n.extracted = n.files[archive]
+	}
+
+	return &daemon.CommandResult{}, nil
+}
+
+func (n *fakeNode) DownloadStream(_ context.Context, _ *domain.Node, filePath string) (io.ReadCloser, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	data, ok := n.files[filePath]
+	if !ok {
+		return nil, errors.New("file not found")
+	}
+
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (n *fakeNode) UploadStream(
+	_ context.Context,
+	_ *domain.Node,
+	filePath string,
+	r io.Reader,
+	size uint64,
+	_ os.FileMode,
+) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	if uint64(len(data)) != size {
+		return errors.New("size mismatch")
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.files[filePath] = data
+
+	return nil
+}
+
+func (n *fakeNode) Remove(_ context.Context, _ *domain.Node, path string, _ bool) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	delete(n.files, path)
+	n.removed = append(n.removed, path)
+
+	return nil
+}
+
+type testEnv struct {
+	service     *Service
+	backupRepo  *inmemory.BackupRepository
+	settingRepo *inmemory.ServerSettingRepository
+	node        *fakeNode
+	fileManager *files.InMemoryFileManager
+	server      *domain.Server
+
+	mu        sync.Mutex
+	published []domain.Event
+}
+
+func newTestEnv(t *testing.T, policy domain.BackupRetentionPolicy) *testEnv {
+	t.Helper()
+
+	nodeRepo := inmemory.NewNodeRepository()
+	node := &domain.Node{ID: 1, WorkPath: "/srv/gameap"}
+	require.NoError(t, nodeRepo.Save(context.Background(), node))
+
+	env := &testEnv{
+		backupRepo:  inmemory.NewBackupRepository(),
+		settingRepo: inmemory.NewServerSettingRepository(),
+		node:        newFakeNode(),
+		fileManager: files.NewInMemoryFileManager(),
+		server: &domain.Server{
+			ID:   1,
+			UUID: uuid.New(),
+			DSID: node.ID,
+			Dir:  "servers/cs",
+		},
+	}
+
+	bus := events.NewBus()
+	bus.Subscribe(events.HandlerFunc(func(_ context.Context, event domain.Event) error {
+		env.mu.Lock()
+		defer env.mu.Unlock()
+
+		env.published = append(env.published, event)
+
+		return nil
+	}))
+
+	env.service = NewService(
+		env.backupRepo,
+		env.settingRepo,
+		nodeRepo,
+		env.node,
+		env.node,
+		env.fileManager,
+		policy,
+		time.Minute,
+		bus,
+	)
+
+	return env
+}
+
+func (e *testEnv) findBackup(t *testing.T, id uint) domain.Backup {
+	t.Helper()
+
+	backups, err := e.backupRepo.Find(context.Background(), &filters.FindBackup{IDs: []uint{id}}, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+
+	return backups[0]
+}
+
+func TestService_Start(t *testing.T) {
+	env := newTestEnv(t, domain.BackupRetentionPolicy{})
+
+	backup, err := env.service.Start(context.Background(), env.server)
+	require.NoError(t, err)
+	assert.Equal(t, domain.BackupStatusCreating, backup.Status)
+	assert.True(t, strings.HasPrefix(backup.Path, "backups/"+env.server.UUID.String()+"/"))
+
+	env.service.Wait()
+
+	stored := env.findBackup(t, backup.ID)
+	assert.Equal(t, domain.BackupStatusCompleted, stored.Status)
+	assert.Equal(t, int64(len("archive content")), stored.Size)
+	assert.Nil(t, stored.Error)
+
+	data, err := env.fileManager.Read(context.Background(), backup.Path)
+	require.NoError(t, err)
+	assert.Equal(t, "archive content", string(data))
+
+	require.Len(t, env.node.commands, 1)
+	assert.Equal(t,
+		`tar -czf '/srv/gameap/.backup-1-1.tar.gz' -C '/srv/gameap/servers/cs' '.'`,
+		env.node.commands[0],
+	)
+	assert.Equal(t, []string{"/srv/gameap/.backup-1-1.tar.gz"}, env.node.removed)
+}
+
+func TestService_Start_ArchiveCommandFailed(t *testing.T) {
+	env := newTestEnv(t, domain.BackupRetentionPolicy{})
+	env.node.exitCode = 2
+
+	backup, err := env.service.Start(context.Background(), env.server)
+	require.NoError(t, err)
+
+	env.service.Wait()
+
+	stored := env.findBackup(t, backup.ID)
+	assert.Equal(t, domain.BackupStatusFailed, stored.Status)
+	require.NotNil(t, stored.Error)
+	assert.Contains(t, *stored.Error, "archive command exited with code 2")
+	assert.False(t, env.fileManager.Exists(context.Background(), backup.Path))
+
+	require.Len(t, env.published, 1)
+	assert.Equal(t, domain.EventTypeBackupFailed, env.published[0].Type)
+	data, ok := env.published[0].Data.(events.BackupFailedData)
+	require.True(t, ok)
+	assert.Equal(t, backup.ID, data.BackupID)
+	assert.Contains(t, data.Error, "archive command exited with code 2")
+}
+
+func TestService_Stop_InterruptsBackup(t *testing.T) {
+	env := newTestEnv(t, domain.BackupRetentionPolicy{})
+	env.node.hang = make(chan string, 1)
+
+	backup, err := env.service.Start(context.Background(), env.server)
+	require.NoError(t, err)
+
+	<-env.node.hang
+
+	stopped := make(chan struct{})
+	go func() {
+		env.service.Stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Stop didn't return")
+	}
+
+	stored := env.findBackup(t, backup.ID)
+	assert.Equal(t, domain.BackupStatusFailed, stored.Status)
+	require.NotNil(t, stored.Error)
+	assert.Contains(t, *stored.Error, ErrInterrupted.Error())
+	assert.False(t, env.service.isRunning(env.server.ID))
+
+	require.Len(t, env.published, 1)
+	assert.Equal(t, domain.EventTypeBackupFailed, env.published[0].Type)
+}
+
+func TestService_Start_OperationInProgress(t *testing.T) {
+	env := newTestEnv(t, domain.BackupRetentionPolicy{})
+
+	require.True(t, env.service.acquire(env.server.ID))
+
+	_, err := env.service.Start(context.Background(), env.server)
+	require.ErrorIs(t, err, ErrOperationInProgress)
+
+	env.service.release(env.server.ID)
+
+	_, err = env.service.Start(context.Background(), env.server)
+	require.NoError(t, err)
+
+	env.service.Wait()
+}
+
+func TestService_Start_AppliesRetention(t *testing.T) {
+	env := newTestEnv(t, domain.BackupRetentionPolicy{KeepLast: 5})
+	ctx := context.Background()
+
+	// Server setting overrides the default policy
+	require.NoError(t, env.settingRepo.Save(ctx, &domain.ServerSetting{
+		ServerID: env.server.ID,
+		Name:     KeepLastSettingKey,
+		Value:    domain.NewServerSettingValue("2"),
+	}))
+
+	old := make([]domain.Backup, 0, 2)
+	for i := range 2 {
+		b := domain.Backup{
+			ServerID:  env.server.ID,
+			Status:    domain.BackupStatusCompleted,
+			Path:      "backups/old-" + string(rune('a'+i)) + ".tar.gz",
+			CreatedAt: lo.ToPtr(time.Now().Add(-time.Duration(2-i) * time.Hour)),
+		}
+		require.NoError(t, env.backupRepo.Save(ctx, &b))
+		require.NoError(t, env.fileManager.Write(ctx, b.Path, []byte("old")))
+		old = append(old, b)
+	}
+
+	backup, err := env.service.Start(ctx, env.server)
+	require.NoError(t, err)
+
+	env.service.Wait()
+
+	backups, err := env.backupRepo.Find(ctx, filters.FindBackupByServerIDs(env.server.ID), nil, nil)
+	require.NoError(t, err)
+	ids := lo.Map(backups, func(b domain.Backup, _ int) uint { return b.ID })
+	assert.ElementsMatch(t, []uint{old[1].ID, backup.ID}, ids)
+	assert.False(t, env.fileManager.Exists(ctx, old[0].Path))
+	assert.True(t, env.fileManager.Exists(ctx, old[1].Path))
+}
+
+func TestService_Restore(t *testing.T) {
+	env := newTestEnv(t, domain.BackupRetentionPolicy{})
+	ctx := context.Background()
+
+	backup := &domain.Backup{
+		ServerID: env.server.ID,
+		Status:   domain.BackupStatusCompleted,
+		Path:     "backups/server.tar.gz",
+		Size:     int64(len("stored archive")),
+		Error:    lo.ToPtr("previous restore failed"),
+	}
+	require.NoError(t, env.backupRepo.Save(ctx, backup))
+	require.NoError(t, env.fileManager.Write(ctx, backup.Path, []byte("stored archive")))
+
+	restoring, err := env.service.Restore(ctx, env.server, backup)
+	require.NoError(t, err)
+	assert.Equal(t, domain.BackupStatusRestoring, restoring.Status)
+
+	env.service.Wait()
+
+	stored := env.findBackup(t, backup.ID)
+	assert.Equal(t, domain.BackupStatusCompleted, stored.Status)
+	assert.Nil(t, stored.Error)
+	assert.Equal(t, "stored archive", string(env.node.extracted))
+
+	archivePath := "/srv/gameap/.backup-1-1.tar.gz"
+	assert.Equal(t, []string{`tar -xzf '` + archivePath + `' -C '/srv/gameap/servers/cs'`}, env.node.commands)
+	assert.Equal(t, []string{archivePath}, env.node.removed)
+}
+
+func TestService_Restore_Failed(t *testing.T) {
+	env := newTestEnv(t, domain.BackupRetentionPolicy{})
+	ctx := context.Background()
+
+	backup := &domain.Backup{
+		ServerID: env.server.ID,
+		Status:   domain.BackupStatusCompleted,
+		Path:     "backups/missing.tar.gz",
+	}
+	require.NoError(t, env.backupRepo.Save(ctx, backup))
+
+	_, err := env.service.Restore(ctx, env.server, backup)
+	require.NoError(t, err)
+
+	env.service.Wait()
+
+	stored := env.findBackup(t, backup.ID)
+	assert.Equal(t, domain.BackupStatusCompleted, stored.Status)
+	require.NotNil(t, stored.Error)
+	assert.Contains(t, *stored.Error, "failed to read archive from storage")
+}
+
+func TestService_Restore_Validation(t *testing.T) {
+	env := newTestEnv(t, domain.BackupRetentionPolicy{})
+	ctx := context.Background()
+
+	t.Run("backup_of_another_server", func(t *testing.T) {
+		_, err := env.service.Restore(ctx, env.server, &domain.Backup{
+			ID:       10,
+			ServerID: 2,
+			Status:   domain.BackupStatusCompleted,
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "backup not found")
+	})
+
+	t.Run("backup_not_completed", func(t *testing.T) {
+		_, err := env.service.Restore(ctx, env.server, &domain.Backup{
+			ID:       11,
+			ServerID: env.server.ID,
+			Status:   domain.BackupStatusFailed,
+		})
+		require.ErrorIs(t, err, ErrBackupNotCompleted)
+	})
+}
+
+func TestService_Delete(t *testing.T) {
+	env := newTestEnv(t, domain.BackupRetentionPolicy{})
+	ctx := context.Background()
+
+	backup := &domain.Backup{
+		ServerID: env.server.ID,
+		Status:   domain.BackupStatusCompleted,
+		Path:     "backups/server.tar.gz",
+	}
+	require.NoError(t, env.backupRepo.Save(ctx, backup))
+	require.NoError(t, env.fileManager.Write(ctx, backup.Path, []byte("archive")))
+
+	require.NoError(t, env.service.Delete(ctx, backup))
+
+	backups, err := env.backupRepo.Find(ctx, &filters.FindBackup{IDs: []uint{backup.ID}}, nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, backups)
+	assert.False(t, env.fileManager.Exists(ctx, backup.Path))
+
+	t.Run("in_progress", func(t *testing.T) {
+		require.True(t, env.service.acquire(env.server.ID))
+		defer env.service.release(env.server.ID)
+
+		err := env.service.Delete(ctx, &domain.Backup{
+			ID:       5,
+			ServerID: env.server.ID,
+			Status:   domain.BackupStatusCreating,
+		})
+		require.ErrorIs(t, err, ErrOperationInProgress)
+	})
+
+	t.Run("interrupted", func(t *testing.T) {
+		interrupted := &domain.Backup{
+			ServerID: env.server.ID,
+			Status:   domain.BackupStatusCreating,
+			Path:     "backups/interrupted.tar.gz",
+		}
+		require.NoError(t, env.backupRepo.Save(ctx, interrupted))
+
+		require.NoError(t, env.service.Delete(ctx, interrupted))
+	})
+}
@@ -0,0 +1,130 @@
+package daemontaskoutput
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/pubsub"
+	"github.com/pkg/errors"
+)
+
+const topicPrefix = "daemon_task_output:"
+
+// Event is published when a daemon sends a new chunk of the task output or changes the task status.
+type Event struct {
+	// Offset is the position of the output chunk in the whole task output in bytes.
+	Offset int                     `json:"offset"`
+	Output string                  `json:"output,omitempty"`
+	Status domain.DaemonTaskStatus `json:"status,omitempty"`
+}
+
+// End returns the position in the whole task output right after the chunk.
+func (e Event) End() int {
+	return e.Offset + len(e.Output)
+}
+
+// Broadcaster fans out daemon task output to all panel instances.
+type Broadcaster struct {
+	pubSub pubsub.PubSub
+}
+
+func NewBroadcaster(pubSub pubsub.PubSub) *Broadcaster {
+	return &Broadcaster{
+		pubSub: pubSub,
+	}
+}
+
+// PublishOutput publishes the output chunk appended to the task.
+// The length is the whole task output length in bytes after appending the chunk.
+func (b *Broadcaster) PublishOutput(ctx context.Context, taskID uint, length int, output string) error {
+	return b.publish(ctx, taskID, Event{
+		Offset: length - len(output),
+		Output: output,
+	})
+}
+
+// PublishStatus publishes the new task status.
+func (b *Broadcaster) PublishStatus(ctx context.Context, taskID uint, status domain.DaemonTaskStatus) error {
+	return b.publish(ctx, taskID, Event{
+		Status: status,
+	})
+}
+
+func (b *Broadcaster) publish(ctx context.Context, taskID uint, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return errors.WithMessage(err, "failed to marshal daemon task output event")
+	}
+
+	err = b.pubSub.Publish(ctx, topic(taskID), payload)
+	if err != nil {
+		return errors.WithMessage(err, "failed to publish daemon task output event")
+	}
+
+	return nil
+}
+
+// Subscribe starts receiving events of the task.
+func (b *Broadcaster) Subscribe(ctx context.Context, taskID uint) (*Subscription, error) {
+	sub, err := b.pubSub.Subscribe(ctx, topic(taskID))
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to subscribe to daemon task output")
+	}
+
+	s := &Subscription{
+		sub:    sub,
+		events: make(chan Event),
+		done:   make(chan struct{}),
+	}
+
+	go s.run()
+
+	return s, nil
+}
+
+// Subscription receives events of a single daemon task.
+type Subscription struct {
+	sub    pubsub.Subscription
+	events chan Event
+	done   chan struct{}
+
+	closeOnce sync.Once
+}
+
+// Events returns the channel of task events. The channel is closed by Close.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Close unsubscribes from the task events. It is safe to call Close several times.
+func (s *Subscription) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+
+	return s.sub.Close()
+}
+
+func (s *Subscription) run() {
+	defer close(s.events)
+
+	for payload := range s.sub.Messages() {
+		var event Event
+		if err := json.Unmarshal(payload, &event); err != nil {
+			continue
+		}
+
+		select {
+		case s.events <- event:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func topic(taskID uint) string {
+	return topicPrefix + strconv.FormatUint(uint64(taskID), 10)
+}
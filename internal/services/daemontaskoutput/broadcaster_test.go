@@ -0,0 +1,77 @@
+package daemontaskoutput
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/pubsub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func nextEvent(t *testing.T, sub *Subscription) Event {
+	t.Helper()
+
+	select {
+	case event, ok := <-sub.Events():
+		require.True(t, ok, "events channel is closed")
+
+		return event
+	case <-time.After(time.Second):
+		require.Fail(t, "timeout waiting for daemon task output event")
+	}
+
+	return Event{}
+}
+
+func TestBroadcaster_PublishAndSubscribe(t *testing.T) {
+	ctx := context.Background()
+	broadcaster := NewBroadcaster(pubsub.NewInMemory())
+
+	sub, err := broadcaster.Subscribe(ctx, 1)
+	require.NoError(t, err)
+	defer sub.Close()
+
+	other, err := broadcaster.Subscribe(ctx, 2)
+	require.NoError(t, err)
+	defer other.Close()
+
+	require.NoError(t, broadcaster.PublishOutput(ctx, 1, 12, "line 2\n"))
+	require.NoError(t, broadcaster.PublishStatus(ctx, 1, domain.DaemonTaskStatusSuccess))
+
+	event := nextEvent(t, sub)
+	assert.Equal(t, Event{Offset: 5, Output: "line 2\n"}, event)
+	assert.Equal(t, 12, event.End())
+
+	assert.Equal(t, Event{Status: domain.DaemonTaskStatusSuccess}, nextEvent(t, sub))
+
+	select {
+	case event := <-other.Events():
+		assert.Fail(t, "unexpected event for other task", event)
+	default:
+	}
+}
+
+func TestSubscription_Close(t *testing.T) {
+	ctx := context.Background()
+	broadcaster := NewBroadcaster(pubsub.NewInMemory())
+
+	sub, err := broadcaster.Subscribe(ctx, 1)
+	require.NoError(t, err)
+
+	require.NoError(t, broadcaster.PublishOutput(ctx, 1, 4, "test"))
+	require.NoError(t, sub.Close())
+	require.NoError(t, sub.Close())
+
+	select {
+	case <-time.After(time.Second):
+		require.Fail(t, "timeout waiting for events channel to be closed")
+	case _, ok := <-sub.Events():
+		if ok {
+			_, ok = <-sub.Events()
+		}
+		assert.False(t, ok)
+	}
+}
@@ -48,7 +48,20 @@ func (s *GameUpgradeService) UpgradeGames(ctx context.Context) error {
 		for _, apiGame := range apiGames {
 			game := apiGame.ToDomainGame()
 
-			err := s.gameRepo.Save(ctx, game)
+			existing, err := s.gameRepo.Find(ctx, &filters.FindGame{
+				Codes: []string{game.Code},
+			}, nil, nil)
+			if err != nil {
+				return errors.WithMessage(err, "failed to find game")
+			}
+
+			// Port layout is configured in the panel, it is not provided by the global API
+			if len(existing) > 0 {
+				game.QueryPortOffset = existing[0].QueryPortOffset
+				game.RconPortOffset = existing[0].RconPortOffset
+			}
+
+			err = s.gameRepo.Save(ctx, game)
 			if err != nil {
 				return errors.WithMessage(err, "failed to save game")
 			}
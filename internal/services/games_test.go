@@ -319,3 +319,43 @@ func TestGameUpgradeService_UpgradeGames(t *testing.T) {
 		})
 	}
 }
+
+func TestGameUpgradeService_UpgradeGames_KeepsPortLayout(t *testing.T) {
+	ctx := context.Background()
+	gameRepo := inmemory.NewGameRepository()
+	gameModRepo := inmemory.NewGameModRepository()
+
+	require.NoError(t, gameRepo.Save(ctx, &domain.Game{
+		Code:            "cstrike",
+		Name:            "Counter-Strike",
+		Engine:          "GoldSource",
+		QueryPortOffset: 1,
+		RconPortOffset:  2,
+	}))
+
+	service := NewGameUpgradeService(
+		&mockGlobalAPIService{
+			games: []domain.GlobalAPIGame{
+				{
+					Code:   "cstrike",
+					Name:   "Counter-Strike 1.6",
+					Engine: "GoldSource",
+				},
+			},
+		},
+		gameRepo,
+		gameModRepo,
+		NewNilTransactionManager(),
+	)
+
+	require.NoError(t, service.UpgradeGames(ctx))
+
+	games, err := gameRepo.Find(ctx, &filters.FindGame{
+		Codes: []string{"cstrike"},
+	}, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, games, 1)
+	assert.Equal(t, "Counter-Strike 1.6", games[0].Name)
+	assert.Equal(t, 1, games[0].QueryPortOffset)
+	assert.Equal(t, 2, games[0].RconPortOffset)
+}
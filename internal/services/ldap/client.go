@@ -0,0 +1,198 @@
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gameap/gameap/internal/services/authenticator"
+	goldap "github.com/go-ldap/ldap/v3"
+	"github.com/pkg/errors"
+)
+
+const (
+	loginPlaceholder = "{login}"
+
+	// searchSizeLimit is enough to detect a filter matching several entries.
+	searchSizeLimit = 2
+)
+
+var ErrEntryNotFound = errors.New("directory entry is not found")
+
+type ClientConfig struct {
+	URL                string
+	StartTLS           bool
+	InsecureSkipVerify bool
+	BindDN             string
+	BindPassword       string
+	BaseDN             string
+	UserFilter         string
+	LoginAttribute     string
+	EmailAttribute     string
+	NameAttribute      string
+	GroupAttribute     string
+	Timeout            time.Duration
+}
+
+// Client searches and binds the users in an LDAP directory or Active Directory.
+// It opens a new connection for each operation.
+type Client struct {
+	cfg ClientConfig
+}
+
+func NewClient(cfg ClientConfig) *Client {
+	return &Client{
+		cfg: cfg,
+	}
+}
+
+// Authenticate finds the user entry with the service account and binds as the user with the password.
+// It returns authenticator.ErrInvalidCredentials if the entry isn't found or the password is rejected.
+func (c *Client) Authenticate(ctx context.Context, login, password string) (*Entry, error) {
+	conn, closeConn, err := c.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer closeConn()
+
+	entry, err := c.search(conn, login)
+	if errors.Is(err, ErrEntryNotFound) {
+		return nil, authenticator.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = conn.Bind(entry.DN, password)
+	if goldap.IsErrorWithCode(err, goldap.LDAPResultInvalidCredentials) {
+		return nil, authenticator.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to bind as user")
+	}
+
+	return entry, nil
+}
+
+// FindUsers returns the entries of the users with the logins, the users missing in the directory are skipped.
+func (c *Client) FindUsers(ctx context.Context, logins []string) ([]Entry, error) {
+	conn, closeConn, err := c.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer closeConn()
+
+	entries := make([]Entry, 0, len(logins))
+
+	for _, login := range logins {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		entry, err := c.search(conn, login)
+		if errors.Is(err, ErrEntryNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, *entry)
+	}
+
+	return entries, nil
+}
+
+// connect dials the directory and binds as the service account.
+// The connection is closed by the returned function or when the context is canceled.
+func (c *Client) connect(ctx context.Context) (*goldap.Conn, func(), error) {
+	u, err := url.Parse(c.cfg.URL)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "invalid directory url")
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName:         u.Hostname(),
+		InsecureSkipVerify: c.cfg.InsecureSkipVerify, //nolint:gosec
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	conn, err := goldap.DialURL(
+		c.cfg.URL,
+		goldap.DialWithDialer(&net.Dialer{Timeout: c.cfg.Timeout}),
+		goldap.DialWithTLSConfig(tlsConfig),
+	)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "failed to connect to directory")
+	}
+
+	conn.SetTimeout(c.cfg.Timeout)
+
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.Close()
+	})
+
+	closeConn := func() {
+		stop()
+		_ = conn.Close()
+	}
+
+	if c.cfg.StartTLS {
+		if err = conn.StartTLS(tlsConfig); err != nil {
+			closeConn()
+
+			return nil, nil, errors.WithMessage(err, "failed to start tls")
+		}
+	}
+
+	if c.cfg.BindDN == "" {
+		err = conn.UnauthenticatedBind("")
+	} else {
+		err = conn.Bind(c.cfg.BindDN, c.cfg.BindPassword)
+	}
+	if err != nil {
+		closeConn()
+
+		return nil, nil, errors.WithMessage(err, "failed to bind as service account")
+	}
+
+	return conn, closeConn, nil
+}
+
+// search finds the single user entry with the login.
+func (c *Client) search(conn *goldap.Conn, login string) (*Entry, error) {
+	filter := strings.ReplaceAll(c.cfg.UserFilter, loginPlaceholder, goldap.EscapeFilter(login))
+
+	result, err := conn.Search(goldap.NewSearchRequest(
+		c.cfg.BaseDN,
+		goldap.ScopeWholeSubtree,
+		goldap.NeverDerefAliases,
+		searchSizeLimit,
+		int(c.cfg.Timeout.Seconds()),
+		false,
+		filter,
+		[]string{c.cfg.LoginAttribute, c.cfg.EmailAttribute, c.cfg.NameAttribute, c.cfg.GroupAttribute},
+		nil,
+	))
+	if err != nil && !goldap.IsErrorWithCode(err, goldap.LDAPResultSizeLimitExceeded) {
+		return nil, errors.WithMessage(err, "failed to search user")
+	}
+
+	// An ambiguous filter must not let the user log in as another entry
+	if result == nil || len(result.Entries) != 1 {
+		return nil, ErrEntryNotFound
+	}
+
+	entry := result.Entries[0]
+
+	return &Entry{
+		DN:     entry.DN,
+		Login:  entry.GetAttributeValue(c.cfg.LoginAttribute),
+		Email:  entry.GetAttributeValue(c.cfg.EmailAttribute),
+		Name:   entry.GetAttributeValue(c.cfg.NameAttribute),
+		Groups: entry.GetAttributeValues(c.cfg.GroupAttribute),
+	}, nil
+}
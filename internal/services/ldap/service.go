@@ -0,0 +1,333 @@
+// Package ldap authenticates the users with the bind to an LDAP directory or Active Directory.
+package ldap
+
+import (
+	"context"
+	"crypto/rand"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/repositories/base"
+	"github.com/gameap/gameap/internal/services/authenticator"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+// IdentityProvider is the provider of the user identities linking the directory entries to the users.
+const IdentityProvider = "ldap"
+
+var (
+	ErrLoginRequired = errors.New("directory entry has no login")
+	ErrEmailRequired = errors.New("directory entry has no email")
+	ErrNotLinked     = errors.New("directory entry is not linked to a user")
+)
+
+// Entry is a user entry of the directory.
+type Entry struct {
+	DN     string
+	Login  string
+	Email  string
+	Name   string
+	Groups []string
+}
+
+type Directory interface {
+	// Authenticate checks the password of the user with the login.
+	// It returns authenticator.ErrInvalidCredentials if the user isn't found or the password is rejected.
+	Authenticate(ctx context.Context, login, password string) (*Entry, error)
+	// FindUsers returns the entries of the users with the logins, the users missing in the directory are skipped.
+	FindUsers(ctx context.Context, logins []string) ([]Entry, error)
+}
+
+type rbac interface {
+	SetRolesToUser(ctx context.Context, userID uint, roleNames []string) error
+}
+
+type Config struct {
+	// RoleMapping maps the group DNs to the panel roles, the DNs are compared case-insensitively.
+	// The roles of the user are synced on each login if it isn't empty.
+	RoleMapping map[string]string
+	// DefaultRoles are assigned to provisioned users and to users without mapped groups.
+	DefaultRoles []string
+	// LinkExistingUsers allows to link an entry on the first login to the user with the same login.
+	LinkExistingUsers bool
+	// LinkByEmail allows to link an entry on the first login to the user with the same login and email.
+	// It must be enabled only if the emails in the directory are verified.
+	LinkByEmail bool
+}
+
+// Service is the authenticator checking the passwords in the directory.
+//
+// Entries are linked to users by the login attribute. On the first login a new user is provisioned.
+// An existing user with the same login is linked only if the config allows it, otherwise the login is refused,
+// so a directory entry can't take over a local account. The email and the name of the user
+// are updated from the directory on each login.
+type Service struct {
+	directory    Directory
+	identityRepo repositories.UserIdentityRepository
+	userRepo     repositories.UserRepository
+	rbac         rbac
+	tm           base.TransactionManager
+	cfg          Config
+
+	now func() time.Time
+}
+
+func NewService(
+	directory Directory,
+	identityRepo repositories.UserIdentityRepository,
+	userRepo repositories.UserRepository,
+	rbac rbac,
+	tm base.TransactionManager,
+	cfg Config,
+) *Service {
+	cfg.RoleMapping = lo.MapKeys(cfg.RoleMapping, func(_ string, group string) string {
+		return strings.ToLower(group)
+	})
+
+	return &Service{
+		directory:    directory,
+		identityRepo: identityRepo,
+		userRepo:     userRepo,
+		rbac:         rbac,
+		tm:           tm,
+		cfg:          cfg,
+		now:          time.Now,
+	}
+}
+
+func (s *Service) Authenticate(ctx context.Context, credentials authenticator.Credentials) (*domain.User, error) {
+	// Binds with empty passwords are unauthenticated, directories accept them for any DN
+	if credentials.Password == "" {
+		return nil, authenticator.ErrInvalidCredentials
+	}
+
+	login := credentials.Login
+	if login == "" {
+		login = credentials.Email
+	}
+
+	entry, err := s.directory.Authenticate(ctx, login, credentials.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	var user *domain.User
+
+	err = s.tm.Do(ctx, func(ctx context.Context) error {
+		var created bool
+
+		user, created, err = s.resolveUser(ctx, entry)
+		if err != nil {
+			return err
+		}
+
+		return s.syncRoles(ctx, user.ID, entry, created)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// resolveUser finds the user linked to the entry, links the user with the same login if it's allowed
+// or provisions a new user. The created result is set for provisioned users.
+func (s *Service) resolveUser(ctx context.Context, entry *Entry) (*domain.User, bool, error) {
+	subject := strings.ToLower(strings.TrimSpace(entry.Login))
+	if subject == "" {
+		return nil, false, errors.Wrap(ErrLoginRequired, entry.DN)
+	}
+
+	identities, err := s.identityRepo.Find(ctx, &filters.FindUserIdentity{
+		Providers: []string{IdentityProvider},
+		Subjects:  []string{subject},
+	}, nil, &filters.Pagination{Limit: 1})
+	if err != nil {
+		return nil, false, errors.WithMessage(err, "failed to find user identity")
+	}
+
+	var user *domain.User
+
+	if len(identities) > 0 {
+		// The linked user may have been deleted, the entry is linked again in this case
+		user, err = s.findUser(ctx, &filters.FindUser{IDs: []uint{identities[0].UserID}})
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	if user == nil {
+		user, err = s.findUser(ctx, &filters.FindUser{Logins: []string{subject}})
+		if err != nil {
+			return nil, false, err
+		}
+
+		if user != nil && !s.canLink(user, entry) {
+			return nil, false, errors.Wrap(ErrNotLinked, "user with the same login exists")
+		}
+	}
+
+	created := user == nil
+
+	if created {
+		user, err = s.provisionUser(ctx, subject, entry)
+	} else {
+		err = s.updateUser(ctx, user, entry)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	now := s.now()
+
+	identity := &domain.UserIdentity{
+		Provider:  IdentityProvider,
+		Subject:   subject,
+		UserID:    user.ID,
+		Email:     entry.Email,
+		CreatedAt: &now,
+		UpdatedAt: &now,
+	}
+
+	if err = s.identityRepo.Save(ctx, identity); err != nil {
+		return nil, false, errors.WithMessage(err, "failed to save user identity")
+	}
+
+	return user, created, nil
+}
+
+// canLink reports whether the entry can be linked to the existing user with the same login.
+func (s *Service) canLink(user *domain.User, entry *Entry) bool {
+	if s.cfg.LinkExistingUsers {
+		return true
+	}
+
+	email := strings.TrimSpace(entry.Email)
+
+	return s.cfg.LinkByEmail && email != "" && strings.EqualFold(email, strings.TrimSpace(user.Email))
+}
+
+func (s *Service) provisionUser(ctx context.Context, login string, entry *Entry) (*domain.User, error) {
+	email := strings.TrimSpace(entry.Email)
+	if email == "" {
+		return nil, errors.Wrap(ErrEmailRequired, entry.DN)
+	}
+
+	// The user logs in with the directory password, the random password is only a placeholder
+	hashedPassword, err := auth.HashPassword(rand.Text())
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to hash password")
+	}
+
+	now := s.now()
+
+	user := &domain.User{
+		Login:     login,
+		Email:     email,
+		Password:  hashedPassword,
+		CreatedAt: &now,
+		UpdatedAt: &now,
+	}
+
+	if name := strings.TrimSpace(entry.Name); name != "" {
+		user.Name = lo.ToPtr(name)
+	}
+
+	if err = s.userRepo.Save(ctx, user); err != nil {
+		return nil, errors.WithMessage(err, "failed to save user")
+	}
+
+	return user, nil
+}
+
+// updateUser copies the email and the name from the directory and enables the user
+// disabled while it was missing in the directory.
+func (s *Service) updateUser(ctx context.Context, user *domain.User, entry *Entry) error {
+	changed := false
+
+	if email := strings.TrimSpace(entry.Email); email != "" && email != user.Email {
+		user.Email = email
+		changed = true
+	}
+
+	if name := strings.TrimSpace(entry.Name); name != "" && name != lo.FromPtr(user.Name) {
+		user.Name = lo.ToPtr(name)
+		changed = true
+	}
+
+	if user.Disabled() {
+		user.DisabledAt = nil
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	user.UpdatedAt = lo.ToPtr(s.now())
+
+	if err := s.userRepo.Save(ctx, user); err != nil {
+		return errors.WithMessage(err, "failed to save user")
+	}
+
+	return nil
+}
+
+// syncRoles assigns the mapped roles on each login if the role mapping is configured,
+// otherwise the default roles are assigned only to provisioned users.
+func (s *Service) syncRoles(ctx context.Context, userID uint, entry *Entry, created bool) error {
+	var roles []string
+
+	if len(s.cfg.RoleMapping) > 0 {
+		roles = mapRoles(entry.Groups, s.cfg.RoleMapping)
+	} else if !created {
+		return nil
+	}
+
+	if len(roles) == 0 {
+		roles = s.cfg.DefaultRoles
+	}
+
+	if len(roles) == 0 && created {
+		return nil
+	}
+
+	if err := s.rbac.SetRolesToUser(ctx, userID, roles); err != nil {
+		return errors.WithMessage(err, "failed to set user roles")
+	}
+
+	return nil
+}
+
+func (s *Service) findUser(ctx context.Context, filter *filters.FindUser) (*domain.User, error) {
+	users, err := s.userRepo.Find(ctx, filter, nil, &filters.Pagination{Limit: 1})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find user")
+	}
+
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	return &users[0], nil
+}
+
+func mapRoles(groups []string, mapping map[string]string) []string {
+	roles := make([]string, 0, len(groups))
+
+	for _, group := range groups {
+		if role, ok := mapping[strings.ToLower(group)]; ok && !slices.Contains(roles, role) {
+			roles = append(roles, role)
+		}
+	}
+
+	slices.Sort(roles)
+
+	return roles
+}
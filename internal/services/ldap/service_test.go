@@ -0,0 +1,339 @@
+package ldap
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/authenticator"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeEntry struct {
+	Entry
+
+	password string
+}
+
+// fakeDirectory is a directory with the entries keyed by the login.
+type fakeDirectory struct {
+	entries map[string]fakeEntry
+	err     error
+}
+
+func (f *fakeDirectory) Authenticate(_ context.Context, login, password string) (*Entry, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	entry, ok := f.entries[login]
+	if !ok || entry.password != password {
+		return nil, authenticator.ErrInvalidCredentials
+	}
+
+	return &entry.Entry, nil
+}
+
+func (f *fakeDirectory) FindUsers(_ context.Context, logins []string) ([]Entry, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	entries := make([]Entry, 0, len(logins))
+
+	for _, login := range logins {
+		if entry, ok := f.entries[login]; ok {
+			entries = append(entries, entry.Entry)
+		}
+	}
+
+	return entries, nil
+}
+
+type fakeRBAC struct {
+	roles map[uint][]string
+}
+
+func (f *fakeRBAC) SetRolesToUser(_ context.Context, userID uint, roleNames []string) error {
+	f.roles[userID] = roleNames
+
+	return nil
+}
+
+type testEnv struct {
+	service      *Service
+	directory    *fakeDirectory
+	identityRepo *inmemory.UserIdentityRepository
+	userRepo     *inmemory.UserRepository
+	rbac         *fakeRBAC
+}
+
+func setup(t *testing.T, cfg Config) *testEnv {
+	t.Helper()
+
+	env := &testEnv{
+		directory: &fakeDirectory{entries: map[string]fakeEntry{
+			"john": {
+				Entry: Entry{
+					DN:     "uid=john,ou=people,dc=example,dc=com",
+					Login:  "John",
+					Email:  "john@example.com",
+					Name:   "John Doe",
+					Groups: []string{"CN=GameAP-Admins,OU=Groups,DC=example,DC=com"},
+				},
+				password: "secret",
+			},
+		}},
+		identityRepo: inmemory.NewUserIdentityRepository(),
+		userRepo:     inmemory.NewUserRepository(),
+		rbac:         &fakeRBAC{roles: map[uint][]string{}},
+	}
+
+	env.service = NewService(
+		env.directory,
+		env.identityRepo,
+		services.NewUserService(env.userRepo),
+		env.rbac,
+		services.NewNilTransactionManager(),
+		cfg,
+	)
+
+	return env
+}
+
+func TestService_Authenticate_Provision(t *testing.T) {
+	env := setup(t, Config{DefaultRoles: []string{"user"}})
+
+	user, err := env.service.Authenticate(context.Background(), authenticator.Credentials{
+		Login:    "john",
+		Password: "secret",
+	})
+	require.NoError(t, err)
+	require.NotZero(t, user.ID)
+	assert.Equal(t, "john", user.Login)
+	assert.Equal(t, "john@example.com", user.Email)
+	assert.Equal(t, "John Doe", lo.FromPtr(user.Name))
+	assert.Error(t, auth.VerifyPassword(user.Password, "secret"), "directory password must not be stored")
+	assert.Equal(t, []string{"user"}, env.rbac.roles[user.ID])
+
+	identities, err := env.identityRepo.Find(context.Background(), &filters.FindUserIdentity{
+		Providers: []string{IdentityProvider},
+	}, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, identities, 1)
+	assert.Equal(t, "john", identities[0].Subject)
+	assert.Equal(t, user.ID, identities[0].UserID)
+
+	// The next login uses the linked user
+	again, err := env.service.Authenticate(context.Background(), authenticator.Credentials{
+		Login:    "john",
+		Password: "secret",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, again.ID)
+}
+
+func TestService_Authenticate_InvalidCredentials(t *testing.T) {
+	env := setup(t, Config{})
+
+	tests := []struct {
+		name        string
+		credentials authenticator.Credentials
+	}{
+		{
+			name:        "wrong_password",
+			credentials: authenticator.Credentials{Login: "john", Password: "wrong"},
+		},
+		{
+			name:        "unknown_user",
+			credentials: authenticator.Credentials{Login: "jane", Password: "secret"},
+		},
+		{
+			name:        "empty_password",
+			credentials: authenticator.Credentials{Login: "john"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := env.service.Authenticate(context.Background(), tt.credentials)
+			require.ErrorIs(t, err, authenticator.ErrInvalidCredentials)
+		})
+	}
+
+	users, err := env.userRepo.FindAll(context.Background(), nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, users)
+}
+
+func TestService_Authenticate_DirectoryError(t *testing.T) {
+	env := setup(t, Config{})
+	env.directory.err = errors.New("connection refused")
+
+	_, err := env.service.Authenticate(context.Background(), authenticator.Credentials{
+		Login:    "john",
+		Password: "secret",
+	})
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, authenticator.ErrInvalidCredentials)
+}
+
+func TestService_Authenticate_LinksExistingUser(t *testing.T) {
+	env := setup(t, Config{DefaultRoles: []string{"user"}, LinkExistingUsers: true})
+
+	disabledAt := time.Now().Add(-time.Hour)
+	existing := &domain.User{
+		Login:      "john",
+		Email:      "old@example.com",
+		Password:   "hash",
+		DisabledAt: &disabledAt,
+	}
+	require.NoError(t, env.userRepo.Save(context.Background(), existing))
+
+	user, err := env.service.Authenticate(context.Background(), authenticator.Credentials{
+		Login:    "john",
+		Password: "secret",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, existing.ID, user.ID)
+	assert.False(t, user.Disabled(), "user found in the directory must be enabled")
+
+	saved, err := env.userRepo.Find(context.Background(), &filters.FindUser{IDs: []uint{existing.ID}}, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+	assert.Equal(t, "john@example.com", saved[0].Email)
+	assert.Equal(t, "John Doe", lo.FromPtr(saved[0].Name))
+	assert.Nil(t, saved[0].DisabledAt)
+	assert.Equal(t, "hash", saved[0].Password)
+
+	// Default roles are assigned only to provisioned users without the role mapping
+	assert.NotContains(t, env.rbac.roles, existing.ID)
+}
+
+func TestService_Authenticate_ExistingUser(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       Config
+		email     string
+		wantError error
+	}{
+		{
+			name:      "linking_not_allowed",
+			email:     "john@example.com",
+			wantError: ErrNotLinked,
+		},
+		{
+			name:  "same_email",
+			cfg:   Config{LinkByEmail: true},
+			email: "John@Example.com",
+		},
+		{
+			name:      "other_email",
+			cfg:       Config{LinkByEmail: true},
+			email:     "other@example.com",
+			wantError: ErrNotLinked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := setup(t, tt.cfg)
+
+			existing := &domain.User{
+				Login:    "john",
+				Email:    tt.email,
+				Password: "hash",
+			}
+			require.NoError(t, env.userRepo.Save(context.Background(), existing))
+
+			user, err := env.service.Authenticate(context.Background(), authenticator.Credentials{
+				Login:    "john",
+				Password: "secret",
+			})
+
+			identities, findErr := env.identityRepo.Find(context.Background(), &filters.FindUserIdentity{
+				Providers: []string{IdentityProvider},
+			}, nil, nil)
+			require.NoError(t, findErr)
+
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+				assert.Empty(t, identities)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, existing.ID, user.ID)
+			require.Len(t, identities, 1)
+			assert.Equal(t, existing.ID, identities[0].UserID)
+		})
+	}
+}
+
+func TestService_Authenticate_EmailRequired(t *testing.T) {
+	env := setup(t, Config{})
+
+	entry := env.directory.entries["john"]
+	entry.Email = ""
+	env.directory.entries["john"] = entry
+
+	_, err := env.service.Authenticate(context.Background(), authenticator.Credentials{
+		Login:    "john",
+		Password: "secret",
+	})
+	require.ErrorIs(t, err, ErrEmailRequired)
+}
+
+func TestService_Authenticate_RoleMapping(t *testing.T) {
+	env := setup(t, Config{
+		RoleMapping: map[string]string{
+			"cn=gameap-admins,ou=groups,dc=example,dc=com": "admin",
+		},
+		DefaultRoles: []string{"user"},
+	})
+
+	user, err := env.service.Authenticate(context.Background(), authenticator.Credentials{
+		Login:    "john",
+		Password: "secret",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admin"}, env.rbac.roles[user.ID])
+
+	// Roles are synced on each login, users without mapped groups get the default roles
+	entry := env.directory.entries["john"]
+	entry.Groups = []string{"cn=others,ou=groups,dc=example,dc=com"}
+	env.directory.entries["john"] = entry
+
+	_, err = env.service.Authenticate(context.Background(), authenticator.Credentials{
+		Login:    "john",
+		Password: "secret",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user"}, env.rbac.roles[user.ID])
+}
+
+func TestMapRoles(t *testing.T) {
+	mapping := map[string]string{
+		"cn=admins,dc=example,dc=com":  "admin",
+		"cn=owners,dc=example,dc=com":  "admin",
+		"cn=players,dc=example,dc=com": "user",
+	}
+
+	roles := mapRoles([]string{
+		"cn=players,dc=example,dc=com",
+		"cn=owners,dc=example,dc=com",
+		"cn=admins,dc=example,dc=com",
+		"cn=unknown,dc=example,dc=com",
+	}, mapping)
+
+	assert.Equal(t, []string{"admin", "user"}, roles)
+}
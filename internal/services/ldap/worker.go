@@ -0,0 +1,118 @@
+package ldap
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+const lockName = "ldap_sync"
+
+// Worker disables the users linked to the directory entries which no longer exist.
+// The users are enabled again on the next successful login with the directory.
+//
+// Only one panel replica runs the worker at a time, it is guarded by a leader lock in the cache.
+type Worker struct {
+	directory    Directory
+	identityRepo repositories.UserIdentityRepository
+	userRepo     repositories.UserRepository
+	lock         *cache.Lock
+	interval     time.Duration
+}
+
+func NewWorker(
+	directory Directory,
+	identityRepo repositories.UserIdentityRepository,
+	userRepo repositories.UserRepository,
+	c cache.Cache,
+	lockTTL time.Duration,
+	interval time.Duration,
+) *Worker {
+	return &Worker{
+		directory:    directory,
+		identityRepo: identityRepo,
+		userRepo:     userRepo,
+		lock:         cache.NewLock(c, lockName, lockTTL),
+		interval:     interval,
+	}
+}
+
+// Run syncs the users periodically until the context is canceled.
+func (w *Worker) Run(ctx context.Context) {
+	cache.RunAsLeader(ctx, w.lock, w.interval, func(ctx context.Context) error {
+		return w.Process(ctx, time.Now())
+	})
+}
+
+// Process disables the linked users missing in the directory.
+// Nothing is disabled if the directory can't be searched.
+func (w *Worker) Process(ctx context.Context, now time.Time) error {
+	identities, err := w.identityRepo.Find(ctx, &filters.FindUserIdentity{
+		Providers: []string{IdentityProvider},
+	}, nil, nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed to find user identities")
+	}
+
+	if len(identities) == 0 {
+		return nil
+	}
+
+	entries, err := w.directory.FindUsers(ctx, lo.Map(identities, func(identity domain.UserIdentity, _ int) string {
+		return identity.Subject
+	}))
+	if err != nil {
+		return errors.WithMessage(err, "failed to find directory users")
+	}
+
+	found := lo.SliceToMap(entries, func(entry Entry) (string, struct{}) {
+		return strings.ToLower(entry.Login), struct{}{}
+	})
+
+	missing := lo.FilterMap(identities, func(identity domain.UserIdentity, _ int) (uint, bool) {
+		_, ok := found[identity.Subject]
+
+		return identity.UserID, !ok
+	})
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	users, err := w.userRepo.Find(ctx, &filters.FindUser{IDs: missing}, nil, nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed to find users")
+	}
+
+	for i := range users {
+		user := &users[i]
+
+		if user.Disabled() {
+			continue
+		}
+
+		user.DisabledAt = lo.ToPtr(now)
+		user.UpdatedAt = lo.ToPtr(now)
+
+		if err = w.userRepo.Save(ctx, user); err != nil {
+			return errors.WithMessage(err, "failed to save user")
+		}
+
+		slog.InfoContext(
+			ctx,
+			"User is disabled as it no longer exists in the directory",
+			slog.Uint64("user_id", uint64(user.ID)),
+			slog.String("login", user.Login),
+		)
+	}
+
+	return nil
+}
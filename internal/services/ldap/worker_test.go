@@ -0,0 +1,98 @@
+package ldap
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupWorker(t *testing.T, logins ...string) (*Worker, *fakeDirectory, *inmemory.UserRepository, []uint) {
+	t.Helper()
+
+	directory := &fakeDirectory{entries: map[string]fakeEntry{
+		"john": {Entry: Entry{Login: "John"}},
+	}}
+	identityRepo := inmemory.NewUserIdentityRepository()
+	userRepo := inmemory.NewUserRepository()
+
+	ids := make([]uint, 0, len(logins))
+
+	for _, login := range logins {
+		user := &domain.User{Login: login, Email: login + "@example.com", Password: "hash"}
+		require.NoError(t, userRepo.Save(context.Background(), user))
+		require.NoError(t, identityRepo.Save(context.Background(), &domain.UserIdentity{
+			Provider: IdentityProvider,
+			Subject:  login,
+			UserID:   user.ID,
+		}))
+
+		ids = append(ids, user.ID)
+	}
+
+	worker := NewWorker(directory, identityRepo, userRepo, cache.NewInMemory(), time.Minute, time.Hour)
+
+	return worker, directory, userRepo, ids
+}
+
+func findUser(t *testing.T, repo *inmemory.UserRepository, id uint) domain.User {
+	t.Helper()
+
+	users, err := repo.Find(context.Background(), &filters.FindUser{IDs: []uint{id}}, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+
+	return users[0]
+}
+
+func TestWorker_Process_DisablesMissingUsers(t *testing.T) {
+	worker, _, userRepo, ids := setupWorker(t, "john", "jane")
+
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	require.NoError(t, worker.Process(context.Background(), now))
+
+	john := findUser(t, userRepo, ids[0])
+	assert.False(t, john.Disabled())
+
+	jane := findUser(t, userRepo, ids[1])
+	require.True(t, jane.Disabled())
+	assert.Equal(t, now, *jane.DisabledAt)
+
+	// Already disabled users keep the original time
+	require.NoError(t, worker.Process(context.Background(), now.Add(time.Hour)))
+
+	jane = findUser(t, userRepo, ids[1])
+	assert.Equal(t, now, *jane.DisabledAt)
+}
+
+func TestWorker_Process_DirectoryError(t *testing.T) {
+	worker, directory, userRepo, ids := setupWorker(t, "john", "jane")
+	directory.err = errors.New("connection refused")
+
+	require.Error(t, worker.Process(context.Background(), time.Now()))
+
+	for _, id := range ids {
+		user := findUser(t, userRepo, id)
+		assert.False(t, user.Disabled(), "users must not be disabled when the directory is unavailable")
+	}
+}
+
+func TestWorker_Process_SkipsLocalUsers(t *testing.T) {
+	worker, _, userRepo, _ := setupWorker(t)
+
+	local := &domain.User{Login: "admin", Email: "admin@example.com", Password: "hash"}
+	require.NoError(t, userRepo.Save(context.Background(), local))
+
+	require.NoError(t, worker.Process(context.Background(), time.Now()))
+
+	user := findUser(t, userRepo, local.ID)
+	assert.False(t, user.Disabled())
+}
@@ -0,0 +1,138 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Mailer sends emails.
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// Message is an email to a single recipient.
+// The HTML body is optional, the message is sent as plain text without it.
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// build renders the message in the RFC 5322 format.
+func (m *Message) build(from mail.Address, now time.Time) ([]byte, error) {
+	to, err := mail.ParseAddress(m.To)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid recipient address")
+	}
+
+	messageID, err := newMessageID(from.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+
+	writeHeader(buf, "From", from.String())
+	writeHeader(buf, "To", to.String())
+	// Encoding also escapes line breaks, so the subject can't inject headers
+	writeHeader(buf, "Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	writeHeader(buf, "Date", now.Format(time.RFC1123Z))
+	writeHeader(buf, "Message-ID", messageID)
+	writeHeader(buf, "MIME-Version", "1.0")
+
+	if m.HTML == "" {
+		writeHeader(buf, "Content-Type", "text/plain; charset=utf-8")
+		writeHeader(buf, "Content-Transfer-Encoding", "quoted-printable")
+		buf.WriteString("\r\n")
+
+		if err = writeQuotedPrintable(buf, m.Text); err != nil {
+			return nil, err
+		}
+
+		return buf.Bytes(), nil
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	writeHeader(buf, "Content-Type", "multipart/alternative; boundary="+writer.Boundary())
+	buf.WriteString("\r\n")
+
+	for _, part := range []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=utf-8", m.Text},
+		{"text/html; charset=utf-8", m.HTML},
+	} {
+		w, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to create message part")
+		}
+
+		if err = writeQuotedPrintable(w, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = writer.Close(); err != nil {
+		return nil, errors.WithMessage(err, "failed to close message parts")
+	}
+
+	buf.Write(body.Bytes())
+
+	return buf.Bytes(), nil
+}
+
+func writeHeader(buf *bytes.Buffer, name, value string) {
+	buf.WriteString(name)
+	buf.WriteString(": ")
+	buf.WriteString(value)
+	buf.WriteString("\r\n")
+}
+
+// writeQuotedPrintable encodes the content, line breaks are written as CRLF.
+func writeQuotedPrintable(w io.Writer, content string) error {
+	qp := quotedprintable.NewWriter(w)
+
+	if _, err := qp.Write([]byte(content)); err != nil {
+		return errors.WithMessage(err, "failed to write message body")
+	}
+
+	if err := qp.Close(); err != nil {
+		return errors.WithMessage(err, "failed to write message body")
+	}
+
+	return nil
+}
+
+func newMessageID(fromAddress string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.WithMessage(err, "failed to generate message id")
+	}
+
+	domain := "localhost"
+	if _, host, ok := strings.Cut(fromAddress, "@"); ok && host != "" {
+		domain = host
+	}
+
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(b), domain), nil
+}
@@ -0,0 +1,140 @@
+package mail
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessage_Build(t *testing.T) {
+	from := mail.Address{Name: "GameAP", Address: "noreply@example.com"}
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("multipart", func(t *testing.T) {
+		msg := &Message{
+			To:      "user@example.com",
+			Subject: "Сброс пароля",
+			Text:    "Plain text\nSecond line",
+			HTML:    "<p>HTML</p>",
+		}
+
+		data, err := msg.build(from, now)
+		require.NoError(t, err)
+
+		parsed, err := mail.ReadMessage(strings.NewReader(string(data)))
+		require.NoError(t, err)
+
+		assert.Equal(t, `"GameAP" <noreply@example.com>`, parsed.Header.Get("From"))
+		assert.Equal(t, "<user@example.com>", parsed.Header.Get("To"))
+		assert.Equal(t, "Thu, 02 Jan 2025 03:04:05 +0000", parsed.Header.Get("Date"))
+		assert.True(t, strings.HasSuffix(parsed.Header.Get("Message-ID"), "@example.com>"))
+
+		subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+		require.NoError(t, err)
+		assert.Equal(t, "Сброс пароля", subject)
+
+		mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+		require.NoError(t, err)
+		assert.Equal(t, "multipart/alternative", mediaType)
+
+		reader := multipart.NewReader(parsed.Body, params["boundary"])
+
+		part, err := reader.NextPart()
+		require.NoError(t, err)
+		assert.Equal(t, "text/plain; charset=utf-8", part.Header.Get("Content-Type"))
+		body, err := io.ReadAll(part)
+		require.NoError(t, err)
+		assert.Equal(t, "Plain text\r\nSecond line", string(body))
+
+		part, err = reader.NextPart()
+		require.NoError(t, err)
+		assert.Equal(t, "text/html; charset=utf-8", part.Header.Get("Content-Type"))
+		body, err = io.ReadAll(part)
+		require.NoError(t, err)
+		assert.Equal(t, "<p>HTML</p>", string(body))
+
+		_, err = reader.NextPart()
+		assert.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("plain_text", func(t *testing.T) {
+		msg := &Message{
+			To:      "user@example.com",
+			Subject: "Subject",
+			Text:    "Plain text",
+		}
+
+		data, err := msg.build(from, now)
+		require.NoError(t, err)
+
+		parsed, err := mail.ReadMessage(strings.NewReader(string(data)))
+		require.NoError(t, err)
+		assert.Equal(t, "text/plain; charset=utf-8", parsed.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(parsed.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "Plain text", string(body))
+	})
+
+	t.Run("subject_line_breaks_are_encoded", func(t *testing.T) {
+		msg := &Message{
+			To:      "user@example.com",
+			Subject: "Subject\r\nBcc: victim@example.com",
+			Text:    "Plain text",
+		}
+
+		data, err := msg.build(from, now)
+		require.NoError(t, err)
+
+		parsed, err := mail.ReadMessage(strings.NewReader(string(data)))
+		require.NoError(t, err)
+		assert.Empty(t, parsed.Header.Get("Bcc"))
+	})
+
+	t.Run("invalid_recipient", func(t *testing.T) {
+		msg := &Message{
+			To:   "user@example.com\r\nBcc: victim@example.com",
+			Text: "Plain text",
+		}
+
+		_, err := msg.build(from, now)
+		require.Error(t, err)
+	})
+}
+
+func TestNewTemplatedMessage(t *testing.T) {
+	msg, err := NewTemplatedMessage("user@example.com", Content{
+		Subject:        "Reset Password",
+		Greeting:       "Hello, <admin>!",
+		Intro:          []string{"Intro line."},
+		ActionText:     "Reset Password",
+		ActionURL:      "https://panel.example.com/reset?token=abc&email=user%40example.com",
+		ActionFallback: "Open the link.",
+		Outro:          []string{"Outro line."},
+		Salutation:     "Regards,\nGameAP",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "user@example.com", msg.To)
+	assert.Equal(t, "Reset Password", msg.Subject)
+	assert.Equal(t,
+		"Hello, <admin>!\n\n"+
+			"Intro line.\n\n"+
+			"Reset Password: https://panel.example.com/reset?token=abc&email=user%40example.com\n\n"+
+			"Outro line.\n\n"+
+			"Regards,\nGameAP\n",
+		msg.Text,
+	)
+
+	assert.Contains(t, msg.HTML, "Hello, &lt;admin&gt;!")
+	assert.Contains(t, msg.HTML, `href="https://panel.example.com/reset?token=abc&amp;email=user%40example.com"`)
+	assert.Contains(t, msg.HTML, "Regards,<br>GameAP")
+	assert.Contains(t, msg.HTML, "Open the link.")
+}
@@ -0,0 +1,154 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type Encryption string
+
+const (
+	EncryptionNone Encryption = "none"
+	// EncryptionSTARTTLS upgrades the plain connection, it fails if the server doesn't support STARTTLS.
+	EncryptionSTARTTLS Encryption = "starttls"
+	// EncryptionTLS connects with implicit TLS, usually on port 465.
+	EncryptionTLS Encryption = "tls"
+)
+
+func (e Encryption) Valid() bool {
+	return e == EncryptionNone || e == EncryptionSTARTTLS || e == EncryptionTLS
+}
+
+type SMTPConfig struct {
+	Host string
+	Port uint16
+	// Username and Password are used for the PLAIN authentication, it's skipped if the username is empty.
+	// net/smtp refuses to send the credentials over a plain connection to a remote host.
+	Username   string
+	Password   string
+	Encryption Encryption
+	From       mail.Address
+	Timeout    time.Duration
+}
+
+// SMTPMailer sends emails through an SMTP server, a new connection is opened for each email.
+type SMTPMailer struct {
+	cfg SMTPConfig
+}
+
+func NewSMTPMailer(cfg SMTPConfig) *SMTPMailer {
+	return &SMTPMailer{
+		cfg: cfg,
+	}
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, msg *Message) error {
+	data, err := msg.build(m.cfg.From, time.Now())
+	if err != nil {
+		return errors.WithMessage(err, "failed to build message")
+	}
+
+	if m.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.cfg.Timeout)
+		defer cancel()
+	}
+
+	conn, err := m.dial(ctx)
+	if err != nil {
+		return errors.WithMessage(err, "failed to connect to smtp server")
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, m.cfg.Host)
+	if err != nil {
+		_ = conn.Close()
+
+		return errors.WithMessage(err, "failed to create smtp client")
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	if m.cfg.Encryption == EncryptionSTARTTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return errors.New("smtp server doesn't support STARTTLS")
+		}
+
+		if err = client.StartTLS(m.tlsConfig()); err != nil {
+			return errors.WithMessage(err, "failed to start tls")
+		}
+	}
+
+	if m.cfg.Username != "" {
+		err = client.Auth(smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host))
+		if err != nil {
+			return errors.WithMessage(err, "failed to authenticate")
+		}
+	}
+
+	if err = client.Mail(m.cfg.From.Address); err != nil {
+		return errors.WithMessage(err, "failed to set sender")
+	}
+
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return errors.WithMessage(err, "invalid recipient address")
+	}
+
+	if err = client.Rcpt(to.Address); err != nil {
+		return errors.WithMessage(err, "failed to set recipient")
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return errors.WithMessage(err, "failed to start data")
+	}
+
+	if _, err = w.Write(data); err != nil {
+		_ = w.Close()
+
+		return errors.WithMessage(err, "failed to write message")
+	}
+
+	if err = w.Close(); err != nil {
+		return errors.WithMessage(err, "failed to send message")
+	}
+
+	if err = client.Quit(); err != nil {
+		return errors.WithMessage(err, "failed to quit")
+	}
+
+	return nil
+}
+
+func (m *SMTPMailer) dial(ctx context.Context) (net.Conn, error) {
+	addr := net.JoinHostPort(m.cfg.Host, strconv.Itoa(int(m.cfg.Port)))
+
+	if m.cfg.Encryption == EncryptionTLS {
+		dialer := &tls.Dialer{Config: m.tlsConfig()}
+
+		return dialer.DialContext(ctx, "tcp", addr)
+	}
+
+	dialer := &net.Dialer{}
+
+	return dialer.DialContext(ctx, "tcp", addr)
+}
+
+func (m *SMTPMailer) tlsConfig() *tls.Config {
+	return &tls.Config{
+		ServerName: m.cfg.Host,
+		MinVersion: tls.VersionTLS12,
+	}
+}
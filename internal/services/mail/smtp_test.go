@@ -0,0 +1,214 @@
+package mail
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/mail"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// smtpServer is a minimal SMTP server accepting a single plain connection.
+type smtpServer struct {
+	listener   net.Listener
+	extensions []string
+
+	mu       sync.Mutex
+	commands []string
+	data     string
+}
+
+func newSMTPServer(t *testing.T, extensions ...string) *smtpServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &smtpServer{
+		listener:   listener,
+		extensions: extensions,
+	}
+
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	go s.serve()
+
+	return s
+}
+
+func (s *smtpServer) port() uint16 {
+	return uint16(s.listener.Addr().(*net.TCPAddr).Port) //nolint:gosec // test listener port
+}
+
+func (s *smtpServer) serve() {
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	reader := bufio.NewReader(conn)
+	reply := func(line string) {
+		_, _ = conn.Write([]byte(line + "\r\n"))
+	}
+
+	reply("220 localhost ESMTP")
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+
+		s.mu.Lock()
+		s.commands = append(s.commands, line)
+		s.mu.Unlock()
+
+		command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+
+		switch command {
+		case "EHLO":
+			lines := append([]string{"localhost"}, s.extensions...)
+			for i, l := range lines {
+				if i == len(lines)-1 {
+					reply("250 " + l)
+				} else {
+					reply("250-" + l)
+				}
+			}
+		case "AUTH":
+			reply("235 Authentication successful")
+		case "MAIL", "RCPT":
+			reply("250 OK")
+		case "DATA":
+			reply("354 Start mail input")
+
+			data := &strings.Builder{}
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+
+				if dataLine == ".\r\n" {
+					break
+				}
+
+				data.WriteString(dataLine)
+			}
+
+			s.mu.Lock()
+			s.data = data.String()
+			s.mu.Unlock()
+
+			reply("250 OK")
+		case "QUIT":
+			reply("221 Bye")
+
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func (s *smtpServer) received() ([]string, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.commands, s.data
+}
+
+func TestSMTPMailer_Send(t *testing.T) {
+	ctx := context.Background()
+	msg := &Message{
+		To:      "user@example.com",
+		Subject: "Subject",
+		Text:    "Hello",
+	}
+
+	t.Run("without_auth", func(t *testing.T) {
+		server := newSMTPServer(t)
+		mailer := NewSMTPMailer(SMTPConfig{
+			Host:       "127.0.0.1",
+			Port:       server.port(),
+			Encryption: EncryptionNone,
+			From:       mail.Address{Name: "GameAP", Address: "noreply@example.com"},
+			Timeout:    5 * time.Second,
+		})
+
+		require.NoError(t, mailer.Send(ctx, msg))
+
+		commands, data := server.received()
+		assert.Contains(t, commands, "MAIL FROM:<noreply@example.com>")
+		assert.Contains(t, commands, "RCPT TO:<user@example.com>")
+		assert.Equal(t, "QUIT", commands[len(commands)-1])
+		assert.Contains(t, data, "Subject: Subject\r\n")
+		assert.Contains(t, data, "\r\n\r\nHello")
+	})
+
+	t.Run("with_auth", func(t *testing.T) {
+		server := newSMTPServer(t, "AUTH PLAIN")
+		mailer := NewSMTPMailer(SMTPConfig{
+			Host:       "127.0.0.1",
+			Port:       server.port(),
+			Username:   "user",
+			Password:   "secret",
+			Encryption: EncryptionNone,
+			From:       mail.Address{Address: "noreply@example.com"},
+			Timeout:    5 * time.Second,
+		})
+
+		require.NoError(t, mailer.Send(ctx, msg))
+
+		commands, _ := server.received()
+		assert.Contains(t, commands, "AUTH PLAIN AHVzZXIAc2VjcmV0")
+	})
+
+	t.Run("starttls_not_supported", func(t *testing.T) {
+		server := newSMTPServer(t)
+		mailer := NewSMTPMailer(SMTPConfig{
+			Host:       "127.0.0.1",
+			Port:       server.port(),
+			Encryption: EncryptionSTARTTLS,
+			From:       mail.Address{Address: "noreply@example.com"},
+			Timeout:    5 * time.Second,
+		})
+
+		err := mailer.Send(ctx, msg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "STARTTLS")
+
+		commands, _ := server.received()
+		assert.NotContains(t, strings.Join(commands, "\n"), "MAIL FROM")
+	})
+
+	t.Run("connection_refused", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		port := listener.Addr().(*net.TCPAddr).Port
+		require.NoError(t, listener.Close())
+
+		mailer := NewSMTPMailer(SMTPConfig{
+			Host:       "127.0.0.1",
+			Port:       uint16(port), //nolint:gosec // test listener port
+			Encryption: EncryptionNone,
+			From:       mail.Address{Address: "noreply@example.com"},
+			Timeout:    5 * time.Second,
+		})
+
+		require.Error(t, mailer.Send(ctx, msg))
+	})
+}
@@ -0,0 +1,64 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/pkg/errors"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+var (
+	htmlLayout = htmltemplate.Must(
+		htmltemplate.New("layout.html").
+			Funcs(htmltemplate.FuncMap{"lines": lines}).
+			ParseFS(templatesFS, "templates/layout.html"),
+	)
+	textLayout = texttemplate.Must(
+		texttemplate.New("layout.txt").ParseFS(templatesFS, "templates/layout.txt"),
+	)
+)
+
+// Content is the content of a templated email: a greeting, the intro paragraphs,
+// an optional action button and the outro paragraphs.
+// The texts are expected to be translated already, they are escaped in the HTML body.
+type Content struct {
+	Subject    string
+	Greeting   string
+	Intro      []string
+	ActionText string
+	ActionURL  string
+	// ActionFallback is shown under the HTML body for clients that can't open the button.
+	ActionFallback string
+	Outro          []string
+	Salutation     string
+}
+
+// NewTemplatedMessage renders the content with the email layout into the plain text and HTML bodies.
+func NewTemplatedMessage(to string, content Content) (*Message, error) {
+	text := &bytes.Buffer{}
+	if err := textLayout.Execute(text, content); err != nil {
+		return nil, errors.WithMessage(err, "failed to render text body")
+	}
+
+	html := &bytes.Buffer{}
+	if err := htmlLayout.Execute(html, content); err != nil {
+		return nil, errors.WithMessage(err, "failed to render html body")
+	}
+
+	return &Message{
+		To:      to,
+		Subject: content.Subject,
+		Text:    strings.TrimSpace(text.String()) + "\n",
+		HTML:    html.String(),
+	}, nil
+}
+
+func lines(s string) []string {
+	return strings.Split(s, "\n")
+}
@@ -0,0 +1,159 @@
+package metrics
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/pkg/errors"
+)
+
+// Retention is how long the points of each resolution are kept.
+type Retention struct {
+	Raw    time.Duration
+	Minute time.Duration
+	Hour   time.Duration
+}
+
+// For returns the retention of the resolution.
+func (r Retention) For(resolution domain.MetricResolution) time.Duration {
+	switch resolution {
+	case domain.MetricResolutionRaw:
+		return r.Raw
+	case domain.MetricResolutionMinute:
+		return r.Minute
+	case domain.MetricResolutionHour:
+		return r.Hour
+	default:
+		return 0
+	}
+}
+
+// Query describes the requested history of a subject.
+type Query struct {
+	SubjectType domain.MetricSubject
+	SubjectID   uint
+	From        time.Time
+	To          time.Time
+	Step        time.Duration
+}
+
+// Series is the history of a subject averaged into points of the step.
+type Series struct {
+	Resolution domain.MetricResolution
+	Points     []domain.Metric
+}
+
+// Service records resource usage samples and reads the usage history.
+type Service struct {
+	metricRepo repositories.MetricRepository
+	retention  Retention
+}
+
+func NewService(metricRepo repositories.MetricRepository, retention Retention) *Service {
+	return &Service{
+		metricRepo: metricRepo,
+		retention:  retention,
+	}
+}
+
+// Record saves the samples as raw points.
+func (s *Service) Record(ctx context.Context, samples []*domain.Metric) error {
+	for _, sample := range samples {
+		sample.Resolution = domain.MetricResolutionRaw
+		sample.Samples = 1
+	}
+
+	if err := s.metricRepo.SaveBulk(ctx, samples); err != nil {
+		return errors.WithMessage(err, "failed to save metrics")
+	}
+
+	return nil
+}
+
+// Query returns the history of the subject in [From, To) averaged into points of the step.
+// The points are read from the coarsest resolution which is not coarser than the step
+// and which still keeps the points from the start of the range. The recent range
+// which is not downsampled yet is read from finer resolutions.
+func (s *Service) Query(ctx context.Context, q Query, now time.Time) (*Series, error) {
+	resolution := s.resolution(q, now)
+
+	points, err := s.find(ctx, q, resolution, q.From)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Series{
+		Resolution: resolution,
+		Points:     domain.AggregateMetrics(points, resolution, q.Step),
+	}, nil
+}
+
+func (s *Service) find(
+	ctx context.Context,
+	q Query,
+	resolution domain.MetricResolution,
+	from time.Time,
+) ([]domain.Metric, error) {
+	points, err := s.metricRepo.Find(ctx, &filters.FindMetric{
+		SubjectType:    q.SubjectType,
+		SubjectIDs:     []uint{q.SubjectID},
+		Resolutions:    []domain.MetricResolution{resolution},
+		RecordedFrom:   &from,
+		RecordedBefore: &q.To,
+	}, nil, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find metrics")
+	}
+
+	finer := slices.Index(domain.MetricResolutions, resolution) - 1
+	if finer < 0 {
+		return points, nil
+	}
+
+	covered := from
+	if len(points) > 0 {
+		covered = points[len(points)-1].RecordedAt.Add(resolution.Step())
+	}
+
+	if !covered.Before(q.To) {
+		return points, nil
+	}
+
+	tail, err := s.find(ctx, q, domain.MetricResolutions[finer], covered)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(points, tail...), nil
+}
+
+func (s *Service) resolution(q Query, now time.Time) domain.MetricResolution {
+	resolution := domain.MetricResolutionRaw
+
+	for _, r := range domain.MetricResolutions {
+		if r.Step() > q.Step {
+			break
+		}
+
+		resolution = r
+	}
+
+	// Finer points may already be deleted, coarser ones are kept longer
+	for _, r := range domain.MetricResolutions {
+		if r.Step() < resolution.Step() {
+			continue
+		}
+
+		resolution = r
+
+		if retention := s.retention.For(r); retention <= 0 || !q.From.Before(now.Add(-retention)) {
+			break
+		}
+	}
+
+	return resolution
+}
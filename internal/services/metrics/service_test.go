@@ -0,0 +1,173 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testRetention = Retention{
+	Raw:    24 * time.Hour,
+	Minute: 7 * 24 * time.Hour,
+	Hour:   90 * 24 * time.Hour,
+}
+
+func TestService_RecordAndQuery(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	service := NewService(inmemory.NewMetricRepository(), testRetention)
+
+	for i := range 6 {
+		require.NoError(t, service.Record(ctx, []*domain.Metric{
+			{
+				SubjectType: domain.MetricSubjectServer,
+				SubjectID:   1,
+				RecordedAt:  now.Add(-time.Hour + time.Duration(i)*20*time.Second),
+				CPU:         lo.ToPtr(float64(i * 10)),
+			},
+			{
+				SubjectType: domain.MetricSubjectServer,
+				SubjectID:   2,
+				RecordedAt:  now.Add(-time.Hour + time.Duration(i)*20*time.Second),
+				CPU:         lo.ToPtr(100.0),
+			},
+		}))
+	}
+
+	series, err := service.Query(ctx, Query{
+		SubjectType: domain.MetricSubjectServer,
+		SubjectID:   1,
+		From:        now.Add(-time.Hour),
+		To:          now,
+		Step:        time.Minute,
+	}, now)
+	require.NoError(t, err)
+
+	// Minute points are not downsampled yet, raw points are used instead
+	assert.Equal(t, domain.MetricResolutionMinute, series.Resolution)
+	require.Len(t, series.Points, 2)
+	assert.Equal(t, now.Add(-time.Hour), series.Points[0].RecordedAt)
+	assert.Equal(t, lo.ToPtr(10.0), series.Points[0].CPU)
+	assert.Equal(t, 3, series.Points[0].Samples)
+	assert.Equal(t, lo.ToPtr(40.0), series.Points[1].CPU)
+}
+
+func TestService_Query_Resolution(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	service := NewService(inmemory.NewMetricRepository(), testRetention)
+
+	tests := []struct {
+		name string
+		from time.Time
+		step time.Duration
+		want domain.MetricResolution
+	}{
+		{
+			name: "step shorter than a minute",
+			from: now.Add(-time.Hour),
+			step: 10 * time.Second,
+			want: domain.MetricResolutionRaw,
+		},
+		{
+			name: "minute step",
+			from: now.Add(-time.Hour),
+			step: 5 * time.Minute,
+			want: domain.MetricResolutionMinute,
+		},
+		{
+			name: "hour step",
+			from: now.Add(-48 * time.Hour),
+			step: 6 * time.Hour,
+			want: domain.MetricResolutionHour,
+		},
+		{
+			name: "raw points are already deleted",
+			from: now.Add(-48 * time.Hour),
+			step: 10 * time.Second,
+			want: domain.MetricResolutionMinute,
+		},
+		{
+			name: "minute points are already deleted",
+			from: now.Add(-30 * 24 * time.Hour),
+			step: time.Minute,
+			want: domain.MetricResolutionHour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			series, err := service.Query(context.Background(), Query{
+				SubjectType: domain.MetricSubjectServer,
+				SubjectID:   1,
+				From:        tt.from,
+				To:          now,
+				Step:        tt.step,
+			}, now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, series.Resolution)
+		})
+	}
+}
+
+func TestService_Query_RecentRangeFromFinerResolution(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	repo := inmemory.NewMetricRepository()
+	service := NewService(repo, testRetention)
+
+	require.NoError(t, repo.SaveBulk(ctx, []*domain.Metric{
+		{
+			SubjectType: domain.MetricSubjectServer,
+			SubjectID:   1,
+			Resolution:  domain.MetricResolutionHour,
+			RecordedAt:  now.Add(-2 * time.Hour),
+			RAM:         lo.ToPtr(100.0),
+			Samples:     120,
+		},
+		{
+			SubjectType: domain.MetricSubjectServer,
+			SubjectID:   1,
+			Resolution:  domain.MetricResolutionMinute,
+			RecordedAt:  now.Add(-2 * time.Hour),
+			RAM:         lo.ToPtr(999.0),
+			Samples:     2,
+		},
+		{
+			SubjectType: domain.MetricSubjectServer,
+			SubjectID:   1,
+			Resolution:  domain.MetricResolutionMinute,
+			RecordedAt:  now.Add(-time.Hour),
+			RAM:         lo.ToPtr(200.0),
+			Samples:     2,
+		},
+		{
+			SubjectType: domain.MetricSubjectServer,
+			SubjectID:   1,
+			Resolution:  domain.MetricResolutionRaw,
+			RecordedAt:  now.Add(-time.Hour + 90*time.Second),
+			RAM:         lo.ToPtr(400.0),
+			Samples:     1,
+		},
+	}))
+
+	series, err := service.Query(ctx, Query{
+		SubjectType: domain.MetricSubjectServer,
+		SubjectID:   1,
+		From:        now.Add(-3 * time.Hour),
+		To:          now,
+		Step:        time.Hour,
+	}, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.MetricResolutionHour, series.Resolution)
+	require.Len(t, series.Points, 2)
+	assert.Equal(t, lo.ToPtr(100.0), series.Points[0].RAM)
+	assert.InDelta(t, 800.0/3, *series.Points[1].RAM, 0.001)
+	assert.Equal(t, 3, series.Points[1].Samples)
+}
@@ -0,0 +1,163 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+// downsampleWindows is the number of target buckets aggregated per query,
+// so a long backlog isn't loaded into memory at once.
+const downsampleWindows = 60
+
+// Worker downsamples the usage history and enforces the retention.
+// Raw points are averaged into minute points, minute points into hour points.
+// Only completed buckets are aggregated, saving a bucket again replaces it,
+// so several panel replicas may run the worker at the same time.
+type Worker struct {
+	metricRepo repositories.MetricRepository
+	retention  Retention
+	interval   time.Duration
+
+	// processed is the end of the aggregated range of each target resolution
+	processed map[domain.MetricResolution]time.Time
+}
+
+func NewWorker(
+	metricRepo repositories.MetricRepository,
+	retention Retention,
+	interval time.Duration,
+) *Worker {
+	return &Worker{
+		metricRepo: metricRepo,
+		retention:  retention,
+		interval:   interval,
+		processed:  make(map[domain.MetricResolution]time.Time),
+	}
+}
+
+// Run downsamples the history periodically until the context is canceled.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.Process(ctx, time.Now()); err != nil {
+			slog.ErrorContext(ctx, "Failed to downsample metrics", slog.String("error", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Process aggregates the buckets completed before the given time and deletes expired points.
+func (w *Worker) Process(ctx context.Context, now time.Time) error {
+	resolutions := domain.MetricResolutions
+
+	for i := 1; i < len(resolutions); i++ {
+		if err := w.downsample(ctx, resolutions[i-1], resolutions[i], now); err != nil {
+			return errors.WithMessagef(err, "failed to downsample %s metrics", resolutions[i-1])
+		}
+	}
+
+	for _, resolution := range resolutions {
+		retention := w.retention.For(resolution)
+		if retention <= 0 {
+			continue
+		}
+
+		if err := w.metricRepo.DeleteBefore(ctx, resolution, now.Add(-retention)); err != nil {
+			return errors.WithMessagef(err, "failed to delete expired %s metrics", resolution)
+		}
+	}
+
+	return nil
+}
+
+func (w *Worker) downsample(ctx context.Context, source, target domain.MetricResolution, now time.Time) error {
+	step := target.Step()
+	end := now.UTC().Truncate(step)
+
+	start, ok := w.processed[target]
+	if !ok {
+		// Nothing is known about previous runs, aggregate all source points still kept
+		var err error
+
+		start, ok, err = w.firstBucket(ctx, source, step, end)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return nil
+		}
+	}
+
+	for start.Before(end) {
+		windowEnd := start.Add(downsampleWindows * step)
+		if windowEnd.After(end) {
+			windowEnd = end
+		}
+
+		points, err := w.metricRepo.Find(ctx, &filters.FindMetric{
+			Resolutions:    []domain.MetricResolution{source},
+			RecordedFrom:   lo.ToPtr(start),
+			RecordedBefore: lo.ToPtr(windowEnd),
+		}, nil, nil)
+		if err != nil {
+			return errors.WithMessage(err, "failed to find metrics")
+		}
+
+		aggregated := domain.AggregateMetrics(points, target, step)
+
+		err = w.metricRepo.SaveBulk(ctx, lo.ToSlicePtr(aggregated))
+		if err != nil {
+			return errors.WithMessage(err, "failed to save metrics")
+		}
+
+		start = windowEnd
+		w.processed[target] = start
+	}
+
+	return nil
+}
+
+// firstBucket returns the start of the first target bucket of the source points still kept.
+// Without a retention the points are kept forever, so the bucket of the oldest point is taken.
+// It returns false if there are no source points.
+func (w *Worker) firstBucket(
+	ctx context.Context,
+	source domain.MetricResolution,
+	step time.Duration,
+	end time.Time,
+) (time.Time, bool, error) {
+	if retention := w.retention.For(source); retention > 0 {
+		return end.Add(-retention).Truncate(step), true, nil
+	}
+
+	points, err := w.metricRepo.Find(
+		ctx,
+		&filters.FindMetric{Resolutions: []domain.MetricResolution{source}},
+		[]filters.Sorting{{Field: "recorded_at", Direction: filters.SortDirectionAsc}},
+		filters.NewPagination(1, 0),
+	)
+	if err != nil {
+		return time.Time{}, false, errors.WithMessage(err, "failed to find oldest metric")
+	}
+
+	if len(points) == 0 {
+		return time.Time{}, false, nil
+	}
+
+	return points[0].RecordedAt.UTC().Truncate(step), true, nil
+}
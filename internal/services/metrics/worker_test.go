@@ -0,0 +1,173 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func findMetrics(t *testing.T, repo *inmemory.MetricRepository, resolution domain.MetricResolution) []domain.Metric {
+	t.Helper()
+
+	metrics, err := repo.Find(context.Background(), &filters.FindMetric{
+		Resolutions: []domain.MetricResolution{resolution},
+	}, nil, nil)
+	require.NoError(t, err)
+
+	return metrics
+}
+
+func TestWorker_Process_Downsamples(t *testing.T) {
+	ctx := context.Background()
+	repo := inmemory.NewMetricRepository()
+	service := NewService(repo, testRetention)
+	worker := NewWorker(repo, testRetention, time.Minute)
+
+	start := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	// One sample every 30 seconds for two hours
+	for i := range 240 {
+		require.NoError(t, service.Record(ctx, []*domain.Metric{{
+			SubjectType: domain.MetricSubjectServer,
+			SubjectID:   1,
+			RecordedAt:  start.Add(time.Duration(i) * 30 * time.Second),
+			CPU:         lo.ToPtr(float64(i % 2 * 20)),
+			RAM:         lo.ToPtr(512.0),
+		}}))
+	}
+
+	now := start.Add(2*time.Hour + 30*time.Second)
+	require.NoError(t, worker.Process(ctx, now))
+
+	minutes := findMetrics(t, repo, domain.MetricResolutionMinute)
+	require.Len(t, minutes, 120)
+	assert.Equal(t, start, minutes[0].RecordedAt)
+	assert.Equal(t, lo.ToPtr(10.0), minutes[0].CPU)
+	assert.Equal(t, lo.ToPtr(512.0), minutes[0].RAM)
+	assert.Equal(t, 2, minutes[0].Samples)
+
+	hours := findMetrics(t, repo, domain.MetricResolutionHour)
+	require.Len(t, hours, 2)
+	assert.Equal(t, start, hours[0].RecordedAt)
+	assert.Equal(t, lo.ToPtr(10.0), hours[0].CPU)
+	assert.Equal(t, 120, hours[0].Samples)
+
+	// The current minute is not completed yet, its raw points are aggregated by the next run
+	require.NoError(t, service.Record(ctx, []*domain.Metric{{
+		SubjectType: domain.MetricSubjectServer,
+		SubjectID:   1,
+		RecordedAt:  start.Add(2*time.Hour + 40*time.Second),
+		CPU:         lo.ToPtr(50.0),
+	}}))
+
+	require.NoError(t, worker.Process(ctx, now.Add(time.Minute)))
+
+	minutes = findMetrics(t, repo, domain.MetricResolutionMinute)
+	require.Len(t, minutes, 121)
+	assert.Equal(t, start.Add(2*time.Hour), minutes[120].RecordedAt)
+	assert.Equal(t, lo.ToPtr(50.0), minutes[120].CPU)
+}
+
+func TestWorker_Process_DeletesExpired(t *testing.T) {
+	ctx := context.Background()
+	repo := inmemory.NewMetricRepository()
+	worker := NewWorker(repo, testRetention, time.Minute)
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	require.NoError(t, repo.SaveBulk(ctx, []*domain.Metric{
+		{
+			SubjectType: domain.MetricSubjectNode,
+			SubjectID:   1,
+			Resolution:  domain.MetricResolutionRaw,
+			RecordedAt:  now.Add(-25 * time.Hour),
+			Samples:     1,
+		},
+		{
+			SubjectType: domain.MetricSubjectNode,
+			SubjectID:   1,
+			Resolution:  domain.MetricResolutionHour,
+			RecordedAt:  now.Add(-25 * time.Hour).Truncate(time.Hour),
+			Samples:     1,
+		},
+		{
+			SubjectType: domain.MetricSubjectNode,
+			SubjectID:   1,
+			Resolution:  domain.MetricResolutionHour,
+			RecordedAt:  now.Add(-91 * 24 * time.Hour),
+			Samples:     1,
+		},
+	}))
+
+	require.NoError(t, worker.Process(ctx, now))
+
+	assert.Empty(t, findMetrics(t, repo, domain.MetricResolutionRaw))
+
+	hours := findMetrics(t, repo, domain.MetricResolutionHour)
+	require.Len(t, hours, 1)
+	assert.Equal(t, now.Add(-25*time.Hour).Truncate(time.Hour), hours[0].RecordedAt)
+}
+
+func TestWorker_Process_DownsamplesWithoutRetention(t *testing.T) {
+	ctx := context.Background()
+	repo := inmemory.NewMetricRepository()
+	service := NewService(repo, Retention{})
+	worker := NewWorker(repo, Retention{}, time.Minute)
+
+	now := time.Date(2025, 3, 3, 10, 0, 30, 0, time.UTC)
+	start := now.Add(-48 * time.Hour).Truncate(time.Hour)
+
+	// One sample every minute for an hour two days ago
+	for i := range 60 {
+		require.NoError(t, service.Record(ctx, []*domain.Metric{{
+			SubjectType: domain.MetricSubjectServer,
+			SubjectID:   1,
+			RecordedAt:  start.Add(time.Duration(i) * time.Minute),
+			CPU:         lo.ToPtr(30.0),
+		}}))
+	}
+
+	require.NoError(t, worker.Process(ctx, now))
+
+	// Points are kept forever, the whole history is aggregated on the first run
+	assert.Len(t, findMetrics(t, repo, domain.MetricResolutionRaw), 60)
+	assert.Len(t, findMetrics(t, repo, domain.MetricResolutionMinute), 60)
+
+	hours := findMetrics(t, repo, domain.MetricResolutionHour)
+	require.Len(t, hours, 1)
+	assert.Equal(t, start, hours[0].RecordedAt)
+	assert.Equal(t, lo.ToPtr(30.0), hours[0].CPU)
+	assert.Equal(t, 60, hours[0].Samples)
+}
+
+func TestWorker_Process_WithoutRetentionAndMetrics(t *testing.T) {
+	ctx := context.Background()
+	repo := inmemory.NewMetricRepository()
+	service := NewService(repo, Retention{})
+	worker := NewWorker(repo, Retention{}, time.Minute)
+
+	now := time.Date(2025, 3, 3, 10, 0, 30, 0, time.UTC)
+
+	require.NoError(t, worker.Process(ctx, now))
+	assert.Empty(t, findMetrics(t, repo, domain.MetricResolutionMinute))
+
+	// The first points recorded later are aggregated by the next run
+	require.NoError(t, service.Record(ctx, []*domain.Metric{{
+		SubjectType: domain.MetricSubjectServer,
+		SubjectID:   1,
+		RecordedAt:  now.Add(-5 * time.Minute),
+		CPU:         lo.ToPtr(30.0),
+	}}))
+
+	require.NoError(t, worker.Process(ctx, now.Add(time.Minute)))
+
+	minutes := findMetrics(t, repo, domain.MetricResolutionMinute)
+	require.Len(t, minutes, 1)
+	assert.Equal(t, now.Add(-5*time.Minute).Truncate(time.Minute), minutes[0].RecordedAt)
+}
@@ -0,0 +1,125 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	userAgent = "GameAP-Notifications"
+
+	// Message length limits of the chat APIs.
+	discordMaxMessageLength  = 2000
+	telegramMaxMessageLength = 4096
+
+	// maxResponseBodySize limits how much of the response body is read before closing the connection.
+	maxResponseBodySize = 64 << 10
+)
+
+// HTTPClient sends the chat API requests. *http.Client implements it,
+// tests replace it to send the requests to a local server.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Sender sends a text message to the target of a notification channel.
+type Sender interface {
+	Send(ctx context.Context, target string, text string) error
+}
+
+// DiscordSender posts messages to Discord webhooks, the target is the webhook URL.
+type DiscordSender struct {
+	client HTTPClient
+}
+
+func NewDiscordSender(client HTTPClient) *DiscordSender {
+	return &DiscordSender{
+		client: client,
+	}
+}
+
+func (s *DiscordSender) Send(ctx context.Context, target string, text string) error {
+	return postJSON(ctx, s.client, target, map[string]any{
+		"content": truncate(text, discordMaxMessageLength),
+		// Mentions in server names must not ping anyone
+		"allowed_mentions": map[string]any{"parse": []string{}},
+	})
+}
+
+// TelegramSender sends messages with the Telegram Bot API, the target is the chat ID.
+type TelegramSender struct {
+	client   HTTPClient
+	apiURL   string
+	botToken string
+}
+
+func NewTelegramSender(client HTTPClient, apiURL, botToken string) *TelegramSender {
+	return &TelegramSender{
+		client:   client,
+		apiURL:   strings.TrimRight(apiURL, "/"),
+		botToken: botToken,
+	}
+}
+
+func (s *TelegramSender) Send(ctx context.Context, target string, text string) error {
+	if s.botToken == "" {
+		return errors.New("telegram bot token is not configured")
+	}
+
+	return postJSON(ctx, s.client, s.apiURL+"/bot"+s.botToken+"/sendMessage", map[string]any{
+		"chat_id": target,
+		"text":    truncate(text, telegramMaxMessageLength),
+	})
+}
+
+func postJSON(ctx context.Context, client HTTPClient, endpoint string, body any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return errors.WithMessage(err, "failed to marshal request body")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return errors.New("failed to create request")
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		// The URL contains the Telegram bot token or the Discord webhook token, it must not be logged
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+
+		return errors.WithMessage(err, "failed to send request")
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBodySize))
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return errors.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func truncate(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+
+	return string(runes[:limit-1]) + "…"
+}
@@ -0,0 +1,94 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordedRequest struct {
+	path string
+	body map[string]any
+}
+
+func newChatServer(t *testing.T, status int) (*httptest.Server, *[]recordedRequest) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var requests []recordedRequest
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		mu.Lock()
+		requests = append(requests, recordedRequest{path: r.URL.Path, body: body})
+		mu.Unlock()
+
+		rw.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &requests
+}
+
+func TestDiscordSender_Send(t *testing.T) {
+	srv, requests := newChatServer(t, http.StatusNoContent)
+
+	err := NewDiscordSender(srv.Client()).Send(context.Background(), srv.URL+"/api/webhooks/1/token", "Server crashed")
+	require.NoError(t, err)
+
+	require.Len(t, *requests, 1)
+	assert.Equal(t, "/api/webhooks/1/token", (*requests)[0].path)
+	assert.Equal(t, "Server crashed", (*requests)[0].body["content"])
+}
+
+func TestDiscordSender_Send_TruncatesLongMessages(t *testing.T) {
+	srv, requests := newChatServer(t, http.StatusNoContent)
+
+	err := NewDiscordSender(srv.Client()).Send(context.Background(), srv.URL, strings.Repeat("a", 3000))
+	require.NoError(t, err)
+
+	require.Len(t, *requests, 1)
+	content, ok := (*requests)[0].body["content"].(string)
+	require.True(t, ok)
+	assert.Len(t, []rune(content), discordMaxMessageLength)
+}
+
+func TestTelegramSender_Send(t *testing.T) {
+	srv, requests := newChatServer(t, http.StatusOK)
+
+	err := NewTelegramSender(srv.Client(), srv.URL+"/", "123:abc").Send(context.Background(), "-100500", "Server crashed")
+	require.NoError(t, err)
+
+	require.Len(t, *requests, 1)
+	assert.Equal(t, "/bot123:abc/sendMessage", (*requests)[0].path)
+	assert.Equal(t, "-100500", (*requests)[0].body["chat_id"])
+	assert.Equal(t, "Server crashed", (*requests)[0].body["text"])
+}
+
+func TestTelegramSender_Send_Errors(t *testing.T) {
+	srv, _ := newChatServer(t, http.StatusBadRequest)
+
+	err := NewTelegramSender(srv.Client(), srv.URL, "123:abc").Send(context.Background(), "1", "text")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected response status 400")
+
+	err = NewTelegramSender(srv.Client(), srv.URL, "").Send(context.Background(), "1", "text")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bot token is not configured")
+
+	srv.Close()
+
+	err = NewTelegramSender(srv.Client(), srv.URL, "123:abc").Send(context.Background(), "1", "text")
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "123:abc")
+}
@@ -0,0 +1,254 @@
+package notifications
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/events"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+var ErrUnsupportedChannelType = errors.New("unsupported notification channel type")
+
+type rbac interface {
+	Can(ctx context.Context, userID uint, abilities []domain.AbilityName) (bool, error)
+}
+
+type translator interface {
+	Translate(lang, key string, params map[string]string) string
+}
+
+// Service sends the panel events to the subscribed Discord and Telegram notification channels.
+//
+// Messages are sent in background, a failed message is logged and isn't retried.
+type Service struct {
+	channelRepo repositories.NotificationChannelRepository
+	serverRepo  repositories.ServerRepository
+	rbac        rbac
+	translator  translator
+	senders     map[domain.NotificationChannelType]Sender
+	timeout     time.Duration
+
+	wg sync.WaitGroup
+}
+
+func NewService(
+	channelRepo repositories.NotificationChannelRepository,
+	serverRepo repositories.ServerRepository,
+	rbac rbac,
+	translator translator,
+	senders map[domain.NotificationChannelType]Sender,
+	timeout time.Duration,
+) *Service {
+	return &Service{
+		channelRepo: channelRepo,
+		serverRepo:  serverRepo,
+		rbac:        rbac,
+		translator:  translator,
+		senders:     senders,
+		timeout:     timeout,
+	}
+}
+
+// HandleEvent sends the event to the enabled channels subscribed to it.
+func (s *Service) HandleEvent(ctx context.Context, event domain.Event) error {
+	if event.ServerID == nil || !lo.Contains(domain.NotificationEventTypes, event.Type) {
+		return nil
+	}
+
+	channels, err := s.channelRepo.Find(ctx, &filters.FindNotificationChannel{Enabled: lo.ToPtr(true)}, nil, nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed to find notification channels")
+	}
+
+	channels = lo.Filter(channels, func(channel domain.NotificationChannel, _ int) bool {
+		return channel.Subscribed(event.Type) &&
+			(channel.ServerID == nil || *channel.ServerID == *event.ServerID)
+	})
+
+	if len(channels) == 0 {
+		return nil
+	}
+
+	server, err := s.findServer(ctx, *event.ServerID)
+	if err != nil {
+		return err
+	}
+
+	channels, err = s.filterAccessible(ctx, channels, server)
+	if err != nil {
+		return err
+	}
+
+	for _, channel := range channels {
+		text := s.message(channel.Language, event, server)
+
+		s.sendInBackground(ctx, channel, text)
+	}
+
+	return nil
+}
+
+// Test sends a test message to the channel right away.
+func (s *Service) Test(ctx context.Context, channel *domain.NotificationChannel) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	return s.send(ctx, channel, s.translator.Translate(channel.Language, "notifications.test", nil))
+}
+
+// Wait blocks until all background messages are sent.
+func (s *Service) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Service) sendInBackground(ctx context.Context, channel domain.NotificationChannel, text string) {
+	// Sending outlives the request, keep only the context values
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.timeout)
+
+	s.wg.Add(1)
+
+	go func() {
+		defer s.wg.Done()
+		defer cancel()
+
+		if err := s.send(ctx, &channel, text); err != nil {
+			slog.WarnContext(
+				ctx,
+				"Failed to send notification",
+				slog.Uint64("channel_id", uint64(channel.ID)),
+				slog.String("channel_type", string(channel.Type)),
+				slog.String("error", err.Error()),
+			)
+		}
+	}()
+}
+
+func (s *Service) send(ctx context.Context, channel *domain.NotificationChannel, text string) error {
+	sender, ok := s.senders[channel.Type]
+	if !ok {
+		return ErrUnsupportedChannelType
+	}
+
+	return sender.Send(ctx, channel.Target, text)
+}
+
+// message renders the event text in the language.
+func (s *Service) message(lang string, event domain.Event, server *domain.Server) string {
+	params := map[string]string{
+		"server": "#" + strconv.FormatUint(uint64(*event.ServerID), 10),
+	}
+
+	if server != nil {
+		params["server"] = server.Name
+	}
+
+	var key string
+
+	switch event.Type {
+	case domain.EventTypeServerCrashed:
+		key = "notifications.server_crashed"
+	case domain.EventTypeServerCrashLoop:
+		key = "notifications.server_crash_loop"
+
+		if data, ok := event.Data.(events.ServerCrashLoopData); ok {
+			params["attempts"] = strconv.Itoa(data.Attempts)
+		}
+	case domain.EventTypeServerUpdateFinished:
+		key = "notifications.server_update_finished"
+	case domain.EventTypeServerUpdateFailed:
+		key = "notifications.server_update_failed"
+	case domain.EventTypeBackupFailed:
+		key = "notifications.backup_failed"
+
+		if data, ok := event.Data.(events.BackupFailedData); ok {
+			params["error"] = data.Error
+		}
+	}
+
+	return s.translator.Translate(lang, key, params)
+}
+
+// filterAccessible returns the channels of the users who have access to the server:
+// all servers for admins, or the servers assigned to the user.
+// A server deleted without keeping its record can't be checked anymore, so its events are
+// sent to the channels of the server, their access was checked when they were saved.
+func (s *Service) filterAccessible(
+	ctx context.Context,
+	channels []domain.NotificationChannel,
+	server *domain.Server,
+) ([]domain.NotificationChannel, error) {
+	if server == nil {
+		return lo.Filter(channels, func(channel domain.NotificationChannel, _ int) bool {
+			return channel.ServerID != nil
+		}), nil
+	}
+
+	result := make([]domain.NotificationChannel, 0, len(channels))
+	access := make(map[uint]bool)
+
+	for _, channel := range channels {
+		allowed, checked := access[channel.UserID]
+		if !checked {
+			var err error
+
+			allowed, err = s.canAccessServer(ctx, channel.UserID, server.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			access[channel.UserID] = allowed
+		}
+
+		if allowed {
+			result = append(result, channel)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *Service) findServer(ctx context.Context, serverID uint) (*domain.Server, error) {
+	servers, err := s.serverRepo.Find(ctx, &filters.FindServer{
+		IDs:         []uint{serverID},
+		WithDeleted: true,
+	}, nil, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find server")
+	}
+
+	if len(servers) == 0 {
+		return nil, nil
+	}
+
+	return &servers[0], nil
+}
+
+func (s *Service) canAccessServer(ctx context.Context, userID, serverID uint) (bool, error) {
+	isAdmin, err := s.rbac.Can(ctx, userID, []domain.AbilityName{domain.AbilityNameAdminRolesPermissions})
+	if err != nil {
+		return false, errors.WithMessage(err, "failed to check admin permissions")
+	}
+
+	if isAdmin {
+		return true, nil
+	}
+
+	servers, err := s.serverRepo.Find(ctx, &filters.FindServer{
+		IDs:         []uint{serverID},
+		UserIDs:     []uint{userID},
+		WithDeleted: true,
+	}, nil, nil)
+	if err != nil {
+		return false, errors.WithMessage(err, "failed to find user server")
+	}
+
+	return len(servers) > 0, nil
+}
@@ -0,0 +1,249 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/events"
+	"github.com/gameap/gameap/internal/i18n"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRBAC struct {
+	admins []uint
+}
+
+func (f *fakeRBAC) Can(_ context.Context, userID uint, _ []domain.AbilityName) (bool, error) {
+	return lo.Contains(f.admins, userID), nil
+}
+
+type testEnv struct {
+	service     *Service
+	channelRepo *inmemory.NotificationChannelRepository
+	serverRepo  *inmemory.ServerRepository
+	discord     *[]recordedRequest
+	telegram    *[]recordedRequest
+	discordURL  string
+}
+
+func setup(t *testing.T) *testEnv {
+	t.Helper()
+
+	translator, err := i18n.NewTranslator()
+	require.NoError(t, err)
+
+	discordSrv, discord := newChatServer(t, http.StatusNoContent)
+	telegramSrv, telegram := newChatServer(t, http.StatusOK)
+
+	env := &testEnv{
+		channelRepo: inmemory.NewNotificationChannelRepository(),
+		serverRepo:  inmemory.NewServerRepository(),
+		discord:     discord,
+		telegram:    telegram,
+		discordURL:  discordSrv.URL + "/api/webhooks/1/token",
+	}
+
+	env.service = NewService(
+		env.channelRepo,
+		env.serverRepo,
+		&fakeRBAC{admins: []uint{1}},
+		translator,
+		map[domain.NotificationChannelType]Sender{
+			domain.NotificationChannelTypeDiscord:  NewDiscordSender(discordSrv.Client()),
+			domain.NotificationChannelTypeTelegram: NewTelegramSender(telegramSrv.Client(), telegramSrv.URL, "123:abc"),
+		},
+		time.Second,
+	)
+
+	return env
+}
+
+func (env *testEnv) addChannel(t *testing.T, channel *domain.NotificationChannel) {
+	t.Helper()
+
+	if channel.Type == "" {
+		channel.Type = domain.NotificationChannelTypeTelegram
+	}
+
+	if channel.Target == "" {
+		channel.Target = channel.Name
+	}
+
+	if channel.Language == "" {
+		channel.Language = "en"
+	}
+
+	require.NoError(t, env.channelRepo.Save(context.Background(), channel))
+}
+
+func (env *testEnv) telegramChats() []any {
+	return lo.Map(*env.telegram, func(r recordedRequest, _ int) any {
+		return r.body["chat_id"]
+	})
+}
+
+func TestService_HandleEvent_MatchesChannels(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+
+	server := &domain.Server{ID: 10, UUID: uuid.New(), Name: "Public CS"}
+	require.NoError(t, env.serverRepo.Save(ctx, server))
+	require.NoError(t, env.serverRepo.Save(ctx, &domain.Server{ID: 11, UUID: uuid.New()}))
+	env.serverRepo.AddUserServer(2, 10)
+
+	env.addChannel(t, &domain.NotificationChannel{Name: "admin", UserID: 1, Enabled: true})
+	env.addChannel(t, &domain.NotificationChannel{Name: "owner", UserID: 2, Enabled: true})
+	env.addChannel(t, &domain.NotificationChannel{
+		Name:     "owner server",
+		UserID:   2,
+		ServerID: lo.ToPtr(uint(10)),
+		Language: "ru",
+		Enabled:  true,
+	})
+	// Not matched: other server, another user, disabled and not subscribed
+	env.addChannel(t, &domain.NotificationChannel{
+		Name:     "other server",
+		UserID:   2,
+		ServerID: lo.ToPtr(uint(11)),
+		Enabled:  true,
+	})
+	env.addChannel(t, &domain.NotificationChannel{Name: "stranger", UserID: 3, Enabled: true})
+	env.addChannel(t, &domain.NotificationChannel{Name: "disabled", UserID: 1})
+	env.addChannel(t, &domain.NotificationChannel{
+		Name:    "not subscribed",
+		UserID:  1,
+		Events:  domain.NotificationEvents{domain.EventTypeBackupFailed},
+		Enabled: true,
+	})
+
+	require.NoError(t, env.service.HandleEvent(ctx, events.ServerCrashed(server)))
+	env.service.Wait()
+
+	assert.ElementsMatch(t, []any{"admin", "owner", "owner server"}, env.telegramChats())
+
+	texts := lo.SliceToMap(*env.telegram, func(r recordedRequest) (any, any) {
+		return r.body["chat_id"], r.body["text"]
+	})
+	assert.Equal(t, `Server "Public CS" has crashed.`, texts["owner"])
+	assert.Equal(t, `Сервер "Public CS" упал.`, texts["owner server"])
+}
+
+func TestService_HandleEvent_Messages(t *testing.T) {
+	server := &domain.Server{ID: 10, UUID: uuid.New(), Name: "Public CS"}
+
+	updateEvent, ok := events.ServerUpdateFinished(&domain.DaemonTask{
+		ServerID: lo.ToPtr(uint(10)),
+		Task:     domain.DaemonTaskTypeServerUpdate,
+		Status:   domain.DaemonTaskStatusError,
+	})
+	require.True(t, ok)
+
+	tests := []struct {
+		name  string
+		event domain.Event
+		want  string
+	}{
+		{
+			name:  "update_failed",
+			event: updateEvent,
+			want:  `Server "Public CS" update has failed.`,
+		},
+		{
+			name:  "backup_failed",
+			event: events.BackupFailed(server, &domain.Backup{ID: 1}, errors.New("disk is full")),
+			want:  `Backup of server "Public CS" has failed: disk is full`,
+		},
+		{
+			name: "crash_loop",
+			event: events.ServerCrashLoop(server, &domain.ServerAutoRestart{
+				ServerID: 10,
+				Attempts: 5,
+			}),
+			want: `Server "Public CS" is in a crash loop, automatic restarts are suspended after 5 attempts.`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			env := setup(t)
+			ctx := context.Background()
+
+			require.NoError(t, env.serverRepo.Save(ctx, server))
+			env.addChannel(t, &domain.NotificationChannel{
+				Name:    "discord",
+				UserID:  1,
+				Type:    domain.NotificationChannelTypeDiscord,
+				Target:  env.discordURL,
+				Enabled: true,
+			})
+
+			require.NoError(t, env.service.HandleEvent(ctx, test.event))
+			env.service.Wait()
+
+			require.Len(t, *env.discord, 1)
+			assert.Equal(t, "/api/webhooks/1/token", (*env.discord)[0].path)
+			assert.Equal(t, test.want, (*env.discord)[0].body["content"])
+		})
+	}
+}
+
+func TestService_HandleEvent_IgnoresNotNotifiableEvents(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+
+	server := &domain.Server{ID: 10, UUID: uuid.New(), Name: "Public CS"}
+	require.NoError(t, env.serverRepo.Save(ctx, server))
+	env.addChannel(t, &domain.NotificationChannel{Name: "admin", UserID: 1, Enabled: true})
+
+	require.NoError(t, env.service.HandleEvent(ctx, events.ServerCreated(server)))
+	env.service.Wait()
+
+	assert.Empty(t, *env.telegram)
+}
+
+func TestService_HandleEvent_DeletedServer(t *testing.T) {
+	env := setup(t)
+
+	env.addChannel(t, &domain.NotificationChannel{Name: "owner", UserID: 2, Enabled: true})
+	env.addChannel(t, &domain.NotificationChannel{
+		Name:     "owner server",
+		UserID:   2,
+		ServerID: lo.ToPtr(uint(10)),
+		Enabled:  true,
+	})
+
+	require.NoError(t, env.service.HandleEvent(
+		context.Background(),
+		events.ServerCrashed(&domain.Server{ID: 10, UUID: uuid.New()}),
+	))
+	env.service.Wait()
+
+	require.Len(t, *env.telegram, 1)
+	assert.Equal(t, "owner server", (*env.telegram)[0].body["chat_id"])
+	assert.Equal(t, `Server "#10" has crashed.`, (*env.telegram)[0].body["text"])
+}
+
+func TestService_Test(t *testing.T) {
+	env := setup(t)
+
+	err := env.service.Test(context.Background(), &domain.NotificationChannel{
+		Type:     domain.NotificationChannelTypeDiscord,
+		Target:   env.discordURL,
+		Language: "ru",
+	})
+	require.NoError(t, err)
+
+	require.Len(t, *env.discord, 1)
+	assert.Equal(t, "Тестовое уведомление от GameAP.", (*env.discord)[0].body["content"])
+
+	err = env.service.Test(context.Background(), &domain.NotificationChannel{Type: "slack"})
+	require.ErrorIs(t, err, ErrUnsupportedChannelType)
+}
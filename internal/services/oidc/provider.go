@@ -0,0 +1,331 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+)
+
+const (
+	discoveryPath   = "/.well-known/openid-configuration"
+	maxResponseSize = 1 << 20
+	idTokenLeeway   = time.Minute
+	authMethodPost  = "client_secret_post"
+	authMethodBasic = "client_secret_basic"
+)
+
+var signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+type ProviderConfig struct {
+	// Name identifies the provider in the API paths.
+	Name         string
+	DisplayName  string
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	// AutoProvision creates a user on the first login with an unknown account.
+	AutoProvision bool
+	// LinkByEmail links an unknown account to the user with the same verified email.
+	LinkByEmail bool
+	// RolesClaim is the claim with the groups or roles of the account, dots separate nested claims.
+	RolesClaim string
+	// RoleMapping maps the values of RolesClaim to the panel roles.
+	// The roles of the user are synced on each login if it isn't empty.
+	RoleMapping map[string]string
+	// DefaultRoles are assigned to provisioned users and to users without mapped roles.
+	DefaultRoles []string
+}
+
+type discoveryDocument struct {
+	Issuer                string   `json:"issuer"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	JWKSURI               string   `json:"jwks_uri"`
+	TokenAuthMethods      []string `json:"token_endpoint_auth_methods_supported"`
+}
+
+type tokenResponse struct {
+	IDToken     string `json:"id_token"`
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+}
+
+// provider is a configured OpenID Connect provider.
+// The discovery document and the keys are loaded on the first use and kept in memory.
+type provider struct {
+	cfg    ProviderConfig
+	client *http.Client
+
+	mu        sync.Mutex
+	discovery *discoveryDocument
+	keys      map[string]crypto.PublicKey
+}
+
+func newProvider(cfg ProviderConfig, client *http.Client) *provider {
+	return &provider{
+		cfg:    cfg,
+		client: client,
+	}
+}
+
+func (p *provider) loadDiscovery(ctx context.Context) (*discoveryDocument, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	doc := &discoveryDocument{}
+
+	err := p.getJSON(ctx, strings.TrimSuffix(p.cfg.IssuerURL, "/")+discoveryPath, doc)
+	if err != nil {
+		return nil, errors.Wrap(ErrProviderUnavailable, "failed to load discovery document: "+err.Error())
+	}
+
+	if strings.TrimSuffix(doc.Issuer, "/") != strings.TrimSuffix(p.cfg.IssuerURL, "/") {
+		return nil, errors.Errorf("discovery document issuer %q doesn't match %q", doc.Issuer, p.cfg.IssuerURL)
+	}
+
+	if doc.AuthorizationEndpoint == "" || doc.TokenEndpoint == "" || doc.JWKSURI == "" {
+		return nil, errors.New("discovery document misses required endpoints")
+	}
+
+	p.discovery = doc
+
+	return doc, nil
+}
+
+// authorizationURL builds the authorization code request with the PKCE S256 challenge.
+func (p *provider) authorizationURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	doc, err := p.loadDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(doc.AuthorizationEndpoint)
+	if err != nil {
+		return "", errors.WithMessage(err, "invalid authorization endpoint")
+	}
+
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", p.cfg.ClientID)
+	q.Set("redirect_uri", p.cfg.RedirectURL)
+	q.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", codeChallenge)
+	q.Set("code_challenge_method", "S256")
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// exchange redeems the authorization code and returns the raw ID token.
+func (p *provider) exchange(ctx context.Context, code, codeVerifier string) (string, error) {
+	doc, err := p.loadDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+	form.Set("code_verifier", codeVerifier)
+
+	basicAuth := p.cfg.ClientSecret != "" &&
+		slices.Contains(doc.TokenAuthMethods, authMethodBasic) &&
+		!slices.Contains(doc.TokenAuthMethods, authMethodPost)
+
+	if !basicAuth {
+		form.Set("client_id", p.cfg.ClientID)
+
+		if p.cfg.ClientSecret != "" {
+			form.Set("client_secret", p.cfg.ClientSecret)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, doc.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to create token request")
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	if basicAuth {
+		req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", errors.Wrap(ErrProviderUnavailable, "failed to send token request: "+err.Error())
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to read token response")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Wrapf(ErrInvalidGrant, "token endpoint returned status %d: %s", resp.StatusCode, body)
+	}
+
+	token := &tokenResponse{}
+	if err = json.Unmarshal(body, token); err != nil {
+		return "", errors.WithMessage(err, "failed to decode token response")
+	}
+
+	if token.IDToken == "" {
+		return "", errors.New("token response has no id_token")
+	}
+
+	return token.IDToken, nil
+}
+
+// verify checks the signature and the standard claims of the ID token.
+func (p *provider) verify(ctx context.Context, rawIDToken, nonce string) (jwt.MapClaims, error) {
+	doc, err := p.loadDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := jwt.MapClaims{}
+
+	_, err = jwt.ParseWithClaims(
+		rawIDToken,
+		claims,
+		func(token *jwt.Token) (any, error) {
+			kid, _ := token.Header["kid"].(string)
+
+			return p.key(ctx, doc, kid)
+		},
+		jwt.WithValidMethods(signingMethods),
+		jwt.WithIssuer(doc.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(idTokenLeeway),
+	)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidIDToken, err.Error())
+	}
+
+	if claimString(claims, "nonce") != nonce {
+		return nil, errors.Wrap(ErrInvalidIDToken, "nonce mismatch")
+	}
+
+	aud, _ := claims.GetAudience()
+	if len(aud) > 1 && claimString(claims, "azp") != p.cfg.ClientID {
+		return nil, errors.Wrap(ErrInvalidIDToken, "authorized party mismatch")
+	}
+
+	if claimString(claims, "sub") == "" {
+		return nil, errors.Wrap(ErrInvalidIDToken, "subject is empty")
+	}
+
+	return claims, nil
+}
+
+// key returns the signature key by its ID, the key set is reloaded when the key is unknown
+// as the provider may have rotated the keys.
+func (p *provider) key(ctx context.Context, doc *discoveryDocument, kid string) (crypto.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.findKey(kid); ok {
+		return key, nil
+	}
+
+	set := &jsonWebKeySet{}
+	if err := p.getJSON(ctx, doc.JWKSURI, set); err != nil {
+		return nil, errors.WithMessage(err, "failed to load key set")
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			// Keys of unsupported types are skipped
+			continue
+		}
+
+		keys[jwk.Kid] = key
+	}
+
+	p.keys = keys
+
+	if key, ok := p.findKey(kid); ok {
+		return key, nil
+	}
+
+	return nil, errors.Errorf("key %q is not found", kid)
+}
+
+// findKey looks up the key by its ID, tokens without the ID are accepted only with a single key.
+func (p *provider) findKey(kid string) (crypto.PublicKey, bool) {
+	if kid == "" {
+		if len(p.keys) != 1 {
+			return nil, false
+		}
+
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+
+	key, ok := p.keys[kid]
+
+	return key, ok
+}
+
+func (p *provider) getJSON(ctx context.Context, rawURL string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed to create request")
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return errors.WithMessage(err, "failed to send request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	if err = json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(v); err != nil {
+		return errors.WithMessage(err, "failed to decode response")
+	}
+
+	return nil
+}
+
+func claimString(claims jwt.MapClaims, name string) string {
+	s, _ := claims[name].(string)
+
+	return s
+}
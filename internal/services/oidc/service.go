@@ -0,0 +1,529 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/repositories/base"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+const (
+	stateKeyPrefix   = "oidc_state:"
+	randomLength     = 32
+	maxLoginLength   = 64
+	subjectSuffixLen = 6
+)
+
+var (
+	ErrUnknownProvider     = errors.New("identity provider is not configured")
+	ErrInvalidState        = errors.New("login state is invalid or expired")
+	ErrInvalidGrant        = errors.New("authorization code is invalid or expired")
+	ErrInvalidIDToken      = errors.New("id token is invalid")
+	ErrProviderUnavailable = errors.New("identity provider is unavailable")
+	ErrNotLinked           = errors.New("account is not linked to a user")
+	ErrEmailRequired       = errors.New("account has no email")
+	ErrUserDisabled        = errors.New("user is disabled")
+)
+
+var loginDisallowedChars = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+type rbac interface {
+	SetRolesToUser(ctx context.Context, userID uint, roleNames []string) error
+}
+
+type Config struct {
+	// StateTTL is how long the login at the identity provider may take.
+	StateTTL time.Duration
+}
+
+// ProviderInfo is a provider shown on the login page.
+type ProviderInfo struct {
+	Name        string
+	DisplayName string
+}
+
+// loginState is kept in the cache between the authorization request and the callback.
+type loginState struct {
+	Provider     string `json:"provider"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+}
+
+// Service logs users in with OpenID Connect providers using the authorization code flow with PKCE.
+//
+// Accounts are linked to users by the provider name and the subject claim.
+// Unknown accounts are linked by the verified email or provisioned as new users if the provider allows it.
+type Service struct {
+	providers    map[string]*provider
+	order        []string
+	identityRepo repositories.UserIdentityRepository
+	userRepo     repositories.UserRepository
+	rbac         rbac
+	tm           base.TransactionManager
+	cache        cache.Cache
+	cfg          Config
+
+	now func() time.Time
+}
+
+func NewService(
+	providers []ProviderConfig,
+	client *http.Client,
+	identityRepo repositories.UserIdentityRepository,
+	userRepo repositories.UserRepository,
+	rbac rbac,
+	tm base.TransactionManager,
+	c cache.Cache,
+	cfg Config,
+) *Service {
+	s := &Service{
+		providers:    make(map[string]*provider, len(providers)),
+		order:        make([]string, 0, len(providers)),
+		identityRepo: identityRepo,
+		userRepo:     userRepo,
+		rbac:         rbac,
+		tm:           tm,
+		cache:        c,
+		cfg:          cfg,
+		now:          time.Now,
+	}
+
+	for _, p := range providers {
+		s.providers[p.Name] = newProvider(p, client)
+		s.order = append(s.order, p.Name)
+	}
+
+	return s
+}
+
+// Providers returns the configured providers in the configuration order.
+func (s *Service) Providers() []ProviderInfo {
+	result := make([]ProviderInfo, 0, len(s.order))
+
+	for _, name := range s.order {
+		p := s.providers[name]
+
+		displayName := p.cfg.DisplayName
+		if displayName == "" {
+			displayName = p.cfg.Name
+		}
+
+		result = append(result, ProviderInfo{
+			Name:        p.cfg.Name,
+			DisplayName: displayName,
+		})
+	}
+
+	return result
+}
+
+// AuthorizationURL starts the login and returns the provider URL the user is redirected to.
+func (s *Service) AuthorizationURL(ctx context.Context, providerName string) (string, error) {
+	p, ok := s.providers[providerName]
+	if !ok {
+		return "", ErrUnknownProvider
+	}
+
+	state, err := randomString()
+	if err != nil {
+		return "", err
+	}
+
+	nonce, err := randomString()
+	if err != nil {
+		return "", err
+	}
+
+	codeVerifier, err := randomString()
+	if err != nil {
+		return "", err
+	}
+
+	challenge := sha256.Sum256([]byte(codeVerifier))
+
+	authURL, err := p.authorizationURL(ctx, state, nonce, base64.RawURLEncoding.EncodeToString(challenge[:]))
+	if err != nil {
+		return "", err
+	}
+
+	value, err := json.Marshal(loginState{
+		Provider:     providerName,
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+	})
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to marshal login state")
+	}
+
+	err = s.cache.Set(ctx, stateKeyPrefix+state, string(value), cache.WithExpiration(s.cfg.StateTTL))
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to save login state")
+	}
+
+	return authURL, nil
+}
+
+// Authenticate completes the login with the code returned by the provider to the redirect URL.
+// It returns the linked user, the user is linked or provisioned on the first login.
+func (s *Service) Authenticate(ctx context.Context, providerName, state, code string) (*domain.User, error) {
+	p, ok := s.providers[providerName]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+
+	loginState, err := s.takeState(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+
+	if loginState.Provider != providerName {
+		return nil, ErrInvalidState
+	}
+
+	rawIDToken, err := p.exchange(ctx, code, loginState.CodeVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := p.verify(ctx, rawIDToken, loginState.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	var user *domain.User
+
+	err = s.tm.Do(ctx, func(ctx context.Context) error {
+		var created bool
+
+		user, created, err = s.resolveUser(ctx, p, claims)
+		if err != nil {
+			return err
+		}
+
+		if user.Disabled() {
+			return ErrUserDisabled
+		}
+
+		return s.syncRoles(ctx, p, user.ID, claims, created)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// takeState loads and deletes the login state, so it can be used once.
+func (s *Service) takeState(ctx context.Context, state string) (*loginState, error) {
+	if state == "" {
+		return nil, ErrInvalidState
+	}
+
+	value, err := s.cache.Get(ctx, stateKeyPrefix+state)
+	if err != nil {
+		if errors.Is(err, cache.ErrNotFound) {
+			return nil, ErrInvalidState
+		}
+
+		return nil, errors.WithMessage(err, "failed to get login state")
+	}
+
+	if err = s.cache.Delete(ctx, stateKeyPrefix+state); err != nil {
+		return nil, errors.WithMessage(err, "failed to delete login state")
+	}
+
+	raw, ok := value.(string)
+	if !ok {
+		return nil, ErrInvalidState
+	}
+
+	result := &loginState{}
+	if err = json.Unmarshal([]byte(raw), result); err != nil {
+		return nil, ErrInvalidState
+	}
+
+	return result, nil
+}
+
+// resolveUser finds the user linked to the account, links it by the verified email
+// or provisions a new user. The created result is set for provisioned users.
+func (s *Service) resolveUser(
+	ctx context.Context, p *provider, claims jwt.MapClaims,
+) (*domain.User, bool, error) {
+	subject := claimString(claims, "sub")
+	email := strings.TrimSpace(claimString(claims, "email"))
+
+	identities, err := s.identityRepo.Find(ctx, &filters.FindUserIdentity{
+		Providers: []string{p.cfg.Name},
+		Subjects:  []string{subject},
+	}, nil, &filters.Pagination{Limit: 1})
+	if err != nil {
+		return nil, false, errors.WithMessage(err, "failed to find user identity")
+	}
+
+	var user *domain.User
+
+	if len(identities) > 0 {
+		// The linked user may have been deleted, the account is linked again in this case
+		user, err = s.findUser(ctx, &filters.FindUser{IDs: []uint{identities[0].UserID}})
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	if user == nil && email != "" {
+		user, err = s.findUser(ctx, &filters.FindUser{Emails: []string{email}})
+		if err != nil {
+			return nil, false, err
+		}
+
+		if user != nil && (!p.cfg.LinkByEmail || !emailVerified(claims)) {
+			return nil, false, errors.Wrap(ErrNotLinked, "user with the same email exists")
+		}
+	}
+
+	created := false
+
+	if user == nil {
+		if !p.cfg.AutoProvision {
+			return nil, false, ErrNotLinked
+		}
+
+		if email == "" {
+			return nil, false, ErrEmailRequired
+		}
+
+		user, err = s.provisionUser(ctx, claims, email)
+		if err != nil {
+			return nil, false, err
+		}
+
+		created = true
+	}
+
+	now := s.now()
+
+	identity := &domain.UserIdentity{
+		Provider:  p.cfg.Name,
+		Subject:   subject,
+		UserID:    user.ID,
+		Email:     email,
+		CreatedAt: &now,
+		UpdatedAt: &now,
+	}
+
+	if err = s.identityRepo.Save(ctx, identity); err != nil {
+		return nil, false, errors.WithMessage(err, "failed to save user identity")
+	}
+
+	return user, created, nil
+}
+
+func (s *Service) provisionUser(ctx context.Context, claims jwt.MapClaims, email string) (*domain.User, error) {
+	login, err := s.availableLogin(ctx, claims, email)
+	if err != nil {
+		return nil, err
+	}
+
+	// The user logs in with the provider, the random password is only a placeholder
+	password, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := auth.HashPassword(password)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to hash password")
+	}
+
+	now := s.now()
+
+	user := &domain.User{
+		Login:     login,
+		Email:     email,
+		Password:  hashedPassword,
+		CreatedAt: &now,
+		UpdatedAt: &now,
+	}
+
+	if name := strings.TrimSpace(claimString(claims, "name")); name != "" {
+		user.Name = lo.ToPtr(name)
+	}
+
+	if err = s.userRepo.Save(ctx, user); err != nil {
+		return nil, errors.WithMessage(err, "failed to save user")
+	}
+
+	return user, nil
+}
+
+// availableLogin derives the login from the preferred username or the email.
+// A suffix derived from the subject is added when the login is taken.
+func (s *Service) availableLogin(ctx context.Context, claims jwt.MapClaims, email string) (string, error) {
+	login := claimString(claims, "preferred_username")
+	if login == "" {
+		login, _, _ = strings.Cut(email, "@")
+	}
+
+	login = loginDisallowedChars.ReplaceAllString(strings.ToLower(login), "")
+	if len(login) > maxLoginLength {
+		login = login[:maxLoginLength]
+	}
+
+	if login == "" {
+		login = "user"
+	}
+
+	user, err := s.findUser(ctx, &filters.FindUser{Logins: []string{login}})
+	if err != nil {
+		return "", err
+	}
+
+	if user == nil {
+		return login, nil
+	}
+
+	hash := sha256.Sum256([]byte(claimString(claims, "sub")))
+	login = login + "-" + hex.EncodeToString(hash[:])[:subjectSuffixLen]
+
+	user, err = s.findUser(ctx, &filters.FindUser{Logins: []string{login}})
+	if err != nil {
+		return "", err
+	}
+
+	if user != nil {
+		return "", errors.Errorf("login %q is already taken", login)
+	}
+
+	return login, nil
+}
+
+// syncRoles assigns the mapped roles on each login if the role mapping is configured,
+// otherwise the default roles are assigned only to provisioned users.
+func (s *Service) syncRoles(
+	ctx context.Context, p *provider, userID uint, claims jwt.MapClaims, created bool,
+) error {
+	var roles []string
+
+	if len(p.cfg.RoleMapping) > 0 {
+		roles = mapRoles(claimValues(claims, p.cfg.RolesClaim), p.cfg.RoleMapping)
+	} else if !created {
+		return nil
+	}
+
+	if len(roles) == 0 {
+		roles = p.cfg.DefaultRoles
+	}
+
+	if len(roles) == 0 && created {
+		return nil
+	}
+
+	if err := s.rbac.SetRolesToUser(ctx, userID, roles); err != nil {
+		return errors.WithMessage(err, "failed to set user roles")
+	}
+
+	return nil
+}
+
+func (s *Service) findUser(ctx context.Context, filter *filters.FindUser) (*domain.User, error) {
+	users, err := s.userRepo.Find(ctx, filter, nil, &filters.Pagination{Limit: 1})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find user")
+	}
+
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	return &users[0], nil
+}
+
+func mapRoles(values []string, mapping map[string]string) []string {
+	roles := make([]string, 0, len(values))
+
+	for _, value := range values {
+		if role, ok := mapping[value]; ok && !slices.Contains(roles, role) {
+			roles = append(roles, role)
+		}
+	}
+
+	slices.Sort(roles)
+
+	return roles
+}
+
+// claimValues returns the string values of the claim, dots in the name separate nested claims,
+// for example "realm_access.roles".
+func claimValues(claims jwt.MapClaims, name string) []string {
+	if name == "" {
+		return nil
+	}
+
+	var value any = map[string]any(claims)
+
+	for part := range strings.SplitSeq(name, ".") {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		value = m[part]
+	}
+
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		values := make([]string, 0, len(v))
+
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+
+		return values
+	default:
+		return nil
+	}
+}
+
+// emailVerified reports whether the provider has verified the email,
+// some providers send the claim as a string.
+func emailVerified(claims jwt.MapClaims) bool {
+	switch v := claims["email_verified"].(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	default:
+		return false
+	}
+}
+
+func randomString() (string, error) {
+	b := make([]byte, randomLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.WithMessage(err, "failed to generate random string")
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
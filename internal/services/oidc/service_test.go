@@ -0,0 +1,406 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/oidc/oidctest"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRBAC struct {
+	roles map[uint][]string
+}
+
+func (f *fakeRBAC) SetRolesToUser(_ context.Context, userID uint, roleNames []string) error {
+	f.roles[userID] = roleNames
+
+	return nil
+}
+
+type testEnv struct {
+	service      *Service
+	server       *oidctest.Server
+	identityRepo *inmemory.UserIdentityRepository
+	userRepo     *inmemory.UserRepository
+	rbac         *fakeRBAC
+}
+
+func setup(t *testing.T, configure func(cfg *ProviderConfig)) *testEnv {
+	t.Helper()
+
+	server := oidctest.NewServer(t, "gameap", "secret")
+
+	cfg := ProviderConfig{
+		Name:         "keycloak",
+		DisplayName:  "Company SSO",
+		IssuerURL:    server.Issuer(),
+		ClientID:     "gameap",
+		ClientSecret: "secret",
+		RedirectURL:  "https://panel.example.com/auth/oidc/keycloak/callback",
+		Scopes:       []string{"openid", "profile", "email"},
+		LinkByEmail:  true,
+		RolesClaim:   "groups",
+		DefaultRoles: []string{"user"},
+	}
+
+	if configure != nil {
+		configure(&cfg)
+	}
+
+	env := &testEnv{
+		server:       server,
+		identityRepo: inmemory.NewUserIdentityRepository(),
+		userRepo:     inmemory.NewUserRepository(),
+		rbac:         &fakeRBAC{roles: map[uint][]string{}},
+	}
+
+	env.service = NewService(
+		[]ProviderConfig{cfg},
+		http.DefaultClient,
+		env.identityRepo,
+		env.userRepo,
+		env.rbac,
+		services.NewNilTransactionManager(),
+		cache.NewInMemory(),
+		Config{StateTTL: 10 * time.Minute},
+	)
+
+	return env
+}
+
+func (e *testEnv) login(t *testing.T, claims jwt.MapClaims) (*domain.User, error) {
+	t.Helper()
+
+	authURL, err := e.service.AuthorizationURL(context.Background(), "keycloak")
+	require.NoError(t, err)
+
+	code, state := e.server.Login(t, authURL, claims)
+
+	return e.service.Authenticate(context.Background(), "keycloak", state, code)
+}
+
+func TestService_Providers(t *testing.T) {
+	env := setup(t, nil)
+
+	assert.Equal(t, []ProviderInfo{{Name: "keycloak", DisplayName: "Company SSO"}}, env.service.Providers())
+}
+
+func TestService_AuthorizationURL_UnknownProvider(t *testing.T) {
+	env := setup(t, nil)
+
+	_, err := env.service.AuthorizationURL(context.Background(), "unknown")
+	require.ErrorIs(t, err, ErrUnknownProvider)
+}
+
+func TestService_Authenticate_AutoProvision(t *testing.T) {
+	env := setup(t, func(cfg *ProviderConfig) {
+		cfg.AutoProvision = true
+	})
+
+	user, err := env.login(t, jwt.MapClaims{
+		"sub":                "5f1c0a3e",
+		"email":              "john@example.com",
+		"preferred_username": "John.Doe",
+		"name":               "John Doe",
+	})
+	require.NoError(t, err)
+	require.NotZero(t, user.ID)
+	assert.Equal(t, "john.doe", user.Login)
+	assert.Equal(t, "john@example.com", user.Email)
+	require.NotNil(t, user.Name)
+	assert.Equal(t, "John Doe", *user.Name)
+	assert.NotEmpty(t, user.Password)
+	assert.Equal(t, []string{"user"}, env.rbac.roles[user.ID])
+
+	identities, err := env.identityRepo.Find(context.Background(), &filters.FindUserIdentity{
+		UserIDs: []uint{user.ID},
+	}, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, identities, 1)
+	assert.Equal(t, "keycloak", identities[0].Provider)
+	assert.Equal(t, "5f1c0a3e", identities[0].Subject)
+
+	// The second login finds the linked user even if the email has changed
+	again, err := env.login(t, jwt.MapClaims{
+		"sub":   "5f1c0a3e",
+		"email": "john.doe@example.com",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, again.ID)
+
+	users, err := env.userRepo.FindAll(context.Background(), nil, nil)
+	require.NoError(t, err)
+	assert.Len(t, users, 1)
+}
+
+func TestService_Authenticate_AutoProvision_LoginTaken(t *testing.T) {
+	env := setup(t, func(cfg *ProviderConfig) {
+		cfg.AutoProvision = true
+	})
+
+	require.NoError(t, env.userRepo.Save(context.Background(), &domain.User{
+		Login: "john",
+		Email: "other@example.com",
+	}))
+
+	user, err := env.login(t, jwt.MapClaims{
+		"sub":   "5f1c0a3e",
+		"email": "john@example.com",
+	})
+	require.NoError(t, err)
+	assert.Regexp(t, `^john-[0-9a-f]{6}$`, user.Login)
+}
+
+func TestService_Authenticate_AutoProvisionDisabled(t *testing.T) {
+	env := setup(t, nil)
+
+	_, err := env.login(t, jwt.MapClaims{
+		"sub":   "5f1c0a3e",
+		"email": "john@example.com",
+	})
+	require.ErrorIs(t, err, ErrNotLinked)
+
+	users, err := env.userRepo.FindAll(context.Background(), nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, users)
+}
+
+func TestService_Authenticate_EmailRequired(t *testing.T) {
+	env := setup(t, func(cfg *ProviderConfig) {
+		cfg.AutoProvision = true
+	})
+
+	_, err := env.login(t, jwt.MapClaims{"sub": "5f1c0a3e"})
+	require.ErrorIs(t, err, ErrEmailRequired)
+}
+
+func TestService_Authenticate_LinkByEmail(t *testing.T) {
+	tests := []struct {
+		name        string
+		linkByEmail bool
+		verified    any
+		wantErr     error
+	}{
+		{name: "verified", linkByEmail: true, verified: true},
+		{name: "verified_string", linkByEmail: true, verified: "true"},
+		{name: "not_verified", linkByEmail: true, verified: false, wantErr: ErrNotLinked},
+		{name: "linking_disabled", linkByEmail: false, verified: true, wantErr: ErrNotLinked},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			env := setup(t, func(cfg *ProviderConfig) {
+				cfg.LinkByEmail = test.linkByEmail
+				cfg.AutoProvision = true
+			})
+
+			existing := &domain.User{Login: "john", Email: "john@example.com"}
+			require.NoError(t, env.userRepo.Save(context.Background(), existing))
+
+			user, err := env.login(t, jwt.MapClaims{
+				"sub":            "5f1c0a3e",
+				"email":          "john@example.com",
+				"email_verified": test.verified,
+			})
+
+			if test.wantErr != nil {
+				require.ErrorIs(t, err, test.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, existing.ID, user.ID)
+			// Roles of the existing users aren't changed without the role mapping
+			assert.NotContains(t, env.rbac.roles, existing.ID)
+		})
+	}
+}
+
+func TestService_Authenticate_DisabledUser(t *testing.T) {
+	env := setup(t, nil)
+
+	now := time.Now()
+	existing := &domain.User{Login: "john", Email: "john@example.com", DisabledAt: &now}
+	require.NoError(t, env.userRepo.Save(context.Background(), existing))
+
+	_, err := env.login(t, jwt.MapClaims{
+		"sub":            "5f1c0a3e",
+		"email":          "john@example.com",
+		"email_verified": true,
+	})
+	require.ErrorIs(t, err, ErrUserDisabled)
+}
+
+func TestService_Authenticate_RoleMapping(t *testing.T) {
+	env := setup(t, func(cfg *ProviderConfig) {
+		cfg.AutoProvision = true
+		cfg.RolesClaim = "realm_access.roles"
+		cfg.RoleMapping = map[string]string{
+			"gameap-admins": "admin",
+			"players":       "user",
+		}
+	})
+
+	user, err := env.login(t, jwt.MapClaims{
+		"sub":   "5f1c0a3e",
+		"email": "john@example.com",
+		"realm_access": map[string]any{
+			"roles": []any{"players", "gameap-admins", "offline_access"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admin", "user"}, env.rbac.roles[user.ID])
+
+	// The roles are synced on each login, the default roles are used without mapped values
+	_, err = env.login(t, jwt.MapClaims{
+		"sub":          "5f1c0a3e",
+		"email":        "john@example.com",
+		"realm_access": map[string]any{"roles": []any{"offline_access"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user"}, env.rbac.roles[user.ID])
+}
+
+func TestService_Authenticate_InvalidState(t *testing.T) {
+	env := setup(t, func(cfg *ProviderConfig) {
+		cfg.AutoProvision = true
+	})
+
+	authURL, err := env.service.AuthorizationURL(context.Background(), "keycloak")
+	require.NoError(t, err)
+
+	code, state := env.server.Login(t, authURL, jwt.MapClaims{"sub": "5f1c0a3e", "email": "john@example.com"})
+
+	_, err = env.service.Authenticate(context.Background(), "keycloak", "unknown", code)
+	require.ErrorIs(t, err, ErrInvalidState)
+
+	_, err = env.service.Authenticate(context.Background(), "keycloak", state, code)
+	require.NoError(t, err)
+
+	// The state can be used once
+	_, err = env.service.Authenticate(context.Background(), "keycloak", state, code)
+	require.ErrorIs(t, err, ErrInvalidState)
+}
+
+func TestService_Authenticate_InvalidCode(t *testing.T) {
+	env := setup(t, nil)
+
+	authURL, err := env.service.AuthorizationURL(context.Background(), "keycloak")
+	require.NoError(t, err)
+
+	_, state := env.server.Login(t, authURL, jwt.MapClaims{"sub": "5f1c0a3e"})
+
+	_, err = env.service.Authenticate(context.Background(), "keycloak", state, "invalid")
+	require.ErrorIs(t, err, ErrInvalidGrant)
+}
+
+func TestService_Authenticate_WrongClientSecret(t *testing.T) {
+	env := setup(t, func(cfg *ProviderConfig) {
+		cfg.ClientSecret = "wrong"
+	})
+
+	_, err := env.login(t, jwt.MapClaims{"sub": "5f1c0a3e"})
+	require.ErrorIs(t, err, ErrInvalidGrant)
+}
+
+func TestService_Authenticate_ProviderUnavailable(t *testing.T) {
+	env := setup(t, nil)
+	env.server.Close()
+
+	_, err := env.service.AuthorizationURL(context.Background(), "keycloak")
+	require.ErrorIs(t, err, ErrProviderUnavailable)
+}
+
+func TestProvider_Verify(t *testing.T) {
+	env := setup(t, nil)
+	p := env.service.providers["keycloak"]
+	now := time.Now()
+
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":   env.server.Issuer(),
+			"aud":   "gameap",
+			"sub":   "5f1c0a3e",
+			"iat":   now.Unix(),
+			"exp":   now.Add(time.Minute).Unix(),
+			"nonce": "nonce",
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(claims jwt.MapClaims)
+		wantErr bool
+	}{
+		{name: "valid", modify: func(jwt.MapClaims) {}},
+		{name: "wrong_nonce", modify: func(c jwt.MapClaims) { c["nonce"] = "other" }, wantErr: true},
+		{name: "wrong_audience", modify: func(c jwt.MapClaims) { c["aud"] = "other" }, wantErr: true},
+		{name: "wrong_issuer", modify: func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" }, wantErr: true},
+		{name: "expired", modify: func(c jwt.MapClaims) { c["exp"] = now.Add(-time.Hour).Unix() }, wantErr: true},
+		{name: "no_expiration", modify: func(c jwt.MapClaims) { delete(c, "exp") }, wantErr: true},
+		{name: "no_subject", modify: func(c jwt.MapClaims) { delete(c, "sub") }, wantErr: true},
+		{
+			name:    "multiple_audiences_without_azp",
+			modify:  func(c jwt.MapClaims) { c["aud"] = []any{"gameap", "other"} },
+			wantErr: true,
+		},
+		{
+			name: "multiple_audiences_with_azp",
+			modify: func(c jwt.MapClaims) {
+				c["aud"] = []any{"gameap", "other"}
+				c["azp"] = "gameap"
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			claims := validClaims()
+			test.modify(claims)
+
+			_, err := p.verify(context.Background(), env.server.SignIDToken(t, claims), "nonce")
+			if test.wantErr {
+				require.ErrorIs(t, err, ErrInvalidIDToken)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("unsigned", func(t *testing.T) {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodNone, validClaims()).
+			SignedString(jwt.UnsafeAllowNoneSignatureType)
+		require.NoError(t, err)
+
+		_, err = p.verify(context.Background(), token, "nonce")
+		require.ErrorIs(t, err, ErrInvalidIDToken)
+	})
+}
+
+func TestClaimValues(t *testing.T) {
+	claims := jwt.MapClaims{
+		"groups":       []any{"/admins", 1, "players"},
+		"role":         "admin",
+		"realm_access": map[string]any{"roles": []any{"offline_access"}},
+	}
+
+	assert.Equal(t, []string{"/admins", "players"}, claimValues(claims, "groups"))
+	assert.Equal(t, []string{"admin"}, claimValues(claims, "role"))
+	assert.Equal(t, []string{"offline_access"}, claimValues(claims, "realm_access.roles"))
+	assert.Nil(t, claimValues(claims, "realm_access.unknown"))
+	assert.Nil(t, claimValues(claims, "role.nested"))
+	assert.Nil(t, claimValues(claims, ""))
+}
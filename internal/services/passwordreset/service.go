@@ -0,0 +1,320 @@
+package passwordreset
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/mail"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+const tokenLength = 32
+
+var (
+	ErrNotConfigured = errors.New("password reset is not configured")
+	ErrInvalidToken  = errors.New("password reset token is invalid")
+)
+
+// TooManyAttemptsError is returned when the client has exceeded the attempts limit.
+type TooManyAttemptsError struct {
+	RetryAfter time.Duration
+}
+
+func (e *TooManyAttemptsError) Error() string {
+	return "too many password reset attempts"
+}
+
+type rateLimiter interface {
+	Hit(ctx context.Context, key string) (bool, time.Duration, error)
+}
+
+type translator interface {
+	Translate(lang, key string, params map[string]string) string
+}
+
+type Config struct {
+	// URL is the link to the reset page, ":token" and ":email" are replaced with the query escaped values.
+	URL string
+	// TTL is how long a reset token is valid.
+	TTL time.Duration
+	// Throttle is the minimal interval between the emails sent to the same address.
+	Throttle time.Duration
+}
+
+// Service resets forgotten passwords with the tokens sent by email.
+//
+// Tokens are stored in the password_resets table as bcrypt hashes, one token per email.
+// Emails are stored trimmed and lowercased, so the token is found regardless of the email case.
+// Both steps are rate limited by the client IP.
+type Service struct {
+	userRepo   repositories.UserRepository
+	resetRepo  repositories.PasswordResetRepository
+	mailer     mail.Mailer
+	translator translator
+	limiter    rateLimiter
+	cfg        Config
+
+	wg sync.WaitGroup
+}
+
+// NewService creates the service. The mailer is nil when email delivery isn't configured.
+func NewService(
+	userRepo repositories.UserRepository,
+	resetRepo repositories.PasswordResetRepository,
+	mailer mail.Mailer,
+	translator translator,
+	limiter rateLimiter,
+	cfg Config,
+) *Service {
+	return &Service{
+		userRepo:   userRepo,
+		resetRepo:  resetRepo,
+		mailer:     mailer,
+		translator: translator,
+		limiter:    limiter,
+		cfg:        cfg,
+	}
+}
+
+// Enabled reports whether the reset links can be sent.
+func (s *Service) Enabled() bool {
+	return s.mailer != nil && s.cfg.URL != ""
+}
+
+// Forgot sends the reset link to the email in the language.
+//
+// The email is sent in background and the result doesn't depend on whether the user exists,
+// so the endpoint can't be used to find out registered emails.
+func (s *Service) Forgot(ctx context.Context, clientIP, email, lang string) error {
+	if !s.Enabled() {
+		return ErrNotConfigured
+	}
+
+	if err := s.hit(ctx, clientIP); err != nil {
+		return err
+	}
+
+	// Sending outlives the request, keep only the context values
+	ctx = context.WithoutCancel(ctx)
+
+	s.wg.Add(1)
+
+	go func() {
+		defer s.wg.Done()
+
+		if err := s.sendResetLink(ctx, email, lang); err != nil {
+			slog.WarnContext(ctx, "Failed to send password reset link", slog.String("error", err.Error()))
+		}
+	}()
+
+	return nil
+}
+
+// Reset sets the new password of the user if the token is valid. The token can be used once.
+func (s *Service) Reset(ctx context.Context, clientIP, email, token, password string) error {
+	if err := s.hit(ctx, clientIP); err != nil {
+		return err
+	}
+
+	user, err := s.findUser(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	email = normalizeEmail(email)
+
+	resets, err := s.resetRepo.Find(ctx, &filters.FindPasswordReset{Emails: []string{email}}, nil, nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed to find password reset")
+	}
+
+	if len(resets) == 0 {
+		return ErrInvalidToken
+	}
+
+	reset := resets[0]
+
+	if reset.Expired(time.Now(), s.cfg.TTL) {
+		if err = s.resetRepo.Delete(ctx, email); err != nil {
+			return errors.WithMessage(err, "failed to delete expired password reset")
+		}
+
+		return ErrInvalidToken
+	}
+
+	if err = auth.VerifyPassword(reset.Token, token); err != nil {
+		return ErrInvalidToken
+	}
+
+	if user == nil || normalizeEmail(user.Email) != email {
+		if err = s.resetRepo.Delete(ctx, email); err != nil {
+			return errors.WithMessage(err, "failed to delete password reset")
+		}
+
+		return ErrInvalidToken
+	}
+
+	hashedPassword, err := auth.HashPassword(password)
+	if err != nil {
+		return errors.WithMessage(err, "failed to hash password")
+	}
+
+	user.Password = hashedPassword
+	user.UpdatedAt = lo.ToPtr(time.Now())
+
+	if err = s.userRepo.Save(ctx, user); err != nil {
+		return errors.WithMessage(err, "failed to save user")
+	}
+
+	if err = s.resetRepo.Delete(ctx, email); err != nil {
+		return errors.WithMessage(err, "failed to delete password reset")
+	}
+
+	return nil
+}
+
+// Wait blocks until all background emails are sent.
+func (s *Service) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Service) hit(ctx context.Context, clientIP string) error {
+	allowed, retryAfter, err := s.limiter.Hit(ctx, clientIP)
+	if err != nil {
+		return errors.WithMessage(err, "failed to check rate limit")
+	}
+
+	if !allowed {
+		return &TooManyAttemptsError{RetryAfter: retryAfter}
+	}
+
+	return nil
+}
+
+func (s *Service) sendResetLink(ctx context.Context, email, lang string) error {
+	user, err := s.findUser(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return nil
+	}
+
+	email = normalizeEmail(user.Email)
+
+	resets, err := s.resetRepo.Find(ctx, &filters.FindPasswordReset{Emails: []string{email}}, nil, nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed to find password reset")
+	}
+
+	if len(resets) > 0 && !resets[0].Expired(time.Now(), s.cfg.Throttle) {
+		slog.InfoContext(ctx, "Password reset link was sent recently, skipped", slog.Uint64("user_id", uint64(user.ID)))
+
+		return nil
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return err
+	}
+
+	hashedToken, err := auth.HashPassword(token)
+	if err != nil {
+		return errors.WithMessage(err, "failed to hash token")
+	}
+
+	err = s.resetRepo.Save(ctx, &domain.PasswordReset{
+		Email:     email,
+		Token:     hashedToken,
+		CreatedAt: lo.ToPtr(time.Now()),
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed to save password reset")
+	}
+
+	msg, err := s.message(user, token, lang)
+	if err != nil {
+		return err
+	}
+
+	if err = s.mailer.Send(ctx, msg); err != nil {
+		// The user can request a new link right away instead of waiting for the throttle
+		if deleteErr := s.resetRepo.Delete(ctx, email); deleteErr != nil {
+			slog.WarnContext(ctx, "Failed to delete password reset", slog.String("error", deleteErr.Error()))
+		}
+
+		return errors.WithMessage(err, "failed to send email")
+	}
+
+	return nil
+}
+
+func (s *Service) message(user *domain.User, token, lang string) (*mail.Message, error) {
+	link := strings.NewReplacer(
+		":token", url.QueryEscape(token),
+		":email", url.QueryEscape(user.Email),
+	).Replace(s.cfg.URL)
+
+	name := user.Login
+	if user.Name != nil && *user.Name != "" {
+		name = *user.Name
+	}
+
+	return mail.NewTemplatedMessage(user.Email, mail.Content{
+		Subject:        s.translator.Translate(lang, "mail.password_reset.subject", nil),
+		Greeting:       s.translator.Translate(lang, "mail.greeting", map[string]string{"name": name}),
+		Intro:          []string{s.translator.Translate(lang, "mail.password_reset.intro", nil)},
+		ActionText:     s.translator.Translate(lang, "mail.password_reset.action", nil),
+		ActionURL:      link,
+		ActionFallback: s.translator.Translate(lang, "mail.action_fallback", map[string]string{"url": link}),
+		Outro: []string{
+			s.translator.Translate(lang, "mail.password_reset.expire", map[string]string{
+				"minutes": strconv.Itoa(int(s.cfg.TTL.Minutes())),
+			}),
+			s.translator.Translate(lang, "mail.password_reset.outro", nil),
+		},
+		Salutation: s.translator.Translate(lang, "mail.regards", nil),
+	})
+}
+
+// findUser finds the user by the email as entered or by the normalized email.
+func (s *Service) findUser(ctx context.Context, email string) (*domain.User, error) {
+	emails := lo.Uniq([]string{strings.TrimSpace(email), normalizeEmail(email)})
+
+	users, err := s.userRepo.Find(ctx, filters.FindUserByEmails(emails...), nil, &filters.Pagination{Limit: 1})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find user")
+	}
+
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	return &users[0], nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func newToken() (string, error) {
+	b := make([]byte, tokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.WithMessage(err, "failed to generate token")
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,317 @@
+package passwordreset
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/i18n"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services/mail"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testResetURL = "https://panel.example.com/password/reset/:token?email=:email"
+
+var linkTokenPattern = regexp.MustCompile(`/password/reset/([0-9a-f]+)\?email=`)
+
+type fakeMailer struct {
+	mu   sync.Mutex
+	sent []*mail.Message
+	err  error
+}
+
+func (m *fakeMailer) Send(_ context.Context, msg *mail.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.err != nil {
+		return m.err
+	}
+
+	m.sent = append(m.sent, msg)
+
+	return nil
+}
+
+func (m *fakeMailer) messages() []*mail.Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.sent
+}
+
+type testEnv struct {
+	service   *Service
+	userRepo  *inmemory.UserRepository
+	resetRepo *inmemory.PasswordResetRepository
+	mailer    *fakeMailer
+}
+
+func setup(t *testing.T, limit int) *testEnv {
+	t.Helper()
+
+	translator, err := i18n.NewTranslator()
+	require.NoError(t, err)
+
+	env := &testEnv{
+		userRepo:  inmemory.NewUserRepository(),
+		resetRepo: inmemory.NewPasswordResetRepository(),
+		mailer:    &fakeMailer{},
+	}
+
+	password, err := auth.HashPassword("old-password")
+	require.NoError(t, err)
+
+	require.NoError(t, env.userRepo.Save(context.Background(), &domain.User{
+		Login:    "admin",
+		Email:    "admin@example.com",
+		Password: password,
+		Name:     lo.ToPtr("Admin"),
+	}))
+
+	env.service = NewService(
+		env.userRepo,
+		env.resetRepo,
+		env.mailer,
+		translator,
+		cache.NewRateLimiter(cache.NewInMemory(), "password_reset", limit, time.Minute),
+		Config{
+			URL:      testResetURL,
+			TTL:      time.Hour,
+			Throttle: time.Minute,
+		},
+	)
+
+	return env
+}
+
+func (env *testEnv) requestToken(t *testing.T) string {
+	t.Helper()
+
+	require.NoError(t, env.service.Forgot(context.Background(), "127.0.0.1", "admin@example.com", "en"))
+	env.service.Wait()
+
+	messages := env.mailer.messages()
+	require.NotEmpty(t, messages)
+
+	matches := linkTokenPattern.FindStringSubmatch(messages[len(messages)-1].Text)
+	require.Len(t, matches, 2)
+
+	return matches[1]
+}
+
+func TestService_Forgot(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("sends_localized_link", func(t *testing.T) {
+		env := setup(t, 10)
+
+		require.NoError(t, env.service.Forgot(ctx, "127.0.0.1", "admin@example.com", "ru"))
+		env.service.Wait()
+
+		messages := env.mailer.messages()
+		require.Len(t, messages, 1)
+		assert.Equal(t, "admin@example.com", messages[0].To)
+		assert.Equal(t, "Сброс пароля", messages[0].Subject)
+		assert.Contains(t, messages[0].Text, "Здравствуйте, Admin!")
+		assert.Contains(t, messages[0].Text, "60 мин.")
+		assert.Contains(t, messages[0].Text, "?email=admin%40example.com")
+
+		token := linkTokenPattern.FindStringSubmatch(messages[0].Text)[1]
+
+		resets, err := env.resetRepo.Find(ctx, &filters.FindPasswordReset{Emails: []string{"admin@example.com"}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, resets, 1)
+		assert.NotEqual(t, token, resets[0].Token, "token must be stored hashed")
+		require.NoError(t, auth.VerifyPassword(resets[0].Token, token))
+	})
+
+	t.Run("stores_normalized_email", func(t *testing.T) {
+		env := setup(t, 10)
+		require.NoError(t, env.userRepo.Save(ctx, &domain.User{
+			Login: "mixed",
+			Email: "Mixed@Example.com",
+		}))
+
+		require.NoError(t, env.service.Forgot(ctx, "127.0.0.1", " Mixed@Example.com ", "en"))
+		env.service.Wait()
+
+		messages := env.mailer.messages()
+		require.Len(t, messages, 1)
+		assert.Equal(t, "Mixed@Example.com", messages[0].To)
+
+		resets, err := env.resetRepo.Find(ctx, nil, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, resets, 1)
+		assert.Equal(t, "mixed@example.com", resets[0].Email)
+	})
+
+	t.Run("unknown_email", func(t *testing.T) {
+		env := setup(t, 10)
+
+		require.NoError(t, env.service.Forgot(ctx, "127.0.0.1", "unknown@example.com", "en"))
+		env.service.Wait()
+
+		assert.Empty(t, env.mailer.messages())
+	})
+
+	t.Run("throttled_email", func(t *testing.T) {
+		env := setup(t, 10)
+
+		require.NoError(t, env.service.Forgot(ctx, "127.0.0.1", "admin@example.com", "en"))
+		env.service.Wait()
+		require.NoError(t, env.service.Forgot(ctx, "127.0.0.1", "admin@example.com", "en"))
+		env.service.Wait()
+
+		assert.Len(t, env.mailer.messages(), 1)
+	})
+
+	t.Run("send_failure_removes_reset", func(t *testing.T) {
+		env := setup(t, 10)
+		env.mailer.err = errors.New("smtp is down")
+
+		require.NoError(t, env.service.Forgot(ctx, "127.0.0.1", "admin@example.com", "en"))
+		env.service.Wait()
+
+		resets, err := env.resetRepo.Find(ctx, nil, nil, nil)
+		require.NoError(t, err)
+		assert.Empty(t, resets)
+	})
+
+	t.Run("rate_limited", func(t *testing.T) {
+		env := setup(t, 1)
+
+		require.NoError(t, env.service.Forgot(ctx, "127.0.0.1", "admin@example.com", "en"))
+
+		err := env.service.Forgot(ctx, "127.0.0.1", "admin@example.com", "en")
+		env.service.Wait()
+
+		var tooMany *TooManyAttemptsError
+		require.ErrorAs(t, err, &tooMany)
+		assert.Greater(t, tooMany.RetryAfter, time.Duration(0))
+	})
+
+	t.Run("not_configured", func(t *testing.T) {
+		env := setup(t, 10)
+		env.service.mailer = nil
+
+		err := env.service.Forgot(ctx, "127.0.0.1", "admin@example.com", "en")
+		require.ErrorIs(t, err, ErrNotConfigured)
+	})
+}
+
+func TestService_Reset(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("valid_token", func(t *testing.T) {
+		env := setup(t, 10)
+		token := env.requestToken(t)
+
+		require.NoError(t, env.service.Reset(ctx, "127.0.0.1", "admin@example.com", token, "new-password"))
+
+		users, err := env.userRepo.Find(ctx, filters.FindUserByEmails("admin@example.com"), nil, nil)
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		require.NoError(t, auth.VerifyPassword(users[0].Password, "new-password"))
+
+		err = env.service.Reset(ctx, "127.0.0.1", "admin@example.com", token, "other-password")
+		require.ErrorIs(t, err, ErrInvalidToken, "token must be usable once")
+	})
+
+	t.Run("email_in_another_case", func(t *testing.T) {
+		env := setup(t, 10)
+		token := env.requestToken(t)
+
+		require.NoError(t, env.service.Reset(ctx, "127.0.0.1", " Admin@Example.COM ", token, "new-password"))
+
+		users, err := env.userRepo.Find(ctx, filters.FindUserByEmails("admin@example.com"), nil, nil)
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		require.NoError(t, auth.VerifyPassword(users[0].Password, "new-password"))
+
+		resets, err := env.resetRepo.Find(ctx, nil, nil, nil)
+		require.NoError(t, err)
+		assert.Empty(t, resets)
+	})
+
+	t.Run("user_email_in_another_case", func(t *testing.T) {
+		env := setup(t, 10)
+		require.NoError(t, env.userRepo.Save(ctx, &domain.User{
+			Login: "mixed",
+			Email: "Mixed@Example.com",
+		}))
+
+		require.NoError(t, env.service.Forgot(ctx, "127.0.0.1", "Mixed@Example.com", "en"))
+		env.service.Wait()
+
+		messages := env.mailer.messages()
+		require.Len(t, messages, 1)
+		token := linkTokenPattern.FindStringSubmatch(messages[0].Text)[1]
+
+		require.NoError(t, env.service.Reset(ctx, "127.0.0.1", "Mixed@Example.com", token, "new-password"))
+
+		users, err := env.userRepo.Find(ctx, filters.FindUserByEmails("Mixed@Example.com"), nil, nil)
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		require.NoError(t, auth.VerifyPassword(users[0].Password, "new-password"))
+	})
+
+	t.Run("invalid_token", func(t *testing.T) {
+		env := setup(t, 10)
+		env.requestToken(t)
+
+		err := env.service.Reset(ctx, "127.0.0.1", "admin@example.com", "invalid", "new-password")
+		require.ErrorIs(t, err, ErrInvalidToken)
+
+		users, err := env.userRepo.Find(ctx, filters.FindUserByEmails("admin@example.com"), nil, nil)
+		require.NoError(t, err)
+		require.NoError(t, auth.VerifyPassword(users[0].Password, "old-password"))
+	})
+
+	t.Run("expired_token", func(t *testing.T) {
+		env := setup(t, 10)
+		token := env.requestToken(t)
+
+		resets, err := env.resetRepo.Find(ctx, nil, nil, nil)
+		require.NoError(t, err)
+		resets[0].CreatedAt = lo.ToPtr(time.Now().Add(-2 * time.Hour))
+		require.NoError(t, env.resetRepo.Save(ctx, &resets[0]))
+
+		err = env.service.Reset(ctx, "127.0.0.1", "admin@example.com", token, "new-password")
+		require.ErrorIs(t, err, ErrInvalidToken)
+
+		resets, err = env.resetRepo.Find(ctx, nil, nil, nil)
+		require.NoError(t, err)
+		assert.Empty(t, resets)
+	})
+
+	t.Run("token_of_another_email", func(t *testing.T) {
+		env := setup(t, 10)
+		token := env.requestToken(t)
+
+		err := env.service.Reset(ctx, "127.0.0.1", "other@example.com", token, "new-password")
+		require.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("rate_limited", func(t *testing.T) {
+		env := setup(t, 1)
+
+		err := env.service.Reset(ctx, "127.0.0.1", "admin@example.com", "invalid", "new-password")
+		require.ErrorIs(t, err, ErrInvalidToken)
+
+		err = env.service.Reset(ctx, "127.0.0.1", "admin@example.com", "invalid", "new-password")
+
+		var tooMany *TooManyAttemptsError
+		require.ErrorAs(t, err, &tooMany)
+	})
+}
@@ -0,0 +1,408 @@
+package serverclone
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/events"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	pkgstrings "github.com/gameap/gameap/pkg/strings"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+const (
+	rconPasswordLength = 10
+	maxPort            = 65535
+)
+
+var (
+	ErrNodeNotFound        = api.NewValidationError("node not found")
+	ErrNodeDisabled        = api.NewValidationError("node is disabled")
+	ErrIPNotAssignedToNode = api.NewValidationError("server_ip is not assigned to the node")
+	ErrNoNodeIP            = api.NewValidationError("node has no IP addresses")
+	ErrDirAlreadyUsed      = api.NewValidationError("dir is already used by another server on the node")
+	ErrInvalidDir          = api.NewValidationError("dir must be a relative path without \"..\" segments and special characters")
+	ErrNoFreePorts         = api.NewValidationError("no free ports on the server IP")
+)
+
+type filesCopier interface {
+	Copy(ctx context.Context, source, target *domain.Server) (uint, error)
+}
+
+type serverPorts interface {
+	Do(ctx context.Context, nodeID uint, fn func(ctx context.Context) error) error
+}
+
+type eventPublisher interface {
+	Publish(ctx context.Context, event domain.Event)
+}
+
+// Options describes the new server.
+type Options struct {
+	Name string
+	// NodeID is the node of the new server. When cloning, zero means the node of the source server.
+	NodeID uint
+	// ServerIP is the IP of the new server, it must be assigned to the node. If empty,
+	// the IP of the source server is used when it is assigned to the node, otherwise the first IP of the node.
+	ServerIP string
+	// Dir is relative to the work path of the node. If empty, the directory is generated from the server UUID.
+	Dir string
+	// CopyFiles copies the files of the source server to the new server. Used for cloning only.
+	CopyFiles bool
+	// Install creates an install task for the new server if the files are not copied.
+	Install bool
+}
+
+// Result is the created server and the daemon task preparing its files.
+// TaskID is zero if no task is created.
+type Result struct {
+	Server *domain.Server
+	TaskID uint
+}
+
+// Service creates new servers from existing servers and from server templates.
+//
+// The configuration and the settings are copied to a new server with a new UUID, directory and rcon password.
+// The ports of the source are preferred, if they are busy on the server IP,
+// all ports are shifted by the same offset until they are free.
+// Ports are allocated and the server is saved while no other server is created on the node.
+// The files of a cloned server are copied by the copy task executed by the server move worker.
+type Service struct {
+	serverRepo        repositories.ServerRepository
+	serverSettingRepo repositories.ServerSettingRepository
+	templateRepo      repositories.ServerTemplateRepository
+	nodeRepo          repositories.NodeRepository
+	daemonTaskRepo    repositories.DaemonTaskRepository
+	filesCopier       filesCopier
+	serverPorts       serverPorts
+	eventPublisher    eventPublisher
+}
+
+func NewService(
+	serverRepo repositories.ServerRepository,
+	serverSettingRepo repositories.ServerSettingRepository,
+	templateRepo repositories.ServerTemplateRepository,
+	nodeRepo repositories.NodeRepository,
+	daemonTaskRepo repositories.DaemonTaskRepository,
+	filesCopier filesCopier,
+	serverPorts serverPorts,
+	eventPublisher eventPublisher,
+) *Service {
+	return &Service{
+		serverRepo:        serverRepo,
+		serverSettingRepo: serverSettingRepo,
+		templateRepo:      templateRepo,
+		nodeRepo:          nodeRepo,
+		daemonTaskRepo:    daemonTaskRepo,
+		filesCopier:       filesCopier,
+		serverPorts:       serverPorts,
+		eventPublisher:    eventPublisher,
+	}
+}
+
+// Clone creates a copy of the source server.
+func (s *Service) Clone(ctx context.Context, source *domain.Server, opts Options) (*Result, error) {
+	if opts.NodeID == 0 {
+		opts.NodeID = source.DSID
+	}
+
+	settings, err := s.findSettings(ctx, source.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	template := domain.NewServerTemplateFromServer(opts.Name, source, settings)
+
+	return s.create(ctx, template, source, source.ServerIP, opts)
+}
+
+// CreateFromTemplate creates a new server from the template.
+func (s *Service) CreateFromTemplate(
+	ctx context.Context,
+	template *domain.ServerTemplate,
+	opts Options,
+) (*Result, error) {
+	opts.CopyFiles = false
+
+	return s.create(ctx, template, nil, "", opts)
+}
+
+// CreateTemplate captures the configuration and the settings of the server into a new template.
+func (s *Service) CreateTemplate(
+	ctx context.Context,
+	server *domain.Server,
+	name string,
+	description *string,
+) (*domain.ServerTemplate, error) {
+	settings, err := s.findSettings(ctx, server.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	template := domain.NewServerTemplateFromServer(name, server, settings)
+	template.Description = description
+
+	if err = s.templateRepo.Save(ctx, template); err != nil {
+		return nil, errors.WithMessage(err, "failed to save server template")
+	}
+
+	return template, nil
+}
+
+func (s *Service) create(
+	ctx context.Context,
+	template *domain.ServerTemplate,
+	source *domain.Server,
+	preferredIP string,
+	opts Options,
+) (*Result, error) {
+	node, err := s.findNode(ctx, opts.NodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverIP, err := nodeServerIP(node, opts.ServerIP, preferredIP)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *Result
+
+	err = s.serverPorts.Do(ctx, node.ID, func(ctx context.Context) error {
+		servers, err := s.serverRepo.Find(ctx, &filters.FindServer{
+			DSIDs: []uint{node.ID},
+		}, nil, nil)
+		if err != nil {
+			return errors.WithMessage(err, "failed to find node servers")
+		}
+
+		ports, ok := allocatePorts(template.Ports(), domain.ServersBusyPorts(servers)[serverIP])
+		if !ok {
+			return ErrNoFreePorts
+		}
+
+		server, err := newServer(template, opts.Name, node.ID, serverIP, ports)
+		if err != nil {
+			return err
+		}
+
+		if opts.Dir != "" {
+			if !domain.IsValidServerDir(opts.Dir) {
+				return ErrInvalidDir
+			}
+
+			server.Dir = opts.Dir
+		}
+
+		if lo.ContainsBy(servers, func(srv domain.Server) bool {
+			return srv.Dir == server.Dir
+		}) {
+			return ErrDirAlreadyUsed
+		}
+
+		if source != nil && opts.CopyFiles {
+			server.Installed = domain.ServerInstalledStatusInstallationInProg
+		}
+
+		result, err = s.save(ctx, template, source, server, opts)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.eventPublisher.Publish(ctx, events.ServerCreated(result.Server))
+
+	return result, nil
+}
+
+func (s *Service) save(
+	ctx context.Context,
+	template *domain.ServerTemplate,
+	source *domain.Server,
+	server *domain.Server,
+	opts Options,
+) (*Result, error) {
+	result := &Result{Server: server}
+
+	if err := s.serverRepo.Save(ctx, server); err != nil {
+		return nil, errors.WithMessage(err, "failed to save server")
+	}
+
+	for _, setting := range template.Settings.ServerSettings(server.ID) {
+		if err := s.serverSettingRepo.Save(ctx, &setting); err != nil {
+			return nil, errors.WithMessagef(err, "failed to save server setting %s", setting.Name)
+		}
+	}
+
+	switch {
+	case source != nil && opts.CopyFiles:
+		taskID, err := s.filesCopier.Copy(ctx, source, server)
+		if err != nil {
+			return nil, err
+		}
+
+		result.TaskID = taskID
+	case opts.Install:
+		taskID, err := s.createInstallTask(ctx, server)
+		if err != nil {
+			return nil, err
+		}
+
+		result.TaskID = taskID
+	}
+
+	return result, nil
+}
+
+func (s *Service) findSettings(ctx context.Context, serverID uint) ([]domain.ServerSetting, error) {
+	settings, err := s.serverSettingRepo.Find(ctx, &filters.FindServerSetting{
+		ServerIDs: []uint{serverID},
+	}, nil, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find server settings")
+	}
+
+	return settings, nil
+}
+
+func (s *Service) findNode(ctx context.Context, id uint) (*domain.Node, error) {
+	nodes, err := s.nodeRepo.Find(ctx, &filters.FindNode{
+		IDs: []uint{id},
+	}, nil, &filters.Pagination{
+		Limit: 1,
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find node")
+	}
+
+	if len(nodes) == 0 {
+		return nil, ErrNodeNotFound
+	}
+
+	if !nodes[0].Enabled {
+		return nil, ErrNodeDisabled
+	}
+
+	return &nodes[0], nil
+}
+
+func (s *Service) createInstallTask(ctx context.Context, server *domain.Server) (uint, error) {
+	task := &domain.DaemonTask{
+		DedicatedServerID: server.DSID,
+		ServerID:          lo.ToPtr(server.ID),
+		Task:              domain.DaemonTaskTypeServerInstall,
+		Status:            domain.DaemonTaskStatusWaiting,
+	}
+
+	if err := s.daemonTaskRepo.Save(ctx, task); err != nil {
+		return 0, errors.WithMessage(err, "failed to create install task")
+	}
+
+	return task.ID, nil
+}
+
+// nodeServerIP returns the requested IP if it is set, otherwise the preferred IP
+// if it is assigned to the node or the first node IP.
+func nodeServerIP(node *domain.Node, requested, preferred string) (string, error) {
+	if requested != "" {
+		if !slices.Contains(node.IPs, requested) {
+			return "", ErrIPNotAssignedToNode
+		}
+
+		return requested, nil
+	}
+
+	if preferred != "" && slices.Contains(node.IPs, preferred) {
+		return preferred, nil
+	}
+
+	if len(node.IPs) == 0 {
+		return "", ErrNoNodeIP
+	}
+
+	return node.IPs[0], nil
+}
+
+// allocatePorts shifts the preferred ports by the smallest offset making all of them free.
+// The distance between the ports is kept. It returns false if there are no free ports.
+func allocatePorts(preferred, busy []int) ([]int, bool) {
+	if len(preferred) == 0 {
+		return nil, false
+	}
+
+	highest := slices.Max(preferred)
+
+	for offset := 0; highest+offset <= maxPort; offset++ {
+		ports := make([]int, 0, len(preferred))
+		for _, port := range preferred {
+			ports = append(ports, port+offset)
+		}
+
+		if !slices.ContainsFunc(ports, func(port int) bool {
+			return slices.Contains(busy, port)
+		}) {
+			return ports, true
+		}
+	}
+
+	return nil, false
+}
+
+func newServer(
+	template *domain.ServerTemplate,
+	name string,
+	nodeID uint,
+	serverIP string,
+	ports []int,
+) (*domain.Server, error) {
+	u, err := uuid.NewV7()
+	if err != nil {
+		slog.Error(
+			"Unable to generate server UUID",
+			slog.String("error", err.Error()),
+		)
+
+		u = uuid.New()
+	}
+
+	rcon, err := pkgstrings.CryptoRandomString(rconPasswordLength)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to generate rcon password")
+	}
+
+	server := &domain.Server{
+		UUID:       u,
+		UUIDShort:  u.String()[0:8],
+		Enabled:    true,
+		Installed:  domain.ServerInstalledStatusNotInstalled,
+		Name:       name,
+		DSID:       nodeID,
+		ServerIP:   serverIP,
+		ServerPort: ports[0],
+		Rcon:       &rcon,
+		Dir:        "servers/" + u.String(),
+	}
+
+	template.ApplyTo(server)
+
+	// Ports are in the same order as returned by ServerTemplate.Ports
+	next := 1
+	if template.QueryPort != nil {
+		server.QueryPort = lo.ToPtr(ports[next])
+		next++
+	}
+
+	if template.RconPort != nil {
+		server.RconPort = lo.ToPtr(ports[next])
+	}
+
+	return server, nil
+}
@@ -0,0 +1,315 @@
+package serverclone
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/events"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/servermove"
+	"github.com/gameap/gameap/internal/services/serverports"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type serviceEnv struct {
+	service           *Service
+	serverRepo        *inmemory.ServerRepository
+	serverSettingRepo *inmemory.ServerSettingRepository
+	templateRepo      *inmemory.ServerTemplateRepository
+	daemonTaskRepo    *inmemory.DaemonTaskRepository
+	server            *domain.Server
+	published         []domain.Event
+}
+
+func newServiceEnv(t *testing.T) *serviceEnv {
+	t.Helper()
+
+	ctx := context.Background()
+
+	env := &serviceEnv{
+		serverRepo:        inmemory.NewServerRepository(),
+		serverSettingRepo: inmemory.NewServerSettingRepository(),
+		templateRepo:      inmemory.NewServerTemplateRepository(),
+		daemonTaskRepo:    inmemory.NewDaemonTaskRepository(),
+	}
+
+	nodeRepo := inmemory.NewNodeRepository()
+	require.NoError(t, nodeRepo.Save(ctx, &domain.Node{
+		ID:      1,
+		Enabled: true,
+		IPs:     domain.IPList{"10.0.0.1"},
+	}))
+	require.NoError(t, nodeRepo.Save(ctx, &domain.Node{
+		ID:      2,
+		Enabled: true,
+		IPs:     domain.IPList{"10.0.0.2", "10.0.0.3"},
+	}))
+	require.NoError(t, nodeRepo.Save(ctx, &domain.Node{
+		ID:      3,
+		Enabled: false,
+		IPs:     domain.IPList{"10.0.0.4"},
+	}))
+
+	env.server = &domain.Server{
+		ID:           1,
+		UUID:         uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+		Enabled:      true,
+		Installed:    domain.ServerInstalledStatusInstalled,
+		Name:         "Public CS",
+		GameID:       "cstrike",
+		GameModID:    3,
+		DSID:         1,
+		ServerIP:     "10.0.0.1",
+		ServerPort:   27015,
+		QueryPort:    lo.ToPtr(27016),
+		Rcon:         lo.ToPtr("secret"),
+		Dir:          "servers/cs",
+		StartCommand: lo.ToPtr("./hlds_run -game cstrike"),
+		RAMLimit:     lo.ToPtr(1024),
+	}
+	require.NoError(t, env.serverRepo.Save(ctx, env.server))
+
+	require.NoError(t, env.serverSettingRepo.Save(ctx, &domain.ServerSetting{
+		Name:     "autostart",
+		ServerID: env.server.ID,
+		Value:    domain.NewServerSettingValue(true),
+	}))
+
+	bus := events.NewBus()
+	bus.Subscribe(events.HandlerFunc(func(_ context.Context, event domain.Event) error {
+		env.published = append(env.published, event)
+
+		return nil
+	}))
+
+	tm := services.NewNilTransactionManager()
+
+	env.service = NewService(
+		env.serverRepo,
+		env.serverSettingRepo,
+		env.templateRepo,
+		nodeRepo,
+		env.daemonTaskRepo,
+		servermove.NewService(env.daemonTaskRepo, env.serverRepo, nodeRepo, tm),
+		serverports.NewService(
+			env.serverRepo,
+			cache.NewInMemory(),
+			tm,
+			domain.PortRange{Start: 27015, End: 27999},
+			time.Second,
+		),
+		bus,
+	)
+
+	return env
+}
+
+func (env *serviceEnv) settings(t *testing.T, serverID uint) map[string]any {
+	t.Helper()
+
+	settings, err := env.serverSettingRepo.Find(context.Background(), &filters.FindServerSetting{
+		ServerIDs: []uint{serverID},
+	}, nil, nil)
+	require.NoError(t, err)
+
+	result := make(map[string]any, len(settings))
+	for _, setting := range settings {
+		result[setting.Name] = setting.Value.Any()
+	}
+
+	return result
+}
+
+func TestService_Clone_SameNodeShiftsPorts(t *testing.T) {
+	env := newServiceEnv(t)
+
+	result, err := env.service.Clone(context.Background(), env.server, Options{Name: "Public CS #2"})
+	require.NoError(t, err)
+
+	clone := result.Server
+	assert.NotZero(t, clone.ID)
+	assert.NotEqual(t, env.server.UUID, clone.UUID)
+	assert.Equal(t, "Public CS #2", clone.Name)
+	assert.Equal(t, uint(1), clone.DSID)
+	assert.Equal(t, "10.0.0.1", clone.ServerIP)
+	assert.Equal(t, 27017, clone.ServerPort)
+	assert.Equal(t, lo.ToPtr(27018), clone.QueryPort)
+	assert.Nil(t, clone.RconPort)
+	assert.Equal(t, "servers/"+clone.UUID.String(), clone.Dir)
+	assert.Equal(t, env.server.StartCommand, clone.StartCommand)
+	assert.Equal(t, env.server.RAMLimit, clone.RAMLimit)
+	assert.NotEqual(t, env.server.Rcon, clone.Rcon)
+	assert.Equal(t, domain.ServerInstalledStatusNotInstalled, clone.Installed)
+
+	assert.Zero(t, result.TaskID)
+	assert.Equal(t, map[string]any{"autostart": true}, env.settings(t, clone.ID))
+
+	require.Len(t, env.published, 1)
+	assert.Equal(t, domain.EventTypeServerCreated, env.published[0].Type)
+	assert.Equal(t, lo.ToPtr(clone.ID), env.published[0].ServerID)
+}
+
+func TestService_Clone_CopiesFilesToAnotherNode(t *testing.T) {
+	env := newServiceEnv(t)
+
+	result, err := env.service.Clone(context.Background(), env.server, Options{
+		Name:      "Public CS #2",
+		NodeID:    2,
+		Dir:       "servers/cs-copy",
+		CopyFiles: true,
+	})
+	require.NoError(t, err)
+
+	clone := result.Server
+	assert.Equal(t, uint(2), clone.DSID)
+	assert.Equal(t, "10.0.0.2", clone.ServerIP)
+	assert.Equal(t, 27015, clone.ServerPort)
+	assert.Equal(t, "servers/cs-copy", clone.Dir)
+	assert.Equal(t, domain.ServerInstalledStatusInstallationInProg, clone.Installed)
+
+	tasks, err := env.daemonTaskRepo.FindAll(context.Background(), nil, nil)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, result.TaskID, tasks[0].ID)
+	assert.Equal(t, domain.DaemonTaskTypeServerCopy, tasks[0].Task)
+	assert.Equal(t, uint(2), tasks[0].DedicatedServerID)
+	assert.Equal(t, &clone.ID, tasks[0].ServerID)
+}
+
+func TestService_Clone_Validation(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      Options
+		wantError string
+	}{
+		{
+			name:      "node not found",
+			opts:      Options{Name: "Clone", NodeID: 999},
+			wantError: ErrNodeNotFound.Error(),
+		},
+		{
+			name:      "node disabled",
+			opts:      Options{Name: "Clone", NodeID: 3},
+			wantError: ErrNodeDisabled.Error(),
+		},
+		{
+			name:      "ip is not assigned to node",
+			opts:      Options{Name: "Clone", NodeID: 2, ServerIP: "10.0.0.1"},
+			wantError: ErrIPNotAssignedToNode.Error(),
+		},
+		{
+			name:      "dir is used on node",
+			opts:      Options{Name: "Clone", Dir: "servers/cs"},
+			wantError: ErrDirAlreadyUsed.Error(),
+		},
+		{
+			name:      "absolute dir",
+			opts:      Options{Name: "Clone", Dir: "/root"},
+			wantError: ErrInvalidDir.Error(),
+		},
+		{
+			name:      "dir with shell metacharacters",
+			opts:      Options{Name: "Clone", Dir: "servers/cs'; reboot; '"},
+			wantError: ErrInvalidDir.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newServiceEnv(t)
+
+			_, err := env.service.Clone(context.Background(), env.server, tt.opts)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantError)
+
+			servers, err := env.serverRepo.FindAll(context.Background(), nil, nil)
+			require.NoError(t, err)
+			assert.Len(t, servers, 1)
+		})
+	}
+}
+
+func TestService_CreateTemplateAndServerFromTemplate(t *testing.T) {
+	env := newServiceEnv(t)
+	ctx := context.Background()
+
+	template, err := env.service.CreateTemplate(ctx, env.server, "CS template", lo.ToPtr("Public server"))
+	require.NoError(t, err)
+	assert.NotZero(t, template.ID)
+
+	result, err := env.service.CreateFromTemplate(ctx, template, Options{
+		Name:     "From template",
+		NodeID:   2,
+		ServerIP: "10.0.0.3",
+		Install:  true,
+	})
+	require.NoError(t, err)
+
+	server := result.Server
+	assert.Equal(t, "From template", server.Name)
+	assert.Equal(t, "cstrike", server.GameID)
+	assert.Equal(t, uint(2), server.DSID)
+	assert.Equal(t, "10.0.0.3", server.ServerIP)
+	assert.Equal(t, []int{27015, 27016}, server.Ports())
+	assert.Equal(t, map[string]any{"autostart": true}, env.settings(t, server.ID))
+
+	tasks, err := env.daemonTaskRepo.FindAll(ctx, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, result.TaskID, tasks[0].ID)
+	assert.Equal(t, domain.DaemonTaskTypeServerInstall, tasks[0].Task)
+}
+
+func TestAllocatePorts(t *testing.T) {
+	tests := []struct {
+		name      string
+		preferred []int
+		busy      []int
+		want      []int
+		wantOK    bool
+	}{
+		{
+			name:      "free ports",
+			preferred: []int{27015, 27016},
+			busy:      []int{27020},
+			want:      []int{27015, 27016},
+			wantOK:    true,
+		},
+		{
+			name:      "shifted keeping distance",
+			preferred: []int{27015, 27020},
+			busy:      []int{27015, 27021, 27022},
+			want:      []int{27018, 27023},
+			wantOK:    true,
+		},
+		{
+			name:      "same game and query port",
+			preferred: []int{27015, 27015},
+			busy:      []int{27015},
+			want:      []int{27016, 27016},
+			wantOK:    true,
+		},
+		{
+			name:      "no free ports",
+			preferred: []int{65534, 65535},
+			busy:      []int{65535},
+			wantOK:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := allocatePorts(tt.preferred, tt.busy)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package serverconsole
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gameap/gameap/internal/daemon"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+// MaxSymbols is the maximum length of the console output returned by the Reader.
+const MaxSymbols = 65536
+
+const (
+	outputFileName = "output.txt"
+	inputFileName  = "input.txt"
+)
+
+type daemonCommands interface {
+	ExecuteCommand(
+		ctx context.Context,
+		node *domain.Node,
+		command string,
+		opts ...daemon.CommandServiceOption,
+	) (*daemon.CommandResult, error)
+}
+
+type fileDownloader interface {
+	Download(ctx context.Context, node *domain.Node, filePath string) ([]byte, error)
+}
+
+type fileUploader interface {
+	Upload(ctx context.Context, node *domain.Node, filePath string, content []byte, perms os.FileMode) error
+}
+
+// Reader reads game server console output.
+// The node get console script is used when it is set,
+// otherwise the output file in the server directory is downloaded.
+type Reader struct {
+	nodeRepo       repositories.NodeRepository
+	daemonCommands daemonCommands
+	fileService    fileDownloader
+}
+
+func NewReader(
+	nodeRepo repositories.NodeRepository,
+	daemonCommands daemonCommands,
+	fileService fileDownloader,
+) *Reader {
+	return &Reader{
+		nodeRepo:       nodeRepo,
+		daemonCommands: daemonCommands,
+		fileService:    fileService,
+	}
+}
+
+// Read returns the last MaxSymbols of the server console output.
+func (r *Reader) Read(ctx context.Context, server *domain.Server) (string, error) {
+	node, err := findNode(ctx, r.nodeRepo, server.DSID)
+	if err != nil {
+		return "", err
+	}
+
+	if node.ScriptGetConsole != nil && *node.ScriptGetConsole != "" {
+		cmd := server.ReplaceServerShortcodes(node, *node.ScriptGetConsole, nil)
+
+		result, err := r.daemonCommands.ExecuteCommand(ctx, node, cmd)
+		if err != nil {
+			return "", errors.WithMessage(err, "failed to execute get console script")
+		}
+
+		return result.Output, nil
+	}
+
+	return r.downloadOutputFile(ctx, node, server.Dir)
+}
+
+func (r *Reader) downloadOutputFile(ctx context.Context, node *domain.Node, serverDir string) (string, error) {
+	outputPath := filepath.Join(serverDir, outputFileName)
+
+	content, err := r.fileService.Download(ctx, node, outputPath)
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to download console log")
+	}
+
+	result := string(content)
+
+	if len(result) > MaxSymbols {
+		result = result[len(result)-MaxSymbols:]
+	}
+
+	result = sanitizeUTF8(result)
+
+	return result, nil
+}
+
+// Sender sends commands into game server console.
+// The node send command script is used when it is set,
+// otherwise the command is uploaded into the input file in the server directory.
+type Sender struct {
+	nodeRepo       repositories.NodeRepository
+	daemonCommands daemonCommands
+	fileService    fileUploader
+}
+
+func NewSender(
+	nodeRepo repositories.NodeRepository,
+	daemonCommands daemonCommands,
+	fileService fileUploader,
+) *Sender {
+	return &Sender{
+		nodeRepo:       nodeRepo,
+		daemonCommands: daemonCommands,
+		fileService:    fileService,
+	}
+}
+
+// Send sends the command into the server console.
+func (s *Sender) Send(ctx context.Context, server *domain.Server, command string) error {
+	node, err := findNode(ctx, s.nodeRepo, server.DSID)
+	if err != nil {
+		return err
+	}
+
+	if node.ScriptSendCommand != nil && *node.ScriptSendCommand != "" {
+		cmd := server.ReplaceServerShortcodes(node, *node.ScriptSendCommand, map[string]string{
+			"command": command,
+		})
+
+		_, err := s.daemonCommands.ExecuteCommand(ctx, node, cmd)
+		if err != nil {
+			return errors.WithMessage(err, "failed to execute send command script")
+		}
+
+		return nil
+	}
+
+	return s.uploadInputFile(ctx, node, server.Dir, command)
+}
+
+func (s *Sender) uploadInputFile(ctx context.Context, node *domain.Node, serverDir string, command string) error {
+	inputPath := filepath.Join(serverDir, inputFileName)
+
+	err := s.fileService.Upload(ctx, node, inputPath, []byte(command), 0644)
+	if err != nil {
+		return errors.WithMessage(err, "failed to upload console command")
+	}
+
+	return nil
+}
+
+func findNode(ctx context.Context, nodeRepo repositories.NodeRepository, nodeID uint) (*domain.Node, error) {
+	nodes, err := nodeRepo.Find(ctx, &filters.FindNode{
+		IDs: []uint{nodeID},
+	}, nil, &filters.Pagination{
+		Limit: 1,
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find node")
+	}
+
+	if len(nodes) == 0 {
+		return nil, api.NewNotFoundError("node not found")
+	}
+
+	return &nodes[0], nil
+}
+
+func sanitizeUTF8(s string) string {
+	if utf8.ValidString(s) {
+		return s
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+
+	for _, r := range s {
+		if r == utf8.RuneError {
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,166 @@
+package serverconsole
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gameap/gameap/internal/daemon"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockDaemonCommands struct {
+	commands []string
+	output   string
+}
+
+func (m *mockDaemonCommands) ExecuteCommand(
+	_ context.Context,
+	_ *domain.Node,
+	command string,
+	_ ...daemon.CommandServiceOption,
+) (*daemon.CommandResult, error) {
+	m.commands = append(m.commands, command)
+
+	return &daemon.CommandResult{Output: m.output}, nil
+}
+
+type mockFileService struct {
+	content  []byte
+	uploaded map[string][]byte
+}
+
+func (m *mockFileService) Download(_ context.Context, _ *domain.Node, _ string) ([]byte, error) {
+	return m.content, nil
+}
+
+func (m *mockFileService) Upload(
+	_ context.Context,
+	_ *domain.Node,
+	filePath string,
+	content []byte,
+	_ os.FileMode,
+) error {
+	if m.uploaded == nil {
+		m.uploaded = make(map[string][]byte)
+	}
+	m.uploaded[filePath] = content
+
+	return nil
+}
+
+func setupNode(t *testing.T, node *domain.Node) *inmemory.NodeRepository {
+	t.Helper()
+
+	repo := inmemory.NewNodeRepository()
+	require.NoError(t, repo.Save(context.Background(), node))
+
+	return repo
+}
+
+func TestReader_Read_OutputFile(t *testing.T) {
+	nodeRepo := setupNode(t, &domain.Node{ID: 1, Enabled: true, Name: "node"})
+	fs := &mockFileService{content: []byte(strings.Repeat("a", MaxSymbols) + "tail")}
+	reader := NewReader(nodeRepo, &mockDaemonCommands{}, fs)
+
+	output, err := reader.Read(context.Background(), &domain.Server{ID: 1, DSID: 1, Dir: "/srv/server"})
+
+	require.NoError(t, err)
+	assert.Len(t, output, MaxSymbols)
+	assert.True(t, strings.HasSuffix(output, "tail"))
+}
+
+func TestReader_Read_Script(t *testing.T) {
+	nodeRepo := setupNode(t, &domain.Node{
+		ID:               1,
+		Enabled:          true,
+		Name:             "node",
+		ScriptGetConsole: lo.ToPtr("get-console {uuid}"),
+	})
+	commands := &mockDaemonCommands{output: "console output"}
+	reader := NewReader(nodeRepo, commands, &mockFileService{})
+
+	output, err := reader.Read(context.Background(), &domain.Server{ID: 1, DSID: 1})
+
+	require.NoError(t, err)
+	assert.Equal(t, "console output", output)
+	assert.Len(t, commands.commands, 1)
+}
+
+func TestReader_Read_NodeNotFound(t *testing.T) {
+	reader := NewReader(inmemory.NewNodeRepository(), &mockDaemonCommands{}, &mockFileService{})
+
+	_, err := reader.Read(context.Background(), &domain.Server{ID: 1, DSID: 1})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "node not found")
+}
+
+func TestSender_Send_InputFile(t *testing.T) {
+	nodeRepo := setupNode(t, &domain.Node{ID: 1, Enabled: true, Name: "node"})
+	fs := &mockFileService{}
+	sender := NewSender(nodeRepo, &mockDaemonCommands{}, fs)
+
+	err := sender.Send(context.Background(), &domain.Server{ID: 1, DSID: 1, Dir: "/srv/server"}, "status")
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte("status"), fs.uploaded["/srv/server/input.txt"])
+}
+
+func TestSender_Send_Script(t *testing.T) {
+	nodeRepo := setupNode(t, &domain.Node{
+		ID:                1,
+		Enabled:           true,
+		Name:              "node",
+		ScriptSendCommand: lo.ToPtr("send-command {command}"),
+	})
+	commands := &mockDaemonCommands{}
+	sender := NewSender(nodeRepo, commands, &mockFileService{})
+
+	err := sender.Send(context.Background(), &domain.Server{ID: 1, DSID: 1}, "status")
+
+	require.NoError(t, err)
+	require.Len(t, commands.commands, 1)
+	assert.Contains(t, commands.commands[0], "status")
+}
+
+func TestSanitizeUTF8(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "valid utf8",
+			input:    "Hello World!",
+			expected: "Hello World!",
+		},
+		{
+			name:     "valid utf8 with unicode",
+			input:    "Hello 世界!",
+			expected: "Hello 世界!",
+		},
+		{
+			name:     "empty string",
+			input:    "",
+			expected: "",
+		},
+		{
+			name:     "string with emojis",
+			input:    "Server starting 🚀",
+			expected: "Server starting 🚀",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := sanitizeUTF8(tt.input)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package serverconsole
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+)
+
+const (
+	subscriptionBufferSize = 32
+	readTimeout            = 30 * time.Second
+)
+
+type EventType string
+
+const (
+	// EventTypeReset event holds the whole console output, it replaces everything received before.
+	EventTypeReset EventType = "reset"
+	// EventTypeOutput event holds console output appended since the previous event.
+	EventTypeOutput EventType = "output"
+	// EventTypeError event holds an error occurred while reading the console.
+	EventTypeError EventType = "error"
+)
+
+type Event struct {
+	Type EventType
+	Data string
+}
+
+type consoleReader interface {
+	Read(ctx context.Context, server *domain.Server) (string, error)
+}
+
+// Hub tails game server consoles and fans out new console output to subscribers.
+// Each console is polled by a single goroutine regardless of the number of subscribers,
+// the goroutine stops when the last subscriber leaves.
+type Hub struct {
+	reader   consoleReader
+	interval time.Duration
+
+	mu    sync.Mutex
+	tails map[uint]*tail
+}
+
+type tail struct {
+	server      *domain.Server
+	cancel      context.CancelFunc
+	subscribers map[*Subscription]struct{}
+
+	output    string
+	hasOutput bool
+	lastError string
+}
+
+func NewHub(reader consoleReader, interval time.Duration) *Hub {
+	return &Hub{
+		reader:   reader,
+		interval: interval,
+		tails:    make(map[uint]*tail),
+	}
+}
+
+// Subscription receives console events of a single server.
+type Subscription struct {
+	hub      *Hub
+	serverID uint
+	events   chan Event
+
+	// resync is set when an event was dropped because the subscriber is too slow.
+	// The next event is replaced with the whole console output.
+	resync bool
+	closed bool
+}
+
+// Events returns the channel of console events. The channel is closed by Close.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Close unsubscribes from the console. It is safe to call Close several times.
+func (s *Subscription) Close() {
+	s.hub.unsubscribe(s)
+}
+
+// Subscribe starts receiving console events of the server.
+// If the console output has already been read, it is sent as the first event.
+func (h *Hub) Subscribe(server *domain.Server) *Subscription {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	t, ok := h.tails[server.ID]
+	if !ok {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		t = &tail{
+			server:      server,
+			cancel:      cancel,
+			subscribers: make(map[*Subscription]struct{}),
+		}
+		h.tails[server.ID] = t
+
+		go h.run(ctx, t)
+	}
+
+	sub := &Subscription{
+		hub:      h,
+		serverID: server.ID,
+		events:   make(chan Event, subscriptionBufferSize),
+	}
+	t.subscribers[sub] = struct{}{}
+
+	if t.hasOutput {
+		sub.events <- Event{Type: EventTypeReset, Data: t.output}
+	}
+
+	return sub
+}
+
+func (h *Hub) unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if sub.closed {
+		return
+	}
+
+	sub.closed = true
+	close(sub.events)
+
+	t, ok := h.tails[sub.serverID]
+	if !ok {
+		return
+	}
+
+	delete(t.subscribers, sub)
+
+	if len(t.subscribers) == 0 {
+		t.cancel()
+		delete(h.tails, sub.serverID)
+	}
+}
+
+func (h *Hub) run(ctx context.Context, t *tail) {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	for {
+		h.poll(ctx, t)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *Hub) poll(ctx context.Context, t *tail) {
+	readCtx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	output, err := h.reader.Read(readCtx, t.server)
+	if ctx.Err() != nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err != nil {
+		slog.WarnContext(
+			ctx,
+			"Failed to read server console",
+			slog.Uint64("server_id", uint64(t.server.ID)),
+			slog.String("error", err.Error()),
+		)
+
+		// Do not repeat the same error on every poll
+		if err.Error() != t.lastError {
+			t.lastError = err.Error()
+			h.broadcast(t, Event{Type: EventTypeError, Data: err.Error()})
+		}
+
+		return
+	}
+
+	t.lastError = ""
+
+	var event Event
+
+	switch added, ok := appended(t.output, output); {
+	case !t.hasOutput || !ok:
+		event = Event{Type: EventTypeReset, Data: output}
+	case added != "":
+		event = Event{Type: EventTypeOutput, Data: added}
+	}
+
+	t.output = output
+	t.hasOutput = true
+
+	if event.Type != "" {
+		h.broadcast(t, event)
+	}
+}
+
+// broadcast sends the event to all subscribers without blocking. Must be called with the lock held.
+func (h *Hub) broadcast(t *tail, event Event) {
+	for sub := range t.subscribers {
+		e := event
+		if sub.resync && event.Type == EventTypeOutput {
+			e = Event{Type: EventTypeReset, Data: t.output}
+		}
+
+		select {
+		case sub.events <- e:
+			if e.Type != EventTypeError {
+				sub.resync = false
+			}
+		default:
+			sub.resync = true
+		}
+	}
+}
+
+// appended returns the output added to cur since prev.
+// Console output is read as a sliding window, so the beginning of prev may be cut off in cur.
+// It returns false if cur doesn't continue prev, for example, when the console log was cleared.
+func appended(prev, cur string) (string, bool) {
+	if strings.HasPrefix(cur, prev) {
+		return cur[len(prev):], true
+	}
+
+	overlap := suffixPrefixOverlap(prev, cur)
+
+	// Less than a half of the output is kept, the console was most likely cleared or rotated
+	if overlap == 0 || 2*overlap < min(len(prev), len(cur)) {
+		return "", false
+	}
+
+	return cur[overlap:], true
+}
+
+// suffixPrefixOverlap returns the length of the longest suffix of s which is a prefix of p.
+func suffixPrefixOverlap(s, p string) int {
+	if p == "" {
+		return 0
+	}
+
+	// Knuth-Morris-Pratt prefix function of p
+	prefix := make([]int, len(p))
+	for i, k := 1, 0; i < len(p); i++ {
+		for k > 0 && p[i] != p[k] {
+			k = prefix[k-1]
+		}
+		if p[i] == p[k] {
+			k++
+		}
+		prefix[i] = k
+	}
+
+	k := 0
+	for i := range len(s) {
+		for k > 0 && (k == len(p) || s[i] != p[k]) {
+			k = prefix[k-1]
+		}
+		if s[i] == p[k] {
+			k++
+		}
+	}
+
+	return k
+}
@@ -0,0 +1,223 @@
+package serverconsole
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPollInterval = 10 * time.Millisecond
+
+type fakeConsoleReader struct {
+	mu     sync.Mutex
+	output string
+	err    error
+	reads  int
+}
+
+func (r *fakeConsoleReader) Read(_ context.Context, _ *domain.Server) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reads++
+
+	return r.output, r.err
+}
+
+func (r *fakeConsoleReader) set(output string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.output = output
+	r.err = err
+}
+
+func (r *fakeConsoleReader) readCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.reads
+}
+
+func nextEvent(t *testing.T, sub *Subscription) Event {
+	t.Helper()
+
+	select {
+	case event, ok := <-sub.Events():
+		require.True(t, ok, "events channel is closed")
+
+		return event
+	case <-time.After(time.Second):
+		require.Fail(t, "timeout waiting for console event")
+	}
+
+	return Event{}
+}
+
+func TestHub_Subscribe_StreamsOutput(t *testing.T) {
+	reader := &fakeConsoleReader{output: "line 1\n"}
+	hub := NewHub(reader, testPollInterval)
+	server := &domain.Server{ID: 1}
+
+	sub := hub.Subscribe(server)
+	defer sub.Close()
+
+	assert.Equal(t, Event{Type: EventTypeReset, Data: "line 1\n"}, nextEvent(t, sub))
+
+	reader.set("line 1\nline 2\n", nil)
+	assert.Equal(t, Event{Type: EventTypeOutput, Data: "line 2\n"}, nextEvent(t, sub))
+
+	reader.set("", errors.New("daemon is offline"))
+	assert.Equal(t, Event{Type: EventTypeError, Data: "daemon is offline"}, nextEvent(t, sub))
+
+	reader.set("cleared\n", nil)
+	assert.Equal(t, Event{Type: EventTypeReset, Data: "cleared\n"}, nextEvent(t, sub))
+}
+
+func TestHub_Subscribe_SharesPolling(t *testing.T) {
+	reader := &fakeConsoleReader{output: "line 1\n"}
+	hub := NewHub(reader, time.Hour)
+	server := &domain.Server{ID: 1}
+
+	first := hub.Subscribe(server)
+	defer first.Close()
+
+	assert.Equal(t, EventTypeReset, nextEvent(t, first).Type)
+
+	second := hub.Subscribe(server)
+	defer second.Close()
+
+	assert.Equal(t, Event{Type: EventTypeReset, Data: "line 1\n"}, nextEvent(t, second))
+	assert.Equal(t, 1, reader.readCount())
+}
+
+func TestHub_Close_StopsPolling(t *testing.T) {
+	reader := &fakeConsoleReader{output: "line 1\n"}
+	hub := NewHub(reader, testPollInterval)
+
+	sub := hub.Subscribe(&domain.Server{ID: 1})
+	nextEvent(t, sub)
+	sub.Close()
+	sub.Close()
+
+	_, ok := <-sub.Events()
+	assert.False(t, ok)
+
+	hub.mu.Lock()
+	assert.Empty(t, hub.tails)
+	hub.mu.Unlock()
+}
+
+func TestHub_SlowSubscriberResyncs(t *testing.T) {
+	reader := &fakeConsoleReader{}
+	hub := NewHub(reader, time.Hour)
+	server := &domain.Server{ID: 1}
+
+	// Register the subscriber without starting the polling goroutine to control polls manually.
+	sub := &Subscription{hub: hub, serverID: server.ID, events: make(chan Event, 1)}
+	tl := &tail{
+		server:      server,
+		cancel:      func() {},
+		subscribers: map[*Subscription]struct{}{sub: {}},
+	}
+	hub.tails[server.ID] = tl
+
+	reader.set("a\n", nil)
+	hub.poll(context.Background(), tl)
+	reader.set("a\nb\n", nil)
+	hub.poll(context.Background(), tl)
+
+	assert.Equal(t, Event{Type: EventTypeReset, Data: "a\n"}, nextEvent(t, sub))
+
+	reader.set("a\nb\nc\n", nil)
+	hub.poll(context.Background(), tl)
+
+	assert.Equal(t, Event{Type: EventTypeReset, Data: "a\nb\nc\n"}, nextEvent(t, sub))
+}
+
+func TestAppended(t *testing.T) {
+	tests := []struct {
+		name   string
+		prev   string
+		cur    string
+		want   string
+		wantOK bool
+	}{
+		{
+			name:   "empty previous output",
+			prev:   "",
+			cur:    "line 1\n",
+			want:   "line 1\n",
+			wantOK: true,
+		},
+		{
+			name:   "no changes",
+			prev:   "line 1\n",
+			cur:    "line 1\n",
+			want:   "",
+			wantOK: true,
+		},
+		{
+			name:   "appended output",
+			prev:   "line 1\n",
+			cur:    "line 1\nline 2\n",
+			want:   "line 2\n",
+			wantOK: true,
+		},
+		{
+			name:   "sliding window",
+			prev:   "line 1\nline 2\nline 3\n",
+			cur:    "line 2\nline 3\nline 4\n",
+			want:   "line 4\n",
+			wantOK: true,
+		},
+		{
+			name:   "sliding window with repeated lines",
+			prev:   "tick\ntick\ntick\n",
+			cur:    "tick\ntick\nstop\n",
+			want:   "stop\n",
+			wantOK: true,
+		},
+		{
+			name:   "sliding window with long lines",
+			prev:   strings.Repeat("x", 300) + "y" + strings.Repeat("z", 300),
+			cur:    strings.Repeat("x", 200) + "y" + strings.Repeat("z", 300) + "new",
+			want:   "new",
+			wantOK: true,
+		},
+		{
+			name:   "console cleared",
+			prev:   "line 1\nline 2\n",
+			cur:    "other\n",
+			wantOK: false,
+		},
+		{
+			name:   "most of the output replaced",
+			prev:   "line 1\nline 2\nline 3\nline 4\n",
+			cur:    "line 4\nother 1\nother 2\nother 3\n",
+			wantOK: false,
+		},
+		{
+			name:   "console emptied",
+			prev:   "line 1\n",
+			cur:    "",
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := appended(tt.prev, tt.cur)
+
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
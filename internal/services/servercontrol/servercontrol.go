@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/events"
 	"github.com/gameap/gameap/internal/filters"
 	"github.com/gameap/gameap/internal/repositories"
 	"github.com/gameap/gameap/internal/repositories/base"
@@ -18,10 +19,16 @@ const (
 	autostartCurrentSettingKey = "autostart_current"
 )
 
+// PausedSettingKey is the server setting marking the server as paused.
+// It is set once the pause task succeeds and cleared once an unpause, start, stop or restart task succeeds.
+const PausedSettingKey = "paused"
+
 var (
 	ErrAnotherTaskAlreadyExists      = errors.New("another task already exists, please wait until it is completed")
 	ErrEmptyServerStartCommand       = errors.New("empty server start command")
 	ErrServerUpdateInstallInProgress = errors.New("server update/install task is already in progress")
+	ErrEmptyNodePauseScript          = errors.New("pause script is not set for the node")
+	ErrEmptyNodeUnpauseScript        = errors.New("unpause script is not set for the node")
 )
 
 type TaskAlreadyExistsError struct {
@@ -42,17 +49,20 @@ func (e *TaskAlreadyExistsError) Error() string {
 type Service struct {
 	daemonTaskRepo    repositories.DaemonTaskRepository
 	serverSettingRepo repositories.ServerSettingRepository
+	nodeRepo          repositories.NodeRepository
 	tm                base.TransactionManager
 }
 
 func NewService(
 	daemonTaskRepo repositories.DaemonTaskRepository,
 	serverSettingRepo repositories.ServerSettingRepository,
+	nodeRepo repositories.NodeRepository,
 	tm base.TransactionManager,
 ) *Service {
 	return &Service{
 		daemonTaskRepo:    daemonTaskRepo,
 		serverSettingRepo: serverSettingRepo,
+		nodeRepo:          nodeRepo,
 		tm:                tm,
 	}
 }
@@ -108,6 +118,86 @@ func (s *Service) Restart(ctx context.Context, server *domain.Server) (uint, err
 	return taskID, nil
 }
 
+// Pause creates a server pause task. The node must have the pause script.
+// The server is marked as paused once the task succeeds, see HandleEvent.
+func (s *Service) Pause(ctx context.Context, server *domain.Server) (uint, error) {
+	node, err := s.findNode(ctx, server.DSID)
+	if err != nil {
+		return 0, err
+	}
+
+	if node.ScriptPause == nil || strings.TrimSpace(*node.ScriptPause) == "" {
+		return 0, ErrEmptyNodePauseScript
+	}
+
+	return s.addServerPause(ctx, server, 0)
+}
+
+// Unpause creates a server unpause task. The node must have the unpause script.
+// The paused mark is cleared once the task succeeds, see HandleEvent.
+func (s *Service) Unpause(ctx context.Context, server *domain.Server) (uint, error) {
+	node, err := s.findNode(ctx, server.DSID)
+	if err != nil {
+		return 0, err
+	}
+
+	if node.ScriptUnpause == nil || strings.TrimSpace(*node.ScriptUnpause) == "" {
+		return 0, ErrEmptyNodeUnpauseScript
+	}
+
+	return s.addServerUnpause(ctx, server, 0)
+}
+
+// IsPaused reports whether the server is marked as paused.
+func (s *Service) IsPaused(ctx context.Context, serverID uint) (bool, error) {
+	pausedSetting, err := s.getSetting(ctx, serverID, PausedSettingKey)
+	if err != nil {
+		return false, errors.WithMessage(err, "failed to get paused setting")
+	}
+
+	if pausedSetting == nil {
+		return false, nil
+	}
+
+	return IsPaused(*pausedSetting), nil
+}
+
+// IsPaused reports whether the setting marks the server as paused.
+func IsPaused(setting domain.ServerSetting) bool {
+	if setting.Name != PausedSettingKey {
+		return false
+	}
+
+	paused, _ := setting.Value.Bool()
+
+	return paused
+}
+
+// HandleEvent updates the paused mark of the server when its daemon task succeeds,
+// so a failed or not yet processed task doesn't change the reported state.
+func (s *Service) HandleEvent(ctx context.Context, event domain.Event) error {
+	if event.Type != domain.EventTypeDaemonTaskStatusChanged {
+		return nil
+	}
+
+	data, ok := event.Data.(events.DaemonTaskStatusChangedData)
+	if !ok || data.Task.ServerID == nil || data.Task.Status != string(domain.DaemonTaskStatusSuccess) {
+		return nil
+	}
+
+	switch domain.DaemonTaskType(data.Task.Task) {
+	case domain.DaemonTaskTypeServerPause:
+		return s.updatePaused(ctx, *data.Task.ServerID, true)
+	case domain.DaemonTaskTypeServerUnpause,
+		domain.DaemonTaskTypeServerStart,
+		domain.DaemonTaskTypeServerStop,
+		domain.DaemonTaskTypeServerRestart:
+		return s.resetPaused(ctx, *data.Task.ServerID)
+	default:
+		return nil
+	}
+}
+
 // Update creates a server update task.
 func (s *Service) Update(ctx context.Context, server *domain.Server) (uint, error) {
 	taskID, err := s.addServerUpdate(ctx, server, 0)
@@ -299,6 +389,76 @@ func (s *Service) addServerRestart(
 	return task.ID, nil
 }
 
+// addServerPause creates a new pausing of game server task.
+func (s *Service) addServerPause(
+	ctx context.Context,
+	server *domain.Server,
+	runAftID uint,
+) (uint, error) {
+	exists, err := s.workingTasksExist(
+		ctx,
+		server,
+		[]domain.DaemonTaskType{domain.DaemonTaskTypeServerPause},
+	)
+	if err != nil {
+		return 0, err
+	}
+	if exists {
+		return 0, &TaskAlreadyExistsError{taskName: "server pause"}
+	}
+
+	task := &domain.DaemonTask{
+		RunAftID:          lo.ToPtr(runAftID),
+		DedicatedServerID: server.DSID,
+		ServerID:          &server.ID,
+		Task:              domain.DaemonTaskTypeServerPause,
+		Status:            domain.DaemonTaskStatusWaiting,
+		CreatedAt:         lo.ToPtr(time.Now()),
+		UpdatedAt:         lo.ToPtr(time.Now()),
+	}
+
+	if err := s.daemonTaskRepo.Save(ctx, task); err != nil {
+		return 0, errors.WithMessage(err, "failed to save daemon task")
+	}
+
+	return task.ID, nil
+}
+
+// addServerUnpause creates a new unpausing of game server task.
+func (s *Service) addServerUnpause(
+	ctx context.Context,
+	server *domain.Server,
+	runAftID uint,
+) (uint, error) {
+	exists, err := s.workingTasksExist(
+		ctx,
+		server,
+		[]domain.DaemonTaskType{domain.DaemonTaskTypeServerUnpause},
+	)
+	if err != nil {
+		return 0, err
+	}
+	if exists {
+		return 0, &TaskAlreadyExistsError{taskName: "server unpause"}
+	}
+
+	task := &domain.DaemonTask{
+		RunAftID:          lo.ToPtr(runAftID),
+		DedicatedServerID: server.DSID,
+		ServerID:          &server.ID,
+		Task:              domain.DaemonTaskTypeServerUnpause,
+		Status:            domain.DaemonTaskStatusWaiting,
+		CreatedAt:         lo.ToPtr(time.Now()),
+		UpdatedAt:         lo.ToPtr(time.Now()),
+	}
+
+	if err := s.daemonTaskRepo.Save(ctx, task); err != nil {
+		return 0, errors.WithMessage(err, "failed to save daemon task")
+	}
+
+	return task.ID, nil
+}
+
 // addServerUpdate creates a new server update task.
 func (s *Service) addServerUpdate(
 	ctx context.Context,
@@ -451,6 +611,19 @@ func (s *Service) serverCommandCorrectOrFail(server *domain.Server) error {
 	return nil
 }
 
+func (s *Service) findNode(ctx context.Context, nodeID uint) (*domain.Node, error) {
+	nodes, err := s.nodeRepo.Find(ctx, &filters.FindNode{IDs: []uint{nodeID}}, nil, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find node")
+	}
+
+	if len(nodes) == 0 {
+		return nil, errors.New("node not found")
+	}
+
+	return &nodes[0], nil
+}
+
 // getSetting retrieves a server setting by name.
 func (s *Service) getSetting(
 	ctx context.Context,
@@ -523,3 +696,45 @@ func (s *Service) updateAutostartCurrent(
 
 	return nil
 }
+
+// updatePaused updates or creates the paused setting.
+func (s *Service) updatePaused(
+	ctx context.Context,
+	serverID uint,
+	value bool,
+) error {
+	pausedSetting, err := s.getSetting(ctx, serverID, PausedSettingKey)
+	if err != nil {
+		return errors.WithMessage(err, "failed to get paused setting")
+	}
+
+	if pausedSetting == nil {
+		pausedSetting = &domain.ServerSetting{
+			Name:     PausedSettingKey,
+			ServerID: serverID,
+			Value:    domain.NewServerSettingValue(value),
+		}
+	} else {
+		pausedSetting.Value = domain.NewServerSettingValue(value)
+	}
+
+	if err := s.serverSettingRepo.Save(ctx, pausedSetting); err != nil {
+		return errors.WithMessage(err, "failed to save paused setting")
+	}
+
+	return nil
+}
+
+// resetPaused clears the paused setting if the server is marked as paused.
+func (s *Service) resetPaused(ctx context.Context, serverID uint) error {
+	paused, err := s.IsPaused(ctx, serverID)
+	if err != nil {
+		return err
+	}
+
+	if !paused {
+		return nil
+	}
+
+	return s.updatePaused(ctx, serverID, false)
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/events"
 	"github.com/gameap/gameap/internal/filters"
 	"github.com/gameap/gameap/internal/repositories/inmemory"
 	"github.com/gameap/gameap/internal/services"
@@ -130,7 +131,7 @@ func TestServerControlService_Start(t *testing.T) {
 			tt.setupSettings(settingRepo)
 			tt.setupTasks(taskRepo)
 
-			service := NewService(taskRepo, settingRepo, services.NewNilTransactionManager())
+			service := NewService(taskRepo, settingRepo, inmemory.NewNodeRepository(), services.NewNilTransactionManager())
 
 			taskID, err := service.Start(context.Background(), tt.server)
 
@@ -225,7 +226,7 @@ func TestServerControlService_Stop(t *testing.T) {
 			tt.setupSettings(settingRepo)
 			tt.setupTasks(taskRepo)
 
-			service := NewService(taskRepo, settingRepo, services.NewNilTransactionManager())
+			service := NewService(taskRepo, settingRepo, inmemory.NewNodeRepository(), services.NewNilTransactionManager())
 
 			taskID, err := service.Stop(context.Background(), tt.server)
 
@@ -340,7 +341,7 @@ func TestServerControlService_Restart(t *testing.T) {
 			tt.setupSettings(settingRepo)
 			tt.setupTasks(taskRepo)
 
-			service := NewService(taskRepo, settingRepo, services.NewNilTransactionManager())
+			service := NewService(taskRepo, settingRepo, inmemory.NewNodeRepository(), services.NewNilTransactionManager())
 
 			taskID, err := service.Restart(context.Background(), tt.server)
 
@@ -360,6 +361,232 @@ func TestServerControlService_Restart(t *testing.T) {
 	}
 }
 
+func newNodeRepository(t *testing.T, scriptPause, scriptUnpause *string) *inmemory.NodeRepository {
+	t.Helper()
+
+	repo := inmemory.NewNodeRepository()
+	require.NoError(t, repo.Save(context.Background(), &domain.Node{
+		ID:            10,
+		ScriptPause:   scriptPause,
+		ScriptUnpause: scriptUnpause,
+	}))
+
+	return repo
+}
+
+func taskSucceeded(taskID uint, serverID uint, taskType domain.DaemonTaskType) domain.Event {
+	return events.DaemonTaskStatusChanged(&domain.DaemonTask{
+		ID:       taskID,
+		ServerID: &serverID,
+		Task:     taskType,
+		Status:   domain.DaemonTaskStatusSuccess,
+	}, domain.DaemonTaskStatusWorking)
+}
+
+func TestServerControlService_Pause(t *testing.T) {
+	tests := []struct {
+		name        string
+		scriptPause *string
+		setupTasks  func(*inmemory.DaemonTaskRepository)
+		wantErr     error
+		errContains string
+	}{
+		{
+			name:        "successful pause",
+			scriptPause: lo.ToPtr("kill -STOP {pid}"),
+			setupTasks:  func(_ *inmemory.DaemonTaskRepository) {},
+		},
+		{
+			name:        "error when pause task already exists",
+			scriptPause: lo.ToPtr("kill -STOP {pid}"),
+			setupTasks: func(repo *inmemory.DaemonTaskRepository) {
+				serverID := uint(1)
+				_ = repo.Save(context.Background(), &domain.DaemonTask{
+					ServerID:          &serverID,
+					DedicatedServerID: 10,
+					Task:              domain.DaemonTaskTypeServerPause,
+					Status:            domain.DaemonTaskStatusWorking,
+				})
+			},
+			errContains: "task 'server pause' already exists",
+		},
+		{
+			name:       "error when node has no pause script",
+			setupTasks: func(_ *inmemory.DaemonTaskRepository) {},
+			wantErr:    ErrEmptyNodePauseScript,
+		},
+		{
+			name:        "error when node pause script is blank",
+			scriptPause: lo.ToPtr("  "),
+			setupTasks:  func(_ *inmemory.DaemonTaskRepository) {},
+			wantErr:     ErrEmptyNodePauseScript,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settingRepo := inmemory.NewServerSettingRepository()
+			taskRepo := inmemory.NewDaemonTaskRepository()
+			nodeRepo := newNodeRepository(t, tt.scriptPause, lo.ToPtr("kill -CONT {pid}"))
+
+			tt.setupTasks(taskRepo)
+
+			service := NewService(taskRepo, settingRepo, nodeRepo, services.NewNilTransactionManager())
+			server := &domain.Server{ID: 1, DSID: 10}
+
+			taskID, err := service.Pause(context.Background(), server)
+
+			if tt.wantErr != nil || tt.errContains != "" {
+				require.Error(t, err)
+				if tt.wantErr != nil {
+					require.ErrorIs(t, err, tt.wantErr)
+				}
+				assert.Contains(t, err.Error(), tt.errContains)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			tasks, err := taskRepo.Find(context.Background(), &filters.FindDaemonTask{
+				IDs: []uint{taskID},
+			}, nil, nil)
+			require.NoError(t, err)
+			require.Len(t, tasks, 1)
+			assert.Equal(t, domain.DaemonTaskTypeServerPause, tasks[0].Task)
+			assert.Equal(t, domain.DaemonTaskStatusWaiting, tasks[0].Status)
+
+			// The server isn't paused until the task succeeds
+			paused, err := service.IsPaused(context.Background(), server.ID)
+			require.NoError(t, err)
+			assert.False(t, paused)
+		})
+	}
+}
+
+func TestServerControlService_Unpause(t *testing.T) {
+	t.Run("creates_unpause_task", func(t *testing.T) {
+		taskRepo := inmemory.NewDaemonTaskRepository()
+		nodeRepo := newNodeRepository(t, lo.ToPtr("kill -STOP {pid}"), lo.ToPtr("kill -CONT {pid}"))
+		service := NewService(taskRepo, inmemory.NewServerSettingRepository(), nodeRepo, services.NewNilTransactionManager())
+		server := &domain.Server{ID: 1, DSID: 10}
+
+		taskID, err := service.Unpause(context.Background(), server)
+		require.NoError(t, err)
+
+		tasks, err := taskRepo.Find(context.Background(), &filters.FindDaemonTask{
+			IDs: []uint{taskID},
+		}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		assert.Equal(t, domain.DaemonTaskTypeServerUnpause, tasks[0].Task)
+
+		_, err = service.Unpause(context.Background(), server)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "task 'server unpause' already exists")
+	})
+
+	t.Run("error_when_node_has_no_unpause_script", func(t *testing.T) {
+		nodeRepo := newNodeRepository(t, lo.ToPtr("kill -STOP {pid}"), nil)
+		service := NewService(
+			inmemory.NewDaemonTaskRepository(),
+			inmemory.NewServerSettingRepository(),
+			nodeRepo,
+			services.NewNilTransactionManager(),
+		)
+
+		_, err := service.Unpause(context.Background(), &domain.Server{ID: 1, DSID: 10})
+		require.ErrorIs(t, err, ErrEmptyNodeUnpauseScript)
+	})
+}
+
+func TestServerControlService_HandleEvent(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		paused     bool
+		event      domain.Event
+		wantPaused bool
+	}{
+		{
+			name:       "pause_succeeded",
+			event:      taskSucceeded(1, 1, domain.DaemonTaskTypeServerPause),
+			wantPaused: true,
+		},
+		{
+			name: "pause_failed",
+			event: events.DaemonTaskStatusChanged(&domain.DaemonTask{
+				ID:       1,
+				ServerID: lo.ToPtr(uint(1)),
+				Task:     domain.DaemonTaskTypeServerPause,
+				Status:   domain.DaemonTaskStatusError,
+			}, domain.DaemonTaskStatusWorking),
+			wantPaused: false,
+		},
+		{
+			name:       "unpause_succeeded",
+			paused:     true,
+			event:      taskSucceeded(1, 1, domain.DaemonTaskTypeServerUnpause),
+			wantPaused: false,
+		},
+		{
+			name:   "unpause_failed",
+			paused: true,
+			event: events.DaemonTaskStatusChanged(&domain.DaemonTask{
+				ID:       1,
+				ServerID: lo.ToPtr(uint(1)),
+				Task:     domain.DaemonTaskTypeServerUnpause,
+				Status:   domain.DaemonTaskStatusError,
+			}, domain.DaemonTaskStatusWorking),
+			wantPaused: true,
+		},
+		{
+			name:       "stop_succeeded",
+			paused:     true,
+			event:      taskSucceeded(1, 1, domain.DaemonTaskTypeServerStop),
+			wantPaused: false,
+		},
+		{
+			name:       "update_succeeded",
+			paused:     true,
+			event:      taskSucceeded(1, 1, domain.DaemonTaskTypeServerUpdate),
+			wantPaused: true,
+		},
+		{
+			name:       "pause_of_another_server_succeeded",
+			event:      taskSucceeded(1, 2, domain.DaemonTaskTypeServerPause),
+			wantPaused: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settingRepo := inmemory.NewServerSettingRepository()
+			service := NewService(
+				inmemory.NewDaemonTaskRepository(),
+				settingRepo,
+				inmemory.NewNodeRepository(),
+				services.NewNilTransactionManager(),
+			)
+
+			if tt.paused {
+				require.NoError(t, settingRepo.Save(ctx, &domain.ServerSetting{
+					Name:     PausedSettingKey,
+					ServerID: 1,
+					Value:    domain.NewServerSettingValue(true),
+				}))
+			}
+
+			require.NoError(t, service.HandleEvent(ctx, tt.event))
+
+			paused, err := service.IsPaused(ctx, 1)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPaused, paused)
+		})
+	}
+}
+
 func TestServerControlService_Update(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -436,7 +663,7 @@ func TestServerControlService_Update(t *testing.T) {
 
 			tt.setupTasks(taskRepo)
 
-			service := NewService(taskRepo, settingRepo, services.NewNilTransactionManager())
+			service := NewService(taskRepo, settingRepo, inmemory.NewNodeRepository(), services.NewNilTransactionManager())
 
 			taskID, err := service.Update(context.Background(), tt.server)
 
@@ -514,7 +741,7 @@ func TestServerControlService_Install(t *testing.T) {
 
 			tt.setupTasks(taskRepo)
 
-			service := NewService(taskRepo, settingRepo, services.NewNilTransactionManager())
+			service := NewService(taskRepo, settingRepo, inmemory.NewNodeRepository(), services.NewNilTransactionManager())
 
 			taskID, err := service.Install(context.Background(), tt.server)
 
@@ -617,7 +844,7 @@ func TestServerControlService_Reinstall(t *testing.T) {
 
 			tt.setupTasks(taskRepo)
 
-			service := NewService(taskRepo, settingRepo, services.NewNilTransactionManager())
+			service := NewService(taskRepo, settingRepo, inmemory.NewNodeRepository(), services.NewNilTransactionManager())
 
 			taskID, err := service.Reinstall(context.Background(), tt.server)
 
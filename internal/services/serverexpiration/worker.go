@@ -0,0 +1,164 @@
+package serverexpiration
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/servercontrol"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+type serverControl interface {
+	Stop(ctx context.Context, server *domain.Server) (uint, error)
+}
+
+// Worker enforces server expiration dates.
+// When the grace period of an expired server is over, the server is stopped and blocked.
+// When the deletion deadline is reached, the server is soft deleted.
+type Worker struct {
+	serverRepo    repositories.ServerRepository
+	serverControl serverControl
+	policy        domain.ServerExpirationPolicy
+	interval      time.Duration
+}
+
+func NewWorker(
+	serverRepo repositories.ServerRepository,
+	serverControl serverControl,
+	policy domain.ServerExpirationPolicy,
+	interval time.Duration,
+) *Worker {
+	return &Worker{
+		serverRepo:    serverRepo,
+		serverControl: serverControl,
+		policy:        policy,
+		interval:      interval,
+	}
+}
+
+// Run processes expired servers periodically until the context is canceled.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.Process(ctx, time.Now()); err != nil {
+			slog.ErrorContext(ctx, "Failed to process expired servers", slog.String("error", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Process blocks and deletes servers whose deadlines are before the given time.
+func (w *Worker) Process(ctx context.Context, now time.Time) error {
+	if err := w.blockExpired(ctx, now); err != nil {
+		return errors.WithMessage(err, "failed to block expired servers")
+	}
+
+	if w.policy.DeleteAfter > 0 {
+		if err := w.deleteExpired(ctx, now); err != nil {
+			return errors.WithMessage(err, "failed to delete expired servers")
+		}
+	}
+
+	return nil
+}
+
+func (w *Worker) blockExpired(ctx context.Context, now time.Time) error {
+	servers, err := w.serverRepo.Find(ctx, &filters.FindServer{
+		Blocked:       lo.ToPtr(false),
+		ExpiresBefore: lo.ToPtr(now.Add(-w.policy.GracePeriod)),
+	}, nil, nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed to find expired servers")
+	}
+
+	for i := range servers {
+		server := &servers[i]
+
+		if err = w.block(ctx, server); err != nil {
+			slog.ErrorContext(
+				ctx,
+				"Failed to block expired server",
+				slog.Uint64("server_id", uint64(server.ID)),
+				slog.String("error", err.Error()),
+			)
+
+			continue
+		}
+
+		slog.InfoContext(
+			ctx,
+			"Expired server has been stopped and blocked",
+			slog.Uint64("server_id", uint64(server.ID)),
+			slog.Time("expires", *server.Expires),
+		)
+	}
+
+	return nil
+}
+
+func (w *Worker) block(ctx context.Context, server *domain.Server) error {
+	_, err := w.serverControl.Stop(ctx, server)
+
+	var taskExistsErr *servercontrol.TaskAlreadyExistsError
+	if err != nil && !errors.As(err, &taskExistsErr) {
+		return errors.WithMessage(err, "failed to stop server")
+	}
+
+	server.Blocked = true
+
+	if err = w.serverRepo.Save(ctx, server); err != nil {
+		return errors.WithMessage(err, "failed to save server")
+	}
+
+	return nil
+}
+
+func (w *Worker) deleteExpired(ctx context.Context, now time.Time) error {
+	servers, err := w.serverRepo.Find(ctx, &filters.FindServer{
+		ExpiresBefore: lo.ToPtr(now.Add(-max(w.policy.DeleteAfter, w.policy.GracePeriod))),
+	}, nil, nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed to find expired servers")
+	}
+
+	for i := range servers {
+		server := &servers[i]
+
+		// Servers are deleted only after they have been stopped and blocked.
+		if !server.Blocked {
+			continue
+		}
+
+		if err = w.serverRepo.SoftDelete(ctx, server.ID); err != nil {
+			slog.ErrorContext(
+				ctx,
+				"Failed to delete expired server",
+				slog.Uint64("server_id", uint64(server.ID)),
+				slog.String("error", err.Error()),
+			)
+
+			continue
+		}
+
+		slog.InfoContext(
+			ctx,
+			"Expired server has been deleted",
+			slog.Uint64("server_id", uint64(server.ID)),
+			slog.Time("expires", *server.Expires),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+package serverexpiration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/servercontrol"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupWorker(
+	t *testing.T,
+	policy domain.ServerExpirationPolicy,
+) (*Worker, *inmemory.ServerRepository, *inmemory.DaemonTaskRepository) {
+	t.Helper()
+
+	serverRepo := inmemory.NewServerRepository()
+	daemonTaskRepo := inmemory.NewDaemonTaskRepository()
+	serverControl := servercontrol.NewService(
+		daemonTaskRepo,
+		inmemory.NewServerSettingRepository(),
+		inmemory.NewNodeRepository(),
+		services.NewNilTransactionManager(),
+	)
+
+	return NewWorker(serverRepo, serverControl, policy, time.Minute), serverRepo, daemonTaskRepo
+}
+
+func createServer(t *testing.T, repo *inmemory.ServerRepository, expires *time.Time, blocked bool) *domain.Server {
+	t.Helper()
+
+	server := &domain.Server{
+		UUID:         uuid.New(),
+		Enabled:      true,
+		Installed:    domain.ServerInstalledStatusInstalled,
+		Blocked:      blocked,
+		Name:         "Test Server",
+		GameID:       "cstrike",
+		DSID:         1,
+		Expires:      expires,
+		ServerIP:     "127.0.0.1",
+		ServerPort:   27015,
+		StartCommand: lo.ToPtr("./start.sh"),
+	}
+
+	require.NoError(t, repo.Save(context.Background(), server))
+
+	return server
+}
+
+func findServer(t *testing.T, repo *inmemory.ServerRepository, id uint) domain.Server {
+	t.Helper()
+
+	servers, err := repo.Find(context.Background(), &filters.FindServer{
+		IDs:         []uint{id},
+		WithDeleted: true,
+	}, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+
+	return servers[0]
+}
+
+func stopTasks(t *testing.T, repo *inmemory.DaemonTaskRepository, serverID uint) []domain.DaemonTask {
+	t.Helper()
+
+	tasks, err := repo.Find(context.Background(), &filters.FindDaemonTask{
+		ServerIDs: []*uint{&serverID},
+		Tasks:     []domain.DaemonTaskType{domain.DaemonTaskTypeServerStop},
+	}, nil, nil)
+	require.NoError(t, err)
+
+	return tasks
+}
+
+func TestWorker_Process_BlocksExpiredServers(t *testing.T) {
+	now := time.Now()
+	worker, serverRepo, daemonTaskRepo := setupWorker(t, domain.ServerExpirationPolicy{})
+
+	expired := createServer(t, serverRepo, lo.ToPtr(now.Add(-time.Hour)), false)
+	active := createServer(t, serverRepo, lo.ToPtr(now.Add(time.Hour)), false)
+	withoutExpiration := createServer(t, serverRepo, nil, false)
+
+	require.NoError(t, worker.Process(context.Background(), now))
+
+	assert.True(t, findServer(t, serverRepo, expired.ID).Blocked)
+	assert.Len(t, stopTasks(t, daemonTaskRepo, expired.ID), 1)
+
+	assert.False(t, findServer(t, serverRepo, active.ID).Blocked)
+	assert.Empty(t, stopTasks(t, daemonTaskRepo, active.ID))
+
+	assert.False(t, findServer(t, serverRepo, withoutExpiration.ID).Blocked)
+	assert.Empty(t, stopTasks(t, daemonTaskRepo, withoutExpiration.ID))
+}
+
+func TestWorker_Process_RespectsGracePeriod(t *testing.T) {
+	now := time.Now()
+	worker, serverRepo, daemonTaskRepo := setupWorker(t, domain.ServerExpirationPolicy{
+		GracePeriod: 24 * time.Hour,
+	})
+
+	inGracePeriod := createServer(t, serverRepo, lo.ToPtr(now.Add(-time.Hour)), false)
+	afterGracePeriod := createServer(t, serverRepo, lo.ToPtr(now.Add(-25*time.Hour)), false)
+
+	require.NoError(t, worker.Process(context.Background(), now))
+
+	assert.False(t, findServer(t, serverRepo, inGracePeriod.ID).Blocked)
+	assert.Empty(t, stopTasks(t, daemonTaskRepo, inGracePeriod.ID))
+
+	assert.True(t, findServer(t, serverRepo, afterGracePeriod.ID).Blocked)
+	assert.Len(t, stopTasks(t, daemonTaskRepo, afterGracePeriod.ID), 1)
+}
+
+func TestWorker_Process_StopTaskAlreadyExists(t *testing.T) {
+	now := time.Now()
+	worker, serverRepo, daemonTaskRepo := setupWorker(t, domain.ServerExpirationPolicy{})
+
+	server := createServer(t, serverRepo, lo.ToPtr(now.Add(-time.Hour)), false)
+
+	require.NoError(t, daemonTaskRepo.Save(context.Background(), &domain.DaemonTask{
+		DedicatedServerID: server.DSID,
+		ServerID:          &server.ID,
+		Task:              domain.DaemonTaskTypeServerStop,
+		Status:            domain.DaemonTaskStatusWaiting,
+	}))
+
+	require.NoError(t, worker.Process(context.Background(), now))
+
+	assert.True(t, findServer(t, serverRepo, server.ID).Blocked)
+	assert.Len(t, stopTasks(t, daemonTaskRepo, server.ID), 1)
+}
+
+func TestWorker_Process_DeletesBlockedServers(t *testing.T) {
+	now := time.Now()
+	worker, serverRepo, _ := setupWorker(t, domain.ServerExpirationPolicy{
+		GracePeriod: time.Hour,
+		DeleteAfter: 7 * 24 * time.Hour,
+	})
+
+	toDelete := createServer(t, serverRepo, lo.ToPtr(now.Add(-8*24*time.Hour)), true)
+	blocked := createServer(t, serverRepo, lo.ToPtr(now.Add(-2*time.Hour)), true)
+
+	require.NoError(t, worker.Process(context.Background(), now))
+
+	assert.NotNil(t, findServer(t, serverRepo, toDelete.ID).DeletedAt)
+	assert.Nil(t, findServer(t, serverRepo, blocked.ID).DeletedAt)
+}
+
+func TestWorker_Process_DeletionDisabled(t *testing.T) {
+	now := time.Now()
+	worker, serverRepo, _ := setupWorker(t, domain.ServerExpirationPolicy{})
+
+	server := createServer(t, serverRepo, lo.ToPtr(now.Add(-365*24*time.Hour)), true)
+
+	require.NoError(t, worker.Process(context.Background(), now))
+
+	assert.Nil(t, findServer(t, serverRepo, server.ID).DeletedAt)
+}
+
+func TestWorker_Process_ExpiredServerIsBlockedBeforeDeletion(t *testing.T) {
+	now := time.Now()
+	worker, serverRepo, daemonTaskRepo := setupWorker(t, domain.ServerExpirationPolicy{
+		DeleteAfter: 24 * time.Hour,
+	})
+
+	server := createServer(t, serverRepo, lo.ToPtr(now.Add(-48*time.Hour)), false)
+
+	require.NoError(t, worker.Process(context.Background(), now))
+
+	result := findServer(t, serverRepo, server.ID)
+	assert.True(t, result.Blocked)
+	assert.NotNil(t, result.DeletedAt)
+	assert.Len(t, stopTasks(t, daemonTaskRepo, server.ID), 1)
+}
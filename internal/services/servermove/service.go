@@ -0,0 +1,272 @@
+package servermove
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/repositories/base"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+var (
+	ErrSameNode             = api.NewValidationError("server is already on the target node")
+	ErrTargetNodeNotFound   = api.NewValidationError("target node not found")
+	ErrTargetNodeDisabled   = api.NewValidationError("target node is disabled")
+	ErrIPNotAssignedToNode  = api.NewValidationError("server_ip is not assigned to the target node")
+	ErrDirAlreadyUsed       = api.NewValidationError("dir is already used by another server on the target node")
+	ErrInvalidDir           = api.NewValidationError("dir must be a relative path without \"..\" segments and special characters")
+	ErrUnfinishedTasksExist = errors.New("server has unfinished daemon tasks, please wait until they are completed")
+)
+
+// Target describes where the server is moved to.
+type Target struct {
+	NodeID     uint
+	ServerIP   string
+	ServerPort int
+	QueryPort  *int
+	RconPort   *int
+	// Dir is relative to the work path of the target node.
+	Dir string
+}
+
+// moveData is stored in the data field of the move and copy daemon tasks.
+type moveData struct {
+	SourceNodeID uint   `json:"source_node_id"`
+	SourceDir    string `json:"source_dir"`
+	TargetNodeID uint   `json:"target_node_id"`
+	ServerIP     string `json:"server_ip"`
+	ServerPort   int    `json:"server_port"`
+	QueryPort    *int   `json:"query_port,omitempty"`
+	RconPort     *int   `json:"rcon_port,omitempty"`
+	Dir          string `json:"dir"`
+	// StartTaskID is the task starting the server on the target node, it is zero if the server was offline.
+	StartTaskID uint `json:"start_task_id,omitempty"`
+}
+
+// Service schedules moving game servers between nodes and copying server files.
+//
+// A move is a chain of daemon tasks: the server is stopped on the source node,
+// then the panel transfers the server files and switches the server to the target node,
+// finally the server is started on the target node if it was online before the move.
+// Copying the files of a server to another server is a single task without stopping the source server.
+// Both transfers are executed by the Worker.
+type Service struct {
+	daemonTaskRepo repositories.DaemonTaskRepository
+	serverRepo     repositories.ServerRepository
+	nodeRepo       repositories.NodeRepository
+	tm             base.TransactionManager
+}
+
+func NewService(
+	daemonTaskRepo repositories.DaemonTaskRepository,
+	serverRepo repositories.ServerRepository,
+	nodeRepo repositories.NodeRepository,
+	tm base.TransactionManager,
+) *Service {
+	return &Service{
+		daemonTaskRepo: daemonTaskRepo,
+		serverRepo:     serverRepo,
+		nodeRepo:       nodeRepo,
+		tm:             tm,
+	}
+}
+
+// Move validates the target and creates the move task chain. It returns the id of the move task.
+func (s *Service) Move(ctx context.Context, server *domain.Server, target Target) (uint, error) {
+	// The current directory is kept as is, so the servers created before the validation can be moved too
+	if target.Dir == "" {
+		target.Dir = server.Dir
+	} else if !domain.IsValidServerDir(target.Dir) {
+		return 0, ErrInvalidDir
+	}
+
+	if err := s.validate(ctx, server, target); err != nil {
+		return 0, err
+	}
+
+	exists, err := s.daemonTaskRepo.Exists(ctx, &filters.FindDaemonTask{
+		ServerIDs: []*uint{&server.ID},
+		Statuses: []domain.DaemonTaskStatus{
+			domain.DaemonTaskStatusWaiting,
+			domain.DaemonTaskStatusWorking,
+		},
+	})
+	if err != nil {
+		return 0, errors.WithMessage(err, "failed to check daemon task existence")
+	}
+	if exists {
+		return 0, ErrUnfinishedTasksExist
+	}
+
+	data := moveData{
+		SourceNodeID: server.DSID,
+		SourceDir:    server.Dir,
+		TargetNodeID: target.NodeID,
+		ServerIP:     target.ServerIP,
+		ServerPort:   target.ServerPort,
+		QueryPort:    target.QueryPort,
+		RconPort:     target.RconPort,
+		Dir:          target.Dir,
+	}
+
+	var moveTaskID uint
+
+	err = s.tm.Do(ctx, func(ctx context.Context) error {
+		stopTask := newDaemonTask(server, server.DSID, domain.DaemonTaskTypeServerStop, nil)
+		if err := s.daemonTaskRepo.Save(ctx, stopTask); err != nil {
+			return errors.WithMessage(err, "failed to create stop task")
+		}
+
+		moveTask := newDaemonTask(server, server.DSID, domain.DaemonTaskTypeServerMove, &stopTask.ID)
+		if err := s.saveMoveTask(ctx, moveTask, &data); err != nil {
+			return errors.WithMessage(err, "failed to create move task")
+		}
+
+		moveTaskID = moveTask.ID
+
+		if !server.IsOnline() {
+			return nil
+		}
+
+		startTask := newDaemonTask(server, target.NodeID, domain.DaemonTaskTypeServerStart, &moveTask.ID)
+		if err := s.daemonTaskRepo.Save(ctx, startTask); err != nil {
+			return errors.WithMessage(err, "failed to create start task")
+		}
+
+		data.StartTaskID = startTask.ID
+
+		if err := s.saveMoveTask(ctx, moveTask, &data); err != nil {
+			return errors.WithMessage(err, "failed to update move task")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return moveTaskID, nil
+}
+
+// Copy creates a task copying the files of the source server into the directory of the target server.
+// The target server must be saved already, its node and directory are the copy destination.
+// It returns the id of the copy task.
+func (s *Service) Copy(ctx context.Context, source, target *domain.Server) (uint, error) {
+	if !domain.IsValidServerDir(target.Dir) {
+		return 0, ErrInvalidDir
+	}
+
+	task := newDaemonTask(target, target.DSID, domain.DaemonTaskTypeServerCopy, nil)
+
+	err := s.saveMoveTask(ctx, task, &moveData{
+		SourceNodeID: source.DSID,
+		SourceDir:    source.Dir,
+		TargetNodeID: target.DSID,
+		ServerIP:     target.ServerIP,
+		ServerPort:   target.ServerPort,
+		QueryPort:    target.QueryPort,
+		RconPort:     target.RconPort,
+		Dir:          target.Dir,
+	})
+	if err != nil {
+		return 0, errors.WithMessage(err, "failed to create copy task")
+	}
+
+	return task.ID, nil
+}
+
+func (s *Service) validate(ctx context.Context, server *domain.Server, target Target) error {
+	if server.DSID == target.NodeID {
+		return ErrSameNode
+	}
+
+	nodes, err := s.nodeRepo.Find(ctx, &filters.FindNode{
+		IDs: []uint{target.NodeID},
+	}, nil, &filters.Pagination{
+		Limit: 1,
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed to find target node")
+	}
+
+	if len(nodes) == 0 {
+		return ErrTargetNodeNotFound
+	}
+
+	node := &nodes[0]
+
+	if !node.Enabled {
+		return ErrTargetNodeDisabled
+	}
+
+	if !slices.Contains(node.IPs, target.ServerIP) {
+		return ErrIPNotAssignedToNode
+	}
+
+	servers, err := s.serverRepo.Find(ctx, &filters.FindServer{
+		DSIDs: []uint{node.ID},
+	}, nil, nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed to find target node servers")
+	}
+
+	if lo.ContainsBy(servers, func(srv domain.Server) bool {
+		return srv.Dir == target.Dir
+	}) {
+		return ErrDirAlreadyUsed
+	}
+
+	busyPorts := domain.ServersBusyPorts(servers)[target.ServerIP]
+
+	moved := domain.Server{
+		ServerPort: target.ServerPort,
+		QueryPort:  target.QueryPort,
+		RconPort:   target.RconPort,
+	}
+
+	for _, port := range moved.Ports() {
+		if slices.Contains(busyPorts, port) {
+			return api.NewValidationError(
+				fmt.Sprintf("port %d is already in use on %s", port, target.ServerIP),
+			)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) saveMoveTask(ctx context.Context, task *domain.DaemonTask, data *moveData) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return errors.WithMessage(err, "failed to marshal move data")
+	}
+
+	task.Data = lo.ToPtr(string(b))
+
+	return s.daemonTaskRepo.Save(ctx, task)
+}
+
+func newDaemonTask(
+	server *domain.Server,
+	nodeID uint,
+	taskType domain.DaemonTaskType,
+	runAftID *uint,
+) *domain.DaemonTask {
+	return &domain.DaemonTask{
+		RunAftID:          runAftID,
+		DedicatedServerID: nodeID,
+		ServerID:          lo.ToPtr(server.ID),
+		Task:              taskType,
+		Status:            domain.DaemonTaskStatusWaiting,
+		CreatedAt:         lo.ToPtr(time.Now()),
+		UpdatedAt:         lo.ToPtr(time.Now()),
+	}
+}
@@ -0,0 +1,267 @@
+package servermove
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type serviceEnv struct {
+	service        *Service
+	daemonTaskRepo *inmemory.DaemonTaskRepository
+	serverRepo     *inmemory.ServerRepository
+	nodeRepo       *inmemory.NodeRepository
+	server         *domain.Server
+}
+
+func newServiceEnv(t *testing.T) *serviceEnv {
+	t.Helper()
+
+	ctx := context.Background()
+
+	env := &serviceEnv{
+		daemonTaskRepo: inmemory.NewDaemonTaskRepository(),
+		serverRepo:     inmemory.NewServerRepository(),
+		nodeRepo:       inmemory.NewNodeRepository(),
+	}
+
+	require.NoError(t, env.nodeRepo.Save(ctx, &domain.Node{
+		ID:       1,
+		Enabled:  true,
+		IPs:      domain.IPList{"10.0.0.1"},
+		WorkPath: "/srv/gameap",
+	}))
+	require.NoError(t, env.nodeRepo.Save(ctx, &domain.Node{
+		ID:       2,
+		Enabled:  true,
+		IPs:      domain.IPList{"10.0.0.2"},
+		WorkPath: "/opt/gameap",
+	}))
+	require.NoError(t, env.nodeRepo.Save(ctx, &domain.Node{
+		ID:       3,
+		Enabled:  false,
+		IPs:      domain.IPList{"10.0.0.3"},
+		WorkPath: "/srv/gameap",
+	}))
+
+	env.server = &domain.Server{
+		ID:           1,
+		UUID:         uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+		DSID:         1,
+		ServerIP:     "10.0.0.1",
+		ServerPort:   27015,
+		QueryPort:    lo.ToPtr(27016),
+		Dir:          "servers/cs",
+		StartCommand: lo.ToPtr("./hlds_run"),
+	}
+	require.NoError(t, env.serverRepo.Save(ctx, env.server))
+
+	// Server which already occupies ports on the target node
+	require.NoError(t, env.serverRepo.Save(ctx, &domain.Server{
+		ID:         2,
+		UUID:       uuid.MustParse("22222222-2222-2222-2222-222222222222"),
+		DSID:       2,
+		ServerIP:   "10.0.0.2",
+		ServerPort: 27015,
+		RconPort:   lo.ToPtr(27020),
+		Dir:        "servers/other",
+	}))
+
+	env.service = NewService(
+		env.daemonTaskRepo,
+		env.serverRepo,
+		env.nodeRepo,
+		services.NewNilTransactionManager(),
+	)
+
+	return env
+}
+
+func validTarget() Target {
+	return Target{
+		NodeID:     2,
+		ServerIP:   "10.0.0.2",
+		ServerPort: 27025,
+		QueryPort:  lo.ToPtr(27026),
+	}
+}
+
+func TestService_Move_CreatesTaskChain(t *testing.T) {
+	env := newServiceEnv(t)
+	env.server.ProcessActive = true
+	env.server.LastProcessCheck = lo.ToPtr(time.Now())
+
+	moveTaskID, err := env.service.Move(context.Background(), env.server, validTarget())
+	require.NoError(t, err)
+
+	tasks, err := env.daemonTaskRepo.FindAll(context.Background(), nil, nil)
+	require.NoError(t, err)
+	require.Len(t, tasks, 3)
+
+	byType := lo.KeyBy(tasks, func(task domain.DaemonTask) domain.DaemonTaskType {
+		return task.Task
+	})
+
+	stopTask := byType[domain.DaemonTaskTypeServerStop]
+	moveTask := byType[domain.DaemonTaskTypeServerMove]
+	startTask := byType[domain.DaemonTaskTypeServerStart]
+
+	assert.Equal(t, moveTaskID, moveTask.ID)
+	assert.Equal(t, uint(1), stopTask.DedicatedServerID)
+	assert.Equal(t, &stopTask.ID, moveTask.RunAftID)
+	assert.Equal(t, uint(2), startTask.DedicatedServerID)
+	assert.Equal(t, &moveTask.ID, startTask.RunAftID)
+
+	data, err := parseMoveData(&moveTask)
+	require.NoError(t, err)
+	assert.Equal(t, &moveData{
+		SourceNodeID: 1,
+		SourceDir:    "servers/cs",
+		TargetNodeID: 2,
+		ServerIP:     "10.0.0.2",
+		ServerPort:   27025,
+		QueryPort:    lo.ToPtr(27026),
+		Dir:          "servers/cs",
+		StartTaskID:  startTask.ID,
+	}, data)
+}
+
+func TestService_Move_OfflineServerIsNotStarted(t *testing.T) {
+	env := newServiceEnv(t)
+
+	_, err := env.service.Move(context.Background(), env.server, validTarget())
+	require.NoError(t, err)
+
+	tasks, err := env.daemonTaskRepo.FindAll(context.Background(), nil, nil)
+	require.NoError(t, err)
+
+	taskTypes := lo.Map(tasks, func(task domain.DaemonTask, _ int) domain.DaemonTaskType {
+		return task.Task
+	})
+	assert.ElementsMatch(t, []domain.DaemonTaskType{
+		domain.DaemonTaskTypeServerStop,
+		domain.DaemonTaskTypeServerMove,
+	}, taskTypes)
+}
+
+func TestService_Move_Validation(t *testing.T) {
+	tests := []struct {
+		name      string
+		target    func(target *Target)
+		setup     func(t *testing.T, env *serviceEnv)
+		wantError string
+	}{
+		{
+			name:      "same node",
+			target:    func(target *Target) { target.NodeID = 1 },
+			wantError: ErrSameNode.Error(),
+		},
+		{
+			name:      "target node not found",
+			target:    func(target *Target) { target.NodeID = 999 },
+			wantError: ErrTargetNodeNotFound.Error(),
+		},
+		{
+			name: "target node disabled",
+			target: func(target *Target) {
+				target.NodeID = 3
+				target.ServerIP = "10.0.0.3"
+			},
+			wantError: ErrTargetNodeDisabled.Error(),
+		},
+		{
+			name:      "ip is not assigned to target node",
+			target:    func(target *Target) { target.ServerIP = "10.0.0.1" },
+			wantError: ErrIPNotAssignedToNode.Error(),
+		},
+		{
+			name:      "dir is used on target node",
+			target:    func(target *Target) { target.Dir = "servers/other" },
+			wantError: ErrDirAlreadyUsed.Error(),
+		},
+		{
+			name:      "dir outside of work path",
+			target:    func(target *Target) { target.Dir = "servers/../../../root" },
+			wantError: ErrInvalidDir.Error(),
+		},
+		{
+			name:      "dir with shell metacharacters",
+			target:    func(target *Target) { target.Dir = "servers/cs`reboot`" },
+			wantError: ErrInvalidDir.Error(),
+		},
+		{
+			name:      "server port is busy",
+			target:    func(target *Target) { target.ServerPort = 27015 },
+			wantError: "port 27015 is already in use on 10.0.0.2",
+		},
+		{
+			name:      "query port is busy",
+			target:    func(target *Target) { target.QueryPort = lo.ToPtr(27020) },
+			wantError: "port 27020 is already in use on 10.0.0.2",
+		},
+		{
+			name: "unfinished tasks exist",
+			setup: func(t *testing.T, env *serviceEnv) {
+				t.Helper()
+
+				require.NoError(t, env.daemonTaskRepo.Save(context.Background(), &domain.DaemonTask{
+					DedicatedServerID: 1,
+					ServerID:          lo.ToPtr(env.server.ID),
+					Task:              domain.DaemonTaskTypeServerUpdate,
+					Status:            domain.DaemonTaskStatusWorking,
+				}))
+			},
+			wantError: ErrUnfinishedTasksExist.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newServiceEnv(t)
+
+			if tt.setup != nil {
+				tt.setup(t, env)
+			}
+
+			target := validTarget()
+			if tt.target != nil {
+				tt.target(&target)
+			}
+
+			_, err := env.service.Move(context.Background(), env.server, target)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantError)
+
+			tasks, err := env.daemonTaskRepo.Find(context.Background(), nil, nil, nil)
+			require.NoError(t, err)
+			assert.False(t, lo.ContainsBy(tasks, func(task domain.DaemonTask) bool {
+				return task.Task == domain.DaemonTaskTypeServerMove
+			}))
+		})
+	}
+}
+
+func TestParseMoveData(t *testing.T) {
+	data := moveData{SourceNodeID: 1, TargetNodeID: 2, ServerIP: "10.0.0.2", ServerPort: 27015, Dir: "servers/cs"}
+	b, err := json.Marshal(data)
+	require.NoError(t, err)
+
+	parsed, err := parseMoveData(&domain.DaemonTask{Data: lo.ToPtr(string(b))})
+	require.NoError(t, err)
+	assert.Equal(t, &data, parsed)
+
+	_, err = parseMoveData(&domain.DaemonTask{})
+	assert.Error(t, err)
+
+	_, err = parseMoveData(&domain.DaemonTask{Data: lo.ToPtr("invalid")})
+	assert.Error(t, err)
+}
@@ -0,0 +1,611 @@
+package servermove
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/daemon"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+const (
+	lockNamePrefix   = "server_move:"
+	archiveExtension = ".tar.gz"
+	archivePerms     = 0644
+)
+
+type daemonCommands interface {
+	ExecuteCommand(
+		ctx context.Context,
+		node *domain.Node,
+		command string,
+		opts ...daemon.CommandServiceOption,
+	) (*daemon.CommandResult, error)
+}
+
+type daemonFiles interface {
+	GetFileInfo(ctx context.Context, node *domain.Node, path string) (*daemon.FileDetails, error)
+	MkDir(ctx context.Context, node *domain.Node, directory string) error
+	DownloadStream(ctx context.Context, node *domain.Node, filePath string) (io.ReadCloser, error)
+	UploadStream(
+		ctx context.Context,
+		node *domain.Node,
+		filePath string,
+		r io.Reader,
+		size uint64,
+		perms os.FileMode,
+	) error
+	Remove(ctx context.Context, node *domain.Node, path string, recursive bool) error
+}
+
+// Worker executes move and copy tasks. The daemon doesn't know how to transfer servers between nodes,
+// so the panel archives the server directory on the source node, transfers the archive
+// to the target node and extracts it there. Within a single node the directory is copied with cp.
+//
+// A move task is executed once the stop task it runs after is finished successfully.
+// If the move fails, the files copied to the target node are removed, the start task on the target node
+// is canceled and the server is started on the source node again if it was online.
+//
+// Each task is guarded by its own lock in the cache, so several panel replicas may run the worker.
+type Worker struct {
+	daemonTaskRepo repositories.DaemonTaskRepository
+	serverRepo     repositories.ServerRepository
+	nodeRepo       repositories.NodeRepository
+	daemonCommands daemonCommands
+	daemonFiles    daemonFiles
+	cache          cache.Cache
+	interval       time.Duration
+	timeout        time.Duration
+}
+
+func NewWorker(
+	daemonTaskRepo repositories.DaemonTaskRepository,
+	serverRepo repositories.ServerRepository,
+	nodeRepo repositories.NodeRepository,
+	daemonCommands daemonCommands,
+	daemonFiles daemonFiles,
+	c cache.Cache,
+	interval time.Duration,
+	timeout time.Duration,
+) *Worker {
+	return &Worker{
+		daemonTaskRepo: daemonTaskRepo,
+		serverRepo:     serverRepo,
+		nodeRepo:       nodeRepo,
+		daemonCommands: daemonCommands,
+		daemonFiles:    daemonFiles,
+		cache:          c,
+		interval:       interval,
+		timeout:        timeout,
+	}
+}
+
+// Run executes move and copy tasks periodically until the context is canceled.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.Process(ctx); err != nil {
+			slog.ErrorContext(ctx, "Failed to process server move tasks", slog.String("error", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Process executes waiting move and copy tasks whose previous tasks are finished.
+func (w *Worker) Process(ctx context.Context) error {
+	tasks, err := w.daemonTaskRepo.Find(ctx, &filters.FindDaemonTask{
+		Tasks: []domain.DaemonTaskType{
+			domain.DaemonTaskTypeServerMove,
+			domain.DaemonTaskTypeServerCopy,
+		},
+		Statuses: []domain.DaemonTaskStatus{domain.DaemonTaskStatusWaiting},
+	}, nil, nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed to find move tasks")
+	}
+
+	for i := range tasks {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if err = w.processTask(ctx, &tasks[i]); err != nil {
+			slog.ErrorContext(
+				ctx,
+				"Failed to process server transfer task",
+				slog.Uint64("daemon_task_id", uint64(tasks[i].ID)),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	return nil
+}
+
+func (w *Worker) processTask(ctx context.Context, task *domain.DaemonTask) error {
+	prevStatus, err := w.previousTaskStatus(ctx, task)
+	if err != nil {
+		return err
+	}
+
+	switch prevStatus {
+	case domain.DaemonTaskStatusWaiting, domain.DaemonTaskStatusWorking:
+		return nil
+	case domain.DaemonTaskStatusError, domain.DaemonTaskStatusCanceled:
+		data, err := parseMoveData(task)
+		if err != nil {
+			return w.finish(ctx, task, domain.DaemonTaskStatusError, err.Error())
+		}
+
+		if task.Task == domain.DaemonTaskTypeServerCopy {
+			w.setInstalled(ctx, task, domain.ServerInstalledStatusNotInstalled)
+		} else {
+			w.cancelStartTask(ctx, data)
+		}
+
+		return w.finish(ctx, task, domain.DaemonTaskStatusError, "previous task is "+string(prevStatus))
+	}
+
+	lock := cache.NewLock(w.cache, lockNamePrefix+strconv.FormatUint(uint64(task.ID), 10), w.timeout)
+
+	acquired, err := lock.Acquire(ctx)
+	if err != nil {
+		return errors.WithMessage(err, "failed to acquire move task lock")
+	}
+
+	if !acquired {
+		return nil
+	}
+
+	defer func() {
+		if err := lock.Release(context.WithoutCancel(ctx)); err != nil {
+			slog.WarnContext(ctx, "Failed to release move task lock", slog.String("error", err.Error()))
+		}
+	}()
+
+	task.Status = domain.DaemonTaskStatusWorking
+	if err = w.daemonTaskRepo.Save(ctx, task); err != nil {
+		return errors.WithMessage(err, "failed to save move task")
+	}
+
+	if task.Task == domain.DaemonTaskTypeServerCopy {
+		return w.processCopy(ctx, task)
+	}
+
+	return w.processMove(ctx, task)
+}
+
+func (w *Worker) processMove(ctx context.Context, task *domain.DaemonTask) error {
+	moveCtx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+
+	data, server, err := w.move(moveCtx, task)
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"Failed to move server",
+			slog.Uint64("daemon_task_id", uint64(task.ID)),
+			slog.String("error", err.Error()),
+		)
+
+		if data != nil {
+			w.rollback(ctx, server, data)
+		}
+
+		// The move may be interrupted by the panel shutdown, the task result must be saved anyway
+		return w.finish(context.WithoutCancel(ctx), task, domain.DaemonTaskStatusError, err.Error())
+	}
+
+	slog.InfoContext(
+		ctx,
+		"Server has been moved",
+		slog.Uint64("daemon_task_id", uint64(task.ID)),
+		slog.Uint64("server_id", uint64(server.ID)),
+		slog.Uint64("source_node_id", uint64(data.SourceNodeID)),
+		slog.Uint64("target_node_id", uint64(data.TargetNodeID)),
+	)
+
+	return w.finish(ctx, task, domain.DaemonTaskStatusSuccess, "server has been moved")
+}
+
+func (w *Worker) processCopy(ctx context.Context, task *domain.DaemonTask) error {
+	copyCtx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+
+	if err := w.copy(copyCtx, task); err != nil {
+		slog.ErrorContext(
+			ctx,
+			"Failed to copy server files",
+			slog.Uint64("daemon_task_id", uint64(task.ID)),
+			slog.String("error", err.Error()),
+		)
+
+		ctx = context.WithoutCancel(ctx)
+		w.setInstalled(ctx, task, domain.ServerInstalledStatusNotInstalled)
+
+		return w.finish(ctx, task, domain.DaemonTaskStatusError, err.Error())
+	}
+
+	w.setInstalled(ctx, task, domain.ServerInstalledStatusInstalled)
+
+	return w.finish(ctx, task, domain.DaemonTaskStatusSuccess, "server files have been copied")
+}
+
+func (w *Worker) previousTaskStatus(ctx context.Context, task *domain.DaemonTask) (domain.DaemonTaskStatus, error) {
+	if task.RunAftID == nil || *task.RunAftID == 0 {
+		return domain.DaemonTaskStatusSuccess, nil
+	}
+
+	tasks, err := w.daemonTaskRepo.Find(ctx, &filters.FindDaemonTask{
+		IDs: []uint{*task.RunAftID},
+	}, nil, nil)
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to find previous task")
+	}
+
+	// The previous task was deleted, nothing to wait for
+	if len(tasks) == 0 {
+		return domain.DaemonTaskStatusSuccess, nil
+	}
+
+	return tasks[0].Status, nil
+}
+
+// move transfers the server files and switches the server to the target node.
+// The returned move data is nil if the move failed before any changes were made.
+func (w *Worker) move(ctx context.Context, task *domain.DaemonTask) (*moveData, *domain.Server, error) {
+	data, err := parseMoveData(task)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if task.ServerID == nil {
+		return data, nil, errors.New("move task has no server")
+	}
+
+	server, err := w.findServer(ctx, *task.ServerID)
+	if err != nil {
+		return data, nil, err
+	}
+
+	source, err := w.findNode(ctx, data.SourceNodeID)
+	if err != nil {
+		return data, server, errors.WithMessage(err, "failed to find source node")
+	}
+
+	target, err := w.findNode(ctx, data.TargetNodeID)
+	if err != nil {
+		return data, server, errors.WithMessage(err, "failed to find target node")
+	}
+
+	targetDir := filepath.Join(target.WorkPath, data.Dir)
+
+	// Never overwrite or remove files which don't belong to the move
+	if _, err = w.daemonFiles.GetFileInfo(ctx, target, targetDir); err == nil {
+		return data, server, errors.New("target directory already exists")
+	}
+
+	if err = w.transfer(ctx, task, source, target, filepath.Join(source.WorkPath, data.SourceDir), targetDir); err != nil {
+		w.removeDir(ctx, target, targetDir)
+
+		return data, server, err
+	}
+
+	server.DSID = data.TargetNodeID
+	server.ServerIP = data.ServerIP
+	server.ServerPort = data.ServerPort
+	server.QueryPort = data.QueryPort
+	server.RconPort = data.RconPort
+	server.Dir = data.Dir
+
+	if err = w.serverRepo.Save(ctx, server); err != nil {
+		w.removeDir(ctx, target, targetDir)
+
+		return data, server, errors.WithMessage(err, "failed to save server")
+	}
+
+	w.removeDir(ctx, source, filepath.Join(source.WorkPath, data.SourceDir))
+
+	return data, server, nil
+}
+
+// copy copies the files of the source server into the directory of the task server.
+func (w *Worker) copy(ctx context.Context, task *domain.DaemonTask) error {
+	data, err := parseMoveData(task)
+	if err != nil {
+		return err
+	}
+
+	source, err := w.findNode(ctx, data.SourceNodeID)
+	if err != nil {
+		return errors.WithMessage(err, "failed to find source node")
+	}
+
+	target, err := w.findNode(ctx, data.TargetNodeID)
+	if err != nil {
+		return errors.WithMessage(err, "failed to find target node")
+	}
+
+	sourceDir := filepath.Join(source.WorkPath, data.SourceDir)
+	targetDir := filepath.Join(target.WorkPath, data.Dir)
+
+	// Never overwrite or remove files which don't belong to the copy
+	if _, err = w.daemonFiles.GetFileInfo(ctx, target, targetDir); err == nil {
+		return errors.New("target directory already exists")
+	}
+
+	if source.ID == target.ID {
+		err = w.copyDir(ctx, source, sourceDir, targetDir)
+	} else {
+		err = w.transfer(ctx, task, source, target, sourceDir, targetDir)
+	}
+
+	if err != nil {
+		w.removeDir(ctx, target, targetDir)
+
+		return err
+	}
+
+	return nil
+}
+
+func (w *Worker) transfer(
+	ctx context.Context,
+	task *domain.DaemonTask,
+	source, target *domain.Node,
+	sourceDir, targetDir string,
+) error {
+	name := "." + string(task.Task) + "-" + strconv.FormatUint(uint64(task.ID), 10) + archiveExtension
+	sourceArchive := filepath.Join(source.WorkPath, name)
+	targetArchive := filepath.Join(target.WorkPath, name)
+
+	command, err := source.OS.TarCommand("-czf", sourceArchive, sourceDir, ".")
+	if err != nil {
+		return errors.WithMessage(err, "failed to build archive command")
+	}
+
+	if err = w.execute(ctx, source, command); err != nil {
+		return errors.WithMessage(err, "failed to archive server files on source node")
+	}
+
+	defer w.removeFile(ctx, source, sourceArchive)
+
+	info, err := w.daemonFiles.GetFileInfo(ctx, source, sourceArchive)
+	if err != nil {
+		return errors.WithMessage(err, "failed to get archive info")
+	}
+
+	stream, err := w.daemonFiles.DownloadStream(ctx, source, sourceArchive)
+	if err != nil {
+		return errors.WithMessage(err, "failed to download archive from source node")
+	}
+	defer func() {
+		if err := stream.Close(); err != nil {
+			slog.WarnContext(ctx, "Failed to close archive stream", slog.String("error", err.Error()))
+		}
+	}()
+
+	err = w.daemonFiles.UploadStream(ctx, target, targetArchive, stream, info.Size, archivePerms)
+	if err != nil {
+		return errors.WithMessage(err, "failed to upload archive to target node")
+	}
+
+	defer w.removeFile(ctx, target, targetArchive)
+
+	if err = w.daemonFiles.MkDir(ctx, target, targetDir); err != nil {
+		return errors.WithMessage(err, "failed to create server directory on target node")
+	}
+
+	command, err = target.OS.TarCommand("-xzf", targetArchive, targetDir)
+	if err != nil {
+		return errors.WithMessage(err, "failed to build extract command")
+	}
+
+	if err = w.execute(ctx, target, command); err != nil {
+		return errors.WithMessage(err, "failed to extract server files on target node")
+	}
+
+	return nil
+}
+
+// copyDir copies the directory on the node without transferring the files through the panel.
+func (w *Worker) copyDir(ctx context.Context, node *domain.Node, sourceDir, targetDir string) error {
+	command, err := node.OS.CopyDirCommand(sourceDir, targetDir)
+	if err != nil {
+		return errors.WithMessage(err, "failed to build copy command")
+	}
+
+	if err = w.execute(ctx, node, command); err != nil {
+		return errors.WithMessage(err, "failed to copy server files")
+	}
+
+	return nil
+}
+
+func (w *Worker) execute(ctx context.Context, node *domain.Node, command string) error {
+	result, err := w.daemonCommands.ExecuteCommand(ctx, node, command)
+	if err != nil {
+		return err
+	}
+
+	if result.ExitCode != 0 {
+		return errors.Errorf("command exited with code %d: %s", result.ExitCode, result.Output)
+	}
+
+	return nil
+}
+
+// rollback cancels the start task on the target node and starts the server on the source node
+// if it had been online before the move.
+func (w *Worker) rollback(ctx context.Context, server *domain.Server, data *moveData) {
+	ctx = context.WithoutCancel(ctx)
+
+	if !w.cancelStartTask(ctx, data) || server == nil {
+		return
+	}
+
+	startTask := newDaemonTask(server, data.SourceNodeID, domain.DaemonTaskTypeServerStart, nil)
+	if err := w.daemonTaskRepo.Save(ctx, startTask); err != nil {
+		slog.ErrorContext(
+			ctx,
+			"Failed to create start task on source node",
+			slog.Uint64("server_id", uint64(server.ID)),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// cancelStartTask cancels the waiting start task on the target node. It returns true if the task was canceled.
+func (w *Worker) cancelStartTask(ctx context.Context, data *moveData) bool {
+	if data.StartTaskID == 0 {
+		return false
+	}
+
+	tasks, err := w.daemonTaskRepo.Find(ctx, &filters.FindDaemonTask{
+		IDs:      []uint{data.StartTaskID},
+		Statuses: []domain.DaemonTaskStatus{domain.DaemonTaskStatusWaiting},
+	}, nil, nil)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to find start task", slog.String("error", err.Error()))
+
+		return false
+	}
+
+	if len(tasks) == 0 {
+		return false
+	}
+
+	tasks[0].Status = domain.DaemonTaskStatusCanceled
+	if err = w.daemonTaskRepo.Save(ctx, &tasks[0]); err != nil {
+		slog.ErrorContext(ctx, "Failed to cancel start task", slog.String("error", err.Error()))
+
+		return false
+	}
+
+	return true
+}
+
+// setInstalled updates the installation status of the server the copy task belongs to.
+func (w *Worker) setInstalled(ctx context.Context, task *domain.DaemonTask, status domain.ServerInstalledStatus) {
+	if task.ServerID == nil {
+		return
+	}
+
+	server, err := w.findServer(ctx, *task.ServerID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to find copied server", slog.String("error", err.Error()))
+
+		return
+	}
+
+	server.Installed = status
+	if err = w.serverRepo.Save(ctx, server); err != nil {
+		slog.ErrorContext(
+			ctx,
+			"Failed to update copied server installation status",
+			slog.Uint64("server_id", uint64(server.ID)),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+func (w *Worker) finish(
+	ctx context.Context,
+	task *domain.DaemonTask,
+	status domain.DaemonTaskStatus,
+	output string,
+) error {
+	task.Status = status
+	task.Output = lo.ToPtr(output)
+
+	if err := w.daemonTaskRepo.Save(ctx, task); err != nil {
+		return errors.WithMessage(err, "failed to save "+string(task.Task)+" task")
+	}
+
+	return nil
+}
+
+func (w *Worker) removeDir(ctx context.Context, node *domain.Node, dir string) {
+	if err := w.daemonFiles.Remove(context.WithoutCancel(ctx), node, dir, true); err != nil {
+		slog.WarnContext(
+			ctx,
+			"Failed to remove server directory",
+			slog.Uint64("node_id", uint64(node.ID)),
+			slog.String("path", dir),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+func (w *Worker) removeFile(ctx context.Context, node *domain.Node, path string) {
+	if err := w.daemonFiles.Remove(context.WithoutCancel(ctx), node, path, false); err != nil {
+		slog.WarnContext(
+			ctx,
+			"Failed to remove temporary transfer archive",
+			slog.Uint64("node_id", uint64(node.ID)),
+			slog.String("path", path),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+func (w *Worker) findServer(ctx context.Context, serverID uint) (*domain.Server, error) {
+	servers, err := w.serverRepo.Find(ctx, filters.FindServerByIDs(serverID), nil, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find server")
+	}
+
+	if len(servers) == 0 {
+		return nil, errors.New("server not found")
+	}
+
+	return &servers[0], nil
+}
+
+func (w *Worker) findNode(ctx context.Context, nodeID uint) (*domain.Node, error) {
+	nodes, err := w.nodeRepo.Find(ctx, &filters.FindNode{
+		IDs: []uint{nodeID},
+	}, nil, &filters.Pagination{
+		Limit: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(nodes) == 0 {
+		return nil, errors.New("node not found")
+	}
+
+	return &nodes[0], nil
+}
+
+func parseMoveData(task *domain.DaemonTask) (*moveData, error) {
+	if task.Data == nil {
+		return nil, errors.New("move task has no data")
+	}
+
+	data := &moveData{}
+	if err := json.Unmarshal([]byte(*task.Data), data); err != nil {
+		return nil, errors.WithMessage(err, "failed to parse move task data")
+	}
+
+	return data, nil
+}
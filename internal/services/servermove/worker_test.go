@@ -0,0 +1,454 @@
+package servermove
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/daemon"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNodes emulates tar, cp and the daemon file API of several nodes.
+// "tar -czf" stores an archive of the directory, "tar -xzf", "cp" and "xcopy" create the directory.
+type fakeNodes struct {
+	mu          sync.Mutex
+	files       map[uint]map[string][]byte
+	dirs        map[uint]map[string]struct{}
+	commands    []string
+	failExtract bool
+}
+
+func newFakeNodes() *fakeNodes {
+	return &fakeNodes{
+		files: map[uint]map[string][]byte{1: {}, 2: {}},
+		dirs:  map[uint]map[string]struct{}{1: {"/srv/gameap/servers/cs": {}}, 2: {}},
+	}
+}
+
+func (n *fakeNodes) ExecuteCommand(
+	_ context.Context,
+	node *domain.Node,
+	command string,
+	_ ...daemon.CommandServiceOption,
+) (*daemon.CommandResult, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.commands = append(n.commands, command)
+
+	args := strings.Fields(strings.NewReplacer(`"`, "", "'", "", `\`, "/").Replace(command))
+
+	if args[0] == "cp" || args[0] == "xcopy" {
+		source, target := args[len(args)-2], args[len(args)-1]
+		if args[0] == "xcopy" {
+			source, target = args[1], args[2]
+		}
+
+		if _, ok := n.dirs[node.ID][source]; !ok {
+			return &daemon.CommandResult{Output: "no such directory", ExitCode: 1}, nil
+		}
+
+		n.dirs[node.ID][target] = struct{}{}
+
+		return &daemon.CommandResult{}, nil
+	}
+
+	switch args[1] {
+	case "-czf":
+		if _, ok := n.dirs[node.ID][args[4]]; !ok {
+			return &daemon.CommandResult{Output: "no such directory", ExitCode: 2}, nil
+		}
+
+		n.files[node.ID][args[2]] = []byte("archive of " + args[4])
+	case "-xzf":
+		if n.failExtract {
+			return &daemon.CommandResult{Output: "no space left on device", ExitCode: 2}, nil
+		}
+
+		n.dirs[node.ID][args[4]] = struct{}{}
+	}
+
+	return &daemon.CommandResult{}, nil
+}
+
+func (n *fakeNodes) GetFileInfo(_ context.Context, node *domain.Node, path string) (*daemon.FileDetails, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if data, ok := n.files[node.ID][path]; ok {
+		return &daemon.FileDetails{Size: uint64(len(data)), Type: daemon.FileTypeFile}, nil
+	}
+
+	if _, ok := n.dirs[node.ID][path]; ok {
+		return &daemon.FileDetails{Type: daemon.FileTypeDir}, nil
+	}
+
+	return nil, errors.New("file not found")
+}
+
+func (n *fakeNodes) MkDir(_ context.Context, node *domain.Node, directory string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.dirs[node.ID][directory] = struct{}{}
+
+	return nil
+}
+
+func (n *fakeNodes) DownloadStream(_ context.Context, node *domain.Node, filePath string) (io.ReadCloser, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	data, ok := n.files[node.ID][filePath]
+	if !ok {
+		return nil, errors.New("file not found")
+	}
+
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (n *fakeNodes) UploadStream(
+	_ context.Context,
+	node *domain.Node,
+	filePath string,
+	r io.Reader,
+	size uint64,
+	_ os.FileMode,
+) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	if uint64(len(data)) != size {
+		return errors.New("size mismatch")
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.files[node.ID][filePath] = data
+
+	return nil
+}
+
+func (n *fakeNodes) Remove(_ context.Context, node *domain.Node, path string, _ bool) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	delete(n.files[node.ID], path)
+	delete(n.dirs[node.ID], path)
+
+	return nil
+}
+
+func (n *fakeNodes) paths(nodeID uint) []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return append(lo.Keys(n.files[nodeID]), lo.Keys(n.dirs[nodeID])...)
+}
+
+type workerEnv struct {
+	*serviceEnv
+
+	worker *Worker
+	nodes  *fakeNodes
+}
+
+func newWorkerEnv(t *testing.T) *workerEnv {
+	t.Helper()
+
+	env := &workerEnv{
+		serviceEnv: newServiceEnv(t),
+		nodes:      newFakeNodes(),
+	}
+
+	env.worker = NewWorker(
+		env.daemonTaskRepo,
+		env.serverRepo,
+		env.nodeRepo,
+		env.nodes,
+		env.nodes,
+		cache.NewInMemory(),
+		time.Minute,
+		time.Minute,
+	)
+
+	return env
+}
+
+// startMove creates the move task chain for the online server and returns the created tasks.
+func (env *workerEnv) startMove(t *testing.T) (stopTask, moveTask, startTask *domain.DaemonTask) {
+	t.Helper()
+
+	env.server.ProcessActive = true
+	env.server.LastProcessCheck = lo.ToPtr(time.Now())
+
+	moveTaskID, err := env.service.Move(context.Background(), env.server, validTarget())
+	require.NoError(t, err)
+
+	moveTask = env.task(t, moveTaskID)
+	stopTask = env.task(t, *moveTask.RunAftID)
+
+	data, err := parseMoveData(moveTask)
+	require.NoError(t, err)
+
+	startTask = env.task(t, data.StartTaskID)
+
+	return stopTask, moveTask, startTask
+}
+
+func (env *workerEnv) task(t *testing.T, id uint) *domain.DaemonTask {
+	t.Helper()
+
+	tasks, err := env.daemonTaskRepo.Find(context.Background(), &filters.FindDaemonTask{IDs: []uint{id}}, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+
+	return &tasks[0]
+}
+
+func (env *workerEnv) setStatus(t *testing.T, task *domain.DaemonTask, status domain.DaemonTaskStatus) {
+	t.Helper()
+
+	task.Status = status
+	require.NoError(t, env.daemonTaskRepo.Save(context.Background(), task))
+}
+
+func (env *workerEnv) reloadServer(t *testing.T) *domain.Server {
+	t.Helper()
+
+	servers, err := env.serverRepo.Find(context.Background(), filters.FindServerByIDs(env.server.ID), nil, nil)
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+
+	return &servers[0]
+}
+
+func TestWorker_Process_MovesServer(t *testing.T) {
+	env := newWorkerEnv(t)
+	stopTask, moveTask, startTask := env.startMove(t)
+	env.setStatus(t, stopTask, domain.DaemonTaskStatusSuccess)
+
+	require.NoError(t, env.worker.Process(context.Background()))
+
+	assert.Equal(t, domain.DaemonTaskStatusSuccess, env.task(t, moveTask.ID).Status)
+	assert.Equal(t, domain.DaemonTaskStatusWaiting, env.task(t, startTask.ID).Status)
+
+	server := env.reloadServer(t)
+	assert.Equal(t, uint(2), server.DSID)
+	assert.Equal(t, "10.0.0.2", server.ServerIP)
+	assert.Equal(t, 27025, server.ServerPort)
+	assert.Equal(t, lo.ToPtr(27026), server.QueryPort)
+	assert.Nil(t, server.RconPort)
+	assert.Equal(t, "servers/cs", server.Dir)
+
+	// Only the server directory is left on the target node, temporary archives are removed
+	assert.Empty(t, env.nodes.paths(1))
+	assert.Equal(t, []string{"/opt/gameap/servers/cs"}, env.nodes.paths(2))
+}
+
+func TestWorker_Process_WaitsForStopTask(t *testing.T) {
+	env := newWorkerEnv(t)
+	_, moveTask, _ := env.startMove(t)
+
+	require.NoError(t, env.worker.Process(context.Background()))
+
+	assert.Equal(t, domain.DaemonTaskStatusWaiting, env.task(t, moveTask.ID).Status)
+	assert.Equal(t, uint(1), env.reloadServer(t).DSID)
+}
+
+func TestWorker_Process_SkipsTaskLockedByAnotherReplica(t *testing.T) {
+	env := newWorkerEnv(t)
+	stopTask, moveTask, _ := env.startMove(t)
+	env.setStatus(t, stopTask, domain.DaemonTaskStatusSuccess)
+
+	lock := cache.NewLock(env.worker.cache, lockNamePrefix+strconv.FormatUint(uint64(moveTask.ID), 10), time.Minute)
+	acquired, err := lock.Acquire(context.Background())
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	require.NoError(t, env.worker.Process(context.Background()))
+
+	assert.Equal(t, domain.DaemonTaskStatusWaiting, env.task(t, moveTask.ID).Status)
+	assert.Equal(t, uint(1), env.reloadServer(t).DSID)
+	assert.Empty(t, env.nodes.paths(2))
+
+	require.NoError(t, lock.Release(context.Background()))
+	require.NoError(t, env.worker.Process(context.Background()))
+
+	assert.Equal(t, domain.DaemonTaskStatusSuccess, env.task(t, moveTask.ID).Status)
+	assert.Equal(t, uint(2), env.reloadServer(t).DSID)
+}
+
+func TestWorker_Process_StopTaskFailed(t *testing.T) {
+	env := newWorkerEnv(t)
+	stopTask, moveTask, startTask := env.startMove(t)
+	env.setStatus(t, stopTask, domain.DaemonTaskStatusError)
+
+	require.NoError(t, env.worker.Process(context.Background()))
+
+	assert.Equal(t, domain.DaemonTaskStatusError, env.task(t, moveTask.ID).Status)
+	assert.Equal(t, domain.DaemonTaskStatusCanceled, env.task(t, startTask.ID).Status)
+	assert.Equal(t, uint(1), env.reloadServer(t).DSID)
+	assert.Equal(t, []string{"/srv/gameap/servers/cs"}, env.nodes.paths(1))
+}
+
+func TestWorker_Process_RollsBackFailedMove(t *testing.T) {
+	env := newWorkerEnv(t)
+	env.nodes.failExtract = true
+	stopTask, moveTask, startTask := env.startMove(t)
+	env.setStatus(t, stopTask, domain.DaemonTaskStatusSuccess)
+
+	require.NoError(t, env.worker.Process(context.Background()))
+
+	failedTask := env.task(t, moveTask.ID)
+	assert.Equal(t, domain.DaemonTaskStatusError, failedTask.Status)
+	require.NotNil(t, failedTask.Output)
+	assert.Contains(t, *failedTask.Output, "no space left on device")
+	assert.Equal(t, domain.DaemonTaskStatusCanceled, env.task(t, startTask.ID).Status)
+
+	server := env.reloadServer(t)
+	assert.Equal(t, uint(1), server.DSID)
+	assert.Equal(t, "10.0.0.1", server.ServerIP)
+	assert.Equal(t, 27015, server.ServerPort)
+
+	// Source files are kept, files copied to the target node are removed
+	assert.Equal(t, []string{"/srv/gameap/servers/cs"}, env.nodes.paths(1))
+	assert.Empty(t, env.nodes.paths(2))
+
+	// The server is started on the source node again
+	tasks, err := env.daemonTaskRepo.Find(context.Background(), &filters.FindDaemonTask{
+		DedicatedServerIDs: []uint{1},
+		Tasks:              []domain.DaemonTaskType{domain.DaemonTaskTypeServerStart},
+		Statuses:           []domain.DaemonTaskStatus{domain.DaemonTaskStatusWaiting},
+	}, nil, nil)
+	require.NoError(t, err)
+	assert.Len(t, tasks, 1)
+}
+
+func TestWorker_Process_TargetDirectoryExists(t *testing.T) {
+	env := newWorkerEnv(t)
+	env.nodes.dirs[2]["/opt/gameap/servers/cs"] = struct{}{}
+	stopTask, moveTask, _ := env.startMove(t)
+	env.setStatus(t, stopTask, domain.DaemonTaskStatusSuccess)
+
+	require.NoError(t, env.worker.Process(context.Background()))
+
+	failedTask := env.task(t, moveTask.ID)
+	assert.Equal(t, domain.DaemonTaskStatusError, failedTask.Status)
+	require.NotNil(t, failedTask.Output)
+	assert.Equal(t, "target directory already exists", *failedTask.Output)
+
+	// Existing files on the target node are not touched
+	assert.Equal(t, []string{"/opt/gameap/servers/cs"}, env.nodes.paths(2))
+	assert.Equal(t, uint(1), env.reloadServer(t).DSID)
+}
+
+// startCopy creates a copy of the server on the given node and the task copying the server files.
+func (env *workerEnv) startCopy(t *testing.T, nodeID uint) (*domain.Server, *domain.DaemonTask) {
+	t.Helper()
+
+	clone := &domain.Server{
+		ID:         3,
+		DSID:       nodeID,
+		ServerIP:   "10.0.0.2",
+		ServerPort: 27035,
+		Dir:        "servers/cs-copy",
+		Installed:  domain.ServerInstalledStatusInstallationInProg,
+	}
+	require.NoError(t, env.serverRepo.Save(context.Background(), clone))
+
+	taskID, err := env.service.Copy(context.Background(), env.server, clone)
+	require.NoError(t, err)
+
+	return clone, env.task(t, taskID)
+}
+
+func (env *workerEnv) findServer(t *testing.T, id uint) *domain.Server {
+	t.Helper()
+
+	servers, err := env.serverRepo.Find(context.Background(), filters.FindServerByIDs(id), nil, nil)
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+
+	return &servers[0]
+}
+
+func TestWorker_Process_CopiesServerToAnotherNode(t *testing.T) {
+	env := newWorkerEnv(t)
+	clone, copyTask := env.startCopy(t, 2)
+
+	require.NoError(t, env.worker.Process(context.Background()))
+
+	assert.Equal(t, domain.DaemonTaskStatusSuccess, env.task(t, copyTask.ID).Status)
+	assert.Equal(t, domain.ServerInstalledStatusInstalled, env.findServer(t, clone.ID).Installed)
+
+	// The source server is not changed
+	assert.Equal(t, []string{"/srv/gameap/servers/cs"}, env.nodes.paths(1))
+	assert.Equal(t, uint(1), env.reloadServer(t).DSID)
+	assert.Equal(t, []string{"/opt/gameap/servers/cs-copy"}, env.nodes.paths(2))
+}
+
+func TestWorker_Process_CopiesServerWithinNode(t *testing.T) {
+	env := newWorkerEnv(t)
+	clone, copyTask := env.startCopy(t, 1)
+
+	require.NoError(t, env.worker.Process(context.Background()))
+
+	assert.Equal(t, domain.DaemonTaskStatusSuccess, env.task(t, copyTask.ID).Status)
+	assert.Equal(t, domain.ServerInstalledStatusInstalled, env.findServer(t, clone.ID).Installed)
+	assert.ElementsMatch(t, []string{"/srv/gameap/servers/cs", "/srv/gameap/servers/cs-copy"}, env.nodes.paths(1))
+	assert.Empty(t, env.nodes.paths(2))
+}
+
+func TestWorker_Process_CopiesServerWithinWindowsNode(t *testing.T) {
+	env := newWorkerEnv(t)
+
+	nodes, err := env.nodeRepo.Find(context.Background(), filters.FindNodeByIDs(1), nil, nil)
+	require.NoError(t, err)
+	require.Len(t, nodes, 1)
+	nodes[0].OS = domain.NodeOSWindows
+	require.NoError(t, env.nodeRepo.Save(context.Background(), &nodes[0]))
+
+	_, copyTask := env.startCopy(t, 1)
+
+	require.NoError(t, env.worker.Process(context.Background()))
+
+	assert.Equal(t, domain.DaemonTaskStatusSuccess, env.task(t, copyTask.ID).Status)
+	assert.Equal(t, []string{
+		`xcopy "\srv\gameap\servers\cs" "\srv\gameap\servers\cs-copy" /E /I /H /K /Y /Q`,
+	}, env.nodes.commands)
+	assert.ElementsMatch(t, []string{"/srv/gameap/servers/cs", "/srv/gameap/servers/cs-copy"}, env.nodes.paths(1))
+}
+
+func TestWorker_Process_FailedCopy(t *testing.T) {
+	env := newWorkerEnv(t)
+	env.nodes.failExtract = true
+	clone, copyTask := env.startCopy(t, 2)
+
+	require.NoError(t, env.worker.Process(context.Background()))
+
+	failedTask := env.task(t, copyTask.ID)
+	assert.Equal(t, domain.DaemonTaskStatusError, failedTask.Status)
+	require.NotNil(t, failedTask.Output)
+	assert.Contains(t, *failedTask.Output, "no space left on device")
+	assert.Equal(t, domain.ServerInstalledStatusNotInstalled, env.findServer(t, clone.ID).Installed)
+
+	assert.Equal(t, []string{"/srv/gameap/servers/cs"}, env.nodes.paths(1))
+	assert.Empty(t, env.nodes.paths(2))
+}
@@ -0,0 +1,177 @@
+package serverports
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/repositories/base"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+const (
+	lockNamePrefix     = "server_ports:node:"
+	lockTTL            = time.Minute
+	lockRetryInterval  = 50 * time.Millisecond
+	defaultLockTimeout = 10 * time.Second
+)
+
+var (
+	ErrNoFreePorts = api.NewValidationError("no free ports on the server IP")
+	ErrNodeLocked  = api.NewError(
+		http.StatusConflict,
+		"another server is being created on the node, please try again",
+	)
+)
+
+// Service allocates ports of new servers.
+//
+// Servers are created on a node one at a time: creations are serialized by an in-process
+// semaphore and by a lock in the cache shared by panel replicas. The lock is held
+// until the transaction saving the server is committed, so ports allocated for one server
+// can't be allocated for another one.
+type Service struct {
+	serverRepo   repositories.ServerRepository
+	cache        cache.Cache
+	tm           base.TransactionManager
+	defaultRange domain.PortRange
+	lockTimeout  time.Duration
+
+	mu    sync.Mutex
+	nodes map[uint]chan struct{}
+}
+
+func NewService(
+	serverRepo repositories.ServerRepository,
+	c cache.Cache,
+	tm base.TransactionManager,
+	defaultRange domain.PortRange,
+	lockTimeout time.Duration,
+) *Service {
+	if lockTimeout <= 0 {
+		lockTimeout = defaultLockTimeout
+	}
+
+	return &Service{
+		serverRepo:   serverRepo,
+		cache:        c,
+		tm:           tm,
+		defaultRange: defaultRange,
+		lockTimeout:  lockTimeout,
+		nodes:        make(map[uint]chan struct{}),
+	}
+}
+
+// Do runs fn in a transaction while no other server is being created on the node.
+func (s *Service) Do(ctx context.Context, nodeID uint, fn func(ctx context.Context) error) error {
+	unlock, err := s.lock(ctx, nodeID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return s.tm.Do(ctx, fn)
+}
+
+// Allocate resolves automatic ports of a new server on the node IP.
+// Ports are taken from the range of the node and placed by the game layout.
+// It must be called by fn passed to Do.
+func (s *Service) Allocate(
+	ctx context.Context,
+	node *domain.Node,
+	serverIP string,
+	layout domain.PortLayout,
+	request domain.ServerPortsRequest,
+) (domain.ServerPorts, error) {
+	busy, err := s.BusyPorts(ctx, node.ID, serverIP)
+	if err != nil {
+		return domain.ServerPorts{}, err
+	}
+
+	ports, ok := domain.AllocateServerPorts(request, layout, node.PortRange(s.defaultRange), busy)
+	if !ok {
+		return domain.ServerPorts{}, ErrNoFreePorts
+	}
+
+	return ports, nil
+}
+
+// BusyPorts returns the ports used by the servers of the node on the IP.
+func (s *Service) BusyPorts(ctx context.Context, nodeID uint, serverIP string) ([]int, error) {
+	servers, err := s.serverRepo.Find(ctx, &filters.FindServer{
+		DSIDs: []uint{nodeID},
+	}, nil, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find node servers")
+	}
+
+	return domain.ServersBusyPorts(servers)[serverIP], nil
+}
+
+func (s *Service) lock(ctx context.Context, nodeID uint) (func(), error) {
+	ctx, cancel := context.WithTimeout(ctx, s.lockTimeout)
+	defer cancel()
+
+	semaphore := s.semaphore(nodeID)
+
+	select {
+	case semaphore <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ErrNodeLocked
+	}
+
+	lock := cache.NewLock(s.cache, lockNamePrefix+strconv.FormatUint(uint64(nodeID), 10), lockTTL)
+
+	ticker := time.NewTicker(lockRetryInterval)
+	defer ticker.Stop()
+
+	for {
+		acquired, err := lock.Acquire(ctx)
+		if err != nil {
+			<-semaphore
+
+			return nil, errors.WithMessage(err, "failed to acquire node ports lock")
+		}
+
+		if acquired {
+			break
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			<-semaphore
+
+			return nil, ErrNodeLocked
+		}
+	}
+
+	return func() {
+		if err := lock.Release(context.WithoutCancel(ctx)); err != nil {
+			slog.Warn("Failed to release node ports lock", slog.String("error", err.Error()))
+		}
+
+		<-semaphore
+	}, nil
+}
+
+func (s *Service) semaphore(nodeID uint) chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	semaphore, ok := s.nodes[nodeID]
+	if !ok {
+		semaphore = make(chan struct{}, 1)
+		s.nodes[nodeID] = semaphore
+	}
+
+	return semaphore
+}
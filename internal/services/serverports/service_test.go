@@ -0,0 +1,152 @@
+package serverports
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Allocate_ConcurrentCreationsDoNotCollide(t *testing.T) {
+	ctx := context.Background()
+	serverRepo := inmemory.NewServerRepository()
+	service := NewService(
+		serverRepo,
+		cache.NewInMemory(),
+		services.NewNilTransactionManager(),
+		domain.PortRange{Start: 27015, End: 27999},
+		5*time.Second,
+	)
+
+	node := &domain.Node{ID: 1, PortRangeStart: lo.ToPtr(30000), PortRangeEnd: lo.ToPtr(30100)}
+	auto := lo.ToPtr(domain.AutoPort())
+	request := domain.ServerPortsRequest{ServerPort: domain.AutoPort(), QueryPort: auto}
+
+	const count = 10
+
+	var wg sync.WaitGroup
+	errs := make(chan error, count)
+
+	for range count {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			errs <- service.Do(ctx, node.ID, func(ctx context.Context) error {
+				ports, err := service.Allocate(ctx, node, "10.0.0.1", domain.PortLayout{QueryOffset: 1}, request)
+				if err != nil {
+					return err
+				}
+
+				server := &domain.Server{
+					UUID:     uuid.New(),
+					DSID:     node.ID,
+					ServerIP: "10.0.0.1",
+				}
+				ports.ApplyTo(server)
+
+				// Give other goroutines a chance to interleave
+				time.Sleep(time.Millisecond)
+
+				return serverRepo.Save(ctx, server)
+			})
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	servers, err := serverRepo.FindAll(ctx, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, servers, count)
+
+	used := make([]int, 0, count*2)
+	for _, server := range servers {
+		assert.GreaterOrEqual(t, server.ServerPort, 30000)
+		require.NotNil(t, server.QueryPort)
+		assert.Equal(t, server.ServerPort+1, *server.QueryPort)
+
+		used = append(used, server.Ports()...)
+	}
+
+	assert.Len(t, lo.Uniq(used), count*2)
+}
+
+func TestService_Allocate_NoFreePorts(t *testing.T) {
+	ctx := context.Background()
+	serverRepo := inmemory.NewServerRepository()
+	require.NoError(t, serverRepo.Save(ctx, &domain.Server{
+		UUID:       uuid.New(),
+		DSID:       1,
+		ServerIP:   "10.0.0.1",
+		ServerPort: 27015,
+	}))
+
+	service := NewService(
+		serverRepo,
+		cache.NewInMemory(),
+		services.NewNilTransactionManager(),
+		domain.PortRange{Start: 27015, End: 27015},
+		time.Second,
+	)
+
+	_, err := service.Allocate(
+		ctx,
+		&domain.Node{ID: 1},
+		"10.0.0.1",
+		domain.PortLayout{},
+		domain.ServerPortsRequest{ServerPort: domain.AutoPort()},
+	)
+	require.ErrorIs(t, err, ErrNoFreePorts)
+}
+
+func TestService_Do_NodeLocked(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewInMemory()
+
+	// Another panel replica holds the lock of the node
+	lock := cache.NewLock(c, lockNamePrefix+"1", time.Minute)
+	acquired, err := lock.Acquire(ctx)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	service := NewService(
+		inmemory.NewServerRepository(),
+		c,
+		services.NewNilTransactionManager(),
+		domain.PortRange{Start: 27015, End: 27999},
+		100*time.Millisecond,
+	)
+
+	called := false
+	err = service.Do(ctx, 1, func(_ context.Context) error {
+		called = true
+
+		return nil
+	})
+	require.ErrorIs(t, err, ErrNodeLocked)
+	assert.False(t, called)
+
+	// Other nodes are not affected
+	err = service.Do(ctx, 2, func(_ context.Context) error {
+		called = true
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.True(t, called)
+}
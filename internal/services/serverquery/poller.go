@@ -0,0 +1,327 @@
+package serverquery
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/quercon/query"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+const (
+	lockName     = "server_query_poller"
+	maxMapLength = 128
+)
+
+type querier interface {
+	Query(ctx context.Context, host string, port int, protocol query.Protocol) (*query.Result, error)
+}
+
+// QuerierFunc adapts a query function, such as query.Query, to the querier of the poller.
+type QuerierFunc func(ctx context.Context, host string, port int, protocol query.Protocol) (*query.Result, error)
+
+func (f QuerierFunc) Query(
+	ctx context.Context,
+	host string,
+	port int,
+	protocol query.Protocol,
+) (*query.Result, error) {
+	return f(ctx, host, port, protocol)
+}
+
+type metricsRecorder interface {
+	Record(ctx context.Context, samples []*domain.Metric) error
+}
+
+type target struct {
+	server   *domain.Server
+	protocol query.Protocol
+}
+
+// Poller queries enabled online servers periodically and stores the number of players and the map.
+//
+// At most concurrency servers are queried at the same time, and queries to the servers
+// of one node are sent no faster than nodeRate per second. The number of players is also
+// recorded to the usage metrics of the servers and their nodes.
+//
+// Only one panel replica runs the poller at a time, it is guarded by a leader lock in the cache.
+type Poller struct {
+	serverRepo      repositories.ServerRepository
+	gameRepo        repositories.GameRepository
+	recordRepo      repositories.ServerQueryRecordRepository
+	querier         querier
+	metricsRecorder metricsRecorder
+	lock            *cache.Lock
+	interval        time.Duration
+	concurrency     int
+	nodeInterval    time.Duration
+	retention       time.Duration
+}
+
+func NewPoller(
+	serverRepo repositories.ServerRepository,
+	gameRepo repositories.GameRepository,
+	recordRepo repositories.ServerQueryRecordRepository,
+	querier querier,
+	metricsRecorder metricsRecorder,
+	c cache.Cache,
+	lockTTL time.Duration,
+	interval time.Duration,
+	concurrency int,
+	nodeRate int,
+	retention time.Duration,
+) *Poller {
+	var nodeInterval time.Duration
+	if nodeRate > 0 {
+		nodeInterval = time.Second / time.Duration(nodeRate)
+	}
+
+	return &Poller{
+		serverRepo:      serverRepo,
+		gameRepo:        gameRepo,
+		recordRepo:      recordRepo,
+		querier:         querier,
+		metricsRecorder: metricsRecorder,
+		lock:            cache.NewLock(c, lockName, lockTTL),
+		interval:        interval,
+		concurrency:     max(concurrency, 1),
+		nodeInterval:    nodeInterval,
+		retention:       retention,
+	}
+}
+
+// Run queries the servers periodically until the context is canceled.
+func (p *Poller) Run(ctx context.Context) {
+	cache.RunAsLeader(ctx, p.lock, p.interval, func(ctx context.Context) error {
+		return p.Process(ctx, time.Now())
+	})
+}
+
+// Process queries the servers and stores the results as queried at the given time.
+// Records older than the retention are deleted.
+func (p *Poller) Process(ctx context.Context, now time.Time) error {
+	queriedAt := now.UTC().Truncate(time.Second)
+
+	targets, err := p.findTargets(ctx)
+	if err != nil {
+		return err
+	}
+
+	records := p.queryAll(ctx, targets, queriedAt)
+
+	if err = p.recordRepo.SaveBulk(ctx, records); err != nil {
+		return errors.WithMessage(err, "failed to save server query records")
+	}
+
+	if err = p.metricsRecorder.Record(ctx, newMetrics(targets, records, queriedAt)); err != nil {
+		return errors.WithMessage(err, "failed to record players metrics")
+	}
+
+	if p.retention > 0 {
+		if err = p.recordRepo.DeleteBefore(ctx, now.Add(-p.retention)); err != nil {
+			return errors.WithMessage(err, "failed to delete expired server query records")
+		}
+	}
+
+	return nil
+}
+
+// findTargets returns the enabled online servers of games with a supported query protocol.
+func (p *Poller) findTargets(ctx context.Context) ([]target, error) {
+	servers, err := p.serverRepo.Find(ctx, &filters.FindServer{
+		Enabled: lo.ToPtr(true),
+		Blocked: lo.ToPtr(false),
+	}, nil, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find servers")
+	}
+
+	servers = lo.Filter(servers, func(server domain.Server, _ int) bool {
+		return server.IsOnline()
+	})
+
+	if len(servers) == 0 {
+		return nil, nil
+	}
+
+	games, err := p.gameRepo.Find(ctx, filters.FindGameByCodes(lo.Uniq(lo.Map(
+		servers,
+		func(server domain.Server, _ int) string {
+			return server.GameID
+		},
+	))...), nil, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find games")
+	}
+
+	protocols := make(map[string]query.Protocol, len(games))
+	for _, game := range games {
+		if protocol, ok := query.ProtocolByEngine(game.Engine); ok {
+			protocols[game.Code] = protocol
+		}
+	}
+
+	targets := make([]target, 0, len(servers))
+	for i := range servers {
+		protocol, ok := protocols[servers[i].GameID]
+		if !ok {
+			continue
+		}
+
+		targets = append(targets, target{server: &servers[i], protocol: protocol})
+	}
+
+	return targets, nil
+}
+
+// queryAll queries the targets, the servers of each node are queried by a separate goroutine.
+// Servers which are not queried because the context is canceled have no records.
+func (p *Poller) queryAll(ctx context.Context, targets []target, queriedAt time.Time) []*domain.ServerQueryRecord {
+	records := make([]*domain.ServerQueryRecord, len(targets))
+
+	nodes := lo.GroupBy(lo.Range(len(targets)), func(i int) uint {
+		return targets[i].server.DSID
+	})
+
+	semaphore := make(chan struct{}, p.concurrency)
+
+	var wg sync.WaitGroup
+
+	for _, indexes := range nodes {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			p.queryNode(ctx, targets, indexes, records, semaphore, queriedAt)
+		}()
+	}
+
+	wg.Wait()
+
+	return lo.Compact(records)
+}
+
+func (p *Poller) queryNode(
+	ctx context.Context,
+	targets []target,
+	indexes []int,
+	records []*domain.ServerQueryRecord,
+	semaphore chan struct{},
+	queriedAt time.Time,
+) {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	var throttle <-chan time.Time
+	if p.nodeInterval > 0 {
+		ticker := time.NewTicker(p.nodeInterval)
+		defer ticker.Stop()
+
+		throttle = ticker.C
+	}
+
+	for n, i := range indexes {
+		if n > 0 && throttle != nil {
+			select {
+			case <-throttle:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			records[i] = p.query(ctx, targets[i], queriedAt)
+		}()
+	}
+}
+
+func (p *Poller) query(ctx context.Context, t target, queriedAt time.Time) *domain.ServerQueryRecord {
+	record := &domain.ServerQueryRecord{
+		ServerID:  t.server.ID,
+		QueriedAt: queriedAt,
+	}
+
+	port := t.server.ServerPort
+	if t.server.QueryPort != nil {
+		port = *t.server.QueryPort
+	}
+
+	result, err := p.querier.Query(ctx, t.server.ServerIP, port, t.protocol)
+	if err != nil || result == nil || !result.Online {
+		if err != nil {
+			slog.DebugContext(
+				ctx,
+				"Server query failed",
+				slog.Uint64("server_id", uint64(t.server.ID)),
+				slog.String("error", err.Error()),
+			)
+		}
+
+		return record
+	}
+
+	record.Online = true
+	record.PlayersNum = result.PlayersNum
+	record.MaxPlayersNum = result.MaxPlayersNum
+	record.Map = lo.Substring(result.Map, 0, maxMapLength)
+
+	return record
+}
+
+// newMetrics creates the players samples of the online servers and the sums of the players of their nodes.
+func newMetrics(targets []target, records []*domain.ServerQueryRecord, queriedAt time.Time) []*domain.Metric {
+	nodeIDs := make(map[uint]uint, len(targets))
+	for _, t := range targets {
+		nodeIDs[t.server.ID] = t.server.DSID
+	}
+
+	samples := make([]*domain.Metric, 0, len(records))
+	nodePlayers := make(map[uint]float64)
+
+	for _, record := range records {
+		if !record.Online {
+			continue
+		}
+
+		samples = append(samples, &domain.Metric{
+			SubjectType: domain.MetricSubjectServer,
+			SubjectID:   record.ServerID,
+			RecordedAt:  queriedAt,
+			Players:     lo.ToPtr(float64(record.PlayersNum)),
+		})
+
+		nodePlayers[nodeIDs[record.ServerID]] += float64(record.PlayersNum)
+	}
+
+	for _, nodeID := range lo.Keys(nodePlayers) {
+		samples = append(samples, &domain.Metric{
+			SubjectType: domain.MetricSubjectNode,
+			SubjectID:   nodeID,
+			RecordedAt:  queriedAt,
+			Players:     lo.ToPtr(nodePlayers[nodeID]),
+		})
+	}
+
+	return samples
+}
@@ -0,0 +1,248 @@
+package serverquery
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services/metrics"
+	"github.com/gameap/gameap/pkg/quercon/query"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeQuerier struct {
+	mu      sync.Mutex
+	results map[int]*query.Result
+	delay   time.Duration
+	queried []int
+	times   map[string][]time.Time
+
+	running    atomic.Int32
+	maxRunning atomic.Int32
+}
+
+func (f *fakeQuerier) Query(_ context.Context, host string, port int, _ query.Protocol) (*query.Result, error) {
+	running := f.running.Add(1)
+	defer f.running.Add(-1)
+
+	for {
+		maxRunning := f.maxRunning.Load()
+		if running <= maxRunning || f.maxRunning.CompareAndSwap(maxRunning, running) {
+			break
+		}
+	}
+
+	f.mu.Lock()
+	f.queried = append(f.queried, port)
+	if f.times != nil {
+		f.times[host] = append(f.times[host], time.Now())
+	}
+	result, ok := f.results[port]
+	f.mu.Unlock()
+
+	time.Sleep(f.delay)
+
+	if !ok {
+		return nil, errors.New("timeout")
+	}
+
+	return result, nil
+}
+
+type testEnv struct {
+	poller     *Poller
+	serverRepo *inmemory.ServerRepository
+	recordRepo *inmemory.ServerQueryRecordRepository
+	metricRepo *inmemory.MetricRepository
+	querier    *fakeQuerier
+}
+
+func setup(t *testing.T, concurrency, nodeRate int) *testEnv {
+	t.Helper()
+
+	ctx := context.Background()
+
+	env := &testEnv{
+		serverRepo: inmemory.NewServerRepository(),
+		recordRepo: inmemory.NewServerQueryRecordRepository(),
+		metricRepo: inmemory.NewMetricRepository(),
+		querier:    &fakeQuerier{results: map[int]*query.Result{}},
+	}
+
+	gameRepo := inmemory.NewGameRepository()
+	require.NoError(t, gameRepo.Save(ctx, &domain.Game{Code: "cstrike", Name: "Counter-Strike", Engine: "GoldSource"}))
+	require.NoError(t, gameRepo.Save(ctx, &domain.Game{Code: "minecraft", Name: "Minecraft", Engine: "Minecraft"}))
+	require.NoError(t, gameRepo.Save(ctx, &domain.Game{Code: "rust", Name: "Rust", Engine: "Unity"}))
+
+	env.poller = NewPoller(
+		env.serverRepo,
+		gameRepo,
+		env.recordRepo,
+		env.querier,
+		metrics.NewService(env.metricRepo, metrics.Retention{}),
+		cache.NewInMemory(),
+		time.Minute,
+		time.Minute,
+		concurrency,
+		nodeRate,
+		24*time.Hour,
+	)
+
+	return env
+}
+
+func (env *testEnv) addServer(t *testing.T, server *domain.Server) {
+	t.Helper()
+
+	server.UUID = uuid.New()
+	if server.GameID == "" {
+		server.GameID = "cstrike"
+	}
+	if server.ServerIP == "" {
+		server.ServerIP = "10.0.0.1"
+	}
+
+	require.NoError(t, env.serverRepo.Save(context.Background(), server))
+}
+
+func online(server *domain.Server) *domain.Server {
+	server.Enabled = true
+	server.ProcessActive = true
+	server.LastProcessCheck = lo.ToPtr(time.Now())
+
+	return server
+}
+
+func TestPoller_Process_StoresRecordsAndPlayersMetrics(t *testing.T) {
+	env := setup(t, 4, 0)
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 10, 0, 0, 500, time.UTC)
+	queriedAt := now.Truncate(time.Second)
+
+	env.addServer(t, online(&domain.Server{ID: 1, DSID: 1, ServerPort: 27015, QueryPort: lo.ToPtr(27016)}))
+	env.addServer(t, online(&domain.Server{ID: 2, DSID: 1, ServerPort: 27017}))
+	env.addServer(t, online(&domain.Server{ID: 3, DSID: 2, ServerPort: 25565, GameID: "minecraft"}))
+	env.addServer(t, online(&domain.Server{ID: 4, DSID: 2, ServerPort: 27018}))
+	// Not queried: unsupported engine, offline, disabled and blocked servers
+	env.addServer(t, online(&domain.Server{ID: 5, DSID: 2, ServerPort: 28015, GameID: "rust"}))
+	env.addServer(t, &domain.Server{ID: 6, DSID: 1, Enabled: true, ServerPort: 27019})
+	env.addServer(t, &domain.Server{ID: 7, DSID: 1, ServerPort: 27020})
+	blocked := online(&domain.Server{ID: 8, DSID: 1, ServerPort: 27021})
+	blocked.Blocked = true
+	env.addServer(t, blocked)
+
+	env.querier.results[27016] = &query.Result{Online: true, PlayersNum: 10, MaxPlayersNum: 32, Map: "de_dust2"}
+	env.querier.results[27017] = &query.Result{Online: true, PlayersNum: 2, MaxPlayersNum: 16, Map: "cs_office"}
+	env.querier.results[25565] = &query.Result{Online: true, PlayersNum: 0, MaxPlayersNum: 20}
+
+	require.NoError(t, env.poller.Process(ctx, now))
+
+	assert.ElementsMatch(t, []int{27016, 27017, 25565, 27018}, env.querier.queried)
+
+	records, err := env.recordRepo.Find(ctx, nil, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ServerQueryRecord{
+		{ServerID: 1, QueriedAt: queriedAt, Online: true, PlayersNum: 10, MaxPlayersNum: 32, Map: "de_dust2"},
+		{ServerID: 2, QueriedAt: queriedAt, Online: true, PlayersNum: 2, MaxPlayersNum: 16, Map: "cs_office"},
+		{ServerID: 3, QueriedAt: queriedAt, Online: true, PlayersNum: 0, MaxPlayersNum: 20},
+		{ServerID: 4, QueriedAt: queriedAt},
+	}, records)
+
+	points, err := env.metricRepo.Find(ctx, nil, nil, nil)
+	require.NoError(t, err)
+
+	players := make(map[string]float64, len(points))
+	for _, point := range points {
+		assert.Equal(t, domain.MetricResolutionRaw, point.Resolution)
+		assert.Equal(t, queriedAt, point.RecordedAt)
+		assert.Nil(t, point.CPU)
+		require.NotNil(t, point.Players)
+
+		players[fmt.Sprintf("%s:%d", point.SubjectType, point.SubjectID)] = *point.Players
+	}
+
+	assert.Equal(t, map[string]float64{
+		"server:1": 10,
+		"server:2": 2,
+		"server:3": 0,
+		"node:1":   12,
+		"node:2":   0,
+	}, players)
+}
+
+func TestPoller_Process_DeletesExpiredRecords(t *testing.T) {
+	env := setup(t, 1, 0)
+	ctx := context.Background()
+	now := time.Date(2025, 3, 2, 10, 0, 0, 0, time.UTC)
+
+	require.NoError(t, env.recordRepo.SaveBulk(ctx, []*domain.ServerQueryRecord{
+		{ServerID: 1, QueriedAt: now.Add(-25 * time.Hour), Online: true, PlayersNum: 1},
+		{ServerID: 1, QueriedAt: now.Add(-23 * time.Hour), Online: true, PlayersNum: 2},
+	}))
+
+	require.NoError(t, env.poller.Process(ctx, now))
+
+	records, err := env.recordRepo.Find(ctx, &filters.FindServerQueryRecord{ServerIDs: []uint{1}}, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, 2, records[0].PlayersNum)
+}
+
+func TestPoller_Process_BoundsConcurrency(t *testing.T) {
+	env := setup(t, 3, 0)
+	env.querier.delay = 20 * time.Millisecond
+
+	for i := range 12 {
+		env.addServer(t, online(&domain.Server{
+			ID:         uint(i + 1),
+			DSID:       uint(i%4 + 1),
+			ServerPort: 27015 + i,
+		}))
+	}
+
+	require.NoError(t, env.poller.Process(context.Background(), time.Now()))
+
+	assert.Len(t, env.querier.queried, 12)
+	assert.LessOrEqual(t, env.querier.maxRunning.Load(), int32(3))
+	assert.Greater(t, env.querier.maxRunning.Load(), int32(1))
+}
+
+func TestPoller_Process_RespectsNodeRate(t *testing.T) {
+	const nodeRate = 20
+
+	env := setup(t, 10, nodeRate)
+	env.querier.times = make(map[string][]time.Time)
+
+	for i := range 4 {
+		env.addServer(t, online(&domain.Server{ID: uint(i + 1), DSID: 1, ServerIP: "10.0.0.1", ServerPort: 27015 + i}))
+		env.addServer(t, online(&domain.Server{ID: uint(i + 11), DSID: 2, ServerIP: "10.0.0.2", ServerPort: 27015 + i}))
+	}
+
+	start := time.Now()
+	require.NoError(t, env.poller.Process(context.Background(), start))
+
+	minInterval := time.Second / nodeRate
+
+	for host, times := range env.querier.times {
+		require.Len(t, times, 4, host)
+
+		for i := 1; i < len(times); i++ {
+			// Allow a small timer skew
+			assert.GreaterOrEqual(t, times[i].Sub(times[i-1]), minInterval-5*time.Millisecond, host)
+		}
+	}
+
+	// Nodes are queried in parallel, so the whole round takes about as long as the slowest node
+	assert.Less(t, time.Since(start), 2*3*minInterval)
+}
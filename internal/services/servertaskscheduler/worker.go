@@ -0,0 +1,246 @@
+package servertaskscheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+const lockName = "server_task_scheduler"
+
+type serverControl interface {
+	Start(ctx context.Context, server *domain.Server) (uint, error)
+	Stop(ctx context.Context, server *domain.Server) (uint, error)
+	Restart(ctx context.Context, server *domain.Server) (uint, error)
+	Update(ctx context.Context, server *domain.Server) (uint, error)
+	Reinstall(ctx context.Context, server *domain.Server) (uint, error)
+}
+
+type backupCreator interface {
+	Start(ctx context.Context, server *domain.Server) (*domain.Backup, error)
+}
+
+// Worker executes server tasks on the panel side.
+// Normally server tasks are executed by the daemon. When the daemon is offline,
+// tasks stay overdue. Once a task is overdue longer than the takeover delay,
+// the worker turns it into a daemon task and advances its schedule.
+// Commands the daemon doesn't know about, such as backups, are executed as soon as they are due.
+//
+// Only one panel replica runs the worker at a time, it is guarded by a leader lock in the cache.
+type Worker struct {
+	serverTaskRepo     repositories.ServerTaskRepository
+	serverTaskFailRepo repositories.ServerTaskFailRepository
+	serverRepo         repositories.ServerRepository
+	serverControl      serverControl
+	backupCreator      backupCreator
+	lock               *cache.Lock
+	interval           time.Duration
+	takeoverDelay      time.Duration
+}
+
+func NewWorker(
+	serverTaskRepo repositories.ServerTaskRepository,
+	serverTaskFailRepo repositories.ServerTaskFailRepository,
+	serverRepo repositories.ServerRepository,
+	serverControl serverControl,
+	backupCreator backupCreator,
+	c cache.Cache,
+	lockTTL time.Duration,
+	interval time.Duration,
+	takeoverDelay time.Duration,
+) *Worker {
+	return &Worker{
+		serverTaskRepo:     serverTaskRepo,
+		serverTaskFailRepo: serverTaskFailRepo,
+		serverRepo:         serverRepo,
+		serverControl:      serverControl,
+		backupCreator:      backupCreator,
+		lock:               cache.NewLock(c, lockName, lockTTL),
+		interval:           interval,
+		takeoverDelay:      takeoverDelay,
+	}
+}
+
+// Run executes overdue server tasks periodically until the context is canceled.
+func (w *Worker) Run(ctx context.Context) {
+	cache.RunAsLeader(ctx, w.lock, w.interval, func(ctx context.Context) error {
+		return w.Process(ctx, time.Now())
+	})
+}
+
+// Process executes server tasks which are overdue longer than the takeover delay at the given time.
+func (w *Worker) Process(ctx context.Context, now time.Time) error {
+	tasks, err := w.findOverdueTasks(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	servers, err := w.findServers(ctx, tasks)
+	if err != nil {
+		return err
+	}
+
+	for i := range tasks {
+		task := &tasks[i]
+
+		if err = w.execute(ctx, task, servers[task.ServerID], now); err != nil {
+			slog.ErrorContext(
+				ctx,
+				"Failed to execute server task",
+				slog.Uint64("server_task_id", uint64(task.ID)),
+				slog.Uint64("server_id", uint64(task.ServerID)),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	return nil
+}
+
+func (w *Worker) findOverdueTasks(ctx context.Context, now time.Time) ([]domain.ServerTask, error) {
+	tasks, err := w.serverTaskRepo.FindAll(ctx, nil, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find server tasks")
+	}
+
+	deadline := now.Add(-w.takeoverDelay)
+
+	return lo.Filter(tasks, func(task domain.ServerTask, _ int) bool {
+		if task.IsFinished() {
+			return false
+		}
+
+		if task.Command.ExecutedByPanel() {
+			return !task.ExecuteDate.After(now)
+		}
+
+		return !task.ExecuteDate.After(deadline)
+	}), nil
+}
+
+func (w *Worker) findServers(ctx context.Context, tasks []domain.ServerTask) (map[uint]*domain.Server, error) {
+	serverIDs := lo.Uniq(lo.Map(tasks, func(task domain.ServerTask, _ int) uint {
+		return task.ServerID
+	}))
+
+	servers, err := w.serverRepo.Find(ctx, filters.FindServerByIDs(serverIDs...), nil, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find servers")
+	}
+
+	result := make(map[uint]*domain.Server, len(servers))
+	for i := range servers {
+		result[servers[i].ID] = &servers[i]
+	}
+
+	return result, nil
+}
+
+func (w *Worker) execute(ctx context.Context, task *domain.ServerTask, server *domain.Server, now time.Time) error {
+	if missed := task.MissedRuns(now); missed > 0 {
+		w.recordFail(ctx, task, fmt.Sprintf(
+			"%d scheduled run(s) were missed since %s",
+			missed,
+			task.ExecuteDate.Format(time.RFC3339),
+		))
+	}
+
+	result, err := w.runCommand(ctx, task, server)
+	if err != nil {
+		w.recordFail(ctx, task, err.Error())
+	} else {
+		slog.InfoContext(
+			ctx,
+			"Server task has been executed by the panel",
+			slog.Uint64("server_task_id", uint64(task.ID)),
+			slog.Uint64("server_id", uint64(task.ServerID)),
+			result,
+			slog.String("command", string(task.Command)),
+		)
+	}
+
+	advanceErr := task.Advance(now)
+	if advanceErr != nil {
+		w.recordFail(ctx, task, advanceErr.Error())
+	}
+
+	task.UpdatedAt = &now
+
+	if err = w.serverTaskRepo.Save(ctx, task); err != nil {
+		return errors.WithMessage(err, "failed to save server task")
+	}
+
+	return errors.WithMessage(advanceErr, "failed to schedule next run")
+}
+
+// runCommand executes the task command and returns the log attribute identifying its result.
+func (w *Worker) runCommand(ctx context.Context, task *domain.ServerTask, server *domain.Server) (slog.Attr, error) {
+	if server == nil {
+		return slog.Attr{}, errors.New("server not found")
+	}
+
+	if server.Blocked && task.Command != domain.ServerTaskCommandStop {
+		return slog.Attr{}, errors.New("server is blocked")
+	}
+
+	switch task.Command {
+	case domain.ServerTaskCommandStart:
+		return daemonTaskResult(w.serverControl.Start(ctx, server))
+	case domain.ServerTaskCommandStop:
+		return daemonTaskResult(w.serverControl.Stop(ctx, server))
+	case domain.ServerTaskCommandRestart:
+		return daemonTaskResult(w.serverControl.Restart(ctx, server))
+	case domain.ServerTaskCommandUpdate:
+		return daemonTaskResult(w.serverControl.Update(ctx, server))
+	case domain.ServerTaskCommandReinstall:
+		return daemonTaskResult(w.serverControl.Reinstall(ctx, server))
+	case domain.ServerTaskCommandBackup:
+		backup, err := w.backupCreator.Start(ctx, server)
+		if err != nil {
+			return slog.Attr{}, errors.WithMessage(err, "failed to start backup")
+		}
+
+		return slog.Uint64("backup_id", uint64(backup.ID)), nil
+	default:
+		return slog.Attr{}, errors.Errorf("unknown server task command %q", task.Command)
+	}
+}
+
+func daemonTaskResult(daemonTaskID uint, err error) (slog.Attr, error) {
+	if err != nil {
+		return slog.Attr{}, err
+	}
+
+	return slog.Uint64("daemon_task_id", uint64(daemonTaskID)), nil
+}
+
+func (w *Worker) recordFail(ctx context.Context, task *domain.ServerTask, output string) {
+	now := time.Now()
+
+	err := w.serverTaskFailRepo.Save(ctx, &domain.ServerTaskFail{
+		ServerTaskID: task.ID,
+		Output:       output,
+		CreatedAt:    &now,
+		UpdatedAt:    &now,
+	})
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"Failed to save server task fail",
+			slog.Uint64("server_task_id", uint64(task.ID)),
+			slog.String("error", err.Error()),
+		)
+	}
+}
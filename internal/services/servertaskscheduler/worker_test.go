@@ -0,0 +1,361 @@
+package servertaskscheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/servercontrol"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const takeoverDelay = 2 * time.Minute
+
+type fakeBackupCreator struct {
+	servers []uint
+	err     error
+}
+
+func (f *fakeBackupCreator) Start(_ context.Context, server *domain.Server) (*domain.Backup, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	f.servers = append(f.servers, server.ID)
+
+	return &domain.Backup{ID: uint(len(f.servers)), ServerID: server.ID}, nil
+}
+
+type testEnv struct {
+	worker             *Worker
+	cache              cache.Cache
+	serverRepo         *inmemory.ServerRepository
+	serverTaskRepo     *inmemory.ServerTaskRepository
+	serverTaskFailRepo *inmemory.ServerTaskFailRepository
+	daemonTaskRepo     *inmemory.DaemonTaskRepository
+	backups            *fakeBackupCreator
+}
+
+func setup(t *testing.T) *testEnv {
+	t.Helper()
+
+	env := &testEnv{
+		cache:              cache.NewInMemory(),
+		serverRepo:         inmemory.NewServerRepository(),
+		serverTaskFailRepo: inmemory.NewServerTaskFailRepository(),
+		daemonTaskRepo:     inmemory.NewDaemonTaskRepository(),
+		backups:            &fakeBackupCreator{},
+	}
+	env.serverTaskRepo = inmemory.NewServerTaskRepository(env.serverRepo)
+
+	serverControl := servercontrol.NewService(
+		env.daemonTaskRepo,
+		inmemory.NewServerSettingRepository(),
+		inmemory.NewNodeRepository(),
+		services.NewNilTransactionManager(),
+	)
+
+	env.worker = NewWorker(
+		env.serverTaskRepo,
+		env.serverTaskFailRepo,
+		env.serverRepo,
+		serverControl,
+		env.backups,
+		env.cache,
+		time.Minute,
+		time.Minute,
+		takeoverDelay,
+	)
+
+	return env
+}
+
+func (env *testEnv) createServer(t *testing.T, blocked bool) *domain.Server {
+	t.Helper()
+
+	server := &domain.Server{
+		UUID:         uuid.New(),
+		Enabled:      true,
+		Installed:    domain.ServerInstalledStatusInstalled,
+		Blocked:      blocked,
+		Name:         "Test Server",
+		GameID:       "cstrike",
+		DSID:         1,
+		ServerIP:     "127.0.0.1",
+		ServerPort:   27015,
+		StartCommand: lo.ToPtr("./start.sh"),
+	}
+
+	require.NoError(t, env.serverRepo.Save(context.Background(), server))
+
+	return server
+}
+
+func (env *testEnv) createTask(t *testing.T, task *domain.ServerTask) *domain.ServerTask {
+	t.Helper()
+
+	require.NoError(t, env.serverTaskRepo.Save(context.Background(), task))
+
+	return task
+}
+
+func (env *testEnv) findTask(t *testing.T, id uint) domain.ServerTask {
+	t.Helper()
+
+	tasks, err := env.serverTaskRepo.Find(context.Background(), &filters.FindServerTask{IDs: []uint{id}}, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+
+	return tasks[0]
+}
+
+func (env *testEnv) daemonTasks(t *testing.T, serverID uint) []domain.DaemonTask {
+	t.Helper()
+
+	tasks, err := env.daemonTaskRepo.Find(context.Background(), &filters.FindDaemonTask{
+		ServerIDs: []*uint{&serverID},
+	}, nil, nil)
+	require.NoError(t, err)
+
+	return tasks
+}
+
+func (env *testEnv) fails(t *testing.T, taskID uint) []domain.ServerTaskFail {
+	t.Helper()
+
+	fails, err := env.serverTaskFailRepo.Find(
+		context.Background(),
+		filters.FindServerTaskFailByServerTaskIDs(taskID),
+		nil,
+		nil,
+	)
+	require.NoError(t, err)
+
+	return fails
+}
+
+func TestWorker_Process_ExecutesOverdueTask(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	env := setup(t)
+	server := env.createServer(t, false)
+
+	task := env.createTask(t, &domain.ServerTask{
+		Command:      domain.ServerTaskCommandRestart,
+		ServerID:     server.ID,
+		RepeatPeriod: time.Hour,
+		ExecuteDate:  now.Add(-5 * time.Minute),
+	})
+
+	require.NoError(t, env.worker.Process(context.Background(), now))
+
+	daemonTasks := env.daemonTasks(t, server.ID)
+	require.Len(t, daemonTasks, 1)
+	assert.Equal(t, domain.DaemonTaskTypeServerRestart, daemonTasks[0].Task)
+
+	result := env.findTask(t, task.ID)
+	assert.Equal(t, uint(1), result.Counter)
+	assert.Equal(t, now.Add(55*time.Minute), result.ExecuteDate)
+	assert.Empty(t, env.fails(t, task.ID))
+}
+
+func TestWorker_Process_SkipsTaskWithinTakeoverDelay(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	env := setup(t)
+	server := env.createServer(t, false)
+
+	task := env.createTask(t, &domain.ServerTask{
+		Command:     domain.ServerTaskCommandStart,
+		ServerID:    server.ID,
+		ExecuteDate: now.Add(-time.Minute),
+	})
+
+	require.NoError(t, env.worker.Process(context.Background(), now))
+
+	assert.Empty(t, env.daemonTasks(t, server.ID))
+	assert.Equal(t, uint(0), env.findTask(t, task.ID).Counter)
+}
+
+func TestWorker_Process_ExecutesDueBackupTask(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	env := setup(t)
+	server := env.createServer(t, false)
+
+	// Backups are executed by the panel only, the takeover delay is not applied
+	task := env.createTask(t, &domain.ServerTask{
+		Command:      domain.ServerTaskCommandBackup,
+		ServerID:     server.ID,
+		RepeatPeriod: 24 * time.Hour,
+		ExecuteDate:  now,
+	})
+	notDue := env.createTask(t, &domain.ServerTask{
+		Command:     domain.ServerTaskCommandBackup,
+		ServerID:    server.ID,
+		ExecuteDate: now.Add(time.Minute),
+	})
+
+	require.NoError(t, env.worker.Process(context.Background(), now))
+
+	assert.Equal(t, []uint{server.ID}, env.backups.servers)
+	assert.Empty(t, env.daemonTasks(t, server.ID))
+
+	result := env.findTask(t, task.ID)
+	assert.Equal(t, uint(1), result.Counter)
+	assert.Equal(t, now.Add(24*time.Hour), result.ExecuteDate)
+	assert.Empty(t, env.fails(t, task.ID))
+
+	assert.Equal(t, uint(0), env.findTask(t, notDue.ID).Counter)
+}
+
+func TestWorker_Process_RecordsFailWhenBackupFails(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	env := setup(t)
+	env.backups.err = errors.New("another backup operation is in progress for this server")
+	server := env.createServer(t, false)
+
+	task := env.createTask(t, &domain.ServerTask{
+		Command:      domain.ServerTaskCommandBackup,
+		ServerID:     server.ID,
+		RepeatPeriod: time.Hour,
+		ExecuteDate:  now,
+	})
+
+	require.NoError(t, env.worker.Process(context.Background(), now))
+
+	fails := env.fails(t, task.ID)
+	require.Len(t, fails, 1)
+	assert.Contains(t, fails[0].Output, "failed to start backup")
+	assert.Equal(t, now.Add(time.Hour), env.findTask(t, task.ID).ExecuteDate)
+}
+
+func TestWorker_Process_SkipsFinishedTask(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	env := setup(t)
+	server := env.createServer(t, false)
+
+	env.createTask(t, &domain.ServerTask{
+		Command:      domain.ServerTaskCommandStart,
+		ServerID:     server.ID,
+		Repeat:       2,
+		RepeatPeriod: time.Hour,
+		Counter:      2,
+		ExecuteDate:  now.Add(-time.Hour),
+	})
+
+	require.NoError(t, env.worker.Process(context.Background(), now))
+
+	assert.Empty(t, env.daemonTasks(t, server.ID))
+}
+
+func TestWorker_Process_RecordsMissedRuns(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	env := setup(t)
+	server := env.createServer(t, false)
+
+	task := env.createTask(t, &domain.ServerTask{
+		Command:      domain.ServerTaskCommandStart,
+		ServerID:     server.ID,
+		RepeatPeriod: time.Hour,
+		ExecuteDate:  now.Add(-150 * time.Minute),
+	})
+
+	require.NoError(t, env.worker.Process(context.Background(), now))
+
+	assert.Len(t, env.daemonTasks(t, server.ID), 1)
+
+	fails := env.fails(t, task.ID)
+	require.Len(t, fails, 1)
+	assert.Contains(t, fails[0].Output, "2 scheduled run(s) were missed")
+
+	result := env.findTask(t, task.ID)
+	assert.Equal(t, uint(1), result.Counter)
+	assert.Equal(t, now.Add(30*time.Minute), result.ExecuteDate)
+}
+
+func TestWorker_Process_RecordsFailForBlockedServer(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	env := setup(t)
+	server := env.createServer(t, true)
+
+	task := env.createTask(t, &domain.ServerTask{
+		Command:     domain.ServerTaskCommandStart,
+		ServerID:    server.ID,
+		ExecuteDate: now.Add(-time.Hour),
+	})
+
+	require.NoError(t, env.worker.Process(context.Background(), now))
+
+	assert.Empty(t, env.daemonTasks(t, server.ID))
+
+	fails := env.fails(t, task.ID)
+	require.Len(t, fails, 1)
+	assert.Equal(t, "server is blocked", fails[0].Output)
+	assert.Equal(t, uint(1), env.findTask(t, task.ID).Counter)
+}
+
+func TestWorker_Process_RecordsFailWhenDaemonTaskExists(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	env := setup(t)
+	server := env.createServer(t, false)
+
+	require.NoError(t, env.daemonTaskRepo.Save(context.Background(), &domain.DaemonTask{
+		DedicatedServerID: server.DSID,
+		ServerID:          &server.ID,
+		Task:              domain.DaemonTaskTypeServerStop,
+		Status:            domain.DaemonTaskStatusWaiting,
+	}))
+
+	task := env.createTask(t, &domain.ServerTask{
+		Command:     domain.ServerTaskCommandStop,
+		ServerID:    server.ID,
+		ExecuteDate: now.Add(-time.Hour),
+	})
+
+	require.NoError(t, env.worker.Process(context.Background(), now))
+
+	assert.Len(t, env.daemonTasks(t, server.ID), 1)
+	assert.Len(t, env.fails(t, task.ID), 1)
+}
+
+func TestWorker_ProcessRequiresLeaderLock(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	env := setup(t)
+	server := env.createServer(t, false)
+
+	task := env.createTask(t, &domain.ServerTask{
+		Command:     domain.ServerTaskCommandStart,
+		ServerID:    server.ID,
+		ExecuteDate: now.Add(-time.Hour),
+	})
+
+	// The same as a run of the worker
+	tick := func() error {
+		return env.worker.lock.Do(context.Background(), func(ctx context.Context) error {
+			return env.worker.Process(ctx, time.Now())
+		})
+	}
+
+	otherReplica := cache.NewLock(env.cache, lockName, time.Minute)
+	acquired, err := otherReplica.Acquire(context.Background())
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	require.NoError(t, tick())
+
+	assert.Empty(t, env.daemonTasks(t, server.ID))
+	assert.Equal(t, uint(0), env.findTask(t, task.ID).Counter)
+
+	require.NoError(t, otherReplica.Release(context.Background()))
+	require.NoError(t, tick())
+
+	assert.Len(t, env.daemonTasks(t, server.ID), 1)
+	assert.Equal(t, uint(1), env.findTask(t, task.ID).Counter)
+}
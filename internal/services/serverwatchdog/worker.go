@@ -0,0 +1,355 @@
+package serverwatchdog
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/events"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/servercontrol"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+const (
+	lockName = "server_watchdog"
+
+	autostartCurrentSettingKey = "autostart_current"
+)
+
+type serverControl interface {
+	Start(ctx context.Context, server *domain.Server) (uint, error)
+}
+
+// Notifier is notified when a server crashes and when it enters a crash loop.
+type Notifier interface {
+	NotifyCrashed(ctx context.Context, server *domain.Server)
+	NotifyCrashLoop(ctx context.Context, server *domain.Server, restart *domain.ServerAutoRestart)
+}
+
+// LogNotifier reports crashes and crash loops to the log.
+type LogNotifier struct{}
+
+func (LogNotifier) NotifyCrashed(ctx context.Context, server *domain.Server) {
+	slog.InfoContext(
+		ctx,
+		"Server has crashed",
+		slog.Uint64("server_id", uint64(server.ID)),
+		slog.String("server_name", server.Name),
+	)
+}
+
+func (LogNotifier) NotifyCrashLoop(ctx context.Context, server *domain.Server, restart *domain.ServerAutoRestart) {
+	slog.WarnContext(
+		ctx,
+		"Server is in a crash loop, automatic restarts are suspended",
+		slog.Uint64("server_id", uint64(server.ID)),
+		slog.String("server_name", server.Name),
+		slog.Int("attempts", restart.Attempts),
+		slog.Int("crash_loops", restart.CrashLoops),
+	)
+}
+
+type eventPublisher interface {
+	Publish(ctx context.Context, event domain.Event)
+}
+
+// EventNotifier reports crashes and crash loops to the log and publishes them as server events.
+type EventNotifier struct {
+	publisher eventPublisher
+}
+
+func NewEventNotifier(publisher eventPublisher) *EventNotifier {
+	return &EventNotifier{publisher: publisher}
+}
+
+func (n *EventNotifier) NotifyCrashed(ctx context.Context, server *domain.Server) {
+	LogNotifier{}.NotifyCrashed(ctx, server)
+
+	n.publisher.Publish(ctx, events.ServerCrashed(server))
+}
+
+func (n *EventNotifier) NotifyCrashLoop(
+	ctx context.Context,
+	server *domain.Server,
+	restart *domain.ServerAutoRestart,
+) {
+	LogNotifier{}.NotifyCrashLoop(ctx, server, restart)
+
+	n.publisher.Publish(ctx, events.ServerCrashLoop(server, restart))
+}
+
+// Worker restarts servers which should be running but went offline.
+//
+// A server should be running when its autostart_current setting is enabled and it isn't paused.
+// Once such server is offline longer than the policy threshold, the worker creates a start task.
+// Next restarts are delayed with an exponential backoff. When the server is still offline
+// after the maximum number of restarts, it is marked as being in a crash loop and isn't
+// restarted anymore until it runs stable again, for example after it's started manually.
+//
+// Only one panel replica runs the worker at a time, it is guarded by a leader lock in the cache.
+type Worker struct {
+	serverRepo        repositories.ServerRepository
+	serverSettingRepo repositories.ServerSettingRepository
+	daemonTaskRepo    repositories.DaemonTaskRepository
+	restartRepo       repositories.ServerAutoRestartRepository
+	serverControl     serverControl
+	notifier          Notifier
+	lock              *cache.Lock
+	interval          time.Duration
+	policy            domain.AutoRestartPolicy
+}
+
+func NewWorker(
+	serverRepo repositories.ServerRepository,
+	serverSettingRepo repositories.ServerSettingRepository,
+	daemonTaskRepo repositories.DaemonTaskRepository,
+	restartRepo repositories.ServerAutoRestartRepository,
+	serverControl serverControl,
+	notifier Notifier,
+	c cache.Cache,
+	lockTTL time.Duration,
+	interval time.Duration,
+	policy domain.AutoRestartPolicy,
+) *Worker {
+	return &Worker{
+		serverRepo:        serverRepo,
+		serverSettingRepo: serverSettingRepo,
+		daemonTaskRepo:    daemonTaskRepo,
+		restartRepo:       restartRepo,
+		serverControl:     serverControl,
+		notifier:          notifier,
+		lock:              cache.NewLock(c, lockName, lockTTL),
+		interval:          interval,
+		policy:            policy,
+	}
+}
+
+// Run checks the servers periodically until the context is canceled.
+func (w *Worker) Run(ctx context.Context) {
+	cache.RunAsLeader(ctx, w.lock, w.interval, func(ctx context.Context) error {
+		return w.Process(ctx, time.Now())
+	})
+}
+
+// Process checks the servers and restarts the crashed ones at the given time.
+func (w *Worker) Process(ctx context.Context, now time.Time) error {
+	servers, err := w.findWatchedServers(ctx)
+	if err != nil {
+		return err
+	}
+
+	restarts, err := w.findRestarts(ctx)
+	if err != nil {
+		return err
+	}
+
+	busy, err := w.findBusyServers(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := range servers {
+		server := &servers[i]
+
+		restart, ok := restarts[server.ID]
+		if !ok {
+			restart = &domain.ServerAutoRestart{ServerID: server.ID}
+		}
+
+		if err = w.check(ctx, server, restart, busy[server.ID], now); err != nil {
+			slog.ErrorContext(
+				ctx,
+				"Failed to check server",
+				slog.Uint64("server_id", uint64(server.ID)),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	return nil
+}
+
+// findWatchedServers returns the installed, enabled and not blocked servers which should be running.
+func (w *Worker) findWatchedServers(ctx context.Context) ([]domain.Server, error) {
+	servers, err := w.serverRepo.Find(ctx, &filters.FindServer{
+		Enabled: lo.ToPtr(true),
+		Blocked: lo.ToPtr(false),
+	}, nil, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find servers")
+	}
+
+	servers = lo.Filter(servers, func(server domain.Server, _ int) bool {
+		return server.Installed == domain.ServerInstalledStatusInstalled
+	})
+
+	if len(servers) == 0 {
+		return nil, nil
+	}
+
+	settings, err := w.serverSettingRepo.Find(ctx, &filters.FindServerSetting{
+		ServerIDs: lo.Map(servers, func(server domain.Server, _ int) uint {
+			return server.ID
+		}),
+		Names: []string{autostartCurrentSettingKey, servercontrol.PausedSettingKey},
+	}, nil, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find server settings")
+	}
+
+	autostart := make(map[uint]bool, len(settings))
+	paused := make(map[uint]bool, len(settings))
+
+	for _, setting := range settings {
+		switch setting.Name {
+		case autostartCurrentSettingKey:
+			autostart[setting.ServerID], _ = setting.Value.Bool()
+		case servercontrol.PausedSettingKey:
+			paused[setting.ServerID] = servercontrol.IsPaused(setting)
+		}
+	}
+
+	return lo.Filter(servers, func(server domain.Server, _ int) bool {
+		return autostart[server.ID] && !paused[server.ID]
+	}), nil
+}
+
+func (w *Worker) findRestarts(ctx context.Context) (map[uint]*domain.ServerAutoRestart, error) {
+	restarts, err := w.restartRepo.Find(ctx, nil, nil, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find server auto restarts")
+	}
+
+	result := make(map[uint]*domain.ServerAutoRestart, len(restarts))
+	for i := range restarts {
+		result[restarts[i].ServerID] = &restarts[i]
+	}
+
+	return result, nil
+}
+
+// findBusyServers returns the servers with waiting or working daemon tasks.
+// The state of such servers is going to change, so they aren't restarted.
+func (w *Worker) findBusyServers(ctx context.Context) (map[uint]bool, error) {
+	tasks, err := w.daemonTaskRepo.Find(ctx, &filters.FindDaemonTask{
+		Statuses: []domain.DaemonTaskStatus{
+			domain.DaemonTaskStatusWaiting,
+			domain.DaemonTaskStatusWorking,
+		},
+	}, nil, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find daemon tasks")
+	}
+
+	busy := make(map[uint]bool, len(tasks))
+	for _, task := range tasks {
+		if task.ServerID != nil {
+			busy[*task.ServerID] = true
+		}
+	}
+
+	return busy, nil
+}
+
+func (w *Worker) check(
+	ctx context.Context,
+	server *domain.Server,
+	restart *domain.ServerAutoRestart,
+	busy bool,
+	now time.Time,
+) error {
+	if server.IsOnline() {
+		return w.checkOnline(ctx, restart, now)
+	}
+
+	if busy || restart.CrashLoop {
+		return nil
+	}
+
+	if restart.CrashedAt == nil {
+		restart.CrashedAt = &now
+
+		if err := w.save(ctx, restart, now); err != nil {
+			return err
+		}
+
+		w.notifier.NotifyCrashed(ctx, server)
+
+		return nil
+	}
+
+	if now.Before(restart.NextAttemptAt(w.policy)) {
+		return nil
+	}
+
+	if restart.Attempts >= w.policy.MaxAttempts {
+		restart.CrashLoop = true
+		restart.CrashLoopAt = &now
+		restart.CrashLoops++
+
+		if err := w.save(ctx, restart, now); err != nil {
+			return err
+		}
+
+		w.notifier.NotifyCrashLoop(ctx, server, restart)
+
+		return nil
+	}
+
+	taskID, err := w.serverControl.Start(ctx, server)
+	if err != nil {
+		return errors.WithMessage(err, "failed to start server")
+	}
+
+	restart.Attempts++
+	restart.LastAttemptAt = &now
+	restart.LastTaskID = &taskID
+
+	slog.InfoContext(
+		ctx,
+		"Crashed server is being restarted",
+		slog.Uint64("server_id", uint64(server.ID)),
+		slog.Uint64("daemon_task_id", uint64(taskID)),
+		slog.Int("attempt", restart.Attempts),
+	)
+
+	return w.save(ctx, restart, now)
+}
+
+// checkOnline clears the crash of the running server,
+// the attempts are reset once the server is running stable.
+func (w *Worker) checkOnline(ctx context.Context, restart *domain.ServerAutoRestart, now time.Time) error {
+	changed := false
+
+	if restart.CrashedAt != nil {
+		restart.CrashedAt = nil
+		changed = true
+	}
+
+	if (restart.Attempts > 0 || restart.CrashLoop) && restart.IsStable(w.policy, now) {
+		restart.Attempts = 0
+		restart.CrashLoop = false
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	return w.save(ctx, restart, now)
+}
+
+func (w *Worker) save(ctx context.Context, restart *domain.ServerAutoRestart, now time.Time) error {
+	restart.UpdatedAt = now
+
+	if err := w.restartRepo.Save(ctx, restart); err != nil {
+		return errors.WithMessage(err, "failed to save server auto restart")
+	}
+
+	return nil
+}
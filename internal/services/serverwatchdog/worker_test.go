@@ -0,0 +1,311 @@
+package serverwatchdog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/events"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/servercontrol"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPolicy = domain.AutoRestartPolicy{
+	Threshold:    time.Minute,
+	BaseDelay:    time.Minute,
+	MaxDelay:     10 * time.Minute,
+	MaxAttempts:  2,
+	StablePeriod: 10 * time.Minute,
+}
+
+type fakeNotifier struct {
+	crashed []uint
+	servers []uint
+}
+
+func (f *fakeNotifier) NotifyCrashed(_ context.Context, server *domain.Server) {
+	f.crashed = append(f.crashed, server.ID)
+}
+
+func (f *fakeNotifier) NotifyCrashLoop(_ context.Context, server *domain.Server, _ *domain.ServerAutoRestart) {
+	f.servers = append(f.servers, server.ID)
+}
+
+type testEnv struct {
+	worker            *Worker
+	serverRepo        *inmemory.ServerRepository
+	serverSettingRepo *inmemory.ServerSettingRepository
+	daemonTaskRepo    *inmemory.DaemonTaskRepository
+	restartRepo       *inmemory.ServerAutoRestartRepository
+	notifier          *fakeNotifier
+}
+
+func setup(t *testing.T) *testEnv {
+	t.Helper()
+
+	env := &testEnv{
+		serverRepo:        inmemory.NewServerRepository(),
+		serverSettingRepo: inmemory.NewServerSettingRepository(),
+		daemonTaskRepo:    inmemory.NewDaemonTaskRepository(),
+		restartRepo:       inmemory.NewServerAutoRestartRepository(),
+		notifier:          &fakeNotifier{},
+	}
+
+	serverControl := servercontrol.NewService(
+		env.daemonTaskRepo,
+		env.serverSettingRepo,
+		inmemory.NewNodeRepository(),
+		services.NewNilTransactionManager(),
+	)
+
+	env.worker = NewWorker(
+		env.serverRepo,
+		env.serverSettingRepo,
+		env.daemonTaskRepo,
+		env.restartRepo,
+		serverControl,
+		env.notifier,
+		cache.NewInMemory(),
+		time.Minute,
+		time.Minute,
+		testPolicy,
+	)
+
+	return env
+}
+
+func (env *testEnv) addServer(t *testing.T, server *domain.Server, settings map[string]bool) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	server.UUID = uuid.New()
+	server.Enabled = true
+	server.Installed = domain.ServerInstalledStatusInstalled
+	server.StartCommand = lo.ToPtr("./run.sh")
+
+	require.NoError(t, env.serverRepo.Save(ctx, server))
+
+	for name, value := range settings {
+		require.NoError(t, env.serverSettingRepo.Save(ctx, &domain.ServerSetting{
+			Name:     name,
+			ServerID: server.ID,
+			Value:    domain.NewServerSettingValue(value),
+		}))
+	}
+}
+
+func (env *testEnv) restart(t *testing.T, serverID uint) *domain.ServerAutoRestart {
+	t.Helper()
+
+	restarts, err := env.restartRepo.Find(
+		context.Background(),
+		&filters.FindServerAutoRestart{ServerIDs: []uint{serverID}},
+		nil,
+		nil,
+	)
+	require.NoError(t, err)
+
+	if len(restarts) == 0 {
+		return nil
+	}
+
+	return &restarts[0]
+}
+
+func (env *testEnv) startTasks(t *testing.T, serverID uint) []domain.DaemonTask {
+	t.Helper()
+
+	tasks, err := env.daemonTaskRepo.Find(context.Background(), &filters.FindDaemonTask{
+		ServerIDs: []*uint{&serverID},
+		Tasks:     []domain.DaemonTaskType{domain.DaemonTaskTypeServerStart},
+	}, nil, nil)
+	require.NoError(t, err)
+
+	return tasks
+}
+
+// finishTasks marks the daemon tasks of the server as failed, as if the server crashed right after the start.
+func (env *testEnv) finishTasks(t *testing.T, serverID uint) {
+	t.Helper()
+
+	for _, task := range env.startTasks(t, serverID) {
+		task.Status = domain.DaemonTaskStatusError
+		require.NoError(t, env.daemonTaskRepo.Save(context.Background(), &task))
+	}
+}
+
+func TestWorker_Process_RestartsCrashedServerWithBackoff(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	env.addServer(t, &domain.Server{ID: 1, DSID: 1}, map[string]bool{"autostart_current": true})
+
+	// The crash is noticed, but the server isn't restarted before the threshold
+	require.NoError(t, env.worker.Process(ctx, now))
+	restart := env.restart(t, 1)
+	require.NotNil(t, restart)
+	assert.Equal(t, &now, restart.CrashedAt)
+	assert.Zero(t, restart.Attempts)
+	assert.Empty(t, env.startTasks(t, 1))
+	assert.Equal(t, []uint{1}, env.notifier.crashed)
+
+	// First restart after the threshold
+	require.NoError(t, env.worker.Process(ctx, now.Add(time.Minute)))
+	restart = env.restart(t, 1)
+	assert.Equal(t, 1, restart.Attempts)
+	assert.Equal(t, lo.ToPtr(now.Add(time.Minute)), restart.LastAttemptAt)
+	tasks := env.startTasks(t, 1)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, &tasks[0].ID, restart.LastTaskID)
+
+	// No restart while the start task is pending
+	require.NoError(t, env.worker.Process(ctx, now.Add(5*time.Minute)))
+	assert.Len(t, env.startTasks(t, 1), 1)
+
+	// The second restart is delayed by the base delay after the first one
+	env.finishTasks(t, 1)
+	require.NoError(t, env.worker.Process(ctx, now.Add(time.Minute+59*time.Second)))
+	assert.Len(t, env.startTasks(t, 1), 1)
+
+	require.NoError(t, env.worker.Process(ctx, now.Add(2*time.Minute)))
+	assert.Len(t, env.startTasks(t, 1), 2)
+	assert.Equal(t, 2, env.restart(t, 1).Attempts)
+
+	// The delay is doubled, after the maximum number of attempts the server is in a crash loop
+	env.finishTasks(t, 1)
+	require.NoError(t, env.worker.Process(ctx, now.Add(3*time.Minute+59*time.Second)))
+	assert.False(t, env.restart(t, 1).CrashLoop)
+
+	require.NoError(t, env.worker.Process(ctx, now.Add(4*time.Minute)))
+	restart = env.restart(t, 1)
+	assert.True(t, restart.CrashLoop)
+	assert.Equal(t, lo.ToPtr(now.Add(4*time.Minute)), restart.CrashLoopAt)
+	assert.Equal(t, 1, restart.CrashLoops)
+	assert.Equal(t, []uint{1}, env.notifier.servers)
+
+	// Servers in a crash loop aren't restarted
+	require.NoError(t, env.worker.Process(ctx, now.Add(time.Hour)))
+	assert.Len(t, env.startTasks(t, 1), 2)
+	assert.Equal(t, []uint{1}, env.notifier.servers)
+}
+
+func TestWorker_Process_ResetsStableServer(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	env.addServer(t, &domain.Server{
+		ID:               1,
+		DSID:             1,
+		ProcessActive:    true,
+		LastProcessCheck: lo.ToPtr(now),
+	}, map[string]bool{"autostart_current": true})
+
+	require.NoError(t, env.restartRepo.Save(ctx, &domain.ServerAutoRestart{
+		ServerID:      1,
+		CrashedAt:     lo.ToPtr(now.Add(-5 * time.Minute)),
+		Attempts:      2,
+		LastAttemptAt: lo.ToPtr(now.Add(-time.Minute)),
+		CrashLoop:     true,
+		CrashLoops:    1,
+	}))
+
+	// The server is running again, but not long enough
+	require.NoError(t, env.worker.Process(ctx, now))
+	restart := env.restart(t, 1)
+	assert.Nil(t, restart.CrashedAt)
+	assert.Equal(t, 2, restart.Attempts)
+	assert.True(t, restart.CrashLoop)
+
+	require.NoError(t, env.worker.Process(ctx, now.Add(9*time.Minute)))
+	restart = env.restart(t, 1)
+	assert.Zero(t, restart.Attempts)
+	assert.False(t, restart.CrashLoop)
+	assert.Equal(t, 1, restart.CrashLoops)
+	assert.Empty(t, env.startTasks(t, 1))
+}
+
+func TestWorker_Process_SkipsNotWatchedServers(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	// autostart_current is disabled or not set
+	env.addServer(t, &domain.Server{ID: 1, DSID: 1}, map[string]bool{"autostart_current": false})
+	env.addServer(t, &domain.Server{ID: 2, DSID: 1}, nil)
+	// paused
+	env.addServer(t, &domain.Server{ID: 3, DSID: 1}, map[string]bool{"autostart_current": true, "paused": true})
+
+	blocked := &domain.Server{ID: 4, DSID: 1}
+	env.addServer(t, blocked, map[string]bool{"autostart_current": true})
+	blocked.Blocked = true
+	require.NoError(t, env.serverRepo.Save(ctx, blocked))
+
+	notInstalled := &domain.Server{ID: 5, DSID: 1}
+	env.addServer(t, notInstalled, map[string]bool{"autostart_current": true})
+	notInstalled.Installed = domain.ServerInstalledStatusInstallationInProg
+	require.NoError(t, env.serverRepo.Save(ctx, notInstalled))
+
+	require.NoError(t, env.worker.Process(ctx, now))
+	require.NoError(t, env.worker.Process(ctx, now.Add(time.Hour)))
+
+	restarts, err := env.restartRepo.Find(ctx, nil, nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, restarts)
+
+	tasks, err := env.daemonTaskRepo.FindAll(ctx, nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+}
+
+func TestEventNotifier_PublishesCrashLoop(t *testing.T) {
+	bus := events.NewBus()
+
+	var published []domain.Event
+	bus.Subscribe(events.HandlerFunc(func(_ context.Context, event domain.Event) error {
+		published = append(published, event)
+
+		return nil
+	}))
+
+	server := &domain.Server{ID: 1, Name: "Public CS"}
+	restart := &domain.ServerAutoRestart{ServerID: 1, Attempts: 3, CrashLoop: true, CrashLoops: 2}
+
+	NewEventNotifier(bus).NotifyCrashLoop(context.Background(), server, restart)
+
+	require.Len(t, published, 1)
+	assert.Equal(t, domain.EventTypeServerCrashLoop, published[0].Type)
+	assert.Equal(t, lo.ToPtr(uint(1)), published[0].ServerID)
+
+	data, ok := published[0].Data.(events.ServerCrashLoopData)
+	require.True(t, ok)
+	assert.Equal(t, 3, data.Attempts)
+	assert.Equal(t, 2, data.CrashLoops)
+}
+
+func TestEventNotifier_PublishesCrashed(t *testing.T) {
+	bus := events.NewBus()
+
+	var published []domain.Event
+	bus.Subscribe(events.HandlerFunc(func(_ context.Context, event domain.Event) error {
+		published = append(published, event)
+
+		return nil
+	}))
+
+	NewEventNotifier(bus).NotifyCrashed(context.Background(), &domain.Server{ID: 1, Name: "Public CS"})
+
+	require.Len(t, published, 1)
+	assert.Equal(t, domain.EventTypeServerCrashed, published[0].Type)
+	assert.Equal(t, lo.ToPtr(uint(1)), published[0].ServerID)
+}
@@ -0,0 +1,514 @@
+package twofactor
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+const (
+	challengeKeyPrefix   = "two_factor_challenge:"
+	challengeTokenLength = 32
+)
+
+var (
+	ErrAlreadyEnabled   = errors.New("two-factor authentication is already enabled")
+	ErrNotEnabled       = errors.New("two-factor authentication is not enabled")
+	ErrNotEnrolling     = errors.New("two-factor enrollment is not started")
+	ErrInvalidCode      = errors.New("two-factor code is invalid")
+	ErrInvalidChallenge = errors.New("two-factor challenge is invalid or expired")
+	ErrRequired         = errors.New("two-factor authentication is required for administrators")
+)
+
+// TooManyAttemptsError is returned when the user has exceeded the code attempts limit.
+type TooManyAttemptsError struct {
+	RetryAfter time.Duration
+}
+
+func (e *TooManyAttemptsError) Error() string {
+	return "too many two-factor code attempts"
+}
+
+type rbac interface {
+	Can(ctx context.Context, userID uint, abilities []domain.AbilityName) (bool, error)
+}
+
+type rateLimiter interface {
+	Hit(ctx context.Context, key string) (bool, time.Duration, error)
+	Reset(ctx context.Context, key string) error
+}
+
+type Config struct {
+	// Issuer is the account issuer shown in authenticator apps.
+	Issuer string
+	// EnforceAdmins requires administrators to use two-factor authentication.
+	// Administrators without it have to enroll during the login.
+	EnforceAdmins bool
+	// ChallengeTTL is how long the second login step waits for the code.
+	ChallengeTTL time.Duration
+}
+
+// Status is the two-factor authentication state of a user.
+type Status struct {
+	Enabled bool
+	// Pending is set when the enrollment is started but not confirmed.
+	Pending bool
+	// Required is set when the user can't log in or disable it without two-factor authentication.
+	Required          bool
+	RecoveryCodesLeft int
+}
+
+// Enrollment is the secret of a started enrollment, shown to the user once.
+type Enrollment struct {
+	Secret string
+	// URI is the otpauth provisioning URI, usually shown as a QR code.
+	URI string
+}
+
+// Challenge is the second step of the login, issued after the password is verified.
+type Challenge struct {
+	Token string
+	// SetupRequired is set when the user has to enroll before completing the login.
+	SetupRequired bool
+	ExpiresIn     time.Duration
+}
+
+// LoginResult is the user of a completed challenge.
+type LoginResult struct {
+	UserID   uint
+	Remember bool
+	// RecoveryCodes are set when the enrollment was confirmed during the login.
+	RecoveryCodes []string
+}
+
+// Service manages TOTP two-factor authentication of panel users.
+//
+// The login challenges are stored in the cache. Code attempts are rate limited by the user,
+// both for the login and for the profile actions.
+type Service struct {
+	repo     repositories.UserTwoFactorRepository
+	userRepo repositories.UserRepository
+	rbac     rbac
+	cache    cache.Cache
+	limiter  rateLimiter
+	cfg      Config
+
+	now func() time.Time
+}
+
+func NewService(
+	repo repositories.UserTwoFactorRepository,
+	userRepo repositories.UserRepository,
+	rbac rbac,
+	c cache.Cache,
+	limiter rateLimiter,
+	cfg Config,
+) *Service {
+	return &Service{
+		repo:     repo,
+		userRepo: userRepo,
+		rbac:     rbac,
+		cache:    c,
+		limiter:  limiter,
+		cfg:      cfg,
+		now:      time.Now,
+	}
+}
+
+func (s *Service) Status(ctx context.Context, userID uint) (*Status, error) {
+	twoFactor, err := s.find(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	required, err := s.Required(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &Status{
+		Required: required,
+	}
+
+	if twoFactor != nil {
+		status.Enabled = twoFactor.Enabled()
+		status.Pending = !twoFactor.Enabled()
+		status.RecoveryCodesLeft = len(twoFactor.RecoveryCodes)
+	}
+
+	return status, nil
+}
+
+// Required reports whether the user must use two-factor authentication.
+func (s *Service) Required(ctx context.Context, userID uint) (bool, error) {
+	if !s.cfg.EnforceAdmins {
+		return false, nil
+	}
+
+	isAdmin, err := s.rbac.Can(ctx, userID, []domain.AbilityName{domain.AbilityNameAdminRolesPermissions})
+	if err != nil {
+		return false, errors.WithMessage(err, "failed to check admin permissions")
+	}
+
+	return isAdmin, nil
+}
+
+// StartEnrollment generates a new secret for the user. A previous unconfirmed enrollment is replaced.
+func (s *Service) StartEnrollment(ctx context.Context, user *domain.User) (*Enrollment, error) {
+	twoFactor, err := s.find(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if twoFactor != nil && twoFactor.Enabled() {
+		return nil, ErrAlreadyEnabled
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+
+	err = s.repo.Save(ctx, &domain.UserTwoFactor{
+		UserID:    user.ID,
+		Secret:    secret,
+		CreatedAt: &now,
+		UpdatedAt: &now,
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to save two-factor authentication")
+	}
+
+	return &Enrollment{
+		Secret: secret,
+		URI:    provisioningURI(s.cfg.Issuer, user.Login, secret),
+	}, nil
+}
+
+// ConfirmEnrollment enables two-factor authentication if the code matches the new secret.
+// It returns the recovery codes, they aren't stored in plain text and can't be shown again.
+func (s *Service) ConfirmEnrollment(ctx context.Context, userID uint, code string) ([]string, error) {
+	twoFactor, err := s.find(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if twoFactor == nil {
+		return nil, ErrNotEnrolling
+	}
+
+	if twoFactor.Enabled() {
+		return nil, ErrAlreadyEnabled
+	}
+
+	if err = s.hit(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	code = normalizeCode(code)
+	if !isTOTPCode(code) {
+		return nil, ErrInvalidCode
+	}
+
+	step, ok := verifyCode(twoFactor.Secret, code, s.now(), twoFactor.LastUsedStep)
+	if !ok {
+		return nil, ErrInvalidCode
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	twoFactor.ConfirmedAt = &now
+	twoFactor.RecoveryCodes = hashes
+	twoFactor.LastUsedStep = step
+	twoFactor.UpdatedAt = &now
+
+	if err = s.repo.Save(ctx, twoFactor); err != nil {
+		return nil, errors.WithMessage(err, "failed to save two-factor authentication")
+	}
+
+	s.resetAttempts(ctx, userID)
+
+	return codes, nil
+}
+
+// Disable turns off two-factor authentication after the TOTP code or a recovery code is verified.
+// An unconfirmed enrollment is cancelled without the code.
+func (s *Service) Disable(ctx context.Context, userID uint, code string) error {
+	twoFactor, err := s.find(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if twoFactor == nil {
+		return ErrNotEnabled
+	}
+
+	if twoFactor.Enabled() {
+		required, err := s.Required(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		if required {
+			return ErrRequired
+		}
+
+		if err = s.verify(ctx, twoFactor, code); err != nil {
+			return err
+		}
+	}
+
+	if err = s.repo.Delete(ctx, userID); err != nil {
+		return errors.WithMessage(err, "failed to delete two-factor authentication")
+	}
+
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes after the code is verified.
+func (s *Service) RegenerateRecoveryCodes(ctx context.Context, userID uint, code string) ([]string, error) {
+	twoFactor, err := s.find(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if twoFactor == nil || !twoFactor.Enabled() {
+		return nil, ErrNotEnabled
+	}
+
+	if err = s.verify(ctx, twoFactor, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	twoFactor.RecoveryCodes = hashes
+	twoFactor.UpdatedAt = lo.ToPtr(s.now())
+
+	if err = s.repo.Save(ctx, twoFactor); err != nil {
+		return nil, errors.WithMessage(err, "failed to save two-factor authentication")
+	}
+
+	return codes, nil
+}
+
+// BeginLogin creates the login challenge for the user with verified password.
+// It returns nil if the user can log in without the second step.
+func (s *Service) BeginLogin(ctx context.Context, userID uint, remember bool) (*Challenge, error) {
+	twoFactor, err := s.find(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := twoFactor != nil && twoFactor.Enabled()
+
+	if !enabled {
+		required, err := s.Required(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		if !required {
+			return nil, nil
+		}
+	}
+
+	b := make([]byte, challengeTokenLength)
+	if _, err = rand.Read(b); err != nil {
+		return nil, errors.WithMessage(err, "failed to generate challenge token")
+	}
+
+	token := hex.EncodeToString(b)
+
+	value := strconv.FormatUint(uint64(userID), 10) + ":" + strconv.FormatBool(remember)
+
+	err = s.cache.Set(ctx, challengeKeyPrefix+token, value, cache.WithExpiration(s.cfg.ChallengeTTL))
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to save challenge")
+	}
+
+	return &Challenge{
+		Token:         token,
+		SetupRequired: !enabled,
+		ExpiresIn:     s.cfg.ChallengeTTL,
+	}, nil
+}
+
+// StartLoginEnrollment starts the enrollment of the user who must set up
+// two-factor authentication to complete the login.
+func (s *Service) StartLoginEnrollment(ctx context.Context, token string) (*Enrollment, error) {
+	userID, _, err := s.challenge(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.userRepo.Find(ctx, &filters.FindUser{IDs: []uint{userID}}, nil, &filters.Pagination{Limit: 1})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find user")
+	}
+
+	if len(users) == 0 {
+		return nil, ErrInvalidChallenge
+	}
+
+	return s.StartEnrollment(ctx, &users[0])
+}
+
+// CompleteLogin verifies the code of the challenge. If the user is enrolling during the login,
+// the code confirms the enrollment and the recovery codes are returned.
+// The challenge can't be used again after it is completed.
+func (s *Service) CompleteLogin(ctx context.Context, token, code string) (*LoginResult, error) {
+	userID, remember, err := s.challenge(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	twoFactor, err := s.find(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if twoFactor == nil {
+		return nil, ErrNotEnrolling
+	}
+
+	result := &LoginResult{
+		UserID:   userID,
+		Remember: remember,
+	}
+
+	if twoFactor.Enabled() {
+		err = s.verify(ctx, twoFactor, code)
+	} else {
+		result.RecoveryCodes, err = s.ConfirmEnrollment(ctx, userID, code)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.cache.Delete(ctx, challengeKeyPrefix+token); err != nil {
+		return nil, errors.WithMessage(err, "failed to delete challenge")
+	}
+
+	return result, nil
+}
+
+func (s *Service) challenge(ctx context.Context, token string) (uint, bool, error) {
+	if token == "" {
+		return 0, false, ErrInvalidChallenge
+	}
+
+	value, err := s.cache.Get(ctx, challengeKeyPrefix+token)
+	if err != nil {
+		if errors.Is(err, cache.ErrNotFound) {
+			return 0, false, ErrInvalidChallenge
+		}
+
+		return 0, false, errors.WithMessage(err, "failed to get challenge")
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return 0, false, ErrInvalidChallenge
+	}
+
+	userIDStr, rememberStr, ok := strings.Cut(str, ":")
+	if !ok {
+		return 0, false, ErrInvalidChallenge
+	}
+
+	userID, err := strconv.ParseUint(userIDStr, 10, 64)
+	if err != nil {
+		return 0, false, ErrInvalidChallenge
+	}
+
+	remember, err := strconv.ParseBool(rememberStr)
+	if err != nil {
+		return 0, false, ErrInvalidChallenge
+	}
+
+	return uint(userID), remember, nil
+}
+
+func (s *Service) verify(ctx context.Context, twoFactor *domain.UserTwoFactor, code string) error {
+	if err := s.hit(ctx, twoFactor.UserID); err != nil {
+		return err
+	}
+
+	code = normalizeCode(code)
+
+	if isTOTPCode(code) {
+		step, ok := verifyCode(twoFactor.Secret, code, s.now(), twoFactor.LastUsedStep)
+		if !ok {
+			return ErrInvalidCode
+		}
+
+		twoFactor.LastUsedStep = step
+	} else if code == "" || !twoFactor.UseRecoveryCode(hashRecoveryCode(code)) {
+		return ErrInvalidCode
+	}
+
+	twoFactor.UpdatedAt = lo.ToPtr(s.now())
+
+	if err := s.repo.Save(ctx, twoFactor); err != nil {
+		return errors.WithMessage(err, "failed to save two-factor authentication")
+	}
+
+	s.resetAttempts(ctx, twoFactor.UserID)
+
+	return nil
+}
+
+func (s *Service) hit(ctx context.Context, userID uint) error {
+	allowed, retryAfter, err := s.limiter.Hit(ctx, strconv.FormatUint(uint64(userID), 10))
+	if err != nil {
+		return errors.WithMessage(err, "failed to check rate limit")
+	}
+
+	if !allowed {
+		return &TooManyAttemptsError{RetryAfter: retryAfter}
+	}
+
+	return nil
+}
+
+// resetAttempts clears the attempts after a valid code, so typos don't add up over time.
+func (s *Service) resetAttempts(ctx context.Context, userID uint) {
+	err := s.limiter.Reset(ctx, strconv.FormatUint(uint64(userID), 10))
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to reset two-factor attempts", slog.String("error", err.Error()))
+	}
+}
+
+func (s *Service) find(ctx context.Context, userID uint) (*domain.UserTwoFactor, error) {
+	twoFactors, err := s.repo.Find(ctx, &filters.FindUserTwoFactor{UserIDs: []uint{userID}}, nil, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find two-factor authentication")
+	}
+
+	if len(twoFactors) == 0 {
+		return nil, nil
+	}
+
+	return &twoFactors[0], nil
+}
@@ -0,0 +1,371 @@
+package twofactor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRBAC struct {
+	admins map[uint]bool
+}
+
+func (f *fakeRBAC) Can(_ context.Context, userID uint, _ []domain.AbilityName) (bool, error) {
+	return f.admins[userID], nil
+}
+
+type testEnv struct {
+	service *Service
+	repo    *inmemory.UserTwoFactorRepository
+	user    *domain.User
+	admin   *domain.User
+	now     time.Time
+}
+
+func setup(t *testing.T, enforceAdmins bool) *testEnv {
+	t.Helper()
+
+	userRepo := inmemory.NewUserRepository()
+
+	user := &domain.User{Login: "user", Email: "user@example.com"}
+	require.NoError(t, userRepo.Save(context.Background(), user))
+
+	admin := &domain.User{Login: "admin", Email: "admin@example.com"}
+	require.NoError(t, userRepo.Save(context.Background(), admin))
+
+	c := cache.NewInMemory()
+
+	env := &testEnv{
+		repo:  inmemory.NewUserTwoFactorRepository(),
+		user:  user,
+		admin: admin,
+		now:   time.Unix(1700000000, 0),
+	}
+
+	env.service = NewService(
+		env.repo,
+		userRepo,
+		&fakeRBAC{admins: map[uint]bool{admin.ID: true}},
+		c,
+		cache.NewRateLimiter(c, "two_factor", 3, time.Minute),
+		Config{
+			Issuer:        "GameAP",
+			EnforceAdmins: enforceAdmins,
+			ChallengeTTL:  5 * time.Minute,
+		},
+	)
+	env.service.now = func() time.Time {
+		return env.now
+	}
+
+	return env
+}
+
+// code returns the TOTP code of the enrolled secret of the user for the current test time.
+func (e *testEnv) code(t *testing.T, userID uint) string {
+	t.Helper()
+
+	twoFactors, err := e.repo.Find(context.Background(), &filters.FindUserTwoFactor{UserIDs: []uint{userID}}, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, twoFactors, 1)
+
+	key, err := secretEncoding.DecodeString(twoFactors[0].Secret)
+	require.NoError(t, err)
+
+	return totpCode(key, timeStep(e.now))
+}
+
+// nextStep moves the test time to the next TOTP period, so a new code can be used.
+func (e *testEnv) nextStep() {
+	e.now = e.now.Add(codePeriod)
+}
+
+func (e *testEnv) enroll(t *testing.T, user *domain.User) []string {
+	t.Helper()
+
+	ctx := context.Background()
+
+	_, err := e.service.StartEnrollment(ctx, user)
+	require.NoError(t, err)
+
+	codes, err := e.service.ConfirmEnrollment(ctx, user.ID, e.code(t, user.ID))
+	require.NoError(t, err)
+
+	e.nextStep()
+
+	return codes
+}
+
+func TestService_Enrollment(t *testing.T) {
+	ctx := context.Background()
+	env := setup(t, false)
+
+	status, err := env.service.Status(ctx, env.user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &Status{}, status)
+
+	enrollment, err := env.service.StartEnrollment(ctx, env.user)
+	require.NoError(t, err)
+	assert.NotEmpty(t, enrollment.Secret)
+	assert.Contains(t, enrollment.URI, "otpauth://totp/GameAP:user?")
+	assert.Contains(t, enrollment.URI, "secret="+enrollment.Secret)
+
+	status, err = env.service.Status(ctx, env.user.ID)
+	require.NoError(t, err)
+	assert.True(t, status.Pending)
+	assert.False(t, status.Enabled)
+
+	_, err = env.service.ConfirmEnrollment(ctx, env.user.ID, "abcde-12345")
+	require.ErrorIs(t, err, ErrInvalidCode)
+
+	codes, err := env.service.ConfirmEnrollment(ctx, env.user.ID, env.code(t, env.user.ID))
+	require.NoError(t, err)
+	assert.Len(t, codes, recoveryCodesCount)
+
+	status, err = env.service.Status(ctx, env.user.ID)
+	require.NoError(t, err)
+	assert.True(t, status.Enabled)
+	assert.False(t, status.Pending)
+	assert.Equal(t, recoveryCodesCount, status.RecoveryCodesLeft)
+
+	twoFactors, err := env.repo.Find(ctx, nil, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, twoFactors, 1)
+	for _, code := range codes {
+		assert.NotContains(t, twoFactors[0].RecoveryCodes, code, "recovery codes must be stored hashed")
+	}
+
+	_, err = env.service.StartEnrollment(ctx, env.user)
+	require.ErrorIs(t, err, ErrAlreadyEnabled)
+
+	_, err = env.service.ConfirmEnrollment(ctx, env.user.ID, env.code(t, env.user.ID))
+	require.ErrorIs(t, err, ErrAlreadyEnabled)
+}
+
+func TestService_ConfirmEnrollment_NotStarted(t *testing.T) {
+	env := setup(t, false)
+
+	_, err := env.service.ConfirmEnrollment(context.Background(), env.user.ID, "123456")
+	require.ErrorIs(t, err, ErrNotEnrolling)
+}
+
+func TestService_Disable(t *testing.T) {
+	ctx := context.Background()
+	env := setup(t, false)
+	env.enroll(t, env.user)
+
+	err := env.service.Disable(ctx, env.user.ID, "000000")
+	require.ErrorIs(t, err, ErrInvalidCode)
+
+	require.NoError(t, env.service.Disable(ctx, env.user.ID, env.code(t, env.user.ID)))
+
+	status, err := env.service.Status(ctx, env.user.ID)
+	require.NoError(t, err)
+	assert.False(t, status.Enabled)
+
+	err = env.service.Disable(ctx, env.user.ID, "123456")
+	require.ErrorIs(t, err, ErrNotEnabled)
+}
+
+func TestService_Disable_RequiredForAdmin(t *testing.T) {
+	ctx := context.Background()
+	env := setup(t, true)
+	env.enroll(t, env.admin)
+
+	err := env.service.Disable(ctx, env.admin.ID, env.code(t, env.admin.ID))
+	require.ErrorIs(t, err, ErrRequired)
+
+	status, err := env.service.Status(ctx, env.admin.ID)
+	require.NoError(t, err)
+	assert.True(t, status.Enabled)
+	assert.True(t, status.Required)
+}
+
+func TestService_RegenerateRecoveryCodes(t *testing.T) {
+	ctx := context.Background()
+	env := setup(t, false)
+	oldCodes := env.enroll(t, env.user)
+
+	newCodes, err := env.service.RegenerateRecoveryCodes(ctx, env.user.ID, oldCodes[0])
+	require.NoError(t, err)
+	assert.Len(t, newCodes, recoveryCodesCount)
+
+	// Old codes are replaced
+	_, err = env.service.RegenerateRecoveryCodes(ctx, env.user.ID, oldCodes[1])
+	require.ErrorIs(t, err, ErrInvalidCode)
+
+	_, err = env.service.RegenerateRecoveryCodes(ctx, env.user.ID, newCodes[0])
+	require.NoError(t, err)
+}
+
+func TestService_BeginLogin(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("without_two_factor", func(t *testing.T) {
+		env := setup(t, false)
+
+		challenge, err := env.service.BeginLogin(ctx, env.user.ID, false)
+		require.NoError(t, err)
+		assert.Nil(t, challenge)
+	})
+
+	t.Run("pending_enrollment_is_ignored", func(t *testing.T) {
+		env := setup(t, false)
+
+		_, err := env.service.StartEnrollment(ctx, env.user)
+		require.NoError(t, err)
+
+		challenge, err := env.service.BeginLogin(ctx, env.user.ID, false)
+		require.NoError(t, err)
+		assert.Nil(t, challenge)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		env := setup(t, false)
+		env.enroll(t, env.user)
+
+		challenge, err := env.service.BeginLogin(ctx, env.user.ID, false)
+		require.NoError(t, err)
+		require.NotNil(t, challenge)
+		assert.Len(t, challenge.Token, 2*challengeTokenLength)
+		assert.False(t, challenge.SetupRequired)
+		assert.Equal(t, 5*time.Minute, challenge.ExpiresIn)
+	})
+
+	t.Run("enforced_for_admin", func(t *testing.T) {
+		env := setup(t, true)
+
+		challenge, err := env.service.BeginLogin(ctx, env.admin.ID, false)
+		require.NoError(t, err)
+		require.NotNil(t, challenge)
+		assert.True(t, challenge.SetupRequired)
+
+		challenge, err = env.service.BeginLogin(ctx, env.user.ID, false)
+		require.NoError(t, err)
+		assert.Nil(t, challenge)
+	})
+}
+
+func TestService_CompleteLogin(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("totp_code", func(t *testing.T) {
+		env := setup(t, false)
+		env.enroll(t, env.user)
+
+		challenge, err := env.service.BeginLogin(ctx, env.user.ID, true)
+		require.NoError(t, err)
+
+		_, err = env.service.CompleteLogin(ctx, challenge.Token, "000000")
+		require.ErrorIs(t, err, ErrInvalidCode)
+
+		result, err := env.service.CompleteLogin(ctx, challenge.Token, env.code(t, env.user.ID))
+		require.NoError(t, err)
+		assert.Equal(t, env.user.ID, result.UserID)
+		assert.True(t, result.Remember)
+		assert.Empty(t, result.RecoveryCodes)
+
+		// The challenge is single-use
+		_, err = env.service.CompleteLogin(ctx, challenge.Token, env.code(t, env.user.ID))
+		require.ErrorIs(t, err, ErrInvalidChallenge)
+	})
+
+	t.Run("code_replay", func(t *testing.T) {
+		env := setup(t, false)
+		env.enroll(t, env.user)
+		code := env.code(t, env.user.ID)
+
+		challenge, err := env.service.BeginLogin(ctx, env.user.ID, false)
+		require.NoError(t, err)
+		_, err = env.service.CompleteLogin(ctx, challenge.Token, code)
+		require.NoError(t, err)
+
+		challenge, err = env.service.BeginLogin(ctx, env.user.ID, false)
+		require.NoError(t, err)
+		_, err = env.service.CompleteLogin(ctx, challenge.Token, code)
+		require.ErrorIs(t, err, ErrInvalidCode)
+	})
+
+	t.Run("recovery_code", func(t *testing.T) {
+		env := setup(t, false)
+		codes := env.enroll(t, env.user)
+
+		challenge, err := env.service.BeginLogin(ctx, env.user.ID, false)
+		require.NoError(t, err)
+		_, err = env.service.CompleteLogin(ctx, challenge.Token, codes[0])
+		require.NoError(t, err)
+
+		status, err := env.service.Status(ctx, env.user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, recoveryCodesCount-1, status.RecoveryCodesLeft)
+
+		// Recovery codes are single-use
+		challenge, err = env.service.BeginLogin(ctx, env.user.ID, false)
+		require.NoError(t, err)
+		_, err = env.service.CompleteLogin(ctx, challenge.Token, codes[0])
+		require.ErrorIs(t, err, ErrInvalidCode)
+	})
+
+	t.Run("setup_during_login", func(t *testing.T) {
+		env := setup(t, true)
+
+		challenge, err := env.service.BeginLogin(ctx, env.admin.ID, false)
+		require.NoError(t, err)
+		require.True(t, challenge.SetupRequired)
+
+		_, err = env.service.CompleteLogin(ctx, challenge.Token, "123456")
+		require.ErrorIs(t, err, ErrNotEnrolling)
+
+		enrollment, err := env.service.StartLoginEnrollment(ctx, challenge.Token)
+		require.NoError(t, err)
+		assert.Contains(t, enrollment.URI, "GameAP:admin")
+
+		result, err := env.service.CompleteLogin(ctx, challenge.Token, env.code(t, env.admin.ID))
+		require.NoError(t, err)
+		assert.Equal(t, env.admin.ID, result.UserID)
+		assert.Len(t, result.RecoveryCodes, recoveryCodesCount)
+
+		status, err := env.service.Status(ctx, env.admin.ID)
+		require.NoError(t, err)
+		assert.True(t, status.Enabled)
+	})
+
+	t.Run("invalid_challenge", func(t *testing.T) {
+		env := setup(t, false)
+
+		_, err := env.service.CompleteLogin(ctx, "unknown", "123456")
+		require.ErrorIs(t, err, ErrInvalidChallenge)
+
+		_, err = env.service.CompleteLogin(ctx, "", "123456")
+		require.ErrorIs(t, err, ErrInvalidChallenge)
+
+		_, err = env.service.StartLoginEnrollment(ctx, "unknown")
+		require.ErrorIs(t, err, ErrInvalidChallenge)
+	})
+
+	t.Run("too_many_attempts", func(t *testing.T) {
+		env := setup(t, false)
+		env.enroll(t, env.user)
+
+		challenge, err := env.service.BeginLogin(ctx, env.user.ID, false)
+		require.NoError(t, err)
+
+		for range 3 {
+			_, err = env.service.CompleteLogin(ctx, challenge.Token, "abcde-abcde")
+			require.ErrorIs(t, err, ErrInvalidCode)
+		}
+
+		_, err = env.service.CompleteLogin(ctx, challenge.Token, env.code(t, env.user.ID))
+
+		var tooMany *TooManyAttemptsError
+		require.ErrorAs(t, err, &tooMany)
+		assert.Positive(t, tooMany.RetryAfter)
+	})
+}
@@ -0,0 +1,161 @@
+package twofactor
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // RFC 6238 TOTP uses HMAC-SHA1, supported by all authenticator apps
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	secretLength = 20 // 160 bits, recommended by RFC 4226
+	codeDigits   = 6
+	codePeriod   = 30 * time.Second
+	// codeSkew is the number of time steps before and after the current one accepted to tolerate clock drift.
+	codeSkew = 1
+
+	recoveryCodesCount  = 8
+	recoveryCodeLength  = 5 // bytes, formatted as two groups of five hex characters
+	recoveryCodeDivider = "-"
+)
+
+var secretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+func newSecret() (string, error) {
+	b := make([]byte, secretLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.WithMessage(err, "failed to generate secret")
+	}
+
+	return secretEncoding.EncodeToString(b), nil
+}
+
+func timeStep(t time.Time) int64 {
+	return t.Unix() / int64(codePeriod/time.Second)
+}
+
+// totpCode returns the RFC 6238 code of the secret for the time step.
+func totpCode(secret []byte, step int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step)) //nolint:gosec // time steps are positive
+
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	code := strconv.FormatUint(uint64(value%1_000_000), 10)
+
+	return strings.Repeat("0", codeDigits-len(code)) + code
+}
+
+// verifyCode checks the code against the time steps around now.
+// Steps up to lastUsedStep are rejected, so a code can't be used twice.
+// It returns the matched time step.
+func verifyCode(secret, code string, now time.Time, lastUsedStep int64) (int64, bool) {
+	key, err := secretEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return 0, false
+	}
+
+	current := timeStep(now)
+
+	for step := current - codeSkew; step <= current+codeSkew; step++ {
+		if step <= lastUsedStep {
+			continue
+		}
+
+		if subtle.ConstantTimeCompare([]byte(totpCode(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// isTOTPCode reports whether the normalized code looks like a TOTP code rather than a recovery code.
+func isTOTPCode(code string) bool {
+	if len(code) != codeDigits {
+		return false
+	}
+
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// provisioningURI returns the otpauth URI encoded in the QR code scanned by authenticator apps.
+func provisioningURI(issuer, account, secret string) string {
+	label := url.PathEscape(account)
+	if issuer != "" {
+		label = url.PathEscape(issuer) + ":" + label
+	}
+
+	query := url.Values{}
+	query.Set("secret", secret)
+	if issuer != "" {
+		query.Set("issuer", issuer)
+	}
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", strconv.Itoa(codeDigits))
+	query.Set("period", strconv.Itoa(int(codePeriod/time.Second)))
+
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// newRecoveryCodes returns the plain recovery codes and their hashes.
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodesCount)
+	hashes := make([]string, 0, recoveryCodesCount)
+
+	for range recoveryCodesCount {
+		b := make([]byte, recoveryCodeLength)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, errors.WithMessage(err, "failed to generate recovery code")
+		}
+
+		code := hex.EncodeToString(b)
+
+		codes = append(codes, code[:len(code)/2]+recoveryCodeDivider+code[len(code)/2:])
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+
+	return codes, hashes, nil
+}
+
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeCode removes the separators users may type along with the code.
+func normalizeCode(code string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", recoveryCodeDivider, "").Replace(code))
+}
+
+// Code returns the TOTP code of the base32 encoded secret at the time.
+func Code(secret string, t time.Time) (string, error) {
+	key, err := secretEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", errors.WithMessage(err, "invalid secret")
+	}
+
+	return totpCode(key, timeStep(t)), nil
+}
@@ -0,0 +1,114 @@
+package twofactor
+
+import (
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTOTPCode_RFC6238Vectors(t *testing.T) {
+	secret := []byte("12345678901234567890")
+
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, totpCode(secret, timeStep(time.Unix(tt.unix, 0))))
+	}
+}
+
+func TestVerifyCode(t *testing.T) {
+	secret, err := newSecret()
+	require.NoError(t, err)
+
+	key, err := secretEncoding.DecodeString(secret)
+	require.NoError(t, err)
+
+	now := time.Unix(1700000000, 0)
+	current := timeStep(now)
+
+	t.Run("current_step", func(t *testing.T) {
+		step, ok := verifyCode(secret, totpCode(key, current), now, 0)
+		assert.True(t, ok)
+		assert.Equal(t, current, step)
+	})
+
+	t.Run("clock_drift", func(t *testing.T) {
+		_, ok := verifyCode(secret, totpCode(key, current-1), now, 0)
+		assert.True(t, ok)
+
+		_, ok = verifyCode(secret, totpCode(key, current+1), now, 0)
+		assert.True(t, ok)
+
+		_, ok = verifyCode(secret, totpCode(key, current-2), now, 0)
+		assert.False(t, ok)
+	})
+
+	t.Run("used_step", func(t *testing.T) {
+		_, ok := verifyCode(secret, totpCode(key, current), now, current)
+		assert.False(t, ok)
+	})
+
+	t.Run("wrong_code", func(t *testing.T) {
+		code := "000000"
+		if totpCode(key, current-1) == code || totpCode(key, current) == code || totpCode(key, current+1) == code {
+			code = "111111"
+		}
+
+		_, ok := verifyCode(secret, code, now, 0)
+		assert.False(t, ok)
+	})
+
+	t.Run("invalid_secret", func(t *testing.T) {
+		_, ok := verifyCode("not base32!", "123456", now, 0)
+		assert.False(t, ok)
+	})
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri, err := url.Parse(provisioningURI("GameAP Panel", "admin@example.com", "JBSWY3DPEHPK3PXP"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/GameAP Panel:admin@example.com", uri.Path)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", uri.Query().Get("secret"))
+	assert.Equal(t, "GameAP Panel", uri.Query().Get("issuer"))
+	assert.Equal(t, "6", uri.Query().Get("digits"))
+	assert.Equal(t, "30", uri.Query().Get("period"))
+}
+
+func TestNewRecoveryCodes(t *testing.T) {
+	codes, hashes, err := newRecoveryCodes()
+	require.NoError(t, err)
+	require.Len(t, codes, recoveryCodesCount)
+	require.Len(t, hashes, recoveryCodesCount)
+
+	pattern := regexp.MustCompile(`^[0-9a-f]{5}-[0-9a-f]{5}$`)
+
+	for i, code := range codes {
+		assert.Regexp(t, pattern, code)
+		assert.Equal(t, hashes[i], hashRecoveryCode(normalizeCode(code)))
+		assert.NotContains(t, hashes, code)
+	}
+}
+
+func TestNormalizeCode(t *testing.T) {
+	assert.Equal(t, "123456", normalizeCode("123 456"))
+	assert.Equal(t, "abcde12345", normalizeCode("ABCDE-12345"))
+	assert.True(t, isTOTPCode(normalizeCode(" 123456 ")))
+	assert.False(t, isTOTPCode("12345a"))
+	assert.False(t, isTOTPCode("abcde12345"))
+}
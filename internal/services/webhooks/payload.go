@@ -0,0 +1,33 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/pkg/errors"
+)
+
+// Payload is the JSON body of webhook requests.
+type Payload struct {
+	ID         string           `json:"id"`
+	Event      domain.EventType `json:"event"`
+	OccurredAt time.Time        `json:"occurred_at"`
+	WebhookID  uint             `json:"webhook_id"`
+	Data       any              `json:"data"`
+}
+
+func newPayload(webhook *domain.Webhook, event domain.Event) (string, error) {
+	b, err := json.Marshal(Payload{
+		ID:         event.ID.String(),
+		Event:      event.Type,
+		OccurredAt: event.OccurredAt.UTC(),
+		WebhookID:  webhook.ID,
+		Data:       event.Data,
+	})
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to marshal webhook payload")
+	}
+
+	return string(b), nil
+}
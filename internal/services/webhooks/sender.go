@@ -0,0 +1,129 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/pkg/errors"
+)
+
+const (
+	HeaderEvent     = "X-GameAP-Event"
+	HeaderDelivery  = "X-GameAP-Delivery"
+	HeaderSignature = "X-GameAP-Signature-256"
+
+	userAgent = "GameAP-Webhook"
+
+	// maxResponseBodySize limits how much of the response body is read before closing the connection.
+	maxResponseBodySize = 64 << 10
+)
+
+// Signature returns the value of the signature header of the payload signed with the secret.
+func Signature(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewHTTPClient creates the client to send webhooks with. Redirects are not followed.
+// Unless private networks are allowed, connections to loopback, private and link-local
+// addresses are refused, so webhooks can't be used to reach internal services.
+func NewHTTPClient(timeout time.Duration, allowPrivateNetworks bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+	}
+
+	if !allowPrivateNetworks {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			ip := net.ParseIP(host)
+			if ip == nil || !isPublicIP(ip) {
+				return errors.Errorf("webhook address %s is not allowed", host)
+			}
+
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	transport.Proxy = nil
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func isPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsUnspecified() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast()
+}
+
+// Sender posts signed delivery payloads to webhooks.
+type Sender struct {
+	client *http.Client
+}
+
+func NewSender(client *http.Client) *Sender {
+	return &Sender{
+		client: client,
+	}
+}
+
+// Send posts the delivery payload to the webhook. It returns the response status if a response
+// was received, and an error unless the status is 2xx.
+func (s *Sender) Send(ctx context.Context, webhook *domain.Webhook, delivery *domain.WebhookDelivery) (*int, error) {
+	payload := []byte(delivery.Payload)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create request")
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(HeaderEvent, string(delivery.EventType))
+	req.Header.Set(HeaderDelivery, strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(HeaderSignature, Signature(webhook.Secret, payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to send request")
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBodySize))
+		_ = resp.Body.Close()
+	}()
+
+	status := resp.StatusCode
+
+	if status < http.StatusOK || status >= http.StatusMultipleChoices {
+		return &status, errors.Errorf("unexpected response status %d", status)
+	}
+
+	return &status, nil
+}
@@ -0,0 +1,75 @@
+package webhooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignature(t *testing.T) {
+	assert.Equal(
+		t,
+		"sha256=b613679a0814d9ec772f95d778c35fc5ff1697c493715653c6c712144292c5ad",
+		Signature("", nil),
+	)
+	assert.NotEqual(t, Signature("secret", []byte("{}")), Signature("other", []byte("{}")))
+}
+
+func TestSender_Send(t *testing.T) {
+	var headers http.Header
+	var body []byte
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		headers = r.Header
+		body, _ = io.ReadAll(r.Body)
+		rw.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	webhook := &domain.Webhook{ID: 1, URL: srv.URL, Secret: "secret"}
+	delivery := &domain.WebhookDelivery{
+		ID:        42,
+		EventType: domain.EventTypeServerOnline,
+		Payload:   `{"event":"server.online"}`,
+	}
+
+	status, err := NewSender(NewHTTPClient(time.Second, true)).Send(context.Background(), webhook, delivery)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, *status)
+
+	assert.Equal(t, delivery.Payload, string(body))
+	assert.Equal(t, "application/json", headers.Get("Content-Type"))
+	assert.Equal(t, "server.online", headers.Get(HeaderEvent))
+	assert.Equal(t, "42", headers.Get(HeaderDelivery))
+	assert.Equal(t, Signature("secret", body), headers.Get(HeaderSignature))
+}
+
+func TestSender_Send_Failures(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		http.Redirect(rw, r, "https://example.com", http.StatusFound)
+	}))
+	defer srv.Close()
+
+	webhook := &domain.Webhook{ID: 1, URL: srv.URL, Secret: "secret"}
+	delivery := &domain.WebhookDelivery{ID: 1, Payload: "{}"}
+
+	t.Run("redirects are not followed", func(t *testing.T) {
+		status, err := NewSender(NewHTTPClient(time.Second, true)).Send(context.Background(), webhook, delivery)
+		require.EqualError(t, err, "unexpected response status 302")
+		assert.Equal(t, http.StatusFound, *status)
+	})
+
+	t.Run("private networks are blocked", func(t *testing.T) {
+		status, err := NewSender(NewHTTPClient(time.Second, false)).Send(context.Background(), webhook, delivery)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "webhook address 127.0.0.1 is not allowed")
+		assert.Nil(t, status)
+	})
+}
@@ -0,0 +1,227 @@
+package webhooks
+
+import (
+	"context"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+type rbac interface {
+	Can(ctx context.Context, userID uint, abilities []domain.AbilityName) (bool, error)
+}
+
+// Service queues the panel events for delivery to the subscribed webhooks.
+type Service struct {
+	webhookRepo  repositories.WebhookRepository
+	deliveryRepo repositories.WebhookDeliveryRepository
+	serverRepo   repositories.ServerRepository
+	rbac         rbac
+	sender       *Sender
+}
+
+func NewService(
+	webhookRepo repositories.WebhookRepository,
+	deliveryRepo repositories.WebhookDeliveryRepository,
+	serverRepo repositories.ServerRepository,
+	rbac rbac,
+	sender *Sender,
+) *Service {
+	return &Service{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		serverRepo:   serverRepo,
+		rbac:         rbac,
+		sender:       sender,
+	}
+}
+
+// HandleEvent creates pending deliveries of the event to the enabled webhooks subscribed to it.
+// The deliveries are sent by the worker.
+func (s *Service) HandleEvent(ctx context.Context, event domain.Event) error {
+	webhooks, err := s.webhookRepo.Find(ctx, &filters.FindWebhook{Enabled: lo.ToPtr(true)}, nil, nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed to find webhooks")
+	}
+
+	webhooks = lo.Filter(webhooks, func(webhook domain.Webhook, _ int) bool {
+		return webhook.Subscribed(event.Type)
+	})
+
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	webhooks, err = s.filterAccessible(ctx, webhooks, event)
+	if err != nil {
+		return err
+	}
+
+	for i := range webhooks {
+		delivery, err := newDelivery(&webhooks[i], event)
+		if err != nil {
+			return err
+		}
+
+		if err = s.deliveryRepo.Save(ctx, delivery); err != nil {
+			return errors.WithMessage(err, "failed to save webhook delivery")
+		}
+	}
+
+	return nil
+}
+
+// Test sends a ping event to the webhook right away. The delivery is recorded to the delivery log,
+// but it isn't retried if it fails.
+func (s *Service) Test(ctx context.Context, webhook *domain.Webhook) (*domain.WebhookDelivery, error) {
+	delivery, err := newDelivery(webhook, domain.Event{
+		ID:         uuid.New(),
+		Type:       domain.EventTypePing,
+		OccurredAt: time.Now(),
+		Data:       struct{}{},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The delivery is saved before sending to have the ID for the delivery header
+	delivery.NextAttemptAt = nil
+	if err = s.deliveryRepo.Save(ctx, delivery); err != nil {
+		return nil, errors.WithMessage(err, "failed to save webhook delivery")
+	}
+
+	status, sendErr := s.sender.Send(ctx, webhook, delivery)
+	if sendErr != nil {
+		delivery.Fail(domain.WebhookRetryPolicy{MaxAttempts: 1}, time.Now(), status, sendErr.Error())
+	} else {
+		delivery.Succeed(time.Now(), lo.FromPtr(status))
+	}
+
+	if err = s.deliveryRepo.Save(ctx, delivery); err != nil {
+		return nil, errors.WithMessage(err, "failed to save webhook delivery")
+	}
+
+	return delivery, nil
+}
+
+// filterAccessible returns the webhooks allowed to receive the event.
+//
+// Global webhooks receive all events. Webhooks of a user receive only the events of the servers
+// the user has access to: all servers for admins, or the servers assigned to the user.
+// A server deleted without keeping its record can't be checked anymore, so its events are
+// delivered to the webhooks of the server, their access was checked when they were saved.
+func (s *Service) filterAccessible(
+	ctx context.Context,
+	webhooks []domain.Webhook,
+	event domain.Event,
+) ([]domain.Webhook, error) {
+	result := make([]domain.Webhook, 0, len(webhooks))
+
+	serverExists, err := s.serverExists(ctx, event.ServerID)
+	if err != nil {
+		return nil, err
+	}
+
+	access := make(map[uint]bool)
+
+	for _, webhook := range webhooks {
+		if webhook.IsGlobal() {
+			result = append(result, webhook)
+
+			continue
+		}
+
+		if event.ServerID == nil {
+			continue
+		}
+
+		if webhook.ServerID != nil && *webhook.ServerID != *event.ServerID {
+			continue
+		}
+
+		if !serverExists {
+			if webhook.ServerID != nil {
+				result = append(result, webhook)
+			}
+
+			continue
+		}
+
+		userID := *webhook.UserID
+
+		allowed, checked := access[userID]
+		if !checked {
+			allowed, err = s.canAccessServer(ctx, userID, *event.ServerID)
+			if err != nil {
+				return nil, err
+			}
+
+			access[userID] = allowed
+		}
+
+		if allowed {
+			result = append(result, webhook)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *Service) serverExists(ctx context.Context, serverID *uint) (bool, error) {
+	if serverID == nil {
+		return false, nil
+	}
+
+	servers, err := s.serverRepo.Find(ctx, &filters.FindServer{
+		IDs:         []uint{*serverID},
+		WithDeleted: true,
+	}, nil, nil)
+	if err != nil {
+		return false, errors.WithMessage(err, "failed to find server")
+	}
+
+	return len(servers) > 0, nil
+}
+
+func (s *Service) canAccessServer(ctx context.Context, userID, serverID uint) (bool, error) {
+	isAdmin, err := s.rbac.Can(ctx, userID, []domain.AbilityName{domain.AbilityNameAdminRolesPermissions})
+	if err != nil {
+		return false, errors.WithMessage(err, "failed to check admin permissions")
+	}
+
+	if isAdmin {
+		return true, nil
+	}
+
+	servers, err := s.serverRepo.Find(ctx, &filters.FindServer{
+		IDs:         []uint{serverID},
+		UserIDs:     []uint{userID},
+		WithDeleted: true,
+	}, nil, nil)
+	if err != nil {
+		return false, errors.WithMessage(err, "failed to find user server")
+	}
+
+	return len(servers) > 0, nil
+}
+
+func newDelivery(webhook *domain.Webhook, event domain.Event) (*domain.WebhookDelivery, error) {
+	payload, err := newPayload(webhook, event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.WebhookDelivery{
+		WebhookID:     webhook.ID,
+		EventID:       event.ID.String(),
+		EventType:     event.Type,
+		Payload:       payload,
+		Status:        domain.WebhookDeliveryStatusPending,
+		NextAttemptAt: lo.ToPtr(event.OccurredAt),
+	}, nil
+}
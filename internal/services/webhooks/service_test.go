@@ -0,0 +1,206 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/events"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRBAC struct {
+	admins []uint
+}
+
+func (f *fakeRBAC) Can(_ context.Context, userID uint, _ []domain.AbilityName) (bool, error) {
+	return lo.Contains(f.admins, userID), nil
+}
+
+type testEnv struct {
+	service      *Service
+	webhookRepo  *inmemory.WebhookRepository
+	deliveryRepo *inmemory.WebhookDeliveryRepository
+	serverRepo   *inmemory.ServerRepository
+}
+
+func setup(t *testing.T) *testEnv {
+	t.Helper()
+
+	env := &testEnv{
+		webhookRepo:  inmemory.NewWebhookRepository(),
+		deliveryRepo: inmemory.NewWebhookDeliveryRepository(),
+		serverRepo:   inmemory.NewServerRepository(),
+	}
+
+	env.service = NewService(
+		env.webhookRepo,
+		env.deliveryRepo,
+		env.serverRepo,
+		&fakeRBAC{admins: []uint{1}},
+		NewSender(NewHTTPClient(time.Second, true)),
+	)
+
+	return env
+}
+
+func (env *testEnv) addWebhook(t *testing.T, webhook *domain.Webhook) *domain.Webhook {
+	t.Helper()
+
+	webhook.URL = "https://example.com/hook"
+	webhook.Secret = "secret"
+	require.NoError(t, env.webhookRepo.Save(context.Background(), webhook))
+
+	return webhook
+}
+
+func (env *testEnv) deliveredWebhookIDs(t *testing.T) []uint {
+	t.Helper()
+
+	deliveries, err := env.deliveryRepo.Find(context.Background(), nil, nil, nil)
+	require.NoError(t, err)
+
+	return lo.Map(deliveries, func(delivery domain.WebhookDelivery, _ int) uint {
+		return delivery.WebhookID
+	})
+}
+
+func TestService_HandleEvent_MatchesWebhooks(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+
+	server := &domain.Server{ID: 10, UUID: uuid.New(), Name: "Public", GameID: "cstrike", DSID: 2}
+	require.NoError(t, env.serverRepo.Save(ctx, server))
+	require.NoError(t, env.serverRepo.Save(ctx, &domain.Server{ID: 11, UUID: uuid.New()}))
+	env.serverRepo.AddUserServer(2, 10)
+
+	global := env.addWebhook(t, &domain.Webhook{Name: "global", Enabled: true})
+	admin := env.addWebhook(t, &domain.Webhook{Name: "admin", UserID: lo.ToPtr(uint(1)), Enabled: true})
+	owner := env.addWebhook(t, &domain.Webhook{Name: "owner", UserID: lo.ToPtr(uint(2)), Enabled: true})
+	ownerServer := env.addWebhook(t, &domain.Webhook{
+		Name:     "owner server",
+		UserID:   lo.ToPtr(uint(2)),
+		ServerID: lo.ToPtr(uint(10)),
+		Enabled:  true,
+	})
+	// Not matched: other server, another user, disabled and not subscribed
+	env.addWebhook(t, &domain.Webhook{
+		Name:     "other server",
+		UserID:   lo.ToPtr(uint(2)),
+		ServerID: lo.ToPtr(uint(11)),
+		Enabled:  true,
+	})
+	env.addWebhook(t, &domain.Webhook{Name: "stranger", UserID: lo.ToPtr(uint(3)), Enabled: true})
+	env.addWebhook(t, &domain.Webhook{Name: "disabled"})
+	env.addWebhook(t, &domain.Webhook{
+		Name:    "not subscribed",
+		Events:  domain.WebhookEvents{domain.EventTypeServerDeleted},
+		Enabled: true,
+	})
+
+	event := events.ServerCreated(server)
+	event.ID = uuid.MustParse("0195c1b4-4a5e-7a6f-8d2a-2f6c1c9e0b11")
+	event.OccurredAt = time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	require.NoError(t, env.service.HandleEvent(ctx, event))
+
+	assert.ElementsMatch(t, []uint{global.ID, admin.ID, owner.ID, ownerServer.ID}, env.deliveredWebhookIDs(t))
+
+	deliveries, err := env.deliveryRepo.Find(ctx, &filters.FindWebhookDelivery{
+		WebhookIDs: []uint{global.ID},
+	}, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+
+	delivery := deliveries[0]
+	assert.Equal(t, domain.WebhookDeliveryStatusPending, delivery.Status)
+	assert.Equal(t, "0195c1b4-4a5e-7a6f-8d2a-2f6c1c9e0b11", delivery.EventID)
+	assert.Equal(t, domain.EventTypeServerCreated, delivery.EventType)
+	assert.Equal(t, lo.ToPtr(event.OccurredAt), delivery.NextAttemptAt)
+	assert.JSONEq(t, `{
+		"id": "0195c1b4-4a5e-7a6f-8d2a-2f6c1c9e0b11",
+		"event": "server.created",
+		"occurred_at": "2025-03-01T10:00:00Z",
+		"webhook_id": 1,
+		"data": {
+			"server": {
+				"id": 10,
+				"uuid": "`+server.UUID.String()+`",
+				"name": "Public",
+				"game_id": "cstrike",
+				"node_id": 2
+			}
+		}
+	}`, delivery.Payload)
+}
+
+func TestService_HandleEvent_DeletedServer(t *testing.T) {
+	env := setup(t)
+
+	global := env.addWebhook(t, &domain.Webhook{Name: "global", Enabled: true})
+	env.addWebhook(t, &domain.Webhook{Name: "owner", UserID: lo.ToPtr(uint(2)), Enabled: true})
+	ownerServer := env.addWebhook(t, &domain.Webhook{
+		Name:     "owner server",
+		UserID:   lo.ToPtr(uint(2)),
+		ServerID: lo.ToPtr(uint(10)),
+		Enabled:  true,
+	})
+
+	require.NoError(t, env.service.HandleEvent(
+		context.Background(),
+		events.ServerDeleted(&domain.Server{ID: 10, UUID: uuid.New()}),
+	))
+
+	assert.ElementsMatch(t, []uint{global.ID, ownerServer.ID}, env.deliveredWebhookIDs(t))
+}
+
+func TestService_Test(t *testing.T) {
+	env := setup(t)
+
+	var received *http.Request
+	var body Payload
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		received = r
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	webhook := env.addWebhook(t, &domain.Webhook{Name: "test", Enabled: true})
+	webhook.URL = srv.URL
+
+	delivery, err := env.service.Test(context.Background(), webhook)
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.WebhookDeliveryStatusSucceeded, delivery.Status)
+	assert.Equal(t, lo.ToPtr(http.StatusNoContent), delivery.ResponseStatus)
+	assert.Equal(t, 1, delivery.Attempts)
+
+	require.NotNil(t, received)
+	assert.Equal(t, "ping", received.Header.Get(HeaderEvent))
+	assert.Equal(t, domain.EventTypePing, body.Event)
+	assert.Equal(t, webhook.ID, body.WebhookID)
+
+	t.Run("failed test is not retried", func(t *testing.T) {
+		srv.Config.Handler = http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+			rw.WriteHeader(http.StatusInternalServerError)
+		})
+
+		delivery, err := env.service.Test(context.Background(), webhook)
+		require.NoError(t, err)
+
+		assert.Equal(t, domain.WebhookDeliveryStatusFailed, delivery.Status)
+		assert.Equal(t, lo.ToPtr(http.StatusInternalServerError), delivery.ResponseStatus)
+		assert.Nil(t, delivery.NextAttemptAt)
+	})
+}
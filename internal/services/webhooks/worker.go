@@ -0,0 +1,158 @@
+package webhooks
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+const (
+	lockName = "webhook_deliveries"
+
+	// batchSize limits the number of deliveries sent in one round.
+	batchSize = 100
+
+	// maxErrorLength limits the stored error message of a failed attempt.
+	maxErrorLength = 1024
+)
+
+// Worker sends the pending webhook deliveries. Failed deliveries are retried with
+// an exponential backoff until the maximum number of attempts. Deliveries older than
+// the retention are deleted from the delivery log.
+//
+// Only one panel replica runs the worker at a time, it is guarded by a leader lock in the cache.
+type Worker struct {
+	webhookRepo  repositories.WebhookRepository
+	deliveryRepo repositories.WebhookDeliveryRepository
+	sender       *Sender
+	lock         *cache.Lock
+	interval     time.Duration
+	policy       domain.WebhookRetryPolicy
+	retention    time.Duration
+}
+
+func NewWorker(
+	webhookRepo repositories.WebhookRepository,
+	deliveryRepo repositories.WebhookDeliveryRepository,
+	sender *Sender,
+	c cache.Cache,
+	lockTTL time.Duration,
+	interval time.Duration,
+	policy domain.WebhookRetryPolicy,
+	retention time.Duration,
+) *Worker {
+	return &Worker{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		sender:       sender,
+		lock:         cache.NewLock(c, lockName, lockTTL),
+		interval:     interval,
+		policy:       policy,
+		retention:    retention,
+	}
+}
+
+// Run sends the deliveries periodically until the context is canceled.
+func (w *Worker) Run(ctx context.Context) {
+	cache.RunAsLeader(ctx, w.lock, w.interval, func(ctx context.Context) error {
+		return w.Process(ctx, time.Now())
+	})
+}
+
+// Process sends the deliveries due at the given time and deletes the expired ones.
+func (w *Worker) Process(ctx context.Context, now time.Time) error {
+	deliveries, err := w.deliveryRepo.Find(ctx, &filters.FindWebhookDelivery{
+		Statuses:          []domain.WebhookDeliveryStatus{domain.WebhookDeliveryStatusPending},
+		NextAttemptBefore: &now,
+	}, nil, &filters.Pagination{
+		Limit: batchSize,
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed to find pending webhook deliveries")
+	}
+
+	if len(deliveries) > 0 {
+		if err = w.sendAll(ctx, deliveries, now); err != nil {
+			return err
+		}
+	}
+
+	if w.retention > 0 {
+		if err = w.deliveryRepo.DeleteBefore(ctx, now.Add(-w.retention)); err != nil {
+			return errors.WithMessage(err, "failed to delete expired webhook deliveries")
+		}
+	}
+
+	return nil
+}
+
+func (w *Worker) sendAll(ctx context.Context, deliveries []domain.WebhookDelivery, now time.Time) error {
+	webhooks, err := w.webhookRepo.Find(ctx, &filters.FindWebhook{
+		IDs: lo.Uniq(lo.Map(deliveries, func(delivery domain.WebhookDelivery, _ int) uint {
+			return delivery.WebhookID
+		})),
+	}, nil, nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed to find webhooks")
+	}
+
+	webhooksByID := lo.KeyBy(webhooks, func(webhook domain.Webhook) uint {
+		return webhook.ID
+	})
+
+	for i := range deliveries {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		delivery := &deliveries[i]
+
+		webhook, ok := webhooksByID[delivery.WebhookID]
+		switch {
+		case !ok:
+			delivery.Cancel(now, "webhook not found")
+		case !webhook.Enabled:
+			delivery.Cancel(now, "webhook is disabled")
+		default:
+			w.send(ctx, &webhook, delivery, now)
+		}
+
+		if err = w.deliveryRepo.Save(ctx, delivery); err != nil {
+			return errors.WithMessage(err, "failed to save webhook delivery")
+		}
+	}
+
+	return nil
+}
+
+func (w *Worker) send(
+	ctx context.Context,
+	webhook *domain.Webhook,
+	delivery *domain.WebhookDelivery,
+	now time.Time,
+) {
+	status, err := w.sender.Send(ctx, webhook, delivery)
+	if err == nil {
+		delivery.Succeed(now, lo.FromPtr(status))
+
+		return
+	}
+
+	delivery.Fail(w.policy, now, status, lo.Substring(err.Error(), 0, maxErrorLength))
+
+	slog.DebugContext(
+		ctx,
+		"Webhook delivery failed",
+		slog.Uint64("webhook_id", uint64(webhook.ID)),
+		slog.Uint64("delivery_id", uint64(delivery.ID)),
+		slog.Int("attempts", delivery.Attempts),
+		slog.String("error", err.Error()),
+	)
+}
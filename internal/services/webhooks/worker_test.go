@@ -0,0 +1,189 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/cache"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testRetryPolicy = domain.WebhookRetryPolicy{
+	BaseDelay:   time.Minute,
+	MaxDelay:    time.Hour,
+	MaxAttempts: 3,
+}
+
+func newTestWorker(env *testEnv, retention time.Duration) *Worker {
+	return NewWorker(
+		env.webhookRepo,
+		env.deliveryRepo,
+		NewSender(NewHTTPClient(time.Second, true)),
+		cache.NewInMemory(),
+		time.Minute,
+		time.Minute,
+		testRetryPolicy,
+		retention,
+	)
+}
+
+func (env *testEnv) addDelivery(t *testing.T, webhookID uint, nextAttemptAt time.Time) *domain.WebhookDelivery {
+	t.Helper()
+
+	delivery := &domain.WebhookDelivery{
+		WebhookID:     webhookID,
+		EventID:       "0195c1b4-4a5e-7a6f-8d2a-2f6c1c9e0b11",
+		EventType:     domain.EventTypeServerOnline,
+		Payload:       "{}",
+		Status:        domain.WebhookDeliveryStatusPending,
+		NextAttemptAt: &nextAttemptAt,
+	}
+	require.NoError(t, env.deliveryRepo.Save(context.Background(), delivery))
+
+	return delivery
+}
+
+func (env *testEnv) delivery(t *testing.T, id uint) domain.WebhookDelivery {
+	t.Helper()
+
+	deliveries, err := env.deliveryRepo.Find(context.Background(), &filters.FindWebhookDelivery{
+		IDs: []uint{id},
+	}, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+
+	return deliveries[0]
+}
+
+func TestWorker_Process_RetriesWithBackoff(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		if requests.Add(1) < 3 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	webhook := env.addWebhook(t, &domain.Webhook{Name: "hook", Enabled: true})
+	webhook.URL = srv.URL
+	require.NoError(t, env.webhookRepo.Save(ctx, webhook))
+
+	delivery := env.addDelivery(t, webhook.ID, now)
+	worker := newTestWorker(env, 0)
+
+	require.NoError(t, worker.Process(ctx, now))
+
+	got := env.delivery(t, delivery.ID)
+	assert.Equal(t, domain.WebhookDeliveryStatusPending, got.Status)
+	assert.Equal(t, 1, got.Attempts)
+	assert.Equal(t, lo.ToPtr(http.StatusServiceUnavailable), got.ResponseStatus)
+	assert.Equal(t, lo.ToPtr(now.Add(time.Minute)), got.NextAttemptAt)
+
+	// Not due yet
+	require.NoError(t, worker.Process(ctx, now.Add(30*time.Second)))
+	assert.Equal(t, int32(1), requests.Load())
+
+	require.NoError(t, worker.Process(ctx, now.Add(time.Minute)))
+
+	got = env.delivery(t, delivery.ID)
+	assert.Equal(t, 2, got.Attempts)
+	assert.Equal(t, lo.ToPtr(now.Add(3*time.Minute)), got.NextAttemptAt)
+
+	require.NoError(t, worker.Process(ctx, now.Add(3*time.Minute)))
+
+	got = env.delivery(t, delivery.ID)
+	assert.Equal(t, domain.WebhookDeliveryStatusSucceeded, got.Status)
+	assert.Equal(t, 3, got.Attempts)
+	assert.Equal(t, lo.ToPtr(http.StatusOK), got.ResponseStatus)
+	assert.Nil(t, got.NextAttemptAt)
+	assert.Nil(t, got.Error)
+}
+
+func TestWorker_Process_FailsAfterMaxAttempts(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	webhook := env.addWebhook(t, &domain.Webhook{Name: "hook", Enabled: true})
+	webhook.URL = srv.URL
+	require.NoError(t, env.webhookRepo.Save(ctx, webhook))
+
+	delivery := env.addDelivery(t, webhook.ID, now)
+	worker := newTestWorker(env, 0)
+
+	for _, at := range []time.Duration{0, time.Minute, 3 * time.Minute} {
+		require.NoError(t, worker.Process(ctx, now.Add(at)))
+	}
+
+	got := env.delivery(t, delivery.ID)
+	assert.Equal(t, domain.WebhookDeliveryStatusFailed, got.Status)
+	assert.Equal(t, 3, got.Attempts)
+	assert.Nil(t, got.NextAttemptAt)
+	assert.Equal(t, lo.ToPtr("unexpected response status 500"), got.Error)
+}
+
+func TestWorker_Process_CancelsUnavailableWebhooks(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	disabled := env.addWebhook(t, &domain.Webhook{Name: "disabled"})
+
+	disabledDelivery := env.addDelivery(t, disabled.ID, now)
+	missingDelivery := env.addDelivery(t, 100, now)
+
+	require.NoError(t, newTestWorker(env, 0).Process(ctx, now))
+
+	got := env.delivery(t, disabledDelivery.ID)
+	assert.Equal(t, domain.WebhookDeliveryStatusFailed, got.Status)
+	assert.Zero(t, got.Attempts)
+	assert.Equal(t, lo.ToPtr("webhook is disabled"), got.Error)
+
+	got = env.delivery(t, missingDelivery.ID)
+	assert.Equal(t, domain.WebhookDeliveryStatusFailed, got.Status)
+	assert.Equal(t, lo.ToPtr("webhook not found"), got.Error)
+}
+
+func TestWorker_Process_DeletesExpiredDeliveries(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	expired := &domain.WebhookDelivery{
+		WebhookID: 1,
+		Status:    domain.WebhookDeliveryStatusSucceeded,
+		CreatedAt: lo.ToPtr(now.Add(-48 * time.Hour)),
+	}
+	require.NoError(t, env.deliveryRepo.Save(ctx, expired))
+
+	recent := &domain.WebhookDelivery{WebhookID: 1, Status: domain.WebhookDeliveryStatusSucceeded}
+	require.NoError(t, env.deliveryRepo.Save(ctx, recent))
+
+	require.NoError(t, newTestWorker(env, 24*time.Hour).Process(ctx, now))
+
+	deliveries, err := env.deliveryRepo.Find(ctx, nil, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, recent.ID, deliveries[0].ID)
+}
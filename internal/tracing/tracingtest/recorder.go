@@ -0,0 +1,39 @@
+// Package tracingtest records the spans of the tests.
+package tracingtest
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// NewRecorder installs the global tracer provider recording the ended spans.
+// The previous provider is restored when the test ends, so the tests using it can't run in parallel.
+func NewRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	previous := otel.GetTracerProvider()
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+	})
+
+	return recorder
+}
+
+// SpanNames returns the names of the ended spans in the order they have ended.
+func SpanNames(recorder *tracetest.SpanRecorder) []string {
+	spans := recorder.Ended()
+	names := make([]string, 0, len(spans))
+
+	for _, span := range spans {
+		names = append(names, span.Name())
+	}
+
+	return names
+}
@@ -39,6 +39,20 @@ type migration struct {
 // List of SQLite-specific migrations in Go.
 var sqliteMigrationsList = []migration{
 	{version: 1, upFN: sqlite.Up001, downFN: sqlite.Down001},
+	{version: 2, upFN: sqlite.Up002, downFN: sqlite.Down002},
+	{version: 3, upFN: sqlite.Up003, downFN: sqlite.Down003},
+	{version: 4, upFN: sqlite.Up004, downFN: sqlite.Down004},
+	{version: 5, upFN: sqlite.Up005, downFN: sqlite.Down005},
+	{version: 6, upFN: sqlite.Up006, downFN: sqlite.Down006},
+	{version: 7, upFN: sqlite.Up007, downFN: sqlite.Down007},
+	{version: 8, upFN: sqlite.Up008, downFN: sqlite.Down008},
+	{version: 9, upFN: sqlite.Up009, downFN: sqlite.Down009},
+	{version: 10, upFN: sqlite.Up010, downFN: sqlite.Down010},
+	{version: 11, upFN: sqlite.Up011, downFN: sqlite.Down011},
+	{version: 12, upFN: sqlite.Up012, downFN: sqlite.Down012},
+	{version: 13, upFN: sqlite.Up013, downFN: sqlite.Down013},
+	{version: 14, upFN: sqlite.Up014, downFN: sqlite.Down014},
+	{version: 15, upFN: sqlite.Up015, downFN: sqlite.Down015},
 }
 
 // SqliteMigrations returns the list of SQLite-specific migrations in Go.
@@ -78,6 +92,20 @@ func PostgresMigrations(_ context.Context, _ container) (goose.Migrations, error
 // List of MySQL-specific migrations in Go.
 var mysqlMigrationsList = []migration{
 	{version: 1, upFN: mysql.Up001, downFN: mysql.Down001},
+	{version: 2, upFN: mysql.Up002, downFN: mysql.Down002},
+	{version: 3, upFN: mysql.Up003, downFN: mysql.Down003},
+	{version: 4, upFN: mysql.Up004, downFN: mysql.Down004},
+	{version: 5, upFN: mysql.Up005, downFN: mysql.Down005},
+	{version: 6, upFN: mysql.Up006, downFN: mysql.Down006},
+	{version: 7, upFN: mysql.Up007, downFN: mysql.Down007},
+	{version: 8, upFN: mysql.Up008, downFN: mysql.Down008},
+	{version: 9, upFN: mysql.Up009, downFN: mysql.Down009},
+	{version: 10, upFN: mysql.Up010, downFN: mysql.Down010},
+	{version: 11, upFN: mysql.Up011, downFN: mysql.Down011},
+	{version: 12, upFN: mysql.Up012, downFN: mysql.Down012},
+	{version: 13, upFN: mysql.Up013, downFN: mysql.Down013},
+	{version: 14, upFN: mysql.Up014, downFN: mysql.Down014},
+	{version: 15, upFN: mysql.Up015, downFN: mysql.Down015},
 }
 
 func MySQLMigrations(_ context.Context, _ container) (goose.Migrations, error) {
@@ -117,11 +145,102 @@ var driverToFSDirMap = map[string]string{
 	databaseDriverPGX:      "postgres",
 }
 
+// ErrNotSupported is returned for the in-memory database, it has no schema to migrate.
+var ErrNotSupported = errors.New("migrations are not supported for the database driver")
+
 func Run(ctx context.Context, c container) error {
 	if c.Config().DatabaseDriver == databaseDriverInMemory {
 		return nil
 	}
 
+	migrator, err := newProvider(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	result, err := migrator.Up(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to run migrations")
+	}
+
+	for i := range result {
+		fields := make([]any, 0, 3)
+
+		if result[i].Source != nil {
+			fields = append(fields, slog.String("path", result[i].Source.Path))
+			fields = append(fields, slog.Int64("version", result[i].Source.Version))
+		}
+
+		fields = append(fields, slog.String("result", result[i].String()))
+
+		slog.InfoContext(
+			ctx,
+			"Applied migration",
+			fields...,
+		)
+	}
+
+	return nil
+}
+
+// Down rolls back the last applied migration.
+func Down(ctx context.Context, c container) (*goose.MigrationResult, error) {
+	if c.Config().DatabaseDriver == databaseDriverInMemory {
+		return nil, ErrNotSupported
+	}
+
+	migrator, err := newProvider(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := migrator.Down(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to roll back migration")
+	}
+
+	return result, nil
+}
+
+// DownTo rolls back the migrations applied after the version.
+func DownTo(ctx context.Context, c container, version int64) ([]*goose.MigrationResult, error) {
+	if c.Config().DatabaseDriver == databaseDriverInMemory {
+		return nil, ErrNotSupported
+	}
+
+	migrator, err := newProvider(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := migrator.DownTo(ctx, version)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to roll back migrations")
+	}
+
+	return result, nil
+}
+
+// Status returns the state of all the migrations.
+func Status(ctx context.Context, c container) ([]*goose.MigrationStatus, error) {
+	if c.Config().DatabaseDriver == databaseDriverInMemory {
+		return nil, ErrNotSupported
+	}
+
+	migrator, err := newProvider(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get migrations status")
+	}
+
+	return status, nil
+}
+
+func newProvider(ctx context.Context, c container) (*goose.Provider, error) {
 	migratorOptions := []goose.ProviderOption{
 		goose.WithSlog(slog.Default()),
 		goose.WithAllowOutofOrder(true),
@@ -131,21 +250,21 @@ func Run(ctx context.Context, c container) error {
 	case databaseDriverMySQL:
 		mg, err := MySQLMigrations(ctx, c)
 		if err != nil {
-			return errors.Wrap(err, "failed to get mysql migrations")
+			return nil, errors.Wrap(err, "failed to get mysql migrations")
 		}
 
 		migratorOptions = append(migratorOptions, goose.WithGoMigrations(mg...))
 	case databaseDriverPostgres, databaseDriverPGX:
 		mg, err := PostgresMigrations(ctx, c)
 		if err != nil {
-			return errors.Wrap(err, "failed to get postgres migrations")
+			return nil, errors.Wrap(err, "failed to get postgres migrations")
 		}
 
 		migratorOptions = append(migratorOptions, goose.WithGoMigrations(mg...))
 	case databaseDriverSQLite:
 		mg, err := SqliteMigrations(ctx, c)
 		if err != nil {
-			return errors.Wrap(err, "failed to get sqlite migrations")
+			return nil, errors.Wrap(err, "failed to get sqlite migrations")
 		}
 
 		migratorOptions = append(migratorOptions, goose.WithGoMigrations(mg...))
@@ -153,17 +272,17 @@ func Run(ctx context.Context, c container) error {
 
 	dialect, ok := driverToDialectMap[c.Config().DatabaseDriver]
 	if !ok {
-		return errors.Errorf("unsupported database driver: %s", c.Config().DatabaseDriver)
+		return nil, errors.Errorf("unsupported database driver: %s", c.Config().DatabaseDriver)
 	}
 
 	dir, ok := driverToFSDirMap[c.Config().DatabaseDriver]
 	if !ok {
-		return errors.Errorf("unsupported database driver for migrations: %s", c.Config().DatabaseDriver)
+		return nil, errors.Errorf("unsupported database driver for migrations: %s", c.Config().DatabaseDriver)
 	}
 
 	migrationsSubFS, err := fs.Sub(GetFS(), dir)
 	if err != nil {
-		return errors.Wrap(err, "failed to create sub filesystem")
+		return nil, errors.Wrap(err, "failed to create sub filesystem")
 	}
 
 	migrator, err := goose.NewProvider(
@@ -173,30 +292,8 @@ func Run(ctx context.Context, c container) error {
 		migratorOptions...,
 	)
 	if err != nil {
-		return errors.Wrap(err, "failed to create migrator")
-	}
-
-	result, err := migrator.Up(ctx)
-	if err != nil {
-		return errors.Wrap(err, "failed to run migrations")
+		return nil, errors.Wrap(err, "failed to create migrator")
 	}
 
-	for i := range result {
-		fields := make([]any, 0, 3)
-
-		if result[i].Source != nil {
-			fields = append(fields, slog.String("path", result[i].Source.Path))
-			fields = append(fields, slog.Int64("version", result[i].Source.Version))
-		}
-
-		fields = append(fields, slog.String("result", result[i].String()))
-
-		slog.InfoContext(
-			ctx,
-			"Applied migration",
-			fields...,
-		)
-	}
-
-	return nil
+	return migrator, nil
 }
@@ -0,0 +1,28 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+)
+
+func Up002(ctx context.Context, tx *sql.Tx) error {
+	queries := []string{
+		`ALTER TABLE servers_tasks
+			ADD COLUMN cron_expression varchar(128) DEFAULT NULL AFTER payload,
+			ADD COLUMN timezone varchar(64) DEFAULT NULL AFTER cron_expression`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func Down002(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `ALTER TABLE servers_tasks DROP COLUMN timezone, DROP COLUMN cron_expression`)
+
+	return err
+}
@@ -0,0 +1,29 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+)
+
+func Up003(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS servers_backups (
+		id bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+		server_id int(10) unsigned NOT NULL,
+		status varchar(32) NOT NULL,
+		path varchar(512) NOT NULL,
+		size bigint(20) NOT NULL DEFAULT 0,
+		error text DEFAULT NULL,
+		created_at timestamp NULL DEFAULT NULL,
+		updated_at timestamp NULL DEFAULT NULL,
+		PRIMARY KEY (id),
+		KEY servers_backups_server_id_index (server_id)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4`)
+
+	return err
+}
+
+func Down003(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `DROP TABLE IF EXISTS servers_backups`)
+
+	return err
+}
@@ -0,0 +1,41 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+)
+
+func Up004(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS server_templates (
+		id int(10) unsigned NOT NULL AUTO_INCREMENT,
+		name varchar(128) NOT NULL,
+		description text DEFAULT NULL,
+		game_id varchar(16) NOT NULL,
+		game_mod_id int(10) unsigned NOT NULL,
+		server_port smallint(5) unsigned NOT NULL,
+		query_port smallint(5) unsigned DEFAULT NULL,
+		rcon_port smallint(5) unsigned DEFAULT NULL,
+		su_user varchar(255) DEFAULT NULL,
+		cpu_limit int(11) DEFAULT NULL,
+		ram_limit int(11) DEFAULT NULL,
+		net_limit int(11) DEFAULT NULL,
+		start_command text DEFAULT NULL,
+		stop_command text DEFAULT NULL,
+		force_stop_command text DEFAULT NULL,
+		restart_command text DEFAULT NULL,
+		vars text DEFAULT NULL,
+		settings text DEFAULT NULL,
+		created_at timestamp NULL DEFAULT NULL,
+		updated_at timestamp NULL DEFAULT NULL,
+		PRIMARY KEY (id),
+		KEY server_templates_game_id_index (game_id)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4`)
+
+	return err
+}
+
+func Down004(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `DROP TABLE IF EXISTS server_templates`)
+
+	return err
+}
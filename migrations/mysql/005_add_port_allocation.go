@@ -0,0 +1,40 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+)
+
+func Up005(ctx context.Context, tx *sql.Tx) error {
+	queries := []string{
+		`ALTER TABLE dedicated_servers
+			ADD COLUMN port_range_start int unsigned DEFAULT NULL AFTER script_delete,
+			ADD COLUMN port_range_end int unsigned DEFAULT NULL AFTER port_range_start`,
+		`ALTER TABLE games
+			ADD COLUMN query_port_offset int NOT NULL DEFAULT 0 AFTER enabled,
+			ADD COLUMN rcon_port_offset int NOT NULL DEFAULT 0 AFTER query_port_offset`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func Down005(ctx context.Context, tx *sql.Tx) error {
+	queries := []string{
+		`ALTER TABLE games DROP COLUMN rcon_port_offset, DROP COLUMN query_port_offset`,
+		`ALTER TABLE dedicated_servers DROP COLUMN port_range_end, DROP COLUMN port_range_start`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+)
+
+func Up006(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS servers_resource_usage (
+		server_id int(10) unsigned NOT NULL,
+		cpu double DEFAULT NULL,
+		ram int(11) DEFAULT NULL,
+		net int(11) DEFAULT NULL,
+		reported_at timestamp NOT NULL,
+		PRIMARY KEY (server_id)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4`)
+
+	return err
+}
+
+func Down006(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `DROP TABLE IF EXISTS servers_resource_usage`)
+
+	return err
+}
@@ -0,0 +1,30 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+)
+
+func Up007(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS metrics (
+		subject_type varchar(16) NOT NULL,
+		subject_id int(10) unsigned NOT NULL,
+		resolution varchar(8) NOT NULL,
+		recorded_at datetime NOT NULL,
+		cpu double DEFAULT NULL,
+		ram double DEFAULT NULL,
+		disk double DEFAULT NULL,
+		players double DEFAULT NULL,
+		samples int(10) unsigned NOT NULL DEFAULT 1,
+		PRIMARY KEY (subject_type, subject_id, resolution, recorded_at),
+		KEY metrics_resolution_recorded_at_index (resolution, recorded_at)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4`)
+
+	return err
+}
+
+func Down007(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `DROP TABLE IF EXISTS metrics`)
+
+	return err
+}
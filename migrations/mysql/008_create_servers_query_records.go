@@ -0,0 +1,27 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+)
+
+func Up008(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS servers_query_records (
+		server_id int(10) unsigned NOT NULL,
+		queried_at datetime NOT NULL,
+		online tinyint(1) NOT NULL DEFAULT 0,
+		players_num int(10) unsigned NOT NULL DEFAULT 0,
+		max_players_num int(10) unsigned NOT NULL DEFAULT 0,
+		map varchar(128) NOT NULL DEFAULT '',
+		PRIMARY KEY (server_id, queried_at),
+		KEY servers_query_records_queried_at_index (queried_at)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4`)
+
+	return err
+}
+
+func Down008(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `DROP TABLE IF EXISTS servers_query_records`)
+
+	return err
+}
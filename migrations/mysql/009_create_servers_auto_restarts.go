@@ -0,0 +1,30 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+)
+
+func Up009(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS servers_auto_restarts (
+		server_id int(10) unsigned NOT NULL,
+		crashed_at timestamp NULL DEFAULT NULL,
+		attempts int(10) unsigned NOT NULL DEFAULT 0,
+		last_attempt_at timestamp NULL DEFAULT NULL,
+		last_task_id int(10) unsigned DEFAULT NULL,
+		crash_loop tinyint(1) NOT NULL DEFAULT 0,
+		crash_loop_at timestamp NULL DEFAULT NULL,
+		crash_loops int(10) unsigned NOT NULL DEFAULT 0,
+		updated_at timestamp NOT NULL,
+		PRIMARY KEY (server_id),
+		KEY servers_auto_restarts_crash_loop_index (crash_loop)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4`)
+
+	return err
+}
+
+func Down009(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `DROP TABLE IF EXISTS servers_auto_restarts`)
+
+	return err
+}
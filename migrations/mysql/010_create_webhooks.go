@@ -0,0 +1,67 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+)
+
+func Up010(ctx context.Context, tx *sql.Tx) error {
+	queries := []string{
+		`CREATE TABLE IF NOT EXISTS webhooks (
+			id int(10) unsigned NOT NULL AUTO_INCREMENT,
+			user_id int(10) unsigned DEFAULT NULL,
+			server_id int(10) unsigned DEFAULT NULL,
+			name varchar(128) NOT NULL,
+			url varchar(2048) NOT NULL,
+			secret varchar(255) NOT NULL,
+			events text DEFAULT NULL,
+			enabled tinyint(1) NOT NULL DEFAULT 1,
+			created_at timestamp NULL DEFAULT NULL,
+			updated_at timestamp NULL DEFAULT NULL,
+			PRIMARY KEY (id),
+			KEY webhooks_user_id_index (user_id),
+			KEY webhooks_server_id_index (server_id)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4`,
+		`CREATE TABLE IF NOT EXISTS webhook_deliveries (
+			id int(10) unsigned NOT NULL AUTO_INCREMENT,
+			webhook_id int(10) unsigned NOT NULL,
+			event_id char(36) NOT NULL,
+			event_type varchar(64) NOT NULL,
+			payload mediumtext NOT NULL,
+			status varchar(16) NOT NULL,
+			attempts int(10) unsigned NOT NULL DEFAULT 0,
+			next_attempt_at timestamp NULL DEFAULT NULL,
+			response_status smallint(5) unsigned DEFAULT NULL,
+			error text DEFAULT NULL,
+			created_at timestamp NULL DEFAULT NULL,
+			updated_at timestamp NULL DEFAULT NULL,
+			PRIMARY KEY (id),
+			KEY webhook_deliveries_webhook_id_index (webhook_id),
+			KEY webhook_deliveries_status_next_attempt_at_index (status, next_attempt_at),
+			KEY webhook_deliveries_created_at_index (created_at)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func Down010(ctx context.Context, tx *sql.Tx) error {
+	queries := []string{
+		`DROP TABLE IF EXISTS webhook_deliveries`,
+		`DROP TABLE IF EXISTS webhooks`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
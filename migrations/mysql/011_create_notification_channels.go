@@ -0,0 +1,33 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+)
+
+func Up011(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS notification_channels (
+		id int(10) unsigned NOT NULL AUTO_INCREMENT,
+		user_id int(10) unsigned NOT NULL,
+		server_id int(10) unsigned DEFAULT NULL,
+		name varchar(128) NOT NULL,
+		type varchar(16) NOT NULL,
+		target varchar(2048) NOT NULL,
+		language varchar(8) NOT NULL,
+		events text DEFAULT NULL,
+		enabled tinyint(1) NOT NULL DEFAULT 1,
+		created_at timestamp NULL DEFAULT NULL,
+		updated_at timestamp NULL DEFAULT NULL,
+		PRIMARY KEY (id),
+		KEY notification_channels_user_id_index (user_id),
+		KEY notification_channels_server_id_index (server_id)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4`)
+
+	return err
+}
+
+func Down011(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `DROP TABLE IF EXISTS notification_channels`)
+
+	return err
+}
@@ -0,0 +1,27 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+)
+
+func Up012(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS user_two_factors (
+		user_id int(10) unsigned NOT NULL,
+		secret varchar(128) NOT NULL,
+		confirmed_at timestamp NULL DEFAULT NULL,
+		recovery_codes text DEFAULT NULL,
+		last_used_step bigint(20) NOT NULL DEFAULT 0,
+		created_at timestamp NULL DEFAULT NULL,
+		updated_at timestamp NULL DEFAULT NULL,
+		PRIMARY KEY (user_id)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4`)
+
+	return err
+}
+
+func Down012(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `DROP TABLE IF EXISTS user_two_factors`)
+
+	return err
+}
@@ -0,0 +1,27 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+)
+
+func Up013(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS user_identities (
+		provider varchar(64) NOT NULL,
+		subject varchar(191) NOT NULL,
+		user_id int(10) unsigned NOT NULL,
+		email varchar(191) NOT NULL DEFAULT '',
+		created_at timestamp NULL DEFAULT NULL,
+		updated_at timestamp NULL DEFAULT NULL,
+		PRIMARY KEY (provider, subject),
+		KEY user_identities_user_id_index (user_id)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4`)
+
+	return err
+}
+
+func Down013(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `DROP TABLE IF EXISTS user_identities`)
+
+	return err
+}
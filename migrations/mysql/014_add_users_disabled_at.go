@@ -0,0 +1,21 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+)
+
+func Up014(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(
+		ctx,
+		`ALTER TABLE users ADD COLUMN disabled_at timestamp NULL DEFAULT NULL AFTER updated_at`,
+	)
+
+	return err
+}
+
+func Down014(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `ALTER TABLE users DROP COLUMN disabled_at`)
+
+	return err
+}
@@ -0,0 +1,36 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+)
+
+func Up015(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS audit_logs (
+		id bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+		user_id int(10) unsigned DEFAULT NULL,
+		token_id int(10) unsigned DEFAULT NULL,
+		ip varchar(45) NOT NULL DEFAULT '',
+		user_agent varchar(512) NOT NULL DEFAULT '',
+		action varchar(255) NOT NULL,
+		entity_type varchar(255) DEFAULT NULL,
+		entity_id varchar(128) DEFAULT NULL,
+		changes mediumtext DEFAULT NULL,
+		request mediumtext DEFAULT NULL,
+		result varchar(16) NOT NULL,
+		status_code smallint(5) unsigned NOT NULL,
+		created_at timestamp NULL DEFAULT NULL,
+		PRIMARY KEY (id),
+		KEY audit_logs_created_at_index (created_at),
+		KEY audit_logs_user_id_index (user_id),
+		KEY audit_logs_entity_type_entity_id_index (entity_type, entity_id)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4`)
+
+	return err
+}
+
+func Down015(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `DROP TABLE IF EXISTS audit_logs`)
+
+	return err
+}
@@ -0,0 +1,9 @@
+-- +goose Up
+
+ALTER TABLE servers_tasks ADD COLUMN cron_expression VARCHAR(128) DEFAULT NULL;
+ALTER TABLE servers_tasks ADD COLUMN timezone VARCHAR(64) DEFAULT NULL;
+
+-- +goose Down
+
+ALTER TABLE servers_tasks DROP COLUMN timezone;
+ALTER TABLE servers_tasks DROP COLUMN cron_expression;
@@ -0,0 +1,17 @@
+-- +goose Up
+
+CREATE TABLE servers_backups (
+    id BIGSERIAL PRIMARY KEY,
+    server_id INTEGER NOT NULL,
+    status VARCHAR(32) NOT NULL,
+    path VARCHAR(512) NOT NULL,
+    size BIGINT NOT NULL DEFAULT 0,
+    error TEXT DEFAULT NULL,
+    created_at TIMESTAMPTZ DEFAULT NULL,
+    updated_at TIMESTAMPTZ DEFAULT NULL
+);
+CREATE INDEX servers_backups_server_id_index ON servers_backups (server_id);
+
+-- +goose Down
+
+DROP TABLE servers_backups;
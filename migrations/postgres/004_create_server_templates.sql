@@ -0,0 +1,29 @@
+-- +goose Up
+
+CREATE TABLE server_templates (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(128) NOT NULL,
+    description TEXT DEFAULT NULL,
+    game_id VARCHAR(16) NOT NULL,
+    game_mod_id INTEGER NOT NULL,
+    server_port INTEGER NOT NULL CHECK (server_port BETWEEN 1 AND 65535),
+    query_port INTEGER DEFAULT NULL CHECK (query_port BETWEEN 1 AND 65535),
+    rcon_port INTEGER DEFAULT NULL CHECK (rcon_port BETWEEN 1 AND 65535),
+    su_user VARCHAR(255) DEFAULT NULL,
+    cpu_limit INTEGER DEFAULT NULL,
+    ram_limit INTEGER DEFAULT NULL,
+    net_limit INTEGER DEFAULT NULL,
+    start_command TEXT DEFAULT NULL,
+    stop_command TEXT DEFAULT NULL,
+    force_stop_command TEXT DEFAULT NULL,
+    restart_command TEXT DEFAULT NULL,
+    vars TEXT DEFAULT NULL,
+    settings TEXT DEFAULT NULL,
+    created_at TIMESTAMPTZ DEFAULT NULL,
+    updated_at TIMESTAMPTZ DEFAULT NULL
+);
+CREATE INDEX server_templates_game_id_index ON server_templates (game_id);
+
+-- +goose Down
+
+DROP TABLE server_templates;
@@ -0,0 +1,13 @@
+-- +goose Up
+
+ALTER TABLE dedicated_servers ADD COLUMN port_range_start INTEGER DEFAULT NULL;
+ALTER TABLE dedicated_servers ADD COLUMN port_range_end INTEGER DEFAULT NULL;
+ALTER TABLE games ADD COLUMN query_port_offset INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE games ADD COLUMN rcon_port_offset INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+
+ALTER TABLE games DROP COLUMN rcon_port_offset;
+ALTER TABLE games DROP COLUMN query_port_offset;
+ALTER TABLE dedicated_servers DROP COLUMN port_range_end;
+ALTER TABLE dedicated_servers DROP COLUMN port_range_start;
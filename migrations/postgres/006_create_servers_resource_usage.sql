@@ -0,0 +1,13 @@
+-- +goose Up
+
+CREATE TABLE servers_resource_usage (
+    server_id INTEGER PRIMARY KEY,
+    cpu DOUBLE PRECISION DEFAULT NULL,
+    ram INTEGER DEFAULT NULL,
+    net INTEGER DEFAULT NULL,
+    reported_at TIMESTAMPTZ NOT NULL
+);
+
+-- +goose Down
+
+DROP TABLE servers_resource_usage;
@@ -0,0 +1,19 @@
+-- +goose Up
+
+CREATE TABLE metrics (
+    subject_type VARCHAR(16) NOT NULL,
+    subject_id INTEGER NOT NULL,
+    resolution VARCHAR(8) NOT NULL,
+    recorded_at TIMESTAMPTZ NOT NULL,
+    cpu DOUBLE PRECISION DEFAULT NULL,
+    ram DOUBLE PRECISION DEFAULT NULL,
+    disk DOUBLE PRECISION DEFAULT NULL,
+    players DOUBLE PRECISION DEFAULT NULL,
+    samples INTEGER NOT NULL DEFAULT 1,
+    PRIMARY KEY (subject_type, subject_id, resolution, recorded_at)
+);
+CREATE INDEX metrics_resolution_recorded_at_index ON metrics (resolution, recorded_at);
+
+-- +goose Down
+
+DROP TABLE metrics;
@@ -0,0 +1,16 @@
+-- +goose Up
+
+CREATE TABLE servers_query_records (
+    server_id INTEGER NOT NULL,
+    queried_at TIMESTAMPTZ NOT NULL,
+    online BOOLEAN NOT NULL DEFAULT FALSE,
+    players_num INTEGER NOT NULL DEFAULT 0,
+    max_players_num INTEGER NOT NULL DEFAULT 0,
+    map VARCHAR(128) NOT NULL DEFAULT '',
+    PRIMARY KEY (server_id, queried_at)
+);
+CREATE INDEX servers_query_records_queried_at_index ON servers_query_records (queried_at);
+
+-- +goose Down
+
+DROP TABLE servers_query_records;
@@ -0,0 +1,18 @@
+-- +goose Up
+
+CREATE TABLE servers_auto_restarts (
+    server_id INTEGER PRIMARY KEY,
+    crashed_at TIMESTAMPTZ DEFAULT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_attempt_at TIMESTAMPTZ DEFAULT NULL,
+    last_task_id INTEGER DEFAULT NULL,
+    crash_loop BOOLEAN NOT NULL DEFAULT FALSE,
+    crash_loop_at TIMESTAMPTZ DEFAULT NULL,
+    crash_loops INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX servers_auto_restarts_crash_loop_index ON servers_auto_restarts (crash_loop);
+
+-- +goose Down
+
+DROP TABLE servers_auto_restarts;
@@ -0,0 +1,39 @@
+-- +goose Up
+
+CREATE TABLE webhooks (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER DEFAULT NULL,
+    server_id INTEGER DEFAULT NULL,
+    name VARCHAR(128) NOT NULL,
+    url VARCHAR(2048) NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    events TEXT DEFAULT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ DEFAULT NULL,
+    updated_at TIMESTAMPTZ DEFAULT NULL
+);
+CREATE INDEX webhooks_user_id_index ON webhooks (user_id);
+CREATE INDEX webhooks_server_id_index ON webhooks (server_id);
+
+CREATE TABLE webhook_deliveries (
+    id SERIAL PRIMARY KEY,
+    webhook_id INTEGER NOT NULL,
+    event_id VARCHAR(36) NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    payload TEXT NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ DEFAULT NULL,
+    response_status INTEGER DEFAULT NULL,
+    error TEXT DEFAULT NULL,
+    created_at TIMESTAMPTZ DEFAULT NULL,
+    updated_at TIMESTAMPTZ DEFAULT NULL
+);
+CREATE INDEX webhook_deliveries_webhook_id_index ON webhook_deliveries (webhook_id);
+CREATE INDEX webhook_deliveries_status_next_attempt_at_index ON webhook_deliveries (status, next_attempt_at);
+CREATE INDEX webhook_deliveries_created_at_index ON webhook_deliveries (created_at);
+
+-- +goose Down
+
+DROP TABLE webhook_deliveries;
+DROP TABLE webhooks;
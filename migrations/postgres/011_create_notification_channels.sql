@@ -0,0 +1,21 @@
+-- +goose Up
+
+CREATE TABLE notification_channels (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    server_id INTEGER DEFAULT NULL,
+    name VARCHAR(128) NOT NULL,
+    type VARCHAR(16) NOT NULL,
+    target VARCHAR(2048) NOT NULL,
+    language VARCHAR(8) NOT NULL,
+    events TEXT DEFAULT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ DEFAULT NULL,
+    updated_at TIMESTAMPTZ DEFAULT NULL
+);
+CREATE INDEX notification_channels_user_id_index ON notification_channels (user_id);
+CREATE INDEX notification_channels_server_id_index ON notification_channels (server_id);
+
+-- +goose Down
+
+DROP TABLE notification_channels;
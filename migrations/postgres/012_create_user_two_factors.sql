@@ -0,0 +1,15 @@
+-- +goose Up
+
+CREATE TABLE user_two_factors (
+    user_id INTEGER PRIMARY KEY,
+    secret VARCHAR(128) NOT NULL,
+    confirmed_at TIMESTAMPTZ DEFAULT NULL,
+    recovery_codes TEXT DEFAULT NULL,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ DEFAULT NULL,
+    updated_at TIMESTAMPTZ DEFAULT NULL
+);
+
+-- +goose Down
+
+DROP TABLE user_two_factors;
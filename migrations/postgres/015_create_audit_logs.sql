@@ -0,0 +1,24 @@
+-- +goose Up
+
+CREATE TABLE audit_logs (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER DEFAULT NULL,
+    token_id INTEGER DEFAULT NULL,
+    ip VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    action VARCHAR(255) NOT NULL,
+    entity_type VARCHAR(255) DEFAULT NULL,
+    entity_id VARCHAR(128) DEFAULT NULL,
+    changes TEXT DEFAULT NULL,
+    request TEXT DEFAULT NULL,
+    result VARCHAR(16) NOT NULL,
+    status_code INTEGER NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NULL
+);
+CREATE INDEX audit_logs_created_at_index ON audit_logs (created_at);
+CREATE INDEX audit_logs_user_id_index ON audit_logs (user_id);
+CREATE INDEX audit_logs_entity_type_entity_id_index ON audit_logs (entity_type, entity_id);
+
+-- +goose Down
+
+DROP TABLE audit_logs;
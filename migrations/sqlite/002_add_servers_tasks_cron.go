@@ -0,0 +1,36 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+)
+
+func Up002(ctx context.Context, tx *sql.Tx) error {
+	queries := []string{
+		`ALTER TABLE servers_tasks ADD COLUMN cron_expression TEXT DEFAULT NULL`,
+		`ALTER TABLE servers_tasks ADD COLUMN timezone TEXT DEFAULT NULL`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func Down002(ctx context.Context, tx *sql.Tx) error {
+	queries := []string{
+		`ALTER TABLE servers_tasks DROP COLUMN timezone`,
+		`ALTER TABLE servers_tasks DROP COLUMN cron_expression`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
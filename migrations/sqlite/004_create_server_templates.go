@@ -0,0 +1,48 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+)
+
+func Up004(ctx context.Context, tx *sql.Tx) error {
+	queries := []string{
+		`CREATE TABLE IF NOT EXISTS server_templates (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL,
+			description TEXT DEFAULT NULL,
+			game_id TEXT NOT NULL,
+			game_mod_id INTEGER NOT NULL,
+			server_port INTEGER NOT NULL,
+			query_port INTEGER DEFAULT NULL,
+			rcon_port INTEGER DEFAULT NULL,
+			su_user TEXT DEFAULT NULL,
+			cpu_limit INTEGER DEFAULT NULL,
+			ram_limit INTEGER DEFAULT NULL,
+			net_limit INTEGER DEFAULT NULL,
+			start_command TEXT DEFAULT NULL,
+			stop_command TEXT DEFAULT NULL,
+			force_stop_command TEXT DEFAULT NULL,
+			restart_command TEXT DEFAULT NULL,
+			vars TEXT DEFAULT NULL,
+			settings TEXT DEFAULT NULL,
+			created_at TEXT DEFAULT NULL,
+			updated_at TEXT DEFAULT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS server_templates_game_id_index ON server_templates(game_id)`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func Down004(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `DROP TABLE IF EXISTS server_templates`)
+
+	return err
+}
@@ -0,0 +1,40 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+)
+
+func Up005(ctx context.Context, tx *sql.Tx) error {
+	queries := []string{
+		`ALTER TABLE dedicated_servers ADD COLUMN port_range_start INTEGER DEFAULT NULL`,
+		`ALTER TABLE dedicated_servers ADD COLUMN port_range_end INTEGER DEFAULT NULL`,
+		`ALTER TABLE games ADD COLUMN query_port_offset INTEGER NOT NULL DEFAULT 0`,
+		`ALTER TABLE games ADD COLUMN rcon_port_offset INTEGER NOT NULL DEFAULT 0`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func Down005(ctx context.Context, tx *sql.Tx) error {
+	queries := []string{
+		`ALTER TABLE games DROP COLUMN rcon_port_offset`,
+		`ALTER TABLE games DROP COLUMN query_port_offset`,
+		`ALTER TABLE dedicated_servers DROP COLUMN port_range_end`,
+		`ALTER TABLE dedicated_servers DROP COLUMN port_range_start`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+)
+
+func Up006(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS servers_resource_usage (
+		server_id INTEGER PRIMARY KEY,
+		cpu REAL DEFAULT NULL,
+		ram INTEGER DEFAULT NULL,
+		net INTEGER DEFAULT NULL,
+		reported_at TEXT NOT NULL
+	)`)
+
+	return err
+}
+
+func Down006(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `DROP TABLE IF EXISTS servers_resource_usage`)
+
+	return err
+}
@@ -0,0 +1,38 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+)
+
+func Up007(ctx context.Context, tx *sql.Tx) error {
+	queries := []string{
+		`CREATE TABLE IF NOT EXISTS metrics (
+			subject_type TEXT NOT NULL,
+			subject_id INTEGER NOT NULL,
+			resolution TEXT NOT NULL,
+			recorded_at TEXT NOT NULL,
+			cpu REAL DEFAULT NULL,
+			ram REAL DEFAULT NULL,
+			disk REAL DEFAULT NULL,
+			players REAL DEFAULT NULL,
+			samples INTEGER NOT NULL DEFAULT 1,
+			PRIMARY KEY (subject_type, subject_id, resolution, recorded_at)
+		)`,
+		`CREATE INDEX IF NOT EXISTS metrics_resolution_recorded_at_index ON metrics(resolution, recorded_at)`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func Down007(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `DROP TABLE IF EXISTS metrics`)
+
+	return err
+}
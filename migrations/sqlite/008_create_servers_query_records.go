@@ -0,0 +1,35 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+)
+
+func Up008(ctx context.Context, tx *sql.Tx) error {
+	queries := []string{
+		`CREATE TABLE IF NOT EXISTS servers_query_records (
+			server_id INTEGER NOT NULL,
+			queried_at TEXT NOT NULL,
+			online INTEGER NOT NULL DEFAULT 0,
+			players_num INTEGER NOT NULL DEFAULT 0,
+			max_players_num INTEGER NOT NULL DEFAULT 0,
+			map TEXT NOT NULL DEFAULT '',
+			PRIMARY KEY (server_id, queried_at)
+		)`,
+		`CREATE INDEX IF NOT EXISTS servers_query_records_queried_at_index ON servers_query_records(queried_at)`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func Down008(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `DROP TABLE IF EXISTS servers_query_records`)
+
+	return err
+}
@@ -0,0 +1,37 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+)
+
+func Up009(ctx context.Context, tx *sql.Tx) error {
+	queries := []string{
+		`CREATE TABLE IF NOT EXISTS servers_auto_restarts (
+			server_id INTEGER PRIMARY KEY,
+			crashed_at TEXT DEFAULT NULL,
+			attempts INTEGER NOT NULL DEFAULT 0,
+			last_attempt_at TEXT DEFAULT NULL,
+			last_task_id INTEGER DEFAULT NULL,
+			crash_loop INTEGER NOT NULL DEFAULT 0,
+			crash_loop_at TEXT DEFAULT NULL,
+			crash_loops INTEGER NOT NULL DEFAULT 0,
+			updated_at TEXT NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS servers_auto_restarts_crash_loop_index ON servers_auto_restarts(crash_loop)`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func Down009(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `DROP TABLE IF EXISTS servers_auto_restarts`)
+
+	return err
+}
@@ -0,0 +1,66 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+)
+
+func Up010(ctx context.Context, tx *sql.Tx) error {
+	queries := []string{
+		`CREATE TABLE IF NOT EXISTS webhooks (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			user_id INTEGER DEFAULT NULL,
+			server_id INTEGER DEFAULT NULL,
+			name TEXT NOT NULL,
+			url TEXT NOT NULL,
+			secret TEXT NOT NULL,
+			events TEXT DEFAULT NULL,
+			enabled INTEGER NOT NULL DEFAULT 1,
+			created_at TEXT DEFAULT NULL,
+			updated_at TEXT DEFAULT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS webhooks_user_id_index ON webhooks(user_id)`,
+		`CREATE INDEX IF NOT EXISTS webhooks_server_id_index ON webhooks(server_id)`,
+		`CREATE TABLE IF NOT EXISTS webhook_deliveries (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			webhook_id INTEGER NOT NULL,
+			event_id TEXT NOT NULL,
+			event_type TEXT NOT NULL,
+			payload TEXT NOT NULL,
+			status TEXT NOT NULL,
+			attempts INTEGER NOT NULL DEFAULT 0,
+			next_attempt_at TEXT DEFAULT NULL,
+			response_status INTEGER DEFAULT NULL,
+			error TEXT DEFAULT NULL,
+			created_at TEXT DEFAULT NULL,
+			updated_at TEXT DEFAULT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS webhook_deliveries_webhook_id_index ON webhook_deliveries(webhook_id)`,
+		`CREATE INDEX IF NOT EXISTS webhook_deliveries_status_next_attempt_at_index
+			ON webhook_deliveries(status, next_attempt_at)`,
+		`CREATE INDEX IF NOT EXISTS webhook_deliveries_created_at_index ON webhook_deliveries(created_at)`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func Down010(ctx context.Context, tx *sql.Tx) error {
+	queries := []string{
+		`DROP TABLE IF EXISTS webhook_deliveries`,
+		`DROP TABLE IF EXISTS webhooks`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+)
+
+func Up011(ctx context.Context, tx *sql.Tx) error {
+	queries := []string{
+		`CREATE TABLE IF NOT EXISTS notification_channels (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			user_id INTEGER NOT NULL,
+			server_id INTEGER DEFAULT NULL,
+			name TEXT NOT NULL,
+			type TEXT NOT NULL,
+			target TEXT NOT NULL,
+			language TEXT NOT NULL,
+			events TEXT DEFAULT NULL,
+			enabled INTEGER NOT NULL DEFAULT 1,
+			created_at TEXT DEFAULT NULL,
+			updated_at TEXT DEFAULT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS notification_channels_user_id_index ON notification_channels(user_id)`,
+		`CREATE INDEX IF NOT EXISTS notification_channels_server_id_index ON notification_channels(server_id)`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func Down011(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `DROP TABLE IF EXISTS notification_channels`)
+
+	return err
+}
@@ -0,0 +1,26 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+)
+
+func Up012(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS user_two_factors (
+		user_id INTEGER PRIMARY KEY,
+		secret TEXT NOT NULL,
+		confirmed_at TEXT DEFAULT NULL,
+		recovery_codes TEXT DEFAULT NULL,
+		last_used_step INTEGER NOT NULL DEFAULT 0,
+		created_at TEXT DEFAULT NULL,
+		updated_at TEXT DEFAULT NULL
+	)`)
+
+	return err
+}
+
+func Down012(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `DROP TABLE IF EXISTS user_two_factors`)
+
+	return err
+}
@@ -0,0 +1,43 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+)
+
+func Up015(ctx context.Context, tx *sql.Tx) error {
+	queries := []string{
+		`CREATE TABLE IF NOT EXISTS audit_logs (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			user_id INTEGER DEFAULT NULL,
+			token_id INTEGER DEFAULT NULL,
+			ip TEXT NOT NULL DEFAULT '',
+			user_agent TEXT NOT NULL DEFAULT '',
+			action TEXT NOT NULL,
+			entity_type TEXT DEFAULT NULL,
+			entity_id TEXT DEFAULT NULL,
+			changes TEXT DEFAULT NULL,
+			request TEXT DEFAULT NULL,
+			result TEXT NOT NULL,
+			status_code INTEGER NOT NULL,
+			created_at TEXT DEFAULT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS audit_logs_created_at_index ON audit_logs(created_at)`,
+		`CREATE INDEX IF NOT EXISTS audit_logs_user_id_index ON audit_logs(user_id)`,
+		`CREATE INDEX IF NOT EXISTS audit_logs_entity_type_entity_id_index ON audit_logs(entity_type, entity_id)`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func Down015(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `DROP TABLE IF EXISTS audit_logs`)
+
+	return err
+}
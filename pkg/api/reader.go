@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
@@ -123,3 +124,22 @@ func (r *QueryReader) ReadUintList(key string) ([]uint, error) {
 
 	return res, nil
 }
+
+// ReadTime reads RFC 3339 time or unix timestamp, the zero time is returned if the key is missing.
+func (r *QueryReader) ReadTime(key string) (time.Time, error) {
+	value, err := r.ReadString(key)
+	if err != nil || value == "" {
+		return time.Time{}, err
+	}
+
+	if unix, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(unix, 0).UTC(), nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.WithMessage(err, "failed to parse time")
+	}
+
+	return t, nil
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
@@ -410,3 +411,50 @@ func TestQueryReader_ReadUintList(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryReader_ReadTime(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    map[string][]string
+		expected time.Time
+		wantErr  bool
+	}{
+		{
+			name:     "rfc3339",
+			query:    map[string][]string{"from": {"2024-05-01T10:00:00+03:00"}},
+			expected: time.Date(2024, 5, 1, 7, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "unix_timestamp",
+			query:    map[string][]string{"from": {"1714557600"}},
+			expected: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "missing_key",
+			query:    map[string][]string{},
+			expected: time.Time{},
+		},
+		{
+			name:    "invalid_time",
+			query:   map[string][]string{"from": {"yesterday"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := &QueryReader{query: tt.query}
+
+			result, err := reader.ReadTime("from")
+
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, tt.expected.Equal(result), "expected %s, got %s", tt.expected, result)
+		})
+	}
+}
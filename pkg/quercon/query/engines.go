@@ -0,0 +1,17 @@
+package query
+
+import "strings"
+
+var protocolsByEngine = map[string]Protocol{
+	"source":     ProtocolSource,
+	"goldsource": ProtocolSource,
+	"goldsrc":    ProtocolSource,
+	"minecraft":  ProtocolMinecraft,
+}
+
+// ProtocolByEngine returns the query protocol of servers of the game engine.
+func ProtocolByEngine(engine string) (Protocol, bool) {
+	protocol, ok := protocolsByEngine[strings.ToLower(engine)]
+
+	return protocol, ok
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/gameap/gameap/internal/cache"
@@ -11,13 +12,31 @@ import (
 	"github.com/gameap/gameap/internal/config"
 	"github.com/gameap/gameap/internal/daemon"
 	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/events"
 	"github.com/gameap/gameap/internal/files"
+	"github.com/gameap/gameap/internal/i18n"
+	"github.com/gameap/gameap/internal/monitoring"
+	"github.com/gameap/gameap/internal/pubsub"
 	"github.com/gameap/gameap/internal/rbac"
 	"github.com/gameap/gameap/internal/repositories"
 	"github.com/gameap/gameap/internal/repositories/base"
 	"github.com/gameap/gameap/internal/repositories/inmemory"
 	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/audit"
+	"github.com/gameap/gameap/internal/services/authenticator"
+	"github.com/gameap/gameap/internal/services/backup"
+	"github.com/gameap/gameap/internal/services/daemontaskoutput"
+	"github.com/gameap/gameap/internal/services/metrics"
+	"github.com/gameap/gameap/internal/services/notifications"
+	"github.com/gameap/gameap/internal/services/oidc"
+	"github.com/gameap/gameap/internal/services/passwordreset"
+	"github.com/gameap/gameap/internal/services/serverclone"
+	"github.com/gameap/gameap/internal/services/serverconsole"
 	"github.com/gameap/gameap/internal/services/servercontrol"
+	"github.com/gameap/gameap/internal/services/servermove"
+	"github.com/gameap/gameap/internal/services/serverports"
+	"github.com/gameap/gameap/internal/services/twofactor"
+	"github.com/gameap/gameap/internal/services/webhooks"
 	pkgapi "github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
 	"github.com/samber/lo"
@@ -40,8 +59,33 @@ type InmemoryContainer struct {
 	serverSettingRepo     repositories.ServerSettingRepository
 	nodeRepo              repositories.NodeRepository
 	clientCertificateRepo repositories.ClientCertificateRepository
+	backupRepo            repositories.BackupRepository
+	serverTemplateRepo    repositories.ServerTemplateRepository
+	resourceUsageRepo     repositories.ServerResourceUsageRepository
+	queryRecordRepo       repositories.ServerQueryRecordRepository
+	autoRestartRepo       repositories.ServerAutoRestartRepository
+	webhookRepo           repositories.WebhookRepository
+	webhookDeliveryRepo   repositories.WebhookDeliveryRepository
+	channelRepo           repositories.NotificationChannelRepository
+	auditLogRepo          repositories.AuditLogRepository
 	rbacService           *rbac.RBAC
 	serverControlService  *servercontrol.Service
+	serverConsoleHub      *serverconsole.Hub
+	daemonTaskOutput      *daemontaskoutput.Broadcaster
+	backupService         *backup.Service
+	serverMoveService     *servermove.Service
+	serverCloneService    *serverclone.Service
+	serverPortsService    *serverports.Service
+	metricsService        *metrics.Service
+	eventBus              *events.Bus
+	webhooksService       *webhooks.Service
+	translator            *i18n.Translator
+	notificationsService  *notifications.Service
+	passwordResetService  *passwordreset.Service
+	twoFactorService      *twofactor.Service
+	oidcService           *oidc.Service
+	authenticator         authenticator.Authenticator
+	auditService          *audit.Service
 	gameUpgradeService    *services.GameUpgradeService
 	fileManager           files.FileManager
 	cacheService          cache.Cache
@@ -50,6 +94,7 @@ type InmemoryContainer struct {
 	daemonStatusService   *daemon.StatusService
 	daemonFilesService    *daemon.FileService
 	daemonCommandsService *daemon.CommandService
+	monitoring            *monitoring.Metrics
 }
 
 func (c *InmemoryContainer) Config() *config.Config                            { return c.cfg }
@@ -65,6 +110,58 @@ func (c *InmemoryContainer) UserService() *services.UserService                {
 func (c *InmemoryContainer) ServerControlService() *servercontrol.Service {
 	return c.serverControlService
 }
+func (c *InmemoryContainer) ServerConsoleHub() *serverconsole.Hub {
+	return c.serverConsoleHub
+}
+func (c *InmemoryContainer) DaemonTaskOutputBroadcaster() *daemontaskoutput.Broadcaster {
+	return c.daemonTaskOutput
+}
+func (c *InmemoryContainer) BackupService() *backup.Service {
+	return c.backupService
+}
+func (c *InmemoryContainer) ServerMoveService() *servermove.Service {
+	return c.serverMoveService
+}
+func (c *InmemoryContainer) ServerCloneService() *serverclone.Service {
+	return c.serverCloneService
+}
+func (c *InmemoryContainer) ServerPortsService() *serverports.Service {
+	return c.serverPortsService
+}
+func (c *InmemoryContainer) MetricsService() *metrics.Service {
+	return c.metricsService
+}
+func (c *InmemoryContainer) EventBus() *events.Bus {
+	return c.eventBus
+}
+func (c *InmemoryContainer) WebhooksService() *webhooks.Service {
+	return c.webhooksService
+}
+func (c *InmemoryContainer) Translator() *i18n.Translator {
+	return c.translator
+}
+func (c *InmemoryContainer) NotificationsService() *notifications.Service {
+	return c.notificationsService
+}
+func (c *InmemoryContainer) PasswordResetService() *passwordreset.Service {
+	return c.passwordResetService
+}
+func (c *InmemoryContainer) TwoFactorService() *twofactor.Service {
+	return c.twoFactorService
+}
+
+func (c *InmemoryContainer) OIDCService() *oidc.Service {
+	return c.oidcService
+}
+func (c *InmemoryContainer) Authenticator() authenticator.Authenticator {
+	return c.authenticator
+}
+func (c *InmemoryContainer) ServerExpirationPolicy() domain.ServerExpirationPolicy {
+	return domain.ServerExpirationPolicy{}
+}
+func (c *InmemoryContainer) AutoRestartPolicy() domain.AutoRestartPolicy {
+	return domain.AutoRestartPolicy{}
+}
 func (c *InmemoryContainer) GameUpgradeService() *services.GameUpgradeService {
 	return c.gameUpgradeService
 }
@@ -88,6 +185,34 @@ func (c *InmemoryContainer) NodeRepository() repositories.NodeRepository { retur
 func (c *InmemoryContainer) ClientCertificateRepository() repositories.ClientCertificateRepository {
 	return c.clientCertificateRepo
 }
+func (c *InmemoryContainer) BackupRepository() repositories.BackupRepository {
+	return c.backupRepo
+}
+func (c *InmemoryContainer) ServerTemplateRepository() repositories.ServerTemplateRepository {
+	return c.serverTemplateRepo
+}
+func (c *InmemoryContainer) ServerResourceUsageRepository() repositories.ServerResourceUsageRepository {
+	return c.resourceUsageRepo
+}
+func (c *InmemoryContainer) ServerQueryRecordRepository() repositories.ServerQueryRecordRepository {
+	return c.queryRecordRepo
+}
+func (c *InmemoryContainer) ServerAutoRestartRepository() repositories.ServerAutoRestartRepository {
+	return c.autoRestartRepo
+}
+func (c *InmemoryContainer) WebhookRepository() repositories.WebhookRepository {
+	return c.webhookRepo
+}
+func (c *InmemoryContainer) WebhookDeliveryRepository() repositories.WebhookDeliveryRepository {
+	return c.webhookDeliveryRepo
+}
+func (c *InmemoryContainer) NotificationChannelRepository() repositories.NotificationChannelRepository {
+	return c.channelRepo
+}
+func (c *InmemoryContainer) AuditLogRepository() repositories.AuditLogRepository {
+	return c.auditLogRepo
+}
+func (c *InmemoryContainer) AuditService() *audit.Service                 { return c.auditService }
 func (c *InmemoryContainer) RBAC() *rbac.RBAC                             { return c.rbacService }
 func (c *InmemoryContainer) FileManager() files.FileManager               { return c.fileManager }
 func (c *InmemoryContainer) Cache() cache.Cache                           { return c.cacheService }
@@ -96,6 +221,7 @@ func (c *InmemoryContainer) GlobalAPIService() *services.GlobalAPIService { retu
 func (c *InmemoryContainer) DaemonStatus() *daemon.StatusService          { return c.daemonStatusService }
 func (c *InmemoryContainer) DaemonFiles() *daemon.FileService             { return c.daemonFilesService }
 func (c *InmemoryContainer) DaemonCommands() *daemon.CommandService       { return c.daemonCommandsService }
+func (c *InmemoryContainer) Monitoring() *monitoring.Metrics              { return c.monitoring }
 
 func LoadInmemoryContainer() (*InmemoryContainer, error) {
 	c := buildInmemoryTestContainer()
@@ -109,8 +235,84 @@ func buildInmemoryTestContainer() *InmemoryContainer {
 	serverRepo := inmemory.NewServerRepository()
 
 	daemonTaskRepo := inmemory.NewDaemonTaskRepository()
+	nodeRepo := inmemory.NewNodeRepository()
 	serverSettingRepo := inmemory.NewServerSettingRepository()
+	serverTemplateRepo := inmemory.NewServerTemplateRepository()
+	webhookRepo := inmemory.NewWebhookRepository()
+	webhookDeliveryRepo := inmemory.NewWebhookDeliveryRepository()
 	tm := services.NewNilTransactionManager()
+	rbacService := rbac.NewRBAC(tm, rbacRepo, time.Minute)
+	webhooksService := webhooks.NewService(
+		webhookRepo,
+		webhookDeliveryRepo,
+		serverRepo,
+		rbacService,
+		webhooks.NewSender(webhooks.NewHTTPClient(10*time.Second, false)),
+	)
+	channelRepo := inmemory.NewNotificationChannelRepository()
+	translator, err := i18n.NewTranslator()
+	if err != nil {
+		panic(fmt.Sprintf("failed to create translator: %v", err))
+	}
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+	notificationsService := notifications.NewService(
+		channelRepo,
+		serverRepo,
+		rbacService,
+		translator,
+		map[domain.NotificationChannelType]notifications.Sender{
+			domain.NotificationChannelTypeDiscord: notifications.NewDiscordSender(httpClient),
+			domain.NotificationChannelTypeTelegram: notifications.NewTelegramSender(
+				httpClient, "https://api.telegram.org", "",
+			),
+		},
+		10*time.Second,
+	)
+	// Emails aren't sent in tests, password reset links can't be requested
+	passwordResetService := passwordreset.NewService(
+		userRepo,
+		inmemory.NewPasswordResetRepository(),
+		nil,
+		translator,
+		cache.NewRateLimiter(cache.NewInMemory(), "password_reset", 5, 15*time.Minute),
+		passwordreset.Config{TTL: time.Hour, Throttle: time.Minute},
+	)
+	twoFactorCache := cache.NewInMemory()
+	twoFactorService := twofactor.NewService(
+		inmemory.NewUserTwoFactorRepository(),
+		userRepo,
+		rbacService,
+		twoFactorCache,
+		cache.NewRateLimiter(twoFactorCache, "two_factor", 5, 15*time.Minute),
+		twofactor.Config{Issuer: "GameAP", ChallengeTTL: 5 * time.Minute},
+	)
+	// No identity providers are configured in tests
+	oidcService := oidc.NewService(
+		nil,
+		httpClient,
+		inmemory.NewUserIdentityRepository(),
+		userRepo,
+		rbacService,
+		tm,
+		cache.NewInMemory(),
+		oidc.Config{StateTTL: 10 * time.Minute},
+	)
+	userService := services.NewUserService(userRepo)
+	// Only the local passwords are checked in tests
+	localAuthenticator := authenticator.NewLocal(userService)
+	serverControlService := servercontrol.NewService(daemonTaskRepo, serverSettingRepo, nodeRepo, tm)
+	eventBus := events.NewBus()
+	eventBus.Subscribe(webhooksService)
+	eventBus.Subscribe(notificationsService)
+	eventBus.Subscribe(serverControlService)
+	serverMoveService := servermove.NewService(daemonTaskRepo, serverRepo, nodeRepo, tm)
+	serverPortsService := serverports.NewService(
+		serverRepo,
+		cache.NewInMemory(),
+		tm,
+		domain.PortRange{Start: 27015, End: 27999},
+		time.Second,
+	)
 
 	c := &InmemoryContainer{
 		cfg: &config.Config{
@@ -123,17 +325,51 @@ func buildInmemoryTestContainer() *InmemoryContainer {
 		serverRepo:            serverRepo,
 		userRepo:              userRepo,
 		authService:           auth.NewJWTService([]byte("test-secret-key-for-testing")),
-		userService:           services.NewUserService(userRepo),
+		userService:           userService,
 		rbacRepo:              rbacRepo,
 		tokenRepo:             inmemory.NewPersonalAccessTokenRepository(),
 		daemonTaskRepo:        daemonTaskRepo,
 		serverTaskRepo:        inmemory.NewServerTaskRepository(serverRepo),
 		serverTaskFailRepo:    inmemory.NewServerTaskFailRepository(),
 		serverSettingRepo:     serverSettingRepo,
-		nodeRepo:              inmemory.NewNodeRepository(),
+		nodeRepo:              nodeRepo,
 		clientCertificateRepo: inmemory.NewClientCertificateRepository(),
-		rbacService:           rbac.NewRBAC(tm, rbacRepo, time.Minute),
-		serverControlService:  servercontrol.NewService(daemonTaskRepo, serverSettingRepo, tm),
+		backupRepo:            inmemory.NewBackupRepository(),
+		serverTemplateRepo:    serverTemplateRepo,
+		resourceUsageRepo:     inmemory.NewServerResourceUsageRepository(),
+		queryRecordRepo:       inmemory.NewServerQueryRecordRepository(),
+		autoRestartRepo:       inmemory.NewServerAutoRestartRepository(),
+		webhookRepo:           webhookRepo,
+		webhookDeliveryRepo:   webhookDeliveryRepo,
+		channelRepo:           channelRepo,
+		auditLogRepo:          inmemory.NewAuditLogRepository(),
+		rbacService:           rbacService,
+		serverControlService:  serverControlService,
+		// Daemon services aren't available, the console of the servers can't be read
+		serverConsoleHub:  serverconsole.NewHub(serverconsole.NewReader(nodeRepo, nil, nil), time.Second),
+		daemonTaskOutput:  daemontaskoutput.NewBroadcaster(pubsub.NewInMemory()),
+		backupService:     nil,
+		serverMoveService: serverMoveService,
+		serverCloneService: serverclone.NewService(
+			serverRepo,
+			serverSettingRepo,
+			serverTemplateRepo,
+			nodeRepo,
+			daemonTaskRepo,
+			serverMoveService,
+			serverPortsService,
+			eventBus,
+		),
+		serverPortsService:    serverPortsService,
+		metricsService:        metrics.NewService(inmemory.NewMetricRepository(), metrics.Retention{}),
+		eventBus:              eventBus,
+		webhooksService:       webhooksService,
+		translator:            translator,
+		notificationsService:  notificationsService,
+		passwordResetService:  passwordResetService,
+		twoFactorService:      twoFactorService,
+		oidcService:           oidcService,
+		authenticator:         localAuthenticator,
 		gameUpgradeService:    nil,
 		fileManager:           nil,
 		cacheService:          nil,
@@ -142,11 +378,28 @@ func buildInmemoryTestContainer() *InmemoryContainer {
 		daemonStatusService:   nil,
 		daemonFilesService:    nil,
 		daemonCommandsService: nil,
+		monitoring:            monitoring.NewMetrics(),
 	}
 
+	c.cfg.Notifications.DefaultLanguage = i18n.DefaultLanguage
+
+	c.auditService = audit.NewService(c.auditLogRepo, audit.Repositories{
+		Servers:              c.serverRepo,
+		Nodes:                c.nodeRepo,
+		Users:                c.userRepo,
+		RBAC:                 c.rbacRepo,
+		Games:                c.gameRepo,
+		GameMods:             c.gameModRepo,
+		ClientCertificates:   c.clientCertificateRepo,
+		ServerTemplates:      c.serverTemplateRepo,
+		Webhooks:             c.webhookRepo,
+		NotificationChannels: c.channelRepo,
+		PersonalAccessTokens: c.tokenRepo,
+	})
+
 	ctx := context.Background()
 
-	err := rbacRepo.SaveRole(ctx, &domain.Role{
+	err = rbacRepo.SaveRole(ctx, &domain.Role{
 		ID:   1,
 		Name: "admin",
 	})
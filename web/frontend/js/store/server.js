@@ -20,6 +20,8 @@ export const useServerStore = defineStore('server', () => {
         'game-server-console-send': false,
         'game-server-rcon-console': false,
         'game-server-rcon-players': false,
+        'game-server-backups': false,
+        'game-server-backups-restore': false,
     })
     const server = ref({
         id: 0,